@@ -16,9 +16,18 @@ import (
 
 	"github.com/codr1/Pickleicious/internal/config"
 	"github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/db/migrate"
 	"github.com/codr1/Pickleicious/internal/scheduler"
 )
 
+// Build metadata reported by /readyz, set with
+// -ldflags "-X main.version=... -X main.commit=... -X main.buildTime=...".
+var (
+	version   = "dev"
+	commit    = ""
+	buildTime = ""
+)
+
 func setupLogger(environment string) {
 	// Set time format for all logging
 	zerolog.TimeFieldFormat = "15:04:05"
@@ -56,6 +65,36 @@ func setupLogger(environment string) {
 	zerolog.DefaultContextLogger = &log.Logger
 }
 
+// prepareSchema applies pending migrations when database.auto_migrate is
+// set, then refuses to start unless the schema matches the migrations built
+// into the binary.
+func prepareSchema(cfg *config.Config, database *db.DB) error {
+	runner, err := migrate.New(database.DB)
+	if err != nil {
+		return err
+	}
+	defer runner.Close()
+
+	if cfg.Database.AutoMigrate {
+		if err := runner.Up(); err != nil {
+			return err
+		}
+	}
+	if err := runner.EnsureCurrent(); err != nil {
+		if !cfg.Database.AutoMigrate {
+			return fmt.Errorf("%w; run cmd/tools/dbmigrate -command up or enable database.auto_migrate", err)
+		}
+		return err
+	}
+
+	version, _, err := runner.Version()
+	if err != nil {
+		return err
+	}
+	log.Info().Int64("migration_version", version).Bool("auto_migrate", cfg.Database.AutoMigrate).Msg("Database schema is current")
+	return nil
+}
+
 func main() {
 	config, err := config.Load("config.yaml")
 	if err != nil {
@@ -77,13 +116,20 @@ func main() {
 	}
 	log.Info().Msg("Database connection successful")
 
-	// Verify users table exists
+	if err := prepareSchema(config, database); err != nil {
+		log.Fatal().Err(err).Msg("Database schema is not current")
+	}
+
+	// The schema check above already covers the users table; the count is
+	// only a hint for a fresh development database.
 	var count int
-	err = database.DB.QueryRow("SELECT COUNT(*) FROM users").Scan(&count)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to query users table")
+	if err := database.DB.QueryRow("SELECT COUNT(*) FROM users").Scan(&count); err != nil {
+		log.Warn().Err(err).Msg("Failed to count users")
+	} else if count == 0 {
+		log.Warn().Msg("No users in database; run `task db:seed` for development data")
+	} else {
+		log.Info().Int("user_count", count).Msg("Found users in database")
 	}
-	log.Info().Int("user_count", count).Msg("Found users in database")
 
 	// Create server instance
 	server, err := newServer(config, database)
@@ -103,13 +149,24 @@ func main() {
 
 	// Run server
 	g.Go(func() error {
-		log.Info().Int("port", config.App.Port).Msg("Starting server")
+		log.Info().Int("port", config.App.Port).Str("version", version).Msg("Starting server")
 		if err := server.ListenAndServe(); err != http.ErrServerClosed {
 			return fmt.Errorf("server error: %w", err)
 		}
 		return nil
 	})
 
+	metricsServer := newMetricsServer(config)
+	if metricsServer != nil {
+		g.Go(func() error {
+			log.Info().Int("port", config.Features.MetricsPort).Msg("Starting metrics server")
+			if err := metricsServer.ListenAndServe(); err != http.ErrServerClosed {
+				return fmt.Errorf("metrics server error: %w", err)
+			}
+			return nil
+		})
+	}
+
 	// Wait for interrupt signal
 	g.Go(func() error {
 		<-ctx.Done()
@@ -123,6 +180,11 @@ func main() {
 		if err := scheduler.Stop(); err != nil {
 			log.Error().Err(err).Msg("Failed to stop scheduler")
 		}
+		if metricsServer != nil {
+			if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+				log.Error().Err(err).Msg("Failed to shut down metrics server")
+			}
+		}
 		if err := server.Shutdown(shutdownCtx); err != nil {
 			return fmt.Errorf("shutdown error: %w", err)
 		}
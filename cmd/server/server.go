@@ -12,6 +12,10 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/codr1/Pickleicious/internal/api"
+	"github.com/codr1/Pickleicious/internal/api/access"
+	"github.com/codr1/Pickleicious/internal/api/adminjobs"
+	"github.com/codr1/Pickleicious/internal/api/announcements"
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/api/auth"
 	"github.com/codr1/Pickleicious/internal/api/authz"
 	"github.com/codr1/Pickleicious/internal/api/cancellationpolicy"
@@ -19,6 +23,10 @@ import (
 	"github.com/codr1/Pickleicious/internal/api/clinics"
 	"github.com/codr1/Pickleicious/internal/api/courts"
 	"github.com/codr1/Pickleicious/internal/api/dashboard"
+	"github.com/codr1/Pickleicious/internal/api/emailevents"
+	"github.com/codr1/Pickleicious/internal/api/facilityconfig"
+	"github.com/codr1/Pickleicious/internal/api/guestpasses"
+	"github.com/codr1/Pickleicious/internal/api/health"
 	"github.com/codr1/Pickleicious/internal/api/leagues"
 	"github.com/codr1/Pickleicious/internal/api/lessonpacks"
 	"github.com/codr1/Pickleicious/internal/api/member"
@@ -27,36 +35,56 @@ import (
 	"github.com/codr1/Pickleicious/internal/api/notifications"
 	openplayapi "github.com/codr1/Pickleicious/internal/api/openplay"
 	"github.com/codr1/Pickleicious/internal/api/operatinghours"
+	"github.com/codr1/Pickleicious/internal/api/organizations"
+	"github.com/codr1/Pickleicious/internal/api/photos"
 	"github.com/codr1/Pickleicious/internal/api/reservations"
+	"github.com/codr1/Pickleicious/internal/api/reservationtypes"
+	"github.com/codr1/Pickleicious/internal/api/seasonpasses"
 	"github.com/codr1/Pickleicious/internal/api/staff"
 	"github.com/codr1/Pickleicious/internal/api/themes"
 	"github.com/codr1/Pickleicious/internal/api/tierbooking"
+	"github.com/codr1/Pickleicious/internal/api/tournaments"
 	"github.com/codr1/Pickleicious/internal/api/visitpacks"
 	"github.com/codr1/Pickleicious/internal/api/waitlist"
 	"github.com/codr1/Pickleicious/internal/cognito"
 	"github.com/codr1/Pickleicious/internal/config"
 	"github.com/codr1/Pickleicious/internal/db"
 	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/images"
+	"github.com/codr1/Pickleicious/internal/jobs"
+	"github.com/codr1/Pickleicious/internal/metrics"
 	"github.com/codr1/Pickleicious/internal/models"
 	openplayengine "github.com/codr1/Pickleicious/internal/openplay"
+	"github.com/codr1/Pickleicious/internal/payments"
+	"github.com/codr1/Pickleicious/internal/ratelimit"
 	"github.com/codr1/Pickleicious/internal/request"
 	"github.com/codr1/Pickleicious/internal/scheduler"
 	"github.com/codr1/Pickleicious/internal/templates/layouts"
 )
 
 func newServer(config *config.Config, database *db.DB) (*http.Server, error) {
+	if err := apiutil.SetDefaultTimezone(config.App.DefaultTimezone); err != nil {
+		return nil, fmt.Errorf("initialize default timezone: %w", err)
+	}
+	images.SetMaxUploadBytes(config.Photos.MaxUploadBytes)
+
 	router := http.NewServeMux()
 
-	// Setup middleware chain
-	handler := api.ChainMiddleware(
-		router,
+	// Setup middleware chain. WithMetrics wraps the router directly so it
+	// sees the matched route pattern.
+	middleware := []api.Middleware{
 		api.WithLogging,
 		api.WithRecovery,
+		api.WithCSRF,
 		api.WithRequestID,
 		api.WithOrganization(database.Queries, config.App.BaseDomain),
 		api.WithAuth,
 		api.WithContentType,
-	)
+	}
+	if config.Features.EnableMetrics {
+		middleware = append([]api.Middleware{api.WithMetrics}, middleware...)
+	}
+	handler := api.ChainMiddleware(router, middleware...)
 
 	// Create Cognito client if configured
 	var cognitoClient *cognito.CognitoClient
@@ -83,31 +111,113 @@ func newServer(config *config.Config, database *db.DB) (*http.Server, error) {
 		log.Warn().Msg("SES configuration incomplete; email features will be disabled")
 	}
 
+	// Texts carry time-sensitive waitlist offers and reminders. Development
+	// logs them when no provider is configured.
+	var smsNotifier email.Notifier
+	if config.SMS.AccountSID != "" && config.SMS.AuthToken != "" && config.SMS.FromNumber != "" {
+		sender, err := email.NewSMSSender(config.SMS.AccountSID, config.SMS.AuthToken, config.SMS.FromNumber, config.SMS.APIBaseURL)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to init SMS sender")
+		} else {
+			smsNotifier = sender
+			log.Info().Msg("SMS sender initialized")
+		}
+	} else if config.App.Environment == "development" {
+		smsNotifier = email.NewLogNotifier(email.ChannelSMS)
+		log.Warn().Msg("Using logging SMS sender; texts are not delivered")
+	} else {
+		log.Warn().Msg("SMS configuration incomplete; text notifications will be disabled")
+	}
+
+	var notifiers []email.Notifier
+	if emailClient != nil {
+		notifiers = append(notifiers, emailClient)
+	} else if config.App.Environment == "development" {
+		notifiers = append(notifiers, email.NewLogNotifier(email.ChannelEmail))
+	}
+	if smsNotifier != nil {
+		notifiers = append(notifiers, smsNotifier)
+	}
+
+	// No real card processor is integrated yet; development gets a fake one
+	// that approves every charge.
+	var paymentProcessor payments.PaymentProcessor
+	if config.App.Environment == "development" {
+		paymentProcessor = payments.NewFakeProcessor()
+		log.Warn().Msg("Using fake payment processor; charges are not collected")
+	} else {
+		log.Warn().Msg("No payment processor configured; paid online purchases will be disabled")
+	}
+
 	auth.InitHandlers(database.Queries, config)
-	members.InitHandlers(database.Queries, cognitoClient)
+	members.InitHandlers(database, cognitoClient)
+	members.InitEmailClient(emailClient, notifiers...)
 	nav.InitHandlers(database.Queries)
 	openplayapi.InitHandlers(database, emailClient)
 	themes.InitHandlers(database.Queries)
+	themes.InitFacilityCache(database.Facilities)
 	courts.InitHandlers(database.Queries)
+	courts.InitReservationService(database, emailClient, notifiers...)
 	dashboard.InitHandlers(database)
 	checkin.InitHandlers(database.Queries)
 	clinics.InitHandlers(database)
-	reservations.InitHandlers(database, emailClient)
-	member.InitHandlers(database, emailClient)
 	operatinghours.InitHandlers(database.Queries)
+	operatinghours.InitFacilityCache(database.Facilities)
 	notifications.InitHandlers(database.Queries)
-	cancellationpolicy.InitHandlers(database.Queries)
+	emailevents.InitHandlers(database.Queries, config.AWS.SESEventsTopicARN)
+	cancellationpolicy.InitHandlers(database)
+	announcements.InitHandlers(database.Queries)
 	lessonpacks.InitHandlers(database.Queries)
-	visitpacks.InitHandlers(database.Queries)
+	visitpacks.InitHandlers(database, paymentProcessor)
+	seasonpasses.InitHandlers(database)
+	guestpasses.InitHandlers(database.Queries)
 	waitlist.InitHandlers(database)
 	tierbooking.InitHandlers(database)
+	organizations.InitHandlers(database)
+	reservationtypes.InitHandlers(database)
+
+	photos.InitHandlers(database.Queries)
+	tournaments.InitHandlers(database)
+
+	cardSigner, err := models.NewMemberCardSigner(config.App.SecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("initialize member card signer: %w", err)
+	}
+	checkin.InitCardSigner(cardSigner)
+	openplayapi.InitCardSigner(cardSigner)
 
-	staff.InitHandlers(database)
-	leagues.InitHandlers(database)
+	calendarFeedSigner, err := models.NewCalendarFeedSigner(config.App.SecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("initialize calendar feed signer: %w", err)
+	}
+
+	accessSigner, err := models.NewReservationAccessSigner(config.App.SecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("initialize reservation access signer: %w", err)
+	}
+
+	handlers := routeHandlers{
+		member: member.NewHandlers(database, emailClient, member.Options{
+			CardSigner:         cardSigner,
+			CalendarFeedSigner: calendarFeedSigner,
+			AccessSigner:       accessSigner,
+			PaymentProcessor:   paymentProcessor,
+			TransferWindow:     time.Duration(config.Reservations.TransferWindowHours) * time.Hour,
+			Notifiers:          notifiers,
+			BaseURL:            config.App.BaseURL,
+		}),
+		reservations: reservations.NewHandlers(database, emailClient, notifiers...),
+		staff:        staff.NewHandlers(database),
+		leagues:      leagues.NewHandlers(database),
+		access:       access.NewHandlers(database, accessSigner),
+		config:       facilityconfig.NewHandlers(database),
+	}
 
 	if err := scheduler.Init(); err != nil {
 		return nil, fmt.Errorf("initialize scheduler: %w", err)
 	}
+	jobs.Init(database.Queries)
+	adminjobs.InitHandlers(database.Queries, jobs.Default())
 
 	openplayEngine, err := openplayengine.NewEngine(database, emailClient)
 	if err != nil {
@@ -116,25 +226,81 @@ func newServer(config *config.Config, database *db.DB) (*http.Server, error) {
 	if err := registerOpenPlayEnforcementJob(config, database, openplayEngine); err != nil {
 		return nil, fmt.Errorf("register open play enforcement job: %w", err)
 	}
-	if err := scheduler.RegisterWaitlistJobs(database); err != nil {
+	if err := registerOpenPlayGenerationJob(config, database, openplayEngine); err != nil {
+		return nil, fmt.Errorf("register open play generation job: %w", err)
+	}
+	if err := scheduler.RegisterWaitlistJobs(database, config.Waitlist.OfferExpiryInterval, notifiers...); err != nil {
 		return nil, fmt.Errorf("register waitlist jobs: %w", err)
 	}
-	if err := scheduler.RegisterReminderJobs(database, emailClient); err != nil {
+	if err := scheduler.RegisterReminderJobs(database, emailClient, smsNotifier); err != nil {
 		return nil, fmt.Errorf("register reminder jobs: %w", err)
 	}
+	if err := scheduler.RegisterDeferredEmailJobs(database, emailClient, smsNotifier); err != nil {
+		return nil, fmt.Errorf("register deferred email jobs: %w", err)
+	}
+	if err := scheduler.RegisterIdempotencyKeyJobs(database); err != nil {
+		return nil, fmt.Errorf("register idempotency key jobs: %w", err)
+	}
+	if err := scheduler.RegisterMemberAnonymizationJobs(database, config.MemberDeletionRetention()); err != nil {
+		return nil, fmt.Errorf("register member anonymization jobs: %w", err)
+	}
+	if err := scheduler.RegisterStaffNotificationJobs(database, config.StaffNotificationRetention()); err != nil {
+		return nil, fmt.Errorf("register staff notification jobs: %w", err)
+	}
+	if err := scheduler.RegisterLeagueResultJobs(database); err != nil {
+		return nil, fmt.Errorf("register league result jobs: %w", err)
+	}
 
 	// Register routes
-	registerRoutes(router, database)
+	registerRoutes(router, database, handlers, newRateLimits(config.RateLimit))
+
+	// Probes sit ahead of the middleware chain so they skip organization
+	// lookup, session loading, and per-request logging.
+	health.InitHandlers(database, jobs.Default(), health.BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildTime: buildTime,
+	})
+	root := http.NewServeMux()
+	root.HandleFunc("/healthz", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: health.HandleHealthz,
+	}))
+	root.HandleFunc("/readyz", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: health.HandleReadyz,
+	}))
+	// Metrics skip auth like the probes; give them their own port with
+	// features.metrics_port to keep them off the public listener.
+	if config.Features.EnableMetrics && config.Features.MetricsPort == 0 {
+		root.Handle("/metrics", metrics.Handler())
+	}
+	root.Handle("/", handler)
 
 	return &http.Server{
 		Addr:         fmt.Sprintf(":%d", config.App.Port),
-		Handler:      handler,
+		Handler:      root,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}, nil
 }
 
+// newMetricsServer returns the listener for /metrics when it is bound to a
+// port of its own, or nil when metrics are off or share the app port.
+func newMetricsServer(config *config.Config) *http.Server {
+	if !config.Features.EnableMetrics || config.Features.MetricsPort == 0 {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	return &http.Server{
+		Addr:         fmt.Sprintf(":%d", config.Features.MetricsPort),
+		Handler:      mux,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+}
+
 func methodHandler(handlers map[string]http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		handler, ok := handlers[r.Method]
@@ -146,7 +312,60 @@ func methodHandler(handlers map[string]http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-func registerRoutes(mux *http.ServeMux, database *db.DB) {
+// routeHandlers holds the handler packages that are built per server rather
+// than initialized as package state.
+type routeHandlers struct {
+	member       *member.Handlers
+	reservations *reservations.Handlers
+	staff        *staff.Handlers
+	leagues      *leagues.Handlers
+	access       *access.Handlers
+	config       *facilityconfig.Handlers
+}
+
+// rateLimits wraps routes that scripts could hammer: per client IP before
+// login, per user for booking and cancellation.
+type rateLimits struct {
+	anonymous api.Middleware
+	booking   api.Middleware
+}
+
+func newRateLimits(cfg config.RateLimitConfig) rateLimits {
+	if !cfg.Enabled {
+		log.Warn().Msg("Request rate limiting is DISABLED - login and booking endpoints are unprotected from abuse")
+		return rateLimits{
+			anonymous: api.WithRateLimit("anonymous", nil, nil),
+			booking:   api.WithRateLimit("booking", nil, nil),
+		}
+	}
+	cfg = cfg.WithDefaults()
+	log.Info().
+		Int("anonymous_per_minute", cfg.Anonymous.RequestsPerMinute).
+		Int("anonymous_burst", cfg.Anonymous.Burst).
+		Int("booking_per_minute", cfg.Booking.RequestsPerMinute).
+		Int("booking_burst", cfg.Booking.Burst).
+		Msg("Request rate limiters initialized")
+	return rateLimits{
+		anonymous: api.WithRateLimit("anonymous", ratelimit.NewBuckets(ratelimit.BucketConfig{
+			RequestsPerMinute: cfg.Anonymous.RequestsPerMinute,
+			Burst:             cfg.Anonymous.Burst,
+		}), api.ClientIPKey(cfg.TrustProxy)),
+		booking: api.WithRateLimit("booking", ratelimit.NewBuckets(ratelimit.BucketConfig{
+			RequestsPerMinute: cfg.Booking.RequestsPerMinute,
+			Burst:             cfg.Booking.Burst,
+		}), api.UserKey(cfg.TrustProxy)),
+	}
+}
+
+func (l rateLimits) anonymousFunc(h http.HandlerFunc) http.HandlerFunc {
+	return l.anonymous(h).ServeHTTP
+}
+
+func (l rateLimits) bookingFunc(h http.HandlerFunc) http.HandlerFunc {
+	return l.booking(h).ServeHTTP
+}
+
+func registerRoutes(mux *http.ServeMux, database *db.DB, handlers routeHandlers, limits rateLimits) {
 	// Main page handler
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/" {
@@ -189,6 +408,11 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 	mux.HandleFunc("/api/v1/nav/menu", nav.HandleMenu)
 	mux.HandleFunc("/api/v1/nav/menu/close", nav.HandleMenuClose)
 	mux.HandleFunc("/api/v1/nav/search", nav.HandleSearch)
+	// SES bounce and complaint notifications arrive from SNS, which cannot
+	// authenticate; the handler verifies the SNS message signature instead.
+	mux.HandleFunc("/api/v1/email/events", methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: emailevents.HandleEmailEvents,
+	}))
 	mux.HandleFunc("/api/v1/notifications/count", methodHandler(map[string]http.HandlerFunc{
 		http.MethodGet: notifications.HandleNotificationCount,
 	}))
@@ -201,6 +425,18 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 	mux.HandleFunc("/api/v1/notifications/{id}/read", methodHandler(map[string]http.HandlerFunc{
 		http.MethodPut: notifications.HandleMarkAsRead,
 	}))
+	mux.Handle("/api/v1/admin/jobs", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: adminjobs.HandleJobList,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/admin/jobs/{name}/run", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: adminjobs.HandleJobRun,
+		})),
+		api.WithStaffAuth,
+	))
 	mux.HandleFunc("/api/v1/waitlist", methodHandler(map[string]http.HandlerFunc{
 		http.MethodGet:  waitlist.HandleWaitlistList,
 		http.MethodPost: waitlist.HandleWaitlistJoin,
@@ -213,78 +449,317 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 	}))
 
 	// Auth routes (unified for staff and members)
-	mux.HandleFunc("/login", auth.HandleLoginPage)
-	mux.HandleFunc("/api/v1/auth/check-staff", auth.HandleCheckStaff)
-	mux.HandleFunc("/api/v1/auth/send-code", auth.HandleSendCode)
-	mux.HandleFunc("/api/v1/auth/verify-code", auth.HandleVerifyCode)
-	mux.HandleFunc("/api/v1/auth/resend-code", auth.HandleResendCode)
-	mux.HandleFunc("/api/v1/auth/staff-login", auth.HandleStaffLogin)
-	mux.HandleFunc("/api/v1/auth/reset-password", auth.HandleResetPassword)
-	mux.HandleFunc("/api/v1/auth/confirm-reset-password", auth.HandleConfirmResetPassword)
-	mux.HandleFunc("/api/v1/auth/standard-login", auth.HandleStandardLogin)
+	mux.HandleFunc("/login", limits.anonymousFunc(auth.HandleLoginPage))
+	mux.HandleFunc("/api/v1/auth/check-staff", limits.anonymousFunc(auth.HandleCheckStaff))
+	mux.HandleFunc("/api/v1/auth/send-code", limits.anonymousFunc(auth.HandleSendCode))
+	mux.HandleFunc("/api/v1/auth/verify-code", limits.anonymousFunc(auth.HandleVerifyCode))
+	mux.HandleFunc("/api/v1/auth/resend-code", limits.anonymousFunc(auth.HandleResendCode))
+	mux.HandleFunc("/api/v1/auth/staff-login", limits.anonymousFunc(auth.HandleStaffLogin))
+	mux.HandleFunc("/api/v1/auth/reset-password", limits.anonymousFunc(auth.HandleResetPassword))
+	mux.HandleFunc("/api/v1/auth/confirm-reset-password", limits.anonymousFunc(auth.HandleConfirmResetPassword))
+	mux.HandleFunc("/api/v1/auth/standard-login", limits.anonymousFunc(auth.HandleStandardLogin))
 	mux.HandleFunc("/api/v1/auth/logout", auth.HandleLogout)
 
+	// Support impersonation. Ending runs from the impersonated member session,
+	// so only starting requires staff auth.
+	mux.Handle("/api/v1/support/impersonate/{member_id}", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: auth.HandleImpersonationStart,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.HandleFunc("/api/v1/support/impersonate/end", methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: auth.HandleImpersonationEnd,
+	}))
+
 	// Member routes
-	mux.Handle("/member", member.RequireMemberSession(http.HandlerFunc(member.HandleMemberPortal)))
-	mux.Handle("/member/booking/new", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: member.HandleMemberBookingFormNew,
+	mux.Handle("/member", handlers.member.RequireMemberSession(http.HandlerFunc(handlers.member.HandleMemberPortal)))
+	mux.Handle("/member/announcements/{id}/dismiss", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: announcements.HandleAnnouncementDismiss,
+	}))))
+	mux.Handle("/member/booking/new", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberBookingFormNew,
+	}))))
+	mux.Handle("/member/booking/slots", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberBookingSlots,
+	}))))
+	mux.Handle("/member/booking/hold", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost:   limits.bookingFunc(handlers.member.HandleMemberBookingHold),
+		http.MethodDelete: handlers.member.HandleMemberBookingHold,
+	}))))
+	mux.Handle("/member/booking/cancellation-policy", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberCancellationPolicy,
+	}))))
+	mux.Handle("/member/reservations", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:  handlers.member.HandleMemberReservationsPartial,
+		http.MethodPost: limits.bookingFunc(handlers.member.HandleMemberBookingCreate),
+	}))))
+	mux.Handle("/member/visits/export", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberVisitsExport,
+	}))))
+	mux.Handle("/member/waitlist", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberWaitlistList,
+	}))))
+	mux.Handle("/member/waitlist/{id}", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodDelete: handlers.member.HandleMemberWaitlistLeave,
+		http.MethodPut:    handlers.member.HandleMemberWaitlistUpdate,
+	}))))
+	mux.Handle("/member/waitlist/offers/{id}/accept", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleMemberWaitlistOfferAccept,
+	}))))
+	mux.Handle("/member/waitlist/offers/{id}/decline", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleMemberWaitlistOfferDecline,
+	}))))
+	mux.Handle("/member/reservations/{id}", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodDelete: limits.bookingFunc(handlers.member.HandleMemberReservationCancel),
+	}))))
+	mux.Handle("/member/reservations/{id}/invitations", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleMemberReservationInvite,
+	}))))
+	mux.Handle("/member/reservations/{id}/transfer", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: limits.bookingFunc(handlers.member.HandleMemberReservationTransfer),
+	}))))
+	mux.Handle("/member/reservations/{id}/reschedule", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: limits.bookingFunc(handlers.member.HandleMemberReservationReschedule),
+	}))))
+	mux.Handle("/member/members/search", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberInviteeSearch,
+	}))))
+	mux.Handle("/member/reservation-invitations/{token}/accept", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:  handlers.member.HandleReservationInvitationAccept,
+		http.MethodPost: handlers.member.HandleReservationInvitationAccept,
+	}))))
+	// Decline links work without a login; the token is the credential.
+	mux.HandleFunc("/reservation-invitations/{token}/decline", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:  handlers.member.HandleReservationInvitationDecline,
+		http.MethodPost: handlers.member.HandleReservationInvitationDecline,
+	}))
+	mux.Handle("/member/reservation-transfers/{token}/accept", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:  handlers.member.HandleReservationTransferAccept,
+		http.MethodPost: handlers.member.HandleReservationTransferAccept,
+	}))))
+	mux.HandleFunc("/reservation-transfers/{token}/decline", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:  handlers.member.HandleReservationTransferDecline,
+		http.MethodPost: handlers.member.HandleReservationTransferDecline,
+	}))
+	mux.Handle("/member/household", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberHousehold,
+	}))))
+	mux.Handle("/member/household/dependents", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleMemberHouseholdDependentAdd,
+	}))))
+	mux.Handle("/member/household/links/{id}", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodDelete: handlers.member.HandleMemberHouseholdLinkRemove,
+	}))))
+	mux.Handle("/member/security/sessions", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: auth.HandleListSessions,
+	}))))
+	mux.Handle("/member/security/sessions/revoke-others", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: auth.HandleRevokeOtherSessions,
+	}))))
+	mux.Handle("/member/security/sessions/{id}", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodDelete: auth.HandleRevokeSession,
+	}))))
+	mux.Handle("/member/household-links/{token}/accept", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:  handlers.member.HandleHouseholdLinkAccept,
+		http.MethodPost: handlers.member.HandleHouseholdLinkAccept,
+	}))))
+	mux.HandleFunc("/household-links/{token}/decline", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:  handlers.member.HandleHouseholdLinkDecline,
+		http.MethodPost: handlers.member.HandleHouseholdLinkDecline,
+	}))
+	// The feed authenticates calendar apps with a signed token, so it is not
+	// wrapped in RequireMemberSession.
+	mux.HandleFunc("/member/reservations/export.ics", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberReservationsExport,
+	}))
+	mux.Handle("/member/reservations/{id}/export.ics", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberReservationExport,
+	}))))
+	mux.Handle("/member/reservations/{id}/qr", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberReservationQR,
+	}))))
+	mux.Handle("/member/openplay", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberOpenPlayList,
+	}))))
+	mux.Handle("/member/openplay/{id}", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:    handlers.member.HandleMemberOpenPlayDetail,
+		http.MethodPost:   handlers.member.HandleMemberOpenPlaySignup,
+		http.MethodDelete: handlers.member.HandleMemberOpenPlayCancel,
+	}))))
+	mux.Handle("/member/rating", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberRating,
+		http.MethodPut: handlers.member.HandleMemberRatingUpdate,
+	}))))
+	mux.Handle("/member/credits", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberCredits,
+	}))))
+	mux.Handle("/member/restrictions", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberRestrictions,
+	}))))
+	mux.Handle("/member/roster-privacy", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleMemberRosterPrivacyUpdate,
+	}))))
+	mux.Handle("/member/account/delete-request", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleMemberAccountDeleteRequest,
+	}))))
+	mux.Handle("/member/notification-preferences", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:  handlers.member.HandleMemberNotificationPreferences,
+		http.MethodPost: handlers.member.HandleMemberNotificationPreferencesUpdate,
+	}))))
+	mux.Handle("/member/guest-passes", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberGuestPasses,
 	}))))
-	mux.Handle("/member/booking/slots", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: member.HandleMemberBookingSlots,
+	mux.Handle("/member/season-passes", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:  handlers.member.HandleMemberSeasonPassesList,
+		http.MethodPost: handlers.member.HandleMemberSeasonPassPurchase,
 	}))))
-	mux.Handle("/member/reservations", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet:  member.HandleMemberReservationsPartial,
-		http.MethodPost: member.HandleMemberBookingCreate,
+	mux.Handle("/member/visit-packs/purchase", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleMemberVisitPackPurchase,
 	}))))
-	mux.Handle("/member/waitlist", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: member.HandleMemberWaitlistList,
+	mux.Handle("/member/facility", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberFacilityInfo,
 	}))))
-	mux.Handle("/member/reservations/{id}", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodDelete: member.HandleMemberReservationCancel,
+	mux.Handle("/member/facilities/{id}", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberFacilityInfoByID,
 	}))))
-	mux.Handle("/member/openplay", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: member.HandleMemberOpenPlayList,
+	mux.Handle("/member/id-card", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberIDCard,
 	}))))
-	mux.Handle("/member/openplay/{id}", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodPost:   member.HandleMemberOpenPlaySignup,
-		http.MethodDelete: member.HandleMemberOpenPlayCancel,
+	mux.Handle("/member/id-card/qr.png", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberIDCardQR,
 	}))))
-	mux.Handle("/member/lessons/pros", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: member.HandleListPros,
+	mux.Handle("/member/id-card/reset", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleMemberIDCardReset,
 	}))))
-	mux.Handle("/member/lessons/pros/{id}/slots", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: member.HandleProAvailability,
+	mux.Handle("/member/id-card/email", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleMemberIDCardEmail,
 	}))))
-	mux.Handle("/member/lessons/new", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: member.HandleLessonBookingFormNew,
+	mux.Handle("/member/lessons/pros", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleListPros,
 	}))))
-	mux.Handle("/member/lessons/slots", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: member.HandleLessonBookingSlots,
+	mux.Handle("/member/lessons/pros/{id}/slots", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleProAvailability,
 	}))))
-	mux.Handle("/member/lessons", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodPost: member.HandleLessonBookingCreate,
+	mux.Handle("/member/lessons/new", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleLessonBookingFormNew,
 	}))))
-	mux.Handle("/member/clinics", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: member.HandleListAvailableClinics,
+	mux.Handle("/member/lessons/slots", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleLessonBookingSlots,
 	}))))
-	mux.Handle("/member/clinics/{id}/enroll", member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
-		http.MethodPost:   member.HandleClinicEnroll,
-		http.MethodDelete: member.HandleClinicCancel,
+	mux.Handle("/member/lessons", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: limits.bookingFunc(handlers.member.HandleLessonBookingCreate),
 	}))))
-	mux.Handle("/api/v1/member/reservations/widget", member.RequireMemberSession(http.HandlerFunc(member.HandleMemberReservationsWidget)))
+	mux.Handle("/member/events", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberOpenEvents,
+	}))))
+	mux.Handle("/member/events/{id}/signup", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost:   handlers.member.HandleMemberOpenEventSignup,
+		http.MethodDelete: handlers.member.HandleMemberOpenEventWithdraw,
+	}))))
+	mux.Handle("/member/clinics", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleListAvailableClinics,
+	}))))
+	mux.Handle("/member/clinics/{id}/enroll", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost:   handlers.member.HandleClinicEnroll,
+		http.MethodDelete: handlers.member.HandleClinicCancel,
+	}))))
+	mux.Handle("/member/leagues", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.member.HandleMemberLeagueTeams,
+	}))))
+	mux.Handle("/member/leagues/{id}/free-agent", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost:   handlers.member.HandleLeagueFreeAgentRegister,
+		http.MethodDelete: handlers.member.HandleLeagueFreeAgentWithdraw,
+	}))))
+	mux.Handle("/member/leagues/{id}/teams/{team_id}/invitations", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleTeamInvitationCreate,
+	}))))
+	mux.Handle("/member/leagues/{id}/teams/{team_id}/members/{user_id}", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodDelete: handlers.member.HandleCaptainRemoveTeamMember,
+	}))))
+	mux.Handle("/member/leagues/{id}/matches/{match_id}/result", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleLeagueResultSubmit,
+	}))))
+	mux.Handle("/member/leagues/{id}/matches/{match_id}/result/confirm", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleLeagueResultConfirm,
+	}))))
+	mux.Handle("/member/leagues/{id}/matches/{match_id}/result/dispute", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.member.HandleLeagueResultDispute,
+	}))))
+	mux.Handle("/member/league-invitations/{token}/accept", handlers.member.RequireMemberSession(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:  handlers.member.HandleTeamInvitationAccept,
+		http.MethodPost: handlers.member.HandleTeamInvitationAccept,
+	}))))
+	mux.Handle("/api/v1/member/reservations/widget", handlers.member.RequireMemberSession(http.HandlerFunc(handlers.member.HandleMemberReservationsWidget)))
 	mux.HandleFunc("/members", members.HandleMembersPage)
 	mux.HandleFunc("/api/v1/members", methodHandler(map[string]http.HandlerFunc{
 		http.MethodGet:  members.HandleMembersList,
 		http.MethodPost: members.HandleCreateMember,
 	}))
-	mux.HandleFunc("/api/v1/members/search", members.HandleMemberSearch)
+	mux.Handle("/api/v1/members/search", api.ChainMiddleware(http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: members.HandleMemberSearch,
+	})), api.WithStaffAuth))
 	mux.HandleFunc("/api/v1/members/new", members.HandleNewMemberForm)
 	mux.HandleFunc("/api/v1/members/billing", members.HandleMemberBilling)
 
 	// Photo endpoint
 	mux.HandleFunc("/api/v1/members/photo/", members.HandleMemberPhoto)
+	mux.HandleFunc("/api/v1/photos/{user_id}", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: photos.HandleUserPhoto,
+	}))
 
 	// Member detail routes
+	memberHomeFacilityHandler := api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPut: members.HandleMemberHomeFacilityUpdate,
+		})),
+		api.WithStaffAuth,
+	)
+	memberRatingHandler := api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: members.HandleMemberRatingGet,
+			http.MethodPut: members.HandleMemberRatingAdjust,
+		})),
+		api.WithStaffAuth,
+	)
+	memberCreditsHandler := api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:  members.HandleMemberCreditsGet,
+			http.MethodPost: members.HandleMemberCreditIssue,
+		})),
+		api.WithStaffAuth,
+	)
+	memberBillingSummaryHandler := api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: members.HandleMemberBillingSummary,
+		})),
+		api.WithStaffAuth,
+	)
+	memberRatingVerifyHandler := api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: members.HandleMemberRatingVerify,
+		})),
+		api.WithStaffAuth,
+	)
+	memberRestrictionClearHandler := api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: members.HandleMemberRestrictionClear,
+		})),
+		api.WithStaffAuth,
+	)
+	memberEmailUndeliverableClearHandler := api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: members.HandleMemberEmailUndeliverableClear,
+		})),
+		api.WithStaffAuth,
+	)
+	memberVisitsExportHandler := api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: members.HandleMemberVisitsExport,
+		})),
+		api.WithStaffAuth,
+	)
+	memberDeleteHandler := api.ChainMiddleware(http.HandlerFunc(members.HandleDeleteMember), api.WithStaffAuth)
 	mux.HandleFunc("/api/v1/members/", func(w http.ResponseWriter, r *http.Request) {
 		path := strings.TrimSuffix(r.URL.Path, "/")
 
@@ -294,11 +769,51 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 			return
 		}
 
+		if strings.HasSuffix(path, "/billing-summary") {
+			memberBillingSummaryHandler.ServeHTTP(w, r)
+			return
+		}
+
 		if strings.HasSuffix(path, "/visits") {
 			members.HandleMemberVisits(w, r)
 			return
 		}
 
+		if strings.HasSuffix(path, "/visits/export") {
+			memberVisitsExportHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasSuffix(path, "/home-facility") {
+			memberHomeFacilityHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasSuffix(path, "/rating") {
+			memberRatingHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasSuffix(path, "/rating/verify") {
+			memberRatingVerifyHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasSuffix(path, "/credits") {
+			memberCreditsHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasSuffix(path, "/restrictions/clear") {
+			memberRestrictionClearHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasSuffix(path, "/email-undeliverable/clear") {
+			memberEmailUndeliverableClearHandler.ServeHTTP(w, r)
+			return
+		}
+
 		// Handle other member routes
 		switch r.Method {
 		case http.MethodGet:
@@ -310,7 +825,7 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 		case http.MethodPut:
 			members.HandleUpdateMember(w, r)
 		case http.MethodDelete:
-			members.HandleDeleteMember(w, r)
+			memberDeleteHandler.ServeHTTP(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
@@ -326,61 +841,100 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 	mux.HandleFunc("/api/v1/checkin", methodHandler(map[string]http.HandlerFunc{
 		http.MethodPost: checkin.HandleCheckin,
 	}))
+	mux.HandleFunc("/api/v1/checkin/scan", methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: checkin.HandleCheckinScan,
+	}))
 	mux.HandleFunc("/api/v1/checkin/activity", methodHandler(map[string]http.HandlerFunc{
 		http.MethodPost: checkin.HandleCheckinActivityUpdate,
 	}))
 
 	// Staff routes
-	mux.HandleFunc("/staff", staff.HandleStaffPage)
+	mux.HandleFunc("/staff", handlers.staff.HandleStaffPage)
 	mux.HandleFunc("/staff/notifications/{id}", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: staff.HandleNotificationDetail,
+		http.MethodGet: handlers.staff.HandleNotificationDetail,
 	}))
 	mux.HandleFunc("/staff/unavailability", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet:  staff.HandleListProUnavailability,
-		http.MethodPost: staff.HandleCreateProUnavailability,
+		http.MethodGet:  handlers.staff.HandleListProUnavailability,
+		http.MethodPost: handlers.staff.HandleCreateProUnavailability,
 	}))
 	mux.HandleFunc("/staff/unavailability/", methodHandler(map[string]http.HandlerFunc{
-		http.MethodDelete: staff.HandleDeleteProUnavailability,
+		http.MethodDelete: handlers.staff.HandleDeleteProUnavailability,
 	}))
+	mux.Handle("/staff/security/sessions", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: auth.HandleListSessions,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/staff/security/sessions/revoke-others", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: auth.HandleRevokeOtherSessions,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/staff/security/sessions/{id}", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodDelete: auth.HandleRevokeSession,
+		})),
+		api.WithStaffAuth,
+	))
 	mux.HandleFunc("/api/v1/staff", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet:  staff.HandleStaffList,
-		http.MethodPost: staff.HandleCreateStaff,
+		http.MethodGet:  handlers.staff.HandleStaffList,
+		http.MethodPost: handlers.staff.HandleCreateStaff,
 	}))
 	mux.HandleFunc("/api/v1/staff/lessons/booking/new", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: staff.HandleStaffLessonBookingFormNew,
+		http.MethodGet: handlers.staff.HandleStaffLessonBookingFormNew,
 	}))
 	mux.HandleFunc("/api/v1/staff/lessons/booking/slots", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: staff.HandleStaffLessonBookingSlots,
+		http.MethodGet: handlers.staff.HandleStaffLessonBookingSlots,
 	}))
 	mux.HandleFunc("/api/v1/staff/lessons/schedule", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: staff.HandleStaffProScheduleView,
+		http.MethodGet: handlers.staff.HandleStaffProScheduleView,
 	}))
 	mux.HandleFunc("/api/v1/staff/lessons/booking", methodHandler(map[string]http.HandlerFunc{
-		http.MethodPost: staff.HandleStaffLessonBookingCreate,
+		http.MethodPost: handlers.staff.HandleStaffLessonBookingCreate,
 	}))
 	mux.HandleFunc("/api/v1/staff/lessons", methodHandler(map[string]http.HandlerFunc{
-		http.MethodPost: staff.HandleStaffLessonCreate,
+		http.MethodPost: handlers.staff.HandleStaffLessonCreate,
 	}))
 	mux.HandleFunc("/api/v1/staff/waitlist", methodHandler(map[string]http.HandlerFunc{
 		http.MethodGet: waitlist.HandleStaffWaitlistView,
 	}))
 	mux.HandleFunc("/api/v1/staff/members/search", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: staff.HandleStaffMemberSearch,
+		http.MethodGet: handlers.staff.HandleStaffMemberSearch,
+	}))
+	mux.HandleFunc("/api/v1/staff/notifications", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.staff.HandleStaffNotifications,
+	}))
+	mux.HandleFunc("/api/v1/staff/notifications/unread-count", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.staff.HandleStaffNotificationUnreadCount,
+	}))
+	mux.HandleFunc("/api/v1/staff/notifications/{id}", methodHandler(map[string]http.HandlerFunc{
+		http.MethodPatch: handlers.staff.HandleStaffNotificationUpdate,
 	}))
 	mux.HandleFunc("/api/v1/staff/new", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-		staff.HandleNewStaffForm(w, r)
+		handlers.staff.HandleNewStaffForm(w, r)
 	})
 	mux.HandleFunc("/api/v1/staff/", func(w http.ResponseWriter, r *http.Request) {
 		path := strings.TrimSuffix(r.URL.Path, "/")
 
+		if strings.HasSuffix(path, "/lessons/report") {
+			if r.Method == http.MethodGet {
+				handlers.staff.HandleStaffLessonReport(w, r)
+				return
+			}
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
 		// Handle deactivation routes
 		if strings.HasSuffix(path, "/deactivate") {
 			if r.Method == http.MethodPost {
-				staff.HandleDeactivateStaff(w, r)
+				handlers.staff.HandleDeactivateStaff(w, r)
 				return
 			}
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -388,7 +942,7 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 		}
 		if strings.HasSuffix(path, "/deactivation/confirm") {
 			if r.Method == http.MethodPost {
-				staff.HandleConfirmDeactivation(w, r)
+				handlers.staff.HandleConfirmDeactivation(w, r)
 				return
 			}
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -398,12 +952,12 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 		switch r.Method {
 		case http.MethodGet:
 			if strings.HasSuffix(path, "/edit") {
-				staff.HandleEditStaffForm(w, r)
+				handlers.staff.HandleEditStaffForm(w, r)
 			} else {
-				staff.HandleStaffDetail(w, r)
+				handlers.staff.HandleStaffDetail(w, r)
 			}
 		case http.MethodPut:
-			staff.HandleUpdateStaff(w, r)
+			handlers.staff.HandleUpdateStaff(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
@@ -411,53 +965,94 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 
 	// League routes
 	mux.HandleFunc("/leagues", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: leagues.HandleLeaguesPage,
+		http.MethodGet: handlers.leagues.HandleLeaguesPage,
 	}))
 	mux.HandleFunc("/api/v1/leagues", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet:  leagues.HandleLeaguesList,
-		http.MethodPost: leagues.HandleLeagueCreate,
+		http.MethodGet:  handlers.leagues.HandleLeaguesList,
+		http.MethodPost: handlers.leagues.HandleLeagueCreate,
 	}))
 	mux.HandleFunc("/api/v1/leagues/{id}", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet:    leagues.HandleLeagueDetail,
-		http.MethodPut:    leagues.HandleLeagueUpdate,
-		http.MethodDelete: leagues.HandleLeagueDelete,
+		http.MethodGet:    handlers.leagues.HandleLeagueDetail,
+		http.MethodPut:    handlers.leagues.HandleLeagueUpdate,
+		http.MethodDelete: handlers.leagues.HandleLeagueDelete,
 	}))
 	mux.HandleFunc("/api/v1/leagues/{id}/teams", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet:  leagues.HandleListLeagueTeams,
-		http.MethodPost: leagues.HandleTeamCreate,
+		http.MethodGet:  handlers.leagues.HandleListLeagueTeams,
+		http.MethodPost: handlers.leagues.HandleTeamCreate,
 	}))
 	mux.HandleFunc("/api/v1/leagues/{id}/teams/{team_id}", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: leagues.HandleTeamDetail,
-		http.MethodPut: leagues.HandleTeamUpdate,
+		http.MethodGet: handlers.leagues.HandleTeamDetail,
+		http.MethodPut: handlers.leagues.HandleTeamUpdate,
 	}))
 	mux.HandleFunc("/api/v1/leagues/{id}/teams/{team_id}/members", methodHandler(map[string]http.HandlerFunc{
-		http.MethodPost: leagues.HandleAddTeamMember,
+		http.MethodPost: handlers.leagues.HandleAddTeamMember,
 	}))
 	mux.HandleFunc("/api/v1/leagues/{id}/teams/{team_id}/members/{user_id}", methodHandler(map[string]http.HandlerFunc{
-		http.MethodDelete: leagues.HandleRemoveTeamMember,
+		http.MethodDelete: handlers.leagues.HandleRemoveTeamMember,
 	}))
+	mux.Handle("/api/v1/leagues/{id}/teams/{team_id}/payments", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: handlers.leagues.HandleRecordTeamPayment,
+		})),
+		api.WithStaffAuth,
+	))
 	mux.HandleFunc("/api/v1/leagues/{id}/free-agents", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: leagues.HandleListFreeAgents,
+		http.MethodGet: handlers.leagues.HandleListFreeAgents,
 	}))
 	mux.HandleFunc("/api/v1/leagues/{id}/free-agents/{user_id}/assign", methodHandler(map[string]http.HandlerFunc{
-		http.MethodPost: leagues.HandleAssignFreeAgent,
+		http.MethodPost: handlers.leagues.HandleAssignFreeAgent,
+	}))
+	mux.HandleFunc("/api/v1/leagues/{id}/divisions/assign", methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.leagues.HandleAssignDivisions,
+		http.MethodPut:  handlers.leagues.HandleOverrideDivisions,
+	}))
+	mux.HandleFunc("/api/v1/leagues/{id}/schedule", methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: handlers.leagues.HandleSchedule,
 	}))
 	mux.HandleFunc("/api/v1/leagues/{id}/schedule/generate", methodHandler(map[string]http.HandlerFunc{
-		http.MethodPost: leagues.HandleGenerateSchedule,
+		http.MethodPost: handlers.leagues.HandleGenerateSchedule,
 	}))
 	mux.HandleFunc("/api/v1/leagues/{id}/schedule/regenerate", methodHandler(map[string]http.HandlerFunc{
-		http.MethodPost: leagues.HandleRegenerateSchedule,
+		http.MethodPost: handlers.leagues.HandleRegenerateSchedule,
 	}))
 	mux.HandleFunc("/api/v1/leagues/{id}/matches/{match_id}/result", methodHandler(map[string]http.HandlerFunc{
-		http.MethodPut: leagues.HandleRecordMatchResult,
+		http.MethodPut: handlers.leagues.HandleRecordMatchResult,
 	}))
 	mux.HandleFunc("/api/v1/leagues/{id}/standings", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: leagues.HandleLeagueStandings,
+		http.MethodGet: handlers.leagues.HandleLeagueStandings,
 	}))
 	mux.HandleFunc("/api/v1/leagues/{id}/standings/export", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: leagues.HandleExportStandingsCSV,
+		http.MethodGet: handlers.leagues.HandleExportStandingsCSV,
 	}))
 
+	// Tournament routes
+	mux.Handle("/api/v1/tournaments", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:  tournaments.HandleTournamentsList,
+			http.MethodPost: tournaments.HandleTournamentCreate,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/tournaments/{id}/bracket", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:  tournaments.HandleTournamentBracket,
+			http.MethodPost: tournaments.HandleGenerateBracket,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/tournaments/{id}/matches/{match_id}/result", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPut: tournaments.HandleRecordMatchResult,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/tournaments/{id}/matches/{match_id}/schedule", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPut: tournaments.HandleScheduleMatch,
+		})),
+		api.WithStaffAuth,
+	))
+
 	// Clinic routes
 	mux.HandleFunc("/api/v1/clinic-types", methodHandler(map[string]http.HandlerFunc{
 		http.MethodGet:  clinics.HandleClinicTypeList,
@@ -490,21 +1085,73 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-		reservations.HandleEventBookingFormNew(w, r)
+		handlers.reservations.HandleEventBookingFormNew(w, r)
 	})
 
 	// Reservation routes
 	mux.HandleFunc("/api/v1/reservations", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet:  reservations.HandleReservationsList,
-		http.MethodPost: reservations.HandleReservationCreate,
+		http.MethodGet:  handlers.reservations.HandleReservationsList,
+		http.MethodPost: limits.bookingFunc(handlers.reservations.HandleReservationCreate),
 	}))
+	mux.HandleFunc("/api/v1/reservations/calendar", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: handlers.reservations.HandleReservationsCalendar,
+	}))
+	mux.Handle("/api/v1/reservations/search", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: handlers.reservations.HandleReservationSearch,
+		})),
+		api.WithStaffAuth,
+	))
 	mux.HandleFunc("/api/v1/reservations/{id}/edit", methodHandler(map[string]http.HandlerFunc{
-		http.MethodGet: reservations.HandleReservationEdit,
+		http.MethodGet: handlers.reservations.HandleReservationEdit,
 	}))
 	mux.HandleFunc("/api/v1/reservations/{id}", methodHandler(map[string]http.HandlerFunc{
-		http.MethodPut:    reservations.HandleReservationUpdate,
-		http.MethodDelete: reservations.HandleReservationDelete,
+		http.MethodPut:    handlers.reservations.HandleReservationUpdate,
+		http.MethodDelete: limits.bookingFunc(handlers.reservations.HandleReservationDelete),
 	}))
+	mux.Handle("/api/v1/facilities/{id}/reservations/import", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: handlers.reservations.HandleReservationImport,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/reservations/{id}/comments", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:  handlers.reservations.HandleReservationCommentsList,
+			http.MethodPost: handlers.reservations.HandleReservationCommentCreate,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/reservations/{id}/checkin", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: handlers.reservations.HandleReservationCheckin,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/reservations/{id}/transfer", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: handlers.reservations.HandleReservationTransfer,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/reservations/{id}/access/revoke", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: handlers.access.HandleReservationAccessRevoke,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/access/verify", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: handlers.access.HandleAccessVerify,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/facilities/{id}/checkins", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: handlers.reservations.HandleFacilityCheckinsList,
+		})),
+		api.WithStaffAuth,
+	))
 
 	// Open play rules
 	mux.HandleFunc("/open-play-rules", openplayapi.HandleOpenPlayRulesPage)
@@ -531,6 +1178,22 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 		}
 		openplayapi.HandleOpenPlayRuleEdit(w, r)
 	})
+	mux.HandleFunc("/api/v1/open-play-rules/{id}/slots", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet:  openplayapi.HandleOpenPlayRuleSlotsList,
+		http.MethodPost: openplayapi.HandleOpenPlayRuleSlotCreate,
+	}))
+	mux.HandleFunc("/api/v1/open-play-rules/{id}/slots/{slot_id}", methodHandler(map[string]http.HandlerFunc{
+		http.MethodDelete: openplayapi.HandleOpenPlayRuleSlotDelete,
+	}))
+	mux.Handle("/api/v1/facilities/{id}/openplay/generate", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: openplayapi.HandleOpenPlayGenerate,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.HandleFunc("/api/v1/open-play-sessions", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: openplayapi.HandleOpenPlaySessionsList,
+	}))
 	mux.HandleFunc("/api/v1/open-play-sessions/{id}/participants", methodHandler(map[string]http.HandlerFunc{
 		http.MethodGet:    openplayapi.HandleListParticipants,
 		http.MethodPost:   openplayapi.HandleAddParticipant,
@@ -542,6 +1205,34 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 	mux.HandleFunc("/api/v1/open-play-sessions/{id}/auto-scale", methodHandler(map[string]http.HandlerFunc{
 		http.MethodPut: openplayapi.HandleOpenPlaySessionAutoScaleToggle,
 	}))
+	mux.HandleFunc("/api/v1/open-play-sessions/{id}/skill-bands", methodHandler(map[string]http.HandlerFunc{
+		http.MethodPut:    openplayapi.HandleOpenPlaySessionSkillBands,
+		http.MethodDelete: openplayapi.HandleOpenPlaySessionSkillBands,
+	}))
+	mux.Handle("/api/v1/open-play-sessions/{id}/checkin", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: openplayapi.HandleOpenPlaySessionCheckin,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/open-play-sessions/{id}/queue", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: openplayapi.HandleOpenPlayQueue,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/open-play-sessions/{id}/queue/advance", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: openplayapi.HandleOpenPlayQueueAdvance,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/open-play-sessions/{id}/queue/bump", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: openplayapi.HandleOpenPlayNoShowBump,
+		})),
+		api.WithStaffAuth,
+	))
 
 	// Theme admin page
 	mux.HandleFunc("/admin/themes", themes.HandleThemesPage)
@@ -551,6 +1242,87 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 	mux.HandleFunc("/api/v1/dashboard/metrics", methodHandler(map[string]http.HandlerFunc{
 		http.MethodGet: dashboard.HandleDashboardMetrics,
 	}))
+	mux.Handle("/api/v1/facilities/{id}/dashboard", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: dashboard.HandleTodayDashboard,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/facilities/{id}/reports/utilization", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: dashboard.HandleUtilizationReport,
+		})),
+		api.WithStaffAuth,
+	))
+	// Members may read availability too, so the booking form can embed it.
+	mux.HandleFunc("/api/v1/facilities/{id}/availability/summary", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: dashboard.HandleAvailabilitySummary,
+	}))
+	mux.Handle("/api/v1/facilities/{id}/reports/conflicts", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: handlers.reservations.HandleConflictReport,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/facilities/{id}/reports/conflicts/resolve", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: handlers.reservations.HandleConflictResolve,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/courts/{id}/status", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPut: courts.HandleCourtStatusUpdate,
+		})),
+		api.WithStaffAuth,
+	))
+
+	// Organization admin API (corporate admins only)
+	mux.Handle("/api/v1/organizations/{id}", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: organizations.HandleGetOrganization,
+			http.MethodPut: organizations.HandleUpdateOrganization,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/organizations/{id}/facilities", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: organizations.HandleListOrganizationFacilities,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/organizations/{id}/membership-tiers", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:  organizations.HandleListMembershipTiers,
+			http.MethodPost: organizations.HandleCreateMembershipTier,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/organizations/{id}/membership-tiers/{tierId}", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:    organizations.HandleGetMembershipTier,
+			http.MethodPut:    organizations.HandleUpdateMembershipTier,
+			http.MethodDelete: organizations.HandleDeleteMembershipTier,
+		})),
+		api.WithStaffAuth,
+	))
+
+	// Reservation type API (facility staff or org admins, by scope)
+	mux.Handle("/api/v1/reservation-types", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:  reservationtypes.HandleReservationTypesList,
+			http.MethodPost: reservationtypes.HandleReservationTypeCreate,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/reservation-types/{id}", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:    reservationtypes.HandleReservationTypeGet,
+			http.MethodPut:    reservationtypes.HandleReservationTypeUpdate,
+			http.MethodDelete: reservationtypes.HandleReservationTypeDelete,
+		})),
+		api.WithStaffAuth,
+	))
 
 	// Theme API
 	mux.HandleFunc("/api/v1/themes", methodHandler(map[string]http.HandlerFunc{
@@ -575,6 +1347,26 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 	mux.HandleFunc("/api/v1/facilities/{id}/theme", methodHandler(map[string]http.HandlerFunc{
 		http.MethodPut: themes.HandleFacilityThemeSet,
 	}))
+	mux.HandleFunc("/api/v1/facilities/{id}/themes", methodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: themes.HandleFacilityThemes,
+	}))
+	mux.HandleFunc("/api/v1/facilities/{id}/themes/active", methodHandler(map[string]http.HandlerFunc{
+		http.MethodPut: themes.HandleFacilityThemeSet,
+	}))
+
+	// Facility configuration bundles
+	mux.Handle("/api/v1/facilities/{id}/config/export", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: handlers.config.HandleConfigExport,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/facilities/{id}/config/import", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: handlers.config.HandleConfigImport,
+		})),
+		api.WithStaffAuth,
+	))
 
 	// Visit pack admin page
 	mux.HandleFunc("/admin/visit-packs", visitpacks.HandleVisitPackTypesPage)
@@ -597,10 +1389,55 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 	mux.HandleFunc("/api/v1/visit-packs", methodHandler(map[string]http.HandlerFunc{
 		http.MethodPost: visitpacks.HandleVisitPackSale,
 	}))
+	mux.HandleFunc("/api/v1/visit-packs/purchase", methodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: visitpacks.HandleVisitPackPurchase,
+	}))
 	mux.HandleFunc("/api/v1/users/{id}/visit-packs", methodHandler(map[string]http.HandlerFunc{
 		http.MethodGet: visitpacks.HandleListUserVisitPacks,
 	}))
 
+	// Season pass API
+	mux.Handle("/api/v1/season-pass-types", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:  seasonpasses.HandleSeasonPassTypesList,
+			http.MethodPost: seasonpasses.HandleSeasonPassTypeCreate,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/season-pass-types/{id}", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPut:    seasonpasses.HandleSeasonPassTypeUpdate,
+			http.MethodDelete: seasonpasses.HandleSeasonPassTypeDeactivate,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/season-passes", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: seasonpasses.HandleSeasonPassGrant,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/season-passes/report", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: seasonpasses.HandleSeasonPassReport,
+		})),
+		api.WithStaffAuth,
+	))
+
+	// Guest pass API
+	mux.Handle("/api/v1/guest-passes", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: guestpasses.HandleGuestPassGrant,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/users/{id}/guest-passes", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet: guestpasses.HandleUserGuestPasses,
+		})),
+		api.WithStaffAuth,
+	))
+
 	// Lesson package API
 	mux.HandleFunc("/api/v1/lesson-package-types", methodHandler(map[string]http.HandlerFunc{
 		http.MethodGet:  lessonpacks.HandleLessonPackageTypesList,
@@ -653,9 +1490,61 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 	mux.HandleFunc("/api/v1/operating-hours/{day_of_week}", methodHandler(map[string]http.HandlerFunc{
 		http.MethodPut: operatinghours.HandleOperatingHoursUpdate,
 	}))
+	mux.Handle("/api/v1/facilities/{id}/hours/overrides", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:    operatinghours.HandleHoursOverridesList,
+			http.MethodPost:   operatinghours.HandleHoursOverrideCreate,
+			http.MethodDelete: operatinghours.HandleHoursOverrideDelete,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/facilities/{id}/prime-time", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:  operatinghours.HandlePrimeTimeRulesList,
+			http.MethodPost: operatinghours.HandlePrimeTimeRuleCreate,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/facilities/{id}/prime-time/{ruleId}", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPut:    operatinghours.HandlePrimeTimeRuleUpdate,
+			http.MethodDelete: operatinghours.HandlePrimeTimeRuleDelete,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/facilities/{id}/pricing-rules", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:  operatinghours.HandlePricingRulesList,
+			http.MethodPost: operatinghours.HandlePricingRuleCreate,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/facilities/{id}/pricing-rules/{ruleId}", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPut:    operatinghours.HandlePricingRuleUpdate,
+			http.MethodDelete: operatinghours.HandlePricingRuleDelete,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/no-show-policy", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:    operatinghours.HandleNoShowPolicyGet,
+			http.MethodPut:    operatinghours.HandleNoShowPolicyUpdate,
+			http.MethodDelete: operatinghours.HandleNoShowPolicyDelete,
+		})),
+		api.WithStaffAuth,
+	))
 	mux.HandleFunc("/api/v1/facility-settings", methodHandler(map[string]http.HandlerFunc{
 		http.MethodPost: operatinghours.HandleFacilitySettingsUpdate,
 	}))
+	mux.Handle("/api/v1/notification-quiet-hours", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:    operatinghours.HandleQuietHoursGet,
+			http.MethodPut:    operatinghours.HandleQuietHoursUpdate,
+			http.MethodDelete: operatinghours.HandleQuietHoursDelete,
+		})),
+		api.WithStaffAuth,
+	))
 
 	// Cancellation policy admin page
 	mux.HandleFunc("/admin/cancellation-policy", cancellationpolicy.HandleCancellationPolicyPage)
@@ -669,6 +1558,44 @@ func registerRoutes(mux *http.ServeMux, database *db.DB) {
 		http.MethodPut:    cancellationpolicy.HandleCancellationPolicyTierUpdate,
 		http.MethodDelete: cancellationpolicy.HandleCancellationPolicyTierDelete,
 	}))
+	mux.Handle("/api/v1/facilities/{id}/cancellation-policies", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:  cancellationpolicy.HandleFacilityCancellationPoliciesList,
+			http.MethodPost: cancellationpolicy.HandleFacilityCancellationPolicyCreate,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/facilities/{id}/cancellation-policies/{policy}", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:    cancellationpolicy.HandleFacilityCancellationPolicyGet,
+			http.MethodPut:    cancellationpolicy.HandleFacilityCancellationPolicyUpdate,
+			http.MethodDelete: cancellationpolicy.HandleFacilityCancellationPolicyDelete,
+		})),
+		api.WithStaffAuth,
+	))
+
+	// Facility announcements API
+	mux.Handle("/api/v1/facilities/{id}/announcements", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:  announcements.HandleAnnouncementsList,
+			http.MethodPost: announcements.HandleAnnouncementCreate,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/facilities/{id}/announcements/{announcementId}", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodGet:    announcements.HandleAnnouncementGet,
+			http.MethodPut:    announcements.HandleAnnouncementUpdate,
+			http.MethodDelete: announcements.HandleAnnouncementDelete,
+		})),
+		api.WithStaffAuth,
+	))
+	mux.Handle("/api/v1/announcements/{id}/dismiss", api.ChainMiddleware(
+		http.HandlerFunc(methodHandler(map[string]http.HandlerFunc{
+			http.MethodPost: announcements.HandleAnnouncementDismiss,
+		})),
+		api.WithStaffAuth,
+	))
 
 	// Static file handling with logging and environment awareness
 	staticDir := os.Getenv("STATIC_DIR")
@@ -707,22 +1634,20 @@ func registerOpenPlayEnforcementJob(cfg *config.Config, database *db.DB, engine
 		Str("cron", cronExpr).
 		Logger()
 
-	_, err := scheduler.AddJob(jobName, cronExpr, func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-		defer cancel()
+	_, err := scheduler.AddTrackedJob(jobName, cronExpr, 2*time.Minute, func(ctx context.Context) (int64, error) {
 		ctx = jobLogger.WithContext(ctx)
 
 		comparisonTime := time.Now()
 		facilityIDs, err := listOpenPlayEnforcementFacilities(ctx, database, comparisonTime)
 		if err != nil {
-			jobLogger.Error().Err(err).Msg("Failed to list facilities for open play enforcement")
-			return
+			return 0, err
 		}
 		if len(facilityIDs) == 0 {
 			jobLogger.Debug().Msg("No facilities with scheduled open play sessions")
-			return
+			return 0, nil
 		}
 
+		var evaluated int64
 		for _, facilityID := range facilityIDs {
 			facilityLogger := jobLogger.With().Int64("facility_id", facilityID).Logger()
 			facilityCtx := facilityLogger.WithContext(ctx)
@@ -730,8 +1655,10 @@ func registerOpenPlayEnforcementJob(cfg *config.Config, database *db.DB, engine
 				facilityLogger.Error().Err(err).Msg("Open play enforcement run failed")
 				continue
 			}
+			evaluated++
 			facilityLogger.Debug().Msg("Open play enforcement run completed")
 		}
+		return evaluated, nil
 	})
 	if err != nil {
 		return fmt.Errorf("add open play enforcement job: %w", err)
@@ -741,6 +1668,60 @@ func registerOpenPlayEnforcementJob(cfg *config.Config, database *db.DB, engine
 	return nil
 }
 
+// registerOpenPlayGenerationJob keeps open_play.generation_days of sessions
+// materialized ahead for every facility with rule slots. Zero days disables it.
+func registerOpenPlayGenerationJob(cfg *config.Config, database *db.DB, engine *openplayengine.Engine) error {
+	if cfg == nil || database == nil || engine == nil {
+		return fmt.Errorf("open play generation job requires config, database, and engine")
+	}
+	days := cfg.OpenPlay.GenerationDays
+	if days <= 0 {
+		return nil
+	}
+
+	jobName := "openplay_generation"
+	cronExpr := "15 2 * * *"
+	jobLogger := log.With().
+		Str("component", "openplay_generation_job").
+		Str("job_name", jobName).
+		Str("cron", cronExpr).
+		Int("generation_days", days).
+		Logger()
+
+	_, err := scheduler.AddTrackedJob(jobName, cronExpr, 5*time.Minute, func(ctx context.Context) (int64, error) {
+		ctx = jobLogger.WithContext(ctx)
+
+		facilityIDs, err := database.Queries.ListFacilitiesWithOpenPlayRuleSlots(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("list facilities for open play generation: %w", err)
+		}
+
+		now := time.Now()
+		var created int64
+		for _, facilityID := range facilityIDs {
+			facilityLogger := jobLogger.With().Int64("facility_id", facilityID).Logger()
+			facilityCtx := facilityLogger.WithContext(ctx)
+			summary, err := engine.GenerateUpcomingSessions(facilityCtx, facilityID, days, now)
+			if err != nil {
+				facilityLogger.Error().Err(err).Msg("Open play generation run failed")
+				continue
+			}
+			created += int64(len(summary.Created))
+			facilityLogger.Debug().
+				Int("created", len(summary.Created)).
+				Int("skipped", len(summary.Skipped)).
+				Msg("Open play generation run completed")
+		}
+		return created, nil
+	})
+	if err != nil {
+		return fmt.Errorf("add open play generation job: %w", err)
+	}
+
+	jobLogger.Info().Msg("Open play generation job registered")
+	return nil
+}
+
 func listOpenPlayEnforcementFacilities(ctx context.Context, database *db.DB, comparisonTime time.Time) ([]int64, error) {
 	facilityIDs, err := database.Queries.ListDistinctFacilitiesWithScheduledSessions(ctx, comparisonTime)
 	if err != nil {
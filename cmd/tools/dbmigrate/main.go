@@ -3,95 +3,67 @@ package main
 
 import (
 	"flag"
-	"fmt"
 	"log"
 	"os"
-	"path/filepath"
 
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/codr1/Pickleicious/internal/db/migrate"
 )
 
 func main() {
 	var (
-		dbPath         = flag.String("db", "", "Path to SQLite database")
-		migrationsPath = flag.String("migrations", "", "Path to migrations directory")
-		command        = flag.String("command", "", "Command to run (up, down, version)")
+		dbPath  = flag.String("db", "", "Path to SQLite database")
+		command = flag.String("command", "", "Command to run (up, down, version, force)")
+		version = flag.Int64("version", -1, "Migration version to record (force only)")
 	)
 	flag.Parse()
 
 	// Validate flags
-	if *dbPath == "" || *migrationsPath == "" || *command == "" {
-		log.Println("All flags are required:")
+	if *dbPath == "" || *command == "" {
+		log.Println("-db and -command are required:")
 		flag.PrintDefaults()
 		os.Exit(1)
 	}
 
-	// Convert paths to absolute
-	absDB, err := filepath.Abs(*dbPath)
+	// Migrations are embedded in the binary; only the database is opened.
+	runner, err := migrate.Open(*dbPath)
 	if err != nil {
-		log.Fatalf("Invalid database path: %v", err)
+		log.Fatalf("Failed to open database: %v", err)
 	}
-
-	absMigrations, err := filepath.Abs(*migrationsPath)
-	if err != nil {
-		log.Fatalf("Invalid migrations path: %v", err)
-	}
-
-	// Ensure migrations directory exists
-	if _, err := os.Stat(absMigrations); os.IsNotExist(err) {
-		log.Fatalf("Migrations directory does not exist: %s", absMigrations)
-	}
-
-	// Create database directory if it doesn't exist
-	dbDir := filepath.Dir(absDB)
-	if err := os.MkdirAll(dbDir, 0755); err != nil {
-		log.Fatalf("Failed to create database directory: %v", err)
-	}
-
-	// Make db path absolute relative to project root
-	if !filepath.IsAbs(*dbPath) {
-		// Get project root (directory containing go.mod)
-		projectRoot, err := os.Getwd()
-		if err != nil {
-			log.Fatalf("Failed to get working directory: %v", err)
-		}
-
-		// Make db path absolute relative to project root
-		*dbPath = filepath.Join(projectRoot, "build", "db", filepath.Base(*dbPath))
-	}
-
-	// Create migration instance
-	sourceURL := fmt.Sprintf("file://%s", absMigrations)
-	databaseURL := fmt.Sprintf("sqlite3://%s", absDB)
-
-	m, err := migrate.New(sourceURL, databaseURL)
-	if err != nil {
-		log.Fatalf("Failed to create migrate instance: %v", err)
-	}
-	defer m.Close()
+	defer runner.Close()
 
 	// Execute command
 	switch *command {
 	case "up":
-		if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		if err := runner.Up(); err != nil {
 			log.Fatalf("Failed to run migrations: %v", err)
 		}
 		log.Println("Successfully ran migrations up")
 
 	case "down":
-		if err := m.Down(); err != nil && err != migrate.ErrNoChange {
-			log.Fatalf("Failed to rollback migrations: %v", err)
+		if err := runner.Down(); err != nil {
+			log.Fatalf("Failed to rollback migration: %v", err)
 		}
-		log.Println("Successfully ran migrations down")
+		log.Println("Successfully rolled back the last migration")
 
 	case "version":
-		version, dirty, err := m.Version()
+		current, dirty, err := runner.Version()
 		if err != nil {
 			log.Fatalf("Failed to get version: %v", err)
 		}
-		log.Printf("Current version: %d, Dirty: %v\n", version, dirty)
+		latest, err := migrate.Latest()
+		if err != nil {
+			log.Fatalf("Failed to read embedded migrations: %v", err)
+		}
+		log.Printf("Current version: %d, Dirty: %v, Latest: %d\n", current, dirty, latest)
+
+	case "force":
+		if *version < 0 {
+			log.Fatalf("force requires -version")
+		}
+		if err := runner.Force(*version); err != nil {
+			log.Fatalf("Failed to force version: %v", err)
+		}
+		log.Printf("Forced version to %d\n", *version)
 
 	default:
 		log.Fatalf("Unknown command: %s", *command)
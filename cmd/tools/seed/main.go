@@ -0,0 +1,82 @@
+// cmd/tools/seed/main.go
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/db/migrate"
+	"github.com/codr1/Pickleicious/internal/db/seed"
+)
+
+func main() {
+	var (
+		dbPath = flag.String("db", "", "Path to SQLite database")
+		wipe   = flag.Bool("wipe", false, "Delete the database file before seeding")
+	)
+	flag.Parse()
+
+	if *dbPath == "" {
+		log.Println("-db is required:")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	file, err := databaseFile(*dbPath)
+	if err != nil {
+		log.Fatalf("Invalid database path: %v", err)
+	}
+	if *wipe {
+		if err := removeDatabase(file); err != nil {
+			log.Fatalf("Failed to wipe database: %v", err)
+		}
+		log.Printf("Wiped %s\n", file)
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		log.Fatalf("Failed to create database directory: %v", err)
+	}
+
+	// Opening the database applies any pending migrations.
+	database, err := appdb.New(file)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer database.Close()
+
+	summary, err := seed.Run(context.Background(), database, time.Now())
+	if err != nil {
+		log.Fatalf("Failed to seed database: %v", err)
+	}
+	log.Printf("Seeded database: %+v\n", summary)
+	log.Println("Staff login: admin@pickle.test / admin123; members sign in with OTP code 123456 in dev mode")
+}
+
+// databaseFile resolves a -db value, which may carry the sqlite3:// scheme or
+// query parameters, to the absolute path of the database file.
+func databaseFile(path string) (string, error) {
+	databaseURL, err := migrate.DatabaseURL(path)
+	if err != nil {
+		return "", err
+	}
+	file, _, _ := strings.Cut(strings.TrimPrefix(databaseURL, "sqlite3://"), "?")
+	return file, nil
+}
+
+// removeDatabase deletes the SQLite file along with its journal and WAL
+// files. A missing file is not an error.
+func removeDatabase(file string) error {
+	for _, suffix := range []string{"", "-journal", "-wal", "-shm"} {
+		if err := os.Remove(file + suffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+// internal/api/access/handlers.go
+package access
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/models"
+)
+
+const (
+	accessQueryTimeout = 5 * time.Second
+
+	decisionAllow = "allow"
+	decisionDeny  = "deny"
+)
+
+// Handlers serves door and gate verification of reservation access codes.
+// Each instance holds its own database and signer.
+type Handlers struct {
+	queries *dbgen.Queries
+	store   *appdb.DB
+	signer  *models.ReservationAccessSigner
+}
+
+// NewHandlers returns the access handlers over database. With a nil database
+// or signer every handler answers 500.
+func NewHandlers(database *appdb.DB, signer *models.ReservationAccessSigner) *Handlers {
+	h := &Handlers{signer: signer}
+	if database != nil {
+		h.queries = database.Queries
+		h.store = database
+	}
+	return h
+}
+
+type verifyRequest struct {
+	Payload    string `json:"payload"`
+	FacilityID int64  `json:"facilityId"`
+}
+
+type verifyResponse struct {
+	Decision      string     `json:"decision"`
+	Reason        string     `json:"reason"`
+	ReservationID int64      `json:"reservationId,omitempty"`
+	UserID        int64      `json:"userId,omitempty"`
+	MemberName    string     `json:"memberName,omitempty"`
+	ValidFrom     *time.Time `json:"validFrom,omitempty"`
+	ValidUntil    *time.Time `json:"validUntil,omitempty"`
+	// CheckedInAt is set on allow: the member's check-in, recorded by this
+	// scan unless an earlier one exists.
+	CheckedInAt *time.Time `json:"checkedInAt,omitempty"`
+}
+
+type revokeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// POST /api/v1/access/verify
+//
+// Checks a scanned reservation access code for a door or gate at the given
+// facility. Every readable request answers 200 with an allow or deny
+// decision and its reason; an allowed member is checked in to the
+// reservation.
+func (h *Handlers) HandleAccessVerify(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if h.queries == nil || h.store == nil || h.signer == nil {
+		logger.Error().Msg("Database queries or access signer not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if !authz.IsStaff(user) {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	req, err := decodeVerifyRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if req.Payload == "" {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Access code payload is required")
+		return
+	}
+	if req.FacilityID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Facility ID is required")
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, req.FacilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), accessQueryTimeout)
+	defer cancel()
+
+	response, err := h.verify(ctx, req, user.ID, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", req.FacilityID).Msg("Failed to verify reservation access code")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to verify access code")
+		return
+	}
+
+	event := logger.Info()
+	if response.Decision == decisionDeny {
+		event = logger.Warn()
+	}
+	event.Int64("facility_id", req.FacilityID).
+		Int64("reservation_id", response.ReservationID).
+		Int64("user_id", response.UserID).
+		Str("decision", response.Decision).
+		Str("reason", response.Reason).
+		Msg("Reservation access code verified")
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Msg("Failed to write access verification response")
+	}
+}
+
+// verify decides a scan against the reservation as it is now, so codes for
+// reservations that were cancelled, revoked, moved, or left stop working at
+// once. Codes carry their window, but the stored reservation is what counts.
+func (h *Handlers) verify(ctx context.Context, req verifyRequest, staffUserID int64, now time.Time) (verifyResponse, error) {
+	deny := func(reason string) (verifyResponse, error) {
+		return verifyResponse{Decision: decisionDeny, Reason: reason}, nil
+	}
+
+	access, err := h.signer.Verify(req.Payload)
+	if err != nil {
+		return deny("Invalid access code")
+	}
+
+	reservation, err := h.queries.GetReservationByID(ctx, access.ReservationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return deny("Reservation not found")
+		}
+		return verifyResponse{}, err
+	}
+	if reservation.FacilityID != req.FacilityID {
+		return deny("Reservation is for another facility")
+	}
+
+	cancelled, err := h.queries.IsReservationCancelled(ctx, reservation.ID)
+	if err != nil {
+		return verifyResponse{}, err
+	}
+	if cancelled != 0 {
+		return deny("Reservation has been cancelled")
+	}
+	if _, err := h.queries.GetReservationAccessRevocation(ctx, reservation.ID); err == nil {
+		return deny("Access has been revoked")
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return verifyResponse{}, err
+	}
+
+	current := models.NewReservationAccess(reservation.ID, access.UserID, reservation.StartTime, reservation.EndTime)
+	if current != access {
+		return deny("Reservation has changed; open the current QR code")
+	}
+
+	roster, err := h.queries.ListReservationCheckinRoster(ctx, reservation.ID)
+	if err != nil {
+		return verifyResponse{}, err
+	}
+	var member *dbgen.ListReservationCheckinRosterRow
+	for i := range roster {
+		if roster[i].UserID == access.UserID {
+			member = &roster[i]
+			break
+		}
+	}
+	if member == nil {
+		return deny("Member is no longer on this reservation")
+	}
+
+	response := verifyResponse{
+		ReservationID: reservation.ID,
+		UserID:        access.UserID,
+		MemberName:    strings.TrimSpace(member.FirstName + " " + member.LastName),
+		ValidFrom:     &access.ValidFrom,
+		ValidUntil:    &access.ValidUntil,
+		Decision:      decisionDeny,
+	}
+	if now.Before(access.ValidFrom) {
+		facilityLoc := time.UTC
+		if facility, err := h.store.Facilities.GetFacilityByID(ctx, reservation.FacilityID); err == nil {
+			facilityLoc = apiutil.FacilityLocation(facility, log.Ctx(ctx))
+		}
+		response.Reason = fmt.Sprintf("Too early; access opens at %s", access.ValidFrom.In(facilityLoc).Format("3:04 PM"))
+		return response, nil
+	}
+	if !now.Before(access.ValidUntil) {
+		response.Reason = "Reservation has ended"
+		return response, nil
+	}
+
+	checkedInAt := now
+	if member.CheckedInAt.Valid {
+		checkedInAt = member.CheckedInAt.Time
+	} else if _, err := h.queries.CreateReservationCheckin(ctx, dbgen.CreateReservationCheckinParams{
+		ReservationID:     reservation.ID,
+		UserID:            access.UserID,
+		CheckedInAt:       now,
+		CheckedInByUserID: staffUserID,
+	}); err != nil {
+		return verifyResponse{}, err
+	}
+	response.Decision = decisionAllow
+	response.Reason = "Access granted"
+	response.CheckedInAt = &checkedInAt
+	return response, nil
+}
+
+// POST /api/v1/reservations/{id}/access/revoke
+//
+// Stops every access code for the reservation from opening the door. The
+// reservation itself is unchanged.
+func (h *Handlers) HandleReservationAccessRevoke(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if h.queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if !authz.IsStaff(user) {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	reservationID, err := apiutil.ReservationIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
+		return
+	}
+
+	var req revokeRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), accessQueryTimeout)
+	defer cancel()
+
+	reservation, err := h.queries.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Reservation not found")
+			return
+		}
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to fetch reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch reservation")
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, reservation.FacilityID) {
+		return
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if _, err := h.queries.RevokeReservationAccess(ctx, dbgen.RevokeReservationAccessParams{
+		ReservationID:   reservation.ID,
+		RevokedByUserID: user.ID,
+		Reason:          sql.NullString{String: reason, Valid: reason != ""},
+	}); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to revoke reservation access")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to revoke access")
+		return
+	}
+	revocation, err := h.queries.GetReservationAccessRevocation(ctx, reservation.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to load reservation access revocation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to revoke access")
+		return
+	}
+
+	logger.Info().Int64("reservation_id", reservation.ID).Int64("staff_user_id", user.ID).Msg("Reservation access revoked")
+	if err := apiutil.WriteJSON(w, http.StatusOK, revocation); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to write access revocation response")
+	}
+}
+
+func decodeVerifyRequest(r *http.Request) (verifyRequest, error) {
+	var req verifyRequest
+	if apiutil.IsJSONRequest(r) {
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return req, err
+		}
+		req.Payload = strings.TrimSpace(req.Payload)
+		return req, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return req, err
+	}
+	facilityID, err := apiutil.ParseRequiredInt64Field(apiutil.FirstNonEmpty(r.FormValue("facilityId"), r.FormValue("facility_id")), "facilityId")
+	if err != nil {
+		return req, err
+	}
+	req.Payload = strings.TrimSpace(r.FormValue("payload"))
+	req.FacilityID = facilityID
+	return req, nil
+}
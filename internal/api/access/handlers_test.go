@@ -0,0 +1,216 @@
+package access
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/models"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type accessFixture struct {
+	h             *Handlers
+	database      *db.DB
+	signer        *models.ReservationAccessSigner
+	facilityID    int64
+	staffID       int64
+	memberID      int64
+	reservationID int64
+	start         time.Time
+	end           time.Time
+}
+
+// setupAccessTest books the member a game that started five minutes ago.
+func setupAccessTest(t *testing.T) accessFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	staffResult, err := database.Exec(
+		"INSERT INTO users (first_name, last_name, email, status) VALUES (?, ?, ?, ?)",
+		"Gate", "Reader", "gate@test.com", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert staff: %v", err)
+	}
+	staffID, _ := staffResult.LastInsertId()
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level)
+		 VALUES (?, ?, ?, ?, 1, 1, 2)`,
+		"Court", "Booker", "member@test.com", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	start := time.Now().UTC().Add(-5 * time.Minute).Truncate(time.Second)
+	end := start.Add(time.Hour)
+	reservationResult, err := database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?, ?)`,
+		facilityID, memberID, memberID, start, end,
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, _ := reservationResult.LastInsertId()
+
+	signer, err := models.NewReservationAccessSigner("test-secret")
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+
+	return accessFixture{
+		h:             NewHandlers(database, signer),
+		database:      database,
+		signer:        signer,
+		facilityID:    facilityID,
+		staffID:       staffID,
+		memberID:      memberID,
+		reservationID: reservationID,
+		start:         start,
+		end:           end,
+	}
+}
+
+func (f accessFixture) code() string {
+	return f.signer.Sign(models.NewReservationAccess(f.reservationID, f.memberID, f.start, f.end))
+}
+
+func (f accessFixture) withStaff(req *http.Request) *http.Request {
+	homeFacilityID := f.facilityID
+	return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             f.staffID,
+		IsStaff:        true,
+		HomeFacilityID: &homeFacilityID,
+	}))
+}
+
+func (f accessFixture) verify(t *testing.T, payload string) verifyResponse {
+	t.Helper()
+
+	body, err := json.Marshal(verifyRequest{Payload: payload, FacilityID: f.facilityID})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/access/verify", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	f.h.HandleAccessVerify(recorder, f.withStaff(req))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("verify status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response verifyResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return response
+}
+
+func (f accessFixture) expectDeny(t *testing.T, payload, reason string) {
+	t.Helper()
+	response := f.verify(t, payload)
+	if response.Decision != decisionDeny || response.Reason != reason {
+		t.Fatalf("expected deny %q, got %s %q", reason, response.Decision, response.Reason)
+	}
+}
+
+func TestHandleAccessVerify_AllowsAndChecksInOnce(t *testing.T) {
+	fixture := setupAccessTest(t)
+
+	for scan := 0; scan < 2; scan++ {
+		response := fixture.verify(t, fixture.code())
+		if response.Decision != decisionAllow {
+			t.Fatalf("scan %d: expected allow, got %s %q", scan, response.Decision, response.Reason)
+		}
+		if response.ReservationID != fixture.reservationID || response.UserID != fixture.memberID || response.CheckedInAt == nil {
+			t.Fatalf("scan %d: unexpected response %+v", scan, response)
+		}
+	}
+
+	var checkins int
+	if err := fixture.database.QueryRow(
+		"SELECT COUNT(*) FROM reservation_checkins WHERE reservation_id = ? AND user_id = ?",
+		fixture.reservationID, fixture.memberID,
+	).Scan(&checkins); err != nil {
+		t.Fatalf("count check-ins: %v", err)
+	}
+	if checkins != 1 {
+		t.Fatalf("expected one check-in, got %d", checkins)
+	}
+}
+
+func TestHandleAccessVerify_DeniesInvalidAndOutOfWindowCodes(t *testing.T) {
+	fixture := setupAccessTest(t)
+
+	fixture.expectDeny(t, fixture.code()+"x", "Invalid access code")
+
+	other, _ := models.NewReservationAccessSigner("other-secret")
+	fixture.expectDeny(t, other.Sign(models.NewReservationAccess(fixture.reservationID, fixture.memberID, fixture.start, fixture.end)), "Invalid access code")
+
+	if _, err := fixture.database.Exec(
+		"UPDATE reservations SET start_time = ?, end_time = ? WHERE id = ?",
+		fixture.start.Add(2*time.Hour), fixture.end.Add(2*time.Hour), fixture.reservationID,
+	); err != nil {
+		t.Fatalf("move reservation: %v", err)
+	}
+	fixture.expectDeny(t, fixture.code(), "Reservation has changed; open the current QR code")
+
+	fixture.start, fixture.end = fixture.start.Add(2*time.Hour), fixture.end.Add(2*time.Hour)
+	opensAt := fixture.start.Add(-models.ReservationAccessOpensBeforeStart)
+	fixture.expectDeny(t, fixture.code(), fmt.Sprintf("Too early; access opens at %s", opensAt.Format("3:04 PM")))
+
+	stranger := fixture.signer.Sign(models.NewReservationAccess(fixture.reservationID, fixture.staffID, fixture.start, fixture.end))
+	fixture.expectDeny(t, stranger, "Member is no longer on this reservation")
+}
+
+func TestHandleAccessVerify_DeniesCancelledAndRevokedReservations(t *testing.T) {
+	fixture := setupAccessTest(t)
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/reservations/%d/access/revoke", fixture.reservationID), bytes.NewReader([]byte(`{"reason":"Lost phone"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", fmt.Sprintf("%d", fixture.reservationID))
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleReservationAccessRevoke(recorder, fixture.withStaff(req))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("revoke status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	fixture.expectDeny(t, fixture.code(), "Access has been revoked")
+
+	if _, err := fixture.database.Exec(
+		`INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, hours_before_start)
+		 VALUES (?, ?, ?, 100, 0)`,
+		fixture.reservationID, fixture.memberID, time.Now(),
+	); err != nil {
+		t.Fatalf("cancel reservation: %v", err)
+	}
+	fixture.expectDeny(t, fixture.code(), "Reservation has been cancelled")
+}
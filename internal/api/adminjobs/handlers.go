@@ -0,0 +1,156 @@
+// internal/api/adminjobs/handlers.go
+package adminjobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/jobs"
+)
+
+const adminJobsQueryTimeout = 5 * time.Second
+
+var (
+	queries     *dbgen.Queries
+	registry    *jobs.Registry
+	queriesOnce sync.Once
+)
+
+type jobListResponse struct {
+	Jobs []jobs.Status `json:"jobs"`
+}
+
+// InitHandlers must be called during server startup, after jobs.Init, before
+// handling requests.
+func InitHandlers(q *dbgen.Queries, r *jobs.Registry) {
+	if q == nil || r == nil {
+		return
+	}
+	queriesOnce.Do(func() {
+		queries = q
+		registry = r
+	})
+}
+
+// GET /api/v1/admin/jobs
+//
+// HandleJobList lists the background jobs with the outcome of each one's
+// latest run.
+func HandleJobList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil || registry == nil {
+		logger.Error().Msg("Job registry not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), adminJobsQueryTimeout)
+	defer cancel()
+
+	if !requireAdmin(ctx, w, r) {
+		return
+	}
+
+	statuses, err := registry.List(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list job statuses")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to list jobs")
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	if err := apiutil.WriteJSON(w, http.StatusOK, jobListResponse{Jobs: statuses}); err != nil {
+		logger.Error().Err(err).Msg("Failed to write job list response")
+	}
+}
+
+// POST /api/v1/admin/jobs/{name}/run
+//
+// HandleJobRun starts an immediate run of the named job and answers 202
+// without waiting for it; the outcome shows in the job list. A job already
+// running, by schedule or by hand, answers 409.
+func HandleJobRun(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil || registry == nil {
+		logger.Error().Msg("Job registry not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), adminJobsQueryTimeout)
+	defer cancel()
+
+	if !requireAdmin(ctx, w, r) {
+		return
+	}
+
+	name := strings.TrimSpace(r.PathValue("name"))
+	err := registry.Start(r.Context(), name, jobs.TriggerManual)
+	switch {
+	case errors.Is(err, jobs.ErrUnknownJob):
+		apiutil.WriteError(w, r, http.StatusNotFound, "Job not found")
+		return
+	case errors.Is(err, jobs.ErrAlreadyRunning):
+		apiutil.WriteError(w, r, http.StatusConflict, "Job is already running")
+		return
+	case err != nil:
+		logger.Error().Err(err).Str("job_name", name).Msg("Failed to start job")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to start job")
+		return
+	}
+
+	var userID int64
+	if user := authz.UserFromContext(r.Context()); user != nil {
+		userID = user.ID
+	}
+	logger.Info().Str("job_name", name).Int64("triggered_by_user_id", userID).Msg("Job run triggered manually")
+
+	if err := apiutil.WriteJSON(w, http.StatusAccepted, map[string]any{"name": name, "trigger": jobs.TriggerManual}); err != nil {
+		logger.Error().Err(err).Str("job_name", name).Msg("Failed to write job run response")
+	}
+}
+
+// requireAdmin lets organization admins through. Jobs run for every
+// organization, so facility admins and other staff may not see or run them.
+func requireAdmin(ctx context.Context, w http.ResponseWriter, r *http.Request) bool {
+	logger := log.Ctx(r.Context())
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return false
+	}
+
+	staffRow, err := queries.GetStaffByUserID(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+			return false
+		}
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load staff role")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to authorize request")
+		return false
+	}
+
+	access := authz.StaffAccess{Role: staffRow.Role}
+	if staffRow.HomeFacilityID.Valid {
+		homeFacilityID := staffRow.HomeFacilityID.Int64
+		access.HomeFacilityID = &homeFacilityID
+	}
+	if !authz.IsOrganizationAdmin(access) {
+		logger.Warn().Int64("user_id", user.ID).Str("role", staffRow.Role).Msg("Job access denied: not an admin")
+		apiutil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+		return false
+	}
+	return true
+}
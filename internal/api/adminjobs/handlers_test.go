@@ -0,0 +1,107 @@
+package adminjobs
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/jobs"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestAdminJobHandlers(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	testutil.ResetHandlers(t, &queriesOnce, &queries, &registry)
+	jobRegistry := jobs.NewRegistry(database.Queries)
+	InitHandlers(database.Queries, jobRegistry)
+
+	ran := make(chan struct{}, 1)
+	release := make(chan struct{})
+	if err := jobRegistry.Register("waitlist_cleanup", "0 * * * *", time.Minute, func(context.Context) (int64, error) {
+		ran <- struct{}{}
+		<-release
+		return 3, nil
+	}); err != nil {
+		t.Fatalf("register job: %v", err)
+	}
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.Exec(query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Org', 'org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main', 'main', 'UTC')", orgID)
+	staffUser := func(email string, home any, role string) int64 {
+		userID := exec("INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES ('Sam', 'Staff', ?, 'active', 1)", email)
+		exec("INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, 'Sam', 'Staff', ?, ?)", userID, home, role)
+		return userID
+	}
+	adminID := staffUser("admin@test.com", nil, "admin")
+	facilityAdminID := staffUser("facility-admin@test.com", facilityID, "admin")
+
+	call := func(method, target, name string, userID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, target, nil)
+		req.SetPathValue("name", name)
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: userID, IsStaff: true}))
+		recorder := httptest.NewRecorder()
+		if method == http.MethodGet {
+			HandleJobList(recorder, req)
+		} else {
+			HandleJobRun(recorder, req)
+		}
+		return recorder
+	}
+
+	if recorder := call(http.MethodGet, "/api/v1/admin/jobs", "", facilityAdminID); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected a facility admin to be refused, got %d", recorder.Code)
+	}
+	if recorder := call(http.MethodPost, "/api/v1/admin/jobs/unknown/run", "unknown", adminID); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown job, got %d", recorder.Code)
+	}
+
+	if recorder := call(http.MethodPost, "/api/v1/admin/jobs/waitlist_cleanup/run", "waitlist_cleanup", adminID); recorder.Code != http.StatusAccepted {
+		t.Fatalf("run status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	<-ran
+	if recorder := call(http.MethodPost, "/api/v1/admin/jobs/waitlist_cleanup/run", "waitlist_cleanup", adminID); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected 409 while the job is running, got %d", recorder.Code)
+	}
+	close(release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		recorder := call(http.MethodGet, "/api/v1/admin/jobs", "", adminID)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("list status %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var body jobListResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode list: %v", err)
+		}
+		if len(body.Jobs) != 1 {
+			t.Fatalf("expected one job, got %+v", body.Jobs)
+		}
+		if status := body.Jobs[0]; !status.Running && status.LastFinishedAt != nil {
+			if status.LastItems != 3 || status.LastTrigger != jobs.TriggerManual {
+				t.Fatalf("unexpected job status %+v", status)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the manual run to be recorded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
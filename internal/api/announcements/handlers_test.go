@@ -0,0 +1,168 @@
+package announcements
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestFacilityAnnouncements(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	testutil.ResetHandlers(t, &queriesOnce, &queries)
+	InitHandlers(database.Queries)
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('PicklePlex', 'pickleplex', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'North', 'north', 'America/New_York')", orgID)
+	otherFacilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'South', 'south', 'UTC')", orgID)
+
+	insertStaff := func(email, role string, homeFacilityID int64) *authz.AuthUser {
+		t.Helper()
+		userID := exec("INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES ('Sam', 'Staff', ?, 'active', 1)", email)
+		exec("INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, 'Sam', 'Staff', ?, ?)", userID, homeFacilityID, role)
+		return &authz.AuthUser{ID: userID, IsStaff: true, HomeFacilityID: &homeFacilityID}
+	}
+	manager := insertStaff("manager@test.com", "manager", facilityID)
+	desk := insertStaff("desk@test.com", "desk", facilityID)
+	otherManager := insertStaff("south@test.com", "manager", otherFacilityID)
+	memberID := exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES ('Mia', 'Member', 'member@test.com', 'active', 1, 1, 2, ?)`,
+		facilityID,
+	)
+	member := &authz.AuthUser{ID: memberID, HomeFacilityID: &facilityID, MembershipLevel: 2}
+
+	call := func(handler http.HandlerFunc, method string, user *authz.AuthUser, announcementID int64, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		target := fmt.Sprintf("/api/v1/facilities/%d/announcements", facilityID)
+		if announcementID != 0 {
+			target += fmt.Sprintf("/%d", announcementID)
+		}
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", fmt.Sprintf("%d", facilityID))
+		req.SetPathValue("announcementId", fmt.Sprintf("%d", announcementID))
+		req = req.WithContext(authz.ContextWithUser(req.Context(), user))
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+	expect := func(recorder *httptest.ResponseRecorder, status int, fragment string) {
+		t.Helper()
+		if recorder.Code != status || !strings.Contains(recorder.Body.String(), fragment) {
+			t.Fatalf("got %d %q, want %d containing %q", recorder.Code, recorder.Body.String(), status, fragment)
+		}
+	}
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load timezone: %v", err)
+	}
+	now := time.Now().In(loc).Truncate(time.Minute)
+	local := func(offset time.Duration) string {
+		return now.Add(offset).Format(announcementTimeLayout)
+	}
+	create := func(title, audience string, startOffset, endOffset time.Duration) int64 {
+		t.Helper()
+		body := fmt.Sprintf(`{"title":%q,"audience":%q,"startsAt":%q,"endsAt":%q}`, title, audience, local(startOffset), local(endOffset))
+		recorder := call(HandleAnnouncementCreate, http.MethodPost, manager, 0, body)
+		expect(recorder, http.StatusCreated, title)
+		var resp struct {
+			Announcement announcementResponse `json:"announcement"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode announcement: %v", err)
+		}
+		return resp.Announcement.ID
+	}
+
+	valid := fmt.Sprintf(`{"title":"Closed early","endsAt":%q}`, local(time.Hour))
+	expect(call(HandleAnnouncementCreate, http.MethodPost, desk, 0, valid), http.StatusForbidden, "Forbidden")
+	expect(call(HandleAnnouncementCreate, http.MethodPost, otherManager, 0, valid), http.StatusForbidden, "Forbidden")
+	expect(call(HandleAnnouncementCreate, http.MethodPost, manager, 0, `{"title":" ","endsAt":"2030-01-01T10:00"}`), http.StatusBadRequest, "title is required")
+	expect(call(HandleAnnouncementCreate, http.MethodPost, manager, 0, `{"title":"Hi","severity":"loud","endsAt":"2030-01-01T10:00"}`), http.StatusBadRequest, "severity")
+	expect(call(HandleAnnouncementCreate, http.MethodPost, manager, 0, `{"title":"Hi","audience":"everyone","endsAt":"2030-01-01T10:00"}`), http.StatusBadRequest, "audience")
+	expect(call(HandleAnnouncementCreate, http.MethodPost, manager, 0, `{"title":"Hi","startsAt":"2030-01-02T10:00","endsAt":"2030-01-01T10:00"}`), http.StatusBadRequest, "endsAt must be after startsAt")
+
+	tournamentID := create("Club tournament", "members", -time.Hour, 2*time.Hour)
+	closingID := create("Closing early", "both", -time.Hour, 2*time.Hour)
+	create("Staff meeting", "staff", -time.Hour, 2*time.Hour)
+	create("Already over", "members", -2*time.Hour, -time.Minute)
+	create("Next week", "members", 7*24*time.Hour, 8*24*time.Hour)
+
+	// Times are entered in facility time and stored in UTC.
+	var storedStart time.Time
+	if err := database.QueryRow("SELECT starts_at FROM facility_announcements WHERE id = ?", tournamentID).Scan(&storedStart); err != nil {
+		t.Fatalf("load starts_at: %v", err)
+	}
+	if !storedStart.Equal(now.Add(-time.Hour)) || storedStart.Location() != time.UTC {
+		t.Fatalf("stored starts_at %v, want %v in UTC", storedStart, now.Add(-time.Hour).UTC())
+	}
+
+	titles := func(user *authz.AuthUser, audience string) []string {
+		t.Helper()
+		banners, err := ActiveBanners(ctx, database.Queries, facilityID, user.ID, audience, MemberDismissBase)
+		if err != nil {
+			t.Fatalf("load banners: %v", err)
+		}
+		titles := make([]string, len(banners))
+		for i, banner := range banners {
+			titles[i] = banner.Title
+		}
+		return titles
+	}
+	if got := strings.Join(titles(member, AudienceMembers), ","); got != "Club tournament,Closing early" && got != "Closing early,Club tournament" {
+		t.Fatalf("member banners: %s", got)
+	}
+	if got := strings.Join(titles(desk, AudienceStaff), ","); got != "Closing early,Staff meeting" && got != "Staff meeting,Closing early" {
+		t.Fatalf("staff banners: %s", got)
+	}
+
+	dismiss := func(user *authz.AuthUser, announcementID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/member/announcements/%d/dismiss", announcementID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", announcementID))
+		req = req.WithContext(authz.ContextWithUser(req.Context(), user))
+		recorder := httptest.NewRecorder()
+		HandleAnnouncementDismiss(recorder, req)
+		return recorder
+	}
+	expect(dismiss(member, closingID), http.StatusOK, "")
+	expect(dismiss(member, closingID), http.StatusOK, "")
+	expect(dismiss(otherManager, closingID), http.StatusNotFound, "not found")
+	if got := strings.Join(titles(member, AudienceMembers), ","); got != "Club tournament" {
+		t.Fatalf("member banners after dismissal: %s", got)
+	}
+	if got := titles(desk, AudienceStaff); len(got) != 2 {
+		t.Fatalf("dismissal should be per user, staff sees %v", got)
+	}
+
+	expect(call(HandleAnnouncementUpdate, http.MethodPut, manager, tournamentID,
+		fmt.Sprintf(`{"title":"Club tournament moved","severity":"warning","startsAt":%q,"endsAt":%q}`, local(-time.Hour), local(time.Hour))),
+		http.StatusOK, `"severity":"warning"`)
+	expect(call(HandleAnnouncementGet, http.MethodGet, manager, tournamentID, ""), http.StatusOK, "Club tournament moved")
+	expect(call(HandleAnnouncementsList, http.MethodGet, manager, 0, ""), http.StatusOK, `"title":"Already over"`)
+	expect(call(HandleAnnouncementDelete, http.MethodDelete, manager, tournamentID, ""), http.StatusNoContent, "")
+	expect(call(HandleAnnouncementGet, http.MethodGet, manager, tournamentID, ""), http.StatusNotFound, "not found")
+	expect(call(HandleAnnouncementDelete, http.MethodDelete, otherManager, closingID, ""), http.StatusForbidden, "Forbidden")
+}
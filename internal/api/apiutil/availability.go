@@ -2,6 +2,7 @@ package apiutil
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strconv"
 	"strings"
@@ -10,7 +11,16 @@ import (
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 )
 
+// EnsureCourtsAvailable returns an AvailabilityError naming any of courtIDs
+// booked over [startTime, endTime), ignoring reservationID itself, or held
+// by a member finishing a booking.
 func EnsureCourtsAvailable(ctx context.Context, q *dbgen.Queries, facilityID, reservationID int64, startTime, endTime time.Time, courtIDs []int64) error {
+	return EnsureCourtsAvailableForHolder(ctx, q, facilityID, reservationID, 0, startTime, endTime, courtIDs)
+}
+
+// EnsureCourtsAvailableForHolder is EnsureCourtsAvailable for a booking by
+// holderUserID, whose own slot hold does not count as a conflict.
+func EnsureCourtsAvailableForHolder(ctx context.Context, q *dbgen.Queries, facilityID, reservationID, holderUserID int64, startTime, endTime time.Time, courtIDs []int64) error {
 	available, err := q.ListAvailableCourts(ctx, dbgen.ListAvailableCourtsParams{
 		FacilityID:    facilityID,
 		ReservationID: reservationID,
@@ -20,11 +30,24 @@ func EnsureCourtsAvailable(ctx context.Context, q *dbgen.Queries, facilityID, re
 	if err != nil {
 		return fmt.Errorf("availability check failed: %w", err)
 	}
+	held, err := q.ListHeldCourtIDs(ctx, dbgen.ListHeldCourtIDsParams{
+		FacilityID:   facilityID,
+		HolderUserID: holderUserID,
+		Now:          time.Now().UTC(),
+		EndTime:      endTime,
+		StartTime:    startTime,
+	})
+	if err != nil {
+		return fmt.Errorf("slot hold check failed: %w", err)
+	}
 
 	availableMap := make(map[int64]struct{}, len(available))
 	for _, court := range available {
 		availableMap[court.ID] = struct{}{}
 	}
+	for _, courtID := range held {
+		delete(availableMap, courtID)
+	}
 
 	var unavailable []string
 	for _, courtID := range courtIDs {
@@ -41,8 +64,235 @@ func EnsureCourtsAvailable(ctx context.Context, q *dbgen.Queries, facilityID, re
 
 type AvailabilityError struct {
 	Courts []string
+	// BufferMinutes is set when the courts are free but too close to another
+	// booking's turnover buffer.
+	BufferMinutes int64
 }
 
 func (e AvailabilityError) Error() string {
+	if e.BufferMinutes > 0 {
+		return fmt.Sprintf("courts unavailable: %s (a %d-minute buffer is required between bookings)", strings.Join(e.Courts, ", "), e.BufferMinutes)
+	}
 	return fmt.Sprintf("courts unavailable: %s", strings.Join(e.Courts, ", "))
 }
+
+// MaxBufferMinutes caps the turnover buffer a facility or reservation type
+// can set.
+const MaxBufferMinutes = 120
+
+// BufferedBooking is a booking checked against court turnover buffers.
+type BufferedBooking struct {
+	FacilityID int64
+	// ReservationID is the booking being edited, or zero for a new one.
+	ReservationID     int64
+	ReservationTypeID int64
+	RecurrenceRuleID  *int64
+	PrimaryUserID     *int64
+	StartTime         time.Time
+	EndTime           time.Time
+	CourtIDs          []int64
+}
+
+// EnsureCourtBuffers returns an AvailabilityError naming any of the booking's
+// courts whose neighbouring reservations leave less than the turnover buffer:
+// the buffer after an earlier reservation must end by the booking's start,
+// and the booking's own buffer by the next one's. Reservations in the same
+// recurring series (same recurrence rule, type and primary user) need no gap.
+// Plain overlaps are left to EnsureCourtsAvailable.
+func EnsureCourtBuffers(ctx context.Context, q *dbgen.Queries, booking BufferedBooking) error {
+	ownBuffer, err := q.GetReservationTypeBufferMinutes(ctx, dbgen.GetReservationTypeBufferMinutesParams{
+		ReservationTypeID: booking.ReservationTypeID,
+		FacilityID:        booking.FacilityID,
+	})
+	if err != nil {
+		return fmt.Errorf("buffer check failed: %w", err)
+	}
+	neighbors, err := q.ListCourtBufferNeighbors(ctx, dbgen.ListCourtBufferNeighborsParams{
+		FacilityID:    booking.FacilityID,
+		ReservationID: booking.ReservationID,
+		WindowEnd:     booking.EndTime.Add(time.Duration(ownBuffer) * time.Minute),
+		WindowStart:   booking.StartTime.Add(-MaxBufferMinutes * time.Minute),
+	})
+	if err != nil {
+		return fmt.Errorf("buffer check failed: %w", err)
+	}
+
+	wanted := make(map[int64]struct{}, len(booking.CourtIDs))
+	for _, courtID := range booking.CourtIDs {
+		wanted[courtID] = struct{}{}
+	}
+	blocked := make(map[int64]struct{})
+	var bufferMinutes int64
+	for _, neighbor := range neighbors {
+		if _, ok := wanted[neighbor.CourtID]; !ok || booking.sameSeries(neighbor) {
+			continue
+		}
+		neighborBuffer := time.Duration(neighbor.BufferMinutes) * time.Minute
+		if !neighbor.StartTime.Before(booking.EndTime.Add(time.Duration(ownBuffer)*time.Minute)) ||
+			!neighbor.EndTime.Add(neighborBuffer).After(booking.StartTime) {
+			continue
+		}
+		blocked[neighbor.CourtID] = struct{}{}
+		if neighbor.StartTime.Before(booking.StartTime) {
+			bufferMinutes = max(bufferMinutes, neighbor.BufferMinutes)
+		} else {
+			bufferMinutes = max(bufferMinutes, ownBuffer)
+		}
+	}
+
+	var unavailable []string
+	for _, courtID := range booking.CourtIDs {
+		if _, ok := blocked[courtID]; ok {
+			unavailable = append(unavailable, strconv.FormatInt(courtID, 10))
+		}
+	}
+	if len(unavailable) > 0 {
+		return AvailabilityError{Courts: unavailable, BufferMinutes: bufferMinutes}
+	}
+	return nil
+}
+
+func (b BufferedBooking) sameSeries(neighbor dbgen.ListCourtBufferNeighborsRow) bool {
+	return b.RecurrenceRuleID != nil && neighbor.RecurrenceRuleID.Valid &&
+		*b.RecurrenceRuleID == neighbor.RecurrenceRuleID.Int64 &&
+		b.ReservationTypeID == neighbor.ReservationTypeID &&
+		b.PrimaryUserID != nil && neighbor.PrimaryUserID.Valid &&
+		*b.PrimaryUserID == neighbor.PrimaryUserID.Int64
+}
+
+// CourtBlocksQuerier loads every active court with the blocks overlapping a
+// time range in one query.
+type CourtBlocksQuerier interface {
+	ListCourtBlocksForDay(ctx context.Context, arg dbgen.ListCourtBlocksForDayParams) ([]dbgen.ListCourtBlocksForDayRow, error)
+}
+
+// DayCourtAvailability answers per-slot availability for a day from a single
+// ListCourtBlocksForDay result.
+type DayCourtAvailability struct {
+	courts []dayCourt
+	// buffer follows each slot, so it must clear the next block.
+	buffer time.Duration
+}
+
+type dayCourt struct {
+	id        int64
+	number    int64
+	isIndoor  bool
+	surface   sql.NullString
+	hasLights bool
+	blocks    []dbgen.ListCourtBlocksForDayRow
+}
+
+// SlotCourtAvailability summarizes one slot: which active courts are free
+// and which are closed for maintenance.
+type SlotCourtAvailability struct {
+	AvailableCourts int
+	TotalCourts     int
+	// FreeCourtIDs lists the free courts in court number order.
+	FreeCourtIDs []int64
+	Closures     []CourtClosure
+}
+
+// CourtClosure groups courts closed by maintenance blocks with the same
+// public reason and end time. PublicReason may be empty.
+type CourtClosure struct {
+	CourtNumbers []int64
+	PublicReason string
+	Until        time.Time
+}
+
+// LoadDayCourtAvailability loads the blocks for [rangeStart, rangeEnd),
+// widened by MaxBufferMinutes so turnover buffers reaching into the range
+// are seen.
+func LoadDayCourtAvailability(ctx context.Context, q CourtBlocksQuerier, facilityID int64, rangeStart, rangeEnd time.Time) (DayCourtAvailability, error) {
+	rows, err := q.ListCourtBlocksForDay(ctx, dbgen.ListCourtBlocksForDayParams{
+		FacilityID: facilityID,
+		RangeStart: rangeStart.Add(-MaxBufferMinutes * time.Minute),
+		RangeEnd:   rangeEnd.Add(MaxBufferMinutes * time.Minute),
+	})
+	if err != nil {
+		return DayCourtAvailability{}, fmt.Errorf("load court blocks: %w", err)
+	}
+
+	var day DayCourtAvailability
+	index := make(map[int64]int)
+	for _, row := range rows {
+		day.buffer = time.Duration(row.FacilityBufferMinutes) * time.Minute
+		i, ok := index[row.CourtID]
+		if !ok {
+			i = len(day.courts)
+			index[row.CourtID] = i
+			day.courts = append(day.courts, dayCourt{
+				id:        row.CourtID,
+				number:    row.CourtNumber,
+				isIndoor:  row.IsIndoor,
+				surface:   row.Surface,
+				hasLights: row.HasLights,
+			})
+		}
+		if row.BlockStartTime.Valid && row.BlockEndTime.Valid {
+			day.courts[i].blocks = append(day.courts[i].blocks, row)
+		}
+	}
+	return day, nil
+}
+
+// Filter keeps only the courts matching filter, so slots count just those.
+func (d DayCourtAvailability) Filter(filter CourtFilter) DayCourtAvailability {
+	if filter.IsZero() {
+		return d
+	}
+	filtered := DayCourtAvailability{buffer: d.buffer}
+	for _, court := range d.courts {
+		if filter.Matches(court.isIndoor, court.surface, court.hasLights) {
+			filtered.courts = append(filtered.courts, court)
+		}
+	}
+	return filtered
+}
+
+// Slot computes availability for [start, end) without touching the database.
+// A court is busy when a block overlaps the slot or the turnover buffer
+// between them is too short.
+func (d DayCourtAvailability) Slot(start, end time.Time) SlotCourtAvailability {
+	slot := SlotCourtAvailability{TotalCourts: len(d.courts)}
+	for _, court := range d.courts {
+		busy := false
+		var closure *dbgen.ListCourtBlocksForDayRow
+		for i := range court.blocks {
+			block := &court.blocks[i]
+			blockBuffer := time.Duration(block.BlockBufferMinutes) * time.Minute
+			if !block.BlockStartTime.Time.Before(end.Add(d.buffer)) || !block.BlockEndTime.Time.Add(blockBuffer).After(start) {
+				continue
+			}
+			busy = true
+			overlaps := block.BlockStartTime.Time.Before(end) && block.BlockEndTime.Time.After(start)
+			if overlaps && closure == nil && block.BlockReservationType.String == "MAINTENANCE" {
+				closure = block
+			}
+		}
+		if !busy {
+			slot.AvailableCourts++
+			slot.FreeCourtIDs = append(slot.FreeCourtIDs, court.id)
+			continue
+		}
+		if closure != nil {
+			slot.addClosure(court.number, strings.TrimSpace(closure.BlockPublicReason.String), closure.BlockEndTime.Time)
+		}
+	}
+	return slot
+}
+
+func (s *SlotCourtAvailability) addClosure(courtNumber int64, reason string, until time.Time) {
+	for i := range s.Closures {
+		if s.Closures[i].PublicReason == reason && s.Closures[i].Until.Equal(until) {
+			s.Closures[i].CourtNumbers = append(s.Closures[i].CourtNumbers, courtNumber)
+			return
+		}
+	}
+	s.Closures = append(s.Closures, CourtClosure{
+		CourtNumbers: []int64{courtNumber},
+		PublicReason: reason,
+		Until:        until,
+	})
+}
@@ -0,0 +1,111 @@
+package apiutil
+
+import (
+	"fmt"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// DefaultBookingSlotMinutes is the hour-long slot every facility used before
+// slot sizes became configurable.
+const DefaultBookingSlotMinutes int64 = 60
+
+const (
+	// Booking slot settings are whole quarter hours so slot grids line up
+	// with the time pickers.
+	BookingMinutesStep     = 15
+	MaxSlotDurationMinutes = 240
+	MaxMinBookingMinutes   = 480
+
+	// MaxGuestsPerReservation bounds a facility's guest cap.
+	MaxGuestsPerReservation = 20
+)
+
+// BookingGranularity is how a facility carves its day into bookable slots.
+type BookingGranularity struct {
+	// Slot is the step between bookable start times.
+	Slot time.Duration
+	// MinBooking is the shortest reservation the facility accepts.
+	MinBooking time.Duration
+}
+
+// FacilityBookingGranularity reads the facility's slot settings. A nil
+// facility or an unset value falls back to DefaultBookingSlotMinutes.
+func FacilityBookingGranularity(facility *dbgen.Facility) BookingGranularity {
+	slotMinutes, minBookingMinutes := DefaultBookingSlotMinutes, DefaultBookingSlotMinutes
+	if facility != nil {
+		if facility.SlotDurationMinutes > 0 {
+			slotMinutes = facility.SlotDurationMinutes
+		}
+		if facility.MinBookingMinutes > 0 {
+			minBookingMinutes = facility.MinBookingMinutes
+		}
+	}
+	return BookingGranularity{
+		Slot:       time.Duration(slotMinutes) * time.Minute,
+		MinBooking: time.Duration(minBookingMinutes) * time.Minute,
+	}
+}
+
+// SlotLength is how long each offered slot lasts: the minimum booking
+// rounded up to whole slots, so a 30-minute grid with a 60-minute minimum
+// offers hour-long slots every half hour.
+func (g BookingGranularity) SlotLength() time.Duration {
+	if g.Slot <= 0 {
+		return g.MinBooking
+	}
+	slots := (g.MinBooking + g.Slot - 1) / g.Slot
+	if slots < 1 {
+		slots = 1
+	}
+	return slots * g.Slot
+}
+
+// FormatBookingDuration renders a duration for validation messages, e.g.
+// "1 hour", "2 hours", or "90 minutes".
+func FormatBookingDuration(d time.Duration) string {
+	minutes := int64(d / time.Minute)
+	switch {
+	case minutes == 60:
+		return "1 hour"
+	case minutes > 0 && minutes%60 == 0:
+		return fmt.Sprintf("%d hours", minutes/60)
+	default:
+		return fmt.Sprintf("%d minutes", minutes)
+	}
+}
+
+// ReservationDuration bounds how long a reservation may run. A zero Max
+// leaves the length unbounded.
+type ReservationDuration struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// ReservationTypeDuration reads resType's duration bounds. A type without a
+// minimum takes the facility's minimum booking. Either may be nil.
+func ReservationTypeDuration(facility *dbgen.Facility, resType *dbgen.ReservationType) ReservationDuration {
+	bounds := ReservationDuration{Min: FacilityBookingGranularity(facility).MinBooking}
+	if resType != nil {
+		if resType.MinDurationMinutes.Valid && resType.MinDurationMinutes.Int64 > 0 {
+			bounds.Min = time.Duration(resType.MinDurationMinutes.Int64) * time.Minute
+		}
+		if resType.MaxDurationMinutes.Valid && resType.MaxDurationMinutes.Int64 > 0 {
+			bounds.Max = time.Duration(resType.MaxDurationMinutes.Int64) * time.Minute
+		}
+	}
+	return bounds
+}
+
+// Violation describes how a reservation of length d breaks the bounds, e.g.
+// "at most 1 hour", or returns "" when it fits.
+func (b ReservationDuration) Violation(d time.Duration) string {
+	switch {
+	case d < b.Min:
+		return fmt.Sprintf("at least %s", FormatBookingDuration(b.Min))
+	case b.Max > 0 && d > b.Max:
+		return fmt.Sprintf("at most %s", FormatBookingDuration(b.Max))
+	}
+	return ""
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"sort"
+	"time"
 
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 )
@@ -23,3 +25,81 @@ func ApplicableRefundPercentage(ctx context.Context, q *dbgen.Queries, facilityI
 	}
 	return tier.RefundPercentage, nil
 }
+
+// CancellationTiers resolves refund percentages from tiers preloaded for a set
+// of facilities, matching ApplicableRefundPercentage without a query per
+// reservation.
+type CancellationTiers struct {
+	byKey map[cancellationTierKey][]dbgen.CancellationPolicyTier
+}
+
+// cancellationTierKey groups tiers by facility and reservation type; a zero
+// reservationTypeID holds the facility's default tiers.
+type cancellationTierKey struct {
+	facilityID        int64
+	reservationTypeID int64
+}
+
+// LoadCancellationTiers loads every cancellation tier for the given facilities
+// in one query.
+func LoadCancellationTiers(ctx context.Context, q *dbgen.Queries, facilityIDs []int64) (CancellationTiers, error) {
+	tiers := CancellationTiers{byKey: make(map[cancellationTierKey][]dbgen.CancellationPolicyTier)}
+	if len(facilityIDs) == 0 {
+		return tiers, nil
+	}
+	rows, err := q.ListCancellationPolicyTiersForFacilities(ctx, facilityIDs)
+	if err != nil {
+		return CancellationTiers{}, err
+	}
+	// Rows arrive ordered by min_hours_before descending within each facility.
+	for _, row := range rows {
+		key := cancellationTierKey{facilityID: row.FacilityID, reservationTypeID: row.ReservationTypeID.Int64}
+		tiers.byKey[key] = append(tiers.byKey[key], row)
+	}
+	return tiers, nil
+}
+
+// RefundPercentage prefers a type-specific tier over the facility default, then
+// the highest hours threshold that applies, and falls back to a full refund.
+func (t CancellationTiers) RefundPercentage(facilityID int64, hoursUntilReservation int64, reservationTypeID *int64) int64 {
+	keys := []cancellationTierKey{{facilityID: facilityID}}
+	if reservationTypeID != nil {
+		keys = append([]cancellationTierKey{{facilityID: facilityID, reservationTypeID: *reservationTypeID}}, keys...)
+	}
+	for _, key := range keys {
+		for _, tier := range t.byKey[key] {
+			if tier.MinHoursBefore <= hoursUntilReservation {
+				return tier.RefundPercentage
+			}
+		}
+	}
+	return 100
+}
+
+// NextRefundDrop returns when the refund for a reservation starting at start
+// next falls below what it is hoursUntilReservation out, and what it falls to.
+// The refund holds until at and drops just after it. ok is false when it
+// stays the same until the reservation starts.
+func (t CancellationTiers) NextRefundDrop(facilityID int64, start time.Time, hoursUntilReservation int64, reservationTypeID *int64) (at time.Time, refundPercentage int64, ok bool) {
+	current := t.RefundPercentage(facilityID, hoursUntilReservation, reservationTypeID)
+	keys := []cancellationTierKey{{facilityID: facilityID}}
+	if reservationTypeID != nil {
+		keys = append(keys, cancellationTierKey{facilityID: facilityID, reservationTypeID: *reservationTypeID})
+	}
+	var thresholds []int64
+	for _, key := range keys {
+		for _, tier := range t.byKey[key] {
+			if tier.MinHoursBefore > 0 && tier.MinHoursBefore <= hoursUntilReservation {
+				thresholds = append(thresholds, tier.MinHoursBefore)
+			}
+		}
+	}
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i] > thresholds[j] })
+	for _, threshold := range thresholds {
+		next := t.RefundPercentage(facilityID, threshold-1, reservationTypeID)
+		if next < current {
+			return start.Add(-time.Duration(threshold) * time.Hour), next, true
+		}
+	}
+	return time.Time{}, 0, false
+}
@@ -0,0 +1,51 @@
+package apiutil
+
+import (
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestCancellationTiersNextRefundDrop(t *testing.T) {
+	const facilityID = 1
+	tiers := CancellationTiers{byKey: map[cancellationTierKey][]dbgen.CancellationPolicyTier{
+		{facilityID: facilityID}: {
+			{FacilityID: facilityID, MinHoursBefore: 48, RefundPercentage: 100},
+			{FacilityID: facilityID, MinHoursBefore: 24, RefundPercentage: 100},
+			{FacilityID: facilityID, MinHoursBefore: 12, RefundPercentage: 50},
+			{FacilityID: facilityID, MinHoursBefore: 0, RefundPercentage: 0},
+		},
+	}}
+	start := time.Date(2026, time.October, 22, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		hours   int64
+		wantAt  time.Time
+		wantPct int64
+		wantOK  bool
+	}{
+		{name: "skips tiers with the same refund", hours: 60, wantAt: start.Add(-24 * time.Hour), wantPct: 50, wantOK: true},
+		{name: "partial refund drops to none", hours: 20, wantAt: start.Add(-12 * time.Hour), wantPct: 0, wantOK: true},
+		{name: "last tier has no deadline", hours: 5, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, pct, ok := tiers.NextRefundDrop(facilityID, start, tt.hours, nil)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if !at.Equal(tt.wantAt) || pct != tt.wantPct {
+				t.Fatalf("got %s at %d%%, want %s at %d%%", at, pct, tt.wantAt, tt.wantPct)
+			}
+		})
+	}
+
+	if _, _, ok := tiers.NextRefundDrop(2, start, 60, nil); ok {
+		t.Fatal("expected no deadline for a facility without tiers")
+	}
+}
@@ -0,0 +1,109 @@
+package apiutil
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CourtSurfaces lists the values courts.surface accepts, in the order filters
+// offer them.
+var CourtSurfaces = []string{"hard", "cushioned", "sport_tile", "wood", "clay", "turf"}
+
+// CourtSurfaceLabel renders a surface value for display, e.g. "Sport tile".
+func CourtSurfaceLabel(surface string) string {
+	switch surface {
+	case "hard":
+		return "Hard"
+	case "cushioned":
+		return "Cushioned"
+	case "sport_tile":
+		return "Sport tile"
+	case "wood":
+		return "Wood"
+	case "clay":
+		return "Clay"
+	case "turf":
+		return "Turf"
+	default:
+		return surface
+	}
+}
+
+// CourtAttributesLabel summarizes a court's attributes, e.g.
+// "Indoor, Sport tile, Lights". An unknown surface is left out.
+func CourtAttributesLabel(isIndoor bool, surface sql.NullString, hasLights bool) string {
+	parts := []string{"Outdoor"}
+	if isIndoor {
+		parts[0] = "Indoor"
+	}
+	if surface.Valid && surface.String != "" {
+		parts = append(parts, CourtSurfaceLabel(surface.String))
+	}
+	if hasLights {
+		parts = append(parts, "Lights")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CourtFilter narrows courts by attribute. Nil and empty fields match any
+// court.
+type CourtFilter struct {
+	Indoor  *bool
+	Surface string
+	Lights  *bool
+}
+
+// IsZero reports whether the filter matches every court.
+func (f CourtFilter) IsZero() bool {
+	return f.Indoor == nil && f.Surface == "" && f.Lights == nil
+}
+
+// Matches reports whether a court with the given attributes passes the
+// filter. Courts with an unknown surface never match a surface filter.
+func (f CourtFilter) Matches(isIndoor bool, surface sql.NullString, hasLights bool) bool {
+	if f.Indoor != nil && *f.Indoor != isIndoor {
+		return false
+	}
+	if f.Surface != "" && (!surface.Valid || surface.String != f.Surface) {
+		return false
+	}
+	if f.Lights != nil && *f.Lights != hasLights {
+		return false
+	}
+	return true
+}
+
+// ParseCourtFilter reads the indoor, surface and lights query parameters.
+// Blank values leave that attribute unfiltered.
+func ParseCourtFilter(values url.Values) (CourtFilter, error) {
+	var filter CourtFilter
+	var err error
+	if filter.Indoor, err = parseCourtFilterBool(values.Get("indoor"), "indoor"); err != nil {
+		return CourtFilter{}, err
+	}
+	if filter.Lights, err = parseCourtFilterBool(values.Get("lights"), "lights"); err != nil {
+		return CourtFilter{}, err
+	}
+	surface := strings.ToLower(strings.TrimSpace(values.Get("surface")))
+	if surface != "" && !slices.Contains(CourtSurfaces, surface) {
+		return CourtFilter{}, fmt.Errorf("surface must be one of %s", strings.Join(CourtSurfaces, ", "))
+	}
+	filter.Surface = surface
+	return filter, nil
+}
+
+func parseCourtFilterBool(raw string, field string) (*bool, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be true or false", field)
+	}
+	return &value, nil
+}
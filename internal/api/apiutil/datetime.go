@@ -0,0 +1,71 @@
+package apiutil
+
+import (
+	"strings"
+	"time"
+)
+
+// wallClockLayouts are the datetime layouts ParseFlexibleTime reads as
+// wall-clock times in the facility's location: datetime-local inputs and the
+// staff "YYYY-MM-DD HH:MM" form, each with optional seconds.
+var wallClockLayouts = []string{
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+}
+
+// ParseFlexibleTime parses a datetime submitted by a form or API client and
+// returns it in loc. RFC 3339 values keep the instant their offset names;
+// values without an offset are wall-clock times in loc. A wall-clock time
+// skipped by a DST change moves forward by the gap, and one repeated by a
+// change resolves to the first occurrence. Errors are FieldErrors for field.
+func ParseFlexibleTime(value, field string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, FieldError{Field: field, Reason: "is required"}
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed.In(loc), nil
+	}
+	for _, layout := range wallClockLayouts {
+		if wall, err := time.Parse(layout, value); err == nil {
+			return inLocation(wall, loc), nil
+		}
+	}
+	return time.Time{}, FieldError{Field: field, Reason: "must be an RFC 3339 timestamp or in YYYY-MM-DDTHH:MM format"}
+}
+
+// inLocation places the wall clock of wall, parsed in UTC, in loc. Go's own
+// choice for a wall clock near a DST change varies by zone, so each offset in
+// effect within a day either side is tried: the earliest instant that shows
+// the wall clock wins, and a wall clock no instant shows is read with the
+// offset from before the change.
+func inLocation(wall time.Time, loc *time.Location) time.Time {
+	approx := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, loc)
+	var (
+		resolved time.Time
+		found    bool
+	)
+	for _, probe := range []time.Time{approx.Add(-24 * time.Hour), approx, approx.Add(24 * time.Hour)} {
+		_, offset := probe.Zone()
+		candidate := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if !sameWallClock(candidate, wall) || (found && !candidate.Before(resolved)) {
+			continue
+		}
+		resolved, found = candidate, true
+	}
+	if found {
+		return resolved
+	}
+	_, before := approx.Add(-24 * time.Hour).Zone()
+	return wall.Add(-time.Duration(before) * time.Second).In(loc)
+}
+
+func sameWallClock(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	ah, amin, as := a.Clock()
+	bh, bmin, bs := b.Clock()
+	return ay == by && am == bm && ad == bd && ah == bh && amin == bmin && as == bs
+}
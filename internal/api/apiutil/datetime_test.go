@@ -0,0 +1,98 @@
+package apiutil
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseFlexibleTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		loc     *time.Location
+		wantUTC string
+		wantErr string
+	}{
+		{name: "datetime-local", value: "2026-07-04T09:30", loc: newYork, wantUTC: "2026-07-04T13:30:00Z"},
+		{name: "datetime-local with seconds", value: "2026-07-04T09:30:15", loc: newYork, wantUTC: "2026-07-04T13:30:15Z"},
+		{name: "space separated", value: "2026-07-04 09:30", loc: newYork, wantUTC: "2026-07-04T13:30:00Z"},
+		{name: "space separated with seconds", value: "2026-07-04 09:30:15", loc: newYork, wantUTC: "2026-07-04T13:30:15Z"},
+		{name: "surrounding whitespace", value: "  2026-07-04T09:30\n", loc: newYork, wantUTC: "2026-07-04T13:30:00Z"},
+		{name: "winter wall clock", value: "2026-01-15T09:30", loc: newYork, wantUTC: "2026-01-15T14:30:00Z"},
+		{name: "utc location", value: "2026-07-04T09:30", loc: time.UTC, wantUTC: "2026-07-04T09:30:00Z"},
+		{name: "rfc3339 utc", value: "2026-07-04T13:30:00Z", loc: newYork, wantUTC: "2026-07-04T13:30:00Z"},
+		{name: "rfc3339 offset honored", value: "2026-07-04T09:30:00-07:00", loc: newYork, wantUTC: "2026-07-04T16:30:00Z"},
+		{name: "rfc3339 fractional seconds", value: "2026-07-04T13:30:00.250Z", loc: newYork, wantUTC: "2026-07-04T13:30:00.25Z"},
+		{name: "before spring forward", value: "2026-03-08T01:59", loc: newYork, wantUTC: "2026-03-08T06:59:00Z"},
+		{name: "spring forward gap moves forward", value: "2026-03-08T02:30", loc: newYork, wantUTC: "2026-03-08T07:30:00Z"},
+		{name: "after spring forward", value: "2026-03-08 03:00", loc: newYork, wantUTC: "2026-03-08T07:00:00Z"},
+		{name: "fall back repeated hour is first occurrence", value: "2026-11-01T01:30", loc: newYork, wantUTC: "2026-11-01T05:30:00Z"},
+		{name: "fall back second occurrence by offset", value: "2026-11-01T01:30:00-05:00", loc: newYork, wantUTC: "2026-11-01T06:30:00Z"},
+		{name: "after fall back", value: "2026-11-01 02:00", loc: newYork, wantUTC: "2026-11-01T07:00:00Z"},
+		{name: "southern hemisphere gap", value: "2026-10-04T02:30", loc: sydney, wantUTC: "2026-10-03T16:30:00Z"},
+		{name: "southern hemisphere repeated hour", value: "2026-04-05T02:30", loc: sydney, wantUTC: "2026-04-04T15:30:00Z"},
+		{name: "empty", value: "", loc: newYork, wantErr: "start_time is required"},
+		{name: "blank", value: "   ", loc: newYork, wantErr: "start_time is required"},
+		{name: "date only", value: "2026-07-04", loc: newYork, wantErr: "start_time must be an RFC 3339 timestamp or in YYYY-MM-DDTHH:MM format"},
+		{name: "twelve hour clock", value: "2026-07-04 9:30 AM", loc: newYork, wantErr: "start_time must be an RFC 3339 timestamp or in YYYY-MM-DDTHH:MM format"},
+		{name: "out of range", value: "2026-02-30T09:30", loc: newYork, wantErr: "start_time must be an RFC 3339 timestamp or in YYYY-MM-DDTHH:MM format"},
+		{name: "offset without seconds", value: "2026-07-04T09:30-04:00", loc: newYork, wantErr: "start_time must be an RFC 3339 timestamp or in YYYY-MM-DDTHH:MM format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFlexibleTime(tt.value, "start_time", tt.loc)
+			if tt.wantErr != "" {
+				var fieldErr FieldError
+				if !errors.As(err, &fieldErr) || fieldErr.Field != "start_time" || err.Error() != tt.wantErr {
+					t.Fatalf("error %v, want field error %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Location() != tt.loc {
+				t.Fatalf("location %s, want %s", got.Location(), tt.loc)
+			}
+			if utc := got.UTC().Format(time.RFC3339Nano); utc != tt.wantUTC {
+				t.Fatalf("parsed %s (%s), want %s", utc, got, tt.wantUTC)
+			}
+		})
+	}
+}
+
+func TestParseFlexibleTime_WallClockRoundTrips(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	// Every quarter hour across both 2026 transitions, outside the skipped
+	// hour, parses back to the instant it was formatted from.
+	for _, day := range []time.Time{
+		time.Date(2026, time.March, 8, 0, 0, 0, 0, loc),
+		time.Date(2026, time.November, 1, 0, 0, 0, 0, loc),
+	} {
+		for value := day; value.Before(day.AddDate(0, 0, 1)); value = value.Add(15 * time.Minute) {
+			for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04"} {
+				formatted := value.Format(layout)
+				got, err := ParseFlexibleTime(formatted, "start_time", loc)
+				if err != nil {
+					t.Fatalf("%s: %v", formatted, err)
+				}
+				if got.Format(layout) != formatted {
+					t.Fatalf("%s parsed to %s", formatted, got)
+				}
+			}
+		}
+	}
+}
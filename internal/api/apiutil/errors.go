@@ -0,0 +1,146 @@
+package apiutil
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Machine-readable codes for the error envelope. Codes not tied to a specific
+// failure come from the status via ErrorCodeForStatus.
+const (
+	ErrorCodeBadRequest              = "bad_request"
+	ErrorCodeValidationFailed        = "validation_failed"
+	ErrorCodeUnauthorized            = "unauthorized"
+	ErrorCodeForbidden               = "forbidden"
+	ErrorCodeNotFound                = "not_found"
+	ErrorCodeMethodNotAllowed        = "method_not_allowed"
+	ErrorCodeConflict                = "conflict"
+	ErrorCodeCourtUnavailable        = "court_unavailable"
+	ErrorCodeReservationLimitReached = "reservation_limit_reached"
+	ErrorCodeReservationOverlap      = "reservation_overlap"
+	ErrorCodeMemberOverlap           = "member_overlap"
+	ErrorCodeRateLimited             = "rate_limited"
+	ErrorCodeInternal                = "internal_error"
+)
+
+// ErrorBody describes a failed JSON API request. It is sent wrapped as
+// {"error": {...}}.
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+	Details any    `json:"details,omitempty"`
+}
+
+// ErrorEnvelope is the JSON body of every API error response.
+type ErrorEnvelope struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorCoder is implemented by errors that carry their own envelope code.
+type ErrorCoder interface {
+	ErrorCode() string
+}
+
+// ErrorCodeForStatus is the default code for an HTTP status.
+func ErrorCodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrorCodeMethodNotAllowed
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusUnprocessableEntity:
+		return ErrorCodeValidationFailed
+	case http.StatusTooManyRequests:
+		return ErrorCodeRateLimited
+	}
+	if status >= http.StatusInternalServerError {
+		return ErrorCodeInternal
+	}
+	return ErrorCodeBadRequest
+}
+
+// NewErrorBody builds the envelope body for message, taking the code from err
+// when it is (or wraps) a FieldError, AvailabilityError or ErrorCoder and from
+// status otherwise. err may be nil.
+func NewErrorBody(status int, message string, err error) ErrorBody {
+	body := ErrorBody{Code: ErrorCodeForStatus(status), Message: message}
+	if err == nil {
+		return body
+	}
+	var fieldErr FieldError
+	var availErr AvailabilityError
+	var coder ErrorCoder
+	switch {
+	case errors.As(err, &fieldErr):
+		body.Code = ErrorCodeValidationFailed
+		body.Field = fieldErr.Field
+	case errors.As(err, &availErr):
+		body.Code = ErrorCodeCourtUnavailable
+		details := map[string]any{"courts": availErr.Courts}
+		if availErr.BufferMinutes > 0 {
+			details["buffer_minutes"] = availErr.BufferMinutes
+		}
+		body.Details = details
+	case errors.As(err, &coder):
+		body.Code = coder.ErrorCode()
+	}
+	return body
+}
+
+// WantsPlainError reports whether an error response should stay plain text:
+// HTMX swaps the body into the page and browsers display it as-is, so only
+// API clients get the JSON envelope.
+func WantsPlainError(r *http.Request) bool {
+	if IsHTMXRequest(r) {
+		return true
+	}
+	accept := strings.ToLower(r.Header.Get("Accept"))
+	return strings.Contains(accept, "text/html") && !strings.Contains(accept, "application/json")
+}
+
+// WriteError answers with the error envelope, or with a plain-text message
+// for HTMX and browser requests. The code comes from status.
+func WriteError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	WriteErrorBody(w, r, status, NewErrorBody(status, message, nil))
+}
+
+// WriteErrorBody answers with body in the error envelope, or with its message
+// as plain text for HTMX and browser requests.
+func WriteErrorBody(w http.ResponseWriter, r *http.Request, status int, body ErrorBody) {
+	if WantsPlainError(r) {
+		http.Error(w, body.Message, status)
+		return
+	}
+	if err := WriteJSON(w, status, ErrorEnvelope{Error: body}); err != nil {
+		log.Ctx(r.Context()).Error().Err(err).Str("code", body.Code).Msg("Failed to write error response")
+	}
+}
+
+// WriteValidationError answers 400 with err's message. A FieldError is
+// reported as validation_failed naming the field; any other error is a
+// validation failure without one.
+func WriteValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	body := NewErrorBody(http.StatusBadRequest, err.Error(), err)
+	if body.Code == ErrorCodeBadRequest {
+		body.Code = ErrorCodeValidationFailed
+	}
+	WriteErrorBody(w, r, http.StatusBadRequest, body)
+}
+
+// WriteHandlerError answers with a HandlerError's status and message, coding
+// it from the wrapped error when there is one.
+func WriteHandlerError(w http.ResponseWriter, r *http.Request, herr HandlerError) {
+	WriteErrorBody(w, r, herr.Status, NewErrorBody(herr.Status, herr.Message, herr.Err))
+}
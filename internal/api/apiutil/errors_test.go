@@ -0,0 +1,138 @@
+package apiutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type codedError struct{}
+
+func (codedError) Error() string     { return "limit reached" }
+func (codedError) ErrorCode() string { return ErrorCodeReservationLimitReached }
+
+func TestWriteErrorEnvelope(t *testing.T) {
+	tests := []struct {
+		name   string
+		write  func(w http.ResponseWriter, r *http.Request)
+		status int
+		want   ErrorBody
+	}{
+		{
+			name: "code from status",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				WriteError(w, r, http.StatusNotFound, "League not found")
+			},
+			status: http.StatusNotFound,
+			want:   ErrorBody{Code: ErrorCodeNotFound, Message: "League not found"},
+		},
+		{
+			name: "server errors",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				WriteError(w, r, http.StatusServiceUnavailable, "Try again")
+			},
+			status: http.StatusServiceUnavailable,
+			want:   ErrorBody{Code: ErrorCodeInternal, Message: "Try again"},
+		},
+		{
+			name: "field error names the field",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				WriteValidationError(w, r, FieldError{Field: "start_time", Reason: "is required"})
+			},
+			status: http.StatusBadRequest,
+			want:   ErrorBody{Code: ErrorCodeValidationFailed, Message: "start_time is required", Field: "start_time"},
+		},
+		{
+			name: "plain validation error",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				WriteValidationError(w, r, errors.New("end_time must be after start_time"))
+			},
+			status: http.StatusBadRequest,
+			want:   ErrorBody{Code: ErrorCodeValidationFailed, Message: "end_time must be after start_time"},
+		},
+		{
+			name: "wrapped availability error",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				WriteHandlerError(w, r, HandlerError{
+					Status:  http.StatusConflict,
+					Message: "Court unavailable",
+					Err:     fmt.Errorf("book: %w", AvailabilityError{Courts: []string{"Court 2"}}),
+				})
+			},
+			status: http.StatusConflict,
+			want: ErrorBody{
+				Code:    ErrorCodeCourtUnavailable,
+				Message: "Court unavailable",
+				Details: map[string]any{"courts": []any{"Court 2"}},
+			},
+		},
+		{
+			name: "error with its own code",
+			write: func(w http.ResponseWriter, r *http.Request) {
+				WriteHandlerError(w, r, HandlerError{Status: http.StatusConflict, Message: "Limit reached", Err: codedError{}})
+			},
+			status: http.StatusConflict,
+			want:   ErrorBody{Code: ErrorCodeReservationLimitReached, Message: "Limit reached"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/reservations", nil)
+			req.Header.Set("Accept", "application/json")
+			recorder := httptest.NewRecorder()
+			tt.write(recorder, req)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status %d, want %d", recorder.Code, tt.status)
+			}
+			if ct := recorder.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+				t.Fatalf("Content-Type %q, want JSON", ct)
+			}
+			var raw map[string]json.RawMessage
+			if err := json.Unmarshal(recorder.Body.Bytes(), &raw); err != nil {
+				t.Fatalf("decode envelope: %v", err)
+			}
+			if len(raw) != 1 || raw["error"] == nil {
+				t.Fatalf("envelope should only hold \"error\": %s", recorder.Body.String())
+			}
+			var got ErrorBody
+			if err := json.Unmarshal(raw["error"], &got); err != nil {
+				t.Fatalf("decode error body: %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteErrorStaysPlainForHTML(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		value  string
+	}{
+		{name: "htmx", header: "HX-Request", value: "true"},
+		{name: "browser", header: "Accept", value: "text/html,application/xhtml+xml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/member/reservations", nil)
+			req.Header.Set(tt.header, tt.value)
+			recorder := httptest.NewRecorder()
+			WriteValidationError(recorder, req, FieldError{Field: "start_time", Reason: "is required"})
+
+			if recorder.Code != http.StatusBadRequest {
+				t.Fatalf("status %d, want 400", recorder.Code)
+			}
+			if body := strings.TrimSpace(recorder.Body.String()); body != "start_time is required" {
+				t.Fatalf("body %q, want plain message", body)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package apiutil
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,6 +18,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 )
 
 type FieldError struct {
@@ -105,6 +107,46 @@ func RequireFacilityAccess(w http.ResponseWriter, r *http.Request, facilityID in
 	return true
 }
 
+// RequireOrganizationAdmin lets org admins through. Requests routed to an
+// organization's subdomain may only reach that organization.
+func RequireOrganizationAdmin(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries, organizationID int64) bool {
+	logger := log.Ctx(r.Context())
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+
+	staffRow, err := q.GetStaffByUserID(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn().Int64("user_id", user.ID).Int64("organization_id", organizationID).Msg("Organization access denied: not staff")
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return false
+		}
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load staff role")
+		http.Error(w, "Failed to authorize request", http.StatusInternalServerError)
+		return false
+	}
+
+	access := authz.StaffAccess{Role: staffRow.Role}
+	if staffRow.HomeFacilityID.Valid {
+		homeFacilityID := staffRow.HomeFacilityID.Int64
+		access.HomeFacilityID = &homeFacilityID
+	}
+	if !authz.IsOrganizationAdmin(access) {
+		logger.Warn().Int64("user_id", user.ID).Str("role", staffRow.Role).Int64("organization_id", organizationID).Msg("Organization access denied: not an org admin")
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return false
+	}
+	if org := authz.OrganizationFromContext(r.Context()); org != nil && org.ID != organizationID {
+		logger.Warn().Int64("user_id", user.ID).Int64("organization_id", organizationID).Int64("request_organization_id", org.ID).Msg("Organization access denied: other organization")
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
 func IsJSONRequest(r *http.Request) bool {
 	return strings.Contains(strings.ToLower(r.Header.Get("Content-Type")), "application/json")
 }
@@ -164,6 +206,20 @@ func ParseBool(raw string) bool {
 	}
 }
 
+// SanitizeCSVField prefixes values a spreadsheet would evaluate as a formula.
+func SanitizeCSVField(value string) string {
+	trimmed := strings.TrimLeft(value, " \t\r\n")
+	if trimmed == "" {
+		return value
+	}
+	switch trimmed[0] {
+	case '=', '+', '-', '@':
+		return "'" + value
+	default:
+		return value
+	}
+}
+
 func IsHTMXRequest(r *http.Request) bool {
 	return r.Header.Get("HX-Request") == "true"
 }
@@ -195,6 +251,10 @@ func RenderHTMLComponent(ctx context.Context, w http.ResponseWriter, component t
 	}
 	w.Header().Set("Content-Type", "text/html")
 	for key, value := range headers {
+		if strings.EqualFold(key, hxTriggerHeader) {
+			AppendHXTrigger(w, value)
+			continue
+		}
 		w.Header().Set(key, value)
 	}
 	if _, err := w.Write(buf.Bytes()); err != nil {
@@ -0,0 +1,138 @@
+package apiutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const hxTriggerHeader = "HX-Trigger"
+
+// AppendHXTrigger adds events to the HX-Trigger response header without
+// discarding events set earlier in the request. Existing values may be either
+// the comma-separated form ("a,b") or the JSON object form HTMX uses for events
+// with payloads; events may also be passed as JSON objects. Duplicate event
+// names are dropped, keeping the first payload seen.
+func AppendHXTrigger(w http.ResponseWriter, events ...string) {
+	merged := mergeHXTriggers(w.Header().Get(hxTriggerHeader), events...)
+	if merged == "" {
+		return
+	}
+	w.Header().Set(hxTriggerHeader, merged)
+}
+
+type hxTriggerEvent struct {
+	name    string
+	payload json.RawMessage
+}
+
+func mergeHXTriggers(existing string, events ...string) string {
+	var (
+		merged     []hxTriggerEvent
+		seen       = make(map[string]struct{})
+		hasPayload bool
+	)
+	add := func(value string) {
+		for _, event := range parseHXTrigger(value) {
+			if _, ok := seen[event.name]; ok {
+				continue
+			}
+			seen[event.name] = struct{}{}
+			if event.payload != nil {
+				hasPayload = true
+			}
+			merged = append(merged, event)
+		}
+	}
+
+	add(existing)
+	for _, event := range events {
+		add(event)
+	}
+
+	if len(merged) == 0 {
+		return ""
+	}
+	if !hasPayload {
+		names := make([]string, len(merged))
+		for i, event := range merged {
+			names[i] = event.name
+		}
+		return strings.Join(names, ",")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, event := range merged {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(event.name)
+		buf.Write(name)
+		buf.WriteByte(':')
+		if event.payload == nil {
+			buf.WriteString("null")
+		} else {
+			buf.Write(event.payload)
+		}
+	}
+	buf.WriteByte('}')
+	return buf.String()
+}
+
+func parseHXTrigger(value string) []hxTriggerEvent {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(value, "{") {
+		if events, ok := parseHXTriggerJSON(value); ok {
+			return events
+		}
+	}
+
+	var events []hxTriggerEvent
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		events = append(events, hxTriggerEvent{name: name})
+	}
+	return events
+}
+
+// parseHXTriggerJSON walks the object token by token so event order survives
+// the merge; HTMX fires events in header order.
+func parseHXTriggerJSON(value string) ([]hxTriggerEvent, bool) {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, false
+	}
+
+	var events []hxTriggerEvent
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, false
+		}
+		name, ok := token.(string)
+		if !ok {
+			return nil, false
+		}
+		var payload json.RawMessage
+		if err := decoder.Decode(&payload); err != nil {
+			return nil, false
+		}
+		if bytes.Equal(payload, []byte("null")) {
+			payload = nil
+		}
+		events = append(events, hxTriggerEvent{name: name, payload: payload})
+	}
+	if token, err := decoder.Token(); err != nil || token != json.Delim('}') {
+		return nil, false
+	}
+	return events, true
+}
@@ -0,0 +1,124 @@
+package apiutil
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a-h/templ"
+)
+
+func TestAppendHXTrigger(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		events   []string
+		want     string
+	}{
+		{
+			name:   "empty header",
+			events: []string{"refreshCourtsCalendar"},
+			want:   "refreshCourtsCalendar",
+		},
+		{
+			name:     "appends to plain list",
+			existing: "refreshMemberReservations",
+			events:   []string{"refreshMemberOpenPlay"},
+			want:     "refreshMemberReservations,refreshMemberOpenPlay",
+		},
+		{
+			name:     "deduplicates plain events",
+			existing: "refreshMemberReservations, refreshMemberOpenPlay",
+			events:   []string{"refreshMemberOpenPlay", "refreshMemberReservations", "refreshMemberClinics"},
+			want:     "refreshMemberReservations,refreshMemberOpenPlay,refreshMemberClinics",
+		},
+		{
+			name:     "comma-joined argument",
+			existing: "refreshStaffList",
+			events:   []string{"refreshStaffList,deactivationSessionsChanged"},
+			want:     "refreshStaffList,deactivationSessionsChanged",
+		},
+		{
+			name:     "merges plain event into JSON form",
+			existing: `{"showMessage":{"level":"info","text":"Saved"}}`,
+			events:   []string{"refreshLeaguesList"},
+			want:     `{"showMessage":{"level":"info","text":"Saved"},"refreshLeaguesList":null}`,
+		},
+		{
+			name:     "merges JSON event into plain form",
+			existing: "refreshCourtsCalendar",
+			events:   []string{`{"showMessage":"Booked"}`},
+			want:     `{"refreshCourtsCalendar":null,"showMessage":"Booked"}`,
+		},
+		{
+			name:     "keeps first payload for duplicate JSON events",
+			existing: `{"showMessage":"first"}`,
+			events:   []string{`{"showMessage":"second","refreshThemesList":null}`},
+			want:     `{"showMessage":"first","refreshThemesList":null}`,
+		},
+		{
+			name:     "JSON form without payloads collapses to plain list",
+			existing: `{"refreshStaffList":null}`,
+			events:   []string{"refreshNotificationCount"},
+			want:     "refreshStaffList,refreshNotificationCount",
+		},
+		{
+			name:     "ignores blank events",
+			existing: "refreshMembersList",
+			events:   []string{"", "  ", ","},
+			want:     "refreshMembersList",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			if tc.existing != "" {
+				recorder.Header().Set("HX-Trigger", tc.existing)
+			}
+			AppendHXTrigger(recorder, tc.events...)
+			if got := recorder.Header().Get("HX-Trigger"); got != tc.want {
+				t.Fatalf("HX-Trigger = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAppendHXTriggerRetrySafe(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	for i := 0; i < 3; i++ {
+		AppendHXTrigger(recorder, "refreshMemberReservations", "refreshMemberOpenPlay")
+	}
+	want := "refreshMemberReservations,refreshMemberOpenPlay"
+	if got := recorder.Header().Get("HX-Trigger"); got != want {
+		t.Fatalf("HX-Trigger = %q, want %q", got, want)
+	}
+}
+
+func TestAppendHXTriggerNoEventsLeavesHeaderUnset(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	AppendHXTrigger(recorder)
+	if _, ok := recorder.Header()["Hx-Trigger"]; ok {
+		t.Fatalf("expected HX-Trigger to remain unset")
+	}
+}
+
+func TestRenderHTMLComponentMergesHXTrigger(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	AppendHXTrigger(recorder, "refreshCourtsCalendar")
+
+	headers := map[string]string{
+		"HX-Trigger": "refreshLeaguesList",
+		"HX-Reswap":  "innerHTML",
+	}
+	request := httptest.NewRequest("GET", "/", nil)
+	if !RenderHTMLComponent(request.Context(), recorder, templ.Raw("<div></div>"), headers, "render failed", "render failed") {
+		t.Fatalf("expected render to succeed")
+	}
+
+	if got, want := recorder.Header().Get("HX-Trigger"), "refreshCourtsCalendar,refreshLeaguesList"; got != want {
+		t.Fatalf("HX-Trigger = %q, want %q", got, want)
+	}
+	if got := recorder.Header().Get("HX-Reswap"); got != "innerHTML" {
+		t.Fatalf("HX-Reswap = %q, want innerHTML", got)
+	}
+}
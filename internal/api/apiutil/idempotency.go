@@ -0,0 +1,38 @@
+package apiutil
+
+import (
+	"crypto/rand"
+	"net/http"
+	"strings"
+)
+
+const (
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	idempotencyKeyFormField = "idempotency_key"
+	maxIdempotencyKeyLength = 255
+)
+
+// NewIdempotencyKey returns a fresh key for a create form to submit, so
+// double-submits and retries of that form book once.
+func NewIdempotencyKey() string {
+	return rand.Text()
+}
+
+// IdempotencyKeyFromRequest returns the Idempotency-Key header, falling back
+// to the idempotency_key form field. It is empty when the client sent
+// neither. Form bodies must already be parsed.
+func IdempotencyKeyFromRequest(r *http.Request) (string, error) {
+	key := strings.TrimSpace(r.Header.Get(IdempotencyKeyHeader))
+	if key == "" && r.Form != nil {
+		key = strings.TrimSpace(r.Form.Get(idempotencyKeyFormField))
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return "", FieldError{Field: idempotencyKeyFormField, Reason: "must be at most 255 characters"}
+	}
+	for _, c := range key {
+		if c < 0x21 || c > 0x7e {
+			return "", FieldError{Field: idempotencyKeyFormField, Reason: "must be printable ASCII"}
+		}
+	}
+	return key, nil
+}
@@ -0,0 +1,65 @@
+package apiutil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+type MembershipTierQuerier interface {
+	GetMembershipTierForLevel(ctx context.Context, arg dbgen.GetMembershipTierForLevelParams) (dbgen.MembershipTier, error)
+}
+
+// DefaultMembershipTier is the tier a level gets when its organization has
+// not defined one covering it. It matches the behavior levels had before
+// tiers were configurable: guests may buy visit packs, members may not, and
+// everything else follows the facility settings.
+func DefaultMembershipTier(organizationID, membershipLevel int64) dbgen.MembershipTier {
+	level := min(max(membershipLevel, 0), MaxMembershipLevel)
+	return dbgen.MembershipTier{
+		OrganizationID: organizationID,
+		Level:          level,
+		Name:           MembershipLevelName(level),
+		VisitPacks:     level <= 1,
+	}
+}
+
+// LoadMembershipTier returns the organization's tier for a member at
+// membershipLevel: the one with the highest level at or below it, or the
+// default tier when none qualifies. On error it also returns the default
+// tier so callers can carry on with today's behavior.
+func LoadMembershipTier(ctx context.Context, q MembershipTierQuerier, organizationID, membershipLevel int64) (dbgen.MembershipTier, error) {
+	tier, err := q.GetMembershipTierForLevel(ctx, dbgen.GetMembershipTierForLevelParams{
+		OrganizationID:  organizationID,
+		MembershipLevel: membershipLevel,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return DefaultMembershipTier(organizationID, membershipLevel), nil
+		}
+		return DefaultMembershipTier(organizationID, membershipLevel), err
+	}
+	return tier, nil
+}
+
+// MemberReservationLimit is how many active reservations a member of tier may
+// hold at a facility whose own limit is facilityLimit. Zero or less means no
+// limit.
+func MemberReservationLimit(tier dbgen.MembershipTier, facilityLimit int64) int64 {
+	if tier.MaxActiveReservations.Valid {
+		return tier.MaxActiveReservations.Int64
+	}
+	return facilityLimit
+}
+
+// PrimeTimeLevel is the membership level prime-time rules should see for a
+// member of tier. A tier with prime-time access clears every hold.
+func PrimeTimeLevel(tier dbgen.MembershipTier, membershipLevel int64) int64 {
+	if tier.PrimeTimeAccess {
+		return math.MaxInt64
+	}
+	return membershipLevel
+}
@@ -0,0 +1,71 @@
+package apiutil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+type fakeMembershipTierQuerier struct {
+	tier dbgen.MembershipTier
+	err  error
+}
+
+func (f fakeMembershipTierQuerier) GetMembershipTierForLevel(context.Context, dbgen.GetMembershipTierForLevelParams) (dbgen.MembershipTier, error) {
+	return f.tier, f.err
+}
+
+func TestLoadMembershipTierFallsBackToDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	tier, err := LoadMembershipTier(ctx, fakeMembershipTierQuerier{err: sql.ErrNoRows}, 7, 1)
+	if err != nil || tier.Name != "Verified Guest" || !tier.VisitPacks || tier.OrganizationID != 7 {
+		t.Fatalf("expected the Verified Guest default, got %+v, %v", tier, err)
+	}
+	tier, err = LoadMembershipTier(ctx, fakeMembershipTierQuerier{err: sql.ErrNoRows}, 7, 9)
+	if err != nil || tier.Name != "Member+" || tier.VisitPacks {
+		t.Fatalf("expected levels above the top to default to Member+, got %+v, %v", tier, err)
+	}
+
+	boom := errors.New("boom")
+	tier, err = LoadMembershipTier(ctx, fakeMembershipTierQuerier{err: boom}, 7, 2)
+	if !errors.Is(err, boom) || tier.Name != "Member" {
+		t.Fatalf("expected the error alongside the Member default, got %+v, %v", tier, err)
+	}
+
+	gold := dbgen.MembershipTier{ID: 3, OrganizationID: 7, Level: 2, Name: "Gold"}
+	tier, err = LoadMembershipTier(ctx, fakeMembershipTierQuerier{tier: gold}, 7, 2)
+	if err != nil || tier.Name != "Gold" {
+		t.Fatalf("expected the organization's tier, got %+v, %v", tier, err)
+	}
+}
+
+func TestMemberReservationLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		tier dbgen.MembershipTier
+		want int64
+	}{
+		{name: "facility limit", tier: dbgen.MembershipTier{}, want: 30},
+		{name: "tier limit", tier: dbgen.MembershipTier{MaxActiveReservations: sql.NullInt64{Int64: 5, Valid: true}}, want: 5},
+		{name: "tier without limit", tier: dbgen.MembershipTier{MaxActiveReservations: sql.NullInt64{Int64: 0, Valid: true}}, want: 0},
+	}
+	for _, tt := range tests {
+		if got := MemberReservationLimit(tt.tier, 30); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPrimeTimeLevel(t *testing.T) {
+	if got := PrimeTimeLevel(dbgen.MembershipTier{}, 1); got != 1 {
+		t.Fatalf("expected the member's level without access, got %d", got)
+	}
+	rule := dbgen.PrimeTimeRule{MinMembershipLevel: 3}
+	if got := PrimeTimeLevel(dbgen.MembershipTier{PrimeTimeAccess: true}, 1); got < rule.MinMembershipLevel {
+		t.Fatalf("expected prime-time access to clear a level 3 hold, got %d", got)
+	}
+}
@@ -0,0 +1,93 @@
+package apiutil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// NoShowWindow is the rolling period no-shows are counted over.
+const NoShowWindow = 30 * 24 * time.Hour
+
+// NoShowRestriction describes an active booking restriction for a member at
+// a facility.
+type NoShowRestriction struct {
+	FacilityID      int64
+	NoShowCount     int64
+	MaxNoShows      int64
+	RestrictionDays int64
+	Until           time.Time
+}
+
+// NoShowRestrictionError rejects a booking while a restriction is active.
+type NoShowRestrictionError struct {
+	Restriction NoShowRestriction
+}
+
+func (e NoShowRestrictionError) Error() string {
+	return fmt.Sprintf("Booking is restricted until %s after %d no-shows in 30 days", e.Restriction.Until.Format("Mon, Jan 2, 2006 3:04 PM"), e.Restriction.NoShowCount)
+}
+
+// LoadNoShowRestriction returns the member's active restriction at the
+// facility, or nil when the facility has no policy or the member is in good
+// standing. A restriction starts when a no-show brings the count within the
+// preceding 30 days above the policy maximum and lasts restriction_days from
+// that no-show's end. No-shows up to the latest staff clear are ignored.
+func LoadNoShowRestriction(ctx context.Context, q *dbgen.Queries, facilityID, userID int64, now time.Time) (*NoShowRestriction, error) {
+	policy, err := q.GetFacilityNoShowPolicy(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	restrictionPeriod := time.Duration(policy.RestrictionDays) * 24 * time.Hour
+	since := now.Add(-NoShowWindow - restrictionPeriod)
+	lastClear, err := q.GetLatestNoShowRestrictionClear(ctx, dbgen.GetLatestNoShowRestrictionClearParams{
+		UserID:     userID,
+		FacilityID: facilityID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil && lastClear.ClearedAt.After(since) {
+		since = lastClear.ClearedAt
+	}
+
+	endTimes, err := q.ListMemberNoShowEndTimes(ctx, dbgen.ListMemberNoShowEndTimesParams{
+		FacilityID:     facilityID,
+		UserID:         userID,
+		Since:          since,
+		ComparisonTime: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var restriction *NoShowRestriction
+	windowStart := 0
+	for i, endTime := range endTimes {
+		for !endTimes[windowStart].After(endTime.Add(-NoShowWindow)) {
+			windowStart++
+		}
+		count := int64(i - windowStart + 1)
+		if count <= policy.MaxNoShowsPer30Days {
+			continue
+		}
+		if until := endTime.Add(restrictionPeriod); until.After(now) {
+			restriction = &NoShowRestriction{
+				FacilityID:      facilityID,
+				NoShowCount:     count,
+				MaxNoShows:      policy.MaxNoShowsPer30Days,
+				RestrictionDays: policy.RestrictionDays,
+				Until:           until,
+			}
+		}
+	}
+	return restriction, nil
+}
@@ -0,0 +1,54 @@
+package apiutil
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MaxMembershipLevel is the highest membership tier; higher stored levels are
+// treated as this tier.
+const MaxMembershipLevel int64 = 3
+
+func MembershipLevelName(level int64) string {
+	switch {
+	case level <= 0:
+		return "Unverified Guest"
+	case level == 1:
+		return "Verified Guest"
+	case level == 2:
+		return "Member"
+	default:
+		return "Member+"
+	}
+}
+
+// OpenPlayEligible reports whether a member at membershipLevel may join an
+// open play session restricted to [minLevel, maxLevel].
+func OpenPlayEligible(membershipLevel, minLevel int64, maxLevel sql.NullInt64) bool {
+	if membershipLevel > MaxMembershipLevel {
+		membershipLevel = MaxMembershipLevel
+	}
+	if membershipLevel < minLevel {
+		return false
+	}
+	return !maxLevel.Valid || membershipLevel <= maxLevel.Int64
+}
+
+// OpenPlayEligibilityLabel describes who may join; it is empty when the rule
+// is open to every level.
+func OpenPlayEligibilityLabel(minLevel int64, maxLevel sql.NullInt64) string {
+	hasMin := minLevel > 0
+	hasMax := maxLevel.Valid && maxLevel.Int64 < MaxMembershipLevel
+	switch {
+	case hasMax && minLevel == maxLevel.Int64:
+		return fmt.Sprintf("%s only", MembershipLevelName(minLevel))
+	case hasMin && hasMax:
+		return fmt.Sprintf("%s to %s only", MembershipLevelName(minLevel), MembershipLevelName(maxLevel.Int64))
+	case hasMin:
+		return fmt.Sprintf("%s and above only", MembershipLevelName(minLevel))
+	case hasMax:
+		return fmt.Sprintf("%s and below only", MembershipLevelName(maxLevel.Int64))
+	default:
+		return ""
+	}
+}
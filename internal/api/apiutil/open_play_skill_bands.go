@@ -0,0 +1,164 @@
+package apiutil
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Member skill ratings use the usual pickleball scale.
+const (
+	MinSkillRating = 1.0
+	MaxSkillRating = 8.0
+)
+
+// OpenPlaySkillBand splits an open play session by skill: members rated
+// within [MinRating, MaxRating] play on CourtNumbers. A nil MaxRating leaves
+// the band open-ended.
+type OpenPlaySkillBand struct {
+	Name         string   `json:"name,omitempty"`
+	MinRating    float64  `json:"minRating"`
+	MaxRating    *float64 `json:"maxRating,omitempty"`
+	CourtNumbers []int64  `json:"courtNumbers"`
+}
+
+// Contains reports whether rating falls inside the band, inclusive.
+func (b OpenPlaySkillBand) Contains(rating float64) bool {
+	if rating < b.MinRating {
+		return false
+	}
+	return b.MaxRating == nil || rating <= *b.MaxRating
+}
+
+// Capacity is the band's share of the session: perCourt players on each of
+// its courts.
+func (b OpenPlaySkillBand) Capacity(perCourt int64) int64 {
+	return perCourt * int64(len(b.CourtNumbers))
+}
+
+// RangeLabel renders the band's ratings, e.g. "3.0–3.5" or "4.0+".
+func (b OpenPlaySkillBand) RangeLabel() string {
+	if b.MaxRating == nil {
+		return fmt.Sprintf("%.1f+", b.MinRating)
+	}
+	return fmt.Sprintf("%.1f–%.1f", b.MinRating, *b.MaxRating)
+}
+
+// Label is the band's name followed by its range, or just the range when the
+// band is unnamed.
+func (b OpenPlaySkillBand) Label() string {
+	if name := strings.TrimSpace(b.Name); name != "" {
+		return fmt.Sprintf("%s (%s)", name, b.RangeLabel())
+	}
+	return b.RangeLabel()
+}
+
+// CourtsLabel lists the band's courts, e.g. "Courts 1, 2".
+func (b OpenPlaySkillBand) CourtsLabel() string {
+	numbers := make([]string, len(b.CourtNumbers))
+	for i, number := range b.CourtNumbers {
+		numbers[i] = strconv.FormatInt(number, 10)
+	}
+	if len(numbers) == 1 {
+		return "Court " + numbers[0]
+	}
+	return "Courts " + strings.Join(numbers, ", ")
+}
+
+// ParseOpenPlaySkillBands decodes a stored skill_bands value. NULL and empty
+// values mean the session is not split by skill.
+func ParseOpenPlaySkillBands(raw sql.NullString) ([]OpenPlaySkillBand, error) {
+	if !raw.Valid || strings.TrimSpace(raw.String) == "" {
+		return nil, nil
+	}
+	var bands []OpenPlaySkillBand
+	if err := json.Unmarshal([]byte(raw.String), &bands); err != nil {
+		return nil, fmt.Errorf("decode skill bands: %w", err)
+	}
+	return bands, nil
+}
+
+// EffectiveOpenPlaySkillBands returns a session's skill bands: its own
+// override when staff set one, otherwise its rule's.
+func EffectiveOpenPlaySkillBands(ruleBands, sessionBands sql.NullString) ([]OpenPlaySkillBand, error) {
+	if sessionBands.Valid {
+		return ParseOpenPlaySkillBands(sessionBands)
+	}
+	return ParseOpenPlaySkillBands(ruleBands)
+}
+
+// MarshalOpenPlaySkillBands encodes bands for storage, sorted by MinRating.
+func MarshalOpenPlaySkillBands(bands []OpenPlaySkillBand) (string, error) {
+	if bands == nil {
+		bands = []OpenPlaySkillBand{}
+	}
+	sorted := append([]OpenPlaySkillBand(nil), bands...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].MinRating < sorted[j].MinRating })
+	encoded, err := json.Marshal(sorted)
+	if err != nil {
+		return "", fmt.Errorf("encode skill bands: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// ValidateOpenPlaySkillBands checks that every band has a sane rating range
+// and at least one court, and that no rating or court belongs to two bands.
+func ValidateOpenPlaySkillBands(bands []OpenPlaySkillBand) error {
+	courts := make(map[int64]bool)
+	for i, band := range bands {
+		if band.MinRating < MinSkillRating || band.MinRating > MaxSkillRating {
+			return fmt.Errorf("skill band %d: min rating must be between %.1f and %.1f", i+1, MinSkillRating, MaxSkillRating)
+		}
+		if band.MaxRating != nil && (*band.MaxRating < band.MinRating || *band.MaxRating > MaxSkillRating) {
+			return fmt.Errorf("skill band %d: max rating must be between its min rating and %.1f", i+1, MaxSkillRating)
+		}
+		if len(band.CourtNumbers) == 0 {
+			return fmt.Errorf("skill band %d: at least one court is required", i+1)
+		}
+		for _, number := range band.CourtNumbers {
+			if number <= 0 {
+				return fmt.Errorf("skill band %d: court numbers must be positive", i+1)
+			}
+			if courts[number] {
+				return fmt.Errorf("court %d is assigned to more than one skill band", number)
+			}
+			courts[number] = true
+		}
+		for j := 0; j < i; j++ {
+			if openPlaySkillBandsOverlap(bands[j], band) {
+				return errors.New("skill band rating ranges must not overlap")
+			}
+		}
+	}
+	return nil
+}
+
+// OpenPlaySkillBandFor returns the band a member rated rating plays in.
+func OpenPlaySkillBandFor(bands []OpenPlaySkillBand, rating float64) (OpenPlaySkillBand, bool) {
+	for _, band := range bands {
+		if band.Contains(rating) {
+			return band, true
+		}
+	}
+	return OpenPlaySkillBand{}, false
+}
+
+// ParseSkillRating parses a member skill rating such as "3.5".
+func ParseSkillRating(value string) (float64, error) {
+	rating, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || rating < MinSkillRating || rating > MaxSkillRating {
+		return 0, fmt.Errorf("skill rating must be a number between %.1f and %.1f", MinSkillRating, MaxSkillRating)
+	}
+	return rating, nil
+}
+
+func openPlaySkillBandsOverlap(a, b OpenPlaySkillBand) bool {
+	if a.MinRating > b.MinRating {
+		a, b = b, a
+	}
+	return a.MaxRating == nil || *a.MaxRating >= b.MinRating
+}
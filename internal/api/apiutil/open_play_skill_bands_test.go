@@ -0,0 +1,92 @@
+package apiutil
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func ratingPtr(value float64) *float64 {
+	return &value
+}
+
+func TestValidateOpenPlaySkillBands(t *testing.T) {
+	tests := []struct {
+		name    string
+		bands   []OpenPlaySkillBand
+		wantErr bool
+	}{
+		{name: "none", bands: nil},
+		{
+			name: "split",
+			bands: []OpenPlaySkillBand{
+				{MinRating: 3.0, MaxRating: ratingPtr(3.5), CourtNumbers: []int64{1, 2}},
+				{MinRating: 4.0, CourtNumbers: []int64{3, 4}},
+			},
+		},
+		{
+			name: "overlapping ratings",
+			bands: []OpenPlaySkillBand{
+				{MinRating: 4.0, CourtNumbers: []int64{3}},
+				{MinRating: 3.0, MaxRating: ratingPtr(4.0), CourtNumbers: []int64{1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "shared court",
+			bands: []OpenPlaySkillBand{
+				{MinRating: 3.0, MaxRating: ratingPtr(3.5), CourtNumbers: []int64{1, 2}},
+				{MinRating: 4.0, CourtNumbers: []int64{2}},
+			},
+			wantErr: true,
+		},
+		{name: "no courts", bands: []OpenPlaySkillBand{{MinRating: 3.0}}, wantErr: true},
+		{name: "inverted range", bands: []OpenPlaySkillBand{{MinRating: 4.0, MaxRating: ratingPtr(3.0), CourtNumbers: []int64{1}}}, wantErr: true},
+		{name: "rating off scale", bands: []OpenPlaySkillBand{{MinRating: 0.5, CourtNumbers: []int64{1}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOpenPlaySkillBands(tt.bands)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEffectiveOpenPlaySkillBands(t *testing.T) {
+	rule := sql.NullString{String: `[{"minRating":3,"maxRating":3.5,"courtNumbers":[1,2]},{"minRating":4,"courtNumbers":[3,4]}]`, Valid: true}
+
+	bands, err := EffectiveOpenPlaySkillBands(rule, sql.NullString{})
+	if err != nil {
+		t.Fatalf("parse rule bands: %v", err)
+	}
+	band, ok := OpenPlaySkillBandFor(bands, 4.5)
+	if !ok || band.RangeLabel() != "4.0+" || band.Capacity(4) != 8 || band.CourtsLabel() != "Courts 3, 4" {
+		t.Fatalf("unexpected band for 4.5: %+v (found %v)", band, ok)
+	}
+	if _, ok := OpenPlaySkillBandFor(bands, 3.75); ok {
+		t.Fatal("expected no band between ranges")
+	}
+
+	bands, err = EffectiveOpenPlaySkillBands(rule, sql.NullString{String: "[]", Valid: true})
+	if err != nil {
+		t.Fatalf("parse session override: %v", err)
+	}
+	if len(bands) != 0 {
+		t.Fatalf("expected session override to clear bands, got %+v", bands)
+	}
+}
+
+func TestMarshalOpenPlaySkillBandsSortsByRating(t *testing.T) {
+	encoded, err := MarshalOpenPlaySkillBands([]OpenPlaySkillBand{
+		{Name: "Advanced", MinRating: 4.0, CourtNumbers: []int64{3}},
+		{MinRating: 3.0, MaxRating: ratingPtr(3.5), CourtNumbers: []int64{1}},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `[{"minRating":3,"maxRating":3.5,"courtNumbers":[1]},{"name":"Advanced","minRating":4,"courtNumbers":[3]}]`
+	if encoded != want {
+		t.Fatalf("expected %s, got %s", want, encoded)
+	}
+}
@@ -1,10 +1,22 @@
 package apiutil
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/rs/zerolog/log"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 )
 
+// HoursOverrideDateLayout is the facility-local date format of
+// facility_hours_overrides.override_date.
+const HoursOverrideDateLayout = "2006-01-02"
+
 func FormatOperatingHourValue(value interface{}) string {
 	switch typed := value.(type) {
 	case time.Time:
@@ -20,3 +32,114 @@ func FormatOperatingHourValue(value interface{}) string {
 		return fmt.Sprint(value)
 	}
 }
+
+type HoursOverrideQuerier interface {
+	GetFacilityHoursOverride(ctx context.Context, arg dbgen.GetFacilityHoursOverrideParams) (dbgen.FacilityHoursOverride, error)
+}
+
+// LoadFacilityHoursOverride returns the override for day's calendar date, or
+// nil when the weekly schedule applies. day must already be in facility time.
+func LoadFacilityHoursOverride(ctx context.Context, q HoursOverrideQuerier, facilityID int64, day time.Time) (*dbgen.FacilityHoursOverride, error) {
+	override, err := q.GetFacilityHoursOverride(ctx, dbgen.GetFacilityHoursOverrideParams{
+		FacilityID:   facilityID,
+		OverrideDate: day.Format(HoursOverrideDateLayout),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &override, nil
+}
+
+// HoursOverrideWindow returns the open and close times an override sets on
+// day. ok is false when the override closes the facility for the day.
+func HoursOverrideWindow(override dbgen.FacilityHoursOverride, day time.Time) (opensAt, closesAt time.Time, ok bool) {
+	if override.IsClosed {
+		return time.Time{}, time.Time{}, false
+	}
+	opens, err := time.Parse("15:04", override.OpensAt.String)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	closes, err := time.Parse("15:04", override.ClosesAt.String)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	opensAt = time.Date(day.Year(), day.Month(), day.Day(), opens.Hour(), opens.Minute(), 0, 0, day.Location())
+	closesAt = time.Date(day.Year(), day.Month(), day.Day(), closes.Hour(), closes.Minute(), 0, 0, day.Location())
+	return opensAt, closesAt, true
+}
+
+// OperatingHoursWindow returns the open and close times on day: the
+// override's when one is given, otherwise the weekly row for day's weekday.
+// ok is false when the facility is closed that day. day must already be in
+// facility time.
+func OperatingHoursWindow(day time.Time, hours []dbgen.OperatingHour, override *dbgen.FacilityHoursOverride) (opensAt, closesAt time.Time, ok bool) {
+	if override != nil {
+		return HoursOverrideWindow(*override, day)
+	}
+	for _, hour := range hours {
+		if hour.DayOfWeek != int64(day.Weekday()) {
+			continue
+		}
+		opens, err := time.Parse("15:04", strings.TrimSpace(FormatOperatingHourValue(hour.OpensAt)))
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		closes, err := time.Parse("15:04", strings.TrimSpace(FormatOperatingHourValue(hour.ClosesAt)))
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		opensAt = time.Date(day.Year(), day.Month(), day.Day(), opens.Hour(), opens.Minute(), 0, 0, day.Location())
+		closesAt = time.Date(day.Year(), day.Month(), day.Day(), closes.Hour(), closes.Minute(), 0, 0, day.Location())
+		return opensAt, closesAt, closesAt.After(opensAt)
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// HoursOverrideError reports a booking on a date the facility is closed or
+// outside that date's overridden hours.
+type HoursOverrideError struct {
+	Override dbgen.FacilityHoursOverride
+}
+
+func (e HoursOverrideError) Error() string {
+	date := e.Override.OverrideDate
+	if parsed, err := time.Parse(HoursOverrideDateLayout, date); err == nil {
+		date = parsed.Format("Mon, Jan 2, 2006")
+	}
+	var message string
+	if e.Override.IsClosed {
+		message = fmt.Sprintf("The facility is closed on %s", date)
+	} else {
+		message = fmt.Sprintf("The facility is only open %s-%s on %s", e.Override.OpensAt.String, e.Override.ClosesAt.String, date)
+	}
+	if e.Override.Reason.Valid && e.Override.Reason.String != "" {
+		message += " (" + e.Override.Reason.String + ")"
+	}
+	return message
+}
+
+// EnsureWithinHoursOverride rejects bookings that start on a date with a
+// closed override or fall outside that date's override hours. Dates without
+// an override are left to the weekly schedule.
+func EnsureWithinHoursOverride(ctx context.Context, q *dbgen.Queries, facilities FacilityQuerier, facilityID int64, startTime, endTime time.Time) error {
+	facility, err := facilities.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		return err
+	}
+	loc := FacilityLocation(facility, log.Ctx(ctx))
+
+	start := startTime.In(loc)
+	override, err := LoadFacilityHoursOverride(ctx, q, facilityID, start)
+	if err != nil || override == nil {
+		return err
+	}
+	opensAt, closesAt, ok := HoursOverrideWindow(*override, start)
+	if !ok || start.Before(opensAt) || endTime.After(closesAt) {
+		return HoursOverrideError{Override: *override}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package apiutil
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/images"
+)
+
+// photoCacheControl lets browsers reuse a photo briefly and revalidate it
+// with its ETag after that, so a replaced photo shows up within minutes.
+const photoCacheControl = "private, max-age=300"
+
+// ProcessPhotoUpload decodes a photo_data form value, a base64 data URL, and
+// normalizes it to JPEG. Errors are HandlerErrors: 400 for malformed data,
+// 413 over the upload limit and 415 for anything but JPEG, PNG or WebP.
+func ProcessPhotoUpload(photoData string) (images.Photo, error) {
+	data, err := images.DecodeDataURL(photoData)
+	if err == nil {
+		var photo images.Photo
+		if photo, err = images.Process(data); err == nil {
+			return photo, nil
+		}
+	}
+
+	switch {
+	case errors.Is(err, images.ErrTooLarge):
+		return images.Photo{}, HandlerError{Status: http.StatusRequestEntityTooLarge, Message: "Photo is too large", Err: err}
+	case errors.Is(err, images.ErrUnsupportedFormat):
+		return images.Photo{}, HandlerError{Status: http.StatusUnsupportedMediaType, Message: "Photo must be a JPEG or PNG image", Err: err}
+	default:
+		return images.Photo{}, HandlerError{Status: http.StatusBadRequest, Message: "Invalid photo data", Err: err}
+	}
+}
+
+// ServePhoto writes a stored photo with cache headers and an ETag derived
+// from its bytes, answering conditional requests with 304. The content type
+// is sniffed so photos stored before uploads were normalized are labeled
+// correctly.
+func ServePhoto(w http.ResponseWriter, r *http.Request, data []byte, storedContentType string, modified time.Time) {
+	contentType, err := images.DetectContentType(data)
+	if err != nil {
+		contentType = storedContentType
+	}
+	sum := sha256.Sum256(data)
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", photoCacheControl)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	http.ServeContent(w, r, "", modified, bytes.NewReader(data))
+}
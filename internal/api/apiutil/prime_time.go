@@ -0,0 +1,87 @@
+package apiutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// MaxPrimeTimeUnlockHours caps a prime-time hold at four weeks, well past
+// any member booking window.
+const MaxPrimeTimeUnlockHours = 24 * 28
+
+type PrimeTimeRulesQuerier interface {
+	ListPrimeTimeRulesForDay(ctx context.Context, arg dbgen.ListPrimeTimeRulesForDayParams) ([]dbgen.PrimeTimeRule, error)
+}
+
+// PrimeTimeLockedError reports a booking in a prime-time window that the
+// member's level cannot book until UnlocksAt.
+type PrimeTimeLockedError struct {
+	Rule      dbgen.PrimeTimeRule
+	UnlocksAt time.Time
+}
+
+func (e PrimeTimeLockedError) Error() string {
+	return fmt.Sprintf(
+		"Prime time %s-%s is reserved for membership level %d and above until %s",
+		e.Rule.StartTime,
+		e.Rule.EndTime,
+		e.Rule.MinMembershipLevel,
+		e.UnlocksAt.Format("Mon, Jan 2 at 3:04 PM"),
+	)
+}
+
+// LoadPrimeTimeRules returns the facility's rules for day's weekday. day must
+// already be in facility time.
+func LoadPrimeTimeRules(ctx context.Context, q PrimeTimeRulesQuerier, facilityID int64, day time.Time) ([]dbgen.PrimeTimeRule, error) {
+	return q.ListPrimeTimeRulesForDay(ctx, dbgen.ListPrimeTimeRulesForDayParams{
+		FacilityID: facilityID,
+		DayOfWeek:  int64(day.Weekday()),
+	})
+}
+
+// PrimeTimeUnlock returns when a member at membershipLevel may book
+// start-end under rules. ok is false when no rule holds the slot for higher
+// levels. When several rules overlap, the one unlocking last wins. start and
+// end must already be in facility time.
+func PrimeTimeUnlock(rules []dbgen.PrimeTimeRule, membershipLevel int64, start, end time.Time) (unlocksAt time.Time, rule dbgen.PrimeTimeRule, ok bool) {
+	for _, candidate := range rules {
+		if membershipLevel >= candidate.MinMembershipLevel || candidate.DayOfWeek != int64(start.Weekday()) {
+			continue
+		}
+		ruleStart, err := time.Parse("15:04", candidate.StartTime)
+		if err != nil {
+			continue
+		}
+		ruleEnd, err := time.Parse("15:04", candidate.EndTime)
+		if err != nil {
+			continue
+		}
+		windowStart := time.Date(start.Year(), start.Month(), start.Day(), ruleStart.Hour(), ruleStart.Minute(), 0, 0, start.Location())
+		windowEnd := time.Date(start.Year(), start.Month(), start.Day(), ruleEnd.Hour(), ruleEnd.Minute(), 0, 0, start.Location())
+		if !windowStart.Before(end) || !windowEnd.After(start) {
+			continue
+		}
+		candidateUnlock := start.Add(-time.Duration(candidate.UnlockHoursBefore) * time.Hour)
+		if !ok || candidateUnlock.After(unlocksAt) {
+			unlocksAt, rule, ok = candidateUnlock, candidate, true
+		}
+	}
+	return unlocksAt, rule, ok
+}
+
+// EnsurePrimeTimeUnlocked rejects a member booking of start-end that a
+// prime-time rule still holds for higher membership levels at now.
+func EnsurePrimeTimeUnlocked(ctx context.Context, q PrimeTimeRulesQuerier, facilityID, membershipLevel int64, start, end, now time.Time) error {
+	rules, err := LoadPrimeTimeRules(ctx, q, facilityID, start)
+	if err != nil {
+		return err
+	}
+	unlocksAt, rule, ok := PrimeTimeUnlock(rules, membershipLevel, start, end)
+	if ok && now.Before(unlocksAt) {
+		return PrimeTimeLockedError{Rule: rule, UnlocksAt: unlocksAt}
+	}
+	return nil
+}
@@ -8,3 +8,12 @@ func ToNullInt64(value *int64) sql.NullInt64 {
 	}
 	return sql.NullInt64{Int64: *value, Valid: true}
 }
+
+// NullInt64Ptr is the inverse of ToNullInt64, for JSON responses that
+// should carry null rather than sql.NullInt64's struct.
+func NullInt64Ptr(value sql.NullInt64) *int64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int64
+}
@@ -10,6 +10,12 @@ import (
 
 const DefaultMaxAdvanceDays int64 = 7
 
+// FacilityQuerier loads a facility. Handlers pass the store's facility cache;
+// *dbgen.Queries also satisfies it.
+type FacilityQuerier interface {
+	GetFacilityByID(ctx context.Context, id int64) (dbgen.Facility, error)
+}
+
 func NormalizedMaxAdvanceDays(value, defaultValue int64) int64 {
 	if value <= 0 {
 		return defaultValue
@@ -17,25 +23,37 @@ func NormalizedMaxAdvanceDays(value, defaultValue int64) int64 {
 	return value
 }
 
+// GetMemberMaxAdvanceDays returns how many days ahead a member at
+// membershipLevel may book at the facility. A facility tier booking window
+// wins when tier booking is enabled, then the member's membership tier
+// window, then the facility's own setting.
 func GetMemberMaxAdvanceDays(
 	ctx context.Context,
 	q *dbgen.Queries,
+	facilities FacilityQuerier,
 	facilityID int64,
 	membershipLevel int64,
 	defaultValue int64,
 ) (int64, *dbgen.Facility, error) {
-	facility, err := q.GetFacilityByID(ctx, facilityID)
+	facility, err := facilities.GetFacilityByID(ctx, facilityID)
 	if err != nil {
 		return defaultValue, nil, err
 	}
 
 	maxAdvanceDays := NormalizedMaxAdvanceDays(facility.MaxAdvanceBookingDays, defaultValue)
+	tier, err := LoadMembershipTier(ctx, q, facility.OrganizationID, membershipLevel)
+	if err != nil {
+		return maxAdvanceDays, &facility, err
+	}
+	if tier.BookingWindowDays.Valid {
+		maxAdvanceDays = NormalizedMaxAdvanceDays(tier.BookingWindowDays.Int64, maxAdvanceDays)
+	}
 	if !facility.TierBookingEnabled {
 		return maxAdvanceDays, &facility, nil
 	}
 
-	if membershipLevel > 3 {
-		membershipLevel = 3
+	if membershipLevel > MaxMembershipLevel {
+		membershipLevel = MaxMembershipLevel
 	}
 	window, err := q.GetTierBookingWindow(ctx, dbgen.GetTierBookingWindowParams{
 		FacilityID:      facilityID,
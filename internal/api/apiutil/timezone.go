@@ -0,0 +1,92 @@
+package apiutil
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+var (
+	defaultLocation atomic.Pointer[time.Location]
+	// loadedLocations caches time.LoadLocation results by timezone name so
+	// neither valid nor invalid names are reparsed per request.
+	loadedLocations sync.Map
+)
+
+type timezoneLookup struct {
+	loc *time.Location
+	err error
+}
+
+// SetDefaultTimezone sets the location used for facilities with no timezone
+// or one that fails to load. An empty name means UTC.
+func SetDefaultTimezone(name string) error {
+	loc := time.UTC
+	if name = strings.TrimSpace(name); name != "" {
+		loaded, err := time.LoadLocation(name)
+		if err != nil {
+			return fmt.Errorf("load default timezone %q: %w", name, err)
+		}
+		loc = loaded
+	}
+	defaultLocation.Store(loc)
+	return nil
+}
+
+// DefaultLocation returns the configured fallback location, UTC until
+// SetDefaultTimezone runs.
+func DefaultLocation() *time.Location {
+	if loc := defaultLocation.Load(); loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// TimezoneLocation loads the named location, falling back to
+// DefaultLocation when the name is empty or invalid. Invalid names are
+// logged when logger is non-nil.
+func TimezoneLocation(timezone string, logger *zerolog.Logger) *time.Location {
+	loc, err := loadTimezone(timezone)
+	if err != nil {
+		if logger != nil {
+			logger.Warn().Err(err).Str("timezone", timezone).Msg("Failed to load facility timezone; using default")
+		}
+		return DefaultLocation()
+	}
+	return loc
+}
+
+// FacilityLocation returns the location facility times are shown and
+// bounded in.
+func FacilityLocation(facility dbgen.Facility, logger *zerolog.Logger) *time.Location {
+	loc, err := loadTimezone(facility.Timezone)
+	if err != nil {
+		if logger != nil {
+			logger.Warn().Err(err).Int64("facility_id", facility.ID).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone; using default")
+		}
+		return DefaultLocation()
+	}
+	return loc
+}
+
+// loadTimezone returns DefaultLocation for an empty name and caches every
+// lookup, including failures, by name.
+func loadTimezone(timezone string) (*time.Location, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		return DefaultLocation(), nil
+	}
+	if cached, ok := loadedLocations.Load(timezone); ok {
+		result := cached.(timezoneLookup)
+		return result.loc, result.err
+	}
+	loc, err := time.LoadLocation(timezone)
+	loadedLocations.Store(timezone, timezoneLookup{loc: loc, err: err})
+	return loc, err
+}
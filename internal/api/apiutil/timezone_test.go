@@ -0,0 +1,91 @@
+package apiutil
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestFacilityLocation(t *testing.T) {
+	if err := SetDefaultTimezone("America/Chicago"); err != nil {
+		t.Fatalf("set default timezone: %v", err)
+	}
+	t.Cleanup(func() {
+		defaultLocation.Store(nil)
+	})
+
+	tests := []struct {
+		name     string
+		timezone string
+		want     string
+		logged   bool
+	}{
+		{name: "valid", timezone: "America/Los_Angeles", want: "America/Los_Angeles"},
+		{name: "empty uses default", timezone: "", want: "America/Chicago"},
+		{name: "invalid uses default", timezone: "Mars/Olympus_Mons", want: "America/Chicago", logged: true},
+		{name: "cached invalid still logs", timezone: "Mars/Olympus_Mons", want: "America/Chicago", logged: true},
+		{name: "offset strings are invalid", timezone: "+05:00", want: "America/Chicago", logged: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := zerolog.New(&buf)
+			loc := FacilityLocation(dbgen.Facility{ID: 7, Timezone: tt.timezone}, &logger)
+			if loc.String() != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, loc)
+			}
+			if logged := strings.Contains(buf.String(), `"facility_id":7`); logged != tt.logged {
+				t.Fatalf("expected logged=%v, got log %q", tt.logged, buf.String())
+			}
+		})
+	}
+
+	if FacilityLocation(dbgen.Facility{Timezone: "Mars/Olympus_Mons"}, nil).String() != "America/Chicago" {
+		t.Fatal("expected a nil logger to fall back quietly")
+	}
+}
+
+func TestDefaultLocation(t *testing.T) {
+	t.Cleanup(func() {
+		defaultLocation.Store(nil)
+	})
+
+	if DefaultLocation() != time.UTC {
+		t.Fatalf("expected UTC before configuration, got %s", DefaultLocation())
+	}
+	if err := SetDefaultTimezone("Not/AZone"); err == nil {
+		t.Fatal("expected an invalid default timezone to be rejected")
+	}
+	if err := SetDefaultTimezone(""); err != nil || DefaultLocation() != time.UTC {
+		t.Fatalf("expected an empty default to mean UTC, got %s (%v)", DefaultLocation(), err)
+	}
+}
+
+// The helper must keep the facility-local calendar day the booking window
+// counts from, even when the instant is on the other side of UTC midnight.
+func TestFacilityLocation_DayBoundaryNearMidnight(t *testing.T) {
+	loc := FacilityLocation(dbgen.Facility{Timezone: "America/Los_Angeles"}, nil)
+	direct, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	instant := time.Date(2026, 3, 8, 7, 30, 0, 0, time.UTC) // 23:30 on Mar 7 in Los Angeles
+	got := instant.In(loc)
+	want := instant.In(direct)
+	if got.Format(time.DateTime) != want.Format(time.DateTime) {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if got.Day() != 7 {
+		t.Fatalf("expected facility-local day 7, got %d", got.Day())
+	}
+	today := time.Date(got.Year(), got.Month(), got.Day(), 0, 0, 0, 0, loc)
+	if maxDate := today.AddDate(0, 0, 1); maxDate.Format(time.DateOnly) != "2026-03-08" {
+		t.Fatalf("expected window to end 2026-03-08, got %s", maxDate.Format(time.DateOnly))
+	}
+}
@@ -0,0 +1,160 @@
+package apiutil
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// visitExportPageSize bounds how many visits are held in memory while the
+// export streams.
+const visitExportPageSize = 500
+
+// visitExportYearPadding widens the UTC query window so every facility's local
+// year is covered; rows outside the local year are dropped as they stream.
+const visitExportYearPadding = 14 * time.Hour
+
+// VisitExport selects the paid visits written by WriteVisitHistoryCSV.
+type VisitExport struct {
+	UserID int64
+	Year   int
+	// FacilityID limits the export to one facility; zero exports every
+	// facility.
+	FacilityID int64
+	Filename   string
+}
+
+// ParseVisitExportYear reads the year query parameter, defaulting to the
+// current year.
+func ParseVisitExportYear(raw string, now time.Time) (int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return now.Year(), nil
+	}
+	year, err := strconv.Atoi(raw)
+	if err != nil || year < 2000 || year > now.Year()+1 {
+		return 0, fmt.Errorf("year must be between 2000 and %d", now.Year()+1)
+	}
+	return year, nil
+}
+
+// WriteVisitHistoryCSV streams a member's paid visits for a year as CSV: their
+// reservations and visit pack redemptions, oldest first, with times in each
+// visit's facility timezone. Visits are read a page at a time and flushed to
+// the client as they are written, so long histories are never buffered.
+func WriteVisitHistoryCSV(ctx context.Context, w http.ResponseWriter, q *dbgen.Queries, facilities FacilityQuerier, export VisitExport, logger *zerolog.Logger) {
+	startFrom := time.Date(export.Year, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-visitExportYearPadding)
+	startBefore := time.Date(export.Year+1, time.January, 1, 0, 0, 0, 0, time.UTC).Add(visitExportYearPadding)
+	var facilityID sql.NullInt64
+	if export.FacilityID > 0 {
+		facilityID = sql.NullInt64{Int64: export.FacilityID, Valid: true}
+	}
+	loadPage := func(offset int64) ([]dbgen.ListMemberPaidVisitsPageRow, error) {
+		return q.ListMemberPaidVisitsPage(ctx, dbgen.ListMemberPaidVisitsPageParams{
+			UserID:      sql.NullInt64{Int64: export.UserID, Valid: true},
+			StartFrom:   startFrom,
+			StartBefore: startBefore,
+			FacilityID:  facilityID,
+			PageOffset:  offset,
+			PageSize:    visitExportPageSize,
+		})
+	}
+
+	// The first page is loaded before any output so a failure can still be
+	// reported with a status code.
+	rows, err := loadPage(0)
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", export.UserID).Msg("Failed to load visit history")
+		http.Error(w, "Failed to export visit history", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", export.Filename))
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{
+		"Date",
+		"Time",
+		"Facility",
+		"Reservation Type",
+		"Court",
+		"Source",
+	}); err != nil {
+		logger.Error().Err(err).Int64("member_id", export.UserID).Msg("Failed to write visit history CSV header")
+		return
+	}
+
+	locations := make(map[int64]*time.Location)
+	facilityNames := make(map[int64]string)
+	var offset int64
+	for {
+		for _, row := range rows {
+			loc, ok := locations[row.FacilityID]
+			if !ok {
+				loc = DefaultLocation()
+				facilityName := fmt.Sprintf("Facility %d", row.FacilityID)
+				facility, err := facilities.GetFacilityByID(ctx, row.FacilityID)
+				if err != nil {
+					logger.Error().Err(err).Int64("facility_id", row.FacilityID).Msg("Failed to load facility for visit history")
+				} else {
+					loc = FacilityLocation(facility, logger)
+					facilityName = facility.Name
+				}
+				locations[row.FacilityID] = loc
+				facilityNames[row.FacilityID] = facilityName
+			}
+
+			visitAt := row.VisitAt.In(loc)
+			if visitAt.Year() != export.Year {
+				continue
+			}
+			source := "Membership"
+			if row.VisitPackID > 0 {
+				source = fmt.Sprintf("Visit pack %d", row.VisitPackID)
+			}
+			if err := writer.Write([]string{
+				visitAt.Format("2006-01-02"),
+				visitAt.Format("15:04 MST"),
+				SanitizeCSVField(facilityNames[row.FacilityID]),
+				SanitizeCSVField(row.ReservationType),
+				SanitizeCSVField(row.CourtNames),
+				source,
+			}); err != nil {
+				logger.Error().Err(err).Int64("member_id", export.UserID).Msg("Failed to write visit history CSV row")
+				return
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			logger.Error().Err(err).Int64("member_id", export.UserID).Msg("Failed to write visit history CSV")
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if len(rows) < visitExportPageSize {
+			return
+		}
+
+		offset += visitExportPageSize
+		rows, err = loadPage(offset)
+		if err != nil {
+			// The status line is already sent; the truncated file is the
+			// best the client can get.
+			logger.Error().Err(err).Int64("member_id", export.UserID).Int64("offset", offset).Msg("Failed to load visit history page")
+			return
+		}
+	}
+}
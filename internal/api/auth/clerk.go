@@ -92,7 +92,7 @@ func findLocalUserFromClerk(ctx context.Context, clerkUser *clerk.User) (dbgen.U
 	if clerkUser.PrimaryEmailAddressID != nil {
 		for _, email := range clerkUser.EmailAddresses {
 			if email.ID == *clerkUser.PrimaryEmailAddressID {
-				user, err := queries.GetUserByEmail(ctx, sql.NullString{String: email.EmailAddress, Valid: true})
+				user, err := loginUser(queries.GetUserByEmail(ctx, sql.NullString{String: email.EmailAddress, Valid: true}))
 				if err == nil {
 					return user, nil
 				}
@@ -112,7 +112,7 @@ func findLocalUserFromClerk(ctx context.Context, clerkUser *clerk.User) (dbgen.U
 				if normalized == "" {
 					break // Invalid phone format, try other identifiers
 				}
-				user, err := queries.GetUserByPhone(ctx, sql.NullString{String: normalized, Valid: true})
+				user, err := loginUser(queries.GetUserByPhone(ctx, sql.NullString{String: normalized, Valid: true}))
 				if err == nil {
 					return user, nil
 				}
@@ -126,7 +126,7 @@ func findLocalUserFromClerk(ctx context.Context, clerkUser *clerk.User) (dbgen.U
 
 	// Try all emails
 	for _, email := range clerkUser.EmailAddresses {
-		user, err := queries.GetUserByEmail(ctx, sql.NullString{String: email.EmailAddress, Valid: true})
+		user, err := loginUser(queries.GetUserByEmail(ctx, sql.NullString{String: email.EmailAddress, Valid: true}))
 		if err == nil {
 			return user, nil
 		}
@@ -141,7 +141,7 @@ func findLocalUserFromClerk(ctx context.Context, clerkUser *clerk.User) (dbgen.U
 		if normalized == "" {
 			continue // Invalid phone format, try next
 		}
-		user, err := queries.GetUserByPhone(ctx, sql.NullString{String: normalized, Valid: true})
+		user, err := loginUser(queries.GetUserByPhone(ctx, sql.NullString{String: normalized, Valid: true}))
 		if err == nil {
 			return user, nil
 		}
@@ -31,6 +31,9 @@ var otpLimiter *ratelimit.Limiter
 var trustProxy bool
 
 // Used to mask timing differences when a user record does not exist.
+// userStatusDeleted marks a soft-deleted account.
+const userStatusDeleted = "deleted"
+
 const dummyPasswordHash = "$2a$10$6bhr8BjYp8rXJejsIExR7uOrcalHplR0RnnoSJk5mZXv5fNru2udi"
 
 // Dev mode bypass constants - only active when environment == "development"
@@ -124,6 +127,8 @@ func HandleLogout(w http.ResponseWriter, r *http.Request) {
 		logger.Error().Err(err).Msg("Failed to parse auth cookie for logout")
 	}
 
+	revokeRequestSession(r)
+
 	// Clear both cookies to cover mixed session states.
 	ClearAuthCookie(w)
 	ClearSession(w, r)
@@ -553,7 +558,7 @@ func HandleStaffLogin(w http.ResponseWriter, r *http.Request) {
 		otpLimiter.ResetVerifyAttempts(identifier)
 	}
 
-	if err := CreateSession(w, user.ID); err != nil {
+	if err := CreateSession(w, r, user.ID); err != nil {
 		logger.Error().Err(err).Msg("Failed to create auth session")
 		http.Error(w, "Failed to start session", http.StatusInternalServerError)
 		return
@@ -772,6 +777,12 @@ func HandleConfirmResetPassword(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// A changed password signs the account out everywhere else, in case the
+	// old one leaked.
+	if _, err := revokeOtherSessions(r.Context(), r, user.ID); err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to revoke sessions after password reset")
+	}
+
 	// Successful password reset - clear attempts
 	if otpLimiter != nil {
 		otpLimiter.ResetVerifyAttempts(identifier)
@@ -936,9 +947,18 @@ func getUserByIdentifier(ctx context.Context, identifier string) (dbgen.User, er
 		if normalized == "" {
 			return dbgen.User{}, sql.ErrNoRows // Invalid phone format
 		}
-		return queries.GetUserByPhone(ctx, sql.NullString{String: normalized, Valid: true})
+		return loginUser(queries.GetUserByPhone(ctx, sql.NullString{String: normalized, Valid: true}))
+	}
+	return loginUser(queries.GetUserByEmail(ctx, sql.NullString{String: identifier, Valid: true}))
+}
+
+// loginUser treats a deleted account as missing so it can no longer sign in
+// while its contact details are still on file.
+func loginUser(user dbgen.User, err error) (dbgen.User, error) {
+	if err == nil && user.Status == userStatusDeleted {
+		return dbgen.User{}, sql.ErrNoRows
 	}
-	return queries.GetUserByEmail(ctx, sql.NullString{String: identifier, Valid: true})
+	return user, err
 }
 
 // getSentToChannel returns "phone" or "email" based on how the user authenticated.
@@ -10,11 +10,13 @@ import (
 
 	"github.com/codr1/Pickleicious/internal/api/authz"
 	"github.com/codr1/Pickleicious/internal/config"
+	"github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/testutil"
 )
 
 type authTestContext struct {
+	database   *db.DB
 	orgID      int64
 	facilityID int64
 }
@@ -71,7 +73,7 @@ func setupAuthTest(t *testing.T, env string) authTestContext {
 		t.Fatalf("insert member user: %v", err)
 	}
 
-	return authTestContext{orgID: orgID, facilityID: facilityID}
+	return authTestContext{database: database, orgID: orgID, facilityID: facilityID}
 }
 
 func TestDevBypassSendCode(t *testing.T) {
@@ -141,6 +143,35 @@ func TestDevBypassVerifyCode(t *testing.T) {
 	}
 }
 
+func TestVerifyCodeRejectsDeletedMember(t *testing.T) {
+	tc := setupAuthTest(t, devEnvironment)
+	if _, err := tc.database.Exec("UPDATE users SET status = 'deleted' WHERE email = 'member@test.com'"); err != nil {
+		t.Fatalf("delete member: %v", err)
+	}
+
+	form := url.Values{}
+	form.Set("identifier", "member@test.com")
+	form.Set("session", devBypassSession)
+	form.Set("code", devBypassCode)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/verify-code", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	ctx := authz.ContextWithOrganization(req.Context(), &authz.Organization{ID: tc.orgID, Name: "Test Org", Slug: "test-org"})
+	req = req.WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	HandleVerifyCode(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 for a deleted member, got %d: %s", rec.Code, rec.Body.String())
+	}
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == authCookieName {
+			t.Error("expected no auth cookie for a deleted member")
+		}
+	}
+}
+
 func TestDevBypassWrongCodeFails(t *testing.T) {
 	tc := setupAuthTest(t, devEnvironment)
 
@@ -0,0 +1,248 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	impersonationQueryTimeout = 5 * time.Second
+
+	impersonationActionStart   = "start"
+	impersonationActionEnd     = "end"
+	impersonationActionRequest = "request"
+)
+
+type impersonationResponse struct {
+	MemberID  int64     `json:"member_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// HandleImpersonationStart handles POST /api/v1/support/impersonate/{member_id}.
+// Admins get a short member session for the member, flagged with their own
+// user ID so the portal shows a banner, emails are suppressed, and changes
+// are audited under both identities. Facility admins may only impersonate
+// members of their own facility.
+func HandleImpersonationStart(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	memberID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("member_id")), 10, 64)
+	if err != nil || memberID <= 0 {
+		http.Error(w, "Invalid member ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), impersonationQueryTimeout)
+	defer cancel()
+
+	staffRow, err := queries.GetStaffByUserID(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load staff role for impersonation")
+		http.Error(w, "Failed to authorize request", http.StatusInternalServerError)
+		return
+	}
+	if !strings.EqualFold(staffRow.Role, "admin") {
+		logger.Warn().Int64("user_id", user.ID).Str("role", staffRow.Role).Msg("Impersonation denied: not an admin")
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	member, err := loginUser(queries.GetUserByID(ctx, memberID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Member not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to load member for impersonation")
+		http.Error(w, "Failed to load member", http.StatusInternalServerError)
+		return
+	}
+	if !member.IsMember || member.IsStaff {
+		http.Error(w, "Only members can be impersonated", http.StatusBadRequest)
+		return
+	}
+	if staffRow.HomeFacilityID.Valid && (!member.HomeFacilityID.Valid || member.HomeFacilityID.Int64 != staffRow.HomeFacilityID.Int64) {
+		logger.Warn().Int64("user_id", user.ID).Int64("member_id", memberID).Msg("Impersonation denied: member at another facility")
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	// Org admins reach any member of the organization they are signed in to.
+	if org := authz.OrganizationFromContext(r.Context()); org != nil && !staffRow.HomeFacilityID.Valid {
+		if !member.HomeFacilityID.Valid {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		facility, err := queries.GetFacilityByID(ctx, member.HomeFacilityID.Int64)
+		if err != nil {
+			logger.Error().Err(err).Int64("facility_id", member.HomeFacilityID.Int64).Msg("Failed to load member facility for impersonation")
+			http.Error(w, "Failed to authorize request", http.StatusInternalServerError)
+			return
+		}
+		if facility.OrganizationID != org.ID {
+			logger.Warn().Int64("user_id", user.ID).Int64("member_id", memberID).Msg("Impersonation denied: member in another organization")
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+	}
+
+	if err := recordImpersonation(ctx, user.ID, member.ID, impersonationActionStart); err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Int64("member_id", memberID).Msg("Failed to record impersonation start")
+		http.Error(w, "Failed to start impersonation", http.StatusInternalServerError)
+		return
+	}
+
+	var homeFacilityID *int64
+	if member.HomeFacilityID.Valid {
+		id := member.HomeFacilityID.Int64
+		homeFacilityID = &id
+	}
+	impersonatorID := user.ID
+	memberUser := &authz.AuthUser{
+		ID:              member.ID,
+		IsStaff:         false,
+		SessionType:     SessionTypeMember,
+		HomeFacilityID:  homeFacilityID,
+		MembershipLevel: member.MembershipLevel,
+		ImpersonatorID:  &impersonatorID,
+	}
+	if err := SetAuthCookie(w, r, memberUser); err != nil {
+		logger.Error().Err(err).Msg("Failed to set impersonation session")
+		http.Error(w, "Failed to start session", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info().Int64("user_id", user.ID).Int64("member_id", member.ID).Msg("Impersonation started")
+	w.Header().Set("HX-Redirect", "/member")
+	if err := apiutil.WriteJSON(w, http.StatusOK, impersonationResponse{
+		MemberID:  member.ID,
+		ExpiresAt: time.Now().Add(impersonationTTL).UTC(),
+	}); err != nil {
+		logger.Error().Err(err).Msg("Failed to write impersonation response")
+	}
+}
+
+// HandleImpersonationEnd handles POST /api/v1/support/impersonate/end. It is
+// called from the impersonated member session and swaps it back for the
+// staff member's own session.
+func HandleImpersonationEnd(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	user := authz.UserFromContext(r.Context())
+	if !authz.IsImpersonated(user) {
+		http.Error(w, "Not impersonating a member", http.StatusBadRequest)
+		return
+	}
+	if queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), impersonationQueryTimeout)
+	defer cancel()
+
+	staffID := *user.ImpersonatorID
+
+	// A revoked staff session must not be traded for a fresh one.
+	if session, err := parseAuthCookie(r); err == nil && session != nil && session.ImpersonatorID != nil {
+		active, err := impersonationSessionActive(r, session)
+		if err != nil {
+			logger.Error().Err(err).Int64("user_id", staffID).Msg("Failed to check impersonation session")
+			http.Error(w, "Failed to end impersonation", http.StatusInternalServerError)
+			return
+		}
+		if !active {
+			ClearAuthCookie(w)
+			w.Header().Set("HX-Redirect", "/login")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	if err := recordImpersonation(ctx, staffID, user.ID, impersonationActionEnd); err != nil {
+		logger.Error().Err(err).Int64("user_id", staffID).Int64("member_id", user.ID).Msg("Failed to record impersonation end")
+	}
+
+	staff, err := loginUser(queries.GetUserByID(ctx, staffID))
+	if err != nil || !staff.IsStaff {
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			logger.Error().Err(err).Int64("user_id", staffID).Msg("Failed to load staff after impersonation")
+		}
+		// The staff account is gone or no longer staff; sign out entirely.
+		ClearAuthCookie(w)
+		w.Header().Set("HX-Redirect", "/login")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var homeFacilityID *int64
+	if staff.HomeFacilityID.Valid {
+		id := staff.HomeFacilityID.Int64
+		homeFacilityID = &id
+	}
+	if err := SetAuthCookie(w, r, &authz.AuthUser{
+		ID:              staff.ID,
+		IsStaff:         true,
+		SessionType:     SessionTypeStaff,
+		HomeFacilityID:  homeFacilityID,
+		MembershipLevel: staff.MembershipLevel,
+	}); err != nil {
+		logger.Error().Err(err).Msg("Failed to restore staff session")
+		http.Error(w, "Failed to end impersonation", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info().Int64("user_id", staffID).Int64("member_id", user.ID).Msg("Impersonation ended")
+	w.Header().Set("HX-Redirect", "/")
+	w.WriteHeader(http.StatusOK)
+}
+
+// RecordImpersonatedRequest audits a request made while impersonating, with
+// the status it returned.
+func RecordImpersonatedRequest(ctx context.Context, user *authz.AuthUser, method, path string, status int) error {
+	if queries == nil || !authz.IsImpersonated(user) {
+		return nil
+	}
+	return queries.CreateImpersonationAuditEntry(ctx, dbgen.CreateImpersonationAuditEntryParams{
+		ImpersonatorUserID: *user.ImpersonatorID,
+		MemberUserID:       user.ID,
+		Action:             impersonationActionRequest,
+		Method:             sql.NullString{String: method, Valid: true},
+		Path:               sql.NullString{String: path, Valid: true},
+		Status:             sql.NullInt64{Int64: int64(status), Valid: true},
+	})
+}
+
+func recordImpersonation(ctx context.Context, staffID, memberID int64, action string) error {
+	return queries.CreateImpersonationAuditEntry(ctx, dbgen.CreateImpersonationAuditEntryParams{
+		ImpersonatorUserID: staffID,
+		MemberUserID:       memberID,
+		Action:             action,
+	})
+}
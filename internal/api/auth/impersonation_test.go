@@ -0,0 +1,237 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func TestImpersonationStartAndEnd(t *testing.T) {
+	tc := setupAuthTest(t, "production")
+
+	var memberID int64
+	if err := tc.database.QueryRow("SELECT id FROM users WHERE email = 'member@test.com'").Scan(&memberID); err != nil {
+		t.Fatalf("load member: %v", err)
+	}
+	if _, err := tc.database.Exec("UPDATE users SET membership_level = 2 WHERE id = ?", memberID); err != nil {
+		t.Fatalf("update member: %v", err)
+	}
+	adminID := addImpersonationStaff(t, tc, "admin@test.com", "admin")
+	deskID := addImpersonationStaff(t, tc, "desk@test.com", "desk")
+
+	start := func(staffID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/support/impersonate/%d", memberID), nil)
+		req.SetPathValue("member_id", fmt.Sprintf("%d", memberID))
+		homeFacilityID := tc.facilityID
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:             staffID,
+			IsStaff:        true,
+			SessionType:    SessionTypeStaff,
+			HomeFacilityID: &homeFacilityID,
+		}))
+		rec := httptest.NewRecorder()
+		HandleImpersonationStart(rec, req)
+		return rec
+	}
+	authCookie := func(rec *httptest.ResponseRecorder) *http.Cookie {
+		t.Helper()
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == authCookieName {
+				return c
+			}
+		}
+		t.Fatal("expected an auth cookie")
+		return nil
+	}
+
+	if rec := start(deskID); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected desk staff to be refused, got %d", rec.Code)
+	}
+
+	rec := start(adminID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("start status %d: %s", rec.Code, rec.Body.String())
+	}
+	cookie := authCookie(rec)
+	if cookie.MaxAge != int(impersonationTTL.Seconds()) {
+		t.Fatalf("expected a %s session, got max age %d", impersonationTTL, cookie.MaxAge)
+	}
+
+	// The impersonation cookie wins over the admin's own session token.
+	httpRec := httptest.NewRecorder()
+	if err := CreateSession(httpRec, httptest.NewRequest(http.MethodPost, "/api/v1/auth/staff-login", nil), adminID); err != nil {
+		t.Fatalf("create staff session: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/member", nil)
+	req.AddCookie(cookie)
+	for _, c := range httpRec.Result().Cookies() {
+		req.AddCookie(c)
+	}
+	user, err := UserFromRequest(httptest.NewRecorder(), req)
+	if err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if user == nil || user.ID != memberID || user.IsStaff || user.MembershipLevel != 2 || !authz.IsImpersonated(user) || *user.ImpersonatorID != adminID {
+		t.Fatalf("expected the impersonated member session, got %+v", user)
+	}
+
+	if err := RecordImpersonatedRequest(req.Context(), user, http.MethodPost, "/member/reservations", http.StatusCreated); err != nil {
+		t.Fatalf("record request: %v", err)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/support/impersonate/end", nil)
+	req = req.WithContext(authz.ContextWithUser(req.Context(), user))
+	rec = httptest.NewRecorder()
+	HandleImpersonationEnd(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("end status %d: %s", rec.Code, rec.Body.String())
+	}
+	session, err := parseAuthCookie(makeCookieRequest(authCookie(rec)))
+	if err != nil {
+		t.Fatalf("parse restored cookie: %v", err)
+	}
+	if session.UserID != adminID || session.SessionType != SessionTypeStaff || session.ImpersonatorID != nil {
+		t.Fatalf("expected the admin session back, got %+v", session)
+	}
+	if time.Until(time.Unix(session.ExpiresAt, 0)) <= impersonationTTL {
+		t.Fatal("expected the restored session to use the normal lifetime")
+	}
+
+	rows, err := tc.database.Query(
+		"SELECT action, COALESCE(path, ''), COALESCE(status, 0) FROM impersonation_audit_log WHERE impersonator_user_id = ? AND member_user_id = ? ORDER BY id",
+		adminID, memberID,
+	)
+	if err != nil {
+		t.Fatalf("load audit log: %v", err)
+	}
+	defer rows.Close()
+	var entries []string
+	for rows.Next() {
+		var action, path string
+		var status int
+		if err := rows.Scan(&action, &path, &status); err != nil {
+			t.Fatalf("scan audit log: %v", err)
+		}
+		entries = append(entries, fmt.Sprintf("%s %s %d", action, path, status))
+	}
+	want := []string{"start  0", "request /member/reservations 201", "end  0"}
+	if fmt.Sprint(entries) != fmt.Sprint(want) {
+		t.Fatalf("audit log = %q, want %q", entries, want)
+	}
+}
+
+func TestImpersonationEndsWhenAdminSessionsRevoked(t *testing.T) {
+	tc := setupAuthTest(t, "production")
+
+	var memberID int64
+	if err := tc.database.QueryRow("SELECT id FROM users WHERE email = 'member@test.com'").Scan(&memberID); err != nil {
+		t.Fatalf("load member: %v", err)
+	}
+	adminID := addImpersonationStaff(t, tc, "admin@test.com", "admin")
+	homeFacilityID := tc.facilityID
+	admin := &authz.AuthUser{
+		ID:             adminID,
+		IsStaff:        true,
+		SessionType:    SessionTypeStaff,
+		HomeFacilityID: &homeFacilityID,
+	}
+	authCookie := func(rec *httptest.ResponseRecorder) *http.Cookie {
+		t.Helper()
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == authCookieName && c.Value != "" {
+				return c
+			}
+		}
+		t.Fatal("expected an auth cookie")
+		return nil
+	}
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/support/impersonate/%d", memberID), nil)
+	req.SetPathValue("member_id", fmt.Sprintf("%d", memberID))
+	req = req.WithContext(authz.ContextWithUser(req.Context(), admin))
+	rec := httptest.NewRecorder()
+	HandleImpersonationStart(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("start status %d: %s", rec.Code, rec.Body.String())
+	}
+	impersonation := authCookie(rec)
+
+	user, err := UserFromRequest(httptest.NewRecorder(), makeCookieRequest(impersonation))
+	if err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if !authz.IsImpersonated(user) || user.ID != memberID {
+		t.Fatalf("expected the impersonated member session, got %+v", user)
+	}
+
+	// The admin signs out everywhere from another device.
+	rec = httptest.NewRecorder()
+	if err := SetAuthCookie(rec, httptest.NewRequest(http.MethodPost, "/api/v1/auth/verify-code", nil), admin); err != nil {
+		t.Fatalf("set admin cookie: %v", err)
+	}
+	req = httptest.NewRequest(http.MethodPost, staffSessionsPath+"/revoke-others", nil)
+	req.Header.Set("Accept", "application/json")
+	req.AddCookie(authCookie(rec))
+	req = req.WithContext(authz.ContextWithUser(req.Context(), admin))
+	rec = httptest.NewRecorder()
+	HandleRevokeOtherSessions(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("revoke status %d: %s", rec.Code, rec.Body.String())
+	}
+
+	user, err = UserFromRequest(httptest.NewRecorder(), makeCookieRequest(impersonation))
+	if err != nil {
+		t.Fatalf("load user after revocation: %v", err)
+	}
+	if user != nil {
+		t.Fatalf("expected the impersonation to end with the admin's sessions, got %+v", user)
+	}
+
+	// Ending the revoked impersonation must not mint a new staff session.
+	req = makeCookieRequest(impersonation)
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             memberID,
+		SessionType:    SessionTypeMember,
+		ImpersonatorID: &adminID,
+	}))
+	rec = httptest.NewRecorder()
+	HandleImpersonationEnd(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected end to be refused, got %d: %s", rec.Code, rec.Body.String())
+	}
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == authCookieName && c.Value != "" {
+			t.Fatal("expected no staff session to be issued")
+		}
+	}
+}
+
+func addImpersonationStaff(t *testing.T, tc authTestContext, email, role string) int64 {
+	t.Helper()
+	result, err := tc.database.Exec(
+		"INSERT INTO users (email, first_name, last_name, is_staff, home_facility_id, status) VALUES (?, 'Staff', 'User', 1, ?, 'active')",
+		email, tc.facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert staff user: %v", err)
+	}
+	userID, _ := result.LastInsertId()
+	if _, err := tc.database.Exec(
+		"INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, 'Staff', 'User', ?, ?)",
+		userID, tc.facilityID, role,
+	); err != nil {
+		t.Fatalf("insert staff: %v", err)
+	}
+	return userID
+}
+
+func makeCookieRequest(cookie *http.Cookie) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookie)
+	return req
+}
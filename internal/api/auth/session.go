@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
@@ -20,6 +21,7 @@ const (
 	authCookieName         = "pickleicious_auth"
 	sessionCookieName      = "pickleicious_session"
 	authSessionTTL         = 8 * time.Hour
+	impersonationTTL       = 30 * time.Minute
 	sessionTokenBytes      = 32
 	sessionCleanupInterval = 15 * time.Minute
 	SessionTypeStaff       = "staff"
@@ -33,7 +35,12 @@ type authSession struct {
 	SessionType     string `json:"session_type"`
 	HomeFacilityID  *int64 `json:"home_facility_id,omitempty"`
 	MembershipLevel int64  `json:"membership_level"`
-	ExpiresAt       int64  `json:"exp"`
+	ImpersonatorID  *int64 `json:"impersonator_id,omitempty"`
+	// SessionToken names the user_sessions row backing the cookie. Cookies
+	// issued before sessions were tracked have none and stay valid until
+	// they expire.
+	SessionToken string `json:"sid,omitempty"`
+	ExpiresAt    int64  `json:"exp"`
 }
 
 type sessionRecord struct {
@@ -52,14 +59,14 @@ func isSecureCookie() bool {
 	return appConfig == nil || appConfig.App.Environment != "development"
 }
 
-func CreateSession(w http.ResponseWriter, userID int64) error {
-	if w == nil {
-		return errors.New("session requires response writer")
+func CreateSession(w http.ResponseWriter, r *http.Request, userID int64) error {
+	if w == nil || r == nil {
+		return errors.New("session requires request and response writer")
 	}
 
 	startSessionCleanup()
 
-	if err := clearExistingSessionsForUser(userID); err != nil {
+	if err := clearExistingSessionsForUser(r.Context(), userID); err != nil {
 		return err
 	}
 
@@ -69,6 +76,12 @@ func CreateSession(w http.ResponseWriter, userID int64) error {
 	}
 
 	expiresAt := time.Now().Add(authSessionTTL)
+	if sessionTrackingEnabled(userID) {
+		// Local password login is staff-only.
+		if err := trackSession(r, token, userID, SessionTypeStaff, expiresAt); err != nil {
+			return err
+		}
+	}
 	sessionMu.Lock()
 	sessionStore[token] = sessionRecord{
 		UserID:    userID,
@@ -139,6 +152,14 @@ func ClearAuthCookie(w http.ResponseWriter) {
 }
 
 func SetAuthCookie(w http.ResponseWriter, r *http.Request, user *authz.AuthUser) error {
+	ttl := authSessionTTL
+	if authz.IsImpersonated(user) {
+		ttl = impersonationTTL
+	}
+	return setAuthCookie(w, r, user, ttl)
+}
+
+func setAuthCookie(w http.ResponseWriter, r *http.Request, user *authz.AuthUser, ttl time.Duration) error {
 	if w == nil || r == nil || user == nil {
 		return errors.New("auth session requires request, response, and user")
 	}
@@ -147,17 +168,41 @@ func SetAuthCookie(w http.ResponseWriter, r *http.Request, user *authz.AuthUser)
 		return errAuthConfigMissing
 	}
 
-	expiresAt := time.Now().Add(authSessionTTL).Unix()
+	expiresAt := time.Now().Add(ttl).Unix()
 	sessionType := user.SessionType
 	if sessionType == "" {
 		sessionType = sessionTypeFromStaff(user.IsStaff)
 	}
 	sessionType = normalizeSessionType(sessionType)
+
+	// The cookie being replaced belongs to this browser, so its session ends.
+	revokeRequestSession(r)
+
+	// Impersonation sessions belong to the staff member, so revoking their
+	// sessions ends the impersonation too.
+	ownerID, ownerType := user.ID, sessionType
+	if authz.IsImpersonated(user) {
+		ownerID, ownerType = *user.ImpersonatorID, SessionTypeStaff
+	}
+	var sessionToken string
+	if sessionTrackingEnabled(ownerID) {
+		token, err := newSessionToken()
+		if err != nil {
+			return err
+		}
+		if err := trackSession(r, token, ownerID, ownerType, time.Unix(expiresAt, 0)); err != nil {
+			return err
+		}
+		sessionToken = token
+	}
+
 	session := authSession{
 		UserID:          user.ID,
 		SessionType:     sessionType,
 		HomeFacilityID:  user.HomeFacilityID,
 		MembershipLevel: user.MembershipLevel,
+		ImpersonatorID:  user.ImpersonatorID,
+		SessionToken:    sessionToken,
 		ExpiresAt:       expiresAt,
 	}
 
@@ -180,13 +225,27 @@ func SetAuthCookie(w http.ResponseWriter, r *http.Request, user *authz.AuthUser)
 		Secure:   isSecureCookie(),
 		SameSite: http.SameSiteLaxMode,
 		Expires:  time.Unix(expiresAt, 0),
-		MaxAge:   int(authSessionTTL.Seconds()),
+		MaxAge:   int(ttl.Seconds()),
 	})
 
 	return nil
 }
 
 func UserFromRequest(w http.ResponseWriter, r *http.Request) (*authz.AuthUser, error) {
+	// An impersonation cookie outranks the staff member's own session token so
+	// the portal behaves as the member until the impersonation ends.
+	if session, err := parseAuthCookie(r); err == nil && session != nil && session.ImpersonatorID != nil {
+		active, err := impersonationSessionActive(r, session)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			ClearAuthCookie(w)
+			return nil, nil
+		}
+		return userFromAuthSession(session), nil
+	}
+
 	user, err := userFromSessionToken(w, r)
 	if err != nil || user != nil {
 		return user, err
@@ -197,13 +256,41 @@ func UserFromRequest(w http.ResponseWriter, r *http.Request) (*authz.AuthUser, e
 		return nil, err
 	}
 
+	if session.SessionToken != "" {
+		active, err := sessionActive(r, session.SessionToken, session.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			ClearAuthCookie(w)
+			return nil, nil
+		}
+	}
+
+	return userFromAuthSession(session), nil
+}
+
+// impersonationSessionActive reports whether the staff session tracked for an
+// impersonation cookie is still live. The row is owned by the impersonator.
+func impersonationSessionActive(r *http.Request, session *authSession) (bool, error) {
+	if session.SessionToken == "" {
+		return true, nil
+	}
+	if queries == nil {
+		return false, errors.New("auth queries not initialized")
+	}
+	return sessionActive(r, session.SessionToken, *session.ImpersonatorID)
+}
+
+func userFromAuthSession(session *authSession) *authz.AuthUser {
 	return &authz.AuthUser{
 		ID:              session.UserID,
 		IsStaff:         session.SessionType == SessionTypeStaff,
 		SessionType:     session.SessionType,
 		HomeFacilityID:  session.HomeFacilityID,
 		MembershipLevel: session.MembershipLevel,
-	}, nil
+		ImpersonatorID:  session.ImpersonatorID,
+	}
 }
 
 func userFromSessionToken(w http.ResponseWriter, r *http.Request) (*authz.AuthUser, error) {
@@ -233,7 +320,17 @@ func userFromSessionToken(w http.ResponseWriter, r *http.Request) (*authz.AuthUs
 		return nil, errors.New("auth queries not initialized")
 	}
 
-	user, err := queries.GetUserByID(r.Context(), session.UserID)
+	active, err := sessionActive(r, token, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		deleteSession(token)
+		ClearSessionCookie(w)
+		return nil, nil
+	}
+
+	user, err := loginUser(queries.GetUserByID(r.Context(), session.UserID))
 	if err != nil {
 		deleteSession(token)
 		ClearSessionCookie(w)
@@ -367,16 +464,29 @@ func pruneExpiredSessions() {
 		}
 	}
 	sessionMu.Unlock()
+
+	pruneExpiredUserSessions(now)
 }
 
-func clearExistingSessionsForUser(userID int64) error {
+func clearExistingSessionsForUser(ctx context.Context, userID int64) error {
+	var cleared []string
 	sessionMu.Lock()
 	for token, session := range sessionStore {
 		if session.UserID == userID {
 			delete(sessionStore, token)
+			cleared = append(cleared, token)
 		}
 	}
 	sessionMu.Unlock()
+
+	if queries == nil {
+		return nil
+	}
+	for _, token := range cleared {
+		if err := queries.RevokeUserSessionByToken(ctx, token); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,347 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/ratelimit"
+	authtempl "github.com/codr1/Pickleicious/internal/templates/components/auth"
+)
+
+const (
+	sessionQueryTimeout = 5 * time.Second
+	// sessionTouchInterval throttles last-seen updates to one write per
+	// session every few minutes.
+	sessionTouchInterval = 5 * time.Minute
+	maxUserAgentLength   = 512
+
+	memberSessionsPath = "/member/security/sessions"
+	staffSessionsPath  = "/staff/security/sessions"
+)
+
+type userSessionResponse struct {
+	ID          int64     `json:"id"`
+	SessionType string    `json:"session_type"`
+	UserAgent   string    `json:"user_agent"`
+	IPAddress   string    `json:"ip_address"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	IsCurrent   bool      `json:"is_current"`
+}
+
+type userSessionListResponse struct {
+	Sessions []userSessionResponse `json:"sessions"`
+}
+
+type revokeSessionsResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
+// HandleListSessions handles GET /member/security/sessions and
+// GET /staff/security/sessions, listing where the user is signed in. The
+// session making the request is flagged as current.
+func HandleListSessions(w http.ResponseWriter, r *http.Request) {
+	user, ok := sessionOwner(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), sessionQueryTimeout)
+	defer cancel()
+
+	writeSessionList(ctx, w, r, user)
+}
+
+// HandleRevokeSession handles DELETE {sessions}/{id}. The revoked session is
+// refused on its next request. The current session is ended by signing out
+// instead, so a user cannot lock themselves out from this list.
+func HandleRevokeSession(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	user, ok := sessionOwner(w, r)
+	if !ok {
+		return
+	}
+
+	sessionID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || sessionID <= 0 {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), sessionQueryTimeout)
+	defer cancel()
+
+	if current, ok := currentUserSession(ctx, r, user.ID); ok && current.ID == sessionID {
+		http.Error(w, "Sign out to end the current session", http.StatusBadRequest)
+		return
+	}
+
+	revoked, err := queries.RevokeUserSession(ctx, dbgen.RevokeUserSessionParams{
+		ID:     sessionID,
+		UserID: user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Int64("session_id", sessionID).Msg("Failed to revoke session")
+		http.Error(w, "Failed to revoke session", http.StatusInternalServerError)
+		return
+	}
+	if revoked == 0 {
+		http.Error(w, "Session not found", http.StatusNotFound)
+		return
+	}
+
+	logger.Info().Int64("user_id", user.ID).Int64("session_id", sessionID).Msg("Session revoked")
+	if wantsSessionsHTML(r) {
+		writeSessionList(ctx, w, r, user)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleRevokeOtherSessions handles POST {sessions}/revoke-others, signing
+// the user out everywhere except the session making the request.
+func HandleRevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	user, ok := sessionOwner(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), sessionQueryTimeout)
+	defer cancel()
+
+	revoked, err := revokeOtherSessions(ctx, r, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to revoke other sessions")
+		http.Error(w, "Failed to revoke sessions", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info().Int64("user_id", user.ID).Int64("revoked", revoked).Msg("Other sessions revoked")
+	if wantsSessionsHTML(r) {
+		writeSessionList(ctx, w, r, user)
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, revokeSessionsResponse{Revoked: revoked}); err != nil {
+		logger.Error().Err(err).Msg("Failed to write revoke sessions response")
+	}
+}
+
+// sessionOwner returns the signed-in user whose sessions are being managed.
+// Staff impersonating a member cannot see or end the member's sessions.
+func sessionOwner(w http.ResponseWriter, r *http.Request) (*authz.AuthUser, bool) {
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+	if authz.IsImpersonated(user) {
+		http.Error(w, "Not available while impersonating a member", http.StatusForbidden)
+		return nil, false
+	}
+	if queries == nil {
+		log.Ctx(r.Context()).Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return nil, false
+	}
+	return user, true
+}
+
+func writeSessionList(ctx context.Context, w http.ResponseWriter, r *http.Request, user *authz.AuthUser) {
+	logger := log.Ctx(r.Context())
+
+	rows, err := queries.ListActiveUserSessions(ctx, dbgen.ListActiveUserSessionsParams{
+		UserID: user.ID,
+		Now:    time.Now().UTC(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to list sessions")
+		http.Error(w, "Failed to load sessions", http.StatusInternalServerError)
+		return
+	}
+
+	currentToken := currentSessionToken(r)
+	response := userSessionListResponse{Sessions: make([]userSessionResponse, 0, len(rows))}
+	for _, row := range rows {
+		response.Sessions = append(response.Sessions, userSessionResponse{
+			ID:          row.ID,
+			SessionType: row.SessionType,
+			UserAgent:   row.UserAgent,
+			IPAddress:   row.IpAddress,
+			CreatedAt:   row.CreatedAt,
+			LastSeenAt:  row.LastSeenAt,
+			ExpiresAt:   row.ExpiresAt,
+			IsCurrent:   currentToken != "" && row.Token == currentToken,
+		})
+	}
+
+	if wantsSessionsHTML(r) {
+		data := authtempl.SessionsData{BasePath: sessionsBasePath(user)}
+		for _, session := range response.Sessions {
+			data.Sessions = append(data.Sessions, authtempl.SessionItem{
+				ID:         session.ID,
+				UserAgent:  session.UserAgent,
+				IPAddress:  session.IPAddress,
+				CreatedAt:  session.CreatedAt,
+				LastSeenAt: session.LastSeenAt,
+				IsCurrent:  session.IsCurrent,
+			})
+		}
+		apiutil.RenderHTMLComponent(r.Context(), w, authtempl.ActiveSessions(data), nil, "Failed to render sessions", "Failed to render sessions")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Msg("Failed to write sessions response")
+	}
+}
+
+func sessionsBasePath(user *authz.AuthUser) string {
+	if user.SessionType == SessionTypeStaff {
+		return staffSessionsPath
+	}
+	return memberSessionsPath
+}
+
+func wantsSessionsHTML(r *http.Request) bool {
+	acceptHeader := strings.ToLower(r.Header.Get("Accept"))
+	wantsHTML := apiutil.IsHTMXRequest(r) || strings.Contains(acceptHeader, "text/html")
+	wantsJSON := strings.Contains(acceptHeader, "application/json")
+	return wantsHTML && (!wantsJSON || apiutil.IsHTMXRequest(r))
+}
+
+// sessionTrackingEnabled reports whether a sign-in is recorded in
+// user_sessions. The dev bypass user has no account to attach it to.
+func sessionTrackingEnabled(userID int64) bool {
+	return queries != nil && userID > 0
+}
+
+// trackSession records a sign-in under token with the device it came from.
+func trackSession(r *http.Request, token string, userID int64, sessionType string, expiresAt time.Time) error {
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	_, err := queries.CreateUserSession(r.Context(), dbgen.CreateUserSessionParams{
+		Token:       token,
+		UserID:      userID,
+		SessionType: sessionType,
+		UserAgent:   userAgent,
+		IpAddress:   ratelimit.GetClientIP(r, trustProxy),
+		CreatedAt:   time.Now().UTC(),
+		ExpiresAt:   expiresAt.UTC(),
+	})
+	return err
+}
+
+// sessionActive reports whether the tracked session behind token still
+// authenticates userID, and refreshes its last-seen time. A missing row
+// counts as revoked.
+func sessionActive(r *http.Request, token string, userID int64) (bool, error) {
+	row, err := queries.GetUserSessionByToken(r.Context(), token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	now := time.Now().UTC()
+	if row.UserID != userID || row.RevokedAt.Valid || !row.ExpiresAt.After(now) {
+		return false, nil
+	}
+
+	if now.Sub(row.LastSeenAt) >= sessionTouchInterval {
+		if err := queries.TouchUserSession(r.Context(), dbgen.TouchUserSessionParams{
+			LastSeenAt: now,
+			ID:         row.ID,
+		}); err != nil {
+			log.Ctx(r.Context()).Warn().Err(err).Int64("session_id", row.ID).Msg("Failed to update session last seen")
+		}
+	}
+	return true, nil
+}
+
+// currentSessionToken returns the token of the tracked session the request
+// authenticated with, checking cookies in the order UserFromRequest does.
+func currentSessionToken(r *http.Request) string {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		if _, ok := getSession(cookie.Value); ok {
+			return cookie.Value
+		}
+	}
+	if session, err := parseAuthCookie(r); err == nil && session != nil && session.ImpersonatorID == nil {
+		return session.SessionToken
+	}
+	return ""
+}
+
+func currentUserSession(ctx context.Context, r *http.Request, userID int64) (dbgen.UserSession, bool) {
+	token := currentSessionToken(r)
+	if token == "" {
+		return dbgen.UserSession{}, false
+	}
+	row, err := queries.GetUserSessionByToken(ctx, token)
+	if err != nil || row.UserID != userID {
+		return dbgen.UserSession{}, false
+	}
+	return row, true
+}
+
+// revokeOtherSessions ends every session of the user except the one making
+// the request, if it is theirs.
+func revokeOtherSessions(ctx context.Context, r *http.Request, userID int64) (int64, error) {
+	var keepID int64
+	if current, ok := currentUserSession(ctx, r, userID); ok {
+		keepID = current.ID
+	}
+	return queries.RevokeOtherUserSessions(ctx, dbgen.RevokeOtherUserSessionsParams{
+		UserID: userID,
+		KeepID: keepID,
+	})
+}
+
+// revokeRequestSession ends the tracked sessions behind the request's
+// cookies, on sign-out or when a new sign-in replaces them.
+func revokeRequestSession(r *http.Request) {
+	if queries == nil || r == nil {
+		return
+	}
+
+	var tokens []string
+	if cookie, err := r.Cookie(sessionCookieName); err == nil && cookie.Value != "" {
+		tokens = append(tokens, cookie.Value)
+	}
+	if session, err := parseAuthCookie(r); err == nil && session != nil && session.SessionToken != "" {
+		tokens = append(tokens, session.SessionToken)
+	}
+	for _, token := range tokens {
+		if err := queries.RevokeUserSessionByToken(r.Context(), token); err != nil {
+			log.Ctx(r.Context()).Warn().Err(err).Msg("Failed to revoke replaced session")
+		}
+	}
+}
+
+func pruneExpiredUserSessions(now time.Time) {
+	if queries == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sessionQueryTimeout)
+	defer cancel()
+	if _, err := queries.DeleteExpiredUserSessions(ctx, now.UTC()); err != nil {
+		log.Warn().Err(err).Msg("Failed to prune expired user sessions")
+	}
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func TestSessionListAndRevocation(t *testing.T) {
+	tc := setupAuthTest(t, "production")
+
+	var memberID int64
+	if err := tc.database.QueryRow("SELECT id FROM users WHERE email = 'member@test.com'").Scan(&memberID); err != nil {
+		t.Fatalf("load member: %v", err)
+	}
+	member := &authz.AuthUser{ID: memberID, SessionType: SessionTypeMember}
+
+	signIn := func(userAgent string) *http.Cookie {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/verify-code", nil)
+		req.Header.Set("User-Agent", userAgent)
+		rec := httptest.NewRecorder()
+		if err := SetAuthCookie(rec, req, member); err != nil {
+			t.Fatalf("set auth cookie: %v", err)
+		}
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == authCookieName {
+				return c
+			}
+		}
+		t.Fatal("expected an auth cookie")
+		return nil
+	}
+	authenticated := func(cookie *http.Cookie) bool {
+		t.Helper()
+		user, err := UserFromRequest(httptest.NewRecorder(), makeCookieRequest(cookie))
+		if err != nil {
+			t.Fatalf("load user: %v", err)
+		}
+		return user != nil && user.ID == memberID
+	}
+	request := func(method, target string, cookie *http.Cookie) *http.Request {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Accept", "application/json")
+		req.AddCookie(cookie)
+		return req.WithContext(authz.ContextWithUser(req.Context(), member))
+	}
+	list := func(cookie *http.Cookie) userSessionListResponse {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		HandleListSessions(rec, request(http.MethodGet, memberSessionsPath, cookie))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("list status %d: %s", rec.Code, rec.Body.String())
+		}
+		var response userSessionListResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("decode sessions: %v", err)
+		}
+		return response
+	}
+	revoke := func(cookie *http.Cookie, sessionID int64) int {
+		t.Helper()
+		req := request(http.MethodDelete, fmt.Sprintf("%s/%d", memberSessionsPath, sessionID), cookie)
+		req.SetPathValue("id", fmt.Sprintf("%d", sessionID))
+		rec := httptest.NewRecorder()
+		HandleRevokeSession(rec, req)
+		return rec.Code
+	}
+
+	laptop := signIn("Laptop")
+	phone := signIn("Phone")
+
+	sessions := list(laptop).Sessions
+	if len(sessions) != 2 {
+		t.Fatalf("expected two sessions, got %+v", sessions)
+	}
+	var currentID, phoneID int64
+	for _, session := range sessions {
+		if session.IsCurrent {
+			currentID = session.ID
+			if session.UserAgent != "Laptop" {
+				t.Fatalf("expected the laptop to be current, got %+v", session)
+			}
+		} else {
+			phoneID = session.ID
+		}
+	}
+	if currentID == 0 || phoneID == 0 {
+		t.Fatalf("expected one current session, got %+v", sessions)
+	}
+
+	if code := revoke(laptop, currentID); code != http.StatusBadRequest {
+		t.Fatalf("expected revoking the current session to be refused, got %d", code)
+	}
+	if code := revoke(laptop, phoneID); code != http.StatusNoContent {
+		t.Fatalf("revoke status %d", code)
+	}
+	if authenticated(phone) {
+		t.Fatal("expected the revoked session to be signed out")
+	}
+	if !authenticated(laptop) {
+		t.Fatal("expected the current session to stay signed in")
+	}
+	if code := revoke(laptop, phoneID); code != http.StatusNotFound {
+		t.Fatalf("expected a revoked session to be gone, got %d", code)
+	}
+
+	tablet := signIn("Tablet")
+	rec := httptest.NewRecorder()
+	HandleRevokeOtherSessions(rec, request(http.MethodPost, memberSessionsPath+"/revoke-others", laptop))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("revoke others status %d: %s", rec.Code, rec.Body.String())
+	}
+	var revoked revokeSessionsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &revoked); err != nil {
+		t.Fatalf("decode revoke response: %v", err)
+	}
+	if revoked.Revoked != 1 || authenticated(tablet) || !authenticated(laptop) {
+		t.Fatalf("expected only the tablet to be signed out, revoked %d", revoked.Revoked)
+	}
+
+	// Resetting the password from another browser signs the laptop out too.
+	if _, err := revokeOtherSessions(t.Context(), httptest.NewRequest(http.MethodPost, "/api/v1/auth/confirm-reset-password", nil), memberID); err != nil {
+		t.Fatalf("revoke after password change: %v", err)
+	}
+	if authenticated(laptop) {
+		t.Fatal("expected a password change to revoke every session")
+	}
+}
@@ -18,6 +18,9 @@ type AuthUser struct {
 	SessionType     string
 	HomeFacilityID  *int64
 	MembershipLevel int64
+	// ImpersonatorID is the staff user viewing the portal as this member.
+	// It is nil outside an impersonation session.
+	ImpersonatorID *int64
 }
 
 type StaffAccess struct {
@@ -76,6 +79,11 @@ func UserFromContext(ctx context.Context) *AuthUser {
 	return user
 }
 
+// IsImpersonated reports whether staff are acting as this user.
+func IsImpersonated(user *AuthUser) bool {
+	return user != nil && user.ImpersonatorID != nil
+}
+
 func IsStaff(user *AuthUser) bool {
 	return user != nil && user.IsStaff
 }
@@ -96,6 +104,13 @@ func CanManageStaff(requesterStaff, targetStaff StaffAccess) bool {
 	return *requesterStaff.HomeFacilityID == *targetStaff.HomeFacilityID
 }
 
+// IsOrganizationAdmin reports whether staff may manage organization-wide
+// settings. Org admins are corporate admins: the admin role with no home
+// facility.
+func IsOrganizationAdmin(staff StaffAccess) bool {
+	return strings.EqualFold(staff.Role, "admin") && staff.HomeFacilityID == nil
+}
+
 func SessionTypeFromContext(ctx context.Context) string {
 	user := UserFromContext(ctx)
 	if user == nil {
@@ -148,3 +148,21 @@ func TestCanManageStaffInsufficientRole(t *testing.T) {
 		t.Fatalf("expected non-admin role to be denied")
 	}
 }
+
+func TestIsOrganizationAdmin(t *testing.T) {
+	facilityID := int64(1)
+	cases := []struct {
+		name  string
+		staff StaffAccess
+		want  bool
+	}{
+		{name: "corporate admin", staff: StaffAccess{Role: "Admin"}, want: true},
+		{name: "facility admin", staff: StaffAccess{Role: "admin", HomeFacilityID: &facilityID}, want: false},
+		{name: "corporate manager", staff: StaffAccess{Role: "manager"}, want: false},
+	}
+	for _, tc := range cases {
+		if got := IsOrganizationAdmin(tc.staff); got != tc.want {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
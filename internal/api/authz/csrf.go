@@ -0,0 +1,41 @@
+package authz
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	// CSRFHeaderName carries the CSRF token on HTMX and script requests.
+	CSRFHeaderName = "X-CSRF-Token"
+	// CSRFFieldName carries the CSRF token in plain form posts.
+	CSRFFieldName = "csrf_token"
+)
+
+type csrfTokenContextKey struct{}
+
+func ContextWithCSRFToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, csrfTokenContextKey{}, token)
+}
+
+// CSRFTokenFromContext returns the request's CSRF token, or empty when the
+// request carries no session.
+func CSRFTokenFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	token, _ := ctx.Value(csrfTokenContextKey{}).(string)
+	return token
+}
+
+// CSRFHeaders returns the hx-headers value that sends the CSRF token with
+// every HTMX request under the element, including bodyless ones like
+// hx-delete.
+func CSRFHeaders(ctx context.Context) string {
+	token := CSRFTokenFromContext(ctx)
+	if token == "" {
+		return ""
+	}
+	headers, _ := json.Marshal(map[string]string{CSRFHeaderName: token})
+	return string(headers)
+}
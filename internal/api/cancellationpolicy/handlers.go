@@ -18,6 +18,7 @@ import (
 
 	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/models"
 	cancellationpolicytempl "github.com/codr1/Pickleicious/internal/templates/components/cancellationpolicy"
@@ -32,6 +33,7 @@ const (
 
 var (
 	queries     *dbgen.Queries
+	store       *appdb.DB
 	queriesOnce sync.Once
 )
 
@@ -43,12 +45,13 @@ type cancellationPolicyTierRequest struct {
 }
 
 // InitHandlers must be called during server startup before handling requests.
-func InitHandlers(q *dbgen.Queries) {
-	if q == nil {
+func InitHandlers(database *appdb.DB) {
+	if database == nil {
 		return
 	}
 	queriesOnce.Do(func() {
-		queries = q
+		queries = database.Queries
+		store = database
 	})
 }
 
@@ -91,7 +94,7 @@ func HandleCancellationPolicyPage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to load cancellation policy tiers", http.StatusInternalServerError)
 		return
 	}
-	reservationTypes, err := q.ListReservationTypes(ctx)
+	reservationTypes, err := q.ListReservationTypesForFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load reservation types")
 		reservationTypes = nil
@@ -157,7 +160,7 @@ func HandleCancellationPolicyTierList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	reservationTypes, err := q.ListReservationTypes(ctx)
+	reservationTypes, err := q.ListReservationTypesForFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load reservation types")
 		reservationTypes = nil
@@ -200,6 +203,10 @@ func HandleCancellationPolicyTierCreate(w http.ResponseWriter, r *http.Request)
 	ctx, cancel := context.WithTimeout(r.Context(), cancellationPolicyQueryTimeout)
 	defer cancel()
 
+	if !requireReservationTypeAvailable(ctx, w, q, facilityID, req.ReservationTypeID, logger) {
+		return
+	}
+
 	tier, err := q.CreateCancellationPolicyTier(ctx, dbgen.CreateCancellationPolicyTierParams{
 		FacilityID:        facilityID,
 		ReservationTypeID: apiutil.ToNullInt64(req.ReservationTypeID),
@@ -272,6 +279,10 @@ func HandleCancellationPolicyTierUpdate(w http.ResponseWriter, r *http.Request)
 	ctx, cancel := context.WithTimeout(r.Context(), cancellationPolicyQueryTimeout)
 	defer cancel()
 
+	if !requireReservationTypeAvailable(ctx, w, q, facilityID, req.ReservationTypeID, logger) {
+		return
+	}
+
 	tier, err := q.UpdateCancellationPolicyTier(ctx, dbgen.UpdateCancellationPolicyTierParams{
 		ID:                tierID,
 		FacilityID:        facilityID,
@@ -365,6 +376,27 @@ func HandleCancellationPolicyTierDelete(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// requireReservationTypeAvailable rejects tiers for custom reservation types
+// owned by another organization or facility.
+func requireReservationTypeAvailable(ctx context.Context, w http.ResponseWriter, q *dbgen.Queries, facilityID int64, reservationTypeID *int64, logger *zerolog.Logger) bool {
+	if reservationTypeID == nil {
+		return true
+	}
+	if _, err := q.GetReservationTypeForFacility(ctx, dbgen.GetReservationTypeForFacilityParams{
+		ID:         *reservationTypeID,
+		FacilityID: sql.NullInt64{Int64: facilityID, Valid: true},
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Facility or reservation type not found", http.StatusNotFound)
+			return false
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Int64("reservation_type_id", *reservationTypeID).Msg("Failed to load reservation type")
+		http.Error(w, "Failed to load reservation type", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
 func renderTiersAfterMutation(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries, facilityID int64, logger *zerolog.Logger) bool {
 	filterReservationTypeID, err := reservationTypeFilterIDFromRequest(r)
 	if err != nil {
@@ -382,7 +414,7 @@ func renderTiersAfterMutation(ctx context.Context, w http.ResponseWriter, r *htt
 		return false
 	}
 
-	reservationTypes, err := q.ListReservationTypes(ctx)
+	reservationTypes, err := q.ListReservationTypesForFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load reservation types")
 		reservationTypes = nil
@@ -599,6 +631,10 @@ func loadQueries() *dbgen.Queries {
 	return queries
 }
 
+func loadDB() *appdb.DB {
+	return store
+}
+
 func reservationTypeFilterIDFromRequest(r *http.Request) (*int64, error) {
 	// Query param takes precedence for GET filtering; form value supports HTMX posts.
 	raw := apiutil.FirstNonEmpty(
@@ -0,0 +1,480 @@
+// internal/api/cancellationpolicy/policies.go
+package cancellationpolicy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	facilityIDParam = "id"
+	policyKeyParam  = "policy"
+	// defaultPolicyKey names the facility-wide policy in policy paths; a
+	// reservation type ID names that type's override.
+	defaultPolicyKey = "default"
+)
+
+// A cancellation policy is the full set of tiers for a facility's default or
+// for one reservation type. Each tier refunds RefundPercentage when the
+// reservation is cancelled at least MinHoursBefore and less than
+// MaxHoursBefore hours ahead; the last tier has no maximum.
+type cancellationPolicyRequest struct {
+	ReservationTypeID *int64              `json:"reservationTypeId"`
+	Tiers             []policyTierRequest `json:"tiers"`
+}
+
+type policyTierRequest struct {
+	MinHoursBefore   *int64 `json:"minHoursBefore"`
+	MaxHoursBefore   *int64 `json:"maxHoursBefore"`
+	RefundPercentage *int64 `json:"refundPercentage"`
+}
+
+type cancellationPolicyResponse struct {
+	ReservationTypeID   *int64                           `json:"reservationTypeId"`
+	ReservationTypeName string                           `json:"reservationTypeName,omitempty"`
+	Tiers               []cancellationPolicyTierResponse `json:"tiers"`
+}
+
+type cancellationPolicyTierResponse struct {
+	ID               int64  `json:"id"`
+	MinHoursBefore   int64  `json:"minHoursBefore"`
+	MaxHoursBefore   *int64 `json:"maxHoursBefore"`
+	RefundPercentage int64  `json:"refundPercentage"`
+}
+
+// policyTier is a validated tier, ordered by MinHoursBefore.
+type policyTier struct {
+	MinHoursBefore   int64
+	RefundPercentage int64
+}
+
+// GET /api/v1/facilities/{id}/cancellation-policies
+func HandleFacilityCancellationPoliciesList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), cancellationPolicyQueryTimeout)
+	defer cancel()
+
+	policies, err := loadCancellationPolicies(ctx, q, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load cancellation policies")
+		http.Error(w, "Failed to load cancellation policies", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"policies": policies}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write cancellation policies response")
+		return
+	}
+}
+
+// POST /api/v1/facilities/{id}/cancellation-policies
+func HandleFacilityCancellationPolicyCreate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	var req cancellationPolicyRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.ReservationTypeID != nil && *req.ReservationTypeID <= 0 {
+		http.Error(w, "reservationTypeId must be a positive integer", http.StatusBadRequest)
+		return
+	}
+
+	writeCancellationPolicy(w, r, logger, facilityID, req.ReservationTypeID, req.Tiers, true)
+}
+
+// GET /api/v1/facilities/{id}/cancellation-policies/{policy}
+func HandleFacilityCancellationPolicyGet(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reservationTypeID, err := policyKeyFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), cancellationPolicyQueryTimeout)
+	defer cancel()
+
+	policy, found, err := loadCancellationPolicy(ctx, q, facilityID, reservationTypeID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load cancellation policy")
+		http.Error(w, "Failed to load cancellation policy", http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		http.Error(w, "Cancellation policy not found", http.StatusNotFound)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"policy": policy}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write cancellation policy response")
+		return
+	}
+}
+
+// PUT /api/v1/facilities/{id}/cancellation-policies/{policy} replaces every
+// tier of the policy, creating it if needed.
+func HandleFacilityCancellationPolicyUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reservationTypeID, err := policyKeyFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	var req cancellationPolicyRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeCancellationPolicy(w, r, logger, facilityID, reservationTypeID, req.Tiers, false)
+}
+
+// DELETE /api/v1/facilities/{id}/cancellation-policies/{policy}
+func HandleFacilityCancellationPolicyDelete(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reservationTypeID, err := policyKeyFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), cancellationPolicyQueryTimeout)
+	defer cancel()
+
+	deleted, err := q.DeleteCancellationPolicy(ctx, dbgen.DeleteCancellationPolicyParams{
+		FacilityID:        facilityID,
+		ReservationTypeID: apiutil.ToNullInt64(reservationTypeID),
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to delete cancellation policy")
+		http.Error(w, "Failed to delete cancellation policy", http.StatusInternalServerError)
+		return
+	}
+	if deleted == 0 {
+		http.Error(w, "Cancellation policy not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeCancellationPolicy validates tiers and stores them as the policy in
+// one transaction, so a cancellation never sees half an edit. With create set
+// an existing policy is a conflict; otherwise it is replaced.
+func writeCancellationPolicy(w http.ResponseWriter, r *http.Request, logger *zerolog.Logger, facilityID int64, reservationTypeID *int64, requested []policyTierRequest, create bool) {
+	database := loadDB()
+	if database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	tiers, err := validateCancellationPolicyTiers(requested)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), cancellationPolicyQueryTimeout)
+	defer cancel()
+
+	if !requireReservationTypeAvailable(ctx, w, database.Queries, facilityID, reservationTypeID, logger) {
+		return
+	}
+
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+		deleted, err := qtx.DeleteCancellationPolicy(ctx, dbgen.DeleteCancellationPolicyParams{
+			FacilityID:        facilityID,
+			ReservationTypeID: apiutil.ToNullInt64(reservationTypeID),
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to save cancellation policy", Err: err}
+		}
+		if create && deleted > 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "A cancellation policy already exists for this reservation type"}
+		}
+		for _, tier := range tiers {
+			if _, err := qtx.CreateCancellationPolicyTier(ctx, dbgen.CreateCancellationPolicyTierParams{
+				FacilityID:        facilityID,
+				ReservationTypeID: apiutil.ToNullInt64(reservationTypeID),
+				MinHoursBefore:    tier.MinHoursBefore,
+				RefundPercentage:  tier.RefundPercentage,
+			}); err != nil {
+				if apiutil.IsSQLiteForeignKeyViolation(err) {
+					return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Facility or reservation type not found", Err: err}
+				}
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to save cancellation policy", Err: err}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if !errors.As(err, &herr) {
+			herr = apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to save cancellation policy", Err: err}
+		}
+		if herr.Status == http.StatusInternalServerError {
+			logger.Error().Err(herr.Err).Int64("facility_id", facilityID).Msg(herr.Message)
+		}
+		http.Error(w, herr.Message, herr.Status)
+		return
+	}
+
+	policy, _, err := loadCancellationPolicy(ctx, database.Queries, facilityID, reservationTypeID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load cancellation policy")
+		http.Error(w, "Failed to load cancellation policy", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if create {
+		status = http.StatusCreated
+	}
+	if err := apiutil.WriteJSON(w, status, map[string]any{"policy": policy}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write cancellation policy response")
+		return
+	}
+}
+
+// validateCancellationPolicyTiers checks that tiers cover every lead time
+// from 0 hours up exactly once: the first starts at 0, each maxHoursBefore
+// (when given) meets the next tier's minHoursBefore, and the last tier is
+// open-ended.
+func validateCancellationPolicyTiers(requested []policyTierRequest) ([]policyTier, error) {
+	if len(requested) == 0 {
+		return nil, fmt.Errorf("at least one tier is required")
+	}
+
+	sorted := make([]policyTierRequest, len(requested))
+	copy(sorted, requested)
+	for _, tier := range sorted {
+		if tier.MinHoursBefore == nil {
+			return nil, fmt.Errorf("minHoursBefore is required for every tier")
+		}
+		if *tier.MinHoursBefore < 0 {
+			return nil, fmt.Errorf("minHoursBefore must be 0 or greater")
+		}
+		if tier.MaxHoursBefore != nil && *tier.MaxHoursBefore <= *tier.MinHoursBefore {
+			return nil, fmt.Errorf("maxHoursBefore must be greater than minHoursBefore")
+		}
+		if tier.RefundPercentage == nil {
+			return nil, fmt.Errorf("refundPercentage is required for every tier")
+		}
+		if *tier.RefundPercentage < 0 || *tier.RefundPercentage > 100 {
+			return nil, fmt.Errorf("refundPercentage must be between 0 and 100")
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return *sorted[i].MinHoursBefore < *sorted[j].MinHoursBefore
+	})
+
+	if *sorted[0].MinHoursBefore != 0 {
+		return nil, fmt.Errorf("tiers must start at 0 hours before the reservation")
+	}
+	tiers := make([]policyTier, 0, len(sorted))
+	for i, tier := range sorted {
+		if i > 0 {
+			previous := sorted[i-1]
+			if *tier.MinHoursBefore == *previous.MinHoursBefore {
+				return nil, fmt.Errorf("tiers overlap at %d hours", *tier.MinHoursBefore)
+			}
+			if previous.MaxHoursBefore != nil {
+				switch {
+				case *previous.MaxHoursBefore > *tier.MinHoursBefore:
+					return nil, fmt.Errorf("tiers overlap between %d and %d hours", *tier.MinHoursBefore, *previous.MaxHoursBefore)
+				case *previous.MaxHoursBefore < *tier.MinHoursBefore:
+					return nil, fmt.Errorf("tiers leave a gap between %d and %d hours", *previous.MaxHoursBefore, *tier.MinHoursBefore)
+				}
+			}
+		}
+		if i == len(sorted)-1 && tier.MaxHoursBefore != nil {
+			return nil, fmt.Errorf("the last tier must have no maxHoursBefore so every lead time is covered")
+		}
+		tiers = append(tiers, policyTier{
+			MinHoursBefore:   *tier.MinHoursBefore,
+			RefundPercentage: *tier.RefundPercentage,
+		})
+	}
+	return tiers, nil
+}
+
+// loadCancellationPolicies groups a facility's tiers into policies, the
+// default first and then overrides by reservation type.
+func loadCancellationPolicies(ctx context.Context, q *dbgen.Queries, facilityID int64) ([]cancellationPolicyResponse, error) {
+	tiers, err := q.ListCancellationPolicyTiers(ctx, dbgen.ListCancellationPolicyTiersParams{FacilityID: facilityID})
+	if err != nil {
+		return nil, err
+	}
+	reservationTypes, err := q.ListReservationTypesForFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	names := reservationTypeNameMap(reservationTypes)
+
+	policies := []cancellationPolicyResponse{}
+	index := make(map[int64]int)
+	for _, tier := range tiers {
+		key := int64(0)
+		if tier.ReservationTypeID.Valid {
+			key = tier.ReservationTypeID.Int64
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(policies)
+			index[key] = i
+			policy := cancellationPolicyResponse{Tiers: []cancellationPolicyTierResponse{}}
+			if tier.ReservationTypeID.Valid {
+				reservationTypeID := tier.ReservationTypeID.Int64
+				policy.ReservationTypeID = &reservationTypeID
+				policy.ReservationTypeName = names[reservationTypeID]
+			}
+			policies = append(policies, policy)
+		}
+		policies[i].Tiers = append(policies[i].Tiers, cancellationPolicyTierResponse{
+			ID:               tier.ID,
+			MinHoursBefore:   tier.MinHoursBefore,
+			RefundPercentage: tier.RefundPercentage,
+		})
+	}
+
+	// ListCancellationPolicyTiers sorts type overrides first and tiers by
+	// descending threshold; present the default first and tiers ascending.
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].ReservationTypeID == nil && policies[j].ReservationTypeID != nil
+	})
+	for i := range policies {
+		policyTiers := policies[i].Tiers
+		sort.Slice(policyTiers, func(a, b int) bool {
+			return policyTiers[a].MinHoursBefore < policyTiers[b].MinHoursBefore
+		})
+		for t := 0; t < len(policyTiers)-1; t++ {
+			maxHours := policyTiers[t+1].MinHoursBefore
+			policyTiers[t].MaxHoursBefore = &maxHours
+		}
+	}
+	return policies, nil
+}
+
+func loadCancellationPolicy(ctx context.Context, q *dbgen.Queries, facilityID int64, reservationTypeID *int64) (cancellationPolicyResponse, bool, error) {
+	policies, err := loadCancellationPolicies(ctx, q, facilityID)
+	if err != nil {
+		return cancellationPolicyResponse{}, false, err
+	}
+	for _, policy := range policies {
+		switch {
+		case reservationTypeID == nil && policy.ReservationTypeID == nil:
+			return policy, true, nil
+		case reservationTypeID != nil && policy.ReservationTypeID != nil && *reservationTypeID == *policy.ReservationTypeID:
+			return policy, true, nil
+		}
+	}
+	return cancellationPolicyResponse{}, false, nil
+}
+
+func facilityIDFromPath(r *http.Request) (int64, error) {
+	raw := strings.TrimSpace(r.PathValue(facilityIDParam))
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid facility ID")
+	}
+	return id, nil
+}
+
+// policyKeyFromPath returns nil for the default policy, otherwise the
+// reservation type ID the policy overrides.
+func policyKeyFromPath(r *http.Request) (*int64, error) {
+	raw := strings.TrimSpace(r.PathValue(policyKeyParam))
+	if strings.EqualFold(raw, defaultPolicyKey) {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("policy must be %q or a reservation type ID", defaultPolicyKey)
+	}
+	return &id, nil
+}
@@ -0,0 +1,237 @@
+package cancellationpolicy
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func setupCancellationPolicyAPITest(t *testing.T, database *db.DB) int64 {
+	t.Helper()
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	testutil.ResetHandlers(t, &queriesOnce, &queries, &store)
+	InitHandlers(database)
+	return facilityID
+}
+
+func callPolicyAPI(t *testing.T, handler http.HandlerFunc, method string, facilityID int64, policy, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	target := fmt.Sprintf("/api/v1/facilities/%d/cancellation-policies", facilityID)
+	if policy != "" {
+		target += "/" + policy
+	}
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", fmt.Sprintf("%d", facilityID))
+	if policy != "" {
+		req.SetPathValue("policy", policy)
+	}
+	homeFacilityID := facilityID
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             1,
+		IsStaff:        true,
+		HomeFacilityID: &homeFacilityID,
+	}))
+	recorder := httptest.NewRecorder()
+	handler(recorder, req)
+	return recorder
+}
+
+func TestFacilityCancellationPolicies_CRUD(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	facilityID := setupCancellationPolicyAPITest(t, database)
+	var gameTypeID int64
+	if err := database.QueryRow("SELECT id FROM reservation_types WHERE name = 'GAME'").Scan(&gameTypeID); err != nil {
+		t.Fatalf("load GAME type: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		body string
+		want string
+	}{
+		{"no tiers", `{"tiers":[]}`, "at least one tier"},
+		{"gap at zero", `{"tiers":[{"minHoursBefore":4,"refundPercentage":100}]}`, "start at 0 hours"},
+		{"duplicate threshold", `{"tiers":[{"minHoursBefore":0,"refundPercentage":0},{"minHoursBefore":0,"refundPercentage":50}]}`, "overlap at 0 hours"},
+		{"overlapping range", `{"tiers":[{"minHoursBefore":0,"maxHoursBefore":24,"refundPercentage":0},{"minHoursBefore":12,"refundPercentage":100}]}`, "overlap between 12 and 24"},
+		{"gap between tiers", `{"tiers":[{"minHoursBefore":0,"maxHoursBefore":12,"refundPercentage":0},{"minHoursBefore":24,"refundPercentage":100}]}`, "gap between 12 and 24"},
+		{"bounded last tier", `{"tiers":[{"minHoursBefore":0,"maxHoursBefore":24,"refundPercentage":0}]}`, "last tier"},
+		{"refund above 100", `{"tiers":[{"minHoursBefore":0,"refundPercentage":101}]}`, "between 0 and 100"},
+		{"missing refund", `{"tiers":[{"minHoursBefore":0}]}`, "refundPercentage is required"},
+	} {
+		recorder := callPolicyAPI(t, HandleFacilityCancellationPolicyCreate, http.MethodPost, facilityID, "", tc.body)
+		if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), tc.want) {
+			t.Fatalf("%s: expected 400 mentioning %q, got %d: %s", tc.name, tc.want, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	recorder := callPolicyAPI(t, HandleFacilityCancellationPolicyCreate, http.MethodPost, facilityID, "",
+		`{"tiers":[{"minHoursBefore":24,"refundPercentage":100},{"minHoursBefore":0,"maxHoursBefore":4,"refundPercentage":0},{"minHoursBefore":4,"maxHoursBefore":24,"refundPercentage":50}]}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("create status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := callPolicyAPI(t, HandleFacilityCancellationPolicyCreate, http.MethodPost, facilityID, "", `{"tiers":[{"minHoursBefore":0,"refundPercentage":0}]}`); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected a second default policy to conflict, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	recorder = callPolicyAPI(t, HandleFacilityCancellationPolicyCreate, http.MethodPost, facilityID, "",
+		fmt.Sprintf(`{"reservationTypeId":%d,"tiers":[{"minHoursBefore":0,"refundPercentage":25},{"minHoursBefore":48,"refundPercentage":100}]}`, gameTypeID))
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("create override status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = callPolicyAPI(t, HandleFacilityCancellationPoliciesList, http.MethodGet, facilityID, "", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("list status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var listed struct {
+		Policies []cancellationPolicyResponse `json:"policies"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	describe := func(policy cancellationPolicyResponse) string {
+		var parts []string
+		for _, tier := range policy.Tiers {
+			maxHours := "inf"
+			if tier.MaxHoursBefore != nil {
+				maxHours = fmt.Sprintf("%d", *tier.MaxHoursBefore)
+			}
+			parts = append(parts, fmt.Sprintf("%d-%s=%d", tier.MinHoursBefore, maxHours, tier.RefundPercentage))
+		}
+		return policy.ReservationTypeName + ":" + strings.Join(parts, ",")
+	}
+	if len(listed.Policies) != 2 || listed.Policies[0].ReservationTypeID != nil {
+		t.Fatalf("expected the default policy then the override, got %+v", listed.Policies)
+	}
+	if got, want := describe(listed.Policies[0]), ":0-4=0,4-24=50,24-inf=100"; got != want {
+		t.Fatalf("default policy = %q, want %q", got, want)
+	}
+	if got, want := describe(listed.Policies[1]), "GAME:0-48=25,48-inf=100"; got != want {
+		t.Fatalf("GAME policy = %q, want %q", got, want)
+	}
+
+	// Replacing a policy swaps every tier; cancellations read the new ones.
+	gameKey := fmt.Sprintf("%d", gameTypeID)
+	recorder = callPolicyAPI(t, HandleFacilityCancellationPolicyUpdate, http.MethodPut, facilityID, gameKey, `{"tiers":[{"minHoursBefore":0,"refundPercentage":10}]}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("update status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	refund, err := apiutil.ApplicableRefundPercentage(context.Background(), database.Queries, facilityID, 72, &gameTypeID)
+	if err != nil {
+		t.Fatalf("applicable refund: %v", err)
+	}
+	if refund != 10 {
+		t.Fatalf("expected the replaced GAME policy to apply, got %d", refund)
+	}
+	if recorder := callPolicyAPI(t, HandleFacilityCancellationPolicyUpdate, http.MethodPut, facilityID, gameKey, `{"tiers":[{"minHoursBefore":2,"refundPercentage":10}]}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected an invalid replacement to be rejected, got %d", recorder.Code)
+	}
+
+	recorder = callPolicyAPI(t, HandleFacilityCancellationPolicyGet, http.MethodGet, facilityID, gameKey, "")
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), `"refundPercentage":10`) {
+		t.Fatalf("get status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if recorder := callPolicyAPI(t, HandleFacilityCancellationPolicyDelete, http.MethodDelete, facilityID, gameKey, ""); recorder.Code != http.StatusNoContent {
+		t.Fatalf("delete status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := callPolicyAPI(t, HandleFacilityCancellationPolicyGet, http.MethodGet, facilityID, gameKey, ""); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected deleted override to 404, got %d", recorder.Code)
+	}
+	refund, err = apiutil.ApplicableRefundPercentage(context.Background(), database.Queries, facilityID, 12, &gameTypeID)
+	if err != nil {
+		t.Fatalf("applicable refund: %v", err)
+	}
+	if refund != 50 {
+		t.Fatalf("expected GAME to fall back to the default policy, got %d", refund)
+	}
+	if recorder := callPolicyAPI(t, HandleFacilityCancellationPolicyGet, http.MethodGet, facilityID, "weekly", ""); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected an unknown policy key to 400, got %d", recorder.Code)
+	}
+	if recorder := callPolicyAPI(t, HandleFacilityCancellationPolicyUpdate, http.MethodPut, facilityID, "999999", `{"tiers":[{"minHoursBefore":0,"refundPercentage":10}]}`); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected an unknown reservation type to 404, got %d", recorder.Code)
+	}
+}
+
+func TestFacilityCancellationPolicies_ReplaceIsAtomic(t *testing.T) {
+	database := testutil.NewInMemoryDB(t)
+	facilityID := setupCancellationPolicyAPITest(t, database)
+
+	policies := []string{
+		`{"tiers":[{"minHoursBefore":0,"refundPercentage":25},{"minHoursBefore":24,"refundPercentage":100}]}`,
+		`{"tiers":[{"minHoursBefore":0,"refundPercentage":75},{"minHoursBefore":48,"refundPercentage":100}]}`,
+	}
+	if recorder := callPolicyAPI(t, HandleFacilityCancellationPolicyUpdate, http.MethodPut, facilityID, "default", policies[0]); recorder.Code != http.StatusOK {
+		t.Fatalf("seed status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	// A cancellation 12 hours out reads either policy in full while staff
+	// keep replacing it, never the "no policy" full refund in between.
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	seen := make(chan int64, 1024)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			refund, err := apiutil.ApplicableRefundPercentage(context.Background(), database.Queries, facilityID, 12, nil)
+			if err != nil {
+				t.Errorf("applicable refund: %v", err)
+				return
+			}
+			select {
+			case seen <- refund:
+			default:
+			}
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		if recorder := callPolicyAPI(t, HandleFacilityCancellationPolicyUpdate, http.MethodPut, facilityID, "default", policies[i%2]); recorder.Code != http.StatusOK {
+			t.Fatalf("replace status %d: %s", recorder.Code, recorder.Body.String())
+		}
+	}
+	close(done)
+	wg.Wait()
+	close(seen)
+
+	for refund := range seen {
+		if refund != 25 && refund != 75 {
+			t.Fatalf("cancellation saw a partial policy: %d%% refund", refund)
+		}
+	}
+}
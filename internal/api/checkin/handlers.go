@@ -292,29 +292,7 @@ func HandleCheckin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !req.Override {
-		if !member.WaiverSigned {
-			blocked := checkinBlockResponse{
-				Status:          "blocked",
-				Reason:          "waiver_unsigned",
-				Badge:           "red",
-				WaiverSigned:    member.WaiverSigned,
-				MembershipLevel: member.MembershipLevel,
-			}
-			if apiutil.IsHTMXRequest(r) {
-				renderCheckinBlocked(r.Context(), w, blocked, member)
-				return
-			}
-			respondCheckinBlocked(w, blocked)
-			return
-		}
-		if member.MembershipLevel == membershipBlockLevel {
-			blocked := checkinBlockResponse{
-				Status:          "blocked",
-				Reason:          "membership_unverified",
-				Badge:           "yellow",
-				WaiverSigned:    member.WaiverSigned,
-				MembershipLevel: member.MembershipLevel,
-			}
+		if blocked, ok := checkinBlockFor(member); ok {
 			if apiutil.IsHTMXRequest(r) {
 				renderCheckinBlocked(r.Context(), w, blocked, member)
 				return
@@ -349,31 +327,7 @@ func HandleCheckin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if apiutil.IsHTMXRequest(r) {
-		currentVisit := checkintempl.NewFacilityVisit(visit)
-		visits, err := listTodayVisitsByUser(ctx, q, req.UserID)
-		if err != nil {
-			logger.Error().Err(err).Int64("user_id", req.UserID).Msg("Failed to load visits for check-in success")
-			visits = nil
-		}
-		activities, err := listMemberTodayActivities(ctx, q, req.UserID, req.FacilityID)
-		if err != nil {
-			logger.Error().Err(err).Int64("user_id", req.UserID).Int64("facility_id", req.FacilityID).Msg("Failed to load activities for check-in success")
-			activities = nil
-		}
-		arrivals, err := listTodayVisitsWithMembers(ctx, q, req.FacilityID)
-		if err != nil {
-			logger.Error().Err(err).Int64("facility_id", req.FacilityID).Msg("Failed to refresh arrivals list after check-in")
-			arrivals = nil
-		}
-		component := checkintempl.CheckinSuccessResponse(
-			checkintempl.NewCheckinMemberFromMember(member),
-			currentVisit,
-			activities,
-			checkintempl.NewFacilityVisits(visits),
-			arrivals,
-			req.FacilityID,
-		)
-		renderHTMLWithStatus(r.Context(), w, component, http.StatusCreated, "Failed to render check-in success response", "Failed to render check-in success")
+		renderCheckinSuccess(ctx, w, q, member, visit, http.StatusCreated)
 		return
 	}
 
@@ -423,6 +377,55 @@ func normalizeActivityType(activityType string) (sql.NullString, error) {
 	}
 }
 
+// checkinBlockFor reports why a member cannot be checked in without an override.
+func checkinBlockFor(member dbgen.GetMemberByIDRow) (checkinBlockResponse, bool) {
+	blocked := checkinBlockResponse{
+		Status:          "blocked",
+		WaiverSigned:    member.WaiverSigned,
+		MembershipLevel: member.MembershipLevel,
+	}
+	switch {
+	case !member.WaiverSigned:
+		blocked.Reason = "waiver_unsigned"
+		blocked.Badge = "red"
+	case member.MembershipLevel == membershipBlockLevel:
+		blocked.Reason = "membership_unverified"
+		blocked.Badge = "yellow"
+	default:
+		return checkinBlockResponse{}, false
+	}
+	return blocked, true
+}
+
+func renderCheckinSuccess(ctx context.Context, w http.ResponseWriter, q *dbgen.Queries, member dbgen.GetMemberByIDRow, visit dbgen.FacilityVisit, status int) {
+	logger := log.Ctx(ctx)
+
+	visits, err := listTodayVisitsByUser(ctx, q, visit.UserID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", visit.UserID).Msg("Failed to load visits for check-in success")
+		visits = nil
+	}
+	activities, err := listMemberTodayActivities(ctx, q, visit.UserID, visit.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", visit.UserID).Int64("facility_id", visit.FacilityID).Msg("Failed to load activities for check-in success")
+		activities = nil
+	}
+	arrivals, err := listTodayVisitsWithMembers(ctx, q, visit.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", visit.FacilityID).Msg("Failed to refresh arrivals list after check-in")
+		arrivals = nil
+	}
+	component := checkintempl.CheckinSuccessResponse(
+		checkintempl.NewCheckinMemberFromMember(member),
+		checkintempl.NewFacilityVisit(visit),
+		activities,
+		checkintempl.NewFacilityVisits(visits),
+		arrivals,
+		visit.FacilityID,
+	)
+	renderHTMLWithStatus(ctx, w, component, status, "Failed to render check-in success response", "Failed to render check-in success")
+}
+
 func respondCheckinBlocked(w http.ResponseWriter, response checkinBlockResponse) {
 	if err := apiutil.WriteJSON(w, http.StatusConflict, response); err != nil {
 		log.Error().Err(err).Msg("Failed to write blocked check-in response")
@@ -0,0 +1,222 @@
+// internal/api/checkin/scan.go
+package checkin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/models"
+)
+
+var cardSigner *models.MemberCardSigner
+
+type checkinScanRequest struct {
+	Payload    string `json:"payload"`
+	FacilityID int64  `json:"facilityId"`
+	Override   bool   `json:"override"`
+}
+
+type checkinScanResponse struct {
+	Member       checkinSearchCard                   `json:"member"`
+	Visit        dbgen.FacilityVisit                 `json:"visit"`
+	Reservations []dbgen.GetMemberTodayActivitiesRow `json:"reservations"`
+}
+
+// InitCardSigner must be called during server startup before handling scans.
+func InitCardSigner(signer *models.MemberCardSigner) {
+	cardSigner = signer
+}
+
+// /api/v1/checkin/scan
+func HandleCheckinScan(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil || cardSigner == nil {
+		logger.Error().Msg("Database queries or card signer not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if !authz.IsStaff(user) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	req, err := decodeCheckinScanRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Payload == "" {
+		http.Error(w, "Scan payload is required", http.StatusBadRequest)
+		return
+	}
+	if req.FacilityID <= 0 {
+		http.Error(w, "Facility ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, req.FacilityID) {
+		return
+	}
+
+	now := time.Now()
+	cardToken, err := cardSigner.Verify(req.Payload, now)
+	if err != nil {
+		if errors.Is(err, models.ErrMemberCardExpired) {
+			http.Error(w, "Member card has expired. Ask the member to open their current card.", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Invalid member card", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), checkinQueryTimeout)
+	defer cancel()
+
+	card, err := q.GetActiveMemberCardByToken(ctx, cardToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Member card has been replaced", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Msg("Failed to look up member card")
+		http.Error(w, "Failed to look up member card", http.StatusInternalServerError)
+		return
+	}
+
+	member, err := q.GetMemberByID(ctx, card.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Member not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("user_id", card.UserID).Msg("Failed to fetch member for card scan")
+		http.Error(w, "Failed to fetch member", http.StatusInternalServerError)
+		return
+	}
+
+	if !req.Override {
+		if blocked, ok := checkinBlockFor(member); ok {
+			if apiutil.IsHTMXRequest(r) {
+				renderCheckinBlocked(r.Context(), w, blocked, member)
+				return
+			}
+			respondCheckinBlocked(w, blocked)
+			return
+		}
+	}
+
+	start, end := todayRange(now)
+	activities, err := q.GetMemberTodayActivities(ctx, dbgen.GetMemberTodayActivitiesParams{
+		UserID:     member.ID,
+		FacilityID: req.FacilityID,
+		TodayStart: start,
+		TodayEnd:   end,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", member.ID).Int64("facility_id", req.FacilityID).Msg("Failed to load reservations for card scan")
+		http.Error(w, "Failed to load reservations", http.StatusInternalServerError)
+		return
+	}
+
+	relatedReservationID := sql.NullInt64{}
+	activityType := sql.NullString{}
+	if match, ok := matchScanReservation(activities, now); ok {
+		relatedReservationID = sql.NullInt64{Int64: match.ReservationID, Valid: true}
+		activityType = sql.NullString{String: match.ActivityType, Valid: true}
+	}
+
+	visit, err := q.CreateFacilityVisit(ctx, dbgen.CreateFacilityVisitParams{
+		UserID:               member.ID,
+		FacilityID:           req.FacilityID,
+		CheckOutTime:         sql.NullTime{},
+		CheckedInByStaffID:   sql.NullInt64{Int64: user.ID, Valid: true},
+		ActivityType:         activityType,
+		RelatedReservationID: relatedReservationID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", member.ID).Int64("facility_id", req.FacilityID).Msg("Failed to create facility visit from card scan")
+		http.Error(w, "Failed to check in member", http.StatusInternalServerError)
+		return
+	}
+
+	if apiutil.IsHTMXRequest(r) {
+		renderCheckinSuccess(ctx, w, q, member, visit, http.StatusCreated)
+		return
+	}
+
+	var photoID *int64
+	photoURL := ""
+	if member.PhotoID.Valid {
+		value := member.PhotoID.Int64
+		photoID = &value
+		photoURL = fmt.Sprintf("/api/v1/members/photo/%d", member.ID)
+	}
+	response := checkinScanResponse{
+		Member: checkinSearchCard{
+			ID:              member.ID,
+			FirstName:       member.FirstName,
+			LastName:        member.LastName,
+			Email:           nullString(member.Email),
+			PhotoURL:        photoURL,
+			PhotoID:         photoID,
+			WaiverSigned:    member.WaiverSigned,
+			MembershipLevel: member.MembershipLevel,
+		},
+		Visit:        visit,
+		Reservations: activities,
+	}
+	if err := apiutil.WriteJSON(w, http.StatusCreated, response); err != nil {
+		logger.Error().Err(err).Int64("visit_id", visit.ID).Msg("Failed to write card scan response")
+		return
+	}
+}
+
+// matchScanReservation picks the reservation the member is most likely
+// arriving for: the first one today that has not ended yet.
+func matchScanReservation(activities []dbgen.GetMemberTodayActivitiesRow, now time.Time) (dbgen.GetMemberTodayActivitiesRow, bool) {
+	for _, activity := range activities {
+		if activity.EndTime.After(now) {
+			return activity, true
+		}
+	}
+	return dbgen.GetMemberTodayActivitiesRow{}, false
+}
+
+func decodeCheckinScanRequest(r *http.Request) (checkinScanRequest, error) {
+	var req checkinScanRequest
+	if apiutil.IsJSONRequest(r) {
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return req, err
+		}
+		req.Payload = strings.TrimSpace(req.Payload)
+		return req, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return req, err
+	}
+
+	facilityID, err := apiutil.ParseRequiredInt64Field(apiutil.FirstNonEmpty(r.FormValue("facilityId"), r.FormValue("facility_id")), "facilityId")
+	if err != nil {
+		return req, err
+	}
+
+	req.Payload = strings.TrimSpace(r.FormValue("payload"))
+	req.FacilityID = facilityID
+	req.Override = apiutil.ParseBool(r.FormValue("override"))
+	return req, nil
+}
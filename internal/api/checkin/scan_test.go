@@ -0,0 +1,206 @@
+package checkin
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/models"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type scanFixture struct {
+	database   *db.DB
+	signer     *models.MemberCardSigner
+	facilityID int64
+	staffID    int64
+	memberID   int64
+}
+
+func setupScanTest(t *testing.T) scanFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	staffResult, err := database.Exec(
+		"INSERT INTO users (first_name, last_name, email, status) VALUES (?, ?, ?, ?)",
+		"Desk", "Staff", "staff@test.com", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert staff: %v", err)
+	}
+	staffID, _ := staffResult.LastInsertId()
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level)
+		 VALUES (?, ?, ?, ?, 1, 1, 2)`,
+		"Card", "Holder", "member@test.com", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	signer, err := models.NewMemberCardSigner("test-secret")
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+
+	queriesOnce = sync.Once{}
+	queries = nil
+	InitHandlers(database.Queries)
+	InitCardSigner(signer)
+
+	return scanFixture{
+		database:   database,
+		signer:     signer,
+		facilityID: facilityID,
+		staffID:    staffID,
+		memberID:   memberID,
+	}
+}
+
+func (f scanFixture) scan(t *testing.T, payload string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := json.Marshal(checkinScanRequest{Payload: payload, FacilityID: f.facilityID})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/checkin/scan", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	homeFacilityID := f.facilityID
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             f.staffID,
+		IsStaff:        true,
+		HomeFacilityID: &homeFacilityID,
+	}))
+
+	recorder := httptest.NewRecorder()
+	HandleCheckinScan(recorder, req)
+	return recorder
+}
+
+func TestHandleCheckinScan_RecordsVisitAgainstReservation(t *testing.T) {
+	fixture := setupScanTest(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	reservationResult, err := fixture.database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?, ?)`,
+		fixture.facilityID, fixture.memberID, fixture.memberID, now, now.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, _ := reservationResult.LastInsertId()
+
+	card, err := models.EnsureMemberCard(ctx, fixture.database.Queries, fixture.memberID)
+	if err != nil {
+		t.Fatalf("issue card: %v", err)
+	}
+
+	recorder := fixture.scan(t, fixture.signer.Sign(card.CardToken, now).Value)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response checkinScanResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if response.Member.ID != fixture.memberID {
+		t.Fatalf("expected member %d, got %d", fixture.memberID, response.Member.ID)
+	}
+	if !response.Visit.RelatedReservationID.Valid || response.Visit.RelatedReservationID.Int64 != reservationID {
+		t.Fatalf("expected visit linked to reservation %d, got %+v", reservationID, response.Visit.RelatedReservationID)
+	}
+	if len(response.Reservations) != 1 {
+		t.Fatalf("expected today's reservation in response, got %d", len(response.Reservations))
+	}
+}
+
+func TestHandleCheckinScan_WithoutReservationRecordsVisit(t *testing.T) {
+	fixture := setupScanTest(t)
+
+	card, err := models.EnsureMemberCard(context.Background(), fixture.database.Queries, fixture.memberID)
+	if err != nil {
+		t.Fatalf("issue card: %v", err)
+	}
+
+	recorder := fixture.scan(t, fixture.signer.Sign(card.CardToken, time.Now()).Value)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response checkinScanResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if response.Visit.RelatedReservationID.Valid || response.Visit.ActivityType.Valid {
+		t.Fatalf("expected a plain facility visit, got %+v", response.Visit)
+	}
+}
+
+func TestHandleCheckinScan_RejectsResetAndForgedCards(t *testing.T) {
+	fixture := setupScanTest(t)
+	ctx := context.Background()
+
+	card, err := models.EnsureMemberCard(ctx, fixture.database.Queries, fixture.memberID)
+	if err != nil {
+		t.Fatalf("issue card: %v", err)
+	}
+	lostPayload := fixture.signer.Sign(card.CardToken, time.Now()).Value
+
+	if _, err := models.ResetMemberCard(ctx, fixture.database.Queries, fixture.memberID); err != nil {
+		t.Fatalf("reset card: %v", err)
+	}
+	if recorder := fixture.scan(t, lostPayload); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected reset card to be rejected with 404, got %d", recorder.Code)
+	}
+
+	forger, _ := models.NewMemberCardSigner("wrong-secret")
+	if recorder := fixture.scan(t, forger.Sign(card.CardToken, time.Now()).Value); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected forged payload to be rejected with 400, got %d", recorder.Code)
+	}
+	if recorder := fixture.scan(t, fmt.Sprintf("%d", fixture.memberID)); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected raw member ID to be rejected with 400, got %d", recorder.Code)
+	}
+
+	var visits int
+	if err := fixture.database.QueryRow("SELECT COUNT(*) FROM facility_visits").Scan(&visits); err != nil {
+		t.Fatalf("count visits: %v", err)
+	}
+	if visits != 0 {
+		t.Fatalf("expected no visits from rejected scans, got %d", visits)
+	}
+}
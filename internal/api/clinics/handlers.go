@@ -12,7 +12,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/codr1/Pickleicious/internal/api/apiutil"
@@ -230,7 +229,7 @@ func HandleClinicSessionCreate(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to load facility", http.StatusInternalServerError)
 		return
 	}
-	facilityLoc := loadFacilityLocation(logger, facility.Timezone)
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
 
 	startTime, endTime, err := parseClinicTimes(req.StartTime, req.EndTime, facilityLoc)
 	if err != nil {
@@ -378,7 +377,7 @@ func HandleClinicSessionUpdate(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to load facility", http.StatusInternalServerError)
 		return
 	}
-	facilityLoc := loadFacilityLocation(logger, facility.Timezone)
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
 
 	_, err = q.GetClinicSession(ctx, dbgen.GetClinicSessionParams{
 		ID:         clinicID,
@@ -683,7 +682,7 @@ func parseClinicTime(value string, loc *time.Location) (time.Time, error) {
 		return time.Time{}, fmt.Errorf("time value is required")
 	}
 	if loc == nil {
-		loc = time.Local
+		loc = apiutil.DefaultLocation()
 	}
 	parsed, err := time.ParseInLocation(timeLayoutDatetimeLocal, value, loc)
 	if err == nil {
@@ -725,18 +724,6 @@ func isActiveStaffStatus(status string) bool {
 	return strings.EqualFold(status, "active")
 }
 
-func loadFacilityLocation(logger *zerolog.Logger, timezone string) *time.Location {
-	if timezone == "" {
-		return time.Local
-	}
-	loc, err := time.LoadLocation(timezone)
-	if err != nil {
-		logger.Error().Err(err).Str("timezone", timezone).Msg("Failed to load facility timezone")
-		return time.Local
-	}
-	return loc
-}
-
 func facilityIDFromRequest(r *http.Request) (int64, error) {
 	value := strings.TrimSpace(r.URL.Query().Get("facility_id"))
 	if value == "" {
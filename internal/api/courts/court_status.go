@@ -0,0 +1,249 @@
+// internal/api/courts/court_status.go
+package courts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+// reservationService cancels the reservations on a court taken out of
+// service. It is nil until InitReservationService runs.
+var reservationService *reservationsvc.Service
+
+// InitReservationService must be called during server startup, with the
+// email client and notifiers staff cancellations use, before the court status
+// endpoint handles requests.
+func InitReservationService(database *appdb.DB, emailClient *email.SESClient, notifiers ...email.Notifier) {
+	if database == nil {
+		return
+	}
+	reservationService = reservationsvc.NewService(database, emailClient, notifiers...)
+}
+
+// Court statuses. Only active courts can be booked.
+const (
+	courtStatusActive      = "active"
+	courtStatusInactive    = "inactive"
+	courtStatusMaintenance = "maintenance"
+)
+
+type courtStatusRequest struct {
+	Status string `json:"status"`
+	// Force cancels the court's current and upcoming reservations instead of
+	// refusing.
+	Force bool `json:"force"`
+}
+
+// courtReservation is a reservation on the court, with the links staff use
+// to move or cancel it.
+type courtReservation struct {
+	ID              int64     `json:"id"`
+	ReservationType string    `json:"reservation_type"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	PrimaryUserID   *int64    `json:"primary_user_id,omitempty"`
+	EditURL         string    `json:"edit_url"`
+	CancelURL       string    `json:"cancel_url"`
+}
+
+type courtStatusResponse struct {
+	ID                    int64              `json:"id"`
+	FacilityID            int64              `json:"facility_id"`
+	Name                  string             `json:"name"`
+	CourtNumber           int64              `json:"court_number"`
+	Status                string             `json:"status"`
+	CancelledReservations []courtReservation `json:"cancelled_reservations"`
+}
+
+// PUT /api/v1/courts/{id}/status
+//
+// Moves a court between active, inactive and maintenance. Taking a court out
+// of service is refused with 409 while it has reservations in progress or
+// upcoming, listed in the error details; with force they are cancelled in
+// full through the staff cancellation flow once the court stops taking
+// bookings.
+func HandleCourtStatusUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	service := reservationService
+	if q == nil || service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	courtID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || courtID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid court ID")
+		return
+	}
+
+	req, err := decodeCourtStatusRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), courtsQueryTimeout)
+	defer cancel()
+
+	court, err := q.GetCourt(ctx, courtID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Court not found")
+			return
+		}
+		logger.Error().Err(err).Int64("court_id", courtID).Msg("Failed to fetch court")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch court")
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, court.FacilityID) {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var upcoming []dbgen.ListUpcomingCourtReservationsRow
+	if req.Status != courtStatusActive {
+		upcoming, err = q.ListUpcomingCourtReservations(ctx, dbgen.ListUpcomingCourtReservationsParams{
+			CourtID: courtID,
+			Now:     time.Now(),
+		})
+		if err != nil {
+			logger.Error().Err(err).Int64("court_id", courtID).Msg("Failed to list upcoming court reservations")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to check court reservations")
+			return
+		}
+		if len(upcoming) > 0 && !req.Force {
+			body := apiutil.NewErrorBody(http.StatusConflict, fmt.Sprintf("%s has %d current or upcoming reservations; set force to cancel them", court.Name, len(upcoming)), nil)
+			body.Details = map[string]any{"reservations": courtReservations(upcoming)}
+			apiutil.WriteErrorBody(w, r, http.StatusConflict, body)
+			return
+		}
+	}
+
+	// The status changes first so nothing new is booked on the court while
+	// its reservations are cancelled; a failed cancellation can be retried.
+	updated, err := q.UpdateCourtStatus(ctx, dbgen.UpdateCourtStatusParams{
+		Status: req.Status,
+		ID:     courtID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("court_id", courtID).Msg("Failed to update court status")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update court status")
+		return
+	}
+
+	cancelled := make([]courtReservation, 0, len(upcoming))
+	for _, reservation := range upcoming {
+		_, err := service.CancelReservation(ctx, reservationsvc.CancelInput{
+			ReservationID:     reservation.ID,
+			CancelledByUserID: user.ID,
+			// The facility closed the court, so members get a full refund.
+			WaiveFee:              true,
+			RestoreLessonPackages: true,
+			NotifyPro:             true,
+			IssueCredit:           true,
+		})
+		if err != nil {
+			var herr apiutil.HandlerError
+			if errors.As(err, &herr) && herr.Status != http.StatusInternalServerError {
+				apiutil.WriteHandlerError(w, r, herr)
+				return
+			}
+			logger.Error().Err(err).Int64("court_id", courtID).Int64("reservation_id", reservation.ID).Msg("Failed to cancel reservation on court leaving service")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to cancel court reservations")
+			return
+		}
+		cancelled = append(cancelled, newCourtReservation(reservation.ID, reservation.ReservationType, reservation.StartTime, reservation.EndTime, reservation.PrimaryUserID))
+	}
+
+	logger.Info().
+		Int64("court_id", courtID).
+		Int64("facility_id", court.FacilityID).
+		Str("from_status", court.Status).
+		Str("to_status", updated.Status).
+		Int("cancelled_reservations", len(cancelled)).
+		Int64("user_id", user.ID).
+		Msg("Court status updated")
+
+	apiutil.AppendHXTrigger(w, "refreshCourtsCalendar")
+	if err := apiutil.WriteJSON(w, http.StatusOK, courtStatusResponse{
+		ID:                    updated.ID,
+		FacilityID:            updated.FacilityID,
+		Name:                  updated.Name,
+		CourtNumber:           updated.CourtNumber,
+		Status:                updated.Status,
+		CancelledReservations: cancelled,
+	}); err != nil {
+		logger.Error().Err(err).Int64("court_id", courtID).Msg("Failed to write court status response")
+	}
+}
+
+func decodeCourtStatusRequest(r *http.Request) (courtStatusRequest, error) {
+	var req courtStatusRequest
+	if apiutil.IsJSONRequest(r) {
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return courtStatusRequest{}, err
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return courtStatusRequest{}, err
+		}
+		req.Status = r.FormValue("status")
+		req.Force = apiutil.ParseBool(r.FormValue("force"))
+	}
+
+	req.Status = strings.ToLower(strings.TrimSpace(req.Status))
+	switch req.Status {
+	case courtStatusActive, courtStatusInactive, courtStatusMaintenance:
+		return req, nil
+	case "":
+		return courtStatusRequest{}, apiutil.FieldError{Field: "status", Reason: "is required"}
+	default:
+		return courtStatusRequest{}, apiutil.FieldError{Field: "status", Reason: "must be active, inactive, or maintenance"}
+	}
+}
+
+func courtReservations(rows []dbgen.ListUpcomingCourtReservationsRow) []courtReservation {
+	reservations := make([]courtReservation, 0, len(rows))
+	for _, row := range rows {
+		reservations = append(reservations, newCourtReservation(row.ID, row.ReservationType, row.StartTime, row.EndTime, row.PrimaryUserID))
+	}
+	return reservations
+}
+
+func newCourtReservation(id int64, reservationType string, start, end time.Time, primaryUserID sql.NullInt64) courtReservation {
+	reservation := courtReservation{
+		ID:              id,
+		ReservationType: reservationType,
+		StartTime:       start,
+		EndTime:         end,
+		EditURL:         fmt.Sprintf("/api/v1/reservations/%d/edit", id),
+		CancelURL:       fmt.Sprintf("/api/v1/reservations/%d", id),
+	}
+	if primaryUserID.Valid {
+		userID := primaryUserID.Int64
+		reservation.PrimaryUserID = &userID
+	}
+	return reservation
+}
@@ -0,0 +1,138 @@
+package courts
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestHandleCourtStatusUpdate_ForceCancelsUpcomingReservations(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	queriesOnce = sync.Once{}
+	queries = nil
+	InitHandlers(database.Queries)
+	InitReservationService(database, nil)
+	t.Cleanup(func() {
+		queriesOnce = sync.Once{}
+		queries = nil
+		reservationService = nil
+	})
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.Exec(query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main Facility', 'main-facility', 'UTC')", orgID)
+	userID := exec("INSERT INTO users (first_name, last_name, email, status) VALUES ('Staff', 'User', 'staff@test.com', 'active')")
+	courtID := exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 1', 1, 'active')", facilityID)
+
+	insertReservation := func(start time.Time) int64 {
+		t.Helper()
+		id := exec(
+			`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+			 VALUES (?, (SELECT id FROM reservation_types ORDER BY id LIMIT 1), ?, ?, ?, ?)`,
+			facilityID, userID, userID, start, start.Add(time.Hour),
+		)
+		exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", id, courtID)
+		return id
+	}
+	now := time.Now().UTC()
+	upcoming := insertReservation(now.AddDate(0, 0, 2).Truncate(time.Hour))
+	// A reservation being played now is still stranded by the court closing.
+	inProgress := insertReservation(now.Add(-30 * time.Minute))
+	past := insertReservation(now.AddDate(0, 0, -2).Truncate(time.Hour))
+
+	setCourtStatus := func(body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/courts/%d/status", courtID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", fmt.Sprintf("%d", courtID))
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:             userID,
+			IsStaff:        true,
+			HomeFacilityID: &facilityID,
+		}))
+		recorder := httptest.NewRecorder()
+		HandleCourtStatusUpdate(recorder, req)
+		return recorder
+	}
+	courtStatus := func() string {
+		var status string
+		if err := database.QueryRow("SELECT status FROM courts WHERE id = ?", courtID).Scan(&status); err != nil {
+			t.Fatalf("load court status: %v", err)
+		}
+		return status
+	}
+
+	if recorder := setCourtStatus(`{"status":"retired"}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown status, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder := setCourtStatus(`{"status":"maintenance"}`)
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("expected 409 without force, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var conflict struct {
+		Error struct {
+			apiutil.ErrorBody
+			Details struct {
+				Reservations []courtReservation `json:"reservations"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &conflict); err != nil {
+		t.Fatalf("decode conflict: %v", err)
+	}
+	if listed := conflict.Error.Details.Reservations; len(listed) != 2 || listed[0].ID != inProgress || listed[1].ID != upcoming {
+		t.Fatalf("expected the in-progress and upcoming reservations listed, got %+v", listed)
+	}
+	if status := courtStatus(); status != "active" {
+		t.Fatalf("refused change left court %s", status)
+	}
+
+	recorder = setCourtStatus(`{"status":"inactive","force":true}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("forced status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response courtStatusResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if response.Status != "inactive" || len(response.CancelledReservations) != 2 {
+		t.Fatalf("unexpected response %+v", response)
+	}
+	var feeWaived bool
+	if err := database.QueryRow(
+		"SELECT fee_waived FROM reservation_cancellations WHERE reservation_id = ?", upcoming,
+	).Scan(&feeWaived); err != nil || !feeWaived {
+		t.Fatalf("expected a fee-waived cancellation for the upcoming reservation (%v)", err)
+	}
+	var pastCancellations int
+	if err := database.QueryRow(
+		"SELECT COUNT(*) FROM reservation_cancellations WHERE reservation_id = ?", past,
+	).Scan(&pastCancellations); err != nil || pastCancellations != 0 {
+		t.Fatalf("past reservation cancelled %d times (%v)", pastCancellations, err)
+	}
+
+	if recorder := setCourtStatus(`{"status":"active"}`); recorder.Code != http.StatusOK {
+		t.Fatalf("reactivate status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if status := courtStatus(); status != "active" {
+		t.Fatalf("reactivated court is %s", status)
+	}
+}
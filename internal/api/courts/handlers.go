@@ -174,7 +174,7 @@ func HandleBookingFormNew(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	reservationTypes, err := q.ListReservationTypes(ctx)
+	reservationTypes, err := q.ListReservationTypesForFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to load reservation types")
 		http.Error(w, "Failed to load reservation types", http.StatusInternalServerError)
@@ -211,6 +211,7 @@ func HandleBookingFormNew(w http.ResponseWriter, r *http.Request) {
 		ReservationTypes: reservationstempl.NewReservationTypeOptions(reservationTypes),
 		Members:          reservationstempl.NewMemberOptions(memberRows),
 		SelectedCourtID:  selectedCourtID,
+		IdempotencyKey:   apiutil.NewIdempotencyKey(),
 	})
 	if err := component.Render(r.Context(), &buf); err != nil {
 		logger.Error().Err(err).Msg("Failed to render booking form")
@@ -0,0 +1,172 @@
+package dashboard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	dashboardtempl "github.com/codr1/Pickleicious/internal/templates/components/dashboard"
+)
+
+// availabilitySummaryDays is the fixed length of an availability summary.
+const availabilitySummaryDays = 7
+
+// GET /api/v1/facilities/{id}/availability/summary
+// Free courts per open hour for the Monday-to-Sunday week containing
+// ?week_of= (default this week, in facility time), as JSON or, for HTMX, as a
+// heatmap partial.
+func HandleAvailabilitySummary(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || facilityID <= 0 {
+		http.Error(w, "invalid facility ID", http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), dashboardQueryTimeout)
+	defer cancel()
+
+	facility, err := q.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Facility not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+		http.Error(w, "Failed to load facility", http.StatusInternalServerError)
+		return
+	}
+	loc := apiutil.FacilityLocation(facility, logger)
+
+	weekStart, err := parseAvailabilityWeek(r.URL.Query().Get("week_of"), time.Now().In(loc))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	summary, err := buildAvailabilitySummary(ctx, q, facilityID, weekStart)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to build availability summary")
+		http.Error(w, "Failed to load availability summary", http.StatusInternalServerError)
+		return
+	}
+
+	if apiutil.IsHTMXRequest(r) {
+		component := dashboardtempl.AvailabilitySummaryPartial(summary)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render availability summary", "Failed to render availability summary")
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, summary); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write availability summary response")
+	}
+}
+
+// buildAvailabilitySummary counts free courts for each hour of the week the
+// facility is open for at least part of. Busy courts per hour come from one
+// aggregate query over the whole week; hours and overrides only decide which
+// of those hours are reported.
+func buildAvailabilitySummary(ctx context.Context, q *dbgen.Queries, facilityID int64, weekStart time.Time) (dashboardtempl.AvailabilitySummary, error) {
+	totalCourts, err := q.CountActiveCourts(ctx, facilityID)
+	if err != nil {
+		return dashboardtempl.AvailabilitySummary{}, fmt.Errorf("count courts: %w", err)
+	}
+	hours, err := q.GetFacilityHours(ctx, facilityID)
+	if err != nil {
+		return dashboardtempl.AvailabilitySummary{}, fmt.Errorf("load operating hours: %w", err)
+	}
+	overrideRows, err := q.ListFacilityHoursOverrides(ctx, dbgen.ListFacilityHoursOverridesParams{
+		FacilityID: facilityID,
+		FromDate:   formatDate(weekStart),
+	})
+	if err != nil {
+		return dashboardtempl.AvailabilitySummary{}, fmt.Errorf("list hours overrides: %w", err)
+	}
+	overrides := make(map[string]dbgen.FacilityHoursOverride, len(overrideRows))
+	for _, override := range overrideRows {
+		overrides[override.OverrideDate] = override
+	}
+
+	weekEnd := weekStart.AddDate(0, 0, availabilitySummaryDays)
+	busyRows, err := q.CountBusyCourtsByHour(ctx, dbgen.CountBusyCourtsByHourParams{
+		RangeStart: weekStart.UTC(),
+		RangeEnd:   weekEnd.UTC(),
+		FacilityID: facilityID,
+	})
+	if err != nil {
+		return dashboardtempl.AvailabilitySummary{}, fmt.Errorf("count busy courts: %w", err)
+	}
+	busy := make(map[int64]int64, len(busyRows))
+	for _, row := range busyRows {
+		busy[row.SlotIndex] = row.BusyCourts
+	}
+
+	summary := dashboardtempl.AvailabilitySummary{
+		FacilityID:  facilityID,
+		WeekOf:      formatDate(weekStart),
+		Timezone:    weekStart.Location().String(),
+		TotalCourts: totalCourts,
+		Days:        make([]dashboardtempl.AvailabilityDay, 0, availabilitySummaryDays),
+	}
+	for day := weekStart; day.Before(weekEnd); day = day.AddDate(0, 0, 1) {
+		entry := dashboardtempl.AvailabilityDay{
+			Date:  formatDate(day),
+			Hours: []dashboardtempl.AvailabilityHour{},
+		}
+		var override *dbgen.FacilityHoursOverride
+		if row, ok := overrides[day.Format(apiutil.HoursOverrideDateLayout)]; ok {
+			override = &row
+		}
+		opensAt, closesAt, open := apiutil.OperatingHoursWindow(day, hours, override)
+		entry.Open = open
+		if open {
+			for hour := opensAt.Hour(); hour < 24; hour++ {
+				start := time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, day.Location())
+				if !start.Before(closesAt) {
+					break
+				}
+				free := totalCourts - busy[int64(start.Sub(weekStart)/time.Hour)]
+				entry.Hours = append(entry.Hours, dashboardtempl.AvailabilityHour{
+					Hour:       hour,
+					StartTime:  start,
+					FreeCourts: max(free, 0),
+				})
+			}
+		}
+		summary.Days = append(summary.Days, entry)
+	}
+	return summary, nil
+}
+
+// parseAvailabilityWeek returns facility-local midnight on the Monday of the
+// week containing raw, or of now's week when raw is blank.
+func parseAvailabilityWeek(raw string, now time.Time) (time.Time, error) {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if raw = strings.TrimSpace(raw); raw != "" {
+		parsed, err := time.ParseInLocation(dashboardDateLayout, raw, now.Location())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("week_of must be in YYYY-MM-DD format")
+		}
+		day = parsed
+	}
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)), nil
+}
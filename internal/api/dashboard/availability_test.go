@@ -0,0 +1,122 @@
+package dashboard
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	dashboardtempl "github.com/codr1/Pickleicious/internal/templates/components/dashboard"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestHandleAvailabilitySummary_CountsFreeCourtsPerOpenHour(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	queriesOnce = sync.Once{}
+	queries = nil
+	InitHandlers(database)
+	t.Cleanup(func() {
+		queriesOnce = sync.Once{}
+		queries = nil
+	})
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone, buffer_minutes) VALUES (?, 'Main', 'main', 'UTC', 30)", orgID)
+	userID := exec("INSERT INTO users (first_name, last_name, email, status) VALUES ('Mia', 'Member', 'mia@test.com', 'active')")
+	courtIDs := []int64{
+		exec("INSERT INTO courts (facility_id, name, court_number) VALUES (?, 'Court 1', 1)", facilityID),
+		exec("INSERT INTO courts (facility_id, name, court_number) VALUES (?, 'Court 2', 2)", facilityID),
+	}
+	exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 3', 3, 'inactive')", facilityID)
+	exec("INSERT INTO operating_hours (facility_id, day_of_week, opens_at, closes_at) VALUES (?, 2, '08:00', '12:00')", facilityID)
+
+	book := func(typeName string, courtID int64, startHour int) {
+		t.Helper()
+		reservationType, err := database.Queries.GetReservationTypeByName(ctx, typeName)
+		if err != nil {
+			t.Fatalf("load reservation type: %v", err)
+		}
+		// Tuesday 2026-03-03.
+		reservation, err := database.Queries.CreateReservation(ctx, dbgen.CreateReservationParams{
+			FacilityID:        facilityID,
+			ReservationTypeID: reservationType.ID,
+			CreatedByUserID:   userID,
+			StartTime:         time.Date(2026, 3, 3, startHour, 0, 0, 0, time.UTC),
+			EndTime:           time.Date(2026, 3, 3, startHour+1, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			t.Fatalf("create reservation: %v", err)
+		}
+		exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", reservation.ID, courtID)
+	}
+	// Each block's 30-minute turnover buffer spills into the next hour: court
+	// 1 stays busy into 10:00, when court 2 is down, and court 2 into 11:00.
+	book("GAME", courtIDs[0], 9)
+	book("MAINTENANCE", courtIDs[1], 10)
+
+	summarize := func(query string, htmx bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/facilities/%d/availability/summary?%s", facilityID, query), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", facilityID))
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: userID}))
+		recorder := httptest.NewRecorder()
+		HandleAvailabilitySummary(recorder, req)
+		return recorder
+	}
+
+	recorder := summarize("week_of=2026-03-04", false)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var summary dashboardtempl.AvailabilitySummary
+	if err := json.Unmarshal(recorder.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if summary.WeekOf != "2026-03-02" || summary.TotalCourts != 2 || len(summary.Days) != 7 {
+		t.Fatalf("expected the Monday-started week over two active courts, got %+v", summary)
+	}
+	for i, day := range summary.Days {
+		if i != 1 && (day.Open || len(day.Hours) != 0) {
+			t.Fatalf("expected only Tuesday open, got %+v", day)
+		}
+	}
+	tuesday := summary.Days[1]
+	want := map[int]int64{8: 2, 9: 1, 10: 0, 11: 1}
+	if tuesday.Date != "2026-03-03" || len(tuesday.Hours) != len(want) {
+		t.Fatalf("expected four open hours on Tuesday, got %+v", tuesday)
+	}
+	for _, hour := range tuesday.Hours {
+		if hour.FreeCourts != want[hour.Hour] {
+			t.Fatalf("hour %d has %d free courts, want %d", hour.Hour, hour.FreeCourts, want[hour.Hour])
+		}
+	}
+
+	recorder = summarize("week_of=2026-03-02", true)
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "Free courts by hour") {
+		t.Fatalf("expected the heatmap partial, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := summarize("week_of=March", false); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a malformed week_of, got %d", recorder.Code)
+	}
+}
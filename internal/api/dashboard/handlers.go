@@ -11,7 +11,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/codr1/Pickleicious/internal/api/apiutil"
@@ -34,6 +33,8 @@ const (
 	dateRangeThisMonth    = "this_month"
 	dateRangeThisYear     = "this_year"
 	dateRangeCustom       = "custom"
+	// noShowListLimit caps how many no-shows are listed; the count covers all.
+	noShowListLimit = 10
 )
 
 var (
@@ -78,7 +79,7 @@ func HandleDashboardPage(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	facilityName := ""
-	facilityLoc := time.Local
+	facilityLoc := apiutil.DefaultLocation()
 	if facilityID > 0 {
 		facility, err := q.GetFacilityByID(ctx, facilityID)
 		if err != nil {
@@ -91,7 +92,7 @@ func HandleDashboardPage(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		facilityName = facility.Name
-		facilityLoc = loadFacilityLocation(logger, facility.Timezone)
+		facilityLoc = apiutil.FacilityLocation(facility, logger)
 	} else {
 		facilityName = "All Facilities"
 	}
@@ -180,7 +181,7 @@ func HandleDashboardMetrics(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	facilityName := ""
-	facilityLoc := time.Local
+	facilityLoc := apiutil.DefaultLocation()
 	if facilityID > 0 {
 		facility, err := q.GetFacilityByID(ctx, facilityID)
 		if err != nil {
@@ -193,7 +194,7 @@ func HandleDashboardMetrics(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		facilityName = facility.Name
-		facilityLoc = loadFacilityLocation(logger, facility.Timezone)
+		facilityLoc = apiutil.FacilityLocation(facility, logger)
 	} else {
 		facilityName = "All Facilities"
 	}
@@ -319,6 +320,26 @@ func buildDashboardData(ctx context.Context, q *dbgen.Queries, facilityID int64,
 		}
 	}
 
+	noShowRows, err := q.ListNoShowReservationsInRange(ctx, dbgen.ListNoShowReservationsInRangeParams{
+		FacilityID:     facilityID,
+		StartTime:      startTime,
+		EndTime:        endTime,
+		ComparisonTime: comparisonTime,
+	})
+	if err != nil {
+		return dashboardtempl.DashboardData{}, err
+	}
+	noShows := make([]dashboardtempl.NoShowReservation, 0, min(len(noShowRows), noShowListLimit))
+	for _, row := range noShowRows[:min(len(noShowRows), noShowListLimit)] {
+		noShows = append(noShows, dashboardtempl.NoShowReservation{
+			ReservationID: row.ID,
+			TypeName:      row.ReservationTypeName.String,
+			MemberName:    strings.TrimSpace(row.PrimaryFirstName.String + " " + row.PrimaryLastName.String),
+			StartTime:     row.StartTime.In(facilityLoc),
+			EndTime:       row.EndTime.In(facilityLoc),
+		})
+	}
+
 	return dashboardtempl.DashboardData{
 		FacilityID:      facilityID,
 		FacilityName:    facilityName,
@@ -333,6 +354,8 @@ func buildDashboardData(ctx context.Context, q *dbgen.Queries, facilityID int64,
 			TotalRefundPercentage: cancellationMetrics.TotalRefundPercentage,
 		},
 		CheckinCount: checkinsCount,
+		NoShowCount:  int64(len(noShowRows)),
+		NoShows:      noShows,
 		Granularity:  granularity,
 	}, nil
 }
@@ -524,18 +547,6 @@ func respondAccessError(w http.ResponseWriter, r *http.Request, facilityID int64
 	}
 }
 
-func loadFacilityLocation(logger *zerolog.Logger, timezone string) *time.Location {
-	if timezone == "" {
-		return time.Local
-	}
-	loc, err := time.LoadLocation(timezone)
-	if err != nil {
-		logger.Error().Err(err).Str("timezone", timezone).Msg("Failed to load facility timezone")
-		return time.Local
-	}
-	return loc
-}
-
 func loadQueries() *dbgen.Queries {
 	return queries
 }
@@ -0,0 +1,225 @@
+package dashboard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	dashboardtempl "github.com/codr1/Pickleicious/internal/templates/components/dashboard"
+)
+
+// GET /api/v1/facilities/{id}/dashboard
+// Today at a glance for the front desk: reservations by type, open play fill,
+// lessons per pro, pending waitlist offers, and courts under maintenance.
+// "Today" is the current day in the facility's timezone. HTMX requests get
+// the partial; other callers get JSON.
+func HandleTodayDashboard(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || facilityID <= 0 {
+		http.Error(w, "invalid facility ID", http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), dashboardQueryTimeout)
+	defer cancel()
+
+	facility, err := q.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Facility not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+		http.Error(w, "Failed to load facility", http.StatusInternalServerError)
+		return
+	}
+	loc := apiutil.FacilityLocation(facility, logger)
+
+	snapshot, err := buildTodaySnapshot(ctx, q, facility, loc, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to build today dashboard")
+		http.Error(w, "Failed to load dashboard", http.StatusInternalServerError)
+		return
+	}
+
+	if apiutil.IsHTMXRequest(r) {
+		component := dashboardtempl.TodayPartial(snapshot)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render today dashboard", "Failed to render dashboard")
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, snapshot); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write today dashboard response")
+		return
+	}
+}
+
+// buildTodaySnapshot runs one query per section over the facility's current
+// day, so the cost stays flat however the frontend lays the sections out.
+func buildTodaySnapshot(ctx context.Context, q *dbgen.Queries, facility dbgen.Facility, loc *time.Location, now time.Time) (dashboardtempl.TodaySnapshot, error) {
+	local := now.In(loc)
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	snapshot := dashboardtempl.TodaySnapshot{
+		FacilityID:             facility.ID,
+		FacilityName:           facility.Name,
+		Date:                   dayStart.Format(dashboardDateLayout),
+		Timezone:               loc.String(),
+		ReservationsByType:     []dashboardtempl.TodayTypeCount{},
+		OpenPlaySessions:       []dashboardtempl.TodayOpenPlaySession{},
+		LessonsByPro:           []dashboardtempl.TodayProLessons{},
+		PendingWaitlistOffers:  []dashboardtempl.TodayWaitlistOffer{},
+		CourtsUnderMaintenance: []dashboardtempl.TodayMaintenanceCourt{},
+	}
+
+	typeCounts, err := q.CountReservationTypeNamesInRange(ctx, dbgen.CountReservationTypeNamesInRangeParams{
+		FacilityID: facility.ID,
+		StartTime:  dayStart.UTC(),
+		EndTime:    dayEnd.UTC(),
+	})
+	if err != nil {
+		return snapshot, fmt.Errorf("count reservations by type: %w", err)
+	}
+	for _, row := range typeCounts {
+		snapshot.ReservationTotal += row.ReservationCount
+		snapshot.ReservationsByType = append(snapshot.ReservationsByType, dashboardtempl.TodayTypeCount{
+			TypeName: row.ReservationTypeName,
+			Count:    row.ReservationCount,
+		})
+	}
+
+	sessions, err := q.ListOpenPlaySessionFillInRange(ctx, dbgen.ListOpenPlaySessionFillInRangeParams{
+		FacilityID: facility.ID,
+		StartTime:  dayStart.UTC(),
+		EndTime:    dayEnd.UTC(),
+	})
+	if err != nil {
+		return snapshot, fmt.Errorf("list open play sessions: %w", err)
+	}
+	for _, row := range sessions {
+		courts := row.CurrentCourtCount
+		if courts <= 0 {
+			courts = row.MinCourts
+		}
+		capacity := courts * row.MaxParticipantsPerCourt
+		var fillRate float64
+		if capacity > 0 {
+			fillRate = float64(row.ParticipantCount) / float64(capacity)
+		}
+		snapshot.OpenPlaySessions = append(snapshot.OpenPlaySessions, dashboardtempl.TodayOpenPlaySession{
+			ID:              row.ID,
+			RuleName:        row.RuleName,
+			StartTime:       row.StartTime.In(loc),
+			EndTime:         row.EndTime.In(loc),
+			Status:          row.Status,
+			Participants:    row.ParticipantCount,
+			MinParticipants: row.MinParticipants,
+			Capacity:        capacity,
+			FillRate:        fillRate,
+		})
+	}
+
+	lessons, err := q.CountProSessionsByProInRange(ctx, dbgen.CountProSessionsByProInRangeParams{
+		FacilityID: facility.ID,
+		StartTime:  dayStart.UTC(),
+		EndTime:    dayEnd.UTC(),
+	})
+	if err != nil {
+		return snapshot, fmt.Errorf("count lessons by pro: %w", err)
+	}
+	for _, row := range lessons {
+		snapshot.LessonsByPro = append(snapshot.LessonsByPro, dashboardtempl.TodayProLessons{
+			ProID:   row.ProID,
+			ProName: strings.TrimSpace(row.FirstName + " " + row.LastName),
+			Lessons: row.LessonCount,
+		})
+	}
+
+	offers, err := q.ListPendingWaitlistOffersForFacility(ctx, dbgen.ListPendingWaitlistOffersForFacilityParams{
+		FacilityID:     facility.ID,
+		ComparisonTime: now.UTC(),
+	})
+	if err != nil {
+		return snapshot, fmt.Errorf("list pending waitlist offers: %w", err)
+	}
+	for _, row := range offers {
+		snapshot.PendingWaitlistOffers = append(snapshot.PendingWaitlistOffers, dashboardtempl.TodayWaitlistOffer{
+			ID:              row.ID,
+			MemberName:      strings.TrimSpace(row.FirstName + " " + row.LastName),
+			TargetDate:      row.TargetDate.Format(dashboardDateLayout),
+			TargetStartTime: formatTimeOfDay(row.TargetStartTime),
+			TargetEndTime:   formatTimeOfDay(row.TargetEndTime),
+			ExpiresAt:       row.ExpiresAt.In(loc),
+		})
+	}
+
+	maintenance, err := q.ListMaintenanceCourtsInRange(ctx, dbgen.ListMaintenanceCourtsInRangeParams{
+		FacilityID: facility.ID,
+		StartTime:  dayStart.UTC(),
+		EndTime:    dayEnd.UTC(),
+	})
+	if err != nil {
+		return snapshot, fmt.Errorf("list courts under maintenance: %w", err)
+	}
+	for _, row := range maintenance {
+		count := len(snapshot.CourtsUnderMaintenance)
+		if count == 0 || snapshot.CourtsUnderMaintenance[count-1].CourtID != row.CourtID {
+			snapshot.CourtsUnderMaintenance = append(snapshot.CourtsUnderMaintenance, dashboardtempl.TodayMaintenanceCourt{
+				CourtID:     row.CourtID,
+				CourtName:   row.Name,
+				CourtNumber: row.CourtNumber,
+				Status:      row.Status,
+				Blocks:      []dashboardtempl.TodayTimeBlock{},
+			})
+			count++
+		}
+		if row.StartTime.Valid && row.EndTime.Valid {
+			court := &snapshot.CourtsUnderMaintenance[count-1]
+			court.Blocks = append(court.Blocks, dashboardtempl.TodayTimeBlock{
+				StartTime: row.StartTime.Time.In(loc),
+				EndTime:   row.EndTime.Time.In(loc),
+			})
+		}
+	}
+
+	return snapshot, nil
+}
+
+// formatTimeOfDay renders a TIME column, which the driver may hand back as a
+// time.Time, bytes, or a string.
+func formatTimeOfDay(value interface{}) string {
+	switch typed := value.(type) {
+	case time.Time:
+		return typed.Format("15:04")
+	case []byte:
+		return string(typed)
+	case string:
+		return typed
+	default:
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(value)
+	}
+}
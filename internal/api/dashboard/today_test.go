@@ -0,0 +1,199 @@
+package dashboard
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dashboardtempl "github.com/codr1/Pickleicious/internal/templates/components/dashboard"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestBuildTodaySnapshot_UsesFacilityDay(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	typeID := func(name string) int64 {
+		t.Helper()
+		var id int64
+		if err := database.QueryRowContext(ctx, "SELECT id FROM reservation_types WHERE name = ?", name).Scan(&id); err != nil {
+			t.Fatalf("load %s type: %v", name, err)
+		}
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main', 'main', 'America/New_York')", orgID)
+	userID := exec("INSERT INTO users (first_name, last_name, email, status, is_member) VALUES ('Mia', 'Member', 'mia@test.com', 'active', 1)")
+	proUserID := exec("INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES ('Pat', 'Pro', 'pat@test.com', 'active', 1)")
+	proID := exec("INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, 'Pat', 'Pro', ?, 'pro')", proUserID, facilityID)
+	court1 := exec("INSERT INTO courts (facility_id, name, court_number) VALUES (?, 'Court 1', 1)", facilityID)
+	court2 := exec("INSERT INTO courts (facility_id, name, court_number) VALUES (?, 'Court 2', 2)", facilityID)
+	exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 3', 3, 'inactive')", facilityID)
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, loc)
+	}
+	now := at(4, 12)
+
+	reserve := func(typeName string, start time.Time, courtID int64, extra string, args ...any) int64 {
+		t.Helper()
+		columns, values := "", ""
+		if extra != "" {
+			columns, values = ", "+extra, strings.Repeat(", ?", len(args))
+		}
+		id := exec(
+			fmt.Sprintf(`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time%s)
+			 VALUES (?, ?, ?, ?, ?, ?%s)`, columns, values),
+			append([]any{facilityID, typeID(typeName), userID, userID, start.UTC(), start.Add(time.Hour).UTC()}, args...)...,
+		)
+		exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", id, courtID)
+		return id
+	}
+	reserve("GAME", at(4, 9), court1, "")
+	reserve("GAME", at(4, 22), court1, "")
+	// 23:00 on the 3rd in New York is already the 4th in UTC.
+	reserve("GAME", at(3, 23), court1, "")
+	cancelled := reserve("GAME", at(4, 10), court1, "")
+	exec(
+		`INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, fee_waived, hours_before_start)
+		 VALUES (?, ?, ?, 100, 0, 24)`,
+		cancelled, userID, now.UTC(),
+	)
+	reserve("PRO_SESSION", at(4, 14), court1, "pro_id", proID)
+	reserve("MAINTENANCE", at(4, 6), court2, "")
+	reserve("MAINTENANCE", at(5, 6), court2, "")
+
+	ruleID := exec("INSERT INTO open_play_rules (facility_id, name) VALUES (?, 'Evening Social')", facilityID)
+	exec("INSERT INTO open_play_sessions (facility_id, open_play_rule_id, start_time, end_time) VALUES (?, ?, ?, ?)", facilityID, ruleID, at(4, 18).UTC(), at(4, 19).UTC())
+	openPlay := reserve("OPEN_PLAY", at(4, 18), court2, "open_play_rule_id", ruleID)
+	otherID := exec("INSERT INTO users (first_name, last_name, email, status, is_member) VALUES ('Ola', 'Other', 'ola@test.com', 'active', 1)")
+	exec("INSERT INTO reservation_participants (reservation_id, user_id) VALUES (?, ?), (?, ?)", openPlay, userID, openPlay, otherID)
+
+	waitlistID := exec(
+		`INSERT INTO waitlists (facility_id, user_id, target_date, target_start_time, target_end_time, position, status)
+		 VALUES (?, ?, '2026-03-04', '15:00', '16:00', 1, 'notified')`,
+		facilityID, userID,
+	)
+	exec("INSERT INTO waitlist_offers (waitlist_id, expires_at, status) VALUES (?, ?, 'pending')", waitlistID, now.Add(20*time.Minute).UTC())
+	exec("INSERT INTO waitlist_offers (waitlist_id, expires_at, status) VALUES (?, ?, 'pending')", waitlistID, now.Add(-time.Minute).UTC())
+
+	facility, err := database.Queries.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		t.Fatalf("load facility: %v", err)
+	}
+	snapshot, err := buildTodaySnapshot(ctx, database.Queries, facility, loc, now)
+	if err != nil {
+		t.Fatalf("build snapshot: %v", err)
+	}
+
+	if snapshot.Date != "2026-03-04" || snapshot.Timezone != "America/New_York" {
+		t.Fatalf("unexpected day: %s %s", snapshot.Date, snapshot.Timezone)
+	}
+	byType := map[string]int64{}
+	for _, count := range snapshot.ReservationsByType {
+		byType[count.TypeName] = count.Count
+	}
+	if byType["GAME"] != 2 || byType["PRO_SESSION"] != 1 || byType["MAINTENANCE"] != 1 || byType["OPEN_PLAY"] != 1 || snapshot.ReservationTotal != 5 {
+		t.Fatalf("unexpected reservation counts: %+v total %d", snapshot.ReservationsByType, snapshot.ReservationTotal)
+	}
+
+	if len(snapshot.OpenPlaySessions) != 1 {
+		t.Fatalf("expected one open play session, got %+v", snapshot.OpenPlaySessions)
+	}
+	session := snapshot.OpenPlaySessions[0]
+	if session.Participants != 2 || session.Capacity != 8 || session.FillRate != 0.25 {
+		t.Fatalf("unexpected open play fill: %+v", session)
+	}
+
+	if len(snapshot.LessonsByPro) != 1 || snapshot.LessonsByPro[0].ProName != "Pat Pro" || snapshot.LessonsByPro[0].Lessons != 1 {
+		t.Fatalf("unexpected lessons: %+v", snapshot.LessonsByPro)
+	}
+
+	if len(snapshot.PendingWaitlistOffers) != 1 || snapshot.PendingWaitlistOffers[0].TargetStartTime != "15:00" {
+		t.Fatalf("expected only the live offer, got %+v", snapshot.PendingWaitlistOffers)
+	}
+
+	maintenance := snapshot.CourtsUnderMaintenance
+	if len(maintenance) != 2 || maintenance[0].CourtID != court2 || len(maintenance[0].Blocks) != 1 ||
+		maintenance[1].CourtName != "Court 3" || len(maintenance[1].Blocks) != 0 {
+		t.Fatalf("unexpected courts under maintenance: %+v", maintenance)
+	}
+}
+
+func TestHandleTodayDashboard_JSONAndPartial(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	queriesOnce = sync.Once{}
+	queries = nil
+	InitHandlers(database)
+	t.Cleanup(func() {
+		queriesOnce = sync.Once{}
+		queries = nil
+	})
+
+	result, err := database.Exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := result.LastInsertId()
+	result, err = database.Exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main', 'main', 'UTC')", orgID)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := result.LastInsertId()
+
+	request := func(id int64, homeFacilityID int64, htmx bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/facilities/%d/dashboard", id), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", id))
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: 1, IsStaff: true, HomeFacilityID: &homeFacilityID}))
+		recorder := httptest.NewRecorder()
+		HandleTodayDashboard(recorder, req)
+		return recorder
+	}
+
+	recorder := request(facilityID, facilityID, false)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var snapshot dashboardtempl.TodaySnapshot
+	if err := json.Unmarshal(recorder.Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if snapshot.FacilityID != facilityID || snapshot.ReservationsByType == nil || snapshot.CourtsUnderMaintenance == nil {
+		t.Fatalf("unexpected snapshot: %+v", snapshot)
+	}
+
+	recorder = request(facilityID, facilityID, true)
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "Today at Main") {
+		t.Fatalf("expected partial, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if recorder := request(facilityID, facilityID+1, false); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for another facility, got %d", recorder.Code)
+	}
+}
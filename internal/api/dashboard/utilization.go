@@ -0,0 +1,401 @@
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// maxUtilizationDays bounds one report to a year of daily buckets.
+const maxUtilizationDays = 366
+
+const (
+	utilizationGranularityDay  = "day"
+	utilizationGranularityWeek = "week"
+)
+
+// utilizationRow is one court's occupancy over one bucket. Minutes only count
+// while the facility is open, so a booking running past closing adds nothing
+// for the time after close.
+type utilizationRow struct {
+	CourtID       int64            `json:"courtId"`
+	CourtNumber   int64            `json:"courtNumber"`
+	CourtName     string           `json:"courtName"`
+	BucketStart   string           `json:"bucketStart"`
+	BucketEnd     string           `json:"bucketEnd"`
+	OpenMinutes   int64            `json:"openMinutes"`
+	BookedMinutes int64            `json:"bookedMinutes"`
+	Utilization   float64          `json:"utilization"`
+	ByType        map[string]int64 `json:"byType"`
+}
+
+type utilizationResponse struct {
+	FacilityID  int64            `json:"facilityId"`
+	From        string           `json:"from"`
+	To          string           `json:"to"`
+	Granularity string           `json:"granularity"`
+	Rows        []utilizationRow `json:"rows"`
+}
+
+// bookedInterval is the part of a court booking inside one day's open hours.
+type bookedInterval struct {
+	start    time.Time
+	end      time.Time
+	typeName string
+}
+
+// GET /api/v1/facilities/{id}/reports/utilization
+// Booked versus open minutes per active court per day or week, as JSON or,
+// with ?format=csv or Accept: text/csv, as a CSV download.
+func HandleUtilizationReport(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || facilityID <= 0 {
+		http.Error(w, "invalid facility ID", http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	query := r.URL.Query()
+	granularity, err := parseUtilizationGranularity(query.Get("granularity"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), dashboardQueryTimeout)
+	defer cancel()
+
+	facility, err := q.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Facility not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+		http.Error(w, "Failed to load facility", http.StatusInternalServerError)
+		return
+	}
+	loc := apiutil.FacilityLocation(facility, logger)
+
+	from, to, err := parseUtilizationRange(query.Get("from"), query.Get("to"), loc)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rows, err := loadUtilizationRows(ctx, q, facilityID, from, to, granularity)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to build utilization report")
+		http.Error(w, "Failed to load utilization report", http.StatusInternalServerError)
+		return
+	}
+
+	if wantsCSV(r) {
+		writeUtilizationCSV(w, r, facilityID, from, to, rows)
+		return
+	}
+
+	response := utilizationResponse{
+		FacilityID:  facilityID,
+		From:        formatDate(from),
+		To:          formatDate(to),
+		Granularity: granularity,
+		Rows:        rows,
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write utilization response")
+	}
+}
+
+func loadUtilizationRows(ctx context.Context, q *dbgen.Queries, facilityID int64, from, to time.Time, granularity string) ([]utilizationRow, error) {
+	courts, err := q.ListCourts(ctx, facilityID)
+	if err != nil {
+		return nil, fmt.Errorf("list courts: %w", err)
+	}
+	hours, err := q.GetFacilityHours(ctx, facilityID)
+	if err != nil {
+		return nil, fmt.Errorf("load operating hours: %w", err)
+	}
+	overrideRows, err := q.ListFacilityHoursOverrides(ctx, dbgen.ListFacilityHoursOverridesParams{
+		FacilityID: facilityID,
+		FromDate:   formatDate(from),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list hours overrides: %w", err)
+	}
+	overrides := make(map[string]dbgen.FacilityHoursOverride, len(overrideRows))
+	for _, override := range overrideRows {
+		overrides[override.OverrideDate] = override
+	}
+	bookings, err := q.ListCourtBookingsInRange(ctx, dbgen.ListCourtBookingsInRangeParams{
+		FacilityID: facilityID,
+		StartTime:  from.UTC(),
+		EndTime:    to.AddDate(0, 0, 1).UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list court bookings: %w", err)
+	}
+
+	return buildUtilizationRows(from, to, granularity, courts, hours, overrides, bookings), nil
+}
+
+// buildUtilizationRows buckets each active court's open and booked minutes
+// by facility date. from and to are inclusive facility-local midnights.
+// Bookings are clipped to each day's open hours before they are counted, so
+// one spanning midnight or a week boundary lands in both buckets, and
+// overlapping bookings on a court count their shared minutes once toward
+// BookedMinutes.
+func buildUtilizationRows(
+	from, to time.Time,
+	granularity string,
+	courts []dbgen.Court,
+	hours []dbgen.OperatingHour,
+	overrides map[string]dbgen.FacilityHoursOverride,
+	bookings []dbgen.ListCourtBookingsInRangeRow,
+) []utilizationRow {
+	bookingsByCourt := make(map[int64][]dbgen.ListCourtBookingsInRangeRow)
+	for _, booking := range bookings {
+		bookingsByCourt[booking.CourtID] = append(bookingsByCourt[booking.CourtID], booking)
+	}
+
+	rows := make([]utilizationRow, 0)
+	for _, court := range courts {
+		if court.Status != "active" {
+			continue
+		}
+		courtBookings := bookingsByCourt[court.ID]
+
+		var current *utilizationRow
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			bucketStart, bucketEnd := utilizationBucket(day, from, to, granularity)
+			if current == nil || current.BucketStart != formatDate(bucketStart) {
+				rows = append(rows, utilizationRow{
+					CourtID:     court.ID,
+					CourtNumber: court.CourtNumber,
+					CourtName:   court.Name,
+					BucketStart: formatDate(bucketStart),
+					BucketEnd:   formatDate(bucketEnd),
+					ByType:      map[string]int64{},
+				})
+				current = &rows[len(rows)-1]
+			}
+
+			var override *dbgen.FacilityHoursOverride
+			if row, ok := overrides[day.Format(apiutil.HoursOverrideDateLayout)]; ok {
+				override = &row
+			}
+			opensAt, closesAt, open := apiutil.OperatingHoursWindow(day, hours, override)
+			if !open {
+				continue
+			}
+			current.OpenMinutes += minutesBetween(opensAt, closesAt)
+
+			var intervals []bookedInterval
+			for _, booking := range courtBookings {
+				start := laterOf(booking.StartTime, opensAt)
+				end := earlierOf(booking.EndTime, closesAt)
+				if !end.After(start) {
+					continue
+				}
+				intervals = append(intervals, bookedInterval{start: start, end: end, typeName: booking.ReservationTypeName})
+				current.ByType[booking.ReservationTypeName] += minutesBetween(start, end)
+			}
+			current.BookedMinutes += mergedMinutes(intervals)
+		}
+	}
+
+	for i := range rows {
+		if rows[i].OpenMinutes > 0 {
+			rows[i].Utilization = float64(rows[i].BookedMinutes) / float64(rows[i].OpenMinutes)
+		}
+	}
+	return rows
+}
+
+// utilizationBucket returns the first and last dates of day's bucket, clipped
+// to the report range. Weeks start on Monday.
+func utilizationBucket(day, from, to time.Time, granularity string) (time.Time, time.Time) {
+	if granularity != utilizationGranularityWeek {
+		return day, day
+	}
+	start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	end := start.AddDate(0, 0, 6)
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	return start, end
+}
+
+// mergedMinutes is the length of the union of intervals.
+func mergedMinutes(intervals []bookedInterval) int64 {
+	if len(intervals) == 0 {
+		return 0
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+	var total int64
+	start, end := intervals[0].start, intervals[0].end
+	for _, interval := range intervals[1:] {
+		if interval.start.After(end) {
+			total += minutesBetween(start, end)
+			start, end = interval.start, interval.end
+			continue
+		}
+		end = laterOf(end, interval.end)
+	}
+	return total + minutesBetween(start, end)
+}
+
+func minutesBetween(start, end time.Time) int64 {
+	return int64(end.Sub(start) / time.Minute)
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierOf(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func parseUtilizationGranularity(raw string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", utilizationGranularityDay:
+		return utilizationGranularityDay, nil
+	case utilizationGranularityWeek:
+		return utilizationGranularityWeek, nil
+	default:
+		return "", fmt.Errorf("granularity must be day or week")
+	}
+}
+
+func parseUtilizationRange(fromRaw, toRaw string, loc *time.Location) (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation(dashboardDateLayout, strings.TrimSpace(fromRaw), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must be in YYYY-MM-DD format")
+	}
+	to, err := time.ParseInLocation(dashboardDateLayout, strings.TrimSpace(toRaw), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must be in YYYY-MM-DD format")
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must not be before from")
+	}
+	if to.After(from.AddDate(0, 0, maxUtilizationDays-1)) {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range cannot exceed %d days", maxUtilizationDays)
+	}
+	return from, to, nil
+}
+
+func wantsCSV(r *http.Request) bool {
+	if format := strings.TrimSpace(r.URL.Query().Get("format")); format != "" {
+		return strings.EqualFold(format, "csv")
+	}
+	return strings.Contains(strings.ToLower(r.Header.Get("Accept")), "text/csv")
+}
+
+// writeUtilizationCSV writes one column per reservation type seen in the
+// report, so every row has the same shape.
+func writeUtilizationCSV(w http.ResponseWriter, r *http.Request, facilityID int64, from, to time.Time, rows []utilizationRow) {
+	logger := log.Ctx(r.Context())
+
+	typeSet := make(map[string]struct{})
+	for _, row := range rows {
+		for typeName := range row.ByType {
+			typeSet[typeName] = struct{}{}
+		}
+	}
+	typeNames := make([]string, 0, len(typeSet))
+	for typeName := range typeSet {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := []string{
+		"Court",
+		"Court Number",
+		"Bucket Start",
+		"Bucket End",
+		"Open Minutes",
+		"Booked Minutes",
+		"Utilization Percent",
+	}
+	for _, typeName := range typeNames {
+		header = append(header, apiutil.SanitizeCSVField(typeName)+" Minutes")
+	}
+	if err := writer.Write(header); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write utilization CSV header")
+		http.Error(w, "Failed to export utilization report", http.StatusInternalServerError)
+		return
+	}
+
+	for _, row := range rows {
+		record := []string{
+			apiutil.SanitizeCSVField(row.CourtName),
+			strconv.FormatInt(row.CourtNumber, 10),
+			row.BucketStart,
+			row.BucketEnd,
+			strconv.FormatInt(row.OpenMinutes, 10),
+			strconv.FormatInt(row.BookedMinutes, 10),
+			strconv.FormatFloat(row.Utilization*100, 'f', 1, 64),
+		}
+		for _, typeName := range typeNames {
+			record = append(record, strconv.FormatInt(row.ByType[typeName], 10))
+		}
+		if err := writer.Write(record); err != nil {
+			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write utilization CSV row")
+			http.Error(w, "Failed to export utilization report", http.StatusInternalServerError)
+			return
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to finalize utilization CSV")
+		http.Error(w, "Failed to export utilization report", http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("facility_%d_utilization_%s_%s.csv", facilityID, formatDate(from), formatDate(to))
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write utilization CSV response")
+	}
+}
@@ -0,0 +1,191 @@
+package dashboard
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func dailyHours(opensAt, closesAt string) []dbgen.OperatingHour {
+	hours := make([]dbgen.OperatingHour, 0, 7)
+	for day := int64(0); day < 7; day++ {
+		hours = append(hours, dbgen.OperatingHour{DayOfWeek: day, OpensAt: opensAt, ClosesAt: closesAt})
+	}
+	return hours
+}
+
+func TestBuildUtilizationRows_ClipsSplitsAndMerges(t *testing.T) {
+	// Wednesday 2026-03-04 through Tuesday 2026-03-10.
+	from := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC)
+	}
+	courts := []dbgen.Court{
+		{ID: 1, CourtNumber: 1, Name: "Court 1", Status: "active"},
+		{ID: 2, CourtNumber: 2, Name: "Court 2", Status: "active"},
+		{ID: 3, CourtNumber: 3, Name: "Court 3", Status: "inactive"},
+	}
+	overrides := map[string]dbgen.FacilityHoursOverride{
+		"2026-03-09": {OverrideDate: "2026-03-09", IsClosed: true},
+	}
+	bookings := []dbgen.ListCourtBookingsInRangeRow{
+		// Runs past the 22:00 close into Monday; only Sunday 21:00-22:00 counts.
+		{CourtID: 1, StartTime: at(8, 21), EndTime: at(9, 9), ReservationTypeName: "EVENT"},
+		// Overlapping bookings on one court count their shared hour once.
+		{CourtID: 1, StartTime: at(10, 9), EndTime: at(10, 11), ReservationTypeName: "GAME"},
+		{CourtID: 1, StartTime: at(10, 10), EndTime: at(10, 12), ReservationTypeName: "LEAGUE"},
+		// A two-court reservation shows up once per court.
+		{CourtID: 1, StartTime: at(4, 8), EndTime: at(4, 10), ReservationTypeName: "GAME"},
+		{CourtID: 2, StartTime: at(4, 8), EndTime: at(4, 10), ReservationTypeName: "GAME"},
+		{CourtID: 3, StartTime: at(4, 8), EndTime: at(4, 10), ReservationTypeName: "GAME"},
+	}
+
+	rows := buildUtilizationRows(from, to, utilizationGranularityWeek, courts, dailyHours("08:00", "22:00"), overrides, bookings)
+	if len(rows) != 4 {
+		t.Fatalf("expected 2 active courts x 2 weeks, got %d rows: %+v", len(rows), rows)
+	}
+
+	first := rows[0]
+	if first.CourtID != 1 || first.BucketStart != "2026-03-04" || first.BucketEnd != "2026-03-08" {
+		t.Fatalf("unexpected first bucket: %+v", first)
+	}
+	if first.OpenMinutes != 5*14*60 {
+		t.Fatalf("expected 5 open days, got %d open minutes", first.OpenMinutes)
+	}
+	if first.BookedMinutes != 180 || first.ByType["GAME"] != 120 || first.ByType["EVENT"] != 60 {
+		t.Fatalf("unexpected first week bookings: %+v", first)
+	}
+
+	second := rows[1]
+	if second.BucketStart != "2026-03-09" || second.BucketEnd != "2026-03-10" {
+		t.Fatalf("unexpected second bucket: %+v", second)
+	}
+	if second.OpenMinutes != 14*60 {
+		t.Fatalf("expected the closed Monday to add no open minutes, got %d", second.OpenMinutes)
+	}
+	if second.BookedMinutes != 180 || second.ByType["GAME"] != 120 || second.ByType["LEAGUE"] != 120 {
+		t.Fatalf("unexpected second week bookings: %+v", second)
+	}
+	if second.Utilization != 180.0/840.0 {
+		t.Fatalf("unexpected utilization %v", second.Utilization)
+	}
+
+	if rows[2].CourtID != 2 || rows[2].BookedMinutes != 120 || rows[3].BookedMinutes != 0 {
+		t.Fatalf("unexpected court 2 rows: %+v %+v", rows[2], rows[3])
+	}
+}
+
+func TestHandleUtilizationReport_JSONAndCSV(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	queriesOnce = sync.Once{}
+	queries = nil
+	InitHandlers(database)
+	t.Cleanup(func() {
+		queriesOnce = sync.Once{}
+		queries = nil
+	})
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main', 'main', 'UTC')", orgID)
+	userID := exec("INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES ('Desk', 'Staff', 'desk@test.com', 'active', 1)")
+	courtID := exec("INSERT INTO courts (facility_id, name, court_number) VALUES (?, '=Center', 1)", facilityID)
+	exec("INSERT INTO operating_hours (facility_id, day_of_week, opens_at, closes_at) VALUES (?, 2, '08:00', '12:00')", facilityID)
+
+	reservationType, err := database.Queries.GetReservationTypeByName(ctx, "GAME")
+	if err != nil {
+		t.Fatalf("load reservation type: %v", err)
+	}
+	// Tuesday 2026-03-03, 09:00-10:00.
+	reservation, err := database.Queries.CreateReservation(ctx, dbgen.CreateReservationParams{
+		FacilityID:        facilityID,
+		ReservationTypeID: reservationType.ID,
+		CreatedByUserID:   userID,
+		StartTime:         time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC),
+		EndTime:           time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+	exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", reservation.ID, courtID)
+
+	report := func(query string, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/facilities/%d/reports/utilization?%s", facilityID, query), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", facilityID))
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		homeFacilityID := facilityID
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: userID, IsStaff: true, HomeFacilityID: &homeFacilityID}))
+		recorder := httptest.NewRecorder()
+		HandleUtilizationReport(recorder, req)
+		return recorder
+	}
+
+	recorder := report("from=2026-03-02&to=2026-03-04", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var body utilizationResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(body.Rows) != 3 {
+		t.Fatalf("expected 3 daily rows, got %+v", body.Rows)
+	}
+	tuesday := body.Rows[1]
+	if tuesday.BucketStart != "2026-03-03" || tuesday.OpenMinutes != 240 || tuesday.BookedMinutes != 60 || tuesday.Utilization != 0.25 {
+		t.Fatalf("unexpected Tuesday row: %+v", tuesday)
+	}
+
+	recorder = report("from=2026-03-02&to=2026-03-04&granularity=week", "text/csv")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected CSV 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("expected text/csv, got %q", recorder.Header().Get("Content-Type"))
+	}
+	records, err := csv.NewReader(recorder.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("parse CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected header and one weekly row, got %v", records)
+	}
+	if records[0][len(records[0])-1] != "GAME Minutes" {
+		t.Fatalf("expected GAME column, got %v", records[0])
+	}
+	if records[1][0] != "'=Center" || records[1][4] != "240" || records[1][5] != "60" || records[1][6] != "25.0" || records[1][7] != "60" {
+		t.Fatalf("unexpected CSV row: %v", records[1])
+	}
+
+	if recorder := report("from=2026-03-04&to=2026-03-02", ""); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for reversed range, got %d", recorder.Code)
+	}
+	if recorder := report("from=2026-03-02&to=2026-03-04&granularity=month", ""); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unsupported granularity, got %d", recorder.Code)
+	}
+}
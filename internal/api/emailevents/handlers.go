@@ -0,0 +1,116 @@
+// internal/api/emailevents/handlers.go
+package emailevents
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+)
+
+const (
+	emailEventsTimeout  = 15 * time.Second
+	maxEmailEventsBytes = 256 << 10
+)
+
+var (
+	queries     *dbgen.Queries
+	verifier    *email.SNSVerifier
+	queriesOnce sync.Once
+)
+
+// InitHandlers sets up the SES event webhook. A non-empty topicARN limits it
+// to notifications from that SNS topic.
+func InitHandlers(q *dbgen.Queries, topicARN string) {
+	if q == nil {
+		return
+	}
+	queriesOnce.Do(func() {
+		queries = q
+		verifier = email.NewSNSVerifier(topicARN)
+	})
+}
+
+// POST /api/v1/email/events
+//
+// HandleEmailEvents receives SES bounce and complaint notifications through
+// an SNS HTTPS subscription. Every message must carry a valid SNS signature.
+// Hard bounces and complaints flag matching users so transactional email
+// skips them. Failures to record return 500 so SNS retries the delivery.
+func HandleEmailEvents(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil || verifier == nil {
+		logger.Error().Msg("Email event handler not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEmailEventsBytes))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	msg, err := email.ParseSNSMessage(body)
+	if err != nil {
+		http.Error(w, "Invalid SNS message", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), emailEventsTimeout)
+	defer cancel()
+
+	if err := verifier.Verify(ctx, msg); err != nil {
+		logger.Warn().Err(err).Str("sns_message_id", msg.MessageID).Str("topic_arn", msg.TopicArn).Msg("Rejected SNS message")
+		http.Error(w, "Invalid SNS signature", http.StatusForbidden)
+		return
+	}
+
+	switch msg.Type {
+	case email.SNSTypeSubscriptionConfirmation:
+		if err := verifier.ConfirmSubscription(ctx, msg); err != nil {
+			logger.Error().Err(err).Str("topic_arn", msg.TopicArn).Msg("Failed to confirm SNS subscription")
+			http.Error(w, "Failed to confirm subscription", http.StatusBadGateway)
+			return
+		}
+		logger.Info().Str("topic_arn", msg.TopicArn).Msg("Confirmed SNS subscription for email events")
+	case email.SNSTypeUnsubscribeConfirmation:
+		logger.Warn().Str("topic_arn", msg.TopicArn).Msg("SNS subscription for email events was removed")
+	case email.SNSTypeNotification:
+		events, err := email.ParseSESDeliveryEvents(msg.Message)
+		if err != nil {
+			// A malformed payload will not parse on retry either.
+			logger.Warn().Err(err).Str("sns_message_id", msg.MessageID).Msg("Ignoring unreadable SES notification")
+			break
+		}
+		for _, event := range events {
+			flagged, err := email.RecordDeliveryEvent(ctx, queries, msg.MessageID, event)
+			if err != nil {
+				logger.Error().Err(err).Str("sns_message_id", msg.MessageID).Msg("Failed to record email delivery event")
+				http.Error(w, "Failed to record email event", http.StatusInternalServerError)
+				return
+			}
+			if flagged > 0 {
+				logger.Info().
+					Str("event_type", event.Type).
+					Str("bounce_type", event.BounceType).
+					Int64("users_flagged", flagged).
+					Msg("Marked email address undeliverable")
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
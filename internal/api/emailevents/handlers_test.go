@@ -0,0 +1,176 @@
+package emailevents
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+const testTopicARN = "arn:aws:sns:us-east-1:123456789012:ses-events"
+
+// certTransport serves the test signing certificate for any .pem URL and
+// records other requests, standing in for the SNS endpoints.
+type certTransport struct {
+	certPEM []byte
+	visited []string
+}
+
+func (c *certTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := c.certPEM
+	if !strings.HasSuffix(req.URL.Path, ".pem") {
+		c.visited = append(c.visited, req.URL.String())
+		body = []byte("<ConfirmSubscriptionResponse/>")
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body)), Header: make(http.Header), Request: req}, nil
+}
+
+func TestHandleEmailEvents(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	testutil.ResetHandlers(t, &queriesOnce, &queries, &verifier)
+	InitHandlers(database.Queries, testTopicARN)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	transport := &certTransport{certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+	verifier.Client = &http.Client{Transport: transport}
+
+	// sign mirrors the SNS signature version 2 canonical form.
+	sign := func(msg email.SNSMessage, fields ...string) email.SNSMessage {
+		t.Helper()
+		values := map[string]string{
+			"Message": msg.Message, "MessageId": msg.MessageID, "SubscribeURL": msg.SubscribeURL,
+			"Timestamp": msg.Timestamp, "Token": msg.Token, "TopicArn": msg.TopicArn, "Type": msg.Type,
+		}
+		var text strings.Builder
+		for _, field := range fields {
+			text.WriteString(field + "\n" + values[field] + "\n")
+		}
+		digest := sha256.Sum256([]byte(text.String()))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		msg.SignatureVersion = "2"
+		msg.SigningCertURL = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-test.pem"
+		msg.Signature = base64.StdEncoding.EncodeToString(signature)
+		return msg
+	}
+	post := func(msg email.SNSMessage) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(msg)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/email/events", bytes.NewReader(body))
+		recorder := httptest.NewRecorder()
+		HandleEmailEvents(recorder, req)
+		return recorder
+	}
+	notificationFields := []string{"Message", "MessageId", "Timestamp", "TopicArn", "Type"}
+
+	result, err := database.ExecContext(ctx, "INSERT INTO users (first_name, last_name, email, status, is_member) VALUES ('Jo', 'Player', 'Jo@Example.com', 'active', 1)")
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	userID, _ := result.LastInsertId()
+	undeliverable := func() bool {
+		t.Helper()
+		user, err := database.Queries.GetUserByID(ctx, userID)
+		if err != nil {
+			t.Fatalf("load user: %v", err)
+		}
+		return user.EmailUndeliverable
+	}
+
+	subscribeURL := "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=abc"
+	recorder := post(sign(email.SNSMessage{
+		Type:         email.SNSTypeSubscriptionConfirmation,
+		MessageID:    "sub-1",
+		Token:        "abc",
+		TopicArn:     testTopicARN,
+		Message:      "You have chosen to subscribe to the topic.",
+		SubscribeURL: subscribeURL,
+		Timestamp:    "2026-10-15T12:00:00.000Z",
+	}, "Message", "MessageId", "SubscribeURL", "Timestamp", "Token", "TopicArn", "Type"))
+	if recorder.Code != http.StatusNoContent || len(transport.visited) != 1 || transport.visited[0] != subscribeURL {
+		t.Fatalf("subscription confirmation: status %d, visited %v", recorder.Code, transport.visited)
+	}
+
+	transient := sign(email.SNSMessage{
+		Type:      email.SNSTypeNotification,
+		MessageID: "msg-1",
+		TopicArn:  testTopicARN,
+		Message:   `{"notificationType":"Bounce","bounce":{"bounceType":"Transient","bounceSubType":"MailboxFull","bouncedRecipients":[{"emailAddress":"jo@example.com"}]}}`,
+		Timestamp: "2026-10-15T12:01:00.000Z",
+	}, notificationFields...)
+	if recorder := post(transient); recorder.Code != http.StatusNoContent {
+		t.Fatalf("transient bounce status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if undeliverable() {
+		t.Fatal("expected a transient bounce to leave the address deliverable")
+	}
+
+	forged := transient
+	forged.MessageID = "msg-2"
+	forged.Message = `{"notificationType":"Complaint","complaint":{"complainedRecipients":[{"emailAddress":"jo@example.com"}]}}`
+	if recorder := post(forged); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected a forged message to be rejected, got %d", recorder.Code)
+	}
+	if undeliverable() {
+		t.Fatal("expected a forged complaint to be ignored")
+	}
+
+	permanent := sign(email.SNSMessage{
+		Type:      email.SNSTypeNotification,
+		MessageID: "msg-3",
+		TopicArn:  testTopicARN,
+		Message:   `{"notificationType":"Bounce","bounce":{"bounceType":"Permanent","bounceSubType":"General","bouncedRecipients":[{"emailAddress":"jo@example.com","diagnosticCode":"smtp; 550 user unknown"}]}}`,
+		Timestamp: "2026-10-15T12:02:00.000Z",
+	}, notificationFields...)
+	for range 2 {
+		if recorder := post(permanent); recorder.Code != http.StatusNoContent {
+			t.Fatalf("permanent bounce status %d: %s", recorder.Code, recorder.Body.String())
+		}
+	}
+	if !undeliverable() {
+		t.Fatal("expected a permanent bounce to mark the address undeliverable")
+	}
+	events, err := database.Queries.ListEmailDeliveryEvents(ctx, dbgen.ListEmailDeliveryEventsParams{Email: "jo@example.com", Limit: 10})
+	if err != nil || len(events) != 2 {
+		t.Fatalf("expected the transient and permanent bounce recorded once each, got %d (err %v)", len(events), err)
+	}
+}
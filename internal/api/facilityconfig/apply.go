@@ -0,0 +1,696 @@
+// internal/api/facilityconfig/apply.go
+package facilityconfig
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// Change actions reported by an import.
+const (
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+)
+
+// configChange is one difference an import made, or would make on a dry run.
+type configChange struct {
+	Section string `json:"section"`
+	Action  string `json:"action"`
+	Item    string `json:"item"`
+	From    any    `json:"from,omitempty"`
+	To      any    `json:"to,omitempty"`
+}
+
+// importer applies a bundle to one facility, writing only what differs and
+// recording each write as a change.
+type importer struct {
+	q          *dbgen.Queries
+	facilityID int64
+	userID     int64
+	changes    []configChange
+}
+
+func (imp *importer) record(section, action, item string, from, to any) {
+	imp.changes = append(imp.changes, configChange{Section: section, Action: action, Item: item, From: from, To: to})
+}
+
+// apply runs every section the bundle carries. Names that do not resolve at
+// the target facility fail with a 400 HandlerError.
+func (imp *importer) apply(ctx context.Context, bundle configBundle) error {
+	if bundle.Booking != nil {
+		if err := imp.applyBooking(ctx, *bundle.Booking); err != nil {
+			return err
+		}
+	}
+	if bundle.Hours != nil {
+		if err := imp.applyHours(ctx, bundle.Hours); err != nil {
+			return err
+		}
+	}
+
+	types, err := imp.reservationTypes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, name := range bundle.ReservationTypes {
+		if _, ok := types[strings.ToLower(strings.TrimSpace(name))]; !ok {
+			return unavailableReservationType(name)
+		}
+	}
+	if bundle.CancellationPolicies != nil {
+		if err := imp.applyCancellationPolicies(ctx, bundle.CancellationPolicies, types); err != nil {
+			return err
+		}
+	}
+
+	if bundle.PrimeTimeRules != nil {
+		if err := imp.applyPrimeTimeRules(ctx, bundle.PrimeTimeRules); err != nil {
+			return err
+		}
+	}
+	if bundle.OpenPlayRules != nil {
+		if err := imp.applyOpenPlayRules(ctx, bundle.OpenPlayRules); err != nil {
+			return err
+		}
+	}
+	if bundle.Themes != nil {
+		if err := imp.applyThemes(ctx, bundle.Themes); err != nil {
+			return err
+		}
+	}
+	if bundle.ActiveTheme != "" {
+		if err := imp.applyActiveTheme(ctx, bundle.ActiveTheme); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (imp *importer) applyBooking(ctx context.Context, booking bundleBooking) error {
+	facility, err := imp.q.GetFacilityByID(ctx, imp.facilityID)
+	if err != nil {
+		return fmt.Errorf("load facility: %w", err)
+	}
+	current := bundleBooking{
+		MaxAdvanceBookingDays:     facility.MaxAdvanceBookingDays,
+		MaxMemberReservations:     facility.MaxMemberReservations,
+		MaxCourtsPerMemberBooking: facility.MaxCourtsPerMemberBooking,
+		LessonMinNoticeHours:      facility.LessonMinNoticeHours,
+		SlotDurationMinutes:       facility.SlotDurationMinutes,
+		MinBookingMinutes:         facility.MinBookingMinutes,
+		MaxGuestsPerReservation:   facility.MaxGuestsPerReservation,
+		ReservationLimitScope:     facility.ReservationLimitScope,
+		BufferMinutes:             facility.BufferMinutes,
+	}
+	if current == booking {
+		return nil
+	}
+
+	settings := []struct {
+		name     string
+		from, to any
+	}{
+		{"maxAdvanceBookingDays", current.MaxAdvanceBookingDays, booking.MaxAdvanceBookingDays},
+		{"maxMemberReservations", current.MaxMemberReservations, booking.MaxMemberReservations},
+		{"maxCourtsPerMemberBooking", current.MaxCourtsPerMemberBooking, booking.MaxCourtsPerMemberBooking},
+		{"lessonMinNoticeHours", current.LessonMinNoticeHours, booking.LessonMinNoticeHours},
+		{"slotDurationMinutes", current.SlotDurationMinutes, booking.SlotDurationMinutes},
+		{"minBookingMinutes", current.MinBookingMinutes, booking.MinBookingMinutes},
+		{"maxGuestsPerReservation", current.MaxGuestsPerReservation, booking.MaxGuestsPerReservation},
+		{"reservationLimitScope", current.ReservationLimitScope, booking.ReservationLimitScope},
+		{"bufferMinutes", current.BufferMinutes, booking.BufferMinutes},
+	}
+	for _, setting := range settings {
+		if setting.from != setting.to {
+			imp.record("booking", actionUpdate, setting.name, setting.from, setting.to)
+		}
+	}
+
+	_, err = imp.q.UpdateFacilityBookingConfig(ctx, dbgen.UpdateFacilityBookingConfigParams{
+		MaxAdvanceBookingDays:     booking.MaxAdvanceBookingDays,
+		MaxMemberReservations:     booking.MaxMemberReservations,
+		MaxCourtsPerMemberBooking: booking.MaxCourtsPerMemberBooking,
+		LessonMinNoticeHours:      booking.LessonMinNoticeHours,
+		SlotDurationMinutes:       booking.SlotDurationMinutes,
+		MinBookingMinutes:         booking.MinBookingMinutes,
+		MaxGuestsPerReservation:   booking.MaxGuestsPerReservation,
+		ReservationLimitScope:     booking.ReservationLimitScope,
+		BufferMinutes:             booking.BufferMinutes,
+		ID:                        imp.facilityID,
+	})
+	if err != nil {
+		return fmt.Errorf("update booking config: %w", err)
+	}
+	return nil
+}
+
+func (imp *importer) applyHours(ctx context.Context, hours []bundleHours) error {
+	existing, err := imp.q.GetFacilityHours(ctx, imp.facilityID)
+	if err != nil {
+		return fmt.Errorf("load operating hours: %w", err)
+	}
+	current := make(map[int64]string, len(existing))
+	for _, day := range existing {
+		current[day.DayOfWeek] = clockValue(day.OpensAt) + "-" + clockValue(day.ClosesAt)
+	}
+	wanted := make(map[int64]bundleHours, len(hours))
+	for _, day := range hours {
+		wanted[day.DayOfWeek] = day
+	}
+
+	for dayOfWeek := int64(0); dayOfWeek <= 6; dayOfWeek++ {
+		item := time.Weekday(dayOfWeek).String()
+		from, open := current[dayOfWeek]
+		day, keep := wanted[dayOfWeek]
+		switch {
+		case !keep && open:
+			if _, err := imp.q.DeleteOperatingHours(ctx, dbgen.DeleteOperatingHoursParams{
+				FacilityID: imp.facilityID,
+				DayOfWeek:  dayOfWeek,
+			}); err != nil {
+				return fmt.Errorf("delete %s hours: %w", item, err)
+			}
+			imp.record("hours", actionDelete, item, from, nil)
+		case keep:
+			to := day.OpensAt + "-" + day.ClosesAt
+			if open && from == to {
+				continue
+			}
+			if _, err := imp.q.UpsertOperatingHours(ctx, dbgen.UpsertOperatingHoursParams{
+				FacilityID: imp.facilityID,
+				DayOfWeek:  dayOfWeek,
+				OpensAt:    day.OpensAt,
+				ClosesAt:   day.ClosesAt,
+			}); err != nil {
+				return fmt.Errorf("save %s hours: %w", item, err)
+			}
+			if open {
+				imp.record("hours", actionUpdate, item, from, to)
+			} else {
+				imp.record("hours", actionCreate, item, nil, to)
+			}
+		}
+	}
+	return nil
+}
+
+// reservationTypes keys the types available at the facility by lowercased
+// name.
+func (imp *importer) reservationTypes(ctx context.Context) (map[string]dbgen.ReservationType, error) {
+	rows, err := imp.q.ListReservationTypesForFacility(ctx, sql.NullInt64{Int64: imp.facilityID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("load reservation types: %w", err)
+	}
+	types := make(map[string]dbgen.ReservationType, len(rows))
+	for _, row := range rows {
+		types[strings.ToLower(row.Name)] = row
+	}
+	return types, nil
+}
+
+func unavailableReservationType(name string) error {
+	return apiutil.HandlerError{
+		Status:  http.StatusBadRequest,
+		Message: fmt.Sprintf("Reservation type %q is not available at this facility; create it or share it with the organization before importing", name),
+	}
+}
+
+// applyCancellationPolicies replaces each policy whose tiers differ and
+// deletes policies the bundle leaves out.
+func (imp *importer) applyCancellationPolicies(ctx context.Context, policies []bundlePolicy, types map[string]dbgen.ReservationType) error {
+	existing, err := imp.q.ListCancellationPolicyTiers(ctx, dbgen.ListCancellationPolicyTiersParams{FacilityID: imp.facilityID})
+	if err != nil {
+		return fmt.Errorf("load cancellation policy tiers: %w", err)
+	}
+	current := make(map[sql.NullInt64][]bundleTier)
+	for _, tier := range existing {
+		current[tier.ReservationTypeID] = append(current[tier.ReservationTypeID], bundleTier{
+			MinHoursBefore:   tier.MinHoursBefore,
+			RefundPercentage: tier.RefundPercentage,
+		})
+	}
+	labels := make(map[sql.NullInt64]string, len(types))
+	for _, reservationType := range types {
+		labels[sql.NullInt64{Int64: reservationType.ID, Valid: true}] = reservationType.Name
+	}
+
+	wanted := make(map[sql.NullInt64]bool, len(policies))
+	for _, policy := range policies {
+		var typeID sql.NullInt64
+		item := "default"
+		if name := strings.TrimSpace(policy.ReservationType); name != "" {
+			reservationType, ok := types[strings.ToLower(name)]
+			if !ok {
+				return unavailableReservationType(name)
+			}
+			typeID = sql.NullInt64{Int64: reservationType.ID, Valid: true}
+			item = reservationType.Name
+		}
+		wanted[typeID] = true
+
+		from, exists := current[typeID]
+		if exists && tierSummary(from) == tierSummary(policy.Tiers) {
+			continue
+		}
+		if _, err := imp.q.DeleteCancellationPolicy(ctx, dbgen.DeleteCancellationPolicyParams{
+			FacilityID:        imp.facilityID,
+			ReservationTypeID: typeID,
+		}); err != nil {
+			return fmt.Errorf("clear %s cancellation policy: %w", item, err)
+		}
+		for _, tier := range policy.Tiers {
+			if _, err := imp.q.CreateCancellationPolicyTier(ctx, dbgen.CreateCancellationPolicyTierParams{
+				FacilityID:        imp.facilityID,
+				ReservationTypeID: typeID,
+				MinHoursBefore:    tier.MinHoursBefore,
+				RefundPercentage:  tier.RefundPercentage,
+			}); err != nil {
+				return fmt.Errorf("create %s cancellation tier: %w", item, err)
+			}
+		}
+		if exists {
+			imp.record("cancellationPolicies", actionUpdate, item, tierSummary(from), tierSummary(policy.Tiers))
+		} else {
+			imp.record("cancellationPolicies", actionCreate, item, nil, tierSummary(policy.Tiers))
+		}
+	}
+
+	for typeID, tiers := range current {
+		if wanted[typeID] {
+			continue
+		}
+		item := "default"
+		if typeID.Valid {
+			item = labels[typeID]
+			if item == "" {
+				item = fmt.Sprintf("reservation type %d", typeID.Int64)
+			}
+		}
+		if _, err := imp.q.DeleteCancellationPolicy(ctx, dbgen.DeleteCancellationPolicyParams{
+			FacilityID:        imp.facilityID,
+			ReservationTypeID: typeID,
+		}); err != nil {
+			return fmt.Errorf("delete %s cancellation policy: %w", item, err)
+		}
+		imp.record("cancellationPolicies", actionDelete, item, tierSummary(tiers), nil)
+	}
+	return nil
+}
+
+// tierSummary renders tiers most notice last, e.g. "0h 0%, 24h 100%".
+func tierSummary(tiers []bundleTier) string {
+	sorted := append([]bundleTier(nil), tiers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MinHoursBefore < sorted[j].MinHoursBefore })
+	parts := make([]string, len(sorted))
+	for i, tier := range sorted {
+		parts[i] = fmt.Sprintf("%dh %d%%", tier.MinHoursBefore, tier.RefundPercentage)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// applyPrimeTimeRules makes the facility's rules match the bundle's, keeping
+// rules that already match.
+func (imp *importer) applyPrimeTimeRules(ctx context.Context, rules []bundlePrimeTimeRule) error {
+	existing, err := imp.q.ListPrimeTimeRules(ctx, imp.facilityID)
+	if err != nil {
+		return fmt.Errorf("load prime time rules: %w", err)
+	}
+	wanted := make(map[bundlePrimeTimeRule]bool, len(rules))
+	for _, rule := range rules {
+		wanted[rule] = true
+	}
+
+	kept := make(map[bundlePrimeTimeRule]bool, len(existing))
+	for _, row := range existing {
+		rule := bundlePrimeTimeRule{
+			DayOfWeek:          row.DayOfWeek,
+			StartTime:          row.StartTime,
+			EndTime:            row.EndTime,
+			MinMembershipLevel: row.MinMembershipLevel,
+			UnlockHoursBefore:  row.UnlockHoursBefore,
+		}
+		if wanted[rule] && !kept[rule] {
+			kept[rule] = true
+			continue
+		}
+		if _, err := imp.q.DeletePrimeTimeRule(ctx, dbgen.DeletePrimeTimeRuleParams{ID: row.ID, FacilityID: imp.facilityID}); err != nil {
+			return fmt.Errorf("delete prime time rule %d: %w", row.ID, err)
+		}
+		imp.record("primeTimeRules", actionDelete, primeTimeLabel(rule), nil, nil)
+	}
+
+	for _, rule := range rules {
+		if kept[rule] {
+			continue
+		}
+		if _, err := imp.q.CreatePrimeTimeRule(ctx, dbgen.CreatePrimeTimeRuleParams{
+			FacilityID:         imp.facilityID,
+			DayOfWeek:          rule.DayOfWeek,
+			StartTime:          rule.StartTime,
+			EndTime:            rule.EndTime,
+			MinMembershipLevel: rule.MinMembershipLevel,
+			UnlockHoursBefore:  rule.UnlockHoursBefore,
+		}); err != nil {
+			return fmt.Errorf("create prime time rule: %w", err)
+		}
+		kept[rule] = true
+		imp.record("primeTimeRules", actionCreate, primeTimeLabel(rule), nil, nil)
+	}
+	return nil
+}
+
+func primeTimeLabel(rule bundlePrimeTimeRule) string {
+	return fmt.Sprintf("%s %s-%s, level %d+, unlocks %dh before",
+		time.Weekday(rule.DayOfWeek), rule.StartTime, rule.EndTime, rule.MinMembershipLevel, rule.UnlockHoursBefore)
+}
+
+// applyOpenPlayRules creates or updates rules by name and replaces their
+// weekly slots. Rules the bundle does not name are left alone: they may
+// already have sessions.
+func (imp *importer) applyOpenPlayRules(ctx context.Context, rules []bundleOpenPlayRule) error {
+	existing, err := imp.q.ListOpenPlayRules(ctx, imp.facilityID)
+	if err != nil {
+		return fmt.Errorf("load open play rules: %w", err)
+	}
+	byName := make(map[string]dbgen.OpenPlayRule, len(existing))
+	for _, rule := range existing {
+		key := strings.ToLower(rule.Name)
+		if _, ok := byName[key]; !ok {
+			byName[key] = rule
+		}
+	}
+	courtNumbers, err := imp.courtNumbers(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		skillBands, err := rule.skillBands(courtNumbers)
+		if err != nil {
+			return err
+		}
+		maxLevel := sql.NullInt64{}
+		if rule.MaxMembershipLevel != nil {
+			maxLevel = sql.NullInt64{Int64: *rule.MaxMembershipLevel, Valid: true}
+		}
+
+		row, exists := byName[strings.ToLower(strings.TrimSpace(rule.Name))]
+		switch {
+		case !exists:
+			row, err = imp.q.CreateOpenPlayRule(ctx, dbgen.CreateOpenPlayRuleParams{
+				FacilityID:                imp.facilityID,
+				Name:                      strings.TrimSpace(rule.Name),
+				MinParticipants:           rule.MinParticipants,
+				MaxParticipantsPerCourt:   rule.MaxParticipantsPerCourt,
+				CancellationCutoffMinutes: rule.CancellationCutoffMinutes,
+				AutoScaleEnabled:          rule.AutoScaleEnabled,
+				MinCourts:                 rule.MinCourts,
+				MaxCourts:                 rule.MaxCourts,
+				MinMembershipLevel:        rule.MinMembershipLevel,
+				MaxMembershipLevel:        maxLevel,
+				SkillBands:                skillBands,
+			})
+			if err != nil {
+				return fmt.Errorf("create open play rule %q: %w", rule.Name, err)
+			}
+			imp.record("openPlayRules", actionCreate, row.Name, nil, nil)
+		case row.MinParticipants != rule.MinParticipants ||
+			row.MaxParticipantsPerCourt != rule.MaxParticipantsPerCourt ||
+			row.CancellationCutoffMinutes != rule.CancellationCutoffMinutes ||
+			row.AutoScaleEnabled != rule.AutoScaleEnabled ||
+			row.MinCourts != rule.MinCourts ||
+			row.MaxCourts != rule.MaxCourts ||
+			row.MinMembershipLevel != rule.MinMembershipLevel ||
+			row.MaxMembershipLevel != maxLevel ||
+			!sameSkillBands(row.SkillBands, skillBands):
+			row, err = imp.q.UpdateOpenPlayRule(ctx, dbgen.UpdateOpenPlayRuleParams{
+				Name:                      row.Name,
+				MinParticipants:           rule.MinParticipants,
+				MaxParticipantsPerCourt:   rule.MaxParticipantsPerCourt,
+				CancellationCutoffMinutes: rule.CancellationCutoffMinutes,
+				AutoScaleEnabled:          rule.AutoScaleEnabled,
+				MinCourts:                 rule.MinCourts,
+				MaxCourts:                 rule.MaxCourts,
+				MinMembershipLevel:        rule.MinMembershipLevel,
+				MaxMembershipLevel:        maxLevel,
+				SkillBands:                skillBands,
+				ID:                        row.ID,
+				FacilityID:                imp.facilityID,
+			})
+			if err != nil {
+				return fmt.Errorf("update open play rule %q: %w", rule.Name, err)
+			}
+			imp.record("openPlayRules", actionUpdate, row.Name, nil, nil)
+		}
+
+		if err := imp.applyOpenPlaySlots(ctx, row, rule.Slots); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (imp *importer) applyOpenPlaySlots(ctx context.Context, rule dbgen.OpenPlayRule, slots []bundleSlot) error {
+	existing, err := imp.q.ListOpenPlayRuleSlots(ctx, dbgen.ListOpenPlayRuleSlotsParams{
+		OpenPlayRuleID: rule.ID,
+		FacilityID:     imp.facilityID,
+	})
+	if err != nil {
+		return fmt.Errorf("load slots for open play rule %q: %w", rule.Name, err)
+	}
+	wanted := make(map[bundleSlot]bool, len(slots))
+	for _, slot := range slots {
+		wanted[slot] = true
+	}
+
+	kept := make(map[bundleSlot]bool, len(existing))
+	for _, row := range existing {
+		slot := bundleSlot{DayOfWeek: row.DayOfWeek, StartTime: row.StartTime, EndTime: row.EndTime}
+		if wanted[slot] {
+			kept[slot] = true
+			continue
+		}
+		if _, err := imp.q.DeleteOpenPlayRuleSlot(ctx, dbgen.DeleteOpenPlayRuleSlotParams{
+			ID:             row.ID,
+			OpenPlayRuleID: rule.ID,
+			FacilityID:     imp.facilityID,
+		}); err != nil {
+			return fmt.Errorf("delete slot for open play rule %q: %w", rule.Name, err)
+		}
+		imp.record("openPlayRules", actionDelete, slotLabel(rule.Name, slot), nil, nil)
+	}
+
+	for _, slot := range slots {
+		if kept[slot] {
+			continue
+		}
+		if _, err := imp.q.CreateOpenPlayRuleSlot(ctx, dbgen.CreateOpenPlayRuleSlotParams{
+			OpenPlayRuleID:  rule.ID,
+			DayOfWeek:       slot.DayOfWeek,
+			StartTime:       slot.StartTime,
+			EndTime:         slot.EndTime,
+			CreatedByUserID: imp.userID,
+		}); err != nil {
+			return fmt.Errorf("create slot for open play rule %q: %w", rule.Name, err)
+		}
+		imp.record("openPlayRules", actionCreate, slotLabel(rule.Name, slot), nil, nil)
+	}
+	return nil
+}
+
+func slotLabel(ruleName string, slot bundleSlot) string {
+	return fmt.Sprintf("%s slot %s %s-%s", ruleName, time.Weekday(slot.DayOfWeek), slot.StartTime, slot.EndTime)
+}
+
+// courtNumbers maps the lowercased names of the facility's courts to their
+// numbers. A name two courts share maps to 0 so using it is refused as
+// ambiguous.
+func (imp *importer) courtNumbers(ctx context.Context) (map[string]int64, error) {
+	courts, err := imp.q.ListCourts(ctx, imp.facilityID)
+	if err != nil {
+		return nil, fmt.Errorf("load courts: %w", err)
+	}
+	numbers := make(map[string]int64, len(courts))
+	for _, court := range courts {
+		key := strings.ToLower(strings.TrimSpace(court.Name))
+		if _, taken := numbers[key]; taken {
+			numbers[key] = 0
+			continue
+		}
+		numbers[key] = court.CourtNumber
+	}
+	return numbers, nil
+}
+
+// skillBands remaps the rule's bands onto the target facility's court
+// numbers and encodes them for storage.
+func (rule bundleOpenPlayRule) skillBands(courtNumbers map[string]int64) (sql.NullString, error) {
+	if len(rule.SkillBands) == 0 {
+		return sql.NullString{}, nil
+	}
+	bands := make([]apiutil.OpenPlaySkillBand, 0, len(rule.SkillBands))
+	for _, band := range rule.SkillBands {
+		mapped := apiutil.OpenPlaySkillBand{
+			Name:         band.Name,
+			MinRating:    band.MinRating,
+			MaxRating:    band.MaxRating,
+			CourtNumbers: make([]int64, 0, len(band.Courts)),
+		}
+		for _, court := range band.Courts {
+			number, ok := courtNumbers[strings.ToLower(strings.TrimSpace(court))]
+			switch {
+			case !ok:
+				return sql.NullString{}, apiutil.HandlerError{
+					Status:  http.StatusBadRequest,
+					Message: fmt.Sprintf("Open play rule %q uses court %q, which does not exist at this facility", rule.Name, court),
+				}
+			case number == 0:
+				return sql.NullString{}, apiutil.HandlerError{
+					Status:  http.StatusBadRequest,
+					Message: fmt.Sprintf("Open play rule %q uses court %q, but more than one court at this facility has that name", rule.Name, court),
+				}
+			}
+			mapped.CourtNumbers = append(mapped.CourtNumbers, number)
+		}
+		bands = append(bands, mapped)
+	}
+	if err := apiutil.ValidateOpenPlaySkillBands(bands); err != nil {
+		return sql.NullString{}, apiutil.HandlerError{
+			Status:  http.StatusBadRequest,
+			Message: fmt.Sprintf("Open play rule %q: %s", rule.Name, err),
+		}
+	}
+	encoded, err := apiutil.MarshalOpenPlaySkillBands(bands)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: encoded, Valid: true}, nil
+}
+
+// sameSkillBands compares stored bands, treating NULL and no bands alike.
+func sameSkillBands(current, wanted sql.NullString) bool {
+	currentBands, err := apiutil.ParseOpenPlaySkillBands(current)
+	if err != nil {
+		return false
+	}
+	if len(currentBands) == 0 || !wanted.Valid {
+		return len(currentBands) == 0 && !wanted.Valid
+	}
+	encoded, err := apiutil.MarshalOpenPlaySkillBands(currentBands)
+	return err == nil && encoded == wanted.String
+}
+
+// applyThemes creates or recolors the facility's themes by name. Themes the
+// bundle does not name are kept.
+func (imp *importer) applyThemes(ctx context.Context, themes []bundleTheme) error {
+	existing, err := imp.q.ListFacilityThemes(ctx, sql.NullInt64{Int64: imp.facilityID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("load themes: %w", err)
+	}
+	byName := make(map[string]dbgen.Theme, len(existing))
+	for _, theme := range existing {
+		byName[strings.ToLower(theme.Name)] = theme
+	}
+
+	for _, theme := range themes {
+		row, exists := byName[strings.ToLower(theme.Name)]
+		if !exists {
+			if _, err := imp.q.CreateTheme(ctx, dbgen.CreateThemeParams{
+				FacilityID:     sql.NullInt64{Int64: imp.facilityID, Valid: true},
+				Name:           theme.Name,
+				PrimaryColor:   theme.PrimaryColor,
+				SecondaryColor: theme.SecondaryColor,
+				TertiaryColor:  theme.TertiaryColor,
+				AccentColor:    theme.AccentColor,
+				HighlightColor: theme.HighlightColor,
+			}); err != nil {
+				return fmt.Errorf("create theme %q: %w", theme.Name, err)
+			}
+			imp.record("themes", actionCreate, theme.Name, nil, nil)
+			continue
+		}
+		if strings.EqualFold(row.PrimaryColor, theme.PrimaryColor) &&
+			strings.EqualFold(row.SecondaryColor, theme.SecondaryColor) &&
+			strings.EqualFold(row.TertiaryColor, theme.TertiaryColor) &&
+			strings.EqualFold(row.AccentColor, theme.AccentColor) &&
+			strings.EqualFold(row.HighlightColor, theme.HighlightColor) {
+			continue
+		}
+		if _, err := imp.q.UpdateTheme(ctx, dbgen.UpdateThemeParams{
+			Name:           row.Name,
+			PrimaryColor:   theme.PrimaryColor,
+			SecondaryColor: theme.SecondaryColor,
+			TertiaryColor:  theme.TertiaryColor,
+			AccentColor:    theme.AccentColor,
+			HighlightColor: theme.HighlightColor,
+			ID:             row.ID,
+		}); err != nil {
+			return fmt.Errorf("update theme %q: %w", theme.Name, err)
+		}
+		imp.record("themes", actionUpdate, row.Name, nil, nil)
+	}
+	return nil
+}
+
+// applyActiveTheme activates the named theme, looking among the facility's
+// own themes before the system themes.
+func (imp *importer) applyActiveTheme(ctx context.Context, name string) error {
+	facilityThemes, err := imp.q.ListFacilityThemes(ctx, sql.NullInt64{Int64: imp.facilityID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("load themes: %w", err)
+	}
+	systemThemes, err := imp.q.ListSystemThemes(ctx)
+	if err != nil {
+		return fmt.Errorf("load system themes: %w", err)
+	}
+
+	var target *dbgen.Theme
+	for _, candidates := range [][]dbgen.Theme{facilityThemes, systemThemes} {
+		for i := range candidates {
+			if strings.EqualFold(candidates[i].Name, name) {
+				target = &candidates[i]
+				break
+			}
+		}
+		if target != nil {
+			break
+		}
+	}
+	if target == nil {
+		return apiutil.HandlerError{
+			Status:  http.StatusBadRequest,
+			Message: fmt.Sprintf("Active theme %q is neither in the bundle nor a system theme", name),
+		}
+	}
+
+	currentID, err := imp.q.GetActiveThemeID(ctx, imp.facilityID)
+	if err != nil {
+		return fmt.Errorf("load active theme: %w", err)
+	}
+	if currentID == target.ID {
+		return nil
+	}
+	var from any
+	if currentID != 0 {
+		if current, err := imp.q.GetTheme(ctx, currentID); err == nil {
+			from = current.Name
+		}
+	}
+	if _, err := imp.q.UpsertActiveThemeID(ctx, dbgen.UpsertActiveThemeIDParams{
+		ActiveThemeID: sql.NullInt64{Int64: target.ID, Valid: true},
+		FacilityID:    imp.facilityID,
+	}); err != nil {
+		return fmt.Errorf("set active theme: %w", err)
+	}
+	imp.record("activeTheme", actionUpdate, target.Name, from, target.Name)
+	return nil
+}
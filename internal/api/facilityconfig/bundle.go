@@ -0,0 +1,385 @@
+// internal/api/facilityconfig/bundle.go
+package facilityconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/models"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+// bundleVersion is the bundle format this server writes and the newest it
+// accepts. Bump it whenever a field is added so older servers refuse the
+// bundle instead of dropping the field.
+const bundleVersion = 1
+
+// configBundle is a facility's configuration without its members, courts or
+// bookings. Other facilities' IDs mean nothing to the importer, so the bundle
+// names reservation types, courts and themes instead.
+//
+// A section left out of an imported bundle (null) is not touched; an empty
+// list clears it.
+type configBundle struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	// Facility names the source facility. Import ignores it.
+	Facility             bundleFacility        `json:"facility"`
+	Booking              *bundleBooking        `json:"booking"`
+	Hours                []bundleHours         `json:"hours"`
+	ReservationTypes     []string              `json:"reservationTypes"`
+	CancellationPolicies []bundlePolicy        `json:"cancellationPolicies"`
+	PrimeTimeRules       []bundlePrimeTimeRule `json:"primeTimeRules"`
+	OpenPlayRules        []bundleOpenPlayRule  `json:"openPlayRules"`
+	Themes               []bundleTheme         `json:"themes"`
+	ActiveTheme          string                `json:"activeTheme,omitempty"`
+}
+
+type bundleFacility struct {
+	Name     string `json:"name"`
+	Timezone string `json:"timezone"`
+}
+
+type bundleBooking struct {
+	MaxAdvanceBookingDays     int64  `json:"maxAdvanceBookingDays"`
+	MaxMemberReservations     int64  `json:"maxMemberReservations"`
+	MaxCourtsPerMemberBooking int64  `json:"maxCourtsPerMemberBooking"`
+	LessonMinNoticeHours      int64  `json:"lessonMinNoticeHours"`
+	SlotDurationMinutes       int64  `json:"slotDurationMinutes"`
+	MinBookingMinutes         int64  `json:"minBookingMinutes"`
+	MaxGuestsPerReservation   int64  `json:"maxGuestsPerReservation"`
+	ReservationLimitScope     string `json:"reservationLimitScope"`
+	BufferMinutes             int64  `json:"bufferMinutes"`
+}
+
+// bundleHours is one open day. Days missing from the list are closed.
+type bundleHours struct {
+	DayOfWeek int64  `json:"dayOfWeek"`
+	OpensAt   string `json:"opensAt"`
+	ClosesAt  string `json:"closesAt"`
+}
+
+// bundlePolicy is a cancellation policy: the facility default when
+// ReservationType is empty, otherwise the named type's override.
+type bundlePolicy struct {
+	ReservationType string       `json:"reservationType,omitempty"`
+	Tiers           []bundleTier `json:"tiers"`
+}
+
+type bundleTier struct {
+	MinHoursBefore   int64 `json:"minHoursBefore"`
+	RefundPercentage int64 `json:"refundPercentage"`
+}
+
+type bundlePrimeTimeRule struct {
+	DayOfWeek          int64  `json:"dayOfWeek"`
+	StartTime          string `json:"startTime"`
+	EndTime            string `json:"endTime"`
+	MinMembershipLevel int64  `json:"minMembershipLevel"`
+	UnlockHoursBefore  int64  `json:"unlockHoursBefore"`
+}
+
+type bundleOpenPlayRule struct {
+	Name                      string            `json:"name"`
+	MinParticipants           int64             `json:"minParticipants"`
+	MaxParticipantsPerCourt   int64             `json:"maxParticipantsPerCourt"`
+	CancellationCutoffMinutes int64             `json:"cancellationCutoffMinutes"`
+	AutoScaleEnabled          bool              `json:"autoScaleEnabled"`
+	MinCourts                 int64             `json:"minCourts"`
+	MaxCourts                 int64             `json:"maxCourts"`
+	MinMembershipLevel        int64             `json:"minMembershipLevel"`
+	MaxMembershipLevel        *int64            `json:"maxMembershipLevel,omitempty"`
+	SkillBands                []bundleSkillBand `json:"skillBands,omitempty"`
+	Slots                     []bundleSlot      `json:"slots"`
+}
+
+// bundleSkillBand is an apiutil.OpenPlaySkillBand with its courts named.
+type bundleSkillBand struct {
+	Name      string   `json:"name,omitempty"`
+	MinRating float64  `json:"minRating"`
+	MaxRating *float64 `json:"maxRating,omitempty"`
+	Courts    []string `json:"courts"`
+}
+
+type bundleSlot struct {
+	DayOfWeek int64  `json:"dayOfWeek"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+type bundleTheme struct {
+	Name           string `json:"name"`
+	PrimaryColor   string `json:"primaryColor"`
+	SecondaryColor string `json:"secondaryColor"`
+	TertiaryColor  string `json:"tertiaryColor"`
+	AccentColor    string `json:"accentColor"`
+	HighlightColor string `json:"highlightColor"`
+}
+
+// decodeBundle reads an uploaded bundle. The version is checked before the
+// strict decode so a bundle from a newer server is refused for its version,
+// not for the first field this server has never heard of.
+func decodeBundle(body []byte) (configBundle, error) {
+	var header struct {
+		Version *int `json:"version"`
+	}
+	if err := json.Unmarshal(body, &header); err != nil {
+		return configBundle{}, fmt.Errorf("bundle is not valid JSON: %w", err)
+	}
+	switch {
+	case header.Version == nil:
+		return configBundle{}, apiutil.FieldError{Field: "version", Reason: "is required"}
+	case *header.Version > bundleVersion:
+		return configBundle{}, apiutil.FieldError{
+			Field:  "version",
+			Reason: fmt.Sprintf("%d is newer than this server supports (%d); upgrade before importing", *header.Version, bundleVersion),
+		}
+	case *header.Version < 1:
+		return configBundle{}, apiutil.FieldError{Field: "version", Reason: "must be 1 or greater"}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	var bundle configBundle
+	if err := decoder.Decode(&bundle); err != nil {
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return configBundle{}, fmt.Errorf("unknown field %s in a version %d bundle", field, *header.Version)
+		}
+		return configBundle{}, fmt.Errorf("invalid bundle: %w", err)
+	}
+	if decoder.More() {
+		return configBundle{}, errors.New("invalid bundle: trailing data after the bundle")
+	}
+	return bundle, nil
+}
+
+// validate checks the bundle on its own, before anything is looked up at the
+// target facility. Errors name the offending field by its bundle path.
+func (b configBundle) validate() error {
+	if b.Booking != nil {
+		if err := b.Booking.validate(); err != nil {
+			return err
+		}
+	}
+
+	days := make(map[int64]bool, len(b.Hours))
+	for i, hours := range b.Hours {
+		path := fmt.Sprintf("hours[%d]", i)
+		if err := validateDayOfWeek(path, hours.DayOfWeek); err != nil {
+			return err
+		}
+		if days[hours.DayOfWeek] {
+			return apiutil.FieldError{Field: path + ".dayOfWeek", Reason: "repeats an earlier day"}
+		}
+		days[hours.DayOfWeek] = true
+		if err := validateClockRange(path, "opensAt", hours.OpensAt, "closesAt", hours.ClosesAt); err != nil {
+			return err
+		}
+	}
+
+	for i, name := range b.ReservationTypes {
+		if strings.TrimSpace(name) == "" {
+			return apiutil.FieldError{Field: fmt.Sprintf("reservationTypes[%d]", i), Reason: "is required"}
+		}
+	}
+
+	policies := make(map[string]bool, len(b.CancellationPolicies))
+	for i, policy := range b.CancellationPolicies {
+		path := fmt.Sprintf("cancellationPolicies[%d]", i)
+		key := strings.ToLower(strings.TrimSpace(policy.ReservationType))
+		if policies[key] {
+			return apiutil.FieldError{Field: path + ".reservationType", Reason: "repeats an earlier policy"}
+		}
+		policies[key] = true
+		if len(policy.Tiers) == 0 {
+			return apiutil.FieldError{Field: path + ".tiers", Reason: "must have at least one tier"}
+		}
+		hours := make(map[int64]bool, len(policy.Tiers))
+		for j, tier := range policy.Tiers {
+			tierPath := fmt.Sprintf("%s.tiers[%d]", path, j)
+			switch {
+			case tier.MinHoursBefore < 0:
+				return apiutil.FieldError{Field: tierPath + ".minHoursBefore", Reason: "must be 0 or greater"}
+			case hours[tier.MinHoursBefore]:
+				return apiutil.FieldError{Field: tierPath + ".minHoursBefore", Reason: "repeats an earlier tier"}
+			case tier.RefundPercentage < 0 || tier.RefundPercentage > 100:
+				return apiutil.FieldError{Field: tierPath + ".refundPercentage", Reason: "must be between 0 and 100"}
+			}
+			hours[tier.MinHoursBefore] = true
+		}
+	}
+
+	for i, rule := range b.PrimeTimeRules {
+		path := fmt.Sprintf("primeTimeRules[%d]", i)
+		if err := validateDayOfWeek(path, rule.DayOfWeek); err != nil {
+			return err
+		}
+		if err := validateClockRange(path, "startTime", rule.StartTime, "endTime", rule.EndTime); err != nil {
+			return err
+		}
+		if rule.MinMembershipLevel < 1 {
+			return apiutil.FieldError{Field: path + ".minMembershipLevel", Reason: "must be at least 1"}
+		}
+		if rule.UnlockHoursBefore < 0 || rule.UnlockHoursBefore > apiutil.MaxPrimeTimeUnlockHours {
+			return apiutil.FieldError{Field: path + ".unlockHoursBefore", Reason: fmt.Sprintf("must be between 0 and %d", apiutil.MaxPrimeTimeUnlockHours)}
+		}
+	}
+
+	rules := make(map[string]bool, len(b.OpenPlayRules))
+	for i, rule := range b.OpenPlayRules {
+		path := fmt.Sprintf("openPlayRules[%d]", i)
+		key := strings.ToLower(strings.TrimSpace(rule.Name))
+		if key == "" {
+			return apiutil.FieldError{Field: path + ".name", Reason: "is required"}
+		}
+		if rules[key] {
+			return apiutil.FieldError{Field: path + ".name", Reason: "repeats an earlier rule"}
+		}
+		rules[key] = true
+		if err := rule.validate(path); err != nil {
+			return err
+		}
+	}
+
+	themes := make(map[string]bool, len(b.Themes))
+	for i, theme := range b.Themes {
+		path := fmt.Sprintf("themes[%d]", i)
+		if themes[strings.ToLower(theme.Name)] {
+			return apiutil.FieldError{Field: path + ".name", Reason: "repeats an earlier theme"}
+		}
+		themes[strings.ToLower(theme.Name)] = true
+		placeholderFacilityID := int64(1)
+		candidate := models.Theme{
+			FacilityID:     &placeholderFacilityID,
+			Name:           theme.Name,
+			PrimaryColor:   theme.PrimaryColor,
+			SecondaryColor: theme.SecondaryColor,
+			TertiaryColor:  theme.TertiaryColor,
+			AccentColor:    theme.AccentColor,
+			HighlightColor: theme.HighlightColor,
+		}
+		if err := candidate.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+func (b bundleBooking) validate() error {
+	positive := []struct {
+		field string
+		value int64
+	}{
+		{"maxAdvanceBookingDays", b.MaxAdvanceBookingDays},
+		{"maxMemberReservations", b.MaxMemberReservations},
+		{"maxCourtsPerMemberBooking", b.MaxCourtsPerMemberBooking},
+	}
+	for _, setting := range positive {
+		if setting.value <= 0 {
+			return apiutil.FieldError{Field: "booking." + setting.field, Reason: "must be greater than 0"}
+		}
+	}
+	if b.LessonMinNoticeHours < 0 {
+		return apiutil.FieldError{Field: "booking.lessonMinNoticeHours", Reason: "must be 0 or greater"}
+	}
+	for _, setting := range []struct {
+		field string
+		value int64
+		max   int64
+	}{
+		{"slotDurationMinutes", b.SlotDurationMinutes, apiutil.MaxSlotDurationMinutes},
+		{"minBookingMinutes", b.MinBookingMinutes, apiutil.MaxMinBookingMinutes},
+	} {
+		if setting.value <= 0 || setting.value%apiutil.BookingMinutesStep != 0 || setting.value > setting.max {
+			return apiutil.FieldError{
+				Field:  "booking." + setting.field,
+				Reason: fmt.Sprintf("must be a multiple of %d minutes, at most %d", apiutil.BookingMinutesStep, setting.max),
+			}
+		}
+	}
+	if b.MaxGuestsPerReservation < 0 || b.MaxGuestsPerReservation > apiutil.MaxGuestsPerReservation {
+		return apiutil.FieldError{Field: "booking.maxGuestsPerReservation", Reason: fmt.Sprintf("must be between 0 and %d", apiutil.MaxGuestsPerReservation)}
+	}
+	if b.BufferMinutes < 0 || b.BufferMinutes > apiutil.MaxBufferMinutes {
+		return apiutil.FieldError{Field: "booking.bufferMinutes", Reason: fmt.Sprintf("must be between 0 and %d", apiutil.MaxBufferMinutes)}
+	}
+	switch b.ReservationLimitScope {
+	case reservationsvc.LimitScopePerson, reservationsvc.LimitScopeHousehold:
+		return nil
+	default:
+		return apiutil.FieldError{Field: "booking.reservationLimitScope", Reason: "must be person or household"}
+	}
+}
+
+func (rule bundleOpenPlayRule) validate(path string) error {
+	switch {
+	case rule.MinParticipants <= 0:
+		return apiutil.FieldError{Field: path + ".minParticipants", Reason: "must be greater than 0"}
+	case rule.MaxParticipantsPerCourt <= 0:
+		return apiutil.FieldError{Field: path + ".maxParticipantsPerCourt", Reason: "must be greater than 0"}
+	case rule.CancellationCutoffMinutes < 0:
+		return apiutil.FieldError{Field: path + ".cancellationCutoffMinutes", Reason: "must be 0 or greater"}
+	case rule.MinCourts <= 0:
+		return apiutil.FieldError{Field: path + ".minCourts", Reason: "must be greater than 0"}
+	case rule.MaxCourts < rule.MinCourts:
+		return apiutil.FieldError{Field: path + ".maxCourts", Reason: "must be greater than or equal to minCourts"}
+	case rule.MinParticipants > rule.MaxParticipantsPerCourt*rule.MinCourts:
+		return apiutil.FieldError{Field: path + ".minParticipants", Reason: "must be less than or equal to maxParticipantsPerCourt * minCourts"}
+	case rule.MinMembershipLevel < 0 || rule.MinMembershipLevel > apiutil.MaxMembershipLevel:
+		return apiutil.FieldError{Field: path + ".minMembershipLevel", Reason: fmt.Sprintf("must be between 0 and %d", apiutil.MaxMembershipLevel)}
+	case rule.MaxMembershipLevel != nil && (*rule.MaxMembershipLevel < rule.MinMembershipLevel || *rule.MaxMembershipLevel > apiutil.MaxMembershipLevel):
+		return apiutil.FieldError{Field: path + ".maxMembershipLevel", Reason: fmt.Sprintf("must be between minMembershipLevel and %d", apiutil.MaxMembershipLevel)}
+	}
+	for i, band := range rule.SkillBands {
+		for j, court := range band.Courts {
+			if strings.TrimSpace(court) == "" {
+				return apiutil.FieldError{Field: fmt.Sprintf("%s.skillBands[%d].courts[%d]", path, i, j), Reason: "is required"}
+			}
+		}
+	}
+	slots := make(map[bundleSlot]bool, len(rule.Slots))
+	for i, slot := range rule.Slots {
+		slotPath := fmt.Sprintf("%s.slots[%d]", path, i)
+		if err := validateDayOfWeek(slotPath, slot.DayOfWeek); err != nil {
+			return err
+		}
+		if err := validateClockRange(slotPath, "startTime", slot.StartTime, "endTime", slot.EndTime); err != nil {
+			return err
+		}
+		if slots[slot] {
+			return apiutil.FieldError{Field: slotPath, Reason: "repeats an earlier slot"}
+		}
+		slots[slot] = true
+	}
+	return nil
+}
+
+func validateDayOfWeek(path string, day int64) error {
+	if day < 0 || day > 6 {
+		return apiutil.FieldError{Field: path + ".dayOfWeek", Reason: "must be between 0 and 6"}
+	}
+	return nil
+}
+
+// validateClockRange checks a pair of HH:MM times where the second must come
+// after the first on the same day.
+func validateClockRange(path, startField, start, endField, end string) error {
+	startTime, err := time.Parse(clockLayout, start)
+	if err != nil {
+		return apiutil.FieldError{Field: path + "." + startField, Reason: "must be in HH:MM format"}
+	}
+	endTime, err := time.Parse(clockLayout, end)
+	if err != nil {
+		return apiutil.FieldError{Field: path + "." + endField, Reason: "must be in HH:MM format"}
+	}
+	if !endTime.After(startTime) {
+		return apiutil.FieldError{Field: path + "." + endField, Reason: "must be after " + startField}
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+// internal/api/facilityconfig/export.go
+package facilityconfig
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const clockLayout = "15:04"
+
+// exportBundle reads facilityID's configuration into a bundle.
+func exportBundle(ctx context.Context, q *dbgen.Queries, facilityID int64, now time.Time) (configBundle, error) {
+	facility, err := q.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		return configBundle{}, err
+	}
+	bundle := configBundle{
+		Version:    bundleVersion,
+		ExportedAt: now.UTC(),
+		Facility: bundleFacility{
+			Name:     facility.Name,
+			Timezone: facility.Timezone,
+		},
+		Booking: &bundleBooking{
+			MaxAdvanceBookingDays:     facility.MaxAdvanceBookingDays,
+			MaxMemberReservations:     facility.MaxMemberReservations,
+			MaxCourtsPerMemberBooking: facility.MaxCourtsPerMemberBooking,
+			LessonMinNoticeHours:      facility.LessonMinNoticeHours,
+			SlotDurationMinutes:       facility.SlotDurationMinutes,
+			MinBookingMinutes:         facility.MinBookingMinutes,
+			MaxGuestsPerReservation:   facility.MaxGuestsPerReservation,
+			ReservationLimitScope:     facility.ReservationLimitScope,
+			BufferMinutes:             facility.BufferMinutes,
+		},
+		Hours:                []bundleHours{},
+		ReservationTypes:     []string{},
+		CancellationPolicies: []bundlePolicy{},
+		PrimeTimeRules:       []bundlePrimeTimeRule{},
+		OpenPlayRules:        []bundleOpenPlayRule{},
+		Themes:               []bundleTheme{},
+	}
+
+	hours, err := q.GetFacilityHours(ctx, facilityID)
+	if err != nil {
+		return configBundle{}, fmt.Errorf("load operating hours: %w", err)
+	}
+	for _, day := range hours {
+		bundle.Hours = append(bundle.Hours, bundleHours{
+			DayOfWeek: day.DayOfWeek,
+			OpensAt:   clockValue(day.OpensAt),
+			ClosesAt:  clockValue(day.ClosesAt),
+		})
+	}
+
+	types, err := q.ListReservationTypesForFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
+	if err != nil {
+		return configBundle{}, fmt.Errorf("load reservation types: %w", err)
+	}
+	typeNames := make(map[int64]string, len(types))
+	for _, reservationType := range types {
+		bundle.ReservationTypes = append(bundle.ReservationTypes, reservationType.Name)
+		typeNames[reservationType.ID] = reservationType.Name
+	}
+
+	policies, err := exportCancellationPolicies(ctx, q, facilityID, typeNames)
+	if err != nil {
+		return configBundle{}, err
+	}
+	bundle.CancellationPolicies = policies
+
+	primeTimeRules, err := q.ListPrimeTimeRules(ctx, facilityID)
+	if err != nil {
+		return configBundle{}, fmt.Errorf("load prime time rules: %w", err)
+	}
+	for _, rule := range primeTimeRules {
+		bundle.PrimeTimeRules = append(bundle.PrimeTimeRules, bundlePrimeTimeRule{
+			DayOfWeek:          rule.DayOfWeek,
+			StartTime:          rule.StartTime,
+			EndTime:            rule.EndTime,
+			MinMembershipLevel: rule.MinMembershipLevel,
+			UnlockHoursBefore:  rule.UnlockHoursBefore,
+		})
+	}
+
+	openPlayRules, err := exportOpenPlayRules(ctx, q, facilityID)
+	if err != nil {
+		return configBundle{}, err
+	}
+	bundle.OpenPlayRules = openPlayRules
+
+	themes, err := q.ListFacilityThemes(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
+	if err != nil {
+		return configBundle{}, fmt.Errorf("load themes: %w", err)
+	}
+	for _, theme := range themes {
+		bundle.Themes = append(bundle.Themes, bundleTheme{
+			Name:           theme.Name,
+			PrimaryColor:   theme.PrimaryColor,
+			SecondaryColor: theme.SecondaryColor,
+			TertiaryColor:  theme.TertiaryColor,
+			AccentColor:    theme.AccentColor,
+			HighlightColor: theme.HighlightColor,
+		})
+	}
+	if facility.ActiveThemeID.Valid {
+		active, err := q.GetTheme(ctx, facility.ActiveThemeID.Int64)
+		if err != nil {
+			return configBundle{}, fmt.Errorf("load active theme: %w", err)
+		}
+		bundle.ActiveTheme = active.Name
+	}
+
+	return bundle, nil
+}
+
+// exportCancellationPolicies groups the facility's tiers by policy, the
+// default first and overrides by type name.
+func exportCancellationPolicies(ctx context.Context, q *dbgen.Queries, facilityID int64, typeNames map[int64]string) ([]bundlePolicy, error) {
+	tiers, err := q.ListCancellationPolicyTiers(ctx, dbgen.ListCancellationPolicyTiersParams{FacilityID: facilityID})
+	if err != nil {
+		return nil, fmt.Errorf("load cancellation policy tiers: %w", err)
+	}
+	byType := make(map[string]*bundlePolicy)
+	for _, tier := range tiers {
+		var name string
+		if tier.ReservationTypeID.Valid {
+			known, ok := typeNames[tier.ReservationTypeID.Int64]
+			if !ok {
+				reservationType, err := q.GetReservationType(ctx, tier.ReservationTypeID.Int64)
+				if err != nil {
+					return nil, fmt.Errorf("load reservation type %d: %w", tier.ReservationTypeID.Int64, err)
+				}
+				known = reservationType.Name
+			}
+			name = known
+		}
+		policy, ok := byType[name]
+		if !ok {
+			policy = &bundlePolicy{ReservationType: name}
+			byType[name] = policy
+		}
+		policy.Tiers = append(policy.Tiers, bundleTier{
+			MinHoursBefore:   tier.MinHoursBefore,
+			RefundPercentage: tier.RefundPercentage,
+		})
+	}
+
+	policies := make([]bundlePolicy, 0, len(byType))
+	for _, policy := range byType {
+		sort.Slice(policy.Tiers, func(i, j int) bool { return policy.Tiers[i].MinHoursBefore < policy.Tiers[j].MinHoursBefore })
+		policies = append(policies, *policy)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].ReservationType < policies[j].ReservationType })
+	return policies, nil
+}
+
+// exportOpenPlayRules writes each rule with its weekly slots and its skill
+// bands' courts named rather than numbered.
+func exportOpenPlayRules(ctx context.Context, q *dbgen.Queries, facilityID int64) ([]bundleOpenPlayRule, error) {
+	rules, err := q.ListOpenPlayRules(ctx, facilityID)
+	if err != nil {
+		return nil, fmt.Errorf("load open play rules: %w", err)
+	}
+	courts, err := q.ListCourts(ctx, facilityID)
+	if err != nil {
+		return nil, fmt.Errorf("load courts: %w", err)
+	}
+	courtNames := make(map[int64]string, len(courts))
+	for _, court := range courts {
+		courtNames[court.CourtNumber] = court.Name
+	}
+
+	exported := make([]bundleOpenPlayRule, 0, len(rules))
+	for _, rule := range rules {
+		entry := bundleOpenPlayRule{
+			Name:                      rule.Name,
+			MinParticipants:           rule.MinParticipants,
+			MaxParticipantsPerCourt:   rule.MaxParticipantsPerCourt,
+			CancellationCutoffMinutes: rule.CancellationCutoffMinutes,
+			AutoScaleEnabled:          rule.AutoScaleEnabled,
+			MinCourts:                 rule.MinCourts,
+			MaxCourts:                 rule.MaxCourts,
+			MinMembershipLevel:        rule.MinMembershipLevel,
+			Slots:                     []bundleSlot{},
+		}
+		if rule.MaxMembershipLevel.Valid {
+			maxLevel := rule.MaxMembershipLevel.Int64
+			entry.MaxMembershipLevel = &maxLevel
+		}
+
+		bands, err := apiutil.ParseOpenPlaySkillBands(rule.SkillBands)
+		if err != nil {
+			return nil, fmt.Errorf("open play rule %d: %w", rule.ID, err)
+		}
+		for _, band := range bands {
+			exportedBand := bundleSkillBand{
+				Name:      band.Name,
+				MinRating: band.MinRating,
+				MaxRating: band.MaxRating,
+				Courts:    make([]string, 0, len(band.CourtNumbers)),
+			}
+			for _, number := range band.CourtNumbers {
+				name, ok := courtNames[number]
+				if !ok {
+					return nil, fmt.Errorf("open play rule %q plays on court %d, which no longer exists", rule.Name, number)
+				}
+				exportedBand.Courts = append(exportedBand.Courts, name)
+			}
+			entry.SkillBands = append(entry.SkillBands, exportedBand)
+		}
+
+		slots, err := q.ListOpenPlayRuleSlots(ctx, dbgen.ListOpenPlayRuleSlotsParams{
+			OpenPlayRuleID: rule.ID,
+			FacilityID:     facilityID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("load slots for open play rule %d: %w", rule.ID, err)
+		}
+		for _, slot := range slots {
+			entry.Slots = append(entry.Slots, bundleSlot{
+				DayOfWeek: slot.DayOfWeek,
+				StartTime: slot.StartTime,
+				EndTime:   slot.EndTime,
+			})
+		}
+		exported = append(exported, entry)
+	}
+	return exported, nil
+}
+
+// clockValue renders a stored operating hours time as HH:MM, whichever form
+// the driver returned it in.
+func clockValue(value interface{}) string {
+	formatted := apiutil.FormatOperatingHourValue(value)
+	for _, layout := range []string{clockLayout, "15:04:05", time.RFC3339, "2006-01-02 15:04:05"} {
+		if parsed, err := time.Parse(layout, formatted); err == nil {
+			return parsed.Format(clockLayout)
+		}
+	}
+	return formatted
+}
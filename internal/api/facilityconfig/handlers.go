@@ -0,0 +1,204 @@
+// internal/api/facilityconfig/handlers.go
+package facilityconfig
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	configQueryTimeout = 10 * time.Second
+	facilityIDParam    = "id"
+	maxBundleBytes     = 1 << 20
+)
+
+// errDryRun rolls back a dry-run import once its changes are listed.
+var errDryRun = errors.New("dry run")
+
+// Handlers serves facility configuration export and import. Each instance
+// holds its own database.
+type Handlers struct {
+	queries *dbgen.Queries
+	store   *appdb.DB
+}
+
+// NewHandlers returns the configuration handlers over database. With a nil
+// database every handler answers 500.
+func NewHandlers(database *appdb.DB) *Handlers {
+	h := &Handlers{}
+	if database != nil {
+		h.queries = database.Queries
+		h.store = database
+	}
+	return h
+}
+
+type importResponse struct {
+	DryRun  bool           `json:"dryRun"`
+	Changes []configChange `json:"changes"`
+}
+
+// GET /api/v1/facilities/{id}/config/export
+//
+// Downloads the facility's configuration as a versioned JSON bundle for
+// import into another facility.
+func (h *Handlers) HandleConfigExport(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if h.queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), configQueryTimeout)
+	defer cancel()
+
+	bundle, err := exportBundle(ctx, h.queries, facilityID, time.Now())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Facility not found")
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to export facility configuration")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to export facility configuration")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="facility-%d-config.json"`, facilityID))
+	if err := apiutil.WriteJSON(w, http.StatusOK, bundle); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write facility configuration export")
+	}
+}
+
+// POST /api/v1/facilities/{id}/config/import
+//
+// Applies an exported bundle to the facility in one transaction. With
+// dry_run=true the changes are listed and rolled back.
+func (h *Handlers) HandleConfigImport(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if h.queries == nil || h.store == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBundleBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			apiutil.WriteError(w, r, http.StatusRequestEntityTooLarge, "Bundle is too large")
+			return
+		}
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Failed to read bundle")
+		return
+	}
+	bundle, err := decodeBundle(body)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if err := bundle.validate(); err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	dryRun := apiutil.ParseBool(r.URL.Query().Get("dry_run"))
+
+	ctx, cancel := context.WithTimeout(r.Context(), configQueryTimeout)
+	defer cancel()
+
+	if _, err := h.queries.GetFacilityByID(ctx, facilityID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Facility not found")
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility for configuration import")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to import facility configuration")
+		return
+	}
+
+	var changes []configChange
+	err = h.store.RunInTx(ctx, func(txdb *appdb.DB) error {
+		imp := &importer{q: txdb.Queries, facilityID: facilityID, userID: user.ID}
+		if err := imp.apply(ctx, bundle); err != nil {
+			return err
+		}
+		changes = imp.changes
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to import facility configuration")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to import facility configuration")
+		return
+	}
+	if !dryRun {
+		h.store.Facilities.Invalidate(facilityID)
+		logger.Info().
+			Int64("facility_id", facilityID).
+			Int64("user_id", user.ID).
+			Str("source_facility", bundle.Facility.Name).
+			Int("changes", len(changes)).
+			Msg("Facility configuration imported")
+	}
+
+	if changes == nil {
+		changes = []configChange{}
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, importResponse{DryRun: dryRun, Changes: changes}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write facility configuration import response")
+	}
+}
+
+func facilityIDFromPath(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(r.PathValue(facilityIDParam)), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid facility ID")
+	}
+	return id, nil
+}
@@ -0,0 +1,289 @@
+package facilityconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type configFixture struct {
+	h        *Handlers
+	database *db.DB
+	sourceID int64
+	targetID int64
+	staffID  int64
+}
+
+// setupConfigTest configures a source facility and leaves a second facility
+// in the same organization at its defaults. The target numbers its courts
+// differently so skill bands have to be remapped by name.
+func setupConfigTest(t *testing.T) configFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.Exec(query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	sourceID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Downtown', 'downtown', 'UTC')", orgID)
+	targetID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Westside', 'westside', 'UTC')", orgID)
+	staffID := exec("INSERT INTO users (first_name, last_name, email, status) VALUES ('Front', 'Desk', 'desk@test.com', 'active')")
+
+	exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Center', 1, 'active'), (?, 'North', 2, 'active')", sourceID, sourceID)
+	exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'North', 5, 'active'), (?, 'Center', 7, 'active')", targetID, targetID)
+
+	exec(`UPDATE facilities SET max_advance_booking_days = 14, max_member_reservations = 5, max_courts_per_member_booking = 2,
+		lesson_min_notice_hours = 12, slot_duration_minutes = 30, min_booking_minutes = 90, max_guests_per_reservation = 3,
+		reservation_limit_scope = 'household', buffer_minutes = 15 WHERE id = ?`, sourceID)
+	exec("INSERT INTO operating_hours (facility_id, day_of_week, opens_at, closes_at) VALUES (?, 1, '06:00', '22:00'), (?, 6, '08:00', '18:00')", sourceID, sourceID)
+	exec("INSERT INTO operating_hours (facility_id, day_of_week, opens_at, closes_at) VALUES (?, 0, '09:00', '17:00')", targetID)
+	exec("INSERT INTO cancellation_policy_tiers (facility_id, reservation_type_id, min_hours_before, refund_percentage) VALUES (?, NULL, 0, 0), (?, NULL, 24, 100)", sourceID, sourceID)
+	exec(`INSERT INTO cancellation_policy_tiers (facility_id, reservation_type_id, min_hours_before, refund_percentage)
+		VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), 48, 50)`, sourceID)
+	exec("INSERT INTO prime_time_rules (facility_id, day_of_week, start_time, end_time, min_membership_level, unlock_hours_before) VALUES (?, 2, '17:00', '20:00', 2, 48)", sourceID)
+	ruleID := exec(`INSERT INTO open_play_rules (facility_id, name, min_participants, max_participants_per_court, cancellation_cutoff_minutes,
+		auto_scale_enabled, min_courts, max_courts, skill_bands)
+		VALUES (?, 'Morning Mixer', 4, 4, 30, 1, 1, 2, '[{"name":"Open","minRating":3,"courtNumbers":[1]},{"minRating":1,"maxRating":2.5,"courtNumbers":[2]}]')`, sourceID)
+	exec("INSERT INTO open_play_rule_slots (open_play_rule_id, day_of_week, start_time, end_time, created_by_user_id) VALUES (?, 3, '09:00', '11:00', ?)", ruleID, staffID)
+	themeID := exec(`INSERT INTO themes (facility_id, name, is_system, primary_color, secondary_color, tertiary_color, accent_color, highlight_color)
+		VALUES (?, 'Downtown Blue', 0, '#1f2937', '#e5e7eb', '#f9fafb', '#2563eb', '#16a34a')`, sourceID)
+	exec("UPDATE facilities SET active_theme_id = ? WHERE id = ?", themeID, sourceID)
+
+	return configFixture{
+		h:        NewHandlers(database),
+		database: database,
+		sourceID: sourceID,
+		targetID: targetID,
+		staffID:  staffID,
+	}
+}
+
+func (f configFixture) withStaff(req *http.Request, facilityID int64) *http.Request {
+	return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             f.staffID,
+		IsStaff:        true,
+		HomeFacilityID: &facilityID,
+	}))
+}
+
+func (f configFixture) export(t *testing.T) []byte {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/facilities/%d/config/export", f.sourceID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", f.sourceID))
+	recorder := httptest.NewRecorder()
+	f.h.HandleConfigExport(recorder, f.withStaff(req, f.sourceID))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("export status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	return recorder.Body.Bytes()
+}
+
+func (f configFixture) importBundle(body []byte, dryRun bool) *httptest.ResponseRecorder {
+	target := fmt.Sprintf("/api/v1/facilities/%d/config/import", f.targetID)
+	if dryRun {
+		target += "?dry_run=true"
+	}
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", fmt.Sprintf("%d", f.targetID))
+	recorder := httptest.NewRecorder()
+	f.h.HandleConfigImport(recorder, f.withStaff(req, f.targetID))
+	return recorder
+}
+
+func (f configFixture) count(t *testing.T, query string, args ...any) int {
+	t.Helper()
+	var count int
+	if err := f.database.QueryRow(query, args...).Scan(&count); err != nil {
+		t.Fatalf("count %q: %v", query, err)
+	}
+	return count
+}
+
+func decodeImport(t *testing.T, recorder *httptest.ResponseRecorder) importResponse {
+	t.Helper()
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("import status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response importResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode import response: %v", err)
+	}
+	return response
+}
+
+func TestConfigExportImport_CopiesToSecondFacility(t *testing.T) {
+	fixture := setupConfigTest(t)
+	bundle := fixture.export(t)
+
+	dryRun := decodeImport(t, fixture.importBundle(bundle, true))
+	if !dryRun.DryRun || len(dryRun.Changes) == 0 {
+		t.Fatalf("expected a dry run listing changes, got %+v", dryRun)
+	}
+	sections := make(map[string]bool)
+	for _, change := range dryRun.Changes {
+		sections[change.Section] = true
+	}
+	for _, section := range []string{"booking", "hours", "cancellationPolicies", "primeTimeRules", "openPlayRules", "themes", "activeTheme"} {
+		if !sections[section] {
+			t.Fatalf("dry run lists no %s changes: %+v", section, dryRun.Changes)
+		}
+	}
+	if rules := fixture.count(t, "SELECT COUNT(*) FROM open_play_rules WHERE facility_id = ?", fixture.targetID); rules != 0 {
+		t.Fatalf("dry run left %d open play rules behind", rules)
+	}
+	if days := fixture.count(t, "SELECT COUNT(*) FROM operating_hours WHERE facility_id = ? AND day_of_week = 0", fixture.targetID); days != 1 {
+		t.Fatal("dry run removed the target's Sunday hours")
+	}
+
+	applied := decodeImport(t, fixture.importBundle(bundle, false))
+	if applied.DryRun || len(applied.Changes) != len(dryRun.Changes) {
+		t.Fatalf("expected the import to make the %d dry-run changes, got %+v", len(dryRun.Changes), applied)
+	}
+
+	var scope string
+	var slotMinutes, bufferMinutes int64
+	if err := fixture.database.QueryRow(
+		"SELECT reservation_limit_scope, slot_duration_minutes, buffer_minutes FROM facilities WHERE id = ?", fixture.targetID,
+	).Scan(&scope, &slotMinutes, &bufferMinutes); err != nil {
+		t.Fatalf("load target booking config: %v", err)
+	}
+	if scope != "household" || slotMinutes != 30 || bufferMinutes != 15 {
+		t.Fatalf("booking config not copied: scope=%s slot=%d buffer=%d", scope, slotMinutes, bufferMinutes)
+	}
+	if days := fixture.count(t, "SELECT COUNT(*) FROM operating_hours WHERE facility_id = ?", fixture.targetID); days != 2 {
+		t.Fatalf("expected the source's 2 open days, got %d", days)
+	}
+	if tiers := fixture.count(t, `SELECT COUNT(*) FROM cancellation_policy_tiers
+		WHERE facility_id = ? AND reservation_type_id = (SELECT id FROM reservation_types WHERE name = 'GAME')`, fixture.targetID); tiers != 1 {
+		t.Fatalf("expected the GAME override copied, got %d tiers", tiers)
+	}
+
+	var skillBands string
+	var ruleID int64
+	if err := fixture.database.QueryRow(
+		"SELECT id, skill_bands FROM open_play_rules WHERE facility_id = ? AND name = 'Morning Mixer'", fixture.targetID,
+	).Scan(&ruleID, &skillBands); err != nil {
+		t.Fatalf("load copied open play rule: %v", err)
+	}
+	if !strings.Contains(skillBands, `"courtNumbers":[7]`) || !strings.Contains(skillBands, `"courtNumbers":[5]`) {
+		t.Fatalf("skill bands not remapped to the target's court numbers: %s", skillBands)
+	}
+	if slots := fixture.count(t, "SELECT COUNT(*) FROM open_play_rule_slots WHERE open_play_rule_id = ?", ruleID); slots != 1 {
+		t.Fatalf("expected the rule's slot copied, got %d", slots)
+	}
+	if active := fixture.count(t, `SELECT COUNT(*) FROM facilities f JOIN themes th ON th.id = f.active_theme_id
+		WHERE f.id = ? AND th.facility_id = ? AND th.name = 'Downtown Blue'`, fixture.targetID, fixture.targetID); active != 1 {
+		t.Fatal("expected the target to activate its own copy of the theme")
+	}
+
+	again := decodeImport(t, fixture.importBundle(bundle, false))
+	if len(again.Changes) != 0 {
+		t.Fatalf("re-importing the same bundle should change nothing, got %+v", again.Changes)
+	}
+}
+
+func TestConfigImport_RejectsBadBundles(t *testing.T) {
+	fixture := setupConfigTest(t)
+
+	var bundle map[string]any
+	if err := json.Unmarshal(fixture.export(t), &bundle); err != nil {
+		t.Fatalf("decode export: %v", err)
+	}
+	encode := func(mutate func(map[string]any)) []byte {
+		copied := make(map[string]any, len(bundle))
+		for key, value := range bundle {
+			copied[key] = value
+		}
+		mutate(copied)
+		body, err := json.Marshal(copied)
+		if err != nil {
+			t.Fatalf("encode bundle: %v", err)
+		}
+		return body
+	}
+
+	tests := []struct {
+		name   string
+		body   []byte
+		status int
+		want   string
+	}{
+		{
+			name:   "unknown field",
+			body:   encode(func(b map[string]any) { b["courtPricing"] = []any{} }),
+			status: http.StatusBadRequest,
+			want:   `unknown field \"courtPricing\"`,
+		},
+		{
+			name:   "newer version",
+			body:   encode(func(b map[string]any) { b["version"] = bundleVersion + 1 }),
+			status: http.StatusBadRequest,
+			want:   "newer than this server supports",
+		},
+		{
+			name:   "missing version",
+			body:   encode(func(b map[string]any) { delete(b, "version") }),
+			status: http.StatusBadRequest,
+			want:   "version is required",
+		},
+		{
+			name: "invalid hours",
+			body: encode(func(b map[string]any) {
+				b["hours"] = []any{map[string]any{"dayOfWeek": 1, "opensAt": "22:00", "closesAt": "06:00"}}
+			}),
+			status: http.StatusBadRequest,
+			want:   "hours[0].closesAt must be after opensAt",
+		},
+		{
+			name: "unknown court",
+			body: encode(func(b map[string]any) {
+				b["openPlayRules"] = []any{map[string]any{
+					"name": "Evening Ladder", "minParticipants": 4, "maxParticipantsPerCourt": 4, "minCourts": 1, "maxCourts": 1,
+					"skillBands": []any{map[string]any{"minRating": 3, "courts": []any{"Stadium"}}},
+					"slots":      []any{},
+				}}
+			}),
+			status: http.StatusBadRequest,
+			want:   `court \"Stadium\", which does not exist`,
+		},
+		{
+			name:   "unavailable reservation type",
+			body:   encode(func(b map[string]any) { b["reservationTypes"] = []any{"GAME", "SUMMER_CAMP"} }),
+			status: http.StatusBadRequest,
+			want:   "SUMMER_CAMP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := fixture.importBundle(tt.body, false)
+			if recorder.Code != tt.status || !strings.Contains(recorder.Body.String(), tt.want) {
+				t.Fatalf("expected %d containing %q, got %d: %s", tt.status, tt.want, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+
+	// The unknown court fails after the booking config and hours were
+	// written; the transaction must undo them.
+	if days := fixture.count(t, "SELECT COUNT(*) FROM operating_hours WHERE facility_id = ?", fixture.targetID); days != 1 {
+		t.Fatalf("failed import left %d days of hours, want the original 1", days)
+	}
+	if scope := fixture.count(t, "SELECT COUNT(*) FROM facilities WHERE id = ? AND reservation_limit_scope = 'household'", fixture.targetID); scope != 0 {
+		t.Fatal("failed import changed the booking config")
+	}
+}
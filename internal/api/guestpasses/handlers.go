@@ -0,0 +1,206 @@
+// internal/api/guestpasses/handlers.go
+package guestpasses
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/reservations"
+)
+
+const (
+	guestPassQueryTimeout = 5 * time.Second
+	userIDParam           = "id"
+)
+
+var (
+	queries     *dbgen.Queries
+	queriesOnce sync.Once
+)
+
+type guestPassGrantRequest struct {
+	FacilityID *int64 `json:"facilityId"`
+	UserID     int64  `json:"userId"`
+	Passes     int64  `json:"passes"`
+}
+
+type guestPassBalanceResponse struct {
+	UserID     int64 `json:"userId"`
+	FacilityID int64 `json:"facilityId"`
+	Balance    int64 `json:"balance"`
+}
+
+// InitHandlers must be called during server startup before handling requests.
+func InitHandlers(q *dbgen.Queries) {
+	if q == nil {
+		return
+	}
+	queriesOnce.Do(func() {
+		queries = q
+	})
+}
+
+func loadQueries() *dbgen.Queries {
+	return queries
+}
+
+// HandleGuestPassGrant handles POST /api/v1/guest-passes. Staff add passes
+// to a member's balance at a facility.
+func HandleGuestPassGrant(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if !authz.IsStaff(user) {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	req, err := decodeGuestPassGrantRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if req.UserID <= 0 {
+		apiutil.WriteValidationError(w, r, apiutil.FieldError{Field: "user_id", Reason: "must be a positive integer"})
+		return
+	}
+	if req.Passes <= 0 {
+		apiutil.WriteValidationError(w, r, apiutil.FieldError{Field: "passes", Reason: "must be a positive integer"})
+		return
+	}
+
+	facilityID, err := apiutil.FacilityIDFromRequest(r, req.FacilityID)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), guestPassQueryTimeout)
+	defer cancel()
+
+	granted, err := q.GrantMemberGuestPasses(ctx, dbgen.GrantMemberGuestPassesParams{
+		UserID:     req.UserID,
+		FacilityID: facilityID,
+		Passes:     req.Passes,
+	})
+	if err != nil {
+		if apiutil.IsSQLiteForeignKeyViolation(err) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "User not found")
+			return
+		}
+		logger.Error().Err(err).Int64("user_id", req.UserID).Int64("facility_id", facilityID).Msg("Failed to grant guest passes")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to grant guest passes")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, guestPassBalanceResponse{
+		UserID:     granted.UserID,
+		FacilityID: granted.FacilityID,
+		Balance:    granted.Balance,
+	}); err != nil {
+		logger.Error().Err(err).Int64("user_id", req.UserID).Msg("Failed to write guest pass response")
+	}
+}
+
+// HandleUserGuestPasses handles GET /api/v1/users/{id}/guest-passes, a
+// member's guest pass balance at the facility in facility_id.
+func HandleUserGuestPasses(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if !authz.IsStaff(user) {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue(userIDParam)), 10, 64)
+	if err != nil || userID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+	facilityID, err := apiutil.FacilityIDFromQuery(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), guestPassQueryTimeout)
+	defer cancel()
+
+	balance, err := reservations.GuestPassBalance(ctx, q, userID, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", userID).Int64("facility_id", facilityID).Msg("Failed to load guest passes")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load guest passes")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, guestPassBalanceResponse{
+		UserID:     userID,
+		FacilityID: facilityID,
+		Balance:    balance,
+	}); err != nil {
+		logger.Error().Err(err).Int64("user_id", userID).Msg("Failed to write guest pass response")
+	}
+}
+
+func decodeGuestPassGrantRequest(r *http.Request) (guestPassGrantRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req guestPassGrantRequest
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return guestPassGrantRequest{}, err
+		}
+		return req, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return guestPassGrantRequest{}, err
+	}
+
+	facilityID, err := apiutil.ParseOptionalInt64Field(apiutil.FirstNonEmpty(r.FormValue("facility_id"), r.FormValue("facilityId")), "facility_id")
+	if err != nil {
+		return guestPassGrantRequest{}, err
+	}
+	userID, err := apiutil.ParseRequiredInt64Field(apiutil.FirstNonEmpty(r.FormValue("user_id"), r.FormValue("userId")), "user_id")
+	if err != nil {
+		return guestPassGrantRequest{}, err
+	}
+	passes, err := apiutil.ParsePositiveInt64Field(r.FormValue("passes"), "passes")
+	if err != nil {
+		return guestPassGrantRequest{}, err
+	}
+
+	return guestPassGrantRequest{
+		FacilityID: facilityID,
+		UserID:     userID,
+		Passes:     passes,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package guestpasses
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestGuestPassGrantAndBalance(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	testutil.ResetHandlers(t, &queriesOnce, &queries)
+	InitHandlers(database.Queries)
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('PicklePlex', 'pickleplex', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'North', 'north', 'UTC')", orgID)
+	otherFacilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'South', 'south', 'UTC')", orgID)
+	staffID := exec("INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES ('Sam', 'Staff', 'staff@test.com', 'active', 1)")
+	exec("INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, 'Sam', 'Staff', ?, 'desk')", staffID, facilityID)
+	memberID := exec("INSERT INTO users (first_name, last_name, email, status, is_member) VALUES ('Mia', 'Member', 'mia@test.com', 'active', 1)")
+	staff := &authz.AuthUser{ID: staffID, IsStaff: true, HomeFacilityID: &facilityID}
+
+	grant := func(body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/guest-passes", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(authz.ContextWithUser(req.Context(), staff))
+		recorder := httptest.NewRecorder()
+		HandleGuestPassGrant(recorder, req)
+		return recorder
+	}
+	balance := func(userID, facilityID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/users/%d/guest-passes?facility_id=%d", userID, facilityID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", userID))
+		req = req.WithContext(authz.ContextWithUser(req.Context(), staff))
+		recorder := httptest.NewRecorder()
+		HandleUserGuestPasses(recorder, req)
+		return recorder
+	}
+	expect := func(recorder *httptest.ResponseRecorder, status int, fragment string) {
+		t.Helper()
+		if recorder.Code != status || !strings.Contains(recorder.Body.String(), fragment) {
+			t.Fatalf("got %d %q, want %d containing %q", recorder.Code, recorder.Body.String(), status, fragment)
+		}
+	}
+
+	expect(balance(memberID, facilityID), http.StatusOK, `"balance":0`)
+	expect(grant(fmt.Sprintf(`{"userId":%d,"facilityId":%d,"passes":0}`, memberID, facilityID)), http.StatusBadRequest, `"field":"passes"`)
+	expect(grant(fmt.Sprintf(`{"userId":%d,"facilityId":%d,"passes":2}`, memberID, otherFacilityID)), http.StatusForbidden, "")
+	expect(grant(fmt.Sprintf(`{"userId":999999,"facilityId":%d,"passes":2}`, facilityID)), http.StatusNotFound, "User not found")
+	expect(grant(fmt.Sprintf(`{"userId":%d,"facilityId":%d,"passes":2}`, memberID, facilityID)), http.StatusCreated, `"balance":2`)
+	expect(grant(fmt.Sprintf(`{"userId":%d,"facilityId":%d,"passes":3}`, memberID, facilityID)), http.StatusCreated, `"balance":5`)
+	expect(balance(memberID, facilityID), http.StatusOK, `"balance":5`)
+}
@@ -0,0 +1,180 @@
+// internal/api/health/handlers.go
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/db/migrate"
+	"github.com/codr1/Pickleicious/internal/jobs"
+)
+
+// readinessPingTimeout keeps /readyz fast enough for load balancer probes,
+// which give up after a few seconds.
+const readinessPingTimeout = 2 * time.Second
+
+const (
+	statusOK      = "ok"
+	statusFailed  = "failed"
+	statusFailing = "failing"
+)
+
+// BuildInfo identifies the running binary. The server injects it with
+// -ldflags at build time.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"buildTime,omitempty"`
+}
+
+type checkResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type migrationCheckResult struct {
+	checkResult
+	Version         int64 `json:"version"`
+	ExpectedVersion int64 `json:"expectedVersion"`
+	Dirty           bool  `json:"dirty,omitempty"`
+}
+
+// jobsCheckResult lists background jobs that have failed several runs in a
+// row. It is informational: restarting the instance would not fix a job.
+type jobsCheckResult struct {
+	checkResult
+	Failing []jobs.Status `json:"failing"`
+}
+
+type readinessChecks struct {
+	Database   checkResult          `json:"database"`
+	Migrations migrationCheckResult `json:"migrations"`
+	Jobs       *jobsCheckResult     `json:"jobs,omitempty"`
+}
+
+type readinessResponse struct {
+	Status string          `json:"status"`
+	Checks readinessChecks `json:"checks"`
+	Build  BuildInfo       `json:"build"`
+}
+
+var (
+	store     *appdb.DB
+	registry  *jobs.Registry
+	buildInfo BuildInfo
+	initOnce  sync.Once
+)
+
+// InitHandlers must be called during server startup before handling
+// requests. A nil registry leaves job health out of /readyz.
+func InitHandlers(database *appdb.DB, jobRegistry *jobs.Registry, info BuildInfo) {
+	if database == nil {
+		return
+	}
+	initOnce.Do(func() {
+		store = database
+		registry = jobRegistry
+		buildInfo = info
+	})
+}
+
+// GET /healthz
+// Liveness only: answers 200 whenever the process can serve a request.
+func HandleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
+
+// GET /readyz
+// Ready when the database answers a ping and is migrated to the version this
+// binary embeds; otherwise 503 with the failing checks. Jobs failing
+// repeatedly are listed under checks.jobs without affecting the status.
+func HandleReadyz(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	response := readinessResponse{
+		Status: "ready",
+		Build:  buildInfo,
+	}
+	if store == nil {
+		response.Checks.Database = checkResult{Status: statusFailed, Error: "database not initialized"}
+		response.Checks.Migrations.checkResult = checkResult{Status: statusFailed, Error: "database not initialized"}
+	} else {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessPingTimeout)
+		defer cancel()
+		response.Checks.Database = checkDatabase(ctx, store)
+		response.Checks.Migrations = checkMigrations(ctx, store)
+		if registry != nil {
+			response.Checks.Jobs = checkJobs(ctx, registry)
+		}
+	}
+
+	status := http.StatusOK
+	if response.Checks.Database.Status != statusOK || response.Checks.Migrations.Status != statusOK {
+		response.Status = "unavailable"
+		status = http.StatusServiceUnavailable
+		logger.Warn().
+			Interface("checks", response.Checks).
+			Msg("Readiness check failed")
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	if err := apiutil.WriteJSON(w, status, response); err != nil {
+		logger.Error().Err(err).Msg("Failed to write readiness response")
+	}
+}
+
+func checkDatabase(ctx context.Context, database *appdb.DB) checkResult {
+	if err := database.PingContext(ctx); err != nil {
+		return checkResult{Status: statusFailed, Error: err.Error()}
+	}
+	return checkResult{Status: statusOK}
+}
+
+// checkJobs reports jobs failing repeatedly, or nil when there are none.
+func checkJobs(ctx context.Context, jobRegistry *jobs.Registry) *jobsCheckResult {
+	failing, err := jobRegistry.Failing(ctx)
+	if err != nil {
+		return &jobsCheckResult{checkResult: checkResult{Status: statusFailed, Error: err.Error()}}
+	}
+	if len(failing) == 0 {
+		return nil
+	}
+	return &jobsCheckResult{checkResult: checkResult{Status: statusFailing}, Failing: failing}
+}
+
+func checkMigrations(ctx context.Context, database *appdb.DB) migrationCheckResult {
+	var result migrationCheckResult
+	expected, err := migrate.Latest()
+	if err != nil {
+		result.checkResult = checkResult{Status: statusFailed, Error: err.Error()}
+		return result
+	}
+	result.ExpectedVersion = expected
+
+	version, dirty, err := database.MigrationVersion(ctx)
+	if err != nil {
+		result.checkResult = checkResult{Status: statusFailed, Error: err.Error()}
+		return result
+	}
+	result.Version = version
+	result.Dirty = dirty
+
+	switch {
+	case dirty:
+		result.checkResult = checkResult{Status: statusFailed, Error: fmt.Sprintf("migration %d is dirty", version)}
+	case version != expected:
+		result.checkResult = checkResult{Status: statusFailed, Error: fmt.Sprintf("database is at migration %d, expected %d", version, expected)}
+	default:
+		result.checkResult = checkResult{Status: statusOK}
+	}
+	return result
+}
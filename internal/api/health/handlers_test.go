@@ -0,0 +1,144 @@
+package health
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/db/migrate"
+	"github.com/codr1/Pickleicious/internal/jobs"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func setupHealthTest(t *testing.T) *appdb.DB {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+	store = nil
+	initOnce = sync.Once{}
+	InitHandlers(database, jobs.NewRegistry(database.Queries), BuildInfo{Version: "v1.2.3", Commit: "abc1234"})
+	t.Cleanup(func() {
+		store = nil
+		registry = nil
+		buildInfo = BuildInfo{}
+		initOnce = sync.Once{}
+	})
+	return database
+}
+
+func readyz(t *testing.T) (*httptest.ResponseRecorder, readinessResponse) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	HandleReadyz(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var body readinessResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode readiness body %q: %v", recorder.Body.String(), err)
+	}
+	return recorder, body
+}
+
+func TestHandleHealthz(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	HandleHealthz(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", recorder.Code)
+	}
+}
+
+func TestHandleReadyz_ReadyWhenMigrated(t *testing.T) {
+	setupHealthTest(t)
+
+	recorder, body := readyz(t)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	expected, err := migrate.Latest()
+	if err != nil {
+		t.Fatalf("latest migration version: %v", err)
+	}
+	if body.Status != "ready" || body.Checks.Database.Status != statusOK {
+		t.Fatalf("unexpected readiness body: %+v", body)
+	}
+	if body.Checks.Migrations.Version != expected || body.Checks.Migrations.ExpectedVersion != expected {
+		t.Fatalf("expected migration %d, got %+v", expected, body.Checks.Migrations)
+	}
+	if body.Build.Version != "v1.2.3" || body.Build.Commit != "abc1234" {
+		t.Fatalf("expected build info in payload, got %+v", body.Build)
+	}
+}
+
+func TestHandleReadyz_UnavailableOnMigrationMismatch(t *testing.T) {
+	database := setupHealthTest(t)
+
+	if _, err := database.Exec("UPDATE schema_migrations SET version = version - 1"); err != nil {
+		t.Fatalf("roll back migration version: %v", err)
+	}
+
+	recorder, body := readyz(t)
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", recorder.Code)
+	}
+	if body.Checks.Database.Status != statusOK {
+		t.Fatalf("expected database check to pass, got %+v", body.Checks.Database)
+	}
+	if body.Checks.Migrations.Status != statusFailed || body.Checks.Migrations.Error == "" {
+		t.Fatalf("expected failed migration check, got %+v", body.Checks.Migrations)
+	}
+}
+
+func TestHandleReadyz_UnavailableWhenDatabaseClosed(t *testing.T) {
+	database := setupHealthTest(t)
+	if err := database.Close(); err != nil {
+		t.Fatalf("close database: %v", err)
+	}
+
+	recorder, body := readyz(t)
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", recorder.Code)
+	}
+	if body.Status != "unavailable" || body.Checks.Database.Status != statusFailed {
+		t.Fatalf("expected failed database check, got %+v", body)
+	}
+}
+
+func TestHandleReadyz_ListsRepeatedlyFailingJobs(t *testing.T) {
+	setupHealthTest(t)
+	ctx := context.Background()
+
+	if err := registry.Register("broken_job", "*/5 * * * *", 0, func(context.Context) (int64, error) {
+		return 0, errors.New("smtp unreachable")
+	}); err != nil {
+		t.Fatalf("register job: %v", err)
+	}
+	for range jobs.FailingThreshold - 1 {
+		if err := registry.Run(ctx, "broken_job", jobs.TriggerSchedule); err == nil {
+			t.Fatal("expected the job to fail")
+		}
+	}
+	if _, body := readyz(t); body.Checks.Jobs != nil {
+		t.Fatalf("expected no job check below the threshold, got %+v", body.Checks.Jobs)
+	}
+
+	if err := registry.Run(ctx, "broken_job", jobs.TriggerSchedule); err == nil {
+		t.Fatal("expected the job to fail")
+	}
+	recorder, body := readyz(t)
+	if recorder.Code != http.StatusOK || body.Status != "ready" {
+		t.Fatalf("expected failing jobs not to affect readiness, got %d %+v", recorder.Code, body)
+	}
+	jobsCheck := body.Checks.Jobs
+	if jobsCheck == nil || jobsCheck.Status != statusFailing || len(jobsCheck.Failing) != 1 {
+		t.Fatalf("expected one failing job, got %+v", jobsCheck)
+	}
+	if failing := jobsCheck.Failing[0]; failing.Name != "broken_job" || failing.LastError != "smtp unreachable" || failing.ConsecutiveFailures != jobs.FailingThreshold {
+		t.Fatalf("unexpected failing job %+v", failing)
+	}
+}
@@ -0,0 +1,269 @@
+package leagues
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	leaguestandings "github.com/codr1/Pickleicious/internal/leagues"
+)
+
+type divisionAssignment struct {
+	TeamID   int64  `json:"teamId"`
+	Division string `json:"division"`
+}
+
+// divisionOverrideRequest moves teams between divisions by hand. An empty
+// division leaves the team unassigned.
+type divisionOverrideRequest struct {
+	Assignments []divisionAssignment `json:"assignments"`
+}
+
+type divisionTeam struct {
+	TeamID   int64    `json:"teamId"`
+	TeamName string   `json:"teamName"`
+	Average  *float64 `json:"average"`
+	Unrated  int      `json:"unrated"`
+	// Reason says why automatic assignment left the team unassigned.
+	Reason string `json:"reason,omitempty"`
+}
+
+type divisionTeams struct {
+	leaguestandings.Division
+	Teams []divisionTeam `json:"teams"`
+}
+
+type divisionsResponse struct {
+	Divisions  []divisionTeams `json:"divisions"`
+	Unassigned []divisionTeam  `json:"unassigned"`
+}
+
+// POST /api/v1/leagues/{id}/divisions/assign
+//
+// Places every active team in a division by its roster's average rating,
+// replacing earlier assignments. Teams that fit no division, or only full
+// ones, are left unassigned with the reason.
+func (h *Handlers) HandleAssignDivisions(w http.ResponseWriter, r *http.Request) {
+	h.handleDivisionAssignment(w, r, nil)
+}
+
+// PUT /api/v1/leagues/{id}/divisions/assign
+//
+// Overrides the division of the listed teams, bypassing rating ranges and
+// team caps. Other teams keep their division.
+func (h *Handlers) HandleOverrideDivisions(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeDivisionOverrideRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if len(req.Assignments) == 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "At least one assignment is required")
+		return
+	}
+	h.handleDivisionAssignment(w, r, req.Assignments)
+}
+
+// handleDivisionAssignment assigns divisions automatically when overrides is
+// nil and applies the overrides otherwise.
+func (h *Handlers) handleDivisionAssignment(w http.ResponseWriter, r *http.Request, overrides []divisionAssignment) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	leagueID, err := leagueIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), leagueQueryTimeout)
+	defer cancel()
+
+	leagueRow, err := q.GetLeagueWithFacilityTimezone(ctx, leagueID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
+			return
+		}
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
+		return
+	}
+
+	league := leagueFromRosterLockRow(leagueRow)
+	if !apiutil.RequireFacilityAccess(w, r, league.FacilityID) {
+		return
+	}
+
+	// Divisions decide who plays whom, so they lock with the rosters.
+	rosterLoc := apiutil.TimezoneLocation(leagueRow.FacilityTimezone, logger)
+	if rosterLocked(league, rosterLoc) {
+		apiutil.WriteError(w, r, http.StatusConflict, "Roster is locked for this league")
+		return
+	}
+
+	config := leaguestandings.LeagueDivisions(league.DivisionConfig)
+
+	teams, err := q.ListLeagueTeams(ctx, leagueID)
+	if err != nil {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to load league teams")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load league teams")
+		return
+	}
+	teams = filterActiveTeams(teams)
+
+	ratings := make(map[int64]leaguestandings.RosterRating, len(teams))
+	for _, team := range teams {
+		rows, err := q.ListTeamMemberRatings(ctx, team.ID)
+		if err != nil {
+			logger.Error().Err(err).Int64("team_id", team.ID).Msg("Failed to load team member ratings")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load team ratings")
+			return
+		}
+		ratings[team.ID] = leaguestandings.SummarizeRosterRating(rows)
+	}
+
+	updates := make(map[int64]sql.NullString)
+	reasons := make(map[int64]string)
+	if overrides == nil {
+		teamRatings := make([]leaguestandings.TeamRating, 0, len(teams))
+		for _, team := range teams {
+			teamRatings = append(teamRatings, leaguestandings.TeamRating{TeamID: team.ID, TeamName: team.Name, Rating: ratings[team.ID]})
+		}
+		for _, placement := range leaguestandings.AssignDivisions(config, teamRatings) {
+			updates[placement.TeamID] = sql.NullString{String: placement.Division, Valid: placement.Division != ""}
+			if placement.Reason != "" {
+				reasons[placement.TeamID] = placement.Reason
+			}
+		}
+	} else {
+		active := make(map[int64]struct{}, len(teams))
+		for _, team := range teams {
+			active[team.ID] = struct{}{}
+		}
+		for _, override := range overrides {
+			if _, ok := active[override.TeamID]; !ok {
+				apiutil.WriteError(w, r, http.StatusNotFound, fmt.Sprintf("Team %d is not an active team in this league", override.TeamID))
+				return
+			}
+			name := strings.TrimSpace(override.Division)
+			if name == "" {
+				updates[override.TeamID] = sql.NullString{}
+				continue
+			}
+			division, ok := config.Lookup(name)
+			if !ok {
+				apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("Division %q is not in this league's division config", name))
+				return
+			}
+			updates[override.TeamID] = sql.NullString{String: division.Name, Valid: true}
+		}
+	}
+
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		for i, team := range teams {
+			division, ok := updates[team.ID]
+			if !ok {
+				continue
+			}
+			updated, err := txdb.Queries.UpdateLeagueTeamDivision(ctx, dbgen.UpdateLeagueTeamDivisionParams{
+				Division: division,
+				ID:       team.ID,
+				LeagueID: leagueID,
+			})
+			if err != nil {
+				return err
+			}
+			teams[i] = updated
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to assign league divisions")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to assign divisions")
+		return
+	}
+
+	logger.Info().
+		Int64("league_id", leagueID).
+		Int64("staff_user_id", user.ID).
+		Bool("override", overrides != nil).
+		Int("teams", len(updates)).
+		Msg("League divisions assigned")
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, buildDivisionsResponse(config, teams, ratings, reasons)); err != nil {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to write divisions response")
+	}
+}
+
+func buildDivisionsResponse(config leaguestandings.DivisionConfig, teams []dbgen.LeagueTeam, ratings map[int64]leaguestandings.RosterRating, reasons map[int64]string) divisionsResponse {
+	response := divisionsResponse{
+		Divisions:  make([]divisionTeams, 0, len(config.Divisions)),
+		Unassigned: []divisionTeam{},
+	}
+	index := make(map[string]int, len(config.Divisions))
+	for i, division := range config.Divisions {
+		index[division.Name] = i
+		response.Divisions = append(response.Divisions, divisionTeams{Division: division, Teams: []divisionTeam{}})
+	}
+
+	for _, team := range teams {
+		rating := ratings[team.ID]
+		entry := divisionTeam{
+			TeamID:   team.ID,
+			TeamName: team.Name,
+			Average:  rating.Average,
+			Unrated:  rating.Unrated,
+		}
+		name, ok := config.TeamDivision(team.Division)
+		if reason, unplaced := reasons[team.ID]; unplaced || !ok {
+			entry.Reason = reason
+			response.Unassigned = append(response.Unassigned, entry)
+			continue
+		}
+		response.Divisions[index[name]].Teams = append(response.Divisions[index[name]].Teams, entry)
+	}
+	return response
+}
+
+func decodeDivisionOverrideRequest(r *http.Request) (divisionOverrideRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req divisionOverrideRequest
+		return req, apiutil.DecodeJSON(r, &req)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return divisionOverrideRequest{}, err
+	}
+
+	teamID, err := apiutil.ParseRequiredInt64Field(apiutil.FirstNonEmpty(r.FormValue("team_id"), r.FormValue("teamId")), "team_id")
+	if err != nil {
+		return divisionOverrideRequest{}, err
+	}
+
+	return divisionOverrideRequest{
+		Assignments: []divisionAssignment{{TeamID: teamID, Division: r.FormValue("division")}},
+	}, nil
+}
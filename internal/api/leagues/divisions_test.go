@@ -0,0 +1,180 @@
+package leagues
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	leaguestandings "github.com/codr1/Pickleicious/internal/leagues"
+)
+
+func (f scheduleFixture) withStaff(req *http.Request) *http.Request {
+	req.SetPathValue("id", fmt.Sprintf("%d", f.leagueID))
+	facilityID := f.facilityID
+	return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             f.staffID,
+		IsStaff:        true,
+		HomeFacilityID: &facilityID,
+	}))
+}
+
+// rateTeam rosters one player with the given rating on the team.
+func (f scheduleFixture) rateTeam(t *testing.T, team string, rating float64) {
+	t.Helper()
+	result, err := f.database.Exec(
+		"INSERT INTO users (first_name, last_name, email, status) VALUES (?, 'Player', ?, 'active')",
+		team, strings.ToLower(team)+"@test.com",
+	)
+	if err != nil {
+		t.Fatalf("insert player for %s: %v", team, err)
+	}
+	userID, _ := result.LastInsertId()
+	if _, err := f.database.Exec("INSERT INTO member_skill_ratings (user_id, rating) VALUES (?, ?)", userID, rating); err != nil {
+		t.Fatalf("rate player for %s: %v", team, err)
+	}
+	if _, err := f.database.Exec("INSERT INTO league_team_members (league_team_id, user_id) VALUES (?, ?)", f.teamIDs[team], userID); err != nil {
+		t.Fatalf("roster player for %s: %v", team, err)
+	}
+}
+
+func (f scheduleFixture) assignDivisions(t *testing.T, method, body string) divisionsResponse {
+	t.Helper()
+	req := httptest.NewRequest(method, fmt.Sprintf("/api/v1/leagues/%d/divisions/assign", f.leagueID), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	if method == http.MethodPut {
+		f.h.HandleOverrideDivisions(recorder, f.withStaff(req))
+	} else {
+		f.h.HandleAssignDivisions(recorder, f.withStaff(req))
+	}
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("assign divisions status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response divisionsResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode divisions: %v", err)
+	}
+	return response
+}
+
+func divisionTeamNames(response divisionsResponse) map[string]string {
+	names := make(map[string]string)
+	for _, division := range response.Divisions {
+		for _, team := range division.Teams {
+			names[team.TeamName] = division.Name
+		}
+	}
+	return names
+}
+
+func TestDivisions_AssignOverrideAndScheduleWithinDivisions(t *testing.T) {
+	fixture := setupScheduleTest(t, "Aces", "Bandits", "Comets", "Dinks")
+	if _, err := fixture.database.Exec(
+		"UPDATE leagues SET division_config = ? WHERE id = ?",
+		`{"divisions":[{"name":"Upper","minRating":3.5},{"name":"Lower","maxRating":3.5}]}`, fixture.leagueID,
+	); err != nil {
+		t.Fatalf("configure divisions: %v", err)
+	}
+	fixture.rateTeam(t, "Aces", 4.5)
+	fixture.rateTeam(t, "Bandits", 4.0)
+	fixture.rateTeam(t, "Comets", 3.0)
+	fixture.rateTeam(t, "Dinks", 2.5)
+
+	assigned := divisionTeamNames(fixture.assignDivisions(t, http.MethodPost, ""))
+	for team, division := range map[string]string{"Aces": "Upper", "Bandits": "Upper", "Comets": "Lower", "Dinks": "Lower"} {
+		if assigned[team] != division {
+			t.Fatalf("%s assigned to %q, want %q", team, assigned[team], division)
+		}
+	}
+
+	overridden := divisionTeamNames(fixture.assignDivisions(t, http.MethodPut, fmt.Sprintf(
+		`{"assignments":[{"teamId":%d,"division":"lower"},{"teamId":%d,"division":"Upper"}]}`,
+		fixture.teamIDs["Bandits"], fixture.teamIDs["Comets"],
+	)))
+	if overridden["Bandits"] != "Lower" || overridden["Comets"] != "Upper" || overridden["Aces"] != "Upper" {
+		t.Fatalf("unexpected divisions after override: %v", overridden)
+	}
+
+	response := decodeScheduleResponse(t, fixture.schedule(t, `{}`))
+	if len(response.Matches) != 2 {
+		t.Fatalf("expected one match per division, got %d", len(response.Matches))
+	}
+	upper := map[int64]bool{fixture.teamIDs["Aces"]: true, fixture.teamIDs["Comets"]: true}
+	for _, match := range response.Matches {
+		if upper[match.HomeTeamID] != upper[match.AwayTeamID] {
+			t.Fatalf("match %d pairs teams from different divisions", match.ID)
+		}
+	}
+}
+
+func TestDivisions_ScheduleRequiresEveryTeamAssigned(t *testing.T) {
+	fixture := setupScheduleTest(t, "Aces", "Bandits", "Comets")
+	if _, err := fixture.database.Exec(
+		"UPDATE leagues SET division_config = ? WHERE id = ?",
+		`{"divisions":["Upper","Lower"]}`, fixture.leagueID,
+	); err != nil {
+		t.Fatalf("configure divisions: %v", err)
+	}
+
+	if recorder := fixture.schedule(t, `{}`); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected 409 with unassigned teams, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleLeagueStandings_PerDivision(t *testing.T) {
+	fixture := setupScheduleTest(t, "Aces", "Bandits", "Comets", "Dinks")
+	if _, err := fixture.database.Exec(
+		"UPDATE leagues SET division_config = ? WHERE id = ?",
+		`{"divisions":["Upper","Lower"]}`, fixture.leagueID,
+	); err != nil {
+		t.Fatalf("configure divisions: %v", err)
+	}
+	fixture.assignDivisions(t, http.MethodPut, fmt.Sprintf(
+		`{"assignments":[{"teamId":%d,"division":"Upper"},{"teamId":%d,"division":"Upper"},{"teamId":%d,"division":"Lower"},{"teamId":%d,"division":"Lower"}]}`,
+		fixture.teamIDs["Aces"], fixture.teamIDs["Bandits"], fixture.teamIDs["Comets"], fixture.teamIDs["Dinks"],
+	))
+	decodeScheduleResponse(t, fixture.schedule(t, `{}`))
+	fixture.completeMatches(t, fixture.teamIDs["Bandits"], fixture.teamIDs["Dinks"])
+
+	standings := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leagues/%d/standings%s", fixture.leagueID, query), nil)
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleLeagueStandings(recorder, fixture.withStaff(req))
+		return recorder
+	}
+
+	recorder := standings("?division=upper")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("standings status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response struct {
+		Division  string                              `json:"division"`
+		Standings []leaguestandings.TeamStanding      `json:"standings"`
+		Divisions []leaguestandings.DivisionStandings `json:"divisions"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode standings: %v", err)
+	}
+	if response.Division != "Upper" || len(response.Standings) != 2 || response.Standings[0].TeamName != "Bandits" {
+		t.Fatalf("unexpected Upper standings: %+v", response)
+	}
+
+	recorder = standings("")
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode standings: %v", err)
+	}
+	if len(response.Divisions) != 2 || response.Divisions[1].Division != "Lower" || response.Divisions[1].Standings[0].TeamName != "Dinks" {
+		t.Fatalf("unexpected division tables: %+v", response.Divisions)
+	}
+	if len(response.Standings) != 4 {
+		t.Fatalf("expected the league-wide table to keep all four teams, got %d", len(response.Standings))
+	}
+
+	if recorder := standings("?division=Middle"); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown division, got %d", recorder.Code)
+	}
+}
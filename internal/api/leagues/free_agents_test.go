@@ -0,0 +1,91 @@
+package leagues
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func TestFreeAgents_SelfRegisteredListedAndAssigned(t *testing.T) {
+	fixture := setupScheduleTest(t, "Aces")
+
+	memberResult, err := fixture.database.Exec(
+		"INSERT INTO users (first_name, last_name, email, status, is_member, home_facility_id) VALUES (?, ?, ?, 'active', 1, ?)",
+		"Sam", "Solo", "sam@test.com", fixture.facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+	if _, err := fixture.database.Exec(
+		"INSERT INTO league_free_agents (league_id, user_id, skill_level, availability_notes) VALUES (?, ?, '4.0', 'Weekends')",
+		fixture.leagueID, memberID,
+	); err != nil {
+		t.Fatalf("insert registration: %v", err)
+	}
+
+	withStaff := func(req *http.Request) *http.Request {
+		facilityID := fixture.facilityID
+		req.SetPathValue("id", fmt.Sprintf("%d", fixture.leagueID))
+		return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:             fixture.staffID,
+			IsStaff:        true,
+			HomeFacilityID: &facilityID,
+		}))
+	}
+	listFreeAgents := func() []freeAgentEntry {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleListFreeAgents(recorder, withStaff(httptest.NewRequest(http.MethodGet, "/api/v1/leagues/1/free-agents", nil)))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("list status %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var response struct {
+			FreeAgents []freeAgentEntry `json:"freeAgents"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("decode free agents: %v", err)
+		}
+		return response.FreeAgents
+	}
+
+	agents := listFreeAgents()
+	if len(agents) != 1 || !agents[0].SelfRegistered || agents[0].UserID != memberID || agents[0].SkillLevel != "4.0" || agents[0].LeagueTeamID != nil {
+		t.Fatalf("unexpected free agents: %+v", agents)
+	}
+
+	req := withStaff(httptest.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("/api/v1/leagues/%d/free-agents/%d/assign", fixture.leagueID, memberID),
+		strings.NewReader(fmt.Sprintf(`{"teamId":%d}`, fixture.teamIDs["Aces"])),
+	))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("user_id", fmt.Sprintf("%d", memberID))
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleAssignFreeAgent(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("assign status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var onTeam, registrations int
+	if err := fixture.database.QueryRow(
+		"SELECT COUNT(*) FROM league_team_members WHERE league_team_id = ? AND user_id = ? AND is_free_agent = 0",
+		fixture.teamIDs["Aces"], memberID,
+	).Scan(&onTeam); err != nil {
+		t.Fatalf("count team members: %v", err)
+	}
+	if err := fixture.database.QueryRow("SELECT COUNT(*) FROM league_free_agents WHERE user_id = ?", memberID).Scan(&registrations); err != nil {
+		t.Fatalf("count registrations: %v", err)
+	}
+	if onTeam != 1 || registrations != 0 {
+		t.Fatalf("expected member on team with registration cleared, got team=%d registrations=%d", onTeam, registrations)
+	}
+	if agents := listFreeAgents(); len(agents) != 0 {
+		t.Fatalf("expected empty free agent list after assignment, got %+v", agents)
+	}
+}
@@ -11,21 +11,21 @@ import (
 	"html"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/a-h/templ"
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/api/authz"
 	"github.com/codr1/Pickleicious/internal/api/htmx"
+	"github.com/codr1/Pickleicious/internal/api/themes"
 	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	leaguestandings "github.com/codr1/Pickleicious/internal/leagues"
-	"github.com/codr1/Pickleicious/internal/models"
 	"github.com/codr1/Pickleicious/internal/templates/layouts"
 )
 
@@ -41,11 +41,6 @@ const (
 	defaultTeamStatus   = "active"
 )
 
-var (
-	queries *dbgen.Queries
-	store   *appdb.DB
-)
-
 type leagueRequest struct {
 	FacilityID     *int64 `json:"facilityId"`
 	Name           string `json:"name"`
@@ -57,6 +52,11 @@ type leagueRequest struct {
 	MaxTeamSize    int64  `json:"maxTeamSize"`
 	RosterLockDate string `json:"rosterLockDate"`
 	Status         string `json:"status"`
+	// RegistrationFeeCents is owed per team or per player, as FeeBasis says.
+	RegistrationFeeCents int64  `json:"registrationFeeCents"`
+	FeeBasis             string `json:"feeBasis"`
+	// OverrideUnpaid lets a league start while teams still owe fees.
+	OverrideUnpaid bool `json:"overrideUnpaid"`
 }
 
 type teamRequest struct {
@@ -66,15 +66,17 @@ type teamRequest struct {
 }
 
 type leagueInput struct {
-	Name           string
-	Format         string
-	StartDate      time.Time
-	EndDate        time.Time
-	DivisionConfig string
-	MinTeamSize    int64
-	MaxTeamSize    int64
-	RosterLockDate sql.NullTime
-	Status         string
+	Name                 string
+	Format               string
+	StartDate            time.Time
+	EndDate              time.Time
+	DivisionConfig       string
+	MinTeamSize          int64
+	MaxTeamSize          int64
+	RosterLockDate       sql.NullTime
+	Status               string
+	RegistrationFeeCents int64
+	FeeBasis             string
 }
 
 type teamInput struct {
@@ -92,35 +94,58 @@ type assignFreeAgentRequest struct {
 	TeamID int64 `json:"teamId"`
 }
 
+// freeAgentEntry is one row of the staff free-agent list. Team-held free
+// agents carry their placeholder team; self-registered members carry the
+// skill level and availability they signed up with.
+type freeAgentEntry struct {
+	ID                int64          `json:"id"`
+	LeagueTeamID      *int64         `json:"leagueTeamId"`
+	UserID            int64          `json:"userId"`
+	FirstName         string         `json:"firstName"`
+	LastName          string         `json:"lastName"`
+	PhotoUrl          sql.NullString `json:"photoUrl"`
+	SelfRegistered    bool           `json:"selfRegistered"`
+	SkillLevel        string         `json:"skillLevel,omitempty"`
+	AvailabilityNotes string         `json:"availabilityNotes,omitempty"`
+	Gender            string         `json:"gender,omitempty"`
+	CreatedAt         time.Time      `json:"createdAt"`
+}
+
 type matchResultRequest struct {
 	HomeScore int64 `json:"homeScore"`
 	AwayScore int64 `json:"awayScore"`
 }
 
-// InitHandlers must be called during server startup before handling requests.
-func InitHandlers(database *appdb.DB) {
+// Handlers serves the league pages and API. Each instance holds its own
+// database.
+type Handlers struct {
+	queries *dbgen.Queries
+	store   *appdb.DB
+}
+
+// NewHandlers returns the league handlers over database. With a nil database
+// every handler answers 500.
+func NewHandlers(database *appdb.DB) *Handlers {
 	if database == nil {
-		log.Warn().Msg("InitHandlers called with nil database; league handlers will not function")
-		return
+		return &Handlers{}
 	}
-	queries = database.Queries
-	store = database
+	return &Handlers{queries: database.Queries, store: database}
 }
 
 // GET /leagues
-func HandleLeaguesPage(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleLeaguesPage(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	facilityID, err := facilityIDFromQuery(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -134,11 +159,11 @@ func HandleLeaguesPage(w http.ResponseWriter, r *http.Request) {
 	leagues, err := q.ListLeaguesByFacility(ctx, facilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list leagues")
-		http.Error(w, "Failed to load leagues", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load leagues")
 		return
 	}
 
-	activeTheme, err := models.GetActiveTheme(ctx, q, facilityID)
+	activeTheme, err := themes.PageTheme(ctx, r, q, facilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load active theme")
 		activeTheme = nil
@@ -152,19 +177,19 @@ func HandleLeaguesPage(w http.ResponseWriter, r *http.Request) {
 }
 
 // GET /api/v1/leagues
-func HandleLeaguesList(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleLeaguesList(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	facilityID, err := facilityIDFromQuery(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
@@ -178,7 +203,7 @@ func HandleLeaguesList(w http.ResponseWriter, r *http.Request) {
 	leagues, err := q.ListLeaguesByFacility(ctx, facilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list leagues")
-		http.Error(w, "Failed to list leagues", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to list leagues")
 		return
 	}
 
@@ -193,25 +218,25 @@ func HandleLeaguesList(w http.ResponseWriter, r *http.Request) {
 }
 
 // POST /api/v1/leagues
-func HandleLeagueCreate(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleLeagueCreate(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	req, err := decodeLeagueRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
 	facilityID, err := facilityIDFromRequest(r, req.FacilityID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
@@ -221,7 +246,7 @@ func HandleLeagueCreate(w http.ResponseWriter, r *http.Request) {
 
 	input, err := parseLeagueRequest(req, defaultLeagueStatus)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
@@ -229,33 +254,33 @@ func HandleLeagueCreate(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	league, err := q.CreateLeague(ctx, dbgen.CreateLeagueParams{
-		FacilityID:     facilityID,
-		Name:           input.Name,
-		Format:         input.Format,
-		StartDate:      input.StartDate,
-		EndDate:        input.EndDate,
-		DivisionConfig: input.DivisionConfig,
-		MinTeamSize:    input.MinTeamSize,
-		MaxTeamSize:    input.MaxTeamSize,
-		RosterLockDate: input.RosterLockDate,
-		Status:         input.Status,
+		FacilityID:           facilityID,
+		Name:                 input.Name,
+		Format:               input.Format,
+		StartDate:            input.StartDate,
+		EndDate:              input.EndDate,
+		DivisionConfig:       input.DivisionConfig,
+		MinTeamSize:          input.MinTeamSize,
+		MaxTeamSize:          input.MaxTeamSize,
+		RosterLockDate:       input.RosterLockDate,
+		Status:               input.Status,
+		RegistrationFeeCents: input.RegistrationFeeCents,
+		FeeBasis:             input.FeeBasis,
 	})
 	if err != nil {
 		if apiutil.IsSQLiteForeignKeyViolation(err) {
-			http.Error(w, "Facility not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Facility not found")
 			return
 		}
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to create league")
-		http.Error(w, "Failed to create league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to create league")
 		return
 	}
 
 	if htmx.IsRequest(r) {
-		headers := map[string]string{
-			"HX-Trigger": "refreshLeaguesList",
-		}
+		apiutil.AppendHXTrigger(w, "refreshLeaguesList")
 		component := leagueDetailComponent(league)
-		if !apiutil.RenderHTMLComponent(r.Context(), w, component, headers, "Failed to render league detail", "Failed to render response") {
+		if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render league detail", "Failed to render response") {
 			return
 		}
 		return
@@ -267,19 +292,19 @@ func HandleLeagueCreate(w http.ResponseWriter, r *http.Request) {
 }
 
 // GET /api/v1/leagues/{id}
-func HandleLeagueDetail(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleLeagueDetail(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
@@ -289,11 +314,11 @@ func HandleLeagueDetail(w http.ResponseWriter, r *http.Request) {
 	league, err := q.GetLeague(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -315,31 +340,31 @@ func HandleLeagueDetail(w http.ResponseWriter, r *http.Request) {
 }
 
 // PUT /api/v1/leagues/{id}
-func HandleLeagueUpdate(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleLeagueUpdate(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
 	req, err := decodeLeagueRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
 	input, err := parseLeagueRequest(req, "")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
@@ -349,11 +374,11 @@ func HandleLeagueUpdate(w http.ResponseWriter, r *http.Request) {
 	league, err := q.GetLeague(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -361,34 +386,56 @@ func HandleLeagueUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Starting the season waits for every active team to pay, unless staff
+	// override it.
+	if league.Status == "registration" && input.Status == "active" && !req.OverrideUnpaid {
+		pending := league
+		pending.RegistrationFeeCents = input.RegistrationFeeCents
+		pending.FeeBasis = input.FeeBasis
+		unpaid, err := leaguestandings.UnpaidTeams(ctx, q, pending)
+		if err != nil {
+			logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to check league payments")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update league")
+			return
+		}
+		if len(unpaid) > 0 {
+			names := make([]string, 0, len(unpaid))
+			for _, team := range unpaid {
+				names = append(names, team.Name)
+			}
+			apiutil.WriteError(w, r, http.StatusConflict, fmt.Sprintf("Teams with unpaid registration fees: %s. Set override_unpaid to start anyway.", strings.Join(names, ", ")))
+			return
+		}
+	}
+
 	updated, err := q.UpdateLeague(ctx, dbgen.UpdateLeagueParams{
-		ID:             leagueID,
-		Name:           input.Name,
-		Format:         input.Format,
-		StartDate:      input.StartDate,
-		EndDate:        input.EndDate,
-		DivisionConfig: input.DivisionConfig,
-		MinTeamSize:    input.MinTeamSize,
-		MaxTeamSize:    input.MaxTeamSize,
-		RosterLockDate: input.RosterLockDate,
-		Status:         input.Status,
+		ID:                   leagueID,
+		Name:                 input.Name,
+		Format:               input.Format,
+		StartDate:            input.StartDate,
+		EndDate:              input.EndDate,
+		DivisionConfig:       input.DivisionConfig,
+		MinTeamSize:          input.MinTeamSize,
+		MaxTeamSize:          input.MaxTeamSize,
+		RosterLockDate:       input.RosterLockDate,
+		Status:               input.Status,
+		RegistrationFeeCents: input.RegistrationFeeCents,
+		FeeBasis:             input.FeeBasis,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to update league")
-		http.Error(w, "Failed to update league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update league")
 		return
 	}
 
 	if htmx.IsRequest(r) {
-		headers := map[string]string{
-			"HX-Trigger": "refreshLeaguesList",
-		}
+		apiutil.AppendHXTrigger(w, "refreshLeaguesList")
 		component := leagueDetailComponent(updated)
-		if !apiutil.RenderHTMLComponent(r.Context(), w, component, headers, "Failed to render league detail", "Failed to render response") {
+		if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render league detail", "Failed to render response") {
 			return
 		}
 		return
@@ -400,19 +447,19 @@ func HandleLeagueUpdate(w http.ResponseWriter, r *http.Request) {
 }
 
 // DELETE /api/v1/leagues/{id}
-func HandleLeagueDelete(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleLeagueDelete(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
@@ -422,11 +469,11 @@ func HandleLeagueDelete(w http.ResponseWriter, r *http.Request) {
 	league, err := q.GetLeague(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -437,20 +484,18 @@ func HandleLeagueDelete(w http.ResponseWriter, r *http.Request) {
 	affected, err := q.DeleteLeague(ctx, leagueID)
 	if err != nil {
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to delete league")
-		http.Error(w, "Failed to delete league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to delete league")
 		return
 	}
 	if affected == 0 {
-		http.Error(w, "League not found", http.StatusNotFound)
+		apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 		return
 	}
 
 	if htmx.IsRequest(r) {
-		headers := map[string]string{
-			"HX-Trigger": "refreshLeaguesList",
-		}
+		apiutil.AppendHXTrigger(w, "refreshLeaguesList")
 		component := leagueDeleteComponent()
-		if !apiutil.RenderHTMLComponent(r.Context(), w, component, headers, "Failed to render delete response", "Failed to render response") {
+		if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render delete response", "Failed to render response") {
 			return
 		}
 		return
@@ -462,31 +507,31 @@ func HandleLeagueDelete(w http.ResponseWriter, r *http.Request) {
 }
 
 // POST /api/v1/leagues/{id}/teams
-func HandleTeamCreate(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleTeamCreate(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
 	req, err := decodeTeamRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
 	input, err := parseTeamRequest(req, defaultTeamStatus)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
@@ -496,11 +541,11 @@ func HandleTeamCreate(w http.ResponseWriter, r *http.Request) {
 	league, err := q.GetLeague(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -510,11 +555,11 @@ func HandleTeamCreate(w http.ResponseWriter, r *http.Request) {
 
 	if _, err := q.GetUserByID(ctx, input.CaptainUserID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Captain not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Captain not found")
 			return
 		}
 		logger.Error().Err(err).Int64("captain_user_id", input.CaptainUserID).Msg("Failed to fetch captain")
-		http.Error(w, "Failed to create team", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to create team")
 		return
 	}
 
@@ -526,16 +571,16 @@ func HandleTeamCreate(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		if apiutil.IsSQLiteForeignKeyViolation(err) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		if apiutil.IsSQLiteUniqueViolation(err) {
 			logger.Warn().Err(err).Int64("league_id", leagueID).Str("name", input.Name).Msg("Team name already exists in league")
-			http.Error(w, "Team name already exists in league", http.StatusConflict)
+			apiutil.WriteError(w, r, http.StatusConflict, "Team name already exists in league")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to create team")
-		http.Error(w, "Failed to create team", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to create team")
 		return
 	}
 
@@ -545,19 +590,19 @@ func HandleTeamCreate(w http.ResponseWriter, r *http.Request) {
 }
 
 // GET /api/v1/leagues/{id}/teams
-func HandleListLeagueTeams(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleListLeagueTeams(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
@@ -567,11 +612,11 @@ func HandleListLeagueTeams(w http.ResponseWriter, r *http.Request) {
 	league, err := q.GetLeague(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -582,7 +627,7 @@ func HandleListLeagueTeams(w http.ResponseWriter, r *http.Request) {
 	teams, err := q.ListLeagueTeams(ctx, leagueID)
 	if err != nil {
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to list league teams")
-		http.Error(w, "Failed to list teams", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to list teams")
 		return
 	}
 
@@ -592,25 +637,25 @@ func HandleListLeagueTeams(w http.ResponseWriter, r *http.Request) {
 }
 
 // GET /api/v1/leagues/{id}/teams/{team_id}
-func HandleTeamDetail(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleTeamDetail(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
 	teamID, err := teamIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
@@ -620,11 +665,11 @@ func HandleTeamDetail(w http.ResponseWriter, r *http.Request) {
 	league, err := q.GetLeague(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -635,66 +680,83 @@ func HandleTeamDetail(w http.ResponseWriter, r *http.Request) {
 	team, err := q.GetLeagueTeam(ctx, teamID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Team not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 			return
 		}
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to fetch team")
-		http.Error(w, "Failed to fetch team", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch team")
 		return
 	}
 	if team.LeagueID != leagueID {
-		http.Error(w, "Team not found", http.StatusNotFound)
+		apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 		return
 	}
 
 	members, err := q.ListTeamMembers(ctx, teamID)
 	if err != nil {
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to list team members")
-		http.Error(w, "Failed to fetch team members", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch team members")
+		return
+	}
+
+	payment, payments, err := leaguestandings.LoadTeamPayment(ctx, q, league.RegistrationFeeCents, league.FeeBasis, teamID)
+	if err != nil {
+		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to load team payments")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch team payments")
+		return
+	}
+
+	ratings, err := q.ListTeamMemberRatings(ctx, teamID)
+	if err != nil {
+		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to load team member ratings")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch team ratings")
 		return
 	}
 
 	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{
-		"team":    team,
-		"members": members,
+		"team":     team,
+		"members":  members,
+		"payment":  payment,
+		"payments": payments,
+		"rating":   leaguestandings.SummarizeRosterRating(ratings),
 	}); err != nil {
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to write team response")
 	}
 }
 
 // PUT /api/v1/leagues/{id}/teams/{team_id}
-func HandleTeamUpdate(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleTeamUpdate(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
-	db := loadDB()
+	q := h.loadQueries()
+	db := h.loadDB()
 	if q == nil || db == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
 	teamID, err := teamIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	req, err := decodeTeamRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
 	input, err := parseTeamRequest(req, "")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
@@ -704,11 +766,11 @@ func HandleTeamUpdate(w http.ResponseWriter, r *http.Request) {
 	league, err := q.GetLeague(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -719,32 +781,32 @@ func HandleTeamUpdate(w http.ResponseWriter, r *http.Request) {
 	team, err := q.GetLeagueTeam(ctx, teamID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Team not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 			return
 		}
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to fetch team")
-		http.Error(w, "Failed to update team", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update team")
 		return
 	}
 	if team.LeagueID != leagueID {
-		http.Error(w, "Team not found", http.StatusNotFound)
+		apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 		return
 	}
 
 	if _, err := q.GetUserByID(ctx, input.CaptainUserID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Captain not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Captain not found")
 			return
 		}
 		logger.Error().Err(err).Int64("captain_user_id", input.CaptainUserID).Msg("Failed to fetch captain")
-		http.Error(w, "Failed to update team", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update team")
 		return
 	}
 
 	tx, err := db.BeginTx(ctx)
 	if err != nil {
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to start team update transaction")
-		http.Error(w, "Failed to update team", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update team")
 		return
 	}
 
@@ -760,29 +822,29 @@ func HandleTeamUpdate(w http.ResponseWriter, r *http.Request) {
 		if errors.Is(err, sql.ErrNoRows) {
 			if rbErr := tx.Rollback(); rbErr != nil {
 				logger.Error().Err(rbErr).Int64("team_id", teamID).Msg("Failed to rollback team update")
-				http.Error(w, "Failed to update team", http.StatusInternalServerError)
+				apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update team")
 				return
 			}
-			http.Error(w, "Team not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 			return
 		}
 		if apiutil.IsSQLiteUniqueViolation(err) {
 			logger.Warn().Err(err).Int64("league_id", leagueID).Str("name", input.Name).Msg("Team name already exists in league")
 			if rbErr := tx.Rollback(); rbErr != nil {
 				logger.Error().Err(rbErr).Int64("team_id", teamID).Msg("Failed to rollback team update")
-				http.Error(w, "Failed to update team", http.StatusInternalServerError)
+				apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update team")
 				return
 			}
-			http.Error(w, "Team name already exists in league", http.StatusConflict)
+			apiutil.WriteError(w, r, http.StatusConflict, "Team name already exists in league")
 			return
 		}
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to update team")
 		if rbErr := tx.Rollback(); rbErr != nil {
 			logger.Error().Err(rbErr).Int64("team_id", teamID).Msg("Failed to rollback team update")
-			http.Error(w, "Failed to update team", http.StatusInternalServerError)
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update team")
 			return
 		}
-		http.Error(w, "Failed to update team", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update team")
 		return
 	}
 
@@ -796,26 +858,26 @@ func HandleTeamUpdate(w http.ResponseWriter, r *http.Request) {
 			if errors.Is(err, sql.ErrNoRows) {
 				if rbErr := tx.Rollback(); rbErr != nil {
 					logger.Error().Err(rbErr).Int64("team_id", teamID).Msg("Failed to rollback team update")
-					http.Error(w, "Failed to update team", http.StatusInternalServerError)
+					apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update team")
 					return
 				}
-				http.Error(w, "Team not found", http.StatusNotFound)
+				apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 				return
 			}
 			logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to update team captain")
 			if rbErr := tx.Rollback(); rbErr != nil {
 				logger.Error().Err(rbErr).Int64("team_id", teamID).Msg("Failed to rollback team update")
-				http.Error(w, "Failed to update team", http.StatusInternalServerError)
+				apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update team")
 				return
 			}
-			http.Error(w, "Failed to update team", http.StatusInternalServerError)
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update team")
 			return
 		}
 	}
 
 	if err := tx.Commit(); err != nil {
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to commit team update")
-		http.Error(w, "Failed to update team", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update team")
 		return
 	}
 
@@ -825,35 +887,35 @@ func HandleTeamUpdate(w http.ResponseWriter, r *http.Request) {
 }
 
 // POST /api/v1/leagues/{id}/teams/{team_id}/members
-func HandleAddTeamMember(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleAddTeamMember(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
 	teamID, err := teamIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	req, err := decodeTeamMemberRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 	if req.UserID <= 0 {
-		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
@@ -863,11 +925,11 @@ func HandleAddTeamMember(w http.ResponseWriter, r *http.Request) {
 	leagueRow, err := q.GetLeagueWithFacilityTimezone(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -876,45 +938,45 @@ func HandleAddTeamMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rosterLoc := rosterLockLocationForTimezone(leagueRow.FacilityTimezone, logger)
+	rosterLoc := apiutil.TimezoneLocation(leagueRow.FacilityTimezone, logger)
 	if rosterLocked(league, rosterLoc) {
-		http.Error(w, "Roster is locked for this league", http.StatusConflict)
+		apiutil.WriteError(w, r, http.StatusConflict, "Roster is locked for this league")
 		return
 	}
 
 	team, err := q.GetLeagueTeam(ctx, teamID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Team not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 			return
 		}
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to fetch team")
-		http.Error(w, "Failed to add team member", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to add team member")
 		return
 	}
 	if team.LeagueID != leagueID {
-		http.Error(w, "Team not found", http.StatusNotFound)
+		apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 		return
 	}
 
 	members, err := q.ListTeamMembers(ctx, teamID)
 	if err != nil {
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to list team members")
-		http.Error(w, "Failed to add team member", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to add team member")
 		return
 	}
 	if int64(len(members)) >= league.MaxTeamSize {
-		http.Error(w, "Team is at max size", http.StatusConflict)
+		apiutil.WriteError(w, r, http.StatusConflict, "Team is at max size")
 		return
 	}
 
 	if _, err := q.GetUserByID(ctx, req.UserID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "User not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "User not found")
 			return
 		}
 		logger.Error().Err(err).Int64("user_id", req.UserID).Msg("Failed to fetch user")
-		http.Error(w, "Failed to add team member", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to add team member")
 		return
 	}
 
@@ -925,11 +987,11 @@ func HandleAddTeamMember(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		if apiutil.IsSQLiteUniqueViolation(err) {
-			http.Error(w, "Team member already exists", http.StatusConflict)
+			apiutil.WriteError(w, r, http.StatusConflict, "Team member already exists")
 			return
 		}
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to add team member")
-		http.Error(w, "Failed to add team member", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to add team member")
 		return
 	}
 
@@ -939,31 +1001,31 @@ func HandleAddTeamMember(w http.ResponseWriter, r *http.Request) {
 }
 
 // DELETE /api/v1/leagues/{id}/teams/{team_id}/members/{user_id}
-func HandleRemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleRemoveTeamMember(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
 	teamID, err := teamIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	userID, err := userIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
@@ -973,11 +1035,11 @@ func HandleRemoveTeamMember(w http.ResponseWriter, r *http.Request) {
 	leagueRow, err := q.GetLeagueWithFacilityTimezone(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -986,24 +1048,24 @@ func HandleRemoveTeamMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rosterLoc := rosterLockLocationForTimezone(leagueRow.FacilityTimezone, logger)
+	rosterLoc := apiutil.TimezoneLocation(leagueRow.FacilityTimezone, logger)
 	if rosterLocked(league, rosterLoc) {
-		http.Error(w, "Roster is locked for this league", http.StatusConflict)
+		apiutil.WriteError(w, r, http.StatusConflict, "Roster is locked for this league")
 		return
 	}
 
 	team, err := q.GetLeagueTeam(ctx, teamID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Team not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 			return
 		}
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to fetch team")
-		http.Error(w, "Failed to remove team member", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to remove team member")
 		return
 	}
 	if team.LeagueID != leagueID {
-		http.Error(w, "Team not found", http.StatusNotFound)
+		apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 		return
 	}
 
@@ -1013,11 +1075,11 @@ func HandleRemoveTeamMember(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to remove team member")
-		http.Error(w, "Failed to remove team member", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to remove team member")
 		return
 	}
 	if affected == 0 {
-		http.Error(w, "Team member not found", http.StatusNotFound)
+		apiutil.WriteError(w, r, http.StatusNotFound, "Team member not found")
 		return
 	}
 
@@ -1027,19 +1089,19 @@ func HandleRemoveTeamMember(w http.ResponseWriter, r *http.Request) {
 }
 
 // GET /api/v1/leagues/{id}/free-agents
-func HandleListFreeAgents(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleListFreeAgents(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
@@ -1049,11 +1111,11 @@ func HandleListFreeAgents(w http.ResponseWriter, r *http.Request) {
 	league, err := q.GetLeague(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -1061,12 +1123,19 @@ func HandleListFreeAgents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	freeAgents, err := q.ListFreeAgentsByLeague(ctx, leagueID)
+	teamFreeAgents, err := q.ListFreeAgentsByLeague(ctx, leagueID)
 	if err != nil {
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to list free agents")
-		http.Error(w, "Failed to list free agents", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to list free agents")
+		return
+	}
+	registrations, err := q.ListSelfRegisteredFreeAgentsByLeague(ctx, leagueID)
+	if err != nil {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to list free agent registrations")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to list free agents")
 		return
 	}
+	freeAgents := mergeFreeAgents(teamFreeAgents, registrations)
 
 	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"freeAgents": freeAgents}); err != nil {
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to write free agents response")
@@ -1074,35 +1143,36 @@ func HandleListFreeAgents(w http.ResponseWriter, r *http.Request) {
 }
 
 // POST /api/v1/leagues/{id}/free-agents/{user_id}/assign
-func HandleAssignFreeAgent(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleAssignFreeAgent(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
-	if q == nil {
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
 	userID, err := userIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
 	req, err := decodeAssignFreeAgentRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 	if req.TeamID <= 0 {
-		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
@@ -1112,11 +1182,11 @@ func HandleAssignFreeAgent(w http.ResponseWriter, r *http.Request) {
 	leagueRow, err := q.GetLeagueWithFacilityTimezone(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -1125,50 +1195,96 @@ func HandleAssignFreeAgent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rosterLoc := rosterLockLocationForTimezone(leagueRow.FacilityTimezone, logger)
+	rosterLoc := apiutil.TimezoneLocation(leagueRow.FacilityTimezone, logger)
 	if rosterLocked(league, rosterLoc) {
-		http.Error(w, "Roster is locked for this league", http.StatusConflict)
+		apiutil.WriteError(w, r, http.StatusConflict, "Roster is locked for this league")
 		return
 	}
 
 	team, err := q.GetLeagueTeam(ctx, req.TeamID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Team not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 			return
 		}
 		logger.Error().Err(err).Int64("team_id", req.TeamID).Msg("Failed to fetch team")
-		http.Error(w, "Failed to assign free agent", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to assign free agent")
 		return
 	}
 	if team.LeagueID != leagueID {
-		http.Error(w, "Team not found", http.StatusNotFound)
+		apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
 		return
 	}
 
 	members, err := q.ListTeamMembers(ctx, req.TeamID)
 	if err != nil {
 		logger.Error().Err(err).Int64("team_id", req.TeamID).Msg("Failed to list team members")
-		http.Error(w, "Failed to assign free agent", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to assign free agent")
 		return
 	}
 	if int64(len(members)) >= league.MaxTeamSize {
-		http.Error(w, "Team is at max size", http.StatusConflict)
+		apiutil.WriteError(w, r, http.StatusConflict, "Team is at max size")
 		return
 	}
 
-	assigned, err := q.AssignFreeAgentToTeam(ctx, dbgen.AssignFreeAgentToTeamParams{
-		LeagueID:     leagueID,
-		LeagueTeamID: req.TeamID,
-		UserID:       userID,
+	var assigned dbgen.LeagueTeamMember
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		assigned, err = qtx.AssignFreeAgentToTeam(ctx, dbgen.AssignFreeAgentToTeamParams{
+			LeagueID:     leagueID,
+			LeagueTeamID: req.TeamID,
+			UserID:       userID,
+		})
+		if err == nil {
+			// A team-held free agent may also have signed up themselves.
+			if _, err := qtx.DeleteLeagueFreeAgentRegistration(ctx, dbgen.DeleteLeagueFreeAgentRegistrationParams{
+				LeagueID: leagueID,
+				UserID:   userID,
+			}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to assign free agent", Err: err}
+			}
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to assign free agent", Err: err}
+		}
+
+		removed, err := qtx.DeleteLeagueFreeAgentRegistration(ctx, dbgen.DeleteLeagueFreeAgentRegistrationParams{
+			LeagueID: leagueID,
+			UserID:   userID,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to assign free agent", Err: err}
+		}
+		if removed == 0 {
+			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Free agent not found"}
+		}
+		assigned, err = qtx.AddTeamMember(ctx, dbgen.AddTeamMemberParams{
+			LeagueTeamID: req.TeamID,
+			UserID:       userID,
+			IsFreeAgent:  false,
+		})
+		if err != nil {
+			if apiutil.IsSQLiteUniqueViolation(err) {
+				return apiutil.HandlerError{Status: http.StatusConflict, Message: "Team member already exists", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to assign free agent", Err: err}
+		}
+		return nil
 	})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Free agent not found", http.StatusNotFound)
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("league_id", leagueID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to assign free agent")
-		http.Error(w, "Failed to assign free agent", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to assign free agent")
 		return
 	}
 
@@ -1178,36 +1294,37 @@ func HandleAssignFreeAgent(w http.ResponseWriter, r *http.Request) {
 }
 
 // PUT /api/v1/leagues/{id}/matches/{match_id}/result
-func HandleRecordMatchResult(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleRecordMatchResult(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
-	if q == nil {
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
 	matchID, err := matchIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid match ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid match ID")
 		return
 	}
 
 	req, err := decodeMatchResultRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
-	if err := validateMatchResult(req.HomeScore, req.AwayScore); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := leaguestandings.ValidateMatchResult(req.HomeScore, req.AwayScore); err != nil {
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
@@ -1217,11 +1334,11 @@ func HandleRecordMatchResult(w http.ResponseWriter, r *http.Request) {
 	league, err := q.GetLeague(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -1232,34 +1349,66 @@ func HandleRecordMatchResult(w http.ResponseWriter, r *http.Request) {
 	match, err := q.GetLeagueMatch(ctx, dbgen.GetLeagueMatchParams{ID: matchID, LeagueID: leagueID})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Match not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Match not found")
 			return
 		}
 		logger.Error().Err(err).Int64("match_id", matchID).Int64("league_id", leagueID).Msg("Failed to fetch match")
-		http.Error(w, "Failed to fetch match", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch match")
 		return
 	}
 
 	status := strings.ToLower(strings.TrimSpace(match.Status))
-	if status != "scheduled" && status != "in_progress" {
-		http.Error(w, "Match result can only be recorded for scheduled or in-progress matches", http.StatusConflict)
+	switch status {
+	case "scheduled", "in_progress", "pending_result", "disputed":
+	default:
+		apiutil.WriteError(w, r, http.StatusConflict, "Match result can only be recorded for scheduled, in-progress, pending or disputed matches")
 		return
 	}
 
-	updated, err := q.UpdateMatchResult(ctx, dbgen.UpdateMatchResultParams{
-		HomeScore: sql.NullInt64{Int64: req.HomeScore, Valid: true},
-		AwayScore: sql.NullInt64{Int64: req.AwayScore, Valid: true},
-		Status:    "completed",
-		ID:        matchID,
-		LeagueID:  leagueID,
+	var overriddenBy sql.NullInt64
+	if user := authz.UserFromContext(r.Context()); user != nil {
+		overriddenBy = sql.NullInt64{Int64: user.ID, Valid: true}
+	}
+
+	// Staff results are final: any captain submission still awaiting
+	// confirmation, or in dispute, is overridden in the same transaction.
+	var updated dbgen.LeagueMatch
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		updated, err = qtx.UpdateMatchResult(ctx, dbgen.UpdateMatchResultParams{
+			HomeScore: sql.NullInt64{Int64: req.HomeScore, Valid: true},
+			AwayScore: sql.NullInt64{Int64: req.AwayScore, Valid: true},
+			Status:    "completed",
+			ID:        matchID,
+			LeagueID:  leagueID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Match not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update match result", Err: err}
+		}
+		if _, err := qtx.OverrideLeagueMatchResultSubmission(ctx, dbgen.OverrideLeagueMatchResultSubmissionParams{
+			RespondedByUserID: overriddenBy,
+			LeagueMatchID:     matchID,
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update match result", Err: err}
+		}
+		return nil
 	})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Match not found", http.StatusNotFound)
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("match_id", matchID).Int64("league_id", leagueID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
 			return
 		}
 		logger.Error().Err(err).Int64("match_id", matchID).Int64("league_id", leagueID).Msg("Failed to update match result")
-		http.Error(w, "Failed to update match result", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update match result")
 		return
 	}
 
@@ -1269,19 +1418,19 @@ func HandleRecordMatchResult(w http.ResponseWriter, r *http.Request) {
 }
 
 // GET /api/v1/leagues/{id}/standings
-func HandleLeagueStandings(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleLeagueStandings(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
@@ -1291,11 +1440,11 @@ func HandleLeagueStandings(w http.ResponseWriter, r *http.Request) {
 	league, err := q.GetLeague(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -1303,45 +1452,60 @@ func HandleLeagueStandings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	divisionName := ""
+	if name := strings.TrimSpace(r.URL.Query().Get("division")); name != "" {
+		found, ok := leaguestandings.LeagueDivisions(league.DivisionConfig).Lookup(name)
+		if !ok {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Division not found")
+			return
+		}
+		divisionName = found.Name
+	}
+
 	standings, err := leaguestandings.CalculateStandings(ctx, q, leagueID)
 	if err != nil {
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to calculate standings")
-		http.Error(w, "Failed to load standings", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load standings")
 		return
 	}
-
-	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"standings": standings}); err != nil {
-		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to write standings response")
+	divisions, err := leaguestandings.CalculateDivisionStandings(ctx, q, league)
+	if err != nil {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to calculate division standings")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load standings")
+		return
 	}
-}
 
-func sanitizeCSVField(value string) string {
-	trimmed := strings.TrimLeft(value, " \t\r\n")
-	if trimmed == "" {
-		return value
+	// standings stays the league-wide table for existing clients; divisions
+	// holds one table per division.
+	response := map[string]any{"standings": standings, "divisions": divisions}
+	if divisionName != "" {
+		for _, table := range divisions {
+			if table.Division == divisionName {
+				response = map[string]any{"division": table.Division, "standings": table.Standings, "divisions": []leaguestandings.DivisionStandings{table}}
+				break
+			}
+		}
 	}
-	switch trimmed[0] {
-	case '=', '+', '-', '@':
-		return "'" + value
-	default:
-		return value
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to write standings response")
 	}
 }
 
 // GET /api/v1/leagues/{id}/standings/export
-func HandleExportStandingsCSV(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleExportStandingsCSV(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
@@ -1351,11 +1515,11 @@ func HandleExportStandingsCSV(w http.ResponseWriter, r *http.Request) {
 	league, err := q.GetLeague(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
@@ -1366,7 +1530,7 @@ func HandleExportStandingsCSV(w http.ResponseWriter, r *http.Request) {
 	standings, err := leaguestandings.CalculateStandings(ctx, q, leagueID)
 	if err != nil {
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to calculate standings")
-		http.Error(w, "Failed to load standings", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load standings")
 		return
 	}
 
@@ -1383,14 +1547,14 @@ func HandleExportStandingsCSV(w http.ResponseWriter, r *http.Request) {
 		"Point Differential",
 	}); err != nil {
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to write standings CSV header")
-		http.Error(w, "Failed to export standings", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to export standings")
 		return
 	}
 
 	for idx, entry := range standings {
 		record := []string{
 			strconv.Itoa(idx + 1),
-			sanitizeCSVField(entry.TeamName),
+			apiutil.SanitizeCSVField(entry.TeamName),
 			strconv.Itoa(entry.MatchesPlayed),
 			strconv.Itoa(entry.Wins),
 			strconv.Itoa(entry.Losses),
@@ -1400,14 +1564,14 @@ func HandleExportStandingsCSV(w http.ResponseWriter, r *http.Request) {
 		}
 		if err := writer.Write(record); err != nil {
 			logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to write standings CSV row")
-			http.Error(w, "Failed to export standings", http.StatusInternalServerError)
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to export standings")
 			return
 		}
 	}
 	writer.Flush()
 	if err := writer.Error(); err != nil {
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to finalize standings CSV")
-		http.Error(w, "Failed to export standings", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to export standings")
 		return
 	}
 
@@ -1444,17 +1608,29 @@ func decodeLeagueRequest(r *http.Request) (leagueRequest, error) {
 		return leagueRequest{}, err
 	}
 
+	registrationFee, err := apiutil.ParseOptionalInt64Field(apiutil.FirstNonEmpty(r.FormValue("registration_fee_cents"), r.FormValue("registrationFeeCents")), "registration_fee_cents")
+	if err != nil {
+		return leagueRequest{}, err
+	}
+	var registrationFeeCents int64
+	if registrationFee != nil {
+		registrationFeeCents = *registrationFee
+	}
+
 	return leagueRequest{
-		FacilityID:     facilityID,
-		Name:           apiutil.FirstNonEmpty(r.FormValue("name")),
-		Format:         apiutil.FirstNonEmpty(r.FormValue("format")),
-		StartDate:      apiutil.FirstNonEmpty(r.FormValue("start_date"), r.FormValue("startDate")),
-		EndDate:        apiutil.FirstNonEmpty(r.FormValue("end_date"), r.FormValue("endDate")),
-		DivisionConfig: apiutil.FirstNonEmpty(r.FormValue("division_config"), r.FormValue("divisionConfig")),
-		MinTeamSize:    minTeamSize,
-		MaxTeamSize:    maxTeamSize,
-		RosterLockDate: apiutil.FirstNonEmpty(r.FormValue("roster_lock_date"), r.FormValue("rosterLockDate")),
-		Status:         apiutil.FirstNonEmpty(r.FormValue("status")),
+		FacilityID:           facilityID,
+		Name:                 apiutil.FirstNonEmpty(r.FormValue("name")),
+		Format:               apiutil.FirstNonEmpty(r.FormValue("format")),
+		StartDate:            apiutil.FirstNonEmpty(r.FormValue("start_date"), r.FormValue("startDate")),
+		EndDate:              apiutil.FirstNonEmpty(r.FormValue("end_date"), r.FormValue("endDate")),
+		DivisionConfig:       apiutil.FirstNonEmpty(r.FormValue("division_config"), r.FormValue("divisionConfig")),
+		MinTeamSize:          minTeamSize,
+		MaxTeamSize:          maxTeamSize,
+		RosterLockDate:       apiutil.FirstNonEmpty(r.FormValue("roster_lock_date"), r.FormValue("rosterLockDate")),
+		Status:               apiutil.FirstNonEmpty(r.FormValue("status")),
+		RegistrationFeeCents: registrationFeeCents,
+		FeeBasis:             apiutil.FirstNonEmpty(r.FormValue("fee_basis"), r.FormValue("feeBasis")),
+		OverrideUnpaid:       apiutil.ParseBool(apiutil.FirstNonEmpty(r.FormValue("override_unpaid"), r.FormValue("overrideUnpaid"))),
 	}, nil
 }
 
@@ -1559,27 +1735,6 @@ func parseScoreField(raw string, field string) (int64, error) {
 	return value, nil
 }
 
-func validateMatchResult(homeScore, awayScore int64) error {
-	if homeScore == awayScore {
-		return fmt.Errorf("matches cannot end in a tie")
-	}
-	diff := homeScore - awayScore
-	if diff < 0 {
-		diff = -diff
-	}
-	if diff < 2 {
-		return fmt.Errorf("winner must lead by at least two points")
-	}
-	winnerScore := homeScore
-	if awayScore > homeScore {
-		winnerScore = awayScore
-	}
-	if winnerScore < 11 {
-		return fmt.Errorf("winning score must be at least 11 points")
-	}
-	return nil
-}
-
 func parseLeagueRequest(req leagueRequest, defaultStatus string) (leagueInput, error) {
 	name := strings.TrimSpace(req.Name)
 	if name == "" {
@@ -1608,6 +1763,9 @@ func parseLeagueRequest(req leagueRequest, defaultStatus string) (leagueInput, e
 	if divisionConfig == "" {
 		return leagueInput{}, fmt.Errorf("division_config is required")
 	}
+	if _, err := leaguestandings.ParseDivisionConfig(divisionConfig); err != nil {
+		return leagueInput{}, err
+	}
 
 	if req.MinTeamSize <= 0 {
 		return leagueInput{}, fmt.Errorf("min_team_size must be greater than 0")
@@ -1632,16 +1790,29 @@ func parseLeagueRequest(req leagueRequest, defaultStatus string) (leagueInput, e
 		return leagueInput{}, fmt.Errorf("roster_lock_date must be a valid date")
 	}
 
+	if req.RegistrationFeeCents < 0 {
+		return leagueInput{}, fmt.Errorf("registration_fee_cents must be zero or greater")
+	}
+	feeBasis := strings.ToLower(strings.TrimSpace(req.FeeBasis))
+	if feeBasis == "" {
+		feeBasis = leaguestandings.FeeBasisTeam
+	}
+	if feeBasis != leaguestandings.FeeBasisTeam && feeBasis != leaguestandings.FeeBasisPlayer {
+		return leagueInput{}, fmt.Errorf("fee_basis must be team or player")
+	}
+
 	return leagueInput{
-		Name:           name,
-		Format:         format,
-		StartDate:      startDate,
-		EndDate:        endDate,
-		DivisionConfig: divisionConfig,
-		MinTeamSize:    req.MinTeamSize,
-		MaxTeamSize:    req.MaxTeamSize,
-		RosterLockDate: rosterLockDate,
-		Status:         status,
+		Name:                 name,
+		Format:               format,
+		StartDate:            startDate,
+		EndDate:              endDate,
+		DivisionConfig:       divisionConfig,
+		MinTeamSize:          req.MinTeamSize,
+		MaxTeamSize:          req.MaxTeamSize,
+		RosterLockDate:       rosterLockDate,
+		Status:               status,
+		RegistrationFeeCents: req.RegistrationFeeCents,
+		FeeBasis:             feeBasis,
 	}, nil
 }
 
@@ -1799,58 +1970,82 @@ func matchIDFromRequest(r *http.Request) (int64, error) {
 	return id, nil
 }
 
-func rosterLockLocationForTimezone(timezone string, logger *zerolog.Logger) *time.Location {
-	if strings.TrimSpace(timezone) == "" {
-		return time.UTC
-	}
-	loc, err := time.LoadLocation(timezone)
-	if err != nil {
-		logger.Warn().Err(err).Str("timezone", timezone).Msg("Failed to load facility timezone; using UTC")
-		return time.UTC
-	}
-	return loc
-}
-
 func rosterLocked(league dbgen.League, loc *time.Location) bool {
 	return rosterLockedAt(league, loc, time.Now())
 }
 
 func rosterLockedAt(league dbgen.League, loc *time.Location, now time.Time) bool {
-	if !league.RosterLockDate.Valid {
-		return false
+	return leaguestandings.RosterLockedAt(league.RosterLockDate, loc, now)
+}
+
+func mergeFreeAgents(teamHeld []dbgen.ListFreeAgentsByLeagueRow, registrations []dbgen.ListSelfRegisteredFreeAgentsByLeagueRow) []freeAgentEntry {
+	entries := make([]freeAgentEntry, 0, len(teamHeld)+len(registrations))
+	listed := make(map[int64]bool, len(teamHeld))
+	for _, agent := range teamHeld {
+		teamID := agent.LeagueTeamID
+		entries = append(entries, freeAgentEntry{
+			ID:           agent.ID,
+			LeagueTeamID: &teamID,
+			UserID:       agent.UserID,
+			FirstName:    agent.FirstName,
+			LastName:     agent.LastName,
+			PhotoUrl:     agent.PhotoUrl,
+			CreatedAt:    agent.CreatedAt,
+		})
+		listed[agent.UserID] = true
 	}
-	if loc == nil {
-		loc = time.UTC
+	for _, registration := range registrations {
+		if listed[registration.UserID] {
+			continue
+		}
+		entries = append(entries, freeAgentEntry{
+			ID:                registration.ID,
+			UserID:            registration.UserID,
+			FirstName:         registration.FirstName,
+			LastName:          registration.LastName,
+			PhotoUrl:          registration.PhotoUrl,
+			SelfRegistered:    true,
+			SkillLevel:        registration.SkillLevel,
+			AvailabilityNotes: registration.AvailabilityNotes.String,
+			Gender:            registration.Gender.String,
+			CreatedAt:         registration.CreatedAt,
+		})
 	}
-	lockDate := league.RosterLockDate.Time
-	lockTime := time.Date(lockDate.Year(), lockDate.Month(), lockDate.Day(), 0, 0, 0, 0, loc)
-	return !now.In(loc).Before(lockTime)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].LastName != entries[j].LastName {
+			return entries[i].LastName < entries[j].LastName
+		}
+		return entries[i].FirstName < entries[j].FirstName
+	})
+	return entries
 }
 
 func leagueFromRosterLockRow(row dbgen.GetLeagueWithFacilityTimezoneRow) dbgen.League {
 	return dbgen.League{
-		ID:             row.ID,
-		FacilityID:     row.FacilityID,
-		Name:           row.Name,
-		Format:         row.Format,
-		StartDate:      row.StartDate,
-		EndDate:        row.EndDate,
-		DivisionConfig: row.DivisionConfig,
-		MinTeamSize:    row.MinTeamSize,
-		MaxTeamSize:    row.MaxTeamSize,
-		RosterLockDate: row.RosterLockDate,
-		Status:         row.Status,
-		CreatedAt:      row.CreatedAt,
-		UpdatedAt:      row.UpdatedAt,
+		ID:                   row.ID,
+		FacilityID:           row.FacilityID,
+		Name:                 row.Name,
+		Format:               row.Format,
+		StartDate:            row.StartDate,
+		EndDate:              row.EndDate,
+		DivisionConfig:       row.DivisionConfig,
+		MinTeamSize:          row.MinTeamSize,
+		MaxTeamSize:          row.MaxTeamSize,
+		RosterLockDate:       row.RosterLockDate,
+		Status:               row.Status,
+		CreatedAt:            row.CreatedAt,
+		UpdatedAt:            row.UpdatedAt,
+		RegistrationFeeCents: row.RegistrationFeeCents,
+		FeeBasis:             row.FeeBasis,
 	}
 }
 
-func loadQueries() *dbgen.Queries {
-	return queries
+func (h *Handlers) loadQueries() *dbgen.Queries {
+	return h.queries
 }
 
-func loadDB() *appdb.DB {
-	return store
+func (h *Handlers) loadDB() *appdb.DB {
+	return h.store
 }
 
 func leaguesPageComponent(leagues []dbgen.League, facilityID int64) templ.Component {
@@ -1920,6 +2115,10 @@ func buildLeagueCardHTML(league dbgen.League) string {
 	if league.RosterLockDate.Valid {
 		rosterLock = formatLeagueDate(league.RosterLockDate.Time)
 	}
+	registrationFee := "None"
+	if league.RegistrationFeeCents > 0 {
+		registrationFee = fmt.Sprintf("%s per %s", apiutil.FormatPriceCents(league.RegistrationFeeCents), league.FeeBasis)
+	}
 
 	return fmt.Sprintf(
 		`<div class="rounded border bg-white p-4 shadow-sm" data-league-id="%d">
@@ -1948,6 +2147,10 @@ func buildLeagueCardHTML(league dbgen.League) string {
 					<dt class="font-medium text-gray-600">Roster lock</dt>
 					<dd>%s</dd>
 				</div>
+				<div class="flex items-center justify-between gap-4">
+					<dt class="font-medium text-gray-600">Registration fee</dt>
+					<dd>%s</dd>
+				</div>
 			</dl>
 		</div>`,
 		league.ID,
@@ -1960,6 +2163,7 @@ func buildLeagueCardHTML(league dbgen.League) string {
 		league.MinTeamSize,
 		league.MaxTeamSize,
 		rosterLock,
+		html.EscapeString(registrationFee),
 	)
 }
 
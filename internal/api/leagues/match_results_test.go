@@ -0,0 +1,60 @@
+package leagues
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func TestHandleRecordMatchResult_OverridesCaptainSubmission(t *testing.T) {
+	fixture := setupScheduleTest(t, "Aces", "Kings")
+
+	result, err := fixture.database.Exec(
+		"INSERT INTO league_matches (league_id, home_team_id, away_team_id, scheduled_time, status) VALUES (?, ?, ?, ?, 'disputed')",
+		fixture.leagueID, fixture.teamIDs["Aces"], fixture.teamIDs["Kings"], scheduleLeagueStart.Add(18*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert match: %v", err)
+	}
+	matchID, _ := result.LastInsertId()
+	if _, err := fixture.database.Exec(
+		`INSERT INTO league_match_result_submissions (league_match_id, submitted_by_user_id, home_score, away_score, status, respond_by, dispute_reason)
+		 VALUES (?, ?, 11, 3, 'disputed', ?, 'Wrong score')`,
+		matchID, fixture.staffID, time.Now().Add(time.Hour).UTC(),
+	); err != nil {
+		t.Fatalf("insert submission: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/leagues/%d/matches/%d/result", fixture.leagueID, matchID), strings.NewReader(`{"homeScore":9,"awayScore":11}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", fmt.Sprintf("%d", fixture.leagueID))
+	req.SetPathValue("match_id", fmt.Sprintf("%d", matchID))
+	facilityID := fixture.facilityID
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             fixture.staffID,
+		IsStaff:        true,
+		HomeFacilityID: &facilityID,
+	}))
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleRecordMatchResult(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("record status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var matchStatus, submissionStatus string
+	var homeScore, awayScore int64
+	if err := fixture.database.QueryRow(
+		`SELECT lm.status, lm.home_score, lm.away_score, s.status FROM league_matches lm
+		 JOIN league_match_result_submissions s ON s.league_match_id = lm.id WHERE lm.id = ?`, matchID,
+	).Scan(&matchStatus, &homeScore, &awayScore, &submissionStatus); err != nil {
+		t.Fatalf("load match: %v", err)
+	}
+	if matchStatus != "completed" || homeScore != 9 || awayScore != 11 || submissionStatus != "overridden" {
+		t.Fatalf("got match %q %d-%d, submission %q", matchStatus, homeScore, awayScore, submissionStatus)
+	}
+}
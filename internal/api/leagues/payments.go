@@ -0,0 +1,200 @@
+package leagues
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	leaguestandings "github.com/codr1/Pickleicious/internal/leagues"
+)
+
+const maxPaymentNoteLength = 500
+
+type teamPaymentRequest struct {
+	AmountCents int64  `json:"amountCents"`
+	Method      string `json:"method"`
+	Note        string `json:"note"`
+	// UserID names the player the payment covers in per-player leagues.
+	UserID int64 `json:"userId"`
+}
+
+type teamPaymentResponse struct {
+	Payment dbgen.LeaguePayment         `json:"payment"`
+	Summary leaguestandings.TeamPayment `json:"summary"`
+}
+
+// POST /api/v1/leagues/{id}/teams/{team_id}/payments
+func (h *Handlers) HandleRecordTeamPayment(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	leagueID, err := leagueIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
+		return
+	}
+
+	teamID, err := teamIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	req, err := decodeTeamPaymentRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if req.AmountCents <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "amount_cents must be greater than 0")
+		return
+	}
+	method := strings.ToLower(strings.TrimSpace(req.Method))
+	if !slices.Contains(leaguestandings.PaymentMethods, method) {
+		apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("method must be one of %s", strings.Join(leaguestandings.PaymentMethods, ", ")))
+		return
+	}
+	note := strings.TrimSpace(req.Note)
+	if len(note) > maxPaymentNoteLength {
+		apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("note must be at most %d characters", maxPaymentNoteLength))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), leagueQueryTimeout)
+	defer cancel()
+
+	league, err := q.GetLeague(ctx, leagueID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
+			return
+		}
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, league.FacilityID) {
+		return
+	}
+
+	team, err := q.GetLeagueTeam(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
+			return
+		}
+		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to fetch team")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch team")
+		return
+	}
+	if team.LeagueID != leagueID {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	var playerID sql.NullInt64
+	if league.FeeBasis == leaguestandings.FeeBasisPlayer {
+		if req.UserID <= 0 {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "user_id is required for per-player fees")
+			return
+		}
+		members, err := q.ListTeamMembers(ctx, teamID)
+		if err != nil {
+			logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to list team members")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to record payment")
+			return
+		}
+		if !slices.ContainsFunc(members, func(member dbgen.LeagueTeamMember) bool { return member.UserID == req.UserID }) {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "user_id is not on this team")
+			return
+		}
+		playerID = sql.NullInt64{Int64: req.UserID, Valid: true}
+	}
+
+	payment, err := q.CreateLeaguePayment(ctx, dbgen.CreateLeaguePaymentParams{
+		LeagueTeamID:     teamID,
+		UserID:           playerID,
+		AmountCents:      req.AmountCents,
+		Method:           method,
+		Note:             sql.NullString{String: note, Valid: note != ""},
+		RecordedByUserID: user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to record league payment")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to record payment")
+		return
+	}
+
+	summary, _, err := leaguestandings.LoadTeamPayment(ctx, q, league.RegistrationFeeCents, league.FeeBasis, teamID)
+	if err != nil {
+		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to load team payments")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch team payments")
+		return
+	}
+
+	logger.Info().
+		Int64("league_id", leagueID).
+		Int64("team_id", teamID).
+		Int64("amount_cents", payment.AmountCents).
+		Str("method", method).
+		Msg("League payment recorded")
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, teamPaymentResponse{
+		Payment: payment,
+		Summary: summary,
+	}); err != nil {
+		logger.Error().Err(err).Int64("team_id", teamID).Msg("Failed to write payment response")
+	}
+}
+
+func decodeTeamPaymentRequest(r *http.Request) (teamPaymentRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req teamPaymentRequest
+		return req, apiutil.DecodeJSON(r, &req)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return teamPaymentRequest{}, err
+	}
+
+	amountCents, err := apiutil.ParseRequiredInt64Field(apiutil.FirstNonEmpty(r.FormValue("amount_cents"), r.FormValue("amountCents")), "amount_cents")
+	if err != nil {
+		return teamPaymentRequest{}, err
+	}
+
+	userID, err := apiutil.ParseOptionalInt64Field(apiutil.FirstNonEmpty(r.FormValue("user_id"), r.FormValue("userId")), "user_id")
+	if err != nil {
+		return teamPaymentRequest{}, err
+	}
+	req := teamPaymentRequest{
+		AmountCents: amountCents,
+		Method:      r.FormValue("method"),
+		Note:        r.FormValue("note"),
+	}
+	if userID != nil {
+		req.UserID = *userID
+	}
+	return req, nil
+}
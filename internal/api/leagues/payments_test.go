@@ -0,0 +1,156 @@
+package leagues
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	leaguestandings "github.com/codr1/Pickleicious/internal/leagues"
+)
+
+func TestLeagueFees_StartBlockedUntilTeamsPay(t *testing.T) {
+	fixture := setupScheduleTest(t, "Aces", "Dinks")
+	if _, err := fixture.database.Exec(
+		"UPDATE leagues SET status = 'registration', registration_fee_cents = 5000, fee_basis = 'team' WHERE id = ?",
+		fixture.leagueID,
+	); err != nil {
+		t.Fatalf("update league: %v", err)
+	}
+
+	withStaff := func(req *http.Request) *http.Request {
+		facilityID := fixture.facilityID
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", fmt.Sprintf("%d", fixture.leagueID))
+		return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:             fixture.staffID,
+			IsStaff:        true,
+			HomeFacilityID: &facilityID,
+		}))
+	}
+	startLeague := func(override bool) *httptest.ResponseRecorder {
+		t.Helper()
+		body := fmt.Sprintf(`{
+			"name": "Summer Ladder",
+			"format": "doubles",
+			"startDate": %q,
+			"endDate": %q,
+			"divisionConfig": "{}",
+			"minTeamSize": 1,
+			"maxTeamSize": 4,
+			"status": "active",
+			"registrationFeeCents": 5000,
+			"feeBasis": "team",
+			"overrideUnpaid": %t
+		}`, scheduleLeagueStart.Format("2006-01-02"), scheduleLeagueStart.AddDate(0, 0, 27).Format("2006-01-02"), override)
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleLeagueUpdate(recorder, withStaff(httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/leagues/%d", fixture.leagueID), strings.NewReader(body))))
+		return recorder
+	}
+	recordPayment := func(teamID int64, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := withStaff(httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/leagues/%d/teams/%d/payments", fixture.leagueID, teamID), strings.NewReader(body)))
+		req.SetPathValue("team_id", fmt.Sprintf("%d", teamID))
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleRecordTeamPayment(recorder, req)
+		return recorder
+	}
+
+	recorder := startLeague(false)
+	if recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), "Aces") || !strings.Contains(recorder.Body.String(), "Dinks") {
+		t.Fatalf("expected both teams to block the start, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if recorder := recordPayment(fixture.teamIDs["Aces"], `{"amountCents":5000,"method":"wire"}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected an unknown method to be rejected, got %d", recorder.Code)
+	}
+	for _, body := range []string{
+		`{"amountCents":2000,"method":"cash","note":"Deposit"}`,
+		`{"amountCents":3000,"method":"card"}`,
+	} {
+		recorder := recordPayment(fixture.teamIDs["Aces"], body)
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("record payment status %d: %s", recorder.Code, recorder.Body.String())
+		}
+	}
+
+	req := withStaff(httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/leagues/%d/teams/%d", fixture.leagueID, fixture.teamIDs["Aces"]), nil))
+	req.SetPathValue("team_id", fmt.Sprintf("%d", fixture.teamIDs["Aces"]))
+	recorder = httptest.NewRecorder()
+	fixture.h.HandleTeamDetail(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("team detail status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var detail struct {
+		Payment  leaguestandings.TeamPayment `json:"payment"`
+		Payments []json.RawMessage           `json:"payments"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &detail); err != nil {
+		t.Fatalf("decode team detail: %v", err)
+	}
+	if detail.Payment.Status != leaguestandings.PaymentStatusPaid || detail.Payment.PaidCents != 5000 || len(detail.Payments) != 2 {
+		t.Fatalf("expected Aces to be paid in full, got %+v", detail)
+	}
+
+	recorder = startLeague(false)
+	if recorder.Code != http.StatusConflict || strings.Contains(recorder.Body.String(), "Aces") {
+		t.Fatalf("expected only Dinks to block the start, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if recorder := startLeague(true); recorder.Code != http.StatusOK {
+		t.Fatalf("expected override to start the league, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestLeagueFees_PerPlayerPaymentNamesRosteredPlayer(t *testing.T) {
+	fixture := setupScheduleTest(t, "Aces")
+	if _, err := fixture.database.Exec(
+		"UPDATE leagues SET status = 'registration', registration_fee_cents = 2500, fee_basis = 'player' WHERE id = ?",
+		fixture.leagueID,
+	); err != nil {
+		t.Fatalf("update league: %v", err)
+	}
+	teamID := fixture.teamIDs["Aces"]
+	if _, err := fixture.database.Exec("INSERT INTO league_team_members (league_team_id, user_id) VALUES (?, ?)", teamID, fixture.staffID); err != nil {
+		t.Fatalf("insert team member: %v", err)
+	}
+
+	record := func(body string) *httptest.ResponseRecorder {
+		t.Helper()
+		facilityID := fixture.facilityID
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/leagues/%d/teams/%d/payments", fixture.leagueID, teamID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", fmt.Sprintf("%d", fixture.leagueID))
+		req.SetPathValue("team_id", fmt.Sprintf("%d", teamID))
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:             fixture.staffID,
+			IsStaff:        true,
+			HomeFacilityID: &facilityID,
+		}))
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleRecordTeamPayment(recorder, req)
+		return recorder
+	}
+
+	if recorder := record(`{"amountCents":2500,"method":"cash"}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected a missing player to be rejected, got %d", recorder.Code)
+	}
+	if recorder := record(`{"amountCents":2500,"method":"cash","userId":999999}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected a player off the roster to be rejected, got %d", recorder.Code)
+	}
+
+	recorder := record(fmt.Sprintf(`{"amountCents":2500,"method":"cash","userId":%d}`, fixture.staffID))
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("record payment status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response teamPaymentResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode payment response: %v", err)
+	}
+	if response.Summary.Status != leaguestandings.PaymentStatusPaid || len(response.Summary.Players) != 1 {
+		t.Fatalf("expected the rostered player to be paid, got %+v", response.Summary)
+	}
+}
@@ -24,49 +24,99 @@ import (
 const leagueReservationTypeName = "LEAGUE"
 const defaultMatchDuration = time.Hour
 
+const (
+	bracketRoundRobin        = "round_robin"
+	bracketSingleElimination = "single_elimination"
+)
+
+type scheduleAction int
+
+const (
+	// scheduleActionGenerate refuses to touch an existing schedule.
+	scheduleActionGenerate scheduleAction = iota
+	// scheduleActionRegenerate replaces an existing schedule.
+	scheduleActionRegenerate
+	// scheduleActionAuto creates a schedule, advances a bracket to its next
+	// round, or replaces a round-robin schedule.
+	scheduleActionAuto
+)
+
+// POST /api/v1/leagues/{id}/schedule
+func (h *Handlers) HandleSchedule(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeScheduleRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	h.handleScheduleGeneration(w, r, scheduleActionAuto, req)
+}
+
 // POST /api/v1/leagues/{id}/schedule/generate
-func HandleGenerateSchedule(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleGenerateSchedule(w http.ResponseWriter, r *http.Request) {
 	req, err := decodeScheduleRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
-	handleScheduleGeneration(w, r, false, req)
+	h.handleScheduleGeneration(w, r, scheduleActionGenerate, req)
 }
 
 // POST /api/v1/leagues/{id}/schedule/regenerate
-func HandleRegenerateSchedule(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleRegenerateSchedule(w http.ResponseWriter, r *http.Request) {
 	req, err := decodeScheduleRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
-	handleScheduleGeneration(w, r, true, req)
+	h.handleScheduleGeneration(w, r, scheduleActionRegenerate, req)
 }
 
 type scheduleRequest struct {
-	PreserveCourts       bool `json:"preserveCourts"`
-	MatchDurationMinutes int  `json:"matchDurationMinutes"`
+	PreserveCourts       bool   `json:"preserveCourts"`
+	MatchDurationMinutes int    `json:"matchDurationMinutes"`
+	Bracket              string `json:"bracket"`
+	Force                bool   `json:"force"`
 }
 
 func decodeScheduleRequest(r *http.Request) (scheduleRequest, error) {
+	var req scheduleRequest
 	if apiutil.IsJSONRequest(r) {
-		var req scheduleRequest
-		return req, apiutil.DecodeJSON(r, &req)
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return scheduleRequest{}, err
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return scheduleRequest{}, err
+		}
+		preserve, err := parseOptionalBool(apiutil.FirstNonEmpty(r.FormValue("preserve_courts"), r.FormValue("preserveCourts")))
+		if err != nil {
+			return scheduleRequest{}, err
+		}
+		matchDurationMinutes, err := parseOptionalInt(apiutil.FirstNonEmpty(r.FormValue("match_duration_minutes"), r.FormValue("matchDurationMinutes")))
+		if err != nil {
+			return scheduleRequest{}, err
+		}
+		force, err := parseOptionalBool(r.FormValue("force"))
+		if err != nil {
+			return scheduleRequest{}, err
+		}
+		req = scheduleRequest{
+			PreserveCourts:       preserve,
+			MatchDurationMinutes: matchDurationMinutes,
+			Bracket:              r.FormValue("bracket"),
+			Force:                force,
+		}
 	}
 
-	if err := r.ParseForm(); err != nil {
-		return scheduleRequest{}, err
-	}
-	preserve, err := parseOptionalBool(apiutil.FirstNonEmpty(r.FormValue("preserve_courts"), r.FormValue("preserveCourts")))
-	if err != nil {
-		return scheduleRequest{}, err
-	}
-	matchDurationMinutes, err := parseOptionalInt(apiutil.FirstNonEmpty(r.FormValue("match_duration_minutes"), r.FormValue("matchDurationMinutes")))
-	if err != nil {
-		return scheduleRequest{}, err
+	req.Bracket = strings.ToLower(strings.TrimSpace(req.Bracket))
+	switch req.Bracket {
+	case "":
+		req.Bracket = bracketRoundRobin
+	case bracketRoundRobin, bracketSingleElimination:
+	default:
+		return scheduleRequest{}, fmt.Errorf("bracket must be round_robin or single_elimination")
 	}
-	return scheduleRequest{PreserveCourts: preserve, MatchDurationMinutes: matchDurationMinutes}, nil
+	return req, nil
 }
 
 func parseOptionalBool(raw string) (bool, error) {
@@ -96,56 +146,81 @@ func parseOptionalInt(raw string) (int, error) {
 	return value, nil
 }
 
-func handleScheduleGeneration(w http.ResponseWriter, r *http.Request, regenerate bool, req scheduleRequest) {
+func (h *Handlers) handleScheduleGeneration(w http.ResponseWriter, r *http.Request, action scheduleAction, req scheduleRequest) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
-	database := loadDB()
+	q := h.loadQueries()
+	database := h.loadDB()
 	if q == nil || database == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	leagueID, err := leagueIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid league ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
 		return
 	}
 
 	user := authz.UserFromContext(r.Context())
 	if user == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), leagueQueryTimeout)
 	defer cancel()
 
-	league, err := q.GetLeague(ctx, leagueID)
+	leagueRow, err := q.GetLeagueWithFacilityTimezone(ctx, leagueID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "League not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
-		http.Error(w, "Failed to fetch league", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
 		return
 	}
 
+	league := leagueFromRosterLockRow(leagueRow)
 	if !apiutil.RequireFacilityAccess(w, r, league.FacilityID) {
 		return
 	}
 
-	if !regenerate {
-		existing, err := q.ListLeagueMatches(ctx, leagueID)
-		if err != nil {
-			logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to check existing schedule")
-			http.Error(w, "Failed to check existing schedule", http.StatusInternalServerError)
+	existingMatches, err := q.ListLeagueMatchesWithReservations(ctx, leagueID)
+	if err != nil {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to check existing schedule")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to check existing schedule")
+		return
+	}
+
+	advance := false
+	replace := false
+	if len(existingMatches) > 0 {
+		switch {
+		case action == scheduleActionGenerate:
+			apiutil.WriteError(w, r, http.StatusConflict, "Schedule already exists for this league")
 			return
+		case action == scheduleActionAuto && req.Bracket == bracketSingleElimination && !req.Force:
+			advance = true
+		default:
+			replace = true
 		}
-		if len(existing) > 0 {
-			http.Error(w, "Schedule already exists for this league", http.StatusConflict)
+	}
+
+	if replace && !req.Force {
+		for _, match := range existingMatches {
+			if matchHasResult(match.Status) {
+				apiutil.WriteError(w, r, http.StatusConflict, "Schedule has completed matches; set force to regenerate")
+				return
+			}
+		}
+		// Replacing the schedule after rosters lock reshuffles matchups for
+		// teams that can no longer change, so it is gated like roster edits.
+		rosterLoc := apiutil.TimezoneLocation(leagueRow.FacilityTimezone, logger)
+		if rosterLocked(league, rosterLoc) {
+			apiutil.WriteError(w, r, http.StatusConflict, "Roster is locked for this league")
 			return
 		}
 	}
@@ -153,90 +228,133 @@ func handleScheduleGeneration(w http.ResponseWriter, r *http.Request, regenerate
 	teams, err := q.ListLeagueTeams(ctx, leagueID)
 	if err != nil {
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to load league teams")
-		http.Error(w, "Failed to load league teams", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load league teams")
 		return
 	}
 	teams = filterActiveTeams(teams)
 	if len(teams) < 2 {
-		http.Error(w, "At least two active teams are required", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "At least two active teams are required")
+		return
+	}
+
+	// Each division gets its own round robin or bracket.
+	divisions, unassigned := leaguescheduler.LeagueDivisions(league.DivisionConfig).GroupTeams(teams)
+	if len(unassigned) > 0 {
+		apiutil.WriteError(w, r, http.StatusConflict, fmt.Sprintf("%d active team(s) have no division; assign divisions first", len(unassigned)))
 		return
 	}
+	for i, division := range divisions {
+		if len(division.Teams) < 2 {
+			apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("Division %s needs at least two active teams", division.Division))
+			return
+		}
+		divisions[i].Round = 1
+	}
 
-	courts, err := q.ListCourts(ctx, league.FacilityID)
+	courts, err := q.ListActiveCourts(ctx, league.FacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", league.FacilityID).Msg("Failed to load courts")
-		http.Error(w, "Failed to load courts", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load courts")
 		return
 	}
-	courts = filterActiveCourts(courts)
 	if len(courts) == 0 {
-		http.Error(w, "No active courts available for scheduling", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "No active courts available for scheduling")
 		return
 	}
 
 	hours, err := q.GetFacilityHours(ctx, league.FacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", league.FacilityID).Msg("Failed to load operating hours")
-		http.Error(w, "Failed to load operating hours", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load operating hours")
 		return
 	}
 
 	reservationType, err := q.GetReservationTypeByName(ctx, leagueReservationTypeName)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Reservation type not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Reservation type not found")
 			return
 		}
 		logger.Error().Err(err).Msg("Failed to load reservation type")
-		http.Error(w, "Failed to load reservation type", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation type")
 		return
 	}
 
 	var preferredCourts map[string]int64
-	var existingMatches []dbgen.ListLeagueMatchesWithReservationsRow
-	if regenerate {
-		existingMatches, err = q.ListLeagueMatchesWithReservations(ctx, leagueID)
-		if err != nil {
-			logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to load existing schedule")
-			http.Error(w, "Failed to load existing schedule", http.StatusInternalServerError)
-			return
-		}
-		if req.PreserveCourts {
-			preferredCourts = buildPreferredCourtMap(existingMatches)
-		}
+	if replace && req.PreserveCourts {
+		preferredCourts = buildPreferredCourtMap(existingMatches)
 	}
 
 	matchDuration := defaultMatchDuration
 	if req.MatchDurationMinutes < 0 {
-		http.Error(w, "Match duration must be positive", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Match duration must be positive")
 		return
 	}
 	if req.MatchDurationMinutes > 0 {
 		matchDuration = time.Duration(req.MatchDurationMinutes) * time.Minute
 	}
 
-	schedule, err := leaguescheduler.GenerateRoundRobinSchedule(leagueID, teams, league.StartDate, league.EndDate, courts, hours, matchDuration)
-	if err != nil {
-		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to generate schedule")
-		http.Error(w, "Unable to generate schedule with the current league settings", http.StatusBadRequest)
-		return
+	window := leaguescheduler.SlotWindow{
+		StartDate:      league.StartDate,
+		EndDate:        league.EndDate,
+		Courts:         courts,
+		OperatingHours: hours,
+		MatchDuration:  matchDuration,
 	}
-	if req.PreserveCourts && len(preferredCourts) > 0 {
-		schedule = applyPreferredCourts(schedule, preferredCourts, courts)
+	if advance {
+		divisions, window.NotBefore, err = nextDivisionBracketRounds(existingMatches, divisions, matchDuration)
+		if err != nil {
+			apiutil.WriteError(w, r, http.StatusConflict, err.Error())
+			return
+		}
 	}
 
-	createdMatches := make([]dbgen.LeagueMatch, 0, len(schedule))
-	peoplePerTeam := peoplePerTeamFromFormat(league.Format)
+	createdMatches := make([]dbgen.LeagueMatch, 0)
+	var byes []dbgen.LeagueTeam
+	peoplePerTeam := leaguescheduler.PeoplePerTeam(league.Format)
 
 	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
 		qtx := txdb.Queries
 
-		if regenerate {
+		if replace {
 			if err := deleteExistingSchedule(ctx, qtx, league.FacilityID, leagueID, existingMatches); err != nil {
 				return err
 			}
 		}
 
+		// Slots are checked inside the transaction, after any old schedule is
+		// removed, so its reservations don't block the replacement.
+		var availabilityErr error
+		window.Available = func(start, end time.Time, court dbgen.Court) (bool, error) {
+			err := apiutil.EnsureCourtsAvailable(ctx, qtx, league.FacilityID, 0, start, end, []int64{court.ID})
+			if err == nil {
+				return true, nil
+			}
+			var availErr apiutil.AvailabilityError
+			if errors.As(err, &availErr) {
+				return false, nil
+			}
+			availabilityErr = err
+			return false, err
+		}
+
+		var schedule []leaguescheduler.ScheduledMatch
+		var err error
+		if req.Bracket == bracketSingleElimination {
+			schedule, byes, err = leaguescheduler.GenerateDivisionEliminationRound(leagueID, divisions, window)
+		} else {
+			schedule, err = leaguescheduler.GenerateDivisionRoundRobinSchedule(leagueID, divisions, window)
+		}
+		if availabilityErr != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check court availability", Err: availabilityErr}
+		}
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Unable to generate schedule with the current league settings", Err: err}
+		}
+		if req.PreserveCourts && len(preferredCourts) > 0 {
+			schedule = applyPreferredCourts(schedule, preferredCourts, courts)
+		}
+
 		for _, match := range schedule {
 			if err := apiutil.EnsureCourtsAvailable(ctx, qtx, league.FacilityID, 0, match.StartTime, match.EndTime, []int64{match.Court.ID}); err != nil {
 				var availErr apiutil.AvailabilityError
@@ -278,6 +396,7 @@ func handleScheduleGeneration(w http.ResponseWriter, r *http.Request, regenerate
 				HomeScore:     sql.NullInt64{},
 				AwayScore:     sql.NullInt64{},
 				Status:        "scheduled",
+				RoundNumber:   sql.NullInt64{Int64: int64(match.Round), Valid: true},
 			})
 			if err != nil {
 				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to create league match", Err: err}
@@ -290,19 +409,158 @@ func handleScheduleGeneration(w http.ResponseWriter, r *http.Request, regenerate
 		var herr apiutil.HandlerError
 		if errors.As(err, &herr) {
 			logger.Error().Err(herr.Err).Int64("league_id", leagueID).Msg(herr.Message)
-			http.Error(w, herr.Message, herr.Status)
+			apiutil.WriteHandlerError(w, r, herr)
 			return
 		}
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to generate schedule")
-		http.Error(w, "Failed to generate schedule", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to generate schedule")
 		return
 	}
 
-	if err := apiutil.WriteJSON(w, http.StatusCreated, map[string]any{"matches": createdMatches}); err != nil {
+	response := map[string]any{"matches": createdMatches}
+	if req.Bracket == bracketSingleElimination {
+		byeIDs := make([]int64, 0, len(byes))
+		for _, team := range byes {
+			byeIDs = append(byeIDs, team.ID)
+		}
+		round := 0
+		divisionRounds := make(map[string]int, len(divisions))
+		for _, division := range divisions {
+			divisionRounds[division.Division] = division.Round
+			round = max(round, division.Round)
+		}
+		response["round"] = round
+		response["byeTeamIds"] = byeIDs
+		if len(divisions) > 1 {
+			response["divisionRounds"] = divisionRounds
+		}
+	}
+	if err := apiutil.WriteJSON(w, http.StatusCreated, response); err != nil {
 		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to write schedule response")
 	}
 }
 
+var errBracketComplete = errors.New("bracket is already complete")
+
+// nextDivisionBracketRounds works out the next round of each division's
+// bracket from that division's matches. Divisions whose bracket is complete
+// sit the round out; the round starts once every division's latest round
+// has ended.
+func nextDivisionBracketRounds(matches []dbgen.ListLeagueMatchesWithReservationsRow, divisions []leaguescheduler.DivisionEntrants, matchDuration time.Duration) ([]leaguescheduler.DivisionEntrants, time.Time, error) {
+	next := make([]leaguescheduler.DivisionEntrants, 0, len(divisions))
+	var notBefore time.Time
+	for _, division := range divisions {
+		divisionMatches := matches
+		if len(divisions) > 1 {
+			divisionMatches = matchesInDivision(matches, division.Teams)
+			if len(divisionMatches) == 0 {
+				return nil, time.Time{}, fmt.Errorf("division %s has no bracket; set force to replace the schedule", division.Division)
+			}
+		}
+
+		round, err := nextBracketRound(divisionMatches, division.Teams, matchDuration)
+		if errors.Is(err, errBracketComplete) {
+			continue
+		}
+		if err != nil {
+			if len(divisions) > 1 {
+				err = fmt.Errorf("division %s: %w", division.Division, err)
+			}
+			return nil, time.Time{}, err
+		}
+		next = append(next, leaguescheduler.DivisionEntrants{Division: division.Division, Round: round.Round, Teams: round.Entrants})
+		if round.NotBefore.After(notBefore) {
+			notBefore = round.NotBefore
+		}
+	}
+	if len(next) == 0 {
+		return nil, time.Time{}, errBracketComplete
+	}
+	return next, notBefore, nil
+}
+
+func matchesInDivision(matches []dbgen.ListLeagueMatchesWithReservationsRow, teams []dbgen.LeagueTeam) []dbgen.ListLeagueMatchesWithReservationsRow {
+	inDivision := make(map[int64]struct{}, len(teams))
+	for _, team := range teams {
+		inDivision[team.ID] = struct{}{}
+	}
+	var filtered []dbgen.ListLeagueMatchesWithReservationsRow
+	for _, match := range matches {
+		if _, ok := inDivision[match.HomeTeamID]; ok {
+			filtered = append(filtered, match)
+		}
+	}
+	return filtered
+}
+
+type bracketRound struct {
+	Round     int
+	Entrants  []dbgen.LeagueTeam
+	NotBefore time.Time
+}
+
+// nextBracketRound works out who plays in the round after the latest one:
+// its winners, plus the round-one byes when advancing into round two.
+// Entrants keep their original seed order.
+func nextBracketRound(matches []dbgen.ListLeagueMatchesWithReservationsRow, teams []dbgen.LeagueTeam, matchDuration time.Duration) (bracketRound, error) {
+	latest := int64(0)
+	for _, match := range matches {
+		if !match.RoundNumber.Valid {
+			return bracketRound{}, fmt.Errorf("existing schedule is not a bracket; set force to replace it")
+		}
+		if match.RoundNumber.Int64 > latest {
+			latest = match.RoundNumber.Int64
+		}
+	}
+
+	advancing := make(map[int64]struct{})
+	var notBefore time.Time
+	for _, match := range matches {
+		if match.RoundNumber.Int64 != latest {
+			continue
+		}
+		if !strings.EqualFold(match.Status, "completed") || !match.HomeScore.Valid || !match.AwayScore.Valid {
+			return bracketRound{}, fmt.Errorf("round %d is not complete", latest)
+		}
+		if match.HomeScore.Int64 > match.AwayScore.Int64 {
+			advancing[match.HomeTeamID] = struct{}{}
+		} else {
+			advancing[match.AwayTeamID] = struct{}{}
+		}
+		end := match.ScheduledTime.Add(matchDuration)
+		if match.EndTime.Valid {
+			end = match.EndTime.Time
+		}
+		if end.After(notBefore) {
+			notBefore = end
+		}
+	}
+
+	if latest == 1 {
+		played := make(map[int64]struct{})
+		for _, match := range matches {
+			played[match.HomeTeamID] = struct{}{}
+			played[match.AwayTeamID] = struct{}{}
+		}
+		for _, team := range teams {
+			if _, ok := played[team.ID]; !ok {
+				advancing[team.ID] = struct{}{}
+			}
+		}
+	}
+
+	entrants := make([]dbgen.LeagueTeam, 0, len(advancing))
+	for _, team := range teams {
+		if _, ok := advancing[team.ID]; ok {
+			entrants = append(entrants, team)
+		}
+	}
+	if len(entrants) < 2 {
+		return bracketRound{}, errBracketComplete
+	}
+	return bracketRound{Round: int(latest) + 1, Entrants: entrants, NotBefore: notBefore}, nil
+}
+
 func deleteExistingSchedule(ctx context.Context, qtx *dbgen.Queries, facilityID int64, leagueID int64, matches []dbgen.ListLeagueMatchesWithReservationsRow) error {
 	reservationIDs := make(map[int64]struct{})
 	for _, match := range matches {
@@ -470,6 +728,17 @@ func matchKey(teamA, teamB int64) string {
 	return fmt.Sprintf("%d:%d", teamA, teamB)
 }
 
+// matchHasResult reports whether a match has a recorded result or a captain
+// submission awaiting confirmation or dispute resolution.
+func matchHasResult(status string) bool {
+	switch strings.ToLower(status) {
+	case "completed", "pending_result", "disputed":
+		return true
+	default:
+		return false
+	}
+}
+
 func filterActiveTeams(teams []dbgen.LeagueTeam) []dbgen.LeagueTeam {
 	active := make([]dbgen.LeagueTeam, 0, len(teams))
 	for _, team := range teams {
@@ -479,24 +748,3 @@ func filterActiveTeams(teams []dbgen.LeagueTeam) []dbgen.LeagueTeam {
 	}
 	return active
 }
-
-func filterActiveCourts(courts []dbgen.Court) []dbgen.Court {
-	active := make([]dbgen.Court, 0, len(courts))
-	for _, court := range courts {
-		if strings.EqualFold(court.Status, "active") {
-			active = append(active, court)
-		}
-	}
-	return active
-}
-
-func peoplePerTeamFromFormat(format string) int64 {
-	switch strings.ToLower(strings.TrimSpace(format)) {
-	case "singles":
-		return 1
-	case "doubles", "mixed_doubles":
-		return 2
-	default:
-		return 2
-	}
-}
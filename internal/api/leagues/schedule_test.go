@@ -0,0 +1,267 @@
+package leagues
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type scheduleFixture struct {
+	h          *Handlers
+	database   *db.DB
+	facilityID int64
+	leagueID   int64
+	staffID    int64
+	courtIDs   []int64
+	teamIDs    map[string]int64
+}
+
+var scheduleLeagueStart = time.Date(2027, time.June, 7, 0, 0, 0, 0, time.UTC)
+
+func setupScheduleTest(t *testing.T, teamNames ...string) scheduleFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	for day := 0; day < 7; day++ {
+		if _, err := database.Exec(
+			"INSERT INTO operating_hours (facility_id, day_of_week, opens_at, closes_at) VALUES (?, ?, '18:00', '20:00')",
+			facilityID, day,
+		); err != nil {
+			t.Fatalf("insert operating hours: %v", err)
+		}
+	}
+
+	courtIDs := make([]int64, 0, 2)
+	for number := 1; number <= 2; number++ {
+		result, err := database.Exec(
+			"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+			facilityID, fmt.Sprintf("Court %d", number), number, "active",
+		)
+		if err != nil {
+			t.Fatalf("insert court: %v", err)
+		}
+		courtID, _ := result.LastInsertId()
+		courtIDs = append(courtIDs, courtID)
+	}
+
+	staffResult, err := database.Exec(
+		"INSERT INTO users (first_name, last_name, email, status) VALUES (?, ?, ?, ?)",
+		"League", "Staff", "league-staff@test.com", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert staff user: %v", err)
+	}
+	staffID, _ := staffResult.LastInsertId()
+
+	leagueResult, err := database.Exec(
+		`INSERT INTO leagues (facility_id, name, format, start_date, end_date, division_config, min_team_size, max_team_size, status)
+		 VALUES (?, 'Summer Ladder', 'doubles', ?, ?, '{}', 1, 4, 'active')`,
+		facilityID, scheduleLeagueStart.Format("2006-01-02"), scheduleLeagueStart.AddDate(0, 0, 27).Format("2006-01-02"),
+	)
+	if err != nil {
+		t.Fatalf("insert league: %v", err)
+	}
+	leagueID, _ := leagueResult.LastInsertId()
+
+	teamIDs := make(map[string]int64, len(teamNames))
+	for _, name := range teamNames {
+		result, err := database.Exec(
+			"INSERT INTO league_teams (league_id, name, captain_user_id, status) VALUES (?, ?, ?, 'active')",
+			leagueID, name, staffID,
+		)
+		if err != nil {
+			t.Fatalf("insert team %s: %v", name, err)
+		}
+		teamIDs[name], _ = result.LastInsertId()
+	}
+
+	return scheduleFixture{
+		h:          NewHandlers(database),
+		database:   database,
+		facilityID: facilityID,
+		leagueID:   leagueID,
+		staffID:    staffID,
+		courtIDs:   courtIDs,
+		teamIDs:    teamIDs,
+	}
+}
+
+func (f scheduleFixture) schedule(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/leagues/%d/schedule", f.leagueID), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", fmt.Sprintf("%d", f.leagueID))
+	facilityID := f.facilityID
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             f.staffID,
+		IsStaff:        true,
+		HomeFacilityID: &facilityID,
+	}))
+	recorder := httptest.NewRecorder()
+	f.h.HandleSchedule(recorder, req)
+	return recorder
+}
+
+func (f scheduleFixture) completeMatches(t *testing.T, winnerIDs ...int64) {
+	t.Helper()
+	for _, winnerID := range winnerIDs {
+		if _, err := f.database.Exec(
+			`UPDATE league_matches
+			 SET status = 'completed',
+			     home_score = CASE WHEN home_team_id = ? THEN 11 ELSE 5 END,
+			     away_score = CASE WHEN away_team_id = ? THEN 11 ELSE 5 END
+			 WHERE league_id = ? AND status = 'scheduled' AND (home_team_id = ? OR away_team_id = ?)`,
+			winnerID, winnerID, f.leagueID, winnerID, winnerID,
+		); err != nil {
+			t.Fatalf("complete match for team %d: %v", winnerID, err)
+		}
+	}
+}
+
+type scheduleResponse struct {
+	Matches    []dbgen.LeagueMatch `json:"matches"`
+	Round      int                 `json:"round"`
+	ByeTeamIDs []int64             `json:"byeTeamIds"`
+}
+
+func decodeScheduleResponse(t *testing.T, recorder *httptest.ResponseRecorder) scheduleResponse {
+	t.Helper()
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response scheduleResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode schedule: %v", err)
+	}
+	return response
+}
+
+func TestHandleSchedule_SingleEliminationAdvancesRounds(t *testing.T) {
+	fixture := setupScheduleTest(t, "Aces", "Bandits", "Comets")
+	aces, bandits, comets := fixture.teamIDs["Aces"], fixture.teamIDs["Bandits"], fixture.teamIDs["Comets"]
+
+	// Court 1 is already booked for the first evening slot.
+	firstSlot := scheduleLeagueStart.Add(18 * time.Hour)
+	blocker, err := fixture.database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?)`,
+		fixture.facilityID, fixture.staffID, firstSlot, firstSlot.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert blocking reservation: %v", err)
+	}
+	blockerID, _ := blocker.LastInsertId()
+	if _, err := fixture.database.Exec(
+		"INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)",
+		blockerID, fixture.courtIDs[0],
+	); err != nil {
+		t.Fatalf("assign blocking court: %v", err)
+	}
+
+	first := decodeScheduleResponse(t, fixture.schedule(t, `{"bracket":"single_elimination"}`))
+	if first.Round != 1 || len(first.Matches) != 1 || fmt.Sprint(first.ByeTeamIDs) != fmt.Sprint([]int64{aces}) {
+		t.Fatalf("unexpected first round: %+v", first)
+	}
+	opener := first.Matches[0]
+	if opener.HomeTeamID != bandits || opener.AwayTeamID != comets || opener.RoundNumber.Int64 != 1 {
+		t.Fatalf("opener = %+v, want Bandits v Comets in round 1", opener)
+	}
+	var openerCourt int64
+	if err := fixture.database.QueryRow(
+		"SELECT court_id FROM reservation_courts WHERE reservation_id = ?",
+		opener.ReservationID.Int64,
+	).Scan(&openerCourt); err != nil {
+		t.Fatalf("load opener court: %v", err)
+	}
+	if !opener.ScheduledTime.Equal(firstSlot) || openerCourt != fixture.courtIDs[1] {
+		t.Fatalf("opener on court %d at %s, want the free court at %s", openerCourt, opener.ScheduledTime, firstSlot)
+	}
+
+	if recorder := fixture.schedule(t, `{"bracket":"single_elimination"}`); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected incomplete round to conflict, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	fixture.completeMatches(t, comets)
+	final := decodeScheduleResponse(t, fixture.schedule(t, `{"bracket":"single_elimination"}`))
+	if final.Round != 2 || len(final.Matches) != 1 {
+		t.Fatalf("unexpected final round: %+v", final)
+	}
+	if match := final.Matches[0]; match.HomeTeamID != aces || match.AwayTeamID != comets || !match.ScheduledTime.After(opener.ScheduledTime) {
+		t.Fatalf("final = %+v, want Aces v Comets after the opener", match)
+	}
+
+	fixture.completeMatches(t, aces)
+	if recorder := fixture.schedule(t, `{"bracket":"single_elimination"}`); recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), "complete") {
+		t.Fatalf("expected finished bracket to conflict, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleSchedule_ReplaceRequiresForce(t *testing.T) {
+	fixture := setupScheduleTest(t, "Aces", "Bandits", "Comets", "Dinks")
+
+	initial := decodeScheduleResponse(t, fixture.schedule(t, `{}`))
+	if len(initial.Matches) != 6 {
+		t.Fatalf("expected 6 round-robin matches, got %d", len(initial.Matches))
+	}
+
+	// Nothing played yet and rosters open: a plain call replaces the schedule.
+	decodeScheduleResponse(t, fixture.schedule(t, `{}`))
+
+	if _, err := fixture.database.Exec("UPDATE leagues SET roster_lock_date = '2020-01-01' WHERE id = ?", fixture.leagueID); err != nil {
+		t.Fatalf("lock roster: %v", err)
+	}
+	if recorder := fixture.schedule(t, `{}`); recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), "Roster is locked") {
+		t.Fatalf("expected roster lock to block replacement, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if _, err := fixture.database.Exec("UPDATE leagues SET roster_lock_date = NULL WHERE id = ?", fixture.leagueID); err != nil {
+		t.Fatalf("unlock roster: %v", err)
+	}
+
+	fixture.completeMatches(t, fixture.teamIDs["Aces"])
+	if recorder := fixture.schedule(t, `{}`); recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), "completed matches") {
+		t.Fatalf("expected completed match to block replacement, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	forced := decodeScheduleResponse(t, fixture.schedule(t, `{"force":true}`))
+	if len(forced.Matches) != 6 {
+		t.Fatalf("expected forced regeneration to recreate 6 matches, got %d", len(forced.Matches))
+	}
+	var completed int
+	if err := fixture.database.QueryRow(
+		"SELECT COUNT(*) FROM league_matches WHERE league_id = ? AND status = 'completed'",
+		fixture.leagueID,
+	).Scan(&completed); err != nil {
+		t.Fatalf("count completed matches: %v", err)
+	}
+	if completed != 0 {
+		t.Fatalf("expected forced regeneration to clear results, %d remain", completed)
+	}
+}
@@ -219,7 +219,7 @@ func HandleLessonPackageTypeCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshLessonPackageTypesList")
+		apiutil.AppendHXTrigger(w, "refreshLessonPackageTypesList")
 		apiutil.WriteHTMLFeedback(w, http.StatusCreated, "Lesson package type created.")
 		return
 	}
@@ -288,7 +288,7 @@ func HandleLessonPackageTypeUpdate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshLessonPackageTypesList")
+		apiutil.AppendHXTrigger(w, "refreshLessonPackageTypesList")
 		apiutil.WriteHTMLFeedback(w, http.StatusOK, "Lesson package type updated.")
 		return
 	}
@@ -343,7 +343,7 @@ func HandleLessonPackageTypeDeactivate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshLessonPackageTypesList")
+		apiutil.AppendHXTrigger(w, "refreshLessonPackageTypesList")
 		apiutil.WriteHTMLFeedback(w, http.StatusOK, "Lesson package type deactivated.")
 		return
 	}
@@ -0,0 +1,60 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+// HandleMemberAccountDeleteRequest handles POST /member/account/delete-request.
+// It only flags the account for staff review; staff carry out the deletion
+// through DELETE /api/v1/members/{id}.
+func (h *Handlers) HandleMemberAccountDeleteRequest(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	requestedAt, err := q.RequestMemberDeletion(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Member not found")
+			return
+		}
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to record account deletion request")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to request account deletion")
+		return
+	}
+	logger.Info().Int64("member_id", user.ID).Msg("Member requested account deletion")
+
+	data := membertempl.AccountDeletionData{RequestedAt: requestedAt.Time}
+	if apiutil.IsHTMXRequest(r) {
+		component := membertempl.MemberAccountDeletion(data)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render account deletion request", "Failed to render account deletion request")
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusAccepted, data); err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to write account deletion response")
+		return
+	}
+}
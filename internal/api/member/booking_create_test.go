@@ -0,0 +1,557 @@
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type memberBookingFixture struct {
+	h          *Handlers
+	database   *db.DB
+	facilityID int64
+	memberID   int64
+	courtIDs   []int64
+}
+
+func setupMemberBookingTest(t *testing.T, maxCourts int64) memberBookingFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone, max_courts_per_member_booking) VALUES (?, ?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC", maxCourts,
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	courtIDs := make([]int64, 0, 3)
+	for number := 1; number <= 3; number++ {
+		result, err := database.Exec(
+			"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+			facilityID, fmt.Sprintf("Court %d", number), number, "active",
+		)
+		if err != nil {
+			t.Fatalf("insert court %d: %v", number, err)
+		}
+		courtID, _ := result.LastInsertId()
+		courtIDs = append(courtIDs, courtID)
+	}
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+		"Group", "Organizer", "group@test.com", "active", facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	return memberBookingFixture{
+		h:          NewHandlers(database, nil, Options{}),
+		database:   database,
+		facilityID: facilityID,
+		memberID:   memberID,
+		courtIDs:   courtIDs,
+	}
+}
+
+func (f memberBookingFixture) withMember(req *http.Request) *http.Request {
+	facilityID := f.facilityID
+	return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:              f.memberID,
+		HomeFacilityID:  &facilityID,
+		MembershipLevel: 2,
+	}))
+}
+
+func (f memberBookingFixture) book(t *testing.T, courtIDs ...int64) *httptest.ResponseRecorder {
+	t.Helper()
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.UTC)
+	form := url.Values{}
+	form.Set("facility_id", fmt.Sprintf("%d", f.facilityID))
+	form.Set("start_time", start.Format(memberBookingTimeLayout))
+	form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+	for _, courtID := range courtIDs {
+		form.Add("court_ids", fmt.Sprintf("%d", courtID))
+	}
+	req := httptest.NewRequest(http.MethodPost, "/member/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+	f.h.HandleMemberBookingCreate(recorder, f.withMember(req))
+	return recorder
+}
+
+func (f memberBookingFixture) courtCount(t *testing.T, reservationID int64) int {
+	t.Helper()
+	var count int
+	if err := f.database.QueryRow(
+		"SELECT COUNT(*) FROM reservation_courts WHERE reservation_id = ?",
+		reservationID,
+	).Scan(&count); err != nil {
+		t.Fatalf("count reservation courts: %v", err)
+	}
+	return count
+}
+
+func TestHandleMemberBookingCreate_MultipleCourts(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 2)
+
+	recorder := fixture.book(t, fixture.courtIDs[0], fixture.courtIDs[1])
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created dbgen.Reservation
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode reservation: %v", err)
+	}
+	if count := fixture.courtCount(t, created.ID); count != 2 {
+		t.Fatalf("expected 2 courts on reservation, got %d", count)
+	}
+
+	// One of the requested courts is now taken, so the whole booking fails.
+	if recorder := fixture.book(t, fixture.courtIDs[1], fixture.courtIDs[2]); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected overlapping court to conflict, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/member/reservations/%d?confirm=true", created.ID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", created.ID))
+	cancelRecorder := httptest.NewRecorder()
+	fixture.h.HandleMemberReservationCancel(cancelRecorder, fixture.withMember(req))
+	if cancelRecorder.Code >= http.StatusBadRequest {
+		t.Fatalf("cancel status %d: %s", cancelRecorder.Code, cancelRecorder.Body.String())
+	}
+	if count := fixture.courtCount(t, created.ID); count != 0 {
+		t.Fatalf("expected cancellation to release all courts, %d remain", count)
+	}
+}
+
+func TestHandleMemberBookingCreate_RejectsOverlapWithOwnReservation(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	recorder := fixture.book(t, fixture.courtIDs[0])
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created dbgen.Reservation
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode reservation: %v", err)
+	}
+
+	// A free court at the same time is still a double booking for the member.
+	recorder = fixture.book(t, fixture.courtIDs[1])
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("expected overlap to conflict, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var body struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Details struct {
+				Conflict struct {
+					ReservationID int64  `json:"reservation_id"`
+					Courts        string `json:"courts"`
+				} `json:"conflict"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode overlap response: %v", err)
+	}
+	conflict := body.Error.Details.Conflict
+	if body.Error.Code != "reservation_overlap" || conflict.ReservationID != created.ID || conflict.Courts != "Court 1" || body.Error.Message == "" {
+		t.Fatalf("unexpected overlap response: %+v", body)
+	}
+}
+
+func TestHandleMemberBookingCreate_DefaultsToOneCourt(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	recorder := fixture.book(t, fixture.courtIDs[0], fixture.courtIDs[1])
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected second court to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.Contains(recorder.Body.String(), "1 court per reservation") {
+		t.Fatalf("unexpected error message: %s", recorder.Body.String())
+	}
+
+	// Repeating the same court is not a second court.
+	if recorder := fixture.book(t, fixture.courtIDs[0], fixture.courtIDs[0]); recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleMemberBookingCreate_RejectsClosedDate(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	if _, err := fixture.database.Exec(
+		"INSERT INTO facility_hours_overrides (facility_id, override_date, is_closed, reason) VALUES (?, ?, 1, 'Holiday')",
+		fixture.facilityID, tomorrow.Format("2006-01-02"),
+	); err != nil {
+		t.Fatalf("insert closed override: %v", err)
+	}
+
+	recorder := fixture.book(t, fixture.courtIDs[0])
+	if recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), "closed") || !strings.Contains(recorder.Body.String(), "Holiday") {
+		t.Fatalf("expected closed-date conflict, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleMemberBookingCreate_EnforcesFacilityMinimumBooking(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+	if _, err := fixture.database.Exec(
+		"UPDATE facilities SET slot_duration_minutes = 90, min_booking_minutes = 90 WHERE id = ?",
+		fixture.facilityID,
+	); err != nil {
+		t.Fatalf("update facility: %v", err)
+	}
+
+	recorder := fixture.book(t, fixture.courtIDs[0])
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), "at least 90 minutes") {
+		t.Fatalf("expected the one-hour booking rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleMemberBookingCreate_PrimeTimeLockedUntilUnlock(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	// fixture.book starts tomorrow at 10:00, at least 10 hours out, so a
+	// rule unlocking one hour before is still holding it.
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	result, err := fixture.database.Exec(
+		`INSERT INTO prime_time_rules (facility_id, day_of_week, start_time, end_time, min_membership_level, unlock_hours_before)
+		 VALUES (?, ?, '09:30', '12:00', 3, 1)`,
+		fixture.facilityID, int(tomorrow.Weekday()),
+	)
+	if err != nil {
+		t.Fatalf("insert prime time rule: %v", err)
+	}
+	ruleID, _ := result.LastInsertId()
+
+	recorder := fixture.book(t, fixture.courtIDs[0])
+	unlocksAt := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.UTC).Add(-time.Hour)
+	if recorder.Code != http.StatusForbidden || !strings.Contains(recorder.Body.String(), unlocksAt.Format("Mon, Jan 2 at 3:04 PM")) {
+		t.Fatalf("expected prime time 403 with unlock time, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if _, err := fixture.database.Exec("UPDATE prime_time_rules SET min_membership_level = 2 WHERE id = ?", ruleID); err != nil {
+		t.Fatalf("lower prime time level: %v", err)
+	}
+	if recorder := fixture.book(t, fixture.courtIDs[0]); recorder.Code != http.StatusCreated {
+		t.Fatalf("expected member at the rule's level to book, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleMemberBookingCreate_TierPrimeTimeAccess(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	if _, err := fixture.database.Exec(
+		`INSERT INTO prime_time_rules (facility_id, day_of_week, start_time, end_time, min_membership_level, unlock_hours_before)
+		 VALUES (?, ?, '09:30', '12:00', 3, 1)`,
+		fixture.facilityID, int(tomorrow.Weekday()),
+	); err != nil {
+		t.Fatalf("insert prime time rule: %v", err)
+	}
+	if _, err := fixture.database.Exec("UPDATE membership_tiers SET prime_time_access = 1 WHERE level = 2"); err != nil {
+		t.Fatalf("grant prime time access: %v", err)
+	}
+
+	if recorder := fixture.book(t, fixture.courtIDs[0]); recorder.Code != http.StatusCreated {
+		t.Fatalf("expected the tier's prime-time access to clear the hold, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleMemberBookingCreate_VisitPacksFollowTier(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	bookWithPack := func() *httptest.ResponseRecorder {
+		t.Helper()
+		now := time.Now().UTC()
+		start := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.UTC)
+		form := url.Values{}
+		form.Set("start_time", start.Format(memberBookingTimeLayout))
+		form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+		form.Set("court_ids", fmt.Sprintf("%d", fixture.courtIDs[0]))
+		form.Set("visit_pack_id", "1")
+		req := httptest.NewRequest(http.MethodPost, "/member/reservations", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberBookingCreate(recorder, fixture.withMember(req))
+		return recorder
+	}
+
+	recorder := bookWithPack()
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), "not available for your membership level") {
+		t.Fatalf("expected the default Member tier to refuse visit packs, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if _, err := fixture.database.Exec("UPDATE membership_tiers SET visit_packs = 1 WHERE level = 2"); err != nil {
+		t.Fatalf("enable visit packs: %v", err)
+	}
+	recorder = bookWithPack()
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), "Selected visit pack is not available") {
+		t.Fatalf("expected the tier to allow visit packs, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleMemberReservationCancel_AfterHomeFacilityChange(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	recorder := fixture.book(t, fixture.courtIDs[0])
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created dbgen.Reservation
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode reservation: %v", err)
+	}
+
+	// Staff move the member to another club after the booking was made.
+	result, err := fixture.database.Exec(
+		`INSERT INTO facilities (organization_id, name, slug, timezone)
+		 SELECT organization_id, 'New Club', 'new-club', timezone FROM facilities WHERE id = ?`,
+		fixture.facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	newFacilityID, _ := result.LastInsertId()
+	if _, err := fixture.database.Exec("UPDATE users SET home_facility_id = ? WHERE id = ?", newFacilityID, fixture.memberID); err != nil {
+		t.Fatalf("move member: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/member/reservations/%d?confirm=true", created.ID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", created.ID))
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:              fixture.memberID,
+		HomeFacilityID:  &newFacilityID,
+		MembershipLevel: 2,
+	}))
+	cancelRecorder := httptest.NewRecorder()
+	fixture.h.HandleMemberReservationCancel(cancelRecorder, req)
+	if cancelRecorder.Code >= http.StatusBadRequest {
+		t.Fatalf("cancel status %d: %s", cancelRecorder.Code, cancelRecorder.Body.String())
+	}
+	if count := fixture.courtCount(t, created.ID); count != 0 {
+		t.Fatalf("expected cancellation to release the court, %d remain", count)
+	}
+}
+
+func TestHandleMemberBookingCreate_ReplaysIdempotencyKey(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.UTC)
+	submit := func(courtID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		form := url.Values{}
+		form.Set("facility_id", fmt.Sprintf("%d", fixture.facilityID))
+		form.Set("start_time", start.Format(memberBookingTimeLayout))
+		form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+		form.Set("court_ids", fmt.Sprintf("%d", courtID))
+		form.Set("idempotency_key", "double-click-1")
+		req := httptest.NewRequest(http.MethodPost, "/member/reservations", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberBookingCreate(recorder, fixture.withMember(req))
+		return recorder
+	}
+
+	first := submit(fixture.courtIDs[0])
+	if first.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", first.Code, first.Body.String())
+	}
+	retry := submit(fixture.courtIDs[0])
+	if retry.Code != http.StatusCreated {
+		t.Fatalf("expected replay to return 201, got %d: %s", retry.Code, retry.Body.String())
+	}
+	if retry.Body.String() != first.Body.String() {
+		t.Fatalf("expected replay to return the original body\nfirst: %s\nretry: %s", first.Body.String(), retry.Body.String())
+	}
+	var count int
+	if err := fixture.database.QueryRow("SELECT COUNT(*) FROM reservations WHERE primary_user_id = ?", fixture.memberID).Scan(&count); err != nil {
+		t.Fatalf("count reservations: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected one reservation, got %d", count)
+	}
+
+	if conflicting := submit(fixture.courtIDs[1]); conflicting.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected reused key with a new payload to 422, got %d: %s", conflicting.Code, conflicting.Body.String())
+	}
+}
+
+func TestHandleMemberBookingCreate_ReplaysAfterLastPackVisit(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	if _, err := fixture.database.Exec("UPDATE membership_tiers SET visit_packs = 1 WHERE level = 2"); err != nil {
+		t.Fatalf("enable visit packs: %v", err)
+	}
+	result, err := fixture.database.Exec(
+		"INSERT INTO visit_pack_types (facility_id, name, price_cents, visit_count, valid_days, status) VALUES (?, 'Single Visit', 1000, 1, 90, 'active')",
+		fixture.facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert visit pack type: %v", err)
+	}
+	packTypeID, _ := result.LastInsertId()
+	now := time.Now().UTC()
+	result, err = fixture.database.Exec(
+		"INSERT INTO visit_packs (pack_type_id, user_id, purchase_date, expires_at, visits_remaining) VALUES (?, ?, ?, ?, 1)",
+		packTypeID, fixture.memberID, now, now.AddDate(0, 0, 90),
+	)
+	if err != nil {
+		t.Fatalf("insert visit pack: %v", err)
+	}
+	packID, _ := result.LastInsertId()
+
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.UTC)
+	submit := func() *httptest.ResponseRecorder {
+		t.Helper()
+		form := url.Values{}
+		form.Set("start_time", start.Format(memberBookingTimeLayout))
+		form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+		form.Set("court_ids", fmt.Sprintf("%d", fixture.courtIDs[0]))
+		form.Set("visit_pack_id", fmt.Sprintf("%d", packID))
+		form.Set("idempotency_key", "last-visit-1")
+		req := httptest.NewRequest(http.MethodPost, "/member/reservations", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberBookingCreate(recorder, fixture.withMember(req))
+		return recorder
+	}
+
+	first := submit()
+	if first.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", first.Code, first.Body.String())
+	}
+	var remaining int64
+	if err := fixture.database.QueryRow("SELECT visits_remaining FROM visit_packs WHERE id = ?", packID).Scan(&remaining); err != nil {
+		t.Fatalf("load visit pack: %v", err)
+	}
+	if remaining != 0 {
+		t.Fatalf("expected the booking to use the last visit, %d left", remaining)
+	}
+
+	retry := submit()
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Fatalf("expected the retry to replay the original 201, got %d: %s", retry.Code, retry.Body.String())
+	}
+}
+
+func (f memberBookingFixture) bookAt(t *testing.T, start time.Time) *httptest.ResponseRecorder {
+	t.Helper()
+	form := url.Values{}
+	form.Set("facility_id", fmt.Sprintf("%d", f.facilityID))
+	form.Set("start_time", start.Format(memberBookingTimeLayout))
+	form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+	form.Set("court_ids", fmt.Sprintf("%d", f.courtIDs[0]))
+	req := httptest.NewRequest(http.MethodPost, "/member/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+	f.h.HandleMemberBookingCreate(recorder, f.withMember(req))
+	return recorder
+}
+
+func TestHandleMemberBookingCreate_AdvanceWindowEndsAtFacilityMidnight(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	// UTC+14 keeps the facility's calendar day apart from the server's.
+	const timezone = "Pacific/Kiritimati"
+	if _, err := fixture.database.Exec(
+		"UPDATE facilities SET timezone = ?, max_advance_booking_days = 1 WHERE id = ?",
+		timezone, fixture.facilityID,
+	); err != nil {
+		t.Fatalf("update facility: %v", err)
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		t.Fatalf("load timezone: %v", err)
+	}
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	if recorder := fixture.bookAt(t, today.AddDate(0, 0, 2)); recorder.Code != http.StatusBadRequest ||
+		!strings.Contains(recorder.Body.String(), "within 1 days") {
+		t.Fatalf("expected the day after the window to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := fixture.bookAt(t, today.AddDate(0, 0, 1).Add(20*time.Hour)); recorder.Code != http.StatusCreated {
+		t.Fatalf("expected the last evening in the window to book, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleMemberBookingCreate_InvalidTimezoneFallsBackToDefault(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	if _, err := fixture.database.Exec(
+		"UPDATE facilities SET timezone = 'Not/AZone' WHERE id = ?",
+		fixture.facilityID,
+	); err != nil {
+		t.Fatalf("update facility: %v", err)
+	}
+
+	// The default location is UTC, so book() lands at 10:00 facility time.
+	if recorder := fixture.book(t, fixture.courtIDs[0]); recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleMemberBookingCreate_AcceptsRFC3339WithOffset(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.UTC)
+	// The offset is honored, so 06:00-04:00 books 10:00 at the UTC facility.
+	offset := time.FixedZone("", -4*60*60)
+	form := url.Values{}
+	form.Set("facility_id", fmt.Sprintf("%d", fixture.facilityID))
+	form.Set("start_time", start.In(offset).Format(time.RFC3339))
+	form.Set("end_time", start.Add(time.Hour).In(offset).Format(time.RFC3339))
+	form.Set("court_ids", fmt.Sprintf("%d", fixture.courtIDs[0]))
+	req := httptest.NewRequest(http.MethodPost, "/member/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleMemberBookingCreate(recorder, fixture.withMember(req))
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var stored time.Time
+	if err := fixture.database.QueryRow(
+		"SELECT start_time FROM reservations WHERE primary_user_id = ?", fixture.memberID,
+	).Scan(&stored); err != nil {
+		t.Fatalf("load reservation start: %v", err)
+	}
+	if !stored.Equal(start) {
+		t.Fatalf("stored start %s, want %s", stored, start)
+	}
+}
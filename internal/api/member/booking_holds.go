@@ -0,0 +1,143 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// bookingHoldTTL is how long a slot stays held for a member filling in the
+// booking form.
+const bookingHoldTTL = 3 * time.Minute
+
+// HandleMemberBookingHold handles POST and DELETE /member/booking/hold.
+// POST holds the member's selected courts for a slot so nobody else can book
+// them while they finish checking out, replacing any slot they already held.
+// The slot must pass the same checks as a booking. DELETE releases the hold.
+func (h *Handlers) HandleMemberBookingHold(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	if r.Method == http.MethodDelete {
+		if _, err := q.DeleteCourtSlotHoldByUser(ctx, user.ID); err != nil {
+			logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to release slot hold")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to release slot hold")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	// A hold blocks the courts for everyone else, so it must be a slot the
+	// member could book.
+	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, q, h.loadFacilities(), *user.HomeFacilityID, user.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Int64("membership_level", user.MembershipLevel).Msg("Failed to load facility booking config")
+		if !errors.Is(err, sql.ErrNoRows) {
+			writeFacilityConfigUnavailable(w, r)
+			return
+		}
+	}
+	if facility == nil {
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility")
+		return
+	}
+	tier, err := apiutil.LoadMembershipTier(ctx, q, facility.OrganizationID, user.MembershipLevel)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", facility.OrganizationID).Int64("membership_level", user.MembershipLevel).Msg("Failed to load membership tier")
+		writeFacilityConfigUnavailable(w, r)
+		return
+	}
+	slot, ok := h.parseMemberBookingSlot(ctx, w, r, q, user, facility, apiutil.FacilityLocation(*facility, logger), maxAdvanceDays, tier)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	var holds []dbgen.CourtSlotHold
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		if _, err := qtx.DeleteExpiredCourtSlotHolds(ctx, now.UTC()); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to hold slot", Err: err}
+		}
+		if err := apiutil.EnsureCourtsAvailableForHolder(ctx, qtx, facility.ID, 0, user.ID, slot.startTime, slot.endTime, slot.courtIDs); err != nil {
+			var availErr apiutil.AvailabilityError
+			if errors.As(err, &availErr) {
+				return apiutil.HandlerError{Status: http.StatusConflict, Message: "This slot is no longer available", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check court availability", Err: err}
+		}
+
+		// A member holds one slot at a time, so a new hold replaces the old.
+		if _, err := qtx.DeleteCourtSlotHoldByUser(ctx, user.ID); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to hold slot", Err: err}
+		}
+		for _, courtID := range slot.courtIDs {
+			hold, err := qtx.CreateCourtSlotHold(ctx, dbgen.CreateCourtSlotHoldParams{
+				FacilityID: facility.ID,
+				CourtID:    courtID,
+				UserID:     user.ID,
+				StartTime:  slot.startTime,
+				EndTime:    slot.endTime,
+				ExpiresAt:  now.Add(bookingHoldTTL).UTC(),
+			})
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to hold slot", Err: err}
+			}
+			holds = append(holds, hold)
+		}
+		return nil
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("user_id", user.ID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to hold slot")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to hold slot")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, holds); err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to write slot hold response")
+	}
+}
@@ -0,0 +1,191 @@
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func TestHandleMemberBookingHold(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	rivalResult, err := fixture.database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES ('Rival', 'Member', 'rival@test.com', 'active', 1, 1, 2, ?)`,
+		fixture.facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert rival: %v", err)
+	}
+	rivalID, _ := rivalResult.LastInsertId()
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.UTC)
+	htmx := false
+	send := func(handler http.HandlerFunc, method, path string, userID, courtID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		form := url.Values{}
+		form.Set("start_time", start.Format(memberBookingTimeLayout))
+		form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+		form.Set("court_ids", fmt.Sprintf("%d", courtID))
+		req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		facilityID := fixture.facilityID
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              userID,
+			HomeFacilityID:  &facilityID,
+			MembershipLevel: 2,
+		}))
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+	hold := func(userID, courtID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		return send(fixture.h.HandleMemberBookingHold, http.MethodPost, "/member/booking/hold", userID, courtID)
+	}
+	book := func(userID, courtID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		return send(fixture.h.HandleMemberBookingCreate, http.MethodPost, "/member/reservations", userID, courtID)
+	}
+	holdCount := func(userID int64) int {
+		t.Helper()
+		var count int
+		if err := fixture.database.QueryRow("SELECT COUNT(*) FROM court_slot_holds WHERE user_id = ?", userID).Scan(&count); err != nil {
+			t.Fatalf("count holds: %v", err)
+		}
+		return count
+	}
+	expect := func(recorder *httptest.ResponseRecorder, status int) {
+		t.Helper()
+		if recorder.Code != status {
+			t.Fatalf("got %d %q, want %d", recorder.Code, recorder.Body.String(), status)
+		}
+	}
+
+	courtA, courtB := fixture.courtIDs[0], fixture.courtIDs[1]
+
+	// Holding a second slot replaces the first.
+	expect(hold(fixture.memberID, courtB), http.StatusCreated)
+	expect(hold(fixture.memberID, courtA), http.StatusCreated)
+	if count := holdCount(fixture.memberID); count != 1 {
+		t.Fatalf("expected one hold per member, got %d", count)
+	}
+
+	conflict := hold(rivalID, courtA)
+	expect(conflict, http.StatusConflict)
+	var envelope struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(conflict.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("decode error envelope: %v", err)
+	}
+	if envelope.Error.Code != "court_unavailable" || envelope.Error.Message != "This slot is no longer available" {
+		t.Fatalf("unexpected error envelope: %s", conflict.Body.String())
+	}
+	expect(book(rivalID, courtA), http.StatusConflict)
+
+	// HTMX swaps the message into the booking form, so it stays plain text.
+	htmx = true
+	conflict = hold(rivalID, courtA)
+	htmx = false
+	expect(conflict, http.StatusConflict)
+	if body := strings.TrimSpace(conflict.Body.String()); body != "This slot is no longer available" {
+		t.Fatalf("expected plain HTMX error, got %q", body)
+	}
+
+	expect(hold(rivalID, courtB), http.StatusCreated)
+
+	// The holder's booking goes through and consumes the hold.
+	expect(book(fixture.memberID, courtA), http.StatusCreated)
+	if count := holdCount(fixture.memberID); count != 0 {
+		t.Fatalf("expected booking to consume the hold, %d left", count)
+	}
+
+	// Expired holds no longer block anyone.
+	if _, err := fixture.database.Exec(
+		"UPDATE court_slot_holds SET expires_at = ? WHERE user_id = ?", now.Add(-time.Second), rivalID,
+	); err != nil {
+		t.Fatalf("expire hold: %v", err)
+	}
+	expect(hold(fixture.memberID, courtB), http.StatusCreated)
+	if count := holdCount(rivalID); count != 0 {
+		t.Fatalf("expected expired hold to be swept, %d left", count)
+	}
+
+	expect(send(fixture.h.HandleMemberBookingHold, http.MethodDelete, "/member/booking/hold", fixture.memberID, courtB), http.StatusNoContent)
+	if count := holdCount(fixture.memberID); count != 0 {
+		t.Fatalf("expected DELETE to release the hold, %d left", count)
+	}
+	expect(book(rivalID, courtB), http.StatusCreated)
+}
+
+func TestHandleMemberBookingHoldChecksSlot(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 2)
+
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.UTC)
+	hold := func(start time.Time, courtIDs ...int64) *httptest.ResponseRecorder {
+		t.Helper()
+		form := url.Values{}
+		form.Set("start_time", start.Format(memberBookingTimeLayout))
+		form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+		for _, courtID := range courtIDs {
+			form.Add("court_ids", fmt.Sprintf("%d", courtID))
+		}
+		req := httptest.NewRequest(http.MethodPost, "/member/booking/hold", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberBookingHold(recorder, fixture.withMember(req))
+		return recorder
+	}
+	heldCourts := func() int {
+		t.Helper()
+		var count int
+		if err := fixture.database.QueryRow("SELECT COUNT(*) FROM court_slot_holds WHERE user_id = ?", fixture.memberID).Scan(&count); err != nil {
+			t.Fatalf("count holds: %v", err)
+		}
+		return count
+	}
+
+	courtA, courtB, courtC := fixture.courtIDs[0], fixture.courtIDs[1], fixture.courtIDs[2]
+
+	// Every selected court is held.
+	if recorder := hold(tomorrow, courtA, courtB); recorder.Code != http.StatusCreated {
+		t.Fatalf("hold two courts: %d %s", recorder.Code, recorder.Body.String())
+	}
+	if count := heldCourts(); count != 2 {
+		t.Fatalf("expected both courts held, got %d", count)
+	}
+
+	// Slots the member could not book are not held.
+	if recorder := hold(tomorrow, courtA, courtB, courtC); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected more courts than a booking allows to be rejected, got %d", recorder.Code)
+	}
+	if recorder := hold(tomorrow.AddDate(0, 0, 60), courtA); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected a slot beyond the booking window to be rejected, got %d", recorder.Code)
+	}
+	if count := heldCourts(); count != 2 {
+		t.Fatalf("expected rejected holds to leave the earlier hold, got %d", count)
+	}
+
+	if recorder := hold(tomorrow, courtC); recorder.Code != http.StatusCreated {
+		t.Fatalf("hold one court: %d %s", recorder.Code, recorder.Body.String())
+	}
+	if count := heldCourts(); count != 1 {
+		t.Fatalf("expected a new hold to replace both courts, got %d", count)
+	}
+}
@@ -0,0 +1,851 @@
+package member
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+// countingSlotQueries counts every call buildMemberBookingSlots makes. It
+// wraps the interface rather than embedding *dbgen.Queries, so a query added
+// to memberBookingSlotQueries must be counted here before this compiles.
+type countingSlotQueries struct {
+	q     memberBookingSlotQueries
+	calls map[string]int
+}
+
+func (c *countingSlotQueries) count(name string) {
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[name]++
+}
+
+func (c *countingSlotQueries) total() int {
+	total := 0
+	for _, n := range c.calls {
+		total += n
+	}
+	return total
+}
+
+func (c *countingSlotQueries) GetFacilityHours(ctx context.Context, facilityID int64) ([]dbgen.OperatingHour, error) {
+	c.count("GetFacilityHours")
+	return c.q.GetFacilityHours(ctx, facilityID)
+}
+
+func (c *countingSlotQueries) GetFacilityHoursOverride(ctx context.Context, arg dbgen.GetFacilityHoursOverrideParams) (dbgen.FacilityHoursOverride, error) {
+	c.count("GetFacilityHoursOverride")
+	return c.q.GetFacilityHoursOverride(ctx, arg)
+}
+
+func (c *countingSlotQueries) ListCourtBlocksForDay(ctx context.Context, arg dbgen.ListCourtBlocksForDayParams) ([]dbgen.ListCourtBlocksForDayRow, error) {
+	c.count("ListCourtBlocksForDay")
+	return c.q.ListCourtBlocksForDay(ctx, arg)
+}
+
+func (c *countingSlotQueries) ListPrimeTimeRulesForDay(ctx context.Context, arg dbgen.ListPrimeTimeRulesForDayParams) ([]dbgen.PrimeTimeRule, error) {
+	c.count("ListPrimeTimeRulesForDay")
+	return c.q.ListPrimeTimeRulesForDay(ctx, arg)
+}
+
+func (c *countingSlotQueries) ListCourtPricingRules(ctx context.Context, facilityID int64) ([]dbgen.CourtPricingRule, error) {
+	c.count("ListCourtPricingRules")
+	return c.q.ListCourtPricingRules(ctx, facilityID)
+}
+
+func TestBuildMemberBookingSlots_OneAvailabilityQueryPerDay(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	for number := 1; number <= 4; number++ {
+		if _, err := database.Exec(
+			"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+			facilityID, fmt.Sprintf("Court %d", number), number, "active",
+		); err != nil {
+			t.Fatalf("insert court %d: %v", number, err)
+		}
+	}
+
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	counter := &countingSlotQueries{q: database.Queries}
+	logger := zerolog.Nop()
+
+	slots, err := buildMemberBookingSlots(context.Background(), counter, facilityID, 2, 2, tomorrow, time.Now(), apiutil.FacilityBookingGranularity(nil), apiutil.CourtFilter{}, &logger)
+	if err != nil {
+		t.Fatalf("buildMemberBookingSlots: %v", err)
+	}
+
+	// Default hours are 08:00-21:00, so thirteen one-hour slots.
+	if len(slots) != 13 {
+		t.Fatalf("expected 13 slots, got %d", len(slots))
+	}
+	// Each query runs once for the whole day, however many slots and courts.
+	for name, n := range counter.calls {
+		if n != 1 {
+			t.Errorf("expected %s once, got %d calls", name, n)
+		}
+	}
+	if total := counter.total(); total != 5 {
+		t.Fatalf("expected 5 queries for a four-court day, got %d: %v", total, counter.calls)
+	}
+}
+
+func TestBuildMemberBookingSlots_MarksPrimeTimeLocked(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	if _, err := database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+		facilityID, "Court 1", 1, "active",
+	); err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+
+	now := time.Now().UTC()
+	inThreeDays := time.Date(now.Year(), now.Month(), now.Day()+3, 0, 0, 0, 0, time.UTC)
+	if _, err := database.Exec(
+		`INSERT INTO prime_time_rules (facility_id, day_of_week, start_time, end_time, min_membership_level, unlock_hours_before)
+		 VALUES (?, ?, '17:00', '20:00', 2, 48)`,
+		facilityID, int(inThreeDays.Weekday()),
+	); err != nil {
+		t.Fatalf("insert prime time rule: %v", err)
+	}
+	logger := zerolog.Nop()
+
+	slots, err := buildMemberBookingSlots(context.Background(), database.Queries, facilityID, 1, 1, inThreeDays, time.Now(), apiutil.FacilityBookingGranularity(nil), apiutil.CourtFilter{}, &logger)
+	if err != nil {
+		t.Fatalf("buildMemberBookingSlots: %v", err)
+	}
+	// Default hours are 08:00-21:00; locked slots stay listed.
+	if len(slots) != 13 {
+		t.Fatalf("expected 13 slots, got %d", len(slots))
+	}
+	for _, slot := range slots {
+		hour := slot.StartTime.Hour()
+		wantLocked := hour >= 17 && hour < 20
+		if slot.Locked != wantLocked {
+			t.Fatalf("slot %02d:00 locked = %v, want %v", hour, slot.Locked, wantLocked)
+		}
+		if wantLocked && !slot.UnlocksAt.Equal(slot.StartTime.Add(-48*time.Hour)) {
+			t.Fatalf("slot %02d:00 unlocks at %v", hour, slot.UnlocksAt)
+		}
+	}
+
+	slots, err = buildMemberBookingSlots(context.Background(), database.Queries, facilityID, 2, 2, inThreeDays, time.Now(), apiutil.FacilityBookingGranularity(nil), apiutil.CourtFilter{}, &logger)
+	if err != nil {
+		t.Fatalf("buildMemberBookingSlots premium: %v", err)
+	}
+	for _, slot := range slots {
+		if slot.Locked {
+			t.Fatalf("expected no locked slots at the rule's level, got %02d:00", slot.StartTime.Hour())
+		}
+	}
+}
+
+func TestHandleMemberBookingSlots_ShowsPublicClosureReasonOnly(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	courtIDs := make([]int64, 0, 6)
+	for number := 1; number <= 6; number++ {
+		result, err := database.Exec(
+			"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+			facilityID, "Court", number, "active",
+		)
+		if err != nil {
+			t.Fatalf("insert court %d: %v", number, err)
+		}
+		courtID, _ := result.LastInsertId()
+		courtIDs = append(courtIDs, courtID)
+	}
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+		"Slot", "Browser", "member@test.com", "active", facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	opens := tomorrow.Add(8 * time.Hour)
+	noon := tomorrow.Add(12 * time.Hour)
+
+	insertBlock := func(reservationType string, start, end time.Time, courts ...int64) int64 {
+		t.Helper()
+		result, err := database.Exec(
+			`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, start_time, end_time)
+			 VALUES (?, (SELECT id FROM reservation_types WHERE name = ?), ?, ?, ?)`,
+			facilityID, reservationType, memberID, start, end,
+		)
+		if err != nil {
+			t.Fatalf("insert %s reservation: %v", reservationType, err)
+		}
+		reservationID, _ := result.LastInsertId()
+		for _, courtID := range courts {
+			if _, err := database.Exec(
+				"INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)",
+				reservationID, courtID,
+			); err != nil {
+				t.Fatalf("assign court: %v", err)
+			}
+		}
+		return reservationID
+	}
+
+	maintenanceID := insertBlock("MAINTENANCE", opens, noon, courtIDs[0], courtIDs[1])
+	insertBlock("GAME", opens, opens.Add(time.Hour), courtIDs[2])
+
+	const internalNote = "Contractor invoice 4471 disputed"
+	if _, err := database.Exec(
+		"INSERT INTO reservation_closure_details (reservation_id, public_reason, internal_notes) VALUES (?, ?, ?)",
+		maintenanceID, "resurfacing", internalNote,
+	); err != nil {
+		t.Fatalf("insert closure details: %v", err)
+	}
+
+	h := NewHandlers(database, nil, Options{})
+
+	req := httptest.NewRequest(http.MethodGet, "/member/booking/slots?date="+tomorrow.Format("2006-01-02"), nil)
+	homeFacilityID := facilityID
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:              memberID,
+		HomeFacilityID:  &homeFacilityID,
+		MembershipLevel: 2,
+	}))
+	recorder := httptest.NewRecorder()
+	h.HandleMemberBookingSlots(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	body := recorder.Body.String()
+
+	for _, want := range []string{
+		"3 of 6 courts available (Courts 1–2 closed for resurfacing until noon)",
+		"4 of 6 courts available (Courts 1–2 closed for resurfacing until noon)",
+		"6 of 6 courts available",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected slot summary %q in response:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, internalNote) || strings.Contains(body, "4471") {
+		t.Fatalf("internal closure notes leaked into member response:\n%s", body)
+	}
+}
+
+func TestBuildMemberBookingSlots_HoursOverrides(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	if _, err := database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+		facilityID, "Court 1", 1, "active",
+	); err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+
+	now := time.Now()
+	closedDay := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	shortDay := closedDay.AddDate(0, 0, 1)
+	if _, err := database.Exec(
+		"INSERT INTO facility_hours_overrides (facility_id, override_date, is_closed, reason) VALUES (?, ?, 1, 'Holiday')",
+		facilityID, closedDay.Format("2006-01-02"),
+	); err != nil {
+		t.Fatalf("insert closed override: %v", err)
+	}
+	if _, err := database.Exec(
+		"INSERT INTO facility_hours_overrides (facility_id, override_date, is_closed, opens_at, closes_at) VALUES (?, ?, 0, '08:00', '12:00')",
+		facilityID, shortDay.Format("2006-01-02"),
+	); err != nil {
+		t.Fatalf("insert early-close override: %v", err)
+	}
+
+	logger := zerolog.Nop()
+	slots, err := buildMemberBookingSlots(context.Background(), database.Queries, facilityID, 2, 2, closedDay, time.Now(), apiutil.FacilityBookingGranularity(nil), apiutil.CourtFilter{}, &logger)
+	if err != nil {
+		t.Fatalf("buildMemberBookingSlots closed day: %v", err)
+	}
+	if len(slots) != 0 {
+		t.Fatalf("expected no slots on a closed day, got %d", len(slots))
+	}
+
+	slots, err = buildMemberBookingSlots(context.Background(), database.Queries, facilityID, 2, 2, shortDay, time.Now(), apiutil.FacilityBookingGranularity(nil), apiutil.CourtFilter{}, &logger)
+	if err != nil {
+		t.Fatalf("buildMemberBookingSlots early close: %v", err)
+	}
+	if len(slots) != 4 || slots[len(slots)-1].EndTime.Hour() != 12 {
+		t.Fatalf("expected four slots ending at noon, got %+v", slots)
+	}
+}
+
+func TestBuildMemberBookingSlots_FollowsFacilityGranularity(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	if _, err := database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+		facilityID, "Court 1", 1, "active",
+	); err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	logger := zerolog.Nop()
+
+	// 90-minute slots from the default 08:00 open: the last one that fits
+	// before 21:00 runs 18:30-20:00.
+	ninety := apiutil.BookingGranularity{Slot: 90 * time.Minute, MinBooking: 90 * time.Minute}
+	slots, err := buildMemberBookingSlots(context.Background(), database.Queries, facilityID, 2, 2, tomorrow, time.Now(), ninety, apiutil.CourtFilter{}, &logger)
+	if err != nil {
+		t.Fatalf("buildMemberBookingSlots 90 minutes: %v", err)
+	}
+	last := slots[len(slots)-1]
+	if len(slots) != 8 || slots[1].StartTime.Format("15:04") != "09:30" || last.StartTime.Format("15:04") != "18:30" || last.EndTime.Format("15:04") != "20:00" {
+		t.Fatalf("unexpected 90-minute slots: %d, last %s-%s", len(slots), last.StartTime.Format("15:04"), last.EndTime.Format("15:04"))
+	}
+
+	// Half-hour starts with a one-hour minimum offer hour-long slots every
+	// 30 minutes.
+	halfHour := apiutil.BookingGranularity{Slot: 30 * time.Minute, MinBooking: time.Hour}
+	slots, err = buildMemberBookingSlots(context.Background(), database.Queries, facilityID, 2, 2, tomorrow, time.Now(), halfHour, apiutil.CourtFilter{}, &logger)
+	if err != nil {
+		t.Fatalf("buildMemberBookingSlots 30 minutes: %v", err)
+	}
+	if len(slots) != 25 || slots[1].StartTime.Format("15:04") != "08:30" || slots[1].EndTime.Sub(slots[1].StartTime) != time.Hour {
+		t.Fatalf("unexpected half-hour slots: %d", len(slots))
+	}
+}
+
+func TestRoundUpToSlot(t *testing.T) {
+	open := time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		value time.Time
+		want  string
+	}{
+		{open.Add(-time.Hour), "08:00"},
+		{open.Add(90 * time.Minute), "09:30"},
+		{open.Add(91 * time.Minute), "11:00"},
+		{open.Add(90*time.Minute + time.Second), "11:00"},
+	} {
+		if got := roundUpToSlot(tc.value, open, 90*time.Minute).Format("15:04"); got != tc.want {
+			t.Fatalf("roundUpToSlot(%s) = %s, want %s", tc.value.Format("15:04:05"), got, tc.want)
+		}
+	}
+}
+
+func TestBookingDateFromRequest_UsesFacilityTimezone(t *testing.T) {
+	serverLocal := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = serverLocal })
+
+	pacific, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("load timezone: %v", err)
+	}
+	// 11:30pm Saturday in the facility is already Sunday on a UTC server.
+	now := time.Date(2026, 3, 7, 23, 30, 0, 0, pacific)
+
+	for _, tc := range []struct {
+		query string
+		want  string
+	}{
+		{"", "2026-03-07"},
+		{"date=2026-03-07", "2026-03-07"},
+		{"date=2026-03-06", "2026-03-07"},
+		{"date=2026-03-14", "2026-03-14"},
+		{"date=2026-03-15", "2026-03-14"},
+		{"booking_year=2026&booking_month=3&booking_day=14", "2026-03-14"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/member/booking/slots?"+tc.query, nil)
+		got := bookingDateFromRequest(req, 7, now)
+		if got.Format("2006-01-02") != tc.want || got.Location() != pacific || got.Hour() != 0 {
+			t.Fatalf("bookingDateFromRequest(%q) = %s, want midnight %s Pacific", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestBuildMemberBookingSlots_PacificFacilityOnUTCServer(t *testing.T) {
+	serverLocal := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = serverLocal })
+
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "America/Los_Angeles",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	if _, err := database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+		facilityID, "Court 1", 1, "active",
+	); err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+	// Clocks spring forward at 2:00am on Sunday, March 8, 2026.
+	if _, err := database.Exec(
+		"INSERT INTO operating_hours (facility_id, day_of_week, opens_at, closes_at) VALUES (?, ?, '00:00', '06:00')",
+		facilityID, int(time.Sunday),
+	); err != nil {
+		t.Fatalf("insert operating hours: %v", err)
+	}
+
+	facility, err := database.Queries.GetFacilityByID(context.Background(), facilityID)
+	if err != nil {
+		t.Fatalf("load facility: %v", err)
+	}
+	logger := zerolog.Nop()
+	loc := memberBookingLocation(&facility, &logger)
+	now := time.Date(2026, 3, 7, 23, 30, 0, 0, loc)
+	halfHour := apiutil.BookingGranularity{Slot: 30 * time.Minute, MinBooking: time.Hour}
+
+	// Saturday's default hours closed at 9pm facility time, so tonight has
+	// nothing left even though it is already Sunday in UTC.
+	today := bookingDateFromRequest(httptest.NewRequest(http.MethodGet, "/member/booking/slots", nil), 7, now)
+	slots, err := buildMemberBookingSlots(context.Background(), database.Queries, facilityID, 2, 2, today, now, halfHour, apiutil.CourtFilter{}, &logger)
+	if err != nil {
+		t.Fatalf("buildMemberBookingSlots tonight: %v", err)
+	}
+	if len(slots) != 0 {
+		t.Fatalf("expected no slots left tonight, got %d starting %s", len(slots), slots[0].StartTime)
+	}
+
+	sunday := bookingDateFromRequest(httptest.NewRequest(http.MethodGet, "/member/booking/slots?date=2026-03-08", nil), 7, now)
+	slots, err = buildMemberBookingSlots(context.Background(), database.Queries, facilityID, 2, 2, sunday, now, halfHour, apiutil.CourtFilter{}, &logger)
+	if err != nil {
+		t.Fatalf("buildMemberBookingSlots spring forward: %v", err)
+	}
+	var starts []string
+	for _, slot := range slots {
+		starts = append(starts, slot.StartTime.Format("15:04"))
+		if slot.EndTime.Sub(slot.StartTime) != time.Hour {
+			t.Fatalf("slot %s lasts %s, want 1h", slot.StartTime, slot.EndTime.Sub(slot.StartTime))
+		}
+		// The form posts slots back as facility wall times; they must parse
+		// to the same instants HandleMemberBookingCreate validates.
+		for _, value := range []time.Time{slot.StartTime, slot.EndTime} {
+			parsed, err := apiutil.ParseFlexibleTime(value.Format(memberBookingTimeLayout), "start_time", loc)
+			if err != nil || !parsed.Equal(value) {
+				t.Fatalf("slot time %s round-trips to %s (%v)", value, parsed, err)
+			}
+		}
+	}
+	want := "00:00,00:30,01:00,01:30,03:00,03:30,04:00,04:30,05:00"
+	if got := strings.Join(starts, ","); got != want {
+		t.Fatalf("spring-forward slots %s, want %s", got, want)
+	}
+}
+
+func TestHandleMemberBookingSlots_FiltersCourtsByAttribute(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	indoorResult, err := database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status, is_indoor, surface, has_lights) VALUES (?, ?, ?, ?, 1, 'sport_tile', 1)",
+		facilityID, "Center", 1, "active",
+	)
+	if err != nil {
+		t.Fatalf("insert indoor court: %v", err)
+	}
+	indoorCourtID, _ := indoorResult.LastInsertId()
+	for number := 2; number <= 3; number++ {
+		if _, err := database.Exec(
+			"INSERT INTO courts (facility_id, name, court_number, status, surface) VALUES (?, ?, ?, ?, 'hard')",
+			facilityID, "Court", number, "active",
+		); err != nil {
+			t.Fatalf("insert outdoor court %d: %v", number, err)
+		}
+	}
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+		"Slot", "Browser", "member@test.com", "active", facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	opens := tomorrow.Add(8 * time.Hour)
+	result, err := database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?)`,
+		facilityID, memberID, opens, opens.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, _ := result.LastInsertId()
+	if _, err := database.Exec(
+		"INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)",
+		reservationID, indoorCourtID,
+	); err != nil {
+		t.Fatalf("assign court: %v", err)
+	}
+
+	h := NewHandlers(database, nil, Options{})
+
+	request := func(filters string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/member/booking/slots?date="+tomorrow.Format("2006-01-02")+filters, nil)
+		homeFacilityID := facilityID
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              memberID,
+			HomeFacilityID:  &homeFacilityID,
+			MembershipLevel: 2,
+		}))
+		recorder := httptest.NewRecorder()
+		h.HandleMemberBookingSlots(recorder, req)
+		return recorder
+	}
+
+	recorder := request("&indoor=true")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	body := recorder.Body.String()
+	// The only indoor court is taken at opening, so that slot is gone.
+	if strings.Contains(body, `value="`+opens.Format("2006-01-02T15:04")+`"`) {
+		t.Fatalf("expected the 8:00 slot to be unavailable indoors:\n%s", body)
+	}
+	for _, want := range []string{"1 of 1 courts available", "Center (Court 1) · Indoor, Sport tile, Lights"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q in response:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "Court (Court 2)") {
+		t.Fatalf("expected outdoor courts to be filtered out:\n%s", body)
+	}
+
+	body = request("&surface=hard&lights=false").Body.String()
+	for _, want := range []string{"2 of 2 courts available", `value="` + opens.Format("2006-01-02T15:04") + `"`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q in response:\n%s", want, body)
+		}
+	}
+
+	if recorder := request("&surface=grass"); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown surface, got %d", recorder.Code)
+	}
+}
+
+func TestBuildMemberBookingSlots_SkipsSlotsInsideBuffer(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone, buffer_minutes) VALUES (?, ?, ?, ?, 10)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	courtResult, err := database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+		facilityID, "Court 1", 1, "active",
+	)
+	if err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+	courtID, _ := courtResult.LastInsertId()
+	userResult, err := database.Exec(
+		"INSERT INTO users (first_name, last_name, email, status) VALUES ('Staff', 'User', 'staff@test.com', 'active')",
+	)
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	userID, _ := userResult.LastInsertId()
+
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	start := tomorrow.Add(10 * time.Hour)
+	result, err := database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?)`,
+		facilityID, userID, start, start.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, _ := result.LastInsertId()
+	if _, err := database.Exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", reservationID, courtID); err != nil {
+		t.Fatalf("assign court: %v", err)
+	}
+	logger := zerolog.Nop()
+
+	slots, err := buildMemberBookingSlots(context.Background(), database.Queries, facilityID, 2, 2, tomorrow, time.Now(), apiutil.FacilityBookingGranularity(nil), apiutil.CourtFilter{}, &logger)
+	if err != nil {
+		t.Fatalf("buildMemberBookingSlots: %v", err)
+	}
+	// The booked 10:00 slot is gone, and so are the slots whose buffer runs
+	// into it (09:00) or that start inside its buffer (11:00).
+	hours := make(map[int]bool, len(slots))
+	for _, slot := range slots {
+		hours[slot.StartTime.Hour()] = true
+	}
+	for _, hour := range []int{9, 10, 11} {
+		if hours[hour] {
+			t.Fatalf("expected the %02d:00 slot to be unavailable, got %v", hour, hours)
+		}
+	}
+	if !hours[8] || !hours[12] || len(slots) != 10 {
+		t.Fatalf("expected the other ten slots, got %v", hours)
+	}
+}
+
+func TestHandleMemberBookingSlots_NarrowsCourtsToChosenSlot(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	courtIDs := make([]int64, 0, 2)
+	for number, name := range []string{"Alpha", "Beta"} {
+		result, err := database.Exec(
+			"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+			facilityID, name, number+1, "active",
+		)
+		if err != nil {
+			t.Fatalf("insert court %s: %v", name, err)
+		}
+		courtID, _ := result.LastInsertId()
+		courtIDs = append(courtIDs, courtID)
+	}
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+		"Slot", "Browser", "member@test.com", "active", facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	booked := tomorrow.Add(9 * time.Hour)
+	result, err := database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?)`,
+		facilityID, memberID, booked, booked.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, _ := result.LastInsertId()
+	if _, err := database.Exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", reservationID, courtIDs[0]); err != nil {
+		t.Fatalf("assign court: %v", err)
+	}
+
+	logger := zerolog.Nop()
+	slots, err := buildMemberBookingSlots(context.Background(), database.Queries, facilityID, 2, 2, tomorrow, time.Now(), apiutil.FacilityBookingGranularity(nil), apiutil.CourtFilter{}, &logger)
+	if err != nil {
+		t.Fatalf("buildMemberBookingSlots: %v", err)
+	}
+	for _, slot := range slots {
+		want := courtIDs
+		if slot.StartTime.Equal(booked) {
+			want = courtIDs[1:]
+		}
+		if !slices.Equal(slot.FreeCourtIDs, want) {
+			t.Fatalf("slot %s: expected free courts %v, got %v", slot.StartTime.Format("15:04"), want, slot.FreeCourtIDs)
+		}
+	}
+
+	h := NewHandlers(database, nil, Options{})
+
+	request := func(startTime time.Time) string {
+		t.Helper()
+		target := "/member/booking/slots?date=" + tomorrow.Format("2006-01-02") + "&start_time=" + startTime.Format("2006-01-02T15:04")
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		homeFacilityID := facilityID
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              memberID,
+			HomeFacilityID:  &homeFacilityID,
+			MembershipLevel: 2,
+		}))
+		recorder := httptest.NewRecorder()
+		h.HandleMemberBookingSlots(recorder, req)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+		}
+		return recorder.Body.String()
+	}
+
+	body := request(booked)
+	if strings.Contains(body, "Alpha (Court 1)") || !strings.Contains(body, "Beta (Court 2)") {
+		t.Fatalf("expected only the free court for the 9:00 slot:\n%s", body)
+	}
+	if !strings.Contains(body, `value="`+booked.Add(time.Hour).Format("2006-01-02T15:04")+`"`) {
+		t.Fatalf("expected the end time of the chosen slot:\n%s", body)
+	}
+
+	body = request(booked.Add(time.Hour))
+	if !strings.Contains(body, "Alpha (Court 1)") || !strings.Contains(body, "Beta (Court 2)") {
+		t.Fatalf("expected both courts for the 10:00 slot:\n%s", body)
+	}
+}
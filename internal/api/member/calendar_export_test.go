@@ -0,0 +1,185 @@
+package member
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/auth"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/models"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type calendarExportFixture struct {
+	h        *Handlers
+	database *db.DB
+	signer   *models.CalendarFeedSigner
+	memberID int64
+	courtID  int64
+	facility int64
+}
+
+func setupCalendarExportTest(t *testing.T) calendarExportFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Riverside Club", "riverside", "America/Chicago",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	courtResult, err := database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+		facilityID, "Court 4", 4, "active",
+	)
+	if err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+	courtID, _ := courtResult.LastInsertId()
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+		"Cal", "Endar", "member@test.com", "active", facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	signer, err := models.NewCalendarFeedSigner("test-secret")
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+
+	return calendarExportFixture{
+		h:        NewHandlers(database, nil, Options{CalendarFeedSigner: signer}),
+		database: database,
+		signer:   signer,
+		memberID: memberID,
+		courtID:  courtID,
+		facility: facilityID,
+	}
+}
+
+func (f calendarExportFixture) insertReservation(t *testing.T, start time.Time) int64 {
+	t.Helper()
+	result, err := f.database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?, ?)`,
+		f.facility, f.memberID, f.memberID, start, start.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, _ := result.LastInsertId()
+	if _, err := f.database.Exec(
+		"INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)",
+		reservationID, f.courtID,
+	); err != nil {
+		t.Fatalf("assign court: %v", err)
+	}
+	return reservationID
+}
+
+func TestHandleMemberReservationsExport_SubscriptionFeed(t *testing.T) {
+	fixture := setupCalendarExportTest(t)
+
+	start := time.Date(time.Now().Year()+1, time.July, 10, 15, 0, 0, 0, time.UTC)
+	keptID := fixture.insertReservation(t, start)
+	cancelledID := fixture.insertReservation(t, start.Add(2*time.Hour))
+	if _, err := fixture.database.Exec(
+		`INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, fee_waived, hours_before_start)
+		 VALUES (?, ?, ?, 100, 0, 48)`,
+		cancelledID, fixture.memberID, time.Now(),
+	); err != nil {
+		t.Fatalf("cancel reservation: %v", err)
+	}
+
+	// Calendar apps fetch the feed without a session cookie.
+	target := fmt.Sprintf("/member/reservations/export.ics?member=%d&token=%s", fixture.memberID, fixture.signer.Token(fixture.memberID))
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleMemberReservationsExport(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/calendar") {
+		t.Fatalf("content type = %q", contentType)
+	}
+
+	body := recorder.Body.String()
+	for _, want := range []string{
+		fmt.Sprintf("UID:reservation-%d@pickleicious", keptID),
+		// 15:00 UTC is 10:00 CDT in July.
+		fmt.Sprintf("DTSTART;TZID=America/Chicago:%d0710T100000", start.Year()),
+		"LOCATION:Riverside Club",
+		"DESCRIPTION:Court: Court 4",
+		"SUMMARY:Court Reservation at Riverside Club",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q in feed:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, fmt.Sprintf("reservation-%d@", cancelledID)) {
+		t.Fatalf("cancelled reservation appeared in feed:\n%s", body)
+	}
+
+	recorder = httptest.NewRecorder()
+	forged := fmt.Sprintf("/member/reservations/export.ics?member=%d&token=%s", fixture.memberID, fixture.signer.Token(fixture.memberID+1))
+	fixture.h.HandleMemberReservationsExport(recorder, httptest.NewRequest(http.MethodGet, forged, nil))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("expected forged token to be rejected, got %d", recorder.Code)
+	}
+}
+
+func TestHandleMemberReservationExport_SingleReservation(t *testing.T) {
+	fixture := setupCalendarExportTest(t)
+
+	reservationID := fixture.insertReservation(t, time.Now().Add(48*time.Hour))
+
+	request := func(id int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/member/reservations/%d/export.ics", id), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", id))
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:          fixture.memberID,
+			SessionType: auth.SessionTypeMember,
+		}))
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberReservationExport(recorder, req)
+		return recorder
+	}
+
+	recorder := request(reservationID)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if disposition := recorder.Header().Get("Content-Disposition"); !strings.Contains(disposition, fmt.Sprintf("reservation-%d.ics", reservationID)) {
+		t.Fatalf("content disposition = %q", disposition)
+	}
+	if body := recorder.Body.String(); strings.Count(body, "BEGIN:VEVENT") != 1 || !strings.Contains(body, fmt.Sprintf("UID:reservation-%d@pickleicious", reservationID)) {
+		t.Fatalf("expected a single event for reservation %d:\n%s", reservationID, body)
+	}
+
+	if recorder := request(reservationID + 100); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected unknown reservation to 404, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,202 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+const defaultCancellationPolicyReservationType = "GAME"
+
+// HandleMemberCancellationPolicy handles GET /member/booking/cancellation-policy.
+func (h *Handlers) HandleMemberCancellationPolicy(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodGet {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+	facilityID := *user.HomeFacilityID
+	if rawFacilityID := strings.TrimSpace(r.URL.Query().Get("facility_id")); rawFacilityID != "" {
+		requested := requestedFacilityID(r)
+		if requested == nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid facility_id")
+			return
+		}
+		if *requested != facilityID {
+			apiutil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+			return
+		}
+	}
+
+	reservationType := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("reservation_type")))
+	if reservationType == "" {
+		reservationType = defaultCancellationPolicyReservationType
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility configuration")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+
+	startTime, err := apiutil.ParseFlexibleTime(r.URL.Query().Get("start_time"), "start_time", facilityLoc)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	resType, err := q.GetReservationTypeByName(ctx, reservationType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Unknown reservation_type")
+			return
+		}
+		logger.Error().Err(err).Str("reservation_type", reservationType).Msg("Failed to load reservation type")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load cancellation policy")
+		return
+	}
+
+	preview, err := buildCancellationPolicyPreview(ctx, q, facilityID, reservationType, resType.ID, startTime, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Str("reservation_type", reservationType).Msg("Failed to build cancellation policy preview")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load cancellation policy")
+		return
+	}
+
+	acceptHeader := strings.ToLower(r.Header.Get("Accept"))
+	wantsHTML := apiutil.IsHTMXRequest(r) || strings.Contains(acceptHeader, "text/html")
+	wantsJSON := strings.Contains(acceptHeader, "application/json")
+	if wantsHTML && (!wantsJSON || apiutil.IsHTMXRequest(r)) {
+		component := membertempl.MemberCancellationPolicyPreview(preview)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render cancellation policy preview", "Failed to render cancellation policy")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, preview); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write cancellation policy response")
+		return
+	}
+}
+
+// buildCancellationPolicyPreview turns the facility's tiers into contiguous
+// refund bands for a reservation starting at startTime. Each band's refund
+// comes from ApplicableRefundPercentage so type overrides resolve exactly as
+// they do at cancellation time.
+func buildCancellationPolicyPreview(
+	ctx context.Context,
+	q *dbgen.Queries,
+	facilityID int64,
+	reservationType string,
+	reservationTypeID int64,
+	startTime time.Time,
+	now time.Time,
+) (membertempl.CancellationPolicyPreviewData, error) {
+	preview := membertempl.CancellationPolicyPreviewData{
+		FacilityID:      facilityID,
+		ReservationType: reservationType,
+		StartTime:       startTime,
+		Tiers:           []membertempl.CancellationPolicyTier{},
+	}
+
+	rows, err := q.ListCancellationPolicyTiers(ctx, dbgen.ListCancellationPolicyTiersParams{
+		FacilityID:        facilityID,
+		ReservationTypeID: nil,
+	})
+	if err != nil {
+		return preview, err
+	}
+
+	thresholds := map[int64]struct{}{0: {}}
+	for _, row := range rows {
+		if row.ReservationTypeID.Valid && row.ReservationTypeID.Int64 != reservationTypeID {
+			continue
+		}
+		preview.HasPolicy = true
+		thresholds[row.MinHoursBefore] = struct{}{}
+	}
+
+	summary, err := reservationsvc.CancellationPolicySummary(ctx, q, facilityID, &reservationTypeID, startTime, now)
+	if err != nil {
+		return preview, err
+	}
+	preview.Summary = summary
+
+	hoursUntil := reservationsvc.HoursUntilStart(startTime, now)
+	preview.RefundPercentage, err = apiutil.ApplicableRefundPercentage(ctx, q, facilityID, hoursUntil, &reservationTypeID)
+	if err != nil {
+		return preview, err
+	}
+	if !preview.HasPolicy {
+		return preview, nil
+	}
+
+	bounds := make([]int64, 0, len(thresholds))
+	for threshold := range thresholds {
+		bounds = append(bounds, threshold)
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] > bounds[j] })
+
+	var upper *int64
+	for i, minHours := range bounds {
+		refund, err := apiutil.ApplicableRefundPercentage(ctx, q, facilityID, minHours, &reservationTypeID)
+		if err != nil {
+			return preview, err
+		}
+		if i > 0 {
+			last := &preview.Tiers[len(preview.Tiers)-1]
+			if last.RefundPercentage == refund {
+				last.MinHoursBefore = minHours
+				last.CancelBy = startTime.Add(-time.Duration(minHours) * time.Hour)
+				continue
+			}
+			upper = &bounds[i-1]
+		}
+		preview.Tiers = append(preview.Tiers, membertempl.CancellationPolicyTier{
+			MinHoursBefore:   minHours,
+			MaxHoursBefore:   upper,
+			RefundPercentage: refund,
+			CancelBy:         startTime.Add(-time.Duration(minHours) * time.Hour),
+		})
+	}
+
+	for i := range preview.Tiers {
+		tier := &preview.Tiers[i]
+		tier.Current = hoursUntil >= tier.MinHoursBefore && (tier.MaxHoursBefore == nil || hoursUntil < *tier.MaxHoursBefore)
+	}
+	return preview, nil
+}
@@ -0,0 +1,268 @@
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func setupCancellationPolicyTest(t *testing.T) (*Handlers, *db.DB, int64) {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	return NewHandlers(database, nil, Options{}), database, facilityID
+}
+
+func requestCancellationPolicy(t *testing.T, h *Handlers, homeFacilityID, facilityID int64, reservationType string, start time.Time, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+	target := fmt.Sprintf(
+		"/member/booking/cancellation-policy?facility_id=%d&reservation_type=%s&start_time=%s",
+		facilityID, reservationType, start.Format(memberBookingTimeLayout),
+	)
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("Accept", accept)
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:              1,
+		HomeFacilityID:  &homeFacilityID,
+		MembershipLevel: 2,
+	}))
+	recorder := httptest.NewRecorder()
+	h.HandleMemberCancellationPolicy(recorder, req)
+	return recorder
+}
+
+func TestHandleMemberCancellationPolicy_TypeOverride(t *testing.T) {
+	h, database, facilityID := setupCancellationPolicyTest(t)
+
+	for _, tier := range []struct {
+		reservationType string
+		minHours        int64
+		refund          int64
+	}{
+		{"", 24, 100},
+		{"", 4, 50},
+		{"", 0, 0},
+		{"GAME", 48, 100},
+		{"GAME", 0, 25},
+	} {
+		if _, err := database.Exec(
+			`INSERT INTO cancellation_policy_tiers (facility_id, reservation_type_id, min_hours_before, refund_percentage)
+			 VALUES (?, (SELECT id FROM reservation_types WHERE name = ?), ?, ?)`,
+			facilityID, tier.reservationType, tier.minHours, tier.refund,
+		); err != nil {
+			t.Fatalf("insert tier: %v", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+5, 10, 0, 0, 0, time.UTC)
+
+	recorder := requestCancellationPolicy(t, h, facilityID, facilityID, "EVENT", start, "application/json")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var preview membertempl.CancellationPolicyPreviewData
+	if err := json.Unmarshal(recorder.Body.Bytes(), &preview); err != nil {
+		t.Fatalf("decode preview: %v", err)
+	}
+	if !preview.HasPolicy || preview.RefundPercentage != 100 {
+		t.Fatalf("unexpected default preview: %+v", preview)
+	}
+	var bands []string
+	for _, tier := range preview.Tiers {
+		bands = append(bands, fmt.Sprintf("%s=%d", tier.RangeLabel(), tier.RefundPercentage))
+	}
+	if got, want := strings.Join(bands, "; "), "More than 24 hours before=100; 4–24 hours before=50; Less than 4 hours before=0"; got != want {
+		t.Fatalf("default bands = %q, want %q", got, want)
+	}
+	if !preview.Tiers[0].Current || preview.Tiers[1].Current {
+		t.Fatalf("expected only the earliest band to be current: %+v", preview.Tiers)
+	}
+	if !preview.Tiers[1].CancelBy.Equal(start.Add(-4 * time.Hour)) {
+		t.Fatalf("cancel-by = %s, want %s", preview.Tiers[1].CancelBy, start.Add(-4*time.Hour))
+	}
+
+	// GAME has its own tiers, which take precedence over the facility default.
+	recorder = requestCancellationPolicy(t, h, facilityID, facilityID, "GAME", start, "application/json")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &preview); err != nil {
+		t.Fatalf("decode preview: %v", err)
+	}
+	bands = nil
+	for _, tier := range preview.Tiers {
+		bands = append(bands, fmt.Sprintf("%s=%d", tier.RangeLabel(), tier.RefundPercentage))
+	}
+	if got, want := strings.Join(bands, "; "), "More than 48 hours before=100; Less than 48 hours before=25"; got != want {
+		t.Fatalf("GAME bands = %q, want %q", got, want)
+	}
+
+	recorder = requestCancellationPolicy(t, h, facilityID, facilityID, "GAME", start, "text/html")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	for _, want := range []string{"Cancellation policy", "Less than 48 hours before", "25% refund"} {
+		if !strings.Contains(recorder.Body.String(), want) {
+			t.Fatalf("expected %q in partial:\n%s", want, recorder.Body.String())
+		}
+	}
+}
+
+func TestHandleMemberCancellationPolicy_NoPolicy(t *testing.T) {
+	h, _, facilityID := setupCancellationPolicyTest(t)
+
+	start := time.Now().UTC().Add(72 * time.Hour)
+	recorder := requestCancellationPolicy(t, h, facilityID, facilityID, "GAME", start, "application/json")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var preview membertempl.CancellationPolicyPreviewData
+	if err := json.Unmarshal(recorder.Body.Bytes(), &preview); err != nil {
+		t.Fatalf("decode preview: %v", err)
+	}
+	if preview.HasPolicy || len(preview.Tiers) != 0 || preview.RefundPercentage != 100 {
+		t.Fatalf("unexpected preview without policy: %+v", preview)
+	}
+	if !strings.Contains(preview.Summary, "fully refundable") {
+		t.Fatalf("unexpected summary: %q", preview.Summary)
+	}
+
+	if recorder := requestCancellationPolicy(t, h, facilityID, facilityID, "NOPE", start, "application/json"); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected unknown reservation type to 400, got %d", recorder.Code)
+	}
+	if recorder := requestCancellationPolicy(t, h, facilityID, facilityID+1, "GAME", start, "application/json"); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected other facility to 403, got %d", recorder.Code)
+	}
+}
+
+func TestHandleMemberReservationCancel_PolicyEditBeforeConfirm(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	// replacePolicy swaps the default tiers in one transaction, as the policy
+	// API does.
+	replacePolicy := func(tiers ...[2]int64) {
+		t.Helper()
+		tx, err := fixture.database.Begin()
+		if err != nil {
+			t.Fatalf("begin: %v", err)
+		}
+		if _, err := tx.Exec("DELETE FROM cancellation_policy_tiers WHERE facility_id = ? AND reservation_type_id IS NULL", fixture.facilityID); err != nil {
+			t.Fatalf("delete tiers: %v", err)
+		}
+		for _, tier := range tiers {
+			if _, err := tx.Exec(
+				"INSERT INTO cancellation_policy_tiers (facility_id, min_hours_before, refund_percentage) VALUES (?, ?, ?)",
+				fixture.facilityID, tier[0], tier[1],
+			); err != nil {
+				t.Fatalf("insert tier: %v", err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+	}
+	replacePolicy([2]int64{48, 100}, [2]int64{0, 50})
+
+	recorder := fixture.book(t, fixture.courtIDs[0])
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("book status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created dbgen.Reservation
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode reservation: %v", err)
+	}
+
+	cancel := func(penalty *membertempl.CancellationPenaltyData) *httptest.ResponseRecorder {
+		t.Helper()
+		target := fmt.Sprintf("/member/reservations/%d", created.ID)
+		if penalty != nil {
+			query := url.Values{}
+			query.Set("confirm", "true")
+			query.Set("hours_before_start", fmt.Sprintf("%d", penalty.HoursBeforeStart))
+			query.Set("penalty_calculated_at", penalty.CalculatedAt.Format(time.RFC3339Nano))
+			query.Set("refund_percentage", fmt.Sprintf("%d", penalty.RefundPercentage))
+			target += "?" + query.Encode()
+		}
+		req := httptest.NewRequest(http.MethodDelete, target, nil)
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", fmt.Sprintf("%d", created.ID))
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberReservationCancel(recorder, fixture.withMember(req))
+		return recorder
+	}
+	decodePenalty := func(recorder *httptest.ResponseRecorder) membertempl.CancellationPenaltyData {
+		t.Helper()
+		if recorder.Code != http.StatusConflict {
+			t.Fatalf("expected the penalty to need confirming, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var penalty membertempl.CancellationPenaltyData
+		if err := json.Unmarshal(recorder.Body.Bytes(), &penalty); err != nil {
+			t.Fatalf("decode penalty: %v", err)
+		}
+		return penalty
+	}
+
+	shown := decodePenalty(cancel(nil))
+	if shown.RefundPercentage != 50 {
+		t.Fatalf("expected a 50%% refund, got %d", shown.RefundPercentage)
+	}
+
+	// Staff drop the refund while the member is looking at the modal. The
+	// confirmation must not go through on terms the member never saw.
+	replacePolicy([2]int64{48, 100}, [2]int64{0, 0})
+	reshown := decodePenalty(cancel(&shown))
+	if reshown.RefundPercentage != 0 {
+		t.Fatalf("expected the new terms to be shown, got %d%% refund", reshown.RefundPercentage)
+	}
+	if count := fixture.courtCount(t, created.ID); count != 1 {
+		t.Fatalf("expected the reservation to stay booked, %d courts held", count)
+	}
+
+	// An edit that leaves this reservation's band alone does not disturb a
+	// pending confirmation.
+	replacePolicy([2]int64{72, 100}, [2]int64{0, 0})
+	recorder = cancel(&reshown)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("confirm status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var result struct {
+		RefundPercentage int64 `json:"refund_percentage"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decode cancellation: %v", err)
+	}
+	if result.RefundPercentage != 0 {
+		t.Fatalf("expected the confirmed 0%% refund, got %d", result.RefundPercentage)
+	}
+}
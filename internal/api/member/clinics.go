@@ -16,6 +16,7 @@ import (
 	"github.com/codr1/Pickleicious/internal/api/authz"
 	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
 	clinictempl "github.com/codr1/Pickleicious/internal/templates/components/clinics"
 	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
 )
@@ -45,18 +46,18 @@ type memberClinicSummary struct {
 }
 
 // HandleListAvailableClinics handles GET /member/clinics.
-func HandleListAvailableClinics(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleListAvailableClinics(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -66,32 +67,25 @@ func HandleListAvailableClinics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
 	defer cancel()
 
-	facilityLoc := time.Local
-	facility, err := q.GetFacilityByID(ctx, *user.HomeFacilityID)
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, *user.HomeFacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load facility booking config")
-		http.Error(w, "Failed to load facility configuration", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility configuration")
 		return
-	} else if facility.Timezone != "" {
-		loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-		if loadErr != nil {
-			logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone")
-		} else {
-			facilityLoc = loadedLoc
-		}
 	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
 
 	sessions, err := q.ListClinicSessionsByFacility(ctx, *user.HomeFacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to list clinic sessions")
-		http.Error(w, "Failed to load clinics", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load clinics")
 		return
 	}
 
@@ -135,7 +129,7 @@ func HandleListAvailableClinics(w http.ResponseWriter, r *http.Request) {
 		})
 		if err != nil {
 			logger.Error().Err(err).Int64("clinic_session_id", session.ID).Msg("Failed to load clinic enrollments")
-			http.Error(w, "Failed to load clinics", http.StatusInternalServerError)
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load clinics")
 			return
 		}
 
@@ -218,25 +212,25 @@ func HandleListAvailableClinics(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleClinicEnroll handles POST /member/clinics/{id}/enroll.
-func HandleClinicEnroll(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleClinicEnroll(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
-	database := loadDB()
+	q := h.loadQueries()
+	database := h.loadDB()
 	if q == nil || database == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	clinicID, err := parseClinicSessionID(r)
 	if err != nil {
-		http.Error(w, "Invalid clinic ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid clinic ID")
 		return
 	}
 
@@ -246,42 +240,37 @@ func HandleClinicEnroll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
 	defer cancel()
 
-	facilityLoc := time.Local
 	maxMemberReservations := int64(0)
 	lessonMinNoticeHours := int64(0)
-	facility, err := q.GetFacilityByID(ctx, *user.HomeFacilityID)
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, *user.HomeFacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load facility booking config")
-		http.Error(w, "Failed to load facility configuration", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility configuration")
 		return
 	}
-	maxMemberReservations = facility.MaxMemberReservations
-	lessonMinNoticeHours = facility.LessonMinNoticeHours
-	if facility.Timezone != "" {
-		loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-		if loadErr != nil {
-			logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone")
-		} else {
-			facilityLoc = loadedLoc
-		}
+	tier, err := apiutil.LoadMembershipTier(ctx, q, facility.OrganizationID, user.MembershipLevel)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", facility.OrganizationID).Int64("membership_level", user.MembershipLevel).Msg("Failed to load membership tier")
+		writeFacilityConfigUnavailable(w, r)
+		return
 	}
+	maxMemberReservations = apiutil.MemberReservationLimit(tier, facility.MaxMemberReservations)
+	lessonMinNoticeHours = facility.LessonMinNoticeHours
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
 
 	var enrollment dbgen.ClinicEnrollment
 	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
 		qtx := txdb.Queries
 
 		if maxMemberReservations > 0 {
-			activeCount, err := qtx.CountActiveMemberReservations(ctx, dbgen.CountActiveMemberReservationsParams{
-				FacilityID:    *user.HomeFacilityID,
-				PrimaryUserID: sql.NullInt64{Int64: user.ID, Valid: true},
-			})
+			activeCount, err := reservationsvc.CountActiveReservations(ctx, qtx, *user.HomeFacilityID, user.ID, facility.ReservationLimitScope)
 			if err != nil {
 				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check reservation limits", Err: err}
 			}
@@ -428,14 +417,7 @@ func HandleClinicEnroll(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		var limitErr reservationLimitError
 		if errors.As(err, &limitErr) {
-			message := fmt.Sprintf("You have reached the maximum of %d active reservations", limitErr.limit)
-			if err := apiutil.WriteJSON(w, http.StatusConflict, map[string]any{
-				"error":         message,
-				"current_count": limitErr.currentCount,
-				"limit":         limitErr.limit,
-			}); err != nil {
-				logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to write clinic reservation limit response")
-			}
+			writeReservationLimitError(w, r, limitErr.currentCount, limitErr.limit)
 			return
 		}
 		var herr apiutil.HandlerError
@@ -443,15 +425,15 @@ func HandleClinicEnroll(w http.ResponseWriter, r *http.Request) {
 			if herr.Status == http.StatusInternalServerError {
 				logger.Error().Err(herr.Err).Int64("clinic_session_id", clinicID).Msg(herr.Message)
 			}
-			http.Error(w, herr.Message, herr.Status)
+			apiutil.WriteHandlerError(w, r, herr)
 			return
 		}
 		logger.Error().Err(err).Int64("clinic_session_id", clinicID).Msg("Failed to enroll in clinic")
-		http.Error(w, "Failed to enroll in clinic", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to enroll in clinic")
 		return
 	}
 
-	w.Header().Set("HX-Trigger", "refreshMemberReservations,refreshMemberClinics")
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations", "refreshMemberClinics")
 	if err := apiutil.WriteJSON(w, http.StatusCreated, enrollment); err != nil {
 		logger.Error().Err(err).Int64("clinic_session_id", clinicID).Msg("Failed to write clinic enrollment response")
 		return
@@ -459,25 +441,25 @@ func HandleClinicEnroll(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleClinicCancel handles DELETE /member/clinics/{id}/enroll.
-func HandleClinicCancel(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleClinicCancel(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
-	database := loadDB()
+	q := h.loadQueries()
+	database := h.loadDB()
 	if q == nil || database == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	clinicID, err := parseClinicSessionID(r)
 	if err != nil {
-		http.Error(w, "Invalid clinic ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid clinic ID")
 		return
 	}
 
@@ -487,27 +469,20 @@ func HandleClinicCancel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
 	defer cancel()
 
-	facilityLoc := time.Local
-	facility, err := q.GetFacilityByID(ctx, *user.HomeFacilityID)
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, *user.HomeFacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load facility booking config")
-		http.Error(w, "Failed to load facility configuration", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility configuration")
 		return
-	} else if facility.Timezone != "" {
-		loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-		if loadErr != nil {
-			logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone")
-		} else {
-			facilityLoc = loadedLoc
-		}
 	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
 
 	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
 		qtx := txdb.Queries
@@ -621,15 +596,15 @@ func HandleClinicCancel(w http.ResponseWriter, r *http.Request) {
 			if herr.Status == http.StatusInternalServerError {
 				logger.Error().Err(herr.Err).Int64("clinic_session_id", clinicID).Msg(herr.Message)
 			}
-			http.Error(w, herr.Message, herr.Status)
+			apiutil.WriteError(w, r, herr.Status, herr.Message)
 			return
 		}
 		logger.Error().Err(err).Int64("clinic_session_id", clinicID).Msg("Failed to cancel clinic enrollment")
-		http.Error(w, "Failed to cancel clinic enrollment", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to cancel clinic enrollment")
 		return
 	}
 
-	w.Header().Set("HX-Trigger", "refreshMemberReservations,refreshMemberClinics")
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations", "refreshMemberClinics")
 	w.WriteHeader(http.StatusNoContent)
 }
 
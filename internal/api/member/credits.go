@@ -0,0 +1,50 @@
+package member
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+// HandleMemberCredits handles GET /member/credits with the member's account
+// credit balance and history at their home facility.
+func (h *Handlers) HandleMemberCredits(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	account, err := reservationsvc.LoadCreditAccount(ctx, q, user.ID, *user.HomeFacilityID, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load account credit")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load account credit")
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, account); err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to write account credit response")
+		return
+	}
+}
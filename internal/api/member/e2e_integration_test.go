@@ -0,0 +1,343 @@
+//go:build integration
+// +build integration
+
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+// memberE2E serves the member portal routes under test over a real HTTP
+// server backed by an in-memory database. Requests name the signed-in member
+// with the memberIDHeader header instead of a session cookie.
+type memberE2E struct {
+	database   *db.DB
+	server     *httptest.Server
+	facilityID int64
+	courtIDs   []int64
+}
+
+const memberIDHeader = "X-Test-Member-ID"
+
+func newMemberE2E(t *testing.T) *memberE2E {
+	t.Helper()
+
+	database := testutil.NewInMemoryDB(t)
+	h := NewHandlers(database, nil, Options{})
+
+	env := &memberE2E{database: database}
+	orgID := env.exec(t, "INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	env.facilityID = env.exec(t,
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main Facility', 'main-facility', 'UTC')",
+		orgID,
+	)
+	for number := 1; number <= 2; number++ {
+		env.courtIDs = append(env.courtIDs, env.exec(t,
+			"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, 'active')",
+			env.facilityID, fmt.Sprintf("Court %d", number), number,
+		))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /member/reservations", h.HandleMemberReservationsPartial)
+	mux.HandleFunc("POST /member/reservations", h.HandleMemberBookingCreate)
+	mux.HandleFunc("DELETE /member/reservations/{id}", h.HandleMemberReservationCancel)
+	mux.HandleFunc("POST /member/openplay/{id}", h.HandleMemberOpenPlaySignup)
+	env.server = httptest.NewServer(env.withMemberHeader(mux))
+	t.Cleanup(env.server.Close)
+
+	return env
+}
+
+// withMemberHeader stands in for RequireMemberSession, loading the member
+// named by memberIDHeader into the request context.
+func (env *memberE2E) withMemberHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		memberID, err := strconv.ParseInt(r.Header.Get(memberIDHeader), 10, 64)
+		if err != nil {
+			http.Error(w, "missing member", http.StatusUnauthorized)
+			return
+		}
+		user, err := env.database.Queries.GetUserByID(r.Context(), memberID)
+		if err != nil {
+			http.Error(w, "unknown member", http.StatusUnauthorized)
+			return
+		}
+		homeFacilityID := user.HomeFacilityID.Int64
+		next.ServeHTTP(w, r.WithContext(authz.ContextWithUser(r.Context(), &authz.AuthUser{
+			ID:              user.ID,
+			HomeFacilityID:  &homeFacilityID,
+			MembershipLevel: user.MembershipLevel,
+		})))
+	})
+}
+
+func (env *memberE2E) exec(t *testing.T, query string, args ...any) int64 {
+	t.Helper()
+	result, err := env.database.Exec(query, args...)
+	if err != nil {
+		t.Fatalf("exec %q: %v", query, err)
+	}
+	id, _ := result.LastInsertId()
+	return id
+}
+
+func (env *memberE2E) count(t *testing.T, query string, args ...any) int {
+	t.Helper()
+	var n int
+	if err := env.database.QueryRow(query, args...).Scan(&n); err != nil {
+		t.Fatalf("count %q: %v", query, err)
+	}
+	return n
+}
+
+func (env *memberE2E) addMember(t *testing.T, email string) int64 {
+	t.Helper()
+	return env.exec(t,
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES ('Test', 'Member', ?, 'active', 1, 1, 2, ?)`,
+		email, env.facilityID,
+	)
+}
+
+type e2eResponse struct {
+	status int
+	header http.Header
+	body   string
+}
+
+// do sends a request as memberID and fails the test if it cannot be sent.
+func (env *memberE2E) do(t *testing.T, memberID int64, method, path string, form url.Values, headers map[string]string) e2eResponse {
+	t.Helper()
+	resp, err := env.send(memberID, method, path, form, headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// send sends a request as memberID. Form values go in the body for POST and
+// in the query string otherwise, as htmx sends them.
+func (env *memberE2E) send(memberID int64, method, path string, form url.Values, headers map[string]string) (e2eResponse, error) {
+	var body io.Reader
+	if len(form) > 0 {
+		if method == http.MethodPost {
+			body = strings.NewReader(form.Encode())
+		} else {
+			separator := "?"
+			if strings.Contains(path, "?") {
+				separator = "&"
+			}
+			path += separator + form.Encode()
+		}
+	}
+	req, err := http.NewRequest(method, env.server.URL+path, body)
+	if err != nil {
+		return e2eResponse{}, fmt.Errorf("new request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Set(memberIDHeader, strconv.FormatInt(memberID, 10))
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := env.server.Client().Do(req)
+	if err != nil {
+		return e2eResponse{}, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return e2eResponse{}, fmt.Errorf("read %s %s: %w", method, path, err)
+	}
+	return e2eResponse{status: resp.StatusCode, header: resp.Header, body: string(raw)}, nil
+}
+
+func (env *memberE2E) book(t *testing.T, memberID int64, start time.Time) dbgen.Reservation {
+	t.Helper()
+	form := url.Values{
+		"facility_id": {strconv.FormatInt(env.facilityID, 10)},
+		"start_time":  {start.Format(memberBookingTimeLayout)},
+		"end_time":    {start.Add(time.Hour).Format(memberBookingTimeLayout)},
+		"court_ids":   {strconv.FormatInt(env.courtIDs[0], 10)},
+	}
+	resp := env.do(t, memberID, http.MethodPost, "/member/reservations", form, nil)
+	if resp.status != http.StatusCreated {
+		t.Fatalf("book status %d: %s", resp.status, resp.body)
+	}
+	var created dbgen.Reservation
+	if err := json.Unmarshal([]byte(resp.body), &created); err != nil {
+		t.Fatalf("decode reservation: %v", err)
+	}
+	return created
+}
+
+func TestMemberE2E_BookingHappyPath(t *testing.T) {
+	env := newMemberE2E(t)
+	memberID := env.addMember(t, "booker@test.com")
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+2, 10, 0, 0, 0, time.UTC)
+	created := env.book(t, memberID, start)
+
+	if created.PrimaryUserID.Int64 != memberID || !created.StartTime.Equal(start) {
+		t.Fatalf("unexpected reservation: %+v", created)
+	}
+	if got := env.count(t, "SELECT COUNT(*) FROM reservation_courts WHERE reservation_id = ? AND court_id = ?", created.ID, env.courtIDs[0]); got != 1 {
+		t.Fatalf("expected the booked court on the reservation, got %d rows", got)
+	}
+	if got := env.count(t, "SELECT COUNT(*) FROM reservation_participants WHERE reservation_id = ? AND user_id = ?", created.ID, memberID); got != 1 {
+		t.Fatalf("expected the member as a participant, got %d rows", got)
+	}
+
+	list := env.do(t, memberID, http.MethodGet, "/member/reservations", nil, map[string]string{"HX-Request": "true"})
+	if list.status != http.StatusOK {
+		t.Fatalf("list status %d: %s", list.status, list.body)
+	}
+	if !strings.Contains(list.body, fmt.Sprintf("/member/reservations/%d", created.ID)) {
+		t.Fatalf("expected the new booking in the member's reservations:\n%s", list.body)
+	}
+}
+
+var hiddenInputPattern = regexp.MustCompile(`name="(hours_before_start|penalty_calculated_at|refund_percentage)" value="([^"]+)"`)
+
+func TestMemberE2E_CancellationPenaltyModalFlow(t *testing.T) {
+	env := newMemberE2E(t)
+	memberID := env.addMember(t, "canceller@test.com")
+	env.exec(t,
+		"INSERT INTO cancellation_policy_tiers (facility_id, min_hours_before, refund_percentage) VALUES (?, 48, 100), (?, 0, 50)",
+		env.facilityID, env.facilityID,
+	)
+
+	// Tomorrow morning is always inside 48 hours, so cancelling forfeits half.
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.UTC)
+	created := env.book(t, memberID, start)
+	cancelPath := fmt.Sprintf("/member/reservations/%d", created.ID)
+
+	modal := env.do(t, memberID, http.MethodDelete, cancelPath, nil, map[string]string{"HX-Request": "true"})
+	if modal.status != http.StatusConflict {
+		t.Fatalf("expected the penalty modal, got %d: %s", modal.status, modal.body)
+	}
+	if modal.header.Get("HX-Retarget") != "#modal" || !strings.Contains(modal.body, "50% fee applies.") {
+		t.Fatalf("unexpected modal response (retarget %q):\n%s", modal.header.Get("HX-Retarget"), modal.body)
+	}
+	if got := env.count(t, "SELECT COUNT(*) FROM reservation_cancellations WHERE reservation_id = ?", created.ID); got != 0 {
+		t.Fatalf("expected the reservation to stay booked until confirmed, got %d cancellations", got)
+	}
+
+	// Submit the modal's form the way htmx does.
+	confirm := url.Values{}
+	for _, match := range hiddenInputPattern.FindAllStringSubmatch(modal.body, -1) {
+		confirm.Set(match[1], match[2])
+	}
+	if len(confirm) != 3 {
+		t.Fatalf("expected the modal to carry the penalty details, got %v", confirm)
+	}
+	resp := env.do(t, memberID, http.MethodDelete, cancelPath+"?confirm=true", confirm, map[string]string{"HX-Request": "true"})
+	if resp.status != http.StatusOK {
+		t.Fatalf("confirm status %d: %s", resp.status, resp.body)
+	}
+	var result struct {
+		RefundPercentage int64 `json:"refund_percentage"`
+	}
+	if err := json.Unmarshal([]byte(resp.body), &result); err != nil {
+		t.Fatalf("decode cancellation: %v", err)
+	}
+	if result.RefundPercentage != 50 {
+		t.Fatalf("expected a 50%% refund, got %d", result.RefundPercentage)
+	}
+	if got := env.count(t, "SELECT COUNT(*) FROM reservation_cancellations WHERE reservation_id = ?", created.ID); got != 1 {
+		t.Fatalf("expected one cancellation record, got %d", got)
+	}
+}
+
+func TestMemberE2E_OpenPlayLastSpotRace(t *testing.T) {
+	env := newMemberE2E(t)
+
+	ruleID := env.exec(t,
+		`INSERT INTO open_play_rules (facility_id, name, min_participants, max_participants_per_court, cancellation_cutoff_minutes, min_courts, max_courts)
+		 VALUES (?, 'Evening Open Play', 2, 4, 60, 1, 1)`,
+		env.facilityID,
+	)
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 18, 0, 0, 0, time.UTC)
+	sessionID := env.exec(t,
+		`INSERT INTO open_play_sessions (facility_id, open_play_rule_id, start_time, end_time, status, current_court_count)
+		 VALUES (?, ?, ?, ?, 'scheduled', 1)`,
+		env.facilityID, ruleID, start, start.Add(2*time.Hour),
+	)
+	staffID := env.exec(t,
+		"INSERT INTO users (first_name, last_name, email, status, is_staff, staff_role, home_facility_id) VALUES ('Desk', 'Staff', 'desk@test.com', 'active', 1, 'desk', ?)",
+		env.facilityID,
+	)
+	// Sessions are backed by an OPEN_PLAY reservation that signups join.
+	env.exec(t,
+		`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, open_play_rule_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'OPEN_PLAY'), ?, ?, ?, ?)`,
+		env.facilityID, staffID, ruleID, start, start.Add(2*time.Hour),
+	)
+	signupPath := fmt.Sprintf("/member/openplay/%d", sessionID)
+
+	// Three of the four spots go first.
+	for i := 1; i <= 3; i++ {
+		memberID := env.addMember(t, fmt.Sprintf("early%d@test.com", i))
+		if resp := env.do(t, memberID, http.MethodPost, signupPath, nil, nil); resp.status != http.StatusCreated {
+			t.Fatalf("signup %d status %d: %s", i, resp.status, resp.body)
+		}
+	}
+
+	racers := []int64{env.addMember(t, "racer1@test.com"), env.addMember(t, "racer2@test.com")}
+	responses := make([]e2eResponse, len(racers))
+	var wg sync.WaitGroup
+	for i, memberID := range racers {
+		wg.Add(1)
+		go func(i int, memberID int64) {
+			defer wg.Done()
+			resp, err := env.send(memberID, http.MethodPost, signupPath, nil, nil)
+			if err != nil {
+				t.Error(err)
+			}
+			responses[i] = resp
+		}(i, memberID)
+	}
+	wg.Wait()
+
+	statuses := map[int]int{}
+	for _, resp := range responses {
+		statuses[resp.status]++
+		if resp.status == http.StatusConflict && !strings.Contains(resp.body, "Session is full") {
+			t.Fatalf("unexpected conflict message: %s", resp.body)
+		}
+	}
+	if statuses[http.StatusCreated] != 1 || statuses[http.StatusConflict] != 1 {
+		t.Fatalf("expected one signup to win the last spot and one to conflict, got %v", statuses)
+	}
+	participants := env.count(t,
+		`SELECT COUNT(*) FROM reservation_participants rp
+		 JOIN reservations r ON r.id = rp.reservation_id
+		 WHERE r.open_play_rule_id = ? AND r.start_time = ?`,
+		ruleID, start,
+	)
+	if participants != 4 {
+		t.Fatalf("expected the session to end at capacity with 4 participants, got %d", participants)
+	}
+}
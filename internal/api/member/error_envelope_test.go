@@ -0,0 +1,113 @@
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestMemberJSONErrorsUseEnvelope(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+		"Envelope", "Member", "envelope@test.com", "active", facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	leagueResult, err := database.Exec(
+		`INSERT INTO leagues (facility_id, name, format, start_date, end_date, division_config, min_team_size, max_team_size, status)
+		 VALUES (?, 'Mixed Mondays', 'mixed_doubles', '2027-06-01', '2027-08-01', '{}', 2, 4, 'registration')`,
+		facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert league: %v", err)
+	}
+	leagueID, _ := leagueResult.LastInsertId()
+
+	h := NewHandlers(database, nil, Options{})
+
+	call := func(handler http.HandlerFunc, path, id string, htmx bool) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodDelete, path, nil)
+		req.Header.Set("Accept", "application/json")
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		req.SetPathValue("id", id)
+		homeFacilityID := facilityID
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              memberID,
+			HomeFacilityID:  &homeFacilityID,
+			MembershipLevel: 2,
+		}))
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+	expectEnvelope := func(recorder *httptest.ResponseRecorder, status int, code, message string) {
+		t.Helper()
+		if recorder.Code != status {
+			t.Fatalf("got %d %q, want %d", recorder.Code, recorder.Body.String(), status)
+		}
+		if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/json") {
+			t.Fatalf("expected JSON content type, got %q", contentType)
+		}
+		var envelope struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &envelope); err != nil {
+			t.Fatalf("decode error envelope: %v (%q)", err, recorder.Body.String())
+		}
+		if envelope.Error.Code != code || envelope.Error.Message != message {
+			t.Fatalf("unexpected error envelope: %s", recorder.Body.String())
+		}
+	}
+
+	expectEnvelope(call(h.HandleClinicCancel, "/member/clinics/abc", "abc", false),
+		http.StatusBadRequest, "bad_request", "Invalid clinic ID")
+	expectEnvelope(call(h.HandleClinicCancel, "/member/clinics/999", "999", false),
+		http.StatusNotFound, "not_found", "Clinic session not found")
+
+	leaguePath := fmt.Sprintf("/member/leagues/%d/free-agent", leagueID)
+	leagueIDValue := fmt.Sprintf("%d", leagueID)
+	expectEnvelope(call(h.HandleLeagueFreeAgentWithdraw, leaguePath, leagueIDValue, false),
+		http.StatusNotFound, "not_found", "Free agent registration not found")
+
+	// HTMX swaps the message into the page, so it stays plain text.
+	plain := call(h.HandleLeagueFreeAgentWithdraw, leaguePath, leagueIDValue, true)
+	if plain.Code != http.StatusNotFound || strings.TrimSpace(plain.Body.String()) != "Free agent registration not found" {
+		t.Fatalf("expected plain HTMX error, got %d %q", plain.Code, plain.Body.String())
+	}
+}
@@ -0,0 +1,352 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+// HandleMemberOpenEvents handles GET /member/events.
+func (h *Handlers) HandleMemberOpenEvents(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodGet {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, *user.HomeFacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load facility")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility configuration")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+
+	rows, err := q.ListUpcomingOpenEvents(ctx, dbgen.ListUpcomingOpenEventsParams{
+		UserID:         user.ID,
+		FacilityID:     *user.HomeFacilityID,
+		ComparisonTime: time.Now(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to list open events")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load events")
+		return
+	}
+
+	data := membertempl.OpenEventListData{Upcoming: make([]membertempl.OpenEventSummary, 0, len(rows))}
+	for _, row := range rows {
+		data.Upcoming = append(data.Upcoming, newOpenEventSummary(dbgen.GetOpenEventRow(row), facilityLoc))
+	}
+
+	acceptHeader := strings.ToLower(r.Header.Get("Accept"))
+	wantsHTML := apiutil.IsHTMXRequest(r) || strings.Contains(acceptHeader, "text/html")
+	wantsJSON := strings.Contains(acceptHeader, "application/json")
+	if wantsHTML && (!wantsJSON || apiutil.IsHTMXRequest(r)) {
+		component := membertempl.MemberOpenEvents(data)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render open events", "Failed to render events")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, data); err != nil {
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to write open events response")
+	}
+}
+
+// HandleMemberOpenEventSignup handles POST /member/events/{id}/signup.
+func (h *Handlers) HandleMemberOpenEventSignup(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodPost {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	eventID, err := memberOpenEventIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, *user.HomeFacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load facility booking config")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility configuration")
+		return
+	}
+
+	tier, err := apiutil.LoadMembershipTier(ctx, q, facility.OrganizationID, user.MembershipLevel)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", facility.OrganizationID).Int64("membership_level", user.MembershipLevel).Msg("Failed to load membership tier")
+		writeFacilityConfigUnavailable(w, r)
+		return
+	}
+	maxMemberReservations := apiutil.MemberReservationLimit(tier, facility.MaxMemberReservations)
+
+	if !h.ensureNoNoShowRestriction(ctx, w, r, q, *user.HomeFacilityID, user.ID) {
+		return
+	}
+
+	var event dbgen.GetOpenEventRow
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		if maxMemberReservations > 0 {
+			activeCount, err := reservationsvc.CountActiveReservations(ctx, qtx, *user.HomeFacilityID, user.ID, facility.ReservationLimitScope)
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check reservation limits", Err: err}
+			}
+			if activeCount >= maxMemberReservations {
+				return reservationLimitError{currentCount: activeCount, limit: maxMemberReservations}
+			}
+		}
+
+		event, err = loadOpenEvent(ctx, qtx, eventID, *user.HomeFacilityID, user.ID)
+		if err != nil {
+			return err
+		}
+		if event.IsSignedUp != 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Already signed up"}
+		}
+		capacity := openEventCapacity(event)
+		if capacity > 0 && event.SignupCount >= capacity {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Event is full"}
+		}
+		if err := reservationsvc.EnsureNoMemberOverlap(ctx, qtx, user.ID, event.StartTime, event.EndTime); err != nil {
+			return err
+		}
+
+		if err := qtx.AddParticipant(ctx, dbgen.AddParticipantParams{
+			ReservationID: event.ID,
+			UserID:        user.ID,
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to sign up for event", Err: err}
+		}
+
+		// Recount inside the transaction so two members racing for the
+		// last spot cannot both keep it.
+		event.SignupCount, err = qtx.CountReservationParticipants(ctx, event.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to verify event capacity", Err: err}
+		}
+		if capacity > 0 && event.SignupCount > capacity {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Event is full"}
+		}
+		event.IsSignedUp = 1
+		return nil
+	})
+	if err != nil {
+		var limitErr reservationLimitError
+		if errors.As(err, &limitErr) {
+			message := fmt.Sprintf("You have reached the maximum of %d active reservations", limitErr.limit)
+			apiutil.WriteError(w, r, http.StatusConflict, message)
+			return
+		}
+		var overlapErr reservationsvc.MemberOverlapError
+		if errors.As(err, &overlapErr) {
+			writeMemberOverlapError(w, r, overlapErr)
+			return
+		}
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("event_id", eventID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("event_id", eventID).Msg("Failed to sign up for event")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to sign up for event")
+		return
+	}
+
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations", "refreshMemberEvents")
+	summary := newOpenEventSummary(event, apiutil.FacilityLocation(facility, logger))
+	if err := apiutil.WriteJSON(w, http.StatusCreated, summary); err != nil {
+		logger.Error().Err(err).Int64("event_id", eventID).Msg("Failed to write event signup response")
+	}
+}
+
+// HandleMemberOpenEventWithdraw handles DELETE /member/events/{id}/signup.
+func (h *Handlers) HandleMemberOpenEventWithdraw(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodDelete {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	database := h.loadDB()
+	if database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	eventID, err := memberOpenEventIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		event, err := loadOpenEvent(ctx, qtx, eventID, *user.HomeFacilityID, user.ID)
+		if err != nil {
+			return err
+		}
+		if event.IsSignedUp == 0 {
+			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Event signup not found"}
+		}
+		if err := qtx.RemoveParticipant(ctx, dbgen.RemoveParticipantParams{
+			ReservationID: event.ID,
+			UserID:        user.ID,
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to withdraw from event", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("event_id", eventID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("event_id", eventID).Msg("Failed to withdraw from event")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to withdraw from event")
+		return
+	}
+
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations", "refreshMemberEvents")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadOpenEvent loads an upcoming open event at the facility, failing with
+// 404 for unknown events and 400 once the event has started.
+func loadOpenEvent(ctx context.Context, q *dbgen.Queries, eventID, facilityID, userID int64) (dbgen.GetOpenEventRow, error) {
+	event, err := q.GetOpenEvent(ctx, dbgen.GetOpenEventParams{
+		UserID:     userID,
+		ID:         eventID,
+		FacilityID: facilityID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return event, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Event not found", Err: err}
+		}
+		return event, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch event", Err: err}
+	}
+	if !event.StartTime.After(time.Now()) {
+		return event, apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Event must be in the future"}
+	}
+	return event, nil
+}
+
+// openEventCapacity is teams per court × people per team × courts, or zero
+// when the event has no team sizes and so no limit.
+func openEventCapacity(event dbgen.GetOpenEventRow) int64 {
+	if !event.TeamsPerCourt.Valid || !event.PeoplePerTeam.Valid {
+		return 0
+	}
+	return event.TeamsPerCourt.Int64 * event.PeoplePerTeam.Int64 * event.CourtCount
+}
+
+func newOpenEventSummary(event dbgen.GetOpenEventRow, loc *time.Location) membertempl.OpenEventSummary {
+	summary := membertempl.OpenEventSummary{
+		ID:          event.ID,
+		Name:        email.ReservationTypeLabel(event.ReservationType),
+		StartTime:   event.StartTime.In(loc),
+		EndTime:     event.EndTime.In(loc),
+		CourtCount:  event.CourtCount,
+		Capacity:    openEventCapacity(event),
+		SignupCount: event.SignupCount,
+		IsSignedUp:  event.IsSignedUp != 0,
+	}
+	if event.TeamsPerCourt.Valid {
+		summary.TeamsPerCourt = &event.TeamsPerCourt.Int64
+	}
+	if event.PeoplePerTeam.Valid {
+		summary.PeoplePerTeam = &event.PeoplePerTeam.Int64
+	}
+	return summary
+}
+
+func memberOpenEventIDFromRequest(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid event ID")
+	}
+	return id, nil
+}
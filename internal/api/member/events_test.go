@@ -0,0 +1,152 @@
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestMemberOpenEvents_SignupEnforcesCapacity(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.Exec(query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main Facility', 'main-facility', 'UTC')", orgID)
+	otherFacilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Other Facility', 'other-facility', 'UTC')", orgID)
+	courtID := exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 1', 1, 'active')", facilityID)
+	otherCourtID := exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 1', 1, 'active')", otherFacilityID)
+
+	insertMember := func(firstName string) int64 {
+		t.Helper()
+		return exec(
+			`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+			 VALUES (?, 'Player', ?, 'active', 1, 1, 2, ?)`,
+			firstName, strings.ToLower(firstName)+"@test.com", facilityID,
+		)
+	}
+	aliceID := insertMember("Alice")
+	bobID := insertMember("Bob")
+	caseyID := insertMember("Casey")
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 18, 0, 0, 0, time.UTC)
+	insertEvent := func(facilityID, courtID int64, start time.Time, teamsPerCourt, peoplePerTeam any) int64 {
+		t.Helper()
+		id := exec(
+			`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, start_time, end_time, is_open_event, teams_per_court, people_per_team)
+			 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'EVENT'), ?, ?, ?, 1, ?, ?)`,
+			facilityID, aliceID, start, start.Add(2*time.Hour), teamsPerCourt, peoplePerTeam,
+		)
+		exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", id, courtID)
+		return id
+	}
+	// One court of one two-player team holds two signups.
+	eventID := insertEvent(facilityID, courtID, start, 1, 2)
+	pastEventID := insertEvent(facilityID, courtID, now.Add(-3*time.Hour), 1, 2)
+	otherEventID := insertEvent(otherFacilityID, otherCourtID, start, 2, 2)
+
+	h := NewHandlers(database, nil, Options{})
+
+	serve := func(method string, eventID, userID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		target := "/member/events"
+		if eventID != 0 {
+			target = fmt.Sprintf("/member/events/%d/signup", eventID)
+		}
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Accept", "application/json")
+		req.SetPathValue("id", fmt.Sprintf("%d", eventID))
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              userID,
+			HomeFacilityID:  &facilityID,
+			MembershipLevel: 2,
+		}))
+		recorder := httptest.NewRecorder()
+		switch method {
+		case http.MethodGet:
+			h.HandleMemberOpenEvents(recorder, req)
+		case http.MethodPost:
+			h.HandleMemberOpenEventSignup(recorder, req)
+		case http.MethodDelete:
+			h.HandleMemberOpenEventWithdraw(recorder, req)
+		}
+		return recorder
+	}
+	list := func(userID int64) []membertempl.OpenEventSummary {
+		t.Helper()
+		recorder := serve(http.MethodGet, 0, userID)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("list status %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var data membertempl.OpenEventListData
+		if err := json.Unmarshal(recorder.Body.Bytes(), &data); err != nil {
+			t.Fatalf("decode events: %v", err)
+		}
+		return data.Upcoming
+	}
+
+	events := list(aliceID)
+	if len(events) != 1 || events[0].ID != eventID {
+		t.Fatalf("expected only the upcoming event at the home facility, got %+v", events)
+	}
+	if events[0].Capacity != 2 || events[0].SignupCount != 0 || events[0].Name != "Event" {
+		t.Fatalf("unexpected event summary %+v", events[0])
+	}
+
+	for _, userID := range []int64{aliceID, bobID} {
+		if recorder := serve(http.MethodPost, eventID, userID); recorder.Code != http.StatusCreated {
+			t.Fatalf("signup status %d: %s", recorder.Code, recorder.Body.String())
+		}
+	}
+	if recorder := serve(http.MethodPost, eventID, aliceID); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected a repeat signup to conflict, got %d", recorder.Code)
+	}
+	if recorder := serve(http.MethodPost, eventID, caseyID); recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), "Event is full") {
+		t.Fatalf("expected the full event to reject Casey, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if events := list(caseyID); events[0].SignupCount != 2 || events[0].IsSignedUp || !events[0].IsFull() {
+		t.Fatalf("expected Casey to see a full event, got %+v", events[0])
+	}
+
+	if recorder := serve(http.MethodDelete, eventID, aliceID); recorder.Code != http.StatusNoContent {
+		t.Fatalf("withdraw status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := serve(http.MethodDelete, eventID, aliceID); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected a second withdrawal to 404, got %d", recorder.Code)
+	}
+	recorder := serve(http.MethodPost, eventID, caseyID)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected the freed spot to go to Casey, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var signup membertempl.OpenEventSummary
+	if err := json.Unmarshal(recorder.Body.Bytes(), &signup); err != nil {
+		t.Fatalf("decode signup: %v", err)
+	}
+	if !signup.IsSignedUp || signup.SignupCount != 2 {
+		t.Fatalf("unexpected signup response %+v", signup)
+	}
+
+	if recorder := serve(http.MethodPost, pastEventID, aliceID); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected a started event to reject signups, got %d", recorder.Code)
+	}
+	if recorder := serve(http.MethodPost, otherEventID, aliceID); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected another facility's event to be hidden, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,110 @@
+package member
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// breakTable renames a table out from under the handlers so every query
+// against it fails the way a locked or corrupt database would.
+func breakTable(t *testing.T, f memberBookingFixture, table string) {
+	t.Helper()
+	if _, err := f.database.Exec(fmt.Sprintf("ALTER TABLE %s RENAME TO %s_unavailable", table, table)); err != nil {
+		t.Fatalf("rename %s: %v", table, err)
+	}
+}
+
+func (f memberBookingFixture) reservationCount(t *testing.T) int {
+	t.Helper()
+	var count int
+	if err := f.database.QueryRow("SELECT COUNT(*) FROM reservations").Scan(&count); err != nil {
+		t.Fatalf("count reservations: %v", err)
+	}
+	return count
+}
+
+func assertFacilityConfigUnavailable(t *testing.T, recorder *httptest.ResponseRecorder) {
+	t.Helper()
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected a Retry-After header")
+	}
+}
+
+func TestHandleMemberBookingCreate_FailsClosedWhenFacilityConfigUnavailable(t *testing.T) {
+	for _, table := range []string{"facilities", "member_tier_booking_windows"} {
+		t.Run(table, func(t *testing.T) {
+			fixture := setupMemberBookingTest(t, 1)
+			if _, err := fixture.database.Exec("UPDATE facilities SET tier_booking_enabled = 1 WHERE id = ?", fixture.facilityID); err != nil {
+				t.Fatalf("enable tier booking: %v", err)
+			}
+			breakTable(t, fixture, table)
+
+			assertFacilityConfigUnavailable(t, fixture.book(t, fixture.courtIDs[0]))
+			if count := fixture.reservationCount(t); count != 0 {
+				t.Fatalf("expected no reservation to be created, got %d", count)
+			}
+		})
+	}
+}
+
+func TestHandleMemberBookingForm_FailsClosedWhenFacilityConfigUnavailable(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+	breakTable(t, fixture, "facilities")
+
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleMemberBookingFormNew(recorder, fixture.withMember(httptest.NewRequest(http.MethodGet, "/member/booking/new", nil)))
+	assertFacilityConfigUnavailable(t, recorder)
+
+	recorder = httptest.NewRecorder()
+	date := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	fixture.h.HandleMemberBookingSlots(recorder, fixture.withMember(httptest.NewRequest(http.MethodGet, "/member/booking/slots?date="+date, nil)))
+	assertFacilityConfigUnavailable(t, recorder)
+}
+
+func TestHandleMemberOpenPlaySignup_FailsClosedWhenFacilityConfigUnavailable(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	ruleResult, err := fixture.database.Exec(
+		`INSERT INTO open_play_rules (facility_id, name, min_participants, max_participants_per_court, cancellation_cutoff_minutes, min_courts, max_courts)
+		 VALUES (?, 'Ladder', 4, 8, 60, 1, 2)`,
+		fixture.facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert rule: %v", err)
+	}
+	ruleID, _ := ruleResult.LastInsertId()
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 18, 0, 0, 0, time.UTC)
+	sessionResult, err := fixture.database.Exec(
+		`INSERT INTO open_play_sessions (facility_id, open_play_rule_id, start_time, end_time, status, current_court_count)
+		 VALUES (?, ?, ?, ?, 'scheduled', 1)`,
+		fixture.facilityID, ruleID, start, start.Add(2*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	sessionID, _ := sessionResult.LastInsertId()
+
+	breakTable(t, fixture, "facilities")
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/member/openplay/%d", sessionID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", sessionID))
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleMemberOpenPlaySignup(recorder, fixture.withMember(req))
+	assertFacilityConfigUnavailable(t, recorder)
+
+	var participants int
+	if err := fixture.database.QueryRow("SELECT COUNT(*) FROM reservation_participants WHERE user_id = ?", fixture.memberID).Scan(&participants); err != nil {
+		t.Fatalf("count participants: %v", err)
+	}
+	if participants != 0 {
+		t.Fatalf("expected no participant rows, got %d", participants)
+	}
+}
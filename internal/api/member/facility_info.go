@@ -0,0 +1,272 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/announcements"
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/models"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+	"github.com/codr1/Pickleicious/internal/templates/layouts"
+)
+
+// facilityInfoDays is how many days of hours the facility info page shows,
+// starting today.
+const facilityInfoDays = 7
+
+// HandleMemberFacilityInfo handles GET /member/facility for the member's home
+// facility.
+func (h *Handlers) HandleMemberFacilityInfo(w http.ResponseWriter, r *http.Request) {
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+	h.serveFacilityInfo(w, r, user, *user.HomeFacilityID)
+}
+
+// HandleMemberFacilityInfoByID handles GET /member/facilities/{id}, letting a
+// member look up another facility of their organization before playing there.
+func (h *Handlers) HandleMemberFacilityInfoByID(w http.ResponseWriter, r *http.Request) {
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+	facilityID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || facilityID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid facility ID")
+		return
+	}
+	h.serveFacilityInfo(w, r, user, facilityID)
+}
+
+func (h *Handlers) serveFacilityInfo(w http.ResponseWriter, r *http.Request, user *authz.AuthUser, facilityID int64) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodGet {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	homeFacility, err := h.loadFacilities().GetFacilityByID(ctx, *user.HomeFacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load home facility")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility")
+		return
+	}
+	facility := homeFacility
+	if facilityID != homeFacility.ID {
+		facility, err = h.loadFacilities().GetFacilityByID(ctx, facilityID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				apiutil.WriteError(w, r, http.StatusNotFound, "Facility not found")
+				return
+			}
+			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility")
+			return
+		}
+		// Members may only see facilities of their own organization.
+		if facility.OrganizationID != homeFacility.OrganizationID {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Facility not found")
+			return
+		}
+	}
+
+	data, err := buildFacilityInfo(ctx, q, facility, user.ID, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facility.ID).Msg("Failed to build facility info")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility")
+		return
+	}
+	data.IsHome = facility.ID == homeFacility.ID
+
+	w.Header().Set("Cache-Control", "no-store")
+	acceptHeader := strings.ToLower(r.Header.Get("Accept"))
+	wantsHTML := apiutil.IsHTMXRequest(r) || strings.Contains(acceptHeader, "text/html")
+	wantsJSON := strings.Contains(acceptHeader, "application/json")
+	if !wantsHTML || (wantsJSON && !apiutil.IsHTMXRequest(r)) {
+		if err := apiutil.WriteJSON(w, http.StatusOK, data); err != nil {
+			logger.Error().Err(err).Int64("facility_id", facility.ID).Msg("Failed to write facility info response")
+		}
+		return
+	}
+
+	siblings, err := q.ListFacilitiesByOrganization(ctx, homeFacility.OrganizationID)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", homeFacility.OrganizationID).Msg("Failed to list organization facilities")
+	}
+	for _, sibling := range siblings {
+		data.Facilities = append(data.Facilities, membertempl.FacilityInfoOption{ID: sibling.ID, Name: sibling.Name})
+	}
+
+	if apiutil.IsHTMXRequest(r) {
+		component := membertempl.MemberFacilityInfo(data)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render facility info", "Failed to render facility info")
+		return
+	}
+
+	activeTheme, err := models.GetActiveTheme(ctx, q, homeFacility.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", homeFacility.ID).Msg("Failed to load active theme")
+		activeTheme = nil
+	}
+	page := layouts.Base(membertempl.MemberFacilityInfoPage(data), activeTheme, user.SessionType)
+	if err := page.Render(r.Context(), w); err != nil {
+		logger.Error().Err(err).Msg("Failed to render facility info")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render page")
+		return
+	}
+}
+
+// buildFacilityInfo composes the facility's contact details, hours for the
+// week starting today, active courts and current member announcements. Open
+// or closed status is worked out here in facility time so clients need no
+// timezone logic.
+func buildFacilityInfo(ctx context.Context, q *dbgen.Queries, facility dbgen.Facility, userID int64, now time.Time) (membertempl.FacilityInfoData, error) {
+	loc := apiutil.FacilityLocation(facility, log.Ctx(ctx))
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	data := membertempl.FacilityInfoData{
+		FacilityID:    facility.ID,
+		Name:          facility.Name,
+		Address:       facility.Address.String,
+		Phone:         facility.Phone.String,
+		Timezone:      loc.String(),
+		Hours:         make([]membertempl.FacilityInfoDay, 0, facilityInfoDays),
+		Courts:        []membertempl.FacilityInfoCourt{},
+		Announcements: []membertempl.FacilityInfoAnnouncement{},
+	}
+
+	hours, err := q.GetFacilityHours(ctx, facility.ID)
+	if err != nil {
+		return data, fmt.Errorf("load operating hours: %w", err)
+	}
+	overrideRows, err := q.ListFacilityHoursOverrides(ctx, dbgen.ListFacilityHoursOverridesParams{
+		FacilityID: facility.ID,
+		FromDate:   today.Format(apiutil.HoursOverrideDateLayout),
+	})
+	if err != nil {
+		return data, fmt.Errorf("list hours overrides: %w", err)
+	}
+	overrides := make(map[string]dbgen.FacilityHoursOverride, len(overrideRows))
+	for _, override := range overrideRows {
+		overrides[override.OverrideDate] = override
+	}
+
+	for i := range facilityInfoDays {
+		day := today.AddDate(0, 0, i)
+		entry := membertempl.FacilityInfoDay{
+			Date:    day.Format(apiutil.HoursOverrideDateLayout),
+			Weekday: day.Weekday().String(),
+			Today:   i == 0,
+		}
+		var override *dbgen.FacilityHoursOverride
+		if row, ok := overrides[entry.Date]; ok {
+			override = &row
+			entry.Override = true
+			entry.Note = row.Reason.String
+		}
+		if opensAt, closesAt, open := apiutil.OperatingHoursWindow(day, hours, override); open {
+			entry.Open = true
+			entry.OpensAt = &opensAt
+			entry.ClosesAt = &closesAt
+		}
+		data.Hours = append(data.Hours, entry)
+	}
+	data.OpenNow, data.Status = facilityOpenStatus(data.Hours, now)
+
+	courts, err := q.ListActiveCourts(ctx, facility.ID)
+	if err != nil {
+		return data, fmt.Errorf("list courts: %w", err)
+	}
+	for _, court := range courts {
+		data.Courts = append(data.Courts, membertempl.FacilityInfoCourt{
+			ID:          court.ID,
+			Name:        court.Name,
+			CourtNumber: court.CourtNumber,
+			IsIndoor:    court.IsIndoor,
+			Surface:     court.Surface.String,
+			HasLights:   court.HasLights,
+			Attributes:  apiutil.CourtAttributesLabel(court.IsIndoor, court.Surface, court.HasLights),
+		})
+	}
+	data.CourtCount = len(data.Courts)
+
+	rows, err := q.ListActiveFacilityAnnouncements(ctx, dbgen.ListActiveFacilityAnnouncementsParams{
+		FacilityID: facility.ID,
+		Now:        now.UTC(),
+		Audience:   announcements.AudienceMembers,
+		UserID:     userID,
+	})
+	if err != nil {
+		return data, fmt.Errorf("list announcements: %w", err)
+	}
+	for _, row := range rows {
+		data.Announcements = append(data.Announcements, membertempl.FacilityInfoAnnouncement{
+			ID:       row.ID,
+			Title:    row.Title,
+			Body:     row.Body,
+			Severity: row.Severity,
+			EndsAt:   row.EndsAt.In(loc),
+		})
+	}
+	return data, nil
+}
+
+// facilityOpenStatus reports whether the facility is open at now and
+// describes when it closes or next opens, e.g. "Open now · closes at 9:00 PM"
+// or "Closed · opens tomorrow at 7:00 AM". days starts with today.
+func facilityOpenStatus(days []membertempl.FacilityInfoDay, now time.Time) (bool, string) {
+	for i, day := range days {
+		if !day.Open {
+			continue
+		}
+		if i == 0 {
+			if now.Before(*day.OpensAt) {
+				return false, "Closed · opens at " + day.OpensAt.Format("3:04 PM")
+			}
+			if now.Before(*day.ClosesAt) {
+				return true, "Open now · closes at " + day.ClosesAt.Format("3:04 PM")
+			}
+			continue
+		}
+		when := day.Weekday
+		if i == 1 {
+			when = "tomorrow"
+		}
+		return false, fmt.Sprintf("Closed · opens %s at %s", when, day.OpensAt.Format("3:04 PM"))
+	}
+	return false, "Closed"
+}
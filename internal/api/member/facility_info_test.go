@@ -0,0 +1,175 @@
+package member
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/auth"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestMemberFacilityInfo(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	otherOrgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Other Org', 'other-org', 'active')")
+	homeID := exec(
+		`INSERT INTO facilities (organization_id, name, slug, timezone, address, phone)
+		 VALUES (?, 'Main Club', 'main', 'America/New_York', '1 Court St', '555-0100')`,
+		orgID,
+	)
+	awayID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'West Club', 'west', 'America/Denver')", orgID)
+	foreignID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Rival Club', 'rival', 'UTC')", otherOrgID)
+
+	// Open 6am-10pm every day but Sunday, with a closure on Friday.
+	for day := 1; day <= 6; day++ {
+		exec("INSERT INTO operating_hours (facility_id, day_of_week, opens_at, closes_at) VALUES (?, ?, '06:00', '22:00')", homeID, day)
+	}
+	exec("INSERT INTO facility_hours_overrides (facility_id, override_date, is_closed, reason) VALUES (?, '2026-10-16', 1, 'Staff training')", homeID)
+	exec("INSERT INTO courts (facility_id, name, court_number, status, is_indoor, surface, has_lights) VALUES (?, 'Court 1', 1, 'active', 1, 'sport_tile', 1)", homeID)
+	exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 2', 2, 'active')", homeID)
+	exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 3', 3, 'maintenance')", homeID)
+	exec(
+		`INSERT INTO facility_announcements (facility_id, title, severity, audience, starts_at, ends_at)
+		 VALUES (?, 'Ladder night', 'info', 'members', '2020-01-01 00:00:00', '2100-01-01 00:00:00'),
+		        (?, 'Staff meeting', 'info', 'staff', '2020-01-01 00:00:00', '2100-01-01 00:00:00')`,
+		homeID, homeID,
+	)
+	memberID := exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, membership_level, home_facility_id)
+		 VALUES ('Fran', 'Finder', 'fran@test.com', 'active', 1, 1, ?)`,
+		homeID,
+	)
+
+	h := NewHandlers(database, nil, Options{})
+
+	facility, err := database.Queries.GetFacilityByID(ctx, homeID)
+	if err != nil {
+		t.Fatalf("load facility: %v", err)
+	}
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	// Thursday afternoon in New York, already evening in UTC.
+	data, err := buildFacilityInfo(ctx, database.Queries, facility, memberID, time.Date(2026, time.October, 15, 14, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatalf("build facility info: %v", err)
+	}
+	if !data.OpenNow || data.Status != "Open now · closes at 10:00 PM" {
+		t.Fatalf("expected the facility to be open, got %v %q", data.OpenNow, data.Status)
+	}
+	if data.Address != "1 Court St" || data.Phone != "555-0100" || data.Timezone != "America/New_York" {
+		t.Fatalf("unexpected contact details %+v", data)
+	}
+	if len(data.Hours) != facilityInfoDays || data.Hours[0].Date != "2026-10-15" || !data.Hours[0].Today {
+		t.Fatalf("expected the week to start today, got %+v", data.Hours)
+	}
+	friday := data.Hours[1]
+	if friday.Open || !friday.Override || friday.Note != "Staff training" || friday.HoursLabel() != "Closed" {
+		t.Fatalf("expected the Friday closure, got %+v", friday)
+	}
+	if sunday := data.Hours[3]; sunday.Weekday != "Sunday" || sunday.Open {
+		t.Fatalf("expected Sunday closed, got %+v", sunday)
+	}
+	if label := data.Hours[2].HoursLabel(); label != "6:00 AM - 10:00 PM" {
+		t.Fatalf("unexpected Saturday hours %q", label)
+	}
+	if data.CourtCount != 2 || data.Courts[0].Attributes != "Indoor, Sport tile, Lights" {
+		t.Fatalf("expected the two active courts, got %+v", data.Courts)
+	}
+	if len(data.Announcements) != 1 || data.Announcements[0].Title != "Ladder night" {
+		t.Fatalf("expected only the member announcement, got %+v", data.Announcements)
+	}
+
+	for _, tc := range []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2026, time.October, 15, 5, 0, 0, 0, loc), "Closed · opens at 6:00 AM"},
+		// Friday is closed, so the next opening is Saturday.
+		{time.Date(2026, time.October, 15, 23, 0, 0, 0, loc), "Closed · opens Saturday at 6:00 AM"},
+		{time.Date(2026, time.October, 17, 23, 0, 0, 0, loc), "Closed · opens Monday at 6:00 AM"},
+		{time.Date(2026, time.October, 18, 23, 0, 0, 0, loc), "Closed · opens tomorrow at 6:00 AM"},
+	} {
+		data, err := buildFacilityInfo(ctx, database.Queries, facility, memberID, tc.now)
+		if err != nil {
+			t.Fatalf("build facility info: %v", err)
+		}
+		if data.OpenNow || data.Status != tc.want {
+			t.Fatalf("at %s expected %q, got %v %q", tc.now, tc.want, data.OpenNow, data.Status)
+		}
+	}
+
+	get := func(target, pathID, accept string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Accept", accept)
+		req.SetPathValue("id", pathID)
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:             memberID,
+			HomeFacilityID: &homeID,
+			SessionType:    auth.SessionTypeMember,
+		}))
+		recorder := httptest.NewRecorder()
+		if pathID == "" {
+			h.HandleMemberFacilityInfo(recorder, req)
+		} else {
+			h.HandleMemberFacilityInfoByID(recorder, req)
+		}
+		return recorder
+	}
+
+	recorder := get("/member/facility", "", "application/json")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("home facility status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var home membertempl.FacilityInfoData
+	if err := json.Unmarshal(recorder.Body.Bytes(), &home); err != nil {
+		t.Fatalf("decode facility info: %v", err)
+	}
+	if home.FacilityID != homeID || !home.IsHome || home.CourtCount != 2 {
+		t.Fatalf("unexpected home facility info %+v", home)
+	}
+
+	recorder = get(fmt.Sprintf("/member/facilities/%d", awayID), fmt.Sprint(awayID), "application/json")
+	var away membertempl.FacilityInfoData
+	if err := json.Unmarshal(recorder.Body.Bytes(), &away); err != nil || recorder.Code != http.StatusOK {
+		t.Fatalf("away facility status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if away.FacilityID != awayID || away.IsHome || away.Timezone != "America/Denver" || away.Status != "Closed" {
+		t.Fatalf("unexpected away facility info %+v", away)
+	}
+
+	if recorder := get(fmt.Sprintf("/member/facilities/%d", foreignID), fmt.Sprint(foreignID), "application/json"); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected another organization's facility to be hidden, got %d", recorder.Code)
+	}
+	if recorder := get("/member/facilities/999", "999", "application/json"); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a missing facility, got %d", recorder.Code)
+	}
+
+	recorder = get("/member/facility", "", "text/html")
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "1 Court St") || !strings.Contains(recorder.Body.String(), "West Club") {
+		t.Fatalf("expected the page with a link to the other facility, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,92 @@
+package member
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+// HandleMemberGuestPasses handles GET /member/guest-passes, the member's
+// remaining guest passes at their home facility.
+func (h *Handlers) HandleMemberGuestPasses(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+	facilityID := *user.HomeFacilityID
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility")
+		return
+	}
+	balance, err := reservationsvc.GuestPassBalance(ctx, q, user.ID, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Int64("facility_id", facilityID).Msg("Failed to load guest passes")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load guest passes")
+		return
+	}
+
+	data := membertempl.GuestPassesData{
+		FacilityID:              facilityID,
+		Balance:                 balance,
+		MaxGuestsPerReservation: facility.MaxGuestsPerReservation,
+	}
+	acceptHeader := strings.ToLower(r.Header.Get("Accept"))
+	wantsHTML := apiutil.IsHTMXRequest(r) || strings.Contains(acceptHeader, "text/html")
+	wantsJSON := strings.Contains(acceptHeader, "application/json")
+	if wantsHTML && (!wantsJSON || apiutil.IsHTMXRequest(r)) {
+		component := membertempl.MemberGuestPassesNotice(data)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render guest passes", "Failed to render guest passes")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, data); err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to write guest passes response")
+		return
+	}
+}
+
+// parseMemberGuests pairs the booking form's guest_names and guest_emails
+// rows, skipping rows left blank.
+func parseMemberGuests(r *http.Request) []reservationsvc.Guest {
+	names := r.Form["guest_names"]
+	emails := r.Form["guest_emails"]
+	var guests []reservationsvc.Guest
+	for i, name := range names {
+		var email string
+		if i < len(emails) {
+			email = emails[i]
+		}
+		if strings.TrimSpace(name) == "" && strings.TrimSpace(email) == "" {
+			continue
+		}
+		guests = append(guests, reservationsvc.Guest{Name: name, Email: email})
+	}
+	return guests
+}
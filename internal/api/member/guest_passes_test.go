@@ -0,0 +1,101 @@
+package member
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+func (f memberBookingFixture) bookWithGuests(t *testing.T, courtID int64, guests ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.UTC)
+	form := url.Values{}
+	form.Set("facility_id", fmt.Sprintf("%d", f.facilityID))
+	form.Set("start_time", start.Format(memberBookingTimeLayout))
+	form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+	form.Set("court_ids", fmt.Sprintf("%d", courtID))
+	for _, name := range guests {
+		form.Add("guest_names", name)
+		form.Add("guest_emails", "")
+	}
+	// A blank row from the form is ignored.
+	form.Add("guest_names", " ")
+	form.Add("guest_emails", "")
+	req := httptest.NewRequest(http.MethodPost, "/member/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+	f.h.HandleMemberBookingCreate(recorder, f.withMember(req))
+	return recorder
+}
+
+func (f memberBookingFixture) guestPasses(t *testing.T) membertempl.GuestPassesData {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/member/guest-passes", nil)
+	req.Header.Set("Accept", "application/json")
+	recorder := httptest.NewRecorder()
+	f.h.HandleMemberGuestPasses(recorder, f.withMember(req))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("guest passes status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var data membertempl.GuestPassesData
+	if err := json.Unmarshal(recorder.Body.Bytes(), &data); err != nil {
+		t.Fatalf("decode guest passes: %v", err)
+	}
+	return data
+}
+
+func TestHandleMemberBookingCreate_AttachesGuests(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+	if _, err := fixture.database.Queries.GrantMemberGuestPasses(context.Background(), dbgen.GrantMemberGuestPassesParams{
+		UserID:     fixture.memberID,
+		FacilityID: fixture.facilityID,
+		Passes:     3,
+	}); err != nil {
+		t.Fatalf("grant guest passes: %v", err)
+	}
+	if data := fixture.guestPasses(t); data.Balance != 3 || data.MaxGuestsPerReservation != 2 {
+		t.Fatalf("unexpected guest passes before booking: %+v", data)
+	}
+
+	recorder := fixture.bookWithGuests(t, fixture.courtIDs[0], "Alex Guest", "Jo Visitor", "Sam Extra")
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), "guests are limited to 2") {
+		t.Fatalf("expected the guest cap to reject a third guest, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = fixture.bookWithGuests(t, fixture.courtIDs[0], "Alex Guest", "Jo Visitor")
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created dbgen.Reservation
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode reservation: %v", err)
+	}
+	guests, err := fixture.database.Queries.ListReservationGuests(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("list guests: %v", err)
+	}
+	if len(guests) != 2 || guests[0].IsDropIn || guests[1].IsDropIn {
+		t.Fatalf("expected two guests on passes, got %+v", guests)
+	}
+	if data := fixture.guestPasses(t); data.Balance != 1 {
+		t.Fatalf("expected 1 guest pass left, got %d", data.Balance)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/member/guest-passes", nil)
+	req.Header.Set("HX-Request", "true")
+	htmlRecorder := httptest.NewRecorder()
+	fixture.h.HandleMemberGuestPasses(htmlRecorder, fixture.withMember(req))
+	if !strings.Contains(htmlRecorder.Body.String(), "1 guest pass left") {
+		t.Fatalf("expected HTML balance notice, got %s", htmlRecorder.Body.String())
+	}
+}
@@ -7,61 +7,149 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"sort"
+	"net/url"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
+	"github.com/codr1/Pickleicious/internal/api/announcements"
 	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/api/auth"
 	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/api/themes"
 	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/ical"
+	"github.com/codr1/Pickleicious/internal/metrics"
 	"github.com/codr1/Pickleicious/internal/models"
+	openplayengine "github.com/codr1/Pickleicious/internal/openplay"
+	"github.com/codr1/Pickleicious/internal/payments"
+	"github.com/codr1/Pickleicious/internal/pricing"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+	announcementstempl "github.com/codr1/Pickleicious/internal/templates/components/announcements"
 	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
 	reservationstempl "github.com/codr1/Pickleicious/internal/templates/components/reservations"
 	waitlisttempl "github.com/codr1/Pickleicious/internal/templates/components/waitlist"
 	"github.com/codr1/Pickleicious/internal/templates/layouts"
 )
 
-var (
-	queries     *dbgen.Queries
-	store       *appdb.DB
-	queriesOnce sync.Once
-	emailClient *email.SESClient
-)
-
 const portalQueryTimeout = 5 * time.Second
 const memberReservationTypeName = "GAME"
 const memberBookingTimeLayout = "2006-01-02T15:04"
-const memberBookingMinDuration = time.Hour
 const memberBookingDefaultOpensAt = "08:00"
 const memberBookingDefaultClosesAt = "21:00"
 const cancellationPenaltyWindow = 10 * time.Minute
 
-// InitHandlers must be called during server startup before handling requests.
-func InitHandlers(database *appdb.DB, client *email.SESClient) {
-	if database == nil {
-		return
-	}
-	queriesOnce.Do(func() {
-		queries = database.Queries
-		store = database
-		emailClient = client
-	})
+// Subscribed calendars keep recently finished reservations instead of
+// dropping each one as soon as it ends.
+const memberCalendarFeedLookback = 30 * 24 * time.Hour
+const memberCalendarProdID = "-//Pickleicious//Member Reservations//EN"
+
+// The portal pages reservation lists rather than loading a member's full history.
+const memberUpcomingReservationsPageSize = 20
+const memberPastReservationsPageSize = 10
+const memberReservationsWidgetLimit = 5
+const reservationFilterDateLayout = "2006-01-02"
+const reservationSectionUpcoming = "upcoming"
+const reservationSectionPast = "past"
+
+// SQLite treats a negative LIMIT as no limit; calendar exports need every row.
+const memberReservationsNoLimit = -1
+
+// Options carries the member handlers' optional collaborators.
+type Options struct {
+	// CardSigner signs member card QR codes. Cards show no QR code without it.
+	CardSigner *models.MemberCardSigner
+	// CalendarFeedSigner signs calendar subscription URLs. Subscriptions are
+	// unavailable without it.
+	CalendarFeedSigner *models.CalendarFeedSigner
+	// AccessSigner signs reservation access QR codes for door and gate
+	// readers. Reservations have no access code without it.
+	AccessSigner *models.ReservationAccessSigner
+	// PaymentProcessor charges paid visit packs. Leaving it nil disables
+	// online purchases.
+	PaymentProcessor payments.PaymentProcessor
+	// TransferWindow is how long a member has to accept a reservation
+	// transferred to them. Zero uses the reservation service's default.
+	TransferWindow time.Duration
+	// Notifiers tell waitlisted members about offers made when a slot frees
+	// up. Offers are still recorded without them.
+	Notifiers []email.Notifier
+	// BaseURL is the public origin, such as https://club.example.com, that
+	// links sent outside the portal are built against.
+	BaseURL string
+}
+
+// Handlers serves the member portal. Each instance holds its own database
+// and clients, so two can run side by side with different configurations.
+type Handlers struct {
+	queries            *dbgen.Queries
+	store              *appdb.DB
+	emailClient        *email.SESClient
+	cardSigner         *models.MemberCardSigner
+	calendarFeedSigner *models.CalendarFeedSigner
+	accessSigner       *models.ReservationAccessSigner
+	paymentProcessor   payments.PaymentProcessor
+	transferWindow     time.Duration
+	notifiers          []email.Notifier
+	baseURL            string
+}
+
+// NewHandlers returns the member portal handlers over database. With a nil
+// database every handler answers 500.
+func NewHandlers(database *appdb.DB, client *email.SESClient, opts Options) *Handlers {
+	h := &Handlers{
+		emailClient:        client,
+		cardSigner:         opts.CardSigner,
+		calendarFeedSigner: opts.CalendarFeedSigner,
+		accessSigner:       opts.AccessSigner,
+		paymentProcessor:   opts.PaymentProcessor,
+		transferWindow:     opts.TransferWindow,
+		notifiers:          opts.Notifiers,
+		baseURL:            strings.TrimRight(strings.TrimSpace(opts.BaseURL), "/"),
+	}
+	if database != nil {
+		h.queries = database.Queries
+		h.store = database
+	}
+	return h
+}
+
+func (h *Handlers) loadQueries() *dbgen.Queries {
+	return h.queries
+}
+
+func (h *Handlers) loadDB() *appdb.DB {
+	return h.store
+}
+
+// absoluteURL returns path on the configured public origin. Emailed links and
+// calendar subscriptions use it instead of the request's Host header, which
+// a client can set to anything.
+func (h *Handlers) absoluteURL(path string) string {
+	return h.baseURL + path
 }
 
-func loadQueries() *dbgen.Queries {
-	return queries
+// loadFacilities returns the store's facility cache, falling back to the
+// uncached queries when the store was built without one.
+func (h *Handlers) loadFacilities() apiutil.FacilityQuerier {
+	if h.store == nil || h.store.Facilities == nil {
+		return h.queries
+	}
+	return h.store.Facilities
 }
 
-func loadDB() *appdb.DB {
-	return store
+// loadService returns a reservation service over the handlers' store, or nil
+// when they have no database.
+func (h *Handlers) loadService() *reservationsvc.Service {
+	if h.store == nil {
+		return nil
+	}
+	return reservationsvc.NewService(h.store, h.emailClient, h.notifiers...)
 }
 
 func ensureOpenPlayReservation(ctx context.Context, qtx *dbgen.Queries, session dbgen.GetOpenPlaySessionRow, facilityID int64) error {
@@ -97,7 +185,7 @@ func ensureOpenPlayReservation(ctx context.Context, qtx *dbgen.Queries, session
 }
 
 // RequireMemberSession ensures member-authenticated sessions reach member routes.
-func RequireMemberSession(next http.Handler) http.Handler {
+func (h *Handlers) RequireMemberSession(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger := log.Ctx(r.Context())
 
@@ -107,10 +195,10 @@ func RequireMemberSession(next http.Handler) http.Handler {
 			return
 		}
 
-		q := loadQueries()
+		q := h.loadQueries()
 		if q == nil {
 			logger.Error().Msg("Database queries not initialized")
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 			return
 		}
 
@@ -124,12 +212,12 @@ func RequireMemberSession(next http.Handler) http.Handler {
 				return
 			}
 			logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load member profile")
-			http.Error(w, "Failed to load member profile", http.StatusInternalServerError)
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load member profile")
 			return
 		}
 
 		if memberRow.MembershipLevel < 1 {
-			http.Error(w, "Active membership required", http.StatusForbidden)
+			apiutil.WriteError(w, r, http.StatusForbidden, "Active membership required")
 			return
 		}
 		next.ServeHTTP(w, r)
@@ -137,18 +225,18 @@ func RequireMemberSession(next http.Handler) http.Handler {
 }
 
 // HandleMemberPortal renders the member portal for GET /member.
-func HandleMemberPortal(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleMemberPortal(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -168,13 +256,13 @@ func HandleMemberPortal(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		logger.Error().Err(err).Msg("Failed to load member profile")
-		http.Error(w, "Failed to load profile", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load profile")
 		return
 	}
 
 	var activeTheme *models.Theme
 	if user.HomeFacilityID != nil {
-		theme, err := models.GetActiveTheme(ctx, q, *user.HomeFacilityID)
+		theme, err := themes.PageTheme(ctx, r, q, *user.HomeFacilityID)
 		if err != nil {
 			logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load active theme")
 		} else {
@@ -182,11 +270,21 @@ func HandleMemberPortal(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	reservationData, err := buildReservationListData(ctx, q, user.ID, user.HomeFacilityID, requestedFacilityID(r), logger)
+	reservationData, err := h.buildReservationListData(ctx, q, user.ID, user.HomeFacilityID, reservationListFilter{FacilityID: requestedFacilityID(r)}, logger)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to load member reservations")
 		reservationData = membertempl.ReservationListData{}
 	}
+	reservationData.CalendarFeedURL = h.memberCalendarFeedURL(user.ID)
+
+	var banners []announcementstempl.Banner
+	if user.HomeFacilityID != nil {
+		banners, err = announcements.ActiveBanners(ctx, q, *user.HomeFacilityID, user.ID, announcements.AudienceMembers, announcements.MemberDismissBase)
+		if err != nil {
+			logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load announcements")
+			banners = nil
+		}
+	}
 
 	profile := membertempl.PortalProfile{
 		ID:              memberRow.ID,
@@ -196,28 +294,31 @@ func HandleMemberPortal(w http.ResponseWriter, r *http.Request) {
 		MembershipLevel: memberRow.MembershipLevel,
 		HasPhoto:        memberRow.PhotoID.Valid,
 	}
+	h.applyMembershipTier(ctx, q, &profile, user.HomeFacilityID, logger)
 
-	page := layouts.Base(membertempl.MemberPortal(profile, reservationData), activeTheme, user.SessionType)
+	page := layouts.Base(membertempl.MemberPortal(profile, banners, reservationData), activeTheme, user.SessionType)
 	if err := page.Render(r.Context(), w); err != nil {
 		logger.Error().Err(err).Msg("Failed to render member portal")
-		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render page")
 		return
 	}
 }
 
-// HandleMemberReservationsPartial renders the reservation list for facility filtering.
-func HandleMemberReservationsPartial(w http.ResponseWriter, r *http.Request) {
+// HandleMemberReservationsPartial renders the reservation list for facility and
+// date filtering. With a section (upcoming or past) and offset it renders only
+// the next page of that list for the "load more" button.
+func (h *Handlers) HandleMemberReservationsPartial(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -230,33 +331,169 @@ func HandleMemberReservationsPartial(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
 	defer cancel()
 
-	reservationData, err := buildReservationListData(ctx, q, user.ID, user.HomeFacilityID, requestedFacilityID(r), logger)
+	filter, err := parseReservationListFilter(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if section := r.URL.Query().Get("section"); section != "" {
+		if section != reservationSectionUpcoming && section != reservationSectionPast {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid section")
+			return
+		}
+		if filter.FacilityID == nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "facility_id is required")
+			return
+		}
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		if err != nil || offset < 0 {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid offset")
+			return
+		}
+
+		page, err := h.loadReservationPage(ctx, q, user.ID, *filter.FacilityID, section, filter, offset, logger)
+		if err != nil {
+			logger.Error().Err(err).Str("section", section).Msg("Failed to load member reservations page")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservations")
+			return
+		}
+		if err := membertempl.MemberReservationsPage(page).Render(r.Context(), w); err != nil {
+			logger.Error().Err(err).Msg("Failed to render member reservations page")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render reservations")
+		}
+		return
+	}
+
+	reservationData, err := h.buildReservationListData(ctx, q, user.ID, user.HomeFacilityID, filter, logger)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to load member reservations")
-		http.Error(w, "Failed to load reservations", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservations")
 		return
 	}
+	reservationData.CalendarFeedURL = h.memberCalendarFeedURL(user.ID)
 
 	if err := membertempl.MemberReservations(reservationData).Render(r.Context(), w); err != nil {
 		logger.Error().Err(err).Msg("Failed to render member reservations")
-		http.Error(w, "Failed to render reservations", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render reservations")
+		return
+	}
+}
+
+// HandleMemberReservationsExport handles GET /member/reservations/export.ics.
+// Calendar apps subscribe without a session, so the feed also accepts the
+// member and token query parameters from the member's subscription URL.
+func (h *Handlers) HandleMemberReservationsExport(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	userID, ok := h.calendarFeedUserID(r)
+	if !ok {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	memberRow, err := q.GetMemberByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Member not found")
+			return
+		}
+		logger.Error().Err(err).Int64("member_id", userID).Msg("Failed to load member for calendar export")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservations")
+		return
+	}
+	if memberRow.MembershipLevel < 1 {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Active membership required")
 		return
 	}
+
+	feedRows, err := q.ListReservationsByUserID(ctx, dbgen.ListReservationsByUserIDParams{
+		UserID:      sql.NullInt64{Int64: userID, Valid: true},
+		EndAfter:    time.Now().Add(-memberCalendarFeedLookback),
+		OldestFirst: true,
+		Limit:       memberReservationsNoLimit,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", userID).Msg("Failed to load reservations for calendar export")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservations")
+		return
+	}
+
+	calendar := buildMemberReservationsCalendar(feedRows, logger)
+	writeMemberCalendar(w, calendar, "", logger)
+}
+
+// HandleMemberReservationExport handles GET /member/reservations/{id}/export.ics,
+// returning a single reservation as a downloadable calendar file.
+func (h *Handlers) HandleMemberReservationExport(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	reservationID, err := apiutil.ReservationIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	rows, err := q.ListReservationsByUserID(ctx, dbgen.ListReservationsByUserIDParams{
+		UserID: sql.NullInt64{Int64: user.ID, Valid: true},
+		Limit:  memberReservationsNoLimit,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load reservations for calendar export")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation")
+		return
+	}
+
+	for _, row := range rows {
+		if row.ID != reservationID {
+			continue
+		}
+		calendar := buildMemberReservationsCalendar([]dbgen.ListReservationsByUserIDRow{row}, logger)
+		writeMemberCalendar(w, calendar, fmt.Sprintf("reservation-%d.ics", reservationID), logger)
+		return
+	}
+	apiutil.WriteError(w, r, http.StatusNotFound, "Reservation not found")
 }
 
 // HandleMemberReservationsWidget renders the upcoming reservations widget for the nav.
-func HandleMemberReservationsWidget(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleMemberReservationsWidget(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -277,24 +514,24 @@ func HandleMemberReservationsWidget(w http.ResponseWriter, r *http.Request) {
 
 	if err := membertempl.MemberReservationsWidget(widgetData).Render(r.Context(), w); err != nil {
 		logger.Error().Err(err).Msg("Failed to render reservations widget")
-		http.Error(w, "Failed to render reservations widget", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render reservations widget")
 		return
 	}
 }
 
 // HandleMemberWaitlistList renders waitlist entries for members.
-func HandleMemberWaitlistList(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleMemberWaitlistList(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -304,7 +541,7 @@ func HandleMemberWaitlistList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
@@ -317,30 +554,200 @@ func HandleMemberWaitlistList(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load waitlist entries")
-		http.Error(w, "Failed to load waitlist entries", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load waitlist entries")
 		return
 	}
 
-	entries := buildWaitlistEntrySummaries(ctx, q, rows, logger)
+	entries := h.buildWaitlistEntrySummaries(ctx, q, rows, logger)
 	component := waitlisttempl.WaitlistEntryList(waitlisttempl.WaitlistEntryListData{Entries: entries})
 	if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render waitlist list", "Failed to render waitlist entries") {
 		return
 	}
 }
 
+// HandleMemberWaitlistLeave handles DELETE /member/waitlist/{id}. Members can
+// only remove their own entries at their home facility; everyone queued behind
+// the entry moves up in the same transaction.
+func (h *Handlers) HandleMemberWaitlistLeave(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodDelete {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	waitlistID, err := memberWaitlistIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid waitlist ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	entry, err := q.GetWaitlistEntry(ctx, dbgen.GetWaitlistEntryParams{
+		ID:         waitlistID,
+		FacilityID: *user.HomeFacilityID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error().Err(err).Int64("waitlist_id", waitlistID).Msg("Failed to load waitlist entry")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to remove waitlist entry")
+		return
+	}
+	if err != nil || entry.UserID != user.ID {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Waitlist entry not found")
+		return
+	}
+
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+		if _, err := qtx.DeleteWaitlistEntry(ctx, dbgen.DeleteWaitlistEntryParams{
+			ID:         entry.ID,
+			FacilityID: entry.FacilityID,
+		}); err != nil {
+			return err
+		}
+		return reservationsvc.ReorderWaitlistPositions(ctx, qtx, entry)
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("waitlist_id", waitlistID).Msg("Failed to remove waitlist entry")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to remove waitlist entry")
+		return
+	}
+
+	apiutil.AppendHXTrigger(w, "refreshMemberWaitlist")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type memberWaitlistUpdateRequest struct {
+	AutoBook     bool `json:"auto_book"`
+	UseVisitPack bool `json:"use_visit_pack"`
+}
+
+// HandleMemberWaitlistUpdate handles PUT /member/waitlist/{id}, turning
+// auto-booking on or off for one of the member's queued entries. Entries that
+// have been fulfilled or have expired can no longer be changed.
+func (h *Handlers) HandleMemberWaitlistUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodPut {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	waitlistID, err := memberWaitlistIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid waitlist ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	var req memberWaitlistUpdateRequest
+	if apiutil.IsJSONRequest(r) {
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+		req.AutoBook = apiutil.ParseBool(r.FormValue("auto_book"))
+		req.UseVisitPack = apiutil.ParseBool(r.FormValue("use_visit_pack"))
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	entry, err := q.GetWaitlistEntry(ctx, dbgen.GetWaitlistEntryParams{
+		ID:         waitlistID,
+		FacilityID: *user.HomeFacilityID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error().Err(err).Int64("waitlist_id", waitlistID).Msg("Failed to load waitlist entry")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update waitlist entry")
+		return
+	}
+	if err != nil || entry.UserID != user.ID {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Waitlist entry not found")
+		return
+	}
+	if entry.Status != waitlistStatusPending && entry.Status != waitlistStatusNotified {
+		apiutil.WriteError(w, r, http.StatusConflict, "Waitlist entry is no longer queued")
+		return
+	}
+
+	updated, err := q.UpdateWaitlistAutoBook(ctx, dbgen.UpdateWaitlistAutoBookParams{
+		AutoBook:     req.AutoBook,
+		UseVisitPack: req.UseVisitPack,
+		ID:           entry.ID,
+		FacilityID:   entry.FacilityID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("waitlist_id", waitlistID).Msg("Failed to update waitlist entry")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update waitlist entry")
+		return
+	}
+
+	apiutil.AppendHXTrigger(w, "refreshMemberWaitlist")
+	if apiutil.IsHTMXRequest(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, updated); err != nil {
+		logger.Error().Err(err).Int64("waitlist_id", waitlistID).Msg("Failed to write waitlist response")
+		return
+	}
+}
+
 // HandleMemberBookingFormNew handles GET /member/booking/new.
-func HandleMemberBookingFormNew(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleMemberBookingFormNew(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -350,14 +757,14 @@ func HandleMemberBookingFormNew(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
 	defer cancel()
 
-	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, q, *user.HomeFacilityID, user.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
+	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, q, h.loadFacilities(), *user.HomeFacilityID, user.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
 	facilityLoaded := facility != nil
 	if err != nil {
 		message := "Failed to load facility booking config"
@@ -365,31 +772,37 @@ func HandleMemberBookingFormNew(w http.ResponseWriter, r *http.Request) {
 			message = "Failed to load tier booking config"
 		}
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Int64("membership_level", user.MembershipLevel).Msg(message)
+		if !errors.Is(err, sql.ErrNoRows) {
+			writeFacilityConfigUnavailable(w, r)
+			return
+		}
 	}
 
-	courtsList, err := q.ListCourts(ctx, *user.HomeFacilityID)
+	courtFilter, err := apiutil.ParseCourtFilter(r.URL.Query())
 	if err != nil {
-		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load courts")
-		http.Error(w, "Failed to load courts", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
-	activeCourts := courtsList[:0]
-	for _, court := range courtsList {
-		if court.Status == "active" {
-			activeCourts = append(activeCourts, court)
-		}
+	activeCourts, matchingCourts, err := listMemberBookingCourts(ctx, q, *user.HomeFacilityID, courtFilter)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load courts")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load courts")
+		return
 	}
 
-	bookingDate := bookingDateFromRequest(r, maxAdvanceDays)
-	availableSlots, err := buildMemberBookingSlots(ctx, q, *user.HomeFacilityID, bookingDate, logger)
+	tier := loadMemberTier(ctx, q, facility, user.MembershipLevel, logger)
+	now := time.Now().In(memberBookingLocation(facility, logger))
+	bookingDate := bookingDateFromRequest(r, maxAdvanceDays, now)
+	granularity := apiutil.FacilityBookingGranularity(facility)
+	availableSlots, err := buildMemberBookingSlots(ctx, q, *user.HomeFacilityID, user.MembershipLevel, apiutil.PrimeTimeLevel(tier, user.MembershipLevel), bookingDate, now, granularity, courtFilter, logger)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load available slots")
-		http.Error(w, "Failed to load available slots", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load available slots")
 		return
 	}
-	waitlistStartTime, waitlistEndTime := waitlistFallbackTimes(bookingDate, availableSlots)
+	waitlistStartTime, waitlistEndTime := waitlistFallbackTimes(bookingDate, availableSlots, granularity.SlotLength())
 	var visitPackOptions []membertempl.MemberVisitPackOption
-	if user.MembershipLevel <= 1 && facilityLoaded {
+	if tier.VisitPacks && facilityLoaded {
 		crossFacility, err := q.GetOrganizationCrossFacilitySetting(ctx, facility.OrganizationID)
 		if err != nil {
 			logger.Error().Err(err).Int64("organization_id", facility.OrganizationID).Msg("Failed to load visit pack settings")
@@ -403,15 +816,41 @@ func HandleMemberBookingFormNew(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	maxCourts := int64(1)
+	if facilityLoaded && facility.MaxCourtsPerMemberBooking > 0 {
+		maxCourts = facility.MaxCourtsPerMemberBooking
+	}
+
+	var maxGuests int64
+	if facilityLoaded {
+		maxGuests = facility.MaxGuestsPerReservation
+	}
+	reservationTypeOptions, err := listMemberCourtReservationTypes(ctx, q, *user.HomeFacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load reservation types")
+		reservationTypeOptions = nil
+	}
+	bookingForOptions, err := listBookingForOptions(ctx, q, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load household dependents")
+		bookingForOptions = nil
+	}
+
 	component := membertempl.MemberBookingForm(membertempl.MemberBookingFormData{
 		FacilityID:            *user.HomeFacilityID,
-		Courts:                reservationstempl.NewCourtOptions(activeCourts),
+		Courts:                reservationstempl.NewCourtOptions(matchingCourts),
+		MaxCourts:             maxCourts,
 		AvailableSlots:        availableSlots,
 		DatePicker:            membertempl.DatePickerData{Year: bookingDate.Year(), Month: int(bookingDate.Month()), Day: bookingDate.Day()},
 		MaxAdvanceBookingDays: maxAdvanceDays,
 		WaitlistStartTime:     waitlistStartTime,
 		WaitlistEndTime:       waitlistEndTime,
 		VisitPacks:            visitPackOptions,
+		ReservationTypes:      reservationTypeOptions,
+		IdempotencyKey:        apiutil.NewIdempotencyKey(),
+		CourtFilter:           buildMemberCourtFilterData(activeCourts, courtFilter),
+		MaxGuests:             maxGuests,
+		BookingFor:            bookingForOptions,
 	})
 	if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render member booking form", "Failed to render booking form") {
 		return
@@ -419,18 +858,18 @@ func HandleMemberBookingFormNew(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleMemberBookingSlots handles GET /member/booking/slots.
-func HandleMemberBookingSlots(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleMemberBookingSlots(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -440,44 +879,136 @@ func HandleMemberBookingSlots(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
 	defer cancel()
 
-	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, q, *user.HomeFacilityID, user.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
+	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, q, h.loadFacilities(), *user.HomeFacilityID, user.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
 	if err != nil {
 		message := "Failed to load facility booking config"
 		if facility != nil {
 			message = "Failed to load tier booking config"
 		}
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Int64("membership_level", user.MembershipLevel).Msg(message)
+		if !errors.Is(err, sql.ErrNoRows) {
+			writeFacilityConfigUnavailable(w, r)
+			return
+		}
+	}
+
+	courtFilter, err := apiutil.ParseCourtFilter(r.URL.Query())
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	activeCourts, matchingCourts, err := listMemberBookingCourts(ctx, q, *user.HomeFacilityID, courtFilter)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load courts")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load courts")
+		return
 	}
 
-	bookingDate := bookingDateFromRequest(r, maxAdvanceDays)
-	availableSlots, err := buildMemberBookingSlots(ctx, q, *user.HomeFacilityID, bookingDate, logger)
+	tier := loadMemberTier(ctx, q, facility, user.MembershipLevel, logger)
+	now := time.Now().In(memberBookingLocation(facility, logger))
+	bookingDate := bookingDateFromRequest(r, maxAdvanceDays, now)
+	granularity := apiutil.FacilityBookingGranularity(facility)
+	availableSlots, err := buildMemberBookingSlots(ctx, q, *user.HomeFacilityID, user.MembershipLevel, apiutil.PrimeTimeLevel(tier, user.MembershipLevel), bookingDate, now, granularity, courtFilter, logger)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load available slots")
-		http.Error(w, "Failed to load available slots", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load available slots")
 		return
 	}
-	waitlistStartTime, waitlistEndTime := waitlistFallbackTimes(bookingDate, availableSlots)
+	waitlistStartTime, waitlistEndTime := waitlistFallbackTimes(bookingDate, availableSlots, granularity.SlotLength())
+
+	// The form resends the chosen slot so the court select narrows to it. A
+	// slot from a different date simply matches nothing.
+	var selectedStart time.Time
+	if raw := r.URL.Query().Get("start_time"); raw != "" {
+		selectedStart, err = apiutil.ParseFlexibleTime(raw, "start_time", bookingDate.Location())
+		if err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	maxCourts := int64(1)
+	if facility != nil && facility.MaxCourtsPerMemberBooking > 0 {
+		maxCourts = facility.MaxCourtsPerMemberBooking
+	}
 
 	component := membertempl.MemberBookingDateTime(membertempl.MemberBookingFormData{
 		FacilityID:            *user.HomeFacilityID,
+		Courts:                reservationstempl.NewCourtOptions(matchingCourts),
+		MaxCourts:             maxCourts,
+		CourtFilter:           buildMemberCourtFilterData(activeCourts, courtFilter),
 		AvailableSlots:        availableSlots,
 		DatePicker:            membertempl.DatePickerData{Year: bookingDate.Year(), Month: int(bookingDate.Month()), Day: bookingDate.Day()},
 		MaxAdvanceBookingDays: maxAdvanceDays,
 		WaitlistStartTime:     waitlistStartTime,
 		WaitlistEndTime:       waitlistEndTime,
+		SelectedStart:         selectedStart,
 	})
 	if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render booking slots", "Failed to render booking slots") {
 		return
 	}
 }
 
+// listMemberBookingCourts returns the facility's active courts and, of
+// those, the ones matching filter.
+func listMemberBookingCourts(ctx context.Context, q *dbgen.Queries, facilityID int64, filter apiutil.CourtFilter) ([]dbgen.Court, []dbgen.Court, error) {
+	active, err := q.ListActiveCourts(ctx, facilityID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var matching []dbgen.Court
+	for _, court := range active {
+		if filter.Matches(court.IsIndoor, court.Surface, court.HasLights) {
+			matching = append(matching, court)
+		}
+	}
+	return active, matching, nil
+}
+
+// buildMemberCourtFilterData offers the court attribute filters the
+// facility's active courts differ on, with the member's current choices.
+func buildMemberCourtFilterData(courts []dbgen.Court, filter apiutil.CourtFilter) membertempl.MemberCourtFilterData {
+	data := membertempl.MemberCourtFilterData{Surface: filter.Surface}
+	if filter.Indoor != nil {
+		data.Indoor = strconv.FormatBool(*filter.Indoor)
+	}
+	if filter.Lights != nil {
+		data.Lights = strconv.FormatBool(*filter.Lights)
+	}
+
+	var indoor, lit int
+	surfaces := make(map[string]bool)
+	for _, court := range courts {
+		if court.IsIndoor {
+			indoor++
+		}
+		if court.HasLights {
+			lit++
+		}
+		if court.Surface.Valid && court.Surface.String != "" {
+			surfaces[court.Surface.String] = true
+		}
+	}
+	data.OffersIndoor = indoor > 0 && indoor < len(courts)
+	data.OffersLights = lit > 0 && lit < len(courts)
+	for _, surface := range apiutil.CourtSurfaces {
+		if surfaces[surface] {
+			data.Surfaces = append(data.Surfaces, membertempl.MemberCourtSurfaceOption{
+				Value: surface,
+				Label: apiutil.CourtSurfaceLabel(surface),
+			})
+		}
+	}
+	return data
+}
+
 type reservationLimitError struct {
 	currentCount int64
 	limit        int64
@@ -517,25 +1048,57 @@ func buildMemberVisitPackOptions(visitPacks []dbgen.VisitPack) []membertempl.Mem
 	return options
 }
 
+// writeMemberOverlapError answers a booking that would overlap one of the
+// member's own reservations with 409 and the reservation in the way.
+func writeMemberOverlapError(w http.ResponseWriter, r *http.Request, overlapErr reservationsvc.MemberOverlapError) {
+	body := apiutil.NewErrorBody(http.StatusConflict, "You already have a reservation that overlaps this time", overlapErr)
+	body.Details = map[string]any{"conflict": overlapErr.Conflict}
+	apiutil.WriteErrorBody(w, r, http.StatusConflict, body)
+}
+
+// writeReservationLimitError answers 409 when a booking would exceed the
+// member's active reservation limit.
+func writeReservationLimitError(w http.ResponseWriter, r *http.Request, currentCount, limit int64) {
+	message := fmt.Sprintf("You have reached the maximum of %d active reservations", limit)
+	body := apiutil.NewErrorBody(http.StatusConflict, message, nil)
+	body.Code = apiutil.ErrorCodeReservationLimitReached
+	body.Details = map[string]any{"current_count": currentCount, "limit": limit}
+	apiutil.WriteErrorBody(w, r, http.StatusConflict, body)
+}
+
+// writeFacilityConfigUnavailable answers 503 when a booking path cannot read
+// the facility's booking settings. Falling back to defaults could let a
+// member book further ahead or hold more reservations than the facility
+// allows, so these paths fail closed and ask the member to retry.
+func writeFacilityConfigUnavailable(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Retry-After", "5")
+	apiutil.WriteError(w, r, http.StatusServiceUnavailable, "Booking settings are temporarily unavailable. Please try again in a moment.")
+}
+
 // HandleMemberBookingCreate handles POST /member/reservations for member booking.
-func HandleMemberBookingCreate(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleMemberBookingCreate(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
-	database := loadDB()
-	if q == nil || database == nil {
+	q := h.loadQueries()
+	service := h.loadService()
+	if q == nil || service == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+	idempotencyKey, err := apiutil.IdempotencyKeyFromRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
@@ -546,16 +1109,16 @@ func HandleMemberBookingCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
 	defer cancel()
 
-	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, q, *user.HomeFacilityID, user.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
+	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, q, h.loadFacilities(), *user.HomeFacilityID, user.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
 	var maxMemberReservations int64
-	facilityLoc := time.Local
+	facilityLoc := apiutil.DefaultLocation()
 	facilityLoaded := facility != nil
 	if err != nil {
 		message := "Failed to load facility booking config"
@@ -563,38 +1126,77 @@ func HandleMemberBookingCreate(w http.ResponseWriter, r *http.Request) {
 			message = "Failed to load tier booking config"
 		}
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Int64("membership_level", user.MembershipLevel).Msg(message)
+		if !errors.Is(err, sql.ErrNoRows) {
+			writeFacilityConfigUnavailable(w, r)
+			return
+		}
 	}
+	var limitScope string
+	tier := apiutil.DefaultMembershipTier(0, user.MembershipLevel)
 	if facilityLoaded {
-		maxMemberReservations = facility.MaxMemberReservations
-		if facility.Timezone != "" {
-			loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-			if loadErr != nil {
-				logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone")
-			} else {
-				facilityLoc = loadedLoc
+		tier, err = apiutil.LoadMembershipTier(ctx, q, facility.OrganizationID, user.MembershipLevel)
+		if err != nil {
+			logger.Error().Err(err).Int64("organization_id", facility.OrganizationID).Int64("membership_level", user.MembershipLevel).Msg("Failed to load membership tier")
+			writeFacilityConfigUnavailable(w, r)
+			return
+		}
+		maxMemberReservations = apiutil.MemberReservationLimit(tier, facility.MaxMemberReservations)
+		limitScope = facility.ReservationLimitScope
+		facilityLoc = apiutil.FacilityLocation(*facility, logger)
+	}
+
+	// A parent may book for a household dependent: the reservation is the
+	// dependent's, and created_by_user_id records that the parent booked it.
+	bookingForID, err := resolveBookingFor(ctx, q, r, user.ID)
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("user_id", user.ID).Msg(herr.Message)
 			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
 		}
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	// A retry of a create that already went through replays it: the first
+	// create may have used the last pack visit, so those checks would now
+	// reject the request the member is only resending.
+	retry, err := service.HasIdempotentCreate(ctx, user.ID, idempotencyKey)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to check idempotency key")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to check idempotency key")
+		return
+	}
+
+	if !retry && !h.ensureNoNoShowRestriction(ctx, w, r, q, *user.HomeFacilityID, bookingForID) {
+		return
 	}
 
 	visitPackID, visitPackSelected, err := parseOptionalPositiveInt64(r.FormValue("visit_pack_id"), "visit_pack_id")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
 	var availableVisitPackIDs map[int64]struct{}
-	if user.MembershipLevel <= 1 {
+	switch {
+	case retry:
+		// CreateReservation replays the stored booking as it was made.
+	case tier.VisitPacks:
 		if facilityLoaded {
 			crossFacility, err := q.GetOrganizationCrossFacilitySetting(ctx, facility.OrganizationID)
 			if err != nil {
 				logger.Error().Err(err).Int64("organization_id", facility.OrganizationID).Msg("Failed to load visit pack settings")
-				http.Error(w, "Failed to load visit packs", http.StatusInternalServerError)
+				apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load visit packs")
 				return
 			}
 			visitPacks, err := listActiveVisitPacksForMemberBooking(ctx, q, user.ID, facility.ID, facility.OrganizationID, crossFacility, time.Now())
 			if err != nil {
 				logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load visit packs")
-				http.Error(w, "Failed to load visit packs", http.StatusInternalServerError)
+				apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load visit packs")
 				return
 			}
 			availableVisitPackIDs = make(map[int64]struct{}, len(visitPacks))
@@ -604,219 +1206,251 @@ func HandleMemberBookingCreate(w http.ResponseWriter, r *http.Request) {
 		}
 		if visitPackSelected {
 			if !facilityLoaded || len(availableVisitPackIDs) == 0 {
-				http.Error(w, "Selected visit pack is not available", http.StatusBadRequest)
+				apiutil.WriteError(w, r, http.StatusBadRequest, "Selected visit pack is not available")
 				return
 			}
 			if _, ok := availableVisitPackIDs[visitPackID]; !ok {
-				http.Error(w, "Selected visit pack is not available", http.StatusBadRequest)
+				apiutil.WriteError(w, r, http.StatusBadRequest, "Selected visit pack is not available")
 				return
 			}
 		}
-	} else if visitPackSelected {
-		http.Error(w, "Visit packs are not available for your membership level", http.StatusBadRequest)
+	case visitPackSelected:
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Visit packs are not available for your membership level")
 		return
 	}
 
-	startTime, err := parseMemberBookingTime(r.FormValue("start_time"), "start_time", facilityLoc)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	if startTime.Before(time.Now().In(facilityLoc)) {
-		http.Error(w, "start_time must be in the future", http.StatusBadRequest)
-		return
-	}
-
-	now := time.Now().In(facilityLoc)
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, facilityLoc)
-	maxDate := today.AddDate(0, 0, int(maxAdvanceDays))
-	startDay := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, facilityLoc)
-	if startDay.After(maxDate) {
-		http.Error(w, fmt.Sprintf("start_time must be within %d days for your membership level. Upgrade to book further in advance.", maxAdvanceDays), http.StatusBadRequest)
+	slot, ok := h.parseMemberBookingSlot(ctx, w, r, q, user, facility, facilityLoc, maxAdvanceDays, tier)
+	if !ok {
 		return
 	}
-
-	endTime, err := parseMemberBookingTime(r.FormValue("end_time"), "end_time", facilityLoc)
+	startTime, endTime, courtIDs, reservationType := slot.startTime, slot.endTime, slot.courtIDs, slot.reservationType
+	inviteeIDs, err := parseMemberInviteeIDs(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
-	if !endTime.After(startTime) {
-		http.Error(w, "end_time must be after start_time", http.StatusBadRequest)
-		return
-	}
-	if endTime.Sub(startTime) < memberBookingMinDuration {
-		http.Error(w, "Reservation must be at least 1 hour", http.StatusBadRequest)
-		return
+	if !reservationType.CountsTowardMemberLimit {
+		maxMemberReservations = 0
 	}
 
-	courtID, err := parseMemberCourtID(r)
+	// Off-peak bookings covered by a season pass are free and do not count
+	// toward the member reservation limit.
+	seasonPass, err := models.FindCoveringSeasonPass(ctx, q, bookingForID, *user.HomeFacilityID, startTime, endTime, facilityLoc)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		logger.Error().Err(err).Int64("user_id", bookingForID).Msg("Failed to load season passes")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load season passes")
 		return
 	}
 
-	court, err := q.GetCourt(ctx, courtID)
+	input := reservationsvc.CreateInput{
+		Details: reservationsvc.Details{
+			ReservationTypeID: reservationType.ID,
+			PrimaryUserID:     &bookingForID,
+			StartTime:         startTime,
+			EndTime:           endTime,
+			CourtIDs:          courtIDs,
+		},
+		FacilityID:            *user.HomeFacilityID,
+		CreatedByUserID:       user.ID,
+		ParticipantIDs:        []int64{bookingForID},
+		InviteeIDs:            inviteeIDs,
+		InvitationLinks:       h.reservationInvitationLinks(),
+		AccessURL:             h.reservationAccessURL(),
+		Guests:                parseMemberGuests(r),
+		MemberNote:            r.FormValue("member_note"),
+		MaxActiveReservations: maxMemberReservations,
+		LimitScope:            limitScope,
+		PreventMemberOverlap:  true,
+		PriceCourtTime:        true,
+		ApplyCredits:          apiutil.ParseBool(r.FormValue("apply_credits")),
+		SendConfirmation:      facilityLoaded,
+		HolderUserID:          user.ID,
+		IdempotencyKey:        idempotencyKey,
+	}
+	if seasonPass != nil {
+		input.MaxActiveReservations = 0
+		input.SeasonPassID = &seasonPass.SeasonPassID
+	} else if visitPackSelected {
+		input.VisitPackID = &visitPackID
+	}
+	created, err := service.CreateReservation(ctx, input)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Court not found", http.StatusNotFound)
+		var limitErr reservationsvc.ReservationLimitError
+		if errors.As(err, &limitErr) {
+			writeReservationLimitError(w, r, limitErr.CurrentCount, limitErr.Limit)
 			return
 		}
-		logger.Error().Err(err).Int64("court_id", courtID).Msg("Failed to load court")
-		http.Error(w, "Failed to validate court", http.StatusInternalServerError)
-		return
-	}
-	if court.FacilityID != *user.HomeFacilityID {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+		var overlapErr reservationsvc.MemberOverlapError
+		if errors.As(err, &overlapErr) {
+			writeMemberOverlapError(w, r, overlapErr)
+			return
+		}
+		var availErr apiutil.AvailabilityError
+		if errors.As(err, &availErr) {
+			// Someone booked the court since the form loaded; have the form
+			// refetch slots so the court list matches again.
+			apiutil.AppendHXTrigger(w, "refreshMemberBookingSlots")
+		}
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			logger.Error().Err(herr.Err).Int64("facility_id", *user.HomeFacilityID).Msg(herr.Message)
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to create reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to create reservation")
 		return
 	}
 
-	reservationTypeID, err := lookupReservationTypeID(ctx, q, memberReservationTypeName)
+	response, err := reservationsvc.WithPrice(ctx, q, created)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to resolve reservation type")
-		http.Error(w, "Reservation type not available", http.StatusInternalServerError)
+		logger.Error().Err(err).Int64("reservation_id", created.ID).Msg("Failed to load reservation price")
+		response = reservationsvc.PricedReservation{Reservation: created}
+	}
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations")
+	if err := apiutil.WriteJSON(w, http.StatusCreated, response); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", created.ID).Msg("Failed to write reservation response")
 		return
 	}
+}
 
-	var created dbgen.Reservation
-	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
-		qtx := txdb.Queries
+// memberBookingSlot is the courts, time range and reservation type a member
+// asked for, once parseMemberBookingSlot has checked them.
+type memberBookingSlot struct {
+	startTime       time.Time
+	endTime         time.Time
+	courtIDs        []int64
+	reservationType dbgen.ReservationType
+}
 
-		if maxMemberReservations > 0 {
-			activeCount, err := qtx.CountActiveMemberReservations(ctx, dbgen.CountActiveMemberReservationsParams{
-				FacilityID:    *user.HomeFacilityID,
-				PrimaryUserID: sql.NullInt64{Int64: user.ID, Valid: true},
-			})
-			if err != nil {
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check reservation limits", Err: err}
-			}
-			if activeCount >= maxMemberReservations {
-				return reservationLimitError{currentCount: activeCount, limit: maxMemberReservations}
-			}
-		}
+// parseMemberBookingSlot reads start_time, end_time, court_ids and
+// reservation_type and checks them against the rules every member booking
+// follows: the tier's booking window, facility hours, prime time, the
+// per-booking court cap and the reservation type's duration. It writes the
+// error response and returns false when the slot is rejected.
+func (h *Handlers) parseMemberBookingSlot(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries, user *authz.AuthUser, facility *dbgen.Facility, facilityLoc *time.Location, maxAdvanceDays int64, tier dbgen.MembershipTier) (memberBookingSlot, bool) {
+	logger := log.Ctx(r.Context())
 
-		if err := apiutil.EnsureCourtsAvailable(ctx, qtx, *user.HomeFacilityID, 0, startTime, endTime, []int64{courtID}); err != nil {
-			var availErr apiutil.AvailabilityError
-			if errors.As(err, &availErr) {
-				return apiutil.HandlerError{Status: http.StatusConflict, Message: err.Error(), Err: err}
-			}
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check court availability", Err: err}
-		}
-
-		var err error
-		created, err = qtx.CreateReservation(ctx, dbgen.CreateReservationParams{
-			FacilityID:        *user.HomeFacilityID,
-			ReservationTypeID: reservationTypeID,
-			RecurrenceRuleID:  sql.NullInt64{},
-			PrimaryUserID:     sql.NullInt64{Int64: user.ID, Valid: true},
-			CreatedByUserID:   user.ID,
-			ProID:             sql.NullInt64{},
-			OpenPlayRuleID:    sql.NullInt64{},
-			StartTime:         startTime,
-			EndTime:           endTime,
-			IsOpenEvent:       false,
-			TeamsPerCourt:     sql.NullInt64{},
-			PeoplePerTeam:     sql.NullInt64{},
-		})
-		if err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to create reservation", Err: err}
-		}
+	startTime, err := apiutil.ParseFlexibleTime(r.FormValue("start_time"), "start_time", facilityLoc)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return memberBookingSlot{}, false
+	}
+	if startTime.Before(time.Now().In(facilityLoc)) {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "start_time must be in the future")
+		return memberBookingSlot{}, false
+	}
 
-		if err := qtx.AddReservationCourt(ctx, dbgen.AddReservationCourtParams{
-			ReservationID: created.ID,
-			CourtID:       courtID,
-		}); err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to assign reservation court", Err: err}
-		}
+	now := time.Now().In(facilityLoc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, facilityLoc)
+	maxDate := today.AddDate(0, 0, int(maxAdvanceDays))
+	startDay := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, facilityLoc)
+	if startDay.After(maxDate) {
+		apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("start_time must be within %d days for your membership level. Upgrade to book further in advance.", maxAdvanceDays))
+		return memberBookingSlot{}, false
+	}
 
-		if err := qtx.AddParticipant(ctx, dbgen.AddParticipantParams{
-			ReservationID: created.ID,
-			UserID:        user.ID,
-		}); err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation participant", Err: err}
+	endTime, err := apiutil.ParseFlexibleTime(r.FormValue("end_time"), "end_time", facilityLoc)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return memberBookingSlot{}, false
+	}
+	if !endTime.After(startTime) {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "end_time must be after start_time")
+		return memberBookingSlot{}, false
+	}
+	if err := apiutil.EnsureWithinHoursOverride(ctx, q, h.loadFacilities(), *user.HomeFacilityID, startTime, endTime); err != nil {
+		var overrideErr apiutil.HoursOverrideError
+		if errors.As(err, &overrideErr) {
+			apiutil.WriteError(w, r, http.StatusConflict, err.Error())
+			return memberBookingSlot{}, false
 		}
-
-		if visitPackSelected {
-			_, err := models.RedeemVisitPackVisit(ctx, qtx, models.RedeemVisitPackVisitParams{
-				VisitPackID:   visitPackID,
-				FacilityID:    *user.HomeFacilityID,
-				ReservationID: &created.ID,
-			})
-			if err != nil {
-				if errors.Is(err, models.ErrVisitPackUnavailable) {
-					return apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Selected visit pack is not available", Err: err}
-				}
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to redeem visit pack", Err: err}
-			}
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load facility hours override")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate facility hours")
+		return memberBookingSlot{}, false
+	}
+	if err := apiutil.EnsurePrimeTimeUnlocked(ctx, q, *user.HomeFacilityID, apiutil.PrimeTimeLevel(tier, user.MembershipLevel), startTime, endTime, now); err != nil {
+		var primeTimeErr apiutil.PrimeTimeLockedError
+		if errors.As(err, &primeTimeErr) {
+			apiutil.WriteError(w, r, http.StatusForbidden, err.Error())
+			return memberBookingSlot{}, false
 		}
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load prime time rules")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate prime time rules")
+		return memberBookingSlot{}, false
+	}
 
-		return nil
-	})
+	courtIDs, err := parseMemberCourtIDs(r)
 	if err != nil {
-		var limitErr reservationLimitError
-		if errors.As(err, &limitErr) {
-			message := fmt.Sprintf("You have reached the maximum of %d active reservations", limitErr.limit)
-			if err := apiutil.WriteJSON(w, http.StatusConflict, map[string]any{
-				"error":         message,
-				"current_count": limitErr.currentCount,
-				"limit":         limitErr.limit,
-			}); err != nil {
-				logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to write reservation limit response")
-			}
-			return
-		}
-		var herr apiutil.HandlerError
-		if errors.As(err, &herr) {
-			logger.Error().Err(herr.Err).Int64("facility_id", *user.HomeFacilityID).Msg(herr.Message)
-			http.Error(w, herr.Message, herr.Status)
-			return
+		apiutil.WriteValidationError(w, r, err)
+		return memberBookingSlot{}, false
+	}
+	maxCourts := int64(1)
+	if facility != nil && facility.MaxCourtsPerMemberBooking > 0 {
+		maxCourts = facility.MaxCourtsPerMemberBooking
+	}
+	if int64(len(courtIDs)) > maxCourts {
+		if maxCourts == 1 {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "You can book 1 court per reservation")
+		} else {
+			apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("You can book up to %d courts per reservation", maxCourts))
 		}
-		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to create reservation")
-		http.Error(w, "Failed to create reservation", http.StatusInternalServerError)
-		return
+		return memberBookingSlot{}, false
 	}
 
-	if emailClient != nil && facilityLoaded {
-		emailCtx, emailCancel := context.WithTimeout(context.Background(), portalQueryTimeout)
-		defer emailCancel()
-		cancellationPolicy, policyErr := cancellationPolicySummary(emailCtx, q, facility.ID, &reservationTypeID, startTime, now)
-		if policyErr != nil {
-			logger.Error().Err(policyErr).Int64("facility_id", facility.ID).Msg("Failed to load cancellation policy for confirmation email")
-			cancellationPolicy = "Contact the facility for cancellation policy details."
+	for _, courtID := range courtIDs {
+		court, err := q.GetCourt(ctx, courtID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				apiutil.WriteError(w, r, http.StatusNotFound, "Court not found")
+				return memberBookingSlot{}, false
+			}
+			logger.Error().Err(err).Int64("court_id", courtID).Msg("Failed to load court")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate court")
+			return memberBookingSlot{}, false
+		}
+		if court.FacilityID != *user.HomeFacilityID {
+			apiutil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+			return memberBookingSlot{}, false
 		}
-		date, timeRange := email.FormatDateTimeRange(startTime.In(facilityLoc), endTime.In(facilityLoc))
-		confirmation := email.BuildGameConfirmation(email.ConfirmationDetails{
-			FacilityName:       facility.Name,
-			Date:               date,
-			TimeRange:          timeRange,
-			Courts:             court.Name,
-			CancellationPolicy: cancellationPolicy,
-		})
-		email.SendConfirmationEmail(emailCtx, q, emailClient, user.ID, confirmation, logger)
 	}
 
-	w.Header().Set("HX-Trigger", "refreshMemberReservations")
-	if err := apiutil.WriteJSON(w, http.StatusCreated, created); err != nil {
-		logger.Error().Err(err).Int64("reservation_id", created.ID).Msg("Failed to write reservation response")
-		return
+	reservationTypeName := strings.TrimSpace(r.FormValue("reservation_type"))
+	if reservationTypeName == "" {
+		reservationTypeName = memberReservationTypeName
 	}
+	reservationType, err := lookupMemberReservationType(ctx, q, *user.HomeFacilityID, reservationTypeName)
+	if err != nil {
+		writeMemberReservationTypeError(w, r, logger, reservationTypeName, err)
+		return memberBookingSlot{}, false
+	}
+	if violation := apiutil.ReservationTypeDuration(facility, &reservationType).Violation(endTime.Sub(startTime)); violation != "" {
+		apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("Reservation must be %s", violation))
+		return memberBookingSlot{}, false
+	}
+	return memberBookingSlot{
+		startTime:       startTime,
+		endTime:         endTime,
+		courtIDs:        courtIDs,
+		reservationType: reservationType,
+	}, true
 }
 
 // HandleMemberReservationCancel handles DELETE /member/reservations/{id}.
-func HandleMemberReservationCancel(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleMemberReservationCancel(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
-	database := loadDB()
-	if q == nil || database == nil {
+	q := h.loadQueries()
+	service := h.loadService()
+	if q == nil || service == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	reservationID, err := apiutil.ReservationIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
 		return
 	}
 
@@ -831,167 +1465,48 @@ func HandleMemberReservationCancel(w http.ResponseWriter, r *http.Request) {
 
 	confirmCancellation := requestCancellationConfirm(r)
 
-	var refundPercentage int64
-	var reservation dbgen.Reservation
-	var reservationCourts []dbgen.ListReservationCourtsRow
-	var reservationParticipants []dbgen.ListParticipantsForReservationRow
-	var reservationTypeName string
-	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
-		qtx := txdb.Queries
-
-		loadedReservation, err := qtx.GetReservationByID(ctx, reservationID)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Reservation not found", Err: err}
-			}
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch reservation", Err: err}
-		}
-		reservation = loadedReservation
-		if !reservation.PrimaryUserID.Valid || reservation.PrimaryUserID.Int64 != user.ID {
-			return apiutil.HandlerError{Status: http.StatusForbidden, Message: "Forbidden"}
-		}
-		now := time.Now()
-		if !reservation.StartTime.After(now) {
-			return apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Reservation must be in the future"}
-		}
-		facilityID := reservation.FacilityID
-		hoursUntilReservation := hoursUntilReservationStart(reservation.StartTime, now)
-		refundPercentage, err = apiutil.ApplicableRefundPercentage(ctx, qtx, facilityID, hoursUntilReservation, &reservation.ReservationTypeID)
-		if err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load cancellation policy", Err: err}
-		}
-		buildPenalty := func(calculatedAt time.Time, refundPercentage int64, hoursBeforeStart int64) (membertempl.CancellationPenaltyData, error) {
-			courts, err := qtx.ListReservationCourts(ctx, reservationID)
-			if err != nil {
-				return membertempl.CancellationPenaltyData{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation courts", Err: err}
-			}
-			facility, err := qtx.GetFacilityByID(ctx, facilityID)
-			if err != nil {
-				return membertempl.CancellationPenaltyData{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load facility", Err: err}
+	// A household primary may cancel their dependents' reservations.
+	ownerID, err := householdReservationOwner(ctx, q, user.ID, reservationID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to load reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation")
+		return
+	}
+
+	// Members cancel self-booked lessons here, so the pro hears about it and
+	// any redeemed package lesson comes back, as with the staff API. Portal
+	// cancellations still do not offer the slot to the waitlist.
+	result, err := service.CancelReservation(ctx, reservationsvc.CancelInput{
+		ReservationID:         reservationID,
+		CancelledByUserID:     user.ID,
+		OwnerID:               ownerID,
+		RestoreLessonPackages: true,
+		NotifyPro:             true,
+		IssueCredit:           true,
+		ConfirmPenalty: func(ctx context.Context, q *dbgen.Queries, penalty reservationsvc.CancellationPenalty) (bool, error) {
+			if !confirmCancellation {
+				return false, nil
 			}
-			return membertempl.CancellationPenaltyData{
-				ReservationID:    reservationID,
-				RefundPercentage: refundPercentage,
-				FeePercentage:    100 - refundPercentage,
-				HoursBeforeStart: hoursBeforeStart,
-				StartTime:        reservation.StartTime,
-				EndTime:          reservation.EndTime,
-				CourtName:        apiutil.ReservationCourtLabel(courts),
-				FacilityName:     facility.Name,
-				ExpiresAt:        calculatedAt.Add(cancellationPenaltyWindow),
-				CalculatedAt:     calculatedAt,
-			}, nil
-		}
-
-		if refundPercentage < 100 {
-			if confirmCancellation {
-				previousCalculatedAt, previousHours, ok := requestCancellationPenaltyDetails(r)
-				if !ok {
-					penalty, err := buildPenalty(now, refundPercentage, hoursUntilReservation)
-					if err != nil {
-						return err
-					}
-					return cancellationPenaltyError{Penalty: penalty}
-				}
-				penaltyExpired := now.Sub(previousCalculatedAt) > cancellationPenaltyWindow
-				if penaltyExpired {
-					penalty, err := buildPenalty(now, refundPercentage, hoursUntilReservation)
-					if err != nil {
-						return err
-					}
-					return cancellationPenaltyError{Penalty: penalty}
-				}
-				previousRefundPercentage, err := apiutil.ApplicableRefundPercentage(ctx, qtx, facilityID, previousHours, &reservation.ReservationTypeID)
-				if err != nil {
-					return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load cancellation policy", Err: err}
-				}
-				if previousRefundPercentage != refundPercentage {
-					penalty, err := buildPenalty(now, refundPercentage, hoursUntilReservation)
-					if err != nil {
-						return err
-					}
-					return cancellationPenaltyError{Penalty: penalty}
-				}
-			} else {
-				penalty, err := buildPenalty(now, refundPercentage, hoursUntilReservation)
-				if err != nil {
-					return err
-				}
-				return cancellationPenaltyError{Penalty: penalty}
+			previousCalculatedAt, previousHours, ok := requestCancellationPenaltyDetails(r)
+			if !ok || penalty.CalculatedAt.Sub(previousCalculatedAt) > cancellationPenaltyWindow {
+				return false, nil
 			}
-		}
-		if _, err := qtx.LogCancellation(ctx, dbgen.LogCancellationParams{
-			ReservationID:           reservationID,
-			CancelledByUserID:       user.ID,
-			CancelledAt:             now,
-			RefundPercentageApplied: refundPercentage,
-			FeeWaived:               false,
-			HoursBeforeStart:        hoursUntilReservation,
-		}); err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to log cancellation", Err: err}
-		}
-
-		courts, err := qtx.ListReservationCourts(ctx, reservationID)
-		if err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation courts", Err: err}
-		}
-		reservationCourts = courts
-		for _, court := range courts {
-			if err := qtx.RemoveReservationCourt(ctx, dbgen.RemoveReservationCourtParams{
-				ReservationID: reservationID,
-				CourtID:       court.CourtID,
-			}); err != nil {
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation court", Err: err}
+			// A policy edited since the modal was shown can change the refund;
+			// show the new terms again rather than apply ones the member never saw.
+			if shownRefund, ok := requestCancellationShownRefund(r); ok && shownRefund != penalty.RefundPercentage {
+				return false, nil
 			}
-		}
-
-		participants, err := qtx.ListParticipantsForReservation(ctx, reservationID)
-		if err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation participants", Err: err}
-		}
-		reservationParticipants = participants
-		for _, participant := range participants {
-			if err := qtx.RemoveParticipant(ctx, dbgen.RemoveParticipantParams{
-				ReservationID: reservationID,
-				UserID:        participant.ID,
-			}); err != nil {
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation participant", Err: err}
+			previousRefundPercentage, err := apiutil.ApplicableRefundPercentage(ctx, q, penalty.Reservation.FacilityID, previousHours, &penalty.Reservation.ReservationTypeID)
+			if err != nil {
+				return false, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load cancellation policy", Err: err}
 			}
-		}
-
-		name, err := qtx.GetReservationTypeNameByReservationID(ctx, reservationID)
-		if err != nil {
-			logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to load reservation type for cancellation email")
-			reservationTypeName = memberReservationTypeName
-		} else {
-			reservationTypeName = name
-		}
-
-		return nil
+			return previousRefundPercentage == penalty.RefundPercentage, nil
+		},
 	})
 	if err != nil {
-		var penaltyErr cancellationPenaltyError
+		var penaltyErr reservationsvc.CancellationPenaltyError
 		if errors.As(err, &penaltyErr) {
-			if apiutil.IsJSONRequest(r) {
-				if err := apiutil.WriteJSON(w, http.StatusConflict, penaltyErr.Penalty); err != nil {
-					logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write cancellation penalty response")
-				}
-				return
-			}
-			var buf bytes.Buffer
-			component := membertempl.CancellationConfirmModal(penaltyErr.Penalty)
-			if err := component.Render(r.Context(), &buf); err != nil {
-				logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to render cancellation confirmation modal")
-				http.Error(w, "Failed to render modal", http.StatusInternalServerError)
-				return
-			}
-			w.Header().Set("Content-Type", "text/html")
-			w.Header().Set("HX-Retarget", "#modal")
-			w.Header().Set("HX-Reswap", "innerHTML")
-			w.WriteHeader(http.StatusConflict)
-			if _, err := w.Write(buf.Bytes()); err != nil {
-				logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write cancellation confirmation modal")
-			}
+			h.writeCancellationPenalty(ctx, w, r, q, penaltyErr.Penalty)
 			return
 		}
 		var herr apiutil.HandlerError
@@ -999,76 +1514,101 @@ func HandleMemberReservationCancel(w http.ResponseWriter, r *http.Request) {
 			if herr.Status == http.StatusInternalServerError {
 				logger.Error().Err(herr.Err).Int64("reservation_id", reservationID).Msg(herr.Message)
 			}
-			http.Error(w, herr.Message, herr.Status)
+			apiutil.WriteHandlerError(w, r, herr)
 			return
 		}
 		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to cancel reservation")
-		http.Error(w, "Failed to cancel reservation", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to cancel reservation")
 		return
 	}
 
-	if emailClient != nil && reservation.ID != 0 {
-		emailCtx, emailCancel := context.WithTimeout(context.Background(), portalQueryTimeout)
-		defer emailCancel()
-		facility, err := q.GetFacilityByID(emailCtx, reservation.FacilityID)
-		if err != nil {
-			logger.Error().Err(err).Int64("facility_id", reservation.FacilityID).Msg("Failed to load facility for cancellation email")
-		} else {
-			facilityLoc := time.Local
-			if facility.Timezone != "" {
-				if loadedLoc, loadErr := time.LoadLocation(facility.Timezone); loadErr == nil {
-					facilityLoc = loadedLoc
-				} else {
-					logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone for cancellation email")
-				}
-			}
-			date, timeRange := email.FormatDateTimeRange(reservation.StartTime.In(facilityLoc), reservation.EndTime.In(facilityLoc))
-			courtLabel := apiutil.ReservationCourtLabel(reservationCourts)
-			refund := refundPercentage
-			message := email.BuildCancellationEmail(email.CancellationDetails{
-				FacilityName:     facility.Name,
-				ReservationType:  reservationTypeName,
-				Date:             date,
-				TimeRange:        timeRange,
-				Courts:           courtLabel,
-				RefundPercentage: &refund,
-			})
-			sender := email.ResolveFromAddress(emailCtx, q, facility, logger)
-			recipients := make(map[int64]struct{}, len(reservationParticipants)+1)
-			for _, participant := range reservationParticipants {
-				recipients[participant.ID] = struct{}{}
-			}
-			if reservation.PrimaryUserID.Valid {
-				recipients[reservation.PrimaryUserID.Int64] = struct{}{}
-			}
-			for participantID := range recipients {
-				email.SendCancellationEmail(emailCtx, q, emailClient, participantID, message, sender, logger)
-			}
-		}
+	response := map[string]any{
+		"refund_percentage": result.RefundPercentage,
 	}
-
-	w.Header().Set("HX-Trigger", "refreshMemberReservations")
-	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{
-		"refund_percentage": refundPercentage,
-	}); err != nil {
+	if result.RefundAmountCents != nil {
+		response["refund_amount_cents"] = *result.RefundAmountCents
+	}
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations")
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
 		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write cancellation response")
 		return
 	}
 }
 
+// writeCancellationPenalty answers a 409 with the refund the member would
+// forfeit: JSON for API clients, otherwise the confirmation modal.
+func (h *Handlers) writeCancellationPenalty(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries, penaltyDetails reservationsvc.CancellationPenalty) {
+	logger := log.Ctx(r.Context())
+	reservationID := penaltyDetails.Reservation.ID
+
+	penalty, err := h.memberCancellationPenaltyData(ctx, q, penaltyDetails)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to load cancellation penalty details")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation courts")
+		return
+	}
+	if apiutil.IsJSONRequest(r) {
+		if err := apiutil.WriteJSON(w, http.StatusConflict, penalty); err != nil {
+			logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write cancellation penalty response")
+		}
+		return
+	}
+	var buf bytes.Buffer
+	component := membertempl.CancellationConfirmModal(penalty)
+	if err := component.Render(r.Context(), &buf); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to render cancellation confirmation modal")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render modal")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.Header().Set("HX-Retarget", "#modal")
+	w.Header().Set("HX-Reswap", "innerHTML")
+	w.WriteHeader(http.StatusConflict)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write cancellation confirmation modal")
+	}
+}
+
+// memberCancellationPenaltyData describes a held-back cancellation for the
+// confirmation modal.
+func (h *Handlers) memberCancellationPenaltyData(ctx context.Context, q *dbgen.Queries, penalty reservationsvc.CancellationPenalty) (membertempl.CancellationPenaltyData, error) {
+	reservation := penalty.Reservation
+	courts, err := q.ListReservationCourts(ctx, reservation.ID)
+	if err != nil {
+		return membertempl.CancellationPenaltyData{}, err
+	}
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, reservation.FacilityID)
+	if err != nil {
+		return membertempl.CancellationPenaltyData{}, err
+	}
+	return membertempl.CancellationPenaltyData{
+		ReservationID:     reservation.ID,
+		RefundPercentage:  penalty.RefundPercentage,
+		RefundAmountCents: penalty.RefundAmountCents(),
+		FeePercentage:     100 - penalty.RefundPercentage,
+		HoursBeforeStart:  penalty.HoursBeforeStart,
+		StartTime:         reservation.StartTime,
+		EndTime:           reservation.EndTime,
+		CourtName:         apiutil.ReservationCourtLabel(courts),
+		FacilityName:      facility.Name,
+		ExpiresAt:         penalty.CalculatedAt.Add(cancellationPenaltyWindow),
+		CalculatedAt:      penalty.CalculatedAt,
+	}, nil
+}
+
 // HandleMemberOpenPlayList renders upcoming open play sessions for members.
-func HandleMemberOpenPlayList(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleMemberOpenPlayList(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -1078,7 +1618,7 @@ func HandleMemberOpenPlayList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
@@ -1091,12 +1631,25 @@ func HandleMemberOpenPlayList(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load open play sessions")
-		http.Error(w, "Failed to load open play sessions", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load open play sessions")
 		return
 	}
 
+	rating, err := loadMemberSkillRating(ctx, q, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load member skill rating")
+	}
+
 	summaries := membertempl.NewOpenPlaySessionSummaries(rows)
 	for i := range summaries {
+		summaries[i].EligibilityLabel = apiutil.OpenPlayEligibilityLabel(rows[i].MinMembershipLevel, rows[i].MaxMembershipLevel)
+		summaries[i].Ineligible = !apiutil.OpenPlayEligible(user.MembershipLevel, rows[i].MinMembershipLevel, rows[i].MaxMembershipLevel)
+		bands, err := apiutil.EffectiveOpenPlaySkillBands(rows[i].RuleSkillBands, rows[i].SessionSkillBands)
+		if err != nil {
+			logger.Error().Err(err).Int64("session_id", summaries[i].ID).Msg("Failed to parse open play skill bands")
+		} else {
+			applyOpenPlaySkillBand(&summaries[i], bands, rating)
+		}
 		isParticipant, err := q.IsMemberOpenPlayParticipant(ctx, dbgen.IsMemberOpenPlayParticipantParams{
 			SessionID:  summaries[i].ID,
 			FacilityID: *user.HomeFacilityID,
@@ -1115,26 +1668,235 @@ func HandleMemberOpenPlayList(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// HandleMemberOpenPlayDetail handles GET /member/openplay/{id}.
+func (h *Handlers) HandleMemberOpenPlayDetail(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodGet {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	sessionID, err := memberOpenPlaySessionIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	session, err := q.GetOpenPlaySession(ctx, dbgen.GetOpenPlaySessionParams{
+		ID:         sessionID,
+		FacilityID: *user.HomeFacilityID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Open play session not found")
+			return
+		}
+		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to fetch open play session")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch open play session")
+		return
+	}
+
+	rule, err := q.GetOpenPlayRule(ctx, dbgen.GetOpenPlayRuleParams{
+		ID:         session.OpenPlayRuleID,
+		FacilityID: *user.HomeFacilityID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("open_play_rule_id", session.OpenPlayRuleID).Msg("Failed to fetch open play rule")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch open play rule")
+		return
+	}
+
+	participants, err := q.ListOpenPlayParticipantsForSession(ctx, dbgen.ListOpenPlayParticipantsForSessionParams{
+		SessionID:  sessionID,
+		FacilityID: *user.HomeFacilityID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to load open play participants")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load open play participants")
+		return
+	}
+
+	// Courts are assigned when the session's reservation is created; until
+	// then only the planned count is known.
+	courtsLabel := fmt.Sprintf("%d courts", session.CurrentCourtCount)
+	if session.CurrentCourtCount == 1 {
+		courtsLabel = "1 court"
+	}
+	reservationID, err := q.GetOpenPlayReservationID(ctx, dbgen.GetOpenPlayReservationIDParams{
+		FacilityID:     *user.HomeFacilityID,
+		OpenPlayRuleID: sql.NullInt64{Int64: session.OpenPlayRuleID, Valid: true},
+		StartTime:      session.StartTime,
+		EndTime:        session.EndTime,
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to load open play reservation")
+		}
+	} else {
+		courts, err := q.ListReservationCourts(ctx, reservationID)
+		if err != nil {
+			logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to load open play courts")
+		} else if len(courts) > 0 {
+			courtsLabel = apiutil.ReservationCourtLabel(courts)
+		}
+	}
+
+	hideFromRosters := false
+	member, err := q.GetUserByID(ctx, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load member roster privacy")
+	} else {
+		hideFromRosters = member.HideFromRosters
+	}
+
+	data := membertempl.OpenPlaySessionDetailData{
+		Session: membertempl.OpenPlaySessionSummary{
+			ID:               session.ID,
+			RuleName:         rule.Name,
+			StartTime:        session.StartTime,
+			EndTime:          session.EndTime,
+			Status:           session.Status,
+			ParticipantCount: session.ParticipantCount,
+			MinParticipants:  rule.MinParticipants,
+			EligibilityLabel: apiutil.OpenPlayEligibilityLabel(rule.MinMembershipLevel, rule.MaxMembershipLevel),
+			Ineligible:       !apiutil.OpenPlayEligible(user.MembershipLevel, rule.MinMembershipLevel, rule.MaxMembershipLevel),
+		},
+		Courts:             courtsLabel,
+		CancellationCutoff: session.StartTime.Add(-time.Duration(rule.CancellationCutoffMinutes) * time.Minute),
+		Capacity:           rule.MaxParticipantsPerCourt * session.CurrentCourtCount,
+		HideFromRosters:    hideFromRosters,
+	}
+	skillBands, err := q.GetOpenPlaySessionSkillBands(ctx, dbgen.GetOpenPlaySessionSkillBandsParams{
+		SessionID:  sessionID,
+		FacilityID: *user.HomeFacilityID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to load open play skill bands")
+	} else if bands, err := apiutil.EffectiveOpenPlaySkillBands(skillBands.RuleSkillBands, skillBands.SessionSkillBands); err != nil {
+		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to parse open play skill bands")
+	} else if len(bands) > 0 {
+		rating, err := loadMemberSkillRating(ctx, q, user.ID)
+		if err != nil {
+			logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load member skill rating")
+		}
+		applyOpenPlaySkillBand(&data.Session, bands, rating)
+	}
+	for _, participant := range participants {
+		switch {
+		case participant.ID == user.ID:
+			data.Session.IsSignedUp = true
+			data.Participants = append(data.Participants, "You")
+		case participant.HideFromRosters:
+			data.HiddenCount++
+		default:
+			data.Participants = append(data.Participants, participant.FirstName)
+		}
+	}
+	if data.Session.IsSignedUp {
+		queue, err := openplayengine.Queue(ctx, q, sessionID)
+		if err != nil {
+			logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to load open play queue")
+		}
+		for _, player := range queue {
+			if player.UserID == user.ID {
+				data.QueuePosition = player.Position
+				break
+			}
+		}
+	}
+
+	component := membertempl.MemberOpenPlaySessionDetail(data)
+	if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render open play session", "Failed to render open play session") {
+		return
+	}
+}
+
+// HandleMemberRosterPrivacyUpdate handles POST /member/roster-privacy.
+func (h *Handlers) HandleMemberRosterPrivacyUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodPost {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	// Unchecked checkboxes are omitted from the form, so absence means visible.
+	hide := apiutil.ParseBool(r.FormValue("hide_from_rosters"))
+	if err := q.UpdateUserHideFromRosters(ctx, dbgen.UpdateUserHideFromRostersParams{
+		ID:              user.ID,
+		HideFromRosters: hide,
+	}); err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to update roster privacy")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update roster privacy")
+		return
+	}
+
+	apiutil.AppendHXTrigger(w, "refreshMemberOpenPlay")
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // HandleMemberOpenPlaySignup handles POST /member/openplay/{id}.
-func HandleMemberOpenPlaySignup(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleMemberOpenPlaySignup(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
-	database := loadDB()
+	q := h.loadQueries()
+	database := h.loadDB()
 	if q == nil || database == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	sessionID, err := memberOpenPlaySessionIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid session ID")
 		return
 	}
 
@@ -1144,7 +1906,7 @@ func HandleMemberOpenPlaySignup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
@@ -1152,11 +1914,25 @@ func HandleMemberOpenPlaySignup(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	maxMemberReservations := int64(0)
-	facility, err := q.GetFacilityByID(ctx, *user.HomeFacilityID)
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, *user.HomeFacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load facility booking config")
+		if !errors.Is(err, sql.ErrNoRows) {
+			writeFacilityConfigUnavailable(w, r)
+			return
+		}
 	} else {
-		maxMemberReservations = facility.MaxMemberReservations
+		tier, err := apiutil.LoadMembershipTier(ctx, q, facility.OrganizationID, user.MembershipLevel)
+		if err != nil {
+			logger.Error().Err(err).Int64("organization_id", facility.OrganizationID).Int64("membership_level", user.MembershipLevel).Msg("Failed to load membership tier")
+			writeFacilityConfigUnavailable(w, r)
+			return
+		}
+		maxMemberReservations = apiutil.MemberReservationLimit(tier, facility.MaxMemberReservations)
+	}
+
+	if !h.ensureNoNoShowRestriction(ctx, w, r, q, *user.HomeFacilityID, user.ID) {
+		return
 	}
 
 	var participant dbgen.ReservationParticipant
@@ -1166,10 +1942,7 @@ func HandleMemberOpenPlaySignup(w http.ResponseWriter, r *http.Request) {
 		qtx := txdb.Queries
 
 		if maxMemberReservations > 0 {
-			activeCount, err := qtx.CountActiveMemberReservations(ctx, dbgen.CountActiveMemberReservationsParams{
-				FacilityID:    *user.HomeFacilityID,
-				PrimaryUserID: sql.NullInt64{Int64: user.ID, Valid: true},
-			})
+			activeCount, err := reservationsvc.CountActiveReservations(ctx, qtx, *user.HomeFacilityID, user.ID, facility.ReservationLimitScope)
 			if err != nil {
 				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check reservation limits", Err: err}
 			}
@@ -1205,9 +1978,29 @@ func HandleMemberOpenPlaySignup(w http.ResponseWriter, r *http.Request) {
 			}
 			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch open play rule", Err: err}
 		}
+		if !apiutil.OpenPlayEligible(user.MembershipLevel, rule.MinMembershipLevel, rule.MaxMembershipLevel) {
+			message := fmt.Sprintf("This open play session is open to %s", apiutil.OpenPlayEligibilityLabel(rule.MinMembershipLevel, rule.MaxMembershipLevel))
+			return apiutil.HandlerError{Status: http.StatusForbidden, Message: message}
+		}
+		// Sessions split by skill fill band by band; a band holds
+		// MaxParticipantsPerCourt on each of its courts.
+		band, banded, err := memberOpenPlaySkillBand(ctx, qtx, sessionID, *user.HomeFacilityID, user.ID)
+		if err != nil {
+			return err
+		}
 		maxParticipants := rule.MaxParticipantsPerCourt * session.CurrentCourtCount
-		if session.ParticipantCount >= maxParticipants {
-			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Session is full"}
+		filled := session.ParticipantCount
+		fullMessage := "Session is full"
+		if banded {
+			maxParticipants = band.Capacity(rule.MaxParticipantsPerCourt)
+			filled, err = countOpenPlaySkillBandParticipants(ctx, qtx, sessionID, *user.HomeFacilityID, band)
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check open play capacity", Err: err}
+			}
+			fullMessage = fmt.Sprintf("The %s skill band is full", band.Label())
+		}
+		if filled >= maxParticipants {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: fullMessage}
 		}
 
 		isParticipant, err := qtx.IsMemberOpenPlayParticipant(ctx, dbgen.IsMemberOpenPlayParticipantParams{
@@ -1221,6 +2014,9 @@ func HandleMemberOpenPlaySignup(w http.ResponseWriter, r *http.Request) {
 		if isParticipant > 0 {
 			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Already signed up"}
 		}
+		if err := reservationsvc.EnsureNoMemberOverlap(ctx, qtx, user.ID, session.StartTime, session.EndTime); err != nil {
+			return err
+		}
 
 		if err := ensureOpenPlayReservation(ctx, qtx, session, *user.HomeFacilityID); err != nil {
 			return err
@@ -1240,15 +2036,21 @@ func HandleMemberOpenPlaySignup(w http.ResponseWriter, r *http.Request) {
 			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add open play participant", Err: err}
 		}
 
-		updatedSession, err := qtx.GetOpenPlaySession(ctx, dbgen.GetOpenPlaySessionParams{
-			ID:         sessionID,
-			FacilityID: *user.HomeFacilityID,
-		})
+		if banded {
+			filled, err = countOpenPlaySkillBandParticipants(ctx, qtx, sessionID, *user.HomeFacilityID, band)
+		} else {
+			var updatedSession dbgen.GetOpenPlaySessionRow
+			updatedSession, err = qtx.GetOpenPlaySession(ctx, dbgen.GetOpenPlaySessionParams{
+				ID:         sessionID,
+				FacilityID: *user.HomeFacilityID,
+			})
+			filled = updatedSession.ParticipantCount
+		}
 		if err != nil {
 			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to verify open play capacity", Err: err}
 		}
-		if updatedSession.ParticipantCount > maxParticipants {
-			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Session is full"}
+		if filled > maxParticipants {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: fullMessage}
 		}
 
 		return nil
@@ -1257,7 +2059,12 @@ func HandleMemberOpenPlaySignup(w http.ResponseWriter, r *http.Request) {
 		var limitErr reservationLimitError
 		if errors.As(err, &limitErr) {
 			message := fmt.Sprintf("You have reached the maximum of %d active reservations", limitErr.limit)
-			http.Error(w, message, http.StatusConflict)
+			apiutil.WriteError(w, r, http.StatusConflict, message)
+			return
+		}
+		var overlapErr reservationsvc.MemberOverlapError
+		if errors.As(err, &overlapErr) {
+			writeMemberOverlapError(w, r, overlapErr)
 			return
 		}
 		var herr apiutil.HandlerError
@@ -1265,25 +2072,19 @@ func HandleMemberOpenPlaySignup(w http.ResponseWriter, r *http.Request) {
 			if herr.Status == http.StatusInternalServerError {
 				logger.Error().Err(herr.Err).Int64("session_id", sessionID).Msg(herr.Message)
 			}
-			http.Error(w, herr.Message, herr.Status)
+			apiutil.WriteHandlerError(w, r, herr)
 			return
 		}
 		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to sign up for open play")
-		http.Error(w, "Failed to sign up for open play", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to sign up for open play")
 		return
 	}
+	metrics.OpenPlaySignup(*user.HomeFacilityID)
 
-	if emailClient != nil && facility.ID != 0 {
-		emailCtx, emailCancel := context.WithTimeout(context.Background(), portalQueryTimeout)
+	if h.emailClient != nil && facility.ID != 0 {
+		emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(r.Context()), portalQueryTimeout)
 		defer emailCancel()
-		facilityLoc := time.Local
-		if facility.Timezone != "" {
-			if loadedLoc, loadErr := time.LoadLocation(facility.Timezone); loadErr == nil {
-				facilityLoc = loadedLoc
-			} else {
-				logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone for confirmation email")
-			}
-		}
+		facilityLoc := apiutil.FacilityLocation(facility, logger)
 		date, timeRange := email.FormatDateTimeRange(session.StartTime.In(facilityLoc), session.EndTime.In(facilityLoc))
 		courtsLabel := fmt.Sprintf("%d courts", session.CurrentCourtCount)
 		if session.CurrentCourtCount == 1 {
@@ -1297,36 +2098,124 @@ func HandleMemberOpenPlaySignup(w http.ResponseWriter, r *http.Request) {
 			Courts:             courtsLabel,
 			CancellationPolicy: cancellationPolicy,
 		})
-		email.SendConfirmationEmail(emailCtx, q, emailClient, user.ID, confirmation, logger)
+		if email.ShouldSend(emailCtx, q, user.ID, email.PreferenceConfirmations) {
+			email.SendConfirmationEmail(emailCtx, q, h.emailClient, user.ID, confirmation, logger)
+		}
 	}
 
-	w.Header().Set("HX-Trigger", "refreshMemberReservations,refreshMemberOpenPlay")
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations", "refreshMemberOpenPlay")
 	if err := apiutil.WriteJSON(w, http.StatusCreated, participant); err != nil {
 		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to write open play signup response")
 		return
 	}
 }
 
+// loadMemberSkillRating returns the member's skill rating, invalid when
+// staff have not rated them.
+func loadMemberSkillRating(ctx context.Context, q *dbgen.Queries, userID int64) (sql.NullFloat64, error) {
+	rating, err := q.GetMemberSkillRating(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.NullFloat64{}, nil
+		}
+		return sql.NullFloat64{}, err
+	}
+	return sql.NullFloat64{Float64: rating.Rating, Valid: true}, nil
+}
+
+// applyOpenPlaySkillBand shows the member's band on a session split by skill,
+// or marks the session ineligible when no band takes their rating.
+func applyOpenPlaySkillBand(summary *membertempl.OpenPlaySessionSummary, bands []apiutil.OpenPlaySkillBand, rating sql.NullFloat64) {
+	if len(bands) == 0 || summary.Ineligible {
+		return
+	}
+	if !rating.Valid {
+		summary.Ineligible = true
+		summary.IneligibleReason = "Requires a skill rating; ask the front desk to rate you"
+		return
+	}
+	band, ok := apiutil.OpenPlaySkillBandFor(bands, rating.Float64)
+	if !ok {
+		summary.Ineligible = true
+		summary.IneligibleReason = fmt.Sprintf("No skill band for your %.1f rating", rating.Float64)
+		return
+	}
+	summary.SkillBand = fmt.Sprintf("%s · %s", band.Label(), band.CourtsLabel())
+}
+
+// memberOpenPlaySkillBand finds the band the member signs up into. banded is
+// false when the session is not split by skill.
+func memberOpenPlaySkillBand(ctx context.Context, q *dbgen.Queries, sessionID, facilityID, userID int64) (apiutil.OpenPlaySkillBand, bool, error) {
+	row, err := q.GetOpenPlaySessionSkillBands(ctx, dbgen.GetOpenPlaySessionSkillBandsParams{
+		SessionID:  sessionID,
+		FacilityID: facilityID,
+	})
+	if err != nil {
+		return apiutil.OpenPlaySkillBand{}, false, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load open play skill bands", Err: err}
+	}
+	bands, err := apiutil.EffectiveOpenPlaySkillBands(row.RuleSkillBands, row.SessionSkillBands)
+	if err != nil {
+		return apiutil.OpenPlaySkillBand{}, false, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load open play skill bands", Err: err}
+	}
+	if len(bands) == 0 {
+		return apiutil.OpenPlaySkillBand{}, false, nil
+	}
+
+	rating, err := loadMemberSkillRating(ctx, q, userID)
+	if err != nil {
+		return apiutil.OpenPlaySkillBand{}, false, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load skill rating", Err: err}
+	}
+	if !rating.Valid {
+		return apiutil.OpenPlaySkillBand{}, false, apiutil.HandlerError{Status: http.StatusForbidden, Message: "This open play session is grouped by skill; a skill rating is required to join"}
+	}
+	band, ok := apiutil.OpenPlaySkillBandFor(bands, rating.Float64)
+	if !ok {
+		message := fmt.Sprintf("No skill band in this open play session fits your %.1f rating", rating.Float64)
+		return apiutil.OpenPlaySkillBand{}, false, apiutil.HandlerError{Status: http.StatusForbidden, Message: message}
+	}
+	return band, true, nil
+}
+
+// countOpenPlaySkillBandParticipants counts the session's participants rated
+// within band. Unrated participants, such as staff-added guests, count
+// against no band.
+func countOpenPlaySkillBandParticipants(ctx context.Context, q *dbgen.Queries, sessionID, facilityID int64, band apiutil.OpenPlaySkillBand) (int64, error) {
+	ratings, err := q.ListOpenPlayParticipantSkillRatings(ctx, dbgen.ListOpenPlayParticipantSkillRatingsParams{
+		SessionID:  sessionID,
+		FacilityID: facilityID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	for _, participant := range ratings {
+		if participant.Rating.Valid && band.Contains(participant.Rating.Float64) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // HandleMemberOpenPlayCancel handles DELETE /member/openplay/{id}.
-func HandleMemberOpenPlayCancel(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleMemberOpenPlayCancel(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
-	database := loadDB()
+	q := h.loadQueries()
+	database := h.loadDB()
 	if q == nil || database == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	sessionID, err := memberOpenPlaySessionIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid session ID")
 		return
 	}
 
@@ -1336,7 +2225,7 @@ func HandleMemberOpenPlayCancel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
@@ -1410,6 +2299,12 @@ func HandleMemberOpenPlayCancel(w http.ResponseWriter, r *http.Request) {
 		if removed == 0 {
 			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Open play participant not found"}
 		}
+		if err := qtx.DeleteOpenPlayQueueEntry(ctx, dbgen.DeleteOpenPlayQueueEntryParams{
+			SessionID: session.ID,
+			UserID:    user.ID,
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to cancel open play signup", Err: err}
+		}
 
 		return nil
 	})
@@ -1419,35 +2314,94 @@ func HandleMemberOpenPlayCancel(w http.ResponseWriter, r *http.Request) {
 			if herr.Status == http.StatusInternalServerError {
 				logger.Error().Err(herr.Err).Int64("session_id", sessionID).Msg(herr.Message)
 			}
-			http.Error(w, herr.Message, herr.Status)
+			apiutil.WriteError(w, r, herr.Status, herr.Message)
 			return
 		}
 		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to cancel open play signup")
-		http.Error(w, "Failed to cancel open play signup", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to cancel open play signup")
 		return
 	}
 
-	w.Header().Set("HX-Trigger", "refreshMemberReservations,refreshMemberOpenPlay")
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations", "refreshMemberOpenPlay")
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func lookupReservationTypeID(ctx context.Context, q *dbgen.Queries, name string) (int64, error) {
+// errReservationTypeNotBookable marks reservation types staff have not opened
+// to member self-booking.
+var errReservationTypeNotBookable = errors.New("reservation type is not bookable by members")
+
+// lookupMemberReservationType resolves a reservation type a member is booking
+// at facilityID. Types scoped to another organization or facility are not
+// found, and types closed to members fail with errReservationTypeNotBookable.
+func lookupMemberReservationType(ctx context.Context, q *dbgen.Queries, facilityID int64, name string) (dbgen.ReservationType, error) {
 	resType, err := q.GetReservationTypeByName(ctx, name)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return 0, fmt.Errorf("reservation type %q not found", name)
+			return dbgen.ReservationType{}, fmt.Errorf("reservation type %q: %w", name, err)
+		}
+		return dbgen.ReservationType{}, err
+	}
+	if _, err := q.GetReservationTypeForFacility(ctx, dbgen.GetReservationTypeForFacilityParams{
+		ID:         resType.ID,
+		FacilityID: sql.NullInt64{Int64: facilityID, Valid: true},
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbgen.ReservationType{}, fmt.Errorf("reservation type %q at facility %d: %w", name, facilityID, err)
+		}
+		return dbgen.ReservationType{}, err
+	}
+	if !resType.MemberBookable {
+		return resType, errReservationTypeNotBookable
+	}
+	return resType, nil
+}
+
+// listMemberCourtReservationTypes returns the types members may book a court
+// as at facilityID, the default type first. Lessons have their own booking
+// flow and are left out.
+func listMemberCourtReservationTypes(ctx context.Context, q *dbgen.Queries, facilityID int64) ([]membertempl.MemberReservationTypeOption, error) {
+	rows, err := q.ListReservationTypesForFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	options := make([]membertempl.MemberReservationTypeOption, 0, len(rows))
+	for _, row := range rows {
+		if !row.MemberBookable || strings.EqualFold(row.Name, lessonReservationTypeName) {
+			continue
 		}
-		return 0, err
+		label := row.Name
+		if row.Description.Valid && strings.TrimSpace(row.Description.String) != "" {
+			label = strings.TrimSpace(row.Description.String)
+		}
+		option := membertempl.MemberReservationTypeOption{
+			Name:               row.Name,
+			Label:              label,
+			MinDurationMinutes: row.MinDurationMinutes.Int64,
+			MaxDurationMinutes: row.MaxDurationMinutes.Int64,
+		}
+		if strings.EqualFold(row.Name, memberReservationTypeName) {
+			options = append([]membertempl.MemberReservationTypeOption{option}, options...)
+			continue
+		}
+		options = append(options, option)
 	}
-	return resType.ID, nil
+	return options, nil
 }
 
-func hoursUntilReservationStart(start time.Time, now time.Time) int64 {
-	hours := int64(start.Sub(now).Hours())
-	if hours < 0 {
-		return 0
+// writeMemberReservationTypeError reports a lookupMemberReservationType
+// failure.
+func writeMemberReservationTypeError(w http.ResponseWriter, r *http.Request, logger *zerolog.Logger, name string, err error) {
+	switch {
+	case errors.Is(err, errReservationTypeNotBookable):
+		logger.Warn().Str("reservation_type", name).Msg("Member booking rejected: reservation type not bookable by members")
+		apiutil.WriteError(w, r, http.StatusForbidden, "This reservation type can't be booked online. Please contact the front desk.")
+	case errors.Is(err, sql.ErrNoRows):
+		logger.Warn().Err(err).Str("reservation_type", name).Msg("Member booking rejected: unknown reservation type")
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Reservation type not available")
+	default:
+		logger.Error().Err(err).Str("reservation_type", name).Msg("Failed to resolve reservation type")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Reservation type not available")
 	}
-	return hours
 }
 
 func requestCancellationConfirm(r *http.Request) bool {
@@ -1490,6 +2444,25 @@ func requestCancellationPenaltyDetails(r *http.Request) (time.Time, int64, bool)
 	return calculatedAt, hoursBeforeStart, true
 }
 
+// requestCancellationShownRefund returns the refund percentage the
+// confirmation modal showed, when the request carries it.
+func requestCancellationShownRefund(r *http.Request) (int64, bool) {
+	raw := strings.TrimSpace(r.URL.Query().Get("refund_percentage"))
+	if raw == "" {
+		if err := r.ParseForm(); err == nil {
+			raw = strings.TrimSpace(r.FormValue("refund_percentage"))
+		}
+	}
+	if raw == "" {
+		return 0, false
+	}
+	refundPercentage, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return refundPercentage, true
+}
+
 func requestedFacilityID(r *http.Request) *int64 {
 	rawID := r.URL.Query().Get("facility_id")
 	if rawID == "" {
@@ -1502,36 +2475,102 @@ func requestedFacilityID(r *http.Request) *int64 {
 	return &parsed
 }
 
-func buildReservationListData(
+// reservationListFilter narrows the portal reservation list to a facility and
+// an inclusive range of facility-local dates (YYYY-MM-DD).
+type reservationListFilter struct {
+	FacilityID *int64
+	From       string
+	To         string
+}
+
+func parseReservationListFilter(r *http.Request) (reservationListFilter, error) {
+	filter := reservationListFilter{
+		FacilityID: requestedFacilityID(r),
+		From:       strings.TrimSpace(r.URL.Query().Get("from")),
+		To:         strings.TrimSpace(r.URL.Query().Get("to")),
+	}
+	var from, to time.Time
+	var err error
+	if filter.From != "" {
+		if from, err = time.Parse(reservationFilterDateLayout, filter.From); err != nil {
+			return reservationListFilter{}, fmt.Errorf("from must be a date in YYYY-MM-DD format")
+		}
+	}
+	if filter.To != "" {
+		if to, err = time.Parse(reservationFilterDateLayout, filter.To); err != nil {
+			return reservationListFilter{}, fmt.Errorf("to must be a date in YYYY-MM-DD format")
+		}
+	}
+	if filter.From != "" && filter.To != "" && to.Before(from) {
+		return reservationListFilter{}, fmt.Errorf("to must not be before from")
+	}
+	return filter, nil
+}
+
+// bounds converts the filter dates to instants in the facility's timezone. The
+// upper bound is exclusive: midnight after the to date.
+func (f reservationListFilter) bounds(loc *time.Location) (from, before *time.Time) {
+	if f.From != "" {
+		if date, err := time.ParseInLocation(reservationFilterDateLayout, f.From, loc); err == nil {
+			from = &date
+		}
+	}
+	if f.To != "" {
+		if date, err := time.ParseInLocation(reservationFilterDateLayout, f.To, loc); err == nil {
+			next := date.AddDate(0, 0, 1)
+			before = &next
+		}
+	}
+	return from, before
+}
+
+// location returns the facility timezone for interpreting the filter dates,
+// skipping the lookup when no dates are set.
+func (f reservationListFilter) location(ctx context.Context, facilities apiutil.FacilityQuerier, facilityID int64) *time.Location {
+	if f.From == "" && f.To == "" {
+		return time.Local
+	}
+	return memberFacilityLocation(ctx, facilities, facilityID)
+}
+
+func (f reservationListFilter) moreURL(section string, facilityID int64, offset int) string {
+	values := url.Values{}
+	values.Set("section", section)
+	values.Set("facility_id", strconv.FormatInt(facilityID, 10))
+	values.Set("offset", strconv.Itoa(offset))
+	if f.From != "" {
+		values.Set("from", f.From)
+	}
+	if f.To != "" {
+		values.Set("to", f.To)
+	}
+	return "/member/reservations?" + values.Encode()
+}
+
+func (h *Handlers) buildReservationListData(
 	ctx context.Context,
 	q *dbgen.Queries,
 	userID int64,
 	homeFacilityID *int64,
-	requestedFacilityID *int64,
+	filter reservationListFilter,
 	logger *zerolog.Logger,
 ) (membertempl.ReservationListData, error) {
-	rows, err := q.ListReservationsByUserID(ctx, sql.NullInt64{Int64: userID, Valid: true})
+	facilityRows, err := q.ListReservationFacilitiesByUserID(ctx, sql.NullInt64{Int64: userID, Valid: true})
 	if err != nil {
 		return membertempl.ReservationListData{}, err
 	}
 
-	facilitiesByID := make(map[int64]string, len(rows))
-	for _, row := range rows {
-		facilitiesByID[row.FacilityID] = row.FacilityName
-	}
-
-	facilities := make([]membertempl.ReservationFacility, 0, len(facilitiesByID))
-	for id, name := range facilitiesByID {
-		facilities = append(facilities, membertempl.ReservationFacility{ID: id, Name: name})
+	facilities := make([]membertempl.ReservationFacility, 0, len(facilityRows))
+	facilitiesByID := make(map[int64]struct{}, len(facilityRows))
+	for _, row := range facilityRows {
+		facilities = append(facilities, membertempl.ReservationFacility{ID: row.ID, Name: row.Name})
+		facilitiesByID[row.ID] = struct{}{}
 	}
-	sort.Slice(facilities, func(i, j int) bool {
-		return strings.ToLower(facilities[i].Name) < strings.ToLower(facilities[j].Name)
-	})
 
 	selectedFacilityID := int64(0)
-	if requestedFacilityID != nil {
-		if _, ok := facilitiesByID[*requestedFacilityID]; ok {
-			selectedFacilityID = *requestedFacilityID
+	if filter.FacilityID != nil {
+		if _, ok := facilitiesByID[*filter.FacilityID]; ok {
+			selectedFacilityID = *filter.FacilityID
 		}
 	}
 	if selectedFacilityID == 0 && homeFacilityID != nil {
@@ -1543,69 +2582,289 @@ func buildReservationListData(
 		selectedFacilityID = facilities[0].ID
 	}
 
-	var filteredRows []dbgen.ListReservationsByUserIDRow
-	if selectedFacilityID != 0 {
-		filteredRows = make([]dbgen.ListReservationsByUserIDRow, 0, len(rows))
-		for _, row := range rows {
-			if row.FacilityID == selectedFacilityID {
-				filteredRows = append(filteredRows, row)
-			}
+	showFilter := len(facilities) > 1 || homeFacilityID == nil
+	if len(facilities) == 0 {
+		showFilter = false
+	}
+
+	data := membertempl.ReservationListData{
+		Facilities:         facilities,
+		SelectedFacilityID: selectedFacilityID,
+		ShowFacilityFilter: showFilter,
+		FromDate:           filter.From,
+		ToDate:             filter.To,
+	}
+	if selectedFacilityID == 0 {
+		return data, nil
+	}
+
+	now := time.Now()
+	loc := filter.location(ctx, h.loadFacilities(), selectedFacilityID)
+	upcoming, err := fetchReservationPage(ctx, q, userID, selectedFacilityID, reservationSectionUpcoming, filter, loc, 0, now)
+	if err != nil {
+		return membertempl.ReservationListData{}, err
+	}
+	past, err := fetchReservationPage(ctx, q, userID, selectedFacilityID, reservationSectionPast, filter, loc, 0, now)
+	if err != nil {
+		return membertempl.ReservationListData{}, err
+	}
+	enrichReservationSummaries(ctx, q, userID, now, upcoming.Reservations, past.Reservations, logger)
+	data.Upcoming = upcoming.Reservations
+	data.UpcomingMoreURL = upcoming.MoreURL
+	data.Past = past.Reservations
+	data.PastMoreURL = past.MoreURL
+	return data, nil
+}
+
+// loadReservationPage loads one page of a member's upcoming or past
+// reservations at a facility for the "load more" button.
+func (h *Handlers) loadReservationPage(
+	ctx context.Context,
+	q *dbgen.Queries,
+	userID int64,
+	facilityID int64,
+	section string,
+	filter reservationListFilter,
+	offset int,
+	logger *zerolog.Logger,
+) (membertempl.ReservationPage, error) {
+	now := time.Now()
+	page, err := fetchReservationPage(ctx, q, userID, facilityID, section, filter, filter.location(ctx, h.loadFacilities(), facilityID), offset, now)
+	if err != nil {
+		return membertempl.ReservationPage{}, err
+	}
+	if section == reservationSectionUpcoming {
+		enrichReservationSummaries(ctx, q, userID, now, page.Reservations, nil, logger)
+	} else {
+		enrichReservationSummaries(ctx, q, userID, now, nil, page.Reservations, logger)
+	}
+	return page, nil
+}
+
+// fetchReservationPage queries one page of reservations without participants
+// or refund percentages. Upcoming reservations run soonest first and past
+// reservations most recent first; MoreURL is set when another page exists.
+func fetchReservationPage(
+	ctx context.Context,
+	q *dbgen.Queries,
+	userID int64,
+	facilityID int64,
+	section string,
+	filter reservationListFilter,
+	loc *time.Location,
+	offset int,
+	now time.Time,
+) (membertempl.ReservationPage, error) {
+	from, before := filter.bounds(loc)
+
+	pageSize := memberUpcomingReservationsPageSize
+	params := dbgen.ListReservationsByUserIDParams{
+		UserID:     sql.NullInt64{Int64: userID, Valid: true},
+		FacilityID: facilityID,
+		Offset:     int64(offset),
+	}
+	switch section {
+	case reservationSectionUpcoming:
+		params.OldestFirst = true
+		params.StartFrom = now
+		if from != nil && from.After(now) {
+			params.StartFrom = *from
+		}
+		if before != nil {
+			params.StartBefore = *before
+		}
+	case reservationSectionPast:
+		pageSize = memberPastReservationsPageSize
+		if from != nil {
+			params.StartFrom = *from
+		}
+		params.StartBefore = now
+		if before != nil && before.Before(now) {
+			params.StartBefore = *before
 		}
+	default:
+		return membertempl.ReservationPage{}, fmt.Errorf("unknown reservation section %q", section)
 	}
+	// Fetch one extra row to learn whether a further page exists.
+	params.Limit = int64(pageSize + 1)
 
-	summaries := membertempl.NewReservationSummaries(filteredRows)
-	for i := range summaries {
-		participants, err := q.ListParticipantsForReservation(ctx, summaries[i].ID)
+	rows, err := q.ListReservationsByUserID(ctx, params)
+	if err != nil {
+		return membertempl.ReservationPage{}, err
+	}
+	page := membertempl.ReservationPage{Section: section}
+	if len(rows) > pageSize {
+		rows = rows[:pageSize]
+		page.MoreURL = filter.moreURL(section, facilityID, offset+pageSize)
+	}
+	page.Reservations = membertempl.NewReservationSummaries(rows)
+	return page, nil
+}
+
+// enrichReservationSummaries fills in the other participants and invitations
+// on every summary and the refund percentage, and when it next drops, on
+// upcoming ones, using one batched query for each
+// rather than one per reservation. Lookup failures are logged and leave the
+// defaults in place, so the list still renders.
+func enrichReservationSummaries(
+	ctx context.Context,
+	q *dbgen.Queries,
+	userID int64,
+	now time.Time,
+	upcoming []membertempl.ReservationSummary,
+	past []membertempl.ReservationSummary,
+	logger *zerolog.Logger,
+) {
+	reservationIDs := make([]int64, 0, len(upcoming)+len(past))
+	for _, summaries := range [][]membertempl.ReservationSummary{upcoming, past} {
+		for _, summary := range summaries {
+			reservationIDs = append(reservationIDs, summary.ID)
+		}
+	}
+	if len(reservationIDs) > 0 {
+		participants, err := q.ListParticipantsForReservations(ctx, reservationIDs)
 		if err != nil {
-			logger.Error().Err(err).Int64("reservation_id", summaries[i].ID).Msg("Failed to load reservation participants")
-			continue
+			logger.Error().Err(err).Msg("Failed to load reservation participants")
+		} else {
+			names := make(map[int64][]string, len(reservationIDs))
+			for _, participant := range participants {
+				if participant.ID == userID {
+					continue
+				}
+				name := strings.TrimSpace(strings.TrimSpace(participant.FirstName) + " " + strings.TrimSpace(participant.LastName))
+				if name == "" && participant.Email.Valid {
+					name = participant.Email.String
+				}
+				if name != "" {
+					names[participant.ReservationID] = append(names[participant.ReservationID], name)
+				}
+			}
+			for _, summaries := range [][]membertempl.ReservationSummary{upcoming, past} {
+				for i := range summaries {
+					summaries[i].OtherParticipants = names[summaries[i].ID]
+				}
+			}
 		}
-		names := make([]string, 0, len(participants))
-		for _, participant := range participants {
-			if participant.ID == userID {
-				continue
+
+		guests, err := q.ListReservationGuestsForReservations(ctx, reservationIDs)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to load reservation guests")
+		} else {
+			guestNames := make(map[int64][]string, len(reservationIDs))
+			for _, guest := range guests {
+				guestNames[guest.ReservationID] = append(guestNames[guest.ReservationID], guest.Name)
+			}
+			for _, summaries := range [][]membertempl.ReservationSummary{upcoming, past} {
+				for i := range summaries {
+					summaries[i].Guests = guestNames[summaries[i].ID]
+				}
 			}
-			name := strings.TrimSpace(strings.TrimSpace(participant.FirstName) + " " + strings.TrimSpace(participant.LastName))
-			if name == "" && participant.Email.Valid {
-				name = participant.Email.String
+		}
+
+		invitations, err := q.ListReservationInvitationsForReservations(ctx, reservationIDs)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to load reservation invitations")
+		} else {
+			byReservation := make(map[int64][]membertempl.ReservationInvitationSummary, len(reservationIDs))
+			for _, invitation := range invitations {
+				byReservation[invitation.ReservationID] = append(byReservation[invitation.ReservationID], membertempl.ReservationInvitationSummary{
+					Name:   strings.TrimSpace(strings.TrimSpace(invitation.FirstName) + " " + strings.TrimSpace(invitation.LastName)),
+					Status: invitation.Status,
+				})
 			}
-			if name != "" {
-				names = append(names, name)
+			for _, summaries := range [][]membertempl.ReservationSummary{upcoming, past} {
+				for i := range summaries {
+					summaries[i].Invitations = byReservation[summaries[i].ID]
+				}
 			}
 		}
-		summaries[i].OtherParticipants = names
 	}
-
-	now := time.Now()
-	var upcoming []membertempl.ReservationSummary
-	var past []membertempl.ReservationSummary
-	for i := range summaries {
-		if summaries[i].StartTime.After(now) {
-			hoursUntilReservation := hoursUntilReservationStart(summaries[i].StartTime, now)
-			refundPercentage, err := apiutil.ApplicableRefundPercentage(ctx, q, summaries[i].FacilityID, hoursUntilReservation, &summaries[i].ReservationTypeID)
-			if err != nil {
-				logger.Error().Err(err).Int64("facility_id", summaries[i].FacilityID).Msg("Failed to load cancellation policy refund percentage")
-				refundPercentage = 100
+	dependents, err := q.ListActiveHouseholdDependents(ctx, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load household dependents")
+	} else if len(dependents) > 0 {
+		dependentNames := make(map[int64]string, len(dependents))
+		for _, dependent := range dependents {
+			dependentNames[dependent.ID] = strings.TrimSpace(dependent.FirstName + " " + dependent.LastName)
+		}
+		for _, summaries := range [][]membertempl.ReservationSummary{upcoming, past} {
+			for i := range summaries {
+				summaries[i].BookedFor = dependentNames[summaries[i].PrimaryUserID]
 			}
-			summaries[i].RefundPercentage = refundPercentage
-			upcoming = append(upcoming, summaries[i])
+		}
+	}
+	if len(reservationIDs) > 0 {
+		notes, err := q.ListReservationMemberNotesForReservations(ctx, reservationIDs)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to load reservation member notes")
 		} else {
-			past = append(past, summaries[i])
+			noteByReservation := make(map[int64]string, len(notes))
+			for _, note := range notes {
+				noteByReservation[note.ReservationID] = note.Note
+			}
+			for _, summaries := range [][]membertempl.ReservationSummary{upcoming, past} {
+				for i := range summaries {
+					if summaries[i].PrimaryUserID == userID || summaries[i].BookedFor != "" {
+						summaries[i].MemberNote = noteByReservation[summaries[i].ID]
+					}
+				}
+			}
 		}
 	}
+	for i := range upcoming {
+		upcoming[i].CanInvite = upcoming[i].PrimaryUserID == userID &&
+			strings.EqualFold(upcoming[i].ReservationTypeName, memberReservationTypeName)
+		upcoming[i].CanReschedule = upcoming[i].PrimaryUserID == userID &&
+			!upcoming[i].IsOpenEvent && !upcoming[i].IsProSession()
+	}
 
-	showFilter := len(facilities) > 1 || homeFacilityID == nil
-	if len(facilities) == 0 {
-		showFilter = false
+	if len(past) > 0 {
+		pastIDs := make([]int64, 0, len(past))
+		for _, summary := range past {
+			pastIDs = append(pastIDs, summary.ID)
+		}
+		checkedIn, err := q.ListCheckedInReservationIDs(ctx, dbgen.ListCheckedInReservationIDsParams{
+			UserID:         userID,
+			ReservationIds: pastIDs,
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to load reservation check-ins")
+		} else {
+			checkedInIDs := make(map[int64]struct{}, len(checkedIn))
+			for _, reservationID := range checkedIn {
+				checkedInIDs[reservationID] = struct{}{}
+			}
+			for i := range past {
+				_, past[i].CheckedIn = checkedInIDs[past[i].ID]
+			}
+		}
 	}
 
-	return membertempl.ReservationListData{
-		Upcoming:           upcoming,
-		Past:               past,
-		Facilities:         facilities,
-		SelectedFacilityID: selectedFacilityID,
-		ShowFacilityFilter: showFilter,
-	}, nil
+	if len(upcoming) == 0 {
+		return
+	}
+	facilityIDs := make([]int64, 0, 1)
+	seenFacilities := make(map[int64]struct{}, 1)
+	for _, summary := range upcoming {
+		if _, ok := seenFacilities[summary.FacilityID]; !ok {
+			seenFacilities[summary.FacilityID] = struct{}{}
+			facilityIDs = append(facilityIDs, summary.FacilityID)
+		}
+	}
+	tiers, err := apiutil.LoadCancellationTiers(ctx, q, facilityIDs)
+	if err != nil {
+		logger.Error().Err(err).Ints64("facility_ids", facilityIDs).Msg("Failed to load cancellation policy refund percentage")
+	}
+	// A failed load leaves no tiers, so every reservation falls back to a
+	// full refund just as a failed per-reservation lookup did.
+	for i := range upcoming {
+		hoursUntilReservation := reservationsvc.HoursUntilStart(upcoming[i].StartTime, now)
+		upcoming[i].RefundPercentage = tiers.RefundPercentage(upcoming[i].FacilityID, hoursUntilReservation, &upcoming[i].ReservationTypeID)
+		if dropsAt, dropsTo, ok := tiers.NextRefundDrop(upcoming[i].FacilityID, upcoming[i].StartTime, hoursUntilReservation, &upcoming[i].ReservationTypeID); ok {
+			upcoming[i].RefundDropsAt = dropsAt
+			upcoming[i].RefundDropsTo = dropsTo
+		}
+	}
 }
 
 func buildReservationWidgetData(
@@ -1613,29 +2872,101 @@ func buildReservationWidgetData(
 	q *dbgen.Queries,
 	userID int64,
 ) (membertempl.ReservationWidgetData, error) {
-	rows, err := q.ListReservationsByUserID(ctx, sql.NullInt64{Int64: userID, Valid: true})
+	rows, err := q.ListReservationsByUserID(ctx, dbgen.ListReservationsByUserIDParams{
+		UserID:      sql.NullInt64{Int64: userID, Valid: true},
+		StartFrom:   time.Now(),
+		OldestFirst: true,
+		Limit:       memberReservationsWidgetLimit,
+	})
 	if err != nil {
 		return membertempl.ReservationWidgetData{}, err
 	}
 
+	return membertempl.NewReservationWidgetData(membertempl.NewReservationSummaries(rows)), nil
+}
+
+// calendarFeedUserID resolves the member for a calendar feed request from the
+// member session or, for calendar apps, a signed subscription token.
+func (h *Handlers) calendarFeedUserID(r *http.Request) (int64, bool) {
+	if user := authz.UserFromContext(r.Context()); user != nil && user.SessionType == auth.SessionTypeMember {
+		return user.ID, true
+	}
+	if h.calendarFeedSigner == nil {
+		return 0, false
+	}
+	userID, err := strconv.ParseInt(r.URL.Query().Get("member"), 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, false
+	}
+	if !h.calendarFeedSigner.Verify(userID, r.URL.Query().Get("token")) {
+		return 0, false
+	}
+	return userID, true
+}
+
+// memberCalendarFeedURL returns the absolute subscription URL for a member's
+// reservations feed, or "" when feeds are not configured.
+func (h *Handlers) memberCalendarFeedURL(userID int64) string {
+	if h.calendarFeedSigner == nil {
+		return ""
+	}
+	return h.absoluteURL(fmt.Sprintf("/member/reservations/export.ics?member=%d&token=%s", userID, h.calendarFeedSigner.Token(userID)))
+}
+
+func buildMemberReservationsCalendar(rows []dbgen.ListReservationsByUserIDRow, logger *zerolog.Logger) ical.Calendar {
 	summaries := membertempl.NewReservationSummaries(rows)
-	now := time.Now()
-	upcoming := make([]membertempl.ReservationSummary, 0, len(summaries))
-	for _, summary := range summaries {
-		if summary.StartTime.After(now) {
-			upcoming = append(upcoming, summary)
-		}
+	events := make([]ical.Event, 0, len(rows))
+	for i, row := range rows {
+		loc := apiutil.TimezoneLocation(row.FacilityTimezone, logger)
+
+		summary := summaries[i]
+		description := "Court: " + summary.CourtLabel()
+		if summary.IsProSession() {
+			description += "\nPro: " + summary.ProName()
+		}
+		events = append(events, ical.Event{
+			UID:         fmt.Sprintf("reservation-%d@pickleicious", row.ID),
+			Start:       row.StartTime,
+			End:         row.EndTime,
+			TimeZone:    loc,
+			Summary:     fmt.Sprintf("%s at %s", email.ReservationTypeLabel(summary.ReservationTypeName), row.FacilityName),
+			Location:    row.FacilityName,
+			Description: description,
+			Updated:     row.UpdatedAt,
+		})
+	}
+	return ical.Calendar{
+		ProdID: memberCalendarProdID,
+		Name:   "Pickleicious Reservations",
+		Events: events,
 	}
+}
 
-	sort.Slice(upcoming, func(i, j int) bool {
-		return upcoming[i].StartTime.Before(upcoming[j].StartTime)
-	})
+func writeMemberCalendar(w http.ResponseWriter, calendar ical.Calendar, filename string, logger *zerolog.Logger) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if filename != "" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	}
+	if _, err := w.Write(calendar.Bytes()); err != nil {
+		logger.Error().Err(err).Msg("Failed to write calendar export")
+	}
+}
 
-	return membertempl.NewReservationWidgetData(upcoming), nil
+// memberBookingLocation returns the location member booking dates and slots
+// are built in: the facility's, or the default when it failed to load.
+func memberBookingLocation(facility *dbgen.Facility, logger *zerolog.Logger) *time.Location {
+	if facility == nil {
+		return apiutil.DefaultLocation()
+	}
+	return apiutil.FacilityLocation(*facility, logger)
 }
 
-func bookingDateFromRequest(r *http.Request, maxAdvanceDays int64) time.Time {
-	now := time.Now()
+// bookingDateFromRequest returns midnight of the requested booking day,
+// clamped to today through maxAdvanceDays ahead. now must already be in
+// facility time; the day and its bounds are built in now's location so they
+// match what HandleMemberBookingCreate validates.
+func bookingDateFromRequest(r *http.Request, maxAdvanceDays int64, now time.Time) time.Time {
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	if maxAdvanceDays <= 0 {
 		maxAdvanceDays = apiutil.DefaultMaxAdvanceDays
@@ -1688,13 +3019,120 @@ func clampBookingDate(selected time.Time, min time.Time, max time.Time) time.Tim
 	return selected
 }
 
+// memberBookingSlotQueries is the subset of queries buildMemberBookingSlots
+// needs; the whole day loads in a fixed number of round trips regardless of
+// slot count.
+type memberBookingSlotQueries interface {
+	GetFacilityHours(ctx context.Context, facilityID int64) ([]dbgen.OperatingHour, error)
+	apiutil.HoursOverrideQuerier
+	apiutil.CourtBlocksQuerier
+	apiutil.PrimeTimeRulesQuerier
+	pricing.RulesQuerier
+}
+
+// buildMemberBookingSlots lists the day's bookable slots. Slots start every
+// granularity.Slot from opening time and last granularity.SlotLength().
+// Prime-time slots that primeTimeLevel cannot book yet are kept but marked
+// locked so the form can say when they open up. Each slot carries its
+// per-court price at the member's rate when the facility prices it. Only
+// courts matching courtFilter count towards a slot's availability. baseDate
+// must be midnight in facility time; slots follow its wall clock, so times
+// skipped when clocks spring forward are never offered.
 func buildMemberBookingSlots(
 	ctx context.Context,
-	q *dbgen.Queries,
+	q memberBookingSlotQueries,
 	facilityID int64,
+	membershipLevel int64,
+	primeTimeLevel int64,
 	baseDate time.Time,
+	now time.Time,
+	granularity apiutil.BookingGranularity,
+	courtFilter apiutil.CourtFilter,
 	logger *zerolog.Logger,
 ) ([]membertempl.MemberBookingSlot, error) {
+	buildStart := time.Now()
+	defer func() { metrics.ObserveBookingSlots(time.Since(buildStart)) }()
+
+	dayOpen, dayClose, open, err := memberBookingDayHours(ctx, q, facilityID, baseDate, logger)
+	if err != nil {
+		return nil, err
+	}
+	if !open {
+		return nil, nil
+	}
+
+	slotStart := dayOpen
+	now = now.In(baseDate.Location())
+	if sameDay(now, baseDate) && now.After(dayOpen) {
+		slotStart = roundUpToSlot(now, dayOpen, granularity.Slot)
+	}
+
+	availability, err := apiutil.LoadDayCourtAvailability(ctx, q, facilityID, slotStart, dayClose)
+	if err != nil {
+		return nil, err
+	}
+	availability = availability.Filter(courtFilter)
+	primeTimeRules, err := apiutil.LoadPrimeTimeRules(ctx, q, facilityID, baseDate)
+	if err != nil {
+		return nil, err
+	}
+	pricingRules, err := q.ListCourtPricingRules(ctx, facilityID)
+	if err != nil {
+		return nil, err
+	}
+
+	var slots []membertempl.MemberBookingSlot
+	slotLength := granularity.SlotLength()
+	for start := slotStart; !start.Add(slotLength).After(dayClose); start = nextSlotStart(start, granularity.Slot) {
+		end := start.Add(slotLength)
+		slotAvailability := availability.Slot(start, end)
+		if slotAvailability.AvailableCourts == 0 {
+			continue
+		}
+		closures := make([]membertempl.MemberCourtClosure, 0, len(slotAvailability.Closures))
+		for _, closure := range slotAvailability.Closures {
+			closures = append(closures, membertempl.MemberCourtClosure{
+				CourtNumbers: closure.CourtNumbers,
+				Reason:       closure.PublicReason,
+				Until:        closure.Until,
+			})
+		}
+		slot := membertempl.MemberBookingSlot{
+			StartTime:       start,
+			EndTime:         end,
+			AvailableCourts: slotAvailability.AvailableCourts,
+			TotalCourts:     slotAvailability.TotalCourts,
+			Closures:        closures,
+			FreeCourtIDs:    slotAvailability.FreeCourtIDs,
+		}
+		if unlocksAt, _, ok := apiutil.PrimeTimeUnlock(primeTimeRules, primeTimeLevel, start, end); ok && now.Before(unlocksAt) {
+			slot.Locked = true
+			slot.UnlocksAt = unlocksAt
+		}
+		if quote := pricing.Price(pricingRules, pricing.Request{
+			Start:  start,
+			End:    end,
+			Courts: 1,
+			Member: membershipLevel >= pricing.MemberLevel,
+		}); quote.Priced {
+			slot.PriceCents = &quote.PerCourtCents
+		}
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
+
+// memberBookingDayHours returns the hours members can book on baseDate: its
+// override when one exists, otherwise the weekly schedule, falling back to
+// the default hours for weekdays without a row. open is false when the
+// facility is closed that day. baseDate must be midnight in facility time.
+func memberBookingDayHours(
+	ctx context.Context,
+	q memberBookingSlotQueries,
+	facilityID int64,
+	baseDate time.Time,
+	logger *zerolog.Logger,
+) (dayOpen, dayClose time.Time, open bool, err error) {
 	opensAt := memberBookingDefaultOpensAt
 	closesAt := memberBookingDefaultClosesAt
 
@@ -1727,54 +3165,31 @@ func buildMemberBookingSlots(
 		closeTime, _ = parseBookingTimeOfDay(memberBookingDefaultClosesAt, "closes_at")
 	}
 
-	dayOpen := time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), openTime.Hour(), openTime.Minute(), 0, 0, baseDate.Location())
-	dayClose := time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), closeTime.Hour(), closeTime.Minute(), 0, 0, baseDate.Location())
-	if !dayClose.After(dayOpen) {
-		return nil, nil
-	}
+	dayOpen = time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), openTime.Hour(), openTime.Minute(), 0, 0, baseDate.Location())
+	dayClose = time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), closeTime.Hour(), closeTime.Minute(), 0, 0, baseDate.Location())
 
-	slotStart := dayOpen
-	now := time.Now().In(baseDate.Location())
-	if sameDay(now, baseDate) && now.After(dayOpen) {
-		slotStart = roundUpToHour(now)
-		if slotStart.Before(dayOpen) {
-			slotStart = dayOpen
-		}
+	override, err := apiutil.LoadFacilityHoursOverride(ctx, q, facilityID, baseDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, err
 	}
-
-	var slots []membertempl.MemberBookingSlot
-	for start := slotStart; start.Add(memberBookingMinDuration).Before(dayClose) || start.Add(memberBookingMinDuration).Equal(dayClose); start = start.Add(memberBookingMinDuration) {
-		available, err := q.ListAvailableCourts(ctx, dbgen.ListAvailableCourtsParams{
-			FacilityID:    facilityID,
-			ReservationID: 0,
-			StartTime:     start,
-			EndTime:       start.Add(memberBookingMinDuration),
-		})
-		if err != nil {
-			return nil, err
-		}
-		if len(available) == 0 {
-			continue
-		}
-		slots = append(slots, membertempl.MemberBookingSlot{
-			StartTime: start,
-			EndTime:   start.Add(memberBookingMinDuration),
-		})
+	if override != nil {
+		dayOpen, dayClose, open = apiutil.HoursOverrideWindow(*override, baseDate)
+		return dayOpen, dayClose, open && dayClose.After(dayOpen), nil
 	}
-	return slots, nil
+	return dayOpen, dayClose, dayClose.After(dayOpen), nil
 }
 
-func waitlistFallbackTimes(baseDate time.Time, slots []membertempl.MemberBookingSlot) (time.Time, time.Time) {
+func waitlistFallbackTimes(baseDate time.Time, slots []membertempl.MemberBookingSlot, slotLength time.Duration) (time.Time, time.Time) {
 	if len(slots) > 0 {
 		return slots[0].StartTime, slots[0].EndTime
 	}
 	openTime, err := parseBookingTimeOfDay(memberBookingDefaultOpensAt, "opens_at")
 	if err != nil {
 		start := time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), 9, 0, 0, 0, baseDate.Location())
-		return start, start.Add(memberBookingMinDuration)
+		return start, start.Add(slotLength)
 	}
 	start := time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), openTime.Hour(), openTime.Minute(), 0, 0, baseDate.Location())
-	return start, start.Add(memberBookingMinDuration)
+	return start, start.Add(slotLength)
 }
 
 func parseBookingTimeOfDay(raw string, field string) (time.Time, error) {
@@ -1792,27 +3207,16 @@ func parseBookingTimeOfDay(raw string, field string) (time.Time, error) {
 	return parsed, nil
 }
 
-func parseMemberBookingTime(raw string, field string, loc *time.Location) (time.Time, error) {
-	raw = strings.TrimSpace(raw)
-	if raw == "" {
-		return time.Time{}, fmt.Errorf("%s is required", field)
-	}
-	parsed, err := time.ParseInLocation(memberBookingTimeLayout, raw, loc)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("%s must be in YYYY-MM-DDTHH:MM format", field)
-	}
-	return parsed, nil
-}
-
-func buildWaitlistEntrySummaries(ctx context.Context, q *dbgen.Queries, rows []dbgen.Waitlist, logger *zerolog.Logger) []waitlisttempl.WaitlistEntry {
+func (h *Handlers) buildWaitlistEntrySummaries(ctx context.Context, q *dbgen.Queries, rows []dbgen.Waitlist, logger *zerolog.Logger) []waitlisttempl.WaitlistEntry {
 	entries := make([]waitlisttempl.WaitlistEntry, 0, len(rows))
 	facilityNames := make(map[int64]string)
 	courtNames := make(map[int64]string)
+	now := time.Now()
 
 	for _, row := range rows {
 		facilityName, ok := facilityNames[row.FacilityID]
 		if !ok {
-			facility, err := q.GetFacilityByID(ctx, row.FacilityID)
+			facility, err := h.loadFacilities().GetFacilityByID(ctx, row.FacilityID)
 			if err != nil {
 				logger.Error().Err(err).Int64("facility_id", row.FacilityID).Msg("Failed to load facility for waitlist entry")
 			} else {
@@ -1850,7 +3254,7 @@ func buildWaitlistEntrySummaries(ctx context.Context, q *dbgen.Queries, rows []d
 			endTime = row.TargetDate
 		}
 
-		entries = append(entries, waitlisttempl.WaitlistEntry{
+		entry := waitlisttempl.WaitlistEntry{
 			ID:           row.ID,
 			FacilityID:   row.FacilityID,
 			FacilityName: facilityName,
@@ -1859,7 +3263,23 @@ func buildWaitlistEntrySummaries(ctx context.Context, q *dbgen.Queries, rows []d
 			EndTime:      endTime,
 			Position:     row.Position,
 			Status:       row.Status,
-		})
+			AutoBook:     row.AutoBook,
+			UseVisitPack: row.UseVisitPack,
+		}
+		if row.Status == waitlistStatusNotified {
+			offer, err := q.GetPendingOffer(ctx, row.ID)
+			if err != nil {
+				if !errors.Is(err, sql.ErrNoRows) {
+					logger.Error().Err(err).Int64("waitlist_id", row.ID).Msg("Failed to load waitlist offer")
+				}
+			} else if offer.ExpiresAt.After(now) {
+				// A lapsed offer stays pending until the expiry sweep runs;
+				// it is no longer the member's to act on.
+				entry.OfferID = offer.ID
+				entry.OfferExpiresAt = offer.ExpiresAt
+			}
+		}
+		entries = append(entries, entry)
 	}
 
 	return entries
@@ -1877,7 +3297,13 @@ func waitlistDateTime(targetDate time.Time, value interface{}) (time.Time, error
 			return time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, targetDate.Location()), nil
 		}
 	}
-	return time.Time{}, fmt.Errorf("waitlist time must be in HH:MM or HH:MM:SS format")
+	// Full datetimes only contribute their clock in the facility timezone;
+	// the entry's target date still decides the day.
+	parsed, err := apiutil.ParseFlexibleTime(raw, "waitlist time", targetDate.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("waitlist time must be in HH:MM or HH:MM:SS format, or a full datetime")
+	}
+	return time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, targetDate.Location()), nil
 }
 
 func waitlistTimeValue(value interface{}) string {
@@ -1896,23 +3322,33 @@ func waitlistTimeValue(value interface{}) string {
 	}
 }
 
-func parseMemberCourtID(r *http.Request) (int64, error) {
+// parseMemberCourtIDs reads court_ids, ignoring repeats of the same court.
+func parseMemberCourtIDs(r *http.Request) ([]int64, error) {
 	values := r.Form["court_ids"]
 	if len(values) == 0 {
 		values = r.Form["court_ids[]"]
 	}
-	if len(values) == 0 {
-		return 0, fmt.Errorf("court_ids is required")
-	}
-	value := strings.TrimSpace(values[0])
-	if value == "" {
-		return 0, fmt.Errorf("court_ids is required")
+	courtIDs := make([]int64, 0, len(values))
+	seen := make(map[int64]struct{}, len(values))
+	for _, raw := range values {
+		value := strings.TrimSpace(raw)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("court_ids must be a positive integer")
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		courtIDs = append(courtIDs, id)
 	}
-	id, err := strconv.ParseInt(value, 10, 64)
-	if err != nil || id <= 0 {
-		return 0, fmt.Errorf("court_ids must be a positive integer")
+	if len(courtIDs) == 0 {
+		return nil, fmt.Errorf("court_ids is required")
 	}
-	return id, nil
+	return courtIDs, nil
 }
 
 func parseOptionalPositiveInt64(value string, field string) (int64, bool, error) {
@@ -1943,10 +3379,30 @@ func sameDay(a, b time.Time) bool {
 	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
 }
 
-func roundUpToHour(value time.Time) time.Time {
-	truncated := time.Date(value.Year(), value.Month(), value.Day(), value.Hour(), 0, 0, 0, value.Location())
-	if value.After(truncated) {
-		return truncated.Add(time.Hour)
+// roundUpToSlot returns the first slot boundary at or after value, counting
+// slots of length step from origin. Grids are anchored to opening time rather
+// than the clock so 90-minute slots from 8:00 land on 9:30, 11:00, and so on.
+func roundUpToSlot(value, origin time.Time, step time.Duration) time.Time {
+	if !value.After(origin) || step <= 0 {
+		return origin
+	}
+	start := origin
+	for start.Before(value) {
+		start = nextSlotStart(start, step)
+	}
+	return start
+}
+
+// nextSlotStart moves start forward by step on its location's wall clock, so
+// the slot grid survives DST changes. Wall times that do not exist because
+// clocks sprang forward are skipped.
+func nextSlotStart(start time.Time, step time.Duration) time.Time {
+	wall := time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, time.UTC)
+	for {
+		wall = wall.Add(step)
+		next := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, start.Location())
+		if next.Hour() == wall.Hour() && next.Minute() == wall.Minute() {
+			return next
+		}
 	}
-	return truncated
 }
@@ -0,0 +1,668 @@
+package member
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+	"github.com/codr1/Pickleicious/internal/templates/layouts"
+)
+
+const (
+	householdLinkStatusPending  = "pending"
+	householdLinkStatusActive   = "active"
+	householdLinkStatusDeclined = "declined"
+
+	// Dependents younger than this are linked without their consent.
+	householdConsentAge = 18
+	householdLinkTTL    = 7 * 24 * time.Hour
+)
+
+type householdDependentRequest struct {
+	Email       string `json:"email"`
+	DateOfBirth string `json:"dateOfBirth"`
+}
+
+type householdLinkResponse struct {
+	ID              int64     `json:"id"`
+	PrimaryUserID   int64     `json:"primaryUserId"`
+	DependentUserID int64     `json:"dependentUserId"`
+	Status          string    `json:"status"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+}
+
+// HandleMemberHousehold handles GET /member/household.
+func (h *Handlers) HandleMemberHousehold(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	data, err := loadHouseholdData(ctx, q, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load household")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load household")
+		return
+	}
+	writeHousehold(w, r, data)
+}
+
+// HandleMemberHouseholdDependentAdd handles POST /member/household/dependents.
+// The dependent is found by email and date of birth so a member cannot link
+// an account they only know the address of. Children are linked at once;
+// adults are emailed a request to accept.
+func (h *Handlers) HandleMemberHouseholdDependentAdd(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	req, err := decodeHouseholdDependentRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	now := time.Now()
+	var link dbgen.HouseholdLink
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		if _, err := qtx.GetActiveHouseholdLinkByDependent(ctx, user.ID); err == nil {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Dependents cannot add household members"}
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load household", Err: err}
+		}
+
+		dependent, err := qtx.GetUserByEmail(ctx, sql.NullString{String: req.Email, Valid: true})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to look up member", Err: err}
+		}
+		if err != nil || dependent.ID == user.ID || !dependent.IsMember ||
+			!dependent.HomeFacilityID.Valid || dependent.HomeFacilityID.Int64 != *user.HomeFacilityID ||
+			householdDateOfBirth(dependent.DateOfBirth) != req.DateOfBirth {
+			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Member not found"}
+		}
+
+		if _, err := qtx.GetActiveHouseholdLinkByDependent(ctx, dependent.ID); err == nil {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Member is already in a household"}
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load household", Err: err}
+		}
+		dependents, err := qtx.CountActiveHouseholdDependents(ctx, dependent.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load household", Err: err}
+		}
+		if dependents > 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Member already has their own household"}
+		}
+
+		token, err := newLeagueInvitationToken()
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add household member", Err: err}
+		}
+		params := dbgen.CreateHouseholdLinkParams{
+			PrimaryUserID:   user.ID,
+			DependentUserID: dependent.ID,
+			Token:           token,
+			Status:          householdLinkStatusPending,
+			ExpiresAt:       now.Add(householdLinkTTL).UTC(),
+		}
+		if isHouseholdMinor(dependent.DateOfBirth, now) {
+			params.Status = householdLinkStatusActive
+			params.RespondedAt = sql.NullTime{Time: now.UTC(), Valid: true}
+		}
+		link, err = qtx.CreateHouseholdLink(ctx, params)
+		if err != nil {
+			if apiutil.IsSQLiteUniqueViolation(err) {
+				return apiutil.HandlerError{Status: http.StatusConflict, Message: "Member already has a pending request"}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add household member", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		writeHouseholdError(w, r, user.ID, err)
+		return
+	}
+
+	if link.Status == householdLinkStatusPending {
+		h.sendHouseholdLinkRequestEmail(r, q, user.ID, *user.HomeFacilityID, link)
+	} else {
+		apiutil.AppendHXTrigger(w, "refreshMemberReservations")
+	}
+
+	if wantsHouseholdHTML(r) {
+		data, err := loadHouseholdData(ctx, q, user.ID)
+		if err != nil {
+			logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load household")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load household")
+			return
+		}
+		writeHousehold(w, r, data)
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusCreated, newHouseholdLinkResponse(link)); err != nil {
+		logger.Error().Err(err).Int64("household_link_id", link.ID).Msg("Failed to write household link response")
+		return
+	}
+}
+
+// HandleMemberHouseholdLinkRemove handles DELETE /member/household/links/{id}.
+// Either side may end a link: the primary removes a dependent or withdraws a
+// request, and a dependent leaves the household.
+func (h *Handlers) HandleMemberHouseholdLinkRemove(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	linkID, err := parsePathInt64(r, "id")
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid household link ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	link, err := q.GetHouseholdLink(ctx, linkID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Household link not found")
+			return
+		}
+		logger.Error().Err(err).Int64("household_link_id", linkID).Msg("Failed to load household link")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load household link")
+		return
+	}
+	if link.PrimaryUserID != user.ID && link.DependentUserID != user.ID {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Household link not found")
+		return
+	}
+
+	removed, err := q.RemoveHouseholdLink(ctx, dbgen.RemoveHouseholdLinkParams{
+		RespondedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		ID:          link.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("household_link_id", link.ID).Msg("Failed to remove household link")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to remove household link")
+		return
+	}
+	if removed == 0 {
+		apiutil.WriteError(w, r, http.StatusConflict, "Household link is no longer active")
+		return
+	}
+
+	data, err := loadHouseholdData(ctx, q, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load household")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load household")
+		return
+	}
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations")
+	writeHousehold(w, r, data)
+}
+
+// HandleHouseholdLinkAccept handles GET and POST
+// /member/household-links/{token}/accept. GET is the emailed link and
+// redirects to the portal once the member has joined the household.
+func (h *Handlers) HandleHouseholdLinkAccept(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	database := h.loadDB()
+	if database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	token := strings.TrimSpace(r.PathValue("token"))
+	if token == "" {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Household request not found")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	now := time.Now()
+	var link dbgen.HouseholdLink
+	err := database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		link, err = qtx.GetHouseholdLinkByToken(ctx, token)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Household request not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load household request", Err: err}
+		}
+		if link.DependentUserID != user.ID {
+			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Household request not found"}
+		}
+		if link.Status != householdLinkStatusPending {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Household request is no longer pending"}
+		}
+		if !now.Before(link.ExpiresAt) {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Household request has expired"}
+		}
+
+		dependents, err := qtx.CountActiveHouseholdDependents(ctx, user.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load household", Err: err}
+		}
+		if dependents > 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Remove your own dependents before joining another household"}
+		}
+		if _, err := qtx.GetActiveHouseholdLinkByDependent(ctx, link.PrimaryUserID); err == nil {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "The requesting member is now a dependent in another household"}
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load household", Err: err}
+		}
+
+		accepted, err := qtx.RespondToHouseholdLink(ctx, dbgen.RespondToHouseholdLinkParams{
+			Status:      householdLinkStatusActive,
+			RespondedAt: sql.NullTime{Time: now.UTC(), Valid: true},
+			ID:          link.ID,
+		})
+		if err != nil {
+			if apiutil.IsSQLiteUniqueViolation(err) {
+				return apiutil.HandlerError{Status: http.StatusConflict, Message: "You are already in a household"}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to accept household request", Err: err}
+		}
+		if accepted == 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Household request is no longer pending"}
+		}
+		link.Status = householdLinkStatusActive
+		return nil
+	})
+	if err != nil {
+		writeHouseholdError(w, r, user.ID, err)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		http.Redirect(w, r, "/member", http.StatusSeeOther)
+		return
+	}
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations")
+	if err := apiutil.WriteJSON(w, http.StatusOK, newHouseholdLinkResponse(link)); err != nil {
+		logger.Error().Err(err).Int64("household_link_id", link.ID).Msg("Failed to write household link response")
+		return
+	}
+}
+
+// HandleHouseholdLinkDecline handles GET and POST
+// /household-links/{token}/decline. Like transfer declines, the token is the
+// only credential and GET only asks for confirmation.
+func (h *Handlers) HandleHouseholdLinkDecline(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	token := strings.TrimSpace(r.PathValue("token"))
+	data := membertempl.HouseholdDeclineData{Token: token}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	status := http.StatusOK
+	link, err := q.GetHouseholdLinkByToken(ctx, token)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		status = http.StatusNotFound
+		data.Message = "Household request not found"
+	case err != nil:
+		logger.Error().Err(err).Msg("Failed to load household request")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load household request")
+		return
+	case r.Method == http.MethodPost && (link.Status != householdLinkStatusPending || !link.ExpiresAt.After(time.Now())):
+		status = http.StatusConflict
+		data.Message = "This household request is no longer open."
+	case link.Status == householdLinkStatusDeclined:
+		data.Declined = true
+	case link.Status != householdLinkStatusPending || !link.ExpiresAt.After(time.Now()):
+		data.Message = "This household request is no longer open."
+	case r.Method == http.MethodPost:
+		declined, err := q.RespondToHouseholdLink(ctx, dbgen.RespondToHouseholdLinkParams{
+			Status:      householdLinkStatusDeclined,
+			RespondedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+			ID:          link.ID,
+		})
+		if err != nil {
+			logger.Error().Err(err).Int64("household_link_id", link.ID).Msg("Failed to decline household request")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to decline household request")
+			return
+		}
+		if declined == 0 {
+			status = http.StatusConflict
+			data.Message = "This household request is no longer open."
+		} else {
+			data.Declined = true
+		}
+	default:
+		primary, err := q.GetUserByID(ctx, link.PrimaryUserID)
+		if err != nil {
+			logger.Error().Err(err).Int64("user_id", link.PrimaryUserID).Msg("Failed to load household primary")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load household request")
+			return
+		}
+		data.PrimaryName = strings.TrimSpace(primary.FirstName + " " + primary.LastName)
+	}
+
+	var buf bytes.Buffer
+	page := layouts.Base(membertempl.HouseholdDeclinePage(data), nil, "")
+	if err := page.Render(r.Context(), &buf); err != nil {
+		logger.Error().Err(err).Msg("Failed to render household decline page")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render page")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(status)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.Error().Err(err).Msg("Failed to write household decline page")
+	}
+}
+
+// householdDependentOf reports whether dependentID is an active dependent of
+// primaryID.
+func householdDependentOf(ctx context.Context, q *dbgen.Queries, primaryID, dependentID int64) (bool, error) {
+	link, err := q.GetActiveHouseholdLinkByDependent(ctx, dependentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return link.PrimaryUserID == primaryID, nil
+}
+
+// householdReservationOwner returns who the member acts as on a reservation:
+// the household dependent it belongs to, or otherwise the member, leaving
+// the reservation service to turn away anyone else.
+func householdReservationOwner(ctx context.Context, q *dbgen.Queries, userID, reservationID int64) (int64, error) {
+	reservation, err := q.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return userID, nil
+		}
+		return 0, err
+	}
+	if !reservation.PrimaryUserID.Valid || reservation.PrimaryUserID.Int64 == userID {
+		return userID, nil
+	}
+	ok, err := householdDependentOf(ctx, q, userID, reservation.PrimaryUserID.Int64)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		return reservation.PrimaryUserID.Int64, nil
+	}
+	return userID, nil
+}
+
+// resolveBookingFor returns who a booking is for: the member themselves, or
+// the household dependent named by the form's booking_for_user_id.
+func resolveBookingFor(ctx context.Context, q *dbgen.Queries, r *http.Request, userID int64) (int64, error) {
+	bookingForID, selected, err := parseOptionalPositiveInt64(r.FormValue("booking_for_user_id"), "booking_for_user_id")
+	if err != nil {
+		return 0, err
+	}
+	if !selected || bookingForID == userID {
+		return userID, nil
+	}
+	ok, err := householdDependentOf(ctx, q, userID, bookingForID)
+	if err != nil {
+		return 0, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load household", Err: err}
+	}
+	if !ok {
+		return 0, apiutil.HandlerError{Status: http.StatusForbidden, Message: "You can only book for members of your household"}
+	}
+	return bookingForID, nil
+}
+
+// listBookingForOptions lists the member and then their active dependents
+// for the booking form's "booking for" choice.
+func listBookingForOptions(ctx context.Context, q *dbgen.Queries, userID int64) ([]membertempl.MemberBookingForOption, error) {
+	dependents, err := q.ListActiveHouseholdDependents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	options := make([]membertempl.MemberBookingForOption, 0, len(dependents)+1)
+	options = append(options, membertempl.MemberBookingForOption{UserID: userID, Name: "Myself"})
+	for _, dependent := range dependents {
+		options = append(options, membertempl.MemberBookingForOption{
+			UserID: dependent.ID,
+			Name:   strings.TrimSpace(dependent.FirstName + " " + dependent.LastName),
+		})
+	}
+	return options, nil
+}
+
+func loadHouseholdData(ctx context.Context, q *dbgen.Queries, userID int64) (membertempl.HouseholdData, error) {
+	links, err := q.ListHouseholdLinksForUser(ctx, userID)
+	if err != nil {
+		return membertempl.HouseholdData{}, err
+	}
+	data := membertempl.HouseholdData{CanAddDependents: true}
+	for _, link := range links {
+		member := membertempl.HouseholdMember{
+			LinkID:    link.ID,
+			Status:    link.Status,
+			ExpiresAt: link.ExpiresAt,
+		}
+		if link.PrimaryUserID == userID {
+			member.UserID = link.DependentUserID
+			member.Name = strings.TrimSpace(link.DependentFirstName + " " + link.DependentLastName)
+		} else {
+			member.UserID = link.PrimaryUserID
+			member.Name = strings.TrimSpace(link.PrimaryFirstName + " " + link.PrimaryLastName)
+			member.Incoming = true
+		}
+		switch {
+		case link.Status == householdLinkStatusPending:
+			data.Pending = append(data.Pending, member)
+		case member.Incoming:
+			data.Primary = &member
+			data.CanAddDependents = false
+		default:
+			data.Dependents = append(data.Dependents, member)
+		}
+	}
+	return data, nil
+}
+
+func (h *Handlers) sendHouseholdLinkRequestEmail(r *http.Request, q *dbgen.Queries, primaryUserID, facilityID int64, link dbgen.HouseholdLink) {
+	if h.emailClient == nil {
+		return
+	}
+	logger := log.Ctx(r.Context())
+
+	emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(r.Context()), portalQueryTimeout)
+	defer emailCancel()
+	facility, err := h.loadFacilities().GetFacilityByID(emailCtx, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility for household email")
+		return
+	}
+	primaryName := ""
+	if primary, err := q.GetUserByID(emailCtx, primaryUserID); err == nil {
+		primaryName = strings.TrimSpace(primary.FirstName + " " + primary.LastName)
+	}
+	acceptURL, declineURL := h.householdLinkURLs(link.Token)
+	message := email.BuildHouseholdLinkRequestEmail(email.HouseholdLinkRequestDetails{
+		FacilityName: facility.Name,
+		PrimaryName:  primaryName,
+		AcceptURL:    acceptURL,
+		DeclineURL:   declineURL,
+		ExpiresAt:    link.ExpiresAt.In(apiutil.FacilityLocation(facility, logger)).Format("Monday, Jan 2, 2006 3:04 PM MST"),
+	})
+	message.FacilityID = facility.ID
+	sender := email.ResolveFromAddress(emailCtx, q, facility, logger)
+	email.SendHouseholdLinkRequestEmail(emailCtx, q, h.emailClient, link.DependentUserID, message, sender, logger)
+}
+
+// householdLinkURLs builds the emailed accept and decline links for a
+// household link token.
+func (h *Handlers) householdLinkURLs(token string) (string, string) {
+	return h.absoluteURL(fmt.Sprintf("/member/household-links/%s/accept", token)),
+		h.absoluteURL(fmt.Sprintf("/household-links/%s/decline", token))
+}
+
+// householdDateOfBirth is the YYYY-MM-DD part of a stored date of birth.
+func householdDateOfBirth(dateOfBirth string) string {
+	dateOfBirth = strings.TrimSpace(dateOfBirth)
+	if len(dateOfBirth) > len(time.DateOnly) {
+		dateOfBirth = dateOfBirth[:len(time.DateOnly)]
+	}
+	return dateOfBirth
+}
+
+// isHouseholdMinor reports whether the member is younger than
+// householdConsentAge. A missing or unreadable date of birth counts as an
+// adult, so the member is asked for consent.
+func isHouseholdMinor(dateOfBirth string, now time.Time) bool {
+	born, err := time.Parse(time.DateOnly, householdDateOfBirth(dateOfBirth))
+	if err != nil {
+		return false
+	}
+	return now.Before(born.AddDate(householdConsentAge, 0, 0))
+}
+
+func decodeHouseholdDependentRequest(r *http.Request) (householdDependentRequest, error) {
+	var req householdDependentRequest
+	if apiutil.IsJSONRequest(r) {
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return householdDependentRequest{}, err
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return householdDependentRequest{}, err
+		}
+		req.Email = r.FormValue("email")
+		req.DateOfBirth = r.FormValue("date_of_birth")
+	}
+	req.Email = strings.TrimSpace(req.Email)
+	req.DateOfBirth = strings.TrimSpace(req.DateOfBirth)
+	if req.Email == "" {
+		return householdDependentRequest{}, apiutil.FieldError{Field: "email", Reason: "is required"}
+	}
+	if _, err := time.Parse(time.DateOnly, req.DateOfBirth); err != nil {
+		return householdDependentRequest{}, apiutil.FieldError{Field: "date_of_birth", Reason: "must be YYYY-MM-DD"}
+	}
+	return req, nil
+}
+
+func newHouseholdLinkResponse(link dbgen.HouseholdLink) householdLinkResponse {
+	return householdLinkResponse{
+		ID:              link.ID,
+		PrimaryUserID:   link.PrimaryUserID,
+		DependentUserID: link.DependentUserID,
+		Status:          link.Status,
+		ExpiresAt:       link.ExpiresAt,
+	}
+}
+
+func wantsHouseholdHTML(r *http.Request) bool {
+	acceptHeader := strings.ToLower(r.Header.Get("Accept"))
+	wantsHTML := apiutil.IsHTMXRequest(r) || strings.Contains(acceptHeader, "text/html")
+	wantsJSON := strings.Contains(acceptHeader, "application/json")
+	return wantsHTML && (!wantsJSON || apiutil.IsHTMXRequest(r))
+}
+
+func writeHousehold(w http.ResponseWriter, r *http.Request, data membertempl.HouseholdData) {
+	if wantsHouseholdHTML(r) {
+		component := membertempl.MemberHousehold(data)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render household", "Failed to render household")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, data); err != nil {
+		log.Ctx(r.Context()).Error().Err(err).Msg("Failed to write household response")
+		return
+	}
+}
+
+func writeHouseholdError(w http.ResponseWriter, r *http.Request, userID int64, err error) {
+	logger := log.Ctx(r.Context())
+
+	var herr apiutil.HandlerError
+	if errors.As(err, &herr) {
+		if herr.Status == http.StatusInternalServerError {
+			logger.Error().Err(herr.Err).Int64("user_id", userID).Msg(herr.Message)
+		}
+		apiutil.WriteHandlerError(w, r, herr)
+		return
+	}
+	logger.Error().Err(err).Int64("user_id", userID).Msg("Household request failed")
+	apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update household")
+}
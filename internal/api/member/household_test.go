@@ -0,0 +1,223 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func (f memberBookingFixture) insertHouseholdMember(t *testing.T, first, email, dateOfBirth string) int64 {
+	t.Helper()
+	result, err := f.database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id, date_of_birth)
+		 VALUES (?, 'Family', ?, 'active', 1, 1, 2, ?, ?)`,
+		first, email, f.facilityID, dateOfBirth,
+	)
+	if err != nil {
+		t.Fatalf("insert %s: %v", first, err)
+	}
+	id, _ := result.LastInsertId()
+	return id
+}
+
+func (f memberBookingFixture) asUser(req *http.Request, userID int64) *http.Request {
+	facilityID := f.facilityID
+	return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:              userID,
+		HomeFacilityID:  &facilityID,
+		MembershipLevel: 2,
+	}))
+}
+
+func (f memberBookingFixture) addDependent(t *testing.T, email, dateOfBirth string) *httptest.ResponseRecorder {
+	t.Helper()
+	body := fmt.Sprintf(`{"email":%q,"dateOfBirth":%q}`, email, dateOfBirth)
+	req := httptest.NewRequest(http.MethodPost, "/member/household/dependents", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	f.h.HandleMemberHouseholdDependentAdd(recorder, f.withMember(req))
+	return recorder
+}
+
+func (f memberBookingFixture) bookFor(t *testing.T, bookingForID, courtID int64, hour int) *httptest.ResponseRecorder {
+	t.Helper()
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, hour, 0, 0, 0, time.UTC)
+	form := url.Values{}
+	form.Set("facility_id", fmt.Sprintf("%d", f.facilityID))
+	form.Set("start_time", start.Format(memberBookingTimeLayout))
+	form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+	form.Set("court_ids", fmt.Sprintf("%d", courtID))
+	form.Set("booking_for_user_id", fmt.Sprintf("%d", bookingForID))
+	req := httptest.NewRequest(http.MethodPost, "/member/reservations", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+	f.h.HandleMemberBookingCreate(recorder, f.withMember(req))
+	return recorder
+}
+
+func decodeHouseholdLink(t *testing.T, recorder *httptest.ResponseRecorder) householdLinkResponse {
+	t.Helper()
+	var link householdLinkResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&link); err != nil {
+		t.Fatalf("decode household link: %v", err)
+	}
+	return link
+}
+
+func TestHouseholdBookAndCancelForMinorDependent(t *testing.T) {
+	f := setupMemberBookingTest(t, 1)
+	childBirthday := time.Now().AddDate(-10, 0, 0).Format(time.DateOnly)
+	childID := f.insertHouseholdMember(t, "Kid", "kid@test.com", childBirthday)
+	strangerID := f.insertHouseholdMember(t, "Stranger", "stranger@test.com", "1980-01-01")
+
+	// Email alone is not enough to link an account.
+	if recorder := f.addDependent(t, "kid@test.com", "2001-01-01"); recorder.Code != http.StatusNotFound {
+		t.Fatalf("wrong birthday: expected 404, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder := f.addDependent(t, "kid@test.com", childBirthday)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("add child: expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if link := decodeHouseholdLink(t, recorder); link.Status != "active" || link.DependentUserID != childID {
+		t.Fatalf("expected a child to be linked at once, got %+v", link)
+	}
+
+	if recorder := f.bookFor(t, strangerID, f.courtIDs[0], 10); recorder.Code != http.StatusForbidden {
+		t.Fatalf("book for stranger: expected 403, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = f.bookFor(t, childID, f.courtIDs[0], 10)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("book for child: expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created dbgen.Reservation
+	if err := json.NewDecoder(recorder.Body).Decode(&created); err != nil {
+		t.Fatalf("decode reservation: %v", err)
+	}
+	if created.PrimaryUserID.Int64 != childID || created.CreatedByUserID != f.memberID {
+		t.Fatalf("expected the child's reservation booked by the parent, got primary %d created by %d", created.PrimaryUserID.Int64, created.CreatedByUserID)
+	}
+
+	rows, err := f.database.Queries.ListReservationsByUserID(context.Background(), dbgen.ListReservationsByUserIDParams{
+		UserID: sql.NullInt64{Int64: f.memberID, Valid: true},
+		Limit:  memberReservationsNoLimit,
+	})
+	if err != nil {
+		t.Fatalf("list parent reservations: %v", err)
+	}
+	if len(rows) != 1 || rows[0].ID != created.ID {
+		t.Fatalf("expected the parent to see the child's reservation, got %+v", rows)
+	}
+
+	cancelAs := func(userID int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/member/reservations/%d", created.ID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", created.ID))
+		recorder := httptest.NewRecorder()
+		f.h.HandleMemberReservationCancel(recorder, f.asUser(req, userID))
+		return recorder
+	}
+	if recorder := cancelAs(strangerID); recorder.Code != http.StatusForbidden {
+		t.Fatalf("stranger cancel: expected 403, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := cancelAs(f.memberID); recorder.Code != http.StatusOK {
+		t.Fatalf("parent cancel: expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var cancelledBy int64
+	if err := f.database.QueryRow(
+		"SELECT cancelled_by_user_id FROM reservation_cancellations WHERE reservation_id = ?",
+		created.ID,
+	).Scan(&cancelledBy); err != nil {
+		t.Fatalf("load cancellation: %v", err)
+	}
+	if cancelledBy != f.memberID {
+		t.Fatalf("expected the parent recorded as canceller, got %d", cancelledBy)
+	}
+}
+
+func TestHouseholdAdultConsentAndHouseholdLimit(t *testing.T) {
+	f := setupMemberBookingTest(t, 1)
+	if _, err := f.database.Exec(
+		"UPDATE facilities SET max_member_reservations = 1, reservation_limit_scope = 'household' WHERE id = ?",
+		f.facilityID,
+	); err != nil {
+		t.Fatalf("update facility: %v", err)
+	}
+	adultID := f.insertHouseholdMember(t, "Adult", "adult@test.com", "1990-05-05")
+	otherID := f.insertHouseholdMember(t, "Other", "other@test.com", "1985-01-01")
+
+	recorder := f.addDependent(t, "adult@test.com", "1990-05-05")
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("add adult: expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if link := decodeHouseholdLink(t, recorder); link.Status != "pending" {
+		t.Fatalf("expected an adult to need consent, got %+v", link)
+	}
+	if recorder := f.addDependent(t, "adult@test.com", "1990-05-05"); recorder.Code != http.StatusConflict {
+		t.Fatalf("repeat request: expected 409, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := f.bookFor(t, adultID, f.courtIDs[0], 10); recorder.Code != http.StatusForbidden {
+		t.Fatalf("book before consent: expected 403, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var token string
+	if err := f.database.QueryRow("SELECT token FROM household_links WHERE dependent_user_id = ?", adultID).Scan(&token); err != nil {
+		t.Fatalf("load household token: %v", err)
+	}
+	accept := func(userID int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/member/household-links/"+token+"/accept", nil)
+		req.SetPathValue("token", token)
+		recorder := httptest.NewRecorder()
+		f.h.HandleHouseholdLinkAccept(recorder, f.asUser(req, userID))
+		return recorder
+	}
+	if recorder := accept(otherID); recorder.Code != http.StatusNotFound {
+		t.Fatalf("accept as someone else: expected 404, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := accept(adultID); recorder.Code != http.StatusOK {
+		t.Fatalf("accept: expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	// The household shares one reservation under the household scope.
+	if recorder := f.bookFor(t, f.memberID, f.courtIDs[0], 10); recorder.Code != http.StatusCreated {
+		t.Fatalf("book for self: expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := f.bookFor(t, adultID, f.courtIDs[1], 12); recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), "maximum of 1") {
+		t.Fatalf("book for dependent over household limit: expected 409, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if _, err := f.database.Exec("UPDATE facilities SET reservation_limit_scope = 'person' WHERE id = ?", f.facilityID); err != nil {
+		t.Fatalf("update facility: %v", err)
+	}
+	f.database.Facilities.Invalidate(f.facilityID)
+	if recorder := f.bookFor(t, adultID, f.courtIDs[1], 12); recorder.Code != http.StatusCreated {
+		t.Fatalf("book for dependent under person limit: expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	// Leaving the household ends the parent's access.
+	var linkID int64
+	if err := f.database.QueryRow("SELECT id FROM household_links WHERE dependent_user_id = ?", adultID).Scan(&linkID); err != nil {
+		t.Fatalf("load household link: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/member/household/links/%d", linkID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", linkID))
+	recorder = httptest.NewRecorder()
+	f.h.HandleMemberHouseholdLinkRemove(recorder, f.asUser(req, adultID))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("leave household: expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := f.bookFor(t, adultID, f.courtIDs[2], 14); recorder.Code != http.StatusForbidden {
+		t.Fatalf("book after leaving: expected 403, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
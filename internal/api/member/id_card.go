@@ -0,0 +1,301 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/models"
+	"github.com/codr1/Pickleicious/internal/qrcode"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+	"github.com/codr1/Pickleicious/internal/templates/layouts"
+)
+
+const (
+	memberCardQRScale      = 8
+	memberCardFilename     = "member-card.png"
+	memberCardEmailTimeout = 10 * time.Second
+)
+
+// HandleMemberIDCard handles GET /member/id-card.
+func (h *Handlers) HandleMemberIDCard(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	card, err := models.EnsureMemberCard(ctx, q, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to issue member card")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load member card")
+		return
+	}
+
+	data, err := h.buildMemberIDCardData(ctx, q, user, card, "")
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load member card")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load member card")
+		return
+	}
+
+	var activeTheme *models.Theme
+	if user.HomeFacilityID != nil {
+		theme, err := models.GetActiveTheme(ctx, q, *user.HomeFacilityID)
+		if err != nil {
+			logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load active theme")
+		} else {
+			activeTheme = theme
+		}
+	}
+
+	page := layouts.Base(membertempl.MemberIDCardPage(data), activeTheme, user.SessionType)
+	if err := page.Render(r.Context(), w); err != nil {
+		logger.Error().Err(err).Msg("Failed to render member card")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render page")
+		return
+	}
+}
+
+// HandleMemberIDCardQR handles GET /member/id-card/qr.png. With download=1 the
+// image is served as an attachment for saving to a phone.
+func (h *Handlers) HandleMemberIDCardQR(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	card, err := models.EnsureMemberCard(ctx, q, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to issue member card")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load member card")
+		return
+	}
+
+	image, err := h.memberCardQRCode(card, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to render member card QR code")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render member card")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	if apiutil.ParseBool(r.URL.Query().Get("download")) {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", memberCardFilename))
+	}
+	if _, err := w.Write(image); err != nil {
+		logger.Error().Err(err).Msg("Failed to write member card QR code")
+	}
+}
+
+// HandleMemberIDCardReset handles POST /member/id-card/reset. The previous QR
+// code stops validating immediately.
+func (h *Handlers) HandleMemberIDCardReset(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	database := h.loadDB()
+	if database == nil {
+		logger.Error().Msg("Database not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	var card dbgen.MemberCard
+	err := database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		var err error
+		card, err = models.ResetMemberCard(ctx, txdb.Queries, user.ID)
+		return err
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to reset member card")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to reset member card")
+		return
+	}
+
+	logger.Info().Int64("member_id", user.ID).Msg("Member card reset")
+
+	data, err := h.buildMemberIDCardData(ctx, database.Queries, user, card, "Your card has been reset. Previously printed or saved cards no longer work.")
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load member card")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load member card")
+		return
+	}
+
+	component := membertempl.MemberIDCard(data)
+	if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render member card", "Failed to render member card") {
+		return
+	}
+}
+
+// HandleMemberIDCardEmail handles POST /member/id-card/email, sending the QR
+// code to the member's address on file.
+func (h *Handlers) HandleMemberIDCardEmail(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if h.emailClient == nil {
+		apiutil.WriteError(w, r, http.StatusServiceUnavailable, "Email is not available")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	card, err := models.EnsureMemberCard(ctx, q, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to issue member card")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load member card")
+		return
+	}
+
+	data, err := h.buildMemberIDCardData(ctx, q, user, card, "")
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load member card")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load member card")
+		return
+	}
+	if data.Profile.Email == "" {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "No email address on file")
+		return
+	}
+
+	image, err := h.memberCardQRCode(card, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to render member card QR code")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render member card")
+		return
+	}
+
+	message := email.BuildMemberCardEmail(email.MemberCardDetails{
+		MemberName:   data.Profile.FullName(),
+		FacilityName: data.FacilityName,
+		Membership:   data.Profile.MembershipLabel(),
+		ValidThrough: data.ValidThrough.Format("Jan 2, 2006"),
+	})
+	sendCtx, sendCancel := context.WithTimeout(r.Context(), memberCardEmailTimeout)
+	defer sendCancel()
+	if err := h.emailClient.SendWithAttachment(sendCtx, data.Profile.Email, message.Subject, message.Body, email.Attachment{
+		Filename:    memberCardFilename,
+		ContentType: "image/png",
+		Data:        image,
+	}); err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to email member card")
+		apiutil.WriteError(w, r, http.StatusBadGateway, "Failed to email member card")
+		return
+	}
+
+	data.Notice = fmt.Sprintf("Your card was sent to %s.", data.Profile.Email)
+	component := membertempl.MemberIDCard(data)
+	if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render member card", "Failed to render member card") {
+		return
+	}
+}
+
+func (h *Handlers) buildMemberIDCardData(ctx context.Context, q *dbgen.Queries, user *authz.AuthUser, card dbgen.MemberCard, notice string) (membertempl.MemberIDCardData, error) {
+	memberRow, err := q.GetMemberByID(ctx, user.ID)
+	if err != nil {
+		return membertempl.MemberIDCardData{}, err
+	}
+
+	facilityName := ""
+	var homeFacility *dbgen.Facility
+	if user.HomeFacilityID != nil {
+		facility, err := h.loadFacilities().GetFacilityByID(ctx, *user.HomeFacilityID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return membertempl.MemberIDCardData{}, err
+		}
+		facilityName = facility.Name
+		if err == nil {
+			homeFacility = &facility
+		}
+	}
+	tier := loadMemberTier(ctx, q, homeFacility, memberRow.MembershipLevel, log.Ctx(ctx))
+
+	validThrough := time.Time{}
+	if h.cardSigner != nil {
+		validThrough = h.cardSigner.Sign(card.CardToken, time.Now()).ExpiresAt
+	}
+
+	return membertempl.MemberIDCardData{
+		Profile: membertempl.PortalProfile{
+			ID:                 memberRow.ID,
+			FirstName:          memberRow.FirstName,
+			LastName:           memberRow.LastName,
+			Email:              memberRow.Email.String,
+			MembershipLevel:    memberRow.MembershipLevel,
+			HasPhoto:           memberRow.PhotoID.Valid,
+			MembershipTierName: tier.Name,
+		},
+		FacilityName: facilityName,
+		QRCodeURL:    fmt.Sprintf("/member/id-card/qr.png?card=%d", card.ID),
+		ValidThrough: validThrough,
+		Notice:       notice,
+	}, nil
+}
+
+func (h *Handlers) memberCardQRCode(card dbgen.MemberCard, now time.Time) ([]byte, error) {
+	if h.cardSigner == nil {
+		return nil, errors.New("member card signer not initialized")
+	}
+	code, err := qrcode.Encode([]byte(h.cardSigner.Sign(card.CardToken, now).Value))
+	if err != nil {
+		return nil, err
+	}
+	return code.PNG(memberCardQRScale)
+}
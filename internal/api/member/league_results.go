@@ -0,0 +1,460 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	leaguecore "github.com/codr1/Pickleicious/internal/leagues"
+)
+
+const disputeReasonMaxLength = 500
+
+type leagueResultRequest struct {
+	HomeScore int64 `json:"homeScore"`
+	AwayScore int64 `json:"awayScore"`
+}
+
+type leagueResultDisputeRequest struct {
+	Reason string `json:"reason"`
+}
+
+type leagueResultResponse struct {
+	Match      dbgen.LeagueMatch                 `json:"match"`
+	Submission dbgen.LeagueMatchResultSubmission `json:"submission"`
+}
+
+// captainMatch is a league match at the member's home facility with both
+// of its teams loaded, so handlers can tell which side the member captains.
+type captainMatch struct {
+	League   dbgen.GetLeagueWithFacilityTimezoneRow
+	Match    dbgen.LeagueMatch
+	HomeTeam dbgen.LeagueTeam
+	AwayTeam dbgen.LeagueTeam
+}
+
+// HandleLeagueResultSubmit handles POST
+// /member/leagues/{id}/matches/{match_id}/result. The home captain enters
+// the score; the match waits in pending_result until the away captain
+// confirms it, disputes it, or the response window lapses.
+func (h *Handlers) HandleLeagueResultSubmit(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	leagueID, matchID, user, ok := leagueResultRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := decodeLeagueResultRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if err := leaguecore.ValidateMatchResult(req.HomeScore, req.AwayScore); err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	cm, err := loadCaptainMatch(ctx, q, leagueID, matchID, user)
+	if err != nil {
+		writeLeagueHandlerError(w, r, logger, leagueID, err)
+		return
+	}
+	if cm.HomeTeam.CaptainUserID != user.ID {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Only the home team captain can submit the result")
+		return
+	}
+	if cm.Match.Status != "scheduled" && cm.Match.Status != "in_progress" {
+		apiutil.WriteError(w, r, http.StatusConflict, "Match is not awaiting a result")
+		return
+	}
+	now := time.Now()
+	if now.Before(cm.Match.ScheduledTime) {
+		apiutil.WriteError(w, r, http.StatusConflict, "Match has not started yet")
+		return
+	}
+
+	var resp leagueResultResponse
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		moved, err := qtx.UpdateLeagueMatchStatus(ctx, dbgen.UpdateLeagueMatchStatusParams{
+			Status:         "pending_result",
+			ID:             matchID,
+			ExpectedStatus: cm.Match.Status,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to submit result", Err: err}
+		}
+		if moved == 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Match is not awaiting a result"}
+		}
+
+		resp.Submission, err = qtx.CreateLeagueMatchResultSubmission(ctx, dbgen.CreateLeagueMatchResultSubmissionParams{
+			LeagueMatchID:     matchID,
+			SubmittedByUserID: user.ID,
+			HomeScore:         req.HomeScore,
+			AwayScore:         req.AwayScore,
+			RespondBy:         now.Add(leaguecore.ResultResponseWindow).UTC(),
+		})
+		if err != nil {
+			if apiutil.IsSQLiteUniqueViolation(err) {
+				return apiutil.HandlerError{Status: http.StatusConflict, Message: "A result has already been submitted for this match", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to submit result", Err: err}
+		}
+
+		resp.Match, err = qtx.GetLeagueMatch(ctx, dbgen.GetLeagueMatchParams{ID: matchID, LeagueID: leagueID})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to submit result", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		writeLeagueHandlerError(w, r, logger, leagueID, err)
+		return
+	}
+
+	h.sendLeagueResultSubmittedEmail(r.Context(), q, cm, resp.Submission, user, logger)
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, resp); err != nil {
+		logger.Error().Err(err).Int64("match_id", matchID).Msg("Failed to write result submission response")
+	}
+}
+
+// HandleLeagueResultConfirm handles POST
+// /member/leagues/{id}/matches/{match_id}/result/confirm. The away captain
+// accepts the submitted score, which completes the match and counts it in
+// the standings.
+func (h *Handlers) HandleLeagueResultConfirm(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	leagueID, matchID, user, ok := leagueResultRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	var resp leagueResultResponse
+	err := database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		if _, err := loadRespondableSubmission(ctx, qtx, leagueID, matchID, user); err != nil {
+			return err
+		}
+
+		var err error
+		resp.Match, err = leaguecore.ConfirmSubmittedResult(ctx, qtx, leagueID, matchID, sql.NullInt64{Int64: user.ID, Valid: true})
+		if err != nil {
+			if errors.Is(err, leaguecore.ErrResultNotPending) {
+				return apiutil.HandlerError{Status: http.StatusConflict, Message: "Result is no longer awaiting confirmation", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to confirm result", Err: err}
+		}
+		resp.Submission, err = qtx.GetLeagueMatchResultSubmission(ctx, matchID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to confirm result", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		writeLeagueHandlerError(w, r, logger, leagueID, err)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		logger.Error().Err(err).Int64("match_id", matchID).Msg("Failed to write result confirmation response")
+	}
+}
+
+// HandleLeagueResultDispute handles POST
+// /member/leagues/{id}/matches/{match_id}/result/dispute. The away captain
+// rejects the submitted score; the match stays out of the standings until
+// staff record the result.
+func (h *Handlers) HandleLeagueResultDispute(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	leagueID, matchID, user, ok := leagueResultRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := decodeLeagueResultDisputeRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	var resp leagueResultResponse
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		if _, err := loadRespondableSubmission(ctx, qtx, leagueID, matchID, user); err != nil {
+			return err
+		}
+
+		disputed, err := qtx.RespondToLeagueMatchResultSubmission(ctx, dbgen.RespondToLeagueMatchResultSubmissionParams{
+			Status:            "disputed",
+			RespondedByUserID: sql.NullInt64{Int64: user.ID, Valid: true},
+			DisputeReason:     sql.NullString{String: req.Reason, Valid: req.Reason != ""},
+			LeagueMatchID:     matchID,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to dispute result", Err: err}
+		}
+		if disputed == 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Result is no longer awaiting confirmation"}
+		}
+		if _, err := qtx.UpdateLeagueMatchStatus(ctx, dbgen.UpdateLeagueMatchStatusParams{
+			Status:         "disputed",
+			ID:             matchID,
+			ExpectedStatus: "pending_result",
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to dispute result", Err: err}
+		}
+
+		resp.Match, err = qtx.GetLeagueMatch(ctx, dbgen.GetLeagueMatchParams{ID: matchID, LeagueID: leagueID})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to dispute result", Err: err}
+		}
+		resp.Submission, err = qtx.GetLeagueMatchResultSubmission(ctx, matchID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to dispute result", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		writeLeagueHandlerError(w, r, logger, leagueID, err)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		logger.Error().Err(err).Int64("match_id", matchID).Msg("Failed to write result dispute response")
+	}
+}
+
+// leagueResultRequestContext parses the league and match path values and
+// the signed-in member, writing the error response when any is missing.
+func leagueResultRequestContext(w http.ResponseWriter, r *http.Request) (int64, int64, *authz.AuthUser, bool) {
+	leagueID, err := parseLeagueID(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
+		return 0, 0, nil, false
+	}
+	matchID, err := parsePathInt64(r, "match_id")
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid match ID")
+		return 0, 0, nil, false
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return 0, 0, nil, false
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return 0, 0, nil, false
+	}
+	return leagueID, matchID, user, true
+}
+
+// loadCaptainMatch loads a match in a league at the member's home facility
+// and confirms the member captains one of its teams.
+func loadCaptainMatch(ctx context.Context, q *dbgen.Queries, leagueID, matchID int64, user *authz.AuthUser) (captainMatch, error) {
+	var cm captainMatch
+	var err error
+	cm.League, err = q.GetLeagueWithFacilityTimezone(ctx, leagueID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return cm, apiutil.HandlerError{Status: http.StatusNotFound, Message: "League not found", Err: err}
+		}
+		return cm, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch league", Err: err}
+	}
+	if user.HomeFacilityID == nil || cm.League.FacilityID != *user.HomeFacilityID {
+		return cm, apiutil.HandlerError{Status: http.StatusNotFound, Message: "League not found"}
+	}
+
+	cm.Match, err = q.GetLeagueMatch(ctx, dbgen.GetLeagueMatchParams{ID: matchID, LeagueID: leagueID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return cm, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Match not found", Err: err}
+		}
+		return cm, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch match", Err: err}
+	}
+
+	cm.HomeTeam, err = q.GetLeagueTeam(ctx, cm.Match.HomeTeamID)
+	if err != nil {
+		return cm, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch team", Err: err}
+	}
+	cm.AwayTeam, err = q.GetLeagueTeam(ctx, cm.Match.AwayTeamID)
+	if err != nil {
+		return cm, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch team", Err: err}
+	}
+	if cm.HomeTeam.CaptainUserID != user.ID && cm.AwayTeam.CaptainUserID != user.ID {
+		return cm, apiutil.HandlerError{Status: http.StatusForbidden, Message: "Only team captains can report results for this match"}
+	}
+	return cm, nil
+}
+
+// loadRespondableSubmission loads the pending submission for a match the
+// member captains the away side of, still inside its response window.
+func loadRespondableSubmission(ctx context.Context, q *dbgen.Queries, leagueID, matchID int64, user *authz.AuthUser) (dbgen.LeagueMatchResultSubmission, error) {
+	cm, err := loadCaptainMatch(ctx, q, leagueID, matchID, user)
+	if err != nil {
+		return dbgen.LeagueMatchResultSubmission{}, err
+	}
+	if cm.AwayTeam.CaptainUserID != user.ID {
+		return dbgen.LeagueMatchResultSubmission{}, apiutil.HandlerError{Status: http.StatusForbidden, Message: "Only the away team captain can respond to the result"}
+	}
+
+	submission, err := q.GetLeagueMatchResultSubmission(ctx, matchID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return submission, apiutil.HandlerError{Status: http.StatusNotFound, Message: "No result has been submitted for this match", Err: err}
+		}
+		return submission, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch result", Err: err}
+	}
+	if submission.Status != "pending" || cm.Match.Status != "pending_result" {
+		return submission, apiutil.HandlerError{Status: http.StatusConflict, Message: "Result is no longer awaiting confirmation"}
+	}
+	if !time.Now().Before(submission.RespondBy) {
+		return submission, apiutil.HandlerError{Status: http.StatusConflict, Message: "The response window for this result has closed"}
+	}
+	return submission, nil
+}
+
+func (h *Handlers) sendLeagueResultSubmittedEmail(ctx context.Context, q *dbgen.Queries, cm captainMatch, submission dbgen.LeagueMatchResultSubmission, user *authz.AuthUser, logger *zerolog.Logger) {
+	if h.emailClient == nil {
+		return
+	}
+	emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(ctx), portalQueryTimeout)
+	defer emailCancel()
+
+	facility, err := h.loadFacilities().GetFacilityByID(emailCtx, cm.League.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", cm.League.FacilityID).Msg("Failed to load facility for result submission email")
+		return
+	}
+	submitterName := ""
+	if submitter, err := q.GetUserByID(emailCtx, user.ID); err == nil {
+		submitterName = strings.TrimSpace(submitter.FirstName + " " + submitter.LastName)
+	}
+	message := email.BuildLeagueMatchResultSubmittedEmail(email.LeagueMatchResultSubmittedDetails{
+		FacilityName:  facility.Name,
+		LeagueName:    cm.League.Name,
+		HomeTeamName:  cm.HomeTeam.Name,
+		AwayTeamName:  cm.AwayTeam.Name,
+		HomeScore:     submission.HomeScore,
+		AwayScore:     submission.AwayScore,
+		SubmitterName: submitterName,
+		RespondBy:     submission.RespondBy.In(apiutil.TimezoneLocation(cm.League.FacilityTimezone, logger)).Format("Monday, Jan 2, 2006 3:04 PM MST"),
+	})
+	message.FacilityID = facility.ID
+	sender := email.ResolveFromAddress(emailCtx, q, facility, logger)
+	email.SendLeagueMatchResultSubmittedEmail(emailCtx, q, h.emailClient, cm.AwayTeam.CaptainUserID, message, sender, logger)
+}
+
+func decodeLeagueResultRequest(r *http.Request) (leagueResultRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req leagueResultRequest
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return leagueResultRequest{}, err
+		}
+		if req.HomeScore < 0 || req.AwayScore < 0 {
+			return leagueResultRequest{}, fmt.Errorf("scores must be non-negative integers")
+		}
+		return req, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return leagueResultRequest{}, err
+	}
+	homeScore, err := parseLeagueScore(apiutil.FirstNonEmpty(r.FormValue("home_score"), r.FormValue("homeScore")), "home_score")
+	if err != nil {
+		return leagueResultRequest{}, err
+	}
+	awayScore, err := parseLeagueScore(apiutil.FirstNonEmpty(r.FormValue("away_score"), r.FormValue("awayScore")), "away_score")
+	if err != nil {
+		return leagueResultRequest{}, err
+	}
+	return leagueResultRequest{HomeScore: homeScore, AwayScore: awayScore}, nil
+}
+
+func parseLeagueScore(raw string, field string) (int64, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, fmt.Errorf("%s is required", field)
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer", field)
+	}
+	return value, nil
+}
+
+func decodeLeagueResultDisputeRequest(r *http.Request) (leagueResultDisputeRequest, error) {
+	var req leagueResultDisputeRequest
+	if apiutil.IsJSONRequest(r) {
+		if r.ContentLength != 0 {
+			if err := apiutil.DecodeJSON(r, &req); err != nil {
+				return leagueResultDisputeRequest{}, err
+			}
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return leagueResultDisputeRequest{}, err
+		}
+		req.Reason = r.FormValue("reason")
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if len(req.Reason) > disputeReasonMaxLength {
+		return leagueResultDisputeRequest{}, fmt.Errorf("reason must be at most %d characters", disputeReasonMaxLength)
+	}
+	return req, nil
+}
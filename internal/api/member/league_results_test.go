@@ -0,0 +1,166 @@
+package member
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestHandleLeagueResultSubmitConfirmAndDispute(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	insertMember := func(first, emailAddress string) int64 {
+		t.Helper()
+		result, err := database.Exec(
+			`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+			 VALUES (?, 'Captain', ?, 'active', 1, 1, 2, ?)`,
+			first, emailAddress, facilityID,
+		)
+		if err != nil {
+			t.Fatalf("insert member %s: %v", first, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	homeCaptainID := insertMember("Home", "home@test.com")
+	awayCaptainID := insertMember("Away", "away@test.com")
+
+	leagueResult, err := database.Exec(
+		`INSERT INTO leagues (facility_id, name, format, start_date, end_date, division_config, min_team_size, max_team_size, status)
+		 VALUES (?, 'Doubles Ladder', 'doubles', '2026-06-01', '2026-08-01', '{}', 1, 4, 'active')`,
+		facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert league: %v", err)
+	}
+	leagueID, _ := leagueResult.LastInsertId()
+
+	insertTeam := func(name string, captainID int64) int64 {
+		t.Helper()
+		result, err := database.Exec(
+			"INSERT INTO league_teams (league_id, name, captain_user_id, status) VALUES (?, ?, ?, 'active')",
+			leagueID, name, captainID,
+		)
+		if err != nil {
+			t.Fatalf("insert team %s: %v", name, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	homeTeamID := insertTeam("Dinkers", homeCaptainID)
+	awayTeamID := insertTeam("Lobbers", awayCaptainID)
+
+	insertMatch := func(scheduled time.Time) int64 {
+		t.Helper()
+		result, err := database.Exec(
+			"INSERT INTO league_matches (league_id, home_team_id, away_team_id, scheduled_time, status) VALUES (?, ?, ?, ?, 'scheduled')",
+			leagueID, homeTeamID, awayTeamID, scheduled.UTC(),
+		)
+		if err != nil {
+			t.Fatalf("insert match: %v", err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	confirmedMatchID := insertMatch(time.Now().Add(-2 * time.Hour))
+	disputedMatchID := insertMatch(time.Now().Add(-time.Hour))
+	futureMatchID := insertMatch(time.Now().Add(24 * time.Hour))
+
+	h := NewHandlers(database, nil, Options{})
+
+	call := func(handler http.HandlerFunc, userID, matchID int64, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/member/leagues/%d/matches/%d/%s", leagueID, matchID, path), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetPathValue("id", fmt.Sprintf("%d", leagueID))
+		req.SetPathValue("match_id", fmt.Sprintf("%d", matchID))
+		homeFacilityID := facilityID
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              userID,
+			HomeFacilityID:  &homeFacilityID,
+			MembershipLevel: 2,
+		}))
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+	expect := func(recorder *httptest.ResponseRecorder, status int, fragment string) {
+		t.Helper()
+		if recorder.Code != status || !strings.Contains(recorder.Body.String(), fragment) {
+			t.Fatalf("got %d %q, want %d containing %q", recorder.Code, recorder.Body.String(), status, fragment)
+		}
+	}
+	matchState := func(matchID int64) (string, sql.NullInt64) {
+		t.Helper()
+		var status string
+		var homeScore sql.NullInt64
+		if err := database.QueryRow("SELECT status, home_score FROM league_matches WHERE id = ?", matchID).Scan(&status, &homeScore); err != nil {
+			t.Fatalf("load match: %v", err)
+		}
+		return status, homeScore
+	}
+
+	expect(call(h.HandleLeagueResultSubmit, homeCaptainID, confirmedMatchID, "result", "home_score=11&away_score=10"), http.StatusBadRequest, "at least two points")
+	expect(call(h.HandleLeagueResultSubmit, awayCaptainID, confirmedMatchID, "result", "home_score=11&away_score=7"), http.StatusForbidden, "home team captain")
+	expect(call(h.HandleLeagueResultSubmit, homeCaptainID, futureMatchID, "result", "home_score=11&away_score=7"), http.StatusConflict, "not started")
+	expect(call(h.HandleLeagueResultConfirm, awayCaptainID, confirmedMatchID, "result/confirm", ""), http.StatusNotFound, "No result has been submitted")
+
+	// Submitting parks the match without scores so standings ignore it.
+	expect(call(h.HandleLeagueResultSubmit, homeCaptainID, confirmedMatchID, "result", "home_score=11&away_score=7"), http.StatusCreated, `"status":"pending_result"`)
+	if status, homeScore := matchState(confirmedMatchID); status != "pending_result" || homeScore.Valid {
+		t.Fatalf("after submit: status %q home score %+v", status, homeScore)
+	}
+	expect(call(h.HandleLeagueResultSubmit, homeCaptainID, confirmedMatchID, "result", "home_score=11&away_score=7"), http.StatusConflict, "not awaiting a result")
+	expect(call(h.HandleLeagueResultConfirm, homeCaptainID, confirmedMatchID, "result/confirm", ""), http.StatusForbidden, "away team captain")
+
+	expect(call(h.HandleLeagueResultConfirm, awayCaptainID, confirmedMatchID, "result/confirm", ""), http.StatusOK, `"status":"confirmed"`)
+	if status, homeScore := matchState(confirmedMatchID); status != "completed" || homeScore.Int64 != 11 {
+		t.Fatalf("after confirm: status %q home score %+v", status, homeScore)
+	}
+	expect(call(h.HandleLeagueResultDispute, awayCaptainID, confirmedMatchID, "result/dispute", ""), http.StatusConflict, "no longer awaiting confirmation")
+
+	expect(call(h.HandleLeagueResultSubmit, homeCaptainID, disputedMatchID, "result", "home_score=11&away_score=9"), http.StatusCreated, "")
+	expect(call(h.HandleLeagueResultDispute, awayCaptainID, disputedMatchID, "result/dispute", "reason=We+won+game+two"), http.StatusOK, `"disputeReason":{"String":"We won game two"`)
+	if status, homeScore := matchState(disputedMatchID); status != "disputed" || homeScore.Valid {
+		t.Fatalf("after dispute: status %q home score %+v", status, homeScore)
+	}
+	expect(call(h.HandleLeagueResultConfirm, awayCaptainID, disputedMatchID, "result/confirm", ""), http.StatusConflict, "no longer awaiting confirmation")
+
+	// Past the response window the away captain can no longer answer.
+	if _, err := database.Exec(
+		`INSERT INTO league_match_result_submissions (league_match_id, submitted_by_user_id, home_score, away_score, respond_by)
+		 VALUES (?, ?, 11, 4, ?)`,
+		futureMatchID, homeCaptainID, time.Now().Add(-time.Minute).UTC(),
+	); err != nil {
+		t.Fatalf("insert lapsed submission: %v", err)
+	}
+	if _, err := database.Exec("UPDATE league_matches SET status = 'pending_result' WHERE id = ?", futureMatchID); err != nil {
+		t.Fatalf("mark match pending: %v", err)
+	}
+	expect(call(h.HandleLeagueResultDispute, awayCaptainID, futureMatchID, "result/dispute", ""), http.StatusConflict, "response window")
+}
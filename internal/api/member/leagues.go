@@ -0,0 +1,807 @@
+package member
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	leaguecore "github.com/codr1/Pickleicious/internal/leagues"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+const (
+	freeAgentSkillLevelMaxLength   = 32
+	freeAgentAvailabilityMaxLength = 500
+
+	leagueInvitationTTL        = 7 * 24 * time.Hour
+	leagueInvitationTokenBytes = 24
+)
+
+type teamInvitationRequest struct {
+	Email string `json:"email"`
+}
+
+type teamInvitationResponse struct {
+	ID            int64     `json:"id"`
+	TeamID        int64     `json:"teamId"`
+	InvitedUserID int64     `json:"invitedUserId"`
+	Status        string    `json:"status"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+type freeAgentRegistrationRequest struct {
+	SkillLevel        string `json:"skillLevel"`
+	AvailabilityNotes string `json:"availabilityNotes"`
+	Gender            string `json:"gender"`
+}
+
+// HandleMemberLeagueTeams handles GET /member/leagues. Captains also see
+// what their team still owes toward the league's registration fee.
+func (h *Handlers) HandleMemberLeagueTeams(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	rows, err := q.ListMemberLeagueTeams(ctx, dbgen.ListMemberLeagueTeamsParams{
+		FacilityID: *user.HomeFacilityID,
+		UserID:     user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load league teams")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load leagues")
+		return
+	}
+
+	teams := make([]membertempl.LeagueTeamSummary, 0, len(rows))
+	for _, row := range rows {
+		team := membertempl.LeagueTeamSummary{
+			LeagueID:     row.LeagueID,
+			LeagueName:   row.LeagueName,
+			LeagueStatus: row.LeagueStatus,
+			TeamID:       row.TeamID,
+			TeamName:     row.TeamName,
+			IsCaptain:    row.CaptainUserID == user.ID,
+			FeeBasis:     row.FeeBasis,
+		}
+		if team.IsCaptain && row.RegistrationFeeCents > 0 {
+			payment, _, err := leaguecore.LoadTeamPayment(ctx, q, row.RegistrationFeeCents, row.FeeBasis, row.TeamID)
+			if err != nil {
+				logger.Error().Err(err).Int64("team_id", row.TeamID).Msg("Failed to load team payments")
+				apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load leagues")
+				return
+			}
+			team.OutstandingCents = payment.OutstandingCents
+		}
+		teams = append(teams, team)
+	}
+
+	component := membertempl.MemberLeagueTeams(membertempl.LeagueTeamListData{Teams: teams})
+	if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render leagues", "Failed to render leagues") {
+		return
+	}
+}
+
+// HandleLeagueFreeAgentRegister handles POST /member/leagues/{id}/free-agent.
+// Members sign up without a team; staff later place them from the league's
+// free-agent list.
+func (h *Handlers) HandleLeagueFreeAgentRegister(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodPost {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	leagueID, err := parseLeagueID(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	req, err := decodeFreeAgentRegistrationRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	var registration dbgen.LeagueFreeAgent
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		league, err := qtx.GetLeague(ctx, leagueID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "League not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch league", Err: err}
+		}
+		if league.FacilityID != *user.HomeFacilityID {
+			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "League not found"}
+		}
+		if league.Status != "registration" {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "League is not open for registration"}
+		}
+
+		gender, err := freeAgentGenderForFormat(league.Format, req.Gender)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusBadRequest, Message: err.Error()}
+		}
+
+		onTeam, err := qtx.IsUserOnLeagueTeam(ctx, dbgen.IsUserOnLeagueTeamParams{
+			LeagueID: leagueID,
+			UserID:   user.ID,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check league teams", Err: err}
+		}
+		if onTeam != 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "You are already on a team in this league"}
+		}
+
+		registration, err = qtx.CreateLeagueFreeAgentRegistration(ctx, dbgen.CreateLeagueFreeAgentRegistrationParams{
+			LeagueID:          leagueID,
+			UserID:            user.ID,
+			SkillLevel:        req.SkillLevel,
+			AvailabilityNotes: sql.NullString{String: req.AvailabilityNotes, Valid: req.AvailabilityNotes != ""},
+			Gender:            gender,
+		})
+		if err != nil {
+			if apiutil.IsSQLiteUniqueViolation(err) {
+				return apiutil.HandlerError{Status: http.StatusConflict, Message: "Already registered as a free agent", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to register as a free agent", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("league_id", leagueID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to register as a free agent")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to register as a free agent")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, registration); err != nil {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to write free agent registration response")
+		return
+	}
+}
+
+// HandleLeagueFreeAgentWithdraw handles DELETE /member/leagues/{id}/free-agent.
+func (h *Handlers) HandleLeagueFreeAgentWithdraw(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodDelete {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	leagueID, err := parseLeagueID(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	league, err := q.GetLeague(ctx, leagueID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
+			return
+		}
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to fetch league")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
+		return
+	}
+	if league.FacilityID != *user.HomeFacilityID {
+		apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
+		return
+	}
+
+	removed, err := q.DeleteLeagueFreeAgentRegistration(ctx, dbgen.DeleteLeagueFreeAgentRegistrationParams{
+		LeagueID: leagueID,
+		UserID:   user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to withdraw free agent registration")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to withdraw free agent registration")
+		return
+	}
+	if removed == 0 {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Free agent registration not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleTeamInvitationCreate handles POST
+// /member/leagues/{id}/teams/{team_id}/invitations. The team captain invites
+// a member by email; the invitee joins once they follow the emailed link.
+func (h *Handlers) HandleTeamInvitationCreate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodPost {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	leagueID, err := parseLeagueID(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
+		return
+	}
+	teamID, err := parsePathInt64(r, "team_id")
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	req, err := decodeTeamInvitationRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	league, team, err := loadCaptainTeam(ctx, q, leagueID, teamID, user)
+	if err != nil {
+		writeLeagueHandlerError(w, r, logger, leagueID, err)
+		return
+	}
+	if leagueRosterLocked(league, logger) {
+		apiutil.WriteError(w, r, http.StatusConflict, "Roster is locked for this league")
+		return
+	}
+
+	invitee, err := q.GetUserByEmail(ctx, sql.NullString{String: req.Email, Valid: true})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to look up invitee")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to send invitation")
+		return
+	}
+	if err != nil || !invitee.IsMember || !invitee.HomeFacilityID.Valid || invitee.HomeFacilityID.Int64 != league.FacilityID {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Member not found")
+		return
+	}
+
+	onTeam, err := q.IsUserOnLeagueTeam(ctx, dbgen.IsUserOnLeagueTeamParams{
+		LeagueID: leagueID,
+		UserID:   invitee.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to check league teams")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to send invitation")
+		return
+	}
+	if onTeam != 0 {
+		apiutil.WriteError(w, r, http.StatusConflict, "Member is already on a team in this league")
+		return
+	}
+
+	members, err := q.ListTeamMembers(ctx, team.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("team_id", team.ID).Msg("Failed to list team members")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to send invitation")
+		return
+	}
+	if int64(len(members)) >= league.MaxTeamSize {
+		apiutil.WriteError(w, r, http.StatusConflict, "Team is at max size")
+		return
+	}
+
+	token, err := newLeagueInvitationToken()
+	if err != nil {
+		logger.Error().Err(err).Int64("team_id", team.ID).Msg("Failed to generate invitation token")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to send invitation")
+		return
+	}
+	invitation, err := q.CreateLeagueTeamInvitation(ctx, dbgen.CreateLeagueTeamInvitationParams{
+		LeagueTeamID:    team.ID,
+		InvitedUserID:   invitee.ID,
+		InvitedByUserID: user.ID,
+		Token:           token,
+		ExpiresAt:       time.Now().Add(leagueInvitationTTL).UTC(),
+	})
+	if err != nil {
+		if apiutil.IsSQLiteUniqueViolation(err) {
+			apiutil.WriteError(w, r, http.StatusConflict, "Member already has a pending invitation")
+			return
+		}
+		logger.Error().Err(err).Int64("team_id", team.ID).Msg("Failed to create team invitation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to send invitation")
+		return
+	}
+
+	if h.emailClient != nil {
+		emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(r.Context()), portalQueryTimeout)
+		defer emailCancel()
+		facility, err := h.loadFacilities().GetFacilityByID(emailCtx, league.FacilityID)
+		if err != nil {
+			logger.Error().Err(err).Int64("facility_id", league.FacilityID).Msg("Failed to load facility for invitation email")
+		} else {
+			captainName := ""
+			if captain, err := q.GetUserByID(emailCtx, user.ID); err == nil {
+				captainName = strings.TrimSpace(captain.FirstName + " " + captain.LastName)
+			}
+			message := email.BuildLeagueTeamInvitationEmail(email.LeagueTeamInvitationDetails{
+				FacilityName: facility.Name,
+				LeagueName:   league.Name,
+				TeamName:     team.Name,
+				CaptainName:  captainName,
+				AcceptURL:    h.leagueInvitationAcceptURL(invitation.Token),
+				ExpiresAt:    invitation.ExpiresAt.In(apiutil.TimezoneLocation(league.FacilityTimezone, logger)).Format("Monday, Jan 2, 2006 3:04 PM MST"),
+			})
+			message.FacilityID = facility.ID
+			sender := email.ResolveFromAddress(emailCtx, q, facility, logger)
+			email.SendLeagueTeamInvitationEmail(emailCtx, q, h.emailClient, invitee.ID, message, sender, logger)
+		}
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, teamInvitationResponse{
+		ID:            invitation.ID,
+		TeamID:        invitation.LeagueTeamID,
+		InvitedUserID: invitation.InvitedUserID,
+		Status:        invitation.Status,
+		ExpiresAt:     invitation.ExpiresAt,
+	}); err != nil {
+		logger.Error().Err(err).Int64("team_id", team.ID).Msg("Failed to write team invitation response")
+		return
+	}
+}
+
+// HandleTeamInvitationAccept handles GET and POST
+// /member/league-invitations/{token}/accept. GET is the emailed link and
+// redirects to the portal once the member is on the team.
+func (h *Handlers) HandleTeamInvitationAccept(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	token := strings.TrimSpace(r.PathValue("token"))
+	if token == "" {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Invitation not found")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	var member dbgen.LeagueTeamMember
+	err := database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		invitation, err := qtx.GetLeagueTeamInvitationByToken(ctx, token)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Invitation not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load invitation", Err: err}
+		}
+		if invitation.InvitedUserID != user.ID {
+			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Invitation not found"}
+		}
+		if invitation.Status != "pending" {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Invitation is no longer pending"}
+		}
+		if !time.Now().Before(invitation.ExpiresAt) {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Invitation has expired"}
+		}
+
+		league, err := qtx.GetLeagueWithFacilityTimezone(ctx, invitation.LeagueID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch league", Err: err}
+		}
+		if leagueRosterLocked(league, logger) {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Roster is locked for this league"}
+		}
+
+		onTeam, err := qtx.IsUserOnLeagueTeam(ctx, dbgen.IsUserOnLeagueTeamParams{
+			LeagueID: invitation.LeagueID,
+			UserID:   user.ID,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check league teams", Err: err}
+		}
+		if onTeam != 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "You are already on a team in this league"}
+		}
+
+		members, err := qtx.ListTeamMembers(ctx, invitation.LeagueTeamID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check team size", Err: err}
+		}
+		if int64(len(members)) >= league.MaxTeamSize {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Team is at max size"}
+		}
+
+		accepted, err := qtx.MarkLeagueTeamInvitationAccepted(ctx, invitation.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to accept invitation", Err: err}
+		}
+		if accepted == 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Invitation is no longer pending"}
+		}
+		member, err = qtx.AddTeamMember(ctx, dbgen.AddTeamMemberParams{
+			LeagueTeamID: invitation.LeagueTeamID,
+			UserID:       user.ID,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to join team", Err: err}
+		}
+		if _, err := qtx.DeleteLeagueFreeAgentRegistration(ctx, dbgen.DeleteLeagueFreeAgentRegistrationParams{
+			LeagueID: invitation.LeagueID,
+			UserID:   user.ID,
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to join team", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("user_id", user.ID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to accept team invitation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to accept invitation")
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		http.Redirect(w, r, "/member", http.StatusSeeOther)
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, member); err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to write team invitation response")
+		return
+	}
+}
+
+// HandleCaptainRemoveTeamMember handles DELETE
+// /member/leagues/{id}/teams/{team_id}/members/{user_id}. Captains can
+// change their roster until the season's first match starts.
+func (h *Handlers) HandleCaptainRemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodDelete {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	leagueID, err := parseLeagueID(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid league ID")
+		return
+	}
+	teamID, err := parsePathInt64(r, "team_id")
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+	memberID, err := parsePathInt64(r, "user_id")
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	league, team, err := loadCaptainTeam(ctx, q, leagueID, teamID, user)
+	if err != nil {
+		writeLeagueHandlerError(w, r, logger, leagueID, err)
+		return
+	}
+	if leagueRosterLocked(league, logger) {
+		apiutil.WriteError(w, r, http.StatusConflict, "Roster is locked for this league")
+		return
+	}
+
+	firstMatch, err := q.GetFirstLeagueMatchTime(ctx, leagueID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error().Err(err).Int64("league_id", leagueID).Msg("Failed to load league schedule")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to remove team member")
+		return
+	}
+	if err == nil && !time.Now().Before(firstMatch) {
+		apiutil.WriteError(w, r, http.StatusConflict, "Members cannot be removed after the season's first match")
+		return
+	}
+
+	removed, err := q.RemoveTeamMember(ctx, dbgen.RemoveTeamMemberParams{
+		LeagueTeamID: team.ID,
+		UserID:       memberID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("team_id", team.ID).Msg("Failed to remove team member")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to remove team member")
+		return
+	}
+	if removed == 0 {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Team member not found")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"removed": memberID}); err != nil {
+		logger.Error().Err(err).Int64("team_id", team.ID).Msg("Failed to write team member response")
+	}
+}
+
+// loadCaptainTeam loads a team in a league at the member's home facility
+// and confirms the member captains it.
+func loadCaptainTeam(ctx context.Context, q *dbgen.Queries, leagueID, teamID int64, user *authz.AuthUser) (dbgen.GetLeagueWithFacilityTimezoneRow, dbgen.LeagueTeam, error) {
+	league, err := q.GetLeagueWithFacilityTimezone(ctx, leagueID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return league, dbgen.LeagueTeam{}, apiutil.HandlerError{Status: http.StatusNotFound, Message: "League not found", Err: err}
+		}
+		return league, dbgen.LeagueTeam{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch league", Err: err}
+	}
+	if user.HomeFacilityID == nil || league.FacilityID != *user.HomeFacilityID {
+		return league, dbgen.LeagueTeam{}, apiutil.HandlerError{Status: http.StatusNotFound, Message: "League not found"}
+	}
+
+	team, err := q.GetLeagueTeam(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return league, team, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Team not found", Err: err}
+		}
+		return league, team, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch team", Err: err}
+	}
+	if team.LeagueID != leagueID {
+		return league, team, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Team not found"}
+	}
+	if team.CaptainUserID != user.ID {
+		return league, team, apiutil.HandlerError{Status: http.StatusForbidden, Message: "Only the team captain can manage this roster"}
+	}
+	return league, team, nil
+}
+
+func writeLeagueHandlerError(w http.ResponseWriter, r *http.Request, logger *zerolog.Logger, leagueID int64, err error) {
+	var herr apiutil.HandlerError
+	if errors.As(err, &herr) {
+		if herr.Status == http.StatusInternalServerError {
+			logger.Error().Err(herr.Err).Int64("league_id", leagueID).Msg(herr.Message)
+		}
+		apiutil.WriteHandlerError(w, r, herr)
+		return
+	}
+	logger.Error().Err(err).Int64("league_id", leagueID).Msg("League request failed")
+	apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+}
+
+func leagueRosterLocked(league dbgen.GetLeagueWithFacilityTimezoneRow, logger *zerolog.Logger) bool {
+	return leaguecore.RosterLockedAt(league.RosterLockDate, apiutil.TimezoneLocation(league.FacilityTimezone, logger), time.Now())
+}
+
+func (h *Handlers) leagueInvitationAcceptURL(token string) string {
+	return h.absoluteURL(fmt.Sprintf("/member/league-invitations/%s/accept", token))
+}
+
+func newLeagueInvitationToken() (string, error) {
+	token := make([]byte, leagueInvitationTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func decodeTeamInvitationRequest(r *http.Request) (teamInvitationRequest, error) {
+	var req teamInvitationRequest
+	if apiutil.IsJSONRequest(r) {
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return teamInvitationRequest{}, err
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return teamInvitationRequest{}, err
+		}
+		req.Email = r.FormValue("email")
+	}
+	req.Email = strings.TrimSpace(req.Email)
+	if req.Email == "" {
+		return teamInvitationRequest{}, fmt.Errorf("email is required")
+	}
+	return req, nil
+}
+
+func decodeFreeAgentRegistrationRequest(r *http.Request) (freeAgentRegistrationRequest, error) {
+	var req freeAgentRegistrationRequest
+	if apiutil.IsJSONRequest(r) {
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return freeAgentRegistrationRequest{}, err
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return freeAgentRegistrationRequest{}, err
+		}
+		req = freeAgentRegistrationRequest{
+			SkillLevel:        apiutil.FirstNonEmpty(r.FormValue("skill_level"), r.FormValue("skillLevel")),
+			AvailabilityNotes: apiutil.FirstNonEmpty(r.FormValue("availability_notes"), r.FormValue("availabilityNotes")),
+			Gender:            r.FormValue("gender"),
+		}
+	}
+
+	req.SkillLevel = strings.TrimSpace(req.SkillLevel)
+	req.AvailabilityNotes = strings.TrimSpace(req.AvailabilityNotes)
+	req.Gender = strings.ToLower(strings.TrimSpace(req.Gender))
+
+	if req.SkillLevel == "" {
+		return freeAgentRegistrationRequest{}, fmt.Errorf("skill_level is required")
+	}
+	if len(req.SkillLevel) > freeAgentSkillLevelMaxLength {
+		return freeAgentRegistrationRequest{}, fmt.Errorf("skill_level must be at most %d characters", freeAgentSkillLevelMaxLength)
+	}
+	if len(req.AvailabilityNotes) > freeAgentAvailabilityMaxLength {
+		return freeAgentRegistrationRequest{}, fmt.Errorf("availability_notes must be at most %d characters", freeAgentAvailabilityMaxLength)
+	}
+	return req, nil
+}
+
+// freeAgentGenderForFormat validates gender against the league format. Mixed
+// doubles pairing needs it; other formats keep it only when given.
+func freeAgentGenderForFormat(format string, gender string) (sql.NullString, error) {
+	switch gender {
+	case "":
+		if format == "mixed_doubles" {
+			return sql.NullString{}, fmt.Errorf("gender is required for mixed doubles leagues")
+		}
+		return sql.NullString{}, nil
+	case "male", "female":
+		return sql.NullString{String: gender, Valid: true}, nil
+	default:
+		return sql.NullString{}, fmt.Errorf("gender must be male or female")
+	}
+}
+
+func parseLeagueID(r *http.Request) (int64, error) {
+	return parsePathInt64(r, "id")
+}
+
+func parsePathInt64(r *http.Request, key string) (int64, error) {
+	pathID := strings.TrimSpace(r.PathValue(key))
+	if pathID == "" {
+		return 0, fmt.Errorf("invalid %s", key)
+	}
+	id, err := strconv.ParseInt(pathID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid %s", key)
+	}
+	return id, nil
+}
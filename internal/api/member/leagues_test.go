@@ -0,0 +1,395 @@
+package member
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestHandleLeagueFreeAgentRegistration(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+		"Free", "Agent", "free-agent@test.com", "active", facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	insertLeague := func(name, format, status string) int64 {
+		t.Helper()
+		result, err := database.Exec(
+			`INSERT INTO leagues (facility_id, name, format, start_date, end_date, division_config, min_team_size, max_team_size, status)
+			 VALUES (?, ?, ?, '2027-06-01', '2027-08-01', '{}', 2, 4, ?)`,
+			facilityID, name, format, status,
+		)
+		if err != nil {
+			t.Fatalf("insert league %s: %v", name, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	mixedID := insertLeague("Mixed Mondays", "mixed_doubles", "registration")
+	activeID := insertLeague("Doubles Ladder", "doubles", "active")
+	teamLeagueID := insertLeague("Doubles Draft", "doubles", "registration")
+
+	teamResult, err := database.Exec(
+		"INSERT INTO league_teams (league_id, name, captain_user_id, status) VALUES (?, 'Kitchen Kings', ?, 'active')",
+		teamLeagueID, memberID,
+	)
+	if err != nil {
+		t.Fatalf("insert team: %v", err)
+	}
+	teamID, _ := teamResult.LastInsertId()
+	if _, err := database.Exec("INSERT INTO league_team_members (league_team_id, user_id) VALUES (?, ?)", teamID, memberID); err != nil {
+		t.Fatalf("insert team member: %v", err)
+	}
+
+	h := NewHandlers(database, nil, Options{})
+
+	call := func(method string, leagueID int64, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, fmt.Sprintf("/member/leagues/%d/free-agent", leagueID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetPathValue("id", fmt.Sprintf("%d", leagueID))
+		homeFacilityID := facilityID
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              memberID,
+			HomeFacilityID:  &homeFacilityID,
+			MembershipLevel: 2,
+		}))
+		recorder := httptest.NewRecorder()
+		if method == http.MethodDelete {
+			h.HandleLeagueFreeAgentWithdraw(recorder, req)
+		} else {
+			h.HandleLeagueFreeAgentRegister(recorder, req)
+		}
+		return recorder
+	}
+	expect := func(recorder *httptest.ResponseRecorder, status int, fragment string) {
+		t.Helper()
+		if recorder.Code != status || !strings.Contains(recorder.Body.String(), fragment) {
+			t.Fatalf("got %d %q, want %d containing %q", recorder.Code, recorder.Body.String(), status, fragment)
+		}
+	}
+
+	expect(call(http.MethodPost, mixedID, "skill_level=3.5"), http.StatusBadRequest, "gender is required")
+	expect(call(http.MethodPost, mixedID, "availability_notes=weeknights&gender=female"), http.StatusBadRequest, "skill_level is required")
+	expect(call(http.MethodPost, activeID, "skill_level=3.5"), http.StatusConflict, "not open for registration")
+	expect(call(http.MethodPost, teamLeagueID, "skill_level=3.5"), http.StatusConflict, "already on a team")
+
+	expect(call(http.MethodPost, mixedID, "skill_level=3.5&availability_notes=Weeknights+after+6&gender=Female"), http.StatusCreated, `"skillLevel":"3.5"`)
+	var gender, notes string
+	if err := database.QueryRow(
+		"SELECT gender, availability_notes FROM league_free_agents WHERE league_id = ? AND user_id = ?",
+		mixedID, memberID,
+	).Scan(&gender, &notes); err != nil {
+		t.Fatalf("load registration: %v", err)
+	}
+	if gender != "female" || notes != "Weeknights after 6" {
+		t.Fatalf("stored gender %q notes %q", gender, notes)
+	}
+	expect(call(http.MethodPost, mixedID, "skill_level=4.0&gender=female"), http.StatusConflict, "Already registered")
+
+	expect(call(http.MethodDelete, mixedID, ""), http.StatusNoContent, "")
+	expect(call(http.MethodDelete, mixedID, ""), http.StatusNotFound, "registration not found")
+}
+
+func TestCaptainRosterManagement(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	insertMember := func(first, emailAddress string) int64 {
+		t.Helper()
+		result, err := database.Exec(
+			`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+			 VALUES (?, 'Player', ?, 'active', 1, 1, 2, ?)`,
+			first, emailAddress, facilityID,
+		)
+		if err != nil {
+			t.Fatalf("insert member %s: %v", first, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	captainID := insertMember("Casey", "casey@test.com")
+	inviteeID := insertMember("Jordan", "jordan@test.com")
+	thirdID := insertMember("Riley", "riley@test.com")
+
+	leagueResult, err := database.Exec(
+		`INSERT INTO leagues (facility_id, name, format, start_date, end_date, division_config, min_team_size, max_team_size, status)
+		 VALUES (?, 'Summer Doubles', 'doubles', '2027-06-01', '2027-08-01', '{}', 1, 2, 'registration')`,
+		facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert league: %v", err)
+	}
+	leagueID, _ := leagueResult.LastInsertId()
+
+	teamResult, err := database.Exec(
+		"INSERT INTO league_teams (league_id, name, captain_user_id, status) VALUES (?, 'Kitchen Kings', ?, 'active')",
+		leagueID, captainID,
+	)
+	if err != nil {
+		t.Fatalf("insert team: %v", err)
+	}
+	teamID, _ := teamResult.LastInsertId()
+	if _, err := database.Exec("INSERT INTO league_team_members (league_team_id, user_id) VALUES (?, ?)", teamID, captainID); err != nil {
+		t.Fatalf("insert captain membership: %v", err)
+	}
+	if _, err := database.Exec(
+		"INSERT INTO league_free_agents (league_id, user_id, skill_level) VALUES (?, ?, '3.5')",
+		leagueID, inviteeID,
+	); err != nil {
+		t.Fatalf("insert free agent registration: %v", err)
+	}
+
+	h := NewHandlers(database, nil, Options{})
+
+	asMember := func(req *http.Request, userID int64) *http.Request {
+		homeFacilityID := facilityID
+		return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              userID,
+			HomeFacilityID:  &homeFacilityID,
+			MembershipLevel: 2,
+		}))
+	}
+	invite := func(actorID int64, emailAddress string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/member/leagues/%d/teams/%d/invitations", leagueID, teamID), strings.NewReader("email="+emailAddress))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetPathValue("id", fmt.Sprintf("%d", leagueID))
+		req.SetPathValue("team_id", fmt.Sprintf("%d", teamID))
+		recorder := httptest.NewRecorder()
+		h.HandleTeamInvitationCreate(recorder, asMember(req, actorID))
+		return recorder
+	}
+	accept := func(userID int64, token string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/member/league-invitations/"+token+"/accept", nil)
+		req.SetPathValue("token", token)
+		recorder := httptest.NewRecorder()
+		h.HandleTeamInvitationAccept(recorder, asMember(req, userID))
+		return recorder
+	}
+	remove := func(userID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/member/leagues/%d/teams/%d/members/%d", leagueID, teamID, userID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", leagueID))
+		req.SetPathValue("team_id", fmt.Sprintf("%d", teamID))
+		req.SetPathValue("user_id", fmt.Sprintf("%d", userID))
+		recorder := httptest.NewRecorder()
+		h.HandleCaptainRemoveTeamMember(recorder, asMember(req, captainID))
+		return recorder
+	}
+	expect := func(recorder *httptest.ResponseRecorder, status int, fragment string) {
+		t.Helper()
+		if recorder.Code != status || !strings.Contains(recorder.Body.String(), fragment) {
+			t.Fatalf("got %d %q, want %d containing %q", recorder.Code, recorder.Body.String(), status, fragment)
+		}
+	}
+
+	expect(invite(inviteeID, "riley@test.com"), http.StatusForbidden, "Only the team captain")
+	expect(invite(captainID, "nobody@test.com"), http.StatusNotFound, "Member not found")
+
+	expect(invite(captainID, "jordan@test.com"), http.StatusCreated, `"status":"pending"`)
+	expect(invite(captainID, "jordan@test.com"), http.StatusConflict, "pending invitation")
+	var token string
+	if err := database.QueryRow("SELECT token FROM league_team_invitations WHERE invited_user_id = ?", inviteeID).Scan(&token); err != nil {
+		t.Fatalf("load invitation token: %v", err)
+	}
+
+	expect(accept(thirdID, token), http.StatusNotFound, "Invitation not found")
+	if recorder := accept(inviteeID, token); recorder.Code != http.StatusSeeOther {
+		t.Fatalf("expected accept redirect, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	expect(accept(inviteeID, token), http.StatusConflict, "no longer pending")
+	var registrations int
+	if err := database.QueryRow("SELECT COUNT(*) FROM league_free_agents WHERE user_id = ?", inviteeID).Scan(&registrations); err != nil {
+		t.Fatalf("count registrations: %v", err)
+	}
+	if registrations != 0 {
+		t.Fatalf("expected accepted invitee's free agent registration to be cleared")
+	}
+
+	// The team is now at its max size of two.
+	expect(invite(captainID, "riley@test.com"), http.StatusConflict, "max size")
+
+	if _, err := database.Exec("UPDATE leagues SET roster_lock_date = '2020-01-01' WHERE id = ?", leagueID); err != nil {
+		t.Fatalf("lock roster: %v", err)
+	}
+	expect(remove(inviteeID), http.StatusConflict, "Roster is locked")
+	if _, err := database.Exec("UPDATE leagues SET roster_lock_date = NULL WHERE id = ?", leagueID); err != nil {
+		t.Fatalf("unlock roster: %v", err)
+	}
+
+	otherTeam, err := database.Exec(
+		"INSERT INTO league_teams (league_id, name, captain_user_id, status) VALUES (?, 'Dink Dynasty', ?, 'active')",
+		leagueID, thirdID,
+	)
+	if err != nil {
+		t.Fatalf("insert opponent: %v", err)
+	}
+	otherTeamID, _ := otherTeam.LastInsertId()
+	if _, err := database.Exec(
+		"INSERT INTO league_matches (league_id, home_team_id, away_team_id, scheduled_time, status) VALUES (?, ?, ?, ?, 'scheduled')",
+		leagueID, teamID, otherTeamID, time.Now().Add(48*time.Hour).UTC(),
+	); err != nil {
+		t.Fatalf("insert upcoming match: %v", err)
+	}
+	expect(remove(inviteeID), http.StatusOK, fmt.Sprintf(`"removed":%d`, inviteeID))
+	expect(remove(inviteeID), http.StatusNotFound, "Team member not found")
+
+	if _, err := database.Exec("UPDATE league_matches SET scheduled_time = ? WHERE league_id = ?", time.Now().Add(-time.Hour).UTC(), leagueID); err != nil {
+		t.Fatalf("backdate match: %v", err)
+	}
+	expect(remove(captainID), http.StatusConflict, "after the season's first match")
+}
+
+func TestMemberLeagueTeamsShowsCaptainBalance(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	insertUser := func(email string) int64 {
+		t.Helper()
+		result, err := database.Exec(
+			`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+			 VALUES ('League', 'Player', ?, 'active', 1, 1, 2, ?)`,
+			email, facilityID,
+		)
+		if err != nil {
+			t.Fatalf("insert user %s: %v", email, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	memberID := insertUser("captain@test.com")
+	otherCaptainID := insertUser("other-captain@test.com")
+
+	insertTeam := func(leagueName, teamName string, captainID int64) int64 {
+		t.Helper()
+		leagueResult, err := database.Exec(
+			`INSERT INTO leagues (facility_id, name, format, start_date, end_date, division_config, min_team_size, max_team_size, status, registration_fee_cents, fee_basis)
+			 VALUES (?, ?, 'doubles', '2027-06-01', '2027-08-01', '{}', 2, 4, 'registration', 5000, 'team')`,
+			facilityID, leagueName,
+		)
+		if err != nil {
+			t.Fatalf("insert league %s: %v", leagueName, err)
+		}
+		leagueID, _ := leagueResult.LastInsertId()
+		teamResult, err := database.Exec(
+			"INSERT INTO league_teams (league_id, name, captain_user_id, status) VALUES (?, ?, ?, 'active')",
+			leagueID, teamName, captainID,
+		)
+		if err != nil {
+			t.Fatalf("insert team %s: %v", teamName, err)
+		}
+		teamID, _ := teamResult.LastInsertId()
+		if _, err := database.Exec("INSERT INTO league_team_members (league_team_id, user_id) VALUES (?, ?)", teamID, memberID); err != nil {
+			t.Fatalf("insert team member: %v", err)
+		}
+		return teamID
+	}
+	captainTeamID := insertTeam("Doubles Draft", "Kitchen Kings", memberID)
+	insertTeam("Mixed Mondays", "Lob Stars", otherCaptainID)
+
+	if _, err := database.Exec(
+		"INSERT INTO league_payments (league_team_id, amount_cents, method, recorded_by_user_id) VALUES (?, 2000, 'cash', ?)",
+		captainTeamID, otherCaptainID,
+	); err != nil {
+		t.Fatalf("insert payment: %v", err)
+	}
+
+	h := NewHandlers(database, nil, Options{})
+
+	req := httptest.NewRequest(http.MethodGet, "/member/leagues", nil)
+	homeFacilityID := facilityID
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:              memberID,
+		HomeFacilityID:  &homeFacilityID,
+		MembershipLevel: 2,
+	}))
+	recorder := httptest.NewRecorder()
+	h.HandleMemberLeagueTeams(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	body := recorder.Body.String()
+	if !strings.Contains(body, "Kitchen Kings") || !strings.Contains(body, "Lob Stars") {
+		t.Fatalf("expected both teams listed, got %s", body)
+	}
+	if strings.Count(body, "outstanding") != 1 || !strings.Contains(body, "$30.00") {
+		t.Fatalf("expected a single captain balance notice of $30.00, got %s", body)
+	}
+}
+
+func TestLeagueInvitationAcceptURLUsesConfiguredBaseURL(t *testing.T) {
+	h := NewHandlers(nil, nil, Options{BaseURL: "https://club.example.com/"})
+
+	got := h.leagueInvitationAcceptURL("tok123")
+	if want := "https://club.example.com/member/league-invitations/tok123/accept"; got != want {
+		t.Fatalf("accept URL = %q, want %q", got, want)
+	}
+}
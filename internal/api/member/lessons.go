@@ -17,11 +17,17 @@ import (
 	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/metrics"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
 	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
 )
 
 const lessonReservationTypeName = "PRO_SESSION"
 
+// Lessons stay on hour-long slots; facility slot settings only shape court
+// bookings.
+const memberLessonDuration = time.Hour
+
 type lessonPro struct {
 	ID        int64  `json:"id"`
 	FirstName string `json:"firstName"`
@@ -35,18 +41,18 @@ type lessonSlot struct {
 }
 
 // HandleLessonBookingFormNew handles GET /member/lessons/new.
-func HandleLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -56,7 +62,7 @@ func HandleLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
@@ -64,19 +70,21 @@ func HandleLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	maxAdvanceDays := apiutil.DefaultMaxAdvanceDays
-	facility, err := q.GetFacilityByID(ctx, *user.HomeFacilityID)
+	facilityLoc := apiutil.DefaultLocation()
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, *user.HomeFacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load facility booking config")
 	} else {
 		maxAdvanceDays = apiutil.NormalizedMaxAdvanceDays(facility.MaxAdvanceBookingDays, apiutil.DefaultMaxAdvanceDays)
+		facilityLoc = apiutil.FacilityLocation(facility, logger)
 	}
 
-	bookingDate := bookingDateFromRequest(r, maxAdvanceDays)
+	bookingDate := bookingDateFromRequest(r, maxAdvanceDays, time.Now().In(facilityLoc))
 
 	proRows, err := q.ListProsByFacility(ctx, sql.NullInt64{Int64: *user.HomeFacilityID, Valid: true})
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load pros")
-		http.Error(w, "Failed to load pros", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load pros")
 		return
 	}
 
@@ -89,7 +97,7 @@ func HandleLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
 	slots, err := buildLessonSlotOptions(ctx, q, *user.HomeFacilityID, selectedProID, bookingDate)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load lesson availability")
-		http.Error(w, "Failed to load availability", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load availability")
 		return
 	}
 
@@ -106,18 +114,18 @@ func HandleLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleLessonBookingSlots handles GET /member/lessons/slots.
-func HandleLessonBookingSlots(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleLessonBookingSlots(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -127,7 +135,7 @@ func HandleLessonBookingSlots(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
@@ -135,25 +143,27 @@ func HandleLessonBookingSlots(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	maxAdvanceDays := apiutil.DefaultMaxAdvanceDays
-	facility, err := q.GetFacilityByID(ctx, *user.HomeFacilityID)
+	facilityLoc := apiutil.DefaultLocation()
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, *user.HomeFacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load facility booking config")
 	} else {
 		maxAdvanceDays = apiutil.NormalizedMaxAdvanceDays(facility.MaxAdvanceBookingDays, apiutil.DefaultMaxAdvanceDays)
+		facilityLoc = apiutil.FacilityLocation(facility, logger)
 	}
 
-	bookingDate := bookingDateFromRequest(r, maxAdvanceDays)
+	bookingDate := bookingDateFromRequest(r, maxAdvanceDays, time.Now().In(facilityLoc))
 
 	proID, err := parseOptionalProID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	slots, err := buildLessonSlotOptions(ctx, q, *user.HomeFacilityID, proID, bookingDate)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load lesson availability")
-		http.Error(w, "Failed to load availability", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load availability")
 		return
 	}
 
@@ -164,19 +174,19 @@ func HandleLessonBookingSlots(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleListPros handles GET /member/lessons/pros.
-func HandleListPros(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleListPros(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
-	database := loadDB()
+	q := h.loadQueries()
+	database := h.loadDB()
 	if q == nil || database == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -186,7 +196,7 @@ func HandleListPros(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
@@ -196,7 +206,7 @@ func HandleListPros(w http.ResponseWriter, r *http.Request) {
 	proRows, err := q.ListProsByFacility(ctx, sql.NullInt64{Int64: *user.HomeFacilityID, Valid: true})
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load pros")
-		http.Error(w, "Failed to load pros", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load pros")
 		return
 	}
 
@@ -207,7 +217,7 @@ func HandleListPros(w http.ResponseWriter, r *http.Request) {
 	photoMap, err := loadUserPhotoMap(ctx, database, userIDs)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load pro photos")
-		http.Error(w, "Failed to load pros", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load pros")
 		return
 	}
 
@@ -228,18 +238,18 @@ func HandleListPros(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleProAvailability handles GET /member/lessons/pros/{id}/slots.
-func HandleProAvailability(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleProAvailability(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -249,13 +259,13 @@ func HandleProAvailability(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
 	proID, err := parseProIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid pro ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid pro ID")
 		return
 	}
 
@@ -265,25 +275,25 @@ func HandleProAvailability(w http.ResponseWriter, r *http.Request) {
 	staffRow, err := q.GetStaffByID(ctx, proID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Pro not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Pro not found")
 			return
 		}
 		logger.Error().Err(err).Int64("pro_id", proID).Msg("Failed to load pro")
-		http.Error(w, "Failed to load pro", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load pro")
 		return
 	}
 	if !strings.EqualFold(staffRow.Role, "pro") || !staffRow.HomeFacilityID.Valid || staffRow.HomeFacilityID.Int64 != *user.HomeFacilityID {
-		http.Error(w, "Pro not found", http.StatusNotFound)
+		apiutil.WriteError(w, r, http.StatusNotFound, "Pro not found")
 		return
 	}
 
 	targetDate, err := parseLessonDate(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
-	slotMinutes := fmt.Sprintf("%d", int64(memberBookingMinDuration.Minutes()))
+	slotMinutes := fmt.Sprintf("%d", int64(memberLessonDuration.Minutes()))
 	rows, err := q.GetProLessonSlots(ctx, dbgen.GetProLessonSlotsParams{
 		TargetDate:  targetDate.Format("2006-01-02"),
 		FacilityID:  *user.HomeFacilityID,
@@ -292,7 +302,7 @@ func HandleProAvailability(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		logger.Error().Err(err).Int64("pro_id", proID).Msg("Failed to load pro slots")
-		http.Error(w, "Failed to load availability", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load availability")
 		return
 	}
 
@@ -301,13 +311,13 @@ func HandleProAvailability(w http.ResponseWriter, r *http.Request) {
 		startTime, err := parseLessonSlotTime(row.StartTime, time.Local)
 		if err != nil {
 			logger.Error().Err(err).Int64("pro_id", proID).Msg("Failed to parse lesson slot start time")
-			http.Error(w, "Failed to load availability", http.StatusInternalServerError)
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load availability")
 			return
 		}
 		endTime, err := parseLessonSlotTime(row.EndTime, time.Local)
 		if err != nil {
 			logger.Error().Err(err).Int64("pro_id", proID).Msg("Failed to parse lesson slot end time")
-			http.Error(w, "Failed to load availability", http.StatusInternalServerError)
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load availability")
 			return
 		}
 		slots = append(slots, lessonSlot{
@@ -323,24 +333,24 @@ func HandleProAvailability(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleLessonBookingCreate handles POST /member/lessons for member lesson booking.
-func HandleLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	q := loadQueries()
-	database := loadDB()
+	q := h.loadQueries()
+	database := h.loadDB()
 	if q == nil || database == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
 		return
 	}
 
@@ -350,58 +360,56 @@ func HandleLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user.HomeFacilityID == nil {
-		http.Error(w, "Home facility is required", http.StatusForbidden)
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
 	defer cancel()
 
-	facilityLoc := time.Local
-	facility, err := q.GetFacilityByID(ctx, *user.HomeFacilityID)
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, *user.HomeFacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load facility booking config")
-		http.Error(w, "Failed to validate booking rules", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate booking rules")
 		return
 	}
-	if facility.Timezone != "" {
-		loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-		if loadErr != nil {
-			logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone")
-		} else {
-			facilityLoc = loadedLoc
-		}
-	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
 
 	proID, err := apiutil.ParseRequiredInt64Field(r.FormValue("pro_id"), "pro_id")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
-	startTime, err := parseMemberBookingTime(r.FormValue("start_time"), "start_time", facilityLoc)
+	startTime, err := apiutil.ParseFlexibleTime(r.FormValue("start_time"), "start_time", facilityLoc)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
-	endTime, err := parseMemberBookingTime(r.FormValue("end_time"), "end_time", facilityLoc)
+	endTime, err := apiutil.ParseFlexibleTime(r.FormValue("end_time"), "end_time", facilityLoc)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 	if !endTime.After(startTime) {
-		http.Error(w, "end_time must be after start_time", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "end_time must be after start_time")
 		return
 	}
-	if endTime.Sub(startTime) < memberBookingMinDuration {
-		http.Error(w, "Lesson must be at least 1 hour", http.StatusBadRequest)
+	if endTime.Sub(startTime) < memberLessonDuration {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Lesson must be at least 1 hour")
 		return
 	}
 
-	maxMemberReservations := facility.MaxMemberReservations
+	tier, err := apiutil.LoadMembershipTier(ctx, q, facility.OrganizationID, user.MembershipLevel)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", facility.OrganizationID).Int64("membership_level", user.MembershipLevel).Msg("Failed to load membership tier")
+		writeFacilityConfigUnavailable(w, r)
+		return
+	}
+	maxMemberReservations := apiutil.MemberReservationLimit(tier, facility.MaxMemberReservations)
 
 	if startTime.Before(time.Now().In(facilityLoc)) {
-		http.Error(w, "start_time must be in the future", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "start_time must be in the future")
 		return
 	}
 
@@ -411,14 +419,14 @@ func HandleLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 	maxDate := today.AddDate(0, 0, int(maxAdvanceDays))
 	startDay := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, facilityLoc)
 	if startDay.After(maxDate) {
-		http.Error(w, fmt.Sprintf("start_time must be within %d days", maxAdvanceDays), http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("start_time must be within %d days", maxAdvanceDays))
 		return
 	}
 
 	if facility.LessonMinNoticeHours > 0 {
 		earliest := now.Add(time.Duration(facility.LessonMinNoticeHours) * time.Hour)
 		if startTime.Before(earliest) {
-			http.Error(w, fmt.Sprintf("Lessons must be booked at least %d hours in advance", facility.LessonMinNoticeHours), http.StatusBadRequest)
+			apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("Lessons must be booked at least %d hours in advance", facility.LessonMinNoticeHours))
 			return
 		}
 	}
@@ -426,26 +434,28 @@ func HandleLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 	staffRow, err := q.GetStaffByID(ctx, proID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Pro not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Pro not found")
 			return
 		}
 		logger.Error().Err(err).Int64("pro_id", proID).Msg("Failed to load pro")
-		http.Error(w, "Failed to load pro", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load pro")
 		return
 	}
 	if !strings.EqualFold(staffRow.Role, "pro") || !staffRow.HomeFacilityID.Valid || staffRow.HomeFacilityID.Int64 != *user.HomeFacilityID {
-		http.Error(w, "Pro not found", http.StatusNotFound)
+		apiutil.WriteError(w, r, http.StatusNotFound, "Pro not found")
 		return
 	}
 
-	slotMinutes := fmt.Sprintf("%d", int64(memberBookingMinDuration.Minutes()))
+	slotMinutes := fmt.Sprintf("%d", int64(memberLessonDuration.Minutes()))
 
-	reservationTypeID, err := lookupReservationTypeID(ctx, q, lessonReservationTypeName)
+	reservationType, err := lookupMemberReservationType(ctx, q, *user.HomeFacilityID, lessonReservationTypeName)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to resolve reservation type")
-		http.Error(w, "Reservation type not available", http.StatusInternalServerError)
+		writeMemberReservationTypeError(w, r, logger, lessonReservationTypeName, err)
 		return
 	}
+	if !reservationType.CountsTowardMemberLimit {
+		maxMemberReservations = 0
+	}
 
 	var created dbgen.Reservation
 	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
@@ -453,10 +463,7 @@ func HandleLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 		var eligiblePackageID int64
 
 		if maxMemberReservations > 0 {
-			activeCount, err := qtx.CountActiveMemberReservations(ctx, dbgen.CountActiveMemberReservationsParams{
-				FacilityID:    *user.HomeFacilityID,
-				PrimaryUserID: sql.NullInt64{Int64: user.ID, Valid: true},
-			})
+			activeCount, err := reservationsvc.CountActiveReservations(ctx, qtx, *user.HomeFacilityID, user.ID, facility.ReservationLimitScope)
 			if err != nil {
 				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check reservation limits", Err: err}
 			}
@@ -477,11 +484,11 @@ func HandleLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 
 		available := false
 		for _, slot := range slots {
-			slotStart, err := parseLessonSlotTime(slot.StartTime, time.Local)
+			slotStart, err := parseLessonSlotTime(slot.StartTime, facilityLoc)
 			if err != nil {
 				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check lesson availability", Err: err}
 			}
-			slotEnd, err := parseLessonSlotTime(slot.EndTime, time.Local)
+			slotEnd, err := parseLessonSlotTime(slot.EndTime, facilityLoc)
 			if err != nil {
 				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check lesson availability", Err: err}
 			}
@@ -496,7 +503,7 @@ func HandleLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 
 		created, err = qtx.CreateReservation(ctx, dbgen.CreateReservationParams{
 			FacilityID:        *user.HomeFacilityID,
-			ReservationTypeID: reservationTypeID,
+			ReservationTypeID: reservationType.ID,
 			RecurrenceRuleID:  sql.NullInt64{},
 			PrimaryUserID:     sql.NullInt64{Int64: user.ID, Valid: true},
 			CreatedByUserID:   user.ID,
@@ -553,31 +560,25 @@ func HandleLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		var limitErr reservationLimitError
 		if errors.As(err, &limitErr) {
-			message := fmt.Sprintf("You have reached the maximum of %d active reservations", limitErr.limit)
-			if err := apiutil.WriteJSON(w, http.StatusConflict, map[string]any{
-				"error":         message,
-				"current_count": limitErr.currentCount,
-				"limit":         limitErr.limit,
-			}); err != nil {
-				logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to write lesson reservation limit response")
-			}
+			writeReservationLimitError(w, r, limitErr.currentCount, limitErr.limit)
 			return
 		}
 		var herr apiutil.HandlerError
 		if errors.As(err, &herr) {
 			logger.Error().Err(herr.Err).Int64("facility_id", *user.HomeFacilityID).Msg(herr.Message)
-			http.Error(w, herr.Message, herr.Status)
+			apiutil.WriteHandlerError(w, r, herr)
 			return
 		}
 		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to create lesson reservation")
-		http.Error(w, "Failed to create reservation", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to create reservation")
 		return
 	}
+	metrics.ReservationCreated(created.FacilityID, reservationType.Name)
 
-	if emailClient != nil {
-		emailCtx, emailCancel := context.WithTimeout(context.Background(), portalQueryTimeout)
+	if h.emailClient != nil {
+		emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(r.Context()), portalQueryTimeout)
 		defer emailCancel()
-		cancellationPolicy, policyErr := cancellationPolicySummary(emailCtx, q, facility.ID, &reservationTypeID, startTime, now)
+		cancellationPolicy, policyErr := reservationsvc.CancellationPolicySummary(emailCtx, q, facility.ID, &reservationType.ID, startTime, now)
 		if policyErr != nil {
 			logger.Error().Err(policyErr).Int64("facility_id", facility.ID).Msg("Failed to load cancellation policy for confirmation email")
 			cancellationPolicy = "Contact the facility for cancellation policy details."
@@ -591,10 +592,12 @@ func HandleLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 			CancellationPolicy: cancellationPolicy,
 		})
 		// Use the bounded context for the initial user lookup; async send detaches inside SendConfirmationEmail.
-		email.SendConfirmationEmail(emailCtx, q, emailClient, user.ID, confirmation, logger)
+		if email.ShouldSend(emailCtx, q, user.ID, email.PreferenceConfirmations) {
+			email.SendConfirmationEmail(emailCtx, q, h.emailClient, user.ID, confirmation, logger)
+		}
 	}
 
-	w.Header().Set("HX-Trigger", "refreshMemberReservations")
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations")
 	if err := apiutil.WriteJSON(w, http.StatusCreated, created); err != nil {
 		logger.Error().Err(err).Int64("reservation_id", created.ID).Msg("Failed to write lesson reservation response")
 		return
@@ -707,7 +710,7 @@ func buildLessonSlotOptions(
 		return nil, nil
 	}
 
-	slotMinutes := fmt.Sprintf("%d", int64(memberBookingMinDuration.Minutes()))
+	slotMinutes := fmt.Sprintf("%d", int64(memberLessonDuration.Minutes()))
 	rows, err := q.GetProLessonSlots(ctx, dbgen.GetProLessonSlotsParams{
 		TargetDate:  bookingDate.Format("2006-01-02"),
 		FacilityID:  facilityID,
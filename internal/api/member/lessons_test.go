@@ -0,0 +1,126 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestMemberLessonBookAndCancel_NotifiesProAndRestoresPackage(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone, lesson_min_notice_hours) VALUES (?, 'Main', 'main', 'UTC', 24)", orgID)
+	now := time.Now().UTC()
+	lessonDay := time.Date(now.Year(), now.Month(), now.Day()+2, 0, 0, 0, 0, time.UTC)
+	exec("INSERT INTO operating_hours (facility_id, day_of_week, opens_at, closes_at) VALUES (?, ?, '08:00', '21:00')", facilityID, int(lessonDay.Weekday()))
+	proUserID := exec("INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES ('Pat', 'Pro', 'pat@test.com', 'active', 1)")
+	proID := exec("INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, 'Pat', 'Pro', ?, 'pro')", proUserID, facilityID)
+	memberID := exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES ('Lee', 'Learner', 'lee@test.com', 'active', 1, 1, 2, ?)`,
+		facilityID,
+	)
+	packTypeID := exec("INSERT INTO lesson_package_types (facility_id, name, price_cents, lesson_count, valid_days) VALUES (?, 'Five Pack', 25000, 5, 90)", facilityID)
+	packageID := exec(
+		"INSERT INTO lesson_packages (pack_type_id, user_id, purchase_date, expires_at, lessons_remaining) VALUES (?, ?, ?, ?, 5)",
+		packTypeID, memberID, now, now.AddDate(0, 0, 90),
+	)
+
+	h := NewHandlers(database, nil, Options{})
+
+	withMember := func(req *http.Request) *http.Request {
+		return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              memberID,
+			HomeFacilityID:  &facilityID,
+			MembershipLevel: 2,
+		}))
+	}
+	lessonsRemaining := func() int64 {
+		t.Helper()
+		var remaining int64
+		if err := database.QueryRowContext(ctx, "SELECT lessons_remaining FROM lesson_packages WHERE id = ?", packageID).Scan(&remaining); err != nil {
+			t.Fatalf("load lesson package: %v", err)
+		}
+		return remaining
+	}
+
+	start := lessonDay.Add(10 * time.Hour)
+	book := func(start time.Time) *httptest.ResponseRecorder {
+		form := url.Values{}
+		form.Set("pro_id", fmt.Sprintf("%d", proID))
+		form.Set("start_time", start.Format(memberBookingTimeLayout))
+		form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+		req := httptest.NewRequest(http.MethodPost, "/member/lessons", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		h.HandleLessonBookingCreate(recorder, withMember(req))
+		return recorder
+	}
+
+	if recorder := book(now.Add(2 * time.Hour).Truncate(time.Hour)); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected a lesson inside the notice window to be rejected, got %d", recorder.Code)
+	}
+
+	recorder := book(start)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("book lesson: %d %s", recorder.Code, recorder.Body.String())
+	}
+	var created dbgen.Reservation
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode reservation: %v", err)
+	}
+	if !created.ProID.Valid || created.ProID.Int64 != proID || lessonsRemaining() != 4 {
+		t.Fatalf("expected a lesson with the pro paid from the package, got %+v with %d left", created, lessonsRemaining())
+	}
+	if recorder := book(start); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected the taken slot to conflict, got %d", recorder.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/member/reservations/%d?confirm=true", created.ID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", created.ID))
+	cancelRecorder := httptest.NewRecorder()
+	h.HandleMemberReservationCancel(cancelRecorder, withMember(req))
+	if cancelRecorder.Code >= http.StatusBadRequest {
+		t.Fatalf("cancel status %d: %s", cancelRecorder.Code, cancelRecorder.Body.String())
+	}
+
+	var targetStaffID sql.NullInt64
+	if err := database.QueryRowContext(ctx,
+		"SELECT target_staff_id FROM staff_notifications WHERE notification_type = 'lesson_cancelled' AND related_reservation_id = ?", created.ID,
+	).Scan(&targetStaffID); err != nil {
+		t.Fatalf("expected a lesson cancellation notification for the pro: %v", err)
+	}
+	if targetStaffID.Int64 != proID {
+		t.Fatalf("expected the notification to target pro %d, got %v", proID, targetStaffID)
+	}
+	if lessonsRemaining() != 5 {
+		t.Fatalf("expected the package lesson restored, got %d left", lessonsRemaining())
+	}
+
+	if recorder := book(start); recorder.Code != http.StatusCreated {
+		t.Fatalf("expected the cancelled slot to be bookable again, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,65 @@
+package member
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+// loadMemberTier returns the membership tier of a member at membershipLevel
+// booking at facility. Without a facility, or when the tier cannot be
+// loaded, it falls back to the level's default tier; use it only where that
+// fallback is safe, such as rendering.
+func loadMemberTier(ctx context.Context, q *dbgen.Queries, facility *dbgen.Facility, membershipLevel int64, logger *zerolog.Logger) dbgen.MembershipTier {
+	if facility == nil {
+		return apiutil.DefaultMembershipTier(0, membershipLevel)
+	}
+	tier, err := apiutil.LoadMembershipTier(ctx, q, facility.OrganizationID, membershipLevel)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", facility.OrganizationID).Int64("membership_level", membershipLevel).Msg("Failed to load membership tier")
+	}
+	return tier
+}
+
+// applyMembershipTier fills in the portal profile's tier name and benefits
+// for the member's home facility.
+func (h *Handlers) applyMembershipTier(ctx context.Context, q *dbgen.Queries, profile *membertempl.PortalProfile, homeFacilityID *int64, logger *zerolog.Logger) {
+	if homeFacilityID == nil {
+		tier := apiutil.DefaultMembershipTier(0, profile.MembershipLevel)
+		profile.MembershipTierName = tier.Name
+		return
+	}
+
+	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, q, h.loadFacilities(), *homeFacilityID, profile.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", *homeFacilityID).Int64("membership_level", profile.MembershipLevel).Msg("Failed to load tier booking config")
+	}
+	tier := loadMemberTier(ctx, q, facility, profile.MembershipLevel, logger)
+	profile.MembershipTierName = tier.Name
+	if facility != nil {
+		profile.MembershipBenefits = membershipTierBenefits(tier, maxAdvanceDays, facility.MaxMemberReservations)
+	}
+}
+
+// membershipTierBenefits lists what tier gets at a facility with the given
+// booking window and reservation limit, for the member portal.
+func membershipTierBenefits(tier dbgen.MembershipTier, maxAdvanceDays, facilityLimit int64) []string {
+	benefits := []string{fmt.Sprintf("Book courts up to %d days ahead", maxAdvanceDays)}
+	if limit := apiutil.MemberReservationLimit(tier, facilityLimit); limit > 0 {
+		benefits = append(benefits, fmt.Sprintf("Up to %d active reservations", limit))
+	} else {
+		benefits = append(benefits, "Unlimited active reservations")
+	}
+	if tier.PrimeTimeAccess {
+		benefits = append(benefits, "Prime-time booking without waiting for slots to unlock")
+	}
+	if tier.VisitPacks {
+		benefits = append(benefits, "Visit packs for drop-in play")
+	}
+	return benefits
+}
@@ -0,0 +1,156 @@
+package member
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+type notificationPreferencesRequest struct {
+	Confirmations     *bool `json:"confirmations"`
+	Cancellations     *bool `json:"cancellations"`
+	WaitlistOffers    *bool `json:"waitlist_offers"`
+	OpenPlayReminders *bool `json:"open_play_reminders"`
+	SMS               *bool `json:"sms"`
+}
+
+// HandleMemberNotificationPreferences handles GET /member/notification-preferences.
+func (h *Handlers) HandleMemberNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	prefs, err := q.GetUserNotificationPreferences(ctx, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load notification preferences")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load notification preferences")
+		return
+	}
+
+	writeNotificationPreferences(w, r, notificationPreferencesData(prefs))
+}
+
+// HandleMemberNotificationPreferencesUpdate handles POST /member/notification-preferences.
+// JSON bodies update only the fields they include; form posts come from the
+// settings checkboxes, where an unchecked box is omitted and means off.
+func (h *Handlers) HandleMemberNotificationPreferencesUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	prefs, err := q.GetUserNotificationPreferences(ctx, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load notification preferences")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load notification preferences")
+		return
+	}
+
+	if apiutil.IsJSONRequest(r) {
+		var req notificationPreferencesRequest
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.Confirmations != nil {
+			prefs.NotifyConfirmations = *req.Confirmations
+		}
+		if req.Cancellations != nil {
+			prefs.NotifyCancellations = *req.Cancellations
+		}
+		if req.WaitlistOffers != nil {
+			prefs.NotifyWaitlistOffers = *req.WaitlistOffers
+		}
+		if req.OpenPlayReminders != nil {
+			prefs.NotifyOpenPlayReminders = *req.OpenPlayReminders
+		}
+		if req.SMS != nil {
+			prefs.SmsOptIn = *req.SMS
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+		prefs.NotifyConfirmations = apiutil.ParseBool(r.FormValue("confirmations"))
+		prefs.NotifyCancellations = apiutil.ParseBool(r.FormValue("cancellations"))
+		prefs.NotifyWaitlistOffers = apiutil.ParseBool(r.FormValue("waitlist_offers"))
+		prefs.NotifyOpenPlayReminders = apiutil.ParseBool(r.FormValue("open_play_reminders"))
+		prefs.SmsOptIn = apiutil.ParseBool(r.FormValue("sms"))
+	}
+
+	if err := q.UpdateUserNotificationPreferences(ctx, dbgen.UpdateUserNotificationPreferencesParams{
+		ID:                      user.ID,
+		NotifyConfirmations:     prefs.NotifyConfirmations,
+		NotifyCancellations:     prefs.NotifyCancellations,
+		NotifyWaitlistOffers:    prefs.NotifyWaitlistOffers,
+		NotifyOpenPlayReminders: prefs.NotifyOpenPlayReminders,
+		SmsOptIn:                prefs.SmsOptIn,
+	}); err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to update notification preferences")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update notification preferences")
+		return
+	}
+
+	writeNotificationPreferences(w, r, notificationPreferencesData(prefs))
+}
+
+func notificationPreferencesData(prefs dbgen.GetUserNotificationPreferencesRow) membertempl.NotificationPreferencesData {
+	return membertempl.NotificationPreferencesData{
+		Confirmations:     prefs.NotifyConfirmations,
+		Cancellations:     prefs.NotifyCancellations,
+		WaitlistOffers:    prefs.NotifyWaitlistOffers,
+		OpenPlayReminders: prefs.NotifyOpenPlayReminders,
+		SMS:               prefs.SmsOptIn,
+	}
+}
+
+func writeNotificationPreferences(w http.ResponseWriter, r *http.Request, data membertempl.NotificationPreferencesData) {
+	acceptHeader := strings.ToLower(r.Header.Get("Accept"))
+	wantsHTML := apiutil.IsHTMXRequest(r) || strings.Contains(acceptHeader, "text/html")
+	wantsJSON := strings.Contains(acceptHeader, "application/json")
+	if wantsHTML && (!wantsJSON || apiutil.IsHTMXRequest(r)) {
+		component := membertempl.MemberNotificationPreferences(data)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render notification preferences", "Failed to render notification preferences")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, data); err != nil {
+		log.Ctx(r.Context()).Error().Err(err).Msg("Failed to write notification preferences response")
+		return
+	}
+}
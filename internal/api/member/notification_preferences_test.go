@@ -0,0 +1,87 @@
+package member
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+func TestHandleMemberNotificationPreferences_DefaultsAndUpdates(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	decode := func(recorder *httptest.ResponseRecorder) membertempl.NotificationPreferencesData {
+		t.Helper()
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var data membertempl.NotificationPreferencesData
+		if err := json.Unmarshal(recorder.Body.Bytes(), &data); err != nil {
+			t.Fatalf("decode preferences: %v", err)
+		}
+		return data
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/member/notification-preferences", nil)
+	req.Header.Set("Accept", "application/json")
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleMemberNotificationPreferences(recorder, fixture.withMember(req))
+	data := decode(recorder)
+	if !data.Confirmations || !data.Cancellations || !data.WaitlistOffers || !data.OpenPlayReminders {
+		t.Fatalf("expected every preference to default on, got %+v", data)
+	}
+
+	// JSON updates leave omitted fields alone.
+	req = httptest.NewRequest(http.MethodPost, "/member/notification-preferences", strings.NewReader(`{"cancellations":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	recorder = httptest.NewRecorder()
+	fixture.h.HandleMemberNotificationPreferencesUpdate(recorder, fixture.withMember(req))
+	data = decode(recorder)
+	if data.Cancellations || !data.Confirmations || !data.WaitlistOffers || !data.OpenPlayReminders {
+		t.Fatalf("unexpected preferences after JSON update: %+v", data)
+	}
+
+	// A malformed JSON body is answered in the error envelope.
+	req = httptest.NewRequest(http.MethodPost, "/member/notification-preferences", strings.NewReader(`{"cancellations":`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	recorder = httptest.NewRecorder()
+	fixture.h.HandleMemberNotificationPreferencesUpdate(recorder, fixture.withMember(req))
+	var envelope struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &envelope); recorder.Code != http.StatusBadRequest || err != nil ||
+		envelope.Error.Message != "Invalid request body" {
+		t.Fatalf("expected an error envelope, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	// Form posts treat unchecked boxes as off.
+	form := url.Values{"cancellations": {"true"}}
+	req = httptest.NewRequest(http.MethodPost, "/member/notification-preferences", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+	recorder = httptest.NewRecorder()
+	fixture.h.HandleMemberNotificationPreferencesUpdate(recorder, fixture.withMember(req))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "member-notification-preferences") {
+		t.Fatalf("expected the settings partial, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var confirmations, cancellations, waitlistOffers, openPlayReminders bool
+	if err := fixture.database.QueryRow(
+		"SELECT notify_confirmations, notify_cancellations, notify_waitlist_offers, notify_open_play_reminders FROM users WHERE id = ?",
+		fixture.memberID,
+	).Scan(&confirmations, &cancellations, &waitlistOffers, &openPlayReminders); err != nil {
+		t.Fatalf("load preferences: %v", err)
+	}
+	if confirmations || !cancellations || waitlistOffers || openPlayReminders {
+		t.Fatalf("unexpected stored preferences: %v %v %v %v", confirmations, cancellations, waitlistOffers, openPlayReminders)
+	}
+}
@@ -0,0 +1,152 @@
+package member
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestHandleMemberOpenPlayDetail_RosterRespectsPrivacy(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	courtResult, err := database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+		facilityID, "Court 3", 3, "active",
+	)
+	if err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+	courtID, _ := courtResult.LastInsertId()
+
+	ruleResult, err := database.Exec(
+		`INSERT INTO open_play_rules (facility_id, name, min_participants, max_participants_per_court, cancellation_cutoff_minutes, min_courts, max_courts)
+		 VALUES (?, ?, 4, 8, 90, 1, 2)`,
+		facilityID, "Intermediate Drop-In",
+	)
+	if err != nil {
+		t.Fatalf("insert rule: %v", err)
+	}
+	ruleID, _ := ruleResult.LastInsertId()
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 18, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	sessionResult, err := database.Exec(
+		`INSERT INTO open_play_sessions (facility_id, open_play_rule_id, start_time, end_time, status, current_court_count)
+		 VALUES (?, ?, ?, ?, 'scheduled', 1)`,
+		facilityID, ruleID, start, end,
+	)
+	if err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	sessionID, _ := sessionResult.LastInsertId()
+
+	insertMember := func(firstName string, hidden bool) int64 {
+		t.Helper()
+		result, err := database.Exec(
+			`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id, hide_from_rosters)
+			 VALUES (?, ?, ?, ?, 1, 1, 2, ?, ?)`,
+			firstName, "Player", strings.ToLower(firstName)+"@test.com", "active", facilityID, hidden,
+		)
+		if err != nil {
+			t.Fatalf("insert member %s: %v", firstName, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	viewerID := insertMember("Viewer", false)
+	visibleID := insertMember("Alice", false)
+	hiddenID := insertMember("Bartholomew", true)
+
+	reservationResult, err := database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, open_play_rule_id, created_by_user_id, start_time, end_time, is_open_event)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'OPEN_PLAY'), ?, ?, ?, ?, 1)`,
+		facilityID, ruleID, viewerID, start, end,
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, _ := reservationResult.LastInsertId()
+	if _, err := database.Exec(
+		"INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)",
+		reservationID, courtID,
+	); err != nil {
+		t.Fatalf("assign court: %v", err)
+	}
+	for _, userID := range []int64{viewerID, visibleID, hiddenID} {
+		if _, err := database.Exec(
+			"INSERT INTO reservation_participants (reservation_id, user_id) VALUES (?, ?)",
+			reservationID, userID,
+		); err != nil {
+			t.Fatalf("add participant: %v", err)
+		}
+	}
+	for position, userID := range []int64{visibleID, viewerID} {
+		if _, err := database.Exec(
+			"INSERT INTO open_play_queue_entries (session_id, user_id, queue_position) VALUES (?, ?, ?)",
+			sessionID, userID, position+1,
+		); err != nil {
+			t.Fatalf("queue player: %v", err)
+		}
+	}
+
+	h := NewHandlers(database, nil, Options{})
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/member/openplay/%d", sessionID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", sessionID))
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:              viewerID,
+		HomeFacilityID:  &facilityID,
+		MembershipLevel: 2,
+	}))
+	recorder := httptest.NewRecorder()
+	h.HandleMemberOpenPlayDetail(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	body := recorder.Body.String()
+	for _, want := range []string{
+		"Intermediate Drop-In",
+		"Court 3",
+		"3 of 8 spots filled",
+		start.Add(-90 * time.Minute).Format("Jan 2, 2006 3:04 PM"),
+		"Alice",
+		"You",
+		"1 player prefers not to be listed",
+		"#2 in line",
+		"every 30s",
+		fmt.Sprintf(`hx-delete="/member/openplay/%d"`, sessionID),
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q in detail:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "Bartholomew") {
+		t.Fatalf("hidden participant name leaked into roster:\n%s", body)
+	}
+}
@@ -0,0 +1,117 @@
+package member
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestHandleMemberOpenPlay_MembershipEligibility(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	if _, err := database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+		facilityID, "Court 1", 1, "active",
+	); err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+
+	ruleResult, err := database.Exec(
+		`INSERT INTO open_play_rules (facility_id, name, min_participants, max_participants_per_court, cancellation_cutoff_minutes, min_courts, max_courts, min_membership_level)
+		 VALUES (?, ?, 4, 8, 60, 1, 2, 3)`,
+		facilityID, "Member+ Ladder",
+	)
+	if err != nil {
+		t.Fatalf("insert rule: %v", err)
+	}
+	ruleID, _ := ruleResult.LastInsertId()
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 18, 0, 0, 0, time.UTC)
+	sessionResult, err := database.Exec(
+		`INSERT INTO open_play_sessions (facility_id, open_play_rule_id, start_time, end_time, status, current_court_count)
+		 VALUES (?, ?, ?, ?, 'scheduled', 1)`,
+		facilityID, ruleID, start, start.Add(2*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	sessionID, _ := sessionResult.LastInsertId()
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+		"Regular", "Member", "regular@test.com", "active", facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	h := NewHandlers(database, nil, Options{})
+
+	withMember := func(req *http.Request) *http.Request {
+		homeFacilityID := facilityID
+		return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              memberID,
+			HomeFacilityID:  &homeFacilityID,
+			MembershipLevel: 2,
+		}))
+	}
+
+	listRecorder := httptest.NewRecorder()
+	h.HandleMemberOpenPlayList(listRecorder, withMember(httptest.NewRequest(http.MethodGet, "/member/openplay", nil)))
+	if listRecorder.Code != http.StatusOK {
+		t.Fatalf("list status %d: %s", listRecorder.Code, listRecorder.Body.String())
+	}
+	body := listRecorder.Body.String()
+	if !strings.Contains(body, "Member+ and above only") {
+		t.Fatalf("expected eligibility label in list:\n%s", body)
+	}
+	if strings.Contains(body, fmt.Sprintf(`hx-post="/member/openplay/%d"`, sessionID)) {
+		t.Fatalf("ineligible member was offered a sign up button:\n%s", body)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/member/openplay/%d", sessionID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", sessionID))
+	signupRecorder := httptest.NewRecorder()
+	h.HandleMemberOpenPlaySignup(signupRecorder, withMember(req))
+	if signupRecorder.Code != http.StatusForbidden {
+		t.Fatalf("expected ineligible signup to be forbidden, got %d: %s", signupRecorder.Code, signupRecorder.Body.String())
+	}
+	if !strings.Contains(signupRecorder.Body.String(), "Member+ and above only") {
+		t.Fatalf("unexpected error message: %s", signupRecorder.Body.String())
+	}
+
+	var participants int
+	if err := database.QueryRow("SELECT COUNT(*) FROM reservation_participants WHERE user_id = ?", memberID).Scan(&participants); err != nil {
+		t.Fatalf("count participants: %v", err)
+	}
+	if participants != 0 {
+		t.Fatalf("expected no participant rows, got %d", participants)
+	}
+}
@@ -0,0 +1,156 @@
+package member
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestHandleMemberOpenPlay_SkillBands(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	for number := 1; number <= 2; number++ {
+		if _, err := database.Exec(
+			"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+			facilityID, fmt.Sprintf("Court %d", number), number, "active",
+		); err != nil {
+			t.Fatalf("insert court: %v", err)
+		}
+	}
+
+	// One player per court keeps each band to a single spot.
+	ruleResult, err := database.Exec(
+		`INSERT INTO open_play_rules (facility_id, name, min_participants, max_participants_per_court, cancellation_cutoff_minutes, min_courts, max_courts, skill_bands)
+		 VALUES (?, ?, 1, 1, 60, 2, 2, ?)`,
+		facilityID, "Banded Drop-In",
+		`[{"minRating":3,"maxRating":3.5,"courtNumbers":[1]},{"minRating":4,"courtNumbers":[2]}]`,
+	)
+	if err != nil {
+		t.Fatalf("insert rule: %v", err)
+	}
+	ruleID, _ := ruleResult.LastInsertId()
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 18, 0, 0, 0, time.UTC)
+	sessionResult, err := database.Exec(
+		`INSERT INTO open_play_sessions (facility_id, open_play_rule_id, start_time, end_time, status, current_court_count)
+		 VALUES (?, ?, ?, ?, 'scheduled', 2)`,
+		facilityID, ruleID, start, start.Add(2*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	sessionID, _ := sessionResult.LastInsertId()
+
+	staffResult, err := database.Exec(
+		"INSERT INTO users (first_name, last_name, email, status, is_staff, staff_role, home_facility_id) VALUES ('Desk', 'Staff', 'desk@test.com', 'active', 1, 'desk', ?)",
+		facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert staff: %v", err)
+	}
+	staffID, _ := staffResult.LastInsertId()
+	// Sessions are backed by an OPEN_PLAY reservation that signups join.
+	if _, err := database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, open_play_rule_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'OPEN_PLAY'), ?, ?, ?, ?)`,
+		facilityID, staffID, ruleID, start, start.Add(2*time.Hour),
+	); err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+
+	insertMember := func(name string, rating float64) int64 {
+		t.Helper()
+		result, err := database.Exec(
+			`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+			 VALUES (?, 'Player', ?, 'active', 1, 1, 2, ?)`,
+			name, strings.ToLower(name)+"@test.com", facilityID,
+		)
+		if err != nil {
+			t.Fatalf("insert member: %v", err)
+		}
+		id, _ := result.LastInsertId()
+		if rating > 0 {
+			if _, err := database.Exec("INSERT INTO member_skill_ratings (user_id, rating) VALUES (?, ?)", id, rating); err != nil {
+				t.Fatalf("insert rating: %v", err)
+			}
+		}
+		return id
+	}
+	first := insertMember("First", 3.2)
+	second := insertMember("Second", 3.4)
+	advanced := insertMember("Advanced", 4.5)
+	unrated := insertMember("Unrated", 0)
+
+	h := NewHandlers(database, nil, Options{})
+
+	withMember := func(req *http.Request, memberID int64) *http.Request {
+		homeFacilityID := facilityID
+		return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              memberID,
+			HomeFacilityID:  &homeFacilityID,
+			MembershipLevel: 2,
+		}))
+	}
+	signup := func(memberID int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/member/openplay/%d", sessionID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", sessionID))
+		recorder := httptest.NewRecorder()
+		h.HandleMemberOpenPlaySignup(recorder, withMember(req, memberID))
+		return recorder
+	}
+
+	listRecorder := httptest.NewRecorder()
+	h.HandleMemberOpenPlayList(listRecorder, withMember(httptest.NewRequest(http.MethodGet, "/member/openplay", nil), second))
+	if listRecorder.Code != http.StatusOK {
+		t.Fatalf("list status %d: %s", listRecorder.Code, listRecorder.Body.String())
+	}
+	if body := listRecorder.Body.String(); !strings.Contains(body, "3.0–3.5 · Court 1") {
+		t.Fatalf("expected the member's skill band in list:\n%s", body)
+	}
+
+	if recorder := signup(first); recorder.Code != http.StatusCreated {
+		t.Fatalf("expected first signup to succeed, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	recorder := signup(second)
+	if recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), "skill band is full") {
+		t.Fatalf("expected full band conflict, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := signup(advanced); recorder.Code != http.StatusCreated {
+		t.Fatalf("expected other band to have room, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	recorder = signup(unrated)
+	if recorder.Code != http.StatusForbidden || !strings.Contains(recorder.Body.String(), "skill rating is required") {
+		t.Fatalf("expected unrated member to be refused, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	listRecorder = httptest.NewRecorder()
+	h.HandleMemberOpenPlayList(listRecorder, withMember(httptest.NewRequest(http.MethodGet, "/member/openplay", nil), unrated))
+	if body := listRecorder.Body.String(); !strings.Contains(body, "Requires a skill rating") {
+		t.Fatalf("expected unrated member to see why they cannot join:\n%s", body)
+	}
+}
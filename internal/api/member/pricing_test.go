@@ -0,0 +1,164 @@
+package member
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+func TestMemberBookingPricedAndRefundedOnCancel(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	// The booking runs 10:00-11:00 tomorrow. The peak rule's higher rate wins
+	// where the two overlap.
+	tomorrow := int64(time.Now().UTC().AddDate(0, 0, 1).Weekday())
+	if _, err := fixture.database.Exec(
+		`INSERT INTO court_pricing_rules (facility_id, day_of_week, start_time, end_time, member_price_per_hour_cents, non_member_price_per_hour_cents)
+		 VALUES (?, ?, '09:00', '11:00', 3000, 5000), (?, ?, '10:30', '12:00', 4000, 6000)`,
+		fixture.facilityID, tomorrow, fixture.facilityID, tomorrow,
+	); err != nil {
+		t.Fatalf("insert pricing rules: %v", err)
+	}
+	if _, err := fixture.database.Exec("DELETE FROM cancellation_policy_tiers WHERE facility_id = ?", fixture.facilityID); err != nil {
+		t.Fatalf("delete tiers: %v", err)
+	}
+	if _, err := fixture.database.Exec(
+		"INSERT INTO cancellation_policy_tiers (facility_id, min_hours_before, refund_percentage) VALUES (?, 48, 100), (?, 0, 50)",
+		fixture.facilityID, fixture.facilityID,
+	); err != nil {
+		t.Fatalf("insert tiers: %v", err)
+	}
+
+	// The member pays for the booking from credit, so the cancellation has
+	// something to refund.
+	if _, err := reservationsvc.IssueCredit(context.Background(), fixture.database.Queries, fixture.memberID, fixture.facilityID, 3500, nil, "Prepaid", fixture.memberID, nil); err != nil {
+		t.Fatalf("issue prepaid credit: %v", err)
+	}
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.UTC)
+	bookWithCredits := func() *httptest.ResponseRecorder {
+		t.Helper()
+		form := url.Values{}
+		form.Set("start_time", start.Format(memberBookingTimeLayout))
+		form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+		form.Set("court_ids", fmt.Sprintf("%d", fixture.courtIDs[0]))
+		form.Set("apply_credits", "true")
+		req := httptest.NewRequest(http.MethodPost, "/member/reservations", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberBookingCreate(recorder, fixture.withMember(req))
+		return recorder
+	}
+
+	recorder := bookWithCredits()
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("book status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created struct {
+		ID         int64  `json:"id"`
+		PriceCents *int64 `json:"priceCents"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode reservation: %v", err)
+	}
+	if created.PriceCents == nil || *created.PriceCents != 3500 {
+		t.Fatalf("expected a $35.00 member price, got %v", created.PriceCents)
+	}
+
+	cancel := func(penalty *membertempl.CancellationPenaltyData) *httptest.ResponseRecorder {
+		t.Helper()
+		target := fmt.Sprintf("/member/reservations/%d", created.ID)
+		if penalty != nil {
+			query := url.Values{}
+			query.Set("confirm", "true")
+			query.Set("hours_before_start", fmt.Sprintf("%d", penalty.HoursBeforeStart))
+			query.Set("penalty_calculated_at", penalty.CalculatedAt.Format(time.RFC3339Nano))
+			query.Set("refund_percentage", fmt.Sprintf("%d", penalty.RefundPercentage))
+			target += "?" + query.Encode()
+		}
+		req := httptest.NewRequest(http.MethodDelete, target, nil)
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", fmt.Sprintf("%d", created.ID))
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberReservationCancel(recorder, fixture.withMember(req))
+		return recorder
+	}
+
+	recorder = cancel(nil)
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("expected the penalty to need confirming, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var penalty membertempl.CancellationPenaltyData
+	if err := json.Unmarshal(recorder.Body.Bytes(), &penalty); err != nil {
+		t.Fatalf("decode penalty: %v", err)
+	}
+	if penalty.RefundAmountCents == nil || *penalty.RefundAmountCents != 1750 {
+		t.Fatalf("expected a $17.50 refund to be shown, got %v", penalty.RefundAmountCents)
+	}
+
+	recorder = cancel(&penalty)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("cancel status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var result struct {
+		RefundAmountCents *int64 `json:"refund_amount_cents"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decode cancel response: %v", err)
+	}
+	if result.RefundAmountCents == nil || *result.RefundAmountCents != 1750 {
+		t.Fatalf("expected a $17.50 refund, got %v", result.RefundAmountCents)
+	}
+	var stored int64
+	if err := fixture.database.QueryRow(
+		"SELECT refund_amount_cents FROM reservation_cancellations WHERE reservation_id = ?",
+		created.ID,
+	).Scan(&stored); err != nil {
+		t.Fatalf("load cancellation: %v", err)
+	}
+	if stored != 1750 {
+		t.Fatalf("expected the refund amount logged, got %d", stored)
+	}
+
+	// The refund lands as account credit, which the next booking can spend.
+	req := httptest.NewRequest(http.MethodGet, "/member/credits", nil)
+	recorder = httptest.NewRecorder()
+	fixture.h.HandleMemberCredits(recorder, fixture.withMember(req))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("credits status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var account reservationsvc.CreditAccount
+	if err := json.Unmarshal(recorder.Body.Bytes(), &account); err != nil {
+		t.Fatalf("decode credits: %v", err)
+	}
+	if account.BalanceCents != 1750 || len(account.Credits) != 2 {
+		t.Fatalf("expected a $17.50 balance from the cancellation, got %+v", account)
+	}
+	for _, credit := range account.Credits {
+		if credit.Reason == reservationsvc.CancellationCreditReason && (credit.AmountCents != 1750 || credit.SourceReservationID.Int64 != created.ID) {
+			t.Fatalf("expected a $17.50 credit from the cancellation, got %+v", credit)
+		}
+	}
+
+	recorder = bookWithCredits()
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("rebook status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var rebooked reservationsvc.PricedReservation
+	if err := json.Unmarshal(recorder.Body.Bytes(), &rebooked); err != nil {
+		t.Fatalf("decode rebooking: %v", err)
+	}
+	if rebooked.CreditAppliedCents == nil || *rebooked.CreditAppliedCents != 1750 ||
+		rebooked.AmountDueCents == nil || *rebooked.AmountDueCents != 1750 {
+		t.Fatalf("expected $17.50 of credit applied and $17.50 due, got %+v", rebooked)
+	}
+}
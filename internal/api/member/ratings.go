@@ -0,0 +1,135 @@
+package member
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/ratings"
+)
+
+type memberRatingRequest struct {
+	Rating *float64 `json:"rating"`
+	Source string   `json:"source"`
+	Note   string   `json:"note"`
+}
+
+// HandleMemberRating handles GET /member/rating with the member's effective
+// rating and the history behind it.
+func (h *Handlers) HandleMemberRating(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	summary, err := ratings.Load(ctx, q, user.ID)
+	if err != nil {
+		writeMemberRatingError(w, r, user.ID, err)
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, summary); err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to write rating response")
+		return
+	}
+}
+
+// HandleMemberRatingUpdate handles PUT /member/rating. Self-reported ratings
+// stay unverified until staff confirm them, and only become the effective
+// rating while the member has no verified one.
+func (h *Handlers) HandleMemberRatingUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	database := h.loadDB()
+	if database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	var req memberRatingRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Rating == nil {
+		apiutil.WriteValidationError(w, r, apiutil.FieldError{Field: "rating", Reason: "is required"})
+		return
+	}
+	source := strings.ToLower(strings.TrimSpace(req.Source))
+	if source == "" {
+		source = ratings.SourceSelf
+	}
+	if !slices.Contains(ratings.SelfReportSources, source) {
+		apiutil.WriteValidationError(w, r, apiutil.FieldError{Field: "source", Reason: "must be one of self, dupr or utr"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	var summary ratings.Summary
+	err := database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		if _, err := ratings.Record(ctx, txdb.Queries, ratings.RecordInput{
+			UserID:           user.ID,
+			Rating:           *req.Rating,
+			Source:           source,
+			RecordedByUserID: user.ID,
+			Note:             strings.TrimSpace(req.Note),
+		}); err != nil {
+			return err
+		}
+		var err error
+		summary, err = ratings.Load(ctx, txdb.Queries, user.ID)
+		return err
+	})
+	if err != nil {
+		writeMemberRatingError(w, r, user.ID, err)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, summary); err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to write rating response")
+		return
+	}
+}
+
+func writeMemberRatingError(w http.ResponseWriter, r *http.Request, userID int64, err error) {
+	logger := log.Ctx(r.Context())
+
+	var herr apiutil.HandlerError
+	if errors.As(err, &herr) {
+		if herr.Status == http.StatusInternalServerError {
+			logger.Error().Err(herr.Err).Int64("user_id", userID).Msg(herr.Message)
+		}
+		apiutil.WriteHandlerError(w, r, herr)
+		return
+	}
+	logger.Error().Err(err).Int64("user_id", userID).Msg("Rating update failed")
+	apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update rating")
+}
@@ -0,0 +1,71 @@
+package member
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/ratings"
+)
+
+func TestHandleMemberRatingUpdate_SelfReportDefersToVerifiedRating(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+
+	report := func(body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPut, "/member/rating", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberRatingUpdate(recorder, fixture.withMember(req))
+		return recorder
+	}
+	decode := func(recorder *httptest.ResponseRecorder) ratings.Summary {
+		t.Helper()
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var summary ratings.Summary
+		if err := json.Unmarshal(recorder.Body.Bytes(), &summary); err != nil {
+			t.Fatalf("decode rating: %v", err)
+		}
+		return summary
+	}
+
+	if recorder := report(`{"rating": 3.5, "source": "staff"}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected members to be unable to claim a staff rating, got %d", recorder.Code)
+	}
+	if recorder := report(`{"rating": 9}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected an out-of-range rating to be rejected, got %d", recorder.Code)
+	}
+
+	summary := decode(report(`{"rating": 3.5, "source": "dupr", "note": "DUPR doubles"}`))
+	if summary.Rating == nil || *summary.Rating != 3.5 || summary.Current == nil || summary.Current.Verified || summary.Current.Source != ratings.SourceDUPR {
+		t.Fatalf("expected the unverified DUPR rating to take effect, got %+v", summary)
+	}
+
+	if _, err := ratings.Record(t.Context(), fixture.database.Queries, ratings.RecordInput{
+		UserID:   fixture.memberID,
+		Rating:   3.0,
+		Source:   ratings.SourceStaff,
+		Verified: true,
+	}); err != nil {
+		t.Fatalf("record staff rating: %v", err)
+	}
+
+	summary = decode(report(`{"rating": 4.5}`))
+	if summary.Rating == nil || *summary.Rating != 3.0 || summary.Current == nil || !summary.Current.Verified {
+		t.Fatalf("expected the verified rating to stay effective, got %+v", summary)
+	}
+	if len(summary.History) != 3 || summary.History[0].Rating != 4.5 || summary.History[0].Source != ratings.SourceSelf {
+		t.Fatalf("expected the self-report first in a three-entry history, got %+v", summary.History)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/member/rating", nil)
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleMemberRating(recorder, fixture.withMember(req))
+	if got := decode(recorder); got.Rating == nil || *got.Rating != 3.0 {
+		t.Fatalf("expected GET to report the effective rating, got %+v", got)
+	}
+}
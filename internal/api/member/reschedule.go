@@ -0,0 +1,216 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+// HandleMemberReservationReschedule handles POST
+// /member/reservations/{id}/reschedule. The member who booked moves the
+// reservation to a new start_time and end_time, and optionally new
+// court_ids, with the same checks as a new booking. While cancelling would
+// still refund in full the move is free; after that it answers with the
+// cancellation penalty, as cancelling does.
+func (h *Handlers) HandleMemberReservationReschedule(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	service := h.loadService()
+	if q == nil || service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	reservationID, err := apiutil.ReservationIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	reservation, err := q.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Reservation not found")
+			return
+		}
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to load reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation")
+		return
+	}
+	if !reservation.PrimaryUserID.Valid || reservation.PrimaryUserID.Int64 != user.ID {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+		return
+	}
+	facilityID := reservation.FacilityID
+
+	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, q, h.loadFacilities(), facilityID, user.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Int64("membership_level", user.MembershipLevel).Msg("Failed to load facility booking config")
+		writeFacilityConfigUnavailable(w, r)
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(*facility, logger)
+
+	startTime, err := apiutil.ParseFlexibleTime(r.FormValue("start_time"), "start_time", facilityLoc)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	now := time.Now().In(facilityLoc)
+	if startTime.Before(now) {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "start_time must be in the future")
+		return
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, facilityLoc)
+	startDay := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, facilityLoc)
+	if startDay.After(today.AddDate(0, 0, int(maxAdvanceDays))) {
+		apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("start_time must be within %d days for your membership level. Upgrade to book further in advance.", maxAdvanceDays))
+		return
+	}
+
+	endTime, err := apiutil.ParseFlexibleTime(r.FormValue("end_time"), "end_time", facilityLoc)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if !endTime.After(startTime) {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "end_time must be after start_time")
+		return
+	}
+	reservationType, err := q.GetReservationType(ctx, reservation.ReservationTypeID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_type_id", reservation.ReservationTypeID).Msg("Failed to load reservation type")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation type")
+		return
+	}
+	if violation := apiutil.ReservationTypeDuration(facility, &reservationType).Violation(endTime.Sub(startTime)); violation != "" {
+		apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("Reservation must be %s", violation))
+		return
+	}
+
+	dayOpen, dayClose, open, err := memberBookingDayHours(ctx, q, facilityID, startDay, logger)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility hours override")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate facility hours")
+		return
+	}
+	if !open {
+		apiutil.WriteError(w, r, http.StatusConflict, fmt.Sprintf("The facility is closed on %s", startDay.Format("Mon, Jan 2, 2006")))
+		return
+	}
+	if startTime.Before(dayOpen) || endTime.After(dayClose) {
+		apiutil.WriteError(w, r, http.StatusConflict, fmt.Sprintf("The facility is only open %s-%s on %s", dayOpen.Format("15:04"), dayClose.Format("15:04"), startDay.Format("Mon, Jan 2, 2006")))
+		return
+	}
+	tier, err := apiutil.LoadMembershipTier(ctx, q, facility.OrganizationID, user.MembershipLevel)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", facility.OrganizationID).Int64("membership_level", user.MembershipLevel).Msg("Failed to load membership tier")
+		writeFacilityConfigUnavailable(w, r)
+		return
+	}
+	if err := apiutil.EnsurePrimeTimeUnlocked(ctx, q, facilityID, apiutil.PrimeTimeLevel(tier, user.MembershipLevel), startTime, endTime, now); err != nil {
+		var primeTimeErr apiutil.PrimeTimeLockedError
+		if errors.As(err, &primeTimeErr) {
+			apiutil.WriteError(w, r, http.StatusForbidden, err.Error())
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load prime time rules")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate prime time rules")
+		return
+	}
+
+	// Without court_ids the reservation keeps its courts.
+	var courtIDs []int64
+	if len(r.Form["court_ids"]) > 0 || len(r.Form["court_ids[]"]) > 0 {
+		courtIDs, err = parseMemberCourtIDs(r)
+		if err != nil {
+			apiutil.WriteValidationError(w, r, err)
+			return
+		}
+		maxCourts := int64(1)
+		if facility.MaxCourtsPerMemberBooking > 0 {
+			maxCourts = facility.MaxCourtsPerMemberBooking
+		}
+		if int64(len(courtIDs)) > maxCourts {
+			apiutil.WriteError(w, r, http.StatusBadRequest, fmt.Sprintf("You can book up to %d courts per reservation", maxCourts))
+			return
+		}
+		for _, courtID := range courtIDs {
+			court, err := q.GetCourt(ctx, courtID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					apiutil.WriteError(w, r, http.StatusNotFound, "Court not found")
+					return
+				}
+				logger.Error().Err(err).Int64("court_id", courtID).Msg("Failed to load court")
+				apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate court")
+				return
+			}
+			if court.FacilityID != facilityID {
+				apiutil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+				return
+			}
+		}
+	}
+
+	updated, err := service.RescheduleReservation(ctx, reservationsvc.RescheduleInput{
+		ReservationID: reservationID,
+		OwnerID:       user.ID,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		CourtIDs:      courtIDs,
+	})
+	if err != nil {
+		var penaltyErr reservationsvc.CancellationPenaltyError
+		if errors.As(err, &penaltyErr) {
+			h.writeCancellationPenalty(ctx, w, r, q, penaltyErr.Penalty)
+			return
+		}
+		var overlapErr reservationsvc.MemberOverlapError
+		if errors.As(err, &overlapErr) {
+			writeMemberOverlapError(w, r, overlapErr)
+			return
+		}
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("reservation_id", reservationID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to reschedule reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to reschedule reservation")
+		return
+	}
+
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations")
+	if err := apiutil.WriteJSON(w, http.StatusOK, updated); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write reschedule response")
+	}
+}
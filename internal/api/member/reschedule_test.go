@@ -0,0 +1,149 @@
+package member
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// bookedReservation books courtIDs tomorrow at 10:00 and returns the new
+// reservation's ID.
+func (f memberBookingFixture) bookedReservation(t *testing.T, courtIDs ...int64) int64 {
+	t.Helper()
+	if recorder := f.book(t, courtIDs...); recorder.Code != http.StatusCreated {
+		t.Fatalf("book status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var reservationID int64
+	if err := f.database.QueryRow("SELECT id FROM reservations ORDER BY id DESC LIMIT 1").Scan(&reservationID); err != nil {
+		t.Fatalf("load reservation: %v", err)
+	}
+	return reservationID
+}
+
+func (f memberBookingFixture) reschedule(t *testing.T, reservationID int64, start time.Time, courtIDs ...int64) *httptest.ResponseRecorder {
+	t.Helper()
+	form := url.Values{}
+	form.Set("start_time", start.Format(memberBookingTimeLayout))
+	form.Set("end_time", start.Add(time.Hour).Format(memberBookingTimeLayout))
+	for _, courtID := range courtIDs {
+		form.Add("court_ids", fmt.Sprintf("%d", courtID))
+	}
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/member/reservations/%d/reschedule", reservationID), strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetPathValue("id", fmt.Sprintf("%d", reservationID))
+	recorder := httptest.NewRecorder()
+	f.h.HandleMemberReservationReschedule(recorder, f.withMember(req))
+	return recorder
+}
+
+func (f memberBookingFixture) reservationStart(t *testing.T, reservationID int64) time.Time {
+	t.Helper()
+	var start time.Time
+	if err := f.database.QueryRow("SELECT start_time FROM reservations WHERE id = ?", reservationID).Scan(&start); err != nil {
+		t.Fatalf("load reservation start: %v", err)
+	}
+	return start
+}
+
+func TestHandleMemberReservationReschedule_MovesWithinFreeWindow(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+	reservationID := fixture.bookedReservation(t, fixture.courtIDs[0])
+
+	now := time.Now().UTC()
+	target := time.Date(now.Year(), now.Month(), now.Day()+2, 11, 0, 0, 0, time.UTC)
+	recorder := fixture.reschedule(t, reservationID, target, fixture.courtIDs[1])
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("reschedule status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if trigger := recorder.Header().Get("HX-Trigger"); !strings.Contains(trigger, "refreshMemberReservations") {
+		t.Fatalf("expected a reservations refresh trigger, got %q", trigger)
+	}
+
+	if start := fixture.reservationStart(t, reservationID); !start.Equal(target) {
+		t.Fatalf("expected the reservation to start at %s, got %s", target, start)
+	}
+	var courtID int64
+	if err := fixture.database.QueryRow("SELECT court_id FROM reservation_courts WHERE reservation_id = ?", reservationID).Scan(&courtID); err != nil {
+		t.Fatalf("load reservation court: %v", err)
+	}
+	if courtID != fixture.courtIDs[1] {
+		t.Fatalf("expected the reservation on court %d, got %d", fixture.courtIDs[1], courtID)
+	}
+	var cancellations int
+	if err := fixture.database.QueryRow("SELECT COUNT(*) FROM reservation_cancellations").Scan(&cancellations); err != nil {
+		t.Fatalf("count cancellations: %v", err)
+	}
+	if cancellations != 0 {
+		t.Fatalf("expected no cancellation to be logged, got %d", cancellations)
+	}
+}
+
+func TestHandleMemberReservationReschedule_RejectsUnavailableSlots(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+	reservationID := fixture.bookedReservation(t, fixture.courtIDs[0])
+	original := fixture.reservationStart(t, reservationID)
+
+	now := time.Now().UTC()
+	taken := time.Date(now.Year(), now.Month(), now.Day()+2, 14, 0, 0, 0, time.UTC)
+	if _, err := fixture.database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?)`,
+		fixture.facilityID, fixture.memberID, taken, taken.Add(time.Hour),
+	); err != nil {
+		t.Fatalf("insert blocking reservation: %v", err)
+	}
+	if _, err := fixture.database.Exec(
+		"INSERT INTO reservation_courts (reservation_id, court_id) VALUES ((SELECT MAX(id) FROM reservations), ?)",
+		fixture.courtIDs[0],
+	); err != nil {
+		t.Fatalf("insert blocking court: %v", err)
+	}
+
+	if recorder := fixture.reschedule(t, reservationID, taken); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected a taken court to conflict, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	afterHours := time.Date(now.Year(), now.Month(), now.Day()+2, 21, 0, 0, 0, time.UTC)
+	if recorder := fixture.reschedule(t, reservationID, afterHours); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected a slot after closing to conflict, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	tooFar := time.Date(now.Year(), now.Month(), now.Day()+60, 10, 0, 0, 0, time.UTC)
+	if recorder := fixture.reschedule(t, reservationID, tooFar); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected a slot past the advance window to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if start := fixture.reservationStart(t, reservationID); !start.Equal(original) {
+		t.Fatalf("expected the reservation to stay at %s, got %s", original, start)
+	}
+}
+
+func TestHandleMemberReservationReschedule_PenaltyWindowShowsCancellationModal(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+	reservationID := fixture.bookedReservation(t, fixture.courtIDs[0])
+	original := fixture.reservationStart(t, reservationID)
+
+	for _, tier := range []struct{ minHours, refund int64 }{{72, 100}, {0, 50}} {
+		if _, err := fixture.database.Exec(
+			"INSERT INTO cancellation_policy_tiers (facility_id, min_hours_before, refund_percentage) VALUES (?, ?, ?)",
+			fixture.facilityID, tier.minHours, tier.refund,
+		); err != nil {
+			t.Fatalf("insert tier: %v", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	target := time.Date(now.Year(), now.Month(), now.Day()+2, 11, 0, 0, 0, time.UTC)
+	recorder := fixture.reschedule(t, reservationID, target)
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("expected the penalty response, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder.Header().Get("HX-Retarget") != "#modal" || !strings.Contains(recorder.Body.String(), "50% refund") {
+		t.Fatalf("expected the cancellation modal, got %s", recorder.Body.String())
+	}
+	if start := fixture.reservationStart(t, reservationID); !start.Equal(original) {
+		t.Fatalf("expected the reservation to stay at %s, got %s", original, start)
+	}
+}
@@ -0,0 +1,128 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/models"
+	"github.com/codr1/Pickleicious/internal/qrcode"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+const reservationAccessQRScale = 8
+
+// HandleMemberReservationQR handles GET /member/reservations/{id}/qr. It
+// returns a PNG QR code that opens the facility's doors for this member
+// from shortly before the reservation until it ends. Each member on the
+// reservation gets their own code.
+func (h *Handlers) HandleMemberReservationQR(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil || h.accessSigner == nil {
+		logger.Error().Msg("Database queries or access signer not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	reservationID, err := apiutil.ReservationIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	reservation, err := q.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Reservation not found")
+			return
+		}
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to load reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation")
+		return
+	}
+
+	roster, err := q.ListReservationCheckinRoster(ctx, reservation.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to load reservation roster")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation")
+		return
+	}
+	onReservation := false
+	for _, row := range roster {
+		if row.UserID == user.ID {
+			onReservation = true
+			break
+		}
+	}
+	if !onReservation {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Reservation not found")
+		return
+	}
+
+	cancelled, err := q.IsReservationCancelled(ctx, reservation.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to load reservation status")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation")
+		return
+	}
+	if cancelled != 0 {
+		apiutil.WriteError(w, r, http.StatusConflict, "Reservation has been cancelled")
+		return
+	}
+	if !reservation.EndTime.After(time.Now()) {
+		apiutil.WriteError(w, r, http.StatusConflict, "Reservation has ended")
+		return
+	}
+
+	access := models.NewReservationAccess(reservation.ID, user.ID, reservation.StartTime, reservation.EndTime)
+	code, err := qrcode.Encode([]byte(h.accessSigner.Sign(access)))
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to encode reservation access code")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render access code")
+		return
+	}
+	image, err := code.PNG(reservationAccessQRScale)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to render reservation access code")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render access code")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	if apiutil.ParseBool(r.URL.Query().Get("download")) {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"reservation-%d-access.png\"", reservation.ID))
+	}
+	if _, err := w.Write(image); err != nil {
+		logger.Error().Err(err).Msg("Failed to write reservation access code")
+	}
+}
+
+// reservationAccessURL builds the emailed link to a reservation's access QR
+// code. It is nil when access codes are not configured.
+func (h *Handlers) reservationAccessURL() reservationsvc.AccessURL {
+	if h.accessSigner == nil {
+		return nil
+	}
+	return func(reservationID int64) string {
+		return h.absoluteURL(fmt.Sprintf("/member/reservations/%d/qr", reservationID))
+	}
+}
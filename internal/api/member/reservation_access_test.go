@@ -0,0 +1,53 @@
+package member
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/models"
+)
+
+func TestHandleMemberReservationQR_OnlyForMembersOnTheReservation(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+	signer, err := models.NewReservationAccessSigner("test-secret")
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+	fixture.h = NewHandlers(fixture.database, nil, Options{AccessSigner: signer})
+	reservationID := fixture.bookedReservation(t, fixture.courtIDs[0])
+
+	qr := func(req *http.Request) *httptest.ResponseRecorder {
+		req.SetPathValue("id", fmt.Sprintf("%d", reservationID))
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberReservationQR(recorder, req)
+		return recorder
+	}
+
+	recorder := qr(fixture.withMember(httptest.NewRequest(http.MethodGet, fmt.Sprintf("/member/reservations/%d/qr", reservationID), nil)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("qr status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "image/png" {
+		t.Fatalf("expected a PNG, got %q", contentType)
+	}
+
+	stranger := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/member/reservations/%d/qr", reservationID), nil)
+	stranger = stranger.WithContext(authz.ContextWithUser(stranger.Context(), &authz.AuthUser{ID: fixture.memberID + 100}))
+	if recorder := qr(stranger); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a member not on the reservation, got %d", recorder.Code)
+	}
+
+	if _, err := fixture.database.Exec(
+		`INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, hours_before_start)
+		 VALUES (?, ?, CURRENT_TIMESTAMP, 100, 24)`,
+		reservationID, fixture.memberID,
+	); err != nil {
+		t.Fatalf("cancel reservation: %v", err)
+	}
+	if recorder := qr(fixture.withMember(httptest.NewRequest(http.MethodGet, fmt.Sprintf("/member/reservations/%d/qr", reservationID), nil))); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a cancelled reservation, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,352 @@
+package member
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+	"github.com/codr1/Pickleicious/internal/templates/layouts"
+)
+
+const memberInviteeSearchLimit = 20
+
+type reservationInvitationRequest struct {
+	UserIDs []int64 `json:"userIds"`
+}
+
+type reservationInvitationResponse struct {
+	ID            int64  `json:"id"`
+	ReservationID int64  `json:"reservationId"`
+	InvitedUserID int64  `json:"invitedUserId"`
+	Status        string `json:"status"`
+}
+
+// HandleMemberInviteeSearch handles GET /member/members/search?q= for the
+// invite picker. It matches names only among active members at the member's
+// home facility and returns names only, never contact details.
+func (h *Handlers) HandleMemberInviteeSearch(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	members := []membertempl.MemberInviteeOption{}
+	searchTerm := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("q")))
+	if searchTerm != "" {
+		ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+		defer cancel()
+
+		rows, err := q.SearchFacilityMembers(ctx, dbgen.SearchFacilityMembersParams{
+			FacilityID: sql.NullInt64{Int64: *user.HomeFacilityID, Valid: true},
+			Prefix:     searchTerm,
+			Limit:      memberInviteeSearchLimit,
+		})
+		if err != nil {
+			logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to search members")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to search members")
+			return
+		}
+		for _, row := range rows {
+			if row.ID == user.ID {
+				continue
+			}
+			// The shared search also matches email prefixes; dropping
+			// those keeps members from probing each other's addresses.
+			first := strings.ToLower(strings.TrimSpace(row.FirstName))
+			last := strings.ToLower(strings.TrimSpace(row.LastName))
+			if !strings.HasPrefix(first, searchTerm) && !strings.HasPrefix(last, searchTerm) &&
+				!strings.HasPrefix(first+" "+last, searchTerm) {
+				continue
+			}
+			members = append(members, membertempl.MemberInviteeOption{
+				ID:   row.ID,
+				Name: strings.TrimSpace(strings.TrimSpace(row.FirstName) + " " + strings.TrimSpace(row.LastName)),
+			})
+		}
+	}
+
+	if apiutil.IsHTMXRequest(r) {
+		component := membertempl.MemberInviteeOptions(members)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render invitee options", "Failed to render member search")
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"members": members}); err != nil {
+		logger.Error().Err(err).Msg("Failed to write member search response")
+		return
+	}
+}
+
+// HandleMemberReservationInvite handles POST
+// /member/reservations/{id}/invitations. The member who booked invites other
+// members, who join once they accept the emailed link.
+func (h *Handlers) HandleMemberReservationInvite(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	service := h.loadService()
+	if service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	reservationID, err := apiutil.ReservationIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	var userIDs []int64
+	if apiutil.IsJSONRequest(r) {
+		var req reservationInvitationRequest
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			apiutil.WriteValidationError(w, r, err)
+			return
+		}
+		userIDs = req.UserIDs
+	} else {
+		if err := r.ParseForm(); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+		userIDs, err = parseMemberInviteeIDs(r)
+		if err != nil {
+			apiutil.WriteValidationError(w, r, err)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	invitations, err := service.InviteParticipants(ctx, reservationsvc.InviteInput{
+		ReservationID: reservationID,
+		OrganizerID:   user.ID,
+		UserIDs:       userIDs,
+		Links:         h.reservationInvitationLinks(),
+	})
+	if err != nil {
+		writeReservationInvitationError(w, r, reservationID, err)
+		return
+	}
+
+	response := make([]reservationInvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		response = append(response, reservationInvitationResponse{
+			ID:            invitation.ID,
+			ReservationID: invitation.ReservationID,
+			InvitedUserID: invitation.InvitedUserID,
+			Status:        invitation.Status,
+		})
+	}
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations")
+	if err := apiutil.WriteJSON(w, http.StatusCreated, response); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write reservation invitation response")
+		return
+	}
+}
+
+// HandleReservationInvitationAccept handles GET and POST
+// /member/reservation-invitations/{token}/accept. GET is the emailed link and
+// redirects to the portal once the member is on the reservation.
+func (h *Handlers) HandleReservationInvitationAccept(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	service := h.loadService()
+	if service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	token := strings.TrimSpace(r.PathValue("token"))
+	if token == "" {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Invitation not found")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	invitation, err := service.AcceptInvitation(ctx, token, user.ID)
+	if err != nil {
+		writeReservationInvitationError(w, r, 0, err)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		http.Redirect(w, r, "/member", http.StatusSeeOther)
+		return
+	}
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations")
+	if err := apiutil.WriteJSON(w, http.StatusOK, reservationInvitationResponse{
+		ID:            invitation.ID,
+		ReservationID: invitation.ReservationID,
+		InvitedUserID: invitation.InvitedUserID,
+		Status:        invitation.Status,
+	}); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", invitation.ReservationID).Msg("Failed to write reservation invitation response")
+		return
+	}
+}
+
+// HandleReservationInvitationDecline handles GET and POST
+// /reservation-invitations/{token}/decline. The token is the only credential,
+// so invitees can decline without logging in. GET is the emailed link and
+// only asks for confirmation, so mail scanners that follow links cannot
+// decline on the invitee's behalf.
+func (h *Handlers) HandleReservationInvitationDecline(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	service := h.loadService()
+	if q == nil || service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	token := strings.TrimSpace(r.PathValue("token"))
+	data := membertempl.InvitationDeclineData{Token: token}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	status := http.StatusOK
+	if r.Method == http.MethodPost {
+		if _, err := service.DeclineInvitation(ctx, token); err != nil {
+			var herr apiutil.HandlerError
+			if !errors.As(err, &herr) {
+				herr = apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to decline invitation", Err: err}
+			}
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Msg(herr.Message)
+			}
+			status = herr.Status
+			data.Message = herr.Message
+		} else {
+			data.Declined = true
+		}
+	} else {
+		invitation, err := q.GetReservationInvitationByToken(ctx, token)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			status = http.StatusNotFound
+			data.Message = "Invitation not found"
+		case err != nil:
+			logger.Error().Err(err).Msg("Failed to load invitation")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load invitation")
+			return
+		case invitation.Status == reservationsvc.InvitationStatusDeclined:
+			data.Declined = true
+		case invitation.Status == reservationsvc.InvitationStatusCancelled || invitation.ReservationCancelled != 0:
+			data.Message = "This reservation was cancelled."
+		default:
+			facility, err := h.loadFacilities().GetFacilityByID(ctx, invitation.FacilityID)
+			if err != nil {
+				logger.Error().Err(err).Int64("facility_id", invitation.FacilityID).Msg("Failed to load facility")
+				apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load invitation")
+				return
+			}
+			loc := apiutil.FacilityLocation(facility, logger)
+			date, timeRange := email.FormatDateTimeRange(invitation.StartTime.In(loc), invitation.EndTime.In(loc))
+			data.FacilityName = facility.Name
+			data.When = fmt.Sprintf("%s, %s", date, timeRange)
+		}
+	}
+
+	var buf bytes.Buffer
+	page := layouts.Base(membertempl.InvitationDeclinePage(data), nil, "")
+	if err := page.Render(r.Context(), &buf); err != nil {
+		logger.Error().Err(err).Msg("Failed to render invitation decline page")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render page")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(status)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.Error().Err(err).Msg("Failed to write invitation decline page")
+	}
+}
+
+func writeReservationInvitationError(w http.ResponseWriter, r *http.Request, reservationID int64, err error) {
+	logger := log.Ctx(r.Context())
+
+	var herr apiutil.HandlerError
+	if errors.As(err, &herr) {
+		if herr.Status == http.StatusInternalServerError {
+			logger.Error().Err(herr.Err).Int64("reservation_id", reservationID).Msg(herr.Message)
+		}
+		apiutil.WriteHandlerError(w, r, herr)
+		return
+	}
+	logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to update reservation invitation")
+	apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update invitation")
+}
+
+// reservationInvitationLinks builds the emailed accept and decline links for
+// an invitation token.
+func (h *Handlers) reservationInvitationLinks() reservationsvc.InvitationLinks {
+	return func(token string) (string, string) {
+		return h.absoluteURL(fmt.Sprintf("/member/reservation-invitations/%s/accept", token)),
+			h.absoluteURL(fmt.Sprintf("/reservation-invitations/%s/decline", token))
+	}
+}
+
+func parseMemberInviteeIDs(r *http.Request) ([]int64, error) {
+	values := r.Form["invitee_ids"]
+	if len(values) == 0 {
+		values = r.Form["invitee_ids[]"]
+	}
+	userIDs := make([]int64, 0, len(values))
+	for _, raw := range values {
+		value := strings.TrimSpace(raw)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invitee_ids must be a positive integer")
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,127 @@
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestReservationInvitations_SearchInviteAndDecline(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	h := NewHandlers(database, nil, Options{})
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.Exec(query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main', 'main', 'UTC')", orgID)
+	courtID := exec("INSERT INTO courts (facility_id, name, court_number) VALUES (?, 'Court 1', 1)", facilityID)
+	insertMember := func(first, last, email string) int64 {
+		t.Helper()
+		return exec(
+			`INSERT INTO users (first_name, last_name, email, status, is_member, home_facility_id)
+			 VALUES (?, ?, ?, 'active', 1, ?)`,
+			first, last, email, facilityID,
+		)
+	}
+	organizerID := insertMember("Olivia", "Organizer", "olivia@test.com")
+	inviteeID := insertMember("Ivan", "Invitee", "ivan@test.com")
+	insertMember("Zoe", "Hidden", "ivy@test.com")
+
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour).UTC()
+	reservationID := exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?, ?)`,
+		facilityID, organizerID, organizerID, start, start.Add(time.Hour),
+	)
+	exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", reservationID, courtID)
+	exec("INSERT INTO reservation_participants (reservation_id, user_id) VALUES (?, ?)", reservationID, organizerID)
+
+	homeFacilityID := facilityID
+	organizer := &authz.AuthUser{ID: organizerID, HomeFacilityID: &homeFacilityID}
+
+	// Name prefixes match; an email prefix and the searching member do not.
+	req := httptest.NewRequest(http.MethodGet, "/member/members/search?q=iv", nil)
+	req = req.WithContext(authz.ContextWithUser(req.Context(), organizer))
+	recorder := httptest.NewRecorder()
+	h.HandleMemberInviteeSearch(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("search: expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var search struct {
+		Members []membertempl.MemberInviteeOption `json:"members"`
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&search); err != nil {
+		t.Fatalf("decode search: %v", err)
+	}
+	if len(search.Members) != 1 || search.Members[0].ID != inviteeID || search.Members[0].Name != "Ivan Invitee" {
+		t.Fatalf("unexpected search results: %+v", search.Members)
+	}
+	if strings.Contains(recorder.Body.String(), "@") {
+		t.Fatalf("expected no contact details in search results")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/member/reservations/%d/invitations", reservationID), strings.NewReader(fmt.Sprintf("invitee_ids=%d", inviteeID)))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetPathValue("id", fmt.Sprintf("%d", reservationID))
+	req = req.WithContext(authz.ContextWithUser(req.Context(), organizer))
+	recorder = httptest.NewRecorder()
+	h.HandleMemberReservationInvite(recorder, req)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("invite: expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var token string
+	if err := database.QueryRow("SELECT token FROM reservation_invitations WHERE invited_user_id = ?", inviteeID).Scan(&token); err != nil {
+		t.Fatalf("load invitation token: %v", err)
+	}
+	status := func() string {
+		t.Helper()
+		var status string
+		if err := database.QueryRow("SELECT status FROM reservation_invitations WHERE invited_user_id = ?", inviteeID).Scan(&status); err != nil {
+			t.Fatalf("load invitation status: %v", err)
+		}
+		return status
+	}
+
+	// Following the emailed link only asks for confirmation, with no login.
+	decline := func(method string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/reservation-invitations/"+token+"/decline", nil)
+		req.SetPathValue("token", token)
+		recorder := httptest.NewRecorder()
+		h.HandleReservationInvitationDecline(recorder, req)
+		return recorder
+	}
+	recorder = decline(http.MethodGet)
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "Decline invitation") {
+		t.Fatalf("decline link: expected confirmation page, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if got := status(); got != "pending" {
+		t.Fatalf("expected GET to leave the invitation pending, got %q", got)
+	}
+
+	recorder = decline(http.MethodPost)
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "your spot was released") {
+		t.Fatalf("decline: expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if got := status(); got != "declined" {
+		t.Fatalf("expected declined invitation, got %q", got)
+	}
+	if recorder := decline(http.MethodPost); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected second decline to conflict, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,269 @@
+package member
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/email"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+	"github.com/codr1/Pickleicious/internal/templates/layouts"
+)
+
+type reservationTransferRequest struct {
+	UserID int64 `json:"userId"`
+}
+
+type reservationTransferResponse struct {
+	ID            int64     `json:"id"`
+	ReservationID int64     `json:"reservationId"`
+	ToUserID      int64     `json:"toUserId"`
+	Status        string    `json:"status"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+// HandleMemberReservationTransfer handles POST
+// /member/reservations/{id}/transfer. The member who booked offers the
+// reservation to another member, who takes it over once they accept the
+// emailed link.
+func (h *Handlers) HandleMemberReservationTransfer(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	service := h.loadService()
+	if service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	reservationID, err := apiutil.ReservationIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	var toUserID int64
+	if apiutil.IsJSONRequest(r) {
+		var req reservationTransferRequest
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			apiutil.WriteValidationError(w, r, err)
+			return
+		}
+		toUserID = req.UserID
+	} else {
+		if err := r.ParseForm(); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+		toUserID, err = strconv.ParseInt(strings.TrimSpace(r.FormValue("to_user_id")), 10, 64)
+		if err != nil {
+			toUserID = 0
+		}
+	}
+	if toUserID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Choose a member to transfer to")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	transfer, err := service.RequestTransfer(ctx, reservationsvc.TransferInput{
+		ReservationID: reservationID,
+		FromUserID:    user.ID,
+		ToUserID:      toUserID,
+		Window:        h.transferWindow,
+		Links:         h.reservationTransferLinks(),
+	})
+	if err != nil {
+		writeReservationTransferError(w, r, reservationID, err)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, reservationTransferResponse{
+		ID:            transfer.ID,
+		ReservationID: transfer.ReservationID,
+		ToUserID:      transfer.ToUserID,
+		Status:        transfer.Status,
+		ExpiresAt:     transfer.ExpiresAt,
+	}); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write reservation transfer response")
+		return
+	}
+}
+
+// HandleReservationTransferAccept handles GET and POST
+// /member/reservation-transfers/{token}/accept. GET is the emailed link and
+// redirects to the portal once the reservation is the member's.
+func (h *Handlers) HandleReservationTransferAccept(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	service := h.loadService()
+	if service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	token := strings.TrimSpace(r.PathValue("token"))
+	if token == "" {
+		apiutil.WriteError(w, r, http.StatusNotFound, "Transfer not found")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	transfer, err := service.AcceptTransfer(ctx, token, user.ID)
+	if err != nil {
+		writeReservationTransferError(w, r, 0, err)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		http.Redirect(w, r, "/member", http.StatusSeeOther)
+		return
+	}
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations")
+	if err := apiutil.WriteJSON(w, http.StatusOK, reservationTransferResponse{
+		ID:            transfer.ID,
+		ReservationID: transfer.ReservationID,
+		ToUserID:      transfer.ToUserID,
+		Status:        transfer.Status,
+		ExpiresAt:     transfer.ExpiresAt,
+	}); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", transfer.ReservationID).Msg("Failed to write reservation transfer response")
+		return
+	}
+}
+
+// HandleReservationTransferDecline handles GET and POST
+// /reservation-transfers/{token}/decline. Like invitation declines, the token
+// is the only credential and GET only asks for confirmation.
+func (h *Handlers) HandleReservationTransferDecline(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	service := h.loadService()
+	if q == nil || service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	token := strings.TrimSpace(r.PathValue("token"))
+	data := membertempl.TransferDeclineData{Token: token}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	status := http.StatusOK
+	if r.Method == http.MethodPost {
+		if _, err := service.DeclineTransfer(ctx, token); err != nil {
+			var herr apiutil.HandlerError
+			if !errors.As(err, &herr) {
+				herr = apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to decline transfer", Err: err}
+			}
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Msg(herr.Message)
+			}
+			status = herr.Status
+			data.Message = herr.Message
+		} else {
+			data.Declined = true
+		}
+	} else {
+		transfer, err := q.GetReservationTransferByToken(ctx, token)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			status = http.StatusNotFound
+			data.Message = "Transfer not found"
+		case err != nil:
+			logger.Error().Err(err).Msg("Failed to load transfer")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load transfer")
+			return
+		case transfer.Status == reservationsvc.TransferStatusDeclined:
+			data.Declined = true
+		case transfer.ReservationCancelled != 0:
+			data.Message = "This reservation was cancelled."
+		case transfer.Status != reservationsvc.TransferStatusPending || !transfer.ExpiresAt.After(time.Now()):
+			data.Message = "This transfer offer is no longer open."
+		default:
+			facility, err := h.loadFacilities().GetFacilityByID(ctx, transfer.FacilityID)
+			if err != nil {
+				logger.Error().Err(err).Int64("facility_id", transfer.FacilityID).Msg("Failed to load facility")
+				apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load transfer")
+				return
+			}
+			loc := apiutil.FacilityLocation(facility, logger)
+			date, timeRange := email.FormatDateTimeRange(transfer.StartTime.In(loc), transfer.EndTime.In(loc))
+			data.FacilityName = facility.Name
+			data.When = fmt.Sprintf("%s, %s", date, timeRange)
+		}
+	}
+
+	var buf bytes.Buffer
+	page := layouts.Base(membertempl.TransferDeclinePage(data), nil, "")
+	if err := page.Render(r.Context(), &buf); err != nil {
+		logger.Error().Err(err).Msg("Failed to render transfer decline page")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render page")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(status)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.Error().Err(err).Msg("Failed to write transfer decline page")
+	}
+}
+
+func writeReservationTransferError(w http.ResponseWriter, r *http.Request, reservationID int64, err error) {
+	logger := log.Ctx(r.Context())
+
+	var limitErr reservationsvc.ReservationLimitError
+	if errors.As(err, &limitErr) {
+		writeReservationLimitError(w, r, limitErr.CurrentCount, limitErr.Limit)
+		return
+	}
+	var herr apiutil.HandlerError
+	if errors.As(err, &herr) {
+		if herr.Status == http.StatusInternalServerError {
+			logger.Error().Err(herr.Err).Int64("reservation_id", reservationID).Msg(herr.Message)
+		}
+		apiutil.WriteHandlerError(w, r, herr)
+		return
+	}
+	logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to update reservation transfer")
+	apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update transfer")
+}
+
+// reservationTransferLinks builds the emailed accept and decline links for a
+// transfer token.
+func (h *Handlers) reservationTransferLinks() reservationsvc.InvitationLinks {
+	return func(token string) (string, string) {
+		return h.absoluteURL(fmt.Sprintf("/member/reservation-transfers/%s/accept", token)),
+			h.absoluteURL(fmt.Sprintf("/reservation-transfers/%s/decline", token))
+	}
+}
@@ -0,0 +1,282 @@
+package member
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/auth"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+var loadMoreURLPattern = regexp.MustCompile(`hx-get="(/member/reservations\?[^"]+)"`)
+
+func TestHandleMemberReservationsPartial_PagesAndFiltersByDate(t *testing.T) {
+	fixture := setupCalendarExportTest(t)
+
+	loc, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	today := time.Now().In(loc)
+	firstUpcoming := time.Date(today.Year(), today.Month(), today.Day()+1, 10, 0, 0, 0, loc)
+	for day := 0; day < memberUpcomingReservationsPageSize+2; day++ {
+		fixture.insertReservation(t, firstUpcoming.AddDate(0, 0, day))
+	}
+	lastPast := time.Date(today.Year(), today.Month(), today.Day()-1, 10, 0, 0, 0, loc)
+	for day := 0; day < memberPastReservationsPageSize+2; day++ {
+		fixture.insertReservation(t, lastPast.AddDate(0, 0, -day))
+	}
+
+	request := func(target string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:             fixture.memberID,
+			SessionType:    auth.SessionTypeMember,
+			HomeFacilityID: &fixture.facility,
+		}))
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberReservationsPartial(recorder, req)
+		return recorder
+	}
+	countItems := func(body string) (upcoming, past int) {
+		total := strings.Count(body, `<li class="py-4 flex`)
+		upcoming = strings.Count(body, "Add to calendar")
+		return upcoming, total - upcoming
+	}
+	moreURLs := func(body string) []string {
+		var urls []string
+		for _, match := range loadMoreURLPattern.FindAllStringSubmatch(body, -1) {
+			urls = append(urls, html.UnescapeString(match[1]))
+		}
+		return urls
+	}
+
+	recorder := request("/member/reservations")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	body := recorder.Body.String()
+	if upcoming, past := countItems(body); upcoming != memberUpcomingReservationsPageSize || past != memberPastReservationsPageSize {
+		t.Fatalf("first page has %d upcoming and %d past, want %d and %d", upcoming, past, memberUpcomingReservationsPageSize, memberPastReservationsPageSize)
+	}
+	urls := moreURLs(body)
+	if len(urls) != 2 || !strings.Contains(urls[0], "section=upcoming") || !strings.Contains(urls[1], "section=past") {
+		t.Fatalf("unexpected load more URLs: %v", urls)
+	}
+	if first := firstUpcoming.Format("Jan 2, 2006 3:04 PM"); !strings.Contains(body, first) {
+		t.Fatalf("expected soonest reservation %q on the first page", first)
+	}
+
+	recorder = request(urls[0])
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("load more status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if upcoming, past := countItems(recorder.Body.String()); upcoming != 2 || past != 0 {
+		t.Fatalf("second upcoming page has %d upcoming and %d past, want 2 and 0", upcoming, past)
+	}
+	if urls := moreURLs(recorder.Body.String()); len(urls) != 0 {
+		t.Fatalf("expected no further pages, got %v", urls)
+	}
+
+	day := firstUpcoming.AddDate(0, 0, 3).Format(reservationFilterDateLayout)
+	recorder = request(fmt.Sprintf("/member/reservations?from=%s&to=%s", day, day))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("filtered status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if upcoming, past := countItems(recorder.Body.String()); upcoming != 1 || past != 0 {
+		t.Fatalf("date filter returned %d upcoming and %d past, want 1 and 0", upcoming, past)
+	}
+
+	if recorder := request("/member/reservations?from=tomorrow"); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected invalid date to be rejected, got %d", recorder.Code)
+	}
+	if recorder := request(fmt.Sprintf("/member/reservations?section=past&offset=-1&facility_id=%d", fixture.facility)); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected negative offset to be rejected, got %d", recorder.Code)
+	}
+}
+
+func TestHandleMemberReservationsPartial_BatchesParticipantsAndRefundsAcrossFacilities(t *testing.T) {
+	fixture := setupCalendarExportTest(t)
+
+	orgID := int64(0)
+	if err := fixture.database.QueryRow("SELECT organization_id FROM facilities WHERE id = ?", fixture.facility).Scan(&orgID); err != nil {
+		t.Fatalf("load organization: %v", err)
+	}
+	otherResult, err := fixture.database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Lakeside Club", "lakeside", "America/Chicago",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	otherFacility, _ := otherResult.LastInsertId()
+	otherCourtResult, err := fixture.database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+		otherFacility, "Court 1", 1, "active",
+	)
+	if err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+	otherCourt, _ := otherCourtResult.LastInsertId()
+	courtByFacility := map[int64]int64{fixture.facility: fixture.courtID, otherFacility: otherCourt}
+
+	var gameTypeID int64
+	if err := fixture.database.QueryRow("SELECT id FROM reservation_types WHERE name = 'GAME'").Scan(&gameTypeID); err != nil {
+		t.Fatalf("load reservation type: %v", err)
+	}
+	for _, tier := range []struct {
+		facilityID int64
+		typeID     any
+		minHours   int
+		refund     int
+	}{
+		{fixture.facility, nil, 0, 50},
+		{fixture.facility, gameTypeID, 24, 80},
+		{otherFacility, nil, 0, 25},
+	} {
+		if _, err := fixture.database.Exec(
+			"INSERT INTO cancellation_policy_tiers (facility_id, reservation_type_id, min_hours_before, refund_percentage) VALUES (?, ?, ?, ?)",
+			tier.facilityID, tier.typeID, tier.minHours, tier.refund,
+		); err != nil {
+			t.Fatalf("insert cancellation tier: %v", err)
+		}
+	}
+
+	insertUser := func(first, last string) int64 {
+		t.Helper()
+		result, err := fixture.database.Exec(
+			"INSERT INTO users (first_name, last_name, email, status) VALUES (?, ?, ?, 'active')",
+			first, last, strings.ToLower(first)+"@test.com",
+		)
+		if err != nil {
+			t.Fatalf("insert user: %v", err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	partner := insertUser("Pat", "Partner")
+	opponent := insertUser("Quinn", "Opponent")
+
+	insertReservation := func(facilityID int64, start time.Time, participants ...int64) {
+		t.Helper()
+		result, err := fixture.database.Exec(
+			`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+			 VALUES (?, ?, ?, ?, ?, ?)`,
+			facilityID, gameTypeID, fixture.memberID, fixture.memberID, start, start.Add(time.Hour),
+		)
+		if err != nil {
+			t.Fatalf("insert reservation: %v", err)
+		}
+		reservationID, _ := result.LastInsertId()
+		if _, err := fixture.database.Exec(
+			"INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)",
+			reservationID, courtByFacility[facilityID],
+		); err != nil {
+			t.Fatalf("assign court: %v", err)
+		}
+		for _, userID := range append([]int64{fixture.memberID}, participants...) {
+			if _, err := fixture.database.Exec(
+				"INSERT INTO reservation_participants (reservation_id, user_id) VALUES (?, ?)",
+				reservationID, userID,
+			); err != nil {
+				t.Fatalf("insert participant: %v", err)
+			}
+		}
+	}
+	insertReservation(fixture.facility, time.Now().Add(48*time.Hour), partner)
+	insertReservation(otherFacility, time.Now().Add(72*time.Hour), opponent, partner)
+	insertReservation(otherFacility, time.Now().Add(-72*time.Hour), opponent)
+
+	list := func(facilityID int64) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/member/reservations?facility_id=%d", facilityID), nil)
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:             fixture.memberID,
+			SessionType:    auth.SessionTypeMember,
+			HomeFacilityID: &fixture.facility,
+		}))
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleMemberReservationsPartial(recorder, req)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+		}
+		return recorder.Body.String()
+	}
+
+	home := list(fixture.facility)
+	for _, want := range []string{"80% refund", "Participants: Pat Partner"} {
+		if !strings.Contains(home, want) {
+			t.Fatalf("expected %q in home facility list:\n%s", want, home)
+		}
+	}
+	if strings.Contains(home, "Quinn") || strings.Contains(home, "Cal Endar") {
+		t.Fatalf("home facility list shows the wrong participants:\n%s", home)
+	}
+
+	other := list(otherFacility)
+	for _, want := range []string{"25% refund", "Participants: Quinn Opponent, Pat Partner"} {
+		if !strings.Contains(other, want) {
+			t.Fatalf("expected %q in other facility list:\n%s", want, other)
+		}
+	}
+	if count := strings.Count(other, "Quinn Opponent"); count != 2 || strings.Contains(other, "Cal Endar") {
+		t.Fatalf("expected the opponent on both reservations and the member excluded:\n%s", other)
+	}
+
+	// The batched resolver must agree with the per-reservation lookup.
+	ctx := context.Background()
+	tiers, err := apiutil.LoadCancellationTiers(ctx, fixture.database.Queries, []int64{fixture.facility, otherFacility})
+	if err != nil {
+		t.Fatalf("load tiers: %v", err)
+	}
+	for _, facilityID := range []int64{fixture.facility, otherFacility, otherFacility + 1} {
+		for _, typeID := range []*int64{nil, &gameTypeID} {
+			for hours := int64(0); hours <= 48; hours += 12 {
+				want, err := apiutil.ApplicableRefundPercentage(ctx, fixture.database.Queries, facilityID, hours, typeID)
+				if err != nil {
+					t.Fatalf("applicable refund: %v", err)
+				}
+				if got := tiers.RefundPercentage(facilityID, hours, typeID); got != want {
+					t.Fatalf("facility %d type %v at %dh: batched %d, single %d", facilityID, typeID, hours, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestHandleMemberReservationsPartial_ShowsCheckedInBadgeOnPastReservations(t *testing.T) {
+	fixture := setupCalendarExportTest(t)
+
+	attended := fixture.insertReservation(t, time.Now().Add(-48*time.Hour))
+	fixture.insertReservation(t, time.Now().Add(-72*time.Hour))
+	if _, err := fixture.database.Exec(
+		"INSERT INTO reservation_checkins (reservation_id, user_id, checked_in_at, checked_in_by_user_id) VALUES (?, ?, ?, ?)",
+		attended, fixture.memberID, time.Now().Add(-48*time.Hour), fixture.memberID,
+	); err != nil {
+		t.Fatalf("insert check-in: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/member/reservations", nil)
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             fixture.memberID,
+		SessionType:    auth.SessionTypeMember,
+		HomeFacilityID: &fixture.facility,
+	}))
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleMemberReservationsPartial(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if count := strings.Count(recorder.Body.String(), "Checked in"); count != 1 {
+		t.Fatalf("expected one checked-in badge, got %d", count)
+	}
+}
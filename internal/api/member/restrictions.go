@@ -0,0 +1,105 @@
+package member
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+// HandleMemberRestrictions handles GET /member/restrictions.
+func (h *Handlers) HandleMemberRestrictions(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+	facilityID := *user.HomeFacilityID
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	restriction, err := h.loadMemberNoShowRestriction(ctx, q, facilityID, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Int64("facility_id", facilityID).Msg("Failed to load no-show restriction")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load booking restrictions")
+		return
+	}
+
+	data := membertempl.RestrictionData{FacilityID: facilityID}
+	if restriction != nil {
+		until := restriction.Until
+		data.Restricted = true
+		data.Until = &until
+		data.NoShowCount = restriction.NoShowCount
+		data.MaxNoShows = restriction.MaxNoShows
+		data.RestrictionDays = restriction.RestrictionDays
+		data.Message = fmt.Sprintf(
+			"You missed %d reservations in 30 days without checking in (the limit is %d), so booking is paused for %d days until %s. Contact the front desk if this is a mistake.",
+			restriction.NoShowCount, restriction.MaxNoShows, restriction.RestrictionDays, until.Format("Mon, Jan 2, 2006 3:04 PM"),
+		)
+	}
+
+	acceptHeader := strings.ToLower(r.Header.Get("Accept"))
+	wantsHTML := apiutil.IsHTMXRequest(r) || strings.Contains(acceptHeader, "text/html")
+	wantsJSON := strings.Contains(acceptHeader, "application/json")
+	if wantsHTML && (!wantsJSON || apiutil.IsHTMXRequest(r)) {
+		component := membertempl.MemberRestrictionNotice(data)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render booking restrictions", "Failed to render booking restrictions")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, data); err != nil {
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to write booking restrictions response")
+		return
+	}
+}
+
+// loadMemberNoShowRestriction returns the member's active restriction with
+// its end time in facility time, or nil when they may book.
+func (h *Handlers) loadMemberNoShowRestriction(ctx context.Context, q *dbgen.Queries, facilityID, userID int64) (*apiutil.NoShowRestriction, error) {
+	restriction, err := apiutil.LoadNoShowRestriction(ctx, q, facilityID, userID, time.Now())
+	if err != nil || restriction == nil {
+		return nil, err
+	}
+	restriction.Until = restriction.Until.In(memberFacilityLocation(ctx, h.loadFacilities(), facilityID))
+	return restriction, nil
+}
+
+// ensureNoNoShowRestriction writes a 403 naming the restriction end date and
+// returns false when the member may not book at the facility.
+func (h *Handlers) ensureNoNoShowRestriction(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries, facilityID, userID int64) bool {
+	logger := log.Ctx(ctx)
+	restriction, err := h.loadMemberNoShowRestriction(ctx, q, facilityID, userID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", userID).Int64("facility_id", facilityID).Msg("Failed to load no-show restriction")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to check booking restrictions")
+		return false
+	}
+	if restriction != nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, apiutil.NoShowRestrictionError{Restriction: *restriction}.Error())
+		return false
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package member
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+func (f memberBookingFixture) insertPastReservation(t *testing.T, start time.Time) int64 {
+	t.Helper()
+	result, err := f.database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?, ?)`,
+		f.facilityID, f.memberID, f.memberID, start, start.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	id, _ := result.LastInsertId()
+	return id
+}
+
+func (f memberBookingFixture) restrictions(t *testing.T) membertempl.RestrictionData {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/member/restrictions", nil)
+	req.Header.Set("Accept", "application/json")
+	recorder := httptest.NewRecorder()
+	f.h.HandleMemberRestrictions(recorder, f.withMember(req))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("restrictions status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var data membertempl.RestrictionData
+	if err := json.Unmarshal(recorder.Body.Bytes(), &data); err != nil {
+		t.Fatalf("decode restrictions: %v", err)
+	}
+	return data
+}
+
+func TestHandleMemberBookingCreate_RejectsMembersRestrictedForNoShows(t *testing.T) {
+	fixture := setupMemberBookingTest(t, 1)
+	if _, err := fixture.database.Exec(
+		"INSERT INTO facility_no_show_policies (facility_id, max_no_shows_per_30_days, restriction_days) VALUES (?, 1, 7)",
+		fixture.facilityID,
+	); err != nil {
+		t.Fatalf("insert policy: %v", err)
+	}
+
+	now := time.Now()
+	fixture.insertPastReservation(t, now.Add(-72*time.Hour))
+
+	// Cancelled before it started, so not a no-show.
+	cancelled := fixture.insertPastReservation(t, now.Add(-48*time.Hour))
+	if _, err := fixture.database.Exec(
+		`INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, fee_waived, hours_before_start)
+		 VALUES (?, ?, ?, 100, 0, 24)`,
+		cancelled, fixture.memberID, now.Add(-72*time.Hour),
+	); err != nil {
+		t.Fatalf("insert cancellation: %v", err)
+	}
+
+	// Checked in, so not a no-show.
+	attended := fixture.insertPastReservation(t, now.Add(-36*time.Hour))
+	if _, err := fixture.database.Exec(
+		"INSERT INTO reservation_checkins (reservation_id, user_id, checked_in_at, checked_in_by_user_id) VALUES (?, ?, ?, ?)",
+		attended, fixture.memberID, now.Add(-36*time.Hour), fixture.memberID,
+	); err != nil {
+		t.Fatalf("insert check-in: %v", err)
+	}
+
+	if data := fixture.restrictions(t); data.Restricted {
+		t.Fatalf("expected no restriction at the limit, got %+v", data)
+	}
+	if recorder := fixture.book(t, fixture.courtIDs[0]); recorder.Code == http.StatusForbidden {
+		t.Fatalf("unexpected restriction: %s", recorder.Body.String())
+	}
+
+	lastNoShow := now.Add(-24 * time.Hour)
+	fixture.insertPastReservation(t, lastNoShow)
+
+	data := fixture.restrictions(t)
+	wantUntil := lastNoShow.Add(time.Hour).Add(7 * 24 * time.Hour)
+	if !data.Restricted || data.NoShowCount != 2 || data.Until == nil || data.Until.Sub(wantUntil).Abs() > time.Second {
+		t.Fatalf("unexpected restriction %+v, want until %s", data, wantUntil)
+	}
+
+	recorder := fixture.book(t, fixture.courtIDs[1])
+	if recorder.Code != http.StatusForbidden || !strings.Contains(recorder.Body.String(), "restricted until") {
+		t.Fatalf("expected restricted booking to be forbidden, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,234 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/models"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/member"
+)
+
+// HandleMemberSeasonPassesList handles GET /member/season-passes.
+func (h *Handlers) HandleMemberSeasonPassesList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodGet {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	usage, err := q.ListSeasonPassUsageForUser(ctx, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load season pass usage")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load season passes")
+		return
+	}
+
+	passes := make([]membertempl.SeasonPassUsageSummary, 0, len(usage))
+	heldTypes := make(map[int64]bool, len(usage))
+	for _, row := range usage {
+		if row.FacilityID != *user.HomeFacilityID {
+			continue
+		}
+		if row.Status == "active" {
+			heldTypes[row.PassTypeID] = true
+		}
+		passes = append(passes, membertempl.SeasonPassUsageSummary{
+			ID:              row.SeasonPassID,
+			Name:            row.PassTypeName,
+			ValidFrom:       row.ValidFrom,
+			ValidUntil:      row.ValidUntil,
+			Status:          row.Status,
+			CoveredBookings: row.CoveredBookings,
+		})
+	}
+
+	passTypes, err := q.ListSeasonPassTypes(ctx, *user.HomeFacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load season pass types")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load season passes")
+		return
+	}
+
+	today := time.Now().In(memberFacilityLocation(ctx, h.loadFacilities(), *user.HomeFacilityID)).Format(models.SeasonPassDateLayout)
+	offers := make([]membertempl.SeasonPassOffer, 0, len(passTypes))
+	for _, passType := range passTypes {
+		if passType.Status != "active" || passType.ValidUntil < today || heldTypes[passType.ID] {
+			continue
+		}
+		windows, err := q.ListSeasonPassWindows(ctx, passType.ID)
+		if err != nil {
+			logger.Error().Err(err).Int64("pass_type_id", passType.ID).Msg("Failed to load season pass windows")
+			continue
+		}
+		offers = append(offers, membertempl.SeasonPassOffer{
+			ID:         passType.ID,
+			Name:       passType.Name,
+			PriceCents: passType.PriceCents,
+			ValidFrom:  passType.ValidFrom,
+			ValidUntil: passType.ValidUntil,
+			Windows:    describeSeasonPassWindows(windows),
+		})
+	}
+
+	component := membertempl.MemberSeasonPasses(membertempl.SeasonPassListData{Passes: passes, Offers: offers})
+	if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render season passes", "Failed to render season passes") {
+		return
+	}
+}
+
+// HandleMemberSeasonPassPurchase handles POST /member/season-passes.
+func (h *Handlers) HandleMemberSeasonPassPurchase(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodPost {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	var passTypeID int64
+	if apiutil.IsJSONRequest(r) {
+		var req struct {
+			PassTypeID int64 `json:"passTypeId"`
+		}
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+		passTypeID = req.PassTypeID
+	} else {
+		if err := r.ParseForm(); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+		raw := strings.TrimSpace(apiutil.FirstNonEmpty(r.FormValue("pass_type_id"), r.FormValue("passTypeId")))
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid pass type ID")
+			return
+		}
+		passTypeID = parsed
+	}
+	if passTypeID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid pass type ID")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	passType, err := q.GetSeasonPassType(ctx, dbgen.GetSeasonPassTypeParams{
+		ID:         passTypeID,
+		FacilityID: *user.HomeFacilityID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Season pass not found")
+			return
+		}
+		logger.Error().Err(err).Int64("pass_type_id", passTypeID).Msg("Failed to load season pass type")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load season pass")
+		return
+	}
+
+	usage, err := q.ListSeasonPassUsageForUser(ctx, user.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", user.ID).Msg("Failed to load season pass usage")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to purchase season pass")
+		return
+	}
+	for _, row := range usage {
+		if row.PassTypeID == passType.ID && row.Status == "active" {
+			apiutil.WriteError(w, r, http.StatusConflict, "You already hold this season pass")
+			return
+		}
+	}
+
+	created, err := models.IssueSeasonPass(ctx, q, passType, models.IssueSeasonPassParams{
+		UserID:         user.ID,
+		PricePaidCents: passType.PriceCents,
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrSeasonPassUnavailable) {
+			apiutil.WriteError(w, r, http.StatusConflict, "Season pass is no longer available")
+			return
+		}
+		logger.Error().Err(err).Int64("pass_type_id", passType.ID).Int64("member_id", user.ID).Msg("Failed to purchase season pass")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to purchase season pass")
+		return
+	}
+
+	apiutil.AppendHXTrigger(w, "refreshMemberSeasonPasses")
+	if err := apiutil.WriteJSON(w, http.StatusCreated, created); err != nil {
+		logger.Error().Err(err).Int64("season_pass_id", created.ID).Msg("Failed to write season pass response")
+		return
+	}
+}
+
+func memberFacilityLocation(ctx context.Context, facilities apiutil.FacilityQuerier, facilityID int64) *time.Location {
+	facility, err := facilities.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		return apiutil.DefaultLocation()
+	}
+	return apiutil.FacilityLocation(facility, log.Ctx(ctx))
+}
+
+func describeSeasonPassWindows(windows []dbgen.SeasonPassWindow) []string {
+	descriptions := make([]string, 0, len(windows))
+	for _, window := range windows {
+		day := "Unknown day"
+		if window.DayOfWeek >= 0 && window.DayOfWeek <= 6 {
+			day = time.Weekday(window.DayOfWeek).String()
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s %s-%s", day, window.StartsAt, window.EndsAt))
+	}
+	return descriptions
+}
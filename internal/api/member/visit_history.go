@@ -0,0 +1,55 @@
+package member
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+// visitExportTimeout covers streaming a full year of visits.
+const visitExportTimeout = 30 * time.Second
+
+// HandleMemberVisitsExport handles GET /member/visits/export?year=. It
+// downloads the member's paid visits for the year at every facility as CSV.
+func (h *Handlers) HandleMemberVisitsExport(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodGet {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	year, err := apiutil.ParseVisitExportYear(r.URL.Query().Get("year"), time.Now())
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), visitExportTimeout)
+	defer cancel()
+
+	apiutil.WriteVisitHistoryCSV(ctx, w, q, h.loadFacilities(), apiutil.VisitExport{
+		UserID:   user.ID,
+		Year:     year,
+		Filename: fmt.Sprintf("visits_%d.csv", year),
+	}, logger)
+}
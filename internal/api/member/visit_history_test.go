@@ -0,0 +1,114 @@
+package member
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestHandleMemberVisitsExport_WritesPaidVisitsInFacilityTime(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main Club', 'main', 'America/New_York')", orgID)
+	courtID := exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 3', 3, 'active')", facilityID)
+	memberID := exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, membership_level, home_facility_id)
+		 VALUES ('Vera', 'Visitor', 'vera@test.com', 'active', 1, 1, ?)`,
+		facilityID,
+	)
+	packTypeID := exec("INSERT INTO visit_pack_types (facility_id, name, price_cents, visit_count, valid_days) VALUES (?, 'Ten Visits', 10000, 10, 365)", facilityID)
+	packID := exec(
+		"INSERT INTO visit_packs (pack_type_id, user_id, purchase_date, expires_at, visits_remaining) VALUES (?, ?, ?, ?, 8)",
+		packTypeID, memberID, time.Date(2025, time.January, 5, 0, 0, 0, 0, time.UTC), time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC),
+	)
+
+	insertReservation := func(start time.Time) int64 {
+		t.Helper()
+		reservationID := exec(
+			`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+			 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?, ?)`,
+			facilityID, memberID, memberID, start, start.Add(time.Hour),
+		)
+		exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", reservationID, courtID)
+		return reservationID
+	}
+
+	// Paid with the pack.
+	packReservation := insertReservation(time.Date(2025, time.June, 1, 14, 0, 0, 0, time.UTC))
+	exec("INSERT INTO visit_pack_redemptions (visit_pack_id, facility_id, redeemed_at, reservation_id) VALUES (?, ?, ?, ?)",
+		packID, facilityID, time.Date(2025, time.May, 30, 12, 0, 0, 0, time.UTC), packReservation)
+	// New Year's Eve in New York, already January in UTC.
+	insertReservation(time.Date(2026, time.January, 1, 3, 0, 0, 0, time.UTC))
+	// A redemption at the front desk without a reservation.
+	exec("INSERT INTO visit_pack_redemptions (visit_pack_id, facility_id, redeemed_at) VALUES (?, ?, ?)",
+		packID, facilityID, time.Date(2025, time.March, 10, 16, 30, 0, 0, time.UTC))
+	// Cancelled and out-of-year reservations are not visits for 2025.
+	cancelledID := insertReservation(time.Date(2025, time.July, 4, 14, 0, 0, 0, time.UTC))
+	exec(`INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, fee_waived, hours_before_start)
+		 VALUES (?, ?, ?, 100, 0, 48)`, cancelledID, memberID, time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC))
+	insertReservation(time.Date(2024, time.December, 31, 15, 0, 0, 0, time.UTC))
+
+	h := NewHandlers(database, nil, Options{})
+
+	export := func(year string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/member/visits/export?year="+year, nil)
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:              memberID,
+			HomeFacilityID:  &facilityID,
+			MembershipLevel: 1,
+		}))
+		recorder := httptest.NewRecorder()
+		h.HandleMemberVisitsExport(recorder, req)
+		return recorder
+	}
+
+	if recorder := export("nineteen"); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected an invalid year to be rejected, got %d", recorder.Code)
+	}
+
+	recorder := export("2025")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if disposition := recorder.Header().Get("Content-Disposition"); !strings.Contains(disposition, "visits_2025.csv") {
+		t.Fatalf("unexpected Content-Disposition %q", disposition)
+	}
+	records, err := csv.NewReader(strings.NewReader(recorder.Body.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("parse CSV: %v", err)
+	}
+	want := [][]string{
+		{"Date", "Time", "Facility", "Reservation Type", "Court", "Source"},
+		{"2025-03-10", "12:30 EDT", "Main Club", "", "", fmt.Sprintf("Visit pack %d", packID)},
+		{"2025-06-01", "10:00 EDT", "Main Club", "GAME", "Court 3", fmt.Sprintf("Visit pack %d", packID)},
+		{"2025-12-31", "22:00 EST", "Main Club", "GAME", "Court 3", "Membership"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("expected %d rows, got %v", len(want), records)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Fatalf("row %d = %v, want %v", i, records[i], want[i])
+		}
+	}
+}
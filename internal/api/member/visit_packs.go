@@ -0,0 +1,120 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/models"
+	"github.com/codr1/Pickleicious/internal/payments"
+)
+
+// HandleMemberVisitPackPurchase handles POST /member/visit-packs/purchase.
+func (h *Handlers) HandleMemberVisitPackPurchase(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodPost {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	var packTypeID int64
+	if apiutil.IsJSONRequest(r) {
+		var req struct {
+			PackTypeID int64 `json:"packTypeId"`
+		}
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid JSON body")
+			return
+		}
+		packTypeID = req.PackTypeID
+	} else {
+		if err := r.ParseForm(); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+		raw := strings.TrimSpace(apiutil.FirstNonEmpty(r.FormValue("pack_type_id"), r.FormValue("packTypeId")))
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid visit pack ID")
+			return
+		}
+		packTypeID = parsed
+	}
+	if packTypeID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid visit pack ID")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	packType, err := q.GetVisitPackType(ctx, dbgen.GetVisitPackTypeParams{
+		ID:         packTypeID,
+		FacilityID: *user.HomeFacilityID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Visit pack not found")
+			return
+		}
+		logger.Error().Err(err).Int64("visit_pack_type_id", packTypeID).Msg("Failed to load visit pack type")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load visit pack")
+		return
+	}
+
+	result, err := models.PurchaseVisitPack(ctx, func(fn func(dbgen.Querier) error) error {
+		return database.RunInTx(ctx, func(txdb *appdb.DB) error {
+			return fn(txdb.Queries)
+		})
+	}, h.paymentProcessor, packType, models.PurchaseVisitPackParams{
+		UserID: user.ID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrVisitPackUnavailable):
+			apiutil.WriteError(w, r, http.StatusConflict, "Visit pack is no longer available")
+		case errors.Is(err, models.ErrPaymentsUnavailable):
+			apiutil.WriteError(w, r, http.StatusServiceUnavailable, "Online payments are not available")
+		case errors.Is(err, payments.ErrDeclined):
+			apiutil.WriteError(w, r, http.StatusPaymentRequired, "Payment was declined")
+		default:
+			logger.Error().Err(err).Int64("visit_pack_type_id", packType.ID).Int64("member_id", user.ID).Msg("Failed to purchase visit pack")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to purchase visit pack")
+		}
+		return
+	}
+
+	apiutil.AppendHXTrigger(w, "refreshMemberVisitPacks")
+	if err := apiutil.WriteJSON(w, http.StatusCreated, result); err != nil {
+		logger.Error().Err(err).Int64("visit_pack_id", result.VisitPack.ID).Msg("Failed to write visit pack purchase response")
+		return
+	}
+}
@@ -0,0 +1,150 @@
+package member
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/models"
+	"github.com/codr1/Pickleicious/internal/payments"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestHandleMemberVisitPackPurchase(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+		"Pack", "Buyer", "pack-buyer@test.com", "active", facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	packTypeResult, err := database.Exec(
+		"INSERT INTO visit_pack_types (facility_id, name, price_cents, visit_count, valid_days, status) VALUES (?, '10 Visits', 9000, 10, 90, 'active')",
+		facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert visit pack type: %v", err)
+	}
+	packTypeID, _ := packTypeResult.LastInsertId()
+
+	processor := payments.NewFakeProcessor()
+	h := NewHandlers(database, nil, Options{PaymentProcessor: processor})
+
+	purchase := func() *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/member/visit-packs/purchase", strings.NewReader(fmt.Sprintf("pack_type_id=%d", packTypeID)))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		homeFacilityID := facilityID
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:             memberID,
+			IsStaff:        false,
+			HomeFacilityID: &homeFacilityID,
+		}))
+		recorder := httptest.NewRecorder()
+		h.HandleMemberVisitPackPurchase(recorder, req)
+		return recorder
+	}
+	countPacks := func() (packs, paid int) {
+		t.Helper()
+		if err := database.QueryRow("SELECT COUNT(*) FROM visit_packs WHERE user_id = ?", memberID).Scan(&packs); err != nil {
+			t.Fatalf("count visit packs: %v", err)
+		}
+		if err := database.QueryRow("SELECT COUNT(*) FROM visit_pack_payments").Scan(&paid); err != nil {
+			t.Fatalf("count visit pack payments: %v", err)
+		}
+		return packs, paid
+	}
+
+	processor.Err = payments.ErrDeclined
+	if recorder := purchase(); recorder.Code != http.StatusPaymentRequired {
+		t.Fatalf("expected declined charge to return 402, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if packs, paid := countPacks(); packs != 0 || paid != 0 {
+		t.Fatalf("expected declined purchase to leave nothing behind, got packs=%d payments=%d", packs, paid)
+	}
+
+	processor.Err = nil
+	recorder := purchase()
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("purchase status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var result models.VisitPackPurchaseResult
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decode purchase: %v", err)
+	}
+	if result.VisitPack.VisitsRemaining != 10 || result.VisitPack.ExpiresAt.Sub(result.VisitPack.PurchaseDate).Round(time.Hour) != 90*24*time.Hour {
+		t.Fatalf("unexpected visit pack: %+v", result.VisitPack)
+	}
+	if result.Payment == nil || result.Payment.AmountCents != 9000 || result.Payment.Processor != "fake" || result.Payment.Reference != "fake_ch_1" {
+		t.Fatalf("unexpected payment: %+v", result.Payment)
+	}
+	if charges := processor.Charges(); len(charges) != 1 || charges[0].UserID != memberID || charges[0].FacilityID != facilityID {
+		t.Fatalf("unexpected charges: %+v", charges)
+	}
+	if packs, paid := countPacks(); packs != 1 || paid != 1 {
+		t.Fatalf("expected one paid pack, got packs=%d payments=%d", packs, paid)
+	}
+
+	// A charge whose pack cannot be recorded is voided.
+	if _, err := database.Exec(
+		"CREATE TRIGGER fail_visit_pack_payments BEFORE INSERT ON visit_pack_payments BEGIN SELECT RAISE(ABORT, 'payment insert failed'); END",
+	); err != nil {
+		t.Fatalf("create failing trigger: %v", err)
+	}
+	if recorder := purchase(); recorder.Code != http.StatusInternalServerError {
+		t.Fatalf("expected failed payment insert to return 500, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if voids := processor.Voids(); len(voids) != 1 || voids[0].Reference != "fake_ch_2" {
+		t.Fatalf("expected the second charge to be voided, got %+v", voids)
+	}
+	if packs, paid := countPacks(); packs != 1 || paid != 1 {
+		t.Fatalf("expected the failed purchase to leave no pack, got packs=%d payments=%d", packs, paid)
+	}
+	if _, err := database.Exec("DROP TRIGGER fail_visit_pack_payments"); err != nil {
+		t.Fatalf("drop failing trigger: %v", err)
+	}
+
+	h = NewHandlers(database, nil, Options{})
+	if recorder := purchase(); recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected missing processor to return 503, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if _, err := database.Exec("UPDATE visit_pack_types SET status = 'inactive' WHERE id = ?", packTypeID); err != nil {
+		t.Fatalf("deactivate visit pack type: %v", err)
+	}
+	h = NewHandlers(database, nil, Options{PaymentProcessor: processor})
+	if recorder := purchase(); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected inactive pack type to conflict, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if packs, _ := countPacks(); packs != 1 {
+		t.Fatalf("expected no additional packs, got %d", packs)
+	}
+}
@@ -0,0 +1,451 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/metrics"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+const (
+	waitlistNotificationBroadcast  = "broadcast"
+	waitlistNotificationSequential = "sequential"
+	waitlistOfferStatusPending     = "pending"
+	waitlistStatusPending          = "pending"
+	waitlistStatusNotified         = "notified"
+	waitlistStatusFulfilled        = "fulfilled"
+)
+
+// HandleMemberWaitlistOfferAccept handles POST /member/waitlist/offers/{id}/accept.
+func (h *Handlers) HandleMemberWaitlistOfferAccept(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodPost {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	offerID, err := memberWaitlistOfferIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid offer ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	offer, err := loadMemberWaitlistOffer(ctx, q, offerID, user.ID, *user.HomeFacilityID)
+	if err != nil {
+		writeWaitlistOfferError(w, r, logger, offerID, err)
+		return
+	}
+	now := time.Now()
+	if err := requirePendingWaitlistOffer(offer, now); err != nil {
+		writeWaitlistOfferError(w, r, logger, offerID, err)
+		return
+	}
+
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, offer.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", offer.FacilityID).Msg("Failed to load facility")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+
+	startTime, endTime, err := waitlistOfferSlot(offer, facilityLoc)
+	if err != nil {
+		logger.Error().Err(err).Int64("waitlist_id", offer.WaitlistID).Msg("Failed to parse waitlist slot")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load waitlist slot")
+		return
+	}
+	if !startTime.After(now) {
+		apiutil.WriteError(w, r, http.StatusGone, "This slot has already started")
+		return
+	}
+
+	config, err := loadMemberWaitlistConfig(ctx, q, offer.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", offer.FacilityID).Msg("Failed to load waitlist config")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load waitlist config")
+		return
+	}
+
+	reservationType, err := lookupMemberReservationType(ctx, q, offer.FacilityID, memberReservationTypeName)
+	if err != nil {
+		writeMemberReservationTypeError(w, r, logger, memberReservationTypeName, err)
+		return
+	}
+
+	tier, err := apiutil.LoadMembershipTier(ctx, q, facility.OrganizationID, user.MembershipLevel)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", facility.OrganizationID).Int64("membership_level", user.MembershipLevel).Msg("Failed to load membership tier")
+		writeFacilityConfigUnavailable(w, r)
+		return
+	}
+	maxMemberReservations := apiutil.MemberReservationLimit(tier, facility.MaxMemberReservations)
+
+	var created dbgen.Reservation
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		// Re-read inside the transaction so a double-submitted accept cannot
+		// book the slot twice.
+		current, err := loadMemberWaitlistOffer(ctx, qtx, offerID, user.ID, *user.HomeFacilityID)
+		if err != nil {
+			return err
+		}
+		if err := requirePendingWaitlistOffer(current, now); err != nil {
+			return err
+		}
+
+		if maxMemberReservations > 0 && reservationType.CountsTowardMemberLimit {
+			activeCount, err := reservationsvc.CountActiveReservations(ctx, qtx, offer.FacilityID, user.ID, facility.ReservationLimitScope)
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check reservation limits", Err: err}
+			}
+			if activeCount >= maxMemberReservations {
+				return reservationLimitError{currentCount: activeCount, limit: maxMemberReservations}
+			}
+		}
+
+		courtID, err := waitlistOfferCourt(ctx, qtx, offer, startTime, endTime)
+		if err != nil {
+			return err
+		}
+		if err := apiutil.EnsureCourtsAvailable(ctx, qtx, offer.FacilityID, 0, startTime, endTime, []int64{courtID}); err != nil {
+			var availErr apiutil.AvailabilityError
+			if errors.As(err, &availErr) {
+				return apiutil.HandlerError{Status: http.StatusConflict, Message: "This slot is no longer available", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check court availability", Err: err}
+		}
+
+		created, err = qtx.CreateReservation(ctx, dbgen.CreateReservationParams{
+			FacilityID:        offer.FacilityID,
+			ReservationTypeID: reservationType.ID,
+			RecurrenceRuleID:  sql.NullInt64{},
+			PrimaryUserID:     sql.NullInt64{Int64: user.ID, Valid: true},
+			CreatedByUserID:   user.ID,
+			ProID:             sql.NullInt64{},
+			OpenPlayRuleID:    sql.NullInt64{},
+			StartTime:         startTime,
+			EndTime:           endTime,
+			IsOpenEvent:       false,
+			TeamsPerCourt:     sql.NullInt64{},
+			PeoplePerTeam:     sql.NullInt64{},
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to create reservation", Err: err}
+		}
+
+		if err := qtx.AddReservationCourt(ctx, dbgen.AddReservationCourtParams{
+			ReservationID: created.ID,
+			CourtID:       courtID,
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to assign reservation court", Err: err}
+		}
+
+		if err := qtx.AddParticipant(ctx, dbgen.AddParticipantParams{
+			ReservationID: created.ID,
+			UserID:        user.ID,
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation participant", Err: err}
+		}
+		if _, err := reservationsvc.PriceCourtBooking(ctx, qtx, facilityLoc, created, 1, false); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to price reservation", Err: err}
+		}
+
+		if _, err := qtx.AcceptOffer(ctx, dbgen.AcceptOfferParams{
+			ID:         offer.OfferID,
+			WaitlistID: offer.WaitlistID,
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to accept offer", Err: err}
+		}
+		fulfilled, err := qtx.UpdateWaitlistStatus(ctx, dbgen.UpdateWaitlistStatusParams{
+			ID:         offer.WaitlistID,
+			FacilityID: offer.FacilityID,
+			Status:     waitlistStatusFulfilled,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update waitlist entry", Err: err}
+		}
+		if err := reservationsvc.ReorderWaitlistPositions(ctx, qtx, fulfilled); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update waitlist positions", Err: err}
+		}
+
+		if config.NotificationMode == waitlistNotificationSequential {
+			// The slot is taken, so anyone else holding an offer for it goes
+			// back to waiting rather than racing for a booking that will fail.
+			siblings, err := qtx.ExpireSiblingWaitlistOffers(ctx, offer.WaitlistID)
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to expire waitlist offers", Err: err}
+			}
+			for _, sibling := range siblings {
+				if _, err := qtx.UpdateWaitlistStatus(ctx, dbgen.UpdateWaitlistStatusParams{
+					ID:         sibling.WaitlistID,
+					FacilityID: offer.FacilityID,
+					Status:     waitlistStatusPending,
+				}); err != nil {
+					return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update waitlist entry", Err: err}
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		var limitErr reservationLimitError
+		if errors.As(err, &limitErr) {
+			writeReservationLimitError(w, r, limitErr.currentCount, limitErr.limit)
+			return
+		}
+		writeWaitlistOfferError(w, r, logger, offerID, err)
+		return
+	}
+	metrics.ReservationCreated(created.FacilityID, reservationType.Name)
+	notifyDeskOfWaitlistBooking(ctx, q, created, user.ID)
+
+	response, err := reservationsvc.WithPrice(ctx, q, created)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", created.ID).Msg("Failed to load reservation price")
+		response = reservationsvc.PricedReservation{Reservation: created}
+	}
+	apiutil.AppendHXTrigger(w, "refreshMemberReservations", "refreshMemberWaitlist")
+	if err := apiutil.WriteJSON(w, http.StatusCreated, response); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", created.ID).Msg("Failed to write reservation response")
+		return
+	}
+}
+
+// notifyDeskOfWaitlistBooking leaves the facility's staff an untargeted
+// notification that a waitlist offer turned into a booking, so the desk
+// knows the slot is taken. Failing to create it does not undo the booking.
+func notifyDeskOfWaitlistBooking(ctx context.Context, q *dbgen.Queries, reservation dbgen.Reservation, memberID int64) {
+	logger := log.Ctx(ctx)
+
+	memberName := "Member"
+	if member, err := q.GetMemberByID(ctx, memberID); err == nil {
+		if name := strings.TrimSpace(member.FirstName + " " + member.LastName); name != "" {
+			memberName = name
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to load member for waitlist notification")
+	}
+
+	message := fmt.Sprintf(
+		"Waitlist slot booked: %s (%s - %s)",
+		memberName,
+		reservation.StartTime.Format("2006-01-02 15:04"),
+		reservation.EndTime.Format("2006-01-02 15:04"),
+	)
+	if _, err := q.CreateWaitlistFulfilledNotification(ctx, dbgen.CreateWaitlistFulfilledNotificationParams{
+		FacilityID:           reservation.FacilityID,
+		Message:              message,
+		RelatedReservationID: sql.NullInt64{Int64: reservation.ID, Valid: true},
+	}); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to notify staff about waitlist booking")
+	}
+}
+
+// HandleMemberWaitlistOfferDecline handles POST /member/waitlist/offers/{id}/decline.
+func (h *Handlers) HandleMemberWaitlistOfferDecline(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if r.Method != http.MethodPost {
+		apiutil.WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	service := h.loadService()
+	if service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	offerID, err := memberWaitlistOfferIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid offer ID")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+	if user.HomeFacilityID == nil {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Home facility is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), portalQueryTimeout)
+	defer cancel()
+
+	// The service advances a sequential queue and notifies the next member
+	// the same way the expiry sweep does.
+	if err := service.DeclineWaitlistOffer(ctx, reservationsvc.DeclineWaitlistOfferInput{
+		OfferID:    offerID,
+		UserID:     user.ID,
+		FacilityID: *user.HomeFacilityID,
+	}, time.Now()); err != nil {
+		writeWaitlistOfferError(w, r, logger, offerID, err)
+		return
+	}
+
+	apiutil.AppendHXTrigger(w, "refreshMemberWaitlist")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadMemberWaitlistOffer loads an offer belonging to the member's waitlist
+// entry at their home facility. Offers for anyone else are reported as not
+// found.
+func loadMemberWaitlistOffer(ctx context.Context, q *dbgen.Queries, offerID, userID, facilityID int64) (dbgen.GetWaitlistOfferForUserRow, error) {
+	offer, err := q.GetWaitlistOfferForUser(ctx, dbgen.GetWaitlistOfferForUserParams{
+		ID:     offerID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbgen.GetWaitlistOfferForUserRow{}, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Offer not found", Err: err}
+		}
+		return dbgen.GetWaitlistOfferForUserRow{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load offer", Err: err}
+	}
+	if offer.FacilityID != facilityID {
+		return dbgen.GetWaitlistOfferForUserRow{}, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Offer not found"}
+	}
+	return offer, nil
+}
+
+func requirePendingWaitlistOffer(offer dbgen.GetWaitlistOfferForUserRow, now time.Time) error {
+	if offer.OfferStatus != waitlistOfferStatusPending {
+		return apiutil.HandlerError{Status: http.StatusConflict, Message: "This offer is no longer available"}
+	}
+	if !offer.ExpiresAt.After(now) {
+		return apiutil.HandlerError{Status: http.StatusGone, Message: "This offer has expired"}
+	}
+	return nil
+}
+
+// waitlistOfferSlot returns the waitlisted slot as times in the facility's
+// time zone.
+func waitlistOfferSlot(offer dbgen.GetWaitlistOfferForUserRow, loc *time.Location) (time.Time, time.Time, error) {
+	day := time.Date(offer.TargetDate.Year(), offer.TargetDate.Month(), offer.TargetDate.Day(), 0, 0, 0, 0, loc)
+	startTime, err := waitlistDateTime(day, offer.TargetStartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	endTime, err := waitlistDateTime(day, offer.TargetEndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return startTime, endTime, nil
+}
+
+// waitlistOfferCourt picks the court to book: the waitlisted court if the
+// member asked for one, otherwise the first court free for the slot.
+func waitlistOfferCourt(ctx context.Context, q *dbgen.Queries, offer dbgen.GetWaitlistOfferForUserRow, startTime, endTime time.Time) (int64, error) {
+	if offer.TargetCourtID.Valid {
+		return offer.TargetCourtID.Int64, nil
+	}
+	available, err := q.ListAvailableCourts(ctx, dbgen.ListAvailableCourtsParams{
+		FacilityID: offer.FacilityID,
+		StartTime:  startTime,
+		EndTime:    endTime,
+	})
+	if err != nil {
+		return 0, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check court availability", Err: err}
+	}
+	if len(available) == 0 {
+		return 0, apiutil.HandlerError{Status: http.StatusConflict, Message: "This slot is no longer available"}
+	}
+	return available[0].ID, nil
+}
+
+func loadMemberWaitlistConfig(ctx context.Context, q *dbgen.Queries, facilityID int64) (dbgen.WaitlistConfig, error) {
+	config, err := q.GetWaitlistConfig(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbgen.WaitlistConfig{
+				FacilityID:       facilityID,
+				NotificationMode: waitlistNotificationBroadcast,
+			}, nil
+		}
+		return dbgen.WaitlistConfig{}, err
+	}
+	config.NotificationMode = strings.ToLower(strings.TrimSpace(config.NotificationMode))
+	if config.NotificationMode == "" {
+		config.NotificationMode = waitlistNotificationBroadcast
+	}
+	return config, nil
+}
+
+func writeWaitlistOfferError(w http.ResponseWriter, r *http.Request, logger *zerolog.Logger, offerID int64, err error) {
+	var herr apiutil.HandlerError
+	if errors.As(err, &herr) {
+		if herr.Status >= http.StatusInternalServerError {
+			logger.Error().Err(herr.Err).Int64("offer_id", offerID).Msg(herr.Message)
+		}
+		apiutil.WriteHandlerError(w, r, herr)
+		return
+	}
+	logger.Error().Err(err).Int64("offer_id", offerID).Msg("Failed to update waitlist offer")
+	apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update waitlist offer")
+}
+
+func memberWaitlistOfferIDFromRequest(r *http.Request) (int64, error) {
+	pathID := strings.TrimSpace(r.PathValue("id"))
+	if pathID == "" {
+		return 0, fmt.Errorf("invalid offer ID")
+	}
+	id, err := strconv.ParseInt(pathID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid offer ID")
+	}
+	return id, nil
+}
+
+func memberWaitlistIDFromRequest(r *http.Request) (int64, error) {
+	pathID := strings.TrimSpace(r.PathValue("id"))
+	if pathID == "" {
+		return 0, fmt.Errorf("invalid waitlist ID")
+	}
+	id, err := strconv.ParseInt(pathID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid waitlist ID")
+	}
+	return id, nil
+}
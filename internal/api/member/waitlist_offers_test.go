@@ -0,0 +1,319 @@
+package member
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type waitlistOfferFixture struct {
+	h          *Handlers
+	database   *db.DB
+	facilityID int64
+	slotDate   time.Time
+}
+
+func setupWaitlistOfferTest(t *testing.T) waitlistOfferFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	facilityResult, err := database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	if _, err := database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+		facilityID, "Court 1", 1, "active",
+	); err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+
+	if _, err := database.Exec(
+		`INSERT INTO waitlist_config (facility_id, max_waitlist_size, notification_mode, offer_expiry_minutes, notification_window_minutes)
+		 VALUES (?, 10, 'sequential', 30, 0)`,
+		facilityID,
+	); err != nil {
+		t.Fatalf("insert waitlist config: %v", err)
+	}
+
+	now := time.Now().UTC()
+	return waitlistOfferFixture{
+		h:          NewHandlers(database, nil, Options{}),
+		database:   database,
+		facilityID: facilityID,
+		slotDate:   time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (f waitlistOfferFixture) insertMember(t *testing.T, email string) int64 {
+	t.Helper()
+	result, err := f.database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+		"Wait", "Lister", email, "active", f.facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	id, _ := result.LastInsertId()
+	return id
+}
+
+func (f waitlistOfferFixture) insertWaitlist(t *testing.T, userID, position int64, status string) int64 {
+	t.Helper()
+	result, err := f.database.Exec(
+		`INSERT INTO waitlists (facility_id, user_id, target_date, target_start_time, target_end_time, position, status)
+		 VALUES (?, ?, ?, '10:00:00', '11:00:00', ?, ?)`,
+		f.facilityID, userID, f.slotDate, position, status,
+	)
+	if err != nil {
+		t.Fatalf("insert waitlist: %v", err)
+	}
+	id, _ := result.LastInsertId()
+	return id
+}
+
+func (f waitlistOfferFixture) insertOffer(t *testing.T, waitlistID int64, expiresAt time.Time) int64 {
+	t.Helper()
+	result, err := f.database.Exec(
+		"INSERT INTO waitlist_offers (waitlist_id, expires_at, status) VALUES (?, ?, 'pending')",
+		waitlistID, expiresAt,
+	)
+	if err != nil {
+		t.Fatalf("insert offer: %v", err)
+	}
+	id, _ := result.LastInsertId()
+	return id
+}
+
+func (f waitlistOfferFixture) statuses(t *testing.T, waitlistID int64) (string, string) {
+	t.Helper()
+	var waitlistStatus, offerStatus string
+	if err := f.database.QueryRow(
+		`SELECT w.status, wo.status FROM waitlists w JOIN waitlist_offers wo ON wo.waitlist_id = w.id
+		 WHERE w.id = ? ORDER BY wo.id DESC LIMIT 1`,
+		waitlistID,
+	).Scan(&waitlistStatus, &offerStatus); err != nil {
+		t.Fatalf("load statuses for waitlist %d: %v", waitlistID, err)
+	}
+	return waitlistStatus, offerStatus
+}
+
+func (f waitlistOfferFixture) position(t *testing.T, waitlistID int64) int64 {
+	t.Helper()
+	var position int64
+	if err := f.database.QueryRow("SELECT position FROM waitlists WHERE id = ?", waitlistID).Scan(&position); err != nil {
+		t.Fatalf("load position for waitlist %d: %v", waitlistID, err)
+	}
+	return position
+}
+
+func (f waitlistOfferFixture) withMember(req *http.Request, userID int64) *http.Request {
+	facilityID := f.facilityID
+	return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:              userID,
+		HomeFacilityID:  &facilityID,
+		MembershipLevel: 2,
+	}))
+}
+
+func (f waitlistOfferFixture) leave(userID, waitlistID int64) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/member/waitlist/%d", waitlistID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", waitlistID))
+	recorder := httptest.NewRecorder()
+	f.h.HandleMemberWaitlistLeave(recorder, f.withMember(req, userID))
+	return recorder
+}
+
+func (f waitlistOfferFixture) respond(t *testing.T, handler http.HandlerFunc, action string, userID, offerID int64) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/member/waitlist/offers/%d/%s", offerID, action), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", offerID))
+	recorder := httptest.NewRecorder()
+	handler(recorder, f.withMember(req, userID))
+	return recorder
+}
+
+func TestHandleMemberWaitlistOfferAccept_BooksSlotAndExpiresSiblings(t *testing.T) {
+	fixture := setupWaitlistOfferTest(t)
+
+	firstID := fixture.insertMember(t, "first@test.com")
+	secondID := fixture.insertMember(t, "second@test.com")
+	firstWaitlist := fixture.insertWaitlist(t, firstID, 1, "notified")
+	secondWaitlist := fixture.insertWaitlist(t, secondID, 2, "notified")
+	expiresAt := time.Now().Add(20 * time.Minute)
+	firstOffer := fixture.insertOffer(t, firstWaitlist, expiresAt)
+	fixture.insertOffer(t, secondWaitlist, expiresAt)
+
+	if recorder := fixture.respond(t, fixture.h.HandleMemberWaitlistOfferAccept, "accept", secondID, firstOffer); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected another member's offer to 404, got %d", recorder.Code)
+	}
+
+	recorder := fixture.respond(t, fixture.h.HandleMemberWaitlistOfferAccept, "accept", firstID, firstOffer)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if trigger := recorder.Header().Get("HX-Trigger"); !strings.Contains(trigger, "refreshMemberReservations") {
+		t.Fatalf("expected reservation refresh trigger, got %q", trigger)
+	}
+
+	var reservations int
+	if err := fixture.database.QueryRow(
+		"SELECT COUNT(*) FROM reservations WHERE primary_user_id = ? AND start_time = ?",
+		firstID, fixture.slotDate.Add(10*time.Hour),
+	).Scan(&reservations); err != nil {
+		t.Fatalf("count reservations: %v", err)
+	}
+	if reservations != 1 {
+		t.Fatalf("expected one reservation for the offered slot, got %d", reservations)
+	}
+
+	var deskNotifications int
+	if err := fixture.database.QueryRow(
+		"SELECT COUNT(*) FROM staff_notifications WHERE notification_type = 'waitlist_fulfilled' AND target_staff_id IS NULL",
+	).Scan(&deskNotifications); err != nil {
+		t.Fatalf("count staff notifications: %v", err)
+	}
+	if deskNotifications != 1 {
+		t.Fatalf("expected one desk notification for the booking, got %d", deskNotifications)
+	}
+
+	if waitlistStatus, offerStatus := fixture.statuses(t, firstWaitlist); waitlistStatus != "fulfilled" || offerStatus != "accepted" {
+		t.Fatalf("accepted entry = %s/%s, want fulfilled/accepted", waitlistStatus, offerStatus)
+	}
+	if waitlistStatus, offerStatus := fixture.statuses(t, secondWaitlist); waitlistStatus != "pending" || offerStatus != "expired" {
+		t.Fatalf("sibling entry = %s/%s, want pending/expired", waitlistStatus, offerStatus)
+	}
+
+	if recorder := fixture.respond(t, fixture.h.HandleMemberWaitlistOfferAccept, "accept", firstID, firstOffer); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected second accept to conflict, got %d", recorder.Code)
+	}
+}
+
+func TestHandleMemberWaitlistOfferAccept_RejectsExpiredOffer(t *testing.T) {
+	fixture := setupWaitlistOfferTest(t)
+
+	memberID := fixture.insertMember(t, "late@test.com")
+	waitlistID := fixture.insertWaitlist(t, memberID, 1, "notified")
+	offerID := fixture.insertOffer(t, waitlistID, time.Now().Add(-time.Minute))
+
+	if recorder := fixture.respond(t, fixture.h.HandleMemberWaitlistOfferAccept, "accept", memberID, offerID); recorder.Code != http.StatusGone {
+		t.Fatalf("expected expired offer to be rejected with 410, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleMemberWaitlistOfferDecline_AdvancesSequentialWaitlist(t *testing.T) {
+	fixture := setupWaitlistOfferTest(t)
+
+	firstID := fixture.insertMember(t, "first@test.com")
+	secondID := fixture.insertMember(t, "second@test.com")
+	firstWaitlist := fixture.insertWaitlist(t, firstID, 1, "notified")
+	secondWaitlist := fixture.insertWaitlist(t, secondID, 2, "pending")
+	offerID := fixture.insertOffer(t, firstWaitlist, time.Now().Add(20*time.Minute))
+
+	recorder := fixture.respond(t, fixture.h.HandleMemberWaitlistOfferDecline, "decline", firstID, offerID)
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if waitlistStatus, offerStatus := fixture.statuses(t, firstWaitlist); waitlistStatus != "expired" || offerStatus != "declined" {
+		t.Fatalf("declined entry = %s/%s, want expired/declined", waitlistStatus, offerStatus)
+	}
+	if waitlistStatus, offerStatus := fixture.statuses(t, secondWaitlist); waitlistStatus != "notified" || offerStatus != "pending" {
+		t.Fatalf("next entry = %s/%s, want notified/pending", waitlistStatus, offerStatus)
+	}
+	if position := fixture.position(t, secondWaitlist); position != 1 {
+		t.Fatalf("expected the next entry to move up to position 1, got %d", position)
+	}
+}
+
+func TestHandleMemberWaitlistLeave_ConcurrentLeavesKeepPositionsDense(t *testing.T) {
+	fixture := setupWaitlistOfferTest(t)
+
+	memberIDs := make([]int64, 4)
+	waitlistIDs := make([]int64, 4)
+	for i := range memberIDs {
+		memberIDs[i] = fixture.insertMember(t, fmt.Sprintf("member%d@test.com", i+1))
+		waitlistIDs[i] = fixture.insertWaitlist(t, memberIDs[i], int64(i+1), "pending")
+	}
+
+	if recorder := fixture.leave(memberIDs[1], waitlistIDs[0]); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected another member's entry to 404, got %d", recorder.Code)
+	}
+
+	leavers := []int{0, 2}
+	codes := make([]int, len(leavers))
+	var wg sync.WaitGroup
+	for i, leaver := range leavers {
+		wg.Add(1)
+		go func(i, leaver int) {
+			defer wg.Done()
+			codes[i] = fixture.leave(memberIDs[leaver], waitlistIDs[leaver]).Code
+		}(i, leaver)
+	}
+	wg.Wait()
+	for i, code := range codes {
+		if code != http.StatusNoContent {
+			t.Fatalf("leave %d: expected 204, got %d", leavers[i], code)
+		}
+	}
+
+	if position := fixture.position(t, waitlistIDs[1]); position != 1 {
+		t.Fatalf("expected the second member to move up to position 1, got %d", position)
+	}
+	if position := fixture.position(t, waitlistIDs[3]); position != 2 {
+		t.Fatalf("expected the fourth member to move up to position 2, got %d", position)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/member/waitlist", nil)
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleMemberWaitlistList(recorder, fixture.withMember(req, memberIDs[3]))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("list status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if body := recorder.Body.String(); !strings.Contains(body, "Position 2") {
+		t.Fatalf("expected the member list to show position 2, got %s", body)
+	}
+
+	// A member joining after the departures goes to the back of the dense queue.
+	newcomerID := fixture.insertMember(t, "newcomer@test.com")
+	newcomer, err := fixture.database.Queries.CreateWaitlistEntry(context.Background(), dbgen.CreateWaitlistEntryParams{
+		FacilityID:      fixture.facilityID,
+		UserID:          newcomerID,
+		TargetDate:      fixture.slotDate,
+		TargetStartTime: "10:00:00",
+		TargetEndTime:   "11:00:00",
+		Status:          "pending",
+	})
+	if err != nil {
+		t.Fatalf("join waitlist: %v", err)
+	}
+	if newcomer.Position != 3 {
+		t.Fatalf("expected the newcomer at position 3, got %d", newcomer.Position)
+	}
+}
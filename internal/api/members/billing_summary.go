@@ -0,0 +1,246 @@
+// internal/api/members/billing_summary.go
+package members
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	leaguecore "github.com/codr1/Pickleicious/internal/leagues"
+	"github.com/codr1/Pickleicious/internal/pricing"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/members"
+)
+
+// HandleMemberBillingSummary handles GET /api/v1/members/{id}/billing-summary
+// with the member's packs, credit, unpaid league fees and upcoming bookings
+// at their home facility. HTMX and text/html requests get the printable
+// partial; everything else gets JSON.
+func HandleMemberBillingSummary(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), membersQueryTimeout)
+	defer cancel()
+
+	member, ok := loadStaffMember(ctx, w, r, 2)
+	if !ok {
+		return
+	}
+	if !member.HomeFacilityID.Valid {
+		http.Error(w, "Member has no home facility", http.StatusBadRequest)
+		return
+	}
+	facility, err := queries.GetFacilityByID(ctx, member.HomeFacilityID.Int64)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", member.HomeFacilityID.Int64).Msg("Failed to load facility")
+		http.Error(w, "Failed to load facility", http.StatusInternalServerError)
+		return
+	}
+
+	summary, err := loadBillingSummary(ctx, queries, member, facility, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", member.ID).Int64("facility_id", facility.ID).Msg("Failed to load billing summary")
+		http.Error(w, "Failed to load billing summary", http.StatusInternalServerError)
+		return
+	}
+
+	acceptHeader := strings.ToLower(r.Header.Get("Accept"))
+	if apiutil.IsHTMXRequest(r) || (strings.Contains(acceptHeader, "text/html") && !strings.Contains(acceptHeader, "application/json")) {
+		component := membertempl.BillingSummaryPartial(summary)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render billing summary", "Failed to render billing summary")
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, summary); err != nil {
+		logger.Error().Err(err).Int64("member_id", member.ID).Msg("Failed to write billing summary response")
+		return
+	}
+}
+
+// loadBillingSummary gathers each section of the summary. A subsystem the
+// facility has not set up, such as pricing rules or league fees, leaves its
+// section empty.
+func loadBillingSummary(ctx context.Context, q *dbgen.Queries, member dbgen.User, facility dbgen.Facility, now time.Time) (membertempl.BillingSummary, error) {
+	loc := apiutil.FacilityLocation(facility, log.Ctx(ctx))
+	summary := membertempl.BillingSummary{
+		MemberID:             member.ID,
+		MemberName:           strings.TrimSpace(member.FirstName + " " + member.LastName),
+		FacilityID:           facility.ID,
+		FacilityName:         facility.Name,
+		GeneratedAt:          now.In(loc),
+		VisitPacks:           []membertempl.BillingVisitPack{},
+		LessonPackages:       []membertempl.BillingLessonPackage{},
+		AccountCredit:        membertempl.BillingAccountCredit{Credits: []membertempl.BillingCredit{}},
+		UnpaidLeagueFees:     []membertempl.BillingLeagueFee{},
+		UpcomingReservations: []membertempl.BillingReservation{},
+	}
+
+	packs, err := q.ListBillingSummaryVisitPacks(ctx, dbgen.ListBillingSummaryVisitPacksParams{
+		UserID:         member.ID,
+		FacilityID:     facility.ID,
+		ComparisonTime: now,
+	})
+	if err != nil {
+		return summary, err
+	}
+	for _, pack := range packs {
+		summary.VisitPacks = append(summary.VisitPacks, membertempl.BillingVisitPack{
+			ID:              pack.ID,
+			Name:            pack.PackName,
+			VisitsRemaining: pack.VisitsRemaining,
+			ExpiresAt:       pack.ExpiresAt.In(loc),
+		})
+		summary.Totals.VisitsRemaining += pack.VisitsRemaining
+	}
+
+	packages, err := q.ListBillingSummaryLessonPackages(ctx, dbgen.ListBillingSummaryLessonPackagesParams{
+		UserID:         member.ID,
+		FacilityID:     facility.ID,
+		ComparisonTime: now,
+	})
+	if err != nil {
+		return summary, err
+	}
+	for _, pkg := range packages {
+		summary.LessonPackages = append(summary.LessonPackages, membertempl.BillingLessonPackage{
+			ID:               pkg.ID,
+			Name:             pkg.PackageName,
+			LessonsRemaining: pkg.LessonsRemaining,
+			ExpiresAt:        pkg.ExpiresAt.In(loc),
+		})
+		summary.Totals.LessonsRemaining += pkg.LessonsRemaining
+	}
+
+	credits, err := q.ListSpendableAccountCredits(ctx, dbgen.ListSpendableAccountCreditsParams{
+		UserID:         member.ID,
+		FacilityID:     facility.ID,
+		ComparisonTime: now,
+	})
+	if err != nil {
+		return summary, err
+	}
+	for _, credit := range credits {
+		item := membertempl.BillingCredit{
+			ID:             credit.ID,
+			RemainingCents: credit.RemainingCents,
+			Reason:         credit.Reason,
+		}
+		if credit.ExpiresAt.Valid {
+			expiresAt := credit.ExpiresAt.Time.In(loc)
+			item.ExpiresAt = &expiresAt
+		}
+		summary.AccountCredit.Credits = append(summary.AccountCredit.Credits, item)
+		summary.AccountCredit.BalanceCents += credit.RemainingCents
+	}
+	summary.Totals.CreditBalanceCents = summary.AccountCredit.BalanceCents
+
+	teams, err := q.ListMemberLeagueTeams(ctx, dbgen.ListMemberLeagueTeamsParams{
+		FacilityID: facility.ID,
+		UserID:     member.ID,
+	})
+	if err != nil {
+		return summary, err
+	}
+	for _, team := range teams {
+		if team.RegistrationFeeCents <= 0 {
+			continue
+		}
+		if team.FeeBasis != leaguecore.FeeBasisPlayer && team.CaptainUserID != member.ID {
+			continue
+		}
+		payment, _, err := leaguecore.LoadTeamPayment(ctx, q, team.RegistrationFeeCents, team.FeeBasis, team.TeamID)
+		if err != nil {
+			return summary, err
+		}
+		outstanding := payment.OutstandingCents
+		if team.FeeBasis == leaguecore.FeeBasisPlayer {
+			outstanding = 0
+			for _, player := range payment.Players {
+				if player.UserID == member.ID {
+					outstanding = player.OutstandingCents
+				}
+			}
+		}
+		if outstanding <= 0 {
+			continue
+		}
+		summary.UnpaidLeagueFees = append(summary.UnpaidLeagueFees, membertempl.BillingLeagueFee{
+			LeagueID:         team.LeagueID,
+			LeagueName:       team.LeagueName,
+			TeamID:           team.TeamID,
+			TeamName:         team.TeamName,
+			FeeBasis:         team.FeeBasis,
+			OutstandingCents: outstanding,
+		})
+		summary.Totals.LeagueFeesOutstandingCents += outstanding
+	}
+
+	reservations, err := q.ListBillingSummaryReservations(ctx, dbgen.ListBillingSummaryReservationsParams{
+		PrimaryUserID:  member.ID,
+		FacilityID:     facility.ID,
+		ComparisonTime: now,
+	})
+	if err != nil {
+		return summary, err
+	}
+	// Bookings without a stored price are quoted under today's rules, loaded
+	// once and only when needed.
+	var rules []dbgen.CourtPricingRule
+	rulesLoaded := false
+	for _, reservation := range reservations {
+		item := membertempl.BillingReservation{
+			ID:                 reservation.ID,
+			StartTime:          reservation.StartTime.In(loc),
+			EndTime:            reservation.EndTime.In(loc),
+			ReservationType:    reservation.ReservationTypeName,
+			CourtCount:         reservation.CourtCount,
+			PriceSource:        membertempl.BillingPriceUnpriced,
+			CreditAppliedCents: reservation.CreditAppliedCents,
+		}
+		if reservation.PriceCents.Valid {
+			price := reservation.PriceCents.Int64
+			item.PriceSource = membertempl.BillingPriceStored
+			item.PriceCents = &price
+			item.MemberRate = reservation.MemberRate.Bool
+		} else {
+			if !rulesLoaded {
+				rules, err = q.ListCourtPricingRules(ctx, facility.ID)
+				if err != nil {
+					return summary, err
+				}
+				rulesLoaded = true
+			}
+			if len(rules) > 0 {
+				quote := pricing.Price(rules, pricing.Request{
+					Start:  reservation.StartTime,
+					End:    reservation.EndTime,
+					Courts: int(reservation.CourtCount),
+					Member: member.MembershipLevel >= pricing.MemberLevel,
+				})
+				if quote.Priced {
+					price := quote.AmountCents
+					item.PriceSource = membertempl.BillingPriceQuoted
+					item.PriceCents = &price
+					item.MemberRate = quote.Member
+				}
+			}
+		}
+		if item.PriceCents != nil {
+			due := max(*item.PriceCents-item.CreditAppliedCents, 0)
+			item.AmountDueCents = &due
+			summary.Totals.ReservationsDueCents += due
+		}
+		summary.UpcomingReservations = append(summary.UpcomingReservations, item)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,122 @@
+package members
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/members"
+)
+
+func TestMemberBillingSummary(t *testing.T) {
+	fixture := setupHomeFacilityTest(t, false)
+	staffID := fixture.insertStaff(t, "desk", fixture.oldFacility)
+
+	call := func(accept string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/members/%d/billing-summary", fixture.memberID), nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: staffID, IsStaff: true, HomeFacilityID: &fixture.oldFacility}))
+		recorder := httptest.NewRecorder()
+		HandleMemberBillingSummary(recorder, req)
+		return recorder
+	}
+	load := func() (membertempl.BillingSummary, string) {
+		t.Helper()
+		recorder := call("application/json")
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var summary membertempl.BillingSummary
+		if err := json.Unmarshal(recorder.Body.Bytes(), &summary); err != nil {
+			t.Fatalf("decode summary: %v", err)
+		}
+		return summary, recorder.Body.String()
+	}
+
+	// Nothing but a visit pack and an unpriced booking: the other sections
+	// come back empty.
+	summary, body := load()
+	if summary.GeneratedAt.IsZero() || summary.FacilityID != fixture.oldFacility {
+		t.Fatalf("expected a timestamped summary for the home facility, got %+v", summary)
+	}
+	if len(summary.VisitPacks) != 1 || summary.VisitPacks[0].Name != "Ten Visits" || summary.Totals.VisitsRemaining != 10 {
+		t.Fatalf("expected the ten-visit pack, got %+v", summary.VisitPacks)
+	}
+	for _, empty := range []string{`"lessonPackages":[]`, `"credits":[]`, `"unpaidLeagueFees":[]`} {
+		if !strings.Contains(body, empty) {
+			t.Fatalf("expected %s in %s", empty, body)
+		}
+	}
+	if len(summary.UpcomingReservations) != 1 {
+		t.Fatalf("expected the upcoming booking, got %+v", summary.UpcomingReservations)
+	}
+	if reservation := summary.UpcomingReservations[0]; reservation.PriceSource != membertempl.BillingPriceUnpriced || reservation.PriceCents != nil {
+		t.Fatalf("expected an unpriced booking without pricing rules, got %+v", reservation)
+	}
+
+	// Pricing rules quote a booking that was not priced when it was made.
+	var start time.Time
+	if err := fixture.database.QueryRow("SELECT start_time FROM reservations WHERE id = ?", fixture.reservationID).Scan(&start); err != nil {
+		t.Fatalf("load reservation: %v", err)
+	}
+	if _, err := fixture.database.Exec(
+		`INSERT INTO court_pricing_rules (facility_id, day_of_week, start_time, end_time, member_price_per_hour_cents, non_member_price_per_hour_cents)
+		 VALUES (?, ?, '00:00', '23:59', 2000, 3000)`,
+		fixture.oldFacility, int(start.UTC().Weekday()),
+	); err != nil {
+		t.Fatalf("insert pricing rule: %v", err)
+	}
+	courtResult, err := fixture.database.Exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 1', 1, 'active')", fixture.oldFacility)
+	if err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+	courtID, _ := courtResult.LastInsertId()
+	if _, err := fixture.database.Exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", fixture.reservationID, courtID); err != nil {
+		t.Fatalf("insert reservation court: %v", err)
+	}
+	summary, _ = load()
+	if reservation := summary.UpcomingReservations[0]; reservation.PriceSource != membertempl.BillingPriceQuoted || reservation.PriceCents == nil || *reservation.PriceCents <= 0 || reservation.MemberRate {
+		t.Fatalf("expected a non-member quote, got %+v", reservation)
+	}
+
+	// A stored price wins over the quote, and spendable credit is listed.
+	if _, err := fixture.database.Exec("INSERT INTO reservation_prices (reservation_id, amount_cents, member_rate) VALUES (?, 3500, 0)", fixture.reservationID); err != nil {
+		t.Fatalf("insert reservation price: %v", err)
+	}
+	if _, err := fixture.database.Exec(
+		"INSERT INTO account_credits (user_id, facility_id, amount_cents, remaining_cents, reason, issued_by_user_id) VALUES (?, ?, 1000, 1000, 'Goodwill', ?)",
+		fixture.memberID, fixture.oldFacility, staffID,
+	); err != nil {
+		t.Fatalf("insert account credit: %v", err)
+	}
+	summary, _ = load()
+	reservation := summary.UpcomingReservations[0]
+	if reservation.PriceSource != membertempl.BillingPriceStored || *reservation.PriceCents != 3500 || *reservation.AmountDueCents != 3500 {
+		t.Fatalf("expected the stored $35.00 price, got %+v", reservation)
+	}
+	if summary.AccountCredit.BalanceCents != 1000 || len(summary.AccountCredit.Credits) != 1 || summary.Totals.ReservationsDueCents != 3500 {
+		t.Fatalf("unexpected credit and totals: %+v %+v", summary.AccountCredit, summary.Totals)
+	}
+
+	recorder := call("text/html")
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "Billing Summary") || !strings.Contains(recorder.Body.String(), "$35.00") {
+		t.Fatalf("expected the printable partial, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if _, err := fixture.database.Exec("UPDATE users SET home_facility_id = NULL WHERE id = ?", fixture.memberID); err != nil {
+		t.Fatalf("clear home facility: %v", err)
+	}
+	if recorder := call("application/json"); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without a home facility, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,173 @@
+// internal/api/members/credits.go
+package members
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+// maxCreditReasonLength caps the reason staff give for a manual credit.
+const maxCreditReasonLength = 500
+
+type memberCreditRequest struct {
+	AmountCents int64  `json:"amountCents"`
+	Reason      string `json:"reason"`
+	ExpiresAt   string `json:"expiresAt"`
+	FacilityID  *int64 `json:"facilityId"`
+}
+
+// HandleMemberCreditsGet handles GET /api/v1/members/{id}/credits with the
+// member's account credit at their home facility, or at ?facility_id=.
+func HandleMemberCreditsGet(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	var requested *int64
+	if raw := strings.TrimSpace(r.URL.Query().Get("facility_id")); raw != "" {
+		facilityID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || facilityID <= 0 {
+			http.Error(w, "Invalid facility_id", http.StatusBadRequest)
+			return
+		}
+		requested = &facilityID
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), membersQueryTimeout)
+	defer cancel()
+
+	member, facilityID, ok := loadCreditMember(ctx, w, r, requested)
+	if !ok {
+		return
+	}
+
+	account, err := reservationsvc.LoadCreditAccount(ctx, queries, member.ID, facilityID, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", member.ID).Int64("facility_id", facilityID).Msg("Failed to load account credit")
+		http.Error(w, "Failed to load account credit", http.StatusInternalServerError)
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, account); err != nil {
+		logger.Error().Err(err).Int64("member_id", member.ID).Msg("Failed to write account credit response")
+		return
+	}
+}
+
+// HandleMemberCreditIssue handles POST /api/v1/members/{id}/credits, adding a
+// manual credit to the member's account with the reason staff give.
+func HandleMemberCreditIssue(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil || store == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	var req memberCreditRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.AmountCents <= 0 {
+		http.Error(w, "amountCents must be a positive integer", http.StatusBadRequest)
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		http.Error(w, "reason is required", http.StatusBadRequest)
+		return
+	}
+	if len(reason) > maxCreditReasonLength {
+		http.Error(w, "reason is too long", http.StatusBadRequest)
+		return
+	}
+	var expiresAt *time.Time
+	if raw := strings.TrimSpace(req.ExpiresAt); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			http.Error(w, "expiresAt must be an RFC 3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		if !parsed.After(time.Now()) {
+			http.Error(w, "expiresAt must be in the future", http.StatusBadRequest)
+			return
+		}
+		parsed = parsed.UTC()
+		expiresAt = &parsed
+	}
+	if req.FacilityID != nil && *req.FacilityID <= 0 {
+		http.Error(w, "facilityId must be a positive integer", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), membersQueryTimeout)
+	defer cancel()
+
+	member, facilityID, ok := loadCreditMember(ctx, w, r, req.FacilityID)
+	if !ok {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+
+	var credit dbgen.AccountCredit
+	err := store.RunInTx(ctx, func(txdb *appdb.DB) error {
+		var err error
+		credit, err = reservationsvc.IssueCredit(ctx, txdb.Queries, member.ID, facilityID, req.AmountCents, nil, reason, user.ID, expiresAt)
+		return err
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", member.ID).Int64("facility_id", facilityID).Msg("Failed to issue account credit")
+		http.Error(w, "Failed to issue account credit", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info().
+		Int64("member_id", member.ID).
+		Int64("facility_id", facilityID).
+		Int64("credit_id", credit.ID).
+		Int64("amount_cents", credit.AmountCents).
+		Int64("issued_by_user_id", user.ID).
+		Msg("Account credit issued")
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, map[string]any{"credit": credit}); err != nil {
+		logger.Error().Err(err).Int64("member_id", member.ID).Msg("Failed to write account credit response")
+		return
+	}
+}
+
+// loadCreditMember resolves the member from a /credits path and the facility
+// whose credit to use, defaulting to the member's home facility. It writes
+// the error response when ok is false.
+func loadCreditMember(ctx context.Context, w http.ResponseWriter, r *http.Request, requested *int64) (dbgen.User, int64, bool) {
+	member, ok := loadStaffMember(ctx, w, r, 2)
+	if !ok {
+		return dbgen.User{}, 0, false
+	}
+	if requested == nil {
+		if !member.HomeFacilityID.Valid {
+			http.Error(w, "Member has no home facility; facility is required", http.StatusBadRequest)
+			return dbgen.User{}, 0, false
+		}
+		return member, member.HomeFacilityID.Int64, true
+	}
+	if !apiutil.RequireFacilityAccess(w, r, *requested) {
+		return dbgen.User{}, 0, false
+	}
+	return member, *requested, true
+}
@@ -0,0 +1,62 @@
+package members
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+func TestMemberCredits_StaffIssueAndList(t *testing.T) {
+	fixture := setupHomeFacilityTest(t, false)
+	staffID := fixture.insertStaff(t, "desk", fixture.oldFacility)
+
+	call := func(handler http.HandlerFunc, method, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, fmt.Sprintf("/api/v1/members/%d/credits", fixture.memberID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: staffID, IsStaff: true, HomeFacilityID: &fixture.oldFacility}))
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+
+	for _, body := range []string{
+		`{"amountCents": 0, "reason": "Goodwill"}`,
+		`{"amountCents": 500}`,
+		`{"amountCents": 500, "reason": "Goodwill", "expiresAt": "next week"}`,
+		`{"amountCents": 500, "reason": "Goodwill", "expiresAt": "2001-01-01T00:00:00Z"}`,
+	} {
+		if recorder := call(HandleMemberCreditIssue, http.MethodPost, body); recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected %s to be rejected, got %d", body, recorder.Code)
+		}
+	}
+
+	recorder := call(HandleMemberCreditIssue, http.MethodPost, `{"amountCents": 1500, "reason": "Court lights failed"}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("issue status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = call(HandleMemberCreditsGet, http.MethodGet, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("list status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var account reservationsvc.CreditAccount
+	if err := json.Unmarshal(recorder.Body.Bytes(), &account); err != nil {
+		t.Fatalf("decode credits: %v", err)
+	}
+	if account.BalanceCents != 1500 || len(account.Credits) != 1 {
+		t.Fatalf("expected one $15.00 credit, got %+v", account)
+	}
+	credit := account.Credits[0]
+	if credit.FacilityID != fixture.oldFacility || credit.IssuedByUserID != staffID || credit.Reason != "Court lights failed" || credit.SourceReservationID.Valid {
+		t.Fatalf("expected a manual credit at the home facility stamped with the issuer, got %+v", credit)
+	}
+}
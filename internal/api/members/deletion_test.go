@@ -0,0 +1,62 @@
+package members
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func TestHandleDeleteMember_SoftDeletesAndReleasesBookings(t *testing.T) {
+	fixture := setupHomeFacilityTest(t, false)
+
+	deleteMember := func(staffUserID, homeFacilityID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/members/%d", fixture.memberID), nil)
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:             staffUserID,
+			IsStaff:        true,
+			HomeFacilityID: &homeFacilityID,
+		}))
+		recorder := httptest.NewRecorder()
+		HandleDeleteMember(recorder, req)
+		return recorder
+	}
+
+	otherStaff := fixture.insertStaff(t, "desk", fixture.newFacility)
+	if recorder := deleteMember(otherStaff, fixture.newFacility); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected staff at another facility to be forbidden, got %d", recorder.Code)
+	}
+
+	staffUserID := fixture.insertStaff(t, "desk", fixture.oldFacility)
+	if recorder := deleteMember(staffUserID, fixture.oldFacility); recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var status string
+	var deletedAt any
+	if err := fixture.database.QueryRow("SELECT status, deleted_at FROM users WHERE id = ?", fixture.memberID).Scan(&status, &deletedAt); err != nil {
+		t.Fatalf("load member: %v", err)
+	}
+	if status != "deleted" || deletedAt == nil {
+		t.Fatalf("expected a soft-deleted member, got status %q deleted_at %v", status, deletedAt)
+	}
+
+	var refund int64
+	if err := fixture.database.QueryRow(
+		"SELECT refund_percentage_applied FROM reservation_cancellations WHERE reservation_id = ?", fixture.reservationID,
+	).Scan(&refund); err != nil {
+		t.Fatalf("load cancellation: %v", err)
+	}
+	if refund != 100 {
+		t.Fatalf("expected a full refund, got %d%%", refund)
+	}
+
+	if recorder := deleteMember(staffUserID, fixture.oldFacility); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected deleting again to 404, got %d", recorder.Code)
+	}
+}
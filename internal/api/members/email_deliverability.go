@@ -0,0 +1,74 @@
+// internal/api/members/email_deliverability.go
+package members
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	membertempl "github.com/codr1/Pickleicious/internal/templates/components/members"
+)
+
+// HandleMemberEmailUndeliverableClear handles
+// POST /api/v1/members/{id}/email-undeliverable/clear. Staff clear the flag
+// SES bounces and complaints set once the member has fixed their address, so
+// transactional email resumes. HTMX requests get the refreshed member detail.
+func HandleMemberEmailUndeliverableClear(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), membersQueryTimeout)
+	defer cancel()
+
+	// Routed through the /api/v1/members/ catch-all:
+	// /api/v1/members/{id}/email-undeliverable/clear
+	member, ok := loadStaffMember(ctx, w, r, 3)
+	if !ok {
+		return
+	}
+
+	cleared, err := queries.ClearUserEmailUndeliverable(ctx, member.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", member.ID).Msg("Failed to clear undeliverable email flag")
+		http.Error(w, "Failed to clear email flag", http.StatusInternalServerError)
+		return
+	}
+	if cleared == 0 {
+		http.Error(w, "Member email is not marked undeliverable", http.StatusConflict)
+		return
+	}
+
+	var clearedBy int64
+	if user := authz.UserFromContext(r.Context()); user != nil {
+		clearedBy = user.ID
+	}
+	logger.Info().
+		Int64("member_id", member.ID).
+		Int64("cleared_by_user_id", clearedBy).
+		Msg("Undeliverable email flag cleared")
+
+	if apiutil.IsHTMXRequest(r) {
+		detail, err := queries.GetMemberByID(ctx, member.ID)
+		if err != nil {
+			logger.Error().Err(err).Int64("member_id", member.ID).Msg("Failed to reload member")
+			http.Error(w, "Failed to load member", http.StatusInternalServerError)
+			return
+		}
+		component := membertempl.MemberDetail(membertempl.NewMember(toListMembersRow(detail)))
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render member detail", "Failed to render member detail")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"memberId": member.ID, "emailUndeliverable": false}); err != nil {
+		logger.Error().Err(err).Int64("member_id", member.ID).Msg("Failed to write email flag response")
+		return
+	}
+}
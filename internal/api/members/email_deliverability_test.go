@@ -0,0 +1,61 @@
+package members
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func TestMemberEmailUndeliverableClear(t *testing.T) {
+	fixture := setupHomeFacilityTest(t, false)
+	staffID := fixture.insertStaff(t, "desk", fixture.oldFacility)
+	otherStaffID := fixture.insertStaff(t, "desk", fixture.newFacility)
+
+	call := func(staffUserID, facilityID int64, htmxRequest bool) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/members/%d/email-undeliverable/clear", fixture.memberID), nil)
+		if htmxRequest {
+			req.Header.Set("HX-Request", "true")
+		}
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: staffUserID, IsStaff: true, HomeFacilityID: &facilityID}))
+		recorder := httptest.NewRecorder()
+		HandleMemberEmailUndeliverableClear(recorder, req)
+		return recorder
+	}
+
+	if recorder := call(staffID, fixture.oldFacility, false); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected clearing an unflagged member to conflict, got %d", recorder.Code)
+	}
+
+	if _, err := fixture.database.Exec("UPDATE users SET email_undeliverable = 1, date_of_birth = '1990-04-01' WHERE id = ?", fixture.memberID); err != nil {
+		t.Fatalf("flag member: %v", err)
+	}
+	detail := httptest.NewRecorder()
+	detailReq := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/members/%d", fixture.memberID), nil)
+	HandleMemberDetail(detail, detailReq)
+	if !strings.Contains(detail.Body.String(), "Email undeliverable") {
+		t.Fatalf("expected the member detail to show the undeliverable badge")
+	}
+
+	if recorder := call(otherStaffID, fixture.newFacility, false); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected staff at another facility to be refused, got %d", recorder.Code)
+	}
+
+	recorder := call(staffID, fixture.oldFacility, true)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("clear status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if strings.Contains(recorder.Body.String(), "Email undeliverable") {
+		t.Fatalf("expected the refreshed detail to drop the badge")
+	}
+	user, err := fixture.database.Queries.GetUserByID(detailReq.Context(), fixture.memberID)
+	if err != nil || user.EmailUndeliverable {
+		t.Fatalf("expected the flag to be cleared, got %v (err %v)", user.EmailUndeliverable, err)
+	}
+}
@@ -4,8 +4,8 @@ package members
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -14,20 +14,32 @@ import (
 
 	"github.com/rs/zerolog/log"
 
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/api/authz"
 	"github.com/codr1/Pickleicious/internal/cognito"
+	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/images"
 	"github.com/codr1/Pickleicious/internal/models"
 	"github.com/codr1/Pickleicious/internal/request"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
 	membertempl "github.com/codr1/Pickleicious/internal/templates/components/members"
 	"github.com/codr1/Pickleicious/internal/templates/layouts"
 )
 
 var queries *dbgen.Queries
+var store *appdb.DB
 var cognitoClient *cognito.CognitoClient
+var emailClient *email.SESClient
+var notifiers []email.Notifier
 
 const membersQueryTimeout = 5 * time.Second
 
+// memberDeletionTimeout covers cancelling every upcoming reservation, each
+// with its own emails.
+const memberDeletionTimeout = 30 * time.Second
+
 // normalizePhoneInput normalizes a phone number to E.164 format.
 // Returns empty string if input is empty, or error if invalid non-empty phone.
 func normalizePhoneInput(phone string) (sql.NullString, error) {
@@ -42,11 +54,23 @@ func normalizePhoneInput(phone string) (sql.NullString, error) {
 	return sql.NullString{String: normalized, Valid: true}, nil
 }
 
-func InitHandlers(q *dbgen.Queries, cc *cognito.CognitoClient) {
-	queries = q
+func InitHandlers(database *appdb.DB, cc *cognito.CognitoClient) {
+	if database == nil {
+		return
+	}
+	queries = database.Queries
+	store = database
 	cognitoClient = cc
 }
 
+// InitEmailClient sets the client used for cancellation emails when a member
+// is deleted, and the notifiers that tell waitlisted members about the slots
+// the deletion frees. Nil skips the emails.
+func InitEmailClient(client *email.SESClient, ns ...email.Notifier) {
+	emailClient = client
+	notifiers = ns
+}
+
 // /members
 func HandleMembersPage(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
@@ -114,11 +138,16 @@ func HandleMembersList(w http.ResponseWriter, r *http.Request) {
 		offset = 0 // default offset
 	}
 
+	var searchTerm interface{}
+	if search := strings.TrimSpace(r.URL.Query().Get("search")); search != "" {
+		searchTerm = search
+	}
+
 	members, err := queries.ListMembers(r.Context(), dbgen.ListMembersParams{
 		FacilityID: sql.NullInt64{},
 		Limit:      limit,
 		Offset:     offset,
-		SearchTerm: sql.NullString{String: "", Valid: false},
+		SearchTerm: searchTerm,
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to fetch members")
@@ -139,48 +168,25 @@ func HandleMembersList(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func HandleMemberSearch(w http.ResponseWriter, r *http.Request) {
+// HandleDeleteMember handles DELETE /api/v1/members/{id} for staff. The member
+// is soft-deleted: upcoming reservations are cancelled with a full refund,
+// signups, invitations, and waitlist entries are released, member cards are
+// revoked, and the account can no longer sign in. Contact details stay until
+// the anonymization job scrubs them after the retention period.
+func HandleDeleteMember(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
-	searchTerm := r.URL.Query().Get("q")
-
-	// Parse pagination parameters
-	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
-	if err != nil || limit <= 0 {
-		limit = 25 // default limit
-	}
-
-	offset, err := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
-	if err != nil {
-		offset = 0 // default offset
-	}
 
-	members, err := queries.ListMembers(r.Context(), dbgen.ListMembersParams{
-		FacilityID: sql.NullInt64{},
-		Limit:      limit,
-		Offset:     offset,
-		SearchTerm: sql.NullString{String: searchTerm, Valid: true},
-	})
-	if err != nil {
-		logger.Error().Err(err).Msg("Failed to search members")
-		http.Error(w, "Failed to search members", http.StatusInternalServerError)
+	if queries == nil || store == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
-	// Convert to template Members
-	templateMembers := membertempl.NewMembers(members)
-
-	// Render the members list
-	component := membertempl.MembersList(templateMembers)
-	err = component.Render(r.Context(), w)
-	if err != nil {
-		logger.Error().Err(err).Msg("Failed to render members list")
-		http.Error(w, "Failed to render members list", http.StatusInternalServerError)
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
-}
-
-func HandleDeleteMember(w http.ResponseWriter, r *http.Request) {
-	logger := log.Ctx(r.Context())
 
 	// Extract ID from URL path
 	path := strings.TrimSuffix(r.URL.Path, "/")
@@ -204,17 +210,68 @@ func HandleDeleteMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Delete the member
-	err = queries.DeleteMember(r.Context(), id)
+	ctx, cancel := context.WithTimeout(r.Context(), memberDeletionTimeout)
+	defer cancel()
+
+	member, err := queries.GetUserByID(ctx, id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error().Err(err).Int64("id", id).Msg("Failed to load member")
+		http.Error(w, "Failed to delete member", http.StatusInternalServerError)
+		return
+	}
+	if err != nil || !member.IsMember || member.Status == "deleted" {
+		http.Error(w, "Member not found", http.StatusNotFound)
+		return
+	}
+	if member.HomeFacilityID.Valid && !apiutil.RequireFacilityAccess(w, r, member.HomeFacilityID.Int64) {
+		return
+	}
+
+	// Bookings are released before the status flips so a failed run can be
+	// retried; reservations already cancelled are skipped the second time.
+	released, err := reservationsvc.NewService(store, emailClient, notifiers...).ReleaseMember(ctx, reservationsvc.ReleaseMemberInput{
+		UserID:           id,
+		ReleasedByUserID: user.ID,
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) && herr.Status != http.StatusInternalServerError {
+			http.Error(w, herr.Message, herr.Status)
+			return
+		}
+		logger.Error().Err(err).Int64("id", id).Msg("Failed to release member bookings")
+		http.Error(w, "Failed to cancel member reservations", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := queries.RevokeMemberCards(ctx, id); err != nil {
+		logger.Error().Err(err).Int64("id", id).Msg("Failed to revoke member cards")
+		http.Error(w, "Failed to delete member", http.StatusInternalServerError)
+		return
+	}
+	deleted, err := queries.DeleteMember(ctx, id)
 	if err != nil {
 		logger.Error().Err(err).Int64("id", id).Msg("Failed to delete member")
 		http.Error(w, "Failed to delete member", http.StatusInternalServerError)
 		return
 	}
+	if deleted == 0 {
+		http.Error(w, "Member not found", http.StatusNotFound)
+		return
+	}
+
+	logger.Info().
+		Int64("id", id).
+		Int64("deleted_by_user_id", user.ID).
+		Int("cancelled_reservations", len(released.CancelledReservations)).
+		Int64("removed_signups", released.RemovedSignups).
+		Int64("cancelled_invitations", released.CancelledInvitations).
+		Int64("removed_waitlist_entries", released.RemovedWaitlistEntries).
+		Msg("Member deleted")
 
 	// Return success message with HX-Trigger to refresh the list
 	w.Header().Set("Content-Type", "text/html")
-	w.Header().Set("HX-Trigger", "refreshMembersList")
+	apiutil.AppendHXTrigger(w, "refreshMembersList")
 	w.Write([]byte(`
 		<div class="h-full flex items-center justify-center text-gray-500">
 			<p>Member successfully deleted</p>
@@ -260,6 +317,13 @@ func HandleEditMemberForm(w http.ResponseWriter, r *http.Request) {
 
 	// Convert to template Member
 	templMember := membertempl.NewMember(toListMembersRow(member))
+	rating, err := queries.GetMemberSkillRating(r.Context(), id)
+	switch {
+	case err == nil:
+		templMember.SkillRating = sql.NullFloat64{Float64: rating.Rating, Valid: true}
+	case !errors.Is(err, sql.ErrNoRows):
+		log.Error().Err(err).Int64("id", id).Msg("Failed to fetch member skill rating")
+	}
 
 	// Render the edit form instead of detail view
 	component := membertempl.EditMemberForm(templMember)
@@ -301,6 +365,22 @@ func HandleUpdateMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	photo, hasPhoto, ok := memberPhotoFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	// A blank skill rating clears it; forms without the field leave it alone.
+	_, hasSkillRating := r.PostForm["skill_rating"]
+	var skillRating float64
+	if hasSkillRating && strings.TrimSpace(r.PostFormValue("skill_rating")) != "" {
+		skillRating, err = apiutil.ParseSkillRating(r.PostFormValue("skill_rating"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
 	// First update the member details
 	err = queries.UpdateMember(r.Context(), dbgen.UpdateMemberParams{
 		ID:            id,
@@ -321,23 +401,23 @@ func HandleUpdateMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Process photo if present
-	if photoData := r.FormValue("photo_data"); photoData != "" {
-		// Remove data URL prefix and decode
-		photoBytes, err := base64.StdEncoding.DecodeString(strings.Split(photoData, ",")[1])
+	if hasSkillRating {
+		if skillRating > 0 {
+			err = recordStaffSkillRating(r, id, skillRating)
+		} else {
+			// Clearing only drops the effective rating; history stays.
+			err = queries.DeleteMemberSkillRating(r.Context(), id)
+		}
 		if err != nil {
-			logger.Error().Err(err).Msg("Failed to decode photo data")
-			http.Error(w, "Invalid photo data", http.StatusBadRequest)
+			logger.Error().Err(err).Int64("member_id", id).Msg("Failed to update member skill rating")
+			http.Error(w, "Failed to update skill rating", http.StatusInternalServerError)
 			return
 		}
+	}
 
-		// Save/Update the photo and get its ID
-		photo, err := queries.UpsertPhoto(r.Context(), dbgen.UpsertPhotoParams{
-			UserID:      id,
-			Data:        photoBytes,
-			ContentType: "image/jpeg",
-			Size:        int64(len(photoBytes)),
-		})
+	// Save the photo validated above
+	if hasPhoto {
+		photo, err := queries.UpsertPhoto(r.Context(), memberPhotoParams(id, photo))
 		if err != nil {
 			logger.Error().
 				Err(err).
@@ -360,7 +440,7 @@ func HandleUpdateMember(w http.ResponseWriter, r *http.Request) {
 
 	// Set headers and render response
 	w.Header().Set("Content-Type", "text/html")
-	w.Header().Set("HX-Trigger", "refreshMembersList")
+	apiutil.AppendHXTrigger(w, "refreshMembersList")
 	w.Header().Set("HX-Retarget", "#member-detail")
 	w.Header().Set("HX-Reswap", "innerHTML")
 
@@ -581,6 +661,11 @@ func HandleCreateMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	photo, hasPhoto, ok := memberPhotoFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	// Create member and get ID
 	memberID, err := queries.CreateMember(r.Context(), dbgen.CreateMemberParams{
 		FirstName:     r.FormValue("first_name"),
@@ -607,7 +692,7 @@ func HandleCreateMember(w http.ResponseWriter, r *http.Request) {
 		phone := r.FormValue("phone")
 		if err := cognitoClient.CreateUser(r.Context(), email, phone); err != nil {
 			// Delete the member we just created since they can't log in without Cognito
-			if delErr := queries.DeleteMember(r.Context(), memberID); delErr != nil {
+			if _, delErr := queries.DeleteMember(r.Context(), memberID); delErr != nil {
 				logger.Error().Err(delErr).Int64("member_id", memberID).Msg("Failed to rollback member after Cognito failure")
 			}
 			logger.Error().Err(err).Str("email", email).Str("phone", phone).Msg("Failed to create Cognito user")
@@ -625,23 +710,9 @@ func HandleCreateMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Process photo if present
-	if photoData := r.FormValue("photo_data"); photoData != "" {
-		// Remove data URL prefix
-		photoBytes, err := base64.StdEncoding.DecodeString(strings.Split(photoData, ",")[1])
-		if err != nil {
-			logger.Error().Err(err).Msg("Failed to decode photo data")
-			http.Error(w, "Invalid photo data", http.StatusBadRequest)
-			return
-		}
-
-		// Store photo in database
-		photo, err := queries.UpsertPhoto(r.Context(), dbgen.UpsertPhotoParams{
-			UserID:      member.ID,
-			Data:        photoBytes,
-			ContentType: "image/jpeg",
-			Size:        int64(len(photoBytes)),
-		})
+	// Store the photo validated above
+	if hasPhoto {
+		photo, err := queries.UpsertPhoto(r.Context(), memberPhotoParams(member.ID, photo))
 		if err != nil {
 			logger.Error().
 				Err(err).
@@ -664,7 +735,7 @@ func HandleCreateMember(w http.ResponseWriter, r *http.Request) {
 
 	// Set headers and render response
 	w.Header().Set("Content-Type", "text/html")
-	w.Header().Set("HX-Trigger", "refreshMembersList")
+	apiutil.AppendHXTrigger(w, "refreshMembersList")
 	w.Header().Set("HX-Retarget", "#member-detail")
 	w.Header().Set("HX-Reswap", "innerHTML")
 
@@ -678,6 +749,38 @@ func HandleCreateMember(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// memberPhotoFromRequest normalizes the photo_data form value, if any. It
+// writes the error response and reports false when the upload is rejected.
+func memberPhotoFromRequest(w http.ResponseWriter, r *http.Request) (images.Photo, bool, bool) {
+	photoData := r.FormValue("photo_data")
+	if photoData == "" {
+		return images.Photo{}, false, true
+	}
+	photo, err := apiutil.ProcessPhotoUpload(photoData)
+	if err != nil {
+		var handlerErr apiutil.HandlerError
+		if errors.As(err, &handlerErr) {
+			log.Ctx(r.Context()).Warn().Err(err).Msg("Rejected member photo upload")
+			http.Error(w, handlerErr.Message, handlerErr.Status)
+			return images.Photo{}, false, false
+		}
+		log.Ctx(r.Context()).Error().Err(err).Msg("Failed to process member photo")
+		http.Error(w, "Failed to process photo", http.StatusInternalServerError)
+		return images.Photo{}, false, false
+	}
+	return photo, true, true
+}
+
+func memberPhotoParams(userID int64, photo images.Photo) dbgen.UpsertPhotoParams {
+	return dbgen.UpsertPhotoParams{
+		UserID:        userID,
+		Data:          photo.Full,
+		ContentType:   photo.ContentType,
+		Size:          int64(len(photo.Full)),
+		ThumbnailData: photo.Thumbnail,
+	}
+}
+
 func HandleMemberPhoto(w http.ResponseWriter, r *http.Request) {
 	// Extract member ID from URL path: /api/v1/members/photo/{id}
 	parts := strings.Split(r.URL.Path, "/")
@@ -693,7 +796,7 @@ func HandleMemberPhoto(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fetch photo from database
-	photo, err := queries.GetMemberPhoto(r.Context(), memberID)
+	photo, err := queries.GetUserPhoto(r.Context(), memberID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			http.Error(w, "Photo not found", http.StatusNotFound)
@@ -703,9 +806,7 @@ func HandleMemberPhoto(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Set content type and serve photo
-	w.Header().Set("Content-Type", photo.ContentType)
-	w.Write(photo.Data)
+	apiutil.ServePhoto(w, r, photo.Data, photo.ContentType, photo.UpdatedAt)
 }
 
 func HandleRestoreDecision(w http.ResponseWriter, r *http.Request) {
@@ -732,7 +833,7 @@ func HandleRestoreDecision(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Set HTMX headers for UI updates
-		w.Header().Set("HX-Trigger", "refreshMembersList")
+		apiutil.AppendHXTrigger(w, "refreshMembersList")
 		w.Header().Set("HX-Retarget", "#member-detail")
 		w.Header().Set("HX-Reswap", "innerHTML")
 
@@ -770,21 +871,23 @@ func toListMembersRow(member interface{}) dbgen.ListMembersRow {
 	case dbgen.GetMemberByIDRow:
 		// Explicit field mapping since direct conversion isn't allowed
 		return dbgen.ListMembersRow{
-			ID:            m.ID,
-			FirstName:     m.FirstName,
-			LastName:      m.LastName,
-			Email:         m.Email,
-			Phone:         m.Phone,
-			StreetAddress: m.StreetAddress,
-			City:          m.City,
-			State:         m.State,
-			PostalCode:    m.PostalCode,
-			Status:        m.Status,
-			DateOfBirth:   m.DateOfBirth,
-			WaiverSigned:  m.WaiverSigned,
-			CreatedAt:     m.CreatedAt,
-			UpdatedAt:     m.UpdatedAt,
-			PhotoID:       m.PhotoID,
+			ID:                  m.ID,
+			FirstName:           m.FirstName,
+			LastName:            m.LastName,
+			Email:               m.Email,
+			Phone:               m.Phone,
+			StreetAddress:       m.StreetAddress,
+			City:                m.City,
+			State:               m.State,
+			PostalCode:          m.PostalCode,
+			Status:              m.Status,
+			DateOfBirth:         m.DateOfBirth,
+			WaiverSigned:        m.WaiverSigned,
+			CreatedAt:           m.CreatedAt,
+			UpdatedAt:           m.UpdatedAt,
+			PhotoID:             m.PhotoID,
+			DeletionRequestedAt: m.DeletionRequestedAt,
+			EmailUndeliverable:  m.EmailUndeliverable,
 		}
 	default:
 		panic(fmt.Sprintf("unsupported member type: %T", member))
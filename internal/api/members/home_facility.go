@@ -0,0 +1,202 @@
+// internal/api/members/home_facility.go
+package members
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const maxHomeFacilityChangeReasonLength = 500
+
+type homeFacilityUpdateRequest struct {
+	FacilityID         int64  `json:"facilityId"`
+	TransferVisitPacks bool   `json:"transferVisitPacks"`
+	Reason             string `json:"reason"`
+}
+
+// HandleMemberHomeFacilityUpdate handles PUT /api/v1/members/{id}/home-facility.
+// Only admins and managers who could manage staff at the target facility may
+// move a member there. Existing reservations keep their facility; visit packs
+// move only on request and only when the organization shares packs across
+// facilities.
+func HandleMemberHomeFacilityUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil || store == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Routed through the /api/v1/members/ catch-all, so the ID comes from the
+	// path rather than a pattern wildcard.
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(parts) < 3 {
+		http.Error(w, "Invalid member ID", http.StatusBadRequest)
+		return
+	}
+	memberID, err := strconv.ParseInt(parts[len(parts)-2], 10, 64)
+	if err != nil || memberID <= 0 {
+		http.Error(w, "Invalid member ID", http.StatusBadRequest)
+		return
+	}
+
+	var req homeFacilityUpdateRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.FacilityID <= 0 {
+		http.Error(w, "facilityId must be a positive integer", http.StatusBadRequest)
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if len(req.Reason) > maxHomeFacilityChangeReasonLength {
+		http.Error(w, "reason is too long", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), membersQueryTimeout)
+	defer cancel()
+
+	staffRow, err := queries.GetStaffByUserID(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load staff role")
+		http.Error(w, "Failed to authorize request", http.StatusInternalServerError)
+		return
+	}
+	requester := authz.StaffAccess{Role: staffRow.Role}
+	if staffRow.HomeFacilityID.Valid {
+		requester.HomeFacilityID = &staffRow.HomeFacilityID.Int64
+	}
+	if !authz.CanManageStaff(requester, authz.StaffAccess{HomeFacilityID: &req.FacilityID}) {
+		logger.Warn().
+			Int64("user_id", user.ID).
+			Str("role", staffRow.Role).
+			Int64("facility_id", req.FacilityID).
+			Msg("Home facility change denied: insufficient permissions")
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	var change dbgen.MemberHomeFacilityChange
+	err = store.RunInTx(ctx, func(txdb *appdb.DB) error {
+		var err error
+		change, err = changeMemberHomeFacility(ctx, txdb.Queries, memberID, user.ID, req)
+		return err
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("member_id", memberID).Msg(herr.Message)
+			}
+			http.Error(w, herr.Message, herr.Status)
+			return
+		}
+		logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to change member home facility")
+		http.Error(w, "Failed to change home facility", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"change": change}); err != nil {
+		logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to write home facility change response")
+		return
+	}
+}
+
+func changeMemberHomeFacility(ctx context.Context, q *dbgen.Queries, memberID, changedByUserID int64, req homeFacilityUpdateRequest) (dbgen.MemberHomeFacilityChange, error) {
+	member, err := q.GetUserByID(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Member not found", Err: err}
+		}
+		return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load member", Err: err}
+	}
+	if !member.IsMember || member.Status == "deleted" {
+		return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Member not found"}
+	}
+	if member.HomeFacilityID.Valid && member.HomeFacilityID.Int64 == req.FacilityID {
+		return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusConflict, Message: "Member is already homed at this facility"}
+	}
+
+	target, err := q.GetFacilityByID(ctx, req.FacilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Facility not found", Err: err}
+		}
+		return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load facility", Err: err}
+	}
+
+	var transferred int64
+	if req.TransferVisitPacks {
+		if !member.HomeFacilityID.Valid {
+			return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusConflict, Message: "Member has no home facility to transfer visit packs from"}
+		}
+		source, err := q.GetFacilityByID(ctx, member.HomeFacilityID.Int64)
+		if err != nil {
+			return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load current home facility", Err: err}
+		}
+		if source.OrganizationID != target.OrganizationID {
+			return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusConflict, Message: "Visit packs can only move between facilities in the same organization"}
+		}
+		crossFacility, err := q.GetOrganizationCrossFacilitySetting(ctx, target.OrganizationID)
+		if err != nil {
+			return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load organization settings", Err: err}
+		}
+		if !crossFacility {
+			return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusConflict, Message: "Cross-facility visit packs are disabled for this organization"}
+		}
+		transferred, err = q.TransferActiveVisitPacks(ctx, dbgen.TransferActiveVisitPacksParams{
+			ToFacilityID:   target.ID,
+			UserID:         member.ID,
+			ComparisonTime: time.Now(),
+			FromFacilityID: source.ID,
+		})
+		if err != nil {
+			return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to transfer visit packs", Err: err}
+		}
+	}
+
+	if err := q.UpdateUserHomeFacility(ctx, dbgen.UpdateUserHomeFacilityParams{
+		HomeFacilityID: sql.NullInt64{Int64: target.ID, Valid: true},
+		ID:             member.ID,
+	}); err != nil {
+		return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update home facility", Err: err}
+	}
+
+	change, err := q.CreateMemberHomeFacilityChange(ctx, dbgen.CreateMemberHomeFacilityChangeParams{
+		UserID:                member.ID,
+		FromFacilityID:        member.HomeFacilityID,
+		ToFacilityID:          target.ID,
+		ChangedByUserID:       changedByUserID,
+		TransferredVisitPacks: transferred,
+		Reason:                sql.NullString{String: req.Reason, Valid: req.Reason != ""},
+	})
+	if err != nil {
+		return dbgen.MemberHomeFacilityChange{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to record home facility change", Err: err}
+	}
+	return change, nil
+}
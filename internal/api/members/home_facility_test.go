@@ -0,0 +1,241 @@
+package members
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type homeFacilityFixture struct {
+	database      *db.DB
+	orgID         int64
+	oldFacility   int64
+	newFacility   int64
+	memberID      int64
+	visitPackID   int64
+	reservationID int64
+}
+
+func setupHomeFacilityTest(t *testing.T, crossFacility bool) homeFacilityFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+
+	orgResult, err := database.Exec(
+		"INSERT INTO organizations (name, slug, status, cross_facility_visit_packs) VALUES (?, ?, ?, ?)",
+		"Test Org", "test-org", "active", crossFacility,
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+
+	insertFacility := func(name, slug string) int64 {
+		t.Helper()
+		result, err := database.Exec(
+			"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+			orgID, name, slug, "UTC",
+		)
+		if err != nil {
+			t.Fatalf("insert facility: %v", err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	oldFacility := insertFacility("Old Club", "old-club")
+	newFacility := insertFacility("New Club", "new-club")
+
+	memberResult, err := database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, 'active', 1, 1, ?)`,
+		"Moving", "Member", "mover@test.com", oldFacility,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	packTypeResult, err := database.Exec(
+		"INSERT INTO visit_pack_types (facility_id, name, price_cents, visit_count, valid_days) VALUES (?, ?, ?, ?, ?)",
+		oldFacility, "Ten Visits", 10000, 10, 90,
+	)
+	if err != nil {
+		t.Fatalf("insert visit pack type: %v", err)
+	}
+	packTypeID, _ := packTypeResult.LastInsertId()
+	packResult, err := database.Exec(
+		"INSERT INTO visit_packs (pack_type_id, user_id, purchase_date, expires_at, visits_remaining) VALUES (?, ?, ?, ?, ?)",
+		packTypeID, memberID, time.Now(), time.Now().AddDate(0, 0, 90), 10,
+	)
+	if err != nil {
+		t.Fatalf("insert visit pack: %v", err)
+	}
+	visitPackID, _ := packResult.LastInsertId()
+
+	start := time.Now().Add(72 * time.Hour)
+	reservationResult, err := database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?, ?)`,
+		oldFacility, memberID, memberID, start, start.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, _ := reservationResult.LastInsertId()
+
+	InitHandlers(database, nil)
+
+	return homeFacilityFixture{
+		database:      database,
+		orgID:         orgID,
+		oldFacility:   oldFacility,
+		newFacility:   newFacility,
+		memberID:      memberID,
+		visitPackID:   visitPackID,
+		reservationID: reservationID,
+	}
+}
+
+func (f homeFacilityFixture) insertStaff(t *testing.T, role string, homeFacilityID any) int64 {
+	t.Helper()
+	userResult, err := f.database.Exec(
+		"INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES (?, ?, ?, 'active', 1)",
+		"Staff", role, fmt.Sprintf("%s-%d@test.com", role, time.Now().UnixNano()),
+	)
+	if err != nil {
+		t.Fatalf("insert staff user: %v", err)
+	}
+	userID, _ := userResult.LastInsertId()
+	if _, err := f.database.Exec(
+		"INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, 'Staff', ?, ?, ?)",
+		userID, role, homeFacilityID, role,
+	); err != nil {
+		t.Fatalf("insert staff: %v", err)
+	}
+	return userID
+}
+
+func (f homeFacilityFixture) move(t *testing.T, staffUserID int64, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/members/%d/home-facility", f.memberID), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: staffUserID, IsStaff: true}))
+	recorder := httptest.NewRecorder()
+	HandleMemberHomeFacilityUpdate(recorder, req)
+	return recorder
+}
+
+func TestHandleMemberHomeFacilityUpdate_MovesMemberAndVisitPacks(t *testing.T) {
+	fixture := setupHomeFacilityTest(t, true)
+	manager := fixture.insertStaff(t, "manager", fixture.newFacility)
+
+	recorder := fixture.move(t, manager, fmt.Sprintf(`{"facilityId":%d,"transferVisitPacks":true,"reason":"Relocated"}`, fixture.newFacility))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response struct {
+		Change dbgen.MemberHomeFacilityChange `json:"change"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	change := response.Change
+	if change.UserID != fixture.memberID || change.FromFacilityID.Int64 != fixture.oldFacility || change.ToFacilityID != fixture.newFacility ||
+		change.ChangedByUserID != manager || change.TransferredVisitPacks != 1 || change.Reason.String != "Relocated" {
+		t.Fatalf("unexpected audit record: %+v", change)
+	}
+
+	var homeFacility, packFacility int64
+	if err := fixture.database.QueryRow("SELECT home_facility_id FROM users WHERE id = ?", fixture.memberID).Scan(&homeFacility); err != nil {
+		t.Fatalf("load home facility: %v", err)
+	}
+	if err := fixture.database.QueryRow("SELECT facility_id FROM visit_packs WHERE id = ?", fixture.visitPackID).Scan(&packFacility); err != nil {
+		t.Fatalf("load pack facility: %v", err)
+	}
+	if homeFacility != fixture.newFacility || packFacility != fixture.newFacility {
+		t.Fatalf("home facility %d, pack facility %d; want both %d", homeFacility, packFacility, fixture.newFacility)
+	}
+
+	packs, err := queries.ListActiveVisitPacksForUserByFacility(t.Context(), dbgen.ListActiveVisitPacksForUserByFacilityParams{
+		UserID:         fixture.memberID,
+		FacilityID:     fixture.newFacility,
+		ComparisonTime: time.Now(),
+	})
+	if err != nil || len(packs) != 1 {
+		t.Fatalf("expected the pack listed at the new facility, got %d (%v)", len(packs), err)
+	}
+
+	var reservationFacility int64
+	if err := fixture.database.QueryRow("SELECT facility_id FROM reservations WHERE id = ?", fixture.reservationID).Scan(&reservationFacility); err != nil {
+		t.Fatalf("load reservation: %v", err)
+	}
+	if reservationFacility != fixture.oldFacility {
+		t.Fatalf("reservation moved to facility %d, want it left at %d", reservationFacility, fixture.oldFacility)
+	}
+}
+
+func TestHandleMemberHomeFacilityUpdate_Authorization(t *testing.T) {
+	fixture := setupHomeFacilityTest(t, true)
+	body := fmt.Sprintf(`{"facilityId":%d}`, fixture.newFacility)
+
+	for _, tc := range []struct {
+		name     string
+		role     string
+		facility any
+		want     int
+	}{
+		{"desk staff at target", "desk", fixture.newFacility, http.StatusForbidden},
+		{"manager at another facility", "manager", fixture.oldFacility, http.StatusForbidden},
+	} {
+		staffID := fixture.insertStaff(t, tc.role, tc.facility)
+		if recorder := fixture.move(t, staffID, body); recorder.Code != tc.want {
+			t.Fatalf("%s: status %d, want %d: %s", tc.name, recorder.Code, tc.want, recorder.Body.String())
+		}
+	}
+
+	admin := fixture.insertStaff(t, "admin", nil)
+	if recorder := fixture.move(t, admin, body); recorder.Code != http.StatusOK {
+		t.Fatalf("corporate admin: status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := fixture.move(t, admin, body); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected repeat move to conflict, got %d", recorder.Code)
+	}
+}
+
+func TestHandleMemberHomeFacilityUpdate_VisitPackTransferRequiresCrossFacility(t *testing.T) {
+	fixture := setupHomeFacilityTest(t, false)
+	admin := fixture.insertStaff(t, "admin", nil)
+
+	recorder := fixture.move(t, admin, fmt.Sprintf(`{"facilityId":%d,"transferVisitPacks":true}`, fixture.newFacility))
+	if recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), "Cross-facility") {
+		t.Fatalf("expected cross-facility conflict, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var homeFacility int64
+	var changes int
+	if err := fixture.database.QueryRow("SELECT home_facility_id FROM users WHERE id = ?", fixture.memberID).Scan(&homeFacility); err != nil {
+		t.Fatalf("load home facility: %v", err)
+	}
+	if err := fixture.database.QueryRow("SELECT COUNT(*) FROM member_home_facility_changes").Scan(&changes); err != nil {
+		t.Fatalf("count changes: %v", err)
+	}
+	if homeFacility != fixture.oldFacility || changes != 0 {
+		t.Fatalf("rejected transfer changed state: home %d, audit rows %d", homeFacility, changes)
+	}
+
+	// Without the pack transfer the move itself is allowed.
+	if recorder := fixture.move(t, admin, fmt.Sprintf(`{"facilityId":%d}`, fixture.newFacility)); recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
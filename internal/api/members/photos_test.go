@@ -0,0 +1,112 @@
+package members
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/images"
+)
+
+func TestHandleUpdateMember_NormalizesPhotoUploads(t *testing.T) {
+	fixture := setupHomeFacilityTest(t, false)
+
+	update := func(firstName, photoData string) *httptest.ResponseRecorder {
+		t.Helper()
+		form := url.Values{
+			"first_name":    {firstName},
+			"last_name":     {"Member"},
+			"email":         {"mover@test.com"},
+			"status":        {"active"},
+			"date_of_birth": {"1990-01-01"},
+			"photo_data":    {photoData},
+		}
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/members/%d", fixture.memberID), strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		HandleUpdateMember(recorder, req)
+		return recorder
+	}
+	firstName := func() string {
+		t.Helper()
+		var name string
+		if err := fixture.database.QueryRow("SELECT first_name FROM users WHERE id = ?", fixture.memberID).Scan(&name); err != nil {
+			t.Fatalf("load member: %v", err)
+		}
+		return name
+	}
+
+	// Content is checked, not the declared type, and nothing is saved when
+	// the photo is rejected.
+	fake := "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString([]byte("<script>alert(1)</script>"))
+	if recorder := update("Renamed", fake); recorder.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415 for a non-image, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if name := firstName(); name != "Moving" {
+		t.Fatalf("expected a rejected upload to leave the member unchanged, got %q", name)
+	}
+
+	var pngData bytes.Buffer
+	img := image.NewNRGBA(image.Rect(0, 0, 600, 300))
+	for y := 0; y < 300; y++ {
+		for x := 0; x < 600; x++ {
+			img.Set(x, y, color.NRGBA{R: 200, A: 255})
+		}
+	}
+	if err := png.Encode(&pngData, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	upload := "data:image/png;base64," + base64.StdEncoding.EncodeToString(pngData.Bytes())
+
+	images.SetMaxUploadBytes(int64(pngData.Len() - 1))
+	t.Cleanup(func() { images.SetMaxUploadBytes(0) })
+	if recorder := update("Renamed", upload); recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 over the upload limit, got %d", recorder.Code)
+	}
+	images.SetMaxUploadBytes(0)
+
+	if recorder := update("Renamed", upload); recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var contentType string
+	var data, thumbnail []byte
+	if err := fixture.database.QueryRow(
+		"SELECT content_type, data, thumbnail_data FROM user_photos WHERE user_id = ?", fixture.memberID,
+	).Scan(&contentType, &data, &thumbnail); err != nil {
+		t.Fatalf("load photo: %v", err)
+	}
+	if contentType != images.ContentTypeJPEG {
+		t.Fatalf("expected the photo stored as JPEG, got %q", contentType)
+	}
+	for name, encoded := range map[string][]byte{"full": data, "thumbnail": thumbnail} {
+		if detected, err := images.DetectContentType(encoded); err != nil || detected != images.ContentTypeJPEG {
+			t.Fatalf("expected %s bytes to be JPEG, got %q (%v)", name, detected, err)
+		}
+	}
+
+	// The legacy photo URL serves the same bytes with revalidation headers.
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/members/photo/%d", fixture.memberID), nil)
+	recorder := httptest.NewRecorder()
+	HandleMemberPhoto(recorder, req)
+	etag := recorder.Header().Get("ETag")
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != images.ContentTypeJPEG || etag == "" {
+		t.Fatalf("unexpected photo response %d %v", recorder.Code, recorder.Header())
+	}
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/members/photo/%d", fixture.memberID), nil)
+	req.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	HandleMemberPhoto(recorder, req)
+	if recorder.Code != http.StatusNotModified {
+		t.Fatalf("expected 304 for a matching ETag, got %d", recorder.Code)
+	}
+}
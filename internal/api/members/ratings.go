@@ -0,0 +1,272 @@
+// internal/api/members/ratings.go
+package members
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/ratings"
+)
+
+type memberRatingAdjustRequest struct {
+	Rating *float64 `json:"rating"`
+	Source string   `json:"source"`
+	Note   string   `json:"note"`
+}
+
+type memberRatingVerifyRequest struct {
+	EntryID int64 `json:"entryId"`
+}
+
+// HandleMemberRatingGet handles GET /api/v1/members/{id}/rating with the
+// member's effective rating and full rating history.
+func HandleMemberRatingGet(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), membersQueryTimeout)
+	defer cancel()
+
+	memberID, ok := loadRatedMember(ctx, w, r, 2)
+	if !ok {
+		return
+	}
+
+	summary, err := ratings.Load(ctx, queries, memberID)
+	if err != nil {
+		writeMemberRatingError(w, r, memberID, err)
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, summary); err != nil {
+		logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to write member rating response")
+		return
+	}
+}
+
+// HandleMemberRatingAdjust handles PUT /api/v1/members/{id}/rating. Staff set
+// a verified rating, by default with source staff; a DUPR or UTR source
+// records a rating staff checked against that system.
+func HandleMemberRatingAdjust(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil || store == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	var req memberRatingAdjustRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Rating == nil {
+		http.Error(w, "rating is required", http.StatusBadRequest)
+		return
+	}
+	source := strings.TrimSpace(req.Source)
+	if source == "" {
+		source = ratings.SourceStaff
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), membersQueryTimeout)
+	defer cancel()
+
+	memberID, ok := loadRatedMember(ctx, w, r, 2)
+	if !ok {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+
+	var entry ratings.Entry
+	err := store.RunInTx(ctx, func(txdb *appdb.DB) error {
+		var err error
+		entry, err = ratings.Record(ctx, txdb.Queries, ratings.RecordInput{
+			UserID:           memberID,
+			Rating:           *req.Rating,
+			Source:           source,
+			Verified:         true,
+			RecordedByUserID: user.ID,
+			Note:             strings.TrimSpace(req.Note),
+		})
+		return err
+	})
+	if err != nil {
+		writeMemberRatingError(w, r, memberID, err)
+		return
+	}
+
+	logger.Info().
+		Int64("member_id", memberID).
+		Int64("entry_id", entry.ID).
+		Float64("rating", entry.Rating).
+		Str("source", entry.Source).
+		Int64("recorded_by_user_id", user.ID).
+		Msg("Member rating adjusted")
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, map[string]any{"entry": entry}); err != nil {
+		logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to write member rating response")
+		return
+	}
+}
+
+// HandleMemberRatingVerify handles POST /api/v1/members/{id}/rating/verify,
+// confirming a rating the member reported.
+func HandleMemberRatingVerify(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil || store == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	var req memberRatingVerifyRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.EntryID <= 0 {
+		http.Error(w, "entryId must be a positive integer", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), membersQueryTimeout)
+	defer cancel()
+
+	memberID, ok := loadRatedMember(ctx, w, r, 3)
+	if !ok {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+
+	var entry ratings.Entry
+	err := store.RunInTx(ctx, func(txdb *appdb.DB) error {
+		var err error
+		entry, err = ratings.Verify(ctx, txdb.Queries, memberID, req.EntryID, user.ID)
+		return err
+	})
+	if err != nil {
+		writeMemberRatingError(w, r, memberID, err)
+		return
+	}
+
+	logger.Info().
+		Int64("member_id", memberID).
+		Int64("entry_id", entry.ID).
+		Float64("rating", entry.Rating).
+		Int64("verified_by_user_id", user.ID).
+		Msg("Member rating verified")
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"entry": entry}); err != nil {
+		logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to write member rating response")
+		return
+	}
+}
+
+// loadRatedMember resolves the member ID from a catch-all path whose ID sits
+// fromEnd segments before the end, and checks the staff user can reach the
+// member's home facility. It writes the error response when ok is false.
+func loadRatedMember(ctx context.Context, w http.ResponseWriter, r *http.Request, fromEnd int) (int64, bool) {
+	member, ok := loadStaffMember(ctx, w, r, fromEnd)
+	return member.ID, ok
+}
+
+// loadStaffMember is loadRatedMember returning the member's user row.
+func loadStaffMember(ctx context.Context, w http.ResponseWriter, r *http.Request, fromEnd int) (dbgen.User, bool) {
+	logger := log.Ctx(r.Context())
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return dbgen.User{}, false
+	}
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(parts) <= fromEnd {
+		http.Error(w, "Invalid member ID", http.StatusBadRequest)
+		return dbgen.User{}, false
+	}
+	memberID, err := strconv.ParseInt(parts[len(parts)-fromEnd], 10, 64)
+	if err != nil || memberID <= 0 {
+		http.Error(w, "Invalid member ID", http.StatusBadRequest)
+		return dbgen.User{}, false
+	}
+
+	member, err := queries.GetUserByID(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Member not found", http.StatusNotFound)
+			return dbgen.User{}, false
+		}
+		logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to load member")
+		http.Error(w, "Failed to load member", http.StatusInternalServerError)
+		return dbgen.User{}, false
+	}
+	if !member.IsMember || member.Status == "deleted" {
+		http.Error(w, "Member not found", http.StatusNotFound)
+		return dbgen.User{}, false
+	}
+	if member.HomeFacilityID.Valid && !apiutil.RequireFacilityAccess(w, r, member.HomeFacilityID.Int64) {
+		return dbgen.User{}, false
+	}
+	return member, true
+}
+
+// recordStaffSkillRating records a rating set from the member edit form as a
+// verified staff entry. Saving the form without changing the rating leaves
+// the history alone.
+func recordStaffSkillRating(r *http.Request, memberID int64, rating float64) error {
+	current, err := queries.GetMemberSkillRating(r.Context(), memberID)
+	if err == nil && current.Rating == rating {
+		return nil
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	var recordedByUserID int64
+	if user := authz.UserFromContext(r.Context()); user != nil {
+		recordedByUserID = user.ID
+	}
+	return store.RunInTx(r.Context(), func(txdb *appdb.DB) error {
+		_, err := ratings.Record(r.Context(), txdb.Queries, ratings.RecordInput{
+			UserID:           memberID,
+			Rating:           rating,
+			Source:           ratings.SourceStaff,
+			Verified:         true,
+			RecordedByUserID: recordedByUserID,
+		})
+		return err
+	})
+}
+
+func writeMemberRatingError(w http.ResponseWriter, r *http.Request, memberID int64, err error) {
+	logger := log.Ctx(r.Context())
+
+	var herr apiutil.HandlerError
+	if errors.As(err, &herr) {
+		if herr.Status == http.StatusInternalServerError {
+			logger.Error().Err(herr.Err).Int64("member_id", memberID).Msg(herr.Message)
+		}
+		http.Error(w, herr.Message, herr.Status)
+		return
+	}
+	logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to update member rating")
+	http.Error(w, "Failed to update rating", http.StatusInternalServerError)
+}
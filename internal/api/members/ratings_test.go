@@ -0,0 +1,94 @@
+package members
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/ratings"
+)
+
+func TestMemberRating_StaffVerifyAndAdjust(t *testing.T) {
+	fixture := setupHomeFacilityTest(t, false)
+	staffID := fixture.insertStaff(t, "desk", fixture.oldFacility)
+
+	call := func(handler http.HandlerFunc, method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, fmt.Sprintf("/api/v1/members/%d%s", fixture.memberID, path), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: staffID, IsStaff: true, HomeFacilityID: &fixture.oldFacility}))
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+	effective := func() float64 {
+		t.Helper()
+		var rating float64
+		if err := fixture.database.QueryRow("SELECT rating FROM member_skill_ratings WHERE user_id = ?", fixture.memberID).Scan(&rating); err != nil {
+			t.Fatalf("load effective rating: %v", err)
+		}
+		return rating
+	}
+
+	reported, err := ratings.Record(t.Context(), fixture.database.Queries, ratings.RecordInput{
+		UserID:           fixture.memberID,
+		Rating:           4.0,
+		Source:           ratings.SourceUTR,
+		RecordedByUserID: fixture.memberID,
+	})
+	if err != nil {
+		t.Fatalf("record self-reported rating: %v", err)
+	}
+
+	recorder := call(HandleMemberRatingAdjust, http.MethodPut, "/rating", `{"rating": 3.5, "note": "Evaluated at clinic"}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("adjust status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var adjusted struct {
+		Entry ratings.Entry `json:"entry"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &adjusted); err != nil {
+		t.Fatalf("decode adjustment: %v", err)
+	}
+	if !adjusted.Entry.Verified || adjusted.Entry.Source != ratings.SourceStaff ||
+		adjusted.Entry.RecordedByUserID == nil || *adjusted.Entry.RecordedByUserID != staffID ||
+		adjusted.Entry.VerifiedByUserID == nil || *adjusted.Entry.VerifiedByUserID != staffID {
+		t.Fatalf("expected a verified staff entry stamped with the adjuster, got %+v", adjusted.Entry)
+	}
+	if got := effective(); got != 3.5 {
+		t.Fatalf("effective rating %.1f after adjustment, want 3.5", got)
+	}
+
+	// Verifying the older self-report makes it the most recently verified.
+	body := fmt.Sprintf(`{"entryId": %d}`, reported.ID)
+	if recorder := call(HandleMemberRatingVerify, http.MethodPost, "/rating/verify", body); recorder.Code != http.StatusOK {
+		t.Fatalf("verify status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if got := effective(); got != 4.0 {
+		t.Fatalf("effective rating %.1f after verification, want 4.0", got)
+	}
+	if recorder := call(HandleMemberRatingVerify, http.MethodPost, "/rating/verify", body); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected a second verification to conflict, got %d", recorder.Code)
+	}
+	if recorder := call(HandleMemberRatingVerify, http.MethodPost, "/rating/verify", `{"entryId": 9999}`); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected an unknown entry to be not found, got %d", recorder.Code)
+	}
+
+	recorder = call(HandleMemberRatingGet, http.MethodGet, "/rating", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("get status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var summary ratings.Summary
+	if err := json.Unmarshal(recorder.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("decode summary: %v", err)
+	}
+	if summary.Rating == nil || *summary.Rating != 4.0 || summary.Current == nil || summary.Current.ID != reported.ID || len(summary.History) != 2 {
+		t.Fatalf("unexpected rating summary %+v", summary)
+	}
+}
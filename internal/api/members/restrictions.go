@@ -0,0 +1,146 @@
+// internal/api/members/restrictions.go
+package members
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const maxRestrictionClearReasonLength = 500
+
+type restrictionClearRequest struct {
+	FacilityID *int64 `json:"facilityId"`
+	Reason     string `json:"reason"`
+}
+
+// HandleMemberRestrictionClear handles POST /api/v1/members/{id}/restrictions/clear.
+// Staff lift a member's active no-show restriction at a facility; the reason
+// is recorded and earlier no-shows stop counting.
+func HandleMemberRestrictionClear(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Routed through the /api/v1/members/ catch-all:
+	// /api/v1/members/{id}/restrictions/clear
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(parts) < 4 {
+		http.Error(w, "Invalid member ID", http.StatusBadRequest)
+		return
+	}
+	memberID, err := strconv.ParseInt(parts[len(parts)-3], 10, 64)
+	if err != nil || memberID <= 0 {
+		http.Error(w, "Invalid member ID", http.StatusBadRequest)
+		return
+	}
+
+	var req restrictionClearRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		http.Error(w, "reason is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.Reason) > maxRestrictionClearReasonLength {
+		http.Error(w, "reason is too long", http.StatusBadRequest)
+		return
+	}
+	if req.FacilityID != nil && *req.FacilityID <= 0 {
+		http.Error(w, "facilityId must be a positive integer", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), membersQueryTimeout)
+	defer cancel()
+
+	member, err := queries.GetUserByID(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Member not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to load member")
+		http.Error(w, "Failed to load member", http.StatusInternalServerError)
+		return
+	}
+	if !member.IsMember || member.Status == "deleted" {
+		http.Error(w, "Member not found", http.StatusNotFound)
+		return
+	}
+
+	var facilityID int64
+	switch {
+	case req.FacilityID != nil:
+		facilityID = *req.FacilityID
+	case member.HomeFacilityID.Valid:
+		facilityID = member.HomeFacilityID.Int64
+	default:
+		http.Error(w, "facilityId is required for members without a home facility", http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	now := time.Now()
+	restriction, err := apiutil.LoadNoShowRestriction(ctx, queries, facilityID, memberID, now)
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", memberID).Int64("facility_id", facilityID).Msg("Failed to load no-show restriction")
+		http.Error(w, "Failed to load restriction", http.StatusInternalServerError)
+		return
+	}
+	if restriction == nil {
+		http.Error(w, "Member has no active restriction at this facility", http.StatusConflict)
+		return
+	}
+
+	cleared, err := queries.CreateNoShowRestrictionClear(ctx, dbgen.CreateNoShowRestrictionClearParams{
+		UserID:          memberID,
+		FacilityID:      facilityID,
+		RestrictedUntil: restriction.Until,
+		ClearedAt:       now,
+		ClearedByUserID: user.ID,
+		Reason:          req.Reason,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("member_id", memberID).Int64("facility_id", facilityID).Msg("Failed to clear no-show restriction")
+		http.Error(w, "Failed to clear restriction", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info().
+		Int64("member_id", memberID).
+		Int64("facility_id", facilityID).
+		Int64("cleared_by_user_id", user.ID).
+		Str("reason", req.Reason).
+		Msg("No-show restriction cleared")
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"clear": cleared}); err != nil {
+		logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to write restriction clear response")
+		return
+	}
+}
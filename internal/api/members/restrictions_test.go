@@ -0,0 +1,78 @@
+package members
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func TestHandleMemberRestrictionClear_LiftsRestrictionAndLogsReason(t *testing.T) {
+	fixture := setupHomeFacilityTest(t, false)
+	if _, err := fixture.database.Exec(
+		"INSERT INTO facility_no_show_policies (facility_id, max_no_shows_per_30_days, restriction_days) VALUES (?, 0, 14)",
+		fixture.oldFacility,
+	); err != nil {
+		t.Fatalf("insert policy: %v", err)
+	}
+	start := time.Now().Add(-48 * time.Hour)
+	if _, err := fixture.database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?, ?)`,
+		fixture.oldFacility, fixture.memberID, fixture.memberID, start, start.Add(time.Hour),
+	); err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+
+	restriction, err := apiutil.LoadNoShowRestriction(context.Background(), queries, fixture.oldFacility, fixture.memberID, time.Now())
+	if err != nil || restriction == nil {
+		t.Fatalf("expected an active restriction, got %+v (%v)", restriction, err)
+	}
+
+	staffID := fixture.insertStaff(t, "desk", fixture.oldFacility)
+	clearRestriction := func(body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/members/%d/restrictions/clear", fixture.memberID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		facilityID := fixture.oldFacility
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: staffID, IsStaff: true, HomeFacilityID: &facilityID}))
+		recorder := httptest.NewRecorder()
+		HandleMemberRestrictionClear(recorder, req)
+		return recorder
+	}
+
+	if recorder := clearRestriction(`{"reason":"  "}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected missing reason to be rejected, got %d", recorder.Code)
+	}
+	if recorder := clearRestriction(`{"reason":"Car broke down"}`); recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var reason string
+	var clearedBy int64
+	if err := fixture.database.QueryRow(
+		"SELECT reason, cleared_by_user_id FROM no_show_restriction_clears WHERE user_id = ? AND facility_id = ?",
+		fixture.memberID, fixture.oldFacility,
+	).Scan(&reason, &clearedBy); err != nil {
+		t.Fatalf("load clear: %v", err)
+	}
+	if reason != "Car broke down" || clearedBy != staffID {
+		t.Fatalf("unexpected clear record: reason %q by %d", reason, clearedBy)
+	}
+
+	restriction, err = apiutil.LoadNoShowRestriction(context.Background(), queries, fixture.oldFacility, fixture.memberID, time.Now())
+	if err != nil || restriction != nil {
+		t.Fatalf("expected the restriction lifted, got %+v (%v)", restriction, err)
+	}
+	if recorder := clearRestriction(`{"reason":"Again"}`); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected clearing with no restriction to conflict, got %d", recorder.Code)
+	}
+}
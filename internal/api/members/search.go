@@ -0,0 +1,101 @@
+package members
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/request"
+	reservationstempl "github.com/codr1/Pickleicious/internal/templates/components/reservations"
+)
+
+const (
+	memberSearchLimit = 20
+	// Shorter digit runs would match most phone numbers in a facility.
+	memberSearchMinPhoneDigits = 3
+)
+
+// HandleMemberSearch handles GET /api/v1/members/search?q=&facility_id= for
+// the booking participant picker. HTMX requests get <option> elements for
+// the form's datalist; other callers get JSON. An empty q returns no
+// members so a debounced input never lists the whole facility.
+func HandleMemberSearch(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, ok := request.ParseFacilityID(r.URL.Query().Get("facility_id"))
+	if !ok {
+		http.Error(w, "Invalid facility_id", http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	var members []reservationstempl.MemberOption
+	searchTerm := strings.TrimSpace(r.URL.Query().Get("q"))
+	if searchTerm != "" {
+		ctx, cancel := context.WithTimeout(r.Context(), membersQueryTimeout)
+		defer cancel()
+
+		params := dbgen.SearchFacilityMembersParams{
+			FacilityID: sql.NullInt64{Int64: facilityID, Valid: true},
+			Prefix:     strings.ToLower(searchTerm),
+			Limit:      memberSearchLimit,
+		}
+		if digits, ok := phoneSearchDigits(searchTerm); ok {
+			params.PhonePrefix = digits
+		}
+		rows, err := queries.SearchFacilityMembers(ctx, params)
+		if err != nil {
+			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to search members")
+			http.Error(w, "Failed to search members", http.StatusInternalServerError)
+			return
+		}
+		members = reservationstempl.NewMemberSearchOptions(rows)
+	}
+	if members == nil {
+		members = []reservationstempl.MemberOption{}
+	}
+
+	if apiutil.IsHTMXRequest(r) {
+		component := reservationstempl.MemberSearchOptions(members)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render member search options", "Failed to render member search")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"members": members}); err != nil {
+		logger.Error().Err(err).Msg("Failed to write member search response")
+		return
+	}
+}
+
+// phoneSearchDigits returns the digits of a search term that looks like a
+// phone number, ignoring common separators. ok is false for anything else.
+func phoneSearchDigits(term string) (string, bool) {
+	var digits strings.Builder
+	for _, r := range term {
+		switch {
+		case unicode.IsDigit(r):
+			digits.WriteRune(r)
+		case strings.ContainsRune(" +-().", r):
+		default:
+			return "", false
+		}
+	}
+	if digits.Len() < memberSearchMinPhoneDigits {
+		return "", false
+	}
+	return digits.String(), true
+}
@@ -0,0 +1,136 @@
+package members
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	reservationstempl "github.com/codr1/Pickleicious/internal/templates/components/reservations"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestHandleMemberSearch(t *testing.T) {
+	database := testutil.NewTestDB(t)
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.Exec(query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main', 'main', 'UTC')", orgID)
+	otherFacilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Other', 'other', 'UTC')", orgID)
+	insertMember := func(first, last, email, phone, status string, homeFacilityID int64) int64 {
+		t.Helper()
+		return exec(
+			`INSERT INTO users (first_name, last_name, email, phone, status, is_member, home_facility_id)
+			 VALUES (?, ?, ?, ?, ?, 1, ?)`,
+			first, last, email, phone, status, homeFacilityID,
+		)
+	}
+	alice := insertMember("Alice", "Smith", "alice@test.com", "+15551230001", "active", facilityID)
+	bob := insertMember("Bob", "Alison", "bob@test.com", "+15559870002", "active", facilityID)
+	insertMember("Alina", "Deleted", "alina@test.com", "+15551230003", "deleted", facilityID)
+	insertMember("Alan", "Inactive", "alan@test.com", "+15551230004", "suspended", facilityID)
+	insertMember("Alex", "Elsewhere", "alex@test.com", "+15551230005", "active", otherFacilityID)
+	insertMember("Carol", "Malice", "carol@test.com", "+15550000006", "active", facilityID)
+	for i := 0; i < 25; i++ {
+		insertMember("Zed", fmt.Sprintf("Member%02d", i), fmt.Sprintf("zed%02d@test.com", i), "", "active", facilityID)
+	}
+	InitHandlers(database, nil)
+
+	search := func(q string, htmx bool) *httptest.ResponseRecorder {
+		t.Helper()
+		target := fmt.Sprintf("/api/v1/members/search?facility_id=%d&q=%s", facilityID, url.QueryEscape(q))
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		homeFacilityID := facilityID
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: 1, IsStaff: true, HomeFacilityID: &homeFacilityID}))
+		recorder := httptest.NewRecorder()
+		HandleMemberSearch(recorder, req)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("search %q: expected 200, got %d: %s", q, recorder.Code, recorder.Body.String())
+		}
+		return recorder
+	}
+	searchIDs := func(q string) []int64 {
+		t.Helper()
+		var body struct {
+			Members []reservationstempl.MemberOption `json:"members"`
+		}
+		if err := json.NewDecoder(search(q, false).Body).Decode(&body); err != nil {
+			t.Fatalf("decode search %q: %v", q, err)
+		}
+		ids := make([]int64, 0, len(body.Members))
+		for _, member := range body.Members {
+			ids = append(ids, member.ID)
+		}
+		return ids
+	}
+	expectIDs := func(q string, want ...int64) {
+		t.Helper()
+		got := searchIDs(q)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("search %q: expected %v, got %v", q, want, got)
+		}
+	}
+
+	// Prefix matches on first name, last name, full name, email, and phone;
+	// "Malice" contains "ali" but does not start with it.
+	expectIDs("ali", bob, alice)
+	expectIDs("alice s", alice)
+	expectIDs("BOB@", bob)
+	expectIDs("555-987", bob)
+	expectIDs("1555123", alice)
+	expectIDs("")
+	expectIDs("%")
+
+	if got := searchIDs("zed"); len(got) != memberSearchLimit {
+		t.Fatalf("expected results capped at %d, got %d", memberSearchLimit, len(got))
+	}
+
+	body := search("alice", true).Body.String()
+	if !strings.Contains(body, fmt.Sprintf(`<option value="%d">Alice Smith - alice@test.com</option>`, alice)) {
+		t.Fatalf("expected datalist option for Alice, got %q", body)
+	}
+	// Rated members carry their effective rating for roster building.
+	exec("INSERT INTO member_skill_ratings (user_id, rating) VALUES (?, 3.5)", bob)
+	body = search("bob", true).Body.String()
+	if !strings.Contains(body, fmt.Sprintf(`<option value="%d">Bob Alison - bob@test.com (3.5)</option>`, bob)) {
+		t.Fatalf("expected Bob's option to show the rating, got %q", body)
+	}
+}
+
+func TestHandleMemberSearch_RequiresFacilityAccess(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	InitHandlers(database, nil)
+
+	homeFacilityID := int64(1)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/members/search?facility_id=2&q=al", nil)
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: 1, IsStaff: true, HomeFacilityID: &homeFacilityID}))
+	recorder := httptest.NewRecorder()
+	HandleMemberSearch(recorder, req)
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", recorder.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/members/search?q=al", nil)
+	recorder = httptest.NewRecorder()
+	HandleMemberSearch(recorder, req)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without facility_id, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,105 @@
+// internal/api/members/visits_export.go
+package members
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/request"
+)
+
+// memberVisitsExportTimeout covers streaming a full year of visits.
+const memberVisitsExportTimeout = 30 * time.Second
+
+// HandleMemberVisitsExport handles GET /api/v1/members/{id}/visits/export.
+// Staff download a member's paid visits for ?year= at one facility: the
+// facility_id query parameter, or the member's home facility by default.
+func HandleMemberVisitsExport(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if !authz.IsStaff(user) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Routed through the /api/v1/members/ catch-all:
+	// /api/v1/members/{id}/visits/export
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(parts) < 4 {
+		http.Error(w, "Invalid member ID", http.StatusBadRequest)
+		return
+	}
+	memberID, err := strconv.ParseInt(parts[len(parts)-3], 10, 64)
+	if err != nil || memberID <= 0 {
+		http.Error(w, "Invalid member ID", http.StatusBadRequest)
+		return
+	}
+
+	year, err := apiutil.ParseVisitExportYear(r.URL.Query().Get("year"), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), memberVisitsExportTimeout)
+	defer cancel()
+
+	member, err := queries.GetUserByID(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Member not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("member_id", memberID).Msg("Failed to load member")
+		http.Error(w, "Failed to load member", http.StatusInternalServerError)
+		return
+	}
+	if !member.IsMember {
+		http.Error(w, "Member not found", http.StatusNotFound)
+		return
+	}
+
+	facilityID, ok := request.ParseFacilityID(r.URL.Query().Get("facility_id"))
+	if !ok {
+		if strings.TrimSpace(r.URL.Query().Get("facility_id")) != "" {
+			http.Error(w, "Invalid facility_id", http.StatusBadRequest)
+			return
+		}
+		if !member.HomeFacilityID.Valid {
+			http.Error(w, "facility_id is required for members without a home facility", http.StatusBadRequest)
+			return
+		}
+		facilityID = member.HomeFacilityID.Int64
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	var facilities apiutil.FacilityQuerier = queries
+	if store != nil && store.Facilities != nil {
+		facilities = store.Facilities
+	}
+	apiutil.WriteVisitHistoryCSV(ctx, w, queries, facilities, apiutil.VisitExport{
+		UserID:     memberID,
+		Year:       year,
+		FacilityID: facilityID,
+		Filename:   fmt.Sprintf("member_%d_facility_%d_visits_%d.csv", memberID, facilityID, year),
+	}, logger)
+}
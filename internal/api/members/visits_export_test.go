@@ -0,0 +1,68 @@
+package members
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func TestHandleMemberVisitsExport_RequiresFacilityAccess(t *testing.T) {
+	fixture := setupHomeFacilityTest(t, false)
+
+	var start time.Time
+	if err := fixture.database.QueryRow("SELECT start_time FROM reservations WHERE id = ?", fixture.reservationID).Scan(&start); err != nil {
+		t.Fatalf("load reservation: %v", err)
+	}
+	year := start.UTC().Year()
+
+	export := func(staffUserID, homeFacilityID int64, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/members/%d/visits/export?year=%d%s", fixture.memberID, year, query), nil)
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+			ID:             staffUserID,
+			IsStaff:        true,
+			HomeFacilityID: &homeFacilityID,
+		}))
+		recorder := httptest.NewRecorder()
+		HandleMemberVisitsExport(recorder, req)
+		return recorder
+	}
+
+	otherStaff := fixture.insertStaff(t, "desk", fixture.newFacility)
+	if recorder := export(otherStaff, fixture.newFacility, ""); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected staff at another facility to be forbidden, got %d", recorder.Code)
+	}
+	if recorder := export(otherStaff, fixture.newFacility, fmt.Sprintf("&facility_id=%d", fixture.oldFacility)); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected staff at another facility to be forbidden, got %d", recorder.Code)
+	}
+
+	// Staff at the new club only see visits there, and the member has none.
+	recorder := export(otherStaff, fixture.newFacility, fmt.Sprintf("&facility_id=%d", fixture.newFacility))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if records, _ := csv.NewReader(strings.NewReader(recorder.Body.String())).ReadAll(); len(records) != 1 {
+		t.Fatalf("expected only the header for another facility, got %v", records)
+	}
+
+	staffUserID := fixture.insertStaff(t, "desk", fixture.oldFacility)
+	recorder = export(staffUserID, fixture.oldFacility, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	records, err := csv.NewReader(strings.NewReader(recorder.Body.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("parse CSV: %v", err)
+	}
+	if len(records) != 2 || records[1][2] != "Old Club" || records[1][3] != "GAME" || records[1][5] != "Membership" {
+		t.Fatalf("unexpected export rows: %v", records)
+	}
+}
@@ -2,20 +2,30 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"errors"
+	"mime"
 	"net/http"
+	"net/url"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/a-h/templ"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/api/auth"
 	"github.com/codr1/Pickleicious/internal/api/authz"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/metrics"
+	"github.com/codr1/Pickleicious/internal/ratelimit"
+	"github.com/codr1/Pickleicious/internal/templates/components/csrf"
 )
 
 type Middleware func(http.Handler) http.Handler
@@ -45,6 +55,23 @@ func WithLogging(next http.Handler) http.Handler {
 	})
 }
 
+// WithMetrics records handler latency by route pattern. It must wrap the
+// router directly: the mux sets the matched pattern on the request it is
+// handed, and the middleware reads it back after the handler returns.
+func WithMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		wrapped := wrapResponseWriter(w)
+
+		next.ServeHTTP(wrapped, r)
+		status := wrapped.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		metrics.ObserveRequest(r.Method, r.Pattern, status, time.Since(start))
+	})
+}
+
 func WithRecovery(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
@@ -104,10 +131,139 @@ func WithAuth(next http.Handler) http.Handler {
 			r = r.WithContext(ctx)
 		}
 
+		if authz.IsImpersonated(user) {
+			serveImpersonated(w, r, next, user)
+			return
+		}
+
 		next.ServeHTTP(w, r)
 	})
 }
 
+// WithCSRF guards state-changing requests made with session cookies. The
+// token is derived from the cookies (see auth.CSRFToken) and put in the
+// request context for templates; HTMX requests send it in the X-CSRF-Token
+// header set on the page body, plain form posts in the csrf_token field.
+// Requests without a signed-in user carry no ambient credentials and pass,
+// as do JSON calls with an X-Requested-With header, which a cross-site form
+// cannot send. It must run inside WithAuth.
+func WithCSRF(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := auth.CSRFToken(r); token != "" {
+			r = r.WithContext(authz.ContextWithCSRFToken(r.Context(), token))
+		}
+		if csrfExempt(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		submitted := r.Header.Get(authz.CSRFHeaderName)
+		if submitted == "" && isURLEncodedForm(r) {
+			submitted = r.PostFormValue(authz.CSRFFieldName)
+		}
+		if auth.ValidCSRFToken(r, submitted) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		log.Ctx(r.Context()).Warn().
+			Str("method", r.Method).
+			Str("path", r.URL.Path).
+			Bool("token_present", submitted != "").
+			Msg("Rejected request with invalid CSRF token")
+		writeCSRFError(w, r)
+	})
+}
+
+func csrfExempt(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	if authz.UserFromContext(r.Context()) == nil {
+		return true
+	}
+	return apiutil.IsJSONRequest(r) && r.Header.Get("X-Requested-With") != ""
+}
+
+// isURLEncodedForm reports whether the body can be parsed for the token
+// without buffering uploads. Multipart forms are sent by HTMX, which puts the
+// token in the header.
+func isURLEncodedForm(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/x-www-form-urlencoded"
+}
+
+// writeCSRFError answers a stale or missing token. HTMX requests get a
+// dialog appended to the page whatever their target, browser form posts a
+// page linking back, and API clients the error envelope.
+func writeCSRFError(w http.ResponseWriter, r *http.Request) {
+	const message = "Your session has changed. Refresh the page and try again."
+	switch {
+	case apiutil.IsHTMXRequest(r):
+		w.Header().Set("HX-Retarget", "body")
+		w.Header().Set("HX-Reswap", "beforeend")
+		writeCSRFComponent(w, r, csrf.ErrorModal())
+	case apiutil.WantsPlainError(r):
+		writeCSRFComponent(w, r, csrf.ErrorPage(csrfBackURL(r)))
+	default:
+		apiutil.WriteError(w, r, http.StatusForbidden, message)
+	}
+}
+
+func writeCSRFComponent(w http.ResponseWriter, r *http.Request, component templ.Component) {
+	var buf bytes.Buffer
+	if err := component.Render(r.Context(), &buf); err != nil {
+		log.Ctx(r.Context()).Error().Err(err).Msg("Failed to render CSRF error")
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusForbidden)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// csrfBackURL is the same-site page the rejected form was posted from, or
+// the site root.
+func csrfBackURL(r *http.Request) string {
+	referer, err := url.Parse(r.Referer())
+	if err != nil || referer.Path == "" || (referer.Host != "" && referer.Host != r.Host) {
+		return "/"
+	}
+	return referer.RequestURI()
+}
+
+// serveImpersonated runs a request made by staff acting as a member. Emails it
+// triggers are suppressed, and anything other than a read is recorded in the
+// impersonation audit log with its response status.
+func serveImpersonated(w http.ResponseWriter, r *http.Request, next http.Handler, user *authz.AuthUser) {
+	r = r.WithContext(email.WithSuppressed(r.Context()))
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	wrapped := wrapResponseWriter(w)
+	next.ServeHTTP(wrapped, r)
+
+	status := wrapped.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), 5*time.Second)
+	defer cancel()
+	if err := auth.RecordImpersonatedRequest(ctx, user, r.Method, r.URL.Path, status); err != nil {
+		log.Ctx(r.Context()).Error().
+			Err(err).
+			Int64("user_id", *user.ImpersonatorID).
+			Int64("member_id", user.ID).
+			Str("path", r.URL.Path).
+			Msg("Failed to record impersonated request")
+	}
+}
+
 func WithStaffAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger := log.Ctx(r.Context())
@@ -158,6 +314,12 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, so
+// streaming handlers can flush and extend their write deadline.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // WithOrganization extracts the organization from the subdomain and adds it to context.
 // Subdomain format: {org-slug}.{base_domain} (e.g., pickle.localhost)
 func WithOrganization(queries *dbgen.Queries, baseDomain string) Middleware {
@@ -225,3 +387,58 @@ func WithOrganization(queries *dbgen.Queries, baseDomain string) Middleware {
 		})
 	}
 }
+
+// RateLimitKey picks the bucket a request draws from.
+type RateLimitKey func(r *http.Request) string
+
+// ClientIPKey keys requests by client IP, for routes used before login.
+func ClientIPKey(trustProxy bool) RateLimitKey {
+	return func(r *http.Request) string {
+		return "ip:" + ratelimit.GetClientIP(r, trustProxy)
+	}
+}
+
+// UserKey keys requests by the signed-in user, falling back to the client IP
+// when there is no session.
+func UserKey(trustProxy bool) RateLimitKey {
+	return func(r *http.Request) string {
+		if user := authz.UserFromContext(r.Context()); user != nil {
+			return "user:" + strconv.FormatInt(user.ID, 10)
+		}
+		return "ip:" + ratelimit.GetClientIP(r, trustProxy)
+	}
+}
+
+// WithRateLimit rejects requests with 429 once their bucket is empty. A nil
+// limiter disables the check, which is how rate_limit.enabled=false is
+// honored.
+func WithRateLimit(name string, limiter *ratelimit.Buckets, key RateLimitKey) Middleware {
+	return func(next http.Handler) http.Handler {
+		if limiter == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			k := key(r)
+			allowed, retryAfter := limiter.Allow(k)
+			if allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			seconds := int((retryAfter + time.Second - 1) / time.Second)
+			if seconds < 1 {
+				seconds = 1
+			}
+			log.Ctx(r.Context()).Warn().
+				Str("event", "rate_limit_exceeded").
+				Str("limiter", name).
+				Str("key", k).
+				Str("method", r.Method).
+				Str("path", r.URL.Path).
+				Int("retry_after_seconds", seconds).
+				Msg("Request rate limit exceeded")
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			http.Error(w, "Too many requests. Please slow down and try again shortly.", http.StatusTooManyRequests)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/auth"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/metrics"
+	"github.com/codr1/Pickleicious/internal/ratelimit"
+)
+
+func TestWithRateLimit_RejectsOverBurstPerUser(t *testing.T) {
+	limiter := ratelimit.NewBuckets(ratelimit.BucketConfig{RequestsPerMinute: 6, Burst: 2})
+	defer limiter.Close()
+
+	handler := ChainMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}), WithRateLimit("booking", limiter, UserKey(false)))
+
+	send := func(userID int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/member/reservations", nil)
+		req.RemoteAddr = "203.0.113.7:4000"
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: userID}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	for i := 0; i < 2; i++ {
+		if got := send(1).Code; got != http.StatusCreated {
+			t.Fatalf("request %d: expected status %d, got %d", i+1, http.StatusCreated, got)
+		}
+	}
+
+	recorder := send(1)
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, recorder.Code)
+	}
+	if got := recorder.Header().Get("Retry-After"); got != "10" {
+		t.Fatalf("expected Retry-After 10 at 6/min, got %q", got)
+	}
+
+	// A second member behind the same IP keeps their own budget.
+	if got := send(2).Code; got != http.StatusCreated {
+		t.Fatalf("expected another user to be allowed, got %d", got)
+	}
+}
+
+func TestWithRateLimit_NilLimiterPassesThrough(t *testing.T) {
+	handler := ChainMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), WithRateLimit("anonymous", nil, ClientIPKey(false)))
+
+	for i := 0; i < 50; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/login", nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("request %d: expected status %d, got %d", i+1, http.StatusOK, recorder.Code)
+		}
+	}
+}
+
+func TestWithMetrics_LabelsByRoutePattern(t *testing.T) {
+	router := http.NewServeMux()
+	router.HandleFunc("/api/v1/reservations/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := ChainMiddleware(router, WithMetrics)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/reservations/8675309", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	scrape := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(scrape, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := scrape.Body.String()
+	want := `pickleicious_http_request_duration_seconds_count{method="DELETE",route="/api/v1/reservations/{id}",status="2xx"} 1`
+	if !strings.Contains(body, want) {
+		t.Fatalf("expected %q in metrics output", want)
+	}
+	if strings.Contains(body, "8675309") {
+		t.Fatal("expected the raw path to stay out of the labels")
+	}
+}
+
+func csrfTestHandler(t *testing.T) http.Handler {
+	t.Helper()
+	return ChainMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Test-Token", authz.CSRFTokenFromContext(r.Context()))
+		w.WriteHeader(http.StatusNoContent)
+	}), WithCSRF)
+}
+
+func newCSRFTestRequest(method, target, session string, body *strings.Reader) *http.Request {
+	var req *http.Request
+	if body == nil {
+		req = httptest.NewRequest(method, target, nil)
+	} else {
+		req = httptest.NewRequest(method, target, body)
+	}
+	req.AddCookie(&http.Cookie{Name: "pickleicious_session", Value: session})
+	return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: 7}))
+}
+
+func TestWithCSRF_HTMXDeleteWithoutBody(t *testing.T) {
+	handler := csrfTestHandler(t)
+
+	// Page load: the token reaches templates through the context.
+	page := newCSRFTestRequest(http.MethodGet, "/member", "session-a", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, page)
+	token := recorder.Header().Get("X-Test-Token")
+	if token == "" || token != auth.CSRFToken(page) {
+		t.Fatalf("expected the session token in the context, got %q", token)
+	}
+
+	// hx-delete sends no body, so the token only travels in the header.
+	req := newCSRFTestRequest(http.MethodDelete, "/member/booking/hold", "session-a", nil)
+	req.Header.Set("HX-Request", "true")
+	req.Header.Set(authz.CSRFHeaderName, token)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, recorder.Code)
+	}
+
+	req = newCSRFTestRequest(http.MethodDelete, "/member/booking/hold", "session-a", nil)
+	req.Header.Set("HX-Request", "true")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d without a token, got %d", http.StatusForbidden, recorder.Code)
+	}
+	if got := recorder.Header().Get("HX-Retarget"); got != "body" {
+		t.Fatalf("expected HX-Retarget body, got %q", got)
+	}
+	if got := recorder.Header().Get("HX-Reswap"); got != "beforeend" {
+		t.Fatalf("expected HX-Reswap beforeend, got %q", got)
+	}
+	if !strings.Contains(recorder.Body.String(), "Your session has changed") {
+		t.Fatalf("expected the refresh dialog, got %q", recorder.Body.String())
+	}
+}
+
+func TestWithCSRF_FormField(t *testing.T) {
+	handler := csrfTestHandler(t)
+	token := auth.CSRFToken(newCSRFTestRequest(http.MethodGet, "/", "session-a", nil))
+
+	post := func(value string) *httptest.ResponseRecorder {
+		form := url.Values{"csrf_token": {value}}
+		req := newCSRFTestRequest(http.MethodPost, "/household-links/abc/decline", "session-a", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "text/html")
+		req.Header.Set("Referer", "https://example.com/household-links/abc/decline")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	if got := post(token).Code; got != http.StatusNoContent {
+		t.Fatalf("expected status %d with the form token, got %d", http.StatusNoContent, got)
+	}
+	recorder := post("forged")
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d with a forged token, got %d", http.StatusForbidden, recorder.Code)
+	}
+	if !strings.Contains(recorder.Body.String(), `href="/household-links/abc/decline"`) {
+		t.Fatalf("expected a link back to the form, got %q", recorder.Body.String())
+	}
+}
+
+func TestWithCSRF_TokenRotatesWithSession(t *testing.T) {
+	handler := csrfTestHandler(t)
+	oldToken := auth.CSRFToken(newCSRFTestRequest(http.MethodGet, "/", "session-a", nil))
+
+	// Signing in again issues a new cookie; pages loaded before it go stale.
+	req := newCSRFTestRequest(http.MethodPost, "/api/v1/staff", "session-b", nil)
+	req.Header.Set(authz.CSRFHeaderName, oldToken)
+	req.Header.Set("Accept", "application/json")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d for a token from the old session, got %d", http.StatusForbidden, recorder.Code)
+	}
+	if !strings.Contains(recorder.Body.String(), `"code":"forbidden"`) {
+		t.Fatalf("expected the JSON error envelope, got %q", recorder.Body.String())
+	}
+}
+
+func TestWithCSRF_Exemptions(t *testing.T) {
+	handler := csrfTestHandler(t)
+
+	jsonReq := newCSRFTestRequest(http.MethodPost, "/api/v1/reservations", "session-a", strings.NewReader(`{}`))
+	jsonReq.Header.Set("Content-Type", "application/json")
+	jsonReq.Header.Set("X-Requested-With", "XMLHttpRequest")
+
+	anonymous := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader("identifier=a"))
+	anonymous.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	for name, req := range map[string]*http.Request{"json with custom header": jsonReq, "anonymous": anonymous} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusNoContent {
+			t.Fatalf("%s: expected status %d, got %d", name, http.StatusNoContent, recorder.Code)
+		}
+	}
+
+	// JSON alone is not enough: a cross-site form can post text/plain bodies
+	// but not custom headers.
+	plainJSON := newCSRFTestRequest(http.MethodPost, "/api/v1/reservations", "session-a", strings.NewReader(`{}`))
+	plainJSON.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, plainJSON)
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d for JSON without the custom header, got %d", http.StatusForbidden, recorder.Code)
+	}
+}
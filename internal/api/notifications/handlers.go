@@ -183,7 +183,7 @@ func HandleMarkAsRead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("HX-Trigger", "refreshNotificationCount")
+	apiutil.AppendHXTrigger(w, "refreshNotificationCount")
 	if !htmx.IsRequest(r) {
 		w.WriteHeader(http.StatusNoContent)
 		return
@@ -0,0 +1,112 @@
+package openplay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	openplayengine "github.com/codr1/Pickleicious/internal/openplay"
+)
+
+// openPlayGenerateTimeout covers a full MaxGenerationDays run, which commits
+// each session separately.
+const openPlayGenerateTimeout = 30 * time.Second
+
+const generateDateLayout = "2006-01-02"
+
+type openPlayGenerateRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// POST /api/v1/facilities/{id}/openplay/generate
+// Materializes sessions from the facility's open play rule slots for the
+// from-to date range and returns what was created and skipped.
+func HandleOpenPlayGenerate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	engine, err := openplayengine.NewEngine(loadDB(), emailClient)
+	if err != nil {
+		logger.Error().Err(err).Msg("Open play engine not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || facilityID <= 0 {
+		http.Error(w, "invalid facility ID", http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req openPlayGenerateRequest
+	if apiutil.IsJSONRequest(r) {
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+		req = openPlayGenerateRequest{From: r.FormValue("from"), To: r.FormValue("to")}
+	}
+	from, to, err := parseGenerateRange(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), openPlayGenerateTimeout)
+	defer cancel()
+
+	summary, err := engine.GenerateSessions(ctx, openplayengine.GenerationRequest{
+		FacilityID:      facilityID,
+		From:            from,
+		To:              to,
+		CreatedByUserID: user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to generate open play sessions")
+		http.Error(w, "Failed to generate open play sessions", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, summary); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write open play generation response")
+		return
+	}
+}
+
+func parseGenerateRange(req openPlayGenerateRequest) (time.Time, time.Time, error) {
+	from, err := time.Parse(generateDateLayout, strings.TrimSpace(req.From))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must be a YYYY-MM-DD date")
+	}
+	to, err := time.Parse(generateDateLayout, strings.TrimSpace(req.To))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must be a YYYY-MM-DD date")
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must not be before from")
+	}
+	if to.After(from.AddDate(0, 0, openplayengine.MaxGenerationDays-1)) {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range cannot exceed %d days", openplayengine.MaxGenerationDays)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,288 @@
+package openplay
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	openplayengine "github.com/codr1/Pickleicious/internal/openplay"
+)
+
+type generateFixture struct {
+	database   *db.DB
+	facilityID int64
+	staffID    int64
+	rule       dbgen.OpenPlayRule
+	monday     time.Time
+}
+
+// setupGenerateTest gives the facility two courts, Monday hours of
+// 08:00-20:00, and a two-court rule with a Monday 09:00-11:00 slot.
+func setupGenerateTest(t *testing.T) generateFixture {
+	t.Helper()
+
+	database, facilityID := setupOpenPlayTest(t)
+	ctx := context.Background()
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	staffID := exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_staff, home_facility_id)
+		 VALUES ('Desk', 'Staff', 'desk@test.com', 'active', 1, ?)`,
+		facilityID,
+	)
+	exec("INSERT INTO courts (facility_id, name, court_number) VALUES (?, 'Court 1', 1)", facilityID)
+	exec("INSERT INTO courts (facility_id, name, court_number) VALUES (?, 'Court 2', 2)", facilityID)
+	exec("INSERT INTO operating_hours (facility_id, day_of_week, opens_at, closes_at) VALUES (?, 1, '08:00', '20:00')", facilityID)
+
+	rule, err := database.Queries.CreateOpenPlayRule(ctx, dbgen.CreateOpenPlayRuleParams{
+		FacilityID:                facilityID,
+		Name:                      "Morning Open Play",
+		MinParticipants:           4,
+		MaxParticipantsPerCourt:   8,
+		CancellationCutoffMinutes: 60,
+		MinCourts:                 2,
+		MaxCourts:                 2,
+	})
+	if err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+
+	fixture := generateFixture{
+		database:   database,
+		facilityID: facilityID,
+		staffID:    staffID,
+		rule:       rule,
+	}
+	recorder := fixture.createSlot(t, rule.ID, `{"dayOfWeek":1,"startTime":"09:00","endTime":"11:00"}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("create slot status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	monday := time.Now().UTC().AddDate(0, 0, 7)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, 1)
+	}
+	fixture.monday = time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, time.UTC)
+	return fixture
+}
+
+func (f generateFixture) withStaff(req *http.Request) *http.Request {
+	homeFacilityID := f.facilityID
+	user := &authz.AuthUser{
+		ID:             f.staffID,
+		IsStaff:        true,
+		HomeFacilityID: &homeFacilityID,
+	}
+	return req.WithContext(authz.ContextWithUser(req.Context(), user))
+}
+
+func (f generateFixture) createSlot(t *testing.T, ruleID int64, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("/api/v1/open-play-rules/%d/slots?facility_id=%d", ruleID, f.facilityID),
+		strings.NewReader(body),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", fmt.Sprintf("%d", ruleID))
+	recorder := httptest.NewRecorder()
+	HandleOpenPlayRuleSlotCreate(recorder, f.withStaff(req))
+	return recorder
+}
+
+func (f generateFixture) generate(t *testing.T, from, to time.Time) openplayengine.GenerationSummary {
+	t.Helper()
+
+	body := fmt.Sprintf(`{"from":%q,"to":%q}`, from.Format(generateDateLayout), to.Format(generateDateLayout))
+	req := httptest.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("/api/v1/facilities/%d/openplay/generate", f.facilityID),
+		strings.NewReader(body),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", fmt.Sprintf("%d", f.facilityID))
+	recorder := httptest.NewRecorder()
+	HandleOpenPlayGenerate(recorder, f.withStaff(req))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("generate status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var summary openplayengine.GenerationSummary
+	if err := json.NewDecoder(recorder.Body).Decode(&summary); err != nil {
+		t.Fatalf("decode summary: %v", err)
+	}
+	return summary
+}
+
+func TestHandleOpenPlayGenerate_CreatesSessionsOnceAndReportsSkips(t *testing.T) {
+	f := setupGenerateTest(t)
+	ctx := context.Background()
+
+	nextMonday := f.monday.AddDate(0, 0, 7)
+	if _, err := f.database.ExecContext(ctx,
+		"INSERT INTO facility_hours_overrides (facility_id, override_date, is_closed, reason) VALUES (?, ?, 1, 'Holiday')",
+		f.facilityID, nextMonday.Format("2006-01-02"),
+	); err != nil {
+		t.Fatalf("insert hours override: %v", err)
+	}
+	evening, err := f.database.Queries.CreateOpenPlayRule(ctx, dbgen.CreateOpenPlayRuleParams{
+		FacilityID:                f.facilityID,
+		Name:                      "Late Open Play",
+		MinParticipants:           4,
+		MaxParticipantsPerCourt:   8,
+		CancellationCutoffMinutes: 60,
+		MinCourts:                 1,
+		MaxCourts:                 2,
+	})
+	if err != nil {
+		t.Fatalf("create evening rule: %v", err)
+	}
+	if recorder := f.createSlot(t, evening.ID, `{"dayOfWeek":1,"startTime":"19:00","endTime":"21:00"}`); recorder.Code != http.StatusCreated {
+		t.Fatalf("create evening slot status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	summary := f.generate(t, f.monday, nextMonday)
+	if len(summary.Created) != 1 {
+		t.Fatalf("expected 1 created session, got %+v", summary.Created)
+	}
+	created := summary.Created[0]
+	if created.OpenPlayRuleID != f.rule.ID || created.CourtCount != 2 {
+		t.Fatalf("unexpected created session: %+v", created)
+	}
+	reasons := map[string]int{}
+	for _, skipped := range summary.Skipped {
+		reasons[skipped.Reason]++
+	}
+	if reasons[openplayengine.SkipReasonOutsideHours] != 1 || reasons[openplayengine.SkipReasonFacilityClosed] != 2 || len(summary.Skipped) != 3 {
+		t.Fatalf("unexpected skipped sessions: %+v", summary.Skipped)
+	}
+
+	session, err := f.database.Queries.GetOpenPlaySession(ctx, dbgen.GetOpenPlaySessionParams{
+		ID:         created.SessionID,
+		FacilityID: f.facilityID,
+	})
+	if err != nil {
+		t.Fatalf("load generated session: %v", err)
+	}
+	if session.Status != "scheduled" || session.CurrentCourtCount != 2 {
+		t.Fatalf("unexpected generated session: %+v", session)
+	}
+	reservationCount, err := f.database.Queries.CountOpenPlayReservationsForSession(ctx, dbgen.CountOpenPlayReservationsForSessionParams{
+		FacilityID:     f.facilityID,
+		OpenPlayRuleID: sql.NullInt64{Int64: f.rule.ID, Valid: true},
+		StartTime:      session.StartTime,
+		EndTime:        session.EndTime,
+	})
+	if err != nil {
+		t.Fatalf("count open play reservations: %v", err)
+	}
+	if reservationCount != 1 {
+		t.Fatalf("expected exactly 1 backing reservation, got %d", reservationCount)
+	}
+	courts, err := f.database.Queries.ListReservationCourts(ctx, created.ReservationID)
+	if err != nil {
+		t.Fatalf("list reservation courts: %v", err)
+	}
+	if len(courts) != 2 {
+		t.Fatalf("expected 2 reserved courts, got %d", len(courts))
+	}
+
+	rerun := f.generate(t, f.monday, f.monday)
+	if len(rerun.Created) != 0 {
+		t.Fatalf("expected rerun to create nothing, got %+v", rerun.Created)
+	}
+	var sawExisting bool
+	for _, skipped := range rerun.Skipped {
+		if skipped.OpenPlayRuleID == f.rule.ID && skipped.Reason == openplayengine.SkipReasonSessionExists {
+			sawExisting = true
+		}
+	}
+	if !sawExisting {
+		t.Fatalf("expected existing session to be skipped, got %+v", rerun.Skipped)
+	}
+}
+
+func TestHandleOpenPlayGenerate_SkipsWhenCourtsUnavailable(t *testing.T) {
+	f := setupGenerateTest(t)
+	ctx := context.Background()
+
+	reservationType, err := f.database.Queries.GetReservationTypeByName(ctx, "GAME")
+	if err != nil {
+		t.Fatalf("load reservation type: %v", err)
+	}
+	reservation, err := f.database.Queries.CreateReservation(ctx, dbgen.CreateReservationParams{
+		FacilityID:        f.facilityID,
+		ReservationTypeID: reservationType.ID,
+		CreatedByUserID:   f.staffID,
+		StartTime:         f.monday.Add(10 * time.Hour),
+		EndTime:           f.monday.Add(12 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("create blocking reservation: %v", err)
+	}
+	var courtID int64
+	if err := f.database.QueryRowContext(ctx, "SELECT id FROM courts WHERE facility_id = ? AND court_number = 1", f.facilityID).Scan(&courtID); err != nil {
+		t.Fatalf("load court: %v", err)
+	}
+	if err := f.database.Queries.AddReservationCourt(ctx, dbgen.AddReservationCourtParams{
+		ReservationID: reservation.ID,
+		CourtID:       courtID,
+	}); err != nil {
+		t.Fatalf("reserve court: %v", err)
+	}
+
+	summary := f.generate(t, f.monday, f.monday)
+	if len(summary.Created) != 0 || len(summary.Skipped) != 1 {
+		t.Fatalf("expected a single skipped session, got %+v", summary)
+	}
+	if summary.Skipped[0].Reason != openplayengine.SkipReasonCourtsUnavailable {
+		t.Fatalf("expected courts_unavailable, got %+v", summary.Skipped[0])
+	}
+
+	sessions, err := f.database.Queries.ListOpenPlaySessions(ctx, f.facilityID)
+	if err != nil {
+		t.Fatalf("list sessions: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("expected no sessions, got %d", len(sessions))
+	}
+}
+
+func TestHandleOpenPlayRuleSlotCreate_Validation(t *testing.T) {
+	f := setupGenerateTest(t)
+
+	cases := map[string]string{
+		"missing day":    `{"startTime":"09:00","endTime":"10:00"}`,
+		"bad day":        `{"dayOfWeek":7,"startTime":"09:00","endTime":"10:00"}`,
+		"end before":     `{"dayOfWeek":2,"startTime":"10:00","endTime":"09:00"}`,
+		"malformed time": `{"dayOfWeek":2,"startTime":"9am","endTime":"10:00"}`,
+	}
+	for name, body := range cases {
+		if recorder := f.createSlot(t, f.rule.ID, body); recorder.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", name, recorder.Code)
+		}
+	}
+
+	duplicate := f.createSlot(t, f.rule.ID, `{"dayOfWeek":1,"startTime":"09:00","endTime":"11:00"}`)
+	if duplicate.Code != http.StatusConflict {
+		t.Fatalf("expected duplicate slot conflict, got %d", duplicate.Code)
+	}
+}
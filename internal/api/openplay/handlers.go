@@ -24,6 +24,7 @@ import (
 	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/metrics"
 	"github.com/codr1/Pickleicious/internal/models"
 	openplaytempl "github.com/codr1/Pickleicious/internal/templates/components/openplay"
 	"github.com/codr1/Pickleicious/internal/templates/layouts"
@@ -261,10 +262,26 @@ func HandleOpenPlayRuleCreate(w http.ResponseWriter, r *http.Request) {
 
 	autoScaleEnabled := apiutil.ParseBool(r.FormValue("auto_scale_enabled"))
 
+	minMembershipLevel, maxMembershipLevel, err := parseMembershipLevelFields(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bands, skillBands, err := parseSkillBandsField(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	if err := validateOpenPlayRuleInput(minParticipants, maxParticipantsPerCourt, cancellationCutoffMinutes, minCourts, maxCourts); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := validateOpenPlayRuleMembershipLevels(minMembershipLevel, maxMembershipLevel); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), openPlayQueryTimeout)
 	defer cancel()
@@ -272,6 +289,14 @@ func HandleOpenPlayRuleCreate(w http.ResponseWriter, r *http.Request) {
 	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
 		return
 	}
+	if err := ensureSkillBandCourts(ctx, q, facilityID, bands); err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) && herr.Status == http.StatusInternalServerError {
+			logger.Error().Err(herr.Err).Int64("facility_id", facilityID).Msg(herr.Message)
+		}
+		http.Error(w, herr.Message, herr.Status)
+		return
+	}
 	rule, err := q.CreateOpenPlayRule(ctx, dbgen.CreateOpenPlayRuleParams{
 		FacilityID:                facilityID,
 		Name:                      name,
@@ -281,6 +306,9 @@ func HandleOpenPlayRuleCreate(w http.ResponseWriter, r *http.Request) {
 		AutoScaleEnabled:          autoScaleEnabled,
 		MinCourts:                 minCourts,
 		MaxCourts:                 maxCourts,
+		MinMembershipLevel:        minMembershipLevel,
+		MaxMembershipLevel:        maxMembershipLevel,
+		SkillBands:                skillBands,
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to create open play rule")
@@ -411,10 +439,26 @@ func HandleOpenPlayRuleUpdate(w http.ResponseWriter, r *http.Request) {
 
 	autoScaleEnabled := apiutil.ParseBool(r.FormValue("auto_scale_enabled"))
 
+	minMembershipLevel, maxMembershipLevel, err := parseMembershipLevelFields(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bands, skillBands, err := parseSkillBandsField(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	if err := validateOpenPlayRuleInput(minParticipants, maxParticipantsPerCourt, cancellationCutoffMinutes, minCourts, maxCourts); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := validateOpenPlayRuleMembershipLevels(minMembershipLevel, maxMembershipLevel); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), openPlayQueryTimeout)
 	defer cancel()
@@ -422,6 +466,14 @@ func HandleOpenPlayRuleUpdate(w http.ResponseWriter, r *http.Request) {
 	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
 		return
 	}
+	if err := ensureSkillBandCourts(ctx, q, facilityID, bands); err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) && herr.Status == http.StatusInternalServerError {
+			logger.Error().Err(herr.Err).Int64("facility_id", facilityID).Msg(herr.Message)
+		}
+		http.Error(w, herr.Message, herr.Status)
+		return
+	}
 	rule, err := q.UpdateOpenPlayRule(ctx, dbgen.UpdateOpenPlayRuleParams{
 		ID:                        ruleID,
 		FacilityID:                facilityID,
@@ -432,6 +484,9 @@ func HandleOpenPlayRuleUpdate(w http.ResponseWriter, r *http.Request) {
 		AutoScaleEnabled:          autoScaleEnabled,
 		MinCourts:                 minCourts,
 		MaxCourts:                 maxCourts,
+		MinMembershipLevel:        minMembershipLevel,
+		MaxMembershipLevel:        maxMembershipLevel,
+		SkillBands:                skillBands,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -599,6 +654,9 @@ func HandleOpenPlaySessionAutoScaleToggle(w http.ResponseWriter, r *http.Request
 				AutoScaleEnabled:          false,
 				MinCourts:                 rule.MinCourts,
 				MaxCourts:                 rule.MaxCourts,
+				MinMembershipLevel:        rule.MinMembershipLevel,
+				MaxMembershipLevel:        rule.MaxMembershipLevel,
+				SkillBands:                rule.SkillBands,
 			})
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
@@ -642,6 +700,125 @@ func HandleOpenPlaySessionAutoScaleToggle(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// HandleOpenPlaySessionSkillBands handles PUT and DELETE
+// /api/v1/open-play-sessions/{id}/skill-bands. PUT replaces the session's
+// skill bands, overriding its rule's (an empty list turns them off); DELETE
+// returns the session to its rule's bands.
+func HandleOpenPlaySessionSkillBands(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	database := loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	sessionID, err := openPlaySessionIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	facilityID, err := facilityIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Facility ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var payload openPlaySessionSkillBandsRequest
+	if r.Method == http.MethodPut {
+		if err := apiutil.DecodeJSON(r, &payload); err != nil {
+			http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		if err := apiutil.ValidateOpenPlaySkillBands(payload.SkillBands); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), openPlayQueryTimeout)
+	defer cancel()
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	var response openPlaySessionSkillBandsResponse
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		if _, err := fetchOpenPlaySession(ctx, qtx, sessionID, facilityID); err != nil {
+			return err
+		}
+
+		if r.Method == http.MethodPut {
+			if err := ensureSkillBandCourts(ctx, qtx, facilityID, payload.SkillBands); err != nil {
+				return err
+			}
+			encoded, err := apiutil.MarshalOpenPlaySkillBands(payload.SkillBands)
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to save skill bands", Err: err}
+			}
+			if _, err := qtx.UpsertOpenPlaySessionSkillBands(ctx, dbgen.UpsertOpenPlaySessionSkillBandsParams{
+				SessionID:  sessionID,
+				SkillBands: encoded,
+			}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to save skill bands", Err: err}
+			}
+		} else if _, err := qtx.DeleteOpenPlaySessionSkillBands(ctx, sessionID); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to reset skill bands", Err: err}
+		}
+
+		row, err := qtx.GetOpenPlaySessionSkillBands(ctx, dbgen.GetOpenPlaySessionSkillBandsParams{
+			SessionID:  sessionID,
+			FacilityID: facilityID,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load skill bands", Err: err}
+		}
+		bands, err := apiutil.EffectiveOpenPlaySkillBands(row.RuleSkillBands, row.SessionSkillBands)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load skill bands", Err: err}
+		}
+		response = openPlaySessionSkillBandsResponse{
+			SessionID:  sessionID,
+			Override:   row.SessionSkillBands.Valid,
+			SkillBands: bands,
+		}
+		return nil
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("session_id", sessionID).Msg(herr.Message)
+			}
+			http.Error(w, herr.Message, herr.Status)
+			return
+		}
+		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to update open play skill bands")
+		http.Error(w, "Failed to update skill bands", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info().
+		Int64("session_id", sessionID).
+		Bool("override", response.Override).
+		Int("skill_band_count", len(response.SkillBands)).
+		Msg("Updated open play session skill bands")
+
+	if response.SkillBands == nil {
+		response.SkillBands = []apiutil.OpenPlaySkillBand{}
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to write open play skill bands response")
+		return
+	}
+}
+
 func HandleAddParticipant(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
@@ -685,7 +862,7 @@ func HandleAddParticipant(w http.ResponseWriter, r *http.Request) {
 	var facility dbgen.Facility
 	var facilityErr error
 	if emailClient != nil {
-		facility, facilityErr = q.GetFacilityByID(ctx, facilityID)
+		facility, facilityErr = loadFacilities().GetFacilityByID(ctx, facilityID)
 		if facilityErr != nil {
 			logger.Error().Err(facilityErr).Int64("facility_id", facilityID).Msg("Failed to load facility for confirmation email")
 		}
@@ -719,13 +896,33 @@ func HandleAddParticipant(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		if _, err := qtx.GetUserByID(ctx, payload.UserID); err != nil {
+		member, err := qtx.GetUserByID(ctx, payload.UserID)
+		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "User not found", Err: err}
 			}
 			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch user", Err: err}
 		}
 
+		sessionRule, err := qtx.GetOpenPlayRule(ctx, dbgen.GetOpenPlayRuleParams{
+			ID:         session.OpenPlayRuleID,
+			FacilityID: facilityID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Open play rule not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch open play rule", Err: err}
+		}
+		eligible := apiutil.OpenPlayEligible(member.MembershipLevel, sessionRule.MinMembershipLevel, sessionRule.MaxMembershipLevel)
+		if !eligible && !payload.OverrideEligibility {
+			message := fmt.Sprintf(
+				"Member is not eligible for this session (%s); set override_eligibility to add them anyway",
+				apiutil.OpenPlayEligibilityLabel(sessionRule.MinMembershipLevel, sessionRule.MaxMembershipLevel),
+			)
+			return apiutil.HandlerError{Status: http.StatusForbidden, Message: message}
+		}
+
 		participant, err = qtx.AddOpenPlayParticipant(ctx, dbgen.AddOpenPlayParticipantParams{
 			UserID:         payload.UserID,
 			FacilityID:     facilityID,
@@ -740,10 +937,16 @@ func HandleAddParticipant(w http.ResponseWriter, r *http.Request) {
 			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add participant", Err: err}
 		}
 
-		if err := createOpenPlayAuditEntry(ctx, qtx, session.ID, openPlayAuditParticipantAdded, map[string]any{}, map[string]any{
+		afterState := map[string]any{
 			"user_id":        payload.UserID,
 			"reservation_id": reservationID,
-		}, sql.NullString{}); err != nil {
+		}
+		auditReason := sql.NullString{}
+		if !eligible {
+			afterState["override_eligibility"] = true
+			auditReason = sql.NullString{String: "eligibility_override", Valid: true}
+		}
+		if err := createOpenPlayAuditEntry(ctx, qtx, session.ID, openPlayAuditParticipantAdded, map[string]any{}, afterState, auditReason); err != nil {
 			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to log open play participant add", Err: err}
 		}
 
@@ -762,6 +965,7 @@ func HandleAddParticipant(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to add participant", http.StatusInternalServerError)
 		return
 	}
+	metrics.OpenPlaySignup(facilityID)
 
 	if emailClient != nil && facility.ID != 0 {
 		var ruleErr error
@@ -779,14 +983,7 @@ func HandleAddParticipant(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if emailClient != nil && facility.ID != 0 {
-		facilityLoc := time.Local
-		if facility.Timezone != "" {
-			if loadedLoc, loadErr := time.LoadLocation(facility.Timezone); loadErr == nil {
-				facilityLoc = loadedLoc
-			} else {
-				logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone for confirmation email")
-			}
-		}
+		facilityLoc := apiutil.FacilityLocation(facility, logger)
 		date, timeRange := email.FormatDateTimeRange(session.StartTime.In(facilityLoc), session.EndTime.In(facilityLoc))
 		courtsLabel := fmt.Sprintf("%d courts", session.CurrentCourtCount)
 		if session.CurrentCourtCount == 1 {
@@ -806,12 +1003,14 @@ func HandleAddParticipant(w http.ResponseWriter, r *http.Request) {
 		go func() {
 			emailCtx, emailCancel := context.WithTimeout(context.Background(), openPlayQueryTimeout)
 			defer emailCancel()
-			email.SendConfirmationEmail(emailCtx, q, emailClient, payload.UserID, confirmation, logger)
+			if email.ShouldSend(emailCtx, q, payload.UserID, email.PreferenceConfirmations) {
+				email.SendConfirmationEmail(emailCtx, q, emailClient, payload.UserID, confirmation, logger)
+			}
 		}()
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshOpenPlayParticipants")
+		apiutil.AppendHXTrigger(w, "refreshOpenPlayParticipants")
 	}
 	if err := apiutil.WriteJSON(w, http.StatusCreated, participant); err != nil {
 		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to write open play participant response")
@@ -881,6 +1080,12 @@ func HandleRemoveParticipant(w http.ResponseWriter, r *http.Request) {
 		if removed == 0 {
 			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Participant not found"}
 		}
+		if err := qtx.DeleteOpenPlayQueueEntry(ctx, dbgen.DeleteOpenPlayQueueEntryParams{
+			SessionID: session.ID,
+			UserID:    userID,
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove participant from the queue", Err: err}
+		}
 
 		if err := createOpenPlayAuditEntry(ctx, qtx, session.ID, openPlayAuditParticipantRemoved, map[string]any{
 			"user_id":        userID,
@@ -906,7 +1111,7 @@ func HandleRemoveParticipant(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshOpenPlayParticipants")
+		apiutil.AppendHXTrigger(w, "refreshOpenPlayParticipants")
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -1052,6 +1257,15 @@ func loadQueries() *dbgen.Queries {
 	return queries
 }
 
+// loadFacilities prefers the store's facility cache for the confirmation
+// email lookups.
+func loadFacilities() apiutil.FacilityQuerier {
+	if store == nil || store.Facilities == nil {
+		return queries
+	}
+	return store.Facilities
+}
+
 func loadDB() *appdb.DB {
 	return store
 }
@@ -1178,8 +1392,21 @@ type openPlaySessionAutoScaleToggleRequest struct {
 	DisableForRule bool `json:"disable_for_rule"`
 }
 
+type openPlaySessionSkillBandsRequest struct {
+	SkillBands []apiutil.OpenPlaySkillBand `json:"skill_bands"`
+}
+
+type openPlaySessionSkillBandsResponse struct {
+	SessionID  int64                       `json:"sessionId"`
+	Override   bool                        `json:"override"`
+	SkillBands []apiutil.OpenPlaySkillBand `json:"skillBands"`
+}
+
 type openPlayParticipantRequest struct {
 	UserID int64 `json:"user_id"`
+	// OverrideEligibility lets staff add a member outside the rule's
+	// membership level range.
+	OverrideEligibility bool `json:"override_eligibility"`
 }
 
 func parseIntField(r *http.Request, name string) (int64, error) {
@@ -1194,6 +1421,75 @@ func parseIntField(r *http.Request, name string) (int64, error) {
 	return parsed, nil
 }
 
+// parseMembershipLevelFields reads the optional eligibility bounds. A blank
+// minimum admits every level and a blank maximum means no ceiling.
+func parseMembershipLevelFields(r *http.Request) (int64, sql.NullInt64, error) {
+	var minLevel int64
+	if strings.TrimSpace(r.FormValue("min_membership_level")) != "" {
+		parsed, err := parseIntField(r, "min_membership_level")
+		if err != nil {
+			return 0, sql.NullInt64{}, err
+		}
+		minLevel = parsed
+	}
+
+	var maxLevel sql.NullInt64
+	if strings.TrimSpace(r.FormValue("max_membership_level")) != "" {
+		parsed, err := parseIntField(r, "max_membership_level")
+		if err != nil {
+			return 0, sql.NullInt64{}, err
+		}
+		maxLevel = sql.NullInt64{Int64: parsed, Valid: true}
+	}
+
+	return minLevel, maxLevel, nil
+}
+
+// parseSkillBandsField reads the optional skill_bands JSON array. A blank
+// value or an empty array leaves the rule unsplit.
+func parseSkillBandsField(r *http.Request) ([]apiutil.OpenPlaySkillBand, sql.NullString, error) {
+	raw := strings.TrimSpace(r.FormValue("skill_bands"))
+	if raw == "" {
+		return nil, sql.NullString{}, nil
+	}
+	bands, err := apiutil.ParseOpenPlaySkillBands(sql.NullString{String: raw, Valid: true})
+	if err != nil {
+		return nil, sql.NullString{}, apiutil.FieldError{Field: "skill_bands", Reason: "must be a JSON array of skill bands"}
+	}
+	if len(bands) == 0 {
+		return nil, sql.NullString{}, nil
+	}
+	if err := apiutil.ValidateOpenPlaySkillBands(bands); err != nil {
+		return nil, sql.NullString{}, err
+	}
+	encoded, err := apiutil.MarshalOpenPlaySkillBands(bands)
+	if err != nil {
+		return nil, sql.NullString{}, err
+	}
+	return bands, sql.NullString{String: encoded, Valid: true}, nil
+}
+
+// ensureSkillBandCourts checks that every band court is one of the
+// facility's courts.
+func ensureSkillBandCourts(ctx context.Context, q *dbgen.Queries, facilityID int64, bands []apiutil.OpenPlaySkillBand) error {
+	courts, err := q.ListCourts(ctx, facilityID)
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load courts", Err: err}
+	}
+	known := make(map[int64]bool, len(courts))
+	for _, court := range courts {
+		known[court.CourtNumber] = true
+	}
+	for _, band := range bands {
+		for _, number := range band.CourtNumbers {
+			if !known[number] {
+				return apiutil.HandlerError{Status: http.StatusBadRequest, Message: fmt.Sprintf("Court %d does not exist at this facility", number)}
+			}
+		}
+	}
+	return nil
+}
+
 func auditBoolValue(value sql.NullBool) any {
 	if value.Valid {
 		return value.Bool
@@ -1255,6 +1551,19 @@ func validateOpenPlayRuleInput(minParticipants, maxParticipantsPerCourt, cancell
 	}
 }
 
+func validateOpenPlayRuleMembershipLevels(minLevel int64, maxLevel sql.NullInt64) error {
+	switch {
+	case minLevel < 0 || minLevel > apiutil.MaxMembershipLevel:
+		return apiutil.FieldError{Field: "min_membership_level", Reason: fmt.Sprintf("must be between 0 and %d", apiutil.MaxMembershipLevel)}
+	case maxLevel.Valid && (maxLevel.Int64 < 0 || maxLevel.Int64 > apiutil.MaxMembershipLevel):
+		return apiutil.FieldError{Field: "max_membership_level", Reason: fmt.Sprintf("must be between 0 and %d", apiutil.MaxMembershipLevel)}
+	case maxLevel.Valid && maxLevel.Int64 < minLevel:
+		return apiutil.FieldError{Field: "max_membership_level", Reason: "must be greater than or equal to min_membership_level"}
+	default:
+		return nil
+	}
+}
+
 func openPlayRulesPageComponent(rules []dbgen.OpenPlayRule) templ.Component {
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
 		if _, err := io.WriteString(w, `<div class="space-y-6">`); err != nil {
@@ -1317,6 +1626,11 @@ func buildOpenPlayRuleCardHTML(rule dbgen.OpenPlayRule) string {
 		enabledLabel = "Yes"
 	}
 
+	eligibilityLabel := apiutil.OpenPlayEligibilityLabel(rule.MinMembershipLevel, rule.MaxMembershipLevel)
+	if eligibilityLabel == "" {
+		eligibilityLabel = "All levels"
+	}
+
 	name := html.EscapeString(rule.Name)
 
 	return fmt.Sprintf(
@@ -1350,6 +1664,10 @@ func buildOpenPlayRuleCardHTML(rule dbgen.OpenPlayRule) string {
 					<dt class="font-medium text-gray-600">Max courts</dt>
 					<dd>%d</dd>
 				</div>
+				<div class="flex items-center justify-between gap-4">
+					<dt class="font-medium text-gray-600">Eligibility</dt>
+					<dd>%s</dd>
+				</div>
 			</dl>
 		</div>`,
 		rule.ID,
@@ -1361,5 +1679,6 @@ func buildOpenPlayRuleCardHTML(rule dbgen.OpenPlayRule) string {
 		enabledLabel,
 		rule.MinCourts,
 		rule.MaxCourts,
+		eligibilityLabel,
 	)
 }
@@ -271,6 +271,21 @@ func TestOpenPlayRuleCreateValidation(t *testing.T) {
 			wantStatus:  http.StatusBadRequest,
 			wantMessage: "min_participants must be less than or equal to max_participants_per_court * min_courts",
 		},
+		{
+			name: "max membership level below min",
+			form: url.Values{
+				"name":                        []string{"Level Range"},
+				"min_participants":            []string{"4"},
+				"max_participants_per_court":  []string{"8"},
+				"cancellation_cutoff_minutes": []string{"60"},
+				"min_courts":                  []string{"1"},
+				"max_courts":                  []string{"2"},
+				"min_membership_level":        []string{"3"},
+				"max_membership_level":        []string{"2"},
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "max_membership_level must be greater than or equal to min_membership_level",
+		},
 	}
 
 	for _, tc := range cases {
@@ -437,3 +452,178 @@ func TestOpenPlaySessionAutoScaleToggle(t *testing.T) {
 		t.Fatalf("expected audit actions for override and rule disable, got %v", actions)
 	}
 }
+
+func TestHandleAddParticipant_MembershipEligibilityOverride(t *testing.T) {
+	database, facilityID := setupOpenPlayTest(t)
+	ctx := context.Background()
+
+	rule, err := database.Queries.CreateOpenPlayRule(ctx, dbgen.CreateOpenPlayRuleParams{
+		FacilityID:                facilityID,
+		Name:                      "Member+ Ladder",
+		MinParticipants:           4,
+		MaxParticipantsPerCourt:   8,
+		CancellationCutoffMinutes: 60,
+		AutoScaleEnabled:          true,
+		MinCourts:                 1,
+		MaxCourts:                 2,
+		MinMembershipLevel:        3,
+	})
+	if err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+	session, err := database.Queries.CreateOpenPlaySession(ctx, dbgen.CreateOpenPlaySessionParams{
+		FacilityID:        facilityID,
+		OpenPlayRuleID:    rule.ID,
+		StartTime:         start,
+		EndTime:           end,
+		Status:            "scheduled",
+		CurrentCourtCount: 1,
+	})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	memberResult, err := database.ExecContext(ctx,
+		`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+		 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+		"Regular", "Member", "regular@test.com", "active", facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	if _, err := database.ExecContext(ctx,
+		`INSERT INTO reservations (facility_id, reservation_type_id, open_play_rule_id, created_by_user_id, start_time, end_time, is_open_event)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'OPEN_PLAY'), ?, ?, ?, ?, 1)`,
+		facilityID, rule.ID, memberID, start, end,
+	); err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+
+	addParticipant := func(body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(
+			http.MethodPost,
+			fmt.Sprintf("/api/v1/open-play-sessions/%d/participants?facility_id=%d", session.ID, facilityID),
+			strings.NewReader(body),
+		)
+		req.SetPathValue("id", fmt.Sprintf("%d", session.ID))
+		req.Header.Set("Content-Type", "application/json")
+		req = withAuthUser(req, facilityID)
+		recorder := httptest.NewRecorder()
+		HandleAddParticipant(recorder, req)
+		return recorder
+	}
+
+	recorder := addParticipant(fmt.Sprintf(`{"user_id": %d}`, memberID))
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected ineligible member to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.Contains(recorder.Body.String(), "Member+ and above only") {
+		t.Fatalf("unexpected error message: %s", recorder.Body.String())
+	}
+
+	recorder = addParticipant(fmt.Sprintf(`{"user_id": %d, "override_eligibility": true}`, memberID))
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("override status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var reason sql.NullString
+	if err := database.QueryRowContext(ctx,
+		"SELECT reason FROM open_play_audit_log WHERE session_id = ? ORDER BY id DESC LIMIT 1",
+		session.ID,
+	).Scan(&reason); err != nil {
+		t.Fatalf("load audit entry: %v", err)
+	}
+	if reason.String != "eligibility_override" {
+		t.Fatalf("expected override to be audited, got reason %q", reason.String)
+	}
+}
+
+func TestOpenPlaySessionSkillBands(t *testing.T) {
+	database, facilityID := setupOpenPlayTest(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	for number := 1; number <= 2; number++ {
+		if _, err := database.ExecContext(ctx,
+			"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, 'active')",
+			facilityID, fmt.Sprintf("Court %d", number), number,
+		); err != nil {
+			t.Fatalf("insert court: %v", err)
+		}
+	}
+
+	rule, err := database.Queries.CreateOpenPlayRule(ctx, dbgen.CreateOpenPlayRuleParams{
+		FacilityID:                facilityID,
+		Name:                      "Banded Rule",
+		MinParticipants:           4,
+		MaxParticipantsPerCourt:   8,
+		CancellationCutoffMinutes: 30,
+		MinCourts:                 1,
+		MaxCourts:                 2,
+		SkillBands:                sql.NullString{String: `[{"minRating":3,"courtNumbers":[1,2]}]`, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+	session, err := database.Queries.CreateOpenPlaySession(ctx, dbgen.CreateOpenPlaySessionParams{
+		FacilityID:        facilityID,
+		OpenPlayRuleID:    rule.ID,
+		StartTime:         now.Add(2 * time.Hour),
+		EndTime:           now.Add(3 * time.Hour),
+		Status:            "scheduled",
+		CurrentCourtCount: 2,
+	})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	send := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(
+			method,
+			fmt.Sprintf("/api/v1/open-play-sessions/%d/skill-bands?facility_id=%d", session.ID, facilityID),
+			strings.NewReader(body),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", fmt.Sprintf("%d", session.ID))
+		recorder := httptest.NewRecorder()
+		HandleOpenPlaySessionSkillBands(recorder, withAuthUser(req, facilityID))
+		return recorder
+	}
+
+	recorder := send(http.MethodPut, `{"skill_bands":[{"minRating":4,"courtNumbers":[2]},{"minRating":3,"maxRating":3.5,"courtNumbers":[1]}]}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("put status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response openPlaySessionSkillBandsResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !response.Override || len(response.SkillBands) != 2 || response.SkillBands[0].MinRating != 3 {
+		t.Fatalf("expected sorted session override, got %+v", response)
+	}
+
+	if recorder := send(http.MethodPut, `{"skill_bands":[{"minRating":3,"courtNumbers":[7]}]}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected unknown court to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := send(http.MethodPut, `{"skill_bands":[{"minRating":3,"courtNumbers":[1]},{"minRating":3.5,"courtNumbers":[2]}]}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected overlapping bands to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = send(http.MethodDelete, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("delete status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	response = openPlaySessionSkillBandsResponse{}
+	if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if response.Override || len(response.SkillBands) != 1 || len(response.SkillBands[0].CourtNumbers) != 2 {
+		t.Fatalf("expected rule bands after reset, got %+v", response)
+	}
+}
@@ -0,0 +1,389 @@
+// internal/api/openplay/rotation.go
+package openplay
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/models"
+	openplayengine "github.com/codr1/Pickleicious/internal/openplay"
+)
+
+const openPlayNoShowReason = "no_show"
+
+var cardSigner *models.MemberCardSigner
+
+// InitCardSigner must be called during server startup before handling
+// check-ins by member card.
+func InitCardSigner(signer *models.MemberCardSigner) {
+	cardSigner = signer
+}
+
+// openPlayCheckinRequest names the arriving player directly (staff tablet)
+// or by the QR payload on their member card.
+type openPlayCheckinRequest struct {
+	UserID  int64  `json:"user_id"`
+	Payload string `json:"payload"`
+}
+
+type openPlayBumpRequest struct {
+	UserID int64 `json:"user_id"`
+}
+
+type openPlayQueueResponse struct {
+	SessionID int64                         `json:"sessionId"`
+	Queue     []openplayengine.QueuedPlayer `json:"queue"`
+}
+
+type openPlayCheckinResponse struct {
+	Player openplayengine.QueuedPlayer   `json:"player"`
+	Queue  []openplayengine.QueuedPlayer `json:"queue"`
+}
+
+type openPlayGameResponse struct {
+	Game  openplayengine.Game           `json:"game"`
+	Queue []openplayengine.QueuedPlayer `json:"queue"`
+}
+
+type openPlayBumpResponse struct {
+	Bumped []int64 `json:"bumped"`
+}
+
+// POST /api/v1/open-play-sessions/{id}/checkin
+func HandleOpenPlaySessionCheckin(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	database := loadDB()
+	if database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	sessionID, facilityID, ok := openPlayRotationTarget(w, r)
+	if !ok {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+
+	req, err := decodeOpenPlayCheckinRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), openPlayQueryTimeout)
+	defer cancel()
+
+	userID := req.UserID
+	if req.Payload != "" {
+		userID, err = memberIDFromCard(ctx, database.Queries, req.Payload)
+		if err != nil {
+			writeOpenPlayRotationError(w, r, err, sessionID, "Failed to check in player")
+			return
+		}
+	}
+	if userID <= 0 {
+		http.Error(w, "user_id or payload is required", http.StatusBadRequest)
+		return
+	}
+
+	var response openPlayCheckinResponse
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		session, reservationID, err := fetchOpenPlaySessionAndReservation(ctx, qtx, sessionID, facilityID)
+		if err != nil {
+			return err
+		}
+		response.Player, err = openplayengine.CheckIn(ctx, qtx, openplayengine.SessionCheckin{
+			SessionID:         session.ID,
+			ReservationID:     reservationID,
+			StartTime:         session.StartTime,
+			EndTime:           session.EndTime,
+			UserID:            userID,
+			CheckedInByUserID: user.ID,
+		}, time.Now())
+		if err != nil {
+			return err
+		}
+		response.Queue, err = openplayengine.Queue(ctx, qtx, session.ID)
+		return err
+	})
+	if err != nil {
+		writeOpenPlayRotationError(w, r, err, sessionID, "Failed to check in player")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, response); err != nil {
+		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to write open play check-in response")
+	}
+}
+
+// GET /api/v1/open-play-sessions/{id}/queue
+func HandleOpenPlayQueue(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	sessionID, facilityID, ok := openPlayRotationTarget(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), openPlayQueryTimeout)
+	defer cancel()
+
+	if _, err := fetchOpenPlaySession(ctx, q, sessionID, facilityID); err != nil {
+		writeOpenPlayRotationError(w, r, err, sessionID, "Failed to fetch open play session")
+		return
+	}
+	queue, err := openplayengine.Queue(ctx, q, sessionID)
+	if err != nil {
+		writeOpenPlayRotationError(w, r, err, sessionID, "Failed to load the queue")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, openPlayQueueResponse{SessionID: sessionID, Queue: queue}); err != nil {
+		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to write open play queue response")
+	}
+}
+
+// POST /api/v1/open-play-sessions/{id}/queue/advance
+func HandleOpenPlayQueueAdvance(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	database := loadDB()
+	if database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	sessionID, facilityID, ok := openPlayRotationTarget(w, r)
+	if !ok {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+
+	ctx, cancel := context.WithTimeout(r.Context(), openPlayQueryTimeout)
+	defer cancel()
+
+	var response openPlayGameResponse
+	err := database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		if _, _, err := fetchOpenPlaySessionAndReservation(ctx, qtx, sessionID, facilityID); err != nil {
+			return err
+		}
+		var err error
+		response.Game, err = openplayengine.AdvanceQueue(ctx, qtx, sessionID, user.ID)
+		if err != nil {
+			return err
+		}
+		response.Queue, err = openplayengine.Queue(ctx, qtx, sessionID)
+		return err
+	})
+	if err != nil {
+		writeOpenPlayRotationError(w, r, err, sessionID, "Failed to advance the queue")
+		return
+	}
+
+	logger.Info().
+		Int64("session_id", sessionID).
+		Int64("game_id", response.Game.ID).
+		Msg("Open play game recorded")
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, response); err != nil {
+		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to write open play game response")
+	}
+}
+
+// POST /api/v1/open-play-sessions/{id}/queue/bump
+//
+// Removes signed-up players who have not checked in once the no-show grace
+// period has passed: the one named by user_id, or all of them when the body
+// is empty.
+func HandleOpenPlayNoShowBump(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	database := loadDB()
+	if database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	sessionID, facilityID, ok := openPlayRotationTarget(w, r)
+	if !ok {
+		return
+	}
+
+	var req openPlayBumpRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), openPlayQueryTimeout)
+	defer cancel()
+
+	response := openPlayBumpResponse{Bumped: []int64{}}
+	err := database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		session, reservationID, err := fetchOpenPlaySessionAndReservation(ctx, qtx, sessionID, facilityID)
+		if err != nil {
+			return err
+		}
+		if time.Now().Before(session.StartTime.Add(openplayengine.NoShowGracePeriod)) {
+			return apiutil.HandlerError{
+				Status:  http.StatusConflict,
+				Message: fmt.Sprintf("No-shows can be bumped %d minutes after the session starts", int(openplayengine.NoShowGracePeriod.Minutes())),
+			}
+		}
+
+		noShows, err := openplayengine.NoShows(ctx, qtx, reservationID)
+		if err != nil {
+			return err
+		}
+		for _, noShow := range noShows {
+			if req.UserID > 0 && noShow.UserID != req.UserID {
+				continue
+			}
+			removed, err := qtx.RemoveOpenPlayParticipant(ctx, dbgen.RemoveOpenPlayParticipantParams{
+				UserID:         noShow.UserID,
+				FacilityID:     facilityID,
+				OpenPlayRuleID: sql.NullInt64{Int64: session.OpenPlayRuleID, Valid: true},
+				StartTime:      session.StartTime,
+				EndTime:        session.EndTime,
+			})
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove participant", Err: err}
+			}
+			if removed == 0 {
+				continue
+			}
+			if err := createOpenPlayAuditEntry(ctx, qtx, session.ID, openPlayAuditParticipantRemoved, map[string]any{
+				"user_id":        noShow.UserID,
+				"reservation_id": reservationID,
+			}, map[string]any{}, sql.NullString{String: openPlayNoShowReason, Valid: true}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to log open play participant removal", Err: err}
+			}
+			response.Bumped = append(response.Bumped, noShow.UserID)
+		}
+		if req.UserID > 0 && len(response.Bumped) == 0 {
+			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "No-show not found"}
+		}
+		return nil
+	})
+	if err != nil {
+		writeOpenPlayRotationError(w, r, err, sessionID, "Failed to bump no-shows")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("session_id", sessionID).Msg("Failed to write open play bump response")
+	}
+}
+
+// openPlayRotationTarget resolves the session and facility for the staff
+// rotation endpoints, writing the error response when the request is invalid
+// or the caller is not staff at the facility.
+func openPlayRotationTarget(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	sessionID, err := openPlaySessionIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	facilityID, err := facilityIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Facility ID is required", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return 0, 0, false
+	}
+	if !authz.IsStaff(authz.UserFromContext(r.Context())) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return 0, 0, false
+	}
+	return sessionID, facilityID, true
+}
+
+func decodeOpenPlayCheckinRequest(r *http.Request) (openPlayCheckinRequest, error) {
+	var req openPlayCheckinRequest
+	if apiutil.IsJSONRequest(r) {
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return req, err
+		}
+		req.Payload = strings.TrimSpace(req.Payload)
+		return req, nil
+	}
+
+	userID, err := apiutil.ParseOptionalInt64Field(r.FormValue("user_id"), "user_id")
+	if err != nil {
+		return req, err
+	}
+	if userID != nil {
+		req.UserID = *userID
+	}
+	req.Payload = strings.TrimSpace(r.FormValue("payload"))
+	return req, nil
+}
+
+// memberIDFromCard resolves the member a scanned card belongs to.
+func memberIDFromCard(ctx context.Context, q *dbgen.Queries, payload string) (int64, error) {
+	if cardSigner == nil {
+		return 0, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to verify member card", Err: errors.New("card signer not initialized")}
+	}
+	cardToken, err := cardSigner.Verify(payload, time.Now())
+	if err != nil {
+		if errors.Is(err, models.ErrMemberCardExpired) {
+			return 0, apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Member card has expired. Ask the member to open their current card."}
+		}
+		return 0, apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Invalid member card"}
+	}
+	card, err := q.GetActiveMemberCardByToken(ctx, cardToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Member card has been replaced"}
+		}
+		return 0, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to look up member card", Err: err}
+	}
+	return card.UserID, nil
+}
+
+func writeOpenPlayRotationError(w http.ResponseWriter, r *http.Request, err error, sessionID int64, fallback string) {
+	logger := log.Ctx(r.Context())
+
+	var herr apiutil.HandlerError
+	if errors.As(err, &herr) {
+		if herr.Status == http.StatusInternalServerError {
+			logger.Error().Err(herr.Err).Int64("session_id", sessionID).Msg(herr.Message)
+		}
+		http.Error(w, herr.Message, herr.Status)
+		return
+	}
+	logger.Error().Err(err).Int64("session_id", sessionID).Msg(fallback)
+	http.Error(w, fallback, http.StatusInternalServerError)
+}
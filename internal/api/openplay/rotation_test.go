@@ -0,0 +1,187 @@
+package openplay
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestOpenPlayRotation_CheckinAdvanceAndBump(t *testing.T) {
+	database, facilityID := setupOpenPlayTest(t)
+	ctx := context.Background()
+
+	insertUser := func(firstName string) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx,
+			`INSERT INTO users (first_name, last_name, email, status, is_member, waiver_signed, membership_level, home_facility_id)
+			 VALUES (?, ?, ?, ?, 1, 1, 2, ?)`,
+			firstName, "Player", strings.ToLower(firstName)+"@test.com", "active", facilityID,
+		)
+		if err != nil {
+			t.Fatalf("insert user %s: %v", firstName, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	// withAuthUser acts as user 1.
+	staffID := insertUser("Staff")
+
+	rule, err := database.Queries.CreateOpenPlayRule(ctx, dbgen.CreateOpenPlayRuleParams{
+		FacilityID:                facilityID,
+		Name:                      "Evening Drop-In",
+		MinParticipants:           4,
+		MaxParticipantsPerCourt:   8,
+		CancellationCutoffMinutes: 60,
+		MinCourts:                 1,
+		MaxCourts:                 2,
+	})
+	if err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+	start := time.Now().Add(-20 * time.Minute).Truncate(time.Minute)
+	end := start.Add(2 * time.Hour)
+	session, err := database.Queries.CreateOpenPlaySession(ctx, dbgen.CreateOpenPlaySessionParams{
+		FacilityID:        facilityID,
+		OpenPlayRuleID:    rule.ID,
+		StartTime:         start,
+		EndTime:           end,
+		Status:            "scheduled",
+		CurrentCourtCount: 1,
+	})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	reservationResult, err := database.ExecContext(ctx,
+		`INSERT INTO reservations (facility_id, reservation_type_id, open_play_rule_id, created_by_user_id, start_time, end_time, is_open_event)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'OPEN_PLAY'), ?, ?, ?, ?, 1)`,
+		facilityID, rule.ID, staffID, start, end,
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, _ := reservationResult.LastInsertId()
+
+	players := make([]int64, 0, 6)
+	for _, name := range []string{"Ana", "Ben", "Cal", "Dee", "Eve", "Finn"} {
+		userID := insertUser(name)
+		if _, err := database.ExecContext(ctx,
+			"INSERT INTO reservation_participants (reservation_id, user_id) VALUES (?, ?)",
+			reservationID, userID,
+		); err != nil {
+			t.Fatalf("add participant: %v", err)
+		}
+		players = append(players, userID)
+	}
+	outsiderID := insertUser("Gus")
+
+	call := func(handler http.HandlerFunc, method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, fmt.Sprintf("/api/v1/open-play-sessions/%d%s?facility_id=%d", session.ID, path, facilityID), strings.NewReader(body))
+		req.SetPathValue("id", fmt.Sprintf("%d", session.ID))
+		req.Header.Set("Content-Type", "application/json")
+		req = withAuthUser(req, facilityID)
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+	queueOrder := func(recorder *httptest.ResponseRecorder) []int64 {
+		t.Helper()
+		var response struct {
+			Queue []struct {
+				UserID int64 `json:"userId"`
+			} `json:"queue"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("decode queue: %v", err)
+		}
+		order := make([]int64, 0, len(response.Queue))
+		for _, player := range response.Queue {
+			order = append(order, player.UserID)
+		}
+		return order
+	}
+	expectOrder := func(got []int64, want ...int64) {
+		t.Helper()
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("queue order %v, want %v", got, want)
+		}
+	}
+
+	for _, userID := range players[:5] {
+		if recorder := call(HandleOpenPlaySessionCheckin, http.MethodPost, "/checkin", fmt.Sprintf(`{"user_id": %d}`, userID)); recorder.Code != http.StatusCreated {
+			t.Fatalf("check in %d: status %d: %s", userID, recorder.Code, recorder.Body.String())
+		}
+	}
+	if recorder := call(HandleOpenPlaySessionCheckin, http.MethodPost, "/checkin", fmt.Sprintf(`{"user_id": %d}`, players[0])); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected a second check-in to conflict, got %d", recorder.Code)
+	}
+	if recorder := call(HandleOpenPlaySessionCheckin, http.MethodPost, "/checkin", fmt.Sprintf(`{"user_id": %d}`, outsiderID)); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected a player who did not sign up to be rejected, got %d", recorder.Code)
+	}
+
+	recorder := call(HandleOpenPlayQueue, http.MethodGet, "/queue", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("queue status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	expectOrder(queueOrder(recorder), players[0], players[1], players[2], players[3], players[4])
+
+	recorder = call(HandleOpenPlayQueueAdvance, http.MethodPost, "/queue/advance", "")
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("advance status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var game struct {
+		Game struct {
+			Players []struct {
+				UserID int64 `json:"userId"`
+			} `json:"players"`
+		} `json:"game"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &game); err != nil {
+		t.Fatalf("decode game: %v", err)
+	}
+	if len(game.Game.Players) != 4 || game.Game.Players[0].UserID != players[0] {
+		t.Fatalf("expected the first four players to be called, got %+v", game.Game.Players)
+	}
+	expectOrder(queueOrder(recorder), players[4], players[0], players[1], players[2], players[3])
+
+	recorder = call(HandleOpenPlayQueueAdvance, http.MethodPost, "/queue/advance", "")
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("second advance status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	expectOrder(queueOrder(recorder), players[3], players[4], players[0], players[1], players[2])
+
+	recorder = call(HandleOpenPlayNoShowBump, http.MethodPost, "/queue/bump", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("bump status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.Contains(recorder.Body.String(), fmt.Sprintf("[%d]", players[5])) {
+		t.Fatalf("expected only the no-show to be bumped: %s", recorder.Body.String())
+	}
+	var remaining int
+	if err := database.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM reservation_participants WHERE reservation_id = ?", reservationID,
+	).Scan(&remaining); err != nil {
+		t.Fatalf("count participants: %v", err)
+	}
+	if remaining != 5 {
+		t.Fatalf("expected 5 participants after the bump, got %d", remaining)
+	}
+	var games int
+	if err := database.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM open_play_games WHERE session_id = ?", session.ID,
+	).Scan(&games); err != nil {
+		t.Fatalf("count games: %v", err)
+	}
+	if games != 2 {
+		t.Fatalf("expected 2 recorded games, got %d", games)
+	}
+}
@@ -0,0 +1,244 @@
+package openplay
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+type openPlayRuleSlotRequest struct {
+	DayOfWeek *int64 `json:"dayOfWeek"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+// GET /api/v1/open-play-rules/{id}/slots
+func HandleOpenPlayRuleSlotsList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	ruleID, err := openPlayRuleIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	facilityID, err := facilityIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), openPlayQueryTimeout)
+	defer cancel()
+
+	slots, err := q.ListOpenPlayRuleSlots(ctx, dbgen.ListOpenPlayRuleSlotsParams{
+		OpenPlayRuleID: ruleID,
+		FacilityID:     facilityID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("rule_id", ruleID).Msg("Failed to list open play rule slots")
+		http.Error(w, "Failed to load open play rule slots", http.StatusInternalServerError)
+		return
+	}
+	if slots == nil {
+		slots = []dbgen.OpenPlayRuleSlot{}
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"slots": slots}); err != nil {
+		logger.Error().Err(err).Int64("rule_id", ruleID).Msg("Failed to write open play rule slots response")
+		return
+	}
+}
+
+// POST /api/v1/open-play-rules/{id}/slots
+func HandleOpenPlayRuleSlotCreate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	ruleID, err := openPlayRuleIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	facilityID, err := facilityIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	req, err := decodeOpenPlayRuleSlotRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	params, err := openPlayRuleSlotParamsFromRequest(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params.OpenPlayRuleID = ruleID
+	params.CreatedByUserID = user.ID
+
+	ctx, cancel := context.WithTimeout(r.Context(), openPlayQueryTimeout)
+	defer cancel()
+
+	if _, err := q.GetOpenPlayRule(ctx, dbgen.GetOpenPlayRuleParams{ID: ruleID, FacilityID: facilityID}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Open play rule not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("rule_id", ruleID).Msg("Failed to load open play rule")
+		http.Error(w, "Failed to load open play rule", http.StatusInternalServerError)
+		return
+	}
+
+	slot, err := q.CreateOpenPlayRuleSlot(ctx, params)
+	if err != nil {
+		if apiutil.IsSQLiteUniqueViolation(err) {
+			http.Error(w, "Open play rule already has this slot", http.StatusConflict)
+			return
+		}
+		logger.Error().Err(err).Int64("rule_id", ruleID).Msg("Failed to create open play rule slot")
+		http.Error(w, "Failed to save open play rule slot", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, map[string]any{"slot": slot}); err != nil {
+		logger.Error().Err(err).Int64("rule_id", ruleID).Msg("Failed to write open play rule slot response")
+		return
+	}
+}
+
+// DELETE /api/v1/open-play-rules/{id}/slots/{slot_id}
+// Sessions already generated from the slot are kept.
+func HandleOpenPlayRuleSlotDelete(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	ruleID, err := openPlayRuleIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	slotID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("slot_id")), 10, 64)
+	if err != nil || slotID <= 0 {
+		http.Error(w, "invalid slot ID", http.StatusBadRequest)
+		return
+	}
+	facilityID, err := facilityIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), openPlayQueryTimeout)
+	defer cancel()
+
+	deleted, err := q.DeleteOpenPlayRuleSlot(ctx, dbgen.DeleteOpenPlayRuleSlotParams{
+		ID:             slotID,
+		OpenPlayRuleID: ruleID,
+		FacilityID:     facilityID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("rule_id", ruleID).Int64("slot_id", slotID).Msg("Failed to delete open play rule slot")
+		http.Error(w, "Failed to delete open play rule slot", http.StatusInternalServerError)
+		return
+	}
+	if deleted == 0 {
+		http.Error(w, "Open play rule slot not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeOpenPlayRuleSlotRequest(r *http.Request) (openPlayRuleSlotRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req openPlayRuleSlotRequest
+		return req, apiutil.DecodeJSON(r, &req)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return openPlayRuleSlotRequest{}, err
+	}
+	req := openPlayRuleSlotRequest{
+		StartTime: apiutil.FirstNonEmpty(r.FormValue("start_time"), r.FormValue("startTime")),
+		EndTime:   apiutil.FirstNonEmpty(r.FormValue("end_time"), r.FormValue("endTime")),
+	}
+	if raw := strings.TrimSpace(apiutil.FirstNonEmpty(r.FormValue("day_of_week"), r.FormValue("dayOfWeek"))); raw != "" {
+		dayOfWeek, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return openPlayRuleSlotRequest{}, err
+		}
+		req.DayOfWeek = &dayOfWeek
+	}
+	return req, nil
+}
+
+func openPlayRuleSlotParamsFromRequest(req openPlayRuleSlotRequest) (dbgen.CreateOpenPlayRuleSlotParams, error) {
+	if req.DayOfWeek == nil {
+		return dbgen.CreateOpenPlayRuleSlotParams{}, fmt.Errorf("day_of_week is required")
+	}
+	if *req.DayOfWeek < 0 || *req.DayOfWeek > 6 {
+		return dbgen.CreateOpenPlayRuleSlotParams{}, fmt.Errorf("day_of_week must be between 0 and 6")
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(req.StartTime))
+	if err != nil {
+		return dbgen.CreateOpenPlayRuleSlotParams{}, fmt.Errorf("start_time must be in HH:MM format")
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(req.EndTime))
+	if err != nil {
+		return dbgen.CreateOpenPlayRuleSlotParams{}, fmt.Errorf("end_time must be in HH:MM format")
+	}
+	if !end.After(start) {
+		return dbgen.CreateOpenPlayRuleSlotParams{}, fmt.Errorf("end_time must be after start_time")
+	}
+	return dbgen.CreateOpenPlayRuleSlotParams{
+		DayOfWeek: *req.DayOfWeek,
+		StartTime: start.Format("15:04"),
+		EndTime:   end.Format("15:04"),
+	}, nil
+}
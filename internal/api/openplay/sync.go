@@ -0,0 +1,167 @@
+// internal/api/openplay/sync.go
+package openplay
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/models"
+)
+
+const syncTokenHeader = "X-Sync-Token"
+
+type openPlaySessionCapacity struct {
+	ID               int64     `json:"id"`
+	FacilityID       int64     `json:"facilityId"`
+	OpenPlayRuleID   int64     `json:"openPlayRuleId"`
+	RuleName         string    `json:"ruleName"`
+	StartTime        time.Time `json:"startTime"`
+	EndTime          time.Time `json:"endTime"`
+	Status           string    `json:"status"`
+	CourtCount       int64     `json:"courtCount"`
+	MinParticipants  int64     `json:"minParticipants"`
+	MaxParticipants  int64     `json:"maxParticipants"`
+	ParticipantCount int64     `json:"participantCount"`
+	SpotsRemaining   int64     `json:"spotsRemaining"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+type openPlaySessionListResponse struct {
+	Sessions   []openPlaySessionCapacity `json:"openPlaySessions"`
+	Tombstones []models.SyncTombstone    `json:"tombstones,omitempty"`
+	SyncToken  string                    `json:"syncToken"`
+	HasMore    bool                      `json:"hasMore"`
+}
+
+// HandleOpenPlaySessionsList handles GET /api/v1/open-play-sessions?facility_id=...
+// Without sync parameters it returns sessions that have not ended yet; with
+// sync_token or updated_since it returns only sessions changed since then.
+// Cancelled sessions stay in the feed with status "cancelled"; deleted
+// sessions are returned as tombstones.
+func HandleOpenPlaySessionsList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	database := loadDB()
+	if database == nil {
+		logger.Error().Msg("Database not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Facility ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	syncParams, err := models.ParseSyncParams(r.URL.Query().Get("sync_token"), r.URL.Query().Get("updated_since"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), openPlayQueryTimeout)
+	defer cancel()
+
+	response := openPlaySessionListResponse{Sessions: make([]openPlaySessionCapacity, 0)}
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		if !syncParams.Delta() {
+			seq, err := txdb.Queries.GetLatestSyncSeq(ctx)
+			if err != nil {
+				return err
+			}
+			rows, err := txdb.Queries.ListOpenPlaySessionCapacity(ctx, dbgen.ListOpenPlaySessionCapacityParams{
+				FacilityID:     facilityID,
+				ComparisonTime: time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				response.Sessions = append(response.Sessions, newOpenPlaySessionCapacity(dbgen.ListOpenPlaySessionCapacityByIDsRow(row)))
+			}
+			response.SyncToken = models.FormatSyncToken(seq)
+			return nil
+		}
+
+		page, err := models.ListSyncPage(ctx, txdb.Queries, facilityID, models.SyncEntityOpenPlaySession, syncParams)
+		if err != nil {
+			return err
+		}
+		response.SyncToken = page.Token
+		response.HasMore = page.HasMore
+		response.Tombstones = make([]models.SyncTombstone, 0)
+		if len(page.Changes) == 0 {
+			return nil
+		}
+
+		rows, err := txdb.Queries.ListOpenPlaySessionCapacityByIDs(ctx, dbgen.ListOpenPlaySessionCapacityByIDsParams{
+			FacilityID: facilityID,
+			Ids:        page.EntityIDs(),
+		})
+		if err != nil {
+			return err
+		}
+		byID := make(map[int64]dbgen.ListOpenPlaySessionCapacityByIDsRow, len(rows))
+		for _, row := range rows {
+			byID[row.ID] = row
+		}
+		for _, change := range page.Changes {
+			row, ok := byID[change.EntityID]
+			if !ok {
+				response.Tombstones = append(response.Tombstones, models.SyncTombstone{
+					ID:        change.EntityID,
+					Reason:    "deleted",
+					DeletedAt: models.SyncTimestamp(change.LastChangedAt),
+				})
+				continue
+			}
+			response.Sessions = append(response.Sessions, newOpenPlaySessionCapacity(row))
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list open play sessions")
+		http.Error(w, "Failed to list open play sessions", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set(syncTokenHeader, response.SyncToken)
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write open play session list response")
+		return
+	}
+}
+
+func newOpenPlaySessionCapacity(row dbgen.ListOpenPlaySessionCapacityByIDsRow) openPlaySessionCapacity {
+	maxParticipants := row.MaxParticipantsPerCourt * row.CurrentCourtCount
+	spotsRemaining := maxParticipants - row.ParticipantCount
+	if spotsRemaining < 0 || row.Status != "scheduled" {
+		spotsRemaining = 0
+	}
+	return openPlaySessionCapacity{
+		ID:               row.ID,
+		FacilityID:       row.FacilityID,
+		OpenPlayRuleID:   row.OpenPlayRuleID,
+		RuleName:         row.RuleName,
+		StartTime:        row.StartTime,
+		EndTime:          row.EndTime,
+		Status:           row.Status,
+		CourtCount:       row.CurrentCourtCount,
+		MinParticipants:  row.MinParticipants,
+		MaxParticipants:  maxParticipants,
+		ParticipantCount: row.ParticipantCount,
+		SpotsRemaining:   spotsRemaining,
+		UpdatedAt:        row.UpdatedAt,
+	}
+}
@@ -0,0 +1,147 @@
+package openplay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func createSyncTestSession(t *testing.T, database *db.DB, facilityID int64, start time.Time) int64 {
+	t.Helper()
+
+	ctx := context.Background()
+	rule, err := database.Queries.CreateOpenPlayRule(ctx, dbgen.CreateOpenPlayRuleParams{
+		FacilityID:                facilityID,
+		Name:                      "Sync Rule",
+		MinParticipants:           4,
+		MaxParticipantsPerCourt:   8,
+		CancellationCutoffMinutes: 30,
+		MinCourts:                 1,
+		MaxCourts:                 2,
+	})
+	if err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+	session, err := database.Queries.CreateOpenPlaySession(ctx, dbgen.CreateOpenPlaySessionParams{
+		FacilityID:        facilityID,
+		OpenPlayRuleID:    rule.ID,
+		StartTime:         start,
+		EndTime:           start.Add(2 * time.Hour),
+		Status:            "scheduled",
+		CurrentCourtCount: 1,
+	})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	return session.ID
+}
+
+func pollOpenPlaySessions(t *testing.T, facilityID int64, params url.Values) (openPlaySessionListResponse, http.Header) {
+	t.Helper()
+
+	params.Set("facility_id", fmt.Sprintf("%d", facilityID))
+	req := withAuthUser(httptest.NewRequest(http.MethodGet, "/api/v1/open-play-sessions?"+params.Encode(), nil), facilityID)
+	recorder := httptest.NewRecorder()
+	HandleOpenPlaySessionsList(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response openPlaySessionListResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return response, recorder.Header()
+}
+
+func initialOpenPlaySyncToken(t *testing.T, facilityID int64) string {
+	t.Helper()
+
+	response, header := pollOpenPlaySessions(t, facilityID, url.Values{})
+	token := header.Get(syncTokenHeader)
+	if token == "" || token != response.SyncToken {
+		t.Fatalf("expected sync token header on full list, got %q (body %q)", token, response.SyncToken)
+	}
+	return token
+}
+
+func TestOpenPlaySessionsDeltaSync_UpdatedTwiceBetweenPolls(t *testing.T) {
+	database, facilityID := setupOpenPlayTest(t)
+
+	start := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Hour)
+	sessionID := createSyncTestSession(t, database, facilityID, start)
+	token := initialOpenPlaySyncToken(t, facilityID)
+
+	for _, courts := range []int{2, 3} {
+		if _, err := database.Exec(
+			`UPDATE open_play_sessions SET current_court_count = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+			courts, sessionID,
+		); err != nil {
+			t.Fatalf("update session: %v", err)
+		}
+	}
+
+	response, header := pollOpenPlaySessions(t, facilityID, url.Values{"sync_token": []string{token}})
+	if len(response.Sessions) != 1 {
+		t.Fatalf("expected one session after two updates, got %d", len(response.Sessions))
+	}
+	if got := response.Sessions[0]; got.ID != sessionID || got.CourtCount != 3 || got.MaxParticipants != 24 {
+		t.Fatalf("expected latest state for session %d, got %+v", sessionID, got)
+	}
+	if len(response.Tombstones) != 0 {
+		t.Fatalf("expected no tombstones, got %d", len(response.Tombstones))
+	}
+	if response.SyncToken == token || header.Get(syncTokenHeader) != response.SyncToken {
+		t.Fatalf("expected advanced sync token, got %q (header %q)", response.SyncToken, header.Get(syncTokenHeader))
+	}
+
+	next, _ := pollOpenPlaySessions(t, facilityID, url.Values{"sync_token": []string{response.SyncToken}})
+	if len(next.Sessions) != 0 || len(next.Tombstones) != 0 {
+		t.Fatalf("expected empty delta after catching up, got %d sessions and %d tombstones", len(next.Sessions), len(next.Tombstones))
+	}
+	if next.SyncToken != response.SyncToken {
+		t.Fatalf("expected token to stay at %q, got %q", response.SyncToken, next.SyncToken)
+	}
+}
+
+func TestOpenPlaySessionsDeltaSync_DeletedTombstoneOnce(t *testing.T) {
+	database, facilityID := setupOpenPlayTest(t)
+
+	start := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Hour)
+	keptID := createSyncTestSession(t, database, facilityID, start)
+	deletedID := createSyncTestSession(t, database, facilityID, start.Add(3*time.Hour))
+	token := initialOpenPlaySyncToken(t, facilityID)
+
+	if _, err := database.Exec(`DELETE FROM open_play_sessions WHERE id = ?`, deletedID); err != nil {
+		t.Fatalf("delete session: %v", err)
+	}
+
+	response, _ := pollOpenPlaySessions(t, facilityID, url.Values{"sync_token": []string{token}})
+	if len(response.Sessions) != 0 {
+		t.Fatalf("expected deleted session to be omitted, got %d sessions", len(response.Sessions))
+	}
+	if len(response.Tombstones) != 1 {
+		t.Fatalf("expected exactly one tombstone, got %d", len(response.Tombstones))
+	}
+	if tombstone := response.Tombstones[0]; tombstone.ID != deletedID || tombstone.Reason != "deleted" || tombstone.DeletedAt == "" {
+		t.Fatalf("unexpected tombstone: %+v", tombstone)
+	}
+
+	next, _ := pollOpenPlaySessions(t, facilityID, url.Values{"sync_token": []string{response.SyncToken}})
+	if len(next.Tombstones) != 0 || len(next.Sessions) != 0 {
+		t.Fatalf("expected tombstone to be delivered once, got %d tombstones on the next poll", len(next.Tombstones))
+	}
+
+	full, _ := pollOpenPlaySessions(t, facilityID, url.Values{})
+	if len(full.Sessions) != 1 || full.Sessions[0].ID != keptID {
+		t.Fatalf("expected only session %d in the full list, got %+v", keptID, full.Sessions)
+	}
+}
@@ -17,8 +17,10 @@ import (
 
 	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/models"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
 	operatinghourstempl "github.com/codr1/Pickleicious/internal/templates/components/operatinghours"
 	"github.com/codr1/Pickleicious/internal/templates/layouts"
 )
@@ -32,8 +34,9 @@ const (
 )
 
 var (
-	queries     *dbgen.Queries
-	queriesOnce sync.Once
+	queries       *dbgen.Queries
+	queriesOnce   sync.Once
+	facilityCache *appdb.FacilityCache
 )
 
 type operatingHoursRequest struct {
@@ -53,6 +56,13 @@ func InitHandlers(q *dbgen.Queries) {
 	})
 }
 
+// InitFacilityCache sets the facility cache to invalidate when a facility's
+// settings change. Leaving it unset lets cached facilities go stale until
+// their TTL expires.
+func InitFacilityCache(cache *appdb.FacilityCache) {
+	facilityCache = cache
+}
+
 // GET /admin/operating-hours
 func HandleOperatingHoursPage(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
@@ -102,9 +112,15 @@ func HandleOperatingHoursPage(w http.ResponseWriter, r *http.Request) {
 
 	sessionType := authz.SessionTypeFromContext(r.Context())
 	bookingConfig := operatinghourstempl.BookingConfigData{
-		FacilityID:            facilityID,
-		MaxAdvanceBookingDays: facility.MaxAdvanceBookingDays,
-		MaxMemberReservations: facility.MaxMemberReservations,
+		FacilityID:                facilityID,
+		MaxAdvanceBookingDays:     facility.MaxAdvanceBookingDays,
+		MaxMemberReservations:     facility.MaxMemberReservations,
+		MaxCourtsPerMemberBooking: facility.MaxCourtsPerMemberBooking,
+		SlotDurationMinutes:       facility.SlotDurationMinutes,
+		MinBookingMinutes:         facility.MinBookingMinutes,
+		MaxGuestsPerReservation:   facility.MaxGuestsPerReservation,
+		ReservationLimitScope:     facility.ReservationLimitScope,
+		BufferMinutes:             facility.BufferMinutes,
 	}
 	page := layouts.Base(operatingHoursPageComponent(facilityID, hours, bookingConfig), activeTheme, sessionType)
 	if !apiutil.RenderHTMLComponent(r.Context(), w, page, nil, "Failed to render operating hours page", "Failed to render page") {
@@ -266,13 +282,67 @@ func HandleFacilitySettingsUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	maxCourtsPerMemberBooking, err := parsePositiveInt64Field(r.FormValue("max_courts_per_member_booking"), "max_courts_per_member_booking")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	slotDurationMinutes, err := parseBookingMinutesField(r.FormValue("slot_duration_minutes"), "slot_duration_minutes", apiutil.MaxSlotDurationMinutes)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	minBookingMinutes, err := parseBookingMinutesField(r.FormValue("min_booking_minutes"), "min_booking_minutes", apiutil.MaxMinBookingMinutes)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	maxGuests, err := apiutil.ParseNonNegativeInt64Field(r.FormValue("max_guests_per_reservation"), "max_guests_per_reservation")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if maxGuests > apiutil.MaxGuestsPerReservation {
+		http.Error(w, fmt.Sprintf("max_guests_per_reservation must be at most %d", apiutil.MaxGuestsPerReservation), http.StatusBadRequest)
+		return
+	}
+
+	bufferMinutes, err := apiutil.ParseNonNegativeInt64Field(apiutil.FirstNonEmpty(r.FormValue("buffer_minutes"), "0"), "buffer_minutes")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if bufferMinutes > apiutil.MaxBufferMinutes {
+		http.Error(w, fmt.Sprintf("buffer_minutes must be at most %d", apiutil.MaxBufferMinutes), http.StatusBadRequest)
+		return
+	}
+
+	reservationLimitScope := strings.TrimSpace(r.FormValue("reservation_limit_scope"))
+	switch reservationLimitScope {
+	case "":
+		reservationLimitScope = reservationsvc.LimitScopePerson
+	case reservationsvc.LimitScopePerson, reservationsvc.LimitScopeHousehold:
+	default:
+		http.Error(w, "reservation_limit_scope must be person or household", http.StatusBadRequest)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
 	defer cancel()
 
 	_, err = q.UpdateFacilityBookingConfig(ctx, dbgen.UpdateFacilityBookingConfigParams{
-		ID:                    facilityID,
-		MaxAdvanceBookingDays: maxAdvanceDays,
-		MaxMemberReservations: maxMemberReservations,
+		ID:                        facilityID,
+		MaxAdvanceBookingDays:     maxAdvanceDays,
+		MaxMemberReservations:     maxMemberReservations,
+		MaxCourtsPerMemberBooking: maxCourtsPerMemberBooking,
+		SlotDurationMinutes:       slotDurationMinutes,
+		MinBookingMinutes:         minBookingMinutes,
+		MaxGuestsPerReservation:   maxGuests,
+		ReservationLimitScope:     reservationLimitScope,
+		BufferMinutes:             bufferMinutes,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -284,6 +354,7 @@ func HandleFacilitySettingsUpdate(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to update booking configuration", http.StatusInternalServerError)
 		return
 	}
+	facilityCache.Invalidate(facilityID)
 
 	apiutil.WriteHTMLFeedback(w, http.StatusOK, "Booking configuration updated.")
 }
@@ -366,6 +437,17 @@ func parsePositiveInt64Field(raw string, field string) (int64, error) {
 	return value, nil
 }
 
+func parseBookingMinutesField(raw string, field string, max int64) (int64, error) {
+	value, err := parsePositiveInt64Field(raw, field)
+	if err != nil {
+		return 0, err
+	}
+	if value%apiutil.BookingMinutesStep != 0 || value > max {
+		return 0, fmt.Errorf("%s must be a multiple of %d minutes, at most %d", field, apiutil.BookingMinutesStep, max)
+	}
+	return value, nil
+}
+
 func facilityIDFromQuery(r *http.Request) (int64, error) {
 	raw := strings.TrimSpace(r.URL.Query().Get(facilityIDQueryKey))
 	if raw == "" {
@@ -447,3 +529,164 @@ func formatTimeValue(value interface{}) string {
 		return fmt.Sprint(value)
 	}
 }
+
+type quietHoursRequest struct {
+	FacilityID *int64 `json:"facilityId"`
+	StartsAt   string `json:"startsAt"`
+	EndsAt     string `json:"endsAt"`
+}
+
+// GET /api/v1/notification-quiet-hours
+func HandleQuietHoursGet(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	quietHours, err := q.GetFacilityQuietHours(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Quiet hours not configured", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load quiet hours")
+		http.Error(w, "Failed to load quiet hours", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, quietHours); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write quiet hours response")
+		return
+	}
+}
+
+// PUT /api/v1/notification-quiet-hours
+func HandleQuietHoursUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	req, err := decodeQuietHoursRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	facilityID, err := facilityIDFromRequest(r, req.FacilityID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	startsAt, _, err := parseOperatingTime(req.StartsAt, "starts_at")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	endsAt, _, err := parseOperatingTime(req.EndsAt, "ends_at")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if startsAt == endsAt {
+		http.Error(w, "starts_at and ends_at must differ", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	quietHours, err := q.UpsertFacilityQuietHours(ctx, dbgen.UpsertFacilityQuietHoursParams{
+		FacilityID: facilityID,
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to update quiet hours")
+		http.Error(w, "Failed to update quiet hours", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, quietHours); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write quiet hours response")
+		return
+	}
+}
+
+// DELETE /api/v1/notification-quiet-hours
+func HandleQuietHoursDelete(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	if _, err := q.DeleteFacilityQuietHours(ctx, facilityID); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to clear quiet hours")
+		http.Error(w, "Failed to clear quiet hours", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeQuietHoursRequest(r *http.Request) (quietHoursRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req quietHoursRequest
+		return req, apiutil.DecodeJSON(r, &req)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return quietHoursRequest{}, err
+	}
+
+	facilityID, err := apiutil.ParseOptionalInt64Field(apiutil.FirstNonEmpty(r.FormValue("facility_id"), r.FormValue("facilityId")), "facility_id")
+	if err != nil {
+		return quietHoursRequest{}, err
+	}
+
+	return quietHoursRequest{
+		FacilityID: facilityID,
+		StartsAt:   apiutil.FirstNonEmpty(r.FormValue("starts_at"), r.FormValue("startsAt")),
+		EndsAt:     apiutil.FirstNonEmpty(r.FormValue("ends_at"), r.FormValue("endsAt")),
+	}, nil
+}
@@ -0,0 +1,184 @@
+package operatinghours
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+type noShowPolicyRequest struct {
+	FacilityID          *int64 `json:"facilityId"`
+	MaxNoShowsPer30Days *int64 `json:"maxNoShowsPer30Days"`
+	RestrictionDays     *int64 `json:"restrictionDays"`
+}
+
+// GET /api/v1/no-show-policy
+func HandleNoShowPolicyGet(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	policy, err := q.GetFacilityNoShowPolicy(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "No-show policy not configured", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load no-show policy")
+		http.Error(w, "Failed to load no-show policy", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, policy); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write no-show policy response")
+		return
+	}
+}
+
+// PUT /api/v1/no-show-policy
+func HandleNoShowPolicyUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	req, err := decodeNoShowPolicyRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	facilityID, err := facilityIDFromRequest(r, req.FacilityID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	if req.MaxNoShowsPer30Days == nil || *req.MaxNoShowsPer30Days < 0 {
+		http.Error(w, "max_no_shows_per_30_days must be zero or a positive integer", http.StatusBadRequest)
+		return
+	}
+	if req.RestrictionDays == nil || *req.RestrictionDays <= 0 {
+		http.Error(w, "restriction_days must be a positive integer", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	policy, err := q.UpsertFacilityNoShowPolicy(ctx, dbgen.UpsertFacilityNoShowPolicyParams{
+		FacilityID:          facilityID,
+		MaxNoShowsPer30Days: *req.MaxNoShowsPer30Days,
+		RestrictionDays:     *req.RestrictionDays,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to update no-show policy")
+		http.Error(w, "Failed to update no-show policy", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, policy); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write no-show policy response")
+		return
+	}
+}
+
+// DELETE /api/v1/no-show-policy
+func HandleNoShowPolicyDelete(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	if _, err := q.DeleteFacilityNoShowPolicy(ctx, facilityID); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to clear no-show policy")
+		http.Error(w, "Failed to clear no-show policy", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeNoShowPolicyRequest(r *http.Request) (noShowPolicyRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req noShowPolicyRequest
+		return req, apiutil.DecodeJSON(r, &req)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return noShowPolicyRequest{}, err
+	}
+
+	facilityID, err := apiutil.ParseOptionalInt64Field(apiutil.FirstNonEmpty(r.FormValue("facility_id"), r.FormValue("facilityId")), "facility_id")
+	if err != nil {
+		return noShowPolicyRequest{}, err
+	}
+	// Zero is a valid maximum (any no-show restricts), so it is parsed
+	// without the positive-only helper.
+	var maxNoShows *int64
+	if raw := strings.TrimSpace(apiutil.FirstNonEmpty(r.FormValue("max_no_shows_per_30_days"), r.FormValue("maxNoShowsPer30Days"))); raw != "" {
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return noShowPolicyRequest{}, err
+		}
+		maxNoShows = &value
+	}
+	restrictionDays, err := apiutil.ParseOptionalInt64Field(apiutil.FirstNonEmpty(r.FormValue("restriction_days"), r.FormValue("restrictionDays")), "restriction_days")
+	if err != nil {
+		return noShowPolicyRequest{}, err
+	}
+
+	return noShowPolicyRequest{
+		FacilityID:          facilityID,
+		MaxNoShowsPer30Days: maxNoShows,
+		RestrictionDays:     restrictionDays,
+	}, nil
+}
@@ -0,0 +1,375 @@
+package operatinghours
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	facilityIDParam         = "id"
+	overrideDateQuery       = "date"
+	overrideFromQuery       = "from"
+	maxOverrideReasonLength = 200
+)
+
+type hoursOverrideRequest struct {
+	Date     string `json:"date"`
+	IsClosed bool   `json:"isClosed"`
+	OpensAt  string `json:"opensAt"`
+	ClosesAt string `json:"closesAt"`
+	Reason   string `json:"reason"`
+}
+
+type hoursOverrideResponse struct {
+	ID         int64   `json:"id"`
+	FacilityID int64   `json:"facilityId"`
+	Date       string  `json:"date"`
+	IsClosed   bool    `json:"isClosed"`
+	OpensAt    *string `json:"opensAt,omitempty"`
+	ClosesAt   *string `json:"closesAt,omitempty"`
+	Reason     *string `json:"reason,omitempty"`
+}
+
+// affectedReservation is an existing booking that falls outside the hours an
+// override sets, returned so staff know what to cancel or move.
+type affectedReservation struct {
+	ID                int64     `json:"id"`
+	ReservationTypeID int64     `json:"reservationTypeId"`
+	PrimaryUserID     *int64    `json:"primaryUserId,omitempty"`
+	StartTime         time.Time `json:"startTime"`
+	EndTime           time.Time `json:"endTime"`
+}
+
+// POST /api/v1/facilities/{id}/hours/overrides
+func HandleHoursOverrideCreate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	req, err := decodeHoursOverrideRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	params, err := hoursOverrideParams(facilityID, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	loc, err := facilityLocation(ctx, q, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Facility not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+		http.Error(w, "Failed to load facility", http.StatusInternalServerError)
+		return
+	}
+
+	override, err := q.UpsertFacilityHoursOverride(ctx, params)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Str("date", params.OverrideDate).Msg("Failed to save hours override")
+		http.Error(w, "Failed to save hours override", http.StatusInternalServerError)
+		return
+	}
+
+	affected, err := listReservationsOutsideOverride(ctx, q, override, loc)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Str("date", override.OverrideDate).Msg("Failed to list reservations affected by hours override")
+		http.Error(w, "Failed to load affected reservations", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, map[string]any{
+		"override":             newHoursOverrideResponse(override),
+		"affectedReservations": affected,
+	}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write hours override response")
+		return
+	}
+}
+
+// GET /api/v1/facilities/{id}/hours/overrides
+func HandleHoursOverridesList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	fromDate := strings.TrimSpace(r.URL.Query().Get(overrideFromQuery))
+	if fromDate != "" {
+		if _, err := time.Parse(apiutil.HoursOverrideDateLayout, fromDate); err != nil {
+			http.Error(w, "from must be in YYYY-MM-DD format", http.StatusBadRequest)
+			return
+		}
+	} else {
+		loc, err := facilityLocation(ctx, q, facilityID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				http.Error(w, "Facility not found", http.StatusNotFound)
+				return
+			}
+			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+			http.Error(w, "Failed to load facility", http.StatusInternalServerError)
+			return
+		}
+		fromDate = time.Now().In(loc).Format(apiutil.HoursOverrideDateLayout)
+	}
+
+	overrides, err := q.ListFacilityHoursOverrides(ctx, dbgen.ListFacilityHoursOverridesParams{
+		FacilityID: facilityID,
+		FromDate:   fromDate,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list hours overrides")
+		http.Error(w, "Failed to load hours overrides", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]hoursOverrideResponse, 0, len(overrides))
+	for _, override := range overrides {
+		response = append(response, newHoursOverrideResponse(override))
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"overrides": response}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write hours overrides response")
+		return
+	}
+}
+
+// DELETE /api/v1/facilities/{id}/hours/overrides?date=YYYY-MM-DD
+func HandleHoursOverrideDelete(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	date, err := parseOverrideDate(r.URL.Query().Get(overrideDateQuery))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	deleted, err := q.DeleteFacilityHoursOverride(ctx, dbgen.DeleteFacilityHoursOverrideParams{
+		FacilityID:   facilityID,
+		OverrideDate: date,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Str("date", date).Msg("Failed to delete hours override")
+		http.Error(w, "Failed to delete hours override", http.StatusInternalServerError)
+		return
+	}
+	if deleted == 0 {
+		http.Error(w, "Hours override not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func hoursOverrideParams(facilityID int64, req hoursOverrideRequest) (dbgen.UpsertFacilityHoursOverrideParams, error) {
+	date, err := parseOverrideDate(req.Date)
+	if err != nil {
+		return dbgen.UpsertFacilityHoursOverrideParams{}, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > maxOverrideReasonLength {
+		return dbgen.UpsertFacilityHoursOverrideParams{}, fmt.Errorf("reason must be %d characters or fewer", maxOverrideReasonLength)
+	}
+
+	params := dbgen.UpsertFacilityHoursOverrideParams{
+		FacilityID:   facilityID,
+		OverrideDate: date,
+		IsClosed:     req.IsClosed,
+		Reason:       sql.NullString{String: reason, Valid: reason != ""},
+	}
+	if req.IsClosed {
+		return params, nil
+	}
+
+	opensAt, opensTime, err := parseOperatingTime(req.OpensAt, "opens_at")
+	if err != nil {
+		return dbgen.UpsertFacilityHoursOverrideParams{}, err
+	}
+	closesAt, closesTime, err := parseOperatingTime(req.ClosesAt, "closes_at")
+	if err != nil {
+		return dbgen.UpsertFacilityHoursOverrideParams{}, err
+	}
+	if !closesTime.After(opensTime) {
+		return dbgen.UpsertFacilityHoursOverrideParams{}, fmt.Errorf("closes_at must be after opens_at")
+	}
+	params.OpensAt = sql.NullString{String: opensAt, Valid: true}
+	params.ClosesAt = sql.NullString{String: closesAt, Valid: true}
+	return params, nil
+}
+
+func listReservationsOutsideOverride(ctx context.Context, q *dbgen.Queries, override dbgen.FacilityHoursOverride, loc *time.Location) ([]affectedReservation, error) {
+	day, err := time.ParseInLocation(apiutil.HoursOverrideDateLayout, override.OverrideDate, loc)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := q.ListReservationsByDateRange(ctx, dbgen.ListReservationsByDateRangeParams{
+		FacilityID: override.FacilityID,
+		StartTime:  day,
+		EndTime:    day.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opensAt, closesAt, open := apiutil.HoursOverrideWindow(override, day)
+	affected := make([]affectedReservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		if open && !reservation.StartTime.Before(opensAt) && !reservation.EndTime.After(closesAt) {
+			continue
+		}
+		entry := affectedReservation{
+			ID:                reservation.ID,
+			ReservationTypeID: reservation.ReservationTypeID,
+			StartTime:         reservation.StartTime,
+			EndTime:           reservation.EndTime,
+		}
+		if reservation.PrimaryUserID.Valid {
+			primaryUserID := reservation.PrimaryUserID.Int64
+			entry.PrimaryUserID = &primaryUserID
+		}
+		affected = append(affected, entry)
+	}
+	return affected, nil
+}
+
+func newHoursOverrideResponse(override dbgen.FacilityHoursOverride) hoursOverrideResponse {
+	response := hoursOverrideResponse{
+		ID:         override.ID,
+		FacilityID: override.FacilityID,
+		Date:       override.OverrideDate,
+		IsClosed:   override.IsClosed,
+	}
+	if override.OpensAt.Valid {
+		response.OpensAt = &override.OpensAt.String
+	}
+	if override.ClosesAt.Valid {
+		response.ClosesAt = &override.ClosesAt.String
+	}
+	if override.Reason.Valid {
+		response.Reason = &override.Reason.String
+	}
+	return response
+}
+
+func facilityLocation(ctx context.Context, q *dbgen.Queries, facilityID int64) (*time.Location, error) {
+	facility, err := q.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		return nil, err
+	}
+	return apiutil.FacilityLocation(facility, log.Ctx(ctx)), nil
+}
+
+func parseOverrideDate(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("date is required")
+	}
+	parsed, err := time.Parse(apiutil.HoursOverrideDateLayout, raw)
+	if err != nil {
+		return "", fmt.Errorf("date must be in YYYY-MM-DD format")
+	}
+	return parsed.Format(apiutil.HoursOverrideDateLayout), nil
+}
+
+func facilityIDFromPath(r *http.Request) (int64, error) {
+	raw := strings.TrimSpace(r.PathValue(facilityIDParam))
+	if raw == "" {
+		return 0, fmt.Errorf("invalid facility ID")
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid facility ID")
+	}
+	return id, nil
+}
+
+func decodeHoursOverrideRequest(r *http.Request) (hoursOverrideRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req hoursOverrideRequest
+		return req, apiutil.DecodeJSON(r, &req)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return hoursOverrideRequest{}, err
+	}
+
+	isClosed, err := parseOptionalBool(apiutil.FirstNonEmpty(r.FormValue("is_closed"), r.FormValue("isClosed")))
+	if err != nil {
+		return hoursOverrideRequest{}, err
+	}
+
+	return hoursOverrideRequest{
+		Date:     r.FormValue("date"),
+		IsClosed: isClosed,
+		OpensAt:  apiutil.FirstNonEmpty(r.FormValue("opens_at"), r.FormValue("opensAt")),
+		ClosesAt: apiutil.FirstNonEmpty(r.FormValue("closes_at"), r.FormValue("closesAt")),
+		Reason:   r.FormValue("reason"),
+	}, nil
+}
@@ -0,0 +1,118 @@
+package operatinghours
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHoursOverrides_CreateListDelete(t *testing.T) {
+	database, facilityID := setupOperatingHoursTest(t)
+	ctx := context.Background()
+
+	staffResult, err := database.ExecContext(ctx,
+		"INSERT INTO users (first_name, last_name, email, status) VALUES (?, ?, ?, ?)",
+		"Front", "Desk", "desk@test.com", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert staff user: %v", err)
+	}
+	staffID, _ := staffResult.LastInsertId()
+
+	holiday := time.Date(2027, time.December, 24, 0, 0, 0, 0, time.UTC)
+	for _, hour := range []int{10, 16} {
+		start := holiday.Add(time.Duration(hour) * time.Hour)
+		if _, err := database.ExecContext(ctx,
+			`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, start_time, end_time)
+			 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?)`,
+			facilityID, staffID, start, start.Add(time.Hour),
+		); err != nil {
+			t.Fatalf("insert reservation: %v", err)
+		}
+	}
+
+	call := func(method, target, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", fmt.Sprintf("%d", facilityID))
+		req = withAuthUser(req, facilityID)
+		recorder := httptest.NewRecorder()
+		switch method {
+		case http.MethodPost:
+			HandleHoursOverrideCreate(recorder, req)
+		case http.MethodDelete:
+			HandleHoursOverrideDelete(recorder, req)
+		default:
+			HandleHoursOverridesList(recorder, req)
+		}
+		return recorder
+	}
+	path := fmt.Sprintf("/api/v1/facilities/%d/hours/overrides", facilityID)
+
+	if recorder := call(http.MethodPost, path, `{"date":"2027-12-24","opensAt":"14:00","closesAt":"12:00"}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected inverted hours to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder := call(http.MethodPost, path, `{"date":"2027-12-24","opensAt":"08:00","closesAt":"14:00","reason":"Christmas Eve"}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("create status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created struct {
+		Override             hoursOverrideResponse `json:"override"`
+		AffectedReservations []affectedReservation `json:"affectedReservations"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode create response: %v", err)
+	}
+	if created.Override.IsClosed || created.Override.ClosesAt == nil || *created.Override.ClosesAt != "14:00" {
+		t.Fatalf("unexpected override: %+v", created.Override)
+	}
+	if len(created.AffectedReservations) != 1 || created.AffectedReservations[0].StartTime.Hour() != 16 {
+		t.Fatalf("expected only the 16:00 reservation to be affected, got %+v", created.AffectedReservations)
+	}
+
+	// Posting the same date again replaces the override.
+	recorder = call(http.MethodPost, path, `{"date":"2027-12-24","isClosed":true}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("replace status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var replaced struct {
+		Override             hoursOverrideResponse `json:"override"`
+		AffectedReservations []affectedReservation `json:"affectedReservations"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &replaced); err != nil {
+		t.Fatalf("decode replace response: %v", err)
+	}
+	if !replaced.Override.IsClosed || replaced.Override.OpensAt != nil || len(replaced.AffectedReservations) != 2 {
+		t.Fatalf("expected closed override affecting both reservations, got %+v", replaced)
+	}
+
+	recorder = call(http.MethodGet, path+"?from=2027-01-01", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("list status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var listed struct {
+		Overrides []hoursOverrideResponse `json:"overrides"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("decode list response: %v", err)
+	}
+	if len(listed.Overrides) != 1 || listed.Overrides[0].Date != "2027-12-24" {
+		t.Fatalf("unexpected overrides: %+v", listed.Overrides)
+	}
+
+	if recorder := call(http.MethodDelete, path+"?date=2027-12-24", ""); recorder.Code != http.StatusNoContent {
+		t.Fatalf("delete status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := call(http.MethodDelete, path+"?date=2027-12-24", ""); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected second delete to 404, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,322 @@
+package operatinghours
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const pricingRuleIDParam = "ruleId"
+
+type pricingRuleRequest struct {
+	DayOfWeek                  *int64 `json:"dayOfWeek"`
+	StartTime                  string `json:"startTime"`
+	EndTime                    string `json:"endTime"`
+	MemberPricePerHourCents    *int64 `json:"memberPricePerHourCents"`
+	NonMemberPricePerHourCents *int64 `json:"nonMemberPricePerHourCents"`
+}
+
+// pricingRuleFields is a validated pricingRuleRequest.
+type pricingRuleFields struct {
+	DayOfWeek                  int64
+	StartTime                  string
+	EndTime                    string
+	MemberPricePerHourCents    int64
+	NonMemberPricePerHourCents int64
+}
+
+// GET /api/v1/facilities/{id}/pricing-rules
+func HandlePricingRulesList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	rules, err := q.ListCourtPricingRules(ctx, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list court pricing rules")
+		http.Error(w, "Failed to load pricing rules", http.StatusInternalServerError)
+		return
+	}
+	if rules == nil {
+		rules = []dbgen.CourtPricingRule{}
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"rules": rules}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write pricing rules response")
+		return
+	}
+}
+
+// POST /api/v1/facilities/{id}/pricing-rules
+func HandlePricingRuleCreate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	req, err := decodePricingRuleRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	fields, err := pricingRuleFieldsFromRequest(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	rule, err := q.CreateCourtPricingRule(ctx, dbgen.CreateCourtPricingRuleParams{
+		FacilityID:                 facilityID,
+		DayOfWeek:                  fields.DayOfWeek,
+		StartTime:                  fields.StartTime,
+		EndTime:                    fields.EndTime,
+		MemberPricePerHourCents:    fields.MemberPricePerHourCents,
+		NonMemberPricePerHourCents: fields.NonMemberPricePerHourCents,
+	})
+	if err != nil {
+		if apiutil.IsSQLiteForeignKeyViolation(err) {
+			http.Error(w, "Facility not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to create court pricing rule")
+		http.Error(w, "Failed to save pricing rule", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, map[string]any{"rule": rule}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write pricing rule response")
+		return
+	}
+}
+
+// PUT /api/v1/facilities/{id}/pricing-rules/{ruleId}
+func HandlePricingRuleUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ruleID, err := pricingRuleIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	req, err := decodePricingRuleRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	fields, err := pricingRuleFieldsFromRequest(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	rule, err := q.UpdateCourtPricingRule(ctx, dbgen.UpdateCourtPricingRuleParams{
+		ID:                         ruleID,
+		FacilityID:                 facilityID,
+		DayOfWeek:                  fields.DayOfWeek,
+		StartTime:                  fields.StartTime,
+		EndTime:                    fields.EndTime,
+		MemberPricePerHourCents:    fields.MemberPricePerHourCents,
+		NonMemberPricePerHourCents: fields.NonMemberPricePerHourCents,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Pricing rule not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Int64("rule_id", ruleID).Msg("Failed to update court pricing rule")
+		http.Error(w, "Failed to save pricing rule", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"rule": rule}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write pricing rule response")
+		return
+	}
+}
+
+// DELETE /api/v1/facilities/{id}/pricing-rules/{ruleId}
+func HandlePricingRuleDelete(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ruleID, err := pricingRuleIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	deleted, err := q.DeleteCourtPricingRule(ctx, dbgen.DeleteCourtPricingRuleParams{
+		ID:         ruleID,
+		FacilityID: facilityID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Int64("rule_id", ruleID).Msg("Failed to delete court pricing rule")
+		http.Error(w, "Failed to delete pricing rule", http.StatusInternalServerError)
+		return
+	}
+	if deleted == 0 {
+		http.Error(w, "Pricing rule not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func pricingRuleFieldsFromRequest(req pricingRuleRequest) (pricingRuleFields, error) {
+	if req.DayOfWeek == nil {
+		return pricingRuleFields{}, fmt.Errorf("day_of_week is required")
+	}
+	if *req.DayOfWeek < 0 || *req.DayOfWeek > 6 {
+		return pricingRuleFields{}, fmt.Errorf("day_of_week must be between 0 and 6")
+	}
+	startTime, startParsed, err := parseOperatingTime(req.StartTime, "start_time")
+	if err != nil {
+		return pricingRuleFields{}, err
+	}
+	endTime, endParsed, err := parseOperatingTime(req.EndTime, "end_time")
+	if err != nil {
+		return pricingRuleFields{}, err
+	}
+	if !endParsed.After(startParsed) {
+		return pricingRuleFields{}, fmt.Errorf("end_time must be after start_time")
+	}
+	if req.MemberPricePerHourCents == nil {
+		return pricingRuleFields{}, fmt.Errorf("member_price_per_hour_cents is required")
+	}
+	if *req.MemberPricePerHourCents < 0 {
+		return pricingRuleFields{}, fmt.Errorf("member_price_per_hour_cents must not be negative")
+	}
+	if req.NonMemberPricePerHourCents == nil {
+		return pricingRuleFields{}, fmt.Errorf("non_member_price_per_hour_cents is required")
+	}
+	if *req.NonMemberPricePerHourCents < 0 {
+		return pricingRuleFields{}, fmt.Errorf("non_member_price_per_hour_cents must not be negative")
+	}
+
+	return pricingRuleFields{
+		DayOfWeek:                  *req.DayOfWeek,
+		StartTime:                  startTime,
+		EndTime:                    endTime,
+		MemberPricePerHourCents:    *req.MemberPricePerHourCents,
+		NonMemberPricePerHourCents: *req.NonMemberPricePerHourCents,
+	}, nil
+}
+
+func pricingRuleIDFromPath(r *http.Request) (int64, error) {
+	raw := strings.TrimSpace(r.PathValue(pricingRuleIDParam))
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid pricing rule ID")
+	}
+	return id, nil
+}
+
+func decodePricingRuleRequest(r *http.Request) (pricingRuleRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req pricingRuleRequest
+		return req, apiutil.DecodeJSON(r, &req)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return pricingRuleRequest{}, err
+	}
+
+	dayOfWeek, err := parseOptionalInt64(apiutil.FirstNonEmpty(r.FormValue("day_of_week"), r.FormValue("dayOfWeek")))
+	if err != nil {
+		return pricingRuleRequest{}, err
+	}
+	memberPrice, err := parseOptionalInt64(apiutil.FirstNonEmpty(r.FormValue("member_price_per_hour_cents"), r.FormValue("memberPricePerHourCents")))
+	if err != nil {
+		return pricingRuleRequest{}, err
+	}
+	nonMemberPrice, err := parseOptionalInt64(apiutil.FirstNonEmpty(r.FormValue("non_member_price_per_hour_cents"), r.FormValue("nonMemberPricePerHourCents")))
+	if err != nil {
+		return pricingRuleRequest{}, err
+	}
+
+	return pricingRuleRequest{
+		DayOfWeek:                  dayOfWeek,
+		StartTime:                  apiutil.FirstNonEmpty(r.FormValue("start_time"), r.FormValue("startTime")),
+		EndTime:                    apiutil.FirstNonEmpty(r.FormValue("end_time"), r.FormValue("endTime")),
+		MemberPricePerHourCents:    memberPrice,
+		NonMemberPricePerHourCents: nonMemberPrice,
+	}, nil
+}
@@ -0,0 +1,84 @@
+package operatinghours
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestPricingRules_CreateListUpdateDelete(t *testing.T) {
+	_, facilityID := setupOperatingHoursTest(t)
+
+	call := func(handler http.HandlerFunc, method, target, ruleID, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", fmt.Sprintf("%d", facilityID))
+		if ruleID != "" {
+			req.SetPathValue("ruleId", ruleID)
+		}
+		req = withAuthUser(req, facilityID)
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+	path := fmt.Sprintf("/api/v1/facilities/%d/pricing-rules", facilityID)
+
+	if recorder := call(HandlePricingRuleCreate, http.MethodPost, path, "", `{"dayOfWeek":2,"startTime":"17:00","endTime":"20:00","memberPricePerHourCents":-1,"nonMemberPricePerHourCents":4000}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected negative price to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := call(HandlePricingRuleCreate, http.MethodPost, path, "", `{"dayOfWeek":2,"startTime":"17:00","endTime":"20:00","memberPricePerHourCents":2500}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected missing non-member price to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder := call(HandlePricingRuleCreate, http.MethodPost, path, "", `{"dayOfWeek":2,"startTime":"5:00 PM","endTime":"20:00","memberPricePerHourCents":2500,"nonMemberPricePerHourCents":4000}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("create status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created struct {
+		Rule dbgen.CourtPricingRule `json:"rule"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode create response: %v", err)
+	}
+	if created.Rule.StartTime != "17:00" || created.Rule.MemberPricePerHourCents != 2500 || created.Rule.NonMemberPricePerHourCents != 4000 {
+		t.Fatalf("unexpected rule: %+v", created.Rule)
+	}
+	ruleID := fmt.Sprintf("%d", created.Rule.ID)
+
+	recorder = call(HandlePricingRuleUpdate, http.MethodPut, path+"/"+ruleID, ruleID, `{"dayOfWeek":3,"startTime":"17:00","endTime":"21:00","memberPricePerHourCents":0,"nonMemberPricePerHourCents":3000}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("update status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = call(HandlePricingRulesList, http.MethodGet, path, "", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("list status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var listed struct {
+		Rules []dbgen.CourtPricingRule `json:"rules"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("decode list response: %v", err)
+	}
+	if len(listed.Rules) != 1 || listed.Rules[0].DayOfWeek != 3 || listed.Rules[0].MemberPricePerHourCents != 0 || listed.Rules[0].EndTime != "21:00" {
+		t.Fatalf("unexpected rules: %+v", listed.Rules)
+	}
+
+	if recorder := call(HandlePricingRuleUpdate, http.MethodPut, path+"/999", "999", `{"dayOfWeek":3,"startTime":"17:00","endTime":"20:00","memberPricePerHourCents":0,"nonMemberPricePerHourCents":0}`); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected unknown rule update to 404, got %d", recorder.Code)
+	}
+	if recorder := call(HandlePricingRuleDelete, http.MethodDelete, path+"/"+ruleID, ruleID, ""); recorder.Code != http.StatusNoContent {
+		t.Fatalf("delete status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := call(HandlePricingRuleDelete, http.MethodDelete, path+"/"+ruleID, ruleID, ""); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected second delete to 404, got %d", recorder.Code)
+	}
+}
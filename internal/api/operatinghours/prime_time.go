@@ -0,0 +1,336 @@
+package operatinghours
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	primeTimeRuleIDParam = "ruleId"
+)
+
+type primeTimeRuleRequest struct {
+	DayOfWeek          *int64 `json:"dayOfWeek"`
+	StartTime          string `json:"startTime"`
+	EndTime            string `json:"endTime"`
+	MinMembershipLevel *int64 `json:"minMembershipLevel"`
+	UnlockHoursBefore  *int64 `json:"unlockHoursBefore"`
+}
+
+// primeTimeRuleFields is a validated primeTimeRuleRequest.
+type primeTimeRuleFields struct {
+	DayOfWeek          int64
+	StartTime          string
+	EndTime            string
+	MinMembershipLevel int64
+	UnlockHoursBefore  int64
+}
+
+// GET /api/v1/facilities/{id}/prime-time
+func HandlePrimeTimeRulesList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	rules, err := q.ListPrimeTimeRules(ctx, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list prime time rules")
+		http.Error(w, "Failed to load prime time rules", http.StatusInternalServerError)
+		return
+	}
+	if rules == nil {
+		rules = []dbgen.PrimeTimeRule{}
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"rules": rules}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write prime time rules response")
+		return
+	}
+}
+
+// POST /api/v1/facilities/{id}/prime-time
+func HandlePrimeTimeRuleCreate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	req, err := decodePrimeTimeRuleRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	fields, err := primeTimeRuleFieldsFromRequest(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	rule, err := q.CreatePrimeTimeRule(ctx, dbgen.CreatePrimeTimeRuleParams{
+		FacilityID:         facilityID,
+		DayOfWeek:          fields.DayOfWeek,
+		StartTime:          fields.StartTime,
+		EndTime:            fields.EndTime,
+		MinMembershipLevel: fields.MinMembershipLevel,
+		UnlockHoursBefore:  fields.UnlockHoursBefore,
+	})
+	if err != nil {
+		if apiutil.IsSQLiteForeignKeyViolation(err) {
+			http.Error(w, "Facility not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to create prime time rule")
+		http.Error(w, "Failed to save prime time rule", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, map[string]any{"rule": rule}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write prime time rule response")
+		return
+	}
+}
+
+// PUT /api/v1/facilities/{id}/prime-time/{ruleId}
+func HandlePrimeTimeRuleUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ruleID, err := primeTimeRuleIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	req, err := decodePrimeTimeRuleRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	fields, err := primeTimeRuleFieldsFromRequest(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	rule, err := q.UpdatePrimeTimeRule(ctx, dbgen.UpdatePrimeTimeRuleParams{
+		ID:                 ruleID,
+		FacilityID:         facilityID,
+		DayOfWeek:          fields.DayOfWeek,
+		StartTime:          fields.StartTime,
+		EndTime:            fields.EndTime,
+		MinMembershipLevel: fields.MinMembershipLevel,
+		UnlockHoursBefore:  fields.UnlockHoursBefore,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Prime time rule not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Int64("rule_id", ruleID).Msg("Failed to update prime time rule")
+		http.Error(w, "Failed to save prime time rule", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"rule": rule}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write prime time rule response")
+		return
+	}
+}
+
+// DELETE /api/v1/facilities/{id}/prime-time/{ruleId}
+func HandlePrimeTimeRuleDelete(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ruleID, err := primeTimeRuleIDFromPath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), operatingHoursQueryTimeout)
+	defer cancel()
+
+	deleted, err := q.DeletePrimeTimeRule(ctx, dbgen.DeletePrimeTimeRuleParams{
+		ID:         ruleID,
+		FacilityID: facilityID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Int64("rule_id", ruleID).Msg("Failed to delete prime time rule")
+		http.Error(w, "Failed to delete prime time rule", http.StatusInternalServerError)
+		return
+	}
+	if deleted == 0 {
+		http.Error(w, "Prime time rule not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func primeTimeRuleFieldsFromRequest(req primeTimeRuleRequest) (primeTimeRuleFields, error) {
+	if req.DayOfWeek == nil {
+		return primeTimeRuleFields{}, fmt.Errorf("day_of_week is required")
+	}
+	if *req.DayOfWeek < 0 || *req.DayOfWeek > 6 {
+		return primeTimeRuleFields{}, fmt.Errorf("day_of_week must be between 0 and 6")
+	}
+	startTime, startParsed, err := parseOperatingTime(req.StartTime, "start_time")
+	if err != nil {
+		return primeTimeRuleFields{}, err
+	}
+	endTime, endParsed, err := parseOperatingTime(req.EndTime, "end_time")
+	if err != nil {
+		return primeTimeRuleFields{}, err
+	}
+	if !endParsed.After(startParsed) {
+		return primeTimeRuleFields{}, fmt.Errorf("end_time must be after start_time")
+	}
+	if req.MinMembershipLevel == nil {
+		return primeTimeRuleFields{}, fmt.Errorf("min_membership_level is required")
+	}
+	if *req.MinMembershipLevel < 1 {
+		return primeTimeRuleFields{}, fmt.Errorf("min_membership_level must be at least 1")
+	}
+	if req.UnlockHoursBefore == nil {
+		return primeTimeRuleFields{}, fmt.Errorf("unlock_hours_before is required")
+	}
+	if *req.UnlockHoursBefore < 0 || *req.UnlockHoursBefore > apiutil.MaxPrimeTimeUnlockHours {
+		return primeTimeRuleFields{}, fmt.Errorf("unlock_hours_before must be between 0 and %d", apiutil.MaxPrimeTimeUnlockHours)
+	}
+
+	return primeTimeRuleFields{
+		DayOfWeek:          *req.DayOfWeek,
+		StartTime:          startTime,
+		EndTime:            endTime,
+		MinMembershipLevel: *req.MinMembershipLevel,
+		UnlockHoursBefore:  *req.UnlockHoursBefore,
+	}, nil
+}
+
+func primeTimeRuleIDFromPath(r *http.Request) (int64, error) {
+	raw := strings.TrimSpace(r.PathValue(primeTimeRuleIDParam))
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid prime time rule ID")
+	}
+	return id, nil
+}
+
+func decodePrimeTimeRuleRequest(r *http.Request) (primeTimeRuleRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req primeTimeRuleRequest
+		return req, apiutil.DecodeJSON(r, &req)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return primeTimeRuleRequest{}, err
+	}
+
+	dayOfWeek, err := parseOptionalInt64(apiutil.FirstNonEmpty(r.FormValue("day_of_week"), r.FormValue("dayOfWeek")))
+	if err != nil {
+		return primeTimeRuleRequest{}, err
+	}
+	minLevel, err := parseOptionalInt64(apiutil.FirstNonEmpty(r.FormValue("min_membership_level"), r.FormValue("minMembershipLevel")))
+	if err != nil {
+		return primeTimeRuleRequest{}, err
+	}
+	unlockHours, err := parseOptionalInt64(apiutil.FirstNonEmpty(r.FormValue("unlock_hours_before"), r.FormValue("unlockHoursBefore")))
+	if err != nil {
+		return primeTimeRuleRequest{}, err
+	}
+
+	return primeTimeRuleRequest{
+		DayOfWeek:          dayOfWeek,
+		StartTime:          apiutil.FirstNonEmpty(r.FormValue("start_time"), r.FormValue("startTime")),
+		EndTime:            apiutil.FirstNonEmpty(r.FormValue("end_time"), r.FormValue("endTime")),
+		MinMembershipLevel: minLevel,
+		UnlockHoursBefore:  unlockHours,
+	}, nil
+}
+
+func parseOptionalInt64(raw string) (*int64, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,84 @@
+package operatinghours
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestPrimeTimeRules_CreateListUpdateDelete(t *testing.T) {
+	_, facilityID := setupOperatingHoursTest(t)
+
+	call := func(handler http.HandlerFunc, method, target, ruleID, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", fmt.Sprintf("%d", facilityID))
+		if ruleID != "" {
+			req.SetPathValue("ruleId", ruleID)
+		}
+		req = withAuthUser(req, facilityID)
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder
+	}
+	path := fmt.Sprintf("/api/v1/facilities/%d/prime-time", facilityID)
+
+	if recorder := call(HandlePrimeTimeRuleCreate, http.MethodPost, path, "", `{"dayOfWeek":2,"startTime":"20:00","endTime":"17:00","minMembershipLevel":2,"unlockHoursBefore":48}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected inverted window to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := call(HandlePrimeTimeRuleCreate, http.MethodPost, path, "", `{"startTime":"17:00","endTime":"20:00","minMembershipLevel":2,"unlockHoursBefore":48}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected missing day_of_week to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder := call(HandlePrimeTimeRuleCreate, http.MethodPost, path, "", `{"dayOfWeek":2,"startTime":"5:00 PM","endTime":"20:00","minMembershipLevel":2,"unlockHoursBefore":48}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("create status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created struct {
+		Rule dbgen.PrimeTimeRule `json:"rule"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode create response: %v", err)
+	}
+	if created.Rule.StartTime != "17:00" || created.Rule.DayOfWeek != 2 || created.Rule.UnlockHoursBefore != 48 {
+		t.Fatalf("unexpected rule: %+v", created.Rule)
+	}
+	ruleID := fmt.Sprintf("%d", created.Rule.ID)
+
+	recorder = call(HandlePrimeTimeRuleUpdate, http.MethodPut, path+"/"+ruleID, ruleID, `{"dayOfWeek":3,"startTime":"17:00","endTime":"20:00","minMembershipLevel":3,"unlockHoursBefore":24}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("update status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = call(HandlePrimeTimeRulesList, http.MethodGet, path, "", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("list status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var listed struct {
+		Rules []dbgen.PrimeTimeRule `json:"rules"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("decode list response: %v", err)
+	}
+	if len(listed.Rules) != 1 || listed.Rules[0].DayOfWeek != 3 || listed.Rules[0].MinMembershipLevel != 3 {
+		t.Fatalf("unexpected rules: %+v", listed.Rules)
+	}
+
+	if recorder := call(HandlePrimeTimeRuleUpdate, http.MethodPut, path+"/999", "999", `{"dayOfWeek":3,"startTime":"17:00","endTime":"20:00","minMembershipLevel":3,"unlockHoursBefore":24}`); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected unknown rule update to 404, got %d", recorder.Code)
+	}
+	if recorder := call(HandlePrimeTimeRuleDelete, http.MethodDelete, path+"/"+ruleID, ruleID, ""); recorder.Code != http.StatusNoContent {
+		t.Fatalf("delete status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := call(HandlePrimeTimeRuleDelete, http.MethodDelete, path+"/"+ruleID, ruleID, ""); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected second delete to 404, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,360 @@
+// internal/api/organizations/handlers.go
+package organizations
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const organizationQueryTimeout = 5 * time.Second
+
+var (
+	queries     *dbgen.Queries
+	queriesOnce sync.Once
+)
+
+type cancellationTemplateTier struct {
+	MinHoursBefore   int64 `json:"minHoursBefore"`
+	RefundPercentage int64 `json:"refundPercentage"`
+}
+
+type organizationSettingsResponse struct {
+	ID                        int64                      `json:"id"`
+	Name                      string                     `json:"name"`
+	Slug                      string                     `json:"slug"`
+	Status                    string                     `json:"status"`
+	CrossFacilityVisitPacks   bool                       `json:"crossFacilityVisitPacks"`
+	SharedMemberDirectory     bool                       `json:"sharedMemberDirectory"`
+	DefaultCancellationPolicy []cancellationTemplateTier `json:"defaultCancellationPolicy"`
+	UpdatedAt                 time.Time                  `json:"updatedAt"`
+}
+
+// organizationSettingsRequest is a partial update: omitted fields keep their
+// value, and a null defaultCancellationPolicy clears the template.
+type organizationSettingsRequest struct {
+	CrossFacilityVisitPacks   *bool           `json:"crossFacilityVisitPacks"`
+	SharedMemberDirectory     *bool           `json:"sharedMemberDirectory"`
+	DefaultCancellationPolicy json.RawMessage `json:"defaultCancellationPolicy"`
+}
+
+type facilitySettingsSummary struct {
+	ID                        int64  `json:"id"`
+	Name                      string `json:"name"`
+	Slug                      string `json:"slug"`
+	Timezone                  string `json:"timezone"`
+	MaxAdvanceBookingDays     int64  `json:"maxAdvanceBookingDays"`
+	MaxMemberReservations     int64  `json:"maxMemberReservations"`
+	MaxCourtsPerMemberBooking int64  `json:"maxCourtsPerMemberBooking"`
+	LessonMinNoticeHours      int64  `json:"lessonMinNoticeHours"`
+	ReminderHoursBefore       int64  `json:"reminderHoursBefore"`
+	TierBookingEnabled        bool   `json:"tierBookingEnabled"`
+	SlotDurationMinutes       int64  `json:"slotDurationMinutes"`
+	MinBookingMinutes         int64  `json:"minBookingMinutes"`
+}
+
+type organizationFacilitiesResponse struct {
+	OrganizationID int64                     `json:"organizationId"`
+	Facilities     []facilitySettingsSummary `json:"facilities"`
+}
+
+// InitHandlers must be called during server startup before handling requests.
+func InitHandlers(database *appdb.DB) {
+	if database == nil {
+		log.Warn().Msg("organizations.InitHandlers called with nil database; handlers will be unavailable")
+		return
+	}
+	queriesOnce.Do(func() {
+		queries = database.Queries
+	})
+}
+
+// GET /api/v1/organizations/{id}
+func HandleGetOrganization(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	organizationID, err := organizationIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), organizationQueryTimeout)
+	defer cancel()
+
+	if !apiutil.RequireOrganizationAdmin(ctx, w, r, q, organizationID) {
+		return
+	}
+
+	settings, err := q.GetOrganizationSettings(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Organization not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to load organization settings")
+		http.Error(w, "Failed to load organization", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := organizationSettingsFromRow(dbgen.UpdateOrganizationSettingsRow(settings))
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to decode default cancellation policy")
+		http.Error(w, "Failed to load organization", http.StatusInternalServerError)
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to write organization response")
+	}
+}
+
+// PUT /api/v1/organizations/{id}
+func HandleUpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	organizationID, err := organizationIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req organizationSettingsRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), organizationQueryTimeout)
+	defer cancel()
+
+	if !apiutil.RequireOrganizationAdmin(ctx, w, r, q, organizationID) {
+		return
+	}
+
+	current, err := q.GetOrganizationSettings(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Organization not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to load organization settings")
+		http.Error(w, "Failed to load organization", http.StatusInternalServerError)
+		return
+	}
+
+	params := dbgen.UpdateOrganizationSettingsParams{
+		ID:                        organizationID,
+		CrossFacilityVisitPacks:   current.CrossFacilityVisitPacks,
+		SharedMemberDirectory:     current.SharedMemberDirectory,
+		DefaultCancellationPolicy: current.DefaultCancellationPolicy,
+	}
+	if req.CrossFacilityVisitPacks != nil {
+		params.CrossFacilityVisitPacks = *req.CrossFacilityVisitPacks
+	}
+	if req.SharedMemberDirectory != nil {
+		params.SharedMemberDirectory = *req.SharedMemberDirectory
+	}
+	if req.DefaultCancellationPolicy != nil {
+		template, err := parseCancellationTemplate(req.DefaultCancellationPolicy)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		params.DefaultCancellationPolicy = template
+	}
+
+	updated, err := q.UpdateOrganizationSettings(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Organization not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to update organization settings")
+		http.Error(w, "Failed to update organization", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info().
+		Int64("organization_id", organizationID).
+		Bool("cross_facility_visit_packs", updated.CrossFacilityVisitPacks).
+		Bool("shared_member_directory", updated.SharedMemberDirectory).
+		Msg("Organization settings updated")
+
+	response, err := organizationSettingsFromRow(updated)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to decode default cancellation policy")
+		http.Error(w, "Failed to update organization", http.StatusInternalServerError)
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to write organization response")
+	}
+}
+
+// GET /api/v1/organizations/{id}/facilities
+func HandleListOrganizationFacilities(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	organizationID, err := organizationIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), organizationQueryTimeout)
+	defer cancel()
+
+	if !apiutil.RequireOrganizationAdmin(ctx, w, r, q, organizationID) {
+		return
+	}
+
+	if _, err := q.GetOrganizationByID(ctx, organizationID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Organization not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to load organization")
+		http.Error(w, "Failed to load organization", http.StatusInternalServerError)
+		return
+	}
+
+	facilities, err := q.ListFacilitiesByOrganization(ctx, organizationID)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to list organization facilities")
+		http.Error(w, "Failed to list facilities", http.StatusInternalServerError)
+		return
+	}
+
+	response := organizationFacilitiesResponse{
+		OrganizationID: organizationID,
+		Facilities:     make([]facilitySettingsSummary, 0, len(facilities)),
+	}
+	for _, facility := range facilities {
+		response.Facilities = append(response.Facilities, facilitySettingsSummary{
+			ID:                        facility.ID,
+			Name:                      facility.Name,
+			Slug:                      facility.Slug,
+			Timezone:                  facility.Timezone,
+			MaxAdvanceBookingDays:     facility.MaxAdvanceBookingDays,
+			MaxMemberReservations:     facility.MaxMemberReservations,
+			MaxCourtsPerMemberBooking: facility.MaxCourtsPerMemberBooking,
+			LessonMinNoticeHours:      facility.LessonMinNoticeHours,
+			ReminderHoursBefore:       facility.ReminderHoursBefore,
+			TierBookingEnabled:        facility.TierBookingEnabled,
+			SlotDurationMinutes:       facility.SlotDurationMinutes,
+			MinBookingMinutes:         facility.MinBookingMinutes,
+		})
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to write organization facilities response")
+	}
+}
+
+func organizationSettingsFromRow(row dbgen.UpdateOrganizationSettingsRow) (organizationSettingsResponse, error) {
+	response := organizationSettingsResponse{
+		ID:                      row.ID,
+		Name:                    row.Name,
+		Slug:                    row.Slug,
+		Status:                  row.Status,
+		CrossFacilityVisitPacks: row.CrossFacilityVisitPacks,
+		SharedMemberDirectory:   row.SharedMemberDirectory,
+		UpdatedAt:               row.UpdatedAt,
+	}
+	if row.DefaultCancellationPolicy.Valid && row.DefaultCancellationPolicy.String != "" {
+		if err := json.Unmarshal([]byte(row.DefaultCancellationPolicy.String), &response.DefaultCancellationPolicy); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+// parseCancellationTemplate validates the template tiers the same way the
+// facility policy tiers are validated and stores them most-notice first.
+// JSON null or an empty list clears the template.
+func parseCancellationTemplate(raw json.RawMessage) (sql.NullString, error) {
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return sql.NullString{}, nil
+	}
+
+	var tiers []cancellationTemplateTier
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&tiers); err != nil {
+		return sql.NullString{}, fmt.Errorf("defaultCancellationPolicy must be a list of tiers")
+	}
+	if len(tiers) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	seen := make(map[int64]struct{}, len(tiers))
+	for _, tier := range tiers {
+		if tier.MinHoursBefore < 0 {
+			return sql.NullString{}, fmt.Errorf("minHoursBefore must be zero or greater")
+		}
+		if tier.RefundPercentage < 0 || tier.RefundPercentage > 100 {
+			return sql.NullString{}, fmt.Errorf("refundPercentage must be between 0 and 100")
+		}
+		if _, ok := seen[tier.MinHoursBefore]; ok {
+			return sql.NullString{}, fmt.Errorf("minHoursBefore %d appears more than once", tier.MinHoursBefore)
+		}
+		seen[tier.MinHoursBefore] = struct{}{}
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].MinHoursBefore > tiers[j].MinHoursBefore
+	})
+
+	encoded, err := json.Marshal(tiers)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+func organizationIDFromRequest(r *http.Request) (int64, error) {
+	raw := strings.TrimSpace(r.PathValue("id"))
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid organization ID")
+	}
+	return id, nil
+}
+
+func loadQueries() *dbgen.Queries {
+	return queries
+}
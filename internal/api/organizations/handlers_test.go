@@ -0,0 +1,137 @@
+package organizations
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestOrganizationAdminAPI(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	queriesOnce = sync.Once{}
+	queries = nil
+	InitHandlers(database)
+	t.Cleanup(func() {
+		queriesOnce = sync.Once{}
+		queries = nil
+	})
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('PicklePlex', 'pickleplex', 'active')")
+	otherOrgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Other', 'other', 'active')")
+	northID := exec("INSERT INTO facilities (organization_id, name, slug, timezone, max_advance_booking_days) VALUES (?, 'North', 'north', 'UTC', 14)", orgID)
+	exec("INSERT INTO facilities (organization_id, name, slug, timezone, max_member_reservations) VALUES (?, 'South', 'south', 'UTC', 5)", orgID)
+	exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Elsewhere', 'elsewhere', 'UTC')", otherOrgID)
+
+	insertStaff := func(email, role string, homeFacilityID any) int64 {
+		t.Helper()
+		userID := exec("INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES ('Sam', 'Staff', ?, 'active', 1)", email)
+		exec("INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, 'Sam', 'Staff', ?, ?)", userID, homeFacilityID, role)
+		return userID
+	}
+	orgAdminID := insertStaff("corp@test.com", "admin", nil)
+	clubAdminID := insertStaff("club@test.com", "admin", northID)
+
+	request := func(method, path string, userID int64, body string, org *authz.Organization) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		parts := strings.Split(strings.TrimPrefix(path, "/api/v1/organizations/"), "/")
+		req.SetPathValue("id", parts[0])
+		reqCtx := authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: userID, IsStaff: true})
+		if org != nil {
+			reqCtx = authz.ContextWithOrganization(reqCtx, org)
+		}
+		req = req.WithContext(reqCtx)
+		recorder := httptest.NewRecorder()
+		switch {
+		case len(parts) > 1:
+			HandleListOrganizationFacilities(recorder, req)
+		case method == http.MethodPut:
+			HandleUpdateOrganization(recorder, req)
+		default:
+			HandleGetOrganization(recorder, req)
+		}
+		return recorder
+	}
+	orgPath := fmt.Sprintf("/api/v1/organizations/%d", orgID)
+
+	if recorder := request(http.MethodGet, orgPath, clubAdminID, "", nil); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected a facility admin to be forbidden, got %d", recorder.Code)
+	}
+	if recorder := request(http.MethodGet, orgPath, orgAdminID, "", &authz.Organization{ID: otherOrgID}); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected another organization's subdomain to be forbidden, got %d", recorder.Code)
+	}
+
+	recorder := request(http.MethodPut, orgPath, orgAdminID,
+		`{"crossFacilityVisitPacks": true, "defaultCancellationPolicy": [{"minHoursBefore": 0, "refundPercentage": 0}, {"minHoursBefore": 24, "refundPercentage": 100}]}`, nil)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("update: %d %s", recorder.Code, recorder.Body.String())
+	}
+	var settings organizationSettingsResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &settings); err != nil {
+		t.Fatalf("decode settings: %v", err)
+	}
+	if !settings.CrossFacilityVisitPacks || settings.SharedMemberDirectory || len(settings.DefaultCancellationPolicy) != 2 ||
+		settings.DefaultCancellationPolicy[0].MinHoursBefore != 24 {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+	// Booking reads the flag straight from the database, so the change
+	// applies to the next booking.
+	crossFacility, err := database.Queries.GetOrganizationCrossFacilitySetting(ctx, orgID)
+	if err != nil || !crossFacility {
+		t.Fatalf("expected cross-facility visit packs enabled, got %v, %v", crossFacility, err)
+	}
+
+	if recorder := request(http.MethodPut, orgPath, orgAdminID, `{"sharedMemberDirectory": true}`, nil); recorder.Code != http.StatusOK {
+		t.Fatalf("partial update: %d %s", recorder.Code, recorder.Body.String())
+	}
+	recorder = request(http.MethodGet, orgPath, orgAdminID, "", nil)
+	settings = organizationSettingsResponse{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &settings); err != nil {
+		t.Fatalf("decode settings: %v", err)
+	}
+	if !settings.CrossFacilityVisitPacks || !settings.SharedMemberDirectory || len(settings.DefaultCancellationPolicy) != 2 {
+		t.Fatalf("expected a partial update to keep other settings, got %+v", settings)
+	}
+
+	if recorder := request(http.MethodPut, orgPath, orgAdminID, `{"defaultCancellationPolicy": [{"minHoursBefore": 4, "refundPercentage": 150}]}`, nil); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected an invalid refund to be rejected, got %d", recorder.Code)
+	}
+	if recorder := request(http.MethodGet, "/api/v1/organizations/9999", orgAdminID, "", nil); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a missing organization, got %d", recorder.Code)
+	}
+
+	recorder = request(http.MethodGet, orgPath+"/facilities", orgAdminID, "", nil)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("list facilities: %d %s", recorder.Code, recorder.Body.String())
+	}
+	var listing organizationFacilitiesResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &listing); err != nil {
+		t.Fatalf("decode facilities: %v", err)
+	}
+	if len(listing.Facilities) != 2 || listing.Facilities[0].Name != "North" || listing.Facilities[0].MaxAdvanceBookingDays != 14 ||
+		listing.Facilities[1].MaxMemberReservations != 5 || listing.Facilities[1].SlotDurationMinutes != 60 {
+		t.Fatalf("unexpected facilities: %+v", listing.Facilities)
+	}
+}
@@ -0,0 +1,482 @@
+// internal/api/organizations/membership_tiers.go
+package organizations
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// maxMembershipTierBookingWindowDays matches the facility tier booking
+// window cap.
+const maxMembershipTierBookingWindowDays = 364
+
+type membershipTierResponse struct {
+	ID                    int64     `json:"id"`
+	OrganizationID        int64     `json:"organizationId"`
+	Level                 int64     `json:"level"`
+	Name                  string    `json:"name"`
+	BookingWindowDays     *int64    `json:"bookingWindowDays"`
+	MaxActiveReservations *int64    `json:"maxActiveReservations"`
+	PrimeTimeAccess       bool      `json:"primeTimeAccess"`
+	GuestPassesPerMonth   int64     `json:"guestPassesPerMonth"`
+	VisitPacks            bool      `json:"visitPacks"`
+	CreatedAt             time.Time `json:"createdAt"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+}
+
+type membershipTiersResponse struct {
+	OrganizationID int64                    `json:"organizationId"`
+	Tiers          []membershipTierResponse `json:"tiers"`
+}
+
+// membershipTierRequest creates a tier, or partially updates one: omitted
+// fields keep their value, and null bookingWindowDays or
+// maxActiveReservations falls back to the facility setting.
+type membershipTierRequest struct {
+	Level                 *int64          `json:"level"`
+	Name                  *string         `json:"name"`
+	BookingWindowDays     json.RawMessage `json:"bookingWindowDays"`
+	MaxActiveReservations json.RawMessage `json:"maxActiveReservations"`
+	PrimeTimeAccess       *bool           `json:"primeTimeAccess"`
+	GuestPassesPerMonth   *int64          `json:"guestPassesPerMonth"`
+	VisitPacks            *bool           `json:"visitPacks"`
+}
+
+// GET /api/v1/organizations/{id}/membership-tiers
+func HandleListMembershipTiers(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	organizationID, err := organizationIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), organizationQueryTimeout)
+	defer cancel()
+
+	if !apiutil.RequireOrganizationAdmin(ctx, w, r, q, organizationID) {
+		return
+	}
+	if !requireOrganization(ctx, w, r, q, organizationID) {
+		return
+	}
+
+	tiers, err := q.ListMembershipTiers(ctx, organizationID)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to list membership tiers")
+		http.Error(w, "Failed to list membership tiers", http.StatusInternalServerError)
+		return
+	}
+
+	response := membershipTiersResponse{
+		OrganizationID: organizationID,
+		Tiers:          make([]membershipTierResponse, 0, len(tiers)),
+	}
+	for _, tier := range tiers {
+		response.Tiers = append(response.Tiers, membershipTierFromRow(tier))
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to write membership tiers response")
+	}
+}
+
+// POST /api/v1/organizations/{id}/membership-tiers
+func HandleCreateMembershipTier(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	organizationID, err := organizationIDFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req membershipTierRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if req.Level == nil {
+		http.Error(w, "level is required", http.StatusBadRequest)
+		return
+	}
+	if req.Name == nil {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+
+	tier, err := applyMembershipTierRequest(dbgen.MembershipTier{}, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params := dbgen.CreateMembershipTierParams{
+		OrganizationID:        organizationID,
+		Level:                 tier.Level,
+		Name:                  tier.Name,
+		BookingWindowDays:     tier.BookingWindowDays,
+		MaxActiveReservations: tier.MaxActiveReservations,
+		PrimeTimeAccess:       tier.PrimeTimeAccess,
+		GuestPassesPerMonth:   tier.GuestPassesPerMonth,
+		VisitPacks:            tier.VisitPacks,
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), organizationQueryTimeout)
+	defer cancel()
+
+	if !apiutil.RequireOrganizationAdmin(ctx, w, r, q, organizationID) {
+		return
+	}
+	if !requireOrganization(ctx, w, r, q, organizationID) {
+		return
+	}
+	if !requireUnusedTierLevel(ctx, w, r, q, organizationID, params.Level, 0) {
+		return
+	}
+
+	created, err := q.CreateMembershipTier(ctx, params)
+	if err != nil {
+		logger.Error().Err(err).Int64("organization_id", organizationID).Int64("level", params.Level).Msg("Failed to create membership tier")
+		http.Error(w, "Failed to create membership tier", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info().
+		Int64("organization_id", organizationID).
+		Int64("membership_tier_id", created.ID).
+		Int64("level", created.Level).
+		Msg("Membership tier created")
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, membershipTierFromRow(created)); err != nil {
+		logger.Error().Err(err).Int64("membership_tier_id", created.ID).Msg("Failed to write membership tier response")
+	}
+}
+
+// GET /api/v1/organizations/{id}/membership-tiers/{tierId}
+func HandleGetMembershipTier(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	organizationID, tierID, err := membershipTierIDsFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), organizationQueryTimeout)
+	defer cancel()
+
+	if !apiutil.RequireOrganizationAdmin(ctx, w, r, q, organizationID) {
+		return
+	}
+
+	tier, ok := loadMembershipTier(ctx, w, r, q, organizationID, tierID)
+	if !ok {
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, membershipTierFromRow(tier)); err != nil {
+		logger.Error().Err(err).Int64("membership_tier_id", tierID).Msg("Failed to write membership tier response")
+	}
+}
+
+// PUT /api/v1/organizations/{id}/membership-tiers/{tierId}
+func HandleUpdateMembershipTier(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	organizationID, tierID, err := membershipTierIDsFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req membershipTierRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), organizationQueryTimeout)
+	defer cancel()
+
+	if !apiutil.RequireOrganizationAdmin(ctx, w, r, q, organizationID) {
+		return
+	}
+
+	current, ok := loadMembershipTier(ctx, w, r, q, organizationID, tierID)
+	if !ok {
+		return
+	}
+	tier, err := applyMembershipTierRequest(current, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if tier.Level != current.Level && !requireUnusedTierLevel(ctx, w, r, q, organizationID, tier.Level, tierID) {
+		return
+	}
+
+	updated, err := q.UpdateMembershipTier(ctx, dbgen.UpdateMembershipTierParams{
+		Level:                 tier.Level,
+		Name:                  tier.Name,
+		BookingWindowDays:     tier.BookingWindowDays,
+		MaxActiveReservations: tier.MaxActiveReservations,
+		PrimeTimeAccess:       tier.PrimeTimeAccess,
+		GuestPassesPerMonth:   tier.GuestPassesPerMonth,
+		VisitPacks:            tier.VisitPacks,
+		ID:                    tierID,
+		OrganizationID:        organizationID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Membership tier not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("membership_tier_id", tierID).Msg("Failed to update membership tier")
+		http.Error(w, "Failed to update membership tier", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info().
+		Int64("organization_id", organizationID).
+		Int64("membership_tier_id", updated.ID).
+		Int64("level", updated.Level).
+		Msg("Membership tier updated")
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, membershipTierFromRow(updated)); err != nil {
+		logger.Error().Err(err).Int64("membership_tier_id", tierID).Msg("Failed to write membership tier response")
+	}
+}
+
+// DELETE /api/v1/organizations/{id}/membership-tiers/{tierId}
+//
+// Members at the deleted tier's level fall back to the next tier down, or to
+// the built-in default for their level when none is left.
+func HandleDeleteMembershipTier(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	organizationID, tierID, err := membershipTierIDsFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), organizationQueryTimeout)
+	defer cancel()
+
+	if !apiutil.RequireOrganizationAdmin(ctx, w, r, q, organizationID) {
+		return
+	}
+
+	deleted, err := q.DeleteMembershipTier(ctx, dbgen.DeleteMembershipTierParams{
+		ID:             tierID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("membership_tier_id", tierID).Msg("Failed to delete membership tier")
+		http.Error(w, "Failed to delete membership tier", http.StatusInternalServerError)
+		return
+	}
+	if deleted == 0 {
+		http.Error(w, "Membership tier not found", http.StatusNotFound)
+		return
+	}
+
+	logger.Info().
+		Int64("organization_id", organizationID).
+		Int64("membership_tier_id", tierID).
+		Msg("Membership tier deleted")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyMembershipTierRequest returns tier with the request's fields applied
+// and validated.
+func applyMembershipTierRequest(tier dbgen.MembershipTier, req membershipTierRequest) (dbgen.MembershipTier, error) {
+	if req.Level != nil {
+		if *req.Level < 0 {
+			return tier, fmt.Errorf("level must be zero or greater")
+		}
+		tier.Level = *req.Level
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return tier, fmt.Errorf("name is required")
+		}
+		tier.Name = name
+	}
+	if req.BookingWindowDays != nil {
+		days, err := parseNullableInt64(req.BookingWindowDays, "bookingWindowDays")
+		if err != nil {
+			return tier, err
+		}
+		if days.Valid && (days.Int64 < 1 || days.Int64 > maxMembershipTierBookingWindowDays) {
+			return tier, fmt.Errorf("bookingWindowDays must be between 1 and %d", maxMembershipTierBookingWindowDays)
+		}
+		tier.BookingWindowDays = days
+	}
+	if req.MaxActiveReservations != nil {
+		limit, err := parseNullableInt64(req.MaxActiveReservations, "maxActiveReservations")
+		if err != nil {
+			return tier, err
+		}
+		if limit.Valid && limit.Int64 < 0 {
+			return tier, fmt.Errorf("maxActiveReservations must be zero or greater")
+		}
+		tier.MaxActiveReservations = limit
+	}
+	if req.PrimeTimeAccess != nil {
+		tier.PrimeTimeAccess = *req.PrimeTimeAccess
+	}
+	if req.GuestPassesPerMonth != nil {
+		if *req.GuestPassesPerMonth < 0 {
+			return tier, fmt.Errorf("guestPassesPerMonth must be zero or greater")
+		}
+		tier.GuestPassesPerMonth = *req.GuestPassesPerMonth
+	}
+	if req.VisitPacks != nil {
+		tier.VisitPacks = *req.VisitPacks
+	}
+	return tier, nil
+}
+
+func parseNullableInt64(raw json.RawMessage, field string) (sql.NullInt64, error) {
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return sql.NullInt64{}, nil
+	}
+	var value int64
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return sql.NullInt64{}, fmt.Errorf("%s must be an integer or null", field)
+	}
+	return sql.NullInt64{Int64: value, Valid: true}, nil
+}
+
+func requireOrganization(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries, organizationID int64) bool {
+	if _, err := q.GetOrganizationByID(ctx, organizationID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Organization not found", http.StatusNotFound)
+			return false
+		}
+		log.Ctx(r.Context()).Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to load organization")
+		http.Error(w, "Failed to load organization", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// requireUnusedTierLevel rejects a level another tier in the organization
+// already has. exceptID is the tier being updated, or zero.
+func requireUnusedTierLevel(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries, organizationID, level, exceptID int64) bool {
+	tiers, err := q.ListMembershipTiers(ctx, organizationID)
+	if err != nil {
+		log.Ctx(r.Context()).Error().Err(err).Int64("organization_id", organizationID).Msg("Failed to list membership tiers")
+		http.Error(w, "Failed to check membership tier levels", http.StatusInternalServerError)
+		return false
+	}
+	for _, tier := range tiers {
+		if tier.Level == level && tier.ID != exceptID {
+			http.Error(w, fmt.Sprintf("A membership tier already exists for level %d", level), http.StatusConflict)
+			return false
+		}
+	}
+	return true
+}
+
+func loadMembershipTier(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries, organizationID, tierID int64) (dbgen.MembershipTier, bool) {
+	tier, err := q.GetMembershipTier(ctx, dbgen.GetMembershipTierParams{
+		ID:             tierID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Membership tier not found", http.StatusNotFound)
+			return tier, false
+		}
+		log.Ctx(r.Context()).Error().Err(err).Int64("membership_tier_id", tierID).Msg("Failed to load membership tier")
+		http.Error(w, "Failed to load membership tier", http.StatusInternalServerError)
+		return tier, false
+	}
+	return tier, true
+}
+
+func membershipTierFromRow(row dbgen.MembershipTier) membershipTierResponse {
+	response := membershipTierResponse{
+		ID:                  row.ID,
+		OrganizationID:      row.OrganizationID,
+		Level:               row.Level,
+		Name:                row.Name,
+		PrimeTimeAccess:     row.PrimeTimeAccess,
+		GuestPassesPerMonth: row.GuestPassesPerMonth,
+		VisitPacks:          row.VisitPacks,
+		CreatedAt:           row.CreatedAt,
+		UpdatedAt:           row.UpdatedAt,
+	}
+	if row.BookingWindowDays.Valid {
+		days := row.BookingWindowDays.Int64
+		response.BookingWindowDays = &days
+	}
+	if row.MaxActiveReservations.Valid {
+		limit := row.MaxActiveReservations.Int64
+		response.MaxActiveReservations = &limit
+	}
+	return response
+}
+
+func membershipTierIDsFromRequest(r *http.Request) (int64, int64, error) {
+	organizationID, err := organizationIDFromRequest(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	tierID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("tierId")), 10, 64)
+	if err != nil || tierID <= 0 {
+		return 0, 0, fmt.Errorf("invalid membership tier ID")
+	}
+	return organizationID, tierID, nil
+}
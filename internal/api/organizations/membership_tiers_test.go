@@ -0,0 +1,174 @@
+package organizations
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestMembershipTierAPI(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	queriesOnce = sync.Once{}
+	queries = nil
+	InitHandlers(database)
+	t.Cleanup(func() {
+		queriesOnce = sync.Once{}
+		queries = nil
+	})
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('PicklePlex', 'pickleplex', 'active')")
+	otherOrgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Other', 'other', 'active')")
+	northID := exec("INSERT INTO facilities (organization_id, name, slug, timezone, max_advance_booking_days) VALUES (?, 'North', 'north', 'UTC', 7)", orgID)
+
+	insertStaff := func(email string, homeFacilityID any) int64 {
+		t.Helper()
+		userID := exec("INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES ('Sam', 'Staff', ?, 'active', 1)", email)
+		exec("INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, 'Sam', 'Staff', ?, 'admin')", userID, homeFacilityID)
+		return userID
+	}
+	orgAdminID := insertStaff("corp@test.com", nil)
+	clubAdminID := insertStaff("club@test.com", northID)
+
+	request := func(method, path string, userID int64, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		parts := strings.Split(strings.TrimPrefix(path, "/api/v1/organizations/"), "/")
+		req.SetPathValue("id", parts[0])
+		if len(parts) > 2 {
+			req.SetPathValue("tierId", parts[2])
+		}
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: userID, IsStaff: true}))
+		recorder := httptest.NewRecorder()
+		switch {
+		case len(parts) == 2 && method == http.MethodPost:
+			HandleCreateMembershipTier(recorder, req)
+		case len(parts) == 2:
+			HandleListMembershipTiers(recorder, req)
+		case method == http.MethodPut:
+			HandleUpdateMembershipTier(recorder, req)
+		case method == http.MethodDelete:
+			HandleDeleteMembershipTier(recorder, req)
+		default:
+			HandleGetMembershipTier(recorder, req)
+		}
+		return recorder
+	}
+	tiersPath := fmt.Sprintf("/api/v1/organizations/%d/membership-tiers", orgID)
+	decodeTier := func(recorder *httptest.ResponseRecorder) membershipTierResponse {
+		t.Helper()
+		var tier membershipTierResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &tier); err != nil {
+			t.Fatalf("decode tier: %v", err)
+		}
+		return tier
+	}
+
+	if recorder := request(http.MethodGet, tiersPath, clubAdminID, ""); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected a facility admin to be forbidden, got %d", recorder.Code)
+	}
+
+	// New organizations start with the default tiers.
+	recorder := request(http.MethodGet, tiersPath, orgAdminID, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("list tiers: %d %s", recorder.Code, recorder.Body.String())
+	}
+	var listing membershipTiersResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &listing); err != nil {
+		t.Fatalf("decode tiers: %v", err)
+	}
+	if len(listing.Tiers) != 4 || listing.Tiers[1].Name != "Verified Guest" || !listing.Tiers[1].VisitPacks ||
+		listing.Tiers[2].VisitPacks || listing.Tiers[3].BookingWindowDays != nil {
+		t.Fatalf("unexpected default tiers: %+v", listing.Tiers)
+	}
+	memberTierID := listing.Tiers[2].ID
+	memberTierPath := fmt.Sprintf("%s/%d", tiersPath, memberTierID)
+
+	recorder = request(http.MethodPut, memberTierPath, orgAdminID,
+		`{"name": "Gold", "bookingWindowDays": 14, "maxActiveReservations": 0, "primeTimeAccess": true, "guestPassesPerMonth": 2}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("update tier: %d %s", recorder.Code, recorder.Body.String())
+	}
+	updated := decodeTier(recorder)
+	if updated.Name != "Gold" || updated.Level != 2 || updated.BookingWindowDays == nil || *updated.BookingWindowDays != 14 ||
+		updated.MaxActiveReservations == nil || *updated.MaxActiveReservations != 0 || !updated.PrimeTimeAccess || updated.GuestPassesPerMonth != 2 {
+		t.Fatalf("unexpected updated tier: %+v", updated)
+	}
+
+	// The tier now drives the member's booking window.
+	maxAdvanceDays, _, err := apiutil.GetMemberMaxAdvanceDays(ctx, database.Queries, database.Queries, northID, 2, apiutil.DefaultMaxAdvanceDays)
+	if err != nil || maxAdvanceDays != 14 {
+		t.Fatalf("expected a 14 day window for the Gold tier, got %d, %v", maxAdvanceDays, err)
+	}
+
+	if recorder := request(http.MethodPut, memberTierPath, orgAdminID, `{"bookingWindowDays": null}`); recorder.Code != http.StatusOK {
+		t.Fatalf("clear booking window: %d %s", recorder.Code, recorder.Body.String())
+	} else if tier := decodeTier(recorder); tier.BookingWindowDays != nil || tier.Name != "Gold" {
+		t.Fatalf("expected only the booking window cleared, got %+v", tier)
+	}
+
+	for _, body := range []string{
+		`{"bookingWindowDays": 365}`,
+		`{"maxActiveReservations": -1}`,
+		`{"name": "  "}`,
+		`{"bookingWindowDays": "soon"}`,
+	} {
+		if recorder := request(http.MethodPut, memberTierPath, orgAdminID, body); recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected %s to be rejected, got %d", body, recorder.Code)
+		}
+	}
+	if recorder := request(http.MethodPut, memberTierPath, orgAdminID, `{"level": 3}`); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected a duplicate level to conflict, got %d", recorder.Code)
+	}
+
+	recorder = request(http.MethodPost, tiersPath, orgAdminID, `{"level": 5, "name": "Founders", "bookingWindowDays": 30}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("create tier: %d %s", recorder.Code, recorder.Body.String())
+	}
+	founders := decodeTier(recorder)
+	if recorder := request(http.MethodPost, tiersPath, orgAdminID, `{"level": 5, "name": "Again"}`); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected a duplicate level to conflict, got %d", recorder.Code)
+	}
+	if recorder := request(http.MethodPost, tiersPath, orgAdminID, `{"name": "No level"}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected a missing level to be rejected, got %d", recorder.Code)
+	}
+
+	// Tiers belong to their organization.
+	otherPath := fmt.Sprintf("/api/v1/organizations/%d/membership-tiers/%d", otherOrgID, founders.ID)
+	if recorder := request(http.MethodGet, otherPath, orgAdminID, ""); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected another organization's path to miss the tier, got %d", recorder.Code)
+	}
+
+	foundersPath := fmt.Sprintf("%s/%d", tiersPath, founders.ID)
+	if recorder := request(http.MethodDelete, foundersPath, orgAdminID, ""); recorder.Code != http.StatusNoContent {
+		t.Fatalf("delete tier: %d %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := request(http.MethodGet, foundersPath, orgAdminID, ""); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected the deleted tier to be gone, got %d", recorder.Code)
+	}
+	if recorder := request(http.MethodDelete, foundersPath, orgAdminID, ""); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected deleting twice to miss, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,66 @@
+// internal/api/photos/handlers.go
+package photos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	userIDParam = "user_id"
+	sizeFull    = "full"
+	sizeThumb   = "thumb"
+)
+
+type photoQueries interface {
+	GetUserPhoto(ctx context.Context, userID int64) (dbgen.GetUserPhotoRow, error)
+}
+
+var queries photoQueries
+
+func InitHandlers(q *dbgen.Queries) {
+	queries = q
+}
+
+// HandleUserPhoto handles GET /api/v1/photos/{user_id}. size=thumb serves the
+// square thumbnail, falling back to the full photo for photos stored before
+// thumbnails existed.
+func HandleUserPhoto(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	userID, err := strconv.ParseInt(r.PathValue(userIDParam), 10, 64)
+	if err != nil || userID <= 0 {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+	size := r.URL.Query().Get("size")
+	if size != "" && size != sizeFull && size != sizeThumb {
+		http.Error(w, "size must be full or thumb", http.StatusBadRequest)
+		return
+	}
+
+	photo, err := queries.GetUserPhoto(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Photo not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("user_id", userID).Msg("Failed to fetch photo")
+		http.Error(w, "Failed to fetch photo", http.StatusInternalServerError)
+		return
+	}
+
+	data := photo.Data
+	if size == sizeThumb && len(photo.ThumbnailData) > 0 {
+		data = photo.ThumbnailData
+	}
+	apiutil.ServePhoto(w, r, data, photo.ContentType, photo.UpdatedAt)
+}
@@ -0,0 +1,102 @@
+package photos
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func servePhoto(t *testing.T, userID int64, size, ifNoneMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+	target := fmt.Sprintf("/api/v1/photos/%d", userID)
+	if size != "" {
+		target += "?size=" + size
+	}
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.SetPathValue(userIDParam, fmt.Sprintf("%d", userID))
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	recorder := httptest.NewRecorder()
+	HandleUserPhoto(recorder, req)
+	return recorder
+}
+
+func TestHandleUserPhoto(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	InitHandlers(database.Queries)
+
+	insertUser := func(name string) int64 {
+		t.Helper()
+		result, err := database.Exec("INSERT INTO users (first_name, last_name, status) VALUES (?, 'Photo', 'active')", name)
+		if err != nil {
+			t.Fatalf("insert user: %v", err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	normalized := insertUser("Normalized")
+	full := []byte("\xFF\xD8\xFFfull")
+	thumb := []byte("\xFF\xD8\xFFthumb")
+	if _, err := database.Exec(
+		"INSERT INTO user_photos (user_id, data, content_type, size, thumbnail_data) VALUES (?, ?, 'image/jpeg', ?, ?)",
+		normalized, full, len(full), thumb,
+	); err != nil {
+		t.Fatalf("insert photo: %v", err)
+	}
+
+	recorder := servePhoto(t, normalized, "", "")
+	if recorder.Code != http.StatusOK || !bytes.Equal(recorder.Body.Bytes(), full) {
+		t.Fatalf("expected the full photo, got %d %q", recorder.Code, recorder.Body.Bytes())
+	}
+	fullETag := recorder.Header().Get("ETag")
+	if fullETag == "" || recorder.Header().Get("Cache-Control") == "" {
+		t.Fatalf("expected cache headers, got %v", recorder.Header())
+	}
+
+	recorder = servePhoto(t, normalized, "thumb", "")
+	if !bytes.Equal(recorder.Body.Bytes(), thumb) {
+		t.Fatalf("expected the thumbnail, got %q", recorder.Body.Bytes())
+	}
+	if recorder.Header().Get("ETag") == fullETag {
+		t.Fatal("expected the thumbnail to have its own ETag")
+	}
+	if recorder := servePhoto(t, normalized, "", fullETag); recorder.Code != http.StatusNotModified || recorder.Body.Len() != 0 {
+		t.Fatalf("expected 304 for a matching ETag, got %d", recorder.Code)
+	}
+	if recorder := servePhoto(t, normalized, "", `"stale"`); recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a stale ETag, got %d", recorder.Code)
+	}
+
+	// Photos uploaded before normalization were labeled image/jpeg whatever
+	// they held and have no thumbnail.
+	legacy := insertUser("Legacy")
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, image.NewGray(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	if _, err := database.Exec(
+		"INSERT INTO user_photos (user_id, data, content_type, size) VALUES (?, ?, 'image/jpeg', ?)",
+		legacy, pngData.Bytes(), pngData.Len(),
+	); err != nil {
+		t.Fatalf("insert legacy photo: %v", err)
+	}
+	recorder = servePhoto(t, legacy, "thumb", "")
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "image/png" || !bytes.Equal(recorder.Body.Bytes(), pngData.Bytes()) {
+		t.Fatalf("expected the full PNG for a legacy thumbnail, got %d %q", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+
+	if recorder := servePhoto(t, insertUser("Nobody"), "", ""); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 without a photo, got %d", recorder.Code)
+	}
+	if recorder := servePhoto(t, normalized, "huge", ""); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown size, got %d", recorder.Code)
+	}
+}
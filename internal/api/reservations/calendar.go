@@ -0,0 +1,120 @@
+// internal/api/reservations/calendar.go
+package reservations
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/models"
+)
+
+const calendarDateLayout = "2006-01-02"
+
+// HandleReservationsCalendar handles GET /api/v1/reservations/calendar. It
+// returns the facility's courts and every reservation overlapping the day or
+// week starting at date, with court assignments included, so the calendar
+// renders from one request.
+func (h *Handlers) HandleReservationsCalendar(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	facilityID, err := facilityIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Facility ID is required")
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	granularity, err := models.ParseCalendarGranularity(r.URL.Query().Get("granularity"))
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	var courtFilter *int64
+	if rawCourtID := strings.TrimSpace(r.URL.Query().Get("court_id")); rawCourtID != "" {
+		courtID, err := strconv.ParseInt(rawCourtID, 10, 64)
+		if err != nil || courtID <= 0 {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "court_id must be a positive integer")
+			return
+		}
+		courtFilter = &courtID
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
+	defer cancel()
+
+	facility, err := q.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load calendar")
+		return
+	}
+	loc := apiutil.FacilityLocation(facility, logger)
+
+	date := time.Now().In(loc)
+	if rawDate := strings.TrimSpace(r.URL.Query().Get("date")); rawDate != "" {
+		date, err = time.ParseInLocation(calendarDateLayout, rawDate, loc)
+		if err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "date must be in YYYY-MM-DD format")
+			return
+		}
+	}
+	windowStart, windowEnd := models.CalendarWindow(date, granularity)
+
+	courts, err := q.ListCourts(ctx, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list courts")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load calendar")
+		return
+	}
+
+	params := dbgen.ListReservationCalendarEntriesParams{
+		FacilityID: facilityID,
+		StartTime:  windowStart.UTC(),
+		EndTime:    windowEnd.UTC(),
+	}
+	if courtFilter != nil {
+		filtered := courts[:0]
+		for _, court := range courts {
+			if court.ID == *courtFilter {
+				filtered = append(filtered, court)
+			}
+		}
+		if len(filtered) == 0 {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Court not found")
+			return
+		}
+		courts = filtered
+		params.CourtID = *courtFilter
+	}
+
+	rows, err := q.ListReservationCalendarEntries(ctx, params)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list calendar reservations")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load calendar")
+		return
+	}
+
+	calendar := models.BuildReservationCalendar(granularity, windowStart, windowEnd, courts, rows)
+	if err := apiutil.WriteJSON(w, http.StatusOK, calendar); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write calendar response")
+		return
+	}
+}
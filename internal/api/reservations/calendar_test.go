@@ -0,0 +1,114 @@
+package reservations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/models"
+)
+
+func TestHandleReservationsCalendar_OvernightReservation(t *testing.T) {
+	fixture := setupSyncTest(t)
+
+	insertCourt := func(number int) int64 {
+		t.Helper()
+		result, err := fixture.database.Exec(
+			"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, ?)",
+			fixture.facilityID, fmt.Sprintf("Court %d", number), number, "active",
+		)
+		if err != nil {
+			t.Fatalf("insert court: %v", err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	court1 := insertCourt(1)
+	court2 := insertCourt(2)
+
+	insertReservation := func(start, end time.Time, courtIDs ...int64) int64 {
+		t.Helper()
+		result, err := fixture.database.Exec(
+			`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+			 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'GAME'), ?, ?, ?, ?)`,
+			fixture.facilityID, fixture.userID, fixture.userID, start, end,
+		)
+		if err != nil {
+			t.Fatalf("insert reservation: %v", err)
+		}
+		id, _ := result.LastInsertId()
+		for _, courtID := range courtIDs {
+			if _, err := fixture.database.Exec(
+				"INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)",
+				id, courtID,
+			); err != nil {
+				t.Fatalf("assign court: %v", err)
+			}
+		}
+		return id
+	}
+
+	day := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	overnight := insertReservation(day.Add(23*time.Hour), day.Add(25*time.Hour), court1, court2)
+	earlyMorning := insertReservation(day.Add(24*time.Hour+30*time.Minute), day.Add(26*time.Hour), court1)
+	lateEvening := insertReservation(day.Add(20*time.Hour), day.Add(21*time.Hour), court2)
+
+	fetch := func(params url.Values) models.ReservationCalendar {
+		t.Helper()
+		params.Set("facility_id", fmt.Sprintf("%d", fixture.facilityID))
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleReservationsCalendar(recorder, fixture.request(params))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var calendar models.ReservationCalendar
+		if err := json.Unmarshal(recorder.Body.Bytes(), &calendar); err != nil {
+			t.Fatalf("decode calendar: %v", err)
+		}
+		return calendar
+	}
+	ids := func(calendar models.ReservationCalendar) []int64 {
+		result := make([]int64, 0, len(calendar.Reservations))
+		for _, reservation := range calendar.Reservations {
+			result = append(result, reservation.ID)
+		}
+		return result
+	}
+
+	first := fetch(url.Values{"date": {"2026-06-01"}})
+	if got := ids(first); fmt.Sprint(got) != fmt.Sprint([]int64{lateEvening, overnight}) {
+		t.Fatalf("June 1 reservations = %v, want [%d %d]", got, lateEvening, overnight)
+	}
+	if len(first.Courts) != 2 {
+		t.Fatalf("expected both courts, got %+v", first.Courts)
+	}
+	if courts := first.Reservations[1].CourtIDs; len(courts) != 2 || courts[0] != court1 || courts[1] != court2 {
+		t.Fatalf("overnight courts = %v, want [%d %d]", courts, court1, court2)
+	}
+
+	second := fetch(url.Values{"date": {"2026-06-02"}})
+	if got := ids(second); fmt.Sprint(got) != fmt.Sprint([]int64{overnight, earlyMorning}) {
+		t.Fatalf("June 2 reservations = %v, want [%d %d]", got, overnight, earlyMorning)
+	}
+	if !second.Reservations[0].Start.Equal(day.Add(23 * time.Hour)) {
+		t.Fatalf("expected overnight start to be unclipped, got %s", second.Reservations[0].Start)
+	}
+
+	// Filtering by court keeps the overnight block's full court list.
+	filtered := fetch(url.Values{"date": {"2026-06-02"}, "court_id": {fmt.Sprintf("%d", court2)}})
+	if got := ids(filtered); fmt.Sprint(got) != fmt.Sprint([]int64{overnight}) {
+		t.Fatalf("court 2 reservations = %v, want [%d]", got, overnight)
+	}
+	if len(filtered.Courts) != 1 || filtered.Courts[0].ID != court2 || len(filtered.Reservations[0].CourtIDs) != 2 {
+		t.Fatalf("unexpected filtered calendar: %+v", filtered)
+	}
+
+	week := fetch(url.Values{"date": {"2026-05-28"}, "granularity": {"week"}})
+	if got := ids(week); len(got) != 3 || week.Granularity != models.CalendarGranularityWeek {
+		t.Fatalf("week reservations = %v (%s), want all three", got, week.Granularity)
+	}
+}
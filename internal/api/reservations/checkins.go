@@ -0,0 +1,324 @@
+// internal/api/reservations/checkins.go
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	// checkinOpensBeforeStart is how early the front desk may check people in.
+	checkinOpensBeforeStart = 30 * time.Minute
+	arrivalsDateLayout      = "2006-01-02"
+)
+
+type checkinRequest struct {
+	UserIDs []int64 `json:"userIds"`
+}
+
+type arrivalParticipant struct {
+	UserID      int64      `json:"userId"`
+	FirstName   string     `json:"firstName"`
+	LastName    string     `json:"lastName"`
+	CheckedInAt *time.Time `json:"checkedInAt,omitempty"`
+}
+
+type arrivalReservation struct {
+	ReservationID       int64                `json:"reservationId"`
+	ReservationTypeName string               `json:"reservationTypeName"`
+	StartTime           time.Time            `json:"startTime"`
+	EndTime             time.Time            `json:"endTime"`
+	Participants        []arrivalParticipant `json:"participants"`
+	Guests              []arrivalGuest       `json:"guests"`
+}
+
+// arrivalGuest is a non-member on the reservation. DropIn guests did not use
+// a guest pass and are billed at the desk.
+type arrivalGuest struct {
+	Name       string `json:"name"`
+	Email      string `json:"email,omitempty"`
+	HostUserID *int64 `json:"hostUserId,omitempty"`
+	DropIn     bool   `json:"dropIn"`
+}
+
+// POST /api/v1/reservations/{id}/checkin
+//
+// Records a check-in for each listed participant, or for everyone on the
+// reservation not yet checked in when the body is empty.
+func (h *Handlers) HandleReservationCheckin(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	database := h.loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	reservationID, err := apiutil.ReservationIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
+		return
+	}
+
+	var req checkinRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
+	defer cancel()
+
+	reservation, err := q.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Reservation not found")
+			return
+		}
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to fetch reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch reservation")
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, reservation.FacilityID) {
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	now := time.Now()
+	if now.Before(reservation.StartTime.Add(-checkinOpensBeforeStart)) {
+		apiutil.WriteError(w, r, http.StatusConflict, fmt.Sprintf("Check-in opens %d minutes before the reservation starts", int(checkinOpensBeforeStart.Minutes())))
+		return
+	}
+	if now.After(reservation.EndTime) {
+		apiutil.WriteError(w, r, http.StatusConflict, "Check-in closed when the reservation ended")
+		return
+	}
+
+	var checkins []dbgen.ReservationCheckin
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		var err error
+		checkins, err = checkInParticipants(ctx, txdb.Queries, reservationID, req.UserIDs, user.ID, now)
+		return err
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("reservation_id", reservationID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to check in reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to check in reservation")
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, map[string]any{"checkins": checkins}); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write check-in response")
+		return
+	}
+}
+
+func checkInParticipants(ctx context.Context, q *dbgen.Queries, reservationID int64, userIDs []int64, staffUserID int64, now time.Time) ([]dbgen.ReservationCheckin, error) {
+	cancelled, err := q.IsReservationCancelled(ctx, reservationID)
+	if err != nil {
+		return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation status", Err: err}
+	}
+	if cancelled != 0 {
+		return nil, apiutil.HandlerError{Status: http.StatusConflict, Message: "Reservation has been cancelled"}
+	}
+
+	roster, err := q.ListReservationCheckinRoster(ctx, reservationID)
+	if err != nil {
+		return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load participants", Err: err}
+	}
+	checkedIn := make(map[int64]bool, len(roster))
+	for _, row := range roster {
+		checkedIn[row.UserID] = row.CheckedInAt.Valid
+	}
+
+	targets := normalizeParticipantIDs(userIDs)
+	if len(targets) == 0 {
+		for _, row := range roster {
+			if !row.CheckedInAt.Valid {
+				targets = append(targets, row.UserID)
+			}
+		}
+		if len(roster) == 0 {
+			return nil, apiutil.HandlerError{Status: http.StatusConflict, Message: "Reservation has no participants to check in"}
+		}
+		if len(targets) == 0 {
+			return nil, apiutil.HandlerError{Status: http.StatusConflict, Message: "Everyone on this reservation is already checked in"}
+		}
+	}
+
+	checkins := make([]dbgen.ReservationCheckin, 0, len(targets))
+	for _, userID := range targets {
+		already, ok := checkedIn[userID]
+		if !ok {
+			return nil, apiutil.HandlerError{Status: http.StatusBadRequest, Message: fmt.Sprintf("User %d is not on this reservation", userID)}
+		}
+		if already {
+			return nil, apiutil.HandlerError{Status: http.StatusConflict, Message: fmt.Sprintf("User %d is already checked in", userID)}
+		}
+		checkin, err := q.CreateReservationCheckin(ctx, dbgen.CreateReservationCheckinParams{
+			ReservationID:     reservationID,
+			UserID:            userID,
+			CheckedInAt:       now,
+			CheckedInByUserID: staffUserID,
+		})
+		if err != nil {
+			return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to record check-in", Err: err}
+		}
+		checkins = append(checkins, checkin)
+	}
+	return checkins, nil
+}
+
+// GET /api/v1/facilities/{id}/checkins?date=YYYY-MM-DD
+//
+// Lists the day's reservations with who is expected and who has arrived. The
+// date defaults to today in the facility's timezone.
+func (h *Handlers) HandleFacilityCheckinsList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	facilityID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || facilityID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid facility ID")
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
+	defer cancel()
+
+	facility, err := q.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Facility not found")
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility")
+		return
+	}
+	loc := apiutil.FacilityLocation(facility, logger)
+
+	var dayStart time.Time
+	if raw := strings.TrimSpace(r.URL.Query().Get("date")); raw != "" {
+		dayStart, err = time.ParseInLocation(arrivalsDateLayout, raw, loc)
+		if err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "date must be in YYYY-MM-DD format")
+			return
+		}
+	} else {
+		now := time.Now().In(loc)
+		dayStart = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	}
+
+	rows, err := q.ListFacilityArrivalsInRange(ctx, dbgen.ListFacilityArrivalsInRangeParams{
+		FacilityID: facilityID,
+		StartTime:  dayStart,
+		EndTime:    dayStart.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list arrivals")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load check-ins")
+		return
+	}
+
+	arrivals := make([]arrivalReservation, 0)
+	for _, row := range rows {
+		if len(arrivals) == 0 || arrivals[len(arrivals)-1].ReservationID != row.ReservationID {
+			arrivals = append(arrivals, arrivalReservation{
+				ReservationID:       row.ReservationID,
+				ReservationTypeName: row.ReservationTypeName,
+				StartTime:           row.StartTime,
+				EndTime:             row.EndTime,
+			})
+		}
+		participant := arrivalParticipant{
+			UserID:    row.UserID,
+			FirstName: row.FirstName,
+			LastName:  row.LastName,
+		}
+		if row.CheckedInAt.Valid {
+			checkedInAt := row.CheckedInAt.Time
+			participant.CheckedInAt = &checkedInAt
+		}
+		current := &arrivals[len(arrivals)-1]
+		current.Participants = append(current.Participants, participant)
+	}
+
+	if len(arrivals) > 0 {
+		reservationIDs := make([]int64, 0, len(arrivals))
+		byID := make(map[int64]*arrivalReservation, len(arrivals))
+		for i := range arrivals {
+			reservationIDs = append(reservationIDs, arrivals[i].ReservationID)
+			byID[arrivals[i].ReservationID] = &arrivals[i]
+		}
+		guests, err := q.ListReservationGuestsForReservations(ctx, reservationIDs)
+		if err != nil {
+			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list reservation guests")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load check-ins")
+			return
+		}
+		for _, guest := range guests {
+			arrival := byID[guest.ReservationID]
+			if arrival == nil {
+				continue
+			}
+			entry := arrivalGuest{
+				Name:   guest.Name,
+				Email:  guest.Email.String,
+				DropIn: guest.IsDropIn,
+			}
+			if guest.HostUserID.Valid {
+				hostUserID := guest.HostUserID.Int64
+				entry.HostUserID = &hostUserID
+			}
+			arrival.Guests = append(arrival.Guests, entry)
+		}
+	}
+
+	response := map[string]any{
+		"date":     dayStart.Format(arrivalsDateLayout),
+		"arrivals": arrivals,
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write arrivals response")
+		return
+	}
+}
@@ -0,0 +1,175 @@
+package reservations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func (f syncFixture) addParticipant(t *testing.T, reservationID int64, first string) int64 {
+	t.Helper()
+	result, err := f.database.Exec(
+		"INSERT INTO users (first_name, last_name, email, status) VALUES (?, ?, ?, 'active')",
+		first, "Player", strings.ToLower(first)+"@test.com",
+	)
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	userID, _ := result.LastInsertId()
+	if _, err := f.database.Exec(
+		"INSERT INTO reservation_participants (reservation_id, user_id) VALUES (?, ?)",
+		reservationID, userID,
+	); err != nil {
+		t.Fatalf("insert participant: %v", err)
+	}
+	return userID
+}
+
+func (f syncFixture) checkIn(t *testing.T, reservationID int64, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/reservations/%d/checkin", reservationID), strings.NewReader(body))
+	req.SetPathValue("id", fmt.Sprintf("%d", reservationID))
+	req.Header.Set("Content-Type", "application/json")
+	homeFacilityID := f.facilityID
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             f.userID,
+		IsStaff:        true,
+		HomeFacilityID: &homeFacilityID,
+	}))
+	recorder := httptest.NewRecorder()
+	f.h.HandleReservationCheckin(recorder, req)
+	return recorder
+}
+
+func TestHandleReservationCheckin_WindowAndDuplicates(t *testing.T) {
+	fixture := setupSyncTest(t)
+
+	early := fixture.insertReservation(t, time.Now().Add(2*time.Hour))
+	fixture.addParticipant(t, early, "Early")
+	if recorder := fixture.checkIn(t, early, ""); recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), "30 minutes") {
+		t.Fatalf("expected early check-in to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	ended := fixture.insertReservation(t, time.Now().Add(-2*time.Hour))
+	if recorder := fixture.checkIn(t, ended, ""); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected check-in after the end to be rejected, got %d", recorder.Code)
+	}
+
+	reservationID := fixture.insertReservation(t, time.Now().Add(20*time.Minute))
+	partner := fixture.addParticipant(t, reservationID, "Partner")
+	late := fixture.addParticipant(t, reservationID, "Late")
+
+	recorder := fixture.checkIn(t, reservationID, fmt.Sprintf(`{"userIds":[%d,%d]}`, fixture.userID, partner))
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response struct {
+		Checkins []dbgen.ReservationCheckin `json:"checkins"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(response.Checkins) != 2 || response.Checkins[0].CheckedInByUserID != fixture.userID {
+		t.Fatalf("unexpected check-ins: %+v", response.Checkins)
+	}
+
+	if recorder := fixture.checkIn(t, reservationID, fmt.Sprintf(`{"userIds":[%d]}`, partner)); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected duplicate check-in to conflict, got %d", recorder.Code)
+	}
+	if recorder := fixture.checkIn(t, reservationID, `{"userIds":[999999]}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected unknown participant to be rejected, got %d", recorder.Code)
+	}
+
+	// An empty body checks in whoever is left.
+	recorder = fixture.checkIn(t, reservationID, "")
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	response.Checkins = nil
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(response.Checkins) != 1 || response.Checkins[0].UserID != late {
+		t.Fatalf("expected only the late participant, got %+v", response.Checkins)
+	}
+	if recorder := fixture.checkIn(t, reservationID, ""); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected a fully checked-in reservation to conflict, got %d", recorder.Code)
+	}
+}
+
+func TestHandleFacilityCheckinsList_GroupsArrivalsAndReportsNoShows(t *testing.T) {
+	fixture := setupSyncTest(t)
+
+	start := time.Now().Add(10 * time.Minute).UTC()
+	reservationID := fixture.insertReservation(t, start)
+	partner := fixture.addParticipant(t, reservationID, "Partner")
+	if recorder := fixture.checkIn(t, reservationID, fmt.Sprintf(`{"userIds":[%d]}`, partner)); recorder.Code != http.StatusCreated {
+		t.Fatalf("check in: status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if _, err := fixture.database.Exec(
+		"INSERT INTO reservation_guests (reservation_id, host_user_id, name, is_drop_in) VALUES (?, ?, 'Alex Guest', 1)",
+		reservationID, fixture.userID,
+	); err != nil {
+		t.Fatalf("insert guest: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/facilities/%d/checkins?date=%s", fixture.facilityID, start.Format(arrivalsDateLayout)), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", fixture.facilityID))
+	homeFacilityID := fixture.facilityID
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             fixture.userID,
+		IsStaff:        true,
+		HomeFacilityID: &homeFacilityID,
+	}))
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleFacilityCheckinsList(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response struct {
+		Arrivals []arrivalReservation `json:"arrivals"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(response.Arrivals) != 1 || len(response.Arrivals[0].Participants) != 2 {
+		t.Fatalf("expected one reservation with two people, got %+v", response.Arrivals)
+	}
+	if guests := response.Arrivals[0].Guests; len(guests) != 1 || guests[0].Name != "Alex Guest" || !guests[0].DropIn || guests[0].HostUserID == nil {
+		t.Fatalf("expected the drop-in guest on the arrival, got %+v", guests)
+	}
+	for _, participant := range response.Arrivals[0].Participants {
+		if (participant.UserID == partner) != (participant.CheckedInAt != nil) {
+			t.Fatalf("unexpected check-in state for user %d: %+v", participant.UserID, participant)
+		}
+	}
+
+	missed := fixture.insertReservation(t, time.Now().Add(-3*time.Hour))
+	attended := fixture.insertReservation(t, time.Now().Add(-5*time.Hour))
+	if _, err := fixture.database.Exec(
+		"INSERT INTO reservation_checkins (reservation_id, user_id, checked_in_at, checked_in_by_user_id) VALUES (?, ?, ?, ?)",
+		attended, fixture.userID, time.Now().Add(-5*time.Hour), fixture.userID,
+	); err != nil {
+		t.Fatalf("insert check-in: %v", err)
+	}
+	noShows, err := fixture.database.Queries.ListNoShowReservationsInRange(context.Background(), dbgen.ListNoShowReservationsInRangeParams{
+		FacilityID:     fixture.facilityID,
+		StartTime:      time.Now().Add(-24 * time.Hour),
+		EndTime:        time.Now().Add(24 * time.Hour),
+		ComparisonTime: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("list no-shows: %v", err)
+	}
+	if len(noShows) != 1 || noShows[0].ID != missed {
+		t.Fatalf("expected only reservation %d as a no-show, got %+v", missed, noShows)
+	}
+}
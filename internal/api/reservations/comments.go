@@ -0,0 +1,211 @@
+// internal/api/reservations/comments.go
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/api/htmx"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	reservationstempl "github.com/codr1/Pickleicious/internal/templates/components/reservations"
+)
+
+const (
+	maxReservationCommentLength  = 1000
+	reservationCommentTimeLayout = "Jan 2, 3:04 PM"
+)
+
+type reservationCommentRequest struct {
+	Body string `json:"body"`
+}
+
+type reservationComment struct {
+	ID           int64     `json:"id"`
+	AuthorUserID int64     `json:"authorUserId"`
+	AuthorName   string    `json:"authorName"`
+	Body         string    `json:"body"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type reservationCommentsResponse struct {
+	Comments []reservationComment `json:"comments"`
+}
+
+// GET /api/v1/reservations/{id}/comments
+func (h *Handlers) HandleReservationCommentsList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
+	defer cancel()
+
+	reservation, ok := loadCommentReservation(ctx, w, r, q)
+	if !ok {
+		return
+	}
+
+	h.writeReservationComments(ctx, w, r, q, reservation, http.StatusOK)
+}
+
+// POST /api/v1/reservations/{id}/comments
+func (h *Handlers) HandleReservationCommentCreate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	var req reservationCommentRequest
+	if apiutil.IsJSONRequest(r) {
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid form data")
+			return
+		}
+		req.Body = r.FormValue("body")
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		apiutil.WriteValidationError(w, r, apiutil.FieldError{Field: "body", Reason: "is required"})
+		return
+	}
+	if utf8.RuneCountInString(body) > maxReservationCommentLength {
+		apiutil.WriteValidationError(w, r, apiutil.FieldError{Field: "body", Reason: fmt.Sprintf("must be at most %d characters", maxReservationCommentLength)})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
+	defer cancel()
+
+	reservation, ok := loadCommentReservation(ctx, w, r, q)
+	if !ok {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+
+	if _, err := q.CreateReservationComment(ctx, dbgen.CreateReservationCommentParams{
+		ReservationID: reservation.ID,
+		AuthorUserID:  user.ID,
+		Body:          body,
+	}); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to create reservation comment")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to save comment")
+		return
+	}
+
+	h.writeReservationComments(ctx, w, r, q, reservation, http.StatusCreated)
+}
+
+// loadCommentReservation loads the reservation named in the path for a staff
+// member with access to its facility, writing the error response otherwise.
+func loadCommentReservation(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries) (dbgen.Reservation, bool) {
+	logger := log.Ctx(r.Context())
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return dbgen.Reservation{}, false
+	}
+	if !user.IsStaff {
+		apiutil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+		return dbgen.Reservation{}, false
+	}
+
+	reservationID, err := apiutil.ReservationIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
+		return dbgen.Reservation{}, false
+	}
+
+	reservation, err := q.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Reservation not found")
+			return dbgen.Reservation{}, false
+		}
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to fetch reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch reservation")
+		return dbgen.Reservation{}, false
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, reservation.FacilityID) {
+		return dbgen.Reservation{}, false
+	}
+	return reservation, true
+}
+
+// writeReservationComments answers with the reservation's comment thread, as
+// the edit form's partial for HTMX and as JSON with status otherwise.
+func (h *Handlers) writeReservationComments(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries, reservation dbgen.Reservation, status int) {
+	logger := log.Ctx(r.Context())
+
+	rows, err := q.ListReservationComments(ctx, reservation.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to list reservation comments")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load comments")
+		return
+	}
+
+	comments := make([]reservationComment, 0, len(rows))
+	for _, row := range rows {
+		comments = append(comments, reservationComment{
+			ID:           row.ID,
+			AuthorUserID: row.AuthorUserID,
+			AuthorName:   strings.TrimSpace(row.AuthorFirstName + " " + row.AuthorLastName),
+			Body:         row.Body,
+			CreatedAt:    row.CreatedAt,
+		})
+	}
+
+	if !htmx.IsRequest(r) {
+		if err := apiutil.WriteJSON(w, status, reservationCommentsResponse{Comments: comments}); err != nil {
+			logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to write comments response")
+		}
+		return
+	}
+
+	loc := apiutil.DefaultLocation()
+	if facility, err := h.loadFacilities().GetFacilityByID(ctx, reservation.FacilityID); err == nil {
+		loc = apiutil.FacilityLocation(facility, logger)
+	} else {
+		logger.Error().Err(err).Int64("facility_id", reservation.FacilityID).Msg("Failed to load facility for comment times")
+	}
+	items := make([]reservationstempl.ReservationCommentItem, 0, len(comments))
+	for _, comment := range comments {
+		items = append(items, reservationstempl.ReservationCommentItem{
+			AuthorName: comment.AuthorName,
+			Body:       comment.Body,
+			CreatedAt:  comment.CreatedAt.In(loc).Format(reservationCommentTimeLayout),
+		})
+	}
+	component := reservationstempl.ReservationComments(reservationstempl.ReservationCommentsData{
+		ReservationID: reservation.ID,
+		Comments:      items,
+	})
+	if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render comments", "Failed to render comments") {
+		return
+	}
+}
@@ -0,0 +1,78 @@
+package reservations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func (f syncFixture) commentRequest(method string, reservationID int64, body string) *http.Request {
+	req := f.staffRequest(method, fmt.Sprintf("/api/v1/reservations/%d/comments", reservationID), body)
+	req.SetPathValue("id", fmt.Sprintf("%d", reservationID))
+	return req
+}
+
+func TestReservationComments_StaffThread(t *testing.T) {
+	fixture := setupSyncTest(t)
+	reservationID := fixture.insertReservation(t, time.Now().Add(24*time.Hour))
+
+	for _, body := range []string{`{"body":"   "}`, fmt.Sprintf(`{"body":%q}`, strings.Repeat("x", maxReservationCommentLength+1))} {
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleReservationCommentCreate(recorder, fixture.commentRequest(http.MethodPost, reservationID, body))
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected invalid comment to be rejected, got %d", recorder.Code)
+		}
+	}
+
+	for _, body := range []string{`{"body":"Called about parking"}`, `{"body":"Needs a ball machine"}`} {
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleReservationCommentCreate(recorder, fixture.commentRequest(http.MethodPost, reservationID, body))
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("create comment status %d: %s", recorder.Code, recorder.Body.String())
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleReservationCommentsList(recorder, fixture.commentRequest(http.MethodGet, reservationID, ""))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("list comments status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response reservationCommentsResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode comments: %v", err)
+	}
+	if len(response.Comments) != 2 || response.Comments[0].Body != "Called about parking" {
+		t.Fatalf("expected comments oldest first, got %+v", response.Comments)
+	}
+	if response.Comments[0].AuthorName != "Staff User" || response.Comments[0].CreatedAt.IsZero() {
+		t.Fatalf("expected author and timestamp, got %+v", response.Comments[0])
+	}
+
+	req := fixture.commentRequest(http.MethodGet, reservationID, "")
+	req.Header.Set("HX-Request", "true")
+	recorder = httptest.NewRecorder()
+	fixture.h.HandleReservationCommentsList(recorder, req)
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "Needs a ball machine") {
+		t.Fatalf("expected comments partial, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestReservationComments_RejectsMembers(t *testing.T) {
+	fixture := setupSyncTest(t)
+	reservationID := fixture.insertReservation(t, time.Now().Add(24*time.Hour))
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/reservations/%d/comments", reservationID), nil)
+	req.SetPathValue("id", fmt.Sprintf("%d", reservationID))
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: fixture.userID}))
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleReservationCommentsList(recorder, req)
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected members to be forbidden, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,357 @@
+// internal/api/reservations/conflicts.go
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+// maxConflictReportDays bounds one conflict scan to a year.
+const maxConflictReportDays = 366
+
+type conflictReservation struct {
+	ID              int64     `json:"id"`
+	ReservationType string    `json:"reservation_type"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	PrimaryUserID   *int64    `json:"primary_user_id,omitempty"`
+	EditURL         string    `json:"edit_url"`
+	CancelURL       string    `json:"cancel_url"`
+}
+
+// courtConflict is two reservations holding one court at the same time.
+type courtConflict struct {
+	CourtID      int64                 `json:"court_id"`
+	CourtNumber  int64                 `json:"court_number"`
+	CourtName    string                `json:"court_name"`
+	OverlapStart time.Time             `json:"overlap_start"`
+	OverlapEnd   time.Time             `json:"overlap_end"`
+	Reservations []conflictReservation `json:"reservations"`
+	ResolveURL   string                `json:"resolve_url"`
+}
+
+// openPlayConflict is an open play session backed by no reservation or by
+// more than one.
+type openPlayConflict struct {
+	SessionID        int64                 `json:"session_id"`
+	OpenPlayRuleID   int64                 `json:"open_play_rule_id"`
+	StartTime        time.Time             `json:"start_time"`
+	EndTime          time.Time             `json:"end_time"`
+	Status           string                `json:"status"`
+	ReservationCount int64                 `json:"reservation_count"`
+	Reservations     []conflictReservation `json:"reservations"`
+}
+
+type conflictReportResponse struct {
+	FacilityID        int64              `json:"facility_id"`
+	From              string             `json:"from"`
+	To                string             `json:"to"`
+	CourtConflicts    []courtConflict    `json:"court_conflicts"`
+	OpenPlayConflicts []openPlayConflict `json:"open_play_conflicts"`
+}
+
+type conflictResolveRequest struct {
+	CancelReservationID int64 `json:"cancel_reservation_id"`
+	KeepReservationID   int64 `json:"keep_reservation_id"`
+	WaiveFee            *bool `json:"waive_fee"`
+}
+
+// GET /api/v1/facilities/{id}/reports/conflicts?from=YYYY-MM-DD&to=YYYY-MM-DD
+// Court double bookings and open play sessions without exactly one backing
+// reservation, for facility-local dates from through to inclusive.
+func (h *Handlers) HandleConflictReport(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	facilityID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || facilityID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid facility ID")
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
+	defer cancel()
+
+	facility, err := q.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Facility not found")
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility")
+		return
+	}
+	loc := apiutil.FacilityLocation(facility, logger)
+
+	query := r.URL.Query()
+	from, to, err := parseConflictRange(query.Get("from"), query.Get("to"), loc)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	rangeStart := from.UTC()
+	rangeEnd := to.AddDate(0, 0, 1).UTC()
+
+	overlaps, err := q.ListCourtOverlapConflicts(ctx, dbgen.ListCourtOverlapConflictsParams{
+		FacilityID: facilityID,
+		StartTime:  rangeStart,
+		EndTime:    rangeEnd,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list court conflicts")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load conflict report")
+		return
+	}
+	mismatches, err := q.ListOpenPlaySessionReservationMismatches(ctx, dbgen.ListOpenPlaySessionReservationMismatchesParams{
+		FacilityID: facilityID,
+		StartTime:  rangeStart,
+		EndTime:    rangeEnd,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list open play conflicts")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load conflict report")
+		return
+	}
+
+	response := conflictReportResponse{
+		FacilityID:        facilityID,
+		From:              from.Format(time.DateOnly),
+		To:                to.Format(time.DateOnly),
+		CourtConflicts:    make([]courtConflict, 0, len(overlaps)),
+		OpenPlayConflicts: make([]openPlayConflict, 0, len(mismatches)),
+	}
+	for _, row := range overlaps {
+		response.CourtConflicts = append(response.CourtConflicts, buildCourtConflict(facilityID, row))
+	}
+	for _, row := range mismatches {
+		conflict, err := buildOpenPlayConflict(ctx, q, row)
+		if err != nil {
+			logger.Error().Err(err).Int64("session_id", row.SessionID).Msg("Failed to load open play conflict")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load conflict report")
+			return
+		}
+		response.OpenPlayConflicts = append(response.OpenPlayConflicts, conflict)
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write conflict report")
+	}
+}
+
+// POST /api/v1/facilities/{id}/reports/conflicts/resolve
+// Settles a court conflict by cancelling one side through the normal staff
+// cancellation flow. The kept reservation still holds the court, so the
+// freed slot is not offered to the waitlist.
+func (h *Handlers) HandleConflictResolve(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	service := h.loadService()
+	if q == nil || service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	facilityID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || facilityID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid facility ID")
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req conflictResolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	if req.CancelReservationID <= 0 || req.KeepReservationID <= 0 || req.CancelReservationID == req.KeepReservationID {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "cancel_reservation_id and keep_reservation_id must name two reservations")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
+	defer cancel()
+
+	overlapping, err := q.ReservationsOverlapOnCourt(ctx, dbgen.ReservationsOverlapOnCourtParams{
+		ReservationID:      req.CancelReservationID,
+		OtherReservationID: req.KeepReservationID,
+		FacilityID:         facilityID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", req.CancelReservationID).Msg("Failed to check reservation conflict")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to check reservation conflict")
+		return
+	}
+	if overlapping == 0 {
+		apiutil.WriteError(w, r, http.StatusConflict, "These reservations no longer conflict")
+		return
+	}
+
+	_, err = service.CancelReservation(ctx, reservationsvc.CancelInput{
+		ReservationID:     req.CancelReservationID,
+		CancelledByUserID: user.ID,
+		WaiveFee:          req.WaiveFee != nil && *req.WaiveFee,
+		// As with any staff cancellation, a fee needs an explicit choice.
+		ConfirmPenalty: func(context.Context, *dbgen.Queries, reservationsvc.CancellationPenalty) (bool, error) {
+			return req.WaiveFee != nil, nil
+		},
+		RestoreLessonPackages: true,
+		IssueCredit:           true,
+	})
+	if err != nil {
+		var penaltyErr reservationsvc.CancellationPenaltyError
+		if errors.As(err, &penaltyErr) {
+			penalty := newCancellationPenaltyResponse(req.CancelReservationID, facilityID, penaltyErr.Penalty)
+			if err := apiutil.WriteJSON(w, http.StatusConflict, penalty); err != nil {
+				logger.Error().Err(err).Int64("reservation_id", req.CancelReservationID).Msg("Failed to write cancellation penalty response")
+			}
+			return
+		}
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("reservation_id", req.CancelReservationID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("reservation_id", req.CancelReservationID).Msg("Failed to cancel conflicting reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to cancel reservation")
+		return
+	}
+
+	logger.Info().
+		Int64("facility_id", facilityID).
+		Int64("cancelled_reservation_id", req.CancelReservationID).
+		Int64("kept_reservation_id", req.KeepReservationID).
+		Int64("user_id", user.ID).
+		Msg("Resolved reservation conflict")
+	apiutil.AppendHXTrigger(w, "refreshCourtsCalendar")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseConflictRange(fromRaw, toRaw string, loc *time.Location) (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation(time.DateOnly, strings.TrimSpace(fromRaw), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must be in YYYY-MM-DD format")
+	}
+	to, err := time.ParseInLocation(time.DateOnly, strings.TrimSpace(toRaw), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must be in YYYY-MM-DD format")
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must not be before from")
+	}
+	if to.After(from.AddDate(0, 0, maxConflictReportDays-1)) {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range cannot exceed %d days", maxConflictReportDays)
+	}
+	return from, to, nil
+}
+
+func buildCourtConflict(facilityID int64, row dbgen.ListCourtOverlapConflictsRow) courtConflict {
+	overlapStart := row.FirstStartTime
+	if row.SecondStartTime.After(overlapStart) {
+		overlapStart = row.SecondStartTime
+	}
+	overlapEnd := row.FirstEndTime
+	if row.SecondEndTime.Before(overlapEnd) {
+		overlapEnd = row.SecondEndTime
+	}
+	return courtConflict{
+		CourtID:      row.CourtID,
+		CourtNumber:  row.CourtNumber,
+		CourtName:    row.CourtName,
+		OverlapStart: overlapStart,
+		OverlapEnd:   overlapEnd,
+		Reservations: []conflictReservation{
+			newConflictReservation(row.FirstReservationID, row.FirstReservationType, row.FirstStartTime, row.FirstEndTime, row.FirstPrimaryUserID),
+			newConflictReservation(row.SecondReservationID, row.SecondReservationType, row.SecondStartTime, row.SecondEndTime, row.SecondPrimaryUserID),
+		},
+		ResolveURL: fmt.Sprintf("/api/v1/facilities/%d/reports/conflicts/resolve", facilityID),
+	}
+}
+
+// buildOpenPlayConflict loads the reservations a mismatched session matched,
+// in ID order.
+func buildOpenPlayConflict(ctx context.Context, q *dbgen.Queries, row dbgen.ListOpenPlaySessionReservationMismatchesRow) (openPlayConflict, error) {
+	conflict := openPlayConflict{
+		SessionID:        row.SessionID,
+		OpenPlayRuleID:   row.OpenPlayRuleID,
+		StartTime:        row.StartTime,
+		EndTime:          row.EndTime,
+		Status:           row.Status,
+		ReservationCount: row.ReservationCount,
+		Reservations:     []conflictReservation{},
+	}
+	var ids []int64
+	for _, raw := range strings.Split(row.ReservationIds, ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return openPlayConflict{}, fmt.Errorf("parse reservation ID %q: %w", raw, err)
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		reservation, err := q.GetReservationByID(ctx, id)
+		if err != nil {
+			return openPlayConflict{}, fmt.Errorf("load reservation %d: %w", id, err)
+		}
+		conflict.Reservations = append(conflict.Reservations, newConflictReservation(
+			reservation.ID, "OPEN_PLAY", reservation.StartTime, reservation.EndTime, reservation.PrimaryUserID,
+		))
+	}
+	return conflict, nil
+}
+
+func newConflictReservation(id int64, reservationType string, start, end time.Time, primaryUserID sql.NullInt64) conflictReservation {
+	reservation := conflictReservation{
+		ID:              id,
+		ReservationType: reservationType,
+		StartTime:       start,
+		EndTime:         end,
+		EditURL:         fmt.Sprintf("/api/v1/reservations/%d/edit", id),
+		CancelURL:       fmt.Sprintf("/api/v1/reservations/%d", id),
+	}
+	if primaryUserID.Valid {
+		userID := primaryUserID.Int64
+		reservation.PrimaryUserID = &userID
+	}
+	return reservation
+}
@@ -0,0 +1,123 @@
+package reservations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func (f syncFixture) staffRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", fmt.Sprintf("%d", f.facilityID))
+	homeFacilityID := f.facilityID
+	return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             f.userID,
+		IsStaff:        true,
+		HomeFacilityID: &homeFacilityID,
+	}))
+}
+
+func (f syncFixture) conflictReport(t *testing.T, day string) conflictReportResponse {
+	t.Helper()
+	req := f.staffRequest(http.MethodGet, fmt.Sprintf("/api/v1/facilities/%d/reports/conflicts?from=%s&to=%s", f.facilityID, day, day), "")
+	recorder := httptest.NewRecorder()
+	f.h.HandleConflictReport(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("report status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var report conflictReportResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	return report
+}
+
+func TestConflictReportAndResolve(t *testing.T) {
+	fixture := setupSyncTest(t)
+	courtResult, err := fixture.database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 1', 1, 'active')",
+		fixture.facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+	courtID, _ := courtResult.LastInsertId()
+
+	start := time.Now().UTC().AddDate(0, 0, 3).Truncate(24 * time.Hour).Add(10 * time.Hour)
+	day := start.Format(time.DateOnly)
+	first := fixture.insertReservation(t, start)
+	second := fixture.insertReservation(t, start.Add(30*time.Minute))
+	later := fixture.insertReservation(t, start.Add(time.Hour))
+	for _, id := range []int64{first, second, later} {
+		if _, err := fixture.database.Exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", id, courtID); err != nil {
+			t.Fatalf("insert reservation court: %v", err)
+		}
+	}
+
+	ruleResult, err := fixture.database.Exec("INSERT INTO open_play_rules (facility_id, name) VALUES (?, 'Evening')", fixture.facilityID)
+	if err != nil {
+		t.Fatalf("insert open play rule: %v", err)
+	}
+	ruleID, _ := ruleResult.LastInsertId()
+	if _, err := fixture.database.Exec(
+		`INSERT INTO open_play_sessions (facility_id, open_play_rule_id, start_time, end_time, status, current_court_count)
+		 VALUES (?, ?, ?, ?, 'scheduled', 1)`,
+		fixture.facilityID, ruleID, start.Add(8*time.Hour), start.Add(10*time.Hour),
+	); err != nil {
+		t.Fatalf("insert open play session: %v", err)
+	}
+
+	report := fixture.conflictReport(t, day)
+	if len(report.CourtConflicts) != 2 {
+		t.Fatalf("expected two court conflicts, got %+v", report.CourtConflicts)
+	}
+	conflict := report.CourtConflicts[0]
+	if conflict.CourtID != courtID || conflict.Reservations[0].ID != first || conflict.Reservations[1].ID != second {
+		t.Fatalf("unexpected first conflict: %+v", conflict)
+	}
+	if !conflict.OverlapStart.Equal(start.Add(30*time.Minute)) || !conflict.OverlapEnd.Equal(start.Add(time.Hour)) {
+		t.Fatalf("unexpected overlap window %s-%s", conflict.OverlapStart, conflict.OverlapEnd)
+	}
+	if conflict.Reservations[1].CancelURL != fmt.Sprintf("/api/v1/reservations/%d", second) {
+		t.Fatalf("unexpected cancel link %q", conflict.Reservations[1].CancelURL)
+	}
+	if len(report.OpenPlayConflicts) != 1 || report.OpenPlayConflicts[0].ReservationCount != 0 {
+		t.Fatalf("expected the unbacked open play session, got %+v", report.OpenPlayConflicts)
+	}
+
+	resolve := func(cancelID, keepID int64) *httptest.ResponseRecorder {
+		t.Helper()
+		body := fmt.Sprintf(`{"cancel_reservation_id": %d, "keep_reservation_id": %d}`, cancelID, keepID)
+		req := fixture.staffRequest(http.MethodPost, fmt.Sprintf("/api/v1/facilities/%d/reports/conflicts/resolve", fixture.facilityID), body)
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleConflictResolve(recorder, req)
+		return recorder
+	}
+	if rec := resolve(first, later); rec.Code != http.StatusConflict {
+		t.Fatalf("expected back-to-back reservations to be refused, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := resolve(second, first); rec.Code != http.StatusNoContent {
+		t.Fatalf("resolve status %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var cancellations int
+	if err := fixture.database.QueryRow("SELECT COUNT(*) FROM reservation_cancellations WHERE reservation_id = ?", second).Scan(&cancellations); err != nil {
+		t.Fatalf("count cancellations: %v", err)
+	}
+	if cancellations != 1 {
+		t.Fatalf("expected the cancellation to be logged, found %d", cancellations)
+	}
+	if report := fixture.conflictReport(t, day); len(report.CourtConflicts) != 0 {
+		t.Fatalf("expected no court conflicts after resolving, got %+v", report.CourtConflicts)
+	}
+	if rec := resolve(second, first); rec.Code != http.StatusConflict {
+		t.Fatalf("expected a resolved conflict to be refused, got %d", rec.Code)
+	}
+}
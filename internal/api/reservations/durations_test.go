@@ -0,0 +1,125 @@
+package reservations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func (f syncFixture) insertReservationType(t *testing.T, name string, minMinutes, maxMinutes any) int64 {
+	t.Helper()
+	result, err := f.database.Exec(
+		`INSERT INTO reservation_types (name, organization_id, facility_id, min_duration_minutes, max_duration_minutes)
+		 VALUES (?, (SELECT organization_id FROM facilities WHERE id = ?), ?, ?, ?)`,
+		name, f.facilityID, f.facilityID, minMinutes, maxMinutes,
+	)
+	if err != nil {
+		t.Fatalf("insert reservation type: %v", err)
+	}
+	id, _ := result.LastInsertId()
+	return id
+}
+
+func (f syncFixture) reservationUpdateRequest(reservationID int64, body string, isStaff bool) *http.Request {
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/reservations/%d", reservationID), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", fmt.Sprintf("%d", reservationID))
+	homeFacilityID := f.facilityID
+	return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             f.userID,
+		IsStaff:        isStaff,
+		HomeFacilityID: &homeFacilityID,
+	}))
+}
+
+func TestReservationTypeDurationBounds(t *testing.T) {
+	fixture := setupSyncTest(t)
+	courtResult, err := fixture.database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 1', 1, 'active')",
+		fixture.facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+	courtID, _ := courtResult.LastInsertId()
+	clinicID := fixture.insertReservationType(t, "CLINIC_INTENSIVE", 120, nil)
+	ballMachineID := fixture.insertReservationType(t, "BALL_MACHINE_SLOT", nil, 60)
+
+	start := time.Now().UTC().AddDate(0, 0, 3).Truncate(24 * time.Hour).Add(10 * time.Hour)
+	body := func(typeID int64, start time.Time, minutes int, extra string) string {
+		return fmt.Sprintf(`{"facility_id":%d,"reservation_type_id":%d,"court_ids":[%d],"start_time":%q,"end_time":%q%s}`,
+			fixture.facilityID, typeID, courtID,
+			start.Format(time.RFC3339), start.Add(time.Duration(minutes)*time.Minute).Format(time.RFC3339), extra)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		typeID  int64
+		minutes int
+		status  int
+		message string
+	}{
+		{"clinic too short", clinicID, 60, http.StatusBadRequest, "at least 2 hours"},
+		{"ball machine too long", ballMachineID, 90, http.StatusBadRequest, "at most 1 hour"},
+		{"ball machine within bounds", ballMachineID, 60, http.StatusCreated, ""},
+	} {
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleReservationCreate(recorder, fixture.staffRequest(http.MethodPost, "/api/v1/reservations", body(tc.typeID, start, tc.minutes, "")))
+		if recorder.Code != tc.status || !strings.Contains(recorder.Body.String(), tc.message) {
+			t.Errorf("%s: status %d: %s", tc.name, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	// A 90-minute booking made before the type was capped at an hour.
+	existingStart := start.Add(4 * time.Hour)
+	result, err := fixture.database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		fixture.facilityID, ballMachineID, fixture.userID, fixture.userID, existingStart, existingStart.Add(90*time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, _ := result.LastInsertId()
+	if _, err := fixture.database.Exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", reservationID, courtID); err != nil {
+		t.Fatalf("insert reservation court: %v", err)
+	}
+	edit := body(ballMachineID, existingStart, 90, "")
+	override := body(ballMachineID, existingStart, 90, `,"override_duration_limits":true`)
+
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleReservationUpdate(recorder, fixture.reservationUpdateRequest(reservationID, edit, true))
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("edit without override: status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	fixture.h.HandleReservationUpdate(recorder, fixture.reservationUpdateRequest(reservationID, override, false))
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("member override: status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	fixture.h.HandleReservationUpdate(recorder, fixture.reservationUpdateRequest(reservationID, override, true))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("staff override: status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	overrides, err := fixture.database.Queries.ListReservationDurationOverrides(context.Background(), reservationID)
+	if err != nil {
+		t.Fatalf("list overrides: %v", err)
+	}
+	if len(overrides) != 1 {
+		t.Fatalf("expected one audited override, got %d", len(overrides))
+	}
+	audited := overrides[0]
+	if audited.OverriddenByUserID != fixture.userID || audited.DurationMinutes != 90 || audited.MaxDurationMinutes.Int64 != 60 {
+		t.Fatalf("unexpected override record %+v", audited)
+	}
+}
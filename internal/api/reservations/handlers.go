@@ -10,10 +10,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"sort"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -23,67 +21,62 @@ import (
 	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/models"
 	"github.com/codr1/Pickleicious/internal/request"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
 	reservationstempl "github.com/codr1/Pickleicious/internal/templates/components/reservations"
 )
 
-var (
+const reservationQueryTimeout = 5 * time.Second
+
+// Handlers serves the staff reservation API. Each instance holds its own
+// database and notification channels.
+type Handlers struct {
 	queries     *dbgen.Queries
 	store       *appdb.DB
-	queriesOnce sync.Once
 	emailClient *email.SESClient
-)
-
-const (
-	reservationQueryTimeout           = 5 * time.Second
-	waitlistNotificationTimeout       = 5 * time.Second
-	minReservationDuration            = time.Hour
-	timeLayoutDatetimeLocal           = "2006-01-02T15:04"
-	timeLayoutDatetimeMinute          = "2006-01-02 15:04"
-	waitlistTimeLayout                = "15:04:05"
-	defaultWaitlistOfferExpiryMinutes = int64(30)
-)
-
-const (
-	waitlistNotificationBroadcast  = "broadcast"
-	waitlistNotificationSequential = "sequential"
-	waitlistOfferStatusPending     = "pending"
-	waitlistStatusNotified         = "notified"
-)
+	notifiers   []email.Notifier
+}
 
-// InitHandlers must be called during server startup before handling requests.
-func InitHandlers(database *appdb.DB, client *email.SESClient) {
-	if database == nil {
-		return
-	}
-	queriesOnce.Do(func() {
-		queries = database.Queries
-		store = database
-		emailClient = client
-	})
+// NewHandlers returns the reservation handlers over database. notifiers are
+// the channels waitlist offers are delivered on; with none, offers are
+// recorded without messaging anyone. With a nil database every handler
+// answers 500.
+func NewHandlers(database *appdb.DB, client *email.SESClient, notifiers ...email.Notifier) *Handlers {
+	h := &Handlers{emailClient: client, notifiers: notifiers}
+	if database != nil {
+		h.queries = database.Queries
+		h.store = database
+	}
+	return h
 }
 
 // POST /api/v1/reservations
-func HandleReservationCreate(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleReservationCreate(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
-	database := loadDB()
-	if q == nil || database == nil {
+	q := h.loadQueries()
+	service := h.loadService()
+	if q == nil || service == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	req, err := decodeReservationRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	idempotencyKey, err := apiutil.IdempotencyKeyFromRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
 	facilityID, err := resolveFacilityID(r, req.FacilityID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 	req.FacilityID = facilityID
@@ -94,7 +87,7 @@ func HandleReservationCreate(w http.ResponseWriter, r *http.Request) {
 
 	user := authz.UserFromContext(r.Context())
 	if user == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -104,130 +97,154 @@ func HandleReservationCreate(w http.ResponseWriter, r *http.Request) {
 	exists, err := facilityExists(ctx, q, facilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to validate facility")
-		http.Error(w, "Failed to validate facility", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate facility")
 		return
 	}
 	if !exists {
-		http.Error(w, "Facility not found", http.StatusNotFound)
+		apiutil.WriteError(w, r, http.StatusNotFound, "Facility not found")
 		return
 	}
 
-	startTime, endTime, err := parseReservationTimes(req.StartTime, req.EndTime)
+	startTime, endTime, err := parseReservationTimes(req.StartTime, req.EndTime, h.facilityLocation(ctx, facilityID))
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	duration, err := h.reservationDuration(ctx, q, facilityID, req.ReservationTypeID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility booking config")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility booking config")
 		return
 	}
-	if err := validateReservationInput(req, startTime, endTime); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validateReservationInput(req, startTime, endTime, duration); err != nil {
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 	if !user.IsStaff {
 		authUserID := user.ID
 		if req.PrimaryUserID != nil && *req.PrimaryUserID > 0 && *req.PrimaryUserID != authUserID {
-			http.Error(w, "primary_user_id must match authenticated user", http.StatusForbidden)
+			apiutil.WriteError(w, r, http.StatusForbidden, "primary_user_id must match authenticated user")
 			return
 		}
 		req.PrimaryUserID = &authUserID
-		if err := enforceMemberTierBookingWindow(ctx, q, facilityID, req.PrimaryUserID, startTime); err != nil {
+		if err := h.enforceMemberTierBookingWindow(ctx, q, facilityID, req.PrimaryUserID, startTime, endTime); err != nil {
 			var fieldErr apiutil.FieldError
 			if errors.As(err, &fieldErr) {
-				http.Error(w, err.Error(), http.StatusBadRequest)
+				apiutil.WriteValidationError(w, r, err)
+				return
+			}
+			var primeTimeErr apiutil.PrimeTimeLockedError
+			if errors.As(err, &primeTimeErr) {
+				apiutil.WriteError(w, r, http.StatusForbidden, err.Error())
 				return
 			}
 			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to validate member booking window")
-			http.Error(w, "Failed to validate booking window", http.StatusInternalServerError)
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate booking window")
 			return
 		}
 	}
 
-	req.CourtIDs = normalizeCourtIDs(req.CourtIDs)
-	if req.ParticipantIDsSet {
-		req.ParticipantIDs = normalizeParticipantIDs(req.ParticipantIDs)
-	}
-	if err := apiutil.EnsureCourtsAvailable(ctx, q, facilityID, 0, startTime, endTime, req.CourtIDs); err != nil {
-		var availErr apiutil.AvailabilityError
-		if errors.As(err, &availErr) {
-			http.Error(w, err.Error(), http.StatusConflict)
+	if err := apiutil.EnsureWithinHoursOverride(ctx, q, h.loadFacilities(), facilityID, startTime, endTime); err != nil {
+		var overrideErr apiutil.HoursOverrideError
+		if errors.As(err, &overrideErr) {
+			apiutil.WriteError(w, r, http.StatusConflict, err.Error())
 			return
 		}
-		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to check court availability")
-		http.Error(w, "Failed to check court availability", http.StatusInternalServerError)
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility hours override")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate facility hours")
 		return
 	}
 
-	var created dbgen.Reservation
-	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
-		qtx := txdb.Queries
-
-		created, err = qtx.CreateReservation(ctx, dbgen.CreateReservationParams{
-			FacilityID:        facilityID,
-			ReservationTypeID: req.ReservationTypeID,
-			RecurrenceRuleID:  apiutil.ToNullInt64(req.RecurrenceRuleID),
-			PrimaryUserID:     apiutil.ToNullInt64(req.PrimaryUserID),
-			CreatedByUserID:   user.ID,
-			ProID:             apiutil.ToNullInt64(req.ProID),
-			OpenPlayRuleID:    apiutil.ToNullInt64(req.OpenPlayRuleID),
-			StartTime:         startTime,
-			EndTime:           endTime,
-			IsOpenEvent:       req.IsOpenEvent,
-			TeamsPerCourt:     apiutil.ToNullInt64(req.TeamsPerCourt),
-			PeoplePerTeam:     apiutil.ToNullInt64(req.PeoplePerTeam),
-		})
-		if err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to create reservation", Err: err}
-		}
-
-		for _, courtID := range req.CourtIDs {
-			if err := qtx.AddReservationCourt(ctx, dbgen.AddReservationCourtParams{
-				ReservationID: created.ID,
-				CourtID:       courtID,
-			}); err != nil {
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to assign courts", Err: err}
-			}
-		}
-		for _, participantID := range req.ParticipantIDs {
-			if err := qtx.AddParticipant(ctx, dbgen.AddParticipantParams{
-				ReservationID: created.ID,
-				UserID:        participantID,
-			}); err != nil {
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation participant", Err: err}
-			}
-		}
-		return nil
+	created, err := service.CreateReservation(ctx, reservationsvc.CreateInput{
+		Details:         reservationDetails(req, startTime, endTime),
+		FacilityID:      facilityID,
+		CreatedByUserID: user.ID,
+		ParticipantIDs:  req.ParticipantIDs,
+		Guests:          reservationGuests(req.Guests),
+		MemberNote:      req.MemberNote,
+		IdempotencyKey:  idempotencyKey,
+		PriceCourtTime:  true,
+		ApplyCredits:    req.ApplyCredits,
+		// Only staff may book a member into overlapping reservations.
+		PreventMemberOverlap: !(user.IsStaff && req.AllowMemberOverlap),
 	})
 	if err != nil {
+		var overlapErr reservationsvc.MemberOverlapError
+		if errors.As(err, &overlapErr) {
+			h.writeMemberOverlapError(ctx, w, r, overlapErr)
+			return
+		}
 		var herr apiutil.HandlerError
 		if errors.As(err, &herr) {
-			logger.Error().Err(herr.Err).Int64("facility_id", facilityID).Msg(herr.Message)
-			http.Error(w, herr.Message, herr.Status)
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("facility_id", facilityID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
 			return
 		}
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to create reservation")
-		http.Error(w, "Failed to create reservation", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to create reservation")
 		return
 	}
 
-	w.Header().Set("HX-Trigger", "refreshCourtsCalendar")
-	if err := apiutil.WriteJSON(w, http.StatusCreated, created); err != nil {
+	response, err := reservationsvc.WithPrice(ctx, q, created)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", created.ID).Msg("Failed to load reservation price")
+		response = reservationsvc.PricedReservation{Reservation: created}
+	}
+	apiutil.AppendHXTrigger(w, "refreshCourtsCalendar")
+	if err := apiutil.WriteJSON(w, http.StatusCreated, response); err != nil {
 		logger.Error().Err(err).Int64("reservation_id", created.ID).Msg("Failed to write reservation response")
 		return
 	}
 }
 
+// writeMemberOverlapError answers 409 member_overlap with the member's
+// reservation in the way: as JSON details for API clients, or as a sentence
+// for the staff booking form.
+func (h *Handlers) writeMemberOverlapError(ctx context.Context, w http.ResponseWriter, r *http.Request, overlapErr reservationsvc.MemberOverlapError) {
+	logger := log.Ctx(r.Context())
+	conflict := overlapErr.Conflict
+
+	message := "Member already has a reservation that overlaps this time"
+	if apiutil.WantsPlainError(r) {
+		loc := apiutil.DefaultLocation()
+		if facility, err := h.loadFacilities().GetFacilityByID(ctx, conflict.FacilityID); err == nil {
+			loc = apiutil.FacilityLocation(facility, logger)
+		}
+		where := conflict.FacilityName
+		if conflict.Courts != "" {
+			where = fmt.Sprintf("%s (%s)", conflict.FacilityName, conflict.Courts)
+		}
+		message = fmt.Sprintf("Member already has a %s reservation at %s from %s to %s. Check \"Allow overlap\" to book anyway.",
+			conflict.ReservationType,
+			where,
+			conflict.StartTime.In(loc).Format("Jan 2 3:04 PM"),
+			conflict.EndTime.In(loc).Format("3:04 PM"),
+		)
+	}
+
+	body := apiutil.NewErrorBody(http.StatusConflict, message, overlapErr)
+	body.Code = apiutil.ErrorCodeMemberOverlap
+	body.Details = map[string]any{"conflict": conflict}
+	apiutil.WriteErrorBody(w, r, http.StatusConflict, body)
+}
+
 // GET /api/v1/reservations?facility_id=...&start_time=...&end_time=...
-func HandleReservationsList(w http.ResponseWriter, r *http.Request) {
+// GET /api/v1/reservations?facility_id=...&sync_token=... (or updated_since=...) for delta sync
+func (h *Handlers) HandleReservationsList(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	facilityID, err := facilityIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Facility ID is required", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Facility ID is required")
 		return
 	}
 
@@ -235,19 +252,38 @@ func HandleReservationsList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	startTime, endTime, err := parseReservationTimes(r.URL.Query().Get("start_time"), r.URL.Query().Get("end_time"))
+	syncParams, err := models.ParseSyncParams(r.URL.Query().Get("sync_token"), r.URL.Query().Get("updated_since"))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
-	if !endTime.After(startTime) {
-		http.Error(w, "end_time must be after start_time", http.StatusBadRequest)
+	if syncParams.Delta() {
+		h.handleReservationsDelta(w, r, facilityID, syncParams)
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
 	defer cancel()
 
+	startTime, endTime, err := parseReservationTimes(r.URL.Query().Get("start_time"), r.URL.Query().Get("end_time"), h.facilityLocation(ctx, facilityID))
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if !endTime.After(startTime) {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "end_time must be after start_time")
+		return
+	}
+
+	// Read the token before the list so anything committed in between is
+	// replayed on the next delta poll rather than skipped.
+	syncSeq, err := q.GetLatestSyncSeq(ctx)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load sync token")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to list reservations")
+		return
+	}
+
 	reservations, err := q.ListReservationsByDateRange(ctx, dbgen.ListReservationsByDateRangeParams{
 		FacilityID: facilityID,
 		StartTime:  startTime,
@@ -255,10 +291,11 @@ func HandleReservationsList(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list reservations")
-		http.Error(w, "Failed to list reservations", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to list reservations")
 		return
 	}
 
+	w.Header().Set(syncTokenHeader, models.FormatSyncToken(syncSeq))
 	if err := apiutil.WriteJSON(w, http.StatusOK, reservations); err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write reservation list response")
 		return
@@ -266,19 +303,19 @@ func HandleReservationsList(w http.ResponseWriter, r *http.Request) {
 }
 
 // GET /api/v1/events/booking/new
-func HandleEventBookingFormNew(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleEventBookingFormNew(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	facilityID, ok := request.FacilityIDFromBookingRequest(r)
 	if !ok {
-		http.Error(w, "Facility ID is required", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Facility ID is required")
 		return
 	}
 	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
@@ -311,28 +348,18 @@ func HandleEventBookingFormNew(w http.ResponseWriter, r *http.Request) {
 	courtsList, err := q.ListCourts(ctx, facilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load courts")
-		http.Error(w, "Failed to load courts", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load courts")
 		return
 	}
 
-	reservationTypes, err := q.ListReservationTypes(ctx)
+	reservationTypes, err := q.ListReservationTypesForFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to load reservation types")
-		http.Error(w, "Failed to load reservation types", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation types")
 		return
 	}
 
-	memberRows, err := q.ListMembers(ctx, dbgen.ListMembersParams{
-		FacilityID: sql.NullInt64{},
-		SearchTerm: nil,
-		Offset:     0,
-		Limit:      50,
-	})
-	if err != nil {
-		logger.Error().Err(err).Msg("Failed to load members for booking form")
-		memberRows = nil
-	}
-
+	// Members are looked up through /api/v1/members/search as staff type.
 	var buf bytes.Buffer
 	component := reservationstempl.EventBookingForm(reservationstempl.EventBookingFormData{
 		FacilityID:       facilityID,
@@ -340,11 +367,11 @@ func HandleEventBookingFormNew(w http.ResponseWriter, r *http.Request) {
 		EndTime:          endTime,
 		Courts:           reservationstempl.NewCourtOptions(courtsList),
 		ReservationTypes: reservationstempl.NewReservationTypeOptions(reservationTypes),
-		Members:          reservationstempl.NewMemberOptions(memberRows),
+		IdempotencyKey:   apiutil.NewIdempotencyKey(),
 	})
 	if err := component.Render(r.Context(), &buf); err != nil {
 		logger.Error().Err(err).Msg("Failed to render event booking form")
-		http.Error(w, "Failed to render booking form", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render booking form")
 		return
 	}
 
@@ -356,19 +383,19 @@ func HandleEventBookingFormNew(w http.ResponseWriter, r *http.Request) {
 }
 
 // GET /api/v1/reservations/{id}/edit
-func HandleReservationEdit(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleReservationEdit(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
+	q := h.loadQueries()
 	if q == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	reservationID, err := apiutil.ReservationIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
 		return
 	}
 
@@ -378,11 +405,11 @@ func HandleReservationEdit(w http.ResponseWriter, r *http.Request) {
 	reservation, err := q.GetReservationByID(ctx, reservationID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Reservation not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Reservation not found")
 			return
 		}
 		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to fetch reservation")
-		http.Error(w, "Failed to fetch reservation", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch reservation")
 		return
 	}
 	facilityID := reservation.FacilityID
@@ -394,21 +421,21 @@ func HandleReservationEdit(w http.ResponseWriter, r *http.Request) {
 	courtsList, err := q.ListCourts(ctx, facilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load courts")
-		http.Error(w, "Failed to load courts", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load courts")
 		return
 	}
 
-	reservationTypes, err := q.ListReservationTypes(ctx)
+	reservationTypes, err := q.ListReservationTypesForFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to load reservation types")
-		http.Error(w, "Failed to load reservation types", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation types")
 		return
 	}
 
 	reservationCourts, err := q.ListReservationCourts(ctx, reservationID)
 	if err != nil {
 		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to load reservation courts")
-		http.Error(w, "Failed to load reservation courts", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load reservation courts")
 		return
 	}
 
@@ -446,6 +473,20 @@ func HandleReservationEdit(w http.ResponseWriter, r *http.Request) {
 		peoplePerTeam = &value
 	}
 
+	closureDetails, err := q.GetReservationClosureDetails(ctx, reservationID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to load closure details")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load closure details")
+		return
+	}
+
+	memberNote, err := q.GetReservationMemberNote(ctx, reservationID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to load member note")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load member note")
+		return
+	}
+
 	var buf bytes.Buffer
 	component := reservationstempl.BookingForm(reservationstempl.BookingFormData{
 		FacilityID:                facilityID,
@@ -460,12 +501,15 @@ func HandleReservationEdit(w http.ResponseWriter, r *http.Request) {
 		IsOpenEvent:               reservation.IsOpenEvent,
 		TeamsPerCourt:             teamsPerCourt,
 		PeoplePerTeam:             peoplePerTeam,
+		PublicReason:              closureDetails.PublicReason.String,
+		InternalNotes:             closureDetails.InternalNotes.String,
+		MemberNote:                memberNote,
 		IsEdit:                    true,
 		ReservationID:             reservationID,
 	})
 	if err := component.Render(r.Context(), &buf); err != nil {
 		logger.Error().Err(err).Msg("Failed to render reservation edit form")
-		http.Error(w, "Failed to render reservation edit form", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to render reservation edit form")
 		return
 	}
 
@@ -477,20 +521,20 @@ func HandleReservationEdit(w http.ResponseWriter, r *http.Request) {
 }
 
 // PUT /api/v1/reservations/{id}
-func HandleReservationUpdate(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleReservationUpdate(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
-	database := loadDB()
-	if q == nil || database == nil {
+	q := h.loadQueries()
+	service := h.loadService()
+	if q == nil || service == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	reservationID, err := apiutil.ReservationIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
 		return
 	}
 
@@ -500,11 +544,11 @@ func HandleReservationUpdate(w http.ResponseWriter, r *http.Request) {
 	reservation, err := q.GetReservationByID(ctx, reservationID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Reservation not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Reservation not found")
 			return
 		}
 		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to fetch reservation")
-		http.Error(w, "Failed to fetch reservation", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch reservation")
 		return
 	}
 	facilityID := reservation.FacilityID
@@ -514,175 +558,93 @@ func HandleReservationUpdate(w http.ResponseWriter, r *http.Request) {
 	}
 	user := authz.UserFromContext(r.Context())
 	if user == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	req, err := decodeReservationRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
 	if req.FacilityID != 0 && req.FacilityID != facilityID {
-		http.Error(w, "facility_id mismatch between reservation and payload", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "facility_id mismatch between reservation and payload")
 		return
 	}
 	req.FacilityID = facilityID
 
-	startTime, endTime, err := parseReservationTimes(req.StartTime, req.EndTime)
+	startTime, endTime, err := parseReservationTimes(req.StartTime, req.EndTime, h.facilityLocation(ctx, facilityID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
-	if err := validateReservationInput(req, startTime, endTime); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	duration, err := h.reservationDuration(ctx, q, facilityID, req.ReservationTypeID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility booking config")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility booking config")
+		return
+	}
+	// Staff may keep a reservation that no longer fits its type's bounds,
+	// e.g. after they were tightened; the override is audited.
+	checked := duration
+	var durationOverride *reservationsvc.DurationOverride
+	if req.OverrideDurationLimits {
+		if !user.IsStaff {
+			apiutil.WriteError(w, r, http.StatusForbidden, "Only staff can override reservation duration limits")
+			return
+		}
+		checked = apiutil.ReservationDuration{}
+		if duration.Violation(endTime.Sub(startTime)) != "" {
+			durationOverride = &reservationsvc.DurationOverride{UserID: user.ID, Duration: duration}
+		}
+	}
+	if err := validateReservationInput(req, startTime, endTime, checked); err != nil {
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 	if !user.IsStaff {
 		authUserID := user.ID
 		if req.PrimaryUserID != nil && *req.PrimaryUserID > 0 && *req.PrimaryUserID != authUserID {
-			http.Error(w, "primary_user_id must match authenticated user", http.StatusForbidden)
+			apiutil.WriteError(w, r, http.StatusForbidden, "primary_user_id must match authenticated user")
 			return
 		}
 		req.PrimaryUserID = &authUserID
-		if err := enforceMemberTierBookingWindow(ctx, q, facilityID, req.PrimaryUserID, startTime); err != nil {
+		if err := h.enforceMemberTierBookingWindow(ctx, q, facilityID, req.PrimaryUserID, startTime, endTime); err != nil {
 			var fieldErr apiutil.FieldError
 			if errors.As(err, &fieldErr) {
-				http.Error(w, err.Error(), http.StatusBadRequest)
+				apiutil.WriteValidationError(w, r, err)
+				return
+			}
+			var primeTimeErr apiutil.PrimeTimeLockedError
+			if errors.As(err, &primeTimeErr) {
+				apiutil.WriteError(w, r, http.StatusForbidden, err.Error())
 				return
 			}
 			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to validate member booking window")
-			http.Error(w, "Failed to validate booking window", http.StatusInternalServerError)
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate booking window")
 			return
 		}
 	}
 
-	req.CourtIDs = normalizeCourtIDs(req.CourtIDs)
-	if req.ParticipantIDsSet {
-		req.ParticipantIDs = normalizeParticipantIDs(req.ParticipantIDs)
-	}
-
-	var updated dbgen.Reservation
-	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
-		qtx := txdb.Queries
-
-		_, err := qtx.GetReservation(ctx, dbgen.GetReservationParams{
-			ID:         reservationID,
-			FacilityID: facilityID,
-		})
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Reservation not found", Err: err}
-			}
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch reservation", Err: err}
-		}
-
-		if err := apiutil.EnsureCourtsAvailable(ctx, qtx, facilityID, reservationID, startTime, endTime, req.CourtIDs); err != nil {
-			var availErr apiutil.AvailabilityError
-			if errors.As(err, &availErr) {
-				return apiutil.HandlerError{Status: http.StatusConflict, Message: err.Error(), Err: err}
-			}
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check court availability", Err: err}
-		}
-
-		updated, err = qtx.UpdateReservation(ctx, dbgen.UpdateReservationParams{
-			ID:                reservationID,
-			FacilityID:        facilityID,
-			ReservationTypeID: req.ReservationTypeID,
-			RecurrenceRuleID:  apiutil.ToNullInt64(req.RecurrenceRuleID),
-			PrimaryUserID:     apiutil.ToNullInt64(req.PrimaryUserID),
-			ProID:             apiutil.ToNullInt64(req.ProID),
-			OpenPlayRuleID:    apiutil.ToNullInt64(req.OpenPlayRuleID),
-			StartTime:         startTime,
-			EndTime:           endTime,
-			IsOpenEvent:       req.IsOpenEvent,
-			TeamsPerCourt:     apiutil.ToNullInt64(req.TeamsPerCourt),
-			PeoplePerTeam:     apiutil.ToNullInt64(req.PeoplePerTeam),
-		})
-		if err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update reservation", Err: err}
-		}
-
-		existingCourts, err := qtx.ListReservationCourts(ctx, reservationID)
-		if err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation courts", Err: err}
-		}
-
-		existing := make(map[int64]struct{}, len(existingCourts))
-		for _, court := range existingCourts {
-			existing[court.CourtID] = struct{}{}
-		}
-		next := make(map[int64]struct{}, len(req.CourtIDs))
-		for _, courtID := range req.CourtIDs {
-			next[courtID] = struct{}{}
-		}
-
-		for courtID := range existing {
-			if _, ok := next[courtID]; ok {
-				continue
-			}
-			if err := qtx.RemoveReservationCourt(ctx, dbgen.RemoveReservationCourtParams{
-				ReservationID: reservationID,
-				CourtID:       courtID,
-			}); err != nil {
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation court", Err: err}
-			}
-		}
-
-		for courtID := range next {
-			if _, ok := existing[courtID]; ok {
-				continue
-			}
-			if err := qtx.AddReservationCourt(ctx, dbgen.AddReservationCourtParams{
-				ReservationID: reservationID,
-				CourtID:       courtID,
-			}); err != nil {
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation court", Err: err}
-			}
-		}
-
-		if req.ParticipantIDsSet {
-			existingParticipants, err := qtx.ListParticipantsForReservation(ctx, reservationID)
-			if err != nil {
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation participants", Err: err}
-			}
-
-			existingParticipantIDs := make(map[int64]struct{}, len(existingParticipants))
-			for _, participant := range existingParticipants {
-				existingParticipantIDs[participant.ID] = struct{}{}
-			}
-			nextParticipantIDs := make(map[int64]struct{}, len(req.ParticipantIDs))
-			for _, participantID := range req.ParticipantIDs {
-				nextParticipantIDs[participantID] = struct{}{}
-			}
-
-			for participantID := range existingParticipantIDs {
-				if _, ok := nextParticipantIDs[participantID]; ok {
-					continue
-				}
-				if err := qtx.RemoveParticipant(ctx, dbgen.RemoveParticipantParams{
-					ReservationID: reservationID,
-					UserID:        participantID,
-				}); err != nil {
-					return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation participant", Err: err}
-				}
-			}
-
-			for participantID := range nextParticipantIDs {
-				if _, ok := existingParticipantIDs[participantID]; ok {
-					continue
-				}
-				if err := qtx.AddParticipant(ctx, dbgen.AddParticipantParams{
-					ReservationID: reservationID,
-					UserID:        participantID,
-				}); err != nil {
-					return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation participant", Err: err}
-				}
-			}
+	if err := apiutil.EnsureWithinHoursOverride(ctx, q, h.loadFacilities(), facilityID, startTime, endTime); err != nil {
+		var overrideErr apiutil.HoursOverrideError
+		if errors.As(err, &overrideErr) {
+			apiutil.WriteError(w, r, http.StatusConflict, err.Error())
+			return
 		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility hours override")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to validate facility hours")
+		return
+	}
 
-		return nil
+	updated, err := service.UpdateReservation(ctx, reservationsvc.UpdateInput{
+		Details:             reservationDetails(req, startTime, endTime),
+		ReservationID:       reservationID,
+		FacilityID:          facilityID,
+		ReplaceParticipants: req.ParticipantIDsSet,
+		ParticipantIDs:      req.ParticipantIDs,
+		DurationOverride:    durationOverride,
 	})
 	if err != nil {
 		var herr apiutil.HandlerError
@@ -690,15 +652,22 @@ func HandleReservationUpdate(w http.ResponseWriter, r *http.Request) {
 			if herr.Status == http.StatusInternalServerError {
 				logger.Error().Err(herr.Err).Int64("reservation_id", reservationID).Msg(herr.Message)
 			}
-			http.Error(w, herr.Message, herr.Status)
+			apiutil.WriteHandlerError(w, r, herr)
 			return
 		}
 		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to update reservation")
-		http.Error(w, "Failed to update reservation", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to update reservation")
 		return
 	}
+	if durationOverride != nil {
+		logger.Info().
+			Int64("reservation_id", reservationID).
+			Int64("user_id", user.ID).
+			Dur("duration", endTime.Sub(startTime)).
+			Msg("Reservation duration limits overridden")
+	}
 
-	w.Header().Set("HX-Trigger", "refreshCourtsCalendar")
+	apiutil.AppendHXTrigger(w, "refreshCourtsCalendar")
 	if err := apiutil.WriteJSON(w, http.StatusOK, updated); err != nil {
 		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write reservation response")
 		return
@@ -706,20 +675,20 @@ func HandleReservationUpdate(w http.ResponseWriter, r *http.Request) {
 }
 
 // DELETE /api/v1/reservations/{id}
-func HandleReservationDelete(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleReservationDelete(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	q := loadQueries()
-	database := loadDB()
-	if q == nil || database == nil {
+	q := h.loadQueries()
+	service := h.loadService()
+	if q == nil || service == nil {
 		logger.Error().Msg("Database queries not initialized")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
 	reservationID, err := apiutil.ReservationIDFromRequest(r)
 	if err != nil {
-		http.Error(w, "Invalid reservation ID", http.StatusBadRequest)
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
 		return
 	}
 
@@ -728,18 +697,18 @@ func HandleReservationDelete(w http.ResponseWriter, r *http.Request) {
 
 	deleteReq, err := decodeReservationDeleteRequest(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apiutil.WriteValidationError(w, r, err)
 		return
 	}
 
 	reservation, err := q.GetReservationByID(ctx, reservationID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			http.Error(w, "Reservation not found", http.StatusNotFound)
+			apiutil.WriteError(w, r, http.StatusNotFound, "Reservation not found")
 			return
 		}
 		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to fetch reservation")
-		http.Error(w, "Failed to fetch reservation", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch reservation")
 		return
 	}
 	facilityID := reservation.FacilityID
@@ -750,239 +719,60 @@ func HandleReservationDelete(w http.ResponseWriter, r *http.Request) {
 
 	user := authz.UserFromContext(r.Context())
 	if user == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	now := time.Now()
-	hoursUntilReservation := hoursUntilReservationStart(reservation.StartTime, now)
-	policyRefundPercentage, err := apiutil.ApplicableRefundPercentage(ctx, q, facilityID, hoursUntilReservation, &reservation.ReservationTypeID)
-	if err != nil {
-		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to load cancellation policy")
-		http.Error(w, "Failed to load cancellation policy", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	if deleteReq.WaiveFee != nil && *deleteReq.WaiveFee && !user.IsStaff {
-		http.Error(w, "Forbidden", http.StatusForbidden)
-		return
-	}
-
-	if user.IsStaff && policyRefundPercentage < 100 && deleteReq.WaiveFee == nil {
-		penalty := cancellationPenaltyResponse{
-			ReservationID:     reservationID,
-			RefundPercentage:  policyRefundPercentage,
-			FeePercentage:     100 - policyRefundPercentage,
-			HoursBeforeStart:  hoursUntilReservation,
-			FacilityID:        facilityID,
-			ReservationStarts: reservation.StartTime,
-		}
-		if apiutil.IsJSONRequest(r) {
-			if err := apiutil.WriteJSON(w, http.StatusConflict, penalty); err != nil {
-				logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write cancellation penalty response")
-			}
-			return
-		}
-		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		w.WriteHeader(http.StatusConflict)
-		if _, err := w.Write([]byte(renderCancellationPenaltyPrompt(penalty))); err != nil {
-			logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write cancellation penalty prompt")
-		}
+		apiutil.WriteError(w, r, http.StatusForbidden, "Forbidden")
 		return
 	}
 
-	refundPercentage := policyRefundPercentage
-	feeWaived := false
-	if deleteReq.WaiveFee != nil && *deleteReq.WaiveFee {
-		refundPercentage = 100
-		feeWaived = true
-	}
-
-	var reservationCourts []dbgen.ListReservationCourtsRow
-	var reservationParticipants []dbgen.ListParticipantsForReservationRow
-	var reservationTypeName string
-	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
-		qtx := txdb.Queries
-
-		_, err := qtx.GetReservation(ctx, dbgen.GetReservationParams{
-			ID:         reservationID,
-			FacilityID: facilityID,
-		})
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Reservation not found", Err: err}
-			}
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch reservation", Err: err}
-		}
-
-		if _, err := qtx.LogCancellation(ctx, dbgen.LogCancellationParams{
-			ReservationID:           reservationID,
-			CancelledByUserID:       user.ID,
-			CancelledAt:             now,
-			RefundPercentageApplied: refundPercentage,
-			FeeWaived:               feeWaived,
-			HoursBeforeStart:        hoursUntilReservation,
-		}); err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to log cancellation", Err: err}
-		}
-
-		loadedReservationTypeName, err := qtx.GetReservationTypeNameByReservationID(ctx, reservationID)
-		if err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation type", Err: err}
-		}
-		reservationTypeName = loadedReservationTypeName
-		if loadedReservationTypeName == "PRO_SESSION" {
-			redemptions, err := qtx.ListLessonPackageRedemptionsByReservationID(ctx, sql.NullInt64{Int64: reservationID, Valid: true})
-			if err != nil {
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load lesson package redemption", Err: err}
-			}
-			if len(redemptions) > 0 {
-				for _, redemption := range redemptions {
-					if _, err := qtx.RestoreLessonPackageLesson(ctx, redemption.LessonPackageID); err != nil {
-						if errors.Is(err, sql.ErrNoRows) {
-							logger.Info().Int64("lesson_package_id", redemption.LessonPackageID).Msg("Skipped lesson package restore (expired or already at max)")
-							continue
-						}
-						return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to restore lesson package", Err: err}
-					}
-				}
-				if err := qtx.DeleteLessonPackageRedemptionsByReservationID(ctx, sql.NullInt64{Int64: reservationID, Valid: true}); err != nil {
-					return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to clear lesson package redemption", Err: err}
-				}
-			}
-		}
+	_, err = service.CancelReservation(ctx, reservationsvc.CancelInput{
+		ReservationID:     reservationID,
+		CancelledByUserID: user.ID,
+		WaiveFee:          deleteReq.WaiveFee != nil && *deleteReq.WaiveFee,
+		// Staff choose whether to waive a cancellation fee before it applies.
+		ConfirmPenalty: func(context.Context, *dbgen.Queries, reservationsvc.CancellationPenalty) (bool, error) {
+			return !user.IsStaff || deleteReq.WaiveFee != nil, nil
+		},
+		RestoreLessonPackages: true,
 		// Only notify pros for member-initiated lesson cancellations.
-		if loadedReservationTypeName == "PRO_SESSION" && !user.IsStaff {
-			if !reservation.ProID.Valid {
-				logger.Error().Int64("reservation_id", reservationID).Msg("Missing pro for lesson cancellation notification")
-			} else {
-				memberName := "Member"
-				if reservation.PrimaryUserID.Valid {
-					member, err := qtx.GetMemberByID(ctx, reservation.PrimaryUserID.Int64)
-					if err != nil && !errors.Is(err, sql.ErrNoRows) {
-						return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load member details", Err: err}
-					}
-					if err == nil {
-						memberName = strings.TrimSpace(fmt.Sprintf("%s %s", member.FirstName, member.LastName))
-					}
-					if memberName == "" {
-						memberName = "Member"
-					}
-				}
-				message := fmt.Sprintf(
-					"Lesson cancelled: %s (%s - %s)",
-					memberName,
-					reservation.StartTime.Format(timeLayoutDatetimeMinute),
-					reservation.EndTime.Format(timeLayoutDatetimeMinute),
-				)
-				if _, err := qtx.CreateLessonCancelledNotification(ctx, dbgen.CreateLessonCancelledNotificationParams{
-					FacilityID: facilityID,
-					Message:    message,
-					RelatedReservationID: sql.NullInt64{
-						Int64: reservationID,
-						Valid: true,
-					},
-					TargetStaffID: reservation.ProID,
-				}); err != nil {
-					logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to notify pro about lesson cancellation")
+		NotifyPro:       !user.IsStaff,
+		OfferToWaitlist: true,
+		IssueCredit:     true,
+	})
+	if err != nil {
+		var penaltyErr reservationsvc.CancellationPenaltyError
+		if errors.As(err, &penaltyErr) {
+			penalty := newCancellationPenaltyResponse(reservationID, facilityID, penaltyErr.Penalty)
+			if apiutil.IsJSONRequest(r) {
+				if err := apiutil.WriteJSON(w, http.StatusConflict, penalty); err != nil {
+					logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write cancellation penalty response")
 				}
+				return
 			}
-		}
-
-		courts, err := qtx.ListReservationCourts(ctx, reservationID)
-		if err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation courts", Err: err}
-		}
-		reservationCourts = courts
-		for _, court := range courts {
-			if err := qtx.RemoveReservationCourt(ctx, dbgen.RemoveReservationCourtParams{
-				ReservationID: reservationID,
-				CourtID:       court.CourtID,
-			}); err != nil {
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation court", Err: err}
-			}
-		}
-
-		participants, err := qtx.ListParticipantsForReservation(ctx, reservationID)
-		if err != nil {
-			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation participants", Err: err}
-		}
-		reservationParticipants = participants
-		for _, participant := range participants {
-			if err := qtx.RemoveParticipant(ctx, dbgen.RemoveParticipantParams{
-				ReservationID: reservationID,
-				UserID:        participant.ID,
-			}); err != nil {
-				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation participant", Err: err}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusConflict)
+			if _, err := w.Write([]byte(renderCancellationPenaltyPrompt(penalty))); err != nil {
+				logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write cancellation penalty prompt")
 			}
+			return
 		}
-		return nil
-	})
-	if err != nil {
 		var herr apiutil.HandlerError
 		if errors.As(err, &herr) {
 			if herr.Status == http.StatusInternalServerError {
 				logger.Error().Err(herr.Err).Int64("reservation_id", reservationID).Msg(herr.Message)
 			}
-			http.Error(w, herr.Message, herr.Status)
+			apiutil.WriteHandlerError(w, r, herr)
 			return
 		}
 		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to delete reservation")
-		http.Error(w, "Failed to delete reservation", http.StatusInternalServerError)
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to delete reservation")
 		return
 	}
 
-	if emailClient != nil && reservation.ID != 0 {
-		emailCtx, emailCancel := context.WithTimeout(context.Background(), reservationQueryTimeout)
-		defer emailCancel()
-		queryCtx, queryCancel := context.WithTimeout(context.Background(), reservationQueryTimeout)
-		defer queryCancel()
-		facility, err := q.GetFacilityByID(queryCtx, facilityID)
-		if err != nil {
-			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility for cancellation email")
-		} else {
-			facilityLoc := time.Local
-			if facility.Timezone != "" {
-				if loadedLoc, loadErr := time.LoadLocation(facility.Timezone); loadErr == nil {
-					facilityLoc = loadedLoc
-				} else {
-					logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone for cancellation email")
-				}
-			}
-			date, timeRange := email.FormatDateTimeRange(reservation.StartTime.In(facilityLoc), reservation.EndTime.In(facilityLoc))
-			courtLabel := apiutil.ReservationCourtLabel(reservationCourts)
-			refund := refundPercentage
-			message := email.BuildCancellationEmail(email.CancellationDetails{
-				FacilityName:     facility.Name,
-				ReservationType:  reservationTypeName,
-				Date:             date,
-				TimeRange:        timeRange,
-				Courts:           courtLabel,
-				RefundPercentage: &refund,
-				FeeWaived:        feeWaived,
-			})
-			sender := email.ResolveFromAddress(queryCtx, q, facility, logger)
-			recipients := make(map[int64]struct{}, len(reservationParticipants)+1)
-			for _, participant := range reservationParticipants {
-				recipients[participant.ID] = struct{}{}
-			}
-			if reservation.PrimaryUserID.Valid {
-				recipients[reservation.PrimaryUserID.Int64] = struct{}{}
-			}
-			for participantID := range recipients {
-				email.SendCancellationEmail(emailCtx, q, emailClient, participantID, message, sender, logger)
-			}
-		}
-	}
-
-	notifyCtx, notifyCancel := context.WithTimeout(context.Background(), waitlistNotificationTimeout)
-	defer notifyCancel()
-
-	if err := notifyWaitlistedMembers(notifyCtx, database, reservation, reservationCourts); err != nil {
-		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to notify waitlisted members")
-	}
-
-	w.Header().Set("HX-Trigger", "refreshCourtsCalendar")
+	apiutil.AppendHXTrigger(w, "refreshCourtsCalendar")
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -997,6 +787,19 @@ type cancellationPenaltyResponse struct {
 	HoursBeforeStart  int64     `json:"hours_before_start"`
 	FacilityID        int64     `json:"facility_id"`
 	ReservationStarts time.Time `json:"reservation_start_time"`
+	RefundAmountCents *int64    `json:"refund_amount_cents,omitempty"`
+}
+
+func newCancellationPenaltyResponse(reservationID, facilityID int64, penalty reservationsvc.CancellationPenalty) cancellationPenaltyResponse {
+	return cancellationPenaltyResponse{
+		ReservationID:     reservationID,
+		RefundPercentage:  penalty.RefundPercentage,
+		FeePercentage:     100 - penalty.RefundPercentage,
+		HoursBeforeStart:  penalty.HoursBeforeStart,
+		FacilityID:        facilityID,
+		ReservationStarts: penalty.Reservation.StartTime,
+		RefundAmountCents: penalty.RefundAmountCents(),
+	}
 }
 
 func decodeReservationDeleteRequest(r *http.Request) (reservationDeleteRequest, error) {
@@ -1034,9 +837,13 @@ func decodeReservationDeleteRequest(r *http.Request) (reservationDeleteRequest,
 }
 
 func renderCancellationPenaltyPrompt(penalty cancellationPenaltyResponse) string {
+	refund := fmt.Sprintf("%d%% refund", penalty.RefundPercentage)
+	if penalty.RefundAmountCents != nil {
+		refund = fmt.Sprintf("%d%% refund of %s", penalty.RefundPercentage, apiutil.FormatPriceCents(*penalty.RefundAmountCents))
+	}
 	return fmt.Sprintf(`
 <div class="space-y-3">
-  <p class="text-sm text-amber-900">This cancellation applies a %d%% fee (%d%% refund). Choose whether to waive the fee.</p>
+  <p class="text-sm text-amber-900">This cancellation applies a %d%% fee (%s). Choose whether to waive the fee.</p>
   <div class="flex flex-wrap gap-2">
     <form hx-delete="/api/v1/reservations/%d" hx-swap="none" hx-on::response-error="document.getElementById('reservation-cancel-feedback').innerHTML = event.detail.xhr.responseText; document.getElementById('reservation-cancel-feedback').classList.remove('hidden');" hx-on::after-request="if(event.detail.xhr.status === 204){document.getElementById('modal').innerHTML='';}">
       <input type="hidden" name="waive_fee" value="false"/>
@@ -1048,7 +855,7 @@ func renderCancellationPenaltyPrompt(penalty cancellationPenaltyResponse) string
     </form>
   </div>
 </div>
-`, penalty.FeePercentage, penalty.RefundPercentage, penalty.ReservationID, penalty.ReservationID)
+`, penalty.FeePercentage, refund, penalty.ReservationID, penalty.ReservationID)
 }
 
 type reservationRequest struct {
@@ -1066,6 +873,30 @@ type reservationRequest struct {
 	CourtIDs          []int64 `json:"court_ids"`
 	ParticipantIDs    []int64 `json:"participant_ids"`
 	ParticipantIDsSet bool    `json:"-"`
+	PublicReason      string  `json:"public_reason,omitempty"`
+	InternalNotes     string  `json:"internal_notes,omitempty"`
+	ClosureDetailsSet bool    `json:"-"`
+	// AllowMemberOverlap lets staff book a member into overlapping
+	// reservations, e.g. a parent booking for two children on one account.
+	AllowMemberOverlap bool `json:"allow_member_overlap,omitempty"`
+	// Guests are non-members brought along by the primary user. They are
+	// only read when the reservation is created.
+	Guests []guestRequest `json:"guests,omitempty"`
+	// MemberNote is the booking member's note to staff. Like Guests it is
+	// only read when the reservation is created.
+	MemberNote string `json:"member_note,omitempty"`
+	// ApplyCredits pays the court price from the primary user's account
+	// credit where it can. Only read when the reservation is created.
+	ApplyCredits bool `json:"apply_credits,omitempty"`
+	// OverrideDurationLimits lets staff save an edit that breaks the
+	// reservation type's duration bounds. Only read when the reservation is
+	// updated.
+	OverrideDurationLimits bool `json:"override_duration_limits,omitempty"`
+}
+
+type guestRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
 }
 
 func decodeReservationRequest(r *http.Request) (reservationRequest, error) {
@@ -1095,7 +926,12 @@ func decodeReservationRequest(r *http.Request) (reservationRequest, error) {
 			if _, ok := raw["participant_ids"]; ok {
 				req.ParticipantIDsSet = true
 			}
+			_, hasPublicReason := raw["public_reason"]
+			_, hasInternalNotes := raw["internal_notes"]
+			req.ClosureDetailsSet = hasPublicReason || hasInternalNotes
 		}
+		req.PublicReason = strings.TrimSpace(req.PublicReason)
+		req.InternalNotes = strings.TrimSpace(req.InternalNotes)
 		return req, nil
 	}
 
@@ -1136,6 +972,15 @@ func decodeReservationRequest(r *http.Request) (reservationRequest, error) {
 	req.StartTime = strings.TrimSpace(r.FormValue("start_time"))
 	req.EndTime = strings.TrimSpace(r.FormValue("end_time"))
 	req.IsOpenEvent = apiutil.ParseBool(r.FormValue("is_open_event"))
+	req.AllowMemberOverlap = apiutil.ParseBool(r.FormValue("allow_member_overlap"))
+	req.ApplyCredits = apiutil.ParseBool(r.FormValue("apply_credits"))
+	req.OverrideDurationLimits = apiutil.ParseBool(r.FormValue("override_duration_limits"))
+	_, hasPublicReason := r.Form["public_reason"]
+	_, hasInternalNotes := r.Form["internal_notes"]
+	req.ClosureDetailsSet = hasPublicReason || hasInternalNotes
+	req.PublicReason = strings.TrimSpace(r.FormValue("public_reason"))
+	req.InternalNotes = strings.TrimSpace(r.FormValue("internal_notes"))
+	req.MemberNote = r.FormValue("member_note")
 
 	req.TeamsPerCourt, err = parseOptionalPointer(r.FormValue("teams_per_court"), "teams_per_court")
 	if err != nil {
@@ -1155,6 +1000,18 @@ func decodeReservationRequest(r *http.Request) (reservationRequest, error) {
 		return reservationRequest{}, err
 	}
 
+	guestEmails := r.Form["guest_emails"]
+	for i, name := range r.Form["guest_names"] {
+		var email string
+		if i < len(guestEmails) {
+			email = guestEmails[i]
+		}
+		if strings.TrimSpace(name) == "" && strings.TrimSpace(email) == "" {
+			continue
+		}
+		req.Guests = append(req.Guests, guestRequest{Name: name, Email: email})
+	}
+
 	participantValues, participantValuesOK := r.Form["participant_ids"]
 	if !participantValuesOK {
 		participantValues, participantValuesOK = r.Form["participant_ids[]"]
@@ -1170,7 +1027,69 @@ func decodeReservationRequest(r *http.Request) (reservationRequest, error) {
 	return req, nil
 }
 
-func validateReservationInput(req reservationRequest, startTime, endTime time.Time) error {
+// reservationGuests converts the request's guests for the service, which
+// validates them and enforces the facility's guest cap.
+func reservationGuests(guests []guestRequest) []reservationsvc.Guest {
+	if len(guests) == 0 {
+		return nil
+	}
+	converted := make([]reservationsvc.Guest, 0, len(guests))
+	for _, guest := range guests {
+		converted = append(converted, reservationsvc.Guest{Name: guest.Name, Email: guest.Email})
+	}
+	return converted
+}
+
+// reservationDetails is the part of a request the service stores on the
+// reservation itself.
+func reservationDetails(req reservationRequest, startTime, endTime time.Time) reservationsvc.Details {
+	details := reservationsvc.Details{
+		ReservationTypeID: req.ReservationTypeID,
+		RecurrenceRuleID:  req.RecurrenceRuleID,
+		PrimaryUserID:     req.PrimaryUserID,
+		ProID:             req.ProID,
+		OpenPlayRuleID:    req.OpenPlayRuleID,
+		StartTime:         startTime,
+		EndTime:           endTime,
+		IsOpenEvent:       req.IsOpenEvent,
+		TeamsPerCourt:     req.TeamsPerCourt,
+		PeoplePerTeam:     req.PeoplePerTeam,
+		CourtIDs:          req.CourtIDs,
+	}
+	if req.ClosureDetailsSet {
+		details.ClosureDetails = &reservationsvc.ClosureDetails{
+			PublicReason:  req.PublicReason,
+			InternalNotes: req.InternalNotes,
+		}
+	}
+	return details
+}
+
+// reservationDuration is how long a reservation of the type may run at the
+// facility, read through the facility cache. A type the facility can't use
+// gets the facility's bounds; the service rejects it later.
+func (h *Handlers) reservationDuration(ctx context.Context, q *dbgen.Queries, facilityID, reservationTypeID int64) (apiutil.ReservationDuration, error) {
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		return apiutil.ReservationDuration{}, err
+	}
+	var resType *dbgen.ReservationType
+	if reservationTypeID > 0 {
+		row, err := q.GetReservationTypeForFacility(ctx, dbgen.GetReservationTypeForFacilityParams{
+			ID:         reservationTypeID,
+			FacilityID: sql.NullInt64{Int64: facilityID, Valid: true},
+		})
+		switch {
+		case err == nil:
+			resType = &row
+		case !errors.Is(err, sql.ErrNoRows):
+			return apiutil.ReservationDuration{}, err
+		}
+	}
+	return apiutil.ReservationTypeDuration(&facility, resType), nil
+}
+
+func validateReservationInput(req reservationRequest, startTime, endTime time.Time, duration apiutil.ReservationDuration) error {
 	switch {
 	case req.FacilityID <= 0:
 		return apiutil.FieldError{Field: "facility_id", Reason: "must be a positive integer"}
@@ -1180,8 +1099,9 @@ func validateReservationInput(req reservationRequest, startTime, endTime time.Ti
 		return apiutil.FieldError{Field: "court_ids", Reason: "must include at least one court"}
 	case !endTime.After(startTime):
 		return apiutil.FieldError{Field: "end_time", Reason: "must be after start_time"}
-	case endTime.Sub(startTime) < minReservationDuration:
-		return apiutil.FieldError{Field: "end_time", Reason: "must be at least 1 hour after start_time"}
+	}
+	if violation := duration.Violation(endTime.Sub(startTime)); violation != "" {
+		return apiutil.FieldError{Field: "end_time", Reason: fmt.Sprintf("must be %s after start_time", violation)}
 	}
 
 	for _, courtID := range req.CourtIDs {
@@ -1194,6 +1114,16 @@ func validateReservationInput(req reservationRequest, startTime, endTime time.Ti
 			return apiutil.FieldError{Field: "participant_ids", Reason: "must contain only positive integers"}
 		}
 	}
+	// Members sign up for open events against their team sizes, so staff
+	// must set them. Open play reservations size themselves from the rule.
+	if req.IsOpenEvent && req.OpenPlayRuleID == nil {
+		switch {
+		case req.TeamsPerCourt == nil:
+			return apiutil.FieldError{Field: "teams_per_court", Reason: "is required for open events"}
+		case req.PeoplePerTeam == nil:
+			return apiutil.FieldError{Field: "people_per_team", Reason: "is required for open events"}
+		}
+	}
 	for name, value := range map[string]*int64{
 		"recurrence_rule_id": req.RecurrenceRuleID,
 		"primary_user_id":    req.PrimaryUserID,
@@ -1210,7 +1140,7 @@ func validateReservationInput(req reservationRequest, startTime, endTime time.Ti
 	return nil
 }
 
-func enforceMemberTierBookingWindow(ctx context.Context, q *dbgen.Queries, facilityID int64, primaryUserID *int64, startTime time.Time) error {
+func (h *Handlers) enforceMemberTierBookingWindow(ctx context.Context, q *dbgen.Queries, facilityID int64, primaryUserID *int64, startTime, endTime time.Time) error {
 	if primaryUserID == nil || *primaryUserID <= 0 {
 		return nil
 	}
@@ -1223,17 +1153,14 @@ func enforceMemberTierBookingWindow(ctx context.Context, q *dbgen.Queries, facil
 		return err
 	}
 
-	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, q, facilityID, member.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
+	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, q, h.loadFacilities(), facilityID, member.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
 	if err != nil {
 		return err
 	}
 
 	loc := startTime.Location()
-	if facility != nil && facility.Timezone != "" {
-		loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-		if loadErr == nil {
-			loc = loadedLoc
-		}
+	if facility != nil {
+		loc = apiutil.FacilityLocation(*facility, log.Ctx(ctx))
 	}
 
 	now := time.Now().In(loc)
@@ -1245,20 +1172,15 @@ func enforceMemberTierBookingWindow(ctx context.Context, q *dbgen.Queries, facil
 		return apiutil.FieldError{Field: "start_time", Reason: fmt.Sprintf("must be within %d days for the member's booking window", maxAdvanceDays)}
 	}
 
-	return nil
-}
-
-func normalizeCourtIDs(courtIDs []int64) []int64 {
-	seen := make(map[int64]struct{}, len(courtIDs))
-	normalized := make([]int64, 0, len(courtIDs))
-	for _, courtID := range courtIDs {
-		if _, ok := seen[courtID]; ok {
-			continue
+	primeTimeLevel := member.MembershipLevel
+	if facility != nil {
+		tier, err := apiutil.LoadMembershipTier(ctx, q, facility.OrganizationID, member.MembershipLevel)
+		if err != nil {
+			return err
 		}
-		seen[courtID] = struct{}{}
-		normalized = append(normalized, courtID)
+		primeTimeLevel = apiutil.PrimeTimeLevel(tier, member.MembershipLevel)
 	}
-	return normalized
+	return apiutil.EnsurePrimeTimeUnlocked(ctx, q, facilityID, primeTimeLevel, startTimeInLoc, endTime.In(loc), now)
 }
 
 func normalizeParticipantIDs(participantIDs []int64) []int64 {
@@ -1274,226 +1196,25 @@ func normalizeParticipantIDs(participantIDs []int64) []int64 {
 	return normalized
 }
 
-func parseReservationTimes(startValue, endValue string) (time.Time, time.Time, error) {
-	startTime, err := parseReservationTime(startValue, "start_time")
+func parseReservationTimes(startValue, endValue string, loc *time.Location) (time.Time, time.Time, error) {
+	startTime, err := apiutil.ParseFlexibleTime(startValue, "start_time", loc)
 	if err != nil {
 		return time.Time{}, time.Time{}, err
 	}
-	endTime, err := parseReservationTime(endValue, "end_time")
+	endTime, err := apiutil.ParseFlexibleTime(endValue, "end_time", loc)
 	if err != nil {
 		return time.Time{}, time.Time{}, err
 	}
 	return startTime, endTime, nil
 }
 
-func parseReservationTime(value, field string) (time.Time, error) {
-	value = strings.TrimSpace(value)
-	if value == "" {
-		return time.Time{}, apiutil.FieldError{Field: field, Reason: "is required"}
-	}
-
-	layouts := []string{time.RFC3339, timeLayoutDatetimeLocal, timeLayoutDatetimeMinute}
-	for _, layout := range layouts {
-		if layout == time.RFC3339 {
-			parsed, err := time.Parse(layout, value)
-			if err == nil {
-				return parsed, nil
-			}
-			continue
-		}
-		parsed, err := time.ParseInLocation(layout, value, time.Local)
-		if err == nil {
-			return parsed, nil
-		}
-	}
-	return time.Time{}, apiutil.FieldError{Field: field, Reason: "must be a valid datetime"}
-}
-
-func notifyWaitlistedMembers(ctx context.Context, database *appdb.DB, reservation dbgen.Reservation, courts []dbgen.ListReservationCourtsRow) error {
-	if database == nil {
-		return nil
-	}
-
-	targetDate, targetStartTime, targetEndTime := reservationWaitlistSlot(reservation)
-	now := time.Now()
-	return database.RunInTx(ctx, func(txdb *appdb.DB) error {
-		qtx := txdb.Queries
-
-		waitlists, err := listMatchingWaitlistsForCancelledSlot(ctx, qtx, reservation.FacilityID, targetDate, targetStartTime, targetEndTime, courts)
-		if err != nil {
-			return err
-		}
-		if len(waitlists) == 0 {
-			return nil
-		}
-
-		config, err := loadWaitlistNotificationConfig(ctx, qtx, reservation.FacilityID)
-		if err != nil {
-			return err
-		}
-
-		waitlists = filterWaitlistsByNotificationWindow(waitlists, reservation.StartTime, now, config.NotificationWindowMinutes)
-		if len(waitlists) == 0 {
-			return nil
-		}
-
-		return createWaitlistNotifications(ctx, qtx, waitlists, config, now)
-	})
-}
-
-func reservationWaitlistSlot(reservation dbgen.Reservation) (time.Time, string, string) {
-	startTime := reservation.StartTime
-	endTime := reservation.EndTime
-	targetDate := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, startTime.Location())
-	return targetDate, startTime.Format(waitlistTimeLayout), endTime.Format(waitlistTimeLayout)
-}
-
-func listMatchingWaitlistsForCancelledSlot(
-	ctx context.Context,
-	q *dbgen.Queries,
-	facilityID int64,
-	targetDate time.Time,
-	targetStartTime string,
-	targetEndTime string,
-	courts []dbgen.ListReservationCourtsRow,
-) ([]dbgen.Waitlist, error) {
-	waitlistsByID := make(map[int64]dbgen.Waitlist)
-
-	addEntries := func(targetCourtParam sql.NullInt64) error {
-		entries, err := q.ListMatchingPendingWaitlistsForCancelledSlot(ctx, dbgen.ListMatchingPendingWaitlistsForCancelledSlotParams{
-			FacilityID:      facilityID,
-			TargetDate:      targetDate,
-			TargetStartTime: targetStartTime,
-			TargetEndTime:   targetEndTime,
-			TargetCourtID:   targetCourtParam,
-		})
-		if err != nil {
-			return err
-		}
-		for _, entry := range entries {
-			waitlistsByID[entry.ID] = entry
-		}
-		return nil
-	}
-
-	if len(courts) == 0 {
-		if err := addEntries(sql.NullInt64{}); err != nil {
-			return nil, err
-		}
-	} else {
-		for _, court := range courts {
-			if err := addEntries(sql.NullInt64{Int64: court.CourtID, Valid: true}); err != nil {
-				return nil, err
-			}
-		}
-		if err := addEntries(sql.NullInt64{}); err != nil {
-			return nil, err
-		}
-	}
-
-	waitlists := make([]dbgen.Waitlist, 0, len(waitlistsByID))
-	for _, entry := range waitlistsByID {
-		waitlists = append(waitlists, entry)
-	}
-	sort.Slice(waitlists, func(i, j int) bool {
-		if waitlists[i].Position != waitlists[j].Position {
-			return waitlists[i].Position < waitlists[j].Position
-		}
-		if !waitlists[i].CreatedAt.Equal(waitlists[j].CreatedAt) {
-			return waitlists[i].CreatedAt.Before(waitlists[j].CreatedAt)
-		}
-		return waitlists[i].ID < waitlists[j].ID
-	})
-
-	return waitlists, nil
-}
-
-func loadWaitlistNotificationConfig(ctx context.Context, q *dbgen.Queries, facilityID int64) (dbgen.WaitlistConfig, error) {
-	config, err := q.GetWaitlistConfig(ctx, facilityID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return dbgen.WaitlistConfig{
-				FacilityID:       facilityID,
-				NotificationMode: waitlistNotificationBroadcast,
-			}, nil
-		}
-		return dbgen.WaitlistConfig{}, err
-	}
-	if strings.TrimSpace(config.NotificationMode) == "" {
-		config.NotificationMode = waitlistNotificationBroadcast
-	}
-	return config, nil
-}
-
-func filterWaitlistsByNotificationWindow(waitlists []dbgen.Waitlist, slotStart time.Time, now time.Time, windowMinutes int64) []dbgen.Waitlist {
-	if windowMinutes <= 0 {
-		return waitlists
-	}
-	windowStart := now
-	windowEnd := now.Add(time.Duration(windowMinutes) * time.Minute)
-	if slotStart.Before(windowStart) || slotStart.After(windowEnd) {
-		return nil
-	}
-	return waitlists
-}
-
-func createWaitlistNotifications(ctx context.Context, q *dbgen.Queries, waitlists []dbgen.Waitlist, config dbgen.WaitlistConfig, now time.Time) error {
-	mode := strings.ToLower(strings.TrimSpace(config.NotificationMode))
-	if mode == "" {
-		mode = waitlistNotificationBroadcast
-	}
-
-	switch mode {
-	case waitlistNotificationSequential:
-		if len(waitlists) == 0 {
-			return nil
-		}
-		selected := waitlists[0]
-		if _, err := q.UpdateWaitlistStatus(ctx, dbgen.UpdateWaitlistStatusParams{
-			ID:         selected.ID,
-			FacilityID: selected.FacilityID,
-			Status:     waitlistStatusNotified,
-		}); err != nil {
-			return err
-		}
-
-		expiryMinutes := config.OfferExpiryMinutes
-		if expiryMinutes <= 0 {
-			expiryMinutes = defaultWaitlistOfferExpiryMinutes
-		}
-		expiresAt := now.Add(time.Duration(expiryMinutes) * time.Minute)
-
-		_, err := q.CreateWaitlistOffer(ctx, dbgen.CreateWaitlistOfferParams{
-			WaitlistID: selected.ID,
-			ExpiresAt:  expiresAt,
-			Status:     waitlistOfferStatusPending,
-		})
-		return err
-	default:
-		expiryMinutes := config.OfferExpiryMinutes
-		if expiryMinutes <= 0 {
-			expiryMinutes = defaultWaitlistOfferExpiryMinutes
-		}
-		expiresAt := now.Add(time.Duration(expiryMinutes) * time.Minute)
-
-		for _, entry := range waitlists {
-			if _, err := q.UpdateWaitlistStatus(ctx, dbgen.UpdateWaitlistStatusParams{
-				ID:         entry.ID,
-				FacilityID: entry.FacilityID,
-				Status:     waitlistStatusNotified,
-			}); err != nil {
-				return err
-			}
-			if _, err := q.CreateWaitlistOffer(ctx, dbgen.CreateWaitlistOfferParams{
-				WaitlistID: entry.ID,
-				ExpiresAt:  expiresAt,
-				Status:     waitlistOfferStatusPending,
-			}); err != nil {
-				return err
-			}
-		}
-		return nil
+// facilityLocation is the facility's time zone, or the default zone when
+// the facility cannot be loaded.
+func (h *Handlers) facilityLocation(ctx context.Context, facilityID int64) *time.Location {
+	if facility, err := h.loadFacilities().GetFacilityByID(ctx, facilityID); err == nil {
+		return apiutil.FacilityLocation(facility, log.Ctx(ctx))
 	}
+	return apiutil.DefaultLocation()
 }
 
 func parseCourtIDs(values []string) ([]int64, error) {
@@ -1584,14 +1305,6 @@ func parseOptionalPointer(value, name string) (*int64, error) {
 	return &parsed, nil
 }
 
-func hoursUntilReservationStart(start time.Time, now time.Time) int64 {
-	hours := int64(start.Sub(now).Hours())
-	if hours < 0 {
-		return 0
-	}
-	return hours
-}
-
 func facilityIDFromRequest(r *http.Request) (int64, error) {
 	value := strings.TrimSpace(r.URL.Query().Get("facility_id"))
 	if value == "" {
@@ -1631,10 +1344,28 @@ func facilityExists(ctx context.Context, q *dbgen.Queries, facilityID int64) (bo
 	return count > 0, nil
 }
 
-func loadQueries() *dbgen.Queries {
-	return queries
+func (h *Handlers) loadQueries() *dbgen.Queries {
+	return h.queries
+}
+
+// loadFacilities returns the facility cache shared with the member portal,
+// or the plain queries when the store has none.
+func (h *Handlers) loadFacilities() apiutil.FacilityQuerier {
+	if h.store == nil || h.store.Facilities == nil {
+		return h.queries
+	}
+	return h.store.Facilities
 }
 
-func loadDB() *appdb.DB {
-	return store
+func (h *Handlers) loadDB() *appdb.DB {
+	return h.store
+}
+
+// loadService returns a reservation service over the handlers' store, or nil
+// when they have no database.
+func (h *Handlers) loadService() *reservationsvc.Service {
+	if h.store == nil {
+		return nil
+	}
+	return reservationsvc.NewService(h.store, h.emailClient, h.notifiers...)
 }
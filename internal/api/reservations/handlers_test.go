@@ -0,0 +1,134 @@
+package reservations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+)
+
+func TestValidateReservationInput_OpenEventNeedsTeamSizes(t *testing.T) {
+	start := time.Date(2026, time.October, 20, 18, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	two := int64(2)
+	ruleID := int64(7)
+	base := reservationRequest{FacilityID: 1, ReservationTypeID: 1, CourtIDs: []int64{1}, IsOpenEvent: true}
+
+	for _, tc := range []struct {
+		name  string
+		edit  func(*reservationRequest)
+		field string
+	}{
+		{"missing both", func(*reservationRequest) {}, "teams_per_court"},
+		{"missing people", func(req *reservationRequest) { req.TeamsPerCourt = &two }, "people_per_team"},
+		{"complete", func(req *reservationRequest) { req.TeamsPerCourt, req.PeoplePerTeam = &two, &two }, ""},
+		{"open play", func(req *reservationRequest) { req.OpenPlayRuleID = &ruleID }, ""},
+		{"closed event", func(req *reservationRequest) { req.IsOpenEvent = false }, ""},
+	} {
+		req := base
+		tc.edit(&req)
+		err := validateReservationInput(req, start, end, apiutil.ReservationDuration{Min: time.Hour})
+		var fieldErr apiutil.FieldError
+		switch {
+		case tc.field == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		case tc.field != "" && (!errors.As(err, &fieldErr) || fieldErr.Field != tc.field):
+			t.Errorf("%s: expected a %s error, got %v", tc.name, tc.field, err)
+		}
+	}
+}
+
+func TestParseReservationTimes_UsesFacilityLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("load location: %v", err)
+	}
+	start, end, err := parseReservationTimes("2026-07-01T18:00", "2026-07-01T19:00:00Z", loc)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if want := time.Date(2026, time.July, 1, 22, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("expected a facility-local start of %s, got %s", want, start.UTC())
+	}
+	if want := time.Date(2026, time.July, 1, 19, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("expected the RFC 3339 offset to be kept, got %s", end.UTC())
+	}
+}
+
+func TestHandleReservationCreate_StaffMemberOverlapEnvelope(t *testing.T) {
+	fixture := setupSyncTest(t)
+	var courtIDs [2]int64
+	for i := range courtIDs {
+		result, err := fixture.database.Exec(
+			"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, ?, ?, 'active')",
+			fixture.facilityID, fmt.Sprintf("Court %d", i+1), i+1,
+		)
+		if err != nil {
+			t.Fatalf("insert court: %v", err)
+		}
+		courtIDs[i], _ = result.LastInsertId()
+	}
+
+	start := time.Now().UTC().AddDate(0, 0, 3).Truncate(24 * time.Hour).Add(10 * time.Hour)
+	existingID := fixture.insertReservation(t, start)
+	if _, err := fixture.database.Exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", existingID, courtIDs[0]); err != nil {
+		t.Fatalf("insert reservation court: %v", err)
+	}
+
+	var typeID int64
+	if err := fixture.database.QueryRow("SELECT id FROM reservation_types ORDER BY id LIMIT 1").Scan(&typeID); err != nil {
+		t.Fatalf("load reservation type: %v", err)
+	}
+	create := func(extra string, htmx bool) *httptest.ResponseRecorder {
+		t.Helper()
+		body := fmt.Sprintf(`{"facility_id":%d,"reservation_type_id":%d,"primary_user_id":%d,"court_ids":[%d],"start_time":%q,"end_time":%q%s}`,
+			fixture.facilityID, typeID, fixture.userID, courtIDs[1],
+			start.Format(time.RFC3339), start.Add(time.Hour).Format(time.RFC3339), extra)
+		req := fixture.staffRequest(http.MethodPost, "/api/v1/reservations", body)
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleReservationCreate(recorder, req)
+		return recorder
+	}
+
+	recorder := create("", false)
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var envelope struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Details struct {
+				Conflict struct {
+					ReservationID int64 `json:"reservation_id"`
+				} `json:"conflict"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("decode error envelope: %v (%q)", err, recorder.Body.String())
+	}
+	if envelope.Error.Code != apiutil.ErrorCodeMemberOverlap || envelope.Error.Details.Conflict.ReservationID != existingID {
+		t.Fatalf("unexpected error envelope: %s", recorder.Body.String())
+	}
+
+	// The staff booking form shows a sentence instead.
+	recorder = create("", true)
+	if recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), `Check "Allow overlap"`) {
+		t.Fatalf("expected plain HTMX overlap error, got %d %q", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = create(`,"allow_member_overlap":true`, false)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected staff override to book, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,514 @@
+// internal/api/reservations/import.go
+package reservations
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+const (
+	// maxImportBytes bounds an uploaded CSV; it is read whole before any
+	// row is processed so the request's read deadline cannot cut it off.
+	maxImportBytes = 10 << 20
+	// importRowWriteTimeout is how long each row may take before its result
+	// is written. The write deadline moves forward row by row, so large files
+	// stream to completion instead of hitting the server's write timeout.
+	importRowWriteTimeout = 15 * time.Second
+
+	importStatusCreated = "created"
+	importStatusValid   = "valid"
+	importStatusSkipped = "skipped"
+	importStatusError   = "error"
+)
+
+// importColumns are the CSV header names, matched case-insensitively.
+// participants is optional.
+var importColumns = []string{"member_email", "court", "type", "start", "end", "participants"}
+
+// importFieldColumns names the CSV column behind each reservation field
+// validateReservationInput reports on.
+var importFieldColumns = map[string]string{
+	"court_ids":       "court",
+	"participant_ids": "participants",
+	"start_time":      "start",
+	"end_time":        "end",
+}
+
+type importRowResult struct {
+	Row           int    `json:"row"`
+	Status        string `json:"status"`
+	ReservationID int64  `json:"reservation_id,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+type importSummary struct {
+	DryRun  bool `json:"dry_run"`
+	Rows    int  `json:"rows"`
+	Created int  `json:"created"`
+	Valid   int  `json:"valid"`
+	Skipped int  `json:"skipped"`
+	Errors  int  `json:"errors"`
+}
+
+// importRow is a CSV row resolved against the facility.
+type importRow struct {
+	line           int
+	primaryUserID  int64
+	typeID         int64
+	courtIDs       []int64
+	participantIDs []int64
+	startTime      time.Time
+	endTime        time.Time
+}
+
+// importer resolves rows against one facility, caching the lookups a large
+// file repeats.
+type importer struct {
+	q          *dbgen.Queries
+	service    *reservationsvc.Service
+	facilityID int64
+	userID     int64
+	loc        *time.Location
+	dryRun     bool
+	courts     map[string]int64
+	types      map[string]int64
+	members    map[string]int64
+	// durations are each type's length bounds at the facility, by type ID.
+	durations map[int64]apiutil.ReservationDuration
+	// accepted are the rows a dry run has passed, standing in for the
+	// reservations a real import would have created by then.
+	accepted []importRow
+}
+
+// POST /api/v1/facilities/{id}/reservations/import
+func (h *Handlers) HandleReservationImport(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	service := h.loadService()
+	if q == nil || service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	facilityID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || facilityID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid facility ID")
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	dryRun := apiutil.ParseBool(r.URL.Query().Get("dry_run"))
+
+	upload, err := readImportUpload(w, r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	reader := csv.NewReader(bytes.NewReader(upload))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "CSV must start with a header row")
+		return
+	}
+	columns, err := importColumnIndexes(header)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
+	imp, err := h.newImporter(ctx, q, service, facilityID, user.ID, dryRun)
+	cancel()
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to prepare reservation import")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to prepare reservation import")
+		return
+	}
+
+	// Results stream as one JSON object per line, ending with the summary.
+	controller := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+
+	summary := importSummary{DryRun: dryRun}
+	for {
+		_ = controller.SetWriteDeadline(time.Now().Add(importRowWriteTimeout))
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		var result importRowResult
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			result = importRowResult{Row: parseErr.StartLine, Status: importStatusError, Error: parseErr.Err.Error()}
+		case err != nil:
+			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to read reservation import")
+			return
+		default:
+			result = imp.importRecord(r.Context(), logger, line, record, columns)
+		}
+
+		summary.Rows++
+		switch result.Status {
+		case importStatusCreated:
+			summary.Created++
+		case importStatusValid:
+			summary.Valid++
+		case importStatusSkipped:
+			summary.Skipped++
+		default:
+			summary.Errors++
+		}
+		if err := encoder.Encode(result); err != nil {
+			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write reservation import result")
+			return
+		}
+		_ = controller.Flush()
+	}
+
+	if err := encoder.Encode(map[string]importSummary{"summary": summary}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write reservation import summary")
+		return
+	}
+	_ = controller.Flush()
+	if summary.Created > 0 {
+		logger.Info().Int64("facility_id", facilityID).Int("created", summary.Created).Int("skipped", summary.Skipped).Int("errors", summary.Errors).Msg("Imported reservations")
+	}
+}
+
+// readImportUpload returns the CSV sent either as the "file" field of a
+// multipart form or as the request body.
+func readImportUpload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+	var source io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(maxImportBytes); err != nil {
+			return nil, fmt.Errorf("invalid upload: %w", err)
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			return nil, fmt.Errorf("file is required")
+		}
+		defer file.Close()
+		source = file
+	}
+	upload, err := io.ReadAll(source)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return nil, fmt.Errorf("CSV must be at most %d MB", maxImportBytes>>20)
+		}
+		return nil, fmt.Errorf("failed to read upload")
+	}
+	if len(bytes.TrimSpace(upload)) == 0 {
+		return nil, fmt.Errorf("CSV is empty")
+	}
+	return upload, nil
+}
+
+// importColumnIndexes maps each of importColumns to its position in header,
+// or -1 for a missing optional column.
+func importColumnIndexes(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(importColumns))
+	for _, name := range importColumns {
+		columns[name] = -1
+	}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; ok {
+			columns[name] = i
+		}
+	}
+	for _, name := range importColumns {
+		if columns[name] < 0 && name != "participants" {
+			return nil, fmt.Errorf("CSV header is missing the %s column", name)
+		}
+	}
+	return columns, nil
+}
+
+func (h *Handlers) newImporter(ctx context.Context, q *dbgen.Queries, service *reservationsvc.Service, facilityID, userID int64, dryRun bool) (*importer, error) {
+	facility, err := h.loadFacilities().GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Facility not found", Err: err}
+		}
+		return nil, err
+	}
+	courts, err := q.ListCourts(ctx, facilityID)
+	if err != nil {
+		return nil, err
+	}
+	types, err := q.ListReservationTypesForFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &importer{
+		q:          q,
+		service:    service,
+		facilityID: facilityID,
+		userID:     userID,
+		loc:        apiutil.FacilityLocation(facility, log.Ctx(ctx)),
+		dryRun:     dryRun,
+		courts:     make(map[string]int64, len(courts)),
+		types:      make(map[string]int64, len(types)),
+		members:    make(map[string]int64),
+		durations:  make(map[int64]apiutil.ReservationDuration, len(types)),
+	}
+	// Courts are found by name or by their default "Court N" label.
+	for _, court := range courts {
+		imp.courts[fmt.Sprintf("court %d", court.CourtNumber)] = court.ID
+		if name := strings.ToLower(strings.TrimSpace(court.Name)); name != "" {
+			imp.courts[name] = court.ID
+		}
+	}
+	for _, reservationType := range types {
+		imp.types[strings.ToLower(strings.TrimSpace(reservationType.Name))] = reservationType.ID
+		imp.durations[reservationType.ID] = apiutil.ReservationTypeDuration(&facility, &reservationType)
+	}
+	return imp, nil
+}
+
+// importRecord validates one CSV row and, unless this is a dry run, books it
+// in its own transaction.
+func (imp *importer) importRecord(ctx context.Context, logger *zerolog.Logger, line int, record []string, columns map[string]int) importRowResult {
+	ctx, cancel := context.WithTimeout(ctx, reservationQueryTimeout)
+	defer cancel()
+
+	result := importRowResult{Row: line, Status: importStatusError}
+	row, err := imp.resolve(ctx, line, record, columns)
+	if err != nil {
+		result.Error = imp.rowError(logger, line, err)
+		return result
+	}
+
+	existingID, err := imp.q.FindMatchingReservation(ctx, dbgen.FindMatchingReservationParams{
+		FacilityID:        imp.facilityID,
+		ReservationTypeID: row.typeID,
+		PrimaryUserID:     sql.NullInt64{Int64: row.primaryUserID, Valid: true},
+		StartTime:         row.startTime,
+		EndTime:           row.endTime,
+		CourtID:           row.courtIDs[0],
+	})
+	switch {
+	case err == nil:
+		return importRowResult{Row: line, Status: importStatusSkipped, ReservationID: existingID}
+	case !errors.Is(err, sql.ErrNoRows):
+		result.Error = imp.rowError(logger, line, err)
+		return result
+	}
+
+	if imp.dryRun {
+		if err := imp.checkDryRun(ctx, row); err != nil {
+			if errors.Is(err, errImportDuplicate) {
+				return importRowResult{Row: line, Status: importStatusSkipped}
+			}
+			result.Error = imp.rowError(logger, line, err)
+			return result
+		}
+		imp.accepted = append(imp.accepted, row)
+		return importRowResult{Row: line, Status: importStatusValid}
+	}
+
+	primaryUserID := row.primaryUserID
+	created, err := imp.service.CreateReservation(ctx, reservationsvc.CreateInput{
+		Details: reservationsvc.Details{
+			ReservationTypeID: row.typeID,
+			PrimaryUserID:     &primaryUserID,
+			StartTime:         row.startTime,
+			EndTime:           row.endTime,
+			CourtIDs:          row.courtIDs,
+		},
+		FacilityID:      imp.facilityID,
+		CreatedByUserID: imp.userID,
+		ParticipantIDs:  row.participantIDs,
+	})
+	if err != nil {
+		result.Error = imp.rowError(logger, line, err)
+		return result
+	}
+	return importRowResult{Row: line, Status: importStatusCreated, ReservationID: created.ID}
+}
+
+// errImportDuplicate marks a dry-run row that repeats an earlier one.
+var errImportDuplicate = errors.New("duplicate import row")
+
+// checkDryRun checks a row's courts against existing bookings and the rows
+// already accepted, which a real import would have booked by now.
+func (imp *importer) checkDryRun(ctx context.Context, row importRow) error {
+	for _, earlier := range imp.accepted {
+		if !earlier.startTime.Before(row.endTime) || !earlier.endTime.After(row.startTime) {
+			continue
+		}
+		if earlier.primaryUserID == row.primaryUserID && earlier.typeID == row.typeID &&
+			earlier.startTime.Equal(row.startTime) && earlier.endTime.Equal(row.endTime) &&
+			earlier.courtIDs[0] == row.courtIDs[0] {
+			return errImportDuplicate
+		}
+		for _, courtID := range row.courtIDs {
+			for _, earlierCourtID := range earlier.courtIDs {
+				if courtID == earlierCourtID {
+					return apiutil.FieldError{Field: "court", Reason: fmt.Sprintf("overlaps row %d", earlier.line)}
+				}
+			}
+		}
+	}
+	if err := apiutil.EnsureCourtsAvailable(ctx, imp.q, imp.facilityID, 0, row.startTime, row.endTime, row.courtIDs); err != nil {
+		var availErr apiutil.AvailabilityError
+		if errors.As(err, &availErr) {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: err.Error(), Err: err}
+		}
+		return err
+	}
+	return nil
+}
+
+// resolve looks up a row's member, courts, type, and participants. Courts
+// and participants may list several values separated by semicolons; times
+// are facility-local.
+func (imp *importer) resolve(ctx context.Context, line int, record []string, columns map[string]int) (importRow, error) {
+	field := func(name string) string {
+		i := columns[name]
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row := importRow{line: line}
+	email := field("member_email")
+	if email == "" {
+		return importRow{}, apiutil.FieldError{Field: "member_email", Reason: "is required"}
+	}
+	var err error
+	row.primaryUserID, err = imp.memberID(ctx, email, "member_email")
+	if err != nil {
+		return importRow{}, err
+	}
+
+	typeName := field("type")
+	typeID, ok := imp.types[strings.ToLower(typeName)]
+	if !ok {
+		return importRow{}, apiutil.FieldError{Field: "type", Reason: fmt.Sprintf("%q is not a reservation type at this facility", typeName)}
+	}
+	row.typeID = typeID
+
+	for _, name := range splitImportList(field("court")) {
+		courtID, ok := imp.courts[strings.ToLower(name)]
+		if !ok {
+			return importRow{}, apiutil.FieldError{Field: "court", Reason: fmt.Sprintf("%q is not a court at this facility", name)}
+		}
+		row.courtIDs = append(row.courtIDs, courtID)
+	}
+	for _, email := range splitImportList(field("participants")) {
+		participantID, err := imp.memberID(ctx, email, "participants")
+		if err != nil {
+			return importRow{}, err
+		}
+		row.participantIDs = append(row.participantIDs, participantID)
+	}
+
+	if row.startTime, err = apiutil.ParseFlexibleTime(field("start"), "start", imp.loc); err != nil {
+		return importRow{}, err
+	}
+	if row.endTime, err = apiutil.ParseFlexibleTime(field("end"), "end", imp.loc); err != nil {
+		return importRow{}, err
+	}
+
+	if err := validateReservationInput(reservationRequest{
+		FacilityID:        imp.facilityID,
+		ReservationTypeID: row.typeID,
+		CourtIDs:          row.courtIDs,
+		ParticipantIDs:    row.participantIDs,
+	}, row.startTime, row.endTime, imp.durations[row.typeID]); err != nil {
+		var fieldErr apiutil.FieldError
+		if errors.As(err, &fieldErr) {
+			// Name the CSV column rather than the API field.
+			if column, ok := importFieldColumns[fieldErr.Field]; ok {
+				fieldErr.Field = column
+			}
+			return importRow{}, fieldErr
+		}
+		return importRow{}, err
+	}
+	return row, nil
+}
+
+// memberID finds an active member by email.
+func (imp *importer) memberID(ctx context.Context, email, column string) (int64, error) {
+	key := strings.ToLower(email)
+	if id, ok := imp.members[key]; ok {
+		return id, nil
+	}
+	member, err := imp.q.GetMemberByEmail(ctx, sql.NullString{String: email, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, apiutil.FieldError{Field: column, Reason: fmt.Sprintf("no member with email %q", email)}
+		}
+		return 0, err
+	}
+	imp.members[key] = member.ID
+	return member.ID, nil
+}
+
+// rowError is the message reported for a failed row. Unexpected errors are
+// logged and reported generically.
+func (imp *importer) rowError(logger *zerolog.Logger, line int, err error) string {
+	var fieldErr apiutil.FieldError
+	if errors.As(err, &fieldErr) {
+		return fmt.Sprintf("%s %s", fieldErr.Field, fieldErr.Reason)
+	}
+	var herr apiutil.HandlerError
+	if errors.As(err, &herr) && herr.Status != http.StatusInternalServerError {
+		return herr.Message
+	}
+	logger.Error().Err(err).Int64("facility_id", imp.facilityID).Int("row", line).Msg("Failed to import reservation row")
+	return "Failed to import reservation"
+}
+
+func splitImportList(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, ";") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
@@ -0,0 +1,158 @@
+package reservations
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+)
+
+func (f syncFixture) importCSV(t *testing.T, query, body string) ([]importRowResult, importSummary) {
+	t.Helper()
+	target := fmt.Sprintf("/api/v1/facilities/%d/reservations/import%s", f.facilityID, query)
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	req.SetPathValue("id", fmt.Sprintf("%d", f.facilityID))
+	homeFacilityID := f.facilityID
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             f.userID,
+		IsStaff:        true,
+		HomeFacilityID: &homeFacilityID,
+	}))
+	recorder := httptest.NewRecorder()
+	f.h.HandleReservationImport(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("import status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var results []importRowResult
+	var summary importSummary
+	scanner := bufio.NewScanner(recorder.Body)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), `{"summary"`) {
+			var line struct {
+				Summary importSummary `json:"summary"`
+			}
+			if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+				t.Fatalf("decode summary: %v", err)
+			}
+			summary = line.Summary
+			continue
+		}
+		var result importRowResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			t.Fatalf("decode row result %q: %v", scanner.Text(), err)
+		}
+		results = append(results, result)
+	}
+	return results, summary
+}
+
+func TestHandleReservationImport(t *testing.T) {
+	fixture := setupSyncTest(t)
+	if _, err := fixture.database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Center Court', 1, 'active'), (?, '', 2, 'active')",
+		fixture.facilityID, fixture.facilityID,
+	); err != nil {
+		t.Fatalf("insert courts: %v", err)
+	}
+	if _, err := fixture.database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, home_facility_id)
+		 VALUES ('Pat', 'Member', 'pat@test.com', 'active', 1, ?), ('Sam', 'Member', 'sam@test.com', 'active', 1, ?)`,
+		fixture.facilityID, fixture.facilityID,
+	); err != nil {
+		t.Fatalf("insert members: %v", err)
+	}
+
+	day := time.Now().UTC().AddDate(0, 0, 3).Format(time.DateOnly)
+	csvBody := strings.Join([]string{
+		"Member_Email,Court,Type,Start,End,Participants",
+		fmt.Sprintf("pat@test.com,Center Court,game,%[1]s 10:00,%[1]s 11:00,sam@test.com", day),
+		fmt.Sprintf("pat@test.com,center court,GAME,%[1]s 10:00,%[1]s 11:00,", day),
+		fmt.Sprintf("sam@test.com,Center Court,GAME,%[1]s 10:30,%[1]s 11:30,", day),
+		fmt.Sprintf("nobody@test.com,Court 2,GAME,%[1]s 10:00,%[1]s 11:00,", day),
+		fmt.Sprintf("sam@test.com,Court 9,GAME,%[1]s 10:00,%[1]s 11:00,", day),
+		fmt.Sprintf("sam@test.com,Court 2,GAME,%[1]s 12:00,%[1]s 11:00,", day),
+		fmt.Sprintf("sam@test.com;pat@test.com,Court 2,GAME,%[1]s 10:00,%[1]s 11:00,", day),
+	}, "\n")
+	statuses := func(results []importRowResult) string {
+		parts := make([]string, 0, len(results))
+		for _, result := range results {
+			parts = append(parts, fmt.Sprintf("%d:%s", result.Row, result.Status))
+		}
+		return strings.Join(parts, " ")
+	}
+	countReservations := func() int {
+		var count int
+		if err := fixture.database.QueryRow("SELECT COUNT(*) FROM reservations WHERE facility_id = ?", fixture.facilityID).Scan(&count); err != nil {
+			t.Fatalf("count reservations: %v", err)
+		}
+		return count
+	}
+
+	results, summary := fixture.importCSV(t, "?dry_run=true", csvBody)
+	if got, want := statuses(results), "2:valid 3:skipped 4:error 5:error 6:error 7:error 8:error"; got != want {
+		t.Fatalf("dry run: expected %q, got %q (%+v)", want, got, results)
+	}
+	if !strings.Contains(results[2].Error, "overlaps row 2") || !strings.Contains(results[3].Error, "nobody@test.com") || !strings.Contains(results[5].Error, "end must be after start") {
+		t.Fatalf("unexpected dry run errors: %+v", results)
+	}
+	if !summary.DryRun || summary.Valid != 1 || summary.Skipped != 1 || summary.Errors != 5 {
+		t.Fatalf("unexpected dry run summary: %+v", summary)
+	}
+	if count := countReservations(); count != 0 {
+		t.Fatalf("expected a dry run to book nothing, found %d", count)
+	}
+
+	results, summary = fixture.importCSV(t, "", csvBody)
+	if got, want := statuses(results), "2:created 3:skipped 4:error 5:error 6:error 7:error 8:error"; got != want {
+		t.Fatalf("import: expected %q, got %q (%+v)", want, got, results)
+	}
+	if results[1].ReservationID != results[0].ReservationID {
+		t.Fatalf("expected the repeat to point at the created reservation, got %+v", results)
+	}
+	if summary.Created != 1 || summary.Rows != 7 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	var participants int
+	if err := fixture.database.QueryRow("SELECT COUNT(*) FROM reservation_participants WHERE reservation_id = ?", results[0].ReservationID).Scan(&participants); err != nil {
+		t.Fatalf("count participants: %v", err)
+	}
+	if participants != 1 {
+		t.Fatalf("expected the participant to be imported, got %d", participants)
+	}
+
+	// Re-running the file after a partial import only skips what exists.
+	results, _ = fixture.importCSV(t, "", csvBody)
+	if results[0].Status != "skipped" {
+		t.Fatalf("expected re-import to skip the booked row, got %+v", results[0])
+	}
+	if count := countReservations(); count != 1 {
+		t.Fatalf("expected one reservation, found %d", count)
+	}
+}
+
+func TestHandleReservationImport_RejectsMissingColumns(t *testing.T) {
+	fixture := setupSyncTest(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader("member_email,court,start,end\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	req.SetPathValue("id", fmt.Sprintf("%d", fixture.facilityID))
+	homeFacilityID := fixture.facilityID
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             fixture.userID,
+		IsStaff:        true,
+		HomeFacilityID: &homeFacilityID,
+	}))
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleReservationImport(recorder, req)
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), "type column") {
+		t.Fatalf("expected a missing column to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
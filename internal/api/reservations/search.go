@@ -0,0 +1,291 @@
+// internal/api/reservations/search.go
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/request"
+	navtempl "github.com/codr1/Pickleicious/internal/templates/components/nav"
+)
+
+const (
+	reservationSearchDefaultLimit = 20
+	reservationSearchMaxLimit     = 100
+	// reservationSearchTypeaheadLimit caps the navbar dropdown.
+	reservationSearchTypeaheadLimit = 8
+)
+
+type reservationSearchResult struct {
+	ID                  int64     `json:"id"`
+	FacilityID          int64     `json:"facilityId"`
+	StartTime           time.Time `json:"startTime"`
+	EndTime             time.Time `json:"endTime"`
+	ReservationTypeName string    `json:"reservationTypeName"`
+	PrimaryUserID       *int64    `json:"primaryUserId,omitempty"`
+	PrimaryName         string    `json:"primaryName,omitempty"`
+	Courts              string    `json:"courts"`
+	Participants        string    `json:"participants"`
+	Cancelled           bool      `json:"cancelled"`
+}
+
+type reservationSearchResponse struct {
+	Reservations []reservationSearchResult `json:"reservations"`
+	Limit        int64                     `json:"limit"`
+	Offset       int64                     `json:"offset"`
+	HasMore      bool                      `json:"hasMore"`
+}
+
+// reservationSearchFilter is a parsed search request.
+type reservationSearchFilter struct {
+	Term             string
+	From             *time.Time
+	To               *time.Time
+	ReservationType  string
+	IncludeCancelled bool
+	Limit            int64
+	Offset           int64
+}
+
+// GET /api/v1/reservations/search?facility_id=&q=&from=&to=&type=
+//
+// Finds reservations at the facility whose member or participant name or
+// email, court label, or ID matches q, soonest first. from and to are
+// facility-local dates, to inclusive; with neither, the search starts today.
+// Cancelled reservations are left out unless include_cancelled=true. HTMX
+// gets the staff navbar typeahead, which falls back to the facility in the
+// page URL or the staff member's home facility.
+func (h *Handlers) HandleReservationSearch(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	isHTMX := apiutil.IsHTMXRequest(r)
+	facilityID, err := facilityIDFromRequest(r)
+	if err != nil && isHTMX {
+		var ok bool
+		facilityID, ok = reservationSearchFallbackFacility(r)
+		if ok {
+			err = nil
+		}
+	}
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Facility ID is required")
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	query := r.URL.Query()
+	if isHTMX && strings.TrimSpace(query.Get("q")) == "" {
+		apiutil.RenderHTMLComponent(r.Context(), w, navtempl.ReservationSearchResults("", nil), nil, "Failed to render reservation search", "Failed to render reservation search")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
+	defer cancel()
+
+	facility, err := q.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Facility not found")
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility")
+		return
+	}
+	loc := apiutil.FacilityLocation(facility, logger)
+
+	filter, err := parseReservationSearchFilter(query, loc, time.Now())
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if isHTMX {
+		filter.Limit = reservationSearchTypeaheadLimit
+		filter.Offset = 0
+	}
+
+	params := dbgen.SearchReservationsParams{
+		FacilityID:       facilityID,
+		IncludeCancelled: filter.IncludeCancelled,
+		// One extra row tells us whether another page follows.
+		Limit:  filter.Limit + 1,
+		Offset: filter.Offset,
+	}
+	if filter.Term != "" {
+		params.SearchTerm = sql.NullString{String: filter.Term, Valid: true}
+	}
+	if filter.From != nil {
+		params.StartFrom = filter.From.UTC()
+	}
+	if filter.To != nil {
+		params.StartBefore = filter.To.UTC()
+	}
+	if filter.ReservationType != "" {
+		params.ReservationType = filter.ReservationType
+	}
+
+	rows, err := q.SearchReservations(ctx, params)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to search reservations")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to search reservations")
+		return
+	}
+	hasMore := int64(len(rows)) > filter.Limit
+	if hasMore {
+		rows = rows[:filter.Limit]
+	}
+
+	if isHTMX {
+		results := make([]navtempl.ReservationSearchResult, 0, len(rows))
+		for _, row := range rows {
+			results = append(results, newReservationSearchTypeaheadResult(row, loc))
+		}
+		component := navtempl.ReservationSearchResults(filter.Term, results)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render reservation search", "Failed to render reservation search")
+		return
+	}
+
+	response := reservationSearchResponse{
+		Reservations: make([]reservationSearchResult, 0, len(rows)),
+		Limit:        filter.Limit,
+		Offset:       filter.Offset,
+		HasMore:      hasMore,
+	}
+	for _, row := range rows {
+		response.Reservations = append(response.Reservations, newReservationSearchResult(row))
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write reservation search response")
+	}
+}
+
+// parseReservationSearchFilter reads the search query. A leading "#" on q is
+// dropped so "#42" finds reservation 42.
+func parseReservationSearchFilter(query url.Values, loc *time.Location, now time.Time) (reservationSearchFilter, error) {
+	filter := reservationSearchFilter{
+		Term:             strings.ToLower(strings.TrimPrefix(strings.TrimSpace(query.Get("q")), "#")),
+		ReservationType:  strings.ToUpper(strings.TrimSpace(query.Get("type"))),
+		IncludeCancelled: apiutil.ParseBool(query.Get("include_cancelled")),
+		Limit:            reservationSearchDefaultLimit,
+	}
+
+	fromRaw := strings.TrimSpace(query.Get("from"))
+	toRaw := strings.TrimSpace(query.Get("to"))
+	if fromRaw != "" {
+		from, err := time.ParseInLocation(time.DateOnly, fromRaw, loc)
+		if err != nil {
+			return reservationSearchFilter{}, apiutil.FieldError{Field: "from", Reason: "must be in YYYY-MM-DD format"}
+		}
+		filter.From = &from
+	}
+	if toRaw != "" {
+		to, err := time.ParseInLocation(time.DateOnly, toRaw, loc)
+		if err != nil {
+			return reservationSearchFilter{}, apiutil.FieldError{Field: "to", Reason: "must be in YYYY-MM-DD format"}
+		}
+		if filter.From != nil && to.Before(*filter.From) {
+			return reservationSearchFilter{}, apiutil.FieldError{Field: "to", Reason: "must not be before from"}
+		}
+		end := to.AddDate(0, 0, 1)
+		filter.To = &end
+	}
+	if fromRaw == "" && toRaw == "" {
+		local := now.In(loc)
+		today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		filter.From = &today
+	}
+
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		limit, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || limit <= 0 {
+			return reservationSearchFilter{}, apiutil.FieldError{Field: "limit", Reason: "must be a positive integer"}
+		}
+		filter.Limit = min(limit, reservationSearchMaxLimit)
+	}
+	if raw := strings.TrimSpace(query.Get("offset")); raw != "" {
+		offset, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || offset < 0 {
+			return reservationSearchFilter{}, apiutil.FieldError{Field: "offset", Reason: "must be a non-negative integer"}
+		}
+		filter.Offset = offset
+	}
+	return filter, nil
+}
+
+// reservationSearchFallbackFacility is the facility the typeahead searches
+// when the page did not send one: the page URL's, then the staff member's
+// home facility.
+func reservationSearchFallbackFacility(r *http.Request) (int64, bool) {
+	if currentURL := strings.TrimSpace(r.Header.Get("HX-Current-URL")); currentURL != "" {
+		if parsed, err := url.Parse(currentURL); err == nil {
+			if facilityID, ok := request.ParseFacilityID(parsed.Query().Get("facility_id")); ok {
+				return facilityID, true
+			}
+		}
+	}
+	if user := authz.UserFromContext(r.Context()); user != nil && user.HomeFacilityID != nil {
+		return *user.HomeFacilityID, true
+	}
+	return 0, false
+}
+
+func newReservationSearchResult(row dbgen.SearchReservationsRow) reservationSearchResult {
+	result := reservationSearchResult{
+		ID:                  row.ID,
+		FacilityID:          row.FacilityID,
+		StartTime:           row.StartTime,
+		EndTime:             row.EndTime,
+		ReservationTypeName: row.ReservationTypeName,
+		PrimaryName:         strings.TrimSpace(row.PrimaryFirstName + " " + row.PrimaryLastName),
+		Courts:              row.CourtLabels,
+		Participants:        row.ParticipantNames,
+		Cancelled:           row.Cancelled,
+	}
+	if row.PrimaryUserID.Valid {
+		primaryUserID := row.PrimaryUserID.Int64
+		result.PrimaryUserID = &primaryUserID
+	}
+	return result
+}
+
+func newReservationSearchTypeaheadResult(row dbgen.SearchReservationsRow, loc *time.Location) navtempl.ReservationSearchResult {
+	start := row.StartTime.In(loc)
+	end := row.EndTime.In(loc)
+	names := row.ParticipantNames
+	if primary := strings.TrimSpace(row.PrimaryFirstName + " " + row.PrimaryLastName); primary != "" && !strings.Contains(names, primary) {
+		if names == "" {
+			names = primary
+		} else {
+			names = primary + ", " + names
+		}
+	}
+	return navtempl.ReservationSearchResult{
+		ID:        row.ID,
+		When:      fmt.Sprintf("%s - %s", start.Format("Mon Jan 2, 3:04 PM"), end.Format("3:04 PM")),
+		TypeName:  row.ReservationTypeName,
+		Courts:    row.CourtLabels,
+		Names:     names,
+		Cancelled: row.Cancelled,
+	}
+}
@@ -0,0 +1,126 @@
+package reservations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func (f syncFixture) search(t *testing.T, params url.Values) reservationSearchResponse {
+	t.Helper()
+	params.Set("facility_id", fmt.Sprintf("%d", f.facilityID))
+	req := f.staffRequest(http.MethodGet, "/api/v1/reservations/search?"+params.Encode(), "")
+	recorder := httptest.NewRecorder()
+	f.h.HandleReservationSearch(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("search status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response reservationSearchResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode search: %v", err)
+	}
+	return response
+}
+
+func searchIDs(response reservationSearchResponse) []int64 {
+	ids := make([]int64, 0, len(response.Reservations))
+	for _, reservation := range response.Reservations {
+		ids = append(ids, reservation.ID)
+	}
+	return ids
+}
+
+func TestReservationSearch(t *testing.T) {
+	fixture := setupSyncTest(t)
+	courtResult, err := fixture.database.Exec(
+		"INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Center Court', 1, 'active')",
+		fixture.facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert court: %v", err)
+	}
+	courtID, _ := courtResult.LastInsertId()
+	memberResult, err := fixture.database.Exec(
+		"INSERT INTO users (first_name, last_name, email, status, is_member) VALUES ('Pat', 'Smith', 'pat@example.com', 'active', 1)",
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	memberID, _ := memberResult.LastInsertId()
+
+	tuesday := time.Now().UTC().AddDate(0, 0, 5).Truncate(24 * time.Hour).Add(18 * time.Hour)
+	later := fixture.insertReservation(t, tuesday.Add(2*time.Hour))
+	smith := fixture.insertReservation(t, tuesday)
+	cancelled := fixture.insertReservation(t, tuesday.AddDate(0, 0, 1))
+	past := fixture.insertReservation(t, tuesday.AddDate(0, 0, -30))
+	for _, id := range []int64{smith, cancelled, past} {
+		if _, err := fixture.database.Exec("INSERT INTO reservation_participants (reservation_id, user_id) VALUES (?, ?)", id, memberID); err != nil {
+			t.Fatalf("insert participant: %v", err)
+		}
+	}
+	if _, err := fixture.database.Exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", later, courtID); err != nil {
+		t.Fatalf("insert reservation court: %v", err)
+	}
+	if _, err := fixture.database.Exec(
+		`INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, hours_before_start)
+		 VALUES (?, ?, CURRENT_TIMESTAMP, 100, 48)`,
+		cancelled, fixture.userID,
+	); err != nil {
+		t.Fatalf("insert cancellation: %v", err)
+	}
+
+	response := fixture.search(t, url.Values{"q": {"smith"}})
+	if got := searchIDs(response); len(got) != 1 || got[0] != smith {
+		t.Fatalf("expected only the upcoming active Smith booking, got %v", got)
+	}
+	if response.Reservations[0].Participants != "Pat Smith" || response.Reservations[0].PrimaryName != "Staff User" {
+		t.Fatalf("unexpected names: %+v", response.Reservations[0])
+	}
+
+	day := tuesday.Format(time.DateOnly)
+	if got := searchIDs(fixture.search(t, url.Values{"q": {"PAT@EXAMPLE"}, "from": {day}, "include_cancelled": {"true"}})); len(got) != 2 || got[0] != smith || got[1] != cancelled {
+		t.Fatalf("expected the Smith bookings from %s with the cancelled one, got %v", day, got)
+	}
+	if got := searchIDs(fixture.search(t, url.Values{"q": {"smith"}, "from": {tuesday.AddDate(0, 0, -31).Format(time.DateOnly)}, "to": {day}})); len(got) != 2 || got[0] != past || got[1] != smith {
+		t.Fatalf("expected past and Tuesday bookings in range, got %v", got)
+	}
+
+	byCourt := fixture.search(t, url.Values{"q": {"center"}})
+	if got := searchIDs(byCourt); len(got) != 1 || got[0] != later || byCourt.Reservations[0].Courts != "Center Court" {
+		t.Fatalf("expected the Center Court booking, got %+v", byCourt.Reservations)
+	}
+	if got := searchIDs(fixture.search(t, url.Values{"q": {fmt.Sprintf("#%d", later)}})); len(got) != 1 || got[0] != later {
+		t.Fatalf("expected a reservation ID match, got %v", got)
+	}
+
+	page := fixture.search(t, url.Values{"limit": {"1"}})
+	if got := searchIDs(page); len(got) != 1 || got[0] != smith || !page.HasMore {
+		t.Fatalf("expected the first of several results, got %v (hasMore %t)", got, page.HasMore)
+	}
+	page = fixture.search(t, url.Values{"limit": {"1"}, "offset": {"1"}})
+	if got := searchIDs(page); len(got) != 1 || got[0] != later || page.HasMore {
+		t.Fatalf("expected the last result, got %v (hasMore %t)", got, page.HasMore)
+	}
+	if got := searchIDs(fixture.search(t, url.Values{"type": {"no_such_type"}})); len(got) != 0 {
+		t.Fatalf("expected no results for an unknown type, got %v", got)
+	}
+
+	// The navbar typeahead finds the facility from the staff member's home
+	// facility and renders the dropdown.
+	req := fixture.staffRequest(http.MethodGet, "/api/v1/reservations/search?q=smith", "")
+	req.Header.Set("HX-Request", "true")
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleReservationSearch(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("typeahead status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	body := recorder.Body.String()
+	if !strings.Contains(body, `id="reservation-search-results"`) || !strings.Contains(body, fmt.Sprintf("/api/v1/reservations/%d/edit", smith)) {
+		t.Fatalf("expected the typeahead dropdown, got %q", body)
+	}
+}
@@ -0,0 +1,113 @@
+// internal/api/reservations/sync.go
+package reservations
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/models"
+)
+
+const syncTokenHeader = "X-Sync-Token"
+
+type reservationSyncResponse struct {
+	Reservations []dbgen.Reservation    `json:"reservations"`
+	Tombstones   []models.SyncTombstone `json:"tombstones"`
+	SyncToken    string                 `json:"syncToken"`
+	HasMore      bool                   `json:"hasMore"`
+}
+
+// handleReservationsDelta serves GET /api/v1/reservations with sync_token or
+// updated_since. Each changed reservation appears once, either as its current
+// record or as a tombstone when it was cancelled or deleted.
+func (h *Handlers) handleReservationsDelta(w http.ResponseWriter, r *http.Request, facilityID int64, params models.SyncParams) {
+	logger := log.Ctx(r.Context())
+
+	database := h.loadDB()
+	if database == nil {
+		logger.Error().Msg("Database not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
+	defer cancel()
+
+	var page models.SyncPage
+	var rows []dbgen.ListReservationsForSyncRow
+	err := database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		var err error
+		page, err = models.ListSyncPage(ctx, txdb.Queries, facilityID, models.SyncEntityReservation, params)
+		if err != nil {
+			return err
+		}
+		if len(page.Changes) == 0 {
+			return nil
+		}
+		rows, err = txdb.Queries.ListReservationsForSync(ctx, dbgen.ListReservationsForSyncParams{
+			FacilityID: facilityID,
+			Ids:        page.EntityIDs(),
+		})
+		return err
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list reservation changes")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to list reservations")
+		return
+	}
+
+	byID := make(map[int64]dbgen.ListReservationsForSyncRow, len(rows))
+	for _, row := range rows {
+		byID[row.ID] = row
+	}
+
+	response := reservationSyncResponse{
+		Reservations: make([]dbgen.Reservation, 0, len(rows)),
+		Tombstones:   make([]models.SyncTombstone, 0),
+		SyncToken:    page.Token,
+		HasMore:      page.HasMore,
+	}
+	for _, change := range page.Changes {
+		row, ok := byID[change.EntityID]
+		if !ok || row.Cancelled != 0 {
+			reason := "deleted"
+			if ok {
+				reason = "cancelled"
+			}
+			response.Tombstones = append(response.Tombstones, models.SyncTombstone{
+				ID:        change.EntityID,
+				Reason:    reason,
+				DeletedAt: models.SyncTimestamp(change.LastChangedAt),
+			})
+			continue
+		}
+		response.Reservations = append(response.Reservations, dbgen.Reservation{
+			ID:                row.ID,
+			FacilityID:        row.FacilityID,
+			ReservationTypeID: row.ReservationTypeID,
+			RecurrenceRuleID:  row.RecurrenceRuleID,
+			PrimaryUserID:     row.PrimaryUserID,
+			CreatedByUserID:   row.CreatedByUserID,
+			ProID:             row.ProID,
+			OpenPlayRuleID:    row.OpenPlayRuleID,
+			StartTime:         row.StartTime,
+			EndTime:           row.EndTime,
+			IsOpenEvent:       row.IsOpenEvent,
+			TeamsPerCourt:     row.TeamsPerCourt,
+			PeoplePerTeam:     row.PeoplePerTeam,
+			CreatedAt:         row.CreatedAt,
+			UpdatedAt:         row.UpdatedAt,
+		})
+	}
+
+	w.Header().Set(syncTokenHeader, page.Token)
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write reservation sync response")
+		return
+	}
+}
@@ -0,0 +1,258 @@
+package reservations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type syncFixture struct {
+	h          *Handlers
+	database   *db.DB
+	facilityID int64
+	userID     int64
+}
+
+func setupSyncTest(t *testing.T) syncFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+
+	orgResult, err := database.ExecContext(ctx,
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)",
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, err := orgResult.LastInsertId()
+	if err != nil {
+		t.Fatalf("organization id: %v", err)
+	}
+
+	facilityResult, err := database.ExecContext(ctx,
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)",
+		orgID, "Main Facility", "main-facility", "UTC",
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, err := facilityResult.LastInsertId()
+	if err != nil {
+		t.Fatalf("facility id: %v", err)
+	}
+
+	userResult, err := database.ExecContext(ctx,
+		"INSERT INTO users (first_name, last_name, email, status) VALUES (?, ?, ?, ?)",
+		"Staff", "User", "staff@test.com", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	userID, err := userResult.LastInsertId()
+	if err != nil {
+		t.Fatalf("user id: %v", err)
+	}
+
+	return syncFixture{h: NewHandlers(database, nil), database: database, facilityID: facilityID, userID: userID}
+}
+
+func (f syncFixture) insertReservation(t *testing.T, start time.Time) int64 {
+	t.Helper()
+
+	result, err := f.database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types ORDER BY id LIMIT 1), ?, ?, ?, ?)`,
+		f.facilityID, f.userID, f.userID, start, start.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatalf("reservation id: %v", err)
+	}
+	return id
+}
+
+func (f syncFixture) request(params url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/reservations?"+params.Encode(), nil)
+	homeFacilityID := f.facilityID
+	req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             f.userID,
+		IsStaff:        true,
+		HomeFacilityID: &homeFacilityID,
+	}))
+	return req
+}
+
+func (f syncFixture) poll(t *testing.T, params url.Values) (reservationSyncResponse, http.Header) {
+	t.Helper()
+
+	params.Set("facility_id", fmt.Sprintf("%d", f.facilityID))
+	req := f.request(params)
+	recorder := httptest.NewRecorder()
+	f.h.HandleReservationsList(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response reservationSyncResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return response, recorder.Header()
+}
+
+func (f syncFixture) initialToken(t *testing.T) string {
+	t.Helper()
+
+	params := url.Values{}
+	params.Set("facility_id", fmt.Sprintf("%d", f.facilityID))
+	params.Set("start_time", "2026-01-01T00:00")
+	params.Set("end_time", "2027-01-01T00:00")
+	req := f.request(params)
+	recorder := httptest.NewRecorder()
+	f.h.HandleReservationsList(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("full list status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	token := recorder.Header().Get(syncTokenHeader)
+	if token == "" {
+		t.Fatal("expected sync token header on full list")
+	}
+	return token
+}
+
+func TestReservationsDeltaSync_UpdatedTwiceBetweenPolls(t *testing.T) {
+	fixture := setupSyncTest(t)
+
+	start := time.Date(2026, time.June, 1, 9, 0, 0, 0, time.UTC)
+	reservationID := fixture.insertReservation(t, start)
+	token := fixture.initialToken(t)
+
+	for _, hour := range []int{10, 11} {
+		moved := time.Date(2026, time.June, 1, hour, 0, 0, 0, time.UTC)
+		if _, err := fixture.database.Exec(
+			`UPDATE reservations SET start_time = ?, end_time = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+			moved, moved.Add(time.Hour), reservationID,
+		); err != nil {
+			t.Fatalf("update reservation: %v", err)
+		}
+	}
+
+	response, header := fixture.poll(t, url.Values{"sync_token": []string{token}})
+	if len(response.Reservations) != 1 {
+		t.Fatalf("expected one reservation after two updates, got %d", len(response.Reservations))
+	}
+	if got := response.Reservations[0]; got.ID != reservationID || got.StartTime.Hour() != 11 {
+		t.Fatalf("expected latest state for reservation %d, got id %d starting %s", reservationID, got.ID, got.StartTime)
+	}
+	if len(response.Tombstones) != 0 {
+		t.Fatalf("expected no tombstones, got %d", len(response.Tombstones))
+	}
+	if response.SyncToken == token || header.Get(syncTokenHeader) != response.SyncToken {
+		t.Fatalf("expected advanced sync token, got %q (header %q)", response.SyncToken, header.Get(syncTokenHeader))
+	}
+
+	next, _ := fixture.poll(t, url.Values{"sync_token": []string{response.SyncToken}})
+	if len(next.Reservations) != 0 || len(next.Tombstones) != 0 {
+		t.Fatalf("expected empty delta after catching up, got %d records and %d tombstones", len(next.Reservations), len(next.Tombstones))
+	}
+	if next.SyncToken != response.SyncToken {
+		t.Fatalf("expected token to stay at %q, got %q", response.SyncToken, next.SyncToken)
+	}
+}
+
+func TestReservationsDeltaSync_CancellationTombstoneOnce(t *testing.T) {
+	fixture := setupSyncTest(t)
+
+	start := time.Date(2026, time.June, 1, 9, 0, 0, 0, time.UTC)
+	reservationID := fixture.insertReservation(t, start)
+	token := fixture.initialToken(t)
+
+	if _, err := fixture.database.Exec(
+		`INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, fee_waived, hours_before_start)
+		 VALUES (?, ?, ?, 100, 0, 24)`,
+		reservationID, fixture.userID, time.Now().UTC(),
+	); err != nil {
+		t.Fatalf("insert cancellation: %v", err)
+	}
+	// Cancellation cleanup touches the reservation again after the tombstone is logged.
+	if _, err := fixture.database.Exec(`DELETE FROM reservation_participants WHERE reservation_id = ?`, reservationID); err != nil {
+		t.Fatalf("remove participants: %v", err)
+	}
+	if _, err := fixture.database.Exec(`UPDATE reservations SET updated_at = CURRENT_TIMESTAMP WHERE id = ?`, reservationID); err != nil {
+		t.Fatalf("touch reservation: %v", err)
+	}
+
+	response, _ := fixture.poll(t, url.Values{"sync_token": []string{token}})
+	if len(response.Reservations) != 0 {
+		t.Fatalf("expected cancelled reservation to be omitted, got %d records", len(response.Reservations))
+	}
+	if len(response.Tombstones) != 1 {
+		t.Fatalf("expected exactly one tombstone, got %d", len(response.Tombstones))
+	}
+	if tombstone := response.Tombstones[0]; tombstone.ID != reservationID || tombstone.Reason != "cancelled" || tombstone.DeletedAt == "" {
+		t.Fatalf("unexpected tombstone: %+v", tombstone)
+	}
+
+	next, _ := fixture.poll(t, url.Values{"sync_token": []string{response.SyncToken}})
+	if len(next.Tombstones) != 0 || len(next.Reservations) != 0 {
+		t.Fatalf("expected tombstone to be delivered once, got %d tombstones on the next poll", len(next.Tombstones))
+	}
+}
+
+func TestReservationsDeltaSync_UpdatedSince(t *testing.T) {
+	fixture := setupSyncTest(t)
+
+	start := time.Date(2026, time.June, 1, 9, 0, 0, 0, time.UTC)
+	fixture.insertReservation(t, start)
+	deletedID := fixture.insertReservation(t, start.Add(2*time.Hour))
+
+	if _, err := fixture.database.Exec(`DELETE FROM reservations WHERE id = ?`, deletedID); err != nil {
+		t.Fatalf("delete reservation: %v", err)
+	}
+
+	since := time.Now().UTC().Add(-time.Minute).Format(time.RFC3339)
+	response, _ := fixture.poll(t, url.Values{"updated_since": []string{since}})
+	if len(response.Reservations) != 1 {
+		t.Fatalf("expected one live reservation, got %d", len(response.Reservations))
+	}
+	if len(response.Tombstones) != 1 || response.Tombstones[0].ID != deletedID || response.Tombstones[0].Reason != "deleted" {
+		t.Fatalf("expected deleted tombstone for %d, got %+v", deletedID, response.Tombstones)
+	}
+
+	future := time.Now().UTC().Add(time.Minute).Format(time.RFC3339)
+	empty, _ := fixture.poll(t, url.Values{"updated_since": []string{future}})
+	if len(empty.Reservations) != 0 || len(empty.Tombstones) != 0 {
+		t.Fatalf("expected no changes after %s", future)
+	}
+	if empty.SyncToken == "" {
+		t.Fatal("expected sync token on timestamp-based poll")
+	}
+}
+
+func TestReservationsDeltaSync_InvalidToken(t *testing.T) {
+	fixture := setupSyncTest(t)
+
+	req := fixture.request(url.Values{
+		"facility_id": []string{fmt.Sprintf("%d", fixture.facilityID)},
+		"sync_token":  []string{"abc"},
+	})
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleReservationsList(recorder, req)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid token, got %d", recorder.Code)
+	}
+}
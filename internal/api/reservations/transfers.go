@@ -0,0 +1,93 @@
+// internal/api/reservations/transfers.go
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+type reservationTransferRequest struct {
+	UserID int64 `json:"userId"`
+}
+
+// POST /api/v1/reservations/{id}/transfer
+//
+// Hands a member booking to another member at once. Unlike the member
+// portal's transfer, the new holder is not asked to accept.
+func (h *Handlers) HandleReservationTransfer(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := h.loadQueries()
+	service := h.loadService()
+	if q == nil || service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	reservationID, err := apiutil.ReservationIDFromRequest(r)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid reservation ID")
+		return
+	}
+
+	var req reservationTransferRequest
+	if err := apiutil.DecodeJSON(r, &req); err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.UserID <= 0 {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "userId is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), reservationQueryTimeout)
+	defer cancel()
+
+	reservation, err := q.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Reservation not found")
+			return
+		}
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to fetch reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch reservation")
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, reservation.FacilityID) {
+		return
+	}
+
+	transferred, err := service.StaffTransfer(ctx, reservationsvc.StaffTransferInput{
+		ReservationID: reservationID,
+		FacilityID:    reservation.FacilityID,
+		ToUserID:      req.UserID,
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status == http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("reservation_id", reservationID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to transfer reservation")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to transfer reservation")
+		return
+	}
+
+	apiutil.AppendHXTrigger(w, "refreshCourtsCalendar")
+	if err := apiutil.WriteJSON(w, http.StatusOK, transferred); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to write reservation transfer response")
+		return
+	}
+}
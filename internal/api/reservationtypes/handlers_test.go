@@ -0,0 +1,245 @@
+package reservationtypes
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestReservationTypeAPI(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	queriesOnce = sync.Once{}
+	queries = nil
+	InitHandlers(database)
+	t.Cleanup(func() {
+		queriesOnce = sync.Once{}
+		queries = nil
+	})
+
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('PicklePlex', 'pickleplex', 'active')")
+	otherOrgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Other', 'other', 'active')")
+	northID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'North', 'north', 'UTC')", orgID)
+	southID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'South', 'south', 'UTC')", orgID)
+	elsewhereID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Elsewhere', 'elsewhere', 'UTC')", otherOrgID)
+
+	type staffUser struct {
+		id             int64
+		homeFacilityID *int64
+	}
+	insertStaff := func(email, role string, homeFacilityID *int64) staffUser {
+		t.Helper()
+		userID := exec("INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES ('Sam', 'Staff', ?, 'active', 1)", email)
+		var home any
+		if homeFacilityID != nil {
+			home = *homeFacilityID
+		}
+		exec("INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, 'Sam', 'Staff', ?, ?)", userID, home, role)
+		return staffUser{id: userID, homeFacilityID: homeFacilityID}
+	}
+	orgAdmin := insertStaff("corp@test.com", "admin", nil)
+	northManager := insertStaff("north@test.com", "manager", &northID)
+	southDesk := insertStaff("south@test.com", "desk", &southID)
+	elsewhereDesk := insertStaff("elsewhere@test.com", "desk", &elsewhereID)
+
+	request := func(method, target string, user staffUser, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		path := strings.SplitN(strings.TrimPrefix(target, "/api/v1/reservation-types"), "?", 2)[0]
+		id := strings.TrimPrefix(path, "/")
+		if id != "" {
+			req.SetPathValue("id", id)
+		}
+		req = req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{ID: user.id, IsStaff: true, HomeFacilityID: user.homeFacilityID}))
+		recorder := httptest.NewRecorder()
+		switch {
+		case id == "" && method == http.MethodPost:
+			HandleReservationTypeCreate(recorder, req)
+		case id == "":
+			HandleReservationTypesList(recorder, req)
+		case method == http.MethodPut:
+			HandleReservationTypeUpdate(recorder, req)
+		case method == http.MethodDelete:
+			HandleReservationTypeDelete(recorder, req)
+		default:
+			HandleReservationTypeGet(recorder, req)
+		}
+		return recorder
+	}
+	decode := func(recorder *httptest.ResponseRecorder) reservationTypeResponse {
+		t.Helper()
+		var resType reservationTypeResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resType); err != nil {
+			t.Fatalf("decode reservation type: %v (%s)", err, recorder.Body.String())
+		}
+		return resType
+	}
+	listNames := func(target string, user staffUser) map[string]bool {
+		t.Helper()
+		recorder := request(http.MethodGet, target, user, "")
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("list %s: %d %s", target, recorder.Code, recorder.Body.String())
+		}
+		var listing reservationTypesResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &listing); err != nil {
+			t.Fatalf("decode listing: %v", err)
+		}
+		names := make(map[string]bool, len(listing.ReservationTypes))
+		for _, resType := range listing.ReservationTypes {
+			names[resType.Name] = true
+		}
+		return names
+	}
+
+	// Facility staff create types for their own facility only.
+	if recorder := request(http.MethodPost, "/api/v1/reservation-types", southDesk,
+		fmt.Sprintf(`{"name": "ladder", "facilityId": %d}`, northID)); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected another facility's staff to be forbidden, got %d", recorder.Code)
+	}
+	recorder := request(http.MethodPost, "/api/v1/reservation-types", northManager,
+		fmt.Sprintf(`{"name": "ladder", "description": "Ladder play", "color": "#1976d2", "defaultDurationMinutes": 90, "memberBookable": true, "facilityId": %d}`, northID))
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("create facility type: %d %s", recorder.Code, recorder.Body.String())
+	}
+	ladder := decode(recorder)
+	if ladder.Name != "LADDER" || ladder.Color != "#1976D2" || ladder.Scope != scopeFacility || ladder.DefaultDurationMinutes != 90 ||
+		!ladder.MemberBookable || ladder.CountsTowardMemberLimit || ladder.OrganizationID == nil || *ladder.OrganizationID != orgID {
+		t.Fatalf("unexpected facility type: %+v", ladder)
+	}
+
+	for _, body := range []string{
+		fmt.Sprintf(`{"name": "x", "facilityId": %d}`, northID),
+		fmt.Sprintf(`{"name": "SOCIAL", "color": "blue", "facilityId": %d}`, northID),
+		fmt.Sprintf(`{"name": "SOCIAL", "defaultDurationMinutes": 0, "facilityId": %d}`, northID),
+		fmt.Sprintf(`{"name": "SOCIAL", "facilityId": %d, "organizationId": %d}`, northID, orgID),
+	} {
+		if recorder := request(http.MethodPost, "/api/v1/reservation-types", northManager, body); recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected %s to be rejected, got %d", body, recorder.Code)
+		}
+	}
+	if recorder := request(http.MethodPost, "/api/v1/reservation-types", northManager,
+		fmt.Sprintf(`{"name": "Ladder", "facilityId": %d}`, northID)); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected a duplicate name to conflict, got %d", recorder.Code)
+	}
+
+	// Organization-wide types need an org admin.
+	orgBody := fmt.Sprintf(`{"name": "SOCIAL", "organizationId": %d}`, orgID)
+	if recorder := request(http.MethodPost, "/api/v1/reservation-types", northManager, orgBody); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected facility staff to be forbidden from org types, got %d", recorder.Code)
+	}
+	recorder = request(http.MethodPost, "/api/v1/reservation-types", orgAdmin, orgBody)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("create org type: %d %s", recorder.Code, recorder.Body.String())
+	}
+	social := decode(recorder)
+	if social.Scope != scopeOrganization || social.FacilityID != nil || social.DefaultDurationMinutes != defaultDurationMinutes {
+		t.Fatalf("unexpected org type: %+v", social)
+	}
+
+	// Each facility sees built-in types, its organization's shared types,
+	// and its own.
+	north := listNames(fmt.Sprintf("/api/v1/reservation-types?facility_id=%d", northID), northManager)
+	if !north["GAME"] || !north["SOCIAL"] || !north["LADDER"] {
+		t.Fatalf("expected North to see built-in, shared, and own types, got %v", north)
+	}
+	south := listNames(fmt.Sprintf("/api/v1/reservation-types?facility_id=%d", southID), southDesk)
+	if !south["SOCIAL"] || south["LADDER"] {
+		t.Fatalf("expected South to see shared but not North's types, got %v", south)
+	}
+	elsewhere := listNames(fmt.Sprintf("/api/v1/reservation-types?facility_id=%d", elsewhereID), elsewhereDesk)
+	if elsewhere["SOCIAL"] || elsewhere["LADDER"] || !elsewhere["GAME"] {
+		t.Fatalf("expected another organization to see only built-in types, got %v", elsewhere)
+	}
+	managed := listNames(fmt.Sprintf("/api/v1/reservation-types?organization_id=%d", orgID), orgAdmin)
+	if !managed["GAME"] || !managed["SOCIAL"] || !managed["LADDER"] {
+		t.Fatalf("expected the org listing to hold built-in and every custom type, got %v", managed)
+	}
+
+	socialPath := fmt.Sprintf("/api/v1/reservation-types/%d", social.ID)
+	if recorder := request(http.MethodGet, socialPath, southDesk, ""); recorder.Code != http.StatusOK {
+		t.Fatalf("expected staff in the organization to read shared types, got %d", recorder.Code)
+	}
+	if recorder := request(http.MethodGet, socialPath, elsewhereDesk, ""); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected another organization's staff to be forbidden, got %d", recorder.Code)
+	}
+
+	// Updates are partial and keep the scope.
+	ladderPath := fmt.Sprintf("/api/v1/reservation-types/%d", ladder.ID)
+	recorder = request(http.MethodPut, ladderPath, northManager, `{"memberBookable": false, "countsTowardMemberLimit": true}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("update: %d %s", recorder.Code, recorder.Body.String())
+	}
+	if updated := decode(recorder); updated.MemberBookable || !updated.CountsTowardMemberLimit || updated.Color != "#1976D2" || updated.DefaultDurationMinutes != 90 {
+		t.Fatalf("expected a partial update to keep other fields, got %+v", updated)
+	}
+	if recorder := request(http.MethodPut, ladderPath, northManager, fmt.Sprintf(`{"facilityId": %d}`, southID)); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected a scope change to be rejected, got %d", recorder.Code)
+	}
+
+	// Duration bounds are whole quarter hours, and 0 clears one.
+	recorder = request(http.MethodPut, ladderPath, northManager, `{"minDurationMinutes": 120, "maxDurationMinutes": 180}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("set duration bounds: %d %s", recorder.Code, recorder.Body.String())
+	}
+	if bounded := decode(recorder); bounded.MinDurationMinutes == nil || *bounded.MinDurationMinutes != 120 || bounded.MaxDurationMinutes == nil || *bounded.MaxDurationMinutes != 180 {
+		t.Fatalf("expected duration bounds to be saved, got %+v", bounded)
+	}
+	for _, body := range []string{`{"maxDurationMinutes": 60}`, `{"minDurationMinutes": 50}`, `{"maxDurationMinutes": -15}`} {
+		if recorder := request(http.MethodPut, ladderPath, northManager, body); recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected %s to be rejected, got %d", body, recorder.Code)
+		}
+	}
+	recorder = request(http.MethodPut, ladderPath, northManager, `{"minDurationMinutes": 0}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("clear minimum: %d %s", recorder.Code, recorder.Body.String())
+	}
+	if cleared := decode(recorder); cleared.MinDurationMinutes != nil || cleared.MaxDurationMinutes == nil {
+		t.Fatalf("expected only the minimum to be cleared, got %+v", cleared)
+	}
+
+	var gameID int64
+	if err := database.QueryRowContext(ctx, "SELECT id FROM reservation_types WHERE name = 'GAME'").Scan(&gameID); err != nil {
+		t.Fatalf("load GAME type: %v", err)
+	}
+	gamePath := fmt.Sprintf("/api/v1/reservation-types/%d", gameID)
+	if recorder := request(http.MethodGet, gamePath, elsewhereDesk, ""); recorder.Code != http.StatusOK {
+		t.Fatalf("expected built-in types to be readable, got %d", recorder.Code)
+	}
+	if recorder := request(http.MethodPut, gamePath, orgAdmin, `{"memberBookable": false}`); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected built-in types to be read-only, got %d", recorder.Code)
+	}
+
+	// Types in use are kept for reservation history.
+	exec(`INSERT INTO reservations (facility_id, reservation_type_id, recurrence_rule_id, primary_user_id, created_by_user_id, pro_id, open_play_rule_id, start_time, end_time, is_open_event, teams_per_court, people_per_team)
+		VALUES (?, ?, NULL, NULL, ?, NULL, NULL, '2030-01-01 10:00:00', '2030-01-01 11:00:00', 0, NULL, NULL)`, northID, ladder.ID, northManager.id)
+	if recorder := request(http.MethodDelete, ladderPath, northManager, ""); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected deleting a type in use to conflict, got %d", recorder.Code)
+	}
+	if recorder := request(http.MethodDelete, socialPath, orgAdmin, ""); recorder.Code != http.StatusNoContent {
+		t.Fatalf("delete unused type: %d %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := request(http.MethodGet, socialPath, orgAdmin, ""); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected a deleted type to be gone, got %d", recorder.Code)
+	}
+}
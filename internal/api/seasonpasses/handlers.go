@@ -0,0 +1,630 @@
+// internal/api/seasonpasses/handlers.go
+package seasonpasses
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/models"
+)
+
+const (
+	seasonPassQueryTimeout = 5 * time.Second
+	passTypeIDParam        = "id"
+)
+
+var (
+	queries     *dbgen.Queries
+	store       *appdb.DB
+	queriesOnce sync.Once
+)
+
+type seasonPassWindowRequest struct {
+	DayOfWeek int64  `json:"dayOfWeek"`
+	StartsAt  string `json:"startsAt"`
+	EndsAt    string `json:"endsAt"`
+}
+
+type seasonPassTypeRequest struct {
+	FacilityID *int64                    `json:"facilityId"`
+	Name       string                    `json:"name"`
+	PriceCents int64                     `json:"priceCents"`
+	ValidFrom  string                    `json:"validFrom"`
+	ValidUntil string                    `json:"validUntil"`
+	Windows    []seasonPassWindowRequest `json:"windows"`
+}
+
+type seasonPassGrantRequest struct {
+	FacilityID     *int64 `json:"facilityId"`
+	UserID         int64  `json:"userId"`
+	PassTypeID     int64  `json:"passTypeId"`
+	PricePaidCents *int64 `json:"pricePaidCents"`
+}
+
+type seasonPassTypeResponse struct {
+	dbgen.SeasonPassType
+	Windows []dbgen.SeasonPassWindow `json:"windows"`
+}
+
+// InitHandlers must be called during server startup before handling requests.
+func InitHandlers(database *appdb.DB) {
+	if database == nil {
+		return
+	}
+	queriesOnce.Do(func() {
+		queries = database.Queries
+		store = database
+	})
+}
+
+func loadQueries() *dbgen.Queries {
+	return queries
+}
+
+func loadDB() *appdb.DB {
+	return store
+}
+
+// GET /api/v1/season-pass-types
+func HandleSeasonPassTypesList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := apiutil.FacilityIDFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), seasonPassQueryTimeout)
+	defer cancel()
+
+	passTypes, err := q.ListSeasonPassTypes(ctx, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list season pass types")
+		http.Error(w, "Failed to load season pass types", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]seasonPassTypeResponse, 0, len(passTypes))
+	for _, passType := range passTypes {
+		windows, err := q.ListSeasonPassWindows(ctx, passType.ID)
+		if err != nil {
+			logger.Error().Err(err).Int64("season_pass_type_id", passType.ID).Msg("Failed to list season pass windows")
+			http.Error(w, "Failed to load season pass types", http.StatusInternalServerError)
+			return
+		}
+		response = append(response, seasonPassTypeResponse{SeasonPassType: passType, Windows: windows})
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"seasonPassTypes": response}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write season pass types response")
+	}
+}
+
+// POST /api/v1/season-pass-types
+func HandleSeasonPassTypeCreate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	database := loadDB()
+	if database == nil {
+		logger.Error().Msg("Database not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	req, err := decodeSeasonPassTypeRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateSeasonPassTypeRequest(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	facilityID, err := apiutil.FacilityIDFromRequest(r, req.FacilityID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), seasonPassQueryTimeout)
+	defer cancel()
+
+	var response seasonPassTypeResponse
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		created, err := txdb.Queries.CreateSeasonPassType(ctx, dbgen.CreateSeasonPassTypeParams{
+			FacilityID: facilityID,
+			Name:       strings.TrimSpace(req.Name),
+			PriceCents: req.PriceCents,
+			ValidFrom:  req.ValidFrom,
+			ValidUntil: req.ValidUntil,
+			Status:     "active",
+		})
+		if err != nil {
+			return err
+		}
+		windows, err := replaceSeasonPassWindows(ctx, txdb.Queries, created.ID, req.Windows)
+		if err != nil {
+			return err
+		}
+		response = seasonPassTypeResponse{SeasonPassType: created, Windows: windows}
+		return nil
+	})
+	if err != nil {
+		if apiutil.IsSQLiteForeignKeyViolation(err) {
+			http.Error(w, "Facility not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to create season pass type")
+		http.Error(w, "Failed to create season pass type", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, response); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write season pass type create response")
+	}
+}
+
+// PUT /api/v1/season-pass-types/{id}
+func HandleSeasonPassTypeUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	database := loadDB()
+	if database == nil {
+		logger.Error().Msg("Database not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	passTypeID, err := passTypeIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid season pass type ID", http.StatusBadRequest)
+		return
+	}
+
+	req, err := decodeSeasonPassTypeRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateSeasonPassTypeRequest(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	facilityID, err := apiutil.FacilityIDFromRequest(r, req.FacilityID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), seasonPassQueryTimeout)
+	defer cancel()
+
+	var response seasonPassTypeResponse
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		updated, err := txdb.Queries.UpdateSeasonPassType(ctx, dbgen.UpdateSeasonPassTypeParams{
+			ID:         passTypeID,
+			FacilityID: facilityID,
+			Name:       strings.TrimSpace(req.Name),
+			PriceCents: req.PriceCents,
+			ValidFrom:  req.ValidFrom,
+			ValidUntil: req.ValidUntil,
+		})
+		if err != nil {
+			return err
+		}
+		windows, err := replaceSeasonPassWindows(ctx, txdb.Queries, updated.ID, req.Windows)
+		if err != nil {
+			return err
+		}
+		response = seasonPassTypeResponse{SeasonPassType: updated, Windows: windows}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Season pass type not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("season_pass_type_id", passTypeID).Msg("Failed to update season pass type")
+		http.Error(w, "Failed to update season pass type", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, response); err != nil {
+		logger.Error().Err(err).Int64("season_pass_type_id", passTypeID).Msg("Failed to write season pass type update response")
+	}
+}
+
+// DELETE /api/v1/season-pass-types/{id}
+func HandleSeasonPassTypeDeactivate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	passTypeID, err := passTypeIDFromRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid season pass type ID", http.StatusBadRequest)
+		return
+	}
+
+	facilityID, err := apiutil.FacilityIDFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), seasonPassQueryTimeout)
+	defer cancel()
+
+	updated, err := q.DeactivateSeasonPassType(ctx, dbgen.DeactivateSeasonPassTypeParams{
+		ID:         passTypeID,
+		FacilityID: facilityID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Season pass type not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("season_pass_type_id", passTypeID).Msg("Failed to deactivate season pass type")
+		http.Error(w, "Failed to deactivate season pass type", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, updated); err != nil {
+		logger.Error().Err(err).Int64("season_pass_type_id", passTypeID).Msg("Failed to write season pass type deactivate response")
+	}
+}
+
+// POST /api/v1/season-passes
+func HandleSeasonPassGrant(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if !authz.IsStaff(user) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	req, err := decodeSeasonPassGrantRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.UserID <= 0 {
+		http.Error(w, "user_id must be a positive integer", http.StatusBadRequest)
+		return
+	}
+	if req.PassTypeID <= 0 {
+		http.Error(w, "pass_type_id must be a positive integer", http.StatusBadRequest)
+		return
+	}
+	if req.PricePaidCents != nil && *req.PricePaidCents < 0 {
+		http.Error(w, "price_paid_cents must be 0 or greater", http.StatusBadRequest)
+		return
+	}
+
+	facilityID, err := apiutil.FacilityIDFromRequest(r, req.FacilityID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), seasonPassQueryTimeout)
+	defer cancel()
+
+	passType, err := q.GetSeasonPassType(ctx, dbgen.GetSeasonPassTypeParams{
+		ID:         req.PassTypeID,
+		FacilityID: facilityID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Season pass type not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("season_pass_type_id", req.PassTypeID).Msg("Failed to load season pass type")
+		http.Error(w, "Failed to load season pass type", http.StatusInternalServerError)
+		return
+	}
+
+	pricePaid := passType.PriceCents
+	if req.PricePaidCents != nil {
+		pricePaid = *req.PricePaidCents
+	}
+
+	created, err := models.IssueSeasonPass(ctx, q, passType, models.IssueSeasonPassParams{
+		UserID:          req.UserID,
+		PricePaidCents:  pricePaid,
+		GrantedByUserID: &user.ID,
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrSeasonPassUnavailable) {
+			http.Error(w, "Season pass type is not available", http.StatusConflict)
+			return
+		}
+		if apiutil.IsSQLiteForeignKeyViolation(err) {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("season_pass_type_id", req.PassTypeID).Int64("user_id", req.UserID).Msg("Failed to grant season pass")
+		http.Error(w, "Failed to grant season pass", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, created); err != nil {
+		logger.Error().Err(err).Int64("season_pass_id", created.ID).Msg("Failed to write season pass response")
+	}
+}
+
+// GET /api/v1/season-passes/report
+func HandleSeasonPassReport(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := apiutil.FacilityIDFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), seasonPassQueryTimeout)
+	defer cancel()
+
+	rows, err := q.SeasonPassCoverageReport(ctx, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load season pass report")
+		http.Error(w, "Failed to load season pass report", http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"seasonPasses": rows}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write season pass report response")
+	}
+}
+
+func replaceSeasonPassWindows(ctx context.Context, q *dbgen.Queries, passTypeID int64, windows []seasonPassWindowRequest) ([]dbgen.SeasonPassWindow, error) {
+	if err := q.DeleteSeasonPassWindows(ctx, passTypeID); err != nil {
+		return nil, err
+	}
+	created := make([]dbgen.SeasonPassWindow, 0, len(windows))
+	for _, window := range windows {
+		row, err := q.CreateSeasonPassWindow(ctx, dbgen.CreateSeasonPassWindowParams{
+			PassTypeID: passTypeID,
+			DayOfWeek:  window.DayOfWeek,
+			StartsAt:   window.StartsAt,
+			EndsAt:     window.EndsAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, row)
+	}
+	return created, nil
+}
+
+func decodeSeasonPassTypeRequest(r *http.Request) (seasonPassTypeRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req seasonPassTypeRequest
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return req, err
+		}
+		return normalizeSeasonPassTypeRequest(req), nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return seasonPassTypeRequest{}, err
+	}
+
+	facilityID, err := apiutil.ParseOptionalInt64Field(apiutil.FirstNonEmpty(r.FormValue("facility_id"), r.FormValue("facilityId")), "facility_id")
+	if err != nil {
+		return seasonPassTypeRequest{}, err
+	}
+
+	priceCents, err := apiutil.ParseNonNegativeInt64Field(apiutil.FirstNonEmpty(r.FormValue("price_cents"), r.FormValue("priceCents")), "price_cents")
+	if err != nil {
+		return seasonPassTypeRequest{}, err
+	}
+
+	// Windows arrive as parallel day_of_week/starts_at/ends_at form values.
+	days := r.Form["day_of_week"]
+	starts := r.Form["starts_at"]
+	ends := r.Form["ends_at"]
+	if len(days) != len(starts) || len(days) != len(ends) {
+		return seasonPassTypeRequest{}, fmt.Errorf("each window requires day_of_week, starts_at, and ends_at")
+	}
+	windows := make([]seasonPassWindowRequest, 0, len(days))
+	for i := range days {
+		day, err := strconv.ParseInt(strings.TrimSpace(days[i]), 10, 64)
+		if err != nil {
+			return seasonPassTypeRequest{}, fmt.Errorf("day_of_week must be between 0 and 6")
+		}
+		windows = append(windows, seasonPassWindowRequest{
+			DayOfWeek: day,
+			StartsAt:  starts[i],
+			EndsAt:    ends[i],
+		})
+	}
+
+	return normalizeSeasonPassTypeRequest(seasonPassTypeRequest{
+		FacilityID: facilityID,
+		Name:       r.FormValue("name"),
+		PriceCents: priceCents,
+		ValidFrom:  apiutil.FirstNonEmpty(r.FormValue("valid_from"), r.FormValue("validFrom")),
+		ValidUntil: apiutil.FirstNonEmpty(r.FormValue("valid_until"), r.FormValue("validUntil")),
+		Windows:    windows,
+	}), nil
+}
+
+func normalizeSeasonPassTypeRequest(req seasonPassTypeRequest) seasonPassTypeRequest {
+	req.Name = strings.TrimSpace(req.Name)
+	req.ValidFrom = strings.TrimSpace(req.ValidFrom)
+	req.ValidUntil = strings.TrimSpace(req.ValidUntil)
+	for i := range req.Windows {
+		req.Windows[i].StartsAt = strings.TrimSpace(req.Windows[i].StartsAt)
+		req.Windows[i].EndsAt = strings.TrimSpace(req.Windows[i].EndsAt)
+	}
+	return req
+}
+
+func validateSeasonPassTypeRequest(req seasonPassTypeRequest) error {
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("name is required")
+	case req.PriceCents < 0:
+		return fmt.Errorf("price_cents must be 0 or greater")
+	}
+
+	validFrom, err := time.Parse(models.SeasonPassDateLayout, req.ValidFrom)
+	if err != nil {
+		return fmt.Errorf("valid_from must be a date in YYYY-MM-DD format")
+	}
+	validUntil, err := time.Parse(models.SeasonPassDateLayout, req.ValidUntil)
+	if err != nil {
+		return fmt.Errorf("valid_until must be a date in YYYY-MM-DD format")
+	}
+	if validUntil.Before(validFrom) {
+		return fmt.Errorf("valid_until must be on or after valid_from")
+	}
+
+	if len(req.Windows) == 0 {
+		return fmt.Errorf("at least one off-peak window is required")
+	}
+	for _, window := range req.Windows {
+		if window.DayOfWeek < 0 || window.DayOfWeek > 6 {
+			return fmt.Errorf("day_of_week must be between 0 and 6")
+		}
+		if !isWindowClock(window.StartsAt) || !isWindowClock(window.EndsAt) {
+			return fmt.Errorf("window times must use HH:MM format")
+		}
+		if window.StartsAt >= window.EndsAt {
+			return fmt.Errorf("window ends_at must be after starts_at")
+		}
+	}
+	return nil
+}
+
+// isWindowClock accepts HH:MM plus 24:00 so a window can run to close at midnight.
+func isWindowClock(value string) bool {
+	if value == "24:00" {
+		return true
+	}
+	parsed, err := time.Parse(models.SeasonPassTimeLayout, value)
+	return err == nil && parsed.Format(models.SeasonPassTimeLayout) == value
+}
+
+func decodeSeasonPassGrantRequest(r *http.Request) (seasonPassGrantRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req seasonPassGrantRequest
+		return req, apiutil.DecodeJSON(r, &req)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return seasonPassGrantRequest{}, err
+	}
+
+	facilityID, err := apiutil.ParseOptionalInt64Field(apiutil.FirstNonEmpty(r.FormValue("facility_id"), r.FormValue("facilityId")), "facility_id")
+	if err != nil {
+		return seasonPassGrantRequest{}, err
+	}
+
+	userID, err := apiutil.ParseRequiredInt64Field(apiutil.FirstNonEmpty(r.FormValue("user_id"), r.FormValue("userId")), "user_id")
+	if err != nil {
+		return seasonPassGrantRequest{}, err
+	}
+
+	passTypeID, err := apiutil.ParseRequiredInt64Field(apiutil.FirstNonEmpty(r.FormValue("pass_type_id"), r.FormValue("passTypeId")), "pass_type_id")
+	if err != nil {
+		return seasonPassGrantRequest{}, err
+	}
+
+	var pricePaid *int64
+	if raw := apiutil.FirstNonEmpty(r.FormValue("price_paid_cents"), r.FormValue("pricePaidCents")); strings.TrimSpace(raw) != "" {
+		value, err := apiutil.ParseNonNegativeInt64Field(raw, "price_paid_cents")
+		if err != nil {
+			return seasonPassGrantRequest{}, err
+		}
+		pricePaid = &value
+	}
+
+	return seasonPassGrantRequest{
+		FacilityID:     facilityID,
+		UserID:         userID,
+		PassTypeID:     passTypeID,
+		PricePaidCents: pricePaid,
+	}, nil
+}
+
+func passTypeIDFromRequest(r *http.Request) (int64, error) {
+	raw := strings.TrimSpace(r.PathValue(passTypeIDParam))
+	if raw == "" {
+		return 0, fmt.Errorf("invalid season pass type ID")
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid season pass type ID")
+	}
+	return value, nil
+}
@@ -4,7 +4,6 @@ package staff
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
@@ -14,20 +13,19 @@ import (
 
 	"github.com/rs/zerolog/log"
 
+	"github.com/codr1/Pickleicious/internal/api/announcements"
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/api/authz"
 	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/images"
 	"github.com/codr1/Pickleicious/internal/models"
 	"github.com/codr1/Pickleicious/internal/request"
+	announcementstempl "github.com/codr1/Pickleicious/internal/templates/components/announcements"
 	stafftempl "github.com/codr1/Pickleicious/internal/templates/components/staff"
 	"github.com/codr1/Pickleicious/internal/templates/layouts"
 )
 
-var (
-	queries *dbgen.Queries
-	store   *appdb.DB
-)
-
 var (
 	errDeactivationSessionsChanged = errors.New("deactivation sessions changed")
 	errDeactivationRequiresConfirm = errors.New("deactivation requires confirmation")
@@ -46,20 +44,27 @@ const (
 	staffDeactivationTimeout = 60 * time.Second
 )
 
-// InitHandlers must be called during server startup before handling requests.
-func InitHandlers(database *appdb.DB) {
+// Handlers serves the staff management pages and API. Each instance holds
+// its own database.
+type Handlers struct {
+	queries *dbgen.Queries
+	store   *appdb.DB
+}
+
+// NewHandlers returns the staff handlers over database. With a nil database
+// every handler answers 500.
+func NewHandlers(database *appdb.DB) *Handlers {
 	if database == nil {
-		return
+		return &Handlers{}
 	}
-	queries = database.Queries
-	store = database
+	return &Handlers{queries: database.Queries, store: database}
 }
 
 // /staff
-func HandleStaffPage(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleStaffPage(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -68,7 +73,7 @@ func HandleStaffPage(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	staffRows, err := queries.ListStaff(ctx)
+	staffRows, err := h.queries.ListStaff(ctx)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to fetch initial staff list")
 		http.Error(w, "Failed to load staff", http.StatusInternalServerError)
@@ -77,7 +82,7 @@ func HandleStaffPage(w http.ResponseWriter, r *http.Request) {
 
 	var activeTheme *models.Theme
 	if facilityID, ok := request.ParseFacilityID(r.URL.Query().Get("facility_id")); ok {
-		theme, err := models.GetActiveTheme(ctx, queries, facilityID)
+		theme, err := models.GetActiveTheme(ctx, h.queries, facilityID)
 		if err != nil {
 			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load active theme")
 			activeTheme = nil
@@ -86,9 +91,18 @@ func HandleStaffPage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	var banners []announcementstempl.Banner
+	if user := authz.UserFromContext(r.Context()); user != nil && user.HomeFacilityID != nil {
+		banners, err = announcements.ActiveBanners(ctx, h.queries, *user.HomeFacilityID, user.ID, announcements.AudienceStaff, announcements.StaffDismissBase)
+		if err != nil {
+			logger.Error().Err(err).Int64("facility_id", *user.HomeFacilityID).Msg("Failed to load announcements")
+			banners = nil
+		}
+	}
+
 	templateStaff := stafftempl.NewStaffList(staffRows)
 	sessionType := authz.SessionTypeFromContext(r.Context())
-	page := layouts.Base(stafftempl.StaffLayout(templateStaff), activeTheme, sessionType)
+	page := layouts.Base(stafftempl.StaffLayout(templateStaff, banners), activeTheme, sessionType)
 	if err := page.Render(r.Context(), w); err != nil {
 		logger.Error().Err(err).Msg("Failed to render staff layout")
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
@@ -97,10 +111,10 @@ func HandleStaffPage(w http.ResponseWriter, r *http.Request) {
 }
 
 // /api/v1/staff
-func HandleStaffList(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleStaffList(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -120,7 +134,7 @@ func HandleStaffList(w http.ResponseWriter, r *http.Request) {
 
 	switch {
 	case hasFacility:
-		rawRows, err := queries.ListStaffByFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
+		rawRows, err := h.queries.ListStaffByFacility(ctx, sql.NullInt64{Int64: facilityID, Valid: true})
 		if err != nil {
 			logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to fetch staff list by facility")
 			http.Error(w, "Failed to fetch staff", http.StatusInternalServerError)
@@ -134,7 +148,7 @@ func HandleStaffList(w http.ResponseWriter, r *http.Request) {
 			staffRows = filterStaffRowsByRole(staffRows, role)
 		}
 	case role != "":
-		rawRows, err := queries.ListStaffByRole(ctx, role)
+		rawRows, err := h.queries.ListStaffByRole(ctx, role)
 		if err != nil {
 			logger.Error().Err(err).Str("role", role).Msg("Failed to fetch staff list by role")
 			http.Error(w, "Failed to fetch staff", http.StatusInternalServerError)
@@ -145,7 +159,7 @@ func HandleStaffList(w http.ResponseWriter, r *http.Request) {
 			staffRows = append(staffRows, toListStaffRow(row))
 		}
 	default:
-		staffRows, err = queries.ListStaff(ctx)
+		staffRows, err = h.queries.ListStaff(ctx)
 		if err != nil {
 			logger.Error().Err(err).Msg("Failed to fetch staff list")
 			http.Error(w, "Failed to fetch staff", http.StatusInternalServerError)
@@ -168,10 +182,10 @@ func HandleStaffList(w http.ResponseWriter, r *http.Request) {
 }
 
 // /api/v1/staff/{id}
-func HandleStaffDetail(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleStaffDetail(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -194,7 +208,7 @@ func HandleStaffDetail(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	staffRow, err := queries.GetStaffByID(ctx, id)
+	staffRow, err := h.queries.GetStaffByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Staff not found", http.StatusNotFound)
@@ -215,8 +229,8 @@ func HandleStaffDetail(w http.ResponseWriter, r *http.Request) {
 }
 
 // /api/v1/staff/new
-func HandleNewStaffForm(w http.ResponseWriter, r *http.Request) {
-	facilities, err := loadFacilities(r.Context())
+func (h *Handlers) HandleNewStaffForm(w http.ResponseWriter, r *http.Request) {
+	facilities, err := h.loadFacilities(r.Context())
 	if err != nil {
 		log.Ctx(r.Context()).Error().Err(err).Msg("Failed to load facilities")
 		http.Error(w, "Failed to load staff form", http.StatusInternalServerError)
@@ -231,10 +245,10 @@ func HandleNewStaffForm(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func HandleCreateStaff(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleCreateStaff(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil || store == nil {
+	if h.queries == nil || h.store == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -257,7 +271,7 @@ func HandleCreateStaff(w http.ResponseWriter, r *http.Request) {
 
 	email := strings.TrimSpace(r.FormValue("email"))
 	if email != "" {
-		if _, err := queries.GetUserByEmail(ctx, sql.NullString{String: email, Valid: true}); err == nil {
+		if _, err := h.queries.GetUserByEmail(ctx, sql.NullString{String: email, Valid: true}); err == nil {
 			http.Error(w, "Email already exists", http.StatusConflict)
 			return
 		} else if !errors.Is(err, sql.ErrNoRows) {
@@ -269,7 +283,7 @@ func HandleCreateStaff(w http.ResponseWriter, r *http.Request) {
 
 	phone := strings.TrimSpace(r.FormValue("phone"))
 	if phone != "" {
-		if _, err := queries.GetUserByPhone(ctx, sql.NullString{String: phone, Valid: true}); err == nil {
+		if _, err := h.queries.GetUserByPhone(ctx, sql.NullString{String: phone, Valid: true}); err == nil {
 			http.Error(w, "Phone already exists", http.StatusConflict)
 			return
 		} else if !errors.Is(err, sql.ErrNoRows) {
@@ -285,7 +299,7 @@ func HandleCreateStaff(w http.ResponseWriter, r *http.Request) {
 	}
 
 	targetAccess := staffAccessFromRoleAndFacility(role, homeFacilityID)
-	requesterAccess, ok := requireStaffManagement(w, r, ctx, targetAccess, "creation")
+	requesterAccess, ok := h.requireStaffManagement(w, r, ctx, targetAccess, "creation")
 	if !ok {
 		return
 	}
@@ -295,9 +309,14 @@ func HandleCreateStaff(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	photo, hasPhoto, ok := staffPhotoFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	var userID int64
 	var staffID int64
-	err := store.RunInTx(ctx, func(txdb *appdb.DB) error {
+	err := h.store.RunInTx(ctx, func(txdb *appdb.DB) error {
 		qtx := txdb.Queries
 		var err error
 
@@ -325,6 +344,12 @@ func HandleCreateStaff(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			return staffCreateTxError{msg: "Failed to create staff", err: err}
 		}
+
+		if hasPhoto {
+			if _, err := qtx.UpsertPhoto(ctx, staffPhotoParams(userID, photo)); err != nil {
+				return staffCreateTxError{msg: "Failed to save staff photo", err: err}
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -343,27 +368,7 @@ func HandleCreateStaff(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if photoData := r.FormValue("photo_data"); photoData != "" {
-		photoBytes, err := decodePhotoData(photoData)
-		if err != nil {
-			logger.Error().Err(err).Msg("Failed to decode photo data")
-			http.Error(w, "Invalid photo data", http.StatusBadRequest)
-			return
-		}
-
-		if _, err := queries.UpsertPhoto(ctx, dbgen.UpsertPhotoParams{
-			UserID:      userID,
-			Data:        photoBytes,
-			ContentType: "image/jpeg",
-			Size:        int64(len(photoBytes)),
-		}); err != nil {
-			logger.Error().Err(err).Int64("user_id", userID).Msg("Failed to save staff photo")
-			http.Error(w, "Failed to save staff photo", http.StatusInternalServerError)
-			return
-		}
-	}
-
-	createdStaff, err := queries.GetStaffByID(ctx, staffID)
+	createdStaff, err := h.queries.GetStaffByID(ctx, staffID)
 	if err != nil {
 		logger.Error().Err(err).Int64("id", staffID).Msg("Failed to fetch created staff")
 		http.Error(w, "Failed to fetch created staff", http.StatusInternalServerError)
@@ -371,7 +376,7 @@ func HandleCreateStaff(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "text/html")
-	w.Header().Set("HX-Trigger", "refreshStaffList")
+	apiutil.AppendHXTrigger(w, "refreshStaffList")
 	w.Header().Set("HX-Retarget", "#staff-detail")
 	w.Header().Set("HX-Reswap", "innerHTML")
 
@@ -383,10 +388,10 @@ func HandleCreateStaff(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func HandleEditStaffForm(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleEditStaffForm(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -415,7 +420,7 @@ func HandleEditStaffForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	staffRow, err := queries.GetStaffByID(r.Context(), id)
+	staffRow, err := h.queries.GetStaffByID(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Staff not found", http.StatusNotFound)
@@ -426,7 +431,7 @@ func HandleEditStaffForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	facilities, err := loadFacilities(r.Context())
+	facilities, err := h.loadFacilities(r.Context())
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to load facilities")
 		http.Error(w, "Failed to load staff form", http.StatusInternalServerError)
@@ -442,10 +447,10 @@ func HandleEditStaffForm(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func HandleUpdateStaff(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleUpdateStaff(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil || store == nil {
+	if h.queries == nil || h.store == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -481,7 +486,7 @@ func HandleUpdateStaff(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	staffRow, err := queries.GetStaffByID(ctx, id)
+	staffRow, err := h.queries.GetStaffByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Staff not found", http.StatusNotFound)
@@ -493,7 +498,7 @@ func HandleUpdateStaff(w http.ResponseWriter, r *http.Request) {
 	}
 
 	currentAccess := staffAccessFromRoleAndFacility(staffRow.Role, staffRow.HomeFacilityID)
-	requesterAccess, ok := requireStaffManagement(w, r, ctx, currentAccess, "update")
+	requesterAccess, ok := h.requireStaffManagement(w, r, ctx, currentAccess, "update")
 	if !ok {
 		return
 	}
@@ -518,7 +523,7 @@ func HandleUpdateStaff(w http.ResponseWriter, r *http.Request) {
 	phone := strings.TrimSpace(r.FormValue("phone"))
 
 	if email != "" {
-		user, err := queries.GetUserByEmail(ctx, sql.NullString{String: email, Valid: true})
+		user, err := h.queries.GetUserByEmail(ctx, sql.NullString{String: email, Valid: true})
 		if err == nil && user.ID != staffRow.UserID {
 			http.Error(w, "Email already exists", http.StatusConflict)
 			return
@@ -530,7 +535,7 @@ func HandleUpdateStaff(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if phone != "" {
-		user, err := queries.GetUserByPhone(ctx, sql.NullString{String: phone, Valid: true})
+		user, err := h.queries.GetUserByPhone(ctx, sql.NullString{String: phone, Valid: true})
 		if err == nil && user.ID != staffRow.UserID {
 			http.Error(w, "Phone already exists", http.StatusConflict)
 			return
@@ -541,18 +546,12 @@ func HandleUpdateStaff(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var photoBytes []byte
-	if photoData := r.FormValue("photo_data"); photoData != "" {
-		var err error
-		photoBytes, err = decodePhotoData(photoData)
-		if err != nil {
-			logger.Error().Err(err).Msg("Failed to decode photo data")
-			http.Error(w, "Invalid photo data", http.StatusBadRequest)
-			return
-		}
+	photo, hasPhoto, ok := staffPhotoFromRequest(w, r)
+	if !ok {
+		return
 	}
 
-	err = store.RunInTx(ctx, func(txdb *appdb.DB) error {
+	err = h.store.RunInTx(ctx, func(txdb *appdb.DB) error {
 		qtx := txdb.Queries
 
 		if err := qtx.UpdateStaffUser(ctx, dbgen.UpdateStaffUserParams{
@@ -578,13 +577,8 @@ func HandleUpdateStaff(w http.ResponseWriter, r *http.Request) {
 			return staffUpdateTxError{msg: "Failed to update staff record", err: err}
 		}
 
-		if len(photoBytes) > 0 {
-			if _, err := qtx.UpsertPhoto(ctx, dbgen.UpsertPhotoParams{
-				UserID:      staffRow.UserID,
-				Data:        photoBytes,
-				ContentType: "image/jpeg",
-				Size:        int64(len(photoBytes)),
-			}); err != nil {
+		if hasPhoto {
+			if _, err := qtx.UpsertPhoto(ctx, staffPhotoParams(staffRow.UserID, photo)); err != nil {
 				return staffUpdateTxError{msg: "Failed to save staff photo", err: err}
 			}
 		}
@@ -602,7 +596,7 @@ func HandleUpdateStaff(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedStaff, err := queries.GetStaffByID(ctx, id)
+	updatedStaff, err := h.queries.GetStaffByID(ctx, id)
 	if err != nil {
 		logger.Error().Err(err).Int64("id", id).Msg("Failed to fetch updated staff")
 		http.Error(w, "Failed to fetch staff", http.StatusInternalServerError)
@@ -610,7 +604,7 @@ func HandleUpdateStaff(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "text/html")
-	w.Header().Set("HX-Trigger", "refreshStaffList")
+	apiutil.AppendHXTrigger(w, "refreshStaffList")
 	w.Header().Set("HX-Retarget", "#staff-detail")
 	w.Header().Set("HX-Reswap", "innerHTML")
 
@@ -623,10 +617,10 @@ func HandleUpdateStaff(w http.ResponseWriter, r *http.Request) {
 }
 
 // /api/v1/staff/{id}
-func HandleDeactivateStaff(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleDeactivateStaff(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil || store == nil {
+	if h.queries == nil || h.store == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -641,7 +635,7 @@ func HandleDeactivateStaff(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	targetStaff, err := queries.GetStaffByID(ctx, staffID)
+	targetStaff, err := h.queries.GetStaffByID(ctx, staffID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Staff not found", http.StatusNotFound)
@@ -653,7 +647,7 @@ func HandleDeactivateStaff(w http.ResponseWriter, r *http.Request) {
 	}
 
 	targetAccess := staffAccessFromRoleAndFacility(targetStaff.Role, targetStaff.HomeFacilityID)
-	if _, ok := requireStaffManagement(w, r, ctx, targetAccess, "deactivation"); !ok {
+	if _, ok := h.requireStaffManagement(w, r, ctx, targetAccess, "deactivation"); !ok {
 		return
 	}
 	user := authz.UserFromContext(r.Context())
@@ -664,7 +658,7 @@ func HandleDeactivateStaff(w http.ResponseWriter, r *http.Request) {
 		modalPros         []dbgen.ListStaffRow
 		modalSessionCount int
 	)
-	if err := store.RunInTx(ctx, func(txdb *appdb.DB) error {
+	if err := h.store.RunInTx(ctx, func(txdb *appdb.DB) error {
 		qtx := txdb.Queries
 
 		staffRow, err := qtx.GetStaffByID(ctx, staffID)
@@ -742,7 +736,7 @@ func HandleDeactivateStaff(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "text/html")
-	w.Header().Set("HX-Trigger", "refreshStaffList")
+	apiutil.AppendHXTrigger(w, "refreshStaffList")
 	w.Header().Set("HX-Retarget", "#staff-detail")
 	w.Header().Set("HX-Reswap", "innerHTML")
 	if err := renderStaffDetail(w, r, updatedStaff); err != nil {
@@ -752,17 +746,17 @@ func HandleDeactivateStaff(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// /api/v1/staff/{id}/deactivate
-func HandleConfirmDeactivation(w http.ResponseWriter, r *http.Request) {
+// /api/v1/staff/{id}/deactivation/confirm
+func (h *Handlers) HandleConfirmDeactivation(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil || store == nil {
+	if h.queries == nil || h.store == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
-	staffID, err := parseStaffIDFromPath(r.URL.Path, 2)
+	staffID, err := parseStaffIDFromPath(r.URL.Path, 3)
 	if err != nil {
 		http.Error(w, "Invalid staff ID", http.StatusBadRequest)
 		return
@@ -805,7 +799,7 @@ func HandleConfirmDeactivation(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffDeactivationTimeout)
 	defer cancel()
 
-	targetStaff, err := queries.GetStaffByID(ctx, staffID)
+	targetStaff, err := h.queries.GetStaffByID(ctx, staffID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Staff not found", http.StatusNotFound)
@@ -817,7 +811,7 @@ func HandleConfirmDeactivation(w http.ResponseWriter, r *http.Request) {
 	}
 
 	targetAccess := staffAccessFromRoleAndFacility(targetStaff.Role, targetStaff.HomeFacilityID)
-	if _, ok := requireStaffManagement(w, r, ctx, targetAccess, "deactivation"); !ok {
+	if _, ok := h.requireStaffManagement(w, r, ctx, targetAccess, "deactivation"); !ok {
 		return
 	}
 	user := authz.UserFromContext(r.Context())
@@ -829,7 +823,7 @@ func HandleConfirmDeactivation(w http.ResponseWriter, r *http.Request) {
 		modalSessionCount   int
 		modalSelectedAction string
 	)
-	err = store.RunInTx(ctx, func(txdb *appdb.DB) error {
+	err = h.store.RunInTx(ctx, func(txdb *appdb.DB) error {
 		qtx := txdb.Queries
 
 		staffRow, err := qtx.GetStaffByID(ctx, staffID)
@@ -872,6 +866,7 @@ func HandleConfirmDeactivation(w http.ResponseWriter, r *http.Request) {
 			if !strings.EqualFold(reassignStaff.Role, "pro") || !strings.EqualFold(reassignStaff.UserStatus, "active") {
 				return &deactivationValidationError{msg: "Reassignment staff must be an active pro"}
 			}
+			fromName := strings.TrimSpace(staffRow.FirstName + " " + staffRow.LastName)
 			for _, session := range futureSessions {
 				if _, err := qtx.UpdateReservation(ctx, dbgen.UpdateReservationParams{
 					ReservationTypeID: session.ReservationTypeID,
@@ -889,6 +884,21 @@ func HandleConfirmDeactivation(w http.ResponseWriter, r *http.Request) {
 				}); err != nil {
 					return err
 				}
+				// The receiving pro learns about each session in their inbox,
+				// alongside the lesson_cancelled notices they already get.
+				if _, err := qtx.CreateLessonReassignedNotification(ctx, dbgen.CreateLessonReassignedNotificationParams{
+					FacilityID: session.FacilityID,
+					Message: fmt.Sprintf(
+						"Lesson reassigned from %s: %s - %s",
+						fromName,
+						session.StartTime.Format("2006-01-02 15:04"),
+						session.EndTime.Format("2006-01-02 15:04"),
+					),
+					RelatedReservationID: sql.NullInt64{Int64: session.ID, Valid: true},
+					TargetStaffID:        sql.NullInt64{Int64: reassignID, Valid: true},
+				}); err != nil {
+					return err
+				}
 			}
 		case "cancel":
 			for _, session := range futureSessions {
@@ -938,7 +948,7 @@ func HandleConfirmDeactivation(w http.ResponseWriter, r *http.Request) {
 				"Upcoming sessions changed since you opened this dialog. Review your choice and confirm again.",
 			)
 			w.Header().Set("Content-Type", "text/html")
-			w.Header().Set("HX-Trigger", "deactivationSessionsChanged")
+			apiutil.AppendHXTrigger(w, "deactivationSessionsChanged")
 			w.Header().Set("HX-Retarget", "#modal")
 			w.Header().Set("HX-Reswap", "innerHTML")
 			if err := component.Render(r.Context(), w); err != nil {
@@ -963,7 +973,7 @@ func HandleConfirmDeactivation(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "text/html")
 	if action != "abort" {
-		w.Header().Set("HX-Trigger", "refreshStaffList")
+		apiutil.AppendHXTrigger(w, "refreshStaffList")
 	}
 	w.Header().Set("HX-Retarget", "#staff-detail")
 	w.Header().Set("HX-Reswap", "innerHTML")
@@ -1010,7 +1020,7 @@ func staffAccessFromRoleAndFacility(role string, homeFacilityID sql.NullInt64) a
 	}
 }
 
-func requireStaffManagement(w http.ResponseWriter, r *http.Request, ctx context.Context, target authz.StaffAccess, action string) (authz.StaffAccess, bool) {
+func (h *Handlers) requireStaffManagement(w http.ResponseWriter, r *http.Request, ctx context.Context, target authz.StaffAccess, action string) (authz.StaffAccess, bool) {
 	logger := log.Ctx(r.Context())
 	user := authz.UserFromContext(r.Context())
 	if user == nil {
@@ -1019,7 +1029,7 @@ func requireStaffManagement(w http.ResponseWriter, r *http.Request, ctx context.
 		return authz.StaffAccess{}, false
 	}
 
-	staffRow, err := queries.GetStaffByUserID(ctx, user.ID)
+	staffRow, err := h.queries.GetStaffByUserID(ctx, user.ID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			logger.Warn().Int64("user_id", user.ID).Str("action", action).Msg("Staff management denied: not staff")
@@ -1100,11 +1110,11 @@ func parseStaffIDFromPath(path string, indexFromEnd int) (int64, error) {
 	return strconv.ParseInt(idStr, 10, 64)
 }
 
-func loadFacilities(ctx context.Context) ([]dbgen.Facility, error) {
-	if queries == nil {
+func (h *Handlers) loadFacilities(ctx context.Context) ([]dbgen.Facility, error) {
+	if h.queries == nil {
 		return nil, fmt.Errorf("database queries not initialized")
 	}
-	return queries.ListFacilities(ctx)
+	return h.queries.ListFacilities(ctx)
 }
 
 type staffCreateTxError struct {
@@ -1133,16 +1143,36 @@ func (e staffUpdateTxError) Unwrap() error {
 	return e.err
 }
 
-func decodePhotoData(photoData string) ([]byte, error) {
-	parts := strings.SplitN(photoData, ",", 2)
-	if len(parts) < 2 {
-		return nil, fmt.Errorf("invalid photo data format")
+// staffPhotoFromRequest normalizes the photo_data form value, if any. It
+// writes the error response and reports false when the upload is rejected.
+func staffPhotoFromRequest(w http.ResponseWriter, r *http.Request) (images.Photo, bool, bool) {
+	photoData := r.FormValue("photo_data")
+	if photoData == "" {
+		return images.Photo{}, false, true
 	}
-	photoBytes, err := base64.StdEncoding.DecodeString(parts[1])
+	photo, err := apiutil.ProcessPhotoUpload(photoData)
 	if err != nil {
-		return nil, fmt.Errorf("decode photo data: %w", err)
+		var handlerErr apiutil.HandlerError
+		if errors.As(err, &handlerErr) {
+			log.Ctx(r.Context()).Warn().Err(err).Msg("Rejected staff photo upload")
+			http.Error(w, handlerErr.Message, handlerErr.Status)
+			return images.Photo{}, false, false
+		}
+		log.Ctx(r.Context()).Error().Err(err).Msg("Failed to process staff photo")
+		http.Error(w, "Failed to process photo", http.StatusInternalServerError)
+		return images.Photo{}, false, false
+	}
+	return photo, true, true
+}
+
+func staffPhotoParams(userID int64, photo images.Photo) dbgen.UpsertPhotoParams {
+	return dbgen.UpsertPhotoParams{
+		UserID:        userID,
+		Data:          photo.Full,
+		ContentType:   photo.ContentType,
+		Size:          int64(len(photo.Full)),
+		ThumbnailData: photo.Thumbnail,
 	}
-	return photoBytes, nil
 }
 
 func filterStaffRowsBySearch(rows []dbgen.ListStaffRow, search string) []dbgen.ListStaffRow {
@@ -0,0 +1,375 @@
+package staff
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	lessonReportDateLayout = "2006-01-02"
+	// maxLessonReportDays bounds one report to a year of lessons.
+	maxLessonReportDays = 366
+
+	lessonStatusTaught                  = "taught"
+	lessonStatusCancelledWithPenalty    = "cancelled_with_penalty"
+	lessonStatusCancelledWithoutPenalty = "cancelled_without_penalty"
+)
+
+// lessonReportLesson is one pro session in the report. PriceCents is set only
+// for lessons that were priced when booked.
+type lessonReportLesson struct {
+	ReservationID    int64      `json:"reservationId"`
+	StartTime        time.Time  `json:"startTime"`
+	EndTime          time.Time  `json:"endTime"`
+	MemberID         *int64     `json:"memberId"`
+	MemberName       string     `json:"memberName"`
+	Minutes          int64      `json:"minutes"`
+	Status           string     `json:"status"`
+	Reassigned       bool       `json:"reassigned"`
+	PriceCents       *int64     `json:"priceCents"`
+	CancelledAt      *time.Time `json:"cancelledAt,omitempty"`
+	RefundPercentage *int64     `json:"refundPercentage,omitempty"`
+	FeeWaived        bool       `json:"feeWaived,omitempty"`
+}
+
+// lessonReportMember totals one member's lessons with the pro.
+type lessonReportMember struct {
+	MemberID      *int64  `json:"memberId"`
+	MemberName    string  `json:"memberName"`
+	Lessons       int     `json:"lessons"`
+	Hours         float64 `json:"hours"`
+	Cancellations int     `json:"cancellations"`
+	EarningsCents *int64  `json:"earningsCents"`
+}
+
+// lessonReportSummary counts lessons taught apart from cancellations.
+// EarningsCents sums the stored prices of lessons taught and stays null
+// until at least one of them was priced.
+type lessonReportSummary struct {
+	Lessons                     int     `json:"lessons"`
+	Hours                       float64 `json:"hours"`
+	MembersServed               int     `json:"membersServed"`
+	Cancellations               int     `json:"cancellations"`
+	CancellationsWithPenalty    int     `json:"cancellationsWithPenalty"`
+	CancellationsWithoutPenalty int     `json:"cancellationsWithoutPenalty"`
+	ReassignedLessons           int     `json:"reassignedLessons"`
+	EarningsCents               *int64  `json:"earningsCents"`
+}
+
+type lessonReport struct {
+	StaffID   int64                `json:"staffId"`
+	ProName   string               `json:"proName"`
+	From      string               `json:"from"`
+	To        string               `json:"to"`
+	Summary   lessonReportSummary  `json:"summary"`
+	Members   []lessonReportMember `json:"members"`
+	Lessons   []lessonReportLesson `json:"lessons"`
+	Cancelled []lessonReportLesson `json:"cancelled"`
+}
+
+// HandleStaffLessonReport handles GET /api/v1/staff/{id}/lessons/report with
+// the pro's lessons between from and to (YYYY-MM-DD, inclusive, in the pro's
+// home facility time zone; the current month when both are omitted). The pro
+// may read their own report; otherwise it takes a manager who could manage
+// them. ?format=csv or Accept: text/csv returns one CSV row per lesson.
+func (h *Handlers) HandleStaffLessonReport(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if h.queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(parts) < 3 {
+		http.Error(w, "Invalid path", http.StatusBadRequest)
+		return
+	}
+	staffID, err := strconv.ParseInt(parts[len(parts)-3], 10, 64)
+	if err != nil || staffID <= 0 {
+		http.Error(w, "Invalid staff ID", http.StatusBadRequest)
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
+	defer cancel()
+
+	staffRow, err := h.queries.GetStaffByID(ctx, staffID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Staff not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("staff_id", staffID).Msg("Failed to fetch staff")
+		http.Error(w, "Failed to fetch staff", http.StatusInternalServerError)
+		return
+	}
+	if staffRow.UserID != user.ID {
+		target := staffAccessFromRoleAndFacility(staffRow.Role, staffRow.HomeFacilityID)
+		if _, ok := h.requireStaffManagement(w, r, ctx, target, "lesson report"); !ok {
+			return
+		}
+	}
+
+	loc := apiutil.DefaultLocation()
+	if staffRow.HomeFacilityID.Valid {
+		facility, err := h.queries.GetFacilityByID(ctx, staffRow.HomeFacilityID.Int64)
+		if err != nil {
+			logger.Error().Err(err).Int64("facility_id", staffRow.HomeFacilityID.Int64).Msg("Failed to load facility")
+			http.Error(w, "Failed to load facility", http.StatusInternalServerError)
+			return
+		}
+		loc = apiutil.FacilityLocation(facility, logger)
+	}
+
+	query := r.URL.Query()
+	from, to, err := parseLessonReportRange(query.Get("from"), query.Get("to"), time.Now().In(loc))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rows, err := h.queries.ListProLessonReportReservations(ctx, dbgen.ListProLessonReportReservationsParams{
+		ProID:      sql.NullInt64{Int64: staffID, Valid: true},
+		RangeStart: from.UTC(),
+		RangeEnd:   to.AddDate(0, 0, 1).UTC(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("staff_id", staffID).Msg("Failed to load lesson report")
+		http.Error(w, "Failed to load lesson report", http.StatusInternalServerError)
+		return
+	}
+
+	report := buildLessonReport(staffRow, from, to, rows, loc)
+	if lessonReportWantsCSV(r) {
+		h.writeLessonReportCSV(w, r, report)
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, report); err != nil {
+		logger.Error().Err(err).Int64("staff_id", staffID).Msg("Failed to write lesson report response")
+	}
+}
+
+// parseLessonReportRange reads an inclusive from/to date pair, defaulting to
+// the month containing now when both are empty.
+func parseLessonReportRange(fromRaw, toRaw string, now time.Time) (time.Time, time.Time, error) {
+	loc := now.Location()
+	fromRaw = strings.TrimSpace(fromRaw)
+	toRaw = strings.TrimSpace(toRaw)
+	if fromRaw == "" && toRaw == "" {
+		from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+		return from, from.AddDate(0, 1, -1), nil
+	}
+	from, err := time.ParseInLocation(lessonReportDateLayout, fromRaw, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must be in YYYY-MM-DD format")
+	}
+	to, err := time.ParseInLocation(lessonReportDateLayout, toRaw, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must be in YYYY-MM-DD format")
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must not be before from")
+	}
+	if to.After(from.AddDate(0, 0, maxLessonReportDays-1)) {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range cannot exceed %d days", maxLessonReportDays)
+	}
+	return from, to, nil
+}
+
+// buildLessonReport attributes every row to the pro, since the query selects
+// by the pro the reservation names now. Reassignment only moves lessons that
+// have not started, so that is also who held each past lesson when it began.
+func buildLessonReport(staffRow dbgen.GetStaffByIDRow, from, to time.Time, rows []dbgen.ListProLessonReportReservationsRow, loc *time.Location) lessonReport {
+	report := lessonReport{
+		StaffID:   staffRow.ID,
+		ProName:   strings.TrimSpace(staffRow.FirstName + " " + staffRow.LastName),
+		From:      from.Format(lessonReportDateLayout),
+		To:        to.Format(lessonReportDateLayout),
+		Members:   []lessonReportMember{},
+		Lessons:   []lessonReportLesson{},
+		Cancelled: []lessonReportLesson{},
+	}
+
+	var taughtMinutes int64
+	membersByKey := make(map[int64]*lessonReportMember)
+	memberMinutes := make(map[int64]int64)
+	for _, row := range rows {
+		lesson := lessonReportLesson{
+			ReservationID: row.ID,
+			StartTime:     row.StartTime.In(loc),
+			EndTime:       row.EndTime.In(loc),
+			MemberName:    strings.TrimSpace(row.MemberFirstName + " " + row.MemberLastName),
+			Minutes:       int64(row.EndTime.Sub(row.StartTime) / time.Minute),
+			Status:        lessonStatusTaught,
+			Reassigned:    row.Reassigned,
+		}
+		// Lessons without a member are grouped under key 0.
+		memberKey := int64(0)
+		if row.PrimaryUserID.Valid {
+			memberID := row.PrimaryUserID.Int64
+			lesson.MemberID = &memberID
+			memberKey = memberID
+		}
+		if row.PriceCents.Valid {
+			price := row.PriceCents.Int64
+			lesson.PriceCents = &price
+		}
+
+		member, ok := membersByKey[memberKey]
+		if !ok {
+			member = &lessonReportMember{MemberID: lesson.MemberID, MemberName: lesson.MemberName}
+			membersByKey[memberKey] = member
+		}
+
+		if row.CancelledAt.Valid {
+			cancelledAt := row.CancelledAt.Time.In(loc)
+			refund := row.RefundPercentageApplied.Int64
+			lesson.CancelledAt = &cancelledAt
+			lesson.RefundPercentage = &refund
+			lesson.FeeWaived = row.FeeWaived.Bool
+			lesson.Status = lessonStatusCancelledWithoutPenalty
+			if !lesson.FeeWaived && refund < 100 {
+				lesson.Status = lessonStatusCancelledWithPenalty
+				report.Summary.CancellationsWithPenalty++
+			} else {
+				report.Summary.CancellationsWithoutPenalty++
+			}
+			report.Summary.Cancellations++
+			member.Cancellations++
+			report.Cancelled = append(report.Cancelled, lesson)
+			continue
+		}
+
+		report.Summary.Lessons++
+		taughtMinutes += lesson.Minutes
+		member.Lessons++
+		memberMinutes[memberKey] += lesson.Minutes
+		if lesson.Reassigned {
+			report.Summary.ReassignedLessons++
+		}
+		if lesson.PriceCents != nil {
+			report.Summary.EarningsCents = addCents(report.Summary.EarningsCents, *lesson.PriceCents)
+			member.EarningsCents = addCents(member.EarningsCents, *lesson.PriceCents)
+		}
+		report.Lessons = append(report.Lessons, lesson)
+	}
+
+	report.Summary.Hours = lessonHours(taughtMinutes)
+	for key, member := range membersByKey {
+		member.Hours = lessonHours(memberMinutes[key])
+		if member.Lessons > 0 && member.MemberID != nil {
+			report.Summary.MembersServed++
+		}
+		report.Members = append(report.Members, *member)
+	}
+	sort.Slice(report.Members, func(i, j int) bool {
+		if report.Members[i].Lessons != report.Members[j].Lessons {
+			return report.Members[i].Lessons > report.Members[j].Lessons
+		}
+		return strings.ToLower(report.Members[i].MemberName) < strings.ToLower(report.Members[j].MemberName)
+	})
+	return report
+}
+
+func addCents(total *int64, cents int64) *int64 {
+	sum := cents
+	if total != nil {
+		sum += *total
+	}
+	return &sum
+}
+
+// lessonHours converts minutes to hours rounded to two decimals.
+func lessonHours(minutes int64) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
+
+func lessonReportWantsCSV(r *http.Request) bool {
+	if format := strings.TrimSpace(r.URL.Query().Get("format")); format != "" {
+		return strings.EqualFold(format, "csv")
+	}
+	return strings.Contains(strings.ToLower(r.Header.Get("Accept")), "text/csv")
+}
+
+// writeLessonReportCSV writes the lessons taught and then the cancelled ones,
+// one row each.
+func (h *Handlers) writeLessonReportCSV(w http.ResponseWriter, r *http.Request, report lessonReport) {
+	logger := log.Ctx(r.Context())
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{
+		"Date",
+		"Start",
+		"End",
+		"Member",
+		"Hours",
+		"Status",
+		"Reassigned",
+		"Price",
+	}); err != nil {
+		logger.Error().Err(err).Int64("staff_id", report.StaffID).Msg("Failed to write lesson report CSV header")
+		http.Error(w, "Failed to export lesson report", http.StatusInternalServerError)
+		return
+	}
+
+	lessons := append(append([]lessonReportLesson{}, report.Lessons...), report.Cancelled...)
+	for _, lesson := range lessons {
+		price := ""
+		if lesson.PriceCents != nil {
+			price = fmt.Sprintf("%.2f", float64(*lesson.PriceCents)/100)
+		}
+		record := []string{
+			lesson.StartTime.Format(lessonReportDateLayout),
+			lesson.StartTime.Format("15:04"),
+			lesson.EndTime.Format("15:04"),
+			apiutil.SanitizeCSVField(lesson.MemberName),
+			strconv.FormatFloat(lessonHours(lesson.Minutes), 'f', 2, 64),
+			lesson.Status,
+			strconv.FormatBool(lesson.Reassigned),
+			price,
+		}
+		if err := writer.Write(record); err != nil {
+			logger.Error().Err(err).Int64("staff_id", report.StaffID).Msg("Failed to write lesson report CSV row")
+			http.Error(w, "Failed to export lesson report", http.StatusInternalServerError)
+			return
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		logger.Error().Err(err).Int64("staff_id", report.StaffID).Msg("Failed to finalize lesson report CSV")
+		http.Error(w, "Failed to export lesson report", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"staff_%d_lessons_%s_%s.csv\"", report.StaffID, report.From, report.To))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.Error().Err(err).Int64("staff_id", report.StaffID).Msg("Failed to write lesson report CSV response")
+	}
+}
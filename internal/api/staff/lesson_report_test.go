@@ -0,0 +1,100 @@
+package staff
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandleStaffLessonReport(t *testing.T) {
+	fixture := setupNotificationTest(t)
+	proUserID, proID := fixture.insertStaff("pro@test.com", "Paula", "pro")
+	_, otherProID := fixture.insertStaff("other@test.com", "Otto", "pro")
+	managerUserID, _ := fixture.insertStaff("manager@test.com", "Mona", "manager")
+	deskUserID, _ := fixture.insertStaff("desk@test.com", "Dana", "desk")
+	memberID := fixture.exec("INSERT INTO users (first_name, last_name, email, status, is_member) VALUES ('Mia', 'Member', 'mia@test.com', 'active', 1)")
+
+	var typeID int64
+	if err := fixture.database.QueryRow("SELECT id FROM reservation_types WHERE name = 'PRO_SESSION'").Scan(&typeID); err != nil {
+		t.Fatalf("load PRO_SESSION type: %v", err)
+	}
+	lesson := func(pro int64, start time.Time) int64 {
+		return fixture.exec(
+			`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, pro_id, start_time, end_time)
+			 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			fixture.facilityID, typeID, memberID, memberID, pro, start, start.Add(90*time.Minute),
+		)
+	}
+	day := time.Date(2030, time.March, 4, 15, 0, 0, 0, time.UTC)
+	taught := lesson(proID, day)
+	fixture.exec("INSERT INTO reservation_prices (reservation_id, amount_cents, member_rate) VALUES (?, 6000, 1)", taught)
+	reassigned := lesson(proID, day.AddDate(0, 0, 1))
+	fixture.exec(
+		"INSERT INTO staff_notifications (facility_id, notification_type, message, related_reservation_id, target_staff_id) VALUES (?, 'lesson_reassigned', 'Lesson reassigned', ?, ?)",
+		fixture.facilityID, reassigned, proID,
+	)
+	penalised := lesson(proID, day.AddDate(0, 0, 2))
+	fixture.exec(
+		"INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, fee_waived, hours_before_start) VALUES (?, ?, ?, 50, 0, 2)",
+		penalised, memberID, day,
+	)
+	waived := lesson(proID, day.AddDate(0, 0, 3))
+	fixture.exec(
+		"INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, fee_waived, hours_before_start) VALUES (?, ?, ?, 100, 1, 2)",
+		waived, memberID, day,
+	)
+	lesson(otherProID, day)
+	lesson(proID, day.AddDate(0, 1, 0))
+
+	call := func(userID int64, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/staff/%d/lessons/report?%s", proID, query), nil)
+		recorder := httptest.NewRecorder()
+		fixture.h.HandleStaffLessonReport(recorder, fixture.asStaff(req, userID))
+		return recorder
+	}
+
+	recorder := call(proUserID, "from=2030-03-01&to=2030-03-31")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var report lessonReport
+	if err := json.Unmarshal(recorder.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	summary := report.Summary
+	if summary.Lessons != 2 || summary.Hours != 3 || summary.MembersServed != 1 || summary.ReassignedLessons != 1 {
+		t.Fatalf("unexpected lesson totals: %+v", summary)
+	}
+	if summary.Cancellations != 2 || summary.CancellationsWithPenalty != 1 || summary.CancellationsWithoutPenalty != 1 {
+		t.Fatalf("unexpected cancellation totals: %+v", summary)
+	}
+	if summary.EarningsCents == nil || *summary.EarningsCents != 6000 {
+		t.Fatalf("expected $60.00 earned, got %+v", summary.EarningsCents)
+	}
+	if len(report.Cancelled) != 2 || report.Cancelled[0].Status != lessonStatusCancelledWithPenalty {
+		t.Fatalf("expected cancellations listed separately, got %+v", report.Cancelled)
+	}
+	if len(report.Members) != 1 || report.Members[0].MemberName != "Mia Member" || report.Members[0].Lessons != 2 || report.Members[0].Cancellations != 2 {
+		t.Fatalf("unexpected member breakdown: %+v", report.Members)
+	}
+
+	recorder = call(managerUserID, "from=2030-03-01&to=2030-03-31&format=csv")
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "text/csv" {
+		t.Fatalf("expected a CSV for the manager, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n"); len(lines) != 5 || !strings.Contains(lines[1], "Mia Member") {
+		t.Fatalf("expected a header and four lessons, got %q", recorder.Body.String())
+	}
+
+	if recorder := call(deskUserID, "from=2030-03-01&to=2030-03-31"); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected desk staff forbidden, got %d", recorder.Code)
+	}
+	if recorder := call(proUserID, "from=2030-03-31&to=2030-03-01"); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a reversed range, got %d", recorder.Code)
+	}
+}
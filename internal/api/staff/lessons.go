@@ -16,14 +16,15 @@ import (
 	"github.com/codr1/Pickleicious/internal/api/authz"
 	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/metrics"
 	"github.com/codr1/Pickleicious/internal/request"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
 	reservationstempl "github.com/codr1/Pickleicious/internal/templates/components/reservations"
 	stafftempl "github.com/codr1/Pickleicious/internal/templates/components/staff"
 )
 
 const (
 	staffLessonReservationTypeName = "PRO_SESSION"
-	staffLessonTimeLayout          = "2006-01-02 15:04"
 	staffLessonMinDuration         = time.Hour
 )
 
@@ -50,9 +51,9 @@ type staffLessonReservationInput struct {
 	ProRow          *dbgen.GetStaffByIDRow
 }
 
-func createStaffLessonReservation(ctx context.Context, input staffLessonReservationInput) (dbgen.Reservation, error) {
+func (h *Handlers) createStaffLessonReservation(ctx context.Context, input staffLessonReservationInput) (dbgen.Reservation, error) {
 	if input.FacilityLoc == nil {
-		input.FacilityLoc = time.Local
+		input.FacilityLoc = apiutil.DefaultLocation()
 	}
 
 	if !input.EndTime.After(input.StartTime) {
@@ -73,7 +74,7 @@ func createStaffLessonReservation(ctx context.Context, input staffLessonReservat
 		}
 	}
 
-	memberRow, err := queries.GetUserByID(ctx, input.MemberID)
+	memberRow, err := h.queries.GetUserByID(ctx, input.MemberID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return dbgen.Reservation{}, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Member not found"}
@@ -89,7 +90,7 @@ func createStaffLessonReservation(ctx context.Context, input staffLessonReservat
 
 	proRow := input.ProRow
 	if proRow == nil {
-		row, err := queries.GetStaffByID(ctx, input.ProID)
+		row, err := h.queries.GetStaffByID(ctx, input.ProID)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				return dbgen.Reservation{}, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Pro not found"}
@@ -102,21 +103,18 @@ func createStaffLessonReservation(ctx context.Context, input staffLessonReservat
 		return dbgen.Reservation{}, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Pro not found"}
 	}
 
-	reservationTypeID, err := lookupReservationTypeID(ctx, queries, staffLessonReservationTypeName)
+	reservationTypeID, err := lookupReservationTypeID(ctx, h.queries, staffLessonReservationTypeName)
 	if err != nil {
 		return dbgen.Reservation{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Reservation type not available", Err: err}
 	}
 
 	var created dbgen.Reservation
-	err = store.RunInTx(ctx, func(txdb *appdb.DB) error {
+	err = h.store.RunInTx(ctx, func(txdb *appdb.DB) error {
 		qtx := txdb.Queries
 		var eligiblePackageID int64
 
 		if input.Facility.MaxMemberReservations > 0 {
-			activeCount, err := qtx.CountActiveMemberReservations(ctx, dbgen.CountActiveMemberReservationsParams{
-				FacilityID:    input.FacilityID,
-				PrimaryUserID: sql.NullInt64{Int64: input.MemberID, Valid: true},
-			})
+			activeCount, err := reservationsvc.CountActiveReservations(ctx, qtx, input.FacilityID, input.MemberID, input.Facility.ReservationLimitScope)
 			if err != nil {
 				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check reservation limits", Err: err}
 			}
@@ -214,15 +212,16 @@ func createStaffLessonReservation(ctx context.Context, input staffLessonReservat
 	if err != nil {
 		return dbgen.Reservation{}, err
 	}
+	metrics.ReservationCreated(created.FacilityID, staffLessonReservationTypeName)
 
 	return created, nil
 }
 
 // HandleStaffLessonBookingFormNew handles GET /api/v1/staff/lessons/booking/new.
-func HandleStaffLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleStaffLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -247,7 +246,7 @@ func HandleStaffLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
 
 	if showFacilitySelector {
 		var err error
-		facilities, err = loadFacilities(ctx)
+		facilities, err = h.loadFacilities(ctx)
 		if err != nil {
 			logger.Error().Err(err).Msg("Failed to load facilities")
 			http.Error(w, "Failed to load facilities", http.StatusInternalServerError)
@@ -279,7 +278,7 @@ func HandleStaffLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	proRows, err := queries.ListProsByFacility(ctx, sql.NullInt64{Int64: selectedFacilityID, Valid: true})
+	proRows, err := h.queries.ListProsByFacility(ctx, sql.NullInt64{Int64: selectedFacilityID, Valid: true})
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", selectedFacilityID).Msg("Failed to load pros")
 		http.Error(w, "Failed to load pros", http.StatusInternalServerError)
@@ -289,7 +288,7 @@ func HandleStaffLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
 	proOptions := reservationstempl.NewProOptions(proRows)
 	selectedProID := selectedProIDFromRequest(r, proOptions)
 
-	memberRows, err := queries.ListMembers(ctx, dbgen.ListMembersParams{
+	memberRows, err := h.queries.ListMembers(ctx, dbgen.ListMembersParams{
 		FacilityID: sql.NullInt64{Int64: selectedFacilityID, Valid: true},
 		SearchTerm: nil,
 		Offset:     0,
@@ -315,7 +314,7 @@ func HandleStaffLessonBookingFormNew(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleStaffProScheduleView handles GET /api/v1/staff/lessons/schedule.
-func HandleStaffProScheduleView(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleStaffProScheduleView(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
@@ -323,7 +322,7 @@ func HandleStaffProScheduleView(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -338,7 +337,7 @@ func HandleStaffProScheduleView(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	facilityID, err := resolveStaffLessonFacility(ctx, r, user)
+	facilityID, err := h.resolveStaffLessonFacility(ctx, r, user)
 	if err != nil {
 		if facilityErr, ok := err.(staffLessonFacilityError); ok {
 			http.Error(w, facilityErr.msg, facilityErr.status)
@@ -360,7 +359,7 @@ func HandleStaffProScheduleView(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	proRow, err := queries.GetStaffByID(ctx, proID)
+	proRow, err := h.queries.GetStaffByID(ctx, proID)
 	if err != nil {
 		logger.Error().Err(err).Int64("pro_id", proID).Msg("Failed to load pro")
 		http.Error(w, "Pro not found", http.StatusNotFound)
@@ -371,23 +370,15 @@ func HandleStaffProScheduleView(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	facilityLoc := time.Local
-	facility, err := queries.GetFacilityByID(ctx, facilityID)
+	facility, err := h.queries.GetFacilityByID(ctx, facilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
 		http.Error(w, "Failed to load facility", http.StatusInternalServerError)
 		return
 	}
-	if facility.Timezone != "" {
-		loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-		if loadErr != nil {
-			logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone")
-		} else {
-			facilityLoc = loadedLoc
-		}
-	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
 
-	sessions, err := queries.GetFutureProSessionsByStaffID(ctx, dbgen.GetFutureProSessionsByStaffIDParams{
+	sessions, err := h.queries.GetFutureProSessionsByStaffID(ctx, dbgen.GetFutureProSessionsByStaffIDParams{
 		ProID:     sql.NullInt64{Int64: proID, Valid: true},
 		StartTime: time.Now().In(facilityLoc),
 	})
@@ -412,10 +403,10 @@ func HandleStaffProScheduleView(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleStaffLessonBookingSlots handles GET /api/v1/staff/lessons/booking/slots.
-func HandleStaffLessonBookingSlots(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleStaffLessonBookingSlots(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -430,7 +421,7 @@ func HandleStaffLessonBookingSlots(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	selectedFacilityID, err := resolveStaffLessonFacility(ctx, r, user)
+	selectedFacilityID, err := h.resolveStaffLessonFacility(ctx, r, user)
 	if err != nil {
 		if facilityErr, ok := err.(staffLessonFacilityError); ok {
 			http.Error(w, facilityErr.msg, facilityErr.status)
@@ -452,7 +443,7 @@ func HandleStaffLessonBookingSlots(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	proRow, err := queries.GetStaffByID(ctx, proID)
+	proRow, err := h.queries.GetStaffByID(ctx, proID)
 	if err != nil {
 		logger.Error().Err(err).Int64("pro_id", proID).Msg("Failed to load pro")
 		http.Error(w, "Pro not found", http.StatusNotFound)
@@ -469,23 +460,15 @@ func HandleStaffLessonBookingSlots(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	facilityLoc := time.Local
-	facility, err := queries.GetFacilityByID(ctx, selectedFacilityID)
+	facility, err := h.queries.GetFacilityByID(ctx, selectedFacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", selectedFacilityID).Msg("Failed to load facility")
 		http.Error(w, "Failed to load facility", http.StatusInternalServerError)
 		return
 	}
-	if facility.Timezone != "" {
-		loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-		if loadErr != nil {
-			logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone")
-		} else {
-			facilityLoc = loadedLoc
-		}
-	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
 
-	slots, err := buildLessonSlotOptions(ctx, selectedFacilityID, proID, lessonDate, facilityLoc)
+	slots, err := h.buildLessonSlotOptions(ctx, selectedFacilityID, proID, lessonDate, facilityLoc)
 	if err != nil {
 		logger.Error().Err(err).Int64("pro_id", proID).Msg("Failed to load lesson slots")
 		http.Error(w, "Failed to load lesson slots", http.StatusInternalServerError)
@@ -511,7 +494,7 @@ func HandleStaffLessonBookingSlots(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleStaffLessonBookingCreate handles POST /api/v1/staff/lessons/booking.
-func HandleStaffLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleStaffLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodPost {
@@ -519,7 +502,7 @@ func HandleStaffLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if queries == nil || store == nil {
+	if h.queries == nil || h.store == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -539,7 +522,7 @@ func HandleStaffLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	selectedFacilityID, err := resolveStaffLessonFacilityValue(ctx, user, r.FormValue("facility_id"))
+	selectedFacilityID, err := h.resolveStaffLessonFacilityValue(ctx, user, r.FormValue("facility_id"))
 	if err != nil {
 		if facilityErr, ok := err.(staffLessonFacilityError); ok {
 			http.Error(w, facilityErr.msg, facilityErr.status)
@@ -555,21 +538,13 @@ func HandleStaffLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	facilityLoc := time.Local
-	facility, err := queries.GetFacilityByID(ctx, selectedFacilityID)
+	facility, err := h.queries.GetFacilityByID(ctx, selectedFacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", selectedFacilityID).Msg("Failed to load facility")
 		http.Error(w, "Failed to load facility", http.StatusInternalServerError)
 		return
 	}
-	if facility.Timezone != "" {
-		loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-		if loadErr != nil {
-			logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone")
-		} else {
-			facilityLoc = loadedLoc
-		}
-	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
 
 	proID, err := apiutil.ParseRequiredInt64Field(r.FormValue("pro_id"), "pro_id")
 	if err != nil {
@@ -582,18 +557,18 @@ func HandleStaffLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	startTime, err := parseStaffLessonTime(r.FormValue("start_time"), "start_time", facilityLoc)
+	startTime, err := apiutil.ParseFlexibleTime(r.FormValue("start_time"), "start_time", facilityLoc)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	endTime, err := parseStaffLessonTime(r.FormValue("end_time"), "end_time", facilityLoc)
+	endTime, err := apiutil.ParseFlexibleTime(r.FormValue("end_time"), "end_time", facilityLoc)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	created, err := createStaffLessonReservation(ctx, staffLessonReservationInput{
+	created, err := h.createStaffLessonReservation(ctx, staffLessonReservationInput{
 		FacilityID:      selectedFacilityID,
 		Facility:        facility,
 		FacilityLoc:     facilityLoc,
@@ -617,7 +592,7 @@ func HandleStaffLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("HX-Trigger", "refreshCourtsCalendar")
+	apiutil.AppendHXTrigger(w, "refreshCourtsCalendar")
 	if err := apiutil.WriteJSON(w, http.StatusCreated, created); err != nil {
 		logger.Error().Err(err).Int64("reservation_id", created.ID).Msg("Failed to write lesson reservation response")
 		return
@@ -625,7 +600,7 @@ func HandleStaffLessonBookingCreate(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleStaffLessonCreate handles POST /api/v1/staff/lessons.
-func HandleStaffLessonCreate(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleStaffLessonCreate(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodPost {
@@ -633,7 +608,7 @@ func HandleStaffLessonCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if queries == nil || store == nil {
+	if h.queries == nil || h.store == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -693,7 +668,7 @@ func HandleStaffLessonCreate(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	proRow, err := queries.GetStaffByID(ctx, proID)
+	proRow, err := h.queries.GetStaffByID(ctx, proID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Pro not found", http.StatusNotFound)
@@ -715,34 +690,26 @@ func HandleStaffLessonCreate(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	facilityLoc := time.Local
-	facility, err := queries.GetFacilityByID(ctx, facilityID)
+	facility, err := h.queries.GetFacilityByID(ctx, facilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load facility")
 		http.Error(w, "Failed to load facility", http.StatusInternalServerError)
 		return
 	}
-	if facility.Timezone != "" {
-		loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-		if loadErr != nil {
-			logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone")
-		} else {
-			facilityLoc = loadedLoc
-		}
-	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
 
-	startTime, err := parseStaffLessonTime(startRaw, "start_time", facilityLoc)
+	startTime, err := apiutil.ParseFlexibleTime(startRaw, "start_time", facilityLoc)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	endTime, err := parseStaffLessonTime(endRaw, "end_time", facilityLoc)
+	endTime, err := apiutil.ParseFlexibleTime(endRaw, "end_time", facilityLoc)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	created, err := createStaffLessonReservation(ctx, staffLessonReservationInput{
+	created, err := h.createStaffLessonReservation(ctx, staffLessonReservationInput{
 		FacilityID:      facilityID,
 		Facility:        facility,
 		FacilityLoc:     facilityLoc,
@@ -767,7 +734,7 @@ func HandleStaffLessonCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("HX-Trigger", "refreshCourtsCalendar")
+	apiutil.AppendHXTrigger(w, "refreshCourtsCalendar")
 	if err := apiutil.WriteJSON(w, http.StatusCreated, created); err != nil {
 		logger.Error().Err(err).Int64("reservation_id", created.ID).Msg("Failed to write lesson reservation response")
 		return
@@ -775,7 +742,7 @@ func HandleStaffLessonCreate(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleStaffMemberSearch handles GET /api/v1/staff/members/search.
-func HandleStaffMemberSearch(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleStaffMemberSearch(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	if r.Method != http.MethodGet {
@@ -783,7 +750,7 @@ func HandleStaffMemberSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -798,7 +765,7 @@ func HandleStaffMemberSearch(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	facilityID, err := resolveStaffLessonFacility(ctx, r, user)
+	facilityID, err := h.resolveStaffLessonFacility(ctx, r, user)
 	if err != nil {
 		if facilityErr, ok := err.(staffLessonFacilityError); ok {
 			http.Error(w, facilityErr.msg, facilityErr.status)
@@ -823,7 +790,7 @@ func HandleStaffMemberSearch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	limit := parseMemberSearchLimit(r.URL.Query().Get("limit"))
-	rows, err := queries.ListMembers(ctx, dbgen.ListMembersParams{
+	rows, err := h.queries.ListMembers(ctx, dbgen.ListMembersParams{
 		FacilityID: sql.NullInt64{Int64: facilityID, Valid: true},
 		SearchTerm: sql.NullString{String: searchTerm, Valid: true},
 		Offset:     0,
@@ -889,10 +856,10 @@ func (e staffLessonFacilityError) Error() string {
 	return e.msg
 }
 
-func resolveStaffLessonFacility(ctx context.Context, r *http.Request, user *authz.AuthUser) (int64, error) {
+func (h *Handlers) resolveStaffLessonFacility(ctx context.Context, r *http.Request, user *authz.AuthUser) (int64, error) {
 	facilityID, hasFacility := request.ParseFacilityID(r.URL.Query().Get("facility_id"))
 	if user.HomeFacilityID == nil {
-		facilities, err := loadFacilities(ctx)
+		facilities, err := h.loadFacilities(ctx)
 		if err != nil {
 			return 0, staffLessonFacilityError{status: http.StatusInternalServerError, msg: "Failed to load facilities"}
 		}
@@ -913,10 +880,10 @@ func resolveStaffLessonFacility(ctx context.Context, r *http.Request, user *auth
 	return facilityID, nil
 }
 
-func resolveStaffLessonFacilityValue(ctx context.Context, user *authz.AuthUser, raw string) (int64, error) {
+func (h *Handlers) resolveStaffLessonFacilityValue(ctx context.Context, user *authz.AuthUser, raw string) (int64, error) {
 	facilityID, hasFacility := request.ParseFacilityID(raw)
 	if user.HomeFacilityID == nil {
-		facilities, err := loadFacilities(ctx)
+		facilities, err := h.loadFacilities(ctx)
 		if err != nil {
 			return 0, staffLessonFacilityError{status: http.StatusInternalServerError, msg: "Failed to load facilities"}
 		}
@@ -974,12 +941,12 @@ func parseLessonDate(r *http.Request) (time.Time, error) {
 	return parsed, nil
 }
 
-func buildLessonSlotOptions(ctx context.Context, facilityID, proID int64, lessonDate time.Time, facilityLoc *time.Location) ([]reservationstempl.StaffLessonSlotOption, error) {
+func (h *Handlers) buildLessonSlotOptions(ctx context.Context, facilityID, proID int64, lessonDate time.Time, facilityLoc *time.Location) ([]reservationstempl.StaffLessonSlotOption, error) {
 	if facilityLoc == nil {
-		facilityLoc = time.Local
+		facilityLoc = apiutil.DefaultLocation()
 	}
 	slotMinutes := fmt.Sprintf("%d", int64(time.Hour.Minutes()))
-	rows, err := queries.GetProLessonSlots(ctx, dbgen.GetProLessonSlotsParams{
+	rows, err := h.queries.GetProLessonSlots(ctx, dbgen.GetProLessonSlotsParams{
 		TargetDate:  lessonDate.Format("2006-01-02"),
 		FacilityID:  facilityID,
 		SlotMinutes: sql.NullString{String: slotMinutes, Valid: true},
@@ -1038,18 +1005,6 @@ func parseLessonSlotString(raw string, loc *time.Location) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("invalid slot time")
 }
 
-func parseStaffLessonTime(raw string, field string, loc *time.Location) (time.Time, error) {
-	raw = strings.TrimSpace(raw)
-	if raw == "" {
-		return time.Time{}, fmt.Errorf("%s is required", field)
-	}
-	parsed, err := time.ParseInLocation(staffLessonTimeLayout, raw, loc)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("%s must be in YYYY-MM-DD HH:MM format", field)
-	}
-	return parsed, nil
-}
-
 func lookupReservationTypeID(ctx context.Context, q *dbgen.Queries, name string) (int64, error) {
 	resType, err := q.GetReservationTypeByName(ctx, name)
 	if err != nil {
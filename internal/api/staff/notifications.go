@@ -10,21 +10,307 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/api/htmx"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/models"
+	"github.com/codr1/Pickleicious/internal/request"
+	notificationtempl "github.com/codr1/Pickleicious/internal/templates/components/notifications"
 	stafftempl "github.com/codr1/Pickleicious/internal/templates/components/staff"
 	"github.com/codr1/Pickleicious/internal/templates/layouts"
 )
 
+const (
+	notificationInboxDefaultLimit = 25
+	notificationInboxMaxLimit     = 100
+)
+
+// notificationInbox scopes inbox queries to one staff member: notifications
+// targeted at them plus untargeted ones, at their facility. FacilityID is nil
+// for corporate staff viewing every facility; StaffID is invalid for staff
+// users without a staff record, who see only untargeted notifications.
+type notificationInbox struct {
+	FacilityID interface{}
+	StaffID    sql.NullInt64
+}
+
+type staffNotificationResponse struct {
+	ID                     int64     `json:"id"`
+	FacilityID             int64     `json:"facilityId"`
+	Type                   string    `json:"type"`
+	Message                string    `json:"message"`
+	RelatedSessionID       *int64    `json:"relatedSessionId"`
+	RelatedReservationID   *int64    `json:"relatedReservationId"`
+	RelatedClinicSessionID *int64    `json:"relatedClinicSessionId"`
+	TargetStaffID          *int64    `json:"targetStaffId"`
+	Read                   bool      `json:"read"`
+	CreatedAt              time.Time `json:"createdAt"`
+}
+
+func newStaffNotificationResponse(row dbgen.StaffNotification) staffNotificationResponse {
+	return staffNotificationResponse{
+		ID:                     row.ID,
+		FacilityID:             row.FacilityID,
+		Type:                   row.NotificationType,
+		Message:                row.Message,
+		RelatedSessionID:       apiutil.NullInt64Ptr(row.RelatedSessionID),
+		RelatedReservationID:   apiutil.NullInt64Ptr(row.RelatedReservationID),
+		RelatedClinicSessionID: apiutil.NullInt64Ptr(row.RelatedClinicSessionID),
+		TargetStaffID:          apiutil.NullInt64Ptr(row.TargetStaffID),
+		Read:                   row.Read,
+		CreatedAt:              row.CreatedAt,
+	}
+}
+
+// resolveNotificationInbox works out whose inbox the request reads. Staff
+// with a home facility see only that facility; corporate staff may narrow
+// to one with ?facility_id=. It writes the error response when it fails.
+func (h *Handlers) resolveNotificationInbox(ctx context.Context, w http.ResponseWriter, r *http.Request) (notificationInbox, bool) {
+	logger := log.Ctx(r.Context())
+
+	user := authz.UserFromContext(r.Context())
+	if !authz.IsStaff(user) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return notificationInbox{}, false
+	}
+
+	var inbox notificationInbox
+	if user.HomeFacilityID != nil {
+		inbox.FacilityID = *user.HomeFacilityID
+	} else if raw := r.URL.Query().Get("facility_id"); raw != "" {
+		facilityID, ok := request.ParseFacilityID(raw)
+		if !ok {
+			http.Error(w, "Invalid facility_id", http.StatusBadRequest)
+			return notificationInbox{}, false
+		}
+		if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+			return notificationInbox{}, false
+		}
+		inbox.FacilityID = facilityID
+	}
+
+	staffRow, err := h.queries.GetStaffByUserID(ctx, user.ID)
+	switch {
+	case err == nil:
+		inbox.StaffID = sql.NullInt64{Int64: staffRow.ID, Valid: true}
+	case errors.Is(err, sql.ErrNoRows):
+	default:
+		logger.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to load staff record")
+		http.Error(w, "Failed to load staff", http.StatusInternalServerError)
+		return notificationInbox{}, false
+	}
+	return inbox, true
+}
+
+func (h *Handlers) listNotificationInbox(ctx context.Context, inbox notificationInbox, unreadOnly bool, limit, offset int64) ([]dbgen.StaffNotification, error) {
+	return h.queries.ListStaffInboxNotifications(ctx, dbgen.ListStaffInboxNotificationsParams{
+		FacilityID: inbox.FacilityID,
+		StaffID:    inbox.StaffID,
+		UnreadOnly: unreadOnly,
+		Offset:     offset,
+		Limit:      limit,
+	})
+}
+
+func (h *Handlers) countNotificationInboxUnread(ctx context.Context, inbox notificationInbox) (int64, error) {
+	return h.queries.CountUnreadStaffInboxNotifications(ctx, dbgen.CountUnreadStaffInboxNotificationsParams{
+		FacilityID: inbox.FacilityID,
+		StaffID:    inbox.StaffID,
+	})
+}
+
+func parseNotificationInboxPage(r *http.Request) (limit, offset int64) {
+	limit = notificationInboxDefaultLimit
+	if parsed, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64); err == nil && parsed > 0 {
+		limit = min(parsed, notificationInboxMaxLimit)
+	}
+	if parsed, err := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64); err == nil && parsed > 0 {
+		offset = parsed
+	}
+	return limit, offset
+}
+
+// HandleStaffNotifications handles GET /api/v1/staff/notifications. JSON
+// callers get the page and the unread count; HTMX gets the navbar panel.
+// ?unread=true lists only unread notifications.
+func (h *Handlers) HandleStaffNotifications(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if h.queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
+	defer cancel()
+
+	inbox, ok := h.resolveNotificationInbox(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	limit, offset := parseNotificationInboxPage(r)
+	rows, err := h.listNotificationInbox(ctx, inbox, apiutil.ParseBool(r.URL.Query().Get("unread")), limit, offset)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list staff notifications")
+		http.Error(w, "Failed to load notifications", http.StatusInternalServerError)
+		return
+	}
+
+	if htmx.IsRequest(r) {
+		component := notificationtempl.NotificationsPanel(notificationtempl.NewNotifications(rows))
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render notifications panel", "Failed to render notifications panel")
+		return
+	}
+
+	unread, err := h.countNotificationInboxUnread(ctx, inbox)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to count staff notifications")
+		http.Error(w, "Failed to load notifications", http.StatusInternalServerError)
+		return
+	}
+	notifications := make([]staffNotificationResponse, 0, len(rows))
+	for _, row := range rows {
+		notifications = append(notifications, newStaffNotificationResponse(row))
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{
+		"notifications": notifications,
+		"unreadCount":   unread,
+	}); err != nil {
+		logger.Error().Err(err).Msg("Failed to write staff notifications response")
+	}
+}
+
+// HandleStaffNotificationUnreadCount handles GET
+// /api/v1/staff/notifications/unread-count. HTMX gets the navbar badge,
+// which reloads on the refreshNotificationCount event.
+func (h *Handlers) HandleStaffNotificationUnreadCount(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if h.queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
+	defer cancel()
+
+	inbox, ok := h.resolveNotificationInbox(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	unread, err := h.countNotificationInboxUnread(ctx, inbox)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to count staff notifications")
+		http.Error(w, "Failed to load notifications", http.StatusInternalServerError)
+		return
+	}
+
+	if htmx.IsRequest(r) {
+		component := notificationtempl.NotificationCountBadge(unread)
+		apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render notifications count", "Failed to render notifications count")
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]int64{"unreadCount": unread}); err != nil {
+		logger.Error().Err(err).Msg("Failed to write notification count response")
+	}
+}
+
+// HandleStaffNotificationUpdate handles PATCH /api/v1/staff/notifications/{id}.
+// The body's read field (JSON or form) defaults to true, so an empty PATCH
+// marks the notification read; read=false marks it unread again.
+func (h *Handlers) HandleStaffNotificationUpdate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	if h.queries == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	notificationID, err := strconv.ParseInt(strings.TrimSpace(r.PathValue("id")), 10, 64)
+	if err != nil || notificationID <= 0 {
+		http.Error(w, "Invalid notification ID", http.StatusBadRequest)
+		return
+	}
+
+	read := true
+	if apiutil.IsJSONRequest(r) && r.ContentLength != 0 {
+		var payload struct {
+			Read *bool `json:"read"`
+		}
+		if err := apiutil.DecodeJSON(r, &payload); err != nil {
+			http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		if payload.Read != nil {
+			read = *payload.Read
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+		if _, ok := r.Form["read"]; ok {
+			read = apiutil.ParseBool(r.FormValue("read"))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
+	defer cancel()
+
+	inbox, ok := h.resolveNotificationInbox(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	updated, err := h.queries.SetStaffInboxNotificationRead(ctx, dbgen.SetStaffInboxNotificationReadParams{
+		Read:       read,
+		ID:         notificationID,
+		FacilityID: inbox.FacilityID,
+		StaffID:    inbox.StaffID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Notification not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("notification_id", notificationID).Msg("Failed to update staff notification")
+		http.Error(w, "Failed to update notification", http.StatusInternalServerError)
+		return
+	}
+
+	apiutil.AppendHXTrigger(w, "refreshNotificationCount")
+	if !htmx.IsRequest(r) {
+		if err := apiutil.WriteJSON(w, http.StatusOK, newStaffNotificationResponse(updated)); err != nil {
+			logger.Error().Err(err).Int64("notification_id", notificationID).Msg("Failed to write staff notification response")
+		}
+		return
+	}
+
+	rows, err := h.listNotificationInbox(ctx, inbox, false, notificationInboxDefaultLimit, 0)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list staff notifications")
+		http.Error(w, "Failed to load notifications", http.StatusInternalServerError)
+		return
+	}
+	component := notificationtempl.NotificationsPanel(notificationtempl.NewNotifications(rows))
+	apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render notifications panel", "Failed to render notifications panel")
+}
+
 // HandleNotificationDetail handles GET /staff/notifications/{id}.
-func HandleNotificationDetail(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleNotificationDetail(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -50,7 +336,7 @@ func HandleNotificationDetail(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	staffRow, err := queries.GetStaffByUserID(ctx, user.ID)
+	staffRow, err := h.queries.GetStaffByUserID(ctx, user.ID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Forbidden", http.StatusForbidden)
@@ -61,7 +347,7 @@ func HandleNotificationDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	notification, err := queries.GetStaffNotificationByID(ctx, notificationID)
+	notification, err := h.queries.GetStaffNotificationByID(ctx, notificationID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Notification not found", http.StatusNotFound)
@@ -82,7 +368,7 @@ func HandleNotificationDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	reservation, err := queries.GetReservationByID(ctx, notification.RelatedReservationID.Int64)
+	reservation, err := h.queries.GetReservationByID(ctx, notification.RelatedReservationID.Int64)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Reservation not found", http.StatusNotFound)
@@ -98,7 +384,7 @@ func HandleNotificationDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	member, err := queries.GetUserByID(ctx, reservation.PrimaryUserID.Int64)
+	member, err := h.queries.GetUserByID(ctx, reservation.PrimaryUserID.Int64)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Member not found", http.StatusNotFound)
@@ -109,7 +395,7 @@ func HandleNotificationDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	courtRows, err := queries.ListReservationCourts(ctx, reservation.ID)
+	courtRows, err := h.queries.ListReservationCourts(ctx, reservation.ID)
 	if err != nil {
 		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to load reservation courts")
 		http.Error(w, "Failed to load courts", http.StatusInternalServerError)
@@ -141,7 +427,7 @@ func HandleNotificationDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !notification.Read {
-		updated, err := queries.MarkStaffNotificationAsRead(ctx, dbgen.MarkStaffNotificationAsReadParams{
+		updated, err := h.queries.MarkStaffNotificationAsRead(ctx, dbgen.MarkStaffNotificationAsReadParams{
 			ID:         notification.ID,
 			FacilityID: notification.FacilityID,
 		})
@@ -149,7 +435,7 @@ func HandleNotificationDetail(w http.ResponseWriter, r *http.Request) {
 			logger.Error().Err(err).Int64("notification_id", notification.ID).Msg("Failed to mark notification as read")
 		} else {
 			notification = updated
-			w.Header().Set("HX-Trigger", "refreshNotificationCount")
+			apiutil.AppendHXTrigger(w, "refreshNotificationCount")
 		}
 	}
 
@@ -164,7 +450,7 @@ func HandleNotificationDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var activeTheme *models.Theme
-	activeTheme, err = models.GetActiveTheme(ctx, queries, notification.FacilityID)
+	activeTheme, err = models.GetActiveTheme(ctx, h.queries, notification.FacilityID)
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", notification.FacilityID).Msg("Failed to load active theme")
 		activeTheme = nil
@@ -0,0 +1,204 @@
+package staff
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type notificationFixture struct {
+	h          *Handlers
+	database   *db.DB
+	facilityID int64
+	exec       func(query string, args ...any) int64
+}
+
+func setupNotificationTest(t *testing.T) notificationFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.Exec(query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main', 'main', 'UTC')", orgID)
+
+	return notificationFixture{
+		h:          NewHandlers(database),
+		database:   database,
+		facilityID: facilityID,
+		exec:       exec,
+	}
+}
+
+// insertStaff returns the new staff member's user and staff IDs.
+func (f notificationFixture) insertStaff(email, firstName, role string) (int64, int64) {
+	userID := f.exec("INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES (?, 'Staff', ?, 'active', 1)", firstName, email)
+	staffID := f.exec("INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, ?, 'Staff', ?, ?)", userID, firstName, f.facilityID, role)
+	return userID, staffID
+}
+
+func (f notificationFixture) asStaff(req *http.Request, userID int64) *http.Request {
+	facilityID := f.facilityID
+	return req.WithContext(authz.ContextWithUser(req.Context(), &authz.AuthUser{
+		ID:             userID,
+		IsStaff:        true,
+		HomeFacilityID: &facilityID,
+	}))
+}
+
+func (f notificationFixture) unreadCount(t *testing.T, userID int64) int64 {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	f.h.HandleStaffNotificationUnreadCount(recorder, f.asStaff(httptest.NewRequest(http.MethodGet, "/api/v1/staff/notifications/unread-count", nil), userID))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("unread count status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var body struct {
+		UnreadCount int64 `json:"unreadCount"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode unread count: %v", err)
+	}
+	return body.UnreadCount
+}
+
+func (f notificationFixture) setRead(userID, notificationID int64, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/staff/notifications/%d", notificationID), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", fmt.Sprintf("%d", notificationID))
+	recorder := httptest.NewRecorder()
+	f.h.HandleStaffNotificationUpdate(recorder, f.asStaff(req, userID))
+	return recorder
+}
+
+func TestStaffNotificationInbox_TargetedAndBroadcast(t *testing.T) {
+	fixture := setupNotificationTest(t)
+
+	patUserID, patStaffID := fixture.insertStaff("pat@test.com", "Pat", "pro")
+	_, robinStaffID := fixture.insertStaff("robin@test.com", "Robin", "pro")
+	otherFacilityID := fixture.exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES ((SELECT organization_id FROM facilities WHERE id = ?), 'Other', 'other', 'UTC')", fixture.facilityID)
+
+	insertNotification := func(facilityID int64, kind string, target any) int64 {
+		return fixture.exec(
+			"INSERT INTO staff_notifications (facility_id, notification_type, message, target_staff_id) VALUES (?, ?, ?, ?)",
+			facilityID, kind, kind+" message", target,
+		)
+	}
+	ownID := insertNotification(fixture.facilityID, "lesson_cancelled", patStaffID)
+	robinsID := insertNotification(fixture.facilityID, "lesson_cancelled", robinStaffID)
+	broadcastID := insertNotification(fixture.facilityID, "waitlist_fulfilled", nil)
+	insertNotification(otherFacilityID, "waitlist_fulfilled", nil)
+
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleStaffNotifications(recorder, fixture.asStaff(httptest.NewRequest(http.MethodGet, "/api/v1/staff/notifications", nil), patUserID))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("list status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var list struct {
+		Notifications []staffNotificationResponse `json:"notifications"`
+		UnreadCount   int64                       `json:"unreadCount"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	got := map[int64]bool{}
+	for _, notification := range list.Notifications {
+		got[notification.ID] = true
+	}
+	if len(list.Notifications) != 2 || !got[ownID] || !got[broadcastID] || list.UnreadCount != 2 {
+		t.Fatalf("expected Pat's own and the facility broadcast, got %+v", list)
+	}
+
+	if recorder := fixture.setRead(patUserID, robinsID, ""); recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected another pro's notification to 404, got %d", recorder.Code)
+	}
+
+	recorder = fixture.setRead(patUserID, ownID, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("mark read status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if trigger := recorder.Header().Get("HX-Trigger"); !strings.Contains(trigger, "refreshNotificationCount") {
+		t.Fatalf("expected a count refresh trigger, got %q", trigger)
+	}
+	if count := fixture.unreadCount(t, patUserID); count != 1 {
+		t.Fatalf("expected 1 unread after marking read, got %d", count)
+	}
+
+	recorder = httptest.NewRecorder()
+	fixture.h.HandleStaffNotifications(recorder, fixture.asStaff(httptest.NewRequest(http.MethodGet, "/api/v1/staff/notifications?unread=true", nil), patUserID))
+	if err := json.Unmarshal(recorder.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode unread list: %v", err)
+	}
+	if len(list.Notifications) != 1 || list.Notifications[0].ID != broadcastID {
+		t.Fatalf("expected only the unread broadcast, got %+v", list.Notifications)
+	}
+
+	if recorder := fixture.setRead(patUserID, ownID, `{"read": false}`); recorder.Code != http.StatusOK {
+		t.Fatalf("mark unread status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if count := fixture.unreadCount(t, patUserID); count != 2 {
+		t.Fatalf("expected 2 unread after marking unread, got %d", count)
+	}
+}
+
+func TestHandleConfirmDeactivation_ReassignNotifiesReceivingPro(t *testing.T) {
+	fixture := setupNotificationTest(t)
+
+	adminUserID, _ := fixture.insertStaff("admin@test.com", "Alex", "admin")
+	_, leavingStaffID := fixture.insertStaff("leaving@test.com", "Pat", "pro")
+	receivingUserID, receivingStaffID := fixture.insertStaff("receiving@test.com", "Robin", "pro")
+	memberID := fixture.exec("INSERT INTO users (first_name, last_name, email, status, is_member) VALUES ('Mia', 'Member', 'mia@test.com', 'active', 1)")
+
+	start := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Hour)
+	lessonID := fixture.exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, primary_user_id, pro_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types WHERE name = 'PRO_SESSION'), ?, ?, ?, ?, ?)`,
+		fixture.facilityID, memberID, memberID, leavingStaffID, start, start.Add(time.Hour),
+	)
+
+	form := url.Values{}
+	form.Set("deactivation_action", "reassign")
+	form.Set("reassign_to", fmt.Sprintf("%d", receivingStaffID))
+	form.Set("expected_sessions", "1")
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/staff/%d/deactivation/confirm", leavingStaffID), strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+	fixture.h.HandleConfirmDeactivation(recorder, fixture.asStaff(req, adminUserID))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("confirm status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var (
+		targetStaffID        int64
+		relatedReservationID int64
+		message              string
+	)
+	if err := fixture.database.QueryRow(
+		"SELECT target_staff_id, related_reservation_id, message FROM staff_notifications WHERE notification_type = 'lesson_reassigned'",
+	).Scan(&targetStaffID, &relatedReservationID, &message); err != nil {
+		t.Fatalf("load reassignment notification: %v", err)
+	}
+	if targetStaffID != receivingStaffID || relatedReservationID != lessonID || !strings.Contains(message, "Pat Staff") {
+		t.Fatalf("unexpected notification: target %d, reservation %d, message %q", targetStaffID, relatedReservationID, message)
+	}
+	if count := fixture.unreadCount(t, receivingUserID); count != 1 {
+		t.Fatalf("expected the receiving pro to have 1 unread, got %d", count)
+	}
+}
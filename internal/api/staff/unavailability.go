@@ -22,10 +22,10 @@ import (
 const staffUnavailabilityTimeLayout = "2006-01-02T15:04"
 
 // GET /staff/unavailability
-func HandleListProUnavailability(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleListProUnavailability(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -34,12 +34,12 @@ func HandleListProUnavailability(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	staffRow, ok := requireProStaff(w, r, ctx)
+	staffRow, ok := h.requireProStaff(w, r, ctx)
 	if !ok {
 		return
 	}
 
-	rows, err := queries.ListProUnavailabilityByProID(ctx, staffRow.ID)
+	rows, err := h.queries.ListProUnavailabilityByProID(ctx, staffRow.ID)
 	if err != nil {
 		logger.Error().Err(err).Int64("pro_id", staffRow.ID).Msg("Failed to load pro unavailability")
 		http.Error(w, "Failed to load unavailability", http.StatusInternalServerError)
@@ -48,7 +48,7 @@ func HandleListProUnavailability(w http.ResponseWriter, r *http.Request) {
 
 	var activeTheme *models.Theme
 	if staffRow.HomeFacilityID.Valid {
-		theme, err := models.GetActiveTheme(ctx, queries, staffRow.HomeFacilityID.Int64)
+		theme, err := models.GetActiveTheme(ctx, h.queries, staffRow.HomeFacilityID.Int64)
 		if err != nil {
 			logger.Error().Err(err).Int64("facility_id", staffRow.HomeFacilityID.Int64).Msg("Failed to load active theme")
 		} else {
@@ -65,10 +65,10 @@ func HandleListProUnavailability(w http.ResponseWriter, r *http.Request) {
 }
 
 // POST /staff/unavailability
-func HandleCreateProUnavailability(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleCreateProUnavailability(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -77,7 +77,7 @@ func HandleCreateProUnavailability(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	staffRow, ok := requireProStaff(w, r, ctx)
+	staffRow, ok := h.requireProStaff(w, r, ctx)
 	if !ok {
 		return
 	}
@@ -107,7 +107,7 @@ func HandleCreateProUnavailability(w http.ResponseWriter, r *http.Request) {
 		Reason:    sql.NullString{String: reason, Valid: reason != ""},
 	}
 
-	blockID, err := queries.CreateProUnavailability(ctx, params)
+	blockID, err := h.queries.CreateProUnavailability(ctx, params)
 	if err != nil {
 		logger.Error().Err(err).Int64("pro_id", staffRow.ID).Msg("Failed to create pro unavailability")
 		http.Error(w, "Failed to create block", http.StatusInternalServerError)
@@ -121,7 +121,7 @@ func HandleCreateProUnavailability(w http.ResponseWriter, r *http.Request) {
 		Time("end_time", endTime).
 		Msg("Created pro unavailability block")
 
-	rows, err := queries.ListProUnavailabilityByProID(ctx, staffRow.ID)
+	rows, err := h.queries.ListProUnavailabilityByProID(ctx, staffRow.ID)
 	if err != nil {
 		logger.Error().Err(err).Int64("pro_id", staffRow.ID).Msg("Failed to reload pro unavailability")
 		http.Error(w, "Failed to load unavailability", http.StatusInternalServerError)
@@ -137,10 +137,10 @@ func HandleCreateProUnavailability(w http.ResponseWriter, r *http.Request) {
 }
 
 // DELETE /staff/unavailability/{id}
-func HandleDeleteProUnavailability(w http.ResponseWriter, r *http.Request) {
+func (h *Handlers) HandleDeleteProUnavailability(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
-	if queries == nil {
+	if h.queries == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -155,12 +155,12 @@ func HandleDeleteProUnavailability(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), staffQueryTimeout)
 	defer cancel()
 
-	staffRow, ok := requireProStaff(w, r, ctx)
+	staffRow, ok := h.requireProStaff(w, r, ctx)
 	if !ok {
 		return
 	}
 
-	block, err := queries.GetProUnavailabilityByID(ctx, blockID)
+	block, err := h.queries.GetProUnavailabilityByID(ctx, blockID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Unavailability block not found", http.StatusNotFound)
@@ -176,7 +176,7 @@ func HandleDeleteProUnavailability(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := queries.DeleteProUnavailability(ctx, blockID); err != nil {
+	if err := h.queries.DeleteProUnavailability(ctx, blockID); err != nil {
 		logger.Error().Err(err).Int64("id", blockID).Msg("Failed to delete unavailability block")
 		http.Error(w, "Failed to delete block", http.StatusInternalServerError)
 		return
@@ -189,7 +189,7 @@ func HandleDeleteProUnavailability(w http.ResponseWriter, r *http.Request) {
 		Time("end_time", block.EndTime).
 		Msg("Deleted pro unavailability block")
 
-	rows, err := queries.ListProUnavailabilityByProID(ctx, staffRow.ID)
+	rows, err := h.queries.ListProUnavailabilityByProID(ctx, staffRow.ID)
 	if err != nil {
 		logger.Error().Err(err).Int64("pro_id", staffRow.ID).Msg("Failed to reload pro unavailability")
 		http.Error(w, "Failed to load unavailability", http.StatusInternalServerError)
@@ -204,7 +204,7 @@ func HandleDeleteProUnavailability(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func requireProStaff(w http.ResponseWriter, r *http.Request, ctx context.Context) (dbgen.GetStaffByUserIDRow, bool) {
+func (h *Handlers) requireProStaff(w http.ResponseWriter, r *http.Request, ctx context.Context) (dbgen.GetStaffByUserIDRow, bool) {
 	logger := log.Ctx(r.Context())
 	user := authz.UserFromContext(r.Context())
 	if !authz.IsStaff(user) {
@@ -212,7 +212,7 @@ func requireProStaff(w http.ResponseWriter, r *http.Request, ctx context.Context
 		return dbgen.GetStaffByUserIDRow{}, false
 	}
 
-	staffRow, err := queries.GetStaffByUserID(ctx, user.ID)
+	staffRow, err := h.queries.GetStaffByUserID(ctx, user.ID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			http.Error(w, "Staff record not found", http.StatusForbidden)
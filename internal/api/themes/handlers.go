@@ -17,6 +17,7 @@ import (
 	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/api/authz"
 	"github.com/codr1/Pickleicious/internal/api/htmx"
+	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/models"
 	themetempl "github.com/codr1/Pickleicious/internal/templates/components/themes"
@@ -32,8 +33,9 @@ const (
 )
 
 var (
-	queries     themeQueries
-	queriesOnce sync.Once
+	queries       themeQueries
+	queriesOnce   sync.Once
+	facilityCache *appdb.FacilityCache
 )
 
 type themeQueries interface {
@@ -68,6 +70,16 @@ type facilityThemeRequest struct {
 	ThemeID int64 `json:"themeId"`
 }
 
+type facilityThemeResponse struct {
+	models.Theme
+	IsActive bool `json:"isActive"`
+}
+
+type facilityThemesResponse struct {
+	ActiveThemeID *int64                  `json:"activeThemeId"`
+	Themes        []facilityThemeResponse `json:"themes"`
+}
+
 // InitHandlers must be called during server startup before handling requests.
 func InitHandlers(q *dbgen.Queries) {
 	if q == nil {
@@ -78,6 +90,13 @@ func InitHandlers(q *dbgen.Queries) {
 	})
 }
 
+// InitFacilityCache sets the facility cache to invalidate when a facility's
+// settings change. Leaving it unset lets cached facilities go stale until
+// their TTL expires.
+func InitFacilityCache(cache *appdb.FacilityCache) {
+	facilityCache = cache
+}
+
 // /admin/themes
 func HandleThemesPage(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
@@ -335,7 +354,7 @@ func HandleThemeCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshThemesList")
+		apiutil.AppendHXTrigger(w, "refreshThemesList")
 		apiutil.WriteHTMLFeedback(w, http.StatusCreated, "Theme created.")
 		return
 	}
@@ -450,7 +469,7 @@ func HandleThemeUpdate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshThemesList")
+		apiutil.AppendHXTrigger(w, "refreshThemesList")
 		apiutil.WriteHTMLFeedback(w, http.StatusOK, "Theme updated.")
 		return
 	}
@@ -533,7 +552,7 @@ func HandleThemeDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshThemesList")
+		apiutil.AppendHXTrigger(w, "refreshThemesList")
 		apiutil.WriteHTMLFeedback(w, http.StatusOK, "Theme deleted.")
 		return
 	}
@@ -659,7 +678,7 @@ func HandleThemeClone(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshThemesList")
+		apiutil.AppendHXTrigger(w, "refreshThemesList")
 		apiutil.WriteHTMLFeedback(w, http.StatusCreated, "Theme cloned.")
 		return
 	}
@@ -669,6 +688,75 @@ func HandleThemeClone(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// /api/v1/facilities/{id}/themes
+func HandleFacilityThemes(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	facilityID, err := facilityIDFromPath(r)
+	if err != nil {
+		http.Error(w, "Invalid facility ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), themeQueryTimeout)
+	defer cancel()
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	systemThemes, err := models.GetSystemThemes(ctx, q)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list system themes")
+		http.Error(w, "Failed to load system themes", http.StatusInternalServerError)
+		return
+	}
+
+	facilityThemes, err := models.GetFacilityThemes(ctx, q, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list facility themes")
+		http.Error(w, "Failed to load facility themes", http.StatusInternalServerError)
+		return
+	}
+
+	activeThemeID, err := q.GetActiveThemeID(ctx, facilityID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to load active theme")
+		http.Error(w, "Failed to load active theme", http.StatusInternalServerError)
+		return
+	}
+
+	themes := themetempl.NewThemes(append(systemThemes, facilityThemes...), activeThemeID)
+	if htmx.IsRequest(r) {
+		component := themetempl.ThemeList(themes, facilityID)
+		if !apiutil.RenderHTMLComponent(r.Context(), w, component, nil, "Failed to render themes list", "Failed to render list") {
+			return
+		}
+		return
+	}
+
+	resp := facilityThemesResponse{
+		Themes: make([]facilityThemeResponse, 0, len(themes)),
+	}
+	if activeThemeID > 0 {
+		resp.ActiveThemeID = &activeThemeID
+	}
+	for _, theme := range themes {
+		resp.Themes = append(resp.Themes, facilityThemeResponse{Theme: theme.Theme, IsActive: theme.IsActive})
+	}
+	if err := apiutil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write facility themes response")
+	}
+}
+
+// /api/v1/facilities/{id}/themes/active
 func HandleFacilityThemeSet(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
@@ -713,7 +801,7 @@ func HandleFacilityThemeSet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !theme.IsSystem && theme.FacilityID.Valid && theme.FacilityID.Int64 != facilityID {
+	if !models.ThemeFromDB(theme).AvailableTo(facilityID) {
 		http.Error(w, "Theme does not belong to facility", http.StatusBadRequest)
 		return
 	}
@@ -734,9 +822,10 @@ func HandleFacilityThemeSet(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Facility not found", http.StatusNotFound)
 		return
 	}
+	facilityCache.Invalidate(facilityID)
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshThemesList")
+		apiutil.AppendHXTrigger(w, "refreshThemesList")
 		apiutil.WriteHTMLFeedback(w, http.StatusOK, "Active theme updated.")
 		return
 	}
@@ -510,3 +510,79 @@ func TestListThemes_ReturnsAllThemes(t *testing.T) {
 		}
 	}
 }
+
+func TestFacilityThemes_ListsAvailableThemesWithActiveFlag(t *testing.T) {
+	mock := setupThemeHandlers(t)
+	systemID := mock.addTheme(dbgen.Theme{
+		Name:           "Base System",
+		IsSystem:       true,
+		PrimaryColor:   "#1f2937",
+		SecondaryColor: "#e5e7eb",
+		TertiaryColor:  "#f9fafb",
+		AccentColor:    "#2563eb",
+		HighlightColor: "#16a34a",
+	})
+	facilityThemeID := mock.addTheme(dbgen.Theme{
+		FacilityID:     sql.NullInt64{Int64: 5, Valid: true},
+		Name:           "Facility One",
+		PrimaryColor:   "#111827",
+		SecondaryColor: "#d1d5db",
+		TertiaryColor:  "#f3f4f6",
+		AccentColor:    "#0ea5e9",
+		HighlightColor: "#15803d",
+	})
+	otherFacilityThemeID := mock.addTheme(dbgen.Theme{
+		FacilityID:     sql.NullInt64{Int64: 6, Valid: true},
+		Name:           "Elsewhere",
+		PrimaryColor:   "#1f2937",
+		SecondaryColor: "#e5e7eb",
+		TertiaryColor:  "#f9fafb",
+		AccentColor:    "#2563eb",
+		HighlightColor: "#16a34a",
+	})
+
+	setActive := func(themeID int64) int {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/facilities/5/themes/active", strings.NewReader(`{"themeId": `+strconv.FormatInt(themeID, 10)+`}`))
+		req = withAuthUser(req, 5)
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", "5")
+		recorder := httptest.NewRecorder()
+		HandleFacilityThemeSet(recorder, req)
+		return recorder.Code
+	}
+	if code := setActive(otherFacilityThemeID); code != http.StatusBadRequest {
+		t.Fatalf("expected another facility's theme to be rejected, got %d", code)
+	}
+	if code := setActive(facilityThemeID); code != http.StatusNoContent {
+		t.Fatalf("set active status: %d", code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/facilities/5/themes", nil)
+	req = withAuthUser(req, 5)
+	req.SetPathValue("id", "5")
+	recorder := httptest.NewRecorder()
+
+	HandleFacilityThemes(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status: %d", recorder.Code)
+	}
+	var resp facilityThemesResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.ActiveThemeID == nil || *resp.ActiveThemeID != facilityThemeID {
+		t.Fatalf("unexpected active theme: %v", resp.ActiveThemeID)
+	}
+	if len(resp.Themes) != 2 {
+		t.Fatalf("expected the system and facility themes, got %+v", resp.Themes)
+	}
+	for _, theme := range resp.Themes {
+		if theme.IsActive != (theme.ID == facilityThemeID) {
+			t.Fatalf("unexpected active flag on %q", theme.Name)
+		}
+		if theme.ID == systemID && theme.AccentColor != "#2563eb" {
+			t.Fatalf("expected palette in response, got %+v", theme)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// internal/api/themes/preview.go
+package themes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	"github.com/codr1/Pickleicious/internal/models"
+)
+
+// PreviewThemeParam is the query parameter staff use to render a page in a
+// candidate theme without activating it for the facility.
+const PreviewThemeParam = "preview_theme"
+
+// PageTheme returns the theme a facility page should render with. Staff, and
+// staff impersonating a member, may override the facility's active theme with
+// ?preview_theme=<id>; the override is ignored for everyone else and for
+// themes the facility could not activate.
+func PageTheme(ctx context.Context, r *http.Request, q models.ThemeQueries, facilityID int64) (*models.Theme, error) {
+	if preview := previewTheme(ctx, r, q, facilityID); preview != nil {
+		return preview, nil
+	}
+	return models.GetActiveTheme(ctx, q, facilityID)
+}
+
+func previewTheme(ctx context.Context, r *http.Request, q models.ThemeQueries, facilityID int64) *models.Theme {
+	raw := strings.TrimSpace(r.URL.Query().Get(PreviewThemeParam))
+	if raw == "" {
+		return nil
+	}
+	user := authz.UserFromContext(r.Context())
+	if !authz.IsStaff(user) && !authz.IsImpersonated(user) {
+		return nil
+	}
+	themeID, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || themeID <= 0 {
+		return nil
+	}
+
+	row, err := q.GetTheme(ctx, themeID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Ctx(r.Context()).Error().Err(err).Int64("theme_id", themeID).Msg("Failed to load preview theme")
+		}
+		return nil
+	}
+	theme := models.ThemeFromDB(row)
+	if !theme.AvailableTo(facilityID) {
+		return nil
+	}
+	return &theme
+}
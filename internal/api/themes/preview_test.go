@@ -0,0 +1,65 @@
+package themes
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestPageTheme_PreviewOnlyForStaff(t *testing.T) {
+	mock := newMockThemeQueries()
+	palette := dbgen.Theme{
+		PrimaryColor:   "#1f2937",
+		SecondaryColor: "#e5e7eb",
+		TertiaryColor:  "#f9fafb",
+		AccentColor:    "#2563eb",
+		HighlightColor: "#16a34a",
+	}
+	active := palette
+	active.Name, active.IsSystem = "Active", true
+	activeID := mock.addTheme(active)
+	candidate := palette
+	candidate.Name, candidate.FacilityID = "Candidate", sql.NullInt64{Int64: 5, Valid: true}
+	candidateID := mock.addTheme(candidate)
+	foreign := palette
+	foreign.Name, foreign.FacilityID = "Foreign", sql.NullInt64{Int64: 6, Valid: true}
+	foreignID := mock.addTheme(foreign)
+	mock.activeThemeIDs[5] = activeID
+
+	staffID := int64(9)
+	tests := []struct {
+		name    string
+		user    *authz.AuthUser
+		preview int64
+		want    int64
+	}{
+		{name: "staff", user: &authz.AuthUser{ID: 1, IsStaff: true}, preview: candidateID, want: candidateID},
+		{name: "impersonating staff", user: &authz.AuthUser{ID: 2, ImpersonatorID: &staffID}, preview: candidateID, want: candidateID},
+		{name: "member", user: &authz.AuthUser{ID: 2}, preview: candidateID, want: activeID},
+		{name: "anonymous", preview: candidateID, want: activeID},
+		{name: "other facility theme", user: &authz.AuthUser{ID: 1, IsStaff: true}, preview: foreignID, want: activeID},
+		{name: "unknown theme", user: &authz.AuthUser{ID: 1, IsStaff: true}, preview: 999, want: activeID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/leagues?facility_id=5&"+PreviewThemeParam+"="+strconv.FormatInt(tt.preview, 10), nil)
+			if tt.user != nil {
+				req = req.WithContext(authz.ContextWithUser(req.Context(), tt.user))
+			}
+
+			theme, err := PageTheme(context.Background(), req, mock, 5)
+			if err != nil {
+				t.Fatalf("page theme: %v", err)
+			}
+			if theme == nil || theme.ID != tt.want {
+				t.Fatalf("expected theme %d, got %+v", tt.want, theme)
+			}
+		})
+	}
+}
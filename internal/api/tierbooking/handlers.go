@@ -406,6 +406,7 @@ func HandleTierBookingToggle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	commit = true
+	store.Facilities.Invalidate(facilityID)
 
 	w.Header().Set("HX-Redirect", fmt.Sprintf("/admin/booking-windows?facility_id=%d", facilityID))
 	if enabled {
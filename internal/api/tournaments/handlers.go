@@ -0,0 +1,651 @@
+// internal/api/tournaments/handlers.go
+package tournaments
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/leagues"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+	tournamenttempl "github.com/codr1/Pickleicious/internal/templates/components/tournaments"
+)
+
+const (
+	tournamentQueryTimeout = 5 * time.Second
+	tournamentIDPathKey    = "id"
+	matchIDPathKey         = "match_id"
+	seedingManual          = "manual"
+	seedingStandings       = "standings"
+	// maxTournamentEntrants keeps brackets to six winners rounds.
+	maxTournamentEntrants = 64
+)
+
+// Tournament match statuses.
+const (
+	matchStatusPending   = "pending"
+	matchStatusReady     = "ready"
+	matchStatusCompleted = "completed"
+	matchStatusBye       = "bye"
+)
+
+var (
+	queries *dbgen.Queries
+	store   *appdb.DB
+)
+
+type tournamentRequest struct {
+	LeagueID int64   `json:"leagueId"`
+	Name     string  `json:"name"`
+	Format   string  `json:"format"`
+	Seeding  string  `json:"seeding"`
+	TeamIDs  []int64 `json:"teamIds"`
+}
+
+// InitHandlers must be called during server startup before handling requests.
+func InitHandlers(database *appdb.DB) {
+	if database == nil {
+		log.Warn().Msg("InitHandlers called with nil database; tournament handlers will not function")
+		return
+	}
+	queries = database.Queries
+	store = database
+}
+
+func loadQueries() *dbgen.Queries {
+	return queries
+}
+
+func loadDB() *appdb.DB {
+	return store
+}
+
+// loadService returns a reservation service for booking match courts, or nil
+// before InitHandlers has run. Tournament bookings send no emails.
+func loadService() *reservationsvc.Service {
+	if store == nil {
+		return nil
+	}
+	return reservationsvc.NewService(store, nil)
+}
+
+// GET /api/v1/tournaments
+func HandleTournamentsList(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	facilityID, err := apiutil.FacilityIDFromQuery(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), tournamentQueryTimeout)
+	defer cancel()
+
+	tournaments, err := q.ListTournamentsByFacility(ctx, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to list tournaments")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load tournaments")
+		return
+	}
+	if tournaments == nil {
+		tournaments = []dbgen.Tournament{}
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusOK, map[string]any{"tournaments": tournaments}); err != nil {
+		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to write tournaments response")
+	}
+}
+
+// POST /api/v1/tournaments
+func HandleTournamentCreate(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	database := loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	req, err := decodeTournamentRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if err := normalizeTournamentRequest(&req); err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), tournamentQueryTimeout)
+	defer cancel()
+
+	league, err := q.GetLeague(ctx, req.LeagueID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "League not found")
+			return
+		}
+		logger.Error().Err(err).Int64("league_id", req.LeagueID).Msg("Failed to fetch league")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch league")
+		return
+	}
+	if !apiutil.RequireFacilityAccess(w, r, league.FacilityID) {
+		return
+	}
+
+	seeds, err := seedTeams(ctx, q, req)
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status >= http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("league_id", req.LeagueID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	var created dbgen.Tournament
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+		var err error
+		created, err = qtx.CreateTournament(ctx, dbgen.CreateTournamentParams{
+			FacilityID:      league.FacilityID,
+			LeagueID:        league.ID,
+			Name:            req.Name,
+			Format:          req.Format,
+			Seeding:         req.Seeding,
+			CreatedByUserID: user.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("create tournament: %w", err)
+		}
+		for idx, teamID := range seeds {
+			if err := qtx.AddTournamentEntrant(ctx, dbgen.AddTournamentEntrantParams{
+				TournamentID: created.ID,
+				LeagueTeamID: teamID,
+				Seed:         int64(idx + 1),
+			}); err != nil {
+				return fmt.Errorf("add tournament entrant: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Int64("league_id", req.LeagueID).Msg("Failed to create tournament")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to create tournament")
+		return
+	}
+
+	data, err := loadBracketData(ctx, q, created, logger)
+	if err != nil {
+		logger.Error().Err(err).Int64("tournament_id", created.ID).Msg("Failed to load tournament")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load tournament")
+		return
+	}
+	if err := apiutil.WriteJSON(w, http.StatusCreated, data); err != nil {
+		logger.Error().Err(err).Int64("tournament_id", created.ID).Msg("Failed to write tournament response")
+	}
+}
+
+// GET /api/v1/tournaments/{id}/bracket
+func HandleTournamentBracket(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	if q == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), tournamentQueryTimeout)
+	defer cancel()
+
+	tournament, ok := loadTournament(ctx, w, r, q)
+	if !ok {
+		return
+	}
+	writeBracket(ctx, w, r, q, tournament, http.StatusOK)
+}
+
+// POST /api/v1/tournaments/{id}/bracket creates every match of the bracket.
+// First-round byes are decided straight away.
+func HandleGenerateBracket(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	database := loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), tournamentQueryTimeout)
+	defer cancel()
+
+	tournament, ok := loadTournament(ctx, w, r, q)
+	if !ok {
+		return
+	}
+
+	entrants, err := q.ListTournamentEntrants(ctx, tournament.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("tournament_id", tournament.ID).Msg("Failed to load tournament entrants")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load tournament entrants")
+		return
+	}
+	plan, err := leagues.PlanBracket(tournament.Format, len(entrants))
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		// Counting inside the transaction keeps two generate requests from
+		// both building a bracket.
+		existing, err := qtx.CountTournamentMatches(ctx, tournament.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check existing bracket", Err: err}
+		}
+		if existing > 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Bracket already generated for this tournament"}
+		}
+
+		// Matches are inserted last to first so each one can point at the
+		// later matches its teams move on to.
+		created := make([]dbgen.TournamentMatch, len(plan))
+		for idx := len(plan) - 1; idx >= 0; idx-- {
+			planned := plan[idx]
+			params := dbgen.CreateTournamentMatchParams{
+				TournamentID: tournament.ID,
+				Bracket:      planned.Bracket,
+				RoundNumber:  int64(planned.Round),
+				Position:     int64(planned.Position),
+				TeamAID:      seedTeamID(entrants, planned.SeedA),
+				TeamBID:      seedTeamID(entrants, planned.SeedB),
+				Status:       matchStatusPending,
+			}
+			if link := planned.WinnerTo; link != nil {
+				params.WinnerToMatchID = sql.NullInt64{Int64: created[link.Match].ID, Valid: true}
+				params.WinnerToSlot = sql.NullString{String: link.Slot, Valid: true}
+			}
+			if link := planned.LoserTo; link != nil {
+				params.LoserToMatchID = sql.NullInt64{Int64: created[link.Match].ID, Valid: true}
+				params.LoserToSlot = sql.NullString{String: link.Slot, Valid: true}
+			}
+			switch {
+			case planned.Bye:
+				params.Status = matchStatusBye
+			case params.TeamAID.Valid && params.TeamBID.Valid:
+				params.Status = matchStatusReady
+			}
+			created[idx], err = qtx.CreateTournamentMatch(ctx, params)
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to create tournament match", Err: err}
+			}
+		}
+
+		if _, err := qtx.UpdateTournamentStatus(ctx, dbgen.UpdateTournamentStatusParams{
+			Status: "in_progress",
+			ID:     tournament.ID,
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to start tournament", Err: err}
+		}
+
+		for _, match := range created {
+			if match.Status != matchStatusBye || !match.TeamAID.Valid {
+				continue
+			}
+			if err := decideMatch(ctx, qtx, match, match.TeamAID.Int64, 0, sql.NullInt64{}, sql.NullInt64{}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to advance byes", Err: err}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status >= http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("tournament_id", tournament.ID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("tournament_id", tournament.ID).Msg("Failed to generate bracket")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to generate bracket")
+		return
+	}
+
+	tournament, err = q.GetTournament(ctx, tournament.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("tournament_id", tournament.ID).Msg("Failed to reload tournament")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load tournament")
+		return
+	}
+	writeBracket(ctx, w, r, q, tournament, http.StatusCreated)
+}
+
+func decodeTournamentRequest(r *http.Request) (tournamentRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req tournamentRequest
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return tournamentRequest{}, err
+		}
+		return req, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return tournamentRequest{}, err
+	}
+	leagueID, err := apiutil.ParseRequiredInt64Field(apiutil.FirstNonEmpty(r.FormValue("league_id"), r.FormValue("leagueId")), "league_id")
+	if err != nil {
+		return tournamentRequest{}, err
+	}
+	var teamIDs []int64
+	for _, raw := range r.Form["team_ids"] {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		teamID, err := apiutil.ParseRequiredInt64Field(raw, "team_ids")
+		if err != nil {
+			return tournamentRequest{}, err
+		}
+		teamIDs = append(teamIDs, teamID)
+	}
+	return tournamentRequest{
+		LeagueID: leagueID,
+		Name:     r.FormValue("name"),
+		Format:   r.FormValue("format"),
+		Seeding:  r.FormValue("seeding"),
+		TeamIDs:  teamIDs,
+	}, nil
+}
+
+func normalizeTournamentRequest(req *tournamentRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Format = strings.ToLower(strings.TrimSpace(req.Format))
+	req.Seeding = strings.ToLower(strings.TrimSpace(req.Seeding))
+	if req.LeagueID <= 0 {
+		return apiutil.FieldError{Field: "league_id", Reason: "must be a positive integer"}
+	}
+	if req.Name == "" {
+		return apiutil.FieldError{Field: "name", Reason: "is required"}
+	}
+	switch req.Format {
+	case leagues.FormatSingleElimination, leagues.FormatDoubleElimination:
+	default:
+		return apiutil.FieldError{Field: "format", Reason: "must be single_elimination or double_elimination"}
+	}
+	switch req.Seeding {
+	case "":
+		req.Seeding = seedingManual
+	case seedingManual, seedingStandings:
+	default:
+		return apiutil.FieldError{Field: "seeding", Reason: "must be manual or standings"}
+	}
+	if req.Seeding == seedingManual && len(req.TeamIDs) == 0 {
+		return apiutil.FieldError{Field: "team_ids", Reason: "are required for manual seeding"}
+	}
+	return nil
+}
+
+// seedTeams returns the entrants' team IDs in seed order. Manual seeding
+// keeps the order given; standings seeding orders the league's active teams
+// by the current standings, limited to the given teams when there are any.
+func seedTeams(ctx context.Context, q *dbgen.Queries, req tournamentRequest) ([]int64, error) {
+	teams, err := q.ListLeagueTeams(ctx, req.LeagueID)
+	if err != nil {
+		return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load league teams", Err: err}
+	}
+	active := make(map[int64]struct{}, len(teams))
+	for _, team := range teams {
+		if strings.EqualFold(team.Status, "active") {
+			active[team.ID] = struct{}{}
+		}
+	}
+
+	requested := make(map[int64]struct{}, len(req.TeamIDs))
+	for _, teamID := range req.TeamIDs {
+		if _, ok := active[teamID]; !ok {
+			return nil, apiutil.FieldError{Field: "team_ids", Reason: "must be active teams in the league"}
+		}
+		if _, seen := requested[teamID]; seen {
+			return nil, apiutil.FieldError{Field: "team_ids", Reason: "must not repeat a team"}
+		}
+		requested[teamID] = struct{}{}
+	}
+
+	seeds := req.TeamIDs
+	if req.Seeding == seedingStandings {
+		standings, err := leagues.CalculateStandings(ctx, q, req.LeagueID)
+		if err != nil {
+			return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load standings", Err: err}
+		}
+		seeds = make([]int64, 0, len(standings))
+		for _, standing := range standings {
+			if _, ok := active[standing.TeamID]; !ok {
+				continue
+			}
+			if _, ok := requested[standing.TeamID]; len(requested) > 0 && !ok {
+				continue
+			}
+			seeds = append(seeds, standing.TeamID)
+		}
+	}
+
+	if len(seeds) < 2 {
+		return nil, apiutil.FieldError{Field: "team_ids", Reason: "need at least two active teams"}
+	}
+	if len(seeds) > maxTournamentEntrants {
+		return nil, apiutil.FieldError{Field: "team_ids", Reason: fmt.Sprintf("are limited to %d teams", maxTournamentEntrants)}
+	}
+	return seeds, nil
+}
+
+func seedTeamID(entrants []dbgen.ListTournamentEntrantsRow, seed int) sql.NullInt64 {
+	if seed <= 0 || seed > len(entrants) {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: entrants[seed-1].LeagueTeamID, Valid: true}
+}
+
+// loadTournament fetches the tournament named in the path and checks the
+// caller's facility access, writing the error response when it fails.
+func loadTournament(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries) (dbgen.Tournament, bool) {
+	logger := log.Ctx(r.Context())
+
+	tournamentID, err := pathID(r, tournamentIDPathKey)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid tournament ID")
+		return dbgen.Tournament{}, false
+	}
+	tournament, err := q.GetTournament(ctx, tournamentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Tournament not found")
+			return dbgen.Tournament{}, false
+		}
+		logger.Error().Err(err).Int64("tournament_id", tournamentID).Msg("Failed to fetch tournament")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch tournament")
+		return dbgen.Tournament{}, false
+	}
+	if !apiutil.RequireFacilityAccess(w, r, tournament.FacilityID) {
+		return dbgen.Tournament{}, false
+	}
+	return tournament, true
+}
+
+func pathID(r *http.Request, key string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(r.PathValue(key)), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid %s", key)
+	}
+	return id, nil
+}
+
+// writeBracket answers with the bracket partial for HTMX and browser
+// requests and with the bracket JSON, sent with status, otherwise.
+func writeBracket(ctx context.Context, w http.ResponseWriter, r *http.Request, q *dbgen.Queries, tournament dbgen.Tournament, status int) {
+	logger := log.Ctx(r.Context())
+
+	data, err := loadBracketData(ctx, q, tournament, logger)
+	if err != nil {
+		logger.Error().Err(err).Int64("tournament_id", tournament.ID).Msg("Failed to load bracket")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load bracket")
+		return
+	}
+
+	accept := r.Header.Get("Accept")
+	wantsHTML := apiutil.IsHTMXRequest(r) || strings.Contains(accept, "text/html")
+	wantsJSON := strings.Contains(accept, "application/json")
+	if wantsHTML && (!wantsJSON || apiutil.IsHTMXRequest(r)) {
+		apiutil.RenderHTMLComponent(r.Context(), w, tournamenttempl.TournamentBracket(data), nil, "Failed to render bracket", "Failed to render bracket")
+		return
+	}
+	if err := apiutil.WriteJSON(w, status, data); err != nil {
+		logger.Error().Err(err).Int64("tournament_id", tournament.ID).Msg("Failed to write bracket response")
+	}
+}
+
+// loadBracketData gathers the tournament's entrants and matches, grouped by
+// bracket and round. Match times are shown in the facility's timezone.
+func loadBracketData(ctx context.Context, q *dbgen.Queries, tournament dbgen.Tournament, logger *zerolog.Logger) (tournamenttempl.BracketData, error) {
+	entrants, err := q.ListTournamentEntrants(ctx, tournament.ID)
+	if err != nil {
+		return tournamenttempl.BracketData{}, fmt.Errorf("list entrants: %w", err)
+	}
+	matches, err := q.ListTournamentMatches(ctx, tournament.ID)
+	if err != nil {
+		return tournamenttempl.BracketData{}, fmt.Errorf("list matches: %w", err)
+	}
+
+	teams := make(map[int64]tournamenttempl.Team, len(entrants))
+	data := tournamenttempl.BracketData{
+		Tournament: tournamenttempl.Tournament{
+			ID:         tournament.ID,
+			FacilityID: tournament.FacilityID,
+			LeagueID:   tournament.LeagueID,
+			Name:       tournament.Name,
+			Format:     tournament.Format,
+			Seeding:    tournament.Seeding,
+			Status:     tournament.Status,
+		},
+		Entrants: make([]tournamenttempl.Team, 0, len(entrants)),
+		Brackets: []tournamenttempl.Bracket{},
+	}
+	for _, entrant := range entrants {
+		team := tournamenttempl.Team{ID: entrant.LeagueTeamID, Name: entrant.TeamName, Seed: entrant.Seed}
+		teams[team.ID] = team
+		data.Entrants = append(data.Entrants, team)
+	}
+	teamRef := func(id sql.NullInt64) *tournamenttempl.Team {
+		if !id.Valid {
+			return nil
+		}
+		team, ok := teams[id.Int64]
+		if !ok {
+			return nil
+		}
+		return &team
+	}
+	data.Tournament.ChampionTeam = teamRef(tournament.ChampionTeamID)
+	if len(matches) == 0 {
+		return data, nil
+	}
+
+	courtNames := make(map[int64]string)
+	courts, err := q.ListCourts(ctx, tournament.FacilityID)
+	if err != nil {
+		return tournamenttempl.BracketData{}, fmt.Errorf("list courts: %w", err)
+	}
+	for _, court := range courts {
+		courtNames[court.ID] = court.Name
+	}
+	loc := apiutil.DefaultLocation()
+	if facility, err := q.GetFacilityByID(ctx, tournament.FacilityID); err == nil {
+		loc = apiutil.FacilityLocation(facility, logger)
+	} else {
+		logger.Error().Err(err).Int64("facility_id", tournament.FacilityID).Msg("Failed to load facility timezone")
+	}
+
+	for _, match := range matches {
+		entry := tournamenttempl.Match{
+			ID:              match.ID,
+			Position:        match.Position,
+			Status:          match.Status,
+			TeamA:           teamRef(match.TeamAID),
+			TeamB:           teamRef(match.TeamBID),
+			TeamAScore:      nullInt64Ptr(match.TeamAScore),
+			TeamBScore:      nullInt64Ptr(match.TeamBScore),
+			WinnerTeamID:    nullInt64Ptr(match.WinnerTeamID),
+			WinnerToMatchID: nullInt64Ptr(match.WinnerToMatchID),
+			LoserToMatchID:  nullInt64Ptr(match.LoserToMatchID),
+			CourtID:         nullInt64Ptr(match.CourtID),
+			ReservationID:   nullInt64Ptr(match.ReservationID),
+		}
+		if match.CourtID.Valid {
+			entry.CourtName = courtNames[match.CourtID.Int64]
+		}
+		if match.ScheduledTime.Valid {
+			scheduled := match.ScheduledTime.Time.In(loc)
+			entry.ScheduledTime = &scheduled
+		}
+
+		// Matches arrive sorted by bracket, round and position.
+		if n := len(data.Brackets); n == 0 || data.Brackets[n-1].Name != match.Bracket {
+			data.Brackets = append(data.Brackets, tournamenttempl.Bracket{Name: match.Bracket})
+		}
+		bracket := &data.Brackets[len(data.Brackets)-1]
+		if n := len(bracket.Rounds); n == 0 || bracket.Rounds[n-1].Number != match.RoundNumber {
+			bracket.Rounds = append(bracket.Rounds, tournamenttempl.Round{Number: match.RoundNumber})
+		}
+		round := &bracket.Rounds[len(bracket.Rounds)-1]
+		round.Matches = append(round.Matches, entry)
+	}
+	return data, nil
+}
+
+func nullInt64Ptr(value sql.NullInt64) *int64 {
+	if !value.Valid {
+		return nil
+	}
+	v := value.Int64
+	return &v
+}
@@ -0,0 +1,237 @@
+package tournaments
+
+// NOTE: Tests cannot use t.Parallel() due to shared package state.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	tournamenttempl "github.com/codr1/Pickleicious/internal/templates/components/tournaments"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type tournamentFixture struct {
+	facilityID int64
+	leagueID   int64
+	courtID    int64
+	teamIDs    []int64
+	staff      *authz.AuthUser
+}
+
+func setupTournamentTest(t *testing.T, teams int) tournamentFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.Exec(query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main Facility', 'main-facility', 'UTC')", orgID)
+	for day := 0; day < 7; day++ {
+		exec("INSERT INTO operating_hours (facility_id, day_of_week, opens_at, closes_at) VALUES (?, ?, '08:00', '22:00')", facilityID, day)
+	}
+	courtID := exec("INSERT INTO courts (facility_id, name, court_number, status) VALUES (?, 'Court 1', 1, 'active')", facilityID)
+	staffID := exec("INSERT INTO users (first_name, last_name, email, status, is_staff) VALUES ('Sam', 'Staff', 'staff@test.com', 'active', 1)")
+	exec("INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, 'Sam', 'Staff', ?, 'desk')", staffID, facilityID)
+	leagueID := exec(`INSERT INTO leagues (facility_id, name, format, start_date, end_date, division_config, min_team_size, max_team_size, status)
+		VALUES (?, 'Summer Ladder', 'doubles', '2027-06-07', '2027-07-04', '{}', 1, 4, 'active')`, facilityID)
+
+	teamIDs := make([]int64, 0, teams)
+	for i := 1; i <= teams; i++ {
+		teamIDs = append(teamIDs, exec(
+			"INSERT INTO league_teams (league_id, name, captain_user_id, status) VALUES (?, ?, ?, 'active')",
+			leagueID, fmt.Sprintf("Team %d", i), staffID,
+		))
+	}
+
+	InitHandlers(database)
+
+	return tournamentFixture{
+		facilityID: facilityID,
+		leagueID:   leagueID,
+		courtID:    courtID,
+		teamIDs:    teamIDs,
+		staff:      &authz.AuthUser{ID: staffID, IsStaff: true, HomeFacilityID: &facilityID},
+	}
+}
+
+func (f tournamentFixture) serve(t *testing.T, handler http.HandlerFunc, method, target, body string, pathValues ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	if target == "" {
+		target = "/api/v1/tournaments"
+	}
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for i := 0; i+1 < len(pathValues); i += 2 {
+		req.SetPathValue(pathValues[i], pathValues[i+1])
+	}
+	req = req.WithContext(authz.ContextWithUser(req.Context(), f.staff))
+	recorder := httptest.NewRecorder()
+	handler(recorder, req)
+	return recorder
+}
+
+func decodeBracket(t *testing.T, recorder *httptest.ResponseRecorder, status int) tournamenttempl.BracketData {
+	t.Helper()
+	if recorder.Code != status {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, status, recorder.Body.String())
+	}
+	var data tournamenttempl.BracketData
+	if err := json.Unmarshal(recorder.Body.Bytes(), &data); err != nil {
+		t.Fatalf("decode bracket: %v", err)
+	}
+	return data
+}
+
+func findMatch(t *testing.T, data tournamenttempl.BracketData, bracket string, round, position int64) tournamenttempl.Match {
+	t.Helper()
+	for _, b := range data.Brackets {
+		if b.Name != bracket {
+			continue
+		}
+		for _, r := range b.Rounds {
+			if r.Number != round {
+				continue
+			}
+			for _, m := range r.Matches {
+				if m.Position == position {
+					return m
+				}
+			}
+		}
+	}
+	t.Fatalf("no %s match at round %d position %d", bracket, round, position)
+	return tournamenttempl.Match{}
+}
+
+func TestTournamentDoubleEliminationToChampion(t *testing.T) {
+	f := setupTournamentTest(t, 4)
+	ids := f.teamIDs
+
+	invalid := f.serve(t, HandleTournamentCreate, http.MethodPost, "/api/v1/tournaments",
+		fmt.Sprintf(`{"leagueId":%d,"name":"Cup","format":"round_robin","teamIds":[%d,%d]}`, f.leagueID, ids[0], ids[1]))
+	if invalid.Code != http.StatusBadRequest || !strings.Contains(invalid.Body.String(), `"field":"format"`) {
+		t.Fatalf("expected a format validation error, got %d %s", invalid.Code, invalid.Body.String())
+	}
+
+	created := decodeBracket(t, f.serve(t, HandleTournamentCreate, http.MethodPost, "/api/v1/tournaments",
+		fmt.Sprintf(`{"leagueId":%d,"name":"Summer Cup","format":"double_elimination","teamIds":[%d,%d,%d,%d]}`,
+			f.leagueID, ids[0], ids[1], ids[2], ids[3])), http.StatusCreated)
+	if created.Tournament.Status != "draft" || len(created.Entrants) != 4 || len(created.Brackets) != 0 {
+		t.Fatalf("unexpected new tournament: %+v", created)
+	}
+	tournamentID := fmt.Sprintf("%d", created.Tournament.ID)
+	bracketPath := "/api/v1/tournaments/" + tournamentID + "/bracket"
+
+	bracket := decodeBracket(t, f.serve(t, HandleGenerateBracket, http.MethodPost, bracketPath, "", "id", tournamentID), http.StatusCreated)
+	if bracket.Tournament.Status != "in_progress" || len(bracket.Brackets) != 3 {
+		t.Fatalf("expected winners, consolation and final brackets, got %+v", bracket)
+	}
+	again := f.serve(t, HandleGenerateBracket, http.MethodPost, bracketPath, "", "id", tournamentID)
+	if again.Code != http.StatusConflict {
+		t.Fatalf("regenerating should conflict, got %d", again.Code)
+	}
+
+	record := func(match tournamenttempl.Match, scoreA, scoreB int) tournamenttempl.BracketData {
+		t.Helper()
+		matchID := fmt.Sprintf("%d", match.ID)
+		return decodeBracket(t, f.serve(t, HandleRecordMatchResult, http.MethodPut,
+			"/api/v1/tournaments/"+tournamentID+"/matches/"+matchID+"/result",
+			fmt.Sprintf(`{"teamAScore":%d,"teamBScore":%d}`, scoreA, scoreB),
+			"id", tournamentID, "match_id", matchID), http.StatusOK)
+	}
+
+	pending := findMatch(t, bracket, "consolation", 1, 1)
+	early := f.serve(t, HandleRecordMatchResult, http.MethodPut, "", `{"teamAScore":11,"teamBScore":4}`,
+		"id", tournamentID, "match_id", fmt.Sprintf("%d", pending.ID))
+	if early.Code != http.StatusConflict {
+		t.Fatalf("recording a match without teams should conflict, got %d", early.Code)
+	}
+
+	// Seeds 1 and 2 win round one; 4 and 3 drop to the consolation bracket.
+	bracket = record(findMatch(t, bracket, "winners", 1, 1), 11, 3)
+	bracket = record(findMatch(t, bracket, "winners", 1, 2), 11, 9)
+	consolation := findMatch(t, bracket, "consolation", 1, 1)
+	if consolation.Status != "ready" || consolation.TeamA == nil || consolation.TeamB == nil {
+		t.Fatalf("first-round losers should meet in the consolation bracket, got %+v", consolation)
+	}
+
+	bracket = record(findMatch(t, bracket, "winners", 2, 1), 11, 7)     // seed 1 beats seed 2
+	bracket = record(consolation, 5, 11)                                // seed 3 beats seed 4
+	bracket = record(findMatch(t, bracket, "consolation", 2, 1), 8, 11) // seed 2 beats seed 3
+	final := findMatch(t, bracket, "final", 1, 1)
+	if final.TeamA == nil || final.TeamA.ID != ids[0] || final.TeamB == nil || final.TeamB.ID != ids[1] {
+		t.Fatalf("final should be seed 1 against seed 2, got %+v", final)
+	}
+
+	bracket = record(final, 9, 11)
+	if bracket.Tournament.Status != "completed" || bracket.Tournament.ChampionTeam == nil || bracket.Tournament.ChampionTeam.ID != ids[1] {
+		t.Fatalf("seed 2 should be champion, got %+v", bracket.Tournament)
+	}
+	replay := f.serve(t, HandleRecordMatchResult, http.MethodPut, "", `{"teamAScore":11,"teamBScore":4}`,
+		"id", tournamentID, "match_id", fmt.Sprintf("%d", final.ID))
+	if replay.Code != http.StatusConflict {
+		t.Fatalf("re-recording the final should conflict, got %d", replay.Code)
+	}
+}
+
+func TestTournamentStandingsSeedingByesAndScheduling(t *testing.T) {
+	f := setupTournamentTest(t, 3)
+
+	created := decodeBracket(t, f.serve(t, HandleTournamentCreate, http.MethodPost, "/api/v1/tournaments",
+		fmt.Sprintf(`{"leagueId":%d,"name":"Playoffs","format":"single_elimination","seeding":"standings"}`, f.leagueID)), http.StatusCreated)
+	if len(created.Entrants) != 3 {
+		t.Fatalf("standings seeding should enter every active team, got %+v", created.Entrants)
+	}
+	tournamentID := fmt.Sprintf("%d", created.Tournament.ID)
+
+	bracket := decodeBracket(t, f.serve(t, HandleGenerateBracket, http.MethodPost, "", "", "id", tournamentID), http.StatusCreated)
+	bye := findMatch(t, bracket, "winners", 1, 1)
+	if bye.Status != "bye" || bye.WinnerTeamID == nil {
+		t.Fatalf("top seed should get a decided bye, got %+v", bye)
+	}
+	final := findMatch(t, bracket, "winners", 2, 1)
+	if final.TeamA == nil || final.TeamA.ID != *bye.WinnerTeamID || final.Status != "pending" {
+		t.Fatalf("bye winner should wait in the final, got %+v", final)
+	}
+
+	semi := findMatch(t, bracket, "winners", 1, 2)
+	matchID := fmt.Sprintf("%d", semi.ID)
+	schedule := func(body string) *httptest.ResponseRecorder {
+		return f.serve(t, HandleScheduleMatch, http.MethodPut, "", body, "id", tournamentID, "match_id", matchID)
+	}
+	missingCourt := schedule(`{"courtId":999999,"startTime":"2027-06-10T18:00"}`)
+	if missingCourt.Code != http.StatusBadRequest || !strings.Contains(missingCourt.Body.String(), `"field":"court_id"`) {
+		t.Fatalf("expected a court validation error, got %d %s", missingCourt.Code, missingCourt.Body.String())
+	}
+
+	bracket = decodeBracket(t, schedule(fmt.Sprintf(`{"courtId":%d,"startTime":"2027-06-10T18:00","bookReservation":true}`, f.courtID)), http.StatusOK)
+	semi = findMatch(t, bracket, "winners", 1, 2)
+	if semi.ReservationID == nil || semi.CourtName != "Court 1" || semi.ScheduledTime == nil || semi.ScheduledTime.Hour() != 18 {
+		t.Fatalf("expected a booked court, got %+v", semi)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tournaments/"+tournamentID+"/bracket", nil)
+	req.Header.Set("HX-Request", "true")
+	req.SetPathValue("id", tournamentID)
+	req = req.WithContext(authz.ContextWithUser(req.Context(), f.staff))
+	recorder := httptest.NewRecorder()
+	HandleTournamentBracket(recorder, req)
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "tournament-bracket-"+tournamentID) ||
+		!strings.Contains(recorder.Body.String(), "Court 1") {
+		t.Fatalf("expected bracket HTML, got %d %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,435 @@
+package tournaments
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/api/authz"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/leagues"
+	reservationsvc "github.com/codr1/Pickleicious/internal/reservations"
+)
+
+const (
+	tournamentReservationTypeName = "TOURNAMENT"
+	defaultMatchDurationMinutes   = 60
+	maxMatchDurationMinutes       = 240
+	matchTimeLayoutLocal          = "2006-01-02T15:04"
+)
+
+type matchResultRequest struct {
+	TeamAScore int64 `json:"teamAScore"`
+	TeamBScore int64 `json:"teamBScore"`
+}
+
+type matchScheduleRequest struct {
+	CourtID         int64  `json:"courtId"`
+	StartTime       string `json:"startTime"`
+	DurationMinutes int64  `json:"durationMinutes"`
+	BookReservation bool   `json:"bookReservation"`
+}
+
+// PUT /api/v1/tournaments/{id}/matches/{match_id}/result records a final
+// score. The winner advances automatically; in double elimination the loser
+// drops to the consolation bracket.
+func HandleRecordMatchResult(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	database := loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	matchID, err := pathID(r, matchIDPathKey)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+	req, err := decodeMatchResultRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if err := leagues.ValidateMatchResult(req.TeamAScore, req.TeamBScore); err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), tournamentQueryTimeout)
+	defer cancel()
+
+	tournament, ok := loadTournament(ctx, w, r, q)
+	if !ok {
+		return
+	}
+
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+		match, err := qtx.GetTournamentMatch(ctx, dbgen.GetTournamentMatchParams{ID: matchID, TournamentID: tournament.ID})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Match not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch match", Err: err}
+		}
+		switch match.Status {
+		case matchStatusCompleted:
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Match result already recorded"}
+		case matchStatusBye:
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Byes are not played"}
+		case matchStatusPending:
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Match is still waiting for its teams"}
+		}
+
+		winnerID, loserID := match.TeamAID.Int64, match.TeamBID.Int64
+		if req.TeamBScore > req.TeamAScore {
+			winnerID, loserID = loserID, winnerID
+		}
+		if err := decideMatch(ctx, qtx, match, winnerID, loserID,
+			sql.NullInt64{Int64: req.TeamAScore, Valid: true},
+			sql.NullInt64{Int64: req.TeamBScore, Valid: true},
+		); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to advance bracket", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) {
+			if herr.Status >= http.StatusInternalServerError {
+				logger.Error().Err(herr.Err).Int64("match_id", matchID).Msg(herr.Message)
+			}
+			apiutil.WriteHandlerError(w, r, herr)
+			return
+		}
+		logger.Error().Err(err).Int64("match_id", matchID).Msg("Failed to record match result")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to record match result")
+		return
+	}
+
+	tournament, err = q.GetTournament(ctx, tournament.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("tournament_id", tournament.ID).Msg("Failed to reload tournament")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load tournament")
+		return
+	}
+	writeBracket(ctx, w, r, q, tournament, http.StatusOK)
+}
+
+// PUT /api/v1/tournaments/{id}/matches/{match_id}/schedule assigns a court
+// and start time. With bookReservation the court is booked through the
+// reservation service so the match shows on the calendar; a match that
+// already has a reservation always has it moved.
+func HandleScheduleMatch(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	service := loadService()
+	if q == nil || service == nil {
+		logger.Error().Msg("Database queries not initialized")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if user == nil {
+		apiutil.WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	matchID, err := pathID(r, matchIDPathKey)
+	if err != nil {
+		apiutil.WriteError(w, r, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+	req, err := decodeMatchScheduleRequest(r)
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	if req.CourtID <= 0 {
+		apiutil.WriteValidationError(w, r, apiutil.FieldError{Field: "court_id", Reason: "must be a positive integer"})
+		return
+	}
+	if req.DurationMinutes == 0 {
+		req.DurationMinutes = defaultMatchDurationMinutes
+	}
+	if req.DurationMinutes < 0 || req.DurationMinutes > maxMatchDurationMinutes {
+		apiutil.WriteValidationError(w, r, apiutil.FieldError{Field: "duration_minutes", Reason: fmt.Sprintf("must be between 1 and %d", maxMatchDurationMinutes)})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), tournamentQueryTimeout)
+	defer cancel()
+
+	tournament, ok := loadTournament(ctx, w, r, q)
+	if !ok {
+		return
+	}
+
+	facility, err := q.GetFacilityByID(ctx, tournament.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", tournament.FacilityID).Msg("Failed to load facility")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load facility")
+		return
+	}
+	startTime, err := parseMatchStartTime(req.StartTime, apiutil.FacilityLocation(facility, logger))
+	if err != nil {
+		apiutil.WriteValidationError(w, r, err)
+		return
+	}
+	endTime := startTime.Add(time.Duration(req.DurationMinutes) * time.Minute)
+
+	court, err := q.GetCourt(ctx, req.CourtID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error().Err(err).Int64("court_id", req.CourtID).Msg("Failed to load court")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to load court")
+		return
+	}
+	if err != nil || court.FacilityID != tournament.FacilityID {
+		apiutil.WriteValidationError(w, r, apiutil.FieldError{Field: "court_id", Reason: "must be a court at this facility"})
+		return
+	}
+
+	match, err := q.GetTournamentMatch(ctx, dbgen.GetTournamentMatchParams{ID: matchID, TournamentID: tournament.ID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			apiutil.WriteError(w, r, http.StatusNotFound, "Match not found")
+			return
+		}
+		logger.Error().Err(err).Int64("match_id", matchID).Msg("Failed to fetch match")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to fetch match")
+		return
+	}
+	switch match.Status {
+	case matchStatusCompleted:
+		apiutil.WriteError(w, r, http.StatusConflict, "Match is already completed")
+		return
+	case matchStatusBye:
+		apiutil.WriteError(w, r, http.StatusConflict, "Byes are not played")
+		return
+	}
+
+	reservationID := match.ReservationID
+	if reservationID.Valid || req.BookReservation {
+		details, err := matchReservationDetails(ctx, q, tournament, court.ID, startTime, endTime)
+		if err != nil {
+			logger.Error().Err(err).Int64("tournament_id", tournament.ID).Msg("Failed to prepare match reservation")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to prepare match reservation")
+			return
+		}
+		if reservationID.Valid {
+			_, err = service.UpdateReservation(ctx, reservationsvc.UpdateInput{
+				Details:       details,
+				ReservationID: reservationID.Int64,
+				FacilityID:    tournament.FacilityID,
+			})
+		} else {
+			var created dbgen.Reservation
+			created, err = service.CreateReservation(ctx, reservationsvc.CreateInput{
+				Details:         details,
+				FacilityID:      tournament.FacilityID,
+				CreatedByUserID: user.ID,
+			})
+			reservationID = sql.NullInt64{Int64: created.ID, Valid: err == nil}
+		}
+		if err != nil {
+			var herr apiutil.HandlerError
+			if errors.As(err, &herr) {
+				if herr.Status >= http.StatusInternalServerError {
+					logger.Error().Err(herr.Err).Int64("match_id", matchID).Msg(herr.Message)
+				}
+				apiutil.WriteHandlerError(w, r, herr)
+				return
+			}
+			logger.Error().Err(err).Int64("match_id", matchID).Msg("Failed to book match court")
+			apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to book match court")
+			return
+		}
+	}
+
+	if _, err := q.ScheduleTournamentMatch(ctx, dbgen.ScheduleTournamentMatchParams{
+		CourtID:       sql.NullInt64{Int64: court.ID, Valid: true},
+		ScheduledTime: sql.NullTime{Time: startTime.UTC(), Valid: true},
+		ReservationID: reservationID,
+		ID:            match.ID,
+		TournamentID:  tournament.ID,
+	}); err != nil {
+		logger.Error().Err(err).Int64("match_id", matchID).Int64("reservation_id", reservationID.Int64).Msg("Failed to save match schedule")
+		apiutil.WriteError(w, r, http.StatusInternalServerError, "Failed to save match schedule")
+		return
+	}
+
+	writeBracket(ctx, w, r, q, tournament, http.StatusOK)
+}
+
+// decideMatch records a match's winner and moves both teams on: the winner
+// to its next match and the loser, in double elimination, down to the
+// consolation bracket. Byes have no scores and no loser. The winner of the
+// last match is the tournament champion.
+func decideMatch(ctx context.Context, q *dbgen.Queries, match dbgen.TournamentMatch, winnerID, loserID int64, scoreA, scoreB sql.NullInt64) error {
+	status := matchStatusCompleted
+	if match.Status == matchStatusBye {
+		status = matchStatusBye
+	}
+	if _, err := q.SetTournamentMatchWinner(ctx, dbgen.SetTournamentMatchWinnerParams{
+		TeamAScore:   scoreA,
+		TeamBScore:   scoreB,
+		WinnerTeamID: sql.NullInt64{Int64: winnerID, Valid: true},
+		Status:       status,
+		ID:           match.ID,
+	}); err != nil {
+		return fmt.Errorf("set match %d winner: %w", match.ID, err)
+	}
+
+	if match.WinnerToMatchID.Valid {
+		if err := placeTeam(ctx, q, match.WinnerToMatchID.Int64, match.WinnerToSlot.String, winnerID); err != nil {
+			return err
+		}
+	} else if _, err := q.UpdateTournamentStatus(ctx, dbgen.UpdateTournamentStatusParams{
+		Status:         "completed",
+		ChampionTeamID: sql.NullInt64{Int64: winnerID, Valid: true},
+		ID:             match.TournamentID,
+	}); err != nil {
+		return fmt.Errorf("complete tournament %d: %w", match.TournamentID, err)
+	}
+
+	if loserID != 0 && match.LoserToMatchID.Valid {
+		return placeTeam(ctx, q, match.LoserToMatchID.Int64, match.LoserToSlot.String, loserID)
+	}
+	return nil
+}
+
+// placeTeam moves a team into a slot of a later match. A bye passes the team
+// straight through; a match with both teams is ready to play.
+func placeTeam(ctx context.Context, q *dbgen.Queries, matchID int64, slot string, teamID int64) error {
+	team := sql.NullInt64{Int64: teamID, Valid: true}
+	var next dbgen.TournamentMatch
+	var err error
+	if slot == leagues.SlotA {
+		next, err = q.SetTournamentMatchTeamA(ctx, dbgen.SetTournamentMatchTeamAParams{TeamID: team, ID: matchID})
+	} else {
+		next, err = q.SetTournamentMatchTeamB(ctx, dbgen.SetTournamentMatchTeamBParams{TeamID: team, ID: matchID})
+	}
+	if err != nil {
+		return fmt.Errorf("place team %d in match %d: %w", teamID, matchID, err)
+	}
+
+	switch {
+	case next.Status == matchStatusBye:
+		return decideMatch(ctx, q, next, teamID, 0, sql.NullInt64{}, sql.NullInt64{})
+	case next.TeamAID.Valid && next.TeamBID.Valid:
+		if _, err := q.UpdateTournamentMatchStatus(ctx, dbgen.UpdateTournamentMatchStatusParams{
+			Status: matchStatusReady,
+			ID:     next.ID,
+		}); err != nil {
+			return fmt.Errorf("mark match %d ready: %w", next.ID, err)
+		}
+	}
+	return nil
+}
+
+// matchReservationDetails describes a TOURNAMENT reservation of one court
+// for two sides of the league's team size.
+func matchReservationDetails(ctx context.Context, q *dbgen.Queries, tournament dbgen.Tournament, courtID int64, startTime, endTime time.Time) (reservationsvc.Details, error) {
+	reservationType, err := q.GetReservationTypeByName(ctx, tournamentReservationTypeName)
+	if err != nil {
+		return reservationsvc.Details{}, fmt.Errorf("load reservation type: %w", err)
+	}
+	league, err := q.GetLeague(ctx, tournament.LeagueID)
+	if err != nil {
+		return reservationsvc.Details{}, fmt.Errorf("load league: %w", err)
+	}
+	teamsPerCourt := int64(2)
+	peoplePerTeam := leagues.PeoplePerTeam(league.Format)
+	return reservationsvc.Details{
+		ReservationTypeID: reservationType.ID,
+		StartTime:         startTime,
+		EndTime:           endTime,
+		TeamsPerCourt:     &teamsPerCourt,
+		PeoplePerTeam:     &peoplePerTeam,
+		CourtIDs:          []int64{courtID},
+	}, nil
+}
+
+func decodeMatchResultRequest(r *http.Request) (matchResultRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req matchResultRequest
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return matchResultRequest{}, err
+		}
+		return req, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return matchResultRequest{}, err
+	}
+	teamAScore, err := apiutil.ParseNonNegativeInt64Field(apiutil.FirstNonEmpty(r.FormValue("team_a_score"), r.FormValue("teamAScore")), "team_a_score")
+	if err != nil {
+		return matchResultRequest{}, err
+	}
+	teamBScore, err := apiutil.ParseNonNegativeInt64Field(apiutil.FirstNonEmpty(r.FormValue("team_b_score"), r.FormValue("teamBScore")), "team_b_score")
+	if err != nil {
+		return matchResultRequest{}, err
+	}
+	return matchResultRequest{TeamAScore: teamAScore, TeamBScore: teamBScore}, nil
+}
+
+func decodeMatchScheduleRequest(r *http.Request) (matchScheduleRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req matchScheduleRequest
+		if err := apiutil.DecodeJSON(r, &req); err != nil {
+			return matchScheduleRequest{}, err
+		}
+		return req, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return matchScheduleRequest{}, err
+	}
+	courtID, err := apiutil.ParseRequiredInt64Field(apiutil.FirstNonEmpty(r.FormValue("court_id"), r.FormValue("courtId")), "court_id")
+	if err != nil {
+		return matchScheduleRequest{}, err
+	}
+	var duration int64
+	if raw := apiutil.FirstNonEmpty(r.FormValue("duration_minutes"), r.FormValue("durationMinutes")); raw != "" {
+		duration, err = apiutil.ParsePositiveInt64Field(raw, "duration_minutes")
+		if err != nil {
+			return matchScheduleRequest{}, err
+		}
+	}
+	return matchScheduleRequest{
+		CourtID:         courtID,
+		StartTime:       apiutil.FirstNonEmpty(r.FormValue("start_time"), r.FormValue("startTime")),
+		DurationMinutes: duration,
+		BookReservation: apiutil.ParseBool(apiutil.FirstNonEmpty(r.FormValue("book_reservation"), r.FormValue("bookReservation"))),
+	}, nil
+}
+
+// parseMatchStartTime accepts RFC 3339, or a datetime-local value in the
+// facility's timezone.
+func parseMatchStartTime(raw string, loc *time.Location) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, apiutil.FieldError{Field: "start_time", Reason: "is required"}
+	}
+	if parsed, err := time.Parse(time.RFC3339, raw); err == nil {
+		return parsed, nil
+	}
+	if parsed, err := time.ParseInLocation(matchTimeLayoutLocal, raw, loc); err == nil {
+		return parsed, nil
+	}
+	return time.Time{}, apiutil.FieldError{Field: "start_time", Reason: "must be a valid datetime"}
+}
@@ -20,8 +20,10 @@ import (
 	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/api/authz"
 	"github.com/codr1/Pickleicious/internal/api/htmx"
+	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/models"
+	"github.com/codr1/Pickleicious/internal/payments"
 	"github.com/codr1/Pickleicious/internal/templates/layouts"
 )
 
@@ -34,6 +36,8 @@ const (
 
 var (
 	queries     visitPackQueries
+	store       *appdb.DB
+	processor   payments.PaymentProcessor
 	queriesOnce sync.Once
 )
 
@@ -43,6 +47,8 @@ type visitPackQueries interface {
 	CreateVisitPack(ctx context.Context, arg dbgen.CreateVisitPackParams) (dbgen.VisitPack, error)
 	CreateVisitPackType(ctx context.Context, arg dbgen.CreateVisitPackTypeParams) (dbgen.VisitPackType, error)
 	DeactivateVisitPackType(ctx context.Context, arg dbgen.DeactivateVisitPackTypeParams) (dbgen.VisitPackType, error)
+	GetFacilityByID(ctx context.Context, id int64) (dbgen.Facility, error)
+	GetUserByID(ctx context.Context, id int64) (dbgen.User, error)
 	GetVisitPackType(ctx context.Context, arg dbgen.GetVisitPackTypeParams) (dbgen.VisitPackType, error)
 	ListActiveVisitPacksForUser(ctx context.Context, arg dbgen.ListActiveVisitPacksForUserParams) ([]dbgen.VisitPack, error)
 	ListActiveVisitPacksForUserByFacility(ctx context.Context, arg dbgen.ListActiveVisitPacksForUserByFacilityParams) ([]dbgen.VisitPack, error)
@@ -65,13 +71,22 @@ type visitPackSaleRequest struct {
 	PurchaseDate *time.Time `json:"purchaseDate"`
 }
 
+type visitPackPurchaseRequest struct {
+	FacilityID *int64 `json:"facilityId"`
+	UserID     int64  `json:"userId"`
+	PackTypeID int64  `json:"packTypeId"`
+}
+
 // InitHandlers must be called during server startup before handling requests.
-func InitHandlers(q *dbgen.Queries) {
-	if q == nil {
+// A nil processor disables charged purchases; free pack types still work.
+func InitHandlers(database *appdb.DB, paymentProcessor payments.PaymentProcessor) {
+	if database == nil {
 		return
 	}
 	queriesOnce.Do(func() {
-		queries = q
+		queries = database.Queries
+		store = database
+		processor = paymentProcessor
 	})
 }
 
@@ -220,7 +235,7 @@ func HandleVisitPackTypeCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshVisitPackTypesList")
+		apiutil.AppendHXTrigger(w, "refreshVisitPackTypesList")
 		apiutil.WriteHTMLFeedback(w, http.StatusCreated, "Visit pack type created.")
 		return
 	}
@@ -289,7 +304,7 @@ func HandleVisitPackTypeUpdate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshVisitPackTypesList")
+		apiutil.AppendHXTrigger(w, "refreshVisitPackTypesList")
 		apiutil.WriteHTMLFeedback(w, http.StatusOK, "Visit pack type updated.")
 		return
 	}
@@ -344,7 +359,7 @@ func HandleVisitPackTypeDeactivate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if htmx.IsRequest(r) {
-		w.Header().Set("HX-Trigger", "refreshVisitPackTypesList")
+		apiutil.AppendHXTrigger(w, "refreshVisitPackTypesList")
 		apiutil.WriteHTMLFeedback(w, http.StatusOK, "Visit pack type deactivated.")
 		return
 	}
@@ -442,6 +457,133 @@ func HandleVisitPackSale(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// POST /api/v1/visit-packs/purchase
+func HandleVisitPackPurchase(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+
+	q := loadQueries()
+	database := loadDB()
+	if q == nil || database == nil {
+		logger.Error().Msg("Database queries not initialized")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	user := authz.UserFromContext(r.Context())
+	if !authz.IsStaff(user) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	req, err := decodeVisitPackPurchaseRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.UserID <= 0 {
+		http.Error(w, "user_id must be a positive integer", http.StatusBadRequest)
+		return
+	}
+	if req.PackTypeID <= 0 {
+		http.Error(w, "pack_type_id must be a positive integer", http.StatusBadRequest)
+		return
+	}
+
+	facilityID, err := apiutil.FacilityIDFromRequest(r, req.FacilityID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !apiutil.RequireFacilityAccess(w, r, facilityID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), visitPackQueryTimeout)
+	defer cancel()
+
+	packType, err := q.GetVisitPackType(ctx, dbgen.GetVisitPackTypeParams{
+		ID:         req.PackTypeID,
+		FacilityID: facilityID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Visit pack type not found", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Int64("visit_pack_type_id", req.PackTypeID).Msg("Failed to load visit pack type")
+		http.Error(w, "Failed to load visit pack type", http.StatusInternalServerError)
+		return
+	}
+
+	// Charge only members of the facility's organization.
+	inOrganization, err := memberInFacilityOrganization(ctx, q, req.UserID, facilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("user_id", req.UserID).Int64("facility_id", facilityID).Msg("Failed to check member organization")
+		http.Error(w, "Failed to load user", http.StatusInternalServerError)
+		return
+	}
+	if !inOrganization {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	result, err := models.PurchaseVisitPack(ctx, func(fn func(dbgen.Querier) error) error {
+		return database.RunInTx(ctx, func(txdb *appdb.DB) error {
+			return fn(txdb.Queries)
+		})
+	}, processor, packType, models.PurchaseVisitPackParams{
+		UserID: req.UserID,
+	})
+	if err != nil {
+		if apiutil.IsSQLiteForeignKeyViolation(err) {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		status, message := visitPackPurchaseErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			logger.Error().Err(err).Int64("visit_pack_type_id", packType.ID).Int64("user_id", req.UserID).Msg("Failed to purchase visit pack")
+		}
+		http.Error(w, message, status)
+		return
+	}
+
+	if err := apiutil.WriteJSON(w, http.StatusCreated, result); err != nil {
+		logger.Error().Err(err).Int64("visit_pack_id", result.VisitPack.ID).Msg("Failed to write visit pack purchase response")
+	}
+}
+
+// memberInFacilityOrganization reports whether userID's home facility
+// belongs to the same organization as facilityID. Members without a home
+// facility belong to none.
+func memberInFacilityOrganization(ctx context.Context, q visitPackQueries, userID, facilityID int64) (bool, error) {
+	member, err := q.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !member.HomeFacilityID.Valid {
+		return false, nil
+	}
+	facility, err := q.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		return false, err
+	}
+	if member.HomeFacilityID.Int64 == facilityID {
+		return true, nil
+	}
+	home, err := q.GetFacilityByID(ctx, member.HomeFacilityID.Int64)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return home.OrganizationID == facility.OrganizationID, nil
+}
+
 // GET /api/v1/users/{id}/visit-packs
 func HandleListUserVisitPacks(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
@@ -712,6 +854,51 @@ func validateVisitPackSaleRequest(req visitPackSaleRequest) error {
 	return nil
 }
 
+func decodeVisitPackPurchaseRequest(r *http.Request) (visitPackPurchaseRequest, error) {
+	if apiutil.IsJSONRequest(r) {
+		var req visitPackPurchaseRequest
+		return req, apiutil.DecodeJSON(r, &req)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return visitPackPurchaseRequest{}, err
+	}
+
+	facilityID, err := apiutil.ParseOptionalInt64Field(apiutil.FirstNonEmpty(r.FormValue("facility_id"), r.FormValue("facilityId")), "facility_id")
+	if err != nil {
+		return visitPackPurchaseRequest{}, err
+	}
+
+	userID, err := apiutil.ParseRequiredInt64Field(apiutil.FirstNonEmpty(r.FormValue("user_id"), r.FormValue("userId")), "user_id")
+	if err != nil {
+		return visitPackPurchaseRequest{}, err
+	}
+
+	packTypeID, err := apiutil.ParseRequiredInt64Field(apiutil.FirstNonEmpty(r.FormValue("pack_type_id"), r.FormValue("packTypeId")), "pack_type_id")
+	if err != nil {
+		return visitPackPurchaseRequest{}, err
+	}
+
+	return visitPackPurchaseRequest{
+		FacilityID: facilityID,
+		UserID:     userID,
+		PackTypeID: packTypeID,
+	}, nil
+}
+
+func visitPackPurchaseErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, models.ErrVisitPackUnavailable):
+		return http.StatusConflict, "Visit pack type is inactive"
+	case errors.Is(err, models.ErrPaymentsUnavailable):
+		return http.StatusServiceUnavailable, "Online payments are not available"
+	case errors.Is(err, payments.ErrDeclined):
+		return http.StatusPaymentRequired, "Payment was declined"
+	default:
+		return http.StatusInternalServerError, "Failed to purchase visit pack"
+	}
+}
+
 func visitPackTypeIDFromRequest(r *http.Request) (int64, error) {
 	raw := strings.TrimSpace(r.PathValue(visitPackTypeIDParam))
 	if raw == "" {
@@ -739,3 +926,7 @@ func userIDFromRequest(r *http.Request) (int64, error) {
 func loadQueries() visitPackQueries {
 	return queries
 }
+
+func loadDB() *appdb.DB {
+	return store
+}
@@ -19,6 +19,7 @@ import (
 	appdb "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/request"
+	"github.com/codr1/Pickleicious/internal/reservations"
 )
 
 var (
@@ -52,6 +53,10 @@ type waitlistJoinRequest struct {
 	StartTime  string            `json:"start_time"`
 	EndTime    string            `json:"end_time"`
 	TimeRange  waitlistTimeRange `json:"time_range"`
+	// AutoBook books the slot straight away when it opens instead of sending
+	// an offer; UseVisitPack pays for that booking with a visit pack.
+	AutoBook     bool `json:"auto_book"`
+	UseVisitPack bool `json:"use_visit_pack"`
 }
 
 // InitHandlers must be called during server startup before handling requests.
@@ -220,6 +225,8 @@ func HandleWaitlistJoin(w http.ResponseWriter, r *http.Request) {
 				TargetStartTime: targetStartTime,
 				TargetEndTime:   targetEndTime,
 				Status:          waitlistStatusPending,
+				AutoBook:        req.AutoBook,
+				UseVisitPack:    req.UseVisitPack,
 			})
 			if err != nil {
 				return fmt.Errorf("create waitlist entry: %w", err)
@@ -303,7 +310,8 @@ func HandleWaitlistLeave(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
 
 	q := loadQueries()
-	if q == nil {
+	database := loadDB()
+	if q == nil || database == nil {
 		logger.Error().Msg("Database queries not initialized")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -353,9 +361,14 @@ func HandleWaitlistLeave(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = q.DeleteWaitlistEntry(ctx, dbgen.DeleteWaitlistEntryParams{
-		ID:         waitlistID,
-		FacilityID: facilityID,
+	err = database.RunInTx(ctx, func(txdb *appdb.DB) error {
+		if _, err := txdb.Queries.DeleteWaitlistEntry(ctx, dbgen.DeleteWaitlistEntryParams{
+			ID:         waitlistID,
+			FacilityID: facilityID,
+		}); err != nil {
+			return err
+		}
+		return reservations.ReorderWaitlistPositions(ctx, txdb.Queries, entry)
 	})
 	if err != nil {
 		logger.Error().Err(err).Int64("waitlist_id", waitlistID).Msg("Failed to delete waitlist entry")
@@ -470,6 +483,17 @@ func HandleWaitlistConfigUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	expiredOfferAction := strings.ToLower(strings.TrimSpace(r.FormValue("expired_offer_action")))
+	if expiredOfferAction == "" {
+		expiredOfferAction = reservations.ExpiredOfferRemove
+	}
+	switch expiredOfferAction {
+	case reservations.ExpiredOfferRemove, reservations.ExpiredOfferRequeue:
+	default:
+		http.Error(w, "expired_offer_action must be remove or requeue", http.StatusBadRequest)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), waitlistQueryTimeout)
 	defer cancel()
 
@@ -479,6 +503,7 @@ func HandleWaitlistConfigUpdate(w http.ResponseWriter, r *http.Request) {
 		NotificationMode:          notificationMode,
 		OfferExpiryMinutes:        offerExpiryMinutes,
 		NotificationWindowMinutes: notificationWindowMinutes,
+		ExpiredOfferAction:        expiredOfferAction,
 	})
 	if err != nil {
 		logger.Error().Err(err).Int64("facility_id", facilityID).Msg("Failed to update waitlist config")
@@ -517,6 +542,8 @@ func decodeWaitlistJoinRequest(r *http.Request) (waitlistJoinRequest, error) {
 
 	req.StartTime = strings.TrimSpace(r.FormValue("start_time"))
 	req.EndTime = strings.TrimSpace(r.FormValue("end_time"))
+	req.AutoBook = apiutil.ParseBool(r.FormValue("auto_book"))
+	req.UseVisitPack = apiutil.ParseBool(r.FormValue("use_visit_pack"))
 	return req, nil
 }
 
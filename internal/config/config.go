@@ -3,8 +3,10 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/robfig/cron/v3"
@@ -14,6 +16,9 @@ import (
 type DatabaseConfig struct {
 	Driver   string `yaml:"driver"`
 	Filename string `yaml:"filename"`
+	// AutoMigrate applies pending migrations on boot. When it is off the
+	// server refuses to start against a database that is behind.
+	AutoMigrate bool `yaml:"auto_migrate"`
 	// For future Turso support
 	URL       string `yaml:"url,omitempty"`
 	AuthToken string `yaml:"-"` // Loaded from environment
@@ -26,6 +31,17 @@ type AWSConfig struct {
 	SESSecretAccessKey string `yaml:"-"` // Loaded from environment
 	SESRegion       string `yaml:"-"` // Loaded from environment
 	SESSender       string `yaml:"-"` // Loaded from environment
+	// SESEventsTopicARN restricts the bounce/complaint webhook to one SNS
+	// topic. Empty accepts any topic with a valid signature.
+	SESEventsTopicARN string `yaml:"-"` // Loaded from environment
+}
+
+// SMSConfig points at a Twilio-compatible Messages API.
+type SMSConfig struct {
+	AccountSID string `yaml:"-"`            // Loaded from environment
+	AuthToken  string `yaml:"-"`            // Loaded from environment
+	FromNumber string `yaml:"-"`            // Loaded from environment
+	APIBaseURL string `yaml:"api_base_url"` // Empty uses Twilio
 }
 
 type Config struct {
@@ -36,18 +52,62 @@ type Config struct {
 		BaseURL     string `yaml:"base_url"`
 		BaseDomain  string `yaml:"base_domain"` // e.g., "localhost" for dev (subdomain.localhost), "pickleicious.com" for prod
 		SecretKey   string `yaml:"-"`           // Loaded from environment
+		// DefaultTimezone is used for facilities with no timezone or an
+		// invalid one. Empty means UTC.
+		DefaultTimezone string `yaml:"default_timezone"`
 	} `yaml:"app"`
 
 	Database DatabaseConfig `yaml:"database"`
 
 	AWS AWSConfig `yaml:"aws"`
 
+	SMS SMSConfig `yaml:"sms"`
+
 	OpenPlay struct {
 		EnforcementInterval string `yaml:"enforcement_interval"`
+		// GenerationDays is how many days ahead a nightly job materializes
+		// sessions from rule slots. Zero leaves generation to staff.
+		GenerationDays int `yaml:"generation_days"`
 	} `yaml:"open_play"`
 
+	Waitlist struct {
+		// OfferExpiryInterval is the cron schedule for sweeping expired
+		// waitlist offers. Empty sweeps every minute.
+		OfferExpiryInterval string `yaml:"offer_expiry_interval"`
+	} `yaml:"waitlist"`
+
+	Members struct {
+		// DeletionRetentionDays is how long a deleted member's contact
+		// details are kept before a nightly job scrubs them. Zero uses
+		// defaultDeletionRetentionDays.
+		DeletionRetentionDays int `yaml:"deletion_retention_days"`
+	} `yaml:"members"`
+
+	Notifications struct {
+		// StaffRetentionDays is how long staff notifications are kept
+		// before a nightly job deletes them, read or not. Zero uses
+		// defaultStaffNotificationRetentionDays.
+		StaffRetentionDays int `yaml:"staff_retention_days"`
+	} `yaml:"notifications"`
+
+	Reservations struct {
+		// TransferWindowHours is how long a member has to accept a
+		// reservation another member transfers to them. Zero uses the
+		// reservation service's default of 48 hours.
+		TransferWindowHours int `yaml:"transfer_window_hours"`
+	} `yaml:"reservations"`
+
+	Photos struct {
+		// MaxUploadBytes caps the decoded size of an uploaded staff or
+		// member photo. Zero uses the images package default of 5 MB.
+		MaxUploadBytes int64 `yaml:"max_upload_bytes"`
+	} `yaml:"photos"`
+
 	Features struct {
 		EnableMetrics bool `yaml:"enable_metrics"`
+		// MetricsPort serves /metrics on a listener of its own, so it can
+		// be kept off the public port. Zero serves it on the app port.
+		MetricsPort   int  `yaml:"metrics_port"`
 		EnableTracing bool `yaml:"enable_tracing"`
 		EnableDebug   bool `yaml:"enable_debug"`
 	} `yaml:"features"`
@@ -55,8 +115,10 @@ type Config struct {
 	RateLimit RateLimitConfig `yaml:"rate_limit"`
 }
 
-// RateLimitConfig holds OTP rate limiting settings.
+// RateLimitConfig holds OTP and request rate limiting settings.
 type RateLimitConfig struct {
+	// Enabled switches every limiter on or off; leave it false in development
+	// to script against the API freely.
 	Enabled bool `yaml:"enabled"`
 
 	// TrustProxy: if true, extracts client IP from X-Forwarded-For (rightmost non-private IP).
@@ -75,6 +137,18 @@ type RateLimitConfig struct {
 		LockoutSeconds  int `yaml:"lockout_seconds"`     // default: 300 (5 min)
 		MaxPerIPPerHour int `yaml:"max_per_ip_per_hour"` // default: 30
 	} `yaml:"otp_verify"`
+
+	// Anonymous limits login and auth requests per client IP.
+	Anonymous RequestRateConfig `yaml:"anonymous"` // default: 60/min, burst 20
+
+	// Booking limits booking and cancellation requests per signed-in user.
+	Booking RequestRateConfig `yaml:"booking"` // default: 20/min, burst 10
+}
+
+// RequestRateConfig sizes a token bucket.
+type RequestRateConfig struct {
+	RequestsPerMinute int `yaml:"requests_per_minute"`
+	Burst             int `yaml:"burst"`
 }
 
 // Load loads both .env and yaml configuration
@@ -105,6 +179,10 @@ func Load(configPath string) (*Config, error) {
 	cfg.AWS.SESSecretAccessKey = os.Getenv("SES_SECRET_ACCESS_KEY")
 	cfg.AWS.SESRegion = os.Getenv("SES_REGION")
 	cfg.AWS.SESSender = os.Getenv("SES_SENDER")
+	cfg.AWS.SESEventsTopicARN = os.Getenv("SES_EVENTS_TOPIC_ARN")
+	cfg.SMS.AccountSID = os.Getenv("SMS_ACCOUNT_SID")
+	cfg.SMS.AuthToken = os.Getenv("SMS_AUTH_TOKEN")
+	cfg.SMS.FromNumber = os.Getenv("SMS_FROM_NUMBER")
 
 	// Allow environment override (e.g., APP_ENVIRONMENT=staging for real Cognito)
 	if env := os.Getenv("APP_ENVIRONMENT"); env != "" {
@@ -129,9 +207,20 @@ func (c *Config) Validate() error {
 	if c.App.SecretKey == "" {
 		return fmt.Errorf("app secret key is required")
 	}
+	if c.App.BaseURL == "" {
+		return fmt.Errorf("app base URL is required")
+	}
+	if baseURL, err := url.Parse(c.App.BaseURL); err != nil || (baseURL.Scheme != "http" && baseURL.Scheme != "https") || baseURL.Host == "" {
+		return fmt.Errorf("app base URL must be an absolute http or https URL")
+	}
 	if c.Database.Driver == "" {
 		return fmt.Errorf("database driver is required")
 	}
+	if c.App.DefaultTimezone != "" {
+		if _, err := time.LoadLocation(c.App.DefaultTimezone); err != nil {
+			return fmt.Errorf("app default timezone must be a valid IANA timezone: %w", err)
+		}
+	}
 	if c.OpenPlay.EnforcementInterval == "" {
 		return fmt.Errorf("open play enforcement interval is required")
 	}
@@ -140,6 +229,34 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("open play enforcement interval must be a valid cron expression: %w", err)
 	}
 
+	if c.Waitlist.OfferExpiryInterval != "" {
+		if _, err := cronParser.Parse(c.Waitlist.OfferExpiryInterval); err != nil {
+			return fmt.Errorf("waitlist offer expiry interval must be a valid cron expression: %w", err)
+		}
+	}
+
+	if c.OpenPlay.GenerationDays < 0 {
+		return fmt.Errorf("open play generation days must not be negative")
+	}
+	if c.Members.DeletionRetentionDays < 0 {
+		return fmt.Errorf("member deletion retention days must not be negative")
+	}
+	if c.Notifications.StaffRetentionDays < 0 {
+		return fmt.Errorf("staff notification retention days must not be negative")
+	}
+	if c.Reservations.TransferWindowHours < 0 {
+		return fmt.Errorf("reservation transfer window hours must not be negative")
+	}
+	if c.Photos.MaxUploadBytes < 0 {
+		return fmt.Errorf("photo max upload bytes must not be negative")
+	}
+	if c.Features.MetricsPort < 0 {
+		return fmt.Errorf("metrics port must not be negative")
+	}
+	if c.Features.MetricsPort != 0 && c.Features.MetricsPort == c.App.Port {
+		return fmt.Errorf("metrics port must differ from the app port")
+	}
+
 	// Validate based on database driver
 	switch c.Database.Driver {
 	case "sqlite":
@@ -160,6 +277,30 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+const defaultDeletionRetentionDays = 30
+
+// MemberDeletionRetention returns how long deleted members keep their
+// contact details, with the default applied.
+func (c *Config) MemberDeletionRetention() time.Duration {
+	days := c.Members.DeletionRetentionDays
+	if days == 0 {
+		days = defaultDeletionRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+const defaultStaffNotificationRetentionDays = 90
+
+// StaffNotificationRetention returns how long staff notifications are kept,
+// with the default applied.
+func (c *Config) StaffNotificationRetention() time.Duration {
+	days := c.Notifications.StaffRetentionDays
+	if days == 0 {
+		days = defaultStaffNotificationRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
 // RateLimitDefaults returns the rate limit config with defaults applied.
 func (c *RateLimitConfig) WithDefaults() RateLimitConfig {
 	cfg := *c
@@ -181,5 +322,17 @@ func (c *RateLimitConfig) WithDefaults() RateLimitConfig {
 	if cfg.OTPVerify.MaxPerIPPerHour == 0 {
 		cfg.OTPVerify.MaxPerIPPerHour = 30
 	}
+	if cfg.Anonymous.RequestsPerMinute == 0 {
+		cfg.Anonymous.RequestsPerMinute = 60
+	}
+	if cfg.Anonymous.Burst == 0 {
+		cfg.Anonymous.Burst = 20
+	}
+	if cfg.Booking.RequestsPerMinute == 0 {
+		cfg.Booking.RequestsPerMinute = 20
+	}
+	if cfg.Booking.Burst == 0 {
+		cfg.Booking.Burst = 10
+	}
 	return cfg
 }
@@ -4,29 +4,26 @@ package db
 import (
 	"context"
 	"database/sql"
-	"embed"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/sqlite3"
-	"github.com/golang-migrate/migrate/v4/source/iofs"
-
 	"github.com/codr1/Pickleicious/internal/config"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/db/migrate"
 )
 
-//go:embed migrations/*.sql
-var migrationsFS embed.FS
-
 type DB struct {
 	*sql.DB
 	Queries *dbgen.Queries
+	// Facilities caches facility lookups for the hot request paths. It reads
+	// outside any transaction.
+	Facilities *FacilityCache
 }
 
-// New creates a new DB instance with the given data source name
+// New creates a new DB instance with the given data source name and applies
+// any pending migrations.
 func New(dataSourceName string) (*DB, error) {
 	dataSourceName = ensureForeignKeysEnabledDSN(dataSourceName)
 	sqlDB, err := sql.Open("sqlite3", dataSourceName)
@@ -48,12 +45,15 @@ func New(dataSourceName string) (*DB, error) {
 	queries := dbgen.New(sqlDB)
 
 	return &DB{
-		DB:      sqlDB,
-		Queries: queries,
+		DB:         sqlDB,
+		Queries:    queries,
+		Facilities: NewFacilityCache(queries, DefaultFacilityCacheTTL),
 	}, nil
 }
 
-// NewFromConfig creates a new DB instance from configuration
+// NewFromConfig creates a new DB instance from configuration. It does not
+// migrate; the caller decides whether to migrate on boot or only check the
+// schema is current.
 func NewFromConfig(cfg *config.Config) (*DB, error) {
 	var db *sql.DB
 	var err error
@@ -86,16 +86,11 @@ func NewFromConfig(cfg *config.Config) (*DB, error) {
 		return nil, fmt.Errorf("error opening database: %w", err)
 	}
 
-	// Run migrations
-	if err := runMigrations(db); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("error running migrations: %w", err)
-	}
-
 	queries := dbgen.New(db)
 	return &DB{
-		DB:      db,
-		Queries: queries,
+		DB:         db,
+		Queries:    queries,
+		Facilities: NewFacilityCache(queries, DefaultFacilityCacheTTL),
 	}, nil
 }
 
@@ -120,40 +115,30 @@ func enableSQLiteForeignKeys(db *sql.DB) error {
 }
 
 func runMigrations(db *sql.DB) error {
-	// Create migrate instance
-	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
-	if err != nil {
-		return fmt.Errorf("could not create migrate driver: %w", err)
-	}
-
-	// Create source instance
-	source, err := iofs.New(migrationsFS, "migrations")
+	runner, err := migrate.New(db)
 	if err != nil {
-		return fmt.Errorf("could not create source: %w", err)
+		return err
 	}
+	defer runner.Close()
+	return runner.Up()
+}
 
-	// Create migrate instance
-	m, err := migrate.NewWithInstance(
-		"iofs", source,
-		"sqlite3", driver,
-	)
+// MigrationVersion reports the schema version last applied to the database
+// and whether that migration failed partway through.
+func (db *DB) MigrationVersion(ctx context.Context) (version int64, dirty bool, err error) {
+	err = db.DB.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
 	if err != nil {
-		return fmt.Errorf("could not create migrate instance: %w", err)
-	}
-
-	// Run migrations
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("could not run migrations: %w", err)
+		return 0, false, fmt.Errorf("could not read migration version: %w", err)
 	}
-
-	return nil
+	return version, dirty, nil
 }
 
 // WithTx creates a new DB instance with the given transaction
 func (db *DB) WithTx(tx *sql.Tx) *DB {
 	return &DB{
-		DB:      db.DB,
-		Queries: dbgen.New(tx),
+		DB:         db.DB,
+		Queries:    dbgen.New(tx),
+		Facilities: db.Facilities,
 	}
 }
 
@@ -0,0 +1,88 @@
+// internal/db/facility_cache.go
+package db
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// DefaultFacilityCacheTTL bounds how stale a cached facility can be when an
+// update path forgets to invalidate it.
+const DefaultFacilityCacheTTL = 30 * time.Second
+
+type facilityCacheEntry struct {
+	facility  dbgen.Facility
+	expiresAt time.Time
+}
+
+// FacilityCache is a read-through cache over GetFacilityByID. Facility
+// settings change rarely but are read on nearly every member request, so
+// entries live for a short TTL and the facility update endpoints invalidate
+// them explicitly. It is safe for concurrent use.
+type FacilityCache struct {
+	queries *dbgen.Queries
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu      sync.RWMutex
+	entries map[int64]facilityCacheEntry
+	// generation is bumped on every invalidation so a lookup that raced one
+	// does not store the row it read before the update.
+	generation uint64
+}
+
+// NewFacilityCache returns a cache reading through q. A non-positive ttl
+// uses DefaultFacilityCacheTTL.
+func NewFacilityCache(q *dbgen.Queries, ttl time.Duration) *FacilityCache {
+	if ttl <= 0 {
+		ttl = DefaultFacilityCacheTTL
+	}
+	return &FacilityCache{
+		queries: q,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[int64]facilityCacheEntry),
+	}
+}
+
+// GetFacilityByID returns the cached facility, loading it on a miss or once
+// the entry has expired. Lookup errors, including sql.ErrNoRows, are not
+// cached.
+func (c *FacilityCache) GetFacilityByID(ctx context.Context, id int64) (dbgen.Facility, error) {
+	now := c.now()
+
+	c.mu.RLock()
+	entry, ok := c.entries[id]
+	generation := c.generation
+	c.mu.RUnlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.facility, nil
+	}
+
+	facility, err := c.queries.GetFacilityByID(ctx, id)
+	if err != nil {
+		return dbgen.Facility{}, err
+	}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.entries[id] = facilityCacheEntry{facility: facility, expiresAt: now.Add(c.ttl)}
+	}
+	c.mu.Unlock()
+	return facility, nil
+}
+
+// Invalidate drops the cached facility so the next lookup reads the updated
+// row. Call it after the update has committed. It is a no-op on a nil cache.
+func (c *FacilityCache) Invalidate(id int64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.entries, id)
+	c.generation++
+	c.mu.Unlock()
+}
@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func setupFacilityCacheTest(t *testing.T) (*DB, int64) {
+	t.Helper()
+
+	database, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("create test db: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = database.Close()
+	})
+
+	result, err := database.Exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := result.LastInsertId()
+	result, err = database.Exec(
+		"INSERT INTO facilities (organization_id, name, slug, timezone, max_advance_booking_days) VALUES (?, 'Main', 'main', 'UTC', 7)",
+		orgID,
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := result.LastInsertId()
+	return database, facilityID
+}
+
+func setMaxAdvanceBookingDays(t *testing.T, database *DB, facilityID, days int64) {
+	t.Helper()
+
+	if _, err := database.Queries.UpdateFacilityBookingConfig(context.Background(), dbgen.UpdateFacilityBookingConfigParams{
+		ID:                        facilityID,
+		MaxAdvanceBookingDays:     days,
+		MaxMemberReservations:     30,
+		MaxCourtsPerMemberBooking: 1,
+		SlotDurationMinutes:       60,
+		MinBookingMinutes:         60,
+		MaxGuestsPerReservation:   2,
+		ReservationLimitScope:     "person",
+	}); err != nil {
+		t.Fatalf("update booking config: %v", err)
+	}
+}
+
+func TestFacilityCache_InvalidatePicksUpSettingsWithinTTL(t *testing.T) {
+	database, facilityID := setupFacilityCacheTest(t)
+	ctx := context.Background()
+	cache := NewFacilityCache(database.Queries, time.Hour)
+
+	facility, err := cache.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		t.Fatalf("get facility: %v", err)
+	}
+	if facility.MaxAdvanceBookingDays != 7 {
+		t.Fatalf("expected 7 advance days, got %d", facility.MaxAdvanceBookingDays)
+	}
+
+	setMaxAdvanceBookingDays(t, database, facilityID, 14)
+	facility, err = cache.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		t.Fatalf("get cached facility: %v", err)
+	}
+	if facility.MaxAdvanceBookingDays != 7 {
+		t.Fatalf("expected cached 7 advance days before invalidation, got %d", facility.MaxAdvanceBookingDays)
+	}
+
+	cache.Invalidate(facilityID)
+	facility, err = cache.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		t.Fatalf("get invalidated facility: %v", err)
+	}
+	if facility.MaxAdvanceBookingDays != 14 {
+		t.Fatalf("expected 14 advance days after invalidation, got %d", facility.MaxAdvanceBookingDays)
+	}
+}
+
+func TestFacilityCache_ReloadsAfterTTL(t *testing.T) {
+	database, facilityID := setupFacilityCacheTest(t)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewFacilityCache(database.Queries, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	if _, err := cache.GetFacilityByID(ctx, facilityID); err != nil {
+		t.Fatalf("get facility: %v", err)
+	}
+	setMaxAdvanceBookingDays(t, database, facilityID, 21)
+
+	now = now.Add(time.Minute)
+	facility, err := cache.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		t.Fatalf("get expired facility: %v", err)
+	}
+	if facility.MaxAdvanceBookingDays != 21 {
+		t.Fatalf("expected 21 advance days after expiry, got %d", facility.MaxAdvanceBookingDays)
+	}
+}
+
+func TestFacilityCache_ConcurrentAccess(t *testing.T) {
+	database, facilityID := setupFacilityCacheTest(t)
+	ctx := context.Background()
+	cache := NewFacilityCache(database.Queries, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if _, err := cache.GetFacilityByID(ctx, facilityID); err != nil {
+					t.Errorf("get facility: %v", err)
+					return
+				}
+				if j%5 == 0 {
+					cache.Invalidate(facilityID)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var nilCache *FacilityCache
+	nilCache.Invalidate(facilityID)
+}
@@ -8,6 +8,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 )
 
@@ -60,6 +61,27 @@ func (q *Queries) CreateCancellationPolicyTier(ctx context.Context, arg CreateCa
 	return i, err
 }
 
+const deleteCancellationPolicy = `-- name: DeleteCancellationPolicy :execrows
+DELETE FROM cancellation_policy_tiers
+WHERE facility_id = ?1
+  AND reservation_type_id IS ?2
+`
+
+type DeleteCancellationPolicyParams struct {
+	FacilityID        int64         `json:"facilityId"`
+	ReservationTypeID sql.NullInt64 `json:"reservationTypeId"`
+}
+
+// Removes every tier of one policy: the facility default when
+// reservation_type_id is NULL, otherwise that type's override.
+func (q *Queries) DeleteCancellationPolicy(ctx context.Context, arg DeleteCancellationPolicyParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteCancellationPolicyStmt, deleteCancellationPolicy, arg.FacilityID, arg.ReservationTypeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteCancellationPolicyTier = `-- name: DeleteCancellationPolicyTier :execrows
 DELETE FROM cancellation_policy_tiers
 WHERE id = ?1
@@ -166,7 +188,8 @@ SELECT
     refund_percentage_applied,
     fee_waived,
     hours_before_start,
-    created_at
+    created_at,
+    refund_amount_cents
 FROM reservation_cancellations
 WHERE reservation_id = ?1
 ORDER BY cancelled_at DESC
@@ -185,6 +208,7 @@ func (q *Queries) GetLatestCancellationByReservationID(ctx context.Context, rese
 		&i.FeeWaived,
 		&i.HoursBeforeStart,
 		&i.CreatedAt,
+		&i.RefundAmountCents,
 	)
 	return i, err
 }
@@ -243,6 +267,62 @@ func (q *Queries) ListCancellationPolicyTiers(ctx context.Context, arg ListCance
 	return items, nil
 }
 
+const listCancellationPolicyTiersForFacilities = `-- name: ListCancellationPolicyTiersForFacilities :many
+SELECT
+    id,
+    facility_id,
+    reservation_type_id,
+    min_hours_before,
+    refund_percentage,
+    created_at,
+    updated_at
+FROM cancellation_policy_tiers
+WHERE facility_id IN (/*SLICE:facility_ids*/?)
+ORDER BY facility_id, min_hours_before DESC
+`
+
+// Empty facility_ids intentionally yields zero rows (caller should prefilter).
+func (q *Queries) ListCancellationPolicyTiersForFacilities(ctx context.Context, facilityIds []int64) ([]CancellationPolicyTier, error) {
+	query := listCancellationPolicyTiersForFacilities
+	var queryParams []interface{}
+	if len(facilityIds) > 0 {
+		for _, v := range facilityIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:facility_ids*/?", strings.Repeat(",?", len(facilityIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:facility_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CancellationPolicyTier
+	for rows.Next() {
+		var i CancellationPolicyTier
+		if err := rows.Scan(
+			&i.ID,
+			&i.FacilityID,
+			&i.ReservationTypeID,
+			&i.MinHoursBefore,
+			&i.RefundPercentage,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const logCancellation = `-- name: LogCancellation :one
 INSERT INTO reservation_cancellations (
     reservation_id,
@@ -250,14 +330,16 @@ INSERT INTO reservation_cancellations (
     cancelled_at,
     refund_percentage_applied,
     fee_waived,
-    hours_before_start
+    hours_before_start,
+    refund_amount_cents
 ) VALUES (
     ?1,
     ?2,
     ?3,
     ?4,
     ?5,
-    ?6
+    ?6,
+    ?7
 )
 RETURNING
     id,
@@ -267,16 +349,18 @@ RETURNING
     refund_percentage_applied,
     fee_waived,
     hours_before_start,
-    created_at
+    created_at,
+    refund_amount_cents
 `
 
 type LogCancellationParams struct {
-	ReservationID           int64     `json:"reservationId"`
-	CancelledByUserID       int64     `json:"cancelledByUserId"`
-	CancelledAt             time.Time `json:"cancelledAt"`
-	RefundPercentageApplied int64     `json:"refundPercentageApplied"`
-	FeeWaived               bool      `json:"feeWaived"`
-	HoursBeforeStart        int64     `json:"hoursBeforeStart"`
+	ReservationID           int64         `json:"reservationId"`
+	CancelledByUserID       int64         `json:"cancelledByUserId"`
+	CancelledAt             time.Time     `json:"cancelledAt"`
+	RefundPercentageApplied int64         `json:"refundPercentageApplied"`
+	FeeWaived               bool          `json:"feeWaived"`
+	HoursBeforeStart        int64         `json:"hoursBeforeStart"`
+	RefundAmountCents       sql.NullInt64 `json:"refundAmountCents"`
 }
 
 func (q *Queries) LogCancellation(ctx context.Context, arg LogCancellationParams) (ReservationCancellation, error) {
@@ -287,6 +371,7 @@ func (q *Queries) LogCancellation(ctx context.Context, arg LogCancellationParams
 		arg.RefundPercentageApplied,
 		arg.FeeWaived,
 		arg.HoursBeforeStart,
+		arg.RefundAmountCents,
 	)
 	var i ReservationCancellation
 	err := row.Scan(
@@ -298,6 +383,7 @@ func (q *Queries) LogCancellation(ctx context.Context, arg LogCancellationParams
 		&i.FeeWaived,
 		&i.HoursBeforeStart,
 		&i.CreatedAt,
+		&i.RefundAmountCents,
 	)
 	return i, err
 }
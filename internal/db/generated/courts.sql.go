@@ -9,11 +9,24 @@ import (
 	"context"
 )
 
+const countActiveCourts = `-- name: CountActiveCourts :one
+SELECT COUNT(*) FROM courts
+WHERE facility_id = ?
+  AND status = 'active'
+`
+
+func (q *Queries) CountActiveCourts(ctx context.Context, facilityID int64) (int64, error) {
+	row := q.queryRow(ctx, q.countActiveCourtsStmt, countActiveCourts, facilityID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCourt = `-- name: CreateCourt :one
 INSERT INTO courts (
     facility_id, name, court_number, status
 ) VALUES (?, ?, ?, ?)
-RETURNING id, facility_id, name, court_number, status, created_at, updated_at
+RETURNING id, facility_id, name, court_number, status, created_at, updated_at, is_indoor, surface, has_lights
 `
 
 type CreateCourtParams struct {
@@ -39,12 +52,15 @@ func (q *Queries) CreateCourt(ctx context.Context, arg CreateCourtParams) (Court
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsIndoor,
+		&i.Surface,
+		&i.HasLights,
 	)
 	return i, err
 }
 
 const getCourt = `-- name: GetCourt :one
-SELECT id, facility_id, name, court_number, status, created_at, updated_at FROM courts
+SELECT id, facility_id, name, court_number, status, created_at, updated_at, is_indoor, surface, has_lights FROM courts
 WHERE id = ? LIMIT 1
 `
 
@@ -60,12 +76,56 @@ func (q *Queries) GetCourt(ctx context.Context, id int64) (Court, error) {
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsIndoor,
+		&i.Surface,
+		&i.HasLights,
 	)
 	return i, err
 }
 
+const listActiveCourts = `-- name: ListActiveCourts :many
+SELECT id, facility_id, name, court_number, status, created_at, updated_at, is_indoor, surface, has_lights FROM courts
+WHERE facility_id = ?
+  AND status = 'active'
+ORDER BY court_number
+`
+
+func (q *Queries) ListActiveCourts(ctx context.Context, facilityID int64) ([]Court, error) {
+	rows, err := q.query(ctx, q.listActiveCourtsStmt, listActiveCourts, facilityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Court
+	for rows.Next() {
+		var i Court
+		if err := rows.Scan(
+			&i.ID,
+			&i.FacilityID,
+			&i.Name,
+			&i.CourtNumber,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsIndoor,
+			&i.Surface,
+			&i.HasLights,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCourts = `-- name: ListCourts :many
-SELECT id, facility_id, name, court_number, status, created_at, updated_at FROM courts
+SELECT id, facility_id, name, court_number, status, created_at, updated_at, is_indoor, surface, has_lights FROM courts
 WHERE facility_id = ?
 ORDER BY court_number
 `
@@ -87,6 +147,9 @@ func (q *Queries) ListCourts(ctx context.Context, facilityID int64) ([]Court, er
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsIndoor,
+			&i.Surface,
+			&i.HasLights,
 		); err != nil {
 			return nil, err
 		}
@@ -105,7 +168,7 @@ const updateCourtStatus = `-- name: UpdateCourtStatus :one
 UPDATE courts
 SET status = ?, updated_at = CURRENT_TIMESTAMP
 WHERE id = ?
-RETURNING id, facility_id, name, court_number, status, created_at, updated_at
+RETURNING id, facility_id, name, court_number, status, created_at, updated_at, is_indoor, surface, has_lights
 `
 
 type UpdateCourtStatusParams struct {
@@ -124,6 +187,9 @@ func (q *Queries) UpdateCourtStatus(ctx context.Context, arg UpdateCourtStatusPa
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsIndoor,
+		&i.Surface,
+		&i.HasLights,
 	)
 	return i, err
 }
@@ -7,9 +7,85 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
+const countBusyCourtsByHour = `-- name: CountBusyCourtsByHour :many
+WITH RECURSIVE slots(slot_index) AS (
+    SELECT 0
+    UNION ALL
+    SELECT slot_index + 1
+    FROM slots
+    WHERE julianday(?1, '+' || (slot_index + 1) || ' hours') < julianday(?2)
+),
+blocks AS (
+    SELECT rc.court_id,
+        julianday(r.start_time) AS block_start,
+        julianday(r.end_time, '+' || COALESCE(rt.buffer_minutes, f.buffer_minutes) || ' minutes') AS block_end
+    FROM reservation_courts rc
+    JOIN reservations r ON r.id = rc.reservation_id
+    JOIN reservation_types rt ON rt.id = r.reservation_type_id
+    JOIN facilities f ON f.id = r.facility_id
+    JOIN courts c ON c.id = rc.court_id
+    WHERE r.facility_id = ?3
+      AND c.status = 'active'
+      AND r.start_time < ?2
+      AND julianday(r.end_time, '+' || COALESCE(rt.buffer_minutes, f.buffer_minutes) || ' minutes') > julianday(?1)
+      AND NOT EXISTS (
+          SELECT 1
+          FROM reservation_cancellations rcc
+          WHERE rcc.reservation_id = r.id
+      )
+)
+SELECT slots.slot_index,
+    COUNT(DISTINCT blocks.court_id) AS busy_courts
+FROM slots
+LEFT JOIN blocks
+    ON blocks.block_start < julianday(?1, '+' || (slots.slot_index + 1) || ' hours')
+    AND blocks.block_end > julianday(?1, '+' || slots.slot_index || ' hours')
+GROUP BY slots.slot_index
+ORDER BY slots.slot_index
+`
+
+type CountBusyCourtsByHourParams struct {
+	RangeStart interface{} `json:"rangeStart"`
+	RangeEnd   interface{} `json:"rangeEnd"`
+	FacilityID int64       `json:"facilityId"`
+}
+
+type CountBusyCourtsByHourRow struct {
+	SlotIndex  int64 `json:"slotIndex"`
+	BusyCourts int64 `json:"busyCourts"`
+}
+
+// One row per hour from range_start up to range_end with how many active
+// courts are reserved for any part of it. Each reservation holds its court
+// through the turnover buffer that follows it, and maintenance blocks count
+// like any other reservation.
+func (q *Queries) CountBusyCourtsByHour(ctx context.Context, arg CountBusyCourtsByHourParams) ([]CountBusyCourtsByHourRow, error) {
+	rows, err := q.query(ctx, q.countBusyCourtsByHourStmt, countBusyCourtsByHour, arg.RangeStart, arg.RangeEnd, arg.FacilityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountBusyCourtsByHourRow
+	for rows.Next() {
+		var i CountBusyCourtsByHourRow
+		if err := rows.Scan(&i.SlotIndex, &i.BusyCourts); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countCheckinsByFacilityInRange = `-- name: CountCheckinsByFacilityInRange :one
 SELECT COUNT(*) AS checkins_count
 FROM facility_visits
@@ -31,6 +107,119 @@ func (q *Queries) CountCheckinsByFacilityInRange(ctx context.Context, arg CountC
 	return checkins_count, err
 }
 
+const countProSessionsByProInRange = `-- name: CountProSessionsByProInRange :many
+SELECT s.id AS pro_id,
+    s.first_name,
+    s.last_name,
+    COUNT(*) AS lesson_count
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+JOIN staff s ON s.id = r.pro_id
+WHERE r.facility_id = ?1
+  AND rt.name = 'PRO_SESSION'
+  AND r.start_time < ?2
+  AND r.end_time > ?3
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+GROUP BY s.id, s.first_name, s.last_name
+ORDER BY lesson_count DESC, s.last_name, s.first_name
+`
+
+type CountProSessionsByProInRangeParams struct {
+	FacilityID int64     `json:"facilityId"`
+	EndTime    time.Time `json:"endTime"`
+	StartTime  time.Time `json:"startTime"`
+}
+
+type CountProSessionsByProInRangeRow struct {
+	ProID       int64  `json:"proId"`
+	FirstName   string `json:"firstName"`
+	LastName    string `json:"lastName"`
+	LessonCount int64  `json:"lessonCount"`
+}
+
+func (q *Queries) CountProSessionsByProInRange(ctx context.Context, arg CountProSessionsByProInRangeParams) ([]CountProSessionsByProInRangeRow, error) {
+	rows, err := q.query(ctx, q.countProSessionsByProInRangeStmt, countProSessionsByProInRange, arg.FacilityID, arg.EndTime, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountProSessionsByProInRangeRow
+	for rows.Next() {
+		var i CountProSessionsByProInRangeRow
+		if err := rows.Scan(
+			&i.ProID,
+			&i.FirstName,
+			&i.LastName,
+			&i.LessonCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countReservationTypeNamesInRange = `-- name: CountReservationTypeNamesInRange :many
+SELECT rt.name AS reservation_type_name,
+    COUNT(*) AS reservation_count
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+WHERE r.facility_id = ?1
+  AND r.start_time < ?2
+  AND r.end_time > ?3
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+GROUP BY rt.name
+ORDER BY rt.name
+`
+
+type CountReservationTypeNamesInRangeParams struct {
+	FacilityID int64     `json:"facilityId"`
+	EndTime    time.Time `json:"endTime"`
+	StartTime  time.Time `json:"startTime"`
+}
+
+type CountReservationTypeNamesInRangeRow struct {
+	ReservationTypeName string `json:"reservationTypeName"`
+	ReservationCount    int64  `json:"reservationCount"`
+}
+
+func (q *Queries) CountReservationTypeNamesInRange(ctx context.Context, arg CountReservationTypeNamesInRangeParams) ([]CountReservationTypeNamesInRangeRow, error) {
+	rows, err := q.query(ctx, q.countReservationTypeNamesInRangeStmt, countReservationTypeNamesInRange, arg.FacilityID, arg.EndTime, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountReservationTypeNamesInRangeRow
+	for rows.Next() {
+		var i CountReservationTypeNamesInRangeRow
+		if err := rows.Scan(&i.ReservationTypeName, &i.ReservationCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countReservationsByTypeInRange = `-- name: CountReservationsByTypeInRange :many
 SELECT reservation_type_id,
     COUNT(*) AS reservation_count
@@ -295,3 +484,376 @@ func (q *Queries) GetCancellationMetricsInRange(ctx context.Context, arg GetCanc
 	)
 	return i, err
 }
+
+const listCourtBookingsInRange = `-- name: ListCourtBookingsInRange :many
+SELECT rc.court_id,
+    r.start_time,
+    r.end_time,
+    rt.name AS reservation_type_name
+FROM reservation_courts rc
+JOIN reservations r ON r.id = rc.reservation_id
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+WHERE r.facility_id = ?1
+  AND r.start_time < ?2
+  AND r.end_time > ?3
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY rc.court_id, r.start_time
+`
+
+type ListCourtBookingsInRangeParams struct {
+	FacilityID int64     `json:"facilityId"`
+	EndTime    time.Time `json:"endTime"`
+	StartTime  time.Time `json:"startTime"`
+}
+
+type ListCourtBookingsInRangeRow struct {
+	CourtID             int64     `json:"courtId"`
+	StartTime           time.Time `json:"startTime"`
+	EndTime             time.Time `json:"endTime"`
+	ReservationTypeName string    `json:"reservationTypeName"`
+}
+
+// One row per reserved court, so multi-court reservations count on each.
+func (q *Queries) ListCourtBookingsInRange(ctx context.Context, arg ListCourtBookingsInRangeParams) ([]ListCourtBookingsInRangeRow, error) {
+	rows, err := q.query(ctx, q.listCourtBookingsInRangeStmt, listCourtBookingsInRange, arg.FacilityID, arg.EndTime, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCourtBookingsInRangeRow
+	for rows.Next() {
+		var i ListCourtBookingsInRangeRow
+		if err := rows.Scan(
+			&i.CourtID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.ReservationTypeName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMaintenanceCourtsInRange = `-- name: ListMaintenanceCourtsInRange :many
+SELECT c.id AS court_id,
+    c.name,
+    c.court_number,
+    c.status,
+    r.start_time,
+    r.end_time
+FROM courts c
+LEFT JOIN reservation_courts rc ON rc.court_id = c.id
+    AND rc.reservation_id IN (
+        SELECT r2.id
+        FROM reservations r2
+        JOIN reservation_types rt ON rt.id = r2.reservation_type_id
+        WHERE r2.facility_id = ?1
+          AND rt.name = 'MAINTENANCE'
+          AND r2.start_time < ?2
+          AND r2.end_time > ?3
+          AND NOT EXISTS (
+              SELECT 1
+              FROM reservation_cancellations rcc
+              WHERE rcc.reservation_id = r2.id
+          )
+    )
+LEFT JOIN reservations r ON r.id = rc.reservation_id
+WHERE c.facility_id = ?1
+  AND (c.status != 'active' OR r.id IS NOT NULL)
+ORDER BY c.court_number, r.start_time
+`
+
+type ListMaintenanceCourtsInRangeParams struct {
+	FacilityID int64     `json:"facilityId"`
+	EndTime    time.Time `json:"endTime"`
+	StartTime  time.Time `json:"startTime"`
+}
+
+type ListMaintenanceCourtsInRangeRow struct {
+	CourtID     int64        `json:"courtId"`
+	Name        string       `json:"name"`
+	CourtNumber int64        `json:"courtNumber"`
+	Status      string       `json:"status"`
+	StartTime   sql.NullTime `json:"startTime"`
+	EndTime     sql.NullTime `json:"endTime"`
+}
+
+// Courts taken out of service, either by a MAINTENANCE block in the range or
+// by a court status other than active.
+func (q *Queries) ListMaintenanceCourtsInRange(ctx context.Context, arg ListMaintenanceCourtsInRangeParams) ([]ListMaintenanceCourtsInRangeRow, error) {
+	rows, err := q.query(ctx, q.listMaintenanceCourtsInRangeStmt, listMaintenanceCourtsInRange, arg.FacilityID, arg.EndTime, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMaintenanceCourtsInRangeRow
+	for rows.Next() {
+		var i ListMaintenanceCourtsInRangeRow
+		if err := rows.Scan(
+			&i.CourtID,
+			&i.Name,
+			&i.CourtNumber,
+			&i.Status,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNoShowReservationsInRange = `-- name: ListNoShowReservationsInRange :many
+SELECT r.id,
+    r.facility_id,
+    r.start_time,
+    r.end_time,
+    rt.name AS reservation_type_name,
+    u.first_name AS primary_first_name,
+    u.last_name AS primary_last_name
+FROM reservations r
+LEFT JOIN reservation_types rt ON rt.id = r.reservation_type_id
+LEFT JOIN users u ON u.id = r.primary_user_id
+WHERE (?1 = 0 OR r.facility_id = ?1)
+  AND r.start_time >= ?2
+  AND r.start_time < ?3
+  AND r.end_time < ?4
+  AND (
+        r.primary_user_id IS NOT NULL
+     OR EXISTS (
+         SELECT 1
+         FROM reservation_participants rp
+         WHERE rp.reservation_id = r.id
+     )
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_checkins rci
+      WHERE rci.reservation_id = r.id
+  )
+ORDER BY r.start_time DESC, r.id DESC
+`
+
+type ListNoShowReservationsInRangeParams struct {
+	FacilityID     interface{} `json:"facilityId"`
+	StartTime      time.Time   `json:"startTime"`
+	EndTime        time.Time   `json:"endTime"`
+	ComparisonTime time.Time   `json:"comparisonTime"`
+}
+
+type ListNoShowReservationsInRangeRow struct {
+	ID                  int64          `json:"id"`
+	FacilityID          int64          `json:"facilityId"`
+	StartTime           time.Time      `json:"startTime"`
+	EndTime             time.Time      `json:"endTime"`
+	ReservationTypeName sql.NullString `json:"reservationTypeName"`
+	PrimaryFirstName    sql.NullString `json:"primaryFirstName"`
+	PrimaryLastName     sql.NullString `json:"primaryLastName"`
+}
+
+// Finished reservations that had someone booked but nobody checked in.
+func (q *Queries) ListNoShowReservationsInRange(ctx context.Context, arg ListNoShowReservationsInRangeParams) ([]ListNoShowReservationsInRangeRow, error) {
+	rows, err := q.query(ctx, q.listNoShowReservationsInRangeStmt, listNoShowReservationsInRange,
+		arg.FacilityID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.ComparisonTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNoShowReservationsInRangeRow
+	for rows.Next() {
+		var i ListNoShowReservationsInRangeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FacilityID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.ReservationTypeName,
+			&i.PrimaryFirstName,
+			&i.PrimaryLastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOpenPlaySessionFillInRange = `-- name: ListOpenPlaySessionFillInRange :many
+SELECT ops.id,
+    ops.start_time,
+    ops.end_time,
+    ops.status,
+    ops.current_court_count,
+    opr.name AS rule_name,
+    opr.min_participants,
+    opr.min_courts,
+    opr.max_participants_per_court,
+    (
+        SELECT COUNT(*)
+        FROM reservation_participants rp
+        JOIN reservations r ON r.id = rp.reservation_id
+        JOIN reservation_types rt ON rt.id = r.reservation_type_id
+        WHERE r.facility_id = ops.facility_id
+          AND r.open_play_rule_id = ops.open_play_rule_id
+          AND r.start_time = ops.start_time
+          AND r.end_time = ops.end_time
+          AND rt.name = 'OPEN_PLAY'
+    ) AS participant_count
+FROM open_play_sessions ops
+JOIN open_play_rules opr ON opr.id = ops.open_play_rule_id
+WHERE ops.facility_id = ?1
+  AND ops.status != 'cancelled'
+  AND ops.start_time < ?2
+  AND ops.end_time > ?3
+ORDER BY ops.start_time, ops.id
+`
+
+type ListOpenPlaySessionFillInRangeParams struct {
+	FacilityID int64     `json:"facilityId"`
+	EndTime    time.Time `json:"endTime"`
+	StartTime  time.Time `json:"startTime"`
+}
+
+type ListOpenPlaySessionFillInRangeRow struct {
+	ID                      int64     `json:"id"`
+	StartTime               time.Time `json:"startTime"`
+	EndTime                 time.Time `json:"endTime"`
+	Status                  string    `json:"status"`
+	CurrentCourtCount       int64     `json:"currentCourtCount"`
+	RuleName                string    `json:"ruleName"`
+	MinParticipants         int64     `json:"minParticipants"`
+	MinCourts               int64     `json:"minCourts"`
+	MaxParticipantsPerCourt int64     `json:"maxParticipantsPerCourt"`
+	ParticipantCount        int64     `json:"participantCount"`
+}
+
+func (q *Queries) ListOpenPlaySessionFillInRange(ctx context.Context, arg ListOpenPlaySessionFillInRangeParams) ([]ListOpenPlaySessionFillInRangeRow, error) {
+	rows, err := q.query(ctx, q.listOpenPlaySessionFillInRangeStmt, listOpenPlaySessionFillInRange, arg.FacilityID, arg.EndTime, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOpenPlaySessionFillInRangeRow
+	for rows.Next() {
+		var i ListOpenPlaySessionFillInRangeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Status,
+			&i.CurrentCourtCount,
+			&i.RuleName,
+			&i.MinParticipants,
+			&i.MinCourts,
+			&i.MaxParticipantsPerCourt,
+			&i.ParticipantCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingWaitlistOffersForFacility = `-- name: ListPendingWaitlistOffersForFacility :many
+SELECT wo.id,
+    wo.expires_at,
+    w.target_date,
+    w.target_start_time,
+    w.target_end_time,
+    u.first_name,
+    u.last_name
+FROM waitlist_offers wo
+JOIN waitlists w ON w.id = wo.waitlist_id
+JOIN users u ON u.id = w.user_id
+WHERE w.facility_id = ?1
+  AND wo.status = 'pending'
+  AND wo.expires_at > ?2
+ORDER BY wo.expires_at, wo.id
+`
+
+type ListPendingWaitlistOffersForFacilityParams struct {
+	FacilityID     int64     `json:"facilityId"`
+	ComparisonTime time.Time `json:"comparisonTime"`
+}
+
+type ListPendingWaitlistOffersForFacilityRow struct {
+	ID              int64       `json:"id"`
+	ExpiresAt       time.Time   `json:"expiresAt"`
+	TargetDate      time.Time   `json:"targetDate"`
+	TargetStartTime interface{} `json:"targetStartTime"`
+	TargetEndTime   interface{} `json:"targetEndTime"`
+	FirstName       string      `json:"firstName"`
+	LastName        string      `json:"lastName"`
+}
+
+func (q *Queries) ListPendingWaitlistOffersForFacility(ctx context.Context, arg ListPendingWaitlistOffersForFacilityParams) ([]ListPendingWaitlistOffersForFacilityRow, error) {
+	rows, err := q.query(ctx, q.listPendingWaitlistOffersForFacilityStmt, listPendingWaitlistOffersForFacility, arg.FacilityID, arg.ComparisonTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPendingWaitlistOffersForFacilityRow
+	for rows.Next() {
+		var i ListPendingWaitlistOffersForFacilityRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ExpiresAt,
+			&i.TargetDate,
+			&i.TargetStartTime,
+			&i.TargetEndTime,
+			&i.FirstName,
+			&i.LastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -27,6 +27,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.acceptOfferStmt, err = db.PrepareContext(ctx, acceptOffer); err != nil {
 		return nil, fmt.Errorf("error preparing query AcceptOffer: %w", err)
 	}
+	if q.addOpenPlayGamePlayerStmt, err = db.PrepareContext(ctx, addOpenPlayGamePlayer); err != nil {
+		return nil, fmt.Errorf("error preparing query AddOpenPlayGamePlayer: %w", err)
+	}
 	if q.addOpenPlayParticipantStmt, err = db.PrepareContext(ctx, addOpenPlayParticipant); err != nil {
 		return nil, fmt.Errorf("error preparing query AddOpenPlayParticipant: %w", err)
 	}
@@ -36,18 +39,63 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.addReservationCourtStmt, err = db.PrepareContext(ctx, addReservationCourt); err != nil {
 		return nil, fmt.Errorf("error preparing query AddReservationCourt: %w", err)
 	}
+	if q.addReservationGuestStmt, err = db.PrepareContext(ctx, addReservationGuest); err != nil {
+		return nil, fmt.Errorf("error preparing query AddReservationGuest: %w", err)
+	}
 	if q.addTeamMemberStmt, err = db.PrepareContext(ctx, addTeamMember); err != nil {
 		return nil, fmt.Errorf("error preparing query AddTeamMember: %w", err)
 	}
+	if q.addTournamentEntrantStmt, err = db.PrepareContext(ctx, addTournamentEntrant); err != nil {
+		return nil, fmt.Errorf("error preparing query AddTournamentEntrant: %w", err)
+	}
 	if q.advanceWaitlistOfferStmt, err = db.PrepareContext(ctx, advanceWaitlistOffer); err != nil {
 		return nil, fmt.Errorf("error preparing query AdvanceWaitlistOffer: %w", err)
 	}
+	if q.anonymizeDeletedMembersStmt, err = db.PrepareContext(ctx, anonymizeDeletedMembers); err != nil {
+		return nil, fmt.Errorf("error preparing query AnonymizeDeletedMembers: %w", err)
+	}
+	if q.applyStagedWaitlistPositionsStmt, err = db.PrepareContext(ctx, applyStagedWaitlistPositions); err != nil {
+		return nil, fmt.Errorf("error preparing query ApplyStagedWaitlistPositions: %w", err)
+	}
 	if q.assignFreeAgentToTeamStmt, err = db.PrepareContext(ctx, assignFreeAgentToTeam); err != nil {
 		return nil, fmt.Errorf("error preparing query AssignFreeAgentToTeam: %w", err)
 	}
+	if q.cancelPendingReservationInvitationsStmt, err = db.PrepareContext(ctx, cancelPendingReservationInvitations); err != nil {
+		return nil, fmt.Errorf("error preparing query CancelPendingReservationInvitations: %w", err)
+	}
+	if q.cancelPendingReservationTransfersStmt, err = db.PrepareContext(ctx, cancelPendingReservationTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query CancelPendingReservationTransfers: %w", err)
+	}
+	if q.cancelUpcomingReservationInvitationsForUserStmt, err = db.PrepareContext(ctx, cancelUpcomingReservationInvitationsForUser); err != nil {
+		return nil, fmt.Errorf("error preparing query CancelUpcomingReservationInvitationsForUser: %w", err)
+	}
+	if q.claimReservationReminderStmt, err = db.PrepareContext(ctx, claimReservationReminder); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimReservationReminder: %w", err)
+	}
+	if q.clearUserEmailUndeliverableStmt, err = db.PrepareContext(ctx, clearUserEmailUndeliverable); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearUserEmailUndeliverable: %w", err)
+	}
+	if q.consumeAccountCreditStmt, err = db.PrepareContext(ctx, consumeAccountCredit); err != nil {
+		return nil, fmt.Errorf("error preparing query ConsumeAccountCredit: %w", err)
+	}
+	if q.consumeMemberGuestPassStmt, err = db.PrepareContext(ctx, consumeMemberGuestPass); err != nil {
+		return nil, fmt.Errorf("error preparing query ConsumeMemberGuestPass: %w", err)
+	}
+	if q.countActiveCourtsStmt, err = db.PrepareContext(ctx, countActiveCourts); err != nil {
+		return nil, fmt.Errorf("error preparing query CountActiveCourts: %w", err)
+	}
+	if q.countActiveHouseholdDependentsStmt, err = db.PrepareContext(ctx, countActiveHouseholdDependents); err != nil {
+		return nil, fmt.Errorf("error preparing query CountActiveHouseholdDependents: %w", err)
+	}
+	if q.countActiveHouseholdReservationsStmt, err = db.PrepareContext(ctx, countActiveHouseholdReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query CountActiveHouseholdReservations: %w", err)
+	}
 	if q.countActiveMemberReservationsStmt, err = db.PrepareContext(ctx, countActiveMemberReservations); err != nil {
 		return nil, fmt.Errorf("error preparing query CountActiveMemberReservations: %w", err)
 	}
+	if q.countBusyCourtsByHourStmt, err = db.PrepareContext(ctx, countBusyCourtsByHour); err != nil {
+		return nil, fmt.Errorf("error preparing query CountBusyCourtsByHour: %w", err)
+	}
 	if q.countCheckinsByFacilityInRangeStmt, err = db.PrepareContext(ctx, countCheckinsByFacilityInRange); err != nil {
 		return nil, fmt.Errorf("error preparing query CountCheckinsByFacilityInRange: %w", err)
 	}
@@ -66,9 +114,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.countOpenPlayReservationsForSessionStmt, err = db.PrepareContext(ctx, countOpenPlayReservationsForSession); err != nil {
 		return nil, fmt.Errorf("error preparing query CountOpenPlayReservationsForSession: %w", err)
 	}
+	if q.countOpenPlaySessionsForSlotStmt, err = db.PrepareContext(ctx, countOpenPlaySessionsForSlot); err != nil {
+		return nil, fmt.Errorf("error preparing query CountOpenPlaySessionsForSlot: %w", err)
+	}
+	if q.countPendingReservationInvitationsStmt, err = db.PrepareContext(ctx, countPendingReservationInvitations); err != nil {
+		return nil, fmt.Errorf("error preparing query CountPendingReservationInvitations: %w", err)
+	}
+	if q.countProSessionsByProInRangeStmt, err = db.PrepareContext(ctx, countProSessionsByProInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query CountProSessionsByProInRange: %w", err)
+	}
 	if q.countReservationParticipantsStmt, err = db.PrepareContext(ctx, countReservationParticipants); err != nil {
 		return nil, fmt.Errorf("error preparing query CountReservationParticipants: %w", err)
 	}
+	if q.countReservationTypeNamesInRangeStmt, err = db.PrepareContext(ctx, countReservationTypeNamesInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query CountReservationTypeNamesInRange: %w", err)
+	}
+	if q.countReservationTypeUsageStmt, err = db.PrepareContext(ctx, countReservationTypeUsage); err != nil {
+		return nil, fmt.Errorf("error preparing query CountReservationTypeUsage: %w", err)
+	}
 	if q.countReservationsByTypeInRangeStmt, err = db.PrepareContext(ctx, countReservationsByTypeInRange); err != nil {
 		return nil, fmt.Errorf("error preparing query CountReservationsByTypeInRange: %w", err)
 	}
@@ -78,12 +141,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.countThemeUsageStmt, err = db.PrepareContext(ctx, countThemeUsage); err != nil {
 		return nil, fmt.Errorf("error preparing query CountThemeUsage: %w", err)
 	}
+	if q.countTournamentMatchesStmt, err = db.PrepareContext(ctx, countTournamentMatches); err != nil {
+		return nil, fmt.Errorf("error preparing query CountTournamentMatches: %w", err)
+	}
+	if q.countUnreadStaffInboxNotificationsStmt, err = db.PrepareContext(ctx, countUnreadStaffInboxNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUnreadStaffInboxNotifications: %w", err)
+	}
 	if q.countUnreadStaffNotificationsStmt, err = db.PrepareContext(ctx, countUnreadStaffNotifications); err != nil {
 		return nil, fmt.Errorf("error preparing query CountUnreadStaffNotifications: %w", err)
 	}
 	if q.countVisitPackTypesByFacilityStmt, err = db.PrepareContext(ctx, countVisitPackTypesByFacility); err != nil {
 		return nil, fmt.Errorf("error preparing query CountVisitPackTypesByFacility: %w", err)
 	}
+	if q.createAccountCreditStmt, err = db.PrepareContext(ctx, createAccountCredit); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAccountCredit: %w", err)
+	}
+	if q.createAccountCreditRedemptionStmt, err = db.PrepareContext(ctx, createAccountCreditRedemption); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAccountCreditRedemption: %w", err)
+	}
 	if q.createCancellationPolicyTierStmt, err = db.PrepareContext(ctx, createCancellationPolicyTier); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateCancellationPolicyTier: %w", err)
 	}
@@ -99,18 +174,51 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createCourtStmt, err = db.PrepareContext(ctx, createCourt); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateCourt: %w", err)
 	}
+	if q.createCourtPricingRuleStmt, err = db.PrepareContext(ctx, createCourtPricingRule); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCourtPricingRule: %w", err)
+	}
+	if q.createCourtSlotHoldStmt, err = db.PrepareContext(ctx, createCourtSlotHold); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCourtSlotHold: %w", err)
+	}
+	if q.createDeferredEmailStmt, err = db.PrepareContext(ctx, createDeferredEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateDeferredEmail: %w", err)
+	}
+	if q.createEmailDeliveryEventStmt, err = db.PrepareContext(ctx, createEmailDeliveryEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateEmailDeliveryEvent: %w", err)
+	}
+	if q.createFacilityAnnouncementStmt, err = db.PrepareContext(ctx, createFacilityAnnouncement); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFacilityAnnouncement: %w", err)
+	}
 	if q.createFacilityVisitStmt, err = db.PrepareContext(ctx, createFacilityVisit); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFacilityVisit: %w", err)
 	}
+	if q.createHouseholdLinkStmt, err = db.PrepareContext(ctx, createHouseholdLink); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateHouseholdLink: %w", err)
+	}
+	if q.createImpersonationAuditEntryStmt, err = db.PrepareContext(ctx, createImpersonationAuditEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateImpersonationAuditEntry: %w", err)
+	}
 	if q.createLeagueStmt, err = db.PrepareContext(ctx, createLeague); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateLeague: %w", err)
 	}
+	if q.createLeagueFreeAgentRegistrationStmt, err = db.PrepareContext(ctx, createLeagueFreeAgentRegistration); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLeagueFreeAgentRegistration: %w", err)
+	}
 	if q.createLeagueMatchStmt, err = db.PrepareContext(ctx, createLeagueMatch); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateLeagueMatch: %w", err)
 	}
+	if q.createLeagueMatchResultSubmissionStmt, err = db.PrepareContext(ctx, createLeagueMatchResultSubmission); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLeagueMatchResultSubmission: %w", err)
+	}
+	if q.createLeaguePaymentStmt, err = db.PrepareContext(ctx, createLeaguePayment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLeaguePayment: %w", err)
+	}
 	if q.createLeagueTeamStmt, err = db.PrepareContext(ctx, createLeagueTeam); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateLeagueTeam: %w", err)
 	}
+	if q.createLeagueTeamInvitationStmt, err = db.PrepareContext(ctx, createLeagueTeamInvitation); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLeagueTeamInvitation: %w", err)
+	}
 	if q.createLessonCancelledNotificationStmt, err = db.PrepareContext(ctx, createLessonCancelledNotification); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateLessonCancelledNotification: %w", err)
 	}
@@ -123,27 +231,96 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createLessonPackageTypeStmt, err = db.PrepareContext(ctx, createLessonPackageType); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateLessonPackageType: %w", err)
 	}
+	if q.createLessonReassignedNotificationStmt, err = db.PrepareContext(ctx, createLessonReassignedNotification); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLessonReassignedNotification: %w", err)
+	}
 	if q.createMemberStmt, err = db.PrepareContext(ctx, createMember); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateMember: %w", err)
 	}
+	if q.createMemberCardStmt, err = db.PrepareContext(ctx, createMemberCard); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMemberCard: %w", err)
+	}
+	if q.createMemberHomeFacilityChangeStmt, err = db.PrepareContext(ctx, createMemberHomeFacilityChange); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMemberHomeFacilityChange: %w", err)
+	}
+	if q.createMemberRatingEntryStmt, err = db.PrepareContext(ctx, createMemberRatingEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMemberRatingEntry: %w", err)
+	}
+	if q.createMembershipTierStmt, err = db.PrepareContext(ctx, createMembershipTier); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMembershipTier: %w", err)
+	}
+	if q.createNoShowRestrictionClearStmt, err = db.PrepareContext(ctx, createNoShowRestrictionClear); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateNoShowRestrictionClear: %w", err)
+	}
 	if q.createOpenPlayAuditLogStmt, err = db.PrepareContext(ctx, createOpenPlayAuditLog); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateOpenPlayAuditLog: %w", err)
 	}
+	if q.createOpenPlayGameStmt, err = db.PrepareContext(ctx, createOpenPlayGame); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOpenPlayGame: %w", err)
+	}
+	if q.createOpenPlayQueueEntryStmt, err = db.PrepareContext(ctx, createOpenPlayQueueEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOpenPlayQueueEntry: %w", err)
+	}
 	if q.createOpenPlayRuleStmt, err = db.PrepareContext(ctx, createOpenPlayRule); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateOpenPlayRule: %w", err)
 	}
+	if q.createOpenPlayRuleSlotStmt, err = db.PrepareContext(ctx, createOpenPlayRuleSlot); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOpenPlayRuleSlot: %w", err)
+	}
 	if q.createOpenPlaySessionStmt, err = db.PrepareContext(ctx, createOpenPlaySession); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateOpenPlaySession: %w", err)
 	}
 	if q.createPhotoStmt, err = db.PrepareContext(ctx, createPhoto); err != nil {
 		return nil, fmt.Errorf("error preparing query CreatePhoto: %w", err)
 	}
+	if q.createPrimeTimeRuleStmt, err = db.PrepareContext(ctx, createPrimeTimeRule); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePrimeTimeRule: %w", err)
+	}
 	if q.createProUnavailabilityStmt, err = db.PrepareContext(ctx, createProUnavailability); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateProUnavailability: %w", err)
 	}
 	if q.createReservationStmt, err = db.PrepareContext(ctx, createReservation); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateReservation: %w", err)
 	}
+	if q.createReservationCheckinStmt, err = db.PrepareContext(ctx, createReservationCheckin); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationCheckin: %w", err)
+	}
+	if q.createReservationCommentStmt, err = db.PrepareContext(ctx, createReservationComment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationComment: %w", err)
+	}
+	if q.createReservationDurationOverrideStmt, err = db.PrepareContext(ctx, createReservationDurationOverride); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationDurationOverride: %w", err)
+	}
+	if q.createReservationIdempotencyKeyStmt, err = db.PrepareContext(ctx, createReservationIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationIdempotencyKey: %w", err)
+	}
+	if q.createReservationInvitationStmt, err = db.PrepareContext(ctx, createReservationInvitation); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationInvitation: %w", err)
+	}
+	if q.createReservationMemberNoteStmt, err = db.PrepareContext(ctx, createReservationMemberNote); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationMemberNote: %w", err)
+	}
+	if q.createReservationPriceStmt, err = db.PrepareContext(ctx, createReservationPrice); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationPrice: %w", err)
+	}
+	if q.createReservationTransferStmt, err = db.PrepareContext(ctx, createReservationTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationTransfer: %w", err)
+	}
+	if q.createReservationTypeStmt, err = db.PrepareContext(ctx, createReservationType); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationType: %w", err)
+	}
+	if q.createSeasonPassStmt, err = db.PrepareContext(ctx, createSeasonPass); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSeasonPass: %w", err)
+	}
+	if q.createSeasonPassReservationStmt, err = db.PrepareContext(ctx, createSeasonPassReservation); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSeasonPassReservation: %w", err)
+	}
+	if q.createSeasonPassTypeStmt, err = db.PrepareContext(ctx, createSeasonPassType); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSeasonPassType: %w", err)
+	}
+	if q.createSeasonPassWindowStmt, err = db.PrepareContext(ctx, createSeasonPassWindow); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSeasonPassWindow: %w", err)
+	}
 	if q.createStaffStmt, err = db.PrepareContext(ctx, createStaff); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateStaff: %w", err)
 	}
@@ -156,9 +333,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createThemeStmt, err = db.PrepareContext(ctx, createTheme); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateTheme: %w", err)
 	}
+	if q.createTournamentStmt, err = db.PrepareContext(ctx, createTournament); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTournament: %w", err)
+	}
+	if q.createTournamentMatchStmt, err = db.PrepareContext(ctx, createTournamentMatch); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTournamentMatch: %w", err)
+	}
+	if q.createUserSessionStmt, err = db.PrepareContext(ctx, createUserSession); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateUserSession: %w", err)
+	}
 	if q.createVisitPackStmt, err = db.PrepareContext(ctx, createVisitPack); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateVisitPack: %w", err)
 	}
+	if q.createVisitPackPaymentStmt, err = db.PrepareContext(ctx, createVisitPackPayment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateVisitPackPayment: %w", err)
+	}
 	if q.createVisitPackRedemptionStmt, err = db.PrepareContext(ctx, createVisitPackRedemption); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateVisitPackRedemption: %w", err)
 	}
@@ -168,21 +357,42 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createWaitlistEntryStmt, err = db.PrepareContext(ctx, createWaitlistEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateWaitlistEntry: %w", err)
 	}
+	if q.createWaitlistFulfilledNotificationStmt, err = db.PrepareContext(ctx, createWaitlistFulfilledNotification); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWaitlistFulfilledNotification: %w", err)
+	}
 	if q.createWaitlistOfferStmt, err = db.PrepareContext(ctx, createWaitlistOffer); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateWaitlistOffer: %w", err)
 	}
 	if q.deactivateLessonPackageTypeStmt, err = db.PrepareContext(ctx, deactivateLessonPackageType); err != nil {
 		return nil, fmt.Errorf("error preparing query DeactivateLessonPackageType: %w", err)
 	}
+	if q.deactivateSeasonPassTypeStmt, err = db.PrepareContext(ctx, deactivateSeasonPassType); err != nil {
+		return nil, fmt.Errorf("error preparing query DeactivateSeasonPassType: %w", err)
+	}
 	if q.deactivateVisitPackTypeStmt, err = db.PrepareContext(ctx, deactivateVisitPackType); err != nil {
 		return nil, fmt.Errorf("error preparing query DeactivateVisitPackType: %w", err)
 	}
+	if q.declineOfferStmt, err = db.PrepareContext(ctx, declineOffer); err != nil {
+		return nil, fmt.Errorf("error preparing query DeclineOffer: %w", err)
+	}
 	if q.decrementLessonPackageLessonStmt, err = db.PrepareContext(ctx, decrementLessonPackageLesson); err != nil {
 		return nil, fmt.Errorf("error preparing query DecrementLessonPackageLesson: %w", err)
 	}
 	if q.decrementVisitPackVisitStmt, err = db.PrepareContext(ctx, decrementVisitPackVisit); err != nil {
 		return nil, fmt.Errorf("error preparing query DecrementVisitPackVisit: %w", err)
 	}
+	if q.deleteActiveWaitlistEntriesForUserStmt, err = db.PrepareContext(ctx, deleteActiveWaitlistEntriesForUser); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteActiveWaitlistEntriesForUser: %w", err)
+	}
+	if q.deleteAnonymizableMemberBillingStmt, err = db.PrepareContext(ctx, deleteAnonymizableMemberBilling); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAnonymizableMemberBilling: %w", err)
+	}
+	if q.deleteAnonymizableMemberPhotosStmt, err = db.PrepareContext(ctx, deleteAnonymizableMemberPhotos); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAnonymizableMemberPhotos: %w", err)
+	}
+	if q.deleteCancellationPolicyStmt, err = db.PrepareContext(ctx, deleteCancellationPolicy); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCancellationPolicy: %w", err)
+	}
 	if q.deleteCancellationPolicyTierStmt, err = db.PrepareContext(ctx, deleteCancellationPolicyTier); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteCancellationPolicyTier: %w", err)
 	}
@@ -195,9 +405,42 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteClinicTypeStmt, err = db.PrepareContext(ctx, deleteClinicType); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteClinicType: %w", err)
 	}
+	if q.deleteCourtPricingRuleStmt, err = db.PrepareContext(ctx, deleteCourtPricingRule); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCourtPricingRule: %w", err)
+	}
+	if q.deleteCourtSlotHoldByUserStmt, err = db.PrepareContext(ctx, deleteCourtSlotHoldByUser); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCourtSlotHoldByUser: %w", err)
+	}
+	if q.deleteExpiredCourtSlotHoldsStmt, err = db.PrepareContext(ctx, deleteExpiredCourtSlotHolds); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredCourtSlotHolds: %w", err)
+	}
+	if q.deleteExpiredReservationIdempotencyKeyStmt, err = db.PrepareContext(ctx, deleteExpiredReservationIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredReservationIdempotencyKey: %w", err)
+	}
+	if q.deleteExpiredReservationIdempotencyKeysStmt, err = db.PrepareContext(ctx, deleteExpiredReservationIdempotencyKeys); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredReservationIdempotencyKeys: %w", err)
+	}
+	if q.deleteExpiredUserSessionsStmt, err = db.PrepareContext(ctx, deleteExpiredUserSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredUserSessions: %w", err)
+	}
+	if q.deleteFacilityAnnouncementStmt, err = db.PrepareContext(ctx, deleteFacilityAnnouncement); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFacilityAnnouncement: %w", err)
+	}
+	if q.deleteFacilityHoursOverrideStmt, err = db.PrepareContext(ctx, deleteFacilityHoursOverride); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFacilityHoursOverride: %w", err)
+	}
+	if q.deleteFacilityNoShowPolicyStmt, err = db.PrepareContext(ctx, deleteFacilityNoShowPolicy); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFacilityNoShowPolicy: %w", err)
+	}
+	if q.deleteFacilityQuietHoursStmt, err = db.PrepareContext(ctx, deleteFacilityQuietHours); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFacilityQuietHours: %w", err)
+	}
 	if q.deleteLeagueStmt, err = db.PrepareContext(ctx, deleteLeague); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteLeague: %w", err)
 	}
+	if q.deleteLeagueFreeAgentRegistrationStmt, err = db.PrepareContext(ctx, deleteLeagueFreeAgentRegistration); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteLeagueFreeAgentRegistration: %w", err)
+	}
 	if q.deleteLeagueMatchesByLeagueIDStmt, err = db.PrepareContext(ctx, deleteLeagueMatchesByLeagueID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteLeagueMatchesByLeagueID: %w", err)
 	}
@@ -207,9 +450,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteMemberStmt, err = db.PrepareContext(ctx, deleteMember); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteMember: %w", err)
 	}
+	if q.deleteMemberSkillRatingStmt, err = db.PrepareContext(ctx, deleteMemberSkillRating); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteMemberSkillRating: %w", err)
+	}
+	if q.deleteMembershipTierStmt, err = db.PrepareContext(ctx, deleteMembershipTier); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteMembershipTier: %w", err)
+	}
+	if q.deleteOpenPlayQueueEntryStmt, err = db.PrepareContext(ctx, deleteOpenPlayQueueEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteOpenPlayQueueEntry: %w", err)
+	}
 	if q.deleteOpenPlayRuleStmt, err = db.PrepareContext(ctx, deleteOpenPlayRule); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteOpenPlayRule: %w", err)
 	}
+	if q.deleteOpenPlayRuleSlotStmt, err = db.PrepareContext(ctx, deleteOpenPlayRuleSlot); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteOpenPlayRuleSlot: %w", err)
+	}
+	if q.deleteOpenPlaySessionSkillBandsStmt, err = db.PrepareContext(ctx, deleteOpenPlaySessionSkillBands); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteOpenPlaySessionSkillBands: %w", err)
+	}
 	if q.deleteOperatingHoursStmt, err = db.PrepareContext(ctx, deleteOperatingHours); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteOperatingHours: %w", err)
 	}
@@ -219,21 +477,39 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deletePhotoStmt, err = db.PrepareContext(ctx, deletePhoto); err != nil {
 		return nil, fmt.Errorf("error preparing query DeletePhoto: %w", err)
 	}
+	if q.deletePrimeTimeRuleStmt, err = db.PrepareContext(ctx, deletePrimeTimeRule); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePrimeTimeRule: %w", err)
+	}
 	if q.deleteProUnavailabilityStmt, err = db.PrepareContext(ctx, deleteProUnavailability); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteProUnavailability: %w", err)
 	}
 	if q.deleteReservationStmt, err = db.PrepareContext(ctx, deleteReservation); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteReservation: %w", err)
 	}
+	if q.deleteReservationClosureDetailsStmt, err = db.PrepareContext(ctx, deleteReservationClosureDetails); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteReservationClosureDetails: %w", err)
+	}
 	if q.deleteReservationCourtsByReservationIDStmt, err = db.PrepareContext(ctx, deleteReservationCourtsByReservationID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteReservationCourtsByReservationID: %w", err)
 	}
 	if q.deleteReservationParticipantsByReservationIDStmt, err = db.PrepareContext(ctx, deleteReservationParticipantsByReservationID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteReservationParticipantsByReservationID: %w", err)
 	}
+	if q.deleteReservationReminderStmt, err = db.PrepareContext(ctx, deleteReservationReminder); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteReservationReminder: %w", err)
+	}
+	if q.deleteReservationTypeStmt, err = db.PrepareContext(ctx, deleteReservationType); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteReservationType: %w", err)
+	}
+	if q.deleteSeasonPassWindowsStmt, err = db.PrepareContext(ctx, deleteSeasonPassWindows); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSeasonPassWindows: %w", err)
+	}
 	if q.deleteStaffStmt, err = db.PrepareContext(ctx, deleteStaff); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteStaff: %w", err)
 	}
+	if q.deleteStaffNotificationsBeforeStmt, err = db.PrepareContext(ctx, deleteStaffNotificationsBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteStaffNotificationsBefore: %w", err)
+	}
 	if q.deleteThemeStmt, err = db.PrepareContext(ctx, deleteTheme); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteTheme: %w", err)
 	}
@@ -243,12 +519,30 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteWaitlistEntryStmt, err = db.PrepareContext(ctx, deleteWaitlistEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteWaitlistEntry: %w", err)
 	}
+	if q.dismissFacilityAnnouncementStmt, err = db.PrepareContext(ctx, dismissFacilityAnnouncement); err != nil {
+		return nil, fmt.Errorf("error preparing query DismissFacilityAnnouncement: %w", err)
+	}
 	if q.expireOfferStmt, err = db.PrepareContext(ctx, expireOffer); err != nil {
 		return nil, fmt.Errorf("error preparing query ExpireOffer: %w", err)
 	}
+	if q.expireSiblingWaitlistOffersStmt, err = db.PrepareContext(ctx, expireSiblingWaitlistOffers); err != nil {
+		return nil, fmt.Errorf("error preparing query ExpireSiblingWaitlistOffers: %w", err)
+	}
 	if q.facilityExistsStmt, err = db.PrepareContext(ctx, facilityExists); err != nil {
 		return nil, fmt.Errorf("error preparing query FacilityExists: %w", err)
 	}
+	if q.findMatchingReservationStmt, err = db.PrepareContext(ctx, findMatchingReservation); err != nil {
+		return nil, fmt.Errorf("error preparing query FindMatchingReservation: %w", err)
+	}
+	if q.getActiveHouseholdLinkByDependentStmt, err = db.PrepareContext(ctx, getActiveHouseholdLinkByDependent); err != nil {
+		return nil, fmt.Errorf("error preparing query GetActiveHouseholdLinkByDependent: %w", err)
+	}
+	if q.getActiveMemberCardStmt, err = db.PrepareContext(ctx, getActiveMemberCard); err != nil {
+		return nil, fmt.Errorf("error preparing query GetActiveMemberCard: %w", err)
+	}
+	if q.getActiveMemberCardByTokenStmt, err = db.PrepareContext(ctx, getActiveMemberCardByToken); err != nil {
+		return nil, fmt.Errorf("error preparing query GetActiveMemberCardByToken: %w", err)
+	}
 	if q.getActiveThemeIDStmt, err = db.PrepareContext(ctx, getActiveThemeID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetActiveThemeID: %w", err)
 	}
@@ -291,6 +585,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getEnrollmentCountStmt, err = db.PrepareContext(ctx, getEnrollmentCount); err != nil {
 		return nil, fmt.Errorf("error preparing query GetEnrollmentCount: %w", err)
 	}
+	if q.getFacilityAnnouncementStmt, err = db.PrepareContext(ctx, getFacilityAnnouncement); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFacilityAnnouncement: %w", err)
+	}
 	if q.getFacilityByIDStmt, err = db.PrepareContext(ctx, getFacilityByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFacilityByID: %w", err)
 	}
@@ -300,27 +597,60 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getFacilityHoursStmt, err = db.PrepareContext(ctx, getFacilityHours); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFacilityHours: %w", err)
 	}
+	if q.getFacilityHoursOverrideStmt, err = db.PrepareContext(ctx, getFacilityHoursOverride); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFacilityHoursOverride: %w", err)
+	}
+	if q.getFacilityNoShowPolicyStmt, err = db.PrepareContext(ctx, getFacilityNoShowPolicy); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFacilityNoShowPolicy: %w", err)
+	}
+	if q.getFacilityQuietHoursStmt, err = db.PrepareContext(ctx, getFacilityQuietHours); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFacilityQuietHours: %w", err)
+	}
 	if q.getFacilityTierBookingEnabledStmt, err = db.PrepareContext(ctx, getFacilityTierBookingEnabled); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFacilityTierBookingEnabled: %w", err)
 	}
+	if q.getFirstLeagueMatchTimeStmt, err = db.PrepareContext(ctx, getFirstLeagueMatchTime); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFirstLeagueMatchTime: %w", err)
+	}
 	if q.getFutureProSessionsByStaffIDStmt, err = db.PrepareContext(ctx, getFutureProSessionsByStaffID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFutureProSessionsByStaffID: %w", err)
 	}
+	if q.getHouseholdLinkStmt, err = db.PrepareContext(ctx, getHouseholdLink); err != nil {
+		return nil, fmt.Errorf("error preparing query GetHouseholdLink: %w", err)
+	}
+	if q.getHouseholdLinkByTokenStmt, err = db.PrepareContext(ctx, getHouseholdLinkByToken); err != nil {
+		return nil, fmt.Errorf("error preparing query GetHouseholdLinkByToken: %w", err)
+	}
 	if q.getLatestCancellationByReservationIDStmt, err = db.PrepareContext(ctx, getLatestCancellationByReservationID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetLatestCancellationByReservationID: %w", err)
 	}
+	if q.getLatestNoShowRestrictionClearStmt, err = db.PrepareContext(ctx, getLatestNoShowRestrictionClear); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestNoShowRestrictionClear: %w", err)
+	}
+	if q.getLatestSyncSeqStmt, err = db.PrepareContext(ctx, getLatestSyncSeq); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestSyncSeq: %w", err)
+	}
 	if q.getLeagueStmt, err = db.PrepareContext(ctx, getLeague); err != nil {
 		return nil, fmt.Errorf("error preparing query GetLeague: %w", err)
 	}
+	if q.getLeagueFreeAgentRegistrationStmt, err = db.PrepareContext(ctx, getLeagueFreeAgentRegistration); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLeagueFreeAgentRegistration: %w", err)
+	}
 	if q.getLeagueMatchStmt, err = db.PrepareContext(ctx, getLeagueMatch); err != nil {
 		return nil, fmt.Errorf("error preparing query GetLeagueMatch: %w", err)
 	}
+	if q.getLeagueMatchResultSubmissionStmt, err = db.PrepareContext(ctx, getLeagueMatchResultSubmission); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLeagueMatchResultSubmission: %w", err)
+	}
 	if q.getLeagueStandingsDataStmt, err = db.PrepareContext(ctx, getLeagueStandingsData); err != nil {
 		return nil, fmt.Errorf("error preparing query GetLeagueStandingsData: %w", err)
 	}
 	if q.getLeagueTeamStmt, err = db.PrepareContext(ctx, getLeagueTeam); err != nil {
 		return nil, fmt.Errorf("error preparing query GetLeagueTeam: %w", err)
 	}
+	if q.getLeagueTeamInvitationByTokenStmt, err = db.PrepareContext(ctx, getLeagueTeamInvitationByToken); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLeagueTeamInvitationByToken: %w", err)
+	}
 	if q.getLeagueWithFacilityTimezoneStmt, err = db.PrepareContext(ctx, getLeagueWithFacilityTimezone); err != nil {
 		return nil, fmt.Errorf("error preparing query GetLeagueWithFacilityTimezone: %w", err)
 	}
@@ -345,12 +675,33 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getMemberByIDStmt, err = db.PrepareContext(ctx, getMemberByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMemberByID: %w", err)
 	}
+	if q.getMemberGuestPassBalanceStmt, err = db.PrepareContext(ctx, getMemberGuestPassBalance); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMemberGuestPassBalance: %w", err)
+	}
+	if q.getMemberOverlappingReservationStmt, err = db.PrepareContext(ctx, getMemberOverlappingReservation); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMemberOverlappingReservation: %w", err)
+	}
 	if q.getMemberPhotoStmt, err = db.PrepareContext(ctx, getMemberPhoto); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMemberPhoto: %w", err)
 	}
+	if q.getMemberRatingEntryStmt, err = db.PrepareContext(ctx, getMemberRatingEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMemberRatingEntry: %w", err)
+	}
+	if q.getMemberSkillRatingStmt, err = db.PrepareContext(ctx, getMemberSkillRating); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMemberSkillRating: %w", err)
+	}
 	if q.getMemberTodayActivitiesStmt, err = db.PrepareContext(ctx, getMemberTodayActivities); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMemberTodayActivities: %w", err)
 	}
+	if q.getMembershipTierStmt, err = db.PrepareContext(ctx, getMembershipTier); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMembershipTier: %w", err)
+	}
+	if q.getMembershipTierForLevelStmt, err = db.PrepareContext(ctx, getMembershipTierForLevel); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMembershipTierForLevel: %w", err)
+	}
+	if q.getOpenEventStmt, err = db.PrepareContext(ctx, getOpenEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOpenEvent: %w", err)
+	}
 	if q.getOpenPlayReservationIDStmt, err = db.PrepareContext(ctx, getOpenPlayReservationID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOpenPlayReservationID: %w", err)
 	}
@@ -360,6 +711,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getOpenPlaySessionStmt, err = db.PrepareContext(ctx, getOpenPlaySession); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOpenPlaySession: %w", err)
 	}
+	if q.getOpenPlaySessionSkillBandsStmt, err = db.PrepareContext(ctx, getOpenPlaySessionSkillBands); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOpenPlaySessionSkillBands: %w", err)
+	}
 	if q.getOrganizationByIDStmt, err = db.PrepareContext(ctx, getOrganizationByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOrganizationByID: %w", err)
 	}
@@ -375,12 +729,18 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getOrganizationReminderConfigStmt, err = db.PrepareContext(ctx, getOrganizationReminderConfig); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOrganizationReminderConfig: %w", err)
 	}
+	if q.getOrganizationSettingsStmt, err = db.PrepareContext(ctx, getOrganizationSettings); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOrganizationSettings: %w", err)
+	}
 	if q.getPendingOfferStmt, err = db.PrepareContext(ctx, getPendingOffer); err != nil {
 		return nil, fmt.Errorf("error preparing query GetPendingOffer: %w", err)
 	}
 	if q.getPhotoStmt, err = db.PrepareContext(ctx, getPhoto); err != nil {
 		return nil, fmt.Errorf("error preparing query GetPhoto: %w", err)
 	}
+	if q.getPreferredMemberRatingStmt, err = db.PrepareContext(ctx, getPreferredMemberRating); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPreferredMemberRating: %w", err)
+	}
 	if q.getProLessonSlotsStmt, err = db.PrepareContext(ctx, getProLessonSlots); err != nil {
 		return nil, fmt.Errorf("error preparing query GetProLessonSlots: %w", err)
 	}
@@ -390,21 +750,54 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getReservationStmt, err = db.PrepareContext(ctx, getReservation); err != nil {
 		return nil, fmt.Errorf("error preparing query GetReservation: %w", err)
 	}
+	if q.getReservationAccessRevocationStmt, err = db.PrepareContext(ctx, getReservationAccessRevocation); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReservationAccessRevocation: %w", err)
+	}
 	if q.getReservationByIDStmt, err = db.PrepareContext(ctx, getReservationByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetReservationByID: %w", err)
 	}
+	if q.getReservationClosureDetailsStmt, err = db.PrepareContext(ctx, getReservationClosureDetails); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReservationClosureDetails: %w", err)
+	}
+	if q.getReservationCreditAppliedCentsStmt, err = db.PrepareContext(ctx, getReservationCreditAppliedCents); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReservationCreditAppliedCents: %w", err)
+	}
+	if q.getReservationIdempotencyKeyStmt, err = db.PrepareContext(ctx, getReservationIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReservationIdempotencyKey: %w", err)
+	}
+	if q.getReservationInvitationByTokenStmt, err = db.PrepareContext(ctx, getReservationInvitationByToken); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReservationInvitationByToken: %w", err)
+	}
+	if q.getReservationMemberNoteStmt, err = db.PrepareContext(ctx, getReservationMemberNote); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReservationMemberNote: %w", err)
+	}
+	if q.getReservationPriceStmt, err = db.PrepareContext(ctx, getReservationPrice); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReservationPrice: %w", err)
+	}
+	if q.getReservationTransferByTokenStmt, err = db.PrepareContext(ctx, getReservationTransferByToken); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReservationTransferByToken: %w", err)
+	}
 	if q.getReservationTypeStmt, err = db.PrepareContext(ctx, getReservationType); err != nil {
 		return nil, fmt.Errorf("error preparing query GetReservationType: %w", err)
 	}
+	if q.getReservationTypeBufferMinutesStmt, err = db.PrepareContext(ctx, getReservationTypeBufferMinutes); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReservationTypeBufferMinutes: %w", err)
+	}
 	if q.getReservationTypeByNameStmt, err = db.PrepareContext(ctx, getReservationTypeByName); err != nil {
 		return nil, fmt.Errorf("error preparing query GetReservationTypeByName: %w", err)
 	}
+	if q.getReservationTypeForFacilityStmt, err = db.PrepareContext(ctx, getReservationTypeForFacility); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReservationTypeForFacility: %w", err)
+	}
 	if q.getReservationTypeNameByReservationIDStmt, err = db.PrepareContext(ctx, getReservationTypeNameByReservationID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetReservationTypeNameByReservationID: %w", err)
 	}
 	if q.getRestoredMemberStmt, err = db.PrepareContext(ctx, getRestoredMember); err != nil {
 		return nil, fmt.Errorf("error preparing query GetRestoredMember: %w", err)
 	}
+	if q.getSeasonPassTypeStmt, err = db.PrepareContext(ctx, getSeasonPassType); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSeasonPassType: %w", err)
+	}
 	if q.getStaffByEmailStmt, err = db.PrepareContext(ctx, getStaffByEmail); err != nil {
 		return nil, fmt.Errorf("error preparing query GetStaffByEmail: %w", err)
 	}
@@ -426,6 +819,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getTierBookingWindowStmt, err = db.PrepareContext(ctx, getTierBookingWindow); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTierBookingWindow: %w", err)
 	}
+	if q.getTournamentStmt, err = db.PrepareContext(ctx, getTournament); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTournament: %w", err)
+	}
+	if q.getTournamentMatchStmt, err = db.PrepareContext(ctx, getTournamentMatch); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTournamentMatch: %w", err)
+	}
 	if q.getUpdatedMemberStmt, err = db.PrepareContext(ctx, getUpdatedMember); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUpdatedMember: %w", err)
 	}
@@ -438,6 +837,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getUserByPhoneStmt, err = db.PrepareContext(ctx, getUserByPhone); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserByPhone: %w", err)
 	}
+	if q.getUserNotificationPreferencesStmt, err = db.PrepareContext(ctx, getUserNotificationPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserNotificationPreferences: %w", err)
+	}
+	if q.getUserPhotoStmt, err = db.PrepareContext(ctx, getUserPhoto); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserPhoto: %w", err)
+	}
+	if q.getUserSessionByTokenStmt, err = db.PrepareContext(ctx, getUserSessionByToken); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserSessionByToken: %w", err)
+	}
 	if q.getVisitPackStmt, err = db.PrepareContext(ctx, getVisitPack); err != nil {
 		return nil, fmt.Errorf("error preparing query GetVisitPack: %w", err)
 	}
@@ -453,9 +861,39 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getWaitlistEntryStmt, err = db.PrepareContext(ctx, getWaitlistEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query GetWaitlistEntry: %w", err)
 	}
+	if q.getWaitlistOfferForUserStmt, err = db.PrepareContext(ctx, getWaitlistOfferForUser); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWaitlistOfferForUser: %w", err)
+	}
+	if q.grantMemberGuestPassesStmt, err = db.PrepareContext(ctx, grantMemberGuestPasses); err != nil {
+		return nil, fmt.Errorf("error preparing query GrantMemberGuestPasses: %w", err)
+	}
 	if q.isMemberOpenPlayParticipantStmt, err = db.PrepareContext(ctx, isMemberOpenPlayParticipant); err != nil {
 		return nil, fmt.Errorf("error preparing query IsMemberOpenPlayParticipant: %w", err)
 	}
+	if q.isReservationCancelledStmt, err = db.PrepareContext(ctx, isReservationCancelled); err != nil {
+		return nil, fmt.Errorf("error preparing query IsReservationCancelled: %w", err)
+	}
+	if q.isReservationUpcomingStmt, err = db.PrepareContext(ctx, isReservationUpcoming); err != nil {
+		return nil, fmt.Errorf("error preparing query IsReservationUpcoming: %w", err)
+	}
+	if q.isUserOnLeagueTeamStmt, err = db.PrepareContext(ctx, isUserOnLeagueTeam); err != nil {
+		return nil, fmt.Errorf("error preparing query IsUserOnLeagueTeam: %w", err)
+	}
+	if q.listAccountCreditRedemptionsStmt, err = db.PrepareContext(ctx, listAccountCreditRedemptions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAccountCreditRedemptions: %w", err)
+	}
+	if q.listAccountCreditsStmt, err = db.PrepareContext(ctx, listAccountCredits); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAccountCredits: %w", err)
+	}
+	if q.listActiveCourtsStmt, err = db.PrepareContext(ctx, listActiveCourts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveCourts: %w", err)
+	}
+	if q.listActiveFacilityAnnouncementsStmt, err = db.PrepareContext(ctx, listActiveFacilityAnnouncements); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveFacilityAnnouncements: %w", err)
+	}
+	if q.listActiveHouseholdDependentsStmt, err = db.PrepareContext(ctx, listActiveHouseholdDependents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveHouseholdDependents: %w", err)
+	}
 	if q.listActiveLessonPackagesForUserStmt, err = db.PrepareContext(ctx, listActiveLessonPackagesForUser); err != nil {
 		return nil, fmt.Errorf("error preparing query ListActiveLessonPackagesForUser: %w", err)
 	}
@@ -465,6 +903,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listActiveLessonPackagesForUserByOrganizationStmt, err = db.PrepareContext(ctx, listActiveLessonPackagesForUserByOrganization); err != nil {
 		return nil, fmt.Errorf("error preparing query ListActiveLessonPackagesForUserByOrganization: %w", err)
 	}
+	if q.listActiveSeasonPassesForUserByFacilityStmt, err = db.PrepareContext(ctx, listActiveSeasonPassesForUserByFacility); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveSeasonPassesForUserByFacility: %w", err)
+	}
+	if q.listActiveUserSessionsStmt, err = db.PrepareContext(ctx, listActiveUserSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveUserSessions: %w", err)
+	}
 	if q.listActiveVisitPacksForUserStmt, err = db.PrepareContext(ctx, listActiveVisitPacksForUser); err != nil {
 		return nil, fmt.Errorf("error preparing query ListActiveVisitPacksForUser: %w", err)
 	}
@@ -477,21 +921,57 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listAvailableCourtsStmt, err = db.PrepareContext(ctx, listAvailableCourts); err != nil {
 		return nil, fmt.Errorf("error preparing query ListAvailableCourts: %w", err)
 	}
+	if q.listBillingSummaryLessonPackagesStmt, err = db.PrepareContext(ctx, listBillingSummaryLessonPackages); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBillingSummaryLessonPackages: %w", err)
+	}
+	if q.listBillingSummaryReservationsStmt, err = db.PrepareContext(ctx, listBillingSummaryReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBillingSummaryReservations: %w", err)
+	}
+	if q.listBillingSummaryVisitPacksStmt, err = db.PrepareContext(ctx, listBillingSummaryVisitPacks); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBillingSummaryVisitPacks: %w", err)
+	}
 	if q.listCancellationPolicyTiersStmt, err = db.PrepareContext(ctx, listCancellationPolicyTiers); err != nil {
 		return nil, fmt.Errorf("error preparing query ListCancellationPolicyTiers: %w", err)
 	}
+	if q.listCancellationPolicyTiersForFacilitiesStmt, err = db.PrepareContext(ctx, listCancellationPolicyTiersForFacilities); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCancellationPolicyTiersForFacilities: %w", err)
+	}
+	if q.listCheckedInReservationIDsStmt, err = db.PrepareContext(ctx, listCheckedInReservationIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCheckedInReservationIDs: %w", err)
+	}
 	if q.listClinicSessionsByFacilityStmt, err = db.PrepareContext(ctx, listClinicSessionsByFacility); err != nil {
 		return nil, fmt.Errorf("error preparing query ListClinicSessionsByFacility: %w", err)
 	}
 	if q.listClinicTypesByFacilityStmt, err = db.PrepareContext(ctx, listClinicTypesByFacility); err != nil {
 		return nil, fmt.Errorf("error preparing query ListClinicTypesByFacility: %w", err)
 	}
+	if q.listCourtBlocksForDayStmt, err = db.PrepareContext(ctx, listCourtBlocksForDay); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourtBlocksForDay: %w", err)
+	}
+	if q.listCourtBookingsInRangeStmt, err = db.PrepareContext(ctx, listCourtBookingsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourtBookingsInRange: %w", err)
+	}
+	if q.listCourtBufferNeighborsStmt, err = db.PrepareContext(ctx, listCourtBufferNeighbors); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourtBufferNeighbors: %w", err)
+	}
+	if q.listCourtOverlapConflictsStmt, err = db.PrepareContext(ctx, listCourtOverlapConflicts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourtOverlapConflicts: %w", err)
+	}
+	if q.listCourtPricingRulesStmt, err = db.PrepareContext(ctx, listCourtPricingRules); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourtPricingRules: %w", err)
+	}
 	if q.listCourtsStmt, err = db.PrepareContext(ctx, listCourts); err != nil {
 		return nil, fmt.Errorf("error preparing query ListCourts: %w", err)
 	}
 	if q.listDistinctFacilitiesWithScheduledSessionsStmt, err = db.PrepareContext(ctx, listDistinctFacilitiesWithScheduledSessions); err != nil {
 		return nil, fmt.Errorf("error preparing query ListDistinctFacilitiesWithScheduledSessions: %w", err)
 	}
+	if q.listDueDeferredEmailsStmt, err = db.PrepareContext(ctx, listDueDeferredEmails); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueDeferredEmails: %w", err)
+	}
+	if q.listEmailDeliveryEventsStmt, err = db.PrepareContext(ctx, listEmailDeliveryEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListEmailDeliveryEvents: %w", err)
+	}
 	if q.listEnrollmentsForClinicStmt, err = db.PrepareContext(ctx, listEnrollmentsForClinic); err != nil {
 		return nil, fmt.Errorf("error preparing query ListEnrollmentsForClinic: %w", err)
 	}
@@ -501,18 +981,54 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listFacilitiesStmt, err = db.PrepareContext(ctx, listFacilities); err != nil {
 		return nil, fmt.Errorf("error preparing query ListFacilities: %w", err)
 	}
+	if q.listFacilitiesByOrganizationStmt, err = db.PrepareContext(ctx, listFacilitiesByOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFacilitiesByOrganization: %w", err)
+	}
+	if q.listFacilitiesWithOpenPlayRuleSlotsStmt, err = db.PrepareContext(ctx, listFacilitiesWithOpenPlayRuleSlots); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFacilitiesWithOpenPlayRuleSlots: %w", err)
+	}
+	if q.listFacilityAnnouncementsStmt, err = db.PrepareContext(ctx, listFacilityAnnouncements); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFacilityAnnouncements: %w", err)
+	}
+	if q.listFacilityArrivalsInRangeStmt, err = db.PrepareContext(ctx, listFacilityArrivalsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFacilityArrivalsInRange: %w", err)
+	}
+	if q.listFacilityHoursOverridesStmt, err = db.PrepareContext(ctx, listFacilityHoursOverrides); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFacilityHoursOverrides: %w", err)
+	}
+	if q.listFacilityOpenPlayRuleSlotsStmt, err = db.PrepareContext(ctx, listFacilityOpenPlayRuleSlots); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFacilityOpenPlayRuleSlots: %w", err)
+	}
 	if q.listFacilityThemesStmt, err = db.PrepareContext(ctx, listFacilityThemes); err != nil {
 		return nil, fmt.Errorf("error preparing query ListFacilityThemes: %w", err)
 	}
 	if q.listFreeAgentsByLeagueStmt, err = db.PrepareContext(ctx, listFreeAgentsByLeague); err != nil {
 		return nil, fmt.Errorf("error preparing query ListFreeAgentsByLeague: %w", err)
 	}
+	if q.listHeldCourtIDsStmt, err = db.PrepareContext(ctx, listHeldCourtIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHeldCourtIDs: %w", err)
+	}
+	if q.listHouseholdLinksForUserStmt, err = db.PrepareContext(ctx, listHouseholdLinksForUser); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHouseholdLinksForUser: %w", err)
+	}
+	if q.listHouseholdUserIDsStmt, err = db.PrepareContext(ctx, listHouseholdUserIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHouseholdUserIDs: %w", err)
+	}
+	if q.listJobStatusesStmt, err = db.PrepareContext(ctx, listJobStatuses); err != nil {
+		return nil, fmt.Errorf("error preparing query ListJobStatuses: %w", err)
+	}
+	if q.listLapsedLeagueMatchResultSubmissionsStmt, err = db.PrepareContext(ctx, listLapsedLeagueMatchResultSubmissions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListLapsedLeagueMatchResultSubmissions: %w", err)
+	}
 	if q.listLeagueMatchesStmt, err = db.PrepareContext(ctx, listLeagueMatches); err != nil {
 		return nil, fmt.Errorf("error preparing query ListLeagueMatches: %w", err)
 	}
 	if q.listLeagueMatchesWithReservationsStmt, err = db.PrepareContext(ctx, listLeagueMatchesWithReservations); err != nil {
 		return nil, fmt.Errorf("error preparing query ListLeagueMatchesWithReservations: %w", err)
 	}
+	if q.listLeagueTeamPaymentsStmt, err = db.PrepareContext(ctx, listLeagueTeamPayments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListLeagueTeamPayments: %w", err)
+	}
 	if q.listLeagueTeamsStmt, err = db.PrepareContext(ctx, listLeagueTeams); err != nil {
 		return nil, fmt.Errorf("error preparing query ListLeagueTeams: %w", err)
 	}
@@ -525,24 +1041,69 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listLessonPackageTypesStmt, err = db.PrepareContext(ctx, listLessonPackageTypes); err != nil {
 		return nil, fmt.Errorf("error preparing query ListLessonPackageTypes: %w", err)
 	}
+	if q.listMaintenanceCourtsInRangeStmt, err = db.PrepareContext(ctx, listMaintenanceCourtsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMaintenanceCourtsInRange: %w", err)
+	}
 	if q.listMatchingPendingWaitlistsForCancelledSlotStmt, err = db.PrepareContext(ctx, listMatchingPendingWaitlistsForCancelledSlot); err != nil {
 		return nil, fmt.Errorf("error preparing query ListMatchingPendingWaitlistsForCancelledSlot: %w", err)
 	}
+	if q.listMemberLeagueTeamsStmt, err = db.PrepareContext(ctx, listMemberLeagueTeams); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMemberLeagueTeams: %w", err)
+	}
+	if q.listMemberNoShowEndTimesStmt, err = db.PrepareContext(ctx, listMemberNoShowEndTimes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMemberNoShowEndTimes: %w", err)
+	}
+	if q.listMemberPaidVisitsPageStmt, err = db.PrepareContext(ctx, listMemberPaidVisitsPage); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMemberPaidVisitsPage: %w", err)
+	}
+	if q.listMemberRatingHistoryStmt, err = db.PrepareContext(ctx, listMemberRatingHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMemberRatingHistory: %w", err)
+	}
 	if q.listMemberUpcomingOpenPlaySessionsStmt, err = db.PrepareContext(ctx, listMemberUpcomingOpenPlaySessions); err != nil {
 		return nil, fmt.Errorf("error preparing query ListMemberUpcomingOpenPlaySessions: %w", err)
 	}
 	if q.listMembersStmt, err = db.PrepareContext(ctx, listMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query ListMembers: %w", err)
 	}
+	if q.listMembershipTiersStmt, err = db.PrepareContext(ctx, listMembershipTiers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMembershipTiers: %w", err)
+	}
+	if q.listNoShowReservationsInRangeStmt, err = db.PrepareContext(ctx, listNoShowReservationsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListNoShowReservationsInRange: %w", err)
+	}
 	if q.listOpenPlayAuditLogStmt, err = db.PrepareContext(ctx, listOpenPlayAuditLog); err != nil {
 		return nil, fmt.Errorf("error preparing query ListOpenPlayAuditLog: %w", err)
 	}
+	if q.listOpenPlayParticipantSkillRatingsStmt, err = db.PrepareContext(ctx, listOpenPlayParticipantSkillRatings); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOpenPlayParticipantSkillRatings: %w", err)
+	}
 	if q.listOpenPlayParticipantsStmt, err = db.PrepareContext(ctx, listOpenPlayParticipants); err != nil {
 		return nil, fmt.Errorf("error preparing query ListOpenPlayParticipants: %w", err)
 	}
+	if q.listOpenPlayParticipantsForSessionStmt, err = db.PrepareContext(ctx, listOpenPlayParticipantsForSession); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOpenPlayParticipantsForSession: %w", err)
+	}
+	if q.listOpenPlayQueueStmt, err = db.PrepareContext(ctx, listOpenPlayQueue); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOpenPlayQueue: %w", err)
+	}
+	if q.listOpenPlayRuleSlotsStmt, err = db.PrepareContext(ctx, listOpenPlayRuleSlots); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOpenPlayRuleSlots: %w", err)
+	}
 	if q.listOpenPlayRulesStmt, err = db.PrepareContext(ctx, listOpenPlayRules); err != nil {
 		return nil, fmt.Errorf("error preparing query ListOpenPlayRules: %w", err)
 	}
+	if q.listOpenPlaySessionCapacityStmt, err = db.PrepareContext(ctx, listOpenPlaySessionCapacity); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOpenPlaySessionCapacity: %w", err)
+	}
+	if q.listOpenPlaySessionCapacityByIDsStmt, err = db.PrepareContext(ctx, listOpenPlaySessionCapacityByIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOpenPlaySessionCapacityByIDs: %w", err)
+	}
+	if q.listOpenPlaySessionFillInRangeStmt, err = db.PrepareContext(ctx, listOpenPlaySessionFillInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOpenPlaySessionFillInRange: %w", err)
+	}
+	if q.listOpenPlaySessionReservationMismatchesStmt, err = db.PrepareContext(ctx, listOpenPlaySessionReservationMismatches); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOpenPlaySessionReservationMismatches: %w", err)
+	}
 	if q.listOpenPlaySessionsStmt, err = db.PrepareContext(ctx, listOpenPlaySessions); err != nil {
 		return nil, fmt.Errorf("error preparing query ListOpenPlaySessions: %w", err)
 	}
@@ -555,6 +1116,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listParticipantsForReservationStmt, err = db.PrepareContext(ctx, listParticipantsForReservation); err != nil {
 		return nil, fmt.Errorf("error preparing query ListParticipantsForReservation: %w", err)
 	}
+	if q.listParticipantsForReservationsStmt, err = db.PrepareContext(ctx, listParticipantsForReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListParticipantsForReservations: %w", err)
+	}
+	if q.listPendingWaitlistOffersForFacilityStmt, err = db.PrepareContext(ctx, listPendingWaitlistOffersForFacility); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingWaitlistOffersForFacility: %w", err)
+	}
+	if q.listPrimeTimeRulesStmt, err = db.PrepareContext(ctx, listPrimeTimeRules); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPrimeTimeRules: %w", err)
+	}
+	if q.listPrimeTimeRulesForDayStmt, err = db.PrepareContext(ctx, listPrimeTimeRulesForDay); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPrimeTimeRulesForDay: %w", err)
+	}
+	if q.listProLessonReportReservationsStmt, err = db.PrepareContext(ctx, listProLessonReportReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListProLessonReportReservations: %w", err)
+	}
 	if q.listProUnavailabilityByFacilityAndDateRangeStmt, err = db.PrepareContext(ctx, listProUnavailabilityByFacilityAndDateRange); err != nil {
 		return nil, fmt.Errorf("error preparing query ListProUnavailabilityByFacilityAndDateRange: %w", err)
 	}
@@ -567,24 +1143,78 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listRecentVisitsByUserStmt, err = db.PrepareContext(ctx, listRecentVisitsByUser); err != nil {
 		return nil, fmt.Errorf("error preparing query ListRecentVisitsByUser: %w", err)
 	}
+	if q.listReservationCalendarEntriesStmt, err = db.PrepareContext(ctx, listReservationCalendarEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationCalendarEntries: %w", err)
+	}
+	if q.listReservationCheckinRosterStmt, err = db.PrepareContext(ctx, listReservationCheckinRoster); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationCheckinRoster: %w", err)
+	}
+	if q.listReservationCommentsStmt, err = db.PrepareContext(ctx, listReservationComments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationComments: %w", err)
+	}
 	if q.listReservationCourtsStmt, err = db.PrepareContext(ctx, listReservationCourts); err != nil {
 		return nil, fmt.Errorf("error preparing query ListReservationCourts: %w", err)
 	}
 	if q.listReservationCourtsByDateRangeStmt, err = db.PrepareContext(ctx, listReservationCourtsByDateRange); err != nil {
 		return nil, fmt.Errorf("error preparing query ListReservationCourtsByDateRange: %w", err)
 	}
+	if q.listReservationDurationOverridesStmt, err = db.PrepareContext(ctx, listReservationDurationOverrides); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationDurationOverrides: %w", err)
+	}
+	if q.listReservationFacilitiesByUserIDStmt, err = db.PrepareContext(ctx, listReservationFacilitiesByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationFacilitiesByUserID: %w", err)
+	}
+	if q.listReservationGuestsStmt, err = db.PrepareContext(ctx, listReservationGuests); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationGuests: %w", err)
+	}
+	if q.listReservationGuestsForReservationsStmt, err = db.PrepareContext(ctx, listReservationGuestsForReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationGuestsForReservations: %w", err)
+	}
+	if q.listReservationInvitationsForReservationsStmt, err = db.PrepareContext(ctx, listReservationInvitationsForReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationInvitationsForReservations: %w", err)
+	}
+	if q.listReservationMemberNotesForReservationsStmt, err = db.PrepareContext(ctx, listReservationMemberNotesForReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationMemberNotesForReservations: %w", err)
+	}
 	if q.listReservationTypesStmt, err = db.PrepareContext(ctx, listReservationTypes); err != nil {
 		return nil, fmt.Errorf("error preparing query ListReservationTypes: %w", err)
 	}
+	if q.listReservationTypesForFacilityStmt, err = db.PrepareContext(ctx, listReservationTypesForFacility); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationTypesForFacility: %w", err)
+	}
+	if q.listReservationTypesForOrganizationStmt, err = db.PrepareContext(ctx, listReservationTypesForOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationTypesForOrganization: %w", err)
+	}
 	if q.listReservationsByDateRangeStmt, err = db.PrepareContext(ctx, listReservationsByDateRange); err != nil {
 		return nil, fmt.Errorf("error preparing query ListReservationsByDateRange: %w", err)
 	}
 	if q.listReservationsByUserIDStmt, err = db.PrepareContext(ctx, listReservationsByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query ListReservationsByUserID: %w", err)
 	}
+	if q.listReservationsDueForReminderStmt, err = db.PrepareContext(ctx, listReservationsDueForReminder); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationsDueForReminder: %w", err)
+	}
+	if q.listReservationsForSyncStmt, err = db.PrepareContext(ctx, listReservationsForSync); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationsForSync: %w", err)
+	}
 	if q.listReservationsStartingBetweenStmt, err = db.PrepareContext(ctx, listReservationsStartingBetween); err != nil {
 		return nil, fmt.Errorf("error preparing query ListReservationsStartingBetween: %w", err)
 	}
+	if q.listSeasonPassTypesStmt, err = db.PrepareContext(ctx, listSeasonPassTypes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSeasonPassTypes: %w", err)
+	}
+	if q.listSeasonPassUsageForUserStmt, err = db.PrepareContext(ctx, listSeasonPassUsageForUser); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSeasonPassUsageForUser: %w", err)
+	}
+	if q.listSeasonPassWindowsStmt, err = db.PrepareContext(ctx, listSeasonPassWindows); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSeasonPassWindows: %w", err)
+	}
+	if q.listSelfRegisteredFreeAgentsByLeagueStmt, err = db.PrepareContext(ctx, listSelfRegisteredFreeAgentsByLeague); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSelfRegisteredFreeAgentsByLeague: %w", err)
+	}
+	if q.listSpendableAccountCreditsStmt, err = db.PrepareContext(ctx, listSpendableAccountCredits); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSpendableAccountCredits: %w", err)
+	}
 	if q.listStaffStmt, err = db.PrepareContext(ctx, listStaff); err != nil {
 		return nil, fmt.Errorf("error preparing query ListStaff: %w", err)
 	}
@@ -594,6 +1224,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listStaffByRoleStmt, err = db.PrepareContext(ctx, listStaffByRole); err != nil {
 		return nil, fmt.Errorf("error preparing query ListStaffByRole: %w", err)
 	}
+	if q.listStaffInboxNotificationsStmt, err = db.PrepareContext(ctx, listStaffInboxNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStaffInboxNotifications: %w", err)
+	}
 	if q.listStaffNotificationsStmt, err = db.PrepareContext(ctx, listStaffNotifications); err != nil {
 		return nil, fmt.Errorf("error preparing query ListStaffNotifications: %w", err)
 	}
@@ -603,9 +1236,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listStaffNotificationsForStaffStmt, err = db.PrepareContext(ctx, listStaffNotificationsForStaff); err != nil {
 		return nil, fmt.Errorf("error preparing query ListStaffNotificationsForStaff: %w", err)
 	}
+	if q.listSyncChangesStmt, err = db.PrepareContext(ctx, listSyncChanges); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSyncChanges: %w", err)
+	}
 	if q.listSystemThemesStmt, err = db.PrepareContext(ctx, listSystemThemes); err != nil {
 		return nil, fmt.Errorf("error preparing query ListSystemThemes: %w", err)
 	}
+	if q.listTeamMemberRatingsStmt, err = db.PrepareContext(ctx, listTeamMemberRatings); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTeamMemberRatings: %w", err)
+	}
 	if q.listTeamMembersStmt, err = db.PrepareContext(ctx, listTeamMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query ListTeamMembers: %w", err)
 	}
@@ -615,6 +1254,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listTodayVisitsByFacilityStmt, err = db.PrepareContext(ctx, listTodayVisitsByFacility); err != nil {
 		return nil, fmt.Errorf("error preparing query ListTodayVisitsByFacility: %w", err)
 	}
+	if q.listTournamentEntrantsStmt, err = db.PrepareContext(ctx, listTournamentEntrants); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTournamentEntrants: %w", err)
+	}
+	if q.listTournamentMatchesStmt, err = db.PrepareContext(ctx, listTournamentMatches); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTournamentMatches: %w", err)
+	}
+	if q.listTournamentsByFacilityStmt, err = db.PrepareContext(ctx, listTournamentsByFacility); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTournamentsByFacility: %w", err)
+	}
+	if q.listUpcomingCourtReservationsStmt, err = db.PrepareContext(ctx, listUpcomingCourtReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUpcomingCourtReservations: %w", err)
+	}
+	if q.listUpcomingOpenEventsStmt, err = db.PrepareContext(ctx, listUpcomingOpenEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUpcomingOpenEvents: %w", err)
+	}
+	if q.listUpcomingReservationIDsForPrimaryUserStmt, err = db.PrepareContext(ctx, listUpcomingReservationIDsForPrimaryUser); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUpcomingReservationIDsForPrimaryUser: %w", err)
+	}
 	if q.listVisitPackTypesStmt, err = db.PrepareContext(ctx, listVisitPackTypes); err != nil {
 		return nil, fmt.Errorf("error preparing query ListVisitPackTypes: %w", err)
 	}
@@ -633,12 +1290,42 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.logCancellationStmt, err = db.PrepareContext(ctx, logCancellation); err != nil {
 		return nil, fmt.Errorf("error preparing query LogCancellation: %w", err)
 	}
+	if q.markDeferredEmailProcessedStmt, err = db.PrepareContext(ctx, markDeferredEmailProcessed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkDeferredEmailProcessed: %w", err)
+	}
+	if q.markEmailUndeliverableStmt, err = db.PrepareContext(ctx, markEmailUndeliverable); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkEmailUndeliverable: %w", err)
+	}
+	if q.markLeagueTeamInvitationAcceptedStmt, err = db.PrepareContext(ctx, markLeagueTeamInvitationAccepted); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkLeagueTeamInvitationAccepted: %w", err)
+	}
+	if q.markReservationInvitationAcceptedStmt, err = db.PrepareContext(ctx, markReservationInvitationAccepted); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkReservationInvitationAccepted: %w", err)
+	}
+	if q.markReservationInvitationDeclinedStmt, err = db.PrepareContext(ctx, markReservationInvitationDeclined); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkReservationInvitationDeclined: %w", err)
+	}
+	if q.markReservationTransferAcceptedStmt, err = db.PrepareContext(ctx, markReservationTransferAccepted); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkReservationTransferAccepted: %w", err)
+	}
+	if q.markReservationTransferDeclinedStmt, err = db.PrepareContext(ctx, markReservationTransferDeclined); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkReservationTransferDeclined: %w", err)
+	}
 	if q.markStaffNotificationAsReadStmt, err = db.PrepareContext(ctx, markStaffNotificationAsRead); err != nil {
 		return nil, fmt.Errorf("error preparing query MarkStaffNotificationAsRead: %w", err)
 	}
 	if q.operatingHoursExistsStmt, err = db.PrepareContext(ctx, operatingHoursExists); err != nil {
 		return nil, fmt.Errorf("error preparing query OperatingHoursExists: %w", err)
 	}
+	if q.overrideLeagueMatchResultSubmissionStmt, err = db.PrepareContext(ctx, overrideLeagueMatchResultSubmission); err != nil {
+		return nil, fmt.Errorf("error preparing query OverrideLeagueMatchResultSubmission: %w", err)
+	}
+	if q.recordJobRunStmt, err = db.PrepareContext(ctx, recordJobRun); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordJobRun: %w", err)
+	}
+	if q.removeHouseholdLinkStmt, err = db.PrepareContext(ctx, removeHouseholdLink); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveHouseholdLink: %w", err)
+	}
 	if q.removeOpenPlayParticipantStmt, err = db.PrepareContext(ctx, removeOpenPlayParticipant); err != nil {
 		return nil, fmt.Errorf("error preparing query RemoveOpenPlayParticipant: %w", err)
 	}
@@ -651,15 +1338,84 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.removeTeamMemberStmt, err = db.PrepareContext(ctx, removeTeamMember); err != nil {
 		return nil, fmt.Errorf("error preparing query RemoveTeamMember: %w", err)
 	}
+	if q.removeUserFromUpcomingReservationsStmt, err = db.PrepareContext(ctx, removeUserFromUpcomingReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveUserFromUpcomingReservations: %w", err)
+	}
+	if q.requestMemberDeletionStmt, err = db.PrepareContext(ctx, requestMemberDeletion); err != nil {
+		return nil, fmt.Errorf("error preparing query RequestMemberDeletion: %w", err)
+	}
+	if q.requeueOpenPlayPlayerStmt, err = db.PrepareContext(ctx, requeueOpenPlayPlayer); err != nil {
+		return nil, fmt.Errorf("error preparing query RequeueOpenPlayPlayer: %w", err)
+	}
+	if q.reservationsOverlapOnCourtStmt, err = db.PrepareContext(ctx, reservationsOverlapOnCourt); err != nil {
+		return nil, fmt.Errorf("error preparing query ReservationsOverlapOnCourt: %w", err)
+	}
+	if q.resolveReservationInvitationForUserStmt, err = db.PrepareContext(ctx, resolveReservationInvitationForUser); err != nil {
+		return nil, fmt.Errorf("error preparing query ResolveReservationInvitationForUser: %w", err)
+	}
+	if q.respondToHouseholdLinkStmt, err = db.PrepareContext(ctx, respondToHouseholdLink); err != nil {
+		return nil, fmt.Errorf("error preparing query RespondToHouseholdLink: %w", err)
+	}
+	if q.respondToLeagueMatchResultSubmissionStmt, err = db.PrepareContext(ctx, respondToLeagueMatchResultSubmission); err != nil {
+		return nil, fmt.Errorf("error preparing query RespondToLeagueMatchResultSubmission: %w", err)
+	}
 	if q.restoreLessonPackageLessonStmt, err = db.PrepareContext(ctx, restoreLessonPackageLesson); err != nil {
 		return nil, fmt.Errorf("error preparing query RestoreLessonPackageLesson: %w", err)
 	}
 	if q.restoreMemberStmt, err = db.PrepareContext(ctx, restoreMember); err != nil {
 		return nil, fmt.Errorf("error preparing query RestoreMember: %w", err)
 	}
+	if q.revokeMemberCardsStmt, err = db.PrepareContext(ctx, revokeMemberCards); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeMemberCards: %w", err)
+	}
+	if q.revokeOtherUserSessionsStmt, err = db.PrepareContext(ctx, revokeOtherUserSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeOtherUserSessions: %w", err)
+	}
+	if q.revokeReservationAccessStmt, err = db.PrepareContext(ctx, revokeReservationAccess); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeReservationAccess: %w", err)
+	}
+	if q.revokeUserSessionStmt, err = db.PrepareContext(ctx, revokeUserSession); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeUserSession: %w", err)
+	}
+	if q.revokeUserSessionByTokenStmt, err = db.PrepareContext(ctx, revokeUserSessionByToken); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeUserSessionByToken: %w", err)
+	}
+	if q.scheduleTournamentMatchStmt, err = db.PrepareContext(ctx, scheduleTournamentMatch); err != nil {
+		return nil, fmt.Errorf("error preparing query ScheduleTournamentMatch: %w", err)
+	}
+	if q.searchFacilityMembersStmt, err = db.PrepareContext(ctx, searchFacilityMembers); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchFacilityMembers: %w", err)
+	}
 	if q.searchMembersStmt, err = db.PrepareContext(ctx, searchMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query SearchMembers: %w", err)
 	}
+	if q.searchReservationsStmt, err = db.PrepareContext(ctx, searchReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchReservations: %w", err)
+	}
+	if q.seasonPassCoverageReportStmt, err = db.PrepareContext(ctx, seasonPassCoverageReport); err != nil {
+		return nil, fmt.Errorf("error preparing query SeasonPassCoverageReport: %w", err)
+	}
+	if q.setStaffInboxNotificationReadStmt, err = db.PrepareContext(ctx, setStaffInboxNotificationRead); err != nil {
+		return nil, fmt.Errorf("error preparing query SetStaffInboxNotificationRead: %w", err)
+	}
+	if q.setTournamentMatchTeamAStmt, err = db.PrepareContext(ctx, setTournamentMatchTeamA); err != nil {
+		return nil, fmt.Errorf("error preparing query SetTournamentMatchTeamA: %w", err)
+	}
+	if q.setTournamentMatchTeamBStmt, err = db.PrepareContext(ctx, setTournamentMatchTeamB); err != nil {
+		return nil, fmt.Errorf("error preparing query SetTournamentMatchTeamB: %w", err)
+	}
+	if q.setTournamentMatchWinnerStmt, err = db.PrepareContext(ctx, setTournamentMatchWinner); err != nil {
+		return nil, fmt.Errorf("error preparing query SetTournamentMatchWinner: %w", err)
+	}
+	if q.stageWaitlistPositionsStmt, err = db.PrepareContext(ctx, stageWaitlistPositions); err != nil {
+		return nil, fmt.Errorf("error preparing query StageWaitlistPositions: %w", err)
+	}
+	if q.touchUserSessionStmt, err = db.PrepareContext(ctx, touchUserSession); err != nil {
+		return nil, fmt.Errorf("error preparing query TouchUserSession: %w", err)
+	}
+	if q.transferActiveVisitPacksStmt, err = db.PrepareContext(ctx, transferActiveVisitPacks); err != nil {
+		return nil, fmt.Errorf("error preparing query TransferActiveVisitPacks: %w", err)
+	}
 	if q.updateBillingInfoStmt, err = db.PrepareContext(ctx, updateBillingInfo); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateBillingInfo: %w", err)
 	}
@@ -672,12 +1428,18 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateClinicTypeStmt, err = db.PrepareContext(ctx, updateClinicType); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateClinicType: %w", err)
 	}
+	if q.updateCourtPricingRuleStmt, err = db.PrepareContext(ctx, updateCourtPricingRule); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCourtPricingRule: %w", err)
+	}
 	if q.updateCourtStatusStmt, err = db.PrepareContext(ctx, updateCourtStatus); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateCourtStatus: %w", err)
 	}
 	if q.updateEnrollmentStatusStmt, err = db.PrepareContext(ctx, updateEnrollmentStatus); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateEnrollmentStatus: %w", err)
 	}
+	if q.updateFacilityAnnouncementStmt, err = db.PrepareContext(ctx, updateFacilityAnnouncement); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFacilityAnnouncement: %w", err)
+	}
 	if q.updateFacilityBookingConfigStmt, err = db.PrepareContext(ctx, updateFacilityBookingConfig); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFacilityBookingConfig: %w", err)
 	}
@@ -690,9 +1452,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateLeagueStmt, err = db.PrepareContext(ctx, updateLeague); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateLeague: %w", err)
 	}
+	if q.updateLeagueMatchStatusStmt, err = db.PrepareContext(ctx, updateLeagueMatchStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateLeagueMatchStatus: %w", err)
+	}
 	if q.updateLeagueTeamStmt, err = db.PrepareContext(ctx, updateLeagueTeam); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateLeagueTeam: %w", err)
 	}
+	if q.updateLeagueTeamDivisionStmt, err = db.PrepareContext(ctx, updateLeagueTeamDivision); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateLeagueTeamDivision: %w", err)
+	}
 	if q.updateLessonPackageTypeStmt, err = db.PrepareContext(ctx, updateLessonPackageType); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateLessonPackageType: %w", err)
 	}
@@ -705,6 +1473,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateMemberEmailStmt, err = db.PrepareContext(ctx, updateMemberEmail); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateMemberEmail: %w", err)
 	}
+	if q.updateMembershipTierStmt, err = db.PrepareContext(ctx, updateMembershipTier); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateMembershipTier: %w", err)
+	}
 	if q.updateOpenPlayRuleStmt, err = db.PrepareContext(ctx, updateOpenPlayRule); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateOpenPlayRule: %w", err)
 	}
@@ -717,9 +1488,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateOrganizationEmailConfigStmt, err = db.PrepareContext(ctx, updateOrganizationEmailConfig); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateOrganizationEmailConfig: %w", err)
 	}
+	if q.updateOrganizationSettingsStmt, err = db.PrepareContext(ctx, updateOrganizationSettings); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateOrganizationSettings: %w", err)
+	}
+	if q.updatePrimeTimeRuleStmt, err = db.PrepareContext(ctx, updatePrimeTimeRule); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdatePrimeTimeRule: %w", err)
+	}
 	if q.updateReservationStmt, err = db.PrepareContext(ctx, updateReservation); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateReservation: %w", err)
 	}
+	if q.updateReservationPrimaryUserStmt, err = db.PrepareContext(ctx, updateReservationPrimaryUser); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateReservationPrimaryUser: %w", err)
+	}
+	if q.updateReservationTypeStmt, err = db.PrepareContext(ctx, updateReservationType); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateReservationType: %w", err)
+	}
+	if q.updateSeasonPassTypeStmt, err = db.PrepareContext(ctx, updateSeasonPassType); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateSeasonPassType: %w", err)
+	}
 	if q.updateSessionAutoScaleOverrideStmt, err = db.PrepareContext(ctx, updateSessionAutoScaleOverride); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateSessionAutoScaleOverride: %w", err)
 	}
@@ -735,9 +1521,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateThemeStmt, err = db.PrepareContext(ctx, updateTheme); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateTheme: %w", err)
 	}
+	if q.updateTournamentMatchStatusStmt, err = db.PrepareContext(ctx, updateTournamentMatchStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateTournamentMatchStatus: %w", err)
+	}
+	if q.updateTournamentStatusStmt, err = db.PrepareContext(ctx, updateTournamentStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateTournamentStatus: %w", err)
+	}
 	if q.updateUserCognitoStatusStmt, err = db.PrepareContext(ctx, updateUserCognitoStatus); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUserCognitoStatus: %w", err)
 	}
+	if q.updateUserHideFromRostersStmt, err = db.PrepareContext(ctx, updateUserHideFromRosters); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserHideFromRosters: %w", err)
+	}
+	if q.updateUserHomeFacilityStmt, err = db.PrepareContext(ctx, updateUserHomeFacility); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserHomeFacility: %w", err)
+	}
+	if q.updateUserNotificationPreferencesStmt, err = db.PrepareContext(ctx, updateUserNotificationPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserNotificationPreferences: %w", err)
+	}
 	if q.updateUserPasswordHashStmt, err = db.PrepareContext(ctx, updateUserPasswordHash); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUserPasswordHash: %w", err)
 	}
@@ -747,24 +1548,48 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateVisitPackTypeStmt, err = db.PrepareContext(ctx, updateVisitPackType); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateVisitPackType: %w", err)
 	}
+	if q.updateWaitlistAutoBookStmt, err = db.PrepareContext(ctx, updateWaitlistAutoBook); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateWaitlistAutoBook: %w", err)
+	}
 	if q.updateWaitlistStatusStmt, err = db.PrepareContext(ctx, updateWaitlistStatus); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateWaitlistStatus: %w", err)
 	}
 	if q.upsertActiveThemeIDStmt, err = db.PrepareContext(ctx, upsertActiveThemeID); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertActiveThemeID: %w", err)
 	}
+	if q.upsertFacilityHoursOverrideStmt, err = db.PrepareContext(ctx, upsertFacilityHoursOverride); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertFacilityHoursOverride: %w", err)
+	}
+	if q.upsertFacilityNoShowPolicyStmt, err = db.PrepareContext(ctx, upsertFacilityNoShowPolicy); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertFacilityNoShowPolicy: %w", err)
+	}
+	if q.upsertFacilityQuietHoursStmt, err = db.PrepareContext(ctx, upsertFacilityQuietHours); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertFacilityQuietHours: %w", err)
+	}
+	if q.upsertMemberSkillRatingStmt, err = db.PrepareContext(ctx, upsertMemberSkillRating); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertMemberSkillRating: %w", err)
+	}
+	if q.upsertOpenPlaySessionSkillBandsStmt, err = db.PrepareContext(ctx, upsertOpenPlaySessionSkillBands); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertOpenPlaySessionSkillBands: %w", err)
+	}
 	if q.upsertOperatingHoursStmt, err = db.PrepareContext(ctx, upsertOperatingHours); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertOperatingHours: %w", err)
 	}
 	if q.upsertPhotoStmt, err = db.PrepareContext(ctx, upsertPhoto); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertPhoto: %w", err)
 	}
+	if q.upsertReservationClosureDetailsStmt, err = db.PrepareContext(ctx, upsertReservationClosureDetails); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertReservationClosureDetails: %w", err)
+	}
 	if q.upsertTierBookingWindowStmt, err = db.PrepareContext(ctx, upsertTierBookingWindow); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertTierBookingWindow: %w", err)
 	}
 	if q.upsertWaitlistConfigStmt, err = db.PrepareContext(ctx, upsertWaitlistConfig); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertWaitlistConfig: %w", err)
 	}
+	if q.verifyMemberRatingEntryStmt, err = db.PrepareContext(ctx, verifyMemberRatingEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query VerifyMemberRatingEntry: %w", err)
+	}
 	return &q, nil
 }
 
@@ -775,6 +1600,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing acceptOfferStmt: %w", cerr)
 		}
 	}
+	if q.addOpenPlayGamePlayerStmt != nil {
+		if cerr := q.addOpenPlayGamePlayerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addOpenPlayGamePlayerStmt: %w", cerr)
+		}
+	}
 	if q.addOpenPlayParticipantStmt != nil {
 		if cerr := q.addOpenPlayParticipantStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addOpenPlayParticipantStmt: %w", cerr)
@@ -790,26 +1620,101 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addReservationCourtStmt: %w", cerr)
 		}
 	}
+	if q.addReservationGuestStmt != nil {
+		if cerr := q.addReservationGuestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addReservationGuestStmt: %w", cerr)
+		}
+	}
 	if q.addTeamMemberStmt != nil {
 		if cerr := q.addTeamMemberStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addTeamMemberStmt: %w", cerr)
 		}
 	}
+	if q.addTournamentEntrantStmt != nil {
+		if cerr := q.addTournamentEntrantStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addTournamentEntrantStmt: %w", cerr)
+		}
+	}
 	if q.advanceWaitlistOfferStmt != nil {
 		if cerr := q.advanceWaitlistOfferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing advanceWaitlistOfferStmt: %w", cerr)
 		}
 	}
+	if q.anonymizeDeletedMembersStmt != nil {
+		if cerr := q.anonymizeDeletedMembersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing anonymizeDeletedMembersStmt: %w", cerr)
+		}
+	}
+	if q.applyStagedWaitlistPositionsStmt != nil {
+		if cerr := q.applyStagedWaitlistPositionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing applyStagedWaitlistPositionsStmt: %w", cerr)
+		}
+	}
 	if q.assignFreeAgentToTeamStmt != nil {
 		if cerr := q.assignFreeAgentToTeamStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing assignFreeAgentToTeamStmt: %w", cerr)
 		}
 	}
+	if q.cancelPendingReservationInvitationsStmt != nil {
+		if cerr := q.cancelPendingReservationInvitationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cancelPendingReservationInvitationsStmt: %w", cerr)
+		}
+	}
+	if q.cancelPendingReservationTransfersStmt != nil {
+		if cerr := q.cancelPendingReservationTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cancelPendingReservationTransfersStmt: %w", cerr)
+		}
+	}
+	if q.cancelUpcomingReservationInvitationsForUserStmt != nil {
+		if cerr := q.cancelUpcomingReservationInvitationsForUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cancelUpcomingReservationInvitationsForUserStmt: %w", cerr)
+		}
+	}
+	if q.claimReservationReminderStmt != nil {
+		if cerr := q.claimReservationReminderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimReservationReminderStmt: %w", cerr)
+		}
+	}
+	if q.clearUserEmailUndeliverableStmt != nil {
+		if cerr := q.clearUserEmailUndeliverableStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearUserEmailUndeliverableStmt: %w", cerr)
+		}
+	}
+	if q.consumeAccountCreditStmt != nil {
+		if cerr := q.consumeAccountCreditStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing consumeAccountCreditStmt: %w", cerr)
+		}
+	}
+	if q.consumeMemberGuestPassStmt != nil {
+		if cerr := q.consumeMemberGuestPassStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing consumeMemberGuestPassStmt: %w", cerr)
+		}
+	}
+	if q.countActiveCourtsStmt != nil {
+		if cerr := q.countActiveCourtsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countActiveCourtsStmt: %w", cerr)
+		}
+	}
+	if q.countActiveHouseholdDependentsStmt != nil {
+		if cerr := q.countActiveHouseholdDependentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countActiveHouseholdDependentsStmt: %w", cerr)
+		}
+	}
+	if q.countActiveHouseholdReservationsStmt != nil {
+		if cerr := q.countActiveHouseholdReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countActiveHouseholdReservationsStmt: %w", cerr)
+		}
+	}
 	if q.countActiveMemberReservationsStmt != nil {
 		if cerr := q.countActiveMemberReservationsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countActiveMemberReservationsStmt: %w", cerr)
 		}
 	}
+	if q.countBusyCourtsByHourStmt != nil {
+		if cerr := q.countBusyCourtsByHourStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countBusyCourtsByHourStmt: %w", cerr)
+		}
+	}
 	if q.countCheckinsByFacilityInRangeStmt != nil {
 		if cerr := q.countCheckinsByFacilityInRangeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countCheckinsByFacilityInRangeStmt: %w", cerr)
@@ -840,11 +1745,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing countOpenPlayReservationsForSessionStmt: %w", cerr)
 		}
 	}
+	if q.countOpenPlaySessionsForSlotStmt != nil {
+		if cerr := q.countOpenPlaySessionsForSlotStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countOpenPlaySessionsForSlotStmt: %w", cerr)
+		}
+	}
+	if q.countPendingReservationInvitationsStmt != nil {
+		if cerr := q.countPendingReservationInvitationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countPendingReservationInvitationsStmt: %w", cerr)
+		}
+	}
+	if q.countProSessionsByProInRangeStmt != nil {
+		if cerr := q.countProSessionsByProInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countProSessionsByProInRangeStmt: %w", cerr)
+		}
+	}
 	if q.countReservationParticipantsStmt != nil {
 		if cerr := q.countReservationParticipantsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countReservationParticipantsStmt: %w", cerr)
 		}
 	}
+	if q.countReservationTypeNamesInRangeStmt != nil {
+		if cerr := q.countReservationTypeNamesInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countReservationTypeNamesInRangeStmt: %w", cerr)
+		}
+	}
+	if q.countReservationTypeUsageStmt != nil {
+		if cerr := q.countReservationTypeUsageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countReservationTypeUsageStmt: %w", cerr)
+		}
+	}
 	if q.countReservationsByTypeInRangeStmt != nil {
 		if cerr := q.countReservationsByTypeInRangeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countReservationsByTypeInRangeStmt: %w", cerr)
@@ -860,6 +1790,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing countThemeUsageStmt: %w", cerr)
 		}
 	}
+	if q.countTournamentMatchesStmt != nil {
+		if cerr := q.countTournamentMatchesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countTournamentMatchesStmt: %w", cerr)
+		}
+	}
+	if q.countUnreadStaffInboxNotificationsStmt != nil {
+		if cerr := q.countUnreadStaffInboxNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUnreadStaffInboxNotificationsStmt: %w", cerr)
+		}
+	}
 	if q.countUnreadStaffNotificationsStmt != nil {
 		if cerr := q.countUnreadStaffNotificationsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countUnreadStaffNotificationsStmt: %w", cerr)
@@ -870,6 +1810,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing countVisitPackTypesByFacilityStmt: %w", cerr)
 		}
 	}
+	if q.createAccountCreditStmt != nil {
+		if cerr := q.createAccountCreditStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAccountCreditStmt: %w", cerr)
+		}
+	}
+	if q.createAccountCreditRedemptionStmt != nil {
+		if cerr := q.createAccountCreditRedemptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAccountCreditRedemptionStmt: %w", cerr)
+		}
+	}
 	if q.createCancellationPolicyTierStmt != nil {
 		if cerr := q.createCancellationPolicyTierStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createCancellationPolicyTierStmt: %w", cerr)
@@ -895,26 +1845,81 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createCourtStmt: %w", cerr)
 		}
 	}
+	if q.createCourtPricingRuleStmt != nil {
+		if cerr := q.createCourtPricingRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCourtPricingRuleStmt: %w", cerr)
+		}
+	}
+	if q.createCourtSlotHoldStmt != nil {
+		if cerr := q.createCourtSlotHoldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCourtSlotHoldStmt: %w", cerr)
+		}
+	}
+	if q.createDeferredEmailStmt != nil {
+		if cerr := q.createDeferredEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createDeferredEmailStmt: %w", cerr)
+		}
+	}
+	if q.createEmailDeliveryEventStmt != nil {
+		if cerr := q.createEmailDeliveryEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createEmailDeliveryEventStmt: %w", cerr)
+		}
+	}
+	if q.createFacilityAnnouncementStmt != nil {
+		if cerr := q.createFacilityAnnouncementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFacilityAnnouncementStmt: %w", cerr)
+		}
+	}
 	if q.createFacilityVisitStmt != nil {
 		if cerr := q.createFacilityVisitStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createFacilityVisitStmt: %w", cerr)
 		}
 	}
+	if q.createHouseholdLinkStmt != nil {
+		if cerr := q.createHouseholdLinkStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createHouseholdLinkStmt: %w", cerr)
+		}
+	}
+	if q.createImpersonationAuditEntryStmt != nil {
+		if cerr := q.createImpersonationAuditEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createImpersonationAuditEntryStmt: %w", cerr)
+		}
+	}
 	if q.createLeagueStmt != nil {
 		if cerr := q.createLeagueStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createLeagueStmt: %w", cerr)
 		}
 	}
+	if q.createLeagueFreeAgentRegistrationStmt != nil {
+		if cerr := q.createLeagueFreeAgentRegistrationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLeagueFreeAgentRegistrationStmt: %w", cerr)
+		}
+	}
 	if q.createLeagueMatchStmt != nil {
 		if cerr := q.createLeagueMatchStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createLeagueMatchStmt: %w", cerr)
 		}
 	}
+	if q.createLeagueMatchResultSubmissionStmt != nil {
+		if cerr := q.createLeagueMatchResultSubmissionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLeagueMatchResultSubmissionStmt: %w", cerr)
+		}
+	}
+	if q.createLeaguePaymentStmt != nil {
+		if cerr := q.createLeaguePaymentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLeaguePaymentStmt: %w", cerr)
+		}
+	}
 	if q.createLeagueTeamStmt != nil {
 		if cerr := q.createLeagueTeamStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createLeagueTeamStmt: %w", cerr)
 		}
 	}
+	if q.createLeagueTeamInvitationStmt != nil {
+		if cerr := q.createLeagueTeamInvitationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLeagueTeamInvitationStmt: %w", cerr)
+		}
+	}
 	if q.createLessonCancelledNotificationStmt != nil {
 		if cerr := q.createLessonCancelledNotificationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createLessonCancelledNotificationStmt: %w", cerr)
@@ -935,21 +1940,66 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createLessonPackageTypeStmt: %w", cerr)
 		}
 	}
+	if q.createLessonReassignedNotificationStmt != nil {
+		if cerr := q.createLessonReassignedNotificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLessonReassignedNotificationStmt: %w", cerr)
+		}
+	}
 	if q.createMemberStmt != nil {
 		if cerr := q.createMemberStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createMemberStmt: %w", cerr)
 		}
 	}
+	if q.createMemberCardStmt != nil {
+		if cerr := q.createMemberCardStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMemberCardStmt: %w", cerr)
+		}
+	}
+	if q.createMemberHomeFacilityChangeStmt != nil {
+		if cerr := q.createMemberHomeFacilityChangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMemberHomeFacilityChangeStmt: %w", cerr)
+		}
+	}
+	if q.createMemberRatingEntryStmt != nil {
+		if cerr := q.createMemberRatingEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMemberRatingEntryStmt: %w", cerr)
+		}
+	}
+	if q.createMembershipTierStmt != nil {
+		if cerr := q.createMembershipTierStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMembershipTierStmt: %w", cerr)
+		}
+	}
+	if q.createNoShowRestrictionClearStmt != nil {
+		if cerr := q.createNoShowRestrictionClearStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createNoShowRestrictionClearStmt: %w", cerr)
+		}
+	}
 	if q.createOpenPlayAuditLogStmt != nil {
 		if cerr := q.createOpenPlayAuditLogStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createOpenPlayAuditLogStmt: %w", cerr)
 		}
 	}
+	if q.createOpenPlayGameStmt != nil {
+		if cerr := q.createOpenPlayGameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOpenPlayGameStmt: %w", cerr)
+		}
+	}
+	if q.createOpenPlayQueueEntryStmt != nil {
+		if cerr := q.createOpenPlayQueueEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOpenPlayQueueEntryStmt: %w", cerr)
+		}
+	}
 	if q.createOpenPlayRuleStmt != nil {
 		if cerr := q.createOpenPlayRuleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createOpenPlayRuleStmt: %w", cerr)
 		}
 	}
+	if q.createOpenPlayRuleSlotStmt != nil {
+		if cerr := q.createOpenPlayRuleSlotStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOpenPlayRuleSlotStmt: %w", cerr)
+		}
+	}
 	if q.createOpenPlaySessionStmt != nil {
 		if cerr := q.createOpenPlaySessionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createOpenPlaySessionStmt: %w", cerr)
@@ -960,6 +2010,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createPhotoStmt: %w", cerr)
 		}
 	}
+	if q.createPrimeTimeRuleStmt != nil {
+		if cerr := q.createPrimeTimeRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPrimeTimeRuleStmt: %w", cerr)
+		}
+	}
 	if q.createProUnavailabilityStmt != nil {
 		if cerr := q.createProUnavailabilityStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createProUnavailabilityStmt: %w", cerr)
@@ -970,6 +2025,71 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createReservationStmt: %w", cerr)
 		}
 	}
+	if q.createReservationCheckinStmt != nil {
+		if cerr := q.createReservationCheckinStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationCheckinStmt: %w", cerr)
+		}
+	}
+	if q.createReservationCommentStmt != nil {
+		if cerr := q.createReservationCommentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationCommentStmt: %w", cerr)
+		}
+	}
+	if q.createReservationDurationOverrideStmt != nil {
+		if cerr := q.createReservationDurationOverrideStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationDurationOverrideStmt: %w", cerr)
+		}
+	}
+	if q.createReservationIdempotencyKeyStmt != nil {
+		if cerr := q.createReservationIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.createReservationInvitationStmt != nil {
+		if cerr := q.createReservationInvitationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationInvitationStmt: %w", cerr)
+		}
+	}
+	if q.createReservationMemberNoteStmt != nil {
+		if cerr := q.createReservationMemberNoteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationMemberNoteStmt: %w", cerr)
+		}
+	}
+	if q.createReservationPriceStmt != nil {
+		if cerr := q.createReservationPriceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationPriceStmt: %w", cerr)
+		}
+	}
+	if q.createReservationTransferStmt != nil {
+		if cerr := q.createReservationTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationTransferStmt: %w", cerr)
+		}
+	}
+	if q.createReservationTypeStmt != nil {
+		if cerr := q.createReservationTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationTypeStmt: %w", cerr)
+		}
+	}
+	if q.createSeasonPassStmt != nil {
+		if cerr := q.createSeasonPassStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSeasonPassStmt: %w", cerr)
+		}
+	}
+	if q.createSeasonPassReservationStmt != nil {
+		if cerr := q.createSeasonPassReservationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSeasonPassReservationStmt: %w", cerr)
+		}
+	}
+	if q.createSeasonPassTypeStmt != nil {
+		if cerr := q.createSeasonPassTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSeasonPassTypeStmt: %w", cerr)
+		}
+	}
+	if q.createSeasonPassWindowStmt != nil {
+		if cerr := q.createSeasonPassWindowStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSeasonPassWindowStmt: %w", cerr)
+		}
+	}
 	if q.createStaffStmt != nil {
 		if cerr := q.createStaffStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createStaffStmt: %w", cerr)
@@ -990,11 +2110,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createThemeStmt: %w", cerr)
 		}
 	}
+	if q.createTournamentStmt != nil {
+		if cerr := q.createTournamentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTournamentStmt: %w", cerr)
+		}
+	}
+	if q.createTournamentMatchStmt != nil {
+		if cerr := q.createTournamentMatchStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTournamentMatchStmt: %w", cerr)
+		}
+	}
+	if q.createUserSessionStmt != nil {
+		if cerr := q.createUserSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createUserSessionStmt: %w", cerr)
+		}
+	}
 	if q.createVisitPackStmt != nil {
 		if cerr := q.createVisitPackStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createVisitPackStmt: %w", cerr)
 		}
 	}
+	if q.createVisitPackPaymentStmt != nil {
+		if cerr := q.createVisitPackPaymentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createVisitPackPaymentStmt: %w", cerr)
+		}
+	}
 	if q.createVisitPackRedemptionStmt != nil {
 		if cerr := q.createVisitPackRedemptionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createVisitPackRedemptionStmt: %w", cerr)
@@ -1010,6 +2150,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createWaitlistEntryStmt: %w", cerr)
 		}
 	}
+	if q.createWaitlistFulfilledNotificationStmt != nil {
+		if cerr := q.createWaitlistFulfilledNotificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWaitlistFulfilledNotificationStmt: %w", cerr)
+		}
+	}
 	if q.createWaitlistOfferStmt != nil {
 		if cerr := q.createWaitlistOfferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createWaitlistOfferStmt: %w", cerr)
@@ -1020,11 +2165,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deactivateLessonPackageTypeStmt: %w", cerr)
 		}
 	}
+	if q.deactivateSeasonPassTypeStmt != nil {
+		if cerr := q.deactivateSeasonPassTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deactivateSeasonPassTypeStmt: %w", cerr)
+		}
+	}
 	if q.deactivateVisitPackTypeStmt != nil {
 		if cerr := q.deactivateVisitPackTypeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deactivateVisitPackTypeStmt: %w", cerr)
 		}
 	}
+	if q.declineOfferStmt != nil {
+		if cerr := q.declineOfferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing declineOfferStmt: %w", cerr)
+		}
+	}
 	if q.decrementLessonPackageLessonStmt != nil {
 		if cerr := q.decrementLessonPackageLessonStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing decrementLessonPackageLessonStmt: %w", cerr)
@@ -1035,6 +2190,26 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing decrementVisitPackVisitStmt: %w", cerr)
 		}
 	}
+	if q.deleteActiveWaitlistEntriesForUserStmt != nil {
+		if cerr := q.deleteActiveWaitlistEntriesForUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteActiveWaitlistEntriesForUserStmt: %w", cerr)
+		}
+	}
+	if q.deleteAnonymizableMemberBillingStmt != nil {
+		if cerr := q.deleteAnonymizableMemberBillingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAnonymizableMemberBillingStmt: %w", cerr)
+		}
+	}
+	if q.deleteAnonymizableMemberPhotosStmt != nil {
+		if cerr := q.deleteAnonymizableMemberPhotosStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAnonymizableMemberPhotosStmt: %w", cerr)
+		}
+	}
+	if q.deleteCancellationPolicyStmt != nil {
+		if cerr := q.deleteCancellationPolicyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCancellationPolicyStmt: %w", cerr)
+		}
+	}
 	if q.deleteCancellationPolicyTierStmt != nil {
 		if cerr := q.deleteCancellationPolicyTierStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteCancellationPolicyTierStmt: %w", cerr)
@@ -1055,11 +2230,66 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteClinicTypeStmt: %w", cerr)
 		}
 	}
+	if q.deleteCourtPricingRuleStmt != nil {
+		if cerr := q.deleteCourtPricingRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCourtPricingRuleStmt: %w", cerr)
+		}
+	}
+	if q.deleteCourtSlotHoldByUserStmt != nil {
+		if cerr := q.deleteCourtSlotHoldByUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCourtSlotHoldByUserStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredCourtSlotHoldsStmt != nil {
+		if cerr := q.deleteExpiredCourtSlotHoldsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredCourtSlotHoldsStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredReservationIdempotencyKeyStmt != nil {
+		if cerr := q.deleteExpiredReservationIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredReservationIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredReservationIdempotencyKeysStmt != nil {
+		if cerr := q.deleteExpiredReservationIdempotencyKeysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredReservationIdempotencyKeysStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredUserSessionsStmt != nil {
+		if cerr := q.deleteExpiredUserSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredUserSessionsStmt: %w", cerr)
+		}
+	}
+	if q.deleteFacilityAnnouncementStmt != nil {
+		if cerr := q.deleteFacilityAnnouncementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFacilityAnnouncementStmt: %w", cerr)
+		}
+	}
+	if q.deleteFacilityHoursOverrideStmt != nil {
+		if cerr := q.deleteFacilityHoursOverrideStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFacilityHoursOverrideStmt: %w", cerr)
+		}
+	}
+	if q.deleteFacilityNoShowPolicyStmt != nil {
+		if cerr := q.deleteFacilityNoShowPolicyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFacilityNoShowPolicyStmt: %w", cerr)
+		}
+	}
+	if q.deleteFacilityQuietHoursStmt != nil {
+		if cerr := q.deleteFacilityQuietHoursStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFacilityQuietHoursStmt: %w", cerr)
+		}
+	}
 	if q.deleteLeagueStmt != nil {
 		if cerr := q.deleteLeagueStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteLeagueStmt: %w", cerr)
 		}
 	}
+	if q.deleteLeagueFreeAgentRegistrationStmt != nil {
+		if cerr := q.deleteLeagueFreeAgentRegistrationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteLeagueFreeAgentRegistrationStmt: %w", cerr)
+		}
+	}
 	if q.deleteLeagueMatchesByLeagueIDStmt != nil {
 		if cerr := q.deleteLeagueMatchesByLeagueIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteLeagueMatchesByLeagueIDStmt: %w", cerr)
@@ -1075,11 +2305,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteMemberStmt: %w", cerr)
 		}
 	}
+	if q.deleteMemberSkillRatingStmt != nil {
+		if cerr := q.deleteMemberSkillRatingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteMemberSkillRatingStmt: %w", cerr)
+		}
+	}
+	if q.deleteMembershipTierStmt != nil {
+		if cerr := q.deleteMembershipTierStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteMembershipTierStmt: %w", cerr)
+		}
+	}
+	if q.deleteOpenPlayQueueEntryStmt != nil {
+		if cerr := q.deleteOpenPlayQueueEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteOpenPlayQueueEntryStmt: %w", cerr)
+		}
+	}
 	if q.deleteOpenPlayRuleStmt != nil {
 		if cerr := q.deleteOpenPlayRuleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteOpenPlayRuleStmt: %w", cerr)
 		}
 	}
+	if q.deleteOpenPlayRuleSlotStmt != nil {
+		if cerr := q.deleteOpenPlayRuleSlotStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteOpenPlayRuleSlotStmt: %w", cerr)
+		}
+	}
+	if q.deleteOpenPlaySessionSkillBandsStmt != nil {
+		if cerr := q.deleteOpenPlaySessionSkillBandsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteOpenPlaySessionSkillBandsStmt: %w", cerr)
+		}
+	}
 	if q.deleteOperatingHoursStmt != nil {
 		if cerr := q.deleteOperatingHoursStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteOperatingHoursStmt: %w", cerr)
@@ -1095,6 +2350,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deletePhotoStmt: %w", cerr)
 		}
 	}
+	if q.deletePrimeTimeRuleStmt != nil {
+		if cerr := q.deletePrimeTimeRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePrimeTimeRuleStmt: %w", cerr)
+		}
+	}
 	if q.deleteProUnavailabilityStmt != nil {
 		if cerr := q.deleteProUnavailabilityStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteProUnavailabilityStmt: %w", cerr)
@@ -1105,6 +2365,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteReservationStmt: %w", cerr)
 		}
 	}
+	if q.deleteReservationClosureDetailsStmt != nil {
+		if cerr := q.deleteReservationClosureDetailsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteReservationClosureDetailsStmt: %w", cerr)
+		}
+	}
 	if q.deleteReservationCourtsByReservationIDStmt != nil {
 		if cerr := q.deleteReservationCourtsByReservationIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteReservationCourtsByReservationIDStmt: %w", cerr)
@@ -1115,11 +2380,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteReservationParticipantsByReservationIDStmt: %w", cerr)
 		}
 	}
+	if q.deleteReservationReminderStmt != nil {
+		if cerr := q.deleteReservationReminderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteReservationReminderStmt: %w", cerr)
+		}
+	}
+	if q.deleteReservationTypeStmt != nil {
+		if cerr := q.deleteReservationTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteReservationTypeStmt: %w", cerr)
+		}
+	}
+	if q.deleteSeasonPassWindowsStmt != nil {
+		if cerr := q.deleteSeasonPassWindowsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSeasonPassWindowsStmt: %w", cerr)
+		}
+	}
 	if q.deleteStaffStmt != nil {
 		if cerr := q.deleteStaffStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteStaffStmt: %w", cerr)
 		}
 	}
+	if q.deleteStaffNotificationsBeforeStmt != nil {
+		if cerr := q.deleteStaffNotificationsBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStaffNotificationsBeforeStmt: %w", cerr)
+		}
+	}
 	if q.deleteThemeStmt != nil {
 		if cerr := q.deleteThemeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteThemeStmt: %w", cerr)
@@ -1135,16 +2420,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteWaitlistEntryStmt: %w", cerr)
 		}
 	}
+	if q.dismissFacilityAnnouncementStmt != nil {
+		if cerr := q.dismissFacilityAnnouncementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing dismissFacilityAnnouncementStmt: %w", cerr)
+		}
+	}
 	if q.expireOfferStmt != nil {
 		if cerr := q.expireOfferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing expireOfferStmt: %w", cerr)
 		}
 	}
+	if q.expireSiblingWaitlistOffersStmt != nil {
+		if cerr := q.expireSiblingWaitlistOffersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing expireSiblingWaitlistOffersStmt: %w", cerr)
+		}
+	}
 	if q.facilityExistsStmt != nil {
 		if cerr := q.facilityExistsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing facilityExistsStmt: %w", cerr)
 		}
 	}
+	if q.findMatchingReservationStmt != nil {
+		if cerr := q.findMatchingReservationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findMatchingReservationStmt: %w", cerr)
+		}
+	}
+	if q.getActiveHouseholdLinkByDependentStmt != nil {
+		if cerr := q.getActiveHouseholdLinkByDependentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getActiveHouseholdLinkByDependentStmt: %w", cerr)
+		}
+	}
+	if q.getActiveMemberCardStmt != nil {
+		if cerr := q.getActiveMemberCardStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getActiveMemberCardStmt: %w", cerr)
+		}
+	}
+	if q.getActiveMemberCardByTokenStmt != nil {
+		if cerr := q.getActiveMemberCardByTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getActiveMemberCardByTokenStmt: %w", cerr)
+		}
+	}
 	if q.getActiveThemeIDStmt != nil {
 		if cerr := q.getActiveThemeIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getActiveThemeIDStmt: %w", cerr)
@@ -1215,6 +2530,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getEnrollmentCountStmt: %w", cerr)
 		}
 	}
+	if q.getFacilityAnnouncementStmt != nil {
+		if cerr := q.getFacilityAnnouncementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFacilityAnnouncementStmt: %w", cerr)
+		}
+	}
 	if q.getFacilityByIDStmt != nil {
 		if cerr := q.getFacilityByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFacilityByIDStmt: %w", cerr)
@@ -1230,31 +2550,81 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getFacilityHoursStmt: %w", cerr)
 		}
 	}
+	if q.getFacilityHoursOverrideStmt != nil {
+		if cerr := q.getFacilityHoursOverrideStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFacilityHoursOverrideStmt: %w", cerr)
+		}
+	}
+	if q.getFacilityNoShowPolicyStmt != nil {
+		if cerr := q.getFacilityNoShowPolicyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFacilityNoShowPolicyStmt: %w", cerr)
+		}
+	}
+	if q.getFacilityQuietHoursStmt != nil {
+		if cerr := q.getFacilityQuietHoursStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFacilityQuietHoursStmt: %w", cerr)
+		}
+	}
 	if q.getFacilityTierBookingEnabledStmt != nil {
 		if cerr := q.getFacilityTierBookingEnabledStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFacilityTierBookingEnabledStmt: %w", cerr)
 		}
 	}
+	if q.getFirstLeagueMatchTimeStmt != nil {
+		if cerr := q.getFirstLeagueMatchTimeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFirstLeagueMatchTimeStmt: %w", cerr)
+		}
+	}
 	if q.getFutureProSessionsByStaffIDStmt != nil {
 		if cerr := q.getFutureProSessionsByStaffIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFutureProSessionsByStaffIDStmt: %w", cerr)
 		}
 	}
+	if q.getHouseholdLinkStmt != nil {
+		if cerr := q.getHouseholdLinkStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getHouseholdLinkStmt: %w", cerr)
+		}
+	}
+	if q.getHouseholdLinkByTokenStmt != nil {
+		if cerr := q.getHouseholdLinkByTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getHouseholdLinkByTokenStmt: %w", cerr)
+		}
+	}
 	if q.getLatestCancellationByReservationIDStmt != nil {
 		if cerr := q.getLatestCancellationByReservationIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getLatestCancellationByReservationIDStmt: %w", cerr)
 		}
 	}
+	if q.getLatestNoShowRestrictionClearStmt != nil {
+		if cerr := q.getLatestNoShowRestrictionClearStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestNoShowRestrictionClearStmt: %w", cerr)
+		}
+	}
+	if q.getLatestSyncSeqStmt != nil {
+		if cerr := q.getLatestSyncSeqStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestSyncSeqStmt: %w", cerr)
+		}
+	}
 	if q.getLeagueStmt != nil {
 		if cerr := q.getLeagueStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getLeagueStmt: %w", cerr)
 		}
 	}
+	if q.getLeagueFreeAgentRegistrationStmt != nil {
+		if cerr := q.getLeagueFreeAgentRegistrationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLeagueFreeAgentRegistrationStmt: %w", cerr)
+		}
+	}
 	if q.getLeagueMatchStmt != nil {
 		if cerr := q.getLeagueMatchStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getLeagueMatchStmt: %w", cerr)
 		}
 	}
+	if q.getLeagueMatchResultSubmissionStmt != nil {
+		if cerr := q.getLeagueMatchResultSubmissionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLeagueMatchResultSubmissionStmt: %w", cerr)
+		}
+	}
 	if q.getLeagueStandingsDataStmt != nil {
 		if cerr := q.getLeagueStandingsDataStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getLeagueStandingsDataStmt: %w", cerr)
@@ -1265,6 +2635,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getLeagueTeamStmt: %w", cerr)
 		}
 	}
+	if q.getLeagueTeamInvitationByTokenStmt != nil {
+		if cerr := q.getLeagueTeamInvitationByTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLeagueTeamInvitationByTokenStmt: %w", cerr)
+		}
+	}
 	if q.getLeagueWithFacilityTimezoneStmt != nil {
 		if cerr := q.getLeagueWithFacilityTimezoneStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getLeagueWithFacilityTimezoneStmt: %w", cerr)
@@ -1305,16 +2680,51 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getMemberByIDStmt: %w", cerr)
 		}
 	}
+	if q.getMemberGuestPassBalanceStmt != nil {
+		if cerr := q.getMemberGuestPassBalanceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMemberGuestPassBalanceStmt: %w", cerr)
+		}
+	}
+	if q.getMemberOverlappingReservationStmt != nil {
+		if cerr := q.getMemberOverlappingReservationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMemberOverlappingReservationStmt: %w", cerr)
+		}
+	}
 	if q.getMemberPhotoStmt != nil {
 		if cerr := q.getMemberPhotoStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getMemberPhotoStmt: %w", cerr)
 		}
 	}
+	if q.getMemberRatingEntryStmt != nil {
+		if cerr := q.getMemberRatingEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMemberRatingEntryStmt: %w", cerr)
+		}
+	}
+	if q.getMemberSkillRatingStmt != nil {
+		if cerr := q.getMemberSkillRatingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMemberSkillRatingStmt: %w", cerr)
+		}
+	}
 	if q.getMemberTodayActivitiesStmt != nil {
 		if cerr := q.getMemberTodayActivitiesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getMemberTodayActivitiesStmt: %w", cerr)
 		}
 	}
+	if q.getMembershipTierStmt != nil {
+		if cerr := q.getMembershipTierStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMembershipTierStmt: %w", cerr)
+		}
+	}
+	if q.getMembershipTierForLevelStmt != nil {
+		if cerr := q.getMembershipTierForLevelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMembershipTierForLevelStmt: %w", cerr)
+		}
+	}
+	if q.getOpenEventStmt != nil {
+		if cerr := q.getOpenEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOpenEventStmt: %w", cerr)
+		}
+	}
 	if q.getOpenPlayReservationIDStmt != nil {
 		if cerr := q.getOpenPlayReservationIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getOpenPlayReservationIDStmt: %w", cerr)
@@ -1330,6 +2740,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getOpenPlaySessionStmt: %w", cerr)
 		}
 	}
+	if q.getOpenPlaySessionSkillBandsStmt != nil {
+		if cerr := q.getOpenPlaySessionSkillBandsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOpenPlaySessionSkillBandsStmt: %w", cerr)
+		}
+	}
 	if q.getOrganizationByIDStmt != nil {
 		if cerr := q.getOrganizationByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getOrganizationByIDStmt: %w", cerr)
@@ -1355,7 +2770,12 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getOrganizationReminderConfigStmt: %w", cerr)
 		}
 	}
-	if q.getPendingOfferStmt != nil {
+	if q.getOrganizationSettingsStmt != nil {
+		if cerr := q.getOrganizationSettingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOrganizationSettingsStmt: %w", cerr)
+		}
+	}
+	if q.getPendingOfferStmt != nil {
 		if cerr := q.getPendingOfferStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getPendingOfferStmt: %w", cerr)
 		}
@@ -1365,6 +2785,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getPhotoStmt: %w", cerr)
 		}
 	}
+	if q.getPreferredMemberRatingStmt != nil {
+		if cerr := q.getPreferredMemberRatingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPreferredMemberRatingStmt: %w", cerr)
+		}
+	}
 	if q.getProLessonSlotsStmt != nil {
 		if cerr := q.getProLessonSlotsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getProLessonSlotsStmt: %w", cerr)
@@ -1380,21 +2805,71 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getReservationStmt: %w", cerr)
 		}
 	}
+	if q.getReservationAccessRevocationStmt != nil {
+		if cerr := q.getReservationAccessRevocationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReservationAccessRevocationStmt: %w", cerr)
+		}
+	}
 	if q.getReservationByIDStmt != nil {
 		if cerr := q.getReservationByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getReservationByIDStmt: %w", cerr)
 		}
 	}
+	if q.getReservationClosureDetailsStmt != nil {
+		if cerr := q.getReservationClosureDetailsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReservationClosureDetailsStmt: %w", cerr)
+		}
+	}
+	if q.getReservationCreditAppliedCentsStmt != nil {
+		if cerr := q.getReservationCreditAppliedCentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReservationCreditAppliedCentsStmt: %w", cerr)
+		}
+	}
+	if q.getReservationIdempotencyKeyStmt != nil {
+		if cerr := q.getReservationIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReservationIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.getReservationInvitationByTokenStmt != nil {
+		if cerr := q.getReservationInvitationByTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReservationInvitationByTokenStmt: %w", cerr)
+		}
+	}
+	if q.getReservationMemberNoteStmt != nil {
+		if cerr := q.getReservationMemberNoteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReservationMemberNoteStmt: %w", cerr)
+		}
+	}
+	if q.getReservationPriceStmt != nil {
+		if cerr := q.getReservationPriceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReservationPriceStmt: %w", cerr)
+		}
+	}
+	if q.getReservationTransferByTokenStmt != nil {
+		if cerr := q.getReservationTransferByTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReservationTransferByTokenStmt: %w", cerr)
+		}
+	}
 	if q.getReservationTypeStmt != nil {
 		if cerr := q.getReservationTypeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getReservationTypeStmt: %w", cerr)
 		}
 	}
+	if q.getReservationTypeBufferMinutesStmt != nil {
+		if cerr := q.getReservationTypeBufferMinutesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReservationTypeBufferMinutesStmt: %w", cerr)
+		}
+	}
 	if q.getReservationTypeByNameStmt != nil {
 		if cerr := q.getReservationTypeByNameStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getReservationTypeByNameStmt: %w", cerr)
 		}
 	}
+	if q.getReservationTypeForFacilityStmt != nil {
+		if cerr := q.getReservationTypeForFacilityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReservationTypeForFacilityStmt: %w", cerr)
+		}
+	}
 	if q.getReservationTypeNameByReservationIDStmt != nil {
 		if cerr := q.getReservationTypeNameByReservationIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getReservationTypeNameByReservationIDStmt: %w", cerr)
@@ -1405,6 +2880,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getRestoredMemberStmt: %w", cerr)
 		}
 	}
+	if q.getSeasonPassTypeStmt != nil {
+		if cerr := q.getSeasonPassTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSeasonPassTypeStmt: %w", cerr)
+		}
+	}
 	if q.getStaffByEmailStmt != nil {
 		if cerr := q.getStaffByEmailStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getStaffByEmailStmt: %w", cerr)
@@ -1440,6 +2920,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getTierBookingWindowStmt: %w", cerr)
 		}
 	}
+	if q.getTournamentStmt != nil {
+		if cerr := q.getTournamentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTournamentStmt: %w", cerr)
+		}
+	}
+	if q.getTournamentMatchStmt != nil {
+		if cerr := q.getTournamentMatchStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTournamentMatchStmt: %w", cerr)
+		}
+	}
 	if q.getUpdatedMemberStmt != nil {
 		if cerr := q.getUpdatedMemberStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUpdatedMemberStmt: %w", cerr)
@@ -1460,6 +2950,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUserByPhoneStmt: %w", cerr)
 		}
 	}
+	if q.getUserNotificationPreferencesStmt != nil {
+		if cerr := q.getUserNotificationPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserNotificationPreferencesStmt: %w", cerr)
+		}
+	}
+	if q.getUserPhotoStmt != nil {
+		if cerr := q.getUserPhotoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserPhotoStmt: %w", cerr)
+		}
+	}
+	if q.getUserSessionByTokenStmt != nil {
+		if cerr := q.getUserSessionByTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserSessionByTokenStmt: %w", cerr)
+		}
+	}
 	if q.getVisitPackStmt != nil {
 		if cerr := q.getVisitPackStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getVisitPackStmt: %w", cerr)
@@ -1485,11 +2990,61 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getWaitlistEntryStmt: %w", cerr)
 		}
 	}
+	if q.getWaitlistOfferForUserStmt != nil {
+		if cerr := q.getWaitlistOfferForUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWaitlistOfferForUserStmt: %w", cerr)
+		}
+	}
+	if q.grantMemberGuestPassesStmt != nil {
+		if cerr := q.grantMemberGuestPassesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing grantMemberGuestPassesStmt: %w", cerr)
+		}
+	}
 	if q.isMemberOpenPlayParticipantStmt != nil {
 		if cerr := q.isMemberOpenPlayParticipantStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing isMemberOpenPlayParticipantStmt: %w", cerr)
 		}
 	}
+	if q.isReservationCancelledStmt != nil {
+		if cerr := q.isReservationCancelledStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isReservationCancelledStmt: %w", cerr)
+		}
+	}
+	if q.isReservationUpcomingStmt != nil {
+		if cerr := q.isReservationUpcomingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isReservationUpcomingStmt: %w", cerr)
+		}
+	}
+	if q.isUserOnLeagueTeamStmt != nil {
+		if cerr := q.isUserOnLeagueTeamStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isUserOnLeagueTeamStmt: %w", cerr)
+		}
+	}
+	if q.listAccountCreditRedemptionsStmt != nil {
+		if cerr := q.listAccountCreditRedemptionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAccountCreditRedemptionsStmt: %w", cerr)
+		}
+	}
+	if q.listAccountCreditsStmt != nil {
+		if cerr := q.listAccountCreditsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAccountCreditsStmt: %w", cerr)
+		}
+	}
+	if q.listActiveCourtsStmt != nil {
+		if cerr := q.listActiveCourtsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveCourtsStmt: %w", cerr)
+		}
+	}
+	if q.listActiveFacilityAnnouncementsStmt != nil {
+		if cerr := q.listActiveFacilityAnnouncementsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveFacilityAnnouncementsStmt: %w", cerr)
+		}
+	}
+	if q.listActiveHouseholdDependentsStmt != nil {
+		if cerr := q.listActiveHouseholdDependentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveHouseholdDependentsStmt: %w", cerr)
+		}
+	}
 	if q.listActiveLessonPackagesForUserStmt != nil {
 		if cerr := q.listActiveLessonPackagesForUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listActiveLessonPackagesForUserStmt: %w", cerr)
@@ -1505,6 +3060,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listActiveLessonPackagesForUserByOrganizationStmt: %w", cerr)
 		}
 	}
+	if q.listActiveSeasonPassesForUserByFacilityStmt != nil {
+		if cerr := q.listActiveSeasonPassesForUserByFacilityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveSeasonPassesForUserByFacilityStmt: %w", cerr)
+		}
+	}
+	if q.listActiveUserSessionsStmt != nil {
+		if cerr := q.listActiveUserSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveUserSessionsStmt: %w", cerr)
+		}
+	}
 	if q.listActiveVisitPacksForUserStmt != nil {
 		if cerr := q.listActiveVisitPacksForUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listActiveVisitPacksForUserStmt: %w", cerr)
@@ -1525,11 +3090,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listAvailableCourtsStmt: %w", cerr)
 		}
 	}
+	if q.listBillingSummaryLessonPackagesStmt != nil {
+		if cerr := q.listBillingSummaryLessonPackagesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBillingSummaryLessonPackagesStmt: %w", cerr)
+		}
+	}
+	if q.listBillingSummaryReservationsStmt != nil {
+		if cerr := q.listBillingSummaryReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBillingSummaryReservationsStmt: %w", cerr)
+		}
+	}
+	if q.listBillingSummaryVisitPacksStmt != nil {
+		if cerr := q.listBillingSummaryVisitPacksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBillingSummaryVisitPacksStmt: %w", cerr)
+		}
+	}
 	if q.listCancellationPolicyTiersStmt != nil {
 		if cerr := q.listCancellationPolicyTiersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listCancellationPolicyTiersStmt: %w", cerr)
 		}
 	}
+	if q.listCancellationPolicyTiersForFacilitiesStmt != nil {
+		if cerr := q.listCancellationPolicyTiersForFacilitiesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCancellationPolicyTiersForFacilitiesStmt: %w", cerr)
+		}
+	}
+	if q.listCheckedInReservationIDsStmt != nil {
+		if cerr := q.listCheckedInReservationIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCheckedInReservationIDsStmt: %w", cerr)
+		}
+	}
 	if q.listClinicSessionsByFacilityStmt != nil {
 		if cerr := q.listClinicSessionsByFacilityStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listClinicSessionsByFacilityStmt: %w", cerr)
@@ -1540,6 +3130,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listClinicTypesByFacilityStmt: %w", cerr)
 		}
 	}
+	if q.listCourtBlocksForDayStmt != nil {
+		if cerr := q.listCourtBlocksForDayStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourtBlocksForDayStmt: %w", cerr)
+		}
+	}
+	if q.listCourtBookingsInRangeStmt != nil {
+		if cerr := q.listCourtBookingsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourtBookingsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.listCourtBufferNeighborsStmt != nil {
+		if cerr := q.listCourtBufferNeighborsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourtBufferNeighborsStmt: %w", cerr)
+		}
+	}
+	if q.listCourtOverlapConflictsStmt != nil {
+		if cerr := q.listCourtOverlapConflictsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourtOverlapConflictsStmt: %w", cerr)
+		}
+	}
+	if q.listCourtPricingRulesStmt != nil {
+		if cerr := q.listCourtPricingRulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourtPricingRulesStmt: %w", cerr)
+		}
+	}
 	if q.listCourtsStmt != nil {
 		if cerr := q.listCourtsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listCourtsStmt: %w", cerr)
@@ -1550,6 +3165,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listDistinctFacilitiesWithScheduledSessionsStmt: %w", cerr)
 		}
 	}
+	if q.listDueDeferredEmailsStmt != nil {
+		if cerr := q.listDueDeferredEmailsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueDeferredEmailsStmt: %w", cerr)
+		}
+	}
+	if q.listEmailDeliveryEventsStmt != nil {
+		if cerr := q.listEmailDeliveryEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listEmailDeliveryEventsStmt: %w", cerr)
+		}
+	}
 	if q.listEnrollmentsForClinicStmt != nil {
 		if cerr := q.listEnrollmentsForClinicStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listEnrollmentsForClinicStmt: %w", cerr)
@@ -1565,6 +3190,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listFacilitiesStmt: %w", cerr)
 		}
 	}
+	if q.listFacilitiesByOrganizationStmt != nil {
+		if cerr := q.listFacilitiesByOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFacilitiesByOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.listFacilitiesWithOpenPlayRuleSlotsStmt != nil {
+		if cerr := q.listFacilitiesWithOpenPlayRuleSlotsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFacilitiesWithOpenPlayRuleSlotsStmt: %w", cerr)
+		}
+	}
+	if q.listFacilityAnnouncementsStmt != nil {
+		if cerr := q.listFacilityAnnouncementsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFacilityAnnouncementsStmt: %w", cerr)
+		}
+	}
+	if q.listFacilityArrivalsInRangeStmt != nil {
+		if cerr := q.listFacilityArrivalsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFacilityArrivalsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.listFacilityHoursOverridesStmt != nil {
+		if cerr := q.listFacilityHoursOverridesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFacilityHoursOverridesStmt: %w", cerr)
+		}
+	}
+	if q.listFacilityOpenPlayRuleSlotsStmt != nil {
+		if cerr := q.listFacilityOpenPlayRuleSlotsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFacilityOpenPlayRuleSlotsStmt: %w", cerr)
+		}
+	}
 	if q.listFacilityThemesStmt != nil {
 		if cerr := q.listFacilityThemesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listFacilityThemesStmt: %w", cerr)
@@ -1575,6 +3230,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listFreeAgentsByLeagueStmt: %w", cerr)
 		}
 	}
+	if q.listHeldCourtIDsStmt != nil {
+		if cerr := q.listHeldCourtIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHeldCourtIDsStmt: %w", cerr)
+		}
+	}
+	if q.listHouseholdLinksForUserStmt != nil {
+		if cerr := q.listHouseholdLinksForUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHouseholdLinksForUserStmt: %w", cerr)
+		}
+	}
+	if q.listHouseholdUserIDsStmt != nil {
+		if cerr := q.listHouseholdUserIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHouseholdUserIDsStmt: %w", cerr)
+		}
+	}
+	if q.listJobStatusesStmt != nil {
+		if cerr := q.listJobStatusesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listJobStatusesStmt: %w", cerr)
+		}
+	}
+	if q.listLapsedLeagueMatchResultSubmissionsStmt != nil {
+		if cerr := q.listLapsedLeagueMatchResultSubmissionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listLapsedLeagueMatchResultSubmissionsStmt: %w", cerr)
+		}
+	}
 	if q.listLeagueMatchesStmt != nil {
 		if cerr := q.listLeagueMatchesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listLeagueMatchesStmt: %w", cerr)
@@ -1585,6 +3265,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listLeagueMatchesWithReservationsStmt: %w", cerr)
 		}
 	}
+	if q.listLeagueTeamPaymentsStmt != nil {
+		if cerr := q.listLeagueTeamPaymentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listLeagueTeamPaymentsStmt: %w", cerr)
+		}
+	}
 	if q.listLeagueTeamsStmt != nil {
 		if cerr := q.listLeagueTeamsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listLeagueTeamsStmt: %w", cerr)
@@ -1605,11 +3290,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listLessonPackageTypesStmt: %w", cerr)
 		}
 	}
+	if q.listMaintenanceCourtsInRangeStmt != nil {
+		if cerr := q.listMaintenanceCourtsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMaintenanceCourtsInRangeStmt: %w", cerr)
+		}
+	}
 	if q.listMatchingPendingWaitlistsForCancelledSlotStmt != nil {
 		if cerr := q.listMatchingPendingWaitlistsForCancelledSlotStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listMatchingPendingWaitlistsForCancelledSlotStmt: %w", cerr)
 		}
 	}
+	if q.listMemberLeagueTeamsStmt != nil {
+		if cerr := q.listMemberLeagueTeamsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMemberLeagueTeamsStmt: %w", cerr)
+		}
+	}
+	if q.listMemberNoShowEndTimesStmt != nil {
+		if cerr := q.listMemberNoShowEndTimesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMemberNoShowEndTimesStmt: %w", cerr)
+		}
+	}
+	if q.listMemberPaidVisitsPageStmt != nil {
+		if cerr := q.listMemberPaidVisitsPageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMemberPaidVisitsPageStmt: %w", cerr)
+		}
+	}
+	if q.listMemberRatingHistoryStmt != nil {
+		if cerr := q.listMemberRatingHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMemberRatingHistoryStmt: %w", cerr)
+		}
+	}
 	if q.listMemberUpcomingOpenPlaySessionsStmt != nil {
 		if cerr := q.listMemberUpcomingOpenPlaySessionsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listMemberUpcomingOpenPlaySessionsStmt: %w", cerr)
@@ -1620,21 +3330,71 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listMembersStmt: %w", cerr)
 		}
 	}
+	if q.listMembershipTiersStmt != nil {
+		if cerr := q.listMembershipTiersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMembershipTiersStmt: %w", cerr)
+		}
+	}
+	if q.listNoShowReservationsInRangeStmt != nil {
+		if cerr := q.listNoShowReservationsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listNoShowReservationsInRangeStmt: %w", cerr)
+		}
+	}
 	if q.listOpenPlayAuditLogStmt != nil {
 		if cerr := q.listOpenPlayAuditLogStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listOpenPlayAuditLogStmt: %w", cerr)
 		}
 	}
+	if q.listOpenPlayParticipantSkillRatingsStmt != nil {
+		if cerr := q.listOpenPlayParticipantSkillRatingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOpenPlayParticipantSkillRatingsStmt: %w", cerr)
+		}
+	}
 	if q.listOpenPlayParticipantsStmt != nil {
 		if cerr := q.listOpenPlayParticipantsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listOpenPlayParticipantsStmt: %w", cerr)
 		}
 	}
+	if q.listOpenPlayParticipantsForSessionStmt != nil {
+		if cerr := q.listOpenPlayParticipantsForSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOpenPlayParticipantsForSessionStmt: %w", cerr)
+		}
+	}
+	if q.listOpenPlayQueueStmt != nil {
+		if cerr := q.listOpenPlayQueueStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOpenPlayQueueStmt: %w", cerr)
+		}
+	}
+	if q.listOpenPlayRuleSlotsStmt != nil {
+		if cerr := q.listOpenPlayRuleSlotsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOpenPlayRuleSlotsStmt: %w", cerr)
+		}
+	}
 	if q.listOpenPlayRulesStmt != nil {
 		if cerr := q.listOpenPlayRulesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listOpenPlayRulesStmt: %w", cerr)
 		}
 	}
+	if q.listOpenPlaySessionCapacityStmt != nil {
+		if cerr := q.listOpenPlaySessionCapacityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOpenPlaySessionCapacityStmt: %w", cerr)
+		}
+	}
+	if q.listOpenPlaySessionCapacityByIDsStmt != nil {
+		if cerr := q.listOpenPlaySessionCapacityByIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOpenPlaySessionCapacityByIDsStmt: %w", cerr)
+		}
+	}
+	if q.listOpenPlaySessionFillInRangeStmt != nil {
+		if cerr := q.listOpenPlaySessionFillInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOpenPlaySessionFillInRangeStmt: %w", cerr)
+		}
+	}
+	if q.listOpenPlaySessionReservationMismatchesStmt != nil {
+		if cerr := q.listOpenPlaySessionReservationMismatchesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOpenPlaySessionReservationMismatchesStmt: %w", cerr)
+		}
+	}
 	if q.listOpenPlaySessionsStmt != nil {
 		if cerr := q.listOpenPlaySessionsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listOpenPlaySessionsStmt: %w", cerr)
@@ -1655,6 +3415,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listParticipantsForReservationStmt: %w", cerr)
 		}
 	}
+	if q.listParticipantsForReservationsStmt != nil {
+		if cerr := q.listParticipantsForReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listParticipantsForReservationsStmt: %w", cerr)
+		}
+	}
+	if q.listPendingWaitlistOffersForFacilityStmt != nil {
+		if cerr := q.listPendingWaitlistOffersForFacilityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingWaitlistOffersForFacilityStmt: %w", cerr)
+		}
+	}
+	if q.listPrimeTimeRulesStmt != nil {
+		if cerr := q.listPrimeTimeRulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPrimeTimeRulesStmt: %w", cerr)
+		}
+	}
+	if q.listPrimeTimeRulesForDayStmt != nil {
+		if cerr := q.listPrimeTimeRulesForDayStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPrimeTimeRulesForDayStmt: %w", cerr)
+		}
+	}
+	if q.listProLessonReportReservationsStmt != nil {
+		if cerr := q.listProLessonReportReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listProLessonReportReservationsStmt: %w", cerr)
+		}
+	}
 	if q.listProUnavailabilityByFacilityAndDateRangeStmt != nil {
 		if cerr := q.listProUnavailabilityByFacilityAndDateRangeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listProUnavailabilityByFacilityAndDateRangeStmt: %w", cerr)
@@ -1675,6 +3460,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listRecentVisitsByUserStmt: %w", cerr)
 		}
 	}
+	if q.listReservationCalendarEntriesStmt != nil {
+		if cerr := q.listReservationCalendarEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationCalendarEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listReservationCheckinRosterStmt != nil {
+		if cerr := q.listReservationCheckinRosterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationCheckinRosterStmt: %w", cerr)
+		}
+	}
+	if q.listReservationCommentsStmt != nil {
+		if cerr := q.listReservationCommentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationCommentsStmt: %w", cerr)
+		}
+	}
 	if q.listReservationCourtsStmt != nil {
 		if cerr := q.listReservationCourtsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listReservationCourtsStmt: %w", cerr)
@@ -1685,11 +3485,51 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listReservationCourtsByDateRangeStmt: %w", cerr)
 		}
 	}
+	if q.listReservationDurationOverridesStmt != nil {
+		if cerr := q.listReservationDurationOverridesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationDurationOverridesStmt: %w", cerr)
+		}
+	}
+	if q.listReservationFacilitiesByUserIDStmt != nil {
+		if cerr := q.listReservationFacilitiesByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationFacilitiesByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.listReservationGuestsStmt != nil {
+		if cerr := q.listReservationGuestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationGuestsStmt: %w", cerr)
+		}
+	}
+	if q.listReservationGuestsForReservationsStmt != nil {
+		if cerr := q.listReservationGuestsForReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationGuestsForReservationsStmt: %w", cerr)
+		}
+	}
+	if q.listReservationInvitationsForReservationsStmt != nil {
+		if cerr := q.listReservationInvitationsForReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationInvitationsForReservationsStmt: %w", cerr)
+		}
+	}
+	if q.listReservationMemberNotesForReservationsStmt != nil {
+		if cerr := q.listReservationMemberNotesForReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationMemberNotesForReservationsStmt: %w", cerr)
+		}
+	}
 	if q.listReservationTypesStmt != nil {
 		if cerr := q.listReservationTypesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listReservationTypesStmt: %w", cerr)
 		}
 	}
+	if q.listReservationTypesForFacilityStmt != nil {
+		if cerr := q.listReservationTypesForFacilityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationTypesForFacilityStmt: %w", cerr)
+		}
+	}
+	if q.listReservationTypesForOrganizationStmt != nil {
+		if cerr := q.listReservationTypesForOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationTypesForOrganizationStmt: %w", cerr)
+		}
+	}
 	if q.listReservationsByDateRangeStmt != nil {
 		if cerr := q.listReservationsByDateRangeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listReservationsByDateRangeStmt: %w", cerr)
@@ -1700,11 +3540,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listReservationsByUserIDStmt: %w", cerr)
 		}
 	}
+	if q.listReservationsDueForReminderStmt != nil {
+		if cerr := q.listReservationsDueForReminderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationsDueForReminderStmt: %w", cerr)
+		}
+	}
+	if q.listReservationsForSyncStmt != nil {
+		if cerr := q.listReservationsForSyncStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationsForSyncStmt: %w", cerr)
+		}
+	}
 	if q.listReservationsStartingBetweenStmt != nil {
 		if cerr := q.listReservationsStartingBetweenStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listReservationsStartingBetweenStmt: %w", cerr)
 		}
 	}
+	if q.listSeasonPassTypesStmt != nil {
+		if cerr := q.listSeasonPassTypesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSeasonPassTypesStmt: %w", cerr)
+		}
+	}
+	if q.listSeasonPassUsageForUserStmt != nil {
+		if cerr := q.listSeasonPassUsageForUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSeasonPassUsageForUserStmt: %w", cerr)
+		}
+	}
+	if q.listSeasonPassWindowsStmt != nil {
+		if cerr := q.listSeasonPassWindowsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSeasonPassWindowsStmt: %w", cerr)
+		}
+	}
+	if q.listSelfRegisteredFreeAgentsByLeagueStmt != nil {
+		if cerr := q.listSelfRegisteredFreeAgentsByLeagueStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSelfRegisteredFreeAgentsByLeagueStmt: %w", cerr)
+		}
+	}
+	if q.listSpendableAccountCreditsStmt != nil {
+		if cerr := q.listSpendableAccountCreditsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSpendableAccountCreditsStmt: %w", cerr)
+		}
+	}
 	if q.listStaffStmt != nil {
 		if cerr := q.listStaffStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listStaffStmt: %w", cerr)
@@ -1720,6 +3595,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listStaffByRoleStmt: %w", cerr)
 		}
 	}
+	if q.listStaffInboxNotificationsStmt != nil {
+		if cerr := q.listStaffInboxNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStaffInboxNotificationsStmt: %w", cerr)
+		}
+	}
 	if q.listStaffNotificationsStmt != nil {
 		if cerr := q.listStaffNotificationsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listStaffNotificationsStmt: %w", cerr)
@@ -1735,11 +3615,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listStaffNotificationsForStaffStmt: %w", cerr)
 		}
 	}
+	if q.listSyncChangesStmt != nil {
+		if cerr := q.listSyncChangesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSyncChangesStmt: %w", cerr)
+		}
+	}
 	if q.listSystemThemesStmt != nil {
 		if cerr := q.listSystemThemesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listSystemThemesStmt: %w", cerr)
 		}
 	}
+	if q.listTeamMemberRatingsStmt != nil {
+		if cerr := q.listTeamMemberRatingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTeamMemberRatingsStmt: %w", cerr)
+		}
+	}
 	if q.listTeamMembersStmt != nil {
 		if cerr := q.listTeamMembersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listTeamMembersStmt: %w", cerr)
@@ -1755,6 +3645,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listTodayVisitsByFacilityStmt: %w", cerr)
 		}
 	}
+	if q.listTournamentEntrantsStmt != nil {
+		if cerr := q.listTournamentEntrantsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTournamentEntrantsStmt: %w", cerr)
+		}
+	}
+	if q.listTournamentMatchesStmt != nil {
+		if cerr := q.listTournamentMatchesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTournamentMatchesStmt: %w", cerr)
+		}
+	}
+	if q.listTournamentsByFacilityStmt != nil {
+		if cerr := q.listTournamentsByFacilityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTournamentsByFacilityStmt: %w", cerr)
+		}
+	}
+	if q.listUpcomingCourtReservationsStmt != nil {
+		if cerr := q.listUpcomingCourtReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUpcomingCourtReservationsStmt: %w", cerr)
+		}
+	}
+	if q.listUpcomingOpenEventsStmt != nil {
+		if cerr := q.listUpcomingOpenEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUpcomingOpenEventsStmt: %w", cerr)
+		}
+	}
+	if q.listUpcomingReservationIDsForPrimaryUserStmt != nil {
+		if cerr := q.listUpcomingReservationIDsForPrimaryUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUpcomingReservationIDsForPrimaryUserStmt: %w", cerr)
+		}
+	}
 	if q.listVisitPackTypesStmt != nil {
 		if cerr := q.listVisitPackTypesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listVisitPackTypesStmt: %w", cerr)
@@ -1785,6 +3705,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing logCancellationStmt: %w", cerr)
 		}
 	}
+	if q.markDeferredEmailProcessedStmt != nil {
+		if cerr := q.markDeferredEmailProcessedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markDeferredEmailProcessedStmt: %w", cerr)
+		}
+	}
+	if q.markEmailUndeliverableStmt != nil {
+		if cerr := q.markEmailUndeliverableStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markEmailUndeliverableStmt: %w", cerr)
+		}
+	}
+	if q.markLeagueTeamInvitationAcceptedStmt != nil {
+		if cerr := q.markLeagueTeamInvitationAcceptedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markLeagueTeamInvitationAcceptedStmt: %w", cerr)
+		}
+	}
+	if q.markReservationInvitationAcceptedStmt != nil {
+		if cerr := q.markReservationInvitationAcceptedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markReservationInvitationAcceptedStmt: %w", cerr)
+		}
+	}
+	if q.markReservationInvitationDeclinedStmt != nil {
+		if cerr := q.markReservationInvitationDeclinedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markReservationInvitationDeclinedStmt: %w", cerr)
+		}
+	}
+	if q.markReservationTransferAcceptedStmt != nil {
+		if cerr := q.markReservationTransferAcceptedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markReservationTransferAcceptedStmt: %w", cerr)
+		}
+	}
+	if q.markReservationTransferDeclinedStmt != nil {
+		if cerr := q.markReservationTransferDeclinedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markReservationTransferDeclinedStmt: %w", cerr)
+		}
+	}
 	if q.markStaffNotificationAsReadStmt != nil {
 		if cerr := q.markStaffNotificationAsReadStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing markStaffNotificationAsReadStmt: %w", cerr)
@@ -1795,6 +3750,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing operatingHoursExistsStmt: %w", cerr)
 		}
 	}
+	if q.overrideLeagueMatchResultSubmissionStmt != nil {
+		if cerr := q.overrideLeagueMatchResultSubmissionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing overrideLeagueMatchResultSubmissionStmt: %w", cerr)
+		}
+	}
+	if q.recordJobRunStmt != nil {
+		if cerr := q.recordJobRunStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordJobRunStmt: %w", cerr)
+		}
+	}
+	if q.removeHouseholdLinkStmt != nil {
+		if cerr := q.removeHouseholdLinkStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeHouseholdLinkStmt: %w", cerr)
+		}
+	}
 	if q.removeOpenPlayParticipantStmt != nil {
 		if cerr := q.removeOpenPlayParticipantStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing removeOpenPlayParticipantStmt: %w", cerr)
@@ -1815,6 +3785,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing removeTeamMemberStmt: %w", cerr)
 		}
 	}
+	if q.removeUserFromUpcomingReservationsStmt != nil {
+		if cerr := q.removeUserFromUpcomingReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeUserFromUpcomingReservationsStmt: %w", cerr)
+		}
+	}
+	if q.requestMemberDeletionStmt != nil {
+		if cerr := q.requestMemberDeletionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing requestMemberDeletionStmt: %w", cerr)
+		}
+	}
+	if q.requeueOpenPlayPlayerStmt != nil {
+		if cerr := q.requeueOpenPlayPlayerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing requeueOpenPlayPlayerStmt: %w", cerr)
+		}
+	}
+	if q.reservationsOverlapOnCourtStmt != nil {
+		if cerr := q.reservationsOverlapOnCourtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reservationsOverlapOnCourtStmt: %w", cerr)
+		}
+	}
+	if q.resolveReservationInvitationForUserStmt != nil {
+		if cerr := q.resolveReservationInvitationForUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resolveReservationInvitationForUserStmt: %w", cerr)
+		}
+	}
+	if q.respondToHouseholdLinkStmt != nil {
+		if cerr := q.respondToHouseholdLinkStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing respondToHouseholdLinkStmt: %w", cerr)
+		}
+	}
+	if q.respondToLeagueMatchResultSubmissionStmt != nil {
+		if cerr := q.respondToLeagueMatchResultSubmissionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing respondToLeagueMatchResultSubmissionStmt: %w", cerr)
+		}
+	}
 	if q.restoreLessonPackageLessonStmt != nil {
 		if cerr := q.restoreLessonPackageLessonStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing restoreLessonPackageLessonStmt: %w", cerr)
@@ -1825,11 +3830,91 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing restoreMemberStmt: %w", cerr)
 		}
 	}
+	if q.revokeMemberCardsStmt != nil {
+		if cerr := q.revokeMemberCardsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeMemberCardsStmt: %w", cerr)
+		}
+	}
+	if q.revokeOtherUserSessionsStmt != nil {
+		if cerr := q.revokeOtherUserSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeOtherUserSessionsStmt: %w", cerr)
+		}
+	}
+	if q.revokeReservationAccessStmt != nil {
+		if cerr := q.revokeReservationAccessStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeReservationAccessStmt: %w", cerr)
+		}
+	}
+	if q.revokeUserSessionStmt != nil {
+		if cerr := q.revokeUserSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeUserSessionStmt: %w", cerr)
+		}
+	}
+	if q.revokeUserSessionByTokenStmt != nil {
+		if cerr := q.revokeUserSessionByTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeUserSessionByTokenStmt: %w", cerr)
+		}
+	}
+	if q.scheduleTournamentMatchStmt != nil {
+		if cerr := q.scheduleTournamentMatchStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing scheduleTournamentMatchStmt: %w", cerr)
+		}
+	}
+	if q.searchFacilityMembersStmt != nil {
+		if cerr := q.searchFacilityMembersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchFacilityMembersStmt: %w", cerr)
+		}
+	}
 	if q.searchMembersStmt != nil {
 		if cerr := q.searchMembersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing searchMembersStmt: %w", cerr)
 		}
 	}
+	if q.searchReservationsStmt != nil {
+		if cerr := q.searchReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchReservationsStmt: %w", cerr)
+		}
+	}
+	if q.seasonPassCoverageReportStmt != nil {
+		if cerr := q.seasonPassCoverageReportStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing seasonPassCoverageReportStmt: %w", cerr)
+		}
+	}
+	if q.setStaffInboxNotificationReadStmt != nil {
+		if cerr := q.setStaffInboxNotificationReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setStaffInboxNotificationReadStmt: %w", cerr)
+		}
+	}
+	if q.setTournamentMatchTeamAStmt != nil {
+		if cerr := q.setTournamentMatchTeamAStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setTournamentMatchTeamAStmt: %w", cerr)
+		}
+	}
+	if q.setTournamentMatchTeamBStmt != nil {
+		if cerr := q.setTournamentMatchTeamBStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setTournamentMatchTeamBStmt: %w", cerr)
+		}
+	}
+	if q.setTournamentMatchWinnerStmt != nil {
+		if cerr := q.setTournamentMatchWinnerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setTournamentMatchWinnerStmt: %w", cerr)
+		}
+	}
+	if q.stageWaitlistPositionsStmt != nil {
+		if cerr := q.stageWaitlistPositionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing stageWaitlistPositionsStmt: %w", cerr)
+		}
+	}
+	if q.touchUserSessionStmt != nil {
+		if cerr := q.touchUserSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing touchUserSessionStmt: %w", cerr)
+		}
+	}
+	if q.transferActiveVisitPacksStmt != nil {
+		if cerr := q.transferActiveVisitPacksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing transferActiveVisitPacksStmt: %w", cerr)
+		}
+	}
 	if q.updateBillingInfoStmt != nil {
 		if cerr := q.updateBillingInfoStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateBillingInfoStmt: %w", cerr)
@@ -1850,6 +3935,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateClinicTypeStmt: %w", cerr)
 		}
 	}
+	if q.updateCourtPricingRuleStmt != nil {
+		if cerr := q.updateCourtPricingRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCourtPricingRuleStmt: %w", cerr)
+		}
+	}
 	if q.updateCourtStatusStmt != nil {
 		if cerr := q.updateCourtStatusStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateCourtStatusStmt: %w", cerr)
@@ -1860,6 +3950,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateEnrollmentStatusStmt: %w", cerr)
 		}
 	}
+	if q.updateFacilityAnnouncementStmt != nil {
+		if cerr := q.updateFacilityAnnouncementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFacilityAnnouncementStmt: %w", cerr)
+		}
+	}
 	if q.updateFacilityBookingConfigStmt != nil {
 		if cerr := q.updateFacilityBookingConfigStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateFacilityBookingConfigStmt: %w", cerr)
@@ -1880,11 +3975,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateLeagueStmt: %w", cerr)
 		}
 	}
+	if q.updateLeagueMatchStatusStmt != nil {
+		if cerr := q.updateLeagueMatchStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateLeagueMatchStatusStmt: %w", cerr)
+		}
+	}
 	if q.updateLeagueTeamStmt != nil {
 		if cerr := q.updateLeagueTeamStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateLeagueTeamStmt: %w", cerr)
 		}
 	}
+	if q.updateLeagueTeamDivisionStmt != nil {
+		if cerr := q.updateLeagueTeamDivisionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateLeagueTeamDivisionStmt: %w", cerr)
+		}
+	}
 	if q.updateLessonPackageTypeStmt != nil {
 		if cerr := q.updateLessonPackageTypeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateLessonPackageTypeStmt: %w", cerr)
@@ -1905,6 +4010,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateMemberEmailStmt: %w", cerr)
 		}
 	}
+	if q.updateMembershipTierStmt != nil {
+		if cerr := q.updateMembershipTierStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateMembershipTierStmt: %w", cerr)
+		}
+	}
 	if q.updateOpenPlayRuleStmt != nil {
 		if cerr := q.updateOpenPlayRuleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateOpenPlayRuleStmt: %w", cerr)
@@ -1925,11 +4035,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateOrganizationEmailConfigStmt: %w", cerr)
 		}
 	}
+	if q.updateOrganizationSettingsStmt != nil {
+		if cerr := q.updateOrganizationSettingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateOrganizationSettingsStmt: %w", cerr)
+		}
+	}
+	if q.updatePrimeTimeRuleStmt != nil {
+		if cerr := q.updatePrimeTimeRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updatePrimeTimeRuleStmt: %w", cerr)
+		}
+	}
 	if q.updateReservationStmt != nil {
 		if cerr := q.updateReservationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateReservationStmt: %w", cerr)
 		}
 	}
+	if q.updateReservationPrimaryUserStmt != nil {
+		if cerr := q.updateReservationPrimaryUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateReservationPrimaryUserStmt: %w", cerr)
+		}
+	}
+	if q.updateReservationTypeStmt != nil {
+		if cerr := q.updateReservationTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateReservationTypeStmt: %w", cerr)
+		}
+	}
+	if q.updateSeasonPassTypeStmt != nil {
+		if cerr := q.updateSeasonPassTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateSeasonPassTypeStmt: %w", cerr)
+		}
+	}
 	if q.updateSessionAutoScaleOverrideStmt != nil {
 		if cerr := q.updateSessionAutoScaleOverrideStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateSessionAutoScaleOverrideStmt: %w", cerr)
@@ -1955,11 +4090,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateThemeStmt: %w", cerr)
 		}
 	}
+	if q.updateTournamentMatchStatusStmt != nil {
+		if cerr := q.updateTournamentMatchStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateTournamentMatchStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateTournamentStatusStmt != nil {
+		if cerr := q.updateTournamentStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateTournamentStatusStmt: %w", cerr)
+		}
+	}
 	if q.updateUserCognitoStatusStmt != nil {
 		if cerr := q.updateUserCognitoStatusStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserCognitoStatusStmt: %w", cerr)
 		}
 	}
+	if q.updateUserHideFromRostersStmt != nil {
+		if cerr := q.updateUserHideFromRostersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserHideFromRostersStmt: %w", cerr)
+		}
+	}
+	if q.updateUserHomeFacilityStmt != nil {
+		if cerr := q.updateUserHomeFacilityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserHomeFacilityStmt: %w", cerr)
+		}
+	}
+	if q.updateUserNotificationPreferencesStmt != nil {
+		if cerr := q.updateUserNotificationPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserNotificationPreferencesStmt: %w", cerr)
+		}
+	}
 	if q.updateUserPasswordHashStmt != nil {
 		if cerr := q.updateUserPasswordHashStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserPasswordHashStmt: %w", cerr)
@@ -1975,6 +4135,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateVisitPackTypeStmt: %w", cerr)
 		}
 	}
+	if q.updateWaitlistAutoBookStmt != nil {
+		if cerr := q.updateWaitlistAutoBookStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateWaitlistAutoBookStmt: %w", cerr)
+		}
+	}
 	if q.updateWaitlistStatusStmt != nil {
 		if cerr := q.updateWaitlistStatusStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateWaitlistStatusStmt: %w", cerr)
@@ -1985,6 +4150,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing upsertActiveThemeIDStmt: %w", cerr)
 		}
 	}
+	if q.upsertFacilityHoursOverrideStmt != nil {
+		if cerr := q.upsertFacilityHoursOverrideStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertFacilityHoursOverrideStmt: %w", cerr)
+		}
+	}
+	if q.upsertFacilityNoShowPolicyStmt != nil {
+		if cerr := q.upsertFacilityNoShowPolicyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertFacilityNoShowPolicyStmt: %w", cerr)
+		}
+	}
+	if q.upsertFacilityQuietHoursStmt != nil {
+		if cerr := q.upsertFacilityQuietHoursStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertFacilityQuietHoursStmt: %w", cerr)
+		}
+	}
+	if q.upsertMemberSkillRatingStmt != nil {
+		if cerr := q.upsertMemberSkillRatingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertMemberSkillRatingStmt: %w", cerr)
+		}
+	}
+	if q.upsertOpenPlaySessionSkillBandsStmt != nil {
+		if cerr := q.upsertOpenPlaySessionSkillBandsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertOpenPlaySessionSkillBandsStmt: %w", cerr)
+		}
+	}
 	if q.upsertOperatingHoursStmt != nil {
 		if cerr := q.upsertOperatingHoursStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertOperatingHoursStmt: %w", cerr)
@@ -1995,6 +4185,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing upsertPhotoStmt: %w", cerr)
 		}
 	}
+	if q.upsertReservationClosureDetailsStmt != nil {
+		if cerr := q.upsertReservationClosureDetailsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertReservationClosureDetailsStmt: %w", cerr)
+		}
+	}
 	if q.upsertTierBookingWindowStmt != nil {
 		if cerr := q.upsertTierBookingWindowStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertTierBookingWindowStmt: %w", cerr)
@@ -2005,6 +4200,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing upsertWaitlistConfigStmt: %w", cerr)
 		}
 	}
+	if q.verifyMemberRatingEntryStmt != nil {
+		if cerr := q.verifyMemberRatingEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing verifyMemberRatingEntryStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -2045,80 +4245,178 @@ type Queries struct {
 	db                                                DBTX
 	tx                                                *sql.Tx
 	acceptOfferStmt                                   *sql.Stmt
+	addOpenPlayGamePlayerStmt                         *sql.Stmt
 	addOpenPlayParticipantStmt                        *sql.Stmt
 	addParticipantStmt                                *sql.Stmt
 	addReservationCourtStmt                           *sql.Stmt
+	addReservationGuestStmt                           *sql.Stmt
 	addTeamMemberStmt                                 *sql.Stmt
+	addTournamentEntrantStmt                          *sql.Stmt
 	advanceWaitlistOfferStmt                          *sql.Stmt
+	anonymizeDeletedMembersStmt                       *sql.Stmt
+	applyStagedWaitlistPositionsStmt                  *sql.Stmt
 	assignFreeAgentToTeamStmt                         *sql.Stmt
+	cancelPendingReservationInvitationsStmt           *sql.Stmt
+	cancelPendingReservationTransfersStmt             *sql.Stmt
+	cancelUpcomingReservationInvitationsForUserStmt   *sql.Stmt
+	claimReservationReminderStmt                      *sql.Stmt
+	clearUserEmailUndeliverableStmt                   *sql.Stmt
+	consumeAccountCreditStmt                          *sql.Stmt
+	consumeMemberGuestPassStmt                        *sql.Stmt
+	countActiveCourtsStmt                             *sql.Stmt
+	countActiveHouseholdDependentsStmt                *sql.Stmt
+	countActiveHouseholdReservationsStmt              *sql.Stmt
 	countActiveMemberReservationsStmt                 *sql.Stmt
+	countBusyCourtsByHourStmt                         *sql.Stmt
 	countCheckinsByFacilityInRangeStmt                *sql.Stmt
 	countFacilityThemeNameStmt                        *sql.Stmt
 	countFacilityThemeNameExcludingIDStmt             *sql.Stmt
 	countFacilityThemesStmt                           *sql.Stmt
 	countLessonPackageTypesByFacilityStmt             *sql.Stmt
 	countOpenPlayReservationsForSessionStmt           *sql.Stmt
+	countOpenPlaySessionsForSlotStmt                  *sql.Stmt
+	countPendingReservationInvitationsStmt            *sql.Stmt
+	countProSessionsByProInRangeStmt                  *sql.Stmt
 	countReservationParticipantsStmt                  *sql.Stmt
+	countReservationTypeNamesInRangeStmt              *sql.Stmt
+	countReservationTypeUsageStmt                     *sql.Stmt
 	countReservationsByTypeInRangeStmt                *sql.Stmt
 	countScheduledVsCompletedReservationsStmt         *sql.Stmt
 	countThemeUsageStmt                               *sql.Stmt
+	countTournamentMatchesStmt                        *sql.Stmt
+	countUnreadStaffInboxNotificationsStmt            *sql.Stmt
 	countUnreadStaffNotificationsStmt                 *sql.Stmt
 	countVisitPackTypesByFacilityStmt                 *sql.Stmt
+	createAccountCreditStmt                           *sql.Stmt
+	createAccountCreditRedemptionStmt                 *sql.Stmt
 	createCancellationPolicyTierStmt                  *sql.Stmt
 	createClinicEnrollmentStmt                        *sql.Stmt
 	createClinicSessionStmt                           *sql.Stmt
 	createClinicTypeStmt                              *sql.Stmt
 	createCourtStmt                                   *sql.Stmt
+	createCourtPricingRuleStmt                        *sql.Stmt
+	createCourtSlotHoldStmt                           *sql.Stmt
+	createDeferredEmailStmt                           *sql.Stmt
+	createEmailDeliveryEventStmt                      *sql.Stmt
+	createFacilityAnnouncementStmt                    *sql.Stmt
 	createFacilityVisitStmt                           *sql.Stmt
+	createHouseholdLinkStmt                           *sql.Stmt
+	createImpersonationAuditEntryStmt                 *sql.Stmt
 	createLeagueStmt                                  *sql.Stmt
+	createLeagueFreeAgentRegistrationStmt             *sql.Stmt
 	createLeagueMatchStmt                             *sql.Stmt
+	createLeagueMatchResultSubmissionStmt             *sql.Stmt
+	createLeaguePaymentStmt                           *sql.Stmt
 	createLeagueTeamStmt                              *sql.Stmt
+	createLeagueTeamInvitationStmt                    *sql.Stmt
 	createLessonCancelledNotificationStmt             *sql.Stmt
 	createLessonPackageStmt                           *sql.Stmt
 	createLessonPackageRedemptionStmt                 *sql.Stmt
 	createLessonPackageTypeStmt                       *sql.Stmt
+	createLessonReassignedNotificationStmt            *sql.Stmt
 	createMemberStmt                                  *sql.Stmt
+	createMemberCardStmt                              *sql.Stmt
+	createMemberHomeFacilityChangeStmt                *sql.Stmt
+	createMemberRatingEntryStmt                       *sql.Stmt
+	createMembershipTierStmt                          *sql.Stmt
+	createNoShowRestrictionClearStmt                  *sql.Stmt
 	createOpenPlayAuditLogStmt                        *sql.Stmt
+	createOpenPlayGameStmt                            *sql.Stmt
+	createOpenPlayQueueEntryStmt                      *sql.Stmt
 	createOpenPlayRuleStmt                            *sql.Stmt
+	createOpenPlayRuleSlotStmt                        *sql.Stmt
 	createOpenPlaySessionStmt                         *sql.Stmt
 	createPhotoStmt                                   *sql.Stmt
+	createPrimeTimeRuleStmt                           *sql.Stmt
 	createProUnavailabilityStmt                       *sql.Stmt
 	createReservationStmt                             *sql.Stmt
+	createReservationCheckinStmt                      *sql.Stmt
+	createReservationCommentStmt                      *sql.Stmt
+	createReservationDurationOverrideStmt             *sql.Stmt
+	createReservationIdempotencyKeyStmt               *sql.Stmt
+	createReservationInvitationStmt                   *sql.Stmt
+	createReservationMemberNoteStmt                   *sql.Stmt
+	createReservationPriceStmt                        *sql.Stmt
+	createReservationTransferStmt                     *sql.Stmt
+	createReservationTypeStmt                         *sql.Stmt
+	createSeasonPassStmt                              *sql.Stmt
+	createSeasonPassReservationStmt                   *sql.Stmt
+	createSeasonPassTypeStmt                          *sql.Stmt
+	createSeasonPassWindowStmt                        *sql.Stmt
 	createStaffStmt                                   *sql.Stmt
 	createStaffNotificationStmt                       *sql.Stmt
 	createStaffUserStmt                               *sql.Stmt
 	createThemeStmt                                   *sql.Stmt
+	createTournamentStmt                              *sql.Stmt
+	createTournamentMatchStmt                         *sql.Stmt
+	createUserSessionStmt                             *sql.Stmt
 	createVisitPackStmt                               *sql.Stmt
+	createVisitPackPaymentStmt                        *sql.Stmt
 	createVisitPackRedemptionStmt                     *sql.Stmt
 	createVisitPackTypeStmt                           *sql.Stmt
 	createWaitlistEntryStmt                           *sql.Stmt
+	createWaitlistFulfilledNotificationStmt           *sql.Stmt
 	createWaitlistOfferStmt                           *sql.Stmt
 	deactivateLessonPackageTypeStmt                   *sql.Stmt
+	deactivateSeasonPassTypeStmt                      *sql.Stmt
 	deactivateVisitPackTypeStmt                       *sql.Stmt
+	declineOfferStmt                                  *sql.Stmt
 	decrementLessonPackageLessonStmt                  *sql.Stmt
 	decrementVisitPackVisitStmt                       *sql.Stmt
+	deleteActiveWaitlistEntriesForUserStmt            *sql.Stmt
+	deleteAnonymizableMemberBillingStmt               *sql.Stmt
+	deleteAnonymizableMemberPhotosStmt                *sql.Stmt
+	deleteCancellationPolicyStmt                      *sql.Stmt
 	deleteCancellationPolicyTierStmt                  *sql.Stmt
 	deleteClinicEnrollmentStmt                        *sql.Stmt
 	deleteClinicSessionStmt                           *sql.Stmt
 	deleteClinicTypeStmt                              *sql.Stmt
+	deleteCourtPricingRuleStmt                        *sql.Stmt
+	deleteCourtSlotHoldByUserStmt                     *sql.Stmt
+	deleteExpiredCourtSlotHoldsStmt                   *sql.Stmt
+	deleteExpiredReservationIdempotencyKeyStmt        *sql.Stmt
+	deleteExpiredReservationIdempotencyKeysStmt       *sql.Stmt
+	deleteExpiredUserSessionsStmt                     *sql.Stmt
+	deleteFacilityAnnouncementStmt                    *sql.Stmt
+	deleteFacilityHoursOverrideStmt                   *sql.Stmt
+	deleteFacilityNoShowPolicyStmt                    *sql.Stmt
+	deleteFacilityQuietHoursStmt                      *sql.Stmt
 	deleteLeagueStmt                                  *sql.Stmt
+	deleteLeagueFreeAgentRegistrationStmt             *sql.Stmt
 	deleteLeagueMatchesByLeagueIDStmt                 *sql.Stmt
 	deleteLessonPackageRedemptionsByReservationIDStmt *sql.Stmt
 	deleteMemberStmt                                  *sql.Stmt
+	deleteMemberSkillRatingStmt                       *sql.Stmt
+	deleteMembershipTierStmt                          *sql.Stmt
+	deleteOpenPlayQueueEntryStmt                      *sql.Stmt
 	deleteOpenPlayRuleStmt                            *sql.Stmt
+	deleteOpenPlayRuleSlotStmt                        *sql.Stmt
+	deleteOpenPlaySessionSkillBandsStmt               *sql.Stmt
 	deleteOperatingHoursStmt                          *sql.Stmt
 	deletePastWaitlistEntriesStmt                     *sql.Stmt
 	deletePhotoStmt                                   *sql.Stmt
+	deletePrimeTimeRuleStmt                           *sql.Stmt
 	deleteProUnavailabilityStmt                       *sql.Stmt
 	deleteReservationStmt                             *sql.Stmt
+	deleteReservationClosureDetailsStmt               *sql.Stmt
 	deleteReservationCourtsByReservationIDStmt        *sql.Stmt
 	deleteReservationParticipantsByReservationIDStmt  *sql.Stmt
+	deleteReservationReminderStmt                     *sql.Stmt
+	deleteReservationTypeStmt                         *sql.Stmt
+	deleteSeasonPassWindowsStmt                       *sql.Stmt
 	deleteStaffStmt                                   *sql.Stmt
+	deleteStaffNotificationsBeforeStmt                *sql.Stmt
 	deleteThemeStmt                                   *sql.Stmt
 	deleteTierBookingWindowStmt                       *sql.Stmt
 	deleteWaitlistEntryStmt                           *sql.Stmt
+	dismissFacilityAnnouncementStmt                   *sql.Stmt
 	expireOfferStmt                                   *sql.Stmt
+	expireSiblingWaitlistOffersStmt                   *sql.Stmt
 	facilityExistsStmt                                *sql.Stmt
+	findMatchingReservationStmt                       *sql.Stmt
+	getActiveHouseholdLinkByDependentStmt             *sql.Stmt
+	getActiveMemberCardStmt                           *sql.Stmt
+	getActiveMemberCardByTokenStmt                    *sql.Stmt
 	getActiveThemeIDStmt                              *sql.Stmt
 	getApplicableCancellationTierStmt                 *sql.Stmt
 	getAvailableCourtHoursStmt                        *sql.Stmt
@@ -2133,16 +4431,28 @@ type Queries struct {
 	getCreatedMemberStmt                              *sql.Stmt
 	getEligibleLessonPackageForUserStmt               *sql.Stmt
 	getEnrollmentCountStmt                            *sql.Stmt
+	getFacilityAnnouncementStmt                       *sql.Stmt
 	getFacilityByIDStmt                               *sql.Stmt
 	getFacilityEmailConfigStmt                        *sql.Stmt
 	getFacilityHoursStmt                              *sql.Stmt
+	getFacilityHoursOverrideStmt                      *sql.Stmt
+	getFacilityNoShowPolicyStmt                       *sql.Stmt
+	getFacilityQuietHoursStmt                         *sql.Stmt
 	getFacilityTierBookingEnabledStmt                 *sql.Stmt
+	getFirstLeagueMatchTimeStmt                       *sql.Stmt
 	getFutureProSessionsByStaffIDStmt                 *sql.Stmt
+	getHouseholdLinkStmt                              *sql.Stmt
+	getHouseholdLinkByTokenStmt                       *sql.Stmt
 	getLatestCancellationByReservationIDStmt          *sql.Stmt
+	getLatestNoShowRestrictionClearStmt               *sql.Stmt
+	getLatestSyncSeqStmt                              *sql.Stmt
 	getLeagueStmt                                     *sql.Stmt
+	getLeagueFreeAgentRegistrationStmt                *sql.Stmt
 	getLeagueMatchStmt                                *sql.Stmt
+	getLeagueMatchResultSubmissionStmt                *sql.Stmt
 	getLeagueStandingsDataStmt                        *sql.Stmt
 	getLeagueTeamStmt                                 *sql.Stmt
+	getLeagueTeamInvitationByTokenStmt                *sql.Stmt
 	getLeagueWithFacilityTimezoneStmt                 *sql.Stmt
 	getLessonPackageStmt                              *sql.Stmt
 	getLessonPackageRedemptionInfoStmt                *sql.Stmt
@@ -2151,26 +4461,47 @@ type Queries struct {
 	getMemberByEmailStmt                              *sql.Stmt
 	getMemberByEmailIncludeDeletedStmt                *sql.Stmt
 	getMemberByIDStmt                                 *sql.Stmt
+	getMemberGuestPassBalanceStmt                     *sql.Stmt
+	getMemberOverlappingReservationStmt               *sql.Stmt
 	getMemberPhotoStmt                                *sql.Stmt
+	getMemberRatingEntryStmt                          *sql.Stmt
+	getMemberSkillRatingStmt                          *sql.Stmt
 	getMemberTodayActivitiesStmt                      *sql.Stmt
+	getMembershipTierStmt                             *sql.Stmt
+	getMembershipTierForLevelStmt                     *sql.Stmt
+	getOpenEventStmt                                  *sql.Stmt
 	getOpenPlayReservationIDStmt                      *sql.Stmt
 	getOpenPlayRuleStmt                               *sql.Stmt
 	getOpenPlaySessionStmt                            *sql.Stmt
+	getOpenPlaySessionSkillBandsStmt                  *sql.Stmt
 	getOrganizationByIDStmt                           *sql.Stmt
 	getOrganizationBySlugStmt                         *sql.Stmt
 	getOrganizationCrossFacilitySettingStmt           *sql.Stmt
 	getOrganizationEmailConfigStmt                    *sql.Stmt
 	getOrganizationReminderConfigStmt                 *sql.Stmt
+	getOrganizationSettingsStmt                       *sql.Stmt
 	getPendingOfferStmt                               *sql.Stmt
 	getPhotoStmt                                      *sql.Stmt
+	getPreferredMemberRatingStmt                      *sql.Stmt
 	getProLessonSlotsStmt                             *sql.Stmt
 	getProUnavailabilityByIDStmt                      *sql.Stmt
 	getReservationStmt                                *sql.Stmt
+	getReservationAccessRevocationStmt                *sql.Stmt
 	getReservationByIDStmt                            *sql.Stmt
+	getReservationClosureDetailsStmt                  *sql.Stmt
+	getReservationCreditAppliedCentsStmt              *sql.Stmt
+	getReservationIdempotencyKeyStmt                  *sql.Stmt
+	getReservationInvitationByTokenStmt               *sql.Stmt
+	getReservationMemberNoteStmt                      *sql.Stmt
+	getReservationPriceStmt                           *sql.Stmt
+	getReservationTransferByTokenStmt                 *sql.Stmt
 	getReservationTypeStmt                            *sql.Stmt
+	getReservationTypeBufferMinutesStmt               *sql.Stmt
 	getReservationTypeByNameStmt                      *sql.Stmt
+	getReservationTypeForFacilityStmt                 *sql.Stmt
 	getReservationTypeNameByReservationIDStmt         *sql.Stmt
 	getRestoredMemberStmt                             *sql.Stmt
+	getSeasonPassTypeStmt                             *sql.Stmt
 	getStaffByEmailStmt                               *sql.Stmt
 	getStaffByIDStmt                                  *sql.Stmt
 	getStaffByPhoneStmt                               *sql.Stmt
@@ -2178,200 +4509,442 @@ type Queries struct {
 	getStaffNotificationByIDStmt                      *sql.Stmt
 	getThemeStmt                                      *sql.Stmt
 	getTierBookingWindowStmt                          *sql.Stmt
+	getTournamentStmt                                 *sql.Stmt
+	getTournamentMatchStmt                            *sql.Stmt
 	getUpdatedMemberStmt                              *sql.Stmt
 	getUserByEmailStmt                                *sql.Stmt
 	getUserByIDStmt                                   *sql.Stmt
 	getUserByPhoneStmt                                *sql.Stmt
+	getUserNotificationPreferencesStmt                *sql.Stmt
+	getUserPhotoStmt                                  *sql.Stmt
+	getUserSessionByTokenStmt                         *sql.Stmt
 	getVisitPackStmt                                  *sql.Stmt
 	getVisitPackRedemptionInfoStmt                    *sql.Stmt
 	getVisitPackTypeStmt                              *sql.Stmt
 	getWaitlistConfigStmt                             *sql.Stmt
 	getWaitlistEntryStmt                              *sql.Stmt
+	getWaitlistOfferForUserStmt                       *sql.Stmt
+	grantMemberGuestPassesStmt                        *sql.Stmt
 	isMemberOpenPlayParticipantStmt                   *sql.Stmt
+	isReservationCancelledStmt                        *sql.Stmt
+	isReservationUpcomingStmt                         *sql.Stmt
+	isUserOnLeagueTeamStmt                            *sql.Stmt
+	listAccountCreditRedemptionsStmt                  *sql.Stmt
+	listAccountCreditsStmt                            *sql.Stmt
+	listActiveCourtsStmt                              *sql.Stmt
+	listActiveFacilityAnnouncementsStmt               *sql.Stmt
+	listActiveHouseholdDependentsStmt                 *sql.Stmt
 	listActiveLessonPackagesForUserStmt               *sql.Stmt
 	listActiveLessonPackagesForUserByFacilityStmt     *sql.Stmt
 	listActiveLessonPackagesForUserByOrganizationStmt *sql.Stmt
+	listActiveSeasonPassesForUserByFacilityStmt       *sql.Stmt
+	listActiveUserSessionsStmt                        *sql.Stmt
 	listActiveVisitPacksForUserStmt                   *sql.Stmt
 	listActiveVisitPacksForUserByFacilityStmt         *sql.Stmt
 	listActiveVisitPacksForUserByOrganizationStmt     *sql.Stmt
 	listAvailableCourtsStmt                           *sql.Stmt
+	listBillingSummaryLessonPackagesStmt              *sql.Stmt
+	listBillingSummaryReservationsStmt                *sql.Stmt
+	listBillingSummaryVisitPacksStmt                  *sql.Stmt
 	listCancellationPolicyTiersStmt                   *sql.Stmt
+	listCancellationPolicyTiersForFacilitiesStmt      *sql.Stmt
+	listCheckedInReservationIDsStmt                   *sql.Stmt
 	listClinicSessionsByFacilityStmt                  *sql.Stmt
 	listClinicTypesByFacilityStmt                     *sql.Stmt
+	listCourtBlocksForDayStmt                         *sql.Stmt
+	listCourtBookingsInRangeStmt                      *sql.Stmt
+	listCourtBufferNeighborsStmt                      *sql.Stmt
+	listCourtOverlapConflictsStmt                     *sql.Stmt
+	listCourtPricingRulesStmt                         *sql.Stmt
 	listCourtsStmt                                    *sql.Stmt
 	listDistinctFacilitiesWithScheduledSessionsStmt   *sql.Stmt
+	listDueDeferredEmailsStmt                         *sql.Stmt
+	listEmailDeliveryEventsStmt                       *sql.Stmt
 	listEnrollmentsForClinicStmt                      *sql.Stmt
 	listExpiredOffersStmt                             *sql.Stmt
 	listFacilitiesStmt                                *sql.Stmt
+	listFacilitiesByOrganizationStmt                  *sql.Stmt
+	listFacilitiesWithOpenPlayRuleSlotsStmt           *sql.Stmt
+	listFacilityAnnouncementsStmt                     *sql.Stmt
+	listFacilityArrivalsInRangeStmt                   *sql.Stmt
+	listFacilityHoursOverridesStmt                    *sql.Stmt
+	listFacilityOpenPlayRuleSlotsStmt                 *sql.Stmt
 	listFacilityThemesStmt                            *sql.Stmt
 	listFreeAgentsByLeagueStmt                        *sql.Stmt
+	listHeldCourtIDsStmt                              *sql.Stmt
+	listHouseholdLinksForUserStmt                     *sql.Stmt
+	listHouseholdUserIDsStmt                          *sql.Stmt
+	listJobStatusesStmt                               *sql.Stmt
+	listLapsedLeagueMatchResultSubmissionsStmt        *sql.Stmt
 	listLeagueMatchesStmt                             *sql.Stmt
 	listLeagueMatchesWithReservationsStmt             *sql.Stmt
+	listLeagueTeamPaymentsStmt                        *sql.Stmt
 	listLeagueTeamsStmt                               *sql.Stmt
 	listLeaguesByFacilityStmt                         *sql.Stmt
 	listLessonPackageRedemptionsByReservationIDStmt   *sql.Stmt
 	listLessonPackageTypesStmt                        *sql.Stmt
+	listMaintenanceCourtsInRangeStmt                  *sql.Stmt
 	listMatchingPendingWaitlistsForCancelledSlotStmt  *sql.Stmt
+	listMemberLeagueTeamsStmt                         *sql.Stmt
+	listMemberNoShowEndTimesStmt                      *sql.Stmt
+	listMemberPaidVisitsPageStmt                      *sql.Stmt
+	listMemberRatingHistoryStmt                       *sql.Stmt
 	listMemberUpcomingOpenPlaySessionsStmt            *sql.Stmt
 	listMembersStmt                                   *sql.Stmt
+	listMembershipTiersStmt                           *sql.Stmt
+	listNoShowReservationsInRangeStmt                 *sql.Stmt
 	listOpenPlayAuditLogStmt                          *sql.Stmt
+	listOpenPlayParticipantSkillRatingsStmt           *sql.Stmt
 	listOpenPlayParticipantsStmt                      *sql.Stmt
+	listOpenPlayParticipantsForSessionStmt            *sql.Stmt
+	listOpenPlayQueueStmt                             *sql.Stmt
+	listOpenPlayRuleSlotsStmt                         *sql.Stmt
 	listOpenPlayRulesStmt                             *sql.Stmt
+	listOpenPlaySessionCapacityStmt                   *sql.Stmt
+	listOpenPlaySessionCapacityByIDsStmt              *sql.Stmt
+	listOpenPlaySessionFillInRangeStmt                *sql.Stmt
+	listOpenPlaySessionReservationMismatchesStmt      *sql.Stmt
 	listOpenPlaySessionsStmt                          *sql.Stmt
 	listOpenPlaySessionsApproachingCutoffStmt         *sql.Stmt
 	listOrganizationsStmt                             *sql.Stmt
 	listParticipantsForReservationStmt                *sql.Stmt
+	listParticipantsForReservationsStmt               *sql.Stmt
+	listPendingWaitlistOffersForFacilityStmt          *sql.Stmt
+	listPrimeTimeRulesStmt                            *sql.Stmt
+	listPrimeTimeRulesForDayStmt                      *sql.Stmt
+	listProLessonReportReservationsStmt               *sql.Stmt
 	listProUnavailabilityByFacilityAndDateRangeStmt   *sql.Stmt
 	listProUnavailabilityByProIDStmt                  *sql.Stmt
 	listProsByFacilityStmt                            *sql.Stmt
 	listRecentVisitsByUserStmt                        *sql.Stmt
+	listReservationCalendarEntriesStmt                *sql.Stmt
+	listReservationCheckinRosterStmt                  *sql.Stmt
+	listReservationCommentsStmt                       *sql.Stmt
 	listReservationCourtsStmt                         *sql.Stmt
 	listReservationCourtsByDateRangeStmt              *sql.Stmt
+	listReservationDurationOverridesStmt              *sql.Stmt
+	listReservationFacilitiesByUserIDStmt             *sql.Stmt
+	listReservationGuestsStmt                         *sql.Stmt
+	listReservationGuestsForReservationsStmt          *sql.Stmt
+	listReservationInvitationsForReservationsStmt     *sql.Stmt
+	listReservationMemberNotesForReservationsStmt     *sql.Stmt
 	listReservationTypesStmt                          *sql.Stmt
+	listReservationTypesForFacilityStmt               *sql.Stmt
+	listReservationTypesForOrganizationStmt           *sql.Stmt
 	listReservationsByDateRangeStmt                   *sql.Stmt
 	listReservationsByUserIDStmt                      *sql.Stmt
+	listReservationsDueForReminderStmt                *sql.Stmt
+	listReservationsForSyncStmt                       *sql.Stmt
 	listReservationsStartingBetweenStmt               *sql.Stmt
+	listSeasonPassTypesStmt                           *sql.Stmt
+	listSeasonPassUsageForUserStmt                    *sql.Stmt
+	listSeasonPassWindowsStmt                         *sql.Stmt
+	listSelfRegisteredFreeAgentsByLeagueStmt          *sql.Stmt
+	listSpendableAccountCreditsStmt                   *sql.Stmt
 	listStaffStmt                                     *sql.Stmt
 	listStaffByFacilityStmt                           *sql.Stmt
 	listStaffByRoleStmt                               *sql.Stmt
+	listStaffInboxNotificationsStmt                   *sql.Stmt
 	listStaffNotificationsStmt                        *sql.Stmt
 	listStaffNotificationsForFacilityOrCorporateStmt  *sql.Stmt
 	listStaffNotificationsForStaffStmt                *sql.Stmt
+	listSyncChangesStmt                               *sql.Stmt
 	listSystemThemesStmt                              *sql.Stmt
+	listTeamMemberRatingsStmt                         *sql.Stmt
 	listTeamMembersStmt                               *sql.Stmt
 	listTierBookingWindowsForFacilityStmt             *sql.Stmt
 	listTodayVisitsByFacilityStmt                     *sql.Stmt
+	listTournamentEntrantsStmt                        *sql.Stmt
+	listTournamentMatchesStmt                         *sql.Stmt
+	listTournamentsByFacilityStmt                     *sql.Stmt
+	listUpcomingCourtReservationsStmt                 *sql.Stmt
+	listUpcomingOpenEventsStmt                        *sql.Stmt
+	listUpcomingReservationIDsForPrimaryUserStmt      *sql.Stmt
 	listVisitPackTypesStmt                            *sql.Stmt
 	listWaitlistsByFacilityStmt                       *sql.Stmt
 	listWaitlistsByUserStmt                           *sql.Stmt
 	listWaitlistsByUserAndFacilityStmt                *sql.Stmt
 	listWaitlistsForSlotStmt                          *sql.Stmt
 	logCancellationStmt                               *sql.Stmt
+	markDeferredEmailProcessedStmt                    *sql.Stmt
+	markEmailUndeliverableStmt                        *sql.Stmt
+	markLeagueTeamInvitationAcceptedStmt              *sql.Stmt
+	markReservationInvitationAcceptedStmt             *sql.Stmt
+	markReservationInvitationDeclinedStmt             *sql.Stmt
+	markReservationTransferAcceptedStmt               *sql.Stmt
+	markReservationTransferDeclinedStmt               *sql.Stmt
 	markStaffNotificationAsReadStmt                   *sql.Stmt
 	operatingHoursExistsStmt                          *sql.Stmt
+	overrideLeagueMatchResultSubmissionStmt           *sql.Stmt
+	recordJobRunStmt                                  *sql.Stmt
+	removeHouseholdLinkStmt                           *sql.Stmt
 	removeOpenPlayParticipantStmt                     *sql.Stmt
 	removeParticipantStmt                             *sql.Stmt
 	removeReservationCourtStmt                        *sql.Stmt
 	removeTeamMemberStmt                              *sql.Stmt
+	removeUserFromUpcomingReservationsStmt            *sql.Stmt
+	requestMemberDeletionStmt                         *sql.Stmt
+	requeueOpenPlayPlayerStmt                         *sql.Stmt
+	reservationsOverlapOnCourtStmt                    *sql.Stmt
+	resolveReservationInvitationForUserStmt           *sql.Stmt
+	respondToHouseholdLinkStmt                        *sql.Stmt
+	respondToLeagueMatchResultSubmissionStmt          *sql.Stmt
 	restoreLessonPackageLessonStmt                    *sql.Stmt
 	restoreMemberStmt                                 *sql.Stmt
+	revokeMemberCardsStmt                             *sql.Stmt
+	revokeOtherUserSessionsStmt                       *sql.Stmt
+	revokeReservationAccessStmt                       *sql.Stmt
+	revokeUserSessionStmt                             *sql.Stmt
+	revokeUserSessionByTokenStmt                      *sql.Stmt
+	scheduleTournamentMatchStmt                       *sql.Stmt
+	searchFacilityMembersStmt                         *sql.Stmt
 	searchMembersStmt                                 *sql.Stmt
+	searchReservationsStmt                            *sql.Stmt
+	seasonPassCoverageReportStmt                      *sql.Stmt
+	setStaffInboxNotificationReadStmt                 *sql.Stmt
+	setTournamentMatchTeamAStmt                       *sql.Stmt
+	setTournamentMatchTeamBStmt                       *sql.Stmt
+	setTournamentMatchWinnerStmt                      *sql.Stmt
+	stageWaitlistPositionsStmt                        *sql.Stmt
+	touchUserSessionStmt                              *sql.Stmt
+	transferActiveVisitPacksStmt                      *sql.Stmt
 	updateBillingInfoStmt                             *sql.Stmt
 	updateCancellationPolicyTierStmt                  *sql.Stmt
 	updateClinicSessionStmt                           *sql.Stmt
 	updateClinicTypeStmt                              *sql.Stmt
+	updateCourtPricingRuleStmt                        *sql.Stmt
 	updateCourtStatusStmt                             *sql.Stmt
 	updateEnrollmentStatusStmt                        *sql.Stmt
+	updateFacilityAnnouncementStmt                    *sql.Stmt
 	updateFacilityBookingConfigStmt                   *sql.Stmt
 	updateFacilityEmailConfigStmt                     *sql.Stmt
 	updateFacilityVisitActivityStmt                   *sql.Stmt
 	updateLeagueStmt                                  *sql.Stmt
+	updateLeagueMatchStatusStmt                       *sql.Stmt
 	updateLeagueTeamStmt                              *sql.Stmt
+	updateLeagueTeamDivisionStmt                      *sql.Stmt
 	updateLessonPackageTypeStmt                       *sql.Stmt
 	updateMatchResultStmt                             *sql.Stmt
 	updateMemberStmt                                  *sql.Stmt
 	updateMemberEmailStmt                             *sql.Stmt
+	updateMembershipTierStmt                          *sql.Stmt
 	updateOpenPlayRuleStmt                            *sql.Stmt
 	updateOpenPlaySessionCourtCountStmt               *sql.Stmt
 	updateOpenPlaySessionStatusStmt                   *sql.Stmt
 	updateOrganizationEmailConfigStmt                 *sql.Stmt
+	updateOrganizationSettingsStmt                    *sql.Stmt
+	updatePrimeTimeRuleStmt                           *sql.Stmt
 	updateReservationStmt                             *sql.Stmt
+	updateReservationPrimaryUserStmt                  *sql.Stmt
+	updateReservationTypeStmt                         *sql.Stmt
+	updateSeasonPassTypeStmt                          *sql.Stmt
 	updateSessionAutoScaleOverrideStmt                *sql.Stmt
 	updateStaffStmt                                   *sql.Stmt
 	updateStaffUserStmt                               *sql.Stmt
 	updateTeamCaptainStmt                             *sql.Stmt
 	updateThemeStmt                                   *sql.Stmt
+	updateTournamentMatchStatusStmt                   *sql.Stmt
+	updateTournamentStatusStmt                        *sql.Stmt
 	updateUserCognitoStatusStmt                       *sql.Stmt
+	updateUserHideFromRostersStmt                     *sql.Stmt
+	updateUserHomeFacilityStmt                        *sql.Stmt
+	updateUserNotificationPreferencesStmt             *sql.Stmt
 	updateUserPasswordHashStmt                        *sql.Stmt
 	updateUserStatusStmt                              *sql.Stmt
 	updateVisitPackTypeStmt                           *sql.Stmt
+	updateWaitlistAutoBookStmt                        *sql.Stmt
 	updateWaitlistStatusStmt                          *sql.Stmt
 	upsertActiveThemeIDStmt                           *sql.Stmt
+	upsertFacilityHoursOverrideStmt                   *sql.Stmt
+	upsertFacilityNoShowPolicyStmt                    *sql.Stmt
+	upsertFacilityQuietHoursStmt                      *sql.Stmt
+	upsertMemberSkillRatingStmt                       *sql.Stmt
+	upsertOpenPlaySessionSkillBandsStmt               *sql.Stmt
 	upsertOperatingHoursStmt                          *sql.Stmt
 	upsertPhotoStmt                                   *sql.Stmt
+	upsertReservationClosureDetailsStmt               *sql.Stmt
 	upsertTierBookingWindowStmt                       *sql.Stmt
 	upsertWaitlistConfigStmt                          *sql.Stmt
+	verifyMemberRatingEntryStmt                       *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                                                tx,
-		tx:                                                tx,
-		acceptOfferStmt:                                   q.acceptOfferStmt,
-		addOpenPlayParticipantStmt:                        q.addOpenPlayParticipantStmt,
-		addParticipantStmt:                                q.addParticipantStmt,
-		addReservationCourtStmt:                           q.addReservationCourtStmt,
-		addTeamMemberStmt:                                 q.addTeamMemberStmt,
-		advanceWaitlistOfferStmt:                          q.advanceWaitlistOfferStmt,
-		assignFreeAgentToTeamStmt:                         q.assignFreeAgentToTeamStmt,
+		db:                                      tx,
+		tx:                                      tx,
+		acceptOfferStmt:                         q.acceptOfferStmt,
+		addOpenPlayGamePlayerStmt:               q.addOpenPlayGamePlayerStmt,
+		addOpenPlayParticipantStmt:              q.addOpenPlayParticipantStmt,
+		addParticipantStmt:                      q.addParticipantStmt,
+		addReservationCourtStmt:                 q.addReservationCourtStmt,
+		addReservationGuestStmt:                 q.addReservationGuestStmt,
+		addTeamMemberStmt:                       q.addTeamMemberStmt,
+		addTournamentEntrantStmt:                q.addTournamentEntrantStmt,
+		advanceWaitlistOfferStmt:                q.advanceWaitlistOfferStmt,
+		anonymizeDeletedMembersStmt:             q.anonymizeDeletedMembersStmt,
+		applyStagedWaitlistPositionsStmt:        q.applyStagedWaitlistPositionsStmt,
+		assignFreeAgentToTeamStmt:               q.assignFreeAgentToTeamStmt,
+		cancelPendingReservationInvitationsStmt: q.cancelPendingReservationInvitationsStmt,
+		cancelPendingReservationTransfersStmt:   q.cancelPendingReservationTransfersStmt,
+		cancelUpcomingReservationInvitationsForUserStmt:   q.cancelUpcomingReservationInvitationsForUserStmt,
+		claimReservationReminderStmt:                      q.claimReservationReminderStmt,
+		clearUserEmailUndeliverableStmt:                   q.clearUserEmailUndeliverableStmt,
+		consumeAccountCreditStmt:                          q.consumeAccountCreditStmt,
+		consumeMemberGuestPassStmt:                        q.consumeMemberGuestPassStmt,
+		countActiveCourtsStmt:                             q.countActiveCourtsStmt,
+		countActiveHouseholdDependentsStmt:                q.countActiveHouseholdDependentsStmt,
+		countActiveHouseholdReservationsStmt:              q.countActiveHouseholdReservationsStmt,
 		countActiveMemberReservationsStmt:                 q.countActiveMemberReservationsStmt,
+		countBusyCourtsByHourStmt:                         q.countBusyCourtsByHourStmt,
 		countCheckinsByFacilityInRangeStmt:                q.countCheckinsByFacilityInRangeStmt,
 		countFacilityThemeNameStmt:                        q.countFacilityThemeNameStmt,
 		countFacilityThemeNameExcludingIDStmt:             q.countFacilityThemeNameExcludingIDStmt,
 		countFacilityThemesStmt:                           q.countFacilityThemesStmt,
 		countLessonPackageTypesByFacilityStmt:             q.countLessonPackageTypesByFacilityStmt,
 		countOpenPlayReservationsForSessionStmt:           q.countOpenPlayReservationsForSessionStmt,
+		countOpenPlaySessionsForSlotStmt:                  q.countOpenPlaySessionsForSlotStmt,
+		countPendingReservationInvitationsStmt:            q.countPendingReservationInvitationsStmt,
+		countProSessionsByProInRangeStmt:                  q.countProSessionsByProInRangeStmt,
 		countReservationParticipantsStmt:                  q.countReservationParticipantsStmt,
+		countReservationTypeNamesInRangeStmt:              q.countReservationTypeNamesInRangeStmt,
+		countReservationTypeUsageStmt:                     q.countReservationTypeUsageStmt,
 		countReservationsByTypeInRangeStmt:                q.countReservationsByTypeInRangeStmt,
 		countScheduledVsCompletedReservationsStmt:         q.countScheduledVsCompletedReservationsStmt,
 		countThemeUsageStmt:                               q.countThemeUsageStmt,
+		countTournamentMatchesStmt:                        q.countTournamentMatchesStmt,
+		countUnreadStaffInboxNotificationsStmt:            q.countUnreadStaffInboxNotificationsStmt,
 		countUnreadStaffNotificationsStmt:                 q.countUnreadStaffNotificationsStmt,
 		countVisitPackTypesByFacilityStmt:                 q.countVisitPackTypesByFacilityStmt,
+		createAccountCreditStmt:                           q.createAccountCreditStmt,
+		createAccountCreditRedemptionStmt:                 q.createAccountCreditRedemptionStmt,
 		createCancellationPolicyTierStmt:                  q.createCancellationPolicyTierStmt,
 		createClinicEnrollmentStmt:                        q.createClinicEnrollmentStmt,
 		createClinicSessionStmt:                           q.createClinicSessionStmt,
 		createClinicTypeStmt:                              q.createClinicTypeStmt,
 		createCourtStmt:                                   q.createCourtStmt,
+		createCourtPricingRuleStmt:                        q.createCourtPricingRuleStmt,
+		createCourtSlotHoldStmt:                           q.createCourtSlotHoldStmt,
+		createDeferredEmailStmt:                           q.createDeferredEmailStmt,
+		createEmailDeliveryEventStmt:                      q.createEmailDeliveryEventStmt,
+		createFacilityAnnouncementStmt:                    q.createFacilityAnnouncementStmt,
 		createFacilityVisitStmt:                           q.createFacilityVisitStmt,
+		createHouseholdLinkStmt:                           q.createHouseholdLinkStmt,
+		createImpersonationAuditEntryStmt:                 q.createImpersonationAuditEntryStmt,
 		createLeagueStmt:                                  q.createLeagueStmt,
+		createLeagueFreeAgentRegistrationStmt:             q.createLeagueFreeAgentRegistrationStmt,
 		createLeagueMatchStmt:                             q.createLeagueMatchStmt,
+		createLeagueMatchResultSubmissionStmt:             q.createLeagueMatchResultSubmissionStmt,
+		createLeaguePaymentStmt:                           q.createLeaguePaymentStmt,
 		createLeagueTeamStmt:                              q.createLeagueTeamStmt,
+		createLeagueTeamInvitationStmt:                    q.createLeagueTeamInvitationStmt,
 		createLessonCancelledNotificationStmt:             q.createLessonCancelledNotificationStmt,
 		createLessonPackageStmt:                           q.createLessonPackageStmt,
 		createLessonPackageRedemptionStmt:                 q.createLessonPackageRedemptionStmt,
 		createLessonPackageTypeStmt:                       q.createLessonPackageTypeStmt,
+		createLessonReassignedNotificationStmt:            q.createLessonReassignedNotificationStmt,
 		createMemberStmt:                                  q.createMemberStmt,
+		createMemberCardStmt:                              q.createMemberCardStmt,
+		createMemberHomeFacilityChangeStmt:                q.createMemberHomeFacilityChangeStmt,
+		createMemberRatingEntryStmt:                       q.createMemberRatingEntryStmt,
+		createMembershipTierStmt:                          q.createMembershipTierStmt,
+		createNoShowRestrictionClearStmt:                  q.createNoShowRestrictionClearStmt,
 		createOpenPlayAuditLogStmt:                        q.createOpenPlayAuditLogStmt,
+		createOpenPlayGameStmt:                            q.createOpenPlayGameStmt,
+		createOpenPlayQueueEntryStmt:                      q.createOpenPlayQueueEntryStmt,
 		createOpenPlayRuleStmt:                            q.createOpenPlayRuleStmt,
+		createOpenPlayRuleSlotStmt:                        q.createOpenPlayRuleSlotStmt,
 		createOpenPlaySessionStmt:                         q.createOpenPlaySessionStmt,
 		createPhotoStmt:                                   q.createPhotoStmt,
+		createPrimeTimeRuleStmt:                           q.createPrimeTimeRuleStmt,
 		createProUnavailabilityStmt:                       q.createProUnavailabilityStmt,
 		createReservationStmt:                             q.createReservationStmt,
+		createReservationCheckinStmt:                      q.createReservationCheckinStmt,
+		createReservationCommentStmt:                      q.createReservationCommentStmt,
+		createReservationDurationOverrideStmt:             q.createReservationDurationOverrideStmt,
+		createReservationIdempotencyKeyStmt:               q.createReservationIdempotencyKeyStmt,
+		createReservationInvitationStmt:                   q.createReservationInvitationStmt,
+		createReservationMemberNoteStmt:                   q.createReservationMemberNoteStmt,
+		createReservationPriceStmt:                        q.createReservationPriceStmt,
+		createReservationTransferStmt:                     q.createReservationTransferStmt,
+		createReservationTypeStmt:                         q.createReservationTypeStmt,
+		createSeasonPassStmt:                              q.createSeasonPassStmt,
+		createSeasonPassReservationStmt:                   q.createSeasonPassReservationStmt,
+		createSeasonPassTypeStmt:                          q.createSeasonPassTypeStmt,
+		createSeasonPassWindowStmt:                        q.createSeasonPassWindowStmt,
 		createStaffStmt:                                   q.createStaffStmt,
 		createStaffNotificationStmt:                       q.createStaffNotificationStmt,
 		createStaffUserStmt:                               q.createStaffUserStmt,
 		createThemeStmt:                                   q.createThemeStmt,
+		createTournamentStmt:                              q.createTournamentStmt,
+		createTournamentMatchStmt:                         q.createTournamentMatchStmt,
+		createUserSessionStmt:                             q.createUserSessionStmt,
 		createVisitPackStmt:                               q.createVisitPackStmt,
+		createVisitPackPaymentStmt:                        q.createVisitPackPaymentStmt,
 		createVisitPackRedemptionStmt:                     q.createVisitPackRedemptionStmt,
 		createVisitPackTypeStmt:                           q.createVisitPackTypeStmt,
 		createWaitlistEntryStmt:                           q.createWaitlistEntryStmt,
+		createWaitlistFulfilledNotificationStmt:           q.createWaitlistFulfilledNotificationStmt,
 		createWaitlistOfferStmt:                           q.createWaitlistOfferStmt,
 		deactivateLessonPackageTypeStmt:                   q.deactivateLessonPackageTypeStmt,
+		deactivateSeasonPassTypeStmt:                      q.deactivateSeasonPassTypeStmt,
 		deactivateVisitPackTypeStmt:                       q.deactivateVisitPackTypeStmt,
+		declineOfferStmt:                                  q.declineOfferStmt,
 		decrementLessonPackageLessonStmt:                  q.decrementLessonPackageLessonStmt,
 		decrementVisitPackVisitStmt:                       q.decrementVisitPackVisitStmt,
+		deleteActiveWaitlistEntriesForUserStmt:            q.deleteActiveWaitlistEntriesForUserStmt,
+		deleteAnonymizableMemberBillingStmt:               q.deleteAnonymizableMemberBillingStmt,
+		deleteAnonymizableMemberPhotosStmt:                q.deleteAnonymizableMemberPhotosStmt,
+		deleteCancellationPolicyStmt:                      q.deleteCancellationPolicyStmt,
 		deleteCancellationPolicyTierStmt:                  q.deleteCancellationPolicyTierStmt,
 		deleteClinicEnrollmentStmt:                        q.deleteClinicEnrollmentStmt,
 		deleteClinicSessionStmt:                           q.deleteClinicSessionStmt,
 		deleteClinicTypeStmt:                              q.deleteClinicTypeStmt,
+		deleteCourtPricingRuleStmt:                        q.deleteCourtPricingRuleStmt,
+		deleteCourtSlotHoldByUserStmt:                     q.deleteCourtSlotHoldByUserStmt,
+		deleteExpiredCourtSlotHoldsStmt:                   q.deleteExpiredCourtSlotHoldsStmt,
+		deleteExpiredReservationIdempotencyKeyStmt:        q.deleteExpiredReservationIdempotencyKeyStmt,
+		deleteExpiredReservationIdempotencyKeysStmt:       q.deleteExpiredReservationIdempotencyKeysStmt,
+		deleteExpiredUserSessionsStmt:                     q.deleteExpiredUserSessionsStmt,
+		deleteFacilityAnnouncementStmt:                    q.deleteFacilityAnnouncementStmt,
+		deleteFacilityHoursOverrideStmt:                   q.deleteFacilityHoursOverrideStmt,
+		deleteFacilityNoShowPolicyStmt:                    q.deleteFacilityNoShowPolicyStmt,
+		deleteFacilityQuietHoursStmt:                      q.deleteFacilityQuietHoursStmt,
 		deleteLeagueStmt:                                  q.deleteLeagueStmt,
+		deleteLeagueFreeAgentRegistrationStmt:             q.deleteLeagueFreeAgentRegistrationStmt,
 		deleteLeagueMatchesByLeagueIDStmt:                 q.deleteLeagueMatchesByLeagueIDStmt,
 		deleteLessonPackageRedemptionsByReservationIDStmt: q.deleteLessonPackageRedemptionsByReservationIDStmt,
 		deleteMemberStmt:                                  q.deleteMemberStmt,
+		deleteMemberSkillRatingStmt:                       q.deleteMemberSkillRatingStmt,
+		deleteMembershipTierStmt:                          q.deleteMembershipTierStmt,
+		deleteOpenPlayQueueEntryStmt:                      q.deleteOpenPlayQueueEntryStmt,
 		deleteOpenPlayRuleStmt:                            q.deleteOpenPlayRuleStmt,
+		deleteOpenPlayRuleSlotStmt:                        q.deleteOpenPlayRuleSlotStmt,
+		deleteOpenPlaySessionSkillBandsStmt:               q.deleteOpenPlaySessionSkillBandsStmt,
 		deleteOperatingHoursStmt:                          q.deleteOperatingHoursStmt,
 		deletePastWaitlistEntriesStmt:                     q.deletePastWaitlistEntriesStmt,
 		deletePhotoStmt:                                   q.deletePhotoStmt,
+		deletePrimeTimeRuleStmt:                           q.deletePrimeTimeRuleStmt,
 		deleteProUnavailabilityStmt:                       q.deleteProUnavailabilityStmt,
 		deleteReservationStmt:                             q.deleteReservationStmt,
+		deleteReservationClosureDetailsStmt:               q.deleteReservationClosureDetailsStmt,
 		deleteReservationCourtsByReservationIDStmt:        q.deleteReservationCourtsByReservationIDStmt,
 		deleteReservationParticipantsByReservationIDStmt:  q.deleteReservationParticipantsByReservationIDStmt,
+		deleteReservationReminderStmt:                     q.deleteReservationReminderStmt,
+		deleteReservationTypeStmt:                         q.deleteReservationTypeStmt,
+		deleteSeasonPassWindowsStmt:                       q.deleteSeasonPassWindowsStmt,
 		deleteStaffStmt:                                   q.deleteStaffStmt,
+		deleteStaffNotificationsBeforeStmt:                q.deleteStaffNotificationsBeforeStmt,
 		deleteThemeStmt:                                   q.deleteThemeStmt,
 		deleteTierBookingWindowStmt:                       q.deleteTierBookingWindowStmt,
 		deleteWaitlistEntryStmt:                           q.deleteWaitlistEntryStmt,
+		dismissFacilityAnnouncementStmt:                   q.dismissFacilityAnnouncementStmt,
 		expireOfferStmt:                                   q.expireOfferStmt,
+		expireSiblingWaitlistOffersStmt:                   q.expireSiblingWaitlistOffersStmt,
 		facilityExistsStmt:                                q.facilityExistsStmt,
+		findMatchingReservationStmt:                       q.findMatchingReservationStmt,
+		getActiveHouseholdLinkByDependentStmt:             q.getActiveHouseholdLinkByDependentStmt,
+		getActiveMemberCardStmt:                           q.getActiveMemberCardStmt,
+		getActiveMemberCardByTokenStmt:                    q.getActiveMemberCardByTokenStmt,
 		getActiveThemeIDStmt:                              q.getActiveThemeIDStmt,
 		getApplicableCancellationTierStmt:                 q.getApplicableCancellationTierStmt,
 		getAvailableCourtHoursStmt:                        q.getAvailableCourtHoursStmt,
@@ -2386,16 +4959,28 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		getCreatedMemberStmt:                              q.getCreatedMemberStmt,
 		getEligibleLessonPackageForUserStmt:               q.getEligibleLessonPackageForUserStmt,
 		getEnrollmentCountStmt:                            q.getEnrollmentCountStmt,
+		getFacilityAnnouncementStmt:                       q.getFacilityAnnouncementStmt,
 		getFacilityByIDStmt:                               q.getFacilityByIDStmt,
 		getFacilityEmailConfigStmt:                        q.getFacilityEmailConfigStmt,
 		getFacilityHoursStmt:                              q.getFacilityHoursStmt,
+		getFacilityHoursOverrideStmt:                      q.getFacilityHoursOverrideStmt,
+		getFacilityNoShowPolicyStmt:                       q.getFacilityNoShowPolicyStmt,
+		getFacilityQuietHoursStmt:                         q.getFacilityQuietHoursStmt,
 		getFacilityTierBookingEnabledStmt:                 q.getFacilityTierBookingEnabledStmt,
+		getFirstLeagueMatchTimeStmt:                       q.getFirstLeagueMatchTimeStmt,
 		getFutureProSessionsByStaffIDStmt:                 q.getFutureProSessionsByStaffIDStmt,
+		getHouseholdLinkStmt:                              q.getHouseholdLinkStmt,
+		getHouseholdLinkByTokenStmt:                       q.getHouseholdLinkByTokenStmt,
 		getLatestCancellationByReservationIDStmt:          q.getLatestCancellationByReservationIDStmt,
+		getLatestNoShowRestrictionClearStmt:               q.getLatestNoShowRestrictionClearStmt,
+		getLatestSyncSeqStmt:                              q.getLatestSyncSeqStmt,
 		getLeagueStmt:                                     q.getLeagueStmt,
+		getLeagueFreeAgentRegistrationStmt:                q.getLeagueFreeAgentRegistrationStmt,
 		getLeagueMatchStmt:                                q.getLeagueMatchStmt,
+		getLeagueMatchResultSubmissionStmt:                q.getLeagueMatchResultSubmissionStmt,
 		getLeagueStandingsDataStmt:                        q.getLeagueStandingsDataStmt,
 		getLeagueTeamStmt:                                 q.getLeagueTeamStmt,
+		getLeagueTeamInvitationByTokenStmt:                q.getLeagueTeamInvitationByTokenStmt,
 		getLeagueWithFacilityTimezoneStmt:                 q.getLeagueWithFacilityTimezoneStmt,
 		getLessonPackageStmt:                              q.getLessonPackageStmt,
 		getLessonPackageRedemptionInfoStmt:                q.getLessonPackageRedemptionInfoStmt,
@@ -2404,26 +4989,47 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		getMemberByEmailStmt:                              q.getMemberByEmailStmt,
 		getMemberByEmailIncludeDeletedStmt:                q.getMemberByEmailIncludeDeletedStmt,
 		getMemberByIDStmt:                                 q.getMemberByIDStmt,
+		getMemberGuestPassBalanceStmt:                     q.getMemberGuestPassBalanceStmt,
+		getMemberOverlappingReservationStmt:               q.getMemberOverlappingReservationStmt,
 		getMemberPhotoStmt:                                q.getMemberPhotoStmt,
+		getMemberRatingEntryStmt:                          q.getMemberRatingEntryStmt,
+		getMemberSkillRatingStmt:                          q.getMemberSkillRatingStmt,
 		getMemberTodayActivitiesStmt:                      q.getMemberTodayActivitiesStmt,
+		getMembershipTierStmt:                             q.getMembershipTierStmt,
+		getMembershipTierForLevelStmt:                     q.getMembershipTierForLevelStmt,
+		getOpenEventStmt:                                  q.getOpenEventStmt,
 		getOpenPlayReservationIDStmt:                      q.getOpenPlayReservationIDStmt,
 		getOpenPlayRuleStmt:                               q.getOpenPlayRuleStmt,
 		getOpenPlaySessionStmt:                            q.getOpenPlaySessionStmt,
+		getOpenPlaySessionSkillBandsStmt:                  q.getOpenPlaySessionSkillBandsStmt,
 		getOrganizationByIDStmt:                           q.getOrganizationByIDStmt,
 		getOrganizationBySlugStmt:                         q.getOrganizationBySlugStmt,
 		getOrganizationCrossFacilitySettingStmt:           q.getOrganizationCrossFacilitySettingStmt,
 		getOrganizationEmailConfigStmt:                    q.getOrganizationEmailConfigStmt,
 		getOrganizationReminderConfigStmt:                 q.getOrganizationReminderConfigStmt,
+		getOrganizationSettingsStmt:                       q.getOrganizationSettingsStmt,
 		getPendingOfferStmt:                               q.getPendingOfferStmt,
 		getPhotoStmt:                                      q.getPhotoStmt,
+		getPreferredMemberRatingStmt:                      q.getPreferredMemberRatingStmt,
 		getProLessonSlotsStmt:                             q.getProLessonSlotsStmt,
 		getProUnavailabilityByIDStmt:                      q.getProUnavailabilityByIDStmt,
 		getReservationStmt:                                q.getReservationStmt,
+		getReservationAccessRevocationStmt:                q.getReservationAccessRevocationStmt,
 		getReservationByIDStmt:                            q.getReservationByIDStmt,
+		getReservationClosureDetailsStmt:                  q.getReservationClosureDetailsStmt,
+		getReservationCreditAppliedCentsStmt:              q.getReservationCreditAppliedCentsStmt,
+		getReservationIdempotencyKeyStmt:                  q.getReservationIdempotencyKeyStmt,
+		getReservationInvitationByTokenStmt:               q.getReservationInvitationByTokenStmt,
+		getReservationMemberNoteStmt:                      q.getReservationMemberNoteStmt,
+		getReservationPriceStmt:                           q.getReservationPriceStmt,
+		getReservationTransferByTokenStmt:                 q.getReservationTransferByTokenStmt,
 		getReservationTypeStmt:                            q.getReservationTypeStmt,
+		getReservationTypeBufferMinutesStmt:               q.getReservationTypeBufferMinutesStmt,
 		getReservationTypeByNameStmt:                      q.getReservationTypeByNameStmt,
+		getReservationTypeForFacilityStmt:                 q.getReservationTypeForFacilityStmt,
 		getReservationTypeNameByReservationIDStmt:         q.getReservationTypeNameByReservationIDStmt,
 		getRestoredMemberStmt:                             q.getRestoredMemberStmt,
+		getSeasonPassTypeStmt:                             q.getSeasonPassTypeStmt,
 		getStaffByEmailStmt:                               q.getStaffByEmailStmt,
 		getStaffByIDStmt:                                  q.getStaffByIDStmt,
 		getStaffByPhoneStmt:                               q.getStaffByPhoneStmt,
@@ -2431,118 +5037,262 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		getStaffNotificationByIDStmt:                      q.getStaffNotificationByIDStmt,
 		getThemeStmt:                                      q.getThemeStmt,
 		getTierBookingWindowStmt:                          q.getTierBookingWindowStmt,
+		getTournamentStmt:                                 q.getTournamentStmt,
+		getTournamentMatchStmt:                            q.getTournamentMatchStmt,
 		getUpdatedMemberStmt:                              q.getUpdatedMemberStmt,
 		getUserByEmailStmt:                                q.getUserByEmailStmt,
 		getUserByIDStmt:                                   q.getUserByIDStmt,
 		getUserByPhoneStmt:                                q.getUserByPhoneStmt,
+		getUserNotificationPreferencesStmt:                q.getUserNotificationPreferencesStmt,
+		getUserPhotoStmt:                                  q.getUserPhotoStmt,
+		getUserSessionByTokenStmt:                         q.getUserSessionByTokenStmt,
 		getVisitPackStmt:                                  q.getVisitPackStmt,
 		getVisitPackRedemptionInfoStmt:                    q.getVisitPackRedemptionInfoStmt,
 		getVisitPackTypeStmt:                              q.getVisitPackTypeStmt,
 		getWaitlistConfigStmt:                             q.getWaitlistConfigStmt,
 		getWaitlistEntryStmt:                              q.getWaitlistEntryStmt,
+		getWaitlistOfferForUserStmt:                       q.getWaitlistOfferForUserStmt,
+		grantMemberGuestPassesStmt:                        q.grantMemberGuestPassesStmt,
 		isMemberOpenPlayParticipantStmt:                   q.isMemberOpenPlayParticipantStmt,
+		isReservationCancelledStmt:                        q.isReservationCancelledStmt,
+		isReservationUpcomingStmt:                         q.isReservationUpcomingStmt,
+		isUserOnLeagueTeamStmt:                            q.isUserOnLeagueTeamStmt,
+		listAccountCreditRedemptionsStmt:                  q.listAccountCreditRedemptionsStmt,
+		listAccountCreditsStmt:                            q.listAccountCreditsStmt,
+		listActiveCourtsStmt:                              q.listActiveCourtsStmt,
+		listActiveFacilityAnnouncementsStmt:               q.listActiveFacilityAnnouncementsStmt,
+		listActiveHouseholdDependentsStmt:                 q.listActiveHouseholdDependentsStmt,
 		listActiveLessonPackagesForUserStmt:               q.listActiveLessonPackagesForUserStmt,
 		listActiveLessonPackagesForUserByFacilityStmt:     q.listActiveLessonPackagesForUserByFacilityStmt,
 		listActiveLessonPackagesForUserByOrganizationStmt: q.listActiveLessonPackagesForUserByOrganizationStmt,
+		listActiveSeasonPassesForUserByFacilityStmt:       q.listActiveSeasonPassesForUserByFacilityStmt,
+		listActiveUserSessionsStmt:                        q.listActiveUserSessionsStmt,
 		listActiveVisitPacksForUserStmt:                   q.listActiveVisitPacksForUserStmt,
 		listActiveVisitPacksForUserByFacilityStmt:         q.listActiveVisitPacksForUserByFacilityStmt,
 		listActiveVisitPacksForUserByOrganizationStmt:     q.listActiveVisitPacksForUserByOrganizationStmt,
 		listAvailableCourtsStmt:                           q.listAvailableCourtsStmt,
+		listBillingSummaryLessonPackagesStmt:              q.listBillingSummaryLessonPackagesStmt,
+		listBillingSummaryReservationsStmt:                q.listBillingSummaryReservationsStmt,
+		listBillingSummaryVisitPacksStmt:                  q.listBillingSummaryVisitPacksStmt,
 		listCancellationPolicyTiersStmt:                   q.listCancellationPolicyTiersStmt,
+		listCancellationPolicyTiersForFacilitiesStmt:      q.listCancellationPolicyTiersForFacilitiesStmt,
+		listCheckedInReservationIDsStmt:                   q.listCheckedInReservationIDsStmt,
 		listClinicSessionsByFacilityStmt:                  q.listClinicSessionsByFacilityStmt,
 		listClinicTypesByFacilityStmt:                     q.listClinicTypesByFacilityStmt,
+		listCourtBlocksForDayStmt:                         q.listCourtBlocksForDayStmt,
+		listCourtBookingsInRangeStmt:                      q.listCourtBookingsInRangeStmt,
+		listCourtBufferNeighborsStmt:                      q.listCourtBufferNeighborsStmt,
+		listCourtOverlapConflictsStmt:                     q.listCourtOverlapConflictsStmt,
+		listCourtPricingRulesStmt:                         q.listCourtPricingRulesStmt,
 		listCourtsStmt:                                    q.listCourtsStmt,
 		listDistinctFacilitiesWithScheduledSessionsStmt:   q.listDistinctFacilitiesWithScheduledSessionsStmt,
+		listDueDeferredEmailsStmt:                         q.listDueDeferredEmailsStmt,
+		listEmailDeliveryEventsStmt:                       q.listEmailDeliveryEventsStmt,
 		listEnrollmentsForClinicStmt:                      q.listEnrollmentsForClinicStmt,
 		listExpiredOffersStmt:                             q.listExpiredOffersStmt,
 		listFacilitiesStmt:                                q.listFacilitiesStmt,
+		listFacilitiesByOrganizationStmt:                  q.listFacilitiesByOrganizationStmt,
+		listFacilitiesWithOpenPlayRuleSlotsStmt:           q.listFacilitiesWithOpenPlayRuleSlotsStmt,
+		listFacilityAnnouncementsStmt:                     q.listFacilityAnnouncementsStmt,
+		listFacilityArrivalsInRangeStmt:                   q.listFacilityArrivalsInRangeStmt,
+		listFacilityHoursOverridesStmt:                    q.listFacilityHoursOverridesStmt,
+		listFacilityOpenPlayRuleSlotsStmt:                 q.listFacilityOpenPlayRuleSlotsStmt,
 		listFacilityThemesStmt:                            q.listFacilityThemesStmt,
 		listFreeAgentsByLeagueStmt:                        q.listFreeAgentsByLeagueStmt,
+		listHeldCourtIDsStmt:                              q.listHeldCourtIDsStmt,
+		listHouseholdLinksForUserStmt:                     q.listHouseholdLinksForUserStmt,
+		listHouseholdUserIDsStmt:                          q.listHouseholdUserIDsStmt,
+		listJobStatusesStmt:                               q.listJobStatusesStmt,
+		listLapsedLeagueMatchResultSubmissionsStmt:        q.listLapsedLeagueMatchResultSubmissionsStmt,
 		listLeagueMatchesStmt:                             q.listLeagueMatchesStmt,
 		listLeagueMatchesWithReservationsStmt:             q.listLeagueMatchesWithReservationsStmt,
+		listLeagueTeamPaymentsStmt:                        q.listLeagueTeamPaymentsStmt,
 		listLeagueTeamsStmt:                               q.listLeagueTeamsStmt,
 		listLeaguesByFacilityStmt:                         q.listLeaguesByFacilityStmt,
 		listLessonPackageRedemptionsByReservationIDStmt:   q.listLessonPackageRedemptionsByReservationIDStmt,
 		listLessonPackageTypesStmt:                        q.listLessonPackageTypesStmt,
+		listMaintenanceCourtsInRangeStmt:                  q.listMaintenanceCourtsInRangeStmt,
 		listMatchingPendingWaitlistsForCancelledSlotStmt:  q.listMatchingPendingWaitlistsForCancelledSlotStmt,
+		listMemberLeagueTeamsStmt:                         q.listMemberLeagueTeamsStmt,
+		listMemberNoShowEndTimesStmt:                      q.listMemberNoShowEndTimesStmt,
+		listMemberPaidVisitsPageStmt:                      q.listMemberPaidVisitsPageStmt,
+		listMemberRatingHistoryStmt:                       q.listMemberRatingHistoryStmt,
 		listMemberUpcomingOpenPlaySessionsStmt:            q.listMemberUpcomingOpenPlaySessionsStmt,
 		listMembersStmt:                                   q.listMembersStmt,
+		listMembershipTiersStmt:                           q.listMembershipTiersStmt,
+		listNoShowReservationsInRangeStmt:                 q.listNoShowReservationsInRangeStmt,
 		listOpenPlayAuditLogStmt:                          q.listOpenPlayAuditLogStmt,
+		listOpenPlayParticipantSkillRatingsStmt:           q.listOpenPlayParticipantSkillRatingsStmt,
 		listOpenPlayParticipantsStmt:                      q.listOpenPlayParticipantsStmt,
+		listOpenPlayParticipantsForSessionStmt:            q.listOpenPlayParticipantsForSessionStmt,
+		listOpenPlayQueueStmt:                             q.listOpenPlayQueueStmt,
+		listOpenPlayRuleSlotsStmt:                         q.listOpenPlayRuleSlotsStmt,
 		listOpenPlayRulesStmt:                             q.listOpenPlayRulesStmt,
+		listOpenPlaySessionCapacityStmt:                   q.listOpenPlaySessionCapacityStmt,
+		listOpenPlaySessionCapacityByIDsStmt:              q.listOpenPlaySessionCapacityByIDsStmt,
+		listOpenPlaySessionFillInRangeStmt:                q.listOpenPlaySessionFillInRangeStmt,
+		listOpenPlaySessionReservationMismatchesStmt:      q.listOpenPlaySessionReservationMismatchesStmt,
 		listOpenPlaySessionsStmt:                          q.listOpenPlaySessionsStmt,
 		listOpenPlaySessionsApproachingCutoffStmt:         q.listOpenPlaySessionsApproachingCutoffStmt,
 		listOrganizationsStmt:                             q.listOrganizationsStmt,
 		listParticipantsForReservationStmt:                q.listParticipantsForReservationStmt,
+		listParticipantsForReservationsStmt:               q.listParticipantsForReservationsStmt,
+		listPendingWaitlistOffersForFacilityStmt:          q.listPendingWaitlistOffersForFacilityStmt,
+		listPrimeTimeRulesStmt:                            q.listPrimeTimeRulesStmt,
+		listPrimeTimeRulesForDayStmt:                      q.listPrimeTimeRulesForDayStmt,
+		listProLessonReportReservationsStmt:               q.listProLessonReportReservationsStmt,
 		listProUnavailabilityByFacilityAndDateRangeStmt:   q.listProUnavailabilityByFacilityAndDateRangeStmt,
 		listProUnavailabilityByProIDStmt:                  q.listProUnavailabilityByProIDStmt,
 		listProsByFacilityStmt:                            q.listProsByFacilityStmt,
 		listRecentVisitsByUserStmt:                        q.listRecentVisitsByUserStmt,
+		listReservationCalendarEntriesStmt:                q.listReservationCalendarEntriesStmt,
+		listReservationCheckinRosterStmt:                  q.listReservationCheckinRosterStmt,
+		listReservationCommentsStmt:                       q.listReservationCommentsStmt,
 		listReservationCourtsStmt:                         q.listReservationCourtsStmt,
 		listReservationCourtsByDateRangeStmt:              q.listReservationCourtsByDateRangeStmt,
+		listReservationDurationOverridesStmt:              q.listReservationDurationOverridesStmt,
+		listReservationFacilitiesByUserIDStmt:             q.listReservationFacilitiesByUserIDStmt,
+		listReservationGuestsStmt:                         q.listReservationGuestsStmt,
+		listReservationGuestsForReservationsStmt:          q.listReservationGuestsForReservationsStmt,
+		listReservationInvitationsForReservationsStmt:     q.listReservationInvitationsForReservationsStmt,
+		listReservationMemberNotesForReservationsStmt:     q.listReservationMemberNotesForReservationsStmt,
 		listReservationTypesStmt:                          q.listReservationTypesStmt,
+		listReservationTypesForFacilityStmt:               q.listReservationTypesForFacilityStmt,
+		listReservationTypesForOrganizationStmt:           q.listReservationTypesForOrganizationStmt,
 		listReservationsByDateRangeStmt:                   q.listReservationsByDateRangeStmt,
 		listReservationsByUserIDStmt:                      q.listReservationsByUserIDStmt,
+		listReservationsDueForReminderStmt:                q.listReservationsDueForReminderStmt,
+		listReservationsForSyncStmt:                       q.listReservationsForSyncStmt,
 		listReservationsStartingBetweenStmt:               q.listReservationsStartingBetweenStmt,
+		listSeasonPassTypesStmt:                           q.listSeasonPassTypesStmt,
+		listSeasonPassUsageForUserStmt:                    q.listSeasonPassUsageForUserStmt,
+		listSeasonPassWindowsStmt:                         q.listSeasonPassWindowsStmt,
+		listSelfRegisteredFreeAgentsByLeagueStmt:          q.listSelfRegisteredFreeAgentsByLeagueStmt,
+		listSpendableAccountCreditsStmt:                   q.listSpendableAccountCreditsStmt,
 		listStaffStmt:                                     q.listStaffStmt,
 		listStaffByFacilityStmt:                           q.listStaffByFacilityStmt,
 		listStaffByRoleStmt:                               q.listStaffByRoleStmt,
+		listStaffInboxNotificationsStmt:                   q.listStaffInboxNotificationsStmt,
 		listStaffNotificationsStmt:                        q.listStaffNotificationsStmt,
 		listStaffNotificationsForFacilityOrCorporateStmt:  q.listStaffNotificationsForFacilityOrCorporateStmt,
 		listStaffNotificationsForStaffStmt:                q.listStaffNotificationsForStaffStmt,
+		listSyncChangesStmt:                               q.listSyncChangesStmt,
 		listSystemThemesStmt:                              q.listSystemThemesStmt,
+		listTeamMemberRatingsStmt:                         q.listTeamMemberRatingsStmt,
 		listTeamMembersStmt:                               q.listTeamMembersStmt,
 		listTierBookingWindowsForFacilityStmt:             q.listTierBookingWindowsForFacilityStmt,
 		listTodayVisitsByFacilityStmt:                     q.listTodayVisitsByFacilityStmt,
+		listTournamentEntrantsStmt:                        q.listTournamentEntrantsStmt,
+		listTournamentMatchesStmt:                         q.listTournamentMatchesStmt,
+		listTournamentsByFacilityStmt:                     q.listTournamentsByFacilityStmt,
+		listUpcomingCourtReservationsStmt:                 q.listUpcomingCourtReservationsStmt,
+		listUpcomingOpenEventsStmt:                        q.listUpcomingOpenEventsStmt,
+		listUpcomingReservationIDsForPrimaryUserStmt:      q.listUpcomingReservationIDsForPrimaryUserStmt,
 		listVisitPackTypesStmt:                            q.listVisitPackTypesStmt,
 		listWaitlistsByFacilityStmt:                       q.listWaitlistsByFacilityStmt,
 		listWaitlistsByUserStmt:                           q.listWaitlistsByUserStmt,
 		listWaitlistsByUserAndFacilityStmt:                q.listWaitlistsByUserAndFacilityStmt,
 		listWaitlistsForSlotStmt:                          q.listWaitlistsForSlotStmt,
 		logCancellationStmt:                               q.logCancellationStmt,
+		markDeferredEmailProcessedStmt:                    q.markDeferredEmailProcessedStmt,
+		markEmailUndeliverableStmt:                        q.markEmailUndeliverableStmt,
+		markLeagueTeamInvitationAcceptedStmt:              q.markLeagueTeamInvitationAcceptedStmt,
+		markReservationInvitationAcceptedStmt:             q.markReservationInvitationAcceptedStmt,
+		markReservationInvitationDeclinedStmt:             q.markReservationInvitationDeclinedStmt,
+		markReservationTransferAcceptedStmt:               q.markReservationTransferAcceptedStmt,
+		markReservationTransferDeclinedStmt:               q.markReservationTransferDeclinedStmt,
 		markStaffNotificationAsReadStmt:                   q.markStaffNotificationAsReadStmt,
 		operatingHoursExistsStmt:                          q.operatingHoursExistsStmt,
+		overrideLeagueMatchResultSubmissionStmt:           q.overrideLeagueMatchResultSubmissionStmt,
+		recordJobRunStmt:                                  q.recordJobRunStmt,
+		removeHouseholdLinkStmt:                           q.removeHouseholdLinkStmt,
 		removeOpenPlayParticipantStmt:                     q.removeOpenPlayParticipantStmt,
 		removeParticipantStmt:                             q.removeParticipantStmt,
 		removeReservationCourtStmt:                        q.removeReservationCourtStmt,
 		removeTeamMemberStmt:                              q.removeTeamMemberStmt,
+		removeUserFromUpcomingReservationsStmt:            q.removeUserFromUpcomingReservationsStmt,
+		requestMemberDeletionStmt:                         q.requestMemberDeletionStmt,
+		requeueOpenPlayPlayerStmt:                         q.requeueOpenPlayPlayerStmt,
+		reservationsOverlapOnCourtStmt:                    q.reservationsOverlapOnCourtStmt,
+		resolveReservationInvitationForUserStmt:           q.resolveReservationInvitationForUserStmt,
+		respondToHouseholdLinkStmt:                        q.respondToHouseholdLinkStmt,
+		respondToLeagueMatchResultSubmissionStmt:          q.respondToLeagueMatchResultSubmissionStmt,
 		restoreLessonPackageLessonStmt:                    q.restoreLessonPackageLessonStmt,
 		restoreMemberStmt:                                 q.restoreMemberStmt,
+		revokeMemberCardsStmt:                             q.revokeMemberCardsStmt,
+		revokeOtherUserSessionsStmt:                       q.revokeOtherUserSessionsStmt,
+		revokeReservationAccessStmt:                       q.revokeReservationAccessStmt,
+		revokeUserSessionStmt:                             q.revokeUserSessionStmt,
+		revokeUserSessionByTokenStmt:                      q.revokeUserSessionByTokenStmt,
+		scheduleTournamentMatchStmt:                       q.scheduleTournamentMatchStmt,
+		searchFacilityMembersStmt:                         q.searchFacilityMembersStmt,
 		searchMembersStmt:                                 q.searchMembersStmt,
+		searchReservationsStmt:                            q.searchReservationsStmt,
+		seasonPassCoverageReportStmt:                      q.seasonPassCoverageReportStmt,
+		setStaffInboxNotificationReadStmt:                 q.setStaffInboxNotificationReadStmt,
+		setTournamentMatchTeamAStmt:                       q.setTournamentMatchTeamAStmt,
+		setTournamentMatchTeamBStmt:                       q.setTournamentMatchTeamBStmt,
+		setTournamentMatchWinnerStmt:                      q.setTournamentMatchWinnerStmt,
+		stageWaitlistPositionsStmt:                        q.stageWaitlistPositionsStmt,
+		touchUserSessionStmt:                              q.touchUserSessionStmt,
+		transferActiveVisitPacksStmt:                      q.transferActiveVisitPacksStmt,
 		updateBillingInfoStmt:                             q.updateBillingInfoStmt,
 		updateCancellationPolicyTierStmt:                  q.updateCancellationPolicyTierStmt,
 		updateClinicSessionStmt:                           q.updateClinicSessionStmt,
 		updateClinicTypeStmt:                              q.updateClinicTypeStmt,
+		updateCourtPricingRuleStmt:                        q.updateCourtPricingRuleStmt,
 		updateCourtStatusStmt:                             q.updateCourtStatusStmt,
 		updateEnrollmentStatusStmt:                        q.updateEnrollmentStatusStmt,
+		updateFacilityAnnouncementStmt:                    q.updateFacilityAnnouncementStmt,
 		updateFacilityBookingConfigStmt:                   q.updateFacilityBookingConfigStmt,
 		updateFacilityEmailConfigStmt:                     q.updateFacilityEmailConfigStmt,
 		updateFacilityVisitActivityStmt:                   q.updateFacilityVisitActivityStmt,
 		updateLeagueStmt:                                  q.updateLeagueStmt,
+		updateLeagueMatchStatusStmt:                       q.updateLeagueMatchStatusStmt,
 		updateLeagueTeamStmt:                              q.updateLeagueTeamStmt,
+		updateLeagueTeamDivisionStmt:                      q.updateLeagueTeamDivisionStmt,
 		updateLessonPackageTypeStmt:                       q.updateLessonPackageTypeStmt,
 		updateMatchResultStmt:                             q.updateMatchResultStmt,
 		updateMemberStmt:                                  q.updateMemberStmt,
 		updateMemberEmailStmt:                             q.updateMemberEmailStmt,
+		updateMembershipTierStmt:                          q.updateMembershipTierStmt,
 		updateOpenPlayRuleStmt:                            q.updateOpenPlayRuleStmt,
 		updateOpenPlaySessionCourtCountStmt:               q.updateOpenPlaySessionCourtCountStmt,
 		updateOpenPlaySessionStatusStmt:                   q.updateOpenPlaySessionStatusStmt,
 		updateOrganizationEmailConfigStmt:                 q.updateOrganizationEmailConfigStmt,
+		updateOrganizationSettingsStmt:                    q.updateOrganizationSettingsStmt,
+		updatePrimeTimeRuleStmt:                           q.updatePrimeTimeRuleStmt,
 		updateReservationStmt:                             q.updateReservationStmt,
+		updateReservationPrimaryUserStmt:                  q.updateReservationPrimaryUserStmt,
+		updateReservationTypeStmt:                         q.updateReservationTypeStmt,
+		updateSeasonPassTypeStmt:                          q.updateSeasonPassTypeStmt,
 		updateSessionAutoScaleOverrideStmt:                q.updateSessionAutoScaleOverrideStmt,
 		updateStaffStmt:                                   q.updateStaffStmt,
 		updateStaffUserStmt:                               q.updateStaffUserStmt,
 		updateTeamCaptainStmt:                             q.updateTeamCaptainStmt,
 		updateThemeStmt:                                   q.updateThemeStmt,
+		updateTournamentMatchStatusStmt:                   q.updateTournamentMatchStatusStmt,
+		updateTournamentStatusStmt:                        q.updateTournamentStatusStmt,
 		updateUserCognitoStatusStmt:                       q.updateUserCognitoStatusStmt,
+		updateUserHideFromRostersStmt:                     q.updateUserHideFromRostersStmt,
+		updateUserHomeFacilityStmt:                        q.updateUserHomeFacilityStmt,
+		updateUserNotificationPreferencesStmt:             q.updateUserNotificationPreferencesStmt,
 		updateUserPasswordHashStmt:                        q.updateUserPasswordHashStmt,
 		updateUserStatusStmt:                              q.updateUserStatusStmt,
 		updateVisitPackTypeStmt:                           q.updateVisitPackTypeStmt,
+		updateWaitlistAutoBookStmt:                        q.updateWaitlistAutoBookStmt,
 		updateWaitlistStatusStmt:                          q.updateWaitlistStatusStmt,
 		upsertActiveThemeIDStmt:                           q.upsertActiveThemeIDStmt,
+		upsertFacilityHoursOverrideStmt:                   q.upsertFacilityHoursOverrideStmt,
+		upsertFacilityNoShowPolicyStmt:                    q.upsertFacilityNoShowPolicyStmt,
+		upsertFacilityQuietHoursStmt:                      q.upsertFacilityQuietHoursStmt,
+		upsertMemberSkillRatingStmt:                       q.upsertMemberSkillRatingStmt,
+		upsertOpenPlaySessionSkillBandsStmt:               q.upsertOpenPlaySessionSkillBandsStmt,
 		upsertOperatingHoursStmt:                          q.upsertOperatingHoursStmt,
 		upsertPhotoStmt:                                   q.upsertPhotoStmt,
+		upsertReservationClosureDetailsStmt:               q.upsertReservationClosureDetailsStmt,
 		upsertTierBookingWindowStmt:                       q.upsertTierBookingWindowStmt,
 		upsertWaitlistConfigStmt:                          q.upsertWaitlistConfigStmt,
+		verifyMemberRatingEntryStmt:                       q.verifyMemberRatingEntryStmt,
 	}
 }
@@ -21,11 +21,19 @@ SELECT
     email_from_address,
     max_advance_booking_days,
     max_member_reservations,
+    max_courts_per_member_booking,
     lesson_min_notice_hours,
     reminder_hours_before,
     tier_booking_enabled,
     created_at,
-    updated_at
+    updated_at,
+    slot_duration_minutes,
+    min_booking_minutes,
+    max_guests_per_reservation,
+    reservation_limit_scope,
+    buffer_minutes,
+    address,
+    phone
 FROM facilities
 WHERE id = ?
 `
@@ -43,11 +51,19 @@ func (q *Queries) GetFacilityByID(ctx context.Context, id int64) (Facility, erro
 		&i.EmailFromAddress,
 		&i.MaxAdvanceBookingDays,
 		&i.MaxMemberReservations,
+		&i.MaxCourtsPerMemberBooking,
 		&i.LessonMinNoticeHours,
 		&i.ReminderHoursBefore,
 		&i.TierBookingEnabled,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SlotDurationMinutes,
+		&i.MinBookingMinutes,
+		&i.MaxGuestsPerReservation,
+		&i.ReservationLimitScope,
+		&i.BufferMinutes,
+		&i.Address,
+		&i.Phone,
 	)
 	return i, err
 }
@@ -83,11 +99,19 @@ SELECT
     email_from_address,
     max_advance_booking_days,
     max_member_reservations,
+    max_courts_per_member_booking,
     lesson_min_notice_hours,
     reminder_hours_before,
     tier_booking_enabled,
     created_at,
-    updated_at
+    updated_at,
+    slot_duration_minutes,
+    min_booking_minutes,
+    max_guests_per_reservation,
+    reservation_limit_scope,
+    buffer_minutes,
+    address,
+    phone
 FROM facilities
 ORDER BY name
 `
@@ -112,11 +136,94 @@ func (q *Queries) ListFacilities(ctx context.Context) ([]Facility, error) {
 			&i.EmailFromAddress,
 			&i.MaxAdvanceBookingDays,
 			&i.MaxMemberReservations,
+			&i.MaxCourtsPerMemberBooking,
 			&i.LessonMinNoticeHours,
 			&i.ReminderHoursBefore,
 			&i.TierBookingEnabled,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SlotDurationMinutes,
+			&i.MinBookingMinutes,
+			&i.MaxGuestsPerReservation,
+			&i.ReservationLimitScope,
+			&i.BufferMinutes,
+			&i.Address,
+			&i.Phone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFacilitiesByOrganization = `-- name: ListFacilitiesByOrganization :many
+SELECT
+    id,
+    organization_id,
+    name,
+    slug,
+    timezone,
+    active_theme_id,
+    email_from_address,
+    max_advance_booking_days,
+    max_member_reservations,
+    max_courts_per_member_booking,
+    lesson_min_notice_hours,
+    reminder_hours_before,
+    tier_booking_enabled,
+    created_at,
+    updated_at,
+    slot_duration_minutes,
+    min_booking_minutes,
+    max_guests_per_reservation,
+    reservation_limit_scope,
+    buffer_minutes,
+    address,
+    phone
+FROM facilities
+WHERE organization_id = ?1
+ORDER BY name
+`
+
+func (q *Queries) ListFacilitiesByOrganization(ctx context.Context, organizationID int64) ([]Facility, error) {
+	rows, err := q.query(ctx, q.listFacilitiesByOrganizationStmt, listFacilitiesByOrganization, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Facility
+	for rows.Next() {
+		var i Facility
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.Name,
+			&i.Slug,
+			&i.Timezone,
+			&i.ActiveThemeID,
+			&i.EmailFromAddress,
+			&i.MaxAdvanceBookingDays,
+			&i.MaxMemberReservations,
+			&i.MaxCourtsPerMemberBooking,
+			&i.LessonMinNoticeHours,
+			&i.ReminderHoursBefore,
+			&i.TierBookingEnabled,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SlotDurationMinutes,
+			&i.MinBookingMinutes,
+			&i.MaxGuestsPerReservation,
+			&i.ReservationLimitScope,
+			&i.BufferMinutes,
+			&i.Address,
+			&i.Phone,
 		); err != nil {
 			return nil, err
 		}
@@ -135,9 +242,15 @@ const updateFacilityBookingConfig = `-- name: UpdateFacilityBookingConfig :one
 UPDATE facilities
 SET max_advance_booking_days = ?1,
     max_member_reservations = ?2,
-    lesson_min_notice_hours = ?3,
+    max_courts_per_member_booking = ?3,
+    lesson_min_notice_hours = ?4,
+    slot_duration_minutes = ?5,
+    min_booking_minutes = ?6,
+    max_guests_per_reservation = ?7,
+    reservation_limit_scope = ?8,
+    buffer_minutes = ?9,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = ?4
+WHERE id = ?10
 RETURNING
     id,
     organization_id,
@@ -148,25 +261,45 @@ RETURNING
     email_from_address,
     max_advance_booking_days,
     max_member_reservations,
+    max_courts_per_member_booking,
     lesson_min_notice_hours,
     reminder_hours_before,
     tier_booking_enabled,
     created_at,
-    updated_at
+    updated_at,
+    slot_duration_minutes,
+    min_booking_minutes,
+    max_guests_per_reservation,
+    reservation_limit_scope,
+    buffer_minutes,
+    address,
+    phone
 `
 
 type UpdateFacilityBookingConfigParams struct {
-	MaxAdvanceBookingDays int64 `json:"maxAdvanceBookingDays"`
-	MaxMemberReservations int64 `json:"maxMemberReservations"`
-	LessonMinNoticeHours  int64 `json:"lessonMinNoticeHours"`
-	ID                    int64 `json:"id"`
+	MaxAdvanceBookingDays     int64  `json:"maxAdvanceBookingDays"`
+	MaxMemberReservations     int64  `json:"maxMemberReservations"`
+	MaxCourtsPerMemberBooking int64  `json:"maxCourtsPerMemberBooking"`
+	LessonMinNoticeHours      int64  `json:"lessonMinNoticeHours"`
+	SlotDurationMinutes       int64  `json:"slotDurationMinutes"`
+	MinBookingMinutes         int64  `json:"minBookingMinutes"`
+	MaxGuestsPerReservation   int64  `json:"maxGuestsPerReservation"`
+	ReservationLimitScope     string `json:"reservationLimitScope"`
+	BufferMinutes             int64  `json:"bufferMinutes"`
+	ID                        int64  `json:"id"`
 }
 
 func (q *Queries) UpdateFacilityBookingConfig(ctx context.Context, arg UpdateFacilityBookingConfigParams) (Facility, error) {
 	row := q.queryRow(ctx, q.updateFacilityBookingConfigStmt, updateFacilityBookingConfig,
 		arg.MaxAdvanceBookingDays,
 		arg.MaxMemberReservations,
+		arg.MaxCourtsPerMemberBooking,
 		arg.LessonMinNoticeHours,
+		arg.SlotDurationMinutes,
+		arg.MinBookingMinutes,
+		arg.MaxGuestsPerReservation,
+		arg.ReservationLimitScope,
+		arg.BufferMinutes,
 		arg.ID,
 	)
 	var i Facility
@@ -180,11 +313,19 @@ func (q *Queries) UpdateFacilityBookingConfig(ctx context.Context, arg UpdateFac
 		&i.EmailFromAddress,
 		&i.MaxAdvanceBookingDays,
 		&i.MaxMemberReservations,
+		&i.MaxCourtsPerMemberBooking,
 		&i.LessonMinNoticeHours,
 		&i.ReminderHoursBefore,
 		&i.TierBookingEnabled,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SlotDurationMinutes,
+		&i.MinBookingMinutes,
+		&i.MaxGuestsPerReservation,
+		&i.ReservationLimitScope,
+		&i.BufferMinutes,
+		&i.Address,
+		&i.Phone,
 	)
 	return i, err
 }
@@ -97,6 +97,11 @@ LEFT JOIN open_play_sessions ops
 WHERE r.facility_id = ?1
   AND r.start_time >= ?2
   AND r.start_time < ?3
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
   AND (
     (rt.name = 'OPEN_PLAY' AND rp.user_id = ?4 AND ops.id IS NOT NULL)
     OR (rt.name != 'OPEN_PLAY' AND (r.primary_user_id = ?4 OR rp.user_id = ?4))
@@ -89,7 +89,9 @@ INSERT INTO leagues (
     min_team_size,
     max_team_size,
     roster_lock_date,
-    status
+    status,
+    registration_fee_cents,
+    fee_basis
 ) VALUES (
     ?1,
     ?2,
@@ -100,23 +102,28 @@ INSERT INTO leagues (
     ?7,
     ?8,
     ?9,
-    ?10
+    ?10,
+    ?11,
+    ?12
 )
 RETURNING id, facility_id, name, format, start_date, end_date, division_config,
-    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at
+    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at,
+    registration_fee_cents, fee_basis
 `
 
 type CreateLeagueParams struct {
-	FacilityID     int64        `json:"facilityId"`
-	Name           string       `json:"name"`
-	Format         string       `json:"format"`
-	StartDate      time.Time    `json:"startDate"`
-	EndDate        time.Time    `json:"endDate"`
-	DivisionConfig string       `json:"divisionConfig"`
-	MinTeamSize    int64        `json:"minTeamSize"`
-	MaxTeamSize    int64        `json:"maxTeamSize"`
-	RosterLockDate sql.NullTime `json:"rosterLockDate"`
-	Status         string       `json:"status"`
+	FacilityID           int64        `json:"facilityId"`
+	Name                 string       `json:"name"`
+	Format               string       `json:"format"`
+	StartDate            time.Time    `json:"startDate"`
+	EndDate              time.Time    `json:"endDate"`
+	DivisionConfig       string       `json:"divisionConfig"`
+	MinTeamSize          int64        `json:"minTeamSize"`
+	MaxTeamSize          int64        `json:"maxTeamSize"`
+	RosterLockDate       sql.NullTime `json:"rosterLockDate"`
+	Status               string       `json:"status"`
+	RegistrationFeeCents int64        `json:"registrationFeeCents"`
+	FeeBasis             string       `json:"feeBasis"`
 }
 
 // internal/db/queries/leagues.sql
@@ -132,6 +139,8 @@ func (q *Queries) CreateLeague(ctx context.Context, arg CreateLeagueParams) (Lea
 		arg.MaxTeamSize,
 		arg.RosterLockDate,
 		arg.Status,
+		arg.RegistrationFeeCents,
+		arg.FeeBasis,
 	)
 	var i League
 	err := row.Scan(
@@ -148,6 +157,54 @@ func (q *Queries) CreateLeague(ctx context.Context, arg CreateLeagueParams) (Lea
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RegistrationFeeCents,
+		&i.FeeBasis,
+	)
+	return i, err
+}
+
+const createLeagueFreeAgentRegistration = `-- name: CreateLeagueFreeAgentRegistration :one
+INSERT INTO league_free_agents (
+    league_id,
+    user_id,
+    skill_level,
+    availability_notes,
+    gender
+) VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4,
+    ?5
+)
+RETURNING id, league_id, user_id, skill_level, availability_notes, gender, created_at
+`
+
+type CreateLeagueFreeAgentRegistrationParams struct {
+	LeagueID          int64          `json:"leagueId"`
+	UserID            int64          `json:"userId"`
+	SkillLevel        string         `json:"skillLevel"`
+	AvailabilityNotes sql.NullString `json:"availabilityNotes"`
+	Gender            sql.NullString `json:"gender"`
+}
+
+func (q *Queries) CreateLeagueFreeAgentRegistration(ctx context.Context, arg CreateLeagueFreeAgentRegistrationParams) (LeagueFreeAgent, error) {
+	row := q.queryRow(ctx, q.createLeagueFreeAgentRegistrationStmt, createLeagueFreeAgentRegistration,
+		arg.LeagueID,
+		arg.UserID,
+		arg.SkillLevel,
+		arg.AvailabilityNotes,
+		arg.Gender,
+	)
+	var i LeagueFreeAgent
+	err := row.Scan(
+		&i.ID,
+		&i.LeagueID,
+		&i.UserID,
+		&i.SkillLevel,
+		&i.AvailabilityNotes,
+		&i.Gender,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -161,7 +218,8 @@ INSERT INTO league_matches (
     scheduled_time,
     home_score,
     away_score,
-    status
+    status,
+    round_number
 ) VALUES (
     ?1,
     ?2,
@@ -170,10 +228,11 @@ INSERT INTO league_matches (
     ?5,
     ?6,
     ?7,
-    ?8
+    ?8,
+    ?9
 )
 RETURNING id, league_id, home_team_id, away_team_id, reservation_id,
-    scheduled_time, home_score, away_score, status, created_at, updated_at
+    scheduled_time, home_score, away_score, status, created_at, updated_at, round_number
 `
 
 type CreateLeagueMatchParams struct {
@@ -185,6 +244,7 @@ type CreateLeagueMatchParams struct {
 	HomeScore     sql.NullInt64 `json:"homeScore"`
 	AwayScore     sql.NullInt64 `json:"awayScore"`
 	Status        string        `json:"status"`
+	RoundNumber   sql.NullInt64 `json:"roundNumber"`
 }
 
 func (q *Queries) CreateLeagueMatch(ctx context.Context, arg CreateLeagueMatchParams) (LeagueMatch, error) {
@@ -197,6 +257,7 @@ func (q *Queries) CreateLeagueMatch(ctx context.Context, arg CreateLeagueMatchPa
 		arg.HomeScore,
 		arg.AwayScore,
 		arg.Status,
+		arg.RoundNumber,
 	)
 	var i LeagueMatch
 	err := row.Scan(
@@ -211,6 +272,58 @@ func (q *Queries) CreateLeagueMatch(ctx context.Context, arg CreateLeagueMatchPa
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RoundNumber,
+	)
+	return i, err
+}
+
+const createLeagueMatchResultSubmission = `-- name: CreateLeagueMatchResultSubmission :one
+INSERT INTO league_match_result_submissions (
+    league_match_id,
+    submitted_by_user_id,
+    home_score,
+    away_score,
+    respond_by
+) VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4,
+    ?5
+)
+RETURNING id, league_match_id, submitted_by_user_id, home_score, away_score, status,
+    respond_by, responded_by_user_id, responded_at, dispute_reason, created_at
+`
+
+type CreateLeagueMatchResultSubmissionParams struct {
+	LeagueMatchID     int64     `json:"leagueMatchId"`
+	SubmittedByUserID int64     `json:"submittedByUserId"`
+	HomeScore         int64     `json:"homeScore"`
+	AwayScore         int64     `json:"awayScore"`
+	RespondBy         time.Time `json:"respondBy"`
+}
+
+func (q *Queries) CreateLeagueMatchResultSubmission(ctx context.Context, arg CreateLeagueMatchResultSubmissionParams) (LeagueMatchResultSubmission, error) {
+	row := q.queryRow(ctx, q.createLeagueMatchResultSubmissionStmt, createLeagueMatchResultSubmission,
+		arg.LeagueMatchID,
+		arg.SubmittedByUserID,
+		arg.HomeScore,
+		arg.AwayScore,
+		arg.RespondBy,
+	)
+	var i LeagueMatchResultSubmission
+	err := row.Scan(
+		&i.ID,
+		&i.LeagueMatchID,
+		&i.SubmittedByUserID,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.Status,
+		&i.RespondBy,
+		&i.RespondedByUserID,
+		&i.RespondedAt,
+		&i.DisputeReason,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -227,7 +340,7 @@ INSERT INTO league_teams (
     ?3,
     ?4
 )
-RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at
+RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at, division
 `
 
 type CreateLeagueTeamParams struct {
@@ -253,6 +366,55 @@ func (q *Queries) CreateLeagueTeam(ctx context.Context, arg CreateLeagueTeamPara
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Division,
+	)
+	return i, err
+}
+
+const createLeagueTeamInvitation = `-- name: CreateLeagueTeamInvitation :one
+INSERT INTO league_team_invitations (
+    league_team_id,
+    invited_user_id,
+    invited_by_user_id,
+    token,
+    expires_at
+) VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4,
+    ?5
+)
+RETURNING id, league_team_id, invited_user_id, invited_by_user_id, token, status, expires_at, responded_at, created_at
+`
+
+type CreateLeagueTeamInvitationParams struct {
+	LeagueTeamID    int64     `json:"leagueTeamId"`
+	InvitedUserID   int64     `json:"invitedUserId"`
+	InvitedByUserID int64     `json:"invitedByUserId"`
+	Token           string    `json:"token"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+}
+
+func (q *Queries) CreateLeagueTeamInvitation(ctx context.Context, arg CreateLeagueTeamInvitationParams) (LeagueTeamInvitation, error) {
+	row := q.queryRow(ctx, q.createLeagueTeamInvitationStmt, createLeagueTeamInvitation,
+		arg.LeagueTeamID,
+		arg.InvitedUserID,
+		arg.InvitedByUserID,
+		arg.Token,
+		arg.ExpiresAt,
+	)
+	var i LeagueTeamInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.LeagueTeamID,
+		&i.InvitedUserID,
+		&i.InvitedByUserID,
+		&i.Token,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.RespondedAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -270,6 +432,25 @@ func (q *Queries) DeleteLeague(ctx context.Context, id int64) (int64, error) {
 	return result.RowsAffected()
 }
 
+const deleteLeagueFreeAgentRegistration = `-- name: DeleteLeagueFreeAgentRegistration :execrows
+DELETE FROM league_free_agents
+WHERE league_id = ?1
+  AND user_id = ?2
+`
+
+type DeleteLeagueFreeAgentRegistrationParams struct {
+	LeagueID int64 `json:"leagueId"`
+	UserID   int64 `json:"userId"`
+}
+
+func (q *Queries) DeleteLeagueFreeAgentRegistration(ctx context.Context, arg DeleteLeagueFreeAgentRegistrationParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteLeagueFreeAgentRegistrationStmt, deleteLeagueFreeAgentRegistration, arg.LeagueID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteLeagueMatchesByLeagueID = `-- name: DeleteLeagueMatchesByLeagueID :execrows
 DELETE FROM league_matches
 WHERE league_id = ?1
@@ -283,9 +464,26 @@ func (q *Queries) DeleteLeagueMatchesByLeagueID(ctx context.Context, leagueID in
 	return result.RowsAffected()
 }
 
+const getFirstLeagueMatchTime = `-- name: GetFirstLeagueMatchTime :one
+SELECT scheduled_time
+FROM league_matches
+WHERE league_id = ?1
+  AND status != 'cancelled'
+ORDER BY scheduled_time
+LIMIT 1
+`
+
+func (q *Queries) GetFirstLeagueMatchTime(ctx context.Context, leagueID int64) (time.Time, error) {
+	row := q.queryRow(ctx, q.getFirstLeagueMatchTimeStmt, getFirstLeagueMatchTime, leagueID)
+	var scheduled_time time.Time
+	err := row.Scan(&scheduled_time)
+	return scheduled_time, err
+}
+
 const getLeague = `-- name: GetLeague :one
 SELECT id, facility_id, name, format, start_date, end_date, division_config,
-    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at
+    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at,
+    registration_fee_cents, fee_basis
 FROM leagues
 WHERE id = ?1
 `
@@ -307,13 +505,42 @@ func (q *Queries) GetLeague(ctx context.Context, id int64) (League, error) {
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RegistrationFeeCents,
+		&i.FeeBasis,
+	)
+	return i, err
+}
+
+const getLeagueFreeAgentRegistration = `-- name: GetLeagueFreeAgentRegistration :one
+SELECT id, league_id, user_id, skill_level, availability_notes, gender, created_at
+FROM league_free_agents
+WHERE league_id = ?1
+  AND user_id = ?2
+`
+
+type GetLeagueFreeAgentRegistrationParams struct {
+	LeagueID int64 `json:"leagueId"`
+	UserID   int64 `json:"userId"`
+}
+
+func (q *Queries) GetLeagueFreeAgentRegistration(ctx context.Context, arg GetLeagueFreeAgentRegistrationParams) (LeagueFreeAgent, error) {
+	row := q.queryRow(ctx, q.getLeagueFreeAgentRegistrationStmt, getLeagueFreeAgentRegistration, arg.LeagueID, arg.UserID)
+	var i LeagueFreeAgent
+	err := row.Scan(
+		&i.ID,
+		&i.LeagueID,
+		&i.UserID,
+		&i.SkillLevel,
+		&i.AvailabilityNotes,
+		&i.Gender,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
 const getLeagueMatch = `-- name: GetLeagueMatch :one
 SELECT id, league_id, home_team_id, away_team_id, reservation_id,
-    scheduled_time, home_score, away_score, status, created_at, updated_at
+    scheduled_time, home_score, away_score, status, created_at, updated_at, round_number
 FROM league_matches
 WHERE id = ?1
   AND league_id = ?2
@@ -339,6 +566,33 @@ func (q *Queries) GetLeagueMatch(ctx context.Context, arg GetLeagueMatchParams)
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RoundNumber,
+	)
+	return i, err
+}
+
+const getLeagueMatchResultSubmission = `-- name: GetLeagueMatchResultSubmission :one
+SELECT id, league_match_id, submitted_by_user_id, home_score, away_score, status,
+    respond_by, responded_by_user_id, responded_at, dispute_reason, created_at
+FROM league_match_result_submissions
+WHERE league_match_id = ?1
+`
+
+func (q *Queries) GetLeagueMatchResultSubmission(ctx context.Context, leagueMatchID int64) (LeagueMatchResultSubmission, error) {
+	row := q.queryRow(ctx, q.getLeagueMatchResultSubmissionStmt, getLeagueMatchResultSubmission, leagueMatchID)
+	var i LeagueMatchResultSubmission
+	err := row.Scan(
+		&i.ID,
+		&i.LeagueMatchID,
+		&i.SubmittedByUserID,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.Status,
+		&i.RespondBy,
+		&i.RespondedByUserID,
+		&i.RespondedAt,
+		&i.DisputeReason,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -350,7 +604,8 @@ SELECT lt.id AS team_id,
     lm.home_team_id,
     lm.away_team_id,
     lm.home_score,
-    lm.away_score
+    lm.away_score,
+    lt.division
 FROM league_teams lt
 LEFT JOIN league_matches lm
     ON lm.league_id = lt.league_id
@@ -361,13 +616,14 @@ ORDER BY lt.name, lm.scheduled_time
 `
 
 type GetLeagueStandingsDataRow struct {
-	TeamID     int64         `json:"teamId"`
-	TeamName   string        `json:"teamName"`
-	MatchID    sql.NullInt64 `json:"matchId"`
-	HomeTeamID sql.NullInt64 `json:"homeTeamId"`
-	AwayTeamID sql.NullInt64 `json:"awayTeamId"`
-	HomeScore  sql.NullInt64 `json:"homeScore"`
-	AwayScore  sql.NullInt64 `json:"awayScore"`
+	TeamID     int64          `json:"teamId"`
+	TeamName   string         `json:"teamName"`
+	MatchID    sql.NullInt64  `json:"matchId"`
+	HomeTeamID sql.NullInt64  `json:"homeTeamId"`
+	AwayTeamID sql.NullInt64  `json:"awayTeamId"`
+	HomeScore  sql.NullInt64  `json:"homeScore"`
+	AwayScore  sql.NullInt64  `json:"awayScore"`
+	Division   sql.NullString `json:"division"`
 }
 
 func (q *Queries) GetLeagueStandingsData(ctx context.Context, leagueID int64) ([]GetLeagueStandingsDataRow, error) {
@@ -387,6 +643,7 @@ func (q *Queries) GetLeagueStandingsData(ctx context.Context, leagueID int64) ([
 			&i.AwayTeamID,
 			&i.HomeScore,
 			&i.AwayScore,
+			&i.Division,
 		); err != nil {
 			return nil, err
 		}
@@ -402,7 +659,7 @@ func (q *Queries) GetLeagueStandingsData(ctx context.Context, leagueID int64) ([
 }
 
 const getLeagueTeam = `-- name: GetLeagueTeam :one
-SELECT id, league_id, name, captain_user_id, status, created_at, updated_at
+SELECT id, league_id, name, captain_user_id, status, created_at, updated_at, division
 FROM league_teams
 WHERE id = ?1
 `
@@ -418,6 +675,42 @@ func (q *Queries) GetLeagueTeam(ctx context.Context, id int64) (LeagueTeam, erro
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Division,
+	)
+	return i, err
+}
+
+const getLeagueTeamInvitationByToken = `-- name: GetLeagueTeamInvitationByToken :one
+SELECT lti.id,
+    lti.league_team_id,
+    lti.invited_user_id,
+    lti.status,
+    lti.expires_at,
+    lt.league_id
+FROM league_team_invitations lti
+JOIN league_teams lt ON lt.id = lti.league_team_id
+WHERE lti.token = ?1
+`
+
+type GetLeagueTeamInvitationByTokenRow struct {
+	ID            int64     `json:"id"`
+	LeagueTeamID  int64     `json:"leagueTeamId"`
+	InvitedUserID int64     `json:"invitedUserId"`
+	Status        string    `json:"status"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	LeagueID      int64     `json:"leagueId"`
+}
+
+func (q *Queries) GetLeagueTeamInvitationByToken(ctx context.Context, token string) (GetLeagueTeamInvitationByTokenRow, error) {
+	row := q.queryRow(ctx, q.getLeagueTeamInvitationByTokenStmt, getLeagueTeamInvitationByToken, token)
+	var i GetLeagueTeamInvitationByTokenRow
+	err := row.Scan(
+		&i.ID,
+		&i.LeagueTeamID,
+		&i.InvitedUserID,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.LeagueID,
 	)
 	return i, err
 }
@@ -425,6 +718,7 @@ func (q *Queries) GetLeagueTeam(ctx context.Context, id int64) (LeagueTeam, erro
 const getLeagueWithFacilityTimezone = `-- name: GetLeagueWithFacilityTimezone :one
 SELECT l.id, l.facility_id, l.name, l.format, l.start_date, l.end_date, l.division_config,
     l.min_team_size, l.max_team_size, l.roster_lock_date, l.status, l.created_at, l.updated_at,
+    l.registration_fee_cents, l.fee_basis,
     f.timezone AS facility_timezone
 FROM leagues l
 JOIN facilities f ON f.id = l.facility_id
@@ -432,20 +726,22 @@ WHERE l.id = ?1
 `
 
 type GetLeagueWithFacilityTimezoneRow struct {
-	ID               int64        `json:"id"`
-	FacilityID       int64        `json:"facilityId"`
-	Name             string       `json:"name"`
-	Format           string       `json:"format"`
-	StartDate        time.Time    `json:"startDate"`
-	EndDate          time.Time    `json:"endDate"`
-	DivisionConfig   string       `json:"divisionConfig"`
-	MinTeamSize      int64        `json:"minTeamSize"`
-	MaxTeamSize      int64        `json:"maxTeamSize"`
-	RosterLockDate   sql.NullTime `json:"rosterLockDate"`
-	Status           string       `json:"status"`
-	CreatedAt        time.Time    `json:"createdAt"`
-	UpdatedAt        time.Time    `json:"updatedAt"`
-	FacilityTimezone string       `json:"facilityTimezone"`
+	ID                   int64        `json:"id"`
+	FacilityID           int64        `json:"facilityId"`
+	Name                 string       `json:"name"`
+	Format               string       `json:"format"`
+	StartDate            time.Time    `json:"startDate"`
+	EndDate              time.Time    `json:"endDate"`
+	DivisionConfig       string       `json:"divisionConfig"`
+	MinTeamSize          int64        `json:"minTeamSize"`
+	MaxTeamSize          int64        `json:"maxTeamSize"`
+	RosterLockDate       sql.NullTime `json:"rosterLockDate"`
+	Status               string       `json:"status"`
+	CreatedAt            time.Time    `json:"createdAt"`
+	UpdatedAt            time.Time    `json:"updatedAt"`
+	RegistrationFeeCents int64        `json:"registrationFeeCents"`
+	FeeBasis             string       `json:"feeBasis"`
+	FacilityTimezone     string       `json:"facilityTimezone"`
 }
 
 func (q *Queries) GetLeagueWithFacilityTimezone(ctx context.Context, id int64) (GetLeagueWithFacilityTimezoneRow, error) {
@@ -465,11 +761,35 @@ func (q *Queries) GetLeagueWithFacilityTimezone(ctx context.Context, id int64) (
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RegistrationFeeCents,
+		&i.FeeBasis,
 		&i.FacilityTimezone,
 	)
 	return i, err
 }
 
+const isUserOnLeagueTeam = `-- name: IsUserOnLeagueTeam :one
+SELECT EXISTS (
+    SELECT 1
+    FROM league_team_members ltm
+    JOIN league_teams lt ON lt.id = ltm.league_team_id
+    WHERE lt.league_id = ?1
+      AND ltm.user_id = ?2
+) AS on_team
+`
+
+type IsUserOnLeagueTeamParams struct {
+	LeagueID int64 `json:"leagueId"`
+	UserID   int64 `json:"userId"`
+}
+
+func (q *Queries) IsUserOnLeagueTeam(ctx context.Context, arg IsUserOnLeagueTeamParams) (int64, error) {
+	row := q.queryRow(ctx, q.isUserOnLeagueTeamStmt, isUserOnLeagueTeam, arg.LeagueID, arg.UserID)
+	var on_team int64
+	err := row.Scan(&on_team)
+	return on_team, err
+}
+
 const listFreeAgentsByLeague = `-- name: ListFreeAgentsByLeague :many
 SELECT ltm.id,
     ltm.league_team_id,
@@ -527,9 +847,57 @@ func (q *Queries) ListFreeAgentsByLeague(ctx context.Context, leagueID int64) ([
 	return items, nil
 }
 
+const listLapsedLeagueMatchResultSubmissions = `-- name: ListLapsedLeagueMatchResultSubmissions :many
+SELECT s.league_match_id,
+    lm.league_id,
+    s.home_score,
+    s.away_score
+FROM league_match_result_submissions s
+JOIN league_matches lm ON lm.id = s.league_match_id
+WHERE s.status = 'pending'
+  AND lm.status = 'pending_result'
+  AND s.respond_by <= ?1
+ORDER BY s.respond_by
+`
+
+type ListLapsedLeagueMatchResultSubmissionsRow struct {
+	LeagueMatchID int64 `json:"leagueMatchId"`
+	LeagueID      int64 `json:"leagueId"`
+	HomeScore     int64 `json:"homeScore"`
+	AwayScore     int64 `json:"awayScore"`
+}
+
+func (q *Queries) ListLapsedLeagueMatchResultSubmissions(ctx context.Context, now time.Time) ([]ListLapsedLeagueMatchResultSubmissionsRow, error) {
+	rows, err := q.query(ctx, q.listLapsedLeagueMatchResultSubmissionsStmt, listLapsedLeagueMatchResultSubmissions, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLapsedLeagueMatchResultSubmissionsRow
+	for rows.Next() {
+		var i ListLapsedLeagueMatchResultSubmissionsRow
+		if err := rows.Scan(
+			&i.LeagueMatchID,
+			&i.LeagueID,
+			&i.HomeScore,
+			&i.AwayScore,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listLeagueMatches = `-- name: ListLeagueMatches :many
 SELECT id, league_id, home_team_id, away_team_id, reservation_id,
-    scheduled_time, home_score, away_score, status, created_at, updated_at
+    scheduled_time, home_score, away_score, status, created_at, updated_at, round_number
 FROM league_matches
 WHERE league_id = ?1
 ORDER BY scheduled_time
@@ -556,6 +924,7 @@ func (q *Queries) ListLeagueMatches(ctx context.Context, leagueID int64) ([]Leag
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.RoundNumber,
 		); err != nil {
 			return nil, err
 		}
@@ -582,6 +951,7 @@ SELECT lm.id,
     lm.status,
     lm.created_at,
     lm.updated_at,
+    lm.round_number,
     r.start_time,
     r.end_time,
     CASE
@@ -603,6 +973,7 @@ GROUP BY lm.id,
     lm.status,
     lm.created_at,
     lm.updated_at,
+    lm.round_number,
     r.start_time,
     r.end_time
 ORDER BY lm.scheduled_time
@@ -620,6 +991,7 @@ type ListLeagueMatchesWithReservationsRow struct {
 	Status        string        `json:"status"`
 	CreatedAt     time.Time     `json:"createdAt"`
 	UpdatedAt     time.Time     `json:"updatedAt"`
+	RoundNumber   sql.NullInt64 `json:"roundNumber"`
 	StartTime     sql.NullTime  `json:"startTime"`
 	EndTime       sql.NullTime  `json:"endTime"`
 	CourtID       interface{}   `json:"courtId"`
@@ -646,6 +1018,7 @@ func (q *Queries) ListLeagueMatchesWithReservations(ctx context.Context, leagueI
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.RoundNumber,
 			&i.StartTime,
 			&i.EndTime,
 			&i.CourtID,
@@ -664,7 +1037,7 @@ func (q *Queries) ListLeagueMatchesWithReservations(ctx context.Context, leagueI
 }
 
 const listLeagueTeams = `-- name: ListLeagueTeams :many
-SELECT id, league_id, name, captain_user_id, status, created_at, updated_at
+SELECT id, league_id, name, captain_user_id, status, created_at, updated_at, division
 FROM league_teams
 WHERE league_id = ?1
 ORDER BY name
@@ -687,6 +1060,7 @@ func (q *Queries) ListLeagueTeams(ctx context.Context, leagueID int64) ([]League
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Division,
 		); err != nil {
 			return nil, err
 		}
@@ -703,7 +1077,8 @@ func (q *Queries) ListLeagueTeams(ctx context.Context, leagueID int64) ([]League
 
 const listLeaguesByFacility = `-- name: ListLeaguesByFacility :many
 SELECT id, facility_id, name, format, start_date, end_date, division_config,
-    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at
+    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at,
+    registration_fee_cents, fee_basis
 FROM leagues
 WHERE facility_id = ?1
 ORDER BY start_date DESC, name
@@ -732,6 +1107,76 @@ func (q *Queries) ListLeaguesByFacility(ctx context.Context, facilityID int64) (
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.RegistrationFeeCents,
+			&i.FeeBasis,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSelfRegisteredFreeAgentsByLeague = `-- name: ListSelfRegisteredFreeAgentsByLeague :many
+SELECT lfa.id,
+    lfa.user_id,
+    u.first_name,
+    u.last_name,
+    u.photo_url,
+    lfa.skill_level,
+    lfa.availability_notes,
+    lfa.gender,
+    lfa.created_at
+FROM league_free_agents lfa
+JOIN users u ON u.id = lfa.user_id
+WHERE lfa.league_id = ?1
+  AND NOT EXISTS (
+    SELECT 1
+    FROM league_team_members ltm
+    JOIN league_teams lt ON lt.id = ltm.league_team_id
+    WHERE lt.league_id = lfa.league_id
+      AND ltm.user_id = lfa.user_id
+  )
+ORDER BY u.last_name, u.first_name
+`
+
+type ListSelfRegisteredFreeAgentsByLeagueRow struct {
+	ID                int64          `json:"id"`
+	UserID            int64          `json:"userId"`
+	FirstName         string         `json:"firstName"`
+	LastName          string         `json:"lastName"`
+	PhotoUrl          sql.NullString `json:"photoUrl"`
+	SkillLevel        string         `json:"skillLevel"`
+	AvailabilityNotes sql.NullString `json:"availabilityNotes"`
+	Gender            sql.NullString `json:"gender"`
+	CreatedAt         time.Time      `json:"createdAt"`
+}
+
+func (q *Queries) ListSelfRegisteredFreeAgentsByLeague(ctx context.Context, leagueID int64) ([]ListSelfRegisteredFreeAgentsByLeagueRow, error) {
+	rows, err := q.query(ctx, q.listSelfRegisteredFreeAgentsByLeagueStmt, listSelfRegisteredFreeAgentsByLeague, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSelfRegisteredFreeAgentsByLeagueRow
+	for rows.Next() {
+		var i ListSelfRegisteredFreeAgentsByLeagueRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.FirstName,
+			&i.LastName,
+			&i.PhotoUrl,
+			&i.SkillLevel,
+			&i.AvailabilityNotes,
+			&i.Gender,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -782,6 +1227,44 @@ func (q *Queries) ListTeamMembers(ctx context.Context, leagueTeamID int64) ([]Le
 	return items, nil
 }
 
+const markLeagueTeamInvitationAccepted = `-- name: MarkLeagueTeamInvitationAccepted :execrows
+UPDATE league_team_invitations
+SET status = 'accepted',
+    responded_at = CURRENT_TIMESTAMP
+WHERE id = ?1
+  AND status = 'pending'
+`
+
+func (q *Queries) MarkLeagueTeamInvitationAccepted(ctx context.Context, id int64) (int64, error) {
+	result, err := q.exec(ctx, q.markLeagueTeamInvitationAcceptedStmt, markLeagueTeamInvitationAccepted, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const overrideLeagueMatchResultSubmission = `-- name: OverrideLeagueMatchResultSubmission :execrows
+UPDATE league_match_result_submissions
+SET status = 'overridden',
+    responded_by_user_id = ?1,
+    responded_at = CURRENT_TIMESTAMP
+WHERE league_match_id = ?2
+  AND status IN ('pending', 'disputed')
+`
+
+type OverrideLeagueMatchResultSubmissionParams struct {
+	RespondedByUserID sql.NullInt64 `json:"respondedByUserId"`
+	LeagueMatchID     int64         `json:"leagueMatchId"`
+}
+
+func (q *Queries) OverrideLeagueMatchResultSubmission(ctx context.Context, arg OverrideLeagueMatchResultSubmissionParams) (int64, error) {
+	result, err := q.exec(ctx, q.overrideLeagueMatchResultSubmissionStmt, overrideLeagueMatchResultSubmission, arg.RespondedByUserID, arg.LeagueMatchID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const removeTeamMember = `-- name: RemoveTeamMember :execrows
 DELETE FROM league_team_members
 WHERE league_team_id = ?1
@@ -801,6 +1284,36 @@ func (q *Queries) RemoveTeamMember(ctx context.Context, arg RemoveTeamMemberPara
 	return result.RowsAffected()
 }
 
+const respondToLeagueMatchResultSubmission = `-- name: RespondToLeagueMatchResultSubmission :execrows
+UPDATE league_match_result_submissions
+SET status = ?1,
+    responded_by_user_id = ?2,
+    responded_at = CURRENT_TIMESTAMP,
+    dispute_reason = ?3
+WHERE league_match_id = ?4
+  AND status = 'pending'
+`
+
+type RespondToLeagueMatchResultSubmissionParams struct {
+	Status            string         `json:"status"`
+	RespondedByUserID sql.NullInt64  `json:"respondedByUserId"`
+	DisputeReason     sql.NullString `json:"disputeReason"`
+	LeagueMatchID     int64          `json:"leagueMatchId"`
+}
+
+func (q *Queries) RespondToLeagueMatchResultSubmission(ctx context.Context, arg RespondToLeagueMatchResultSubmissionParams) (int64, error) {
+	result, err := q.exec(ctx, q.respondToLeagueMatchResultSubmissionStmt, respondToLeagueMatchResultSubmission,
+		arg.Status,
+		arg.RespondedByUserID,
+		arg.DisputeReason,
+		arg.LeagueMatchID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateLeague = `-- name: UpdateLeague :one
 UPDATE leagues
 SET name = ?1,
@@ -812,23 +1325,28 @@ SET name = ?1,
     max_team_size = ?7,
     roster_lock_date = ?8,
     status = ?9,
+    registration_fee_cents = ?10,
+    fee_basis = ?11,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = ?10
+WHERE id = ?12
 RETURNING id, facility_id, name, format, start_date, end_date, division_config,
-    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at
+    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at,
+    registration_fee_cents, fee_basis
 `
 
 type UpdateLeagueParams struct {
-	Name           string       `json:"name"`
-	Format         string       `json:"format"`
-	StartDate      time.Time    `json:"startDate"`
-	EndDate        time.Time    `json:"endDate"`
-	DivisionConfig string       `json:"divisionConfig"`
-	MinTeamSize    int64        `json:"minTeamSize"`
-	MaxTeamSize    int64        `json:"maxTeamSize"`
-	RosterLockDate sql.NullTime `json:"rosterLockDate"`
-	Status         string       `json:"status"`
-	ID             int64        `json:"id"`
+	Name                 string       `json:"name"`
+	Format               string       `json:"format"`
+	StartDate            time.Time    `json:"startDate"`
+	EndDate              time.Time    `json:"endDate"`
+	DivisionConfig       string       `json:"divisionConfig"`
+	MinTeamSize          int64        `json:"minTeamSize"`
+	MaxTeamSize          int64        `json:"maxTeamSize"`
+	RosterLockDate       sql.NullTime `json:"rosterLockDate"`
+	Status               string       `json:"status"`
+	RegistrationFeeCents int64        `json:"registrationFeeCents"`
+	FeeBasis             string       `json:"feeBasis"`
+	ID                   int64        `json:"id"`
 }
 
 func (q *Queries) UpdateLeague(ctx context.Context, arg UpdateLeagueParams) (League, error) {
@@ -842,6 +1360,8 @@ func (q *Queries) UpdateLeague(ctx context.Context, arg UpdateLeagueParams) (Lea
 		arg.MaxTeamSize,
 		arg.RosterLockDate,
 		arg.Status,
+		arg.RegistrationFeeCents,
+		arg.FeeBasis,
 		arg.ID,
 	)
 	var i League
@@ -859,10 +1379,34 @@ func (q *Queries) UpdateLeague(ctx context.Context, arg UpdateLeagueParams) (Lea
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RegistrationFeeCents,
+		&i.FeeBasis,
 	)
 	return i, err
 }
 
+const updateLeagueMatchStatus = `-- name: UpdateLeagueMatchStatus :execrows
+UPDATE league_matches
+SET status = ?1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?2
+  AND status = ?3
+`
+
+type UpdateLeagueMatchStatusParams struct {
+	Status         string `json:"status"`
+	ID             int64  `json:"id"`
+	ExpectedStatus string `json:"expectedStatus"`
+}
+
+func (q *Queries) UpdateLeagueMatchStatus(ctx context.Context, arg UpdateLeagueMatchStatusParams) (int64, error) {
+	result, err := q.exec(ctx, q.updateLeagueMatchStatusStmt, updateLeagueMatchStatus, arg.Status, arg.ID, arg.ExpectedStatus)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateLeagueTeam = `-- name: UpdateLeagueTeam :one
 UPDATE league_teams
 SET name = ?1,
@@ -870,7 +1414,7 @@ SET name = ?1,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?3
   AND league_id = ?4
-RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at
+RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at, division
 `
 
 type UpdateLeagueTeamParams struct {
@@ -896,6 +1440,43 @@ func (q *Queries) UpdateLeagueTeam(ctx context.Context, arg UpdateLeagueTeamPara
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Division,
+	)
+	return i, err
+}
+
+const updateLeagueTeamDivision = `-- name: UpdateLeagueTeamDivision :one
+UPDATE league_teams
+SET division = ?1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?2
+  AND league_id = ?3
+RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at, division
+`
+
+type UpdateLeagueTeamDivisionParams struct {
+	Division sql.NullString `json:"division"`
+	ID       int64          `json:"id"`
+	LeagueID int64          `json:"leagueId"`
+}
+
+// A NULL division leaves the team unassigned.
+func (q *Queries) UpdateLeagueTeamDivision(ctx context.Context, arg UpdateLeagueTeamDivisionParams) (LeagueTeam, error) {
+	row := q.queryRow(ctx, q.updateLeagueTeamDivisionStmt, updateLeagueTeamDivision,
+		arg.Division,
+		arg.ID,
+		arg.LeagueID,
+	)
+	var i LeagueTeam
+	err := row.Scan(
+		&i.ID,
+		&i.LeagueID,
+		&i.Name,
+		&i.CaptainUserID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Division,
 	)
 	return i, err
 }
@@ -909,7 +1490,7 @@ SET home_score = ?1,
 WHERE id = ?4
   AND league_id = ?5
 RETURNING id, league_id, home_team_id, away_team_id, reservation_id,
-    scheduled_time, home_score, away_score, status, created_at, updated_at
+    scheduled_time, home_score, away_score, status, created_at, updated_at, round_number
 `
 
 type UpdateMatchResultParams struct {
@@ -941,6 +1522,7 @@ func (q *Queries) UpdateMatchResult(ctx context.Context, arg UpdateMatchResultPa
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RoundNumber,
 	)
 	return i, err
 }
@@ -951,7 +1533,7 @@ SET captain_user_id = ?1,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?2
   AND league_id = ?3
-RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at
+RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at, division
 `
 
 type UpdateTeamCaptainParams struct {
@@ -971,6 +1553,7 @@ func (q *Queries) UpdateTeamCaptain(ctx context.Context, arg UpdateTeamCaptainPa
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Division,
 	)
 	return i, err
 }
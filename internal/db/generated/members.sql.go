@@ -11,6 +11,41 @@ import (
 	"time"
 )
 
+const anonymizeDeletedMembers = `-- name: AnonymizeDeletedMembers :execrows
+UPDATE users
+SET first_name = 'Former',
+    last_name = 'Member',
+    email = NULL,
+    phone = NULL,
+    cognito_sub = NULL,
+    photo_url = NULL,
+    street_address = NULL,
+    city = NULL,
+    state = NULL,
+    postal_code = NULL,
+    date_of_birth = '',
+    anonymized_at = ?1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE status = 'deleted'
+  AND anonymized_at IS NULL
+  AND deleted_at <= ?2
+`
+
+type AnonymizeDeletedMembersParams struct {
+	AnonymizedAt sql.NullTime `json:"anonymizedAt"`
+	Cutoff       sql.NullTime `json:"cutoff"`
+}
+
+// Historical reservations keep pointing at the row, so it stays with a
+// placeholder name and no way to contact or identify the member.
+func (q *Queries) AnonymizeDeletedMembers(ctx context.Context, arg AnonymizeDeletedMembersParams) (int64, error) {
+	result, err := q.exec(ctx, q.anonymizeDeletedMembersStmt, anonymizeDeletedMembers, arg.AnonymizedAt, arg.Cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createMember = `-- name: CreateMember :execlastid
 INSERT INTO users (
     first_name, last_name, email, phone,
@@ -64,6 +99,57 @@ func (q *Queries) CreateMember(ctx context.Context, arg CreateMemberParams) (int
 	return result.LastInsertId()
 }
 
+const createMemberHomeFacilityChange = `-- name: CreateMemberHomeFacilityChange :one
+INSERT INTO member_home_facility_changes (
+    user_id,
+    from_facility_id,
+    to_facility_id,
+    changed_by_user_id,
+    transferred_visit_packs,
+    reason
+) VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4,
+    ?5,
+    ?6
+)
+RETURNING id, user_id, from_facility_id, to_facility_id, changed_by_user_id, transferred_visit_packs, reason, created_at
+`
+
+type CreateMemberHomeFacilityChangeParams struct {
+	UserID                int64          `json:"userId"`
+	FromFacilityID        sql.NullInt64  `json:"fromFacilityId"`
+	ToFacilityID          int64          `json:"toFacilityId"`
+	ChangedByUserID       int64          `json:"changedByUserId"`
+	TransferredVisitPacks int64          `json:"transferredVisitPacks"`
+	Reason                sql.NullString `json:"reason"`
+}
+
+func (q *Queries) CreateMemberHomeFacilityChange(ctx context.Context, arg CreateMemberHomeFacilityChangeParams) (MemberHomeFacilityChange, error) {
+	row := q.queryRow(ctx, q.createMemberHomeFacilityChangeStmt, createMemberHomeFacilityChange,
+		arg.UserID,
+		arg.FromFacilityID,
+		arg.ToFacilityID,
+		arg.ChangedByUserID,
+		arg.TransferredVisitPacks,
+		arg.Reason,
+	)
+	var i MemberHomeFacilityChange
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FromFacilityID,
+		&i.ToFacilityID,
+		&i.ChangedByUserID,
+		&i.TransferredVisitPacks,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createPhoto = `-- name: CreatePhoto :one
 INSERT INTO user_photos (user_id, data, content_type, size)
 VALUES (?1, ?2, ?3, ?4)
@@ -89,15 +175,67 @@ func (q *Queries) CreatePhoto(ctx context.Context, arg CreatePhotoParams) (int64
 	return id, err
 }
 
-const deleteMember = `-- name: DeleteMember :exec
+const deleteAnonymizableMemberBilling = `-- name: DeleteAnonymizableMemberBilling :execrows
+DELETE FROM user_billing
+WHERE user_id IN (
+    SELECT id FROM users
+    WHERE status = 'deleted'
+      AND anonymized_at IS NULL
+      AND deleted_at <= ?1
+)
+`
+
+func (q *Queries) DeleteAnonymizableMemberBilling(ctx context.Context, cutoff sql.NullTime) (int64, error) {
+	result, err := q.exec(ctx, q.deleteAnonymizableMemberBillingStmt, deleteAnonymizableMemberBilling, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteAnonymizableMemberPhotos = `-- name: DeleteAnonymizableMemberPhotos :execrows
+DELETE FROM user_photos
+WHERE user_id IN (
+    SELECT id FROM users
+    WHERE status = 'deleted'
+      AND anonymized_at IS NULL
+      AND deleted_at <= ?1
+)
+`
+
+// Photos and billing go before the scrub below stamps anonymized_at, so the
+// three statements share one cutoff.
+func (q *Queries) DeleteAnonymizableMemberPhotos(ctx context.Context, cutoff sql.NullTime) (int64, error) {
+	result, err := q.exec(ctx, q.deleteAnonymizableMemberPhotosStmt, deleteAnonymizableMemberPhotos, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteMember = `-- name: DeleteMember :execrows
 UPDATE users
 SET status = 'deleted',
+    deleted_at = CURRENT_TIMESTAMP,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = ?1 AND is_member = 1
+WHERE id = ?1 AND is_member = 1 AND status != 'deleted'
 `
 
-func (q *Queries) DeleteMember(ctx context.Context, id int64) error {
-	_, err := q.exec(ctx, q.deleteMemberStmt, deleteMember, id)
+func (q *Queries) DeleteMember(ctx context.Context, id int64) (int64, error) {
+	result, err := q.exec(ctx, q.deleteMemberStmt, deleteMember, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteMemberSkillRating = `-- name: DeleteMemberSkillRating :exec
+DELETE FROM member_skill_ratings
+WHERE user_id = ?1
+`
+
+func (q *Queries) DeleteMemberSkillRating(ctx context.Context, userID int64) error {
+	_, err := q.exec(ctx, q.deleteMemberSkillRatingStmt, deleteMemberSkillRating, userID)
 	return err
 }
 
@@ -113,7 +251,7 @@ func (q *Queries) DeletePhoto(ctx context.Context, id int64) error {
 
 const getCreatedMember = `-- name: GetCreatedMember :one
 SELECT
-    u.id, u.email, u.phone, u.cognito_sub, u.cognito_status, u.preferred_auth_method, u.password_hash, u.local_auth_enabled, u.first_name, u.last_name, u.photo_url, u.street_address, u.city, u.state, u.postal_code, u.home_facility_id, u.is_member, u.is_staff, u.date_of_birth, u.waiver_signed, u.membership_level, u.staff_role, u.status, u.created_at, u.updated_at,
+    u.id, u.email, u.phone, u.cognito_sub, u.cognito_status, u.preferred_auth_method, u.password_hash, u.local_auth_enabled, u.first_name, u.last_name, u.photo_url, u.street_address, u.city, u.state, u.postal_code, u.home_facility_id, u.is_member, u.is_staff, u.date_of_birth, u.waiver_signed, u.membership_level, u.hide_from_rosters, u.notify_confirmations, u.notify_cancellations, u.notify_waitlist_offers, u.notify_open_play_reminders, u.sms_opt_in, u.email_undeliverable, u.staff_role, u.deleted_at, u.deletion_requested_at, u.anonymized_at, u.status, u.created_at, u.updated_at,
     p.id as photo_id,
     ub.card_type,
     ub.card_last_four,
@@ -128,38 +266,48 @@ WHERE u.id = last_insert_rowid()
 `
 
 type GetCreatedMemberRow struct {
-	ID                  int64          `json:"id"`
-	Email               sql.NullString `json:"email"`
-	Phone               sql.NullString `json:"phone"`
-	CognitoSub          sql.NullString `json:"cognitoSub"`
-	CognitoStatus       sql.NullString `json:"cognitoStatus"`
-	PreferredAuthMethod sql.NullString `json:"preferredAuthMethod"`
-	PasswordHash        sql.NullString `json:"passwordHash"`
-	LocalAuthEnabled    bool           `json:"localAuthEnabled"`
-	FirstName           string         `json:"firstName"`
-	LastName            string         `json:"lastName"`
-	PhotoUrl            sql.NullString `json:"photoUrl"`
-	StreetAddress       sql.NullString `json:"streetAddress"`
-	City                sql.NullString `json:"city"`
-	State               sql.NullString `json:"state"`
-	PostalCode          sql.NullString `json:"postalCode"`
-	HomeFacilityID      sql.NullInt64  `json:"homeFacilityId"`
-	IsMember            bool           `json:"isMember"`
-	IsStaff             bool           `json:"isStaff"`
-	DateOfBirth         string         `json:"dateOfBirth"`
-	WaiverSigned        bool           `json:"waiverSigned"`
-	MembershipLevel     int64          `json:"membershipLevel"`
-	StaffRole           sql.NullString `json:"staffRole"`
-	Status              string         `json:"status"`
-	CreatedAt           time.Time      `json:"createdAt"`
-	UpdatedAt           time.Time      `json:"updatedAt"`
-	PhotoID             sql.NullInt64  `json:"photoId"`
-	CardType            sql.NullString `json:"cardType"`
-	CardLastFour        sql.NullString `json:"cardLastFour"`
-	BillingAddress      sql.NullString `json:"billingAddress"`
-	BillingCity         sql.NullString `json:"billingCity"`
-	BillingState        sql.NullString `json:"billingState"`
-	BillingPostalCode   sql.NullString `json:"billingPostalCode"`
+	ID                      int64          `json:"id"`
+	Email                   sql.NullString `json:"email"`
+	Phone                   sql.NullString `json:"phone"`
+	CognitoSub              sql.NullString `json:"cognitoSub"`
+	CognitoStatus           sql.NullString `json:"cognitoStatus"`
+	PreferredAuthMethod     sql.NullString `json:"preferredAuthMethod"`
+	PasswordHash            sql.NullString `json:"passwordHash"`
+	LocalAuthEnabled        bool           `json:"localAuthEnabled"`
+	FirstName               string         `json:"firstName"`
+	LastName                string         `json:"lastName"`
+	PhotoUrl                sql.NullString `json:"photoUrl"`
+	StreetAddress           sql.NullString `json:"streetAddress"`
+	City                    sql.NullString `json:"city"`
+	State                   sql.NullString `json:"state"`
+	PostalCode              sql.NullString `json:"postalCode"`
+	HomeFacilityID          sql.NullInt64  `json:"homeFacilityId"`
+	IsMember                bool           `json:"isMember"`
+	IsStaff                 bool           `json:"isStaff"`
+	DateOfBirth             string         `json:"dateOfBirth"`
+	WaiverSigned            bool           `json:"waiverSigned"`
+	MembershipLevel         int64          `json:"membershipLevel"`
+	HideFromRosters         bool           `json:"hideFromRosters"`
+	NotifyConfirmations     bool           `json:"notifyConfirmations"`
+	NotifyCancellations     bool           `json:"notifyCancellations"`
+	NotifyWaitlistOffers    bool           `json:"notifyWaitlistOffers"`
+	NotifyOpenPlayReminders bool           `json:"notifyOpenPlayReminders"`
+	SmsOptIn                bool           `json:"smsOptIn"`
+	EmailUndeliverable      bool           `json:"emailUndeliverable"`
+	StaffRole               sql.NullString `json:"staffRole"`
+	DeletedAt               sql.NullTime   `json:"deletedAt"`
+	DeletionRequestedAt     sql.NullTime   `json:"deletionRequestedAt"`
+	AnonymizedAt            sql.NullTime   `json:"anonymizedAt"`
+	Status                  string         `json:"status"`
+	CreatedAt               time.Time      `json:"createdAt"`
+	UpdatedAt               time.Time      `json:"updatedAt"`
+	PhotoID                 sql.NullInt64  `json:"photoId"`
+	CardType                sql.NullString `json:"cardType"`
+	CardLastFour            sql.NullString `json:"cardLastFour"`
+	BillingAddress          sql.NullString `json:"billingAddress"`
+	BillingCity             sql.NullString `json:"billingCity"`
+	BillingState            sql.NullString `json:"billingState"`
+	BillingPostalCode       sql.NullString `json:"billingPostalCode"`
 }
 
 func (q *Queries) GetCreatedMember(ctx context.Context) (GetCreatedMemberRow, error) {
@@ -187,7 +335,17 @@ func (q *Queries) GetCreatedMember(ctx context.Context) (GetCreatedMemberRow, er
 		&i.DateOfBirth,
 		&i.WaiverSigned,
 		&i.MembershipLevel,
+		&i.HideFromRosters,
+		&i.NotifyConfirmations,
+		&i.NotifyCancellations,
+		&i.NotifyWaitlistOffers,
+		&i.NotifyOpenPlayReminders,
+		&i.SmsOptIn,
+		&i.EmailUndeliverable,
 		&i.StaffRole,
+		&i.DeletedAt,
+		&i.DeletionRequestedAt,
+		&i.AnonymizedAt,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -238,7 +396,7 @@ func (q *Queries) GetMemberBilling(ctx context.Context, userID int64) (GetMember
 }
 
 const getMemberByEmail = `-- name: GetMemberByEmail :one
-SELECT id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, staff_role, status, created_at, updated_at FROM users
+SELECT id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, hide_from_rosters, notify_confirmations, notify_cancellations, notify_waitlist_offers, notify_open_play_reminders, sms_opt_in, email_undeliverable, staff_role, deleted_at, deletion_requested_at, anonymized_at, status, created_at, updated_at FROM users
 WHERE email = ?1 AND is_member = 1 AND status != 'deleted'
 LIMIT 1
 `
@@ -268,7 +426,17 @@ func (q *Queries) GetMemberByEmail(ctx context.Context, email sql.NullString) (U
 		&i.DateOfBirth,
 		&i.WaiverSigned,
 		&i.MembershipLevel,
+		&i.HideFromRosters,
+		&i.NotifyConfirmations,
+		&i.NotifyCancellations,
+		&i.NotifyWaitlistOffers,
+		&i.NotifyOpenPlayReminders,
+		&i.SmsOptIn,
+		&i.EmailUndeliverable,
 		&i.StaffRole,
+		&i.DeletedAt,
+		&i.DeletionRequestedAt,
+		&i.AnonymizedAt,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -277,7 +445,7 @@ func (q *Queries) GetMemberByEmail(ctx context.Context, email sql.NullString) (U
 }
 
 const getMemberByEmailIncludeDeleted = `-- name: GetMemberByEmailIncludeDeleted :one
-SELECT id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, staff_role, status, created_at, updated_at FROM users
+SELECT id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, hide_from_rosters, notify_confirmations, notify_cancellations, notify_waitlist_offers, notify_open_play_reminders, sms_opt_in, email_undeliverable, staff_role, deleted_at, deletion_requested_at, anonymized_at, status, created_at, updated_at FROM users
 WHERE email = ?1
   AND email IS NOT NULL
   AND is_member = 1
@@ -309,7 +477,17 @@ func (q *Queries) GetMemberByEmailIncludeDeleted(ctx context.Context, email sql.
 		&i.DateOfBirth,
 		&i.WaiverSigned,
 		&i.MembershipLevel,
+		&i.HideFromRosters,
+		&i.NotifyConfirmations,
+		&i.NotifyCancellations,
+		&i.NotifyWaitlistOffers,
+		&i.NotifyOpenPlayReminders,
+		&i.SmsOptIn,
+		&i.EmailUndeliverable,
 		&i.StaffRole,
+		&i.DeletedAt,
+		&i.DeletionRequestedAt,
+		&i.AnonymizedAt,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -334,6 +512,8 @@ SELECT
     u.membership_level,
     u.created_at,
     u.updated_at,
+    u.deletion_requested_at,
+    u.email_undeliverable,
     up.id as photo_id
 FROM users u
 LEFT JOIN user_photos up ON up.user_id = u.id
@@ -341,22 +521,24 @@ WHERE u.id = ?1 AND u.is_member = 1 AND u.status != 'deleted'
 `
 
 type GetMemberByIDRow struct {
-	ID              int64          `json:"id"`
-	FirstName       string         `json:"firstName"`
-	LastName        string         `json:"lastName"`
-	Email           sql.NullString `json:"email"`
-	Phone           sql.NullString `json:"phone"`
-	StreetAddress   sql.NullString `json:"streetAddress"`
-	City            sql.NullString `json:"city"`
-	State           sql.NullString `json:"state"`
-	PostalCode      sql.NullString `json:"postalCode"`
-	Status          string         `json:"status"`
-	DateOfBirth     string         `json:"dateOfBirth"`
-	WaiverSigned    bool           `json:"waiverSigned"`
-	MembershipLevel int64          `json:"membershipLevel"`
-	CreatedAt       time.Time      `json:"createdAt"`
-	UpdatedAt       time.Time      `json:"updatedAt"`
-	PhotoID         sql.NullInt64  `json:"photoId"`
+	ID                  int64          `json:"id"`
+	FirstName           string         `json:"firstName"`
+	LastName            string         `json:"lastName"`
+	Email               sql.NullString `json:"email"`
+	Phone               sql.NullString `json:"phone"`
+	StreetAddress       sql.NullString `json:"streetAddress"`
+	City                sql.NullString `json:"city"`
+	State               sql.NullString `json:"state"`
+	PostalCode          sql.NullString `json:"postalCode"`
+	Status              string         `json:"status"`
+	DateOfBirth         string         `json:"dateOfBirth"`
+	WaiverSigned        bool           `json:"waiverSigned"`
+	MembershipLevel     int64          `json:"membershipLevel"`
+	CreatedAt           time.Time      `json:"createdAt"`
+	UpdatedAt           time.Time      `json:"updatedAt"`
+	DeletionRequestedAt sql.NullTime   `json:"deletionRequestedAt"`
+	EmailUndeliverable  bool           `json:"emailUndeliverable"`
+	PhotoID             sql.NullInt64  `json:"photoId"`
 }
 
 func (q *Queries) GetMemberByID(ctx context.Context, id int64) (GetMemberByIDRow, error) {
@@ -378,6 +560,8 @@ func (q *Queries) GetMemberByID(ctx context.Context, id int64) (GetMemberByIDRow
 		&i.MembershipLevel,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletionRequestedAt,
+		&i.EmailUndeliverable,
 		&i.PhotoID,
 	)
 	return i, err
@@ -401,6 +585,19 @@ func (q *Queries) GetMemberPhoto(ctx context.Context, userID int64) (GetMemberPh
 	return i, err
 }
 
+const getMemberSkillRating = `-- name: GetMemberSkillRating :one
+SELECT user_id, rating, updated_at
+FROM member_skill_ratings
+WHERE user_id = ?1
+`
+
+func (q *Queries) GetMemberSkillRating(ctx context.Context, userID int64) (MemberSkillRating, error) {
+	row := q.queryRow(ctx, q.getMemberSkillRatingStmt, getMemberSkillRating, userID)
+	var i MemberSkillRating
+	err := row.Scan(&i.UserID, &i.Rating, &i.UpdatedAt)
+	return i, err
+}
+
 const getPhoto = `-- name: GetPhoto :one
 SELECT data, content_type
 FROM user_photos
@@ -421,7 +618,7 @@ func (q *Queries) GetPhoto(ctx context.Context, id int64) (GetPhotoRow, error) {
 
 const getRestoredMember = `-- name: GetRestoredMember :one
 SELECT
-    u.id, u.email, u.phone, u.cognito_sub, u.cognito_status, u.preferred_auth_method, u.password_hash, u.local_auth_enabled, u.first_name, u.last_name, u.photo_url, u.street_address, u.city, u.state, u.postal_code, u.home_facility_id, u.is_member, u.is_staff, u.date_of_birth, u.waiver_signed, u.membership_level, u.staff_role, u.status, u.created_at, u.updated_at,
+    u.id, u.email, u.phone, u.cognito_sub, u.cognito_status, u.preferred_auth_method, u.password_hash, u.local_auth_enabled, u.first_name, u.last_name, u.photo_url, u.street_address, u.city, u.state, u.postal_code, u.home_facility_id, u.is_member, u.is_staff, u.date_of_birth, u.waiver_signed, u.membership_level, u.hide_from_rosters, u.notify_confirmations, u.notify_cancellations, u.notify_waitlist_offers, u.notify_open_play_reminders, u.sms_opt_in, u.email_undeliverable, u.staff_role, u.deleted_at, u.deletion_requested_at, u.anonymized_at, u.status, u.created_at, u.updated_at,
     p.id as photo_id,
     ub.card_type,
     ub.card_last_four,
@@ -436,38 +633,48 @@ WHERE u.id = ?1
 `
 
 type GetRestoredMemberRow struct {
-	ID                  int64          `json:"id"`
-	Email               sql.NullString `json:"email"`
-	Phone               sql.NullString `json:"phone"`
-	CognitoSub          sql.NullString `json:"cognitoSub"`
-	CognitoStatus       sql.NullString `json:"cognitoStatus"`
-	PreferredAuthMethod sql.NullString `json:"preferredAuthMethod"`
-	PasswordHash        sql.NullString `json:"passwordHash"`
-	LocalAuthEnabled    bool           `json:"localAuthEnabled"`
-	FirstName           string         `json:"firstName"`
-	LastName            string         `json:"lastName"`
-	PhotoUrl            sql.NullString `json:"photoUrl"`
-	StreetAddress       sql.NullString `json:"streetAddress"`
-	City                sql.NullString `json:"city"`
-	State               sql.NullString `json:"state"`
-	PostalCode          sql.NullString `json:"postalCode"`
-	HomeFacilityID      sql.NullInt64  `json:"homeFacilityId"`
-	IsMember            bool           `json:"isMember"`
-	IsStaff             bool           `json:"isStaff"`
-	DateOfBirth         string         `json:"dateOfBirth"`
-	WaiverSigned        bool           `json:"waiverSigned"`
-	MembershipLevel     int64          `json:"membershipLevel"`
-	StaffRole           sql.NullString `json:"staffRole"`
-	Status              string         `json:"status"`
-	CreatedAt           time.Time      `json:"createdAt"`
-	UpdatedAt           time.Time      `json:"updatedAt"`
-	PhotoID             sql.NullInt64  `json:"photoId"`
-	CardType            sql.NullString `json:"cardType"`
-	CardLastFour        sql.NullString `json:"cardLastFour"`
-	BillingAddress      sql.NullString `json:"billingAddress"`
-	BillingCity         sql.NullString `json:"billingCity"`
-	BillingState        sql.NullString `json:"billingState"`
-	BillingPostalCode   sql.NullString `json:"billingPostalCode"`
+	ID                      int64          `json:"id"`
+	Email                   sql.NullString `json:"email"`
+	Phone                   sql.NullString `json:"phone"`
+	CognitoSub              sql.NullString `json:"cognitoSub"`
+	CognitoStatus           sql.NullString `json:"cognitoStatus"`
+	PreferredAuthMethod     sql.NullString `json:"preferredAuthMethod"`
+	PasswordHash            sql.NullString `json:"passwordHash"`
+	LocalAuthEnabled        bool           `json:"localAuthEnabled"`
+	FirstName               string         `json:"firstName"`
+	LastName                string         `json:"lastName"`
+	PhotoUrl                sql.NullString `json:"photoUrl"`
+	StreetAddress           sql.NullString `json:"streetAddress"`
+	City                    sql.NullString `json:"city"`
+	State                   sql.NullString `json:"state"`
+	PostalCode              sql.NullString `json:"postalCode"`
+	HomeFacilityID          sql.NullInt64  `json:"homeFacilityId"`
+	IsMember                bool           `json:"isMember"`
+	IsStaff                 bool           `json:"isStaff"`
+	DateOfBirth             string         `json:"dateOfBirth"`
+	WaiverSigned            bool           `json:"waiverSigned"`
+	MembershipLevel         int64          `json:"membershipLevel"`
+	HideFromRosters         bool           `json:"hideFromRosters"`
+	NotifyConfirmations     bool           `json:"notifyConfirmations"`
+	NotifyCancellations     bool           `json:"notifyCancellations"`
+	NotifyWaitlistOffers    bool           `json:"notifyWaitlistOffers"`
+	NotifyOpenPlayReminders bool           `json:"notifyOpenPlayReminders"`
+	SmsOptIn                bool           `json:"smsOptIn"`
+	EmailUndeliverable      bool           `json:"emailUndeliverable"`
+	StaffRole               sql.NullString `json:"staffRole"`
+	DeletedAt               sql.NullTime   `json:"deletedAt"`
+	DeletionRequestedAt     sql.NullTime   `json:"deletionRequestedAt"`
+	AnonymizedAt            sql.NullTime   `json:"anonymizedAt"`
+	Status                  string         `json:"status"`
+	CreatedAt               time.Time      `json:"createdAt"`
+	UpdatedAt               time.Time      `json:"updatedAt"`
+	PhotoID                 sql.NullInt64  `json:"photoId"`
+	CardType                sql.NullString `json:"cardType"`
+	CardLastFour            sql.NullString `json:"cardLastFour"`
+	BillingAddress          sql.NullString `json:"billingAddress"`
+	BillingCity             sql.NullString `json:"billingCity"`
+	BillingState            sql.NullString `json:"billingState"`
+	BillingPostalCode       sql.NullString `json:"billingPostalCode"`
 }
 
 func (q *Queries) GetRestoredMember(ctx context.Context, id int64) (GetRestoredMemberRow, error) {
@@ -495,7 +702,17 @@ func (q *Queries) GetRestoredMember(ctx context.Context, id int64) (GetRestoredM
 		&i.DateOfBirth,
 		&i.WaiverSigned,
 		&i.MembershipLevel,
+		&i.HideFromRosters,
+		&i.NotifyConfirmations,
+		&i.NotifyCancellations,
+		&i.NotifyWaitlistOffers,
+		&i.NotifyOpenPlayReminders,
+		&i.SmsOptIn,
+		&i.EmailUndeliverable,
 		&i.StaffRole,
+		&i.DeletedAt,
+		&i.DeletionRequestedAt,
+		&i.AnonymizedAt,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -512,7 +729,7 @@ func (q *Queries) GetRestoredMember(ctx context.Context, id int64) (GetRestoredM
 
 const getUpdatedMember = `-- name: GetUpdatedMember :one
 SELECT
-    u.id, u.email, u.phone, u.cognito_sub, u.cognito_status, u.preferred_auth_method, u.password_hash, u.local_auth_enabled, u.first_name, u.last_name, u.photo_url, u.street_address, u.city, u.state, u.postal_code, u.home_facility_id, u.is_member, u.is_staff, u.date_of_birth, u.waiver_signed, u.membership_level, u.staff_role, u.status, u.created_at, u.updated_at,
+    u.id, u.email, u.phone, u.cognito_sub, u.cognito_status, u.preferred_auth_method, u.password_hash, u.local_auth_enabled, u.first_name, u.last_name, u.photo_url, u.street_address, u.city, u.state, u.postal_code, u.home_facility_id, u.is_member, u.is_staff, u.date_of_birth, u.waiver_signed, u.membership_level, u.hide_from_rosters, u.notify_confirmations, u.notify_cancellations, u.notify_waitlist_offers, u.notify_open_play_reminders, u.sms_opt_in, u.email_undeliverable, u.staff_role, u.deleted_at, u.deletion_requested_at, u.anonymized_at, u.status, u.created_at, u.updated_at,
     p.id as photo_id,
     ub.card_type,
     ub.card_last_four,
@@ -527,38 +744,48 @@ WHERE u.id = ?1
 `
 
 type GetUpdatedMemberRow struct {
-	ID                  int64          `json:"id"`
-	Email               sql.NullString `json:"email"`
-	Phone               sql.NullString `json:"phone"`
-	CognitoSub          sql.NullString `json:"cognitoSub"`
-	CognitoStatus       sql.NullString `json:"cognitoStatus"`
-	PreferredAuthMethod sql.NullString `json:"preferredAuthMethod"`
-	PasswordHash        sql.NullString `json:"passwordHash"`
-	LocalAuthEnabled    bool           `json:"localAuthEnabled"`
-	FirstName           string         `json:"firstName"`
-	LastName            string         `json:"lastName"`
-	PhotoUrl            sql.NullString `json:"photoUrl"`
-	StreetAddress       sql.NullString `json:"streetAddress"`
-	City                sql.NullString `json:"city"`
-	State               sql.NullString `json:"state"`
-	PostalCode          sql.NullString `json:"postalCode"`
-	HomeFacilityID      sql.NullInt64  `json:"homeFacilityId"`
-	IsMember            bool           `json:"isMember"`
-	IsStaff             bool           `json:"isStaff"`
-	DateOfBirth         string         `json:"dateOfBirth"`
-	WaiverSigned        bool           `json:"waiverSigned"`
-	MembershipLevel     int64          `json:"membershipLevel"`
-	StaffRole           sql.NullString `json:"staffRole"`
-	Status              string         `json:"status"`
-	CreatedAt           time.Time      `json:"createdAt"`
-	UpdatedAt           time.Time      `json:"updatedAt"`
-	PhotoID             sql.NullInt64  `json:"photoId"`
-	CardType            sql.NullString `json:"cardType"`
-	CardLastFour        sql.NullString `json:"cardLastFour"`
-	BillingAddress      sql.NullString `json:"billingAddress"`
-	BillingCity         sql.NullString `json:"billingCity"`
-	BillingState        sql.NullString `json:"billingState"`
-	BillingPostalCode   sql.NullString `json:"billingPostalCode"`
+	ID                      int64          `json:"id"`
+	Email                   sql.NullString `json:"email"`
+	Phone                   sql.NullString `json:"phone"`
+	CognitoSub              sql.NullString `json:"cognitoSub"`
+	CognitoStatus           sql.NullString `json:"cognitoStatus"`
+	PreferredAuthMethod     sql.NullString `json:"preferredAuthMethod"`
+	PasswordHash            sql.NullString `json:"passwordHash"`
+	LocalAuthEnabled        bool           `json:"localAuthEnabled"`
+	FirstName               string         `json:"firstName"`
+	LastName                string         `json:"lastName"`
+	PhotoUrl                sql.NullString `json:"photoUrl"`
+	StreetAddress           sql.NullString `json:"streetAddress"`
+	City                    sql.NullString `json:"city"`
+	State                   sql.NullString `json:"state"`
+	PostalCode              sql.NullString `json:"postalCode"`
+	HomeFacilityID          sql.NullInt64  `json:"homeFacilityId"`
+	IsMember                bool           `json:"isMember"`
+	IsStaff                 bool           `json:"isStaff"`
+	DateOfBirth             string         `json:"dateOfBirth"`
+	WaiverSigned            bool           `json:"waiverSigned"`
+	MembershipLevel         int64          `json:"membershipLevel"`
+	HideFromRosters         bool           `json:"hideFromRosters"`
+	NotifyConfirmations     bool           `json:"notifyConfirmations"`
+	NotifyCancellations     bool           `json:"notifyCancellations"`
+	NotifyWaitlistOffers    bool           `json:"notifyWaitlistOffers"`
+	NotifyOpenPlayReminders bool           `json:"notifyOpenPlayReminders"`
+	SmsOptIn                bool           `json:"smsOptIn"`
+	EmailUndeliverable      bool           `json:"emailUndeliverable"`
+	StaffRole               sql.NullString `json:"staffRole"`
+	DeletedAt               sql.NullTime   `json:"deletedAt"`
+	DeletionRequestedAt     sql.NullTime   `json:"deletionRequestedAt"`
+	AnonymizedAt            sql.NullTime   `json:"anonymizedAt"`
+	Status                  string         `json:"status"`
+	CreatedAt               time.Time      `json:"createdAt"`
+	UpdatedAt               time.Time      `json:"updatedAt"`
+	PhotoID                 sql.NullInt64  `json:"photoId"`
+	CardType                sql.NullString `json:"cardType"`
+	CardLastFour            sql.NullString `json:"cardLastFour"`
+	BillingAddress          sql.NullString `json:"billingAddress"`
+	BillingCity             sql.NullString `json:"billingCity"`
+	BillingState            sql.NullString `json:"billingState"`
+	BillingPostalCode       sql.NullString `json:"billingPostalCode"`
 }
 
 func (q *Queries) GetUpdatedMember(ctx context.Context, id int64) (GetUpdatedMemberRow, error) {
@@ -586,7 +813,17 @@ func (q *Queries) GetUpdatedMember(ctx context.Context, id int64) (GetUpdatedMem
 		&i.DateOfBirth,
 		&i.WaiverSigned,
 		&i.MembershipLevel,
+		&i.HideFromRosters,
+		&i.NotifyConfirmations,
+		&i.NotifyCancellations,
+		&i.NotifyWaitlistOffers,
+		&i.NotifyOpenPlayReminders,
+		&i.SmsOptIn,
+		&i.EmailUndeliverable,
 		&i.StaffRole,
+		&i.DeletedAt,
+		&i.DeletionRequestedAt,
+		&i.AnonymizedAt,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -601,10 +838,35 @@ func (q *Queries) GetUpdatedMember(ctx context.Context, id int64) (GetUpdatedMem
 	return i, err
 }
 
+const getUserPhoto = `-- name: GetUserPhoto :one
+SELECT data, content_type, thumbnail_data, updated_at
+FROM user_photos
+WHERE user_id = ?1
+`
+
+type GetUserPhotoRow struct {
+	Data          []byte    `json:"data"`
+	ContentType   string    `json:"contentType"`
+	ThumbnailData []byte    `json:"thumbnailData"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+func (q *Queries) GetUserPhoto(ctx context.Context, userID int64) (GetUserPhotoRow, error) {
+	row := q.queryRow(ctx, q.getUserPhotoStmt, getUserPhoto, userID)
+	var i GetUserPhotoRow
+	err := row.Scan(
+		&i.Data,
+		&i.ContentType,
+		&i.ThumbnailData,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const listMembers = `-- name: ListMembers :many
 
 SELECT
-    u.id, u.email, u.phone, u.cognito_sub, u.cognito_status, u.preferred_auth_method, u.password_hash, u.local_auth_enabled, u.first_name, u.last_name, u.photo_url, u.street_address, u.city, u.state, u.postal_code, u.home_facility_id, u.is_member, u.is_staff, u.date_of_birth, u.waiver_signed, u.membership_level, u.staff_role, u.status, u.created_at, u.updated_at,
+    u.id, u.email, u.phone, u.cognito_sub, u.cognito_status, u.preferred_auth_method, u.password_hash, u.local_auth_enabled, u.first_name, u.last_name, u.photo_url, u.street_address, u.city, u.state, u.postal_code, u.home_facility_id, u.is_member, u.is_staff, u.date_of_birth, u.waiver_signed, u.membership_level, u.hide_from_rosters, u.notify_confirmations, u.notify_cancellations, u.notify_waitlist_offers, u.notify_open_play_reminders, u.sms_opt_in, u.email_undeliverable, u.staff_role, u.deleted_at, u.deletion_requested_at, u.anonymized_at, u.status, u.created_at, u.updated_at,
     p.id as photo_id,
     ub.card_type,
     ub.card_last_four,
@@ -639,38 +901,48 @@ type ListMembersParams struct {
 }
 
 type ListMembersRow struct {
-	ID                  int64          `json:"id"`
-	Email               sql.NullString `json:"email"`
-	Phone               sql.NullString `json:"phone"`
-	CognitoSub          sql.NullString `json:"cognitoSub"`
-	CognitoStatus       sql.NullString `json:"cognitoStatus"`
-	PreferredAuthMethod sql.NullString `json:"preferredAuthMethod"`
-	PasswordHash        sql.NullString `json:"passwordHash"`
-	LocalAuthEnabled    bool           `json:"localAuthEnabled"`
-	FirstName           string         `json:"firstName"`
-	LastName            string         `json:"lastName"`
-	PhotoUrl            sql.NullString `json:"photoUrl"`
-	StreetAddress       sql.NullString `json:"streetAddress"`
-	City                sql.NullString `json:"city"`
-	State               sql.NullString `json:"state"`
-	PostalCode          sql.NullString `json:"postalCode"`
-	HomeFacilityID      sql.NullInt64  `json:"homeFacilityId"`
-	IsMember            bool           `json:"isMember"`
-	IsStaff             bool           `json:"isStaff"`
-	DateOfBirth         string         `json:"dateOfBirth"`
-	WaiverSigned        bool           `json:"waiverSigned"`
-	MembershipLevel     int64          `json:"membershipLevel"`
-	StaffRole           sql.NullString `json:"staffRole"`
-	Status              string         `json:"status"`
-	CreatedAt           time.Time      `json:"createdAt"`
-	UpdatedAt           time.Time      `json:"updatedAt"`
-	PhotoID             sql.NullInt64  `json:"photoId"`
-	CardType            sql.NullString `json:"cardType"`
-	CardLastFour        sql.NullString `json:"cardLastFour"`
-	BillingAddress      sql.NullString `json:"billingAddress"`
-	BillingCity         sql.NullString `json:"billingCity"`
-	BillingState        sql.NullString `json:"billingState"`
-	BillingPostalCode   sql.NullString `json:"billingPostalCode"`
+	ID                      int64          `json:"id"`
+	Email                   sql.NullString `json:"email"`
+	Phone                   sql.NullString `json:"phone"`
+	CognitoSub              sql.NullString `json:"cognitoSub"`
+	CognitoStatus           sql.NullString `json:"cognitoStatus"`
+	PreferredAuthMethod     sql.NullString `json:"preferredAuthMethod"`
+	PasswordHash            sql.NullString `json:"passwordHash"`
+	LocalAuthEnabled        bool           `json:"localAuthEnabled"`
+	FirstName               string         `json:"firstName"`
+	LastName                string         `json:"lastName"`
+	PhotoUrl                sql.NullString `json:"photoUrl"`
+	StreetAddress           sql.NullString `json:"streetAddress"`
+	City                    sql.NullString `json:"city"`
+	State                   sql.NullString `json:"state"`
+	PostalCode              sql.NullString `json:"postalCode"`
+	HomeFacilityID          sql.NullInt64  `json:"homeFacilityId"`
+	IsMember                bool           `json:"isMember"`
+	IsStaff                 bool           `json:"isStaff"`
+	DateOfBirth             string         `json:"dateOfBirth"`
+	WaiverSigned            bool           `json:"waiverSigned"`
+	MembershipLevel         int64          `json:"membershipLevel"`
+	HideFromRosters         bool           `json:"hideFromRosters"`
+	NotifyConfirmations     bool           `json:"notifyConfirmations"`
+	NotifyCancellations     bool           `json:"notifyCancellations"`
+	NotifyWaitlistOffers    bool           `json:"notifyWaitlistOffers"`
+	NotifyOpenPlayReminders bool           `json:"notifyOpenPlayReminders"`
+	SmsOptIn                bool           `json:"smsOptIn"`
+	EmailUndeliverable      bool           `json:"emailUndeliverable"`
+	StaffRole               sql.NullString `json:"staffRole"`
+	DeletedAt               sql.NullTime   `json:"deletedAt"`
+	DeletionRequestedAt     sql.NullTime   `json:"deletionRequestedAt"`
+	AnonymizedAt            sql.NullTime   `json:"anonymizedAt"`
+	Status                  string         `json:"status"`
+	CreatedAt               time.Time      `json:"createdAt"`
+	UpdatedAt               time.Time      `json:"updatedAt"`
+	PhotoID                 sql.NullInt64  `json:"photoId"`
+	CardType                sql.NullString `json:"cardType"`
+	CardLastFour            sql.NullString `json:"cardLastFour"`
+	BillingAddress          sql.NullString `json:"billingAddress"`
+	BillingCity             sql.NullString `json:"billingCity"`
+	BillingState            sql.NullString `json:"billingState"`
+	BillingPostalCode       sql.NullString `json:"billingPostalCode"`
 }
 
 // internal/db/queries/members.sql
@@ -712,7 +984,17 @@ func (q *Queries) ListMembers(ctx context.Context, arg ListMembersParams) ([]Lis
 			&i.DateOfBirth,
 			&i.WaiverSigned,
 			&i.MembershipLevel,
+			&i.HideFromRosters,
+			&i.NotifyConfirmations,
+			&i.NotifyCancellations,
+			&i.NotifyWaitlistOffers,
+			&i.NotifyOpenPlayReminders,
+			&i.SmsOptIn,
+			&i.EmailUndeliverable,
 			&i.StaffRole,
+			&i.DeletedAt,
+			&i.DeletionRequestedAt,
+			&i.AnonymizedAt,
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -737,11 +1019,28 @@ func (q *Queries) ListMembers(ctx context.Context, arg ListMembersParams) ([]Lis
 	return items, nil
 }
 
+const requestMemberDeletion = `-- name: RequestMemberDeletion :one
+UPDATE users
+SET deletion_requested_at = COALESCE(deletion_requested_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?1 AND is_member = 1 AND status != 'deleted'
+RETURNING deletion_requested_at
+`
+
+// Repeat requests keep the original timestamp.
+func (q *Queries) RequestMemberDeletion(ctx context.Context, id int64) (sql.NullTime, error) {
+	row := q.queryRow(ctx, q.requestMemberDeletionStmt, requestMemberDeletion, id)
+	var deletion_requested_at sql.NullTime
+	err := row.Scan(&deletion_requested_at)
+	return deletion_requested_at, err
+}
+
 const restoreMember = `-- name: RestoreMember :exec
 UPDATE users
 SET status = 'active',
+    deleted_at = NULL,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = ?1 AND is_member = 1
+WHERE id = ?1 AND is_member = 1 AND anonymized_at IS NULL
 `
 
 func (q *Queries) RestoreMember(ctx context.Context, id int64) error {
@@ -749,6 +1048,88 @@ func (q *Queries) RestoreMember(ctx context.Context, id int64) error {
 	return err
 }
 
+const searchFacilityMembers = `-- name: SearchFacilityMembers :many
+SELECT
+    u.id,
+    u.first_name,
+    u.last_name,
+    u.email,
+    u.phone,
+    msr.rating
+FROM users u
+LEFT JOIN member_skill_ratings msr ON msr.user_id = u.id
+WHERE u.is_member = 1
+    AND u.status = 'active'
+    AND u.home_facility_id = ?1
+    AND (
+        lower(substr(u.first_name, 1, length(?2))) = ?2
+        OR lower(substr(u.last_name, 1, length(?2))) = ?2
+        OR lower(substr(u.first_name || ' ' || u.last_name, 1, length(?2))) = ?2
+        OR lower(substr(u.email, 1, length(?2))) = ?2
+        OR substr(u.phone, 2, length(?3)) = ?3
+        OR (
+            substr(u.phone, 1, 2) = '+1'
+            AND substr(u.phone, 3, length(?3)) = ?3
+        )
+    )
+ORDER BY u.last_name, u.first_name, u.id
+LIMIT ?4
+`
+
+type SearchFacilityMembersParams struct {
+	FacilityID  sql.NullInt64 `json:"facilityId"`
+	Prefix      interface{}   `json:"prefix"`
+	PhonePrefix interface{}   `json:"phonePrefix"`
+	Limit       int64         `json:"limit"`
+}
+
+type SearchFacilityMembersRow struct {
+	ID        int64           `json:"id"`
+	FirstName string          `json:"firstName"`
+	LastName  string          `json:"lastName"`
+	Email     sql.NullString  `json:"email"`
+	Phone     sql.NullString  `json:"phone"`
+	Rating    sql.NullFloat64 `json:"rating"`
+}
+
+// Case-insensitive prefix match on name or email, or on phone digits with or
+// without the +1 country code, for the booking participant picker. @prefix
+// must be lowercase; @phone_prefix is digits only or NULL.
+func (q *Queries) SearchFacilityMembers(ctx context.Context, arg SearchFacilityMembersParams) ([]SearchFacilityMembersRow, error) {
+	rows, err := q.query(ctx, q.searchFacilityMembersStmt, searchFacilityMembers,
+		arg.FacilityID,
+		arg.Prefix,
+		arg.PhonePrefix,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchFacilityMembersRow
+	for rows.Next() {
+		var i SearchFacilityMembersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Phone,
+			&i.Rating,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchMembers = `-- name: SearchMembers :many
 SELECT
     u.id,
@@ -921,7 +1302,7 @@ UPDATE users
 SET email = ?1,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?2 AND is_member = 1
-RETURNING id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, staff_role, status, created_at, updated_at
+RETURNING id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, hide_from_rosters, notify_confirmations, notify_cancellations, notify_waitlist_offers, notify_open_play_reminders, sms_opt_in, email_undeliverable, staff_role, deleted_at, deletion_requested_at, anonymized_at, status, created_at, updated_at
 `
 
 type UpdateMemberEmailParams struct {
@@ -954,7 +1335,17 @@ func (q *Queries) UpdateMemberEmail(ctx context.Context, arg UpdateMemberEmailPa
 		&i.DateOfBirth,
 		&i.WaiverSigned,
 		&i.MembershipLevel,
+		&i.HideFromRosters,
+		&i.NotifyConfirmations,
+		&i.NotifyCancellations,
+		&i.NotifyWaitlistOffers,
+		&i.NotifyOpenPlayReminders,
+		&i.SmsOptIn,
+		&i.EmailUndeliverable,
 		&i.StaffRole,
+		&i.DeletedAt,
+		&i.DeletionRequestedAt,
+		&i.AnonymizedAt,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -962,22 +1353,45 @@ func (q *Queries) UpdateMemberEmail(ctx context.Context, arg UpdateMemberEmailPa
 	return i, err
 }
 
+const upsertMemberSkillRating = `-- name: UpsertMemberSkillRating :one
+INSERT INTO member_skill_ratings (user_id, rating)
+VALUES (?1, ?2)
+ON CONFLICT(user_id) DO UPDATE SET
+    rating = excluded.rating,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING user_id, rating, updated_at
+`
+
+type UpsertMemberSkillRatingParams struct {
+	UserID int64   `json:"userId"`
+	Rating float64 `json:"rating"`
+}
+
+func (q *Queries) UpsertMemberSkillRating(ctx context.Context, arg UpsertMemberSkillRatingParams) (MemberSkillRating, error) {
+	row := q.queryRow(ctx, q.upsertMemberSkillRatingStmt, upsertMemberSkillRating, arg.UserID, arg.Rating)
+	var i MemberSkillRating
+	err := row.Scan(&i.UserID, &i.Rating, &i.UpdatedAt)
+	return i, err
+}
+
 const upsertPhoto = `-- name: UpsertPhoto :one
-INSERT INTO user_photos (user_id, data, content_type, size)
-VALUES (?1, ?2, ?3, ?4)
+INSERT INTO user_photos (user_id, data, content_type, size, thumbnail_data)
+VALUES (?1, ?2, ?3, ?4, ?5)
 ON CONFLICT(user_id) DO UPDATE SET
     data = excluded.data,
     content_type = excluded.content_type,
     size = excluded.size,
+    thumbnail_data = excluded.thumbnail_data,
     updated_at = CURRENT_TIMESTAMP
-RETURNING id, user_id, data, content_type, size, created_at, updated_at
+RETURNING id, user_id, data, content_type, size, created_at, updated_at, thumbnail_data
 `
 
 type UpsertPhotoParams struct {
-	UserID      int64  `json:"userId"`
-	Data        []byte `json:"data"`
-	ContentType string `json:"contentType"`
-	Size        int64  `json:"size"`
+	UserID        int64  `json:"userId"`
+	Data          []byte `json:"data"`
+	ContentType   string `json:"contentType"`
+	Size          int64  `json:"size"`
+	ThumbnailData []byte `json:"thumbnailData"`
 }
 
 func (q *Queries) UpsertPhoto(ctx context.Context, arg UpsertPhotoParams) (UserPhoto, error) {
@@ -986,6 +1400,7 @@ func (q *Queries) UpsertPhoto(ctx context.Context, arg UpsertPhotoParams) (UserP
 		arg.Data,
 		arg.ContentType,
 		arg.Size,
+		arg.ThumbnailData,
 	)
 	var i UserPhoto
 	err := row.Scan(
@@ -996,6 +1411,7 @@ func (q *Queries) UpsertPhoto(ctx context.Context, arg UpsertPhotoParams) (UserP
 		&i.Size,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ThumbnailData,
 	)
 	return i, err
 }
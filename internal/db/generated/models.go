@@ -9,6 +9,27 @@ import (
 	"time"
 )
 
+type AccountCredit struct {
+	ID                  int64         `json:"id"`
+	UserID              int64         `json:"userId"`
+	FacilityID          int64         `json:"facilityId"`
+	AmountCents         int64         `json:"amountCents"`
+	RemainingCents      int64         `json:"remainingCents"`
+	SourceReservationID sql.NullInt64 `json:"sourceReservationId"`
+	Reason              string        `json:"reason"`
+	IssuedByUserID      int64         `json:"issuedByUserId"`
+	ExpiresAt           sql.NullTime  `json:"expiresAt"`
+	CreatedAt           time.Time     `json:"createdAt"`
+}
+
+type AccountCreditRedemption struct {
+	ID            int64     `json:"id"`
+	CreditID      int64     `json:"creditId"`
+	ReservationID int64     `json:"reservationId"`
+	AmountCents   int64     `json:"amountCents"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
 type CancellationPolicyTier struct {
 	ID                int64         `json:"id"`
 	FacilityID        int64         `json:"facilityId"`
@@ -68,30 +89,139 @@ type CognitoConfig struct {
 }
 
 type Court struct {
-	ID          int64     `json:"id"`
-	FacilityID  int64     `json:"facilityId"`
-	Name        string    `json:"name"`
-	CourtNumber int64     `json:"courtNumber"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          int64          `json:"id"`
+	FacilityID  int64          `json:"facilityId"`
+	Name        string         `json:"name"`
+	CourtNumber int64          `json:"courtNumber"`
+	Status      string         `json:"status"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	IsIndoor    bool           `json:"isIndoor"`
+	Surface     sql.NullString `json:"surface"`
+	HasLights   bool           `json:"hasLights"`
+}
+
+type CourtPricingRule struct {
+	ID                         int64     `json:"id"`
+	FacilityID                 int64     `json:"facilityId"`
+	DayOfWeek                  int64     `json:"dayOfWeek"`
+	StartTime                  string    `json:"startTime"`
+	EndTime                    string    `json:"endTime"`
+	MemberPricePerHourCents    int64     `json:"memberPricePerHourCents"`
+	NonMemberPricePerHourCents int64     `json:"nonMemberPricePerHourCents"`
+	CreatedAt                  time.Time `json:"createdAt"`
+	UpdatedAt                  time.Time `json:"updatedAt"`
+}
+
+type CourtSlotHold struct {
+	ID         int64     `json:"id"`
+	FacilityID int64     `json:"facilityId"`
+	CourtID    int64     `json:"courtId"`
+	UserID     int64     `json:"userId"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type DeferredEmail struct {
+	ID            int64         `json:"id"`
+	FacilityID    int64         `json:"facilityId"`
+	ReservationID sql.NullInt64 `json:"reservationId"`
+	UserID        int64         `json:"userId"`
+	Channel       string        `json:"channel"`
+	Recipient     string        `json:"recipient"`
+	Sender        string        `json:"sender"`
+	Subject       string        `json:"subject"`
+	Body          string        `json:"body"`
+	SendAfter     time.Time     `json:"sendAfter"`
+	Status        string        `json:"status"`
+	ProcessedAt   sql.NullTime  `json:"processedAt"`
+	CreatedAt     time.Time     `json:"createdAt"`
+}
+
+type EmailDeliveryEvent struct {
+	ID            int64          `json:"id"`
+	Email         string         `json:"email"`
+	EventType     string         `json:"eventType"`
+	BounceType    sql.NullString `json:"bounceType"`
+	BounceSubType sql.NullString `json:"bounceSubType"`
+	Diagnostic    sql.NullString `json:"diagnostic"`
+	SnsMessageID  string         `json:"snsMessageId"`
+	CreatedAt     time.Time      `json:"createdAt"`
 }
 
 type Facility struct {
-	ID                    int64          `json:"id"`
-	OrganizationID        int64          `json:"organizationId"`
-	Name                  string         `json:"name"`
-	Slug                  string         `json:"slug"`
-	Timezone              string         `json:"timezone"`
-	ActiveThemeID         sql.NullInt64  `json:"activeThemeId"`
-	EmailFromAddress      sql.NullString `json:"emailFromAddress"`
-	MaxAdvanceBookingDays int64          `json:"maxAdvanceBookingDays"`
-	MaxMemberReservations int64          `json:"maxMemberReservations"`
-	LessonMinNoticeHours  int64          `json:"lessonMinNoticeHours"`
-	ReminderHoursBefore   int64          `json:"reminderHoursBefore"`
-	TierBookingEnabled    bool           `json:"tierBookingEnabled"`
-	CreatedAt             time.Time      `json:"createdAt"`
-	UpdatedAt             time.Time      `json:"updatedAt"`
+	ID                        int64          `json:"id"`
+	OrganizationID            int64          `json:"organizationId"`
+	Name                      string         `json:"name"`
+	Slug                      string         `json:"slug"`
+	Timezone                  string         `json:"timezone"`
+	ActiveThemeID             sql.NullInt64  `json:"activeThemeId"`
+	EmailFromAddress          sql.NullString `json:"emailFromAddress"`
+	MaxAdvanceBookingDays     int64          `json:"maxAdvanceBookingDays"`
+	MaxMemberReservations     int64          `json:"maxMemberReservations"`
+	MaxCourtsPerMemberBooking int64          `json:"maxCourtsPerMemberBooking"`
+	LessonMinNoticeHours      int64          `json:"lessonMinNoticeHours"`
+	ReminderHoursBefore       int64          `json:"reminderHoursBefore"`
+	TierBookingEnabled        bool           `json:"tierBookingEnabled"`
+	CreatedAt                 time.Time      `json:"createdAt"`
+	UpdatedAt                 time.Time      `json:"updatedAt"`
+	SlotDurationMinutes       int64          `json:"slotDurationMinutes"`
+	MinBookingMinutes         int64          `json:"minBookingMinutes"`
+	MaxGuestsPerReservation   int64          `json:"maxGuestsPerReservation"`
+	ReservationLimitScope     string         `json:"reservationLimitScope"`
+	BufferMinutes             int64          `json:"bufferMinutes"`
+	Address                   sql.NullString `json:"address"`
+	Phone                     sql.NullString `json:"phone"`
+}
+
+type FacilityAnnouncement struct {
+	ID              int64         `json:"id"`
+	FacilityID      int64         `json:"facilityId"`
+	Title           string        `json:"title"`
+	Body            string        `json:"body"`
+	Severity        string        `json:"severity"`
+	Audience        string        `json:"audience"`
+	StartsAt        time.Time     `json:"startsAt"`
+	EndsAt          time.Time     `json:"endsAt"`
+	CreatedByUserID sql.NullInt64 `json:"createdByUserId"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+}
+
+type FacilityAnnouncementDismissal struct {
+	AnnouncementID int64     `json:"announcementId"`
+	UserID         int64     `json:"userId"`
+	DismissedAt    time.Time `json:"dismissedAt"`
+}
+
+type FacilityHoursOverride struct {
+	ID           int64          `json:"id"`
+	FacilityID   int64          `json:"facilityId"`
+	OverrideDate string         `json:"overrideDate"`
+	IsClosed     bool           `json:"isClosed"`
+	OpensAt      sql.NullString `json:"opensAt"`
+	ClosesAt     sql.NullString `json:"closesAt"`
+	Reason       sql.NullString `json:"reason"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	UpdatedAt    time.Time      `json:"updatedAt"`
+}
+
+type FacilityNoShowPolicy struct {
+	FacilityID          int64     `json:"facilityId"`
+	MaxNoShowsPer30Days int64     `json:"maxNoShowsPer30Days"`
+	RestrictionDays     int64     `json:"restrictionDays"`
+	CreatedAt           time.Time `json:"createdAt"`
+	UpdatedAt           time.Time `json:"updatedAt"`
+}
+
+type FacilityQuietHour struct {
+	FacilityID int64     `json:"facilityId"`
+	StartsAt   string    `json:"startsAt"`
+	EndsAt     string    `json:"endsAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
 }
 
 type FacilityVisit struct {
@@ -107,20 +237,67 @@ type FacilityVisit struct {
 	UpdatedAt            time.Time      `json:"updatedAt"`
 }
 
+type HouseholdLink struct {
+	ID              int64        `json:"id"`
+	PrimaryUserID   int64        `json:"primaryUserId"`
+	DependentUserID int64        `json:"dependentUserId"`
+	Token           string       `json:"token"`
+	Status          string       `json:"status"`
+	ExpiresAt       time.Time    `json:"expiresAt"`
+	RespondedAt     sql.NullTime `json:"respondedAt"`
+	CreatedAt       time.Time    `json:"createdAt"`
+}
+
+type ImpersonationAuditLog struct {
+	ID                 int64          `json:"id"`
+	ImpersonatorUserID int64          `json:"impersonatorUserId"`
+	MemberUserID       int64          `json:"memberUserId"`
+	Action             string         `json:"action"`
+	Method             sql.NullString `json:"method"`
+	Path               sql.NullString `json:"path"`
+	Status             sql.NullInt64  `json:"status"`
+	CreatedAt          time.Time      `json:"createdAt"`
+}
+
+type JobStatus struct {
+	Name                string         `json:"name"`
+	LastTrigger         string         `json:"lastTrigger"`
+	LastStartedAt       time.Time      `json:"lastStartedAt"`
+	LastFinishedAt      time.Time      `json:"lastFinishedAt"`
+	LastDurationMs      int64          `json:"lastDurationMs"`
+	LastItems           int64          `json:"lastItems"`
+	LastError           sql.NullString `json:"lastError"`
+	ConsecutiveFailures int64          `json:"consecutiveFailures"`
+	LastSuccessAt       sql.NullTime   `json:"lastSuccessAt"`
+	UpdatedAt           time.Time      `json:"updatedAt"`
+}
+
 type League struct {
-	ID             int64        `json:"id"`
-	FacilityID     int64        `json:"facilityId"`
-	Name           string       `json:"name"`
-	Format         string       `json:"format"`
-	StartDate      time.Time    `json:"startDate"`
-	EndDate        time.Time    `json:"endDate"`
-	DivisionConfig string       `json:"divisionConfig"`
-	MinTeamSize    int64        `json:"minTeamSize"`
-	MaxTeamSize    int64        `json:"maxTeamSize"`
-	RosterLockDate sql.NullTime `json:"rosterLockDate"`
-	Status         string       `json:"status"`
-	CreatedAt      time.Time    `json:"createdAt"`
-	UpdatedAt      time.Time    `json:"updatedAt"`
+	ID                   int64        `json:"id"`
+	FacilityID           int64        `json:"facilityId"`
+	Name                 string       `json:"name"`
+	Format               string       `json:"format"`
+	StartDate            time.Time    `json:"startDate"`
+	EndDate              time.Time    `json:"endDate"`
+	DivisionConfig       string       `json:"divisionConfig"`
+	MinTeamSize          int64        `json:"minTeamSize"`
+	MaxTeamSize          int64        `json:"maxTeamSize"`
+	RosterLockDate       sql.NullTime `json:"rosterLockDate"`
+	Status               string       `json:"status"`
+	CreatedAt            time.Time    `json:"createdAt"`
+	UpdatedAt            time.Time    `json:"updatedAt"`
+	RegistrationFeeCents int64        `json:"registrationFeeCents"`
+	FeeBasis             string       `json:"feeBasis"`
+}
+
+type LeagueFreeAgent struct {
+	ID                int64          `json:"id"`
+	LeagueID          int64          `json:"leagueId"`
+	UserID            int64          `json:"userId"`
+	SkillLevel        string         `json:"skillLevel"`
+	AvailabilityNotes sql.NullString `json:"availabilityNotes"`
+	Gender            sql.NullString `json:"gender"`
+	CreatedAt         time.Time      `json:"createdAt"`
 }
 
 type LeagueMatch struct {
@@ -135,16 +312,55 @@ type LeagueMatch struct {
 	Status        string        `json:"status"`
 	CreatedAt     time.Time     `json:"createdAt"`
 	UpdatedAt     time.Time     `json:"updatedAt"`
+	RoundNumber   sql.NullInt64 `json:"roundNumber"`
+}
+
+type LeagueMatchResultSubmission struct {
+	ID                int64          `json:"id"`
+	LeagueMatchID     int64          `json:"leagueMatchId"`
+	SubmittedByUserID int64          `json:"submittedByUserId"`
+	HomeScore         int64          `json:"homeScore"`
+	AwayScore         int64          `json:"awayScore"`
+	Status            string         `json:"status"`
+	RespondBy         time.Time      `json:"respondBy"`
+	RespondedByUserID sql.NullInt64  `json:"respondedByUserId"`
+	RespondedAt       sql.NullTime   `json:"respondedAt"`
+	DisputeReason     sql.NullString `json:"disputeReason"`
+	CreatedAt         time.Time      `json:"createdAt"`
+}
+
+type LeaguePayment struct {
+	ID               int64          `json:"id"`
+	LeagueTeamID     int64          `json:"leagueTeamId"`
+	UserID           sql.NullInt64  `json:"userId"`
+	AmountCents      int64          `json:"amountCents"`
+	Method           string         `json:"method"`
+	Note             sql.NullString `json:"note"`
+	RecordedByUserID int64          `json:"recordedByUserId"`
+	CreatedAt        time.Time      `json:"createdAt"`
 }
 
 type LeagueTeam struct {
-	ID            int64     `json:"id"`
-	LeagueID      int64     `json:"leagueId"`
-	Name          string    `json:"name"`
-	CaptainUserID int64     `json:"captainUserId"`
-	Status        string    `json:"status"`
-	CreatedAt     time.Time `json:"createdAt"`
-	UpdatedAt     time.Time `json:"updatedAt"`
+	ID            int64          `json:"id"`
+	LeagueID      int64          `json:"leagueId"`
+	Name          string         `json:"name"`
+	CaptainUserID int64          `json:"captainUserId"`
+	Status        string         `json:"status"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+	Division      sql.NullString `json:"division"`
+}
+
+type LeagueTeamInvitation struct {
+	ID              int64        `json:"id"`
+	LeagueTeamID    int64        `json:"leagueTeamId"`
+	InvitedUserID   int64        `json:"invitedUserId"`
+	InvitedByUserID int64        `json:"invitedByUserId"`
+	Token           string       `json:"token"`
+	Status          string       `json:"status"`
+	ExpiresAt       time.Time    `json:"expiresAt"`
+	RespondedAt     sql.NullTime `json:"respondedAt"`
+	CreatedAt       time.Time    `json:"createdAt"`
 }
 
 type LeagueTeamMember struct {
@@ -188,12 +404,83 @@ type LessonPackageType struct {
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
 
+type MemberCard struct {
+	ID        int64        `json:"id"`
+	UserID    int64        `json:"userId"`
+	CardToken string       `json:"cardToken"`
+	IssuedAt  time.Time    `json:"issuedAt"`
+	RevokedAt sql.NullTime `json:"revokedAt"`
+}
+
+type MemberGuestPass struct {
+	UserID     int64     `json:"userId"`
+	FacilityID int64     `json:"facilityId"`
+	Balance    int64     `json:"balance"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+type MemberHomeFacilityChange struct {
+	ID                    int64          `json:"id"`
+	UserID                int64          `json:"userId"`
+	FromFacilityID        sql.NullInt64  `json:"fromFacilityId"`
+	ToFacilityID          int64          `json:"toFacilityId"`
+	ChangedByUserID       int64          `json:"changedByUserId"`
+	TransferredVisitPacks int64          `json:"transferredVisitPacks"`
+	Reason                sql.NullString `json:"reason"`
+	CreatedAt             time.Time      `json:"createdAt"`
+}
+
+type MemberRatingHistory struct {
+	ID               int64          `json:"id"`
+	UserID           int64          `json:"userId"`
+	Rating           float64        `json:"rating"`
+	Source           string         `json:"source"`
+	Verified         bool           `json:"verified"`
+	RecordedByUserID sql.NullInt64  `json:"recordedByUserId"`
+	VerifiedByUserID sql.NullInt64  `json:"verifiedByUserId"`
+	VerifiedAt       sql.NullTime   `json:"verifiedAt"`
+	Note             sql.NullString `json:"note"`
+	CreatedAt        time.Time      `json:"createdAt"`
+}
+
+type MemberSkillRating struct {
+	UserID    int64     `json:"userId"`
+	Rating    float64   `json:"rating"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
 type MemberTierBookingWindow struct {
 	FacilityID      int64 `json:"facilityId"`
 	MembershipLevel int64 `json:"membershipLevel"`
 	MaxAdvanceDays  int64 `json:"maxAdvanceDays"`
 }
 
+type MembershipTier struct {
+	ID                    int64         `json:"id"`
+	OrganizationID        int64         `json:"organizationId"`
+	Level                 int64         `json:"level"`
+	Name                  string        `json:"name"`
+	BookingWindowDays     sql.NullInt64 `json:"bookingWindowDays"`
+	MaxActiveReservations sql.NullInt64 `json:"maxActiveReservations"`
+	PrimeTimeAccess       bool          `json:"primeTimeAccess"`
+	GuestPassesPerMonth   int64         `json:"guestPassesPerMonth"`
+	VisitPacks            bool          `json:"visitPacks"`
+	CreatedAt             time.Time     `json:"createdAt"`
+	UpdatedAt             time.Time     `json:"updatedAt"`
+}
+
+type NoShowRestrictionClear struct {
+	ID              int64     `json:"id"`
+	UserID          int64     `json:"userId"`
+	FacilityID      int64     `json:"facilityId"`
+	RestrictedUntil time.Time `json:"restrictedUntil"`
+	ClearedAt       time.Time `json:"clearedAt"`
+	ClearedByUserID int64     `json:"clearedByUserId"`
+	Reason          string    `json:"reason"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
 type OpenPlayAuditLog struct {
 	ID          int64          `json:"id"`
 	SessionID   int64          `json:"sessionId"`
@@ -204,18 +491,53 @@ type OpenPlayAuditLog struct {
 	CreatedAt   time.Time      `json:"createdAt"`
 }
 
+type OpenPlayGame struct {
+	ID               int64     `json:"id"`
+	SessionID        int64     `json:"sessionId"`
+	RecordedByUserID int64     `json:"recordedByUserId"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+type OpenPlayGamePlayer struct {
+	GameID int64 `json:"gameId"`
+	UserID int64 `json:"userId"`
+}
+
+type OpenPlayQueueEntry struct {
+	ID            int64     `json:"id"`
+	SessionID     int64     `json:"sessionId"`
+	UserID        int64     `json:"userId"`
+	QueuePosition int64     `json:"queuePosition"`
+	GamesPlayed   int64     `json:"gamesPlayed"`
+	JoinedAt      time.Time `json:"joinedAt"`
+}
+
 type OpenPlayRule struct {
-	ID                        int64     `json:"id"`
-	FacilityID                int64     `json:"facilityId"`
-	Name                      string    `json:"name"`
-	MinParticipants           int64     `json:"minParticipants"`
-	MaxParticipantsPerCourt   int64     `json:"maxParticipantsPerCourt"`
-	CancellationCutoffMinutes int64     `json:"cancellationCutoffMinutes"`
-	AutoScaleEnabled          bool      `json:"autoScaleEnabled"`
-	MinCourts                 int64     `json:"minCourts"`
-	MaxCourts                 int64     `json:"maxCourts"`
-	CreatedAt                 time.Time `json:"createdAt"`
-	UpdatedAt                 time.Time `json:"updatedAt"`
+	ID                        int64          `json:"id"`
+	FacilityID                int64          `json:"facilityId"`
+	Name                      string         `json:"name"`
+	MinParticipants           int64          `json:"minParticipants"`
+	MaxParticipantsPerCourt   int64          `json:"maxParticipantsPerCourt"`
+	CancellationCutoffMinutes int64          `json:"cancellationCutoffMinutes"`
+	AutoScaleEnabled          bool           `json:"autoScaleEnabled"`
+	MinCourts                 int64          `json:"minCourts"`
+	MaxCourts                 int64          `json:"maxCourts"`
+	CreatedAt                 time.Time      `json:"createdAt"`
+	UpdatedAt                 time.Time      `json:"updatedAt"`
+	MinMembershipLevel        int64          `json:"minMembershipLevel"`
+	MaxMembershipLevel        sql.NullInt64  `json:"maxMembershipLevel"`
+	SkillBands                sql.NullString `json:"skillBands"`
+}
+
+type OpenPlayRuleSlot struct {
+	ID              int64     `json:"id"`
+	OpenPlayRuleID  int64     `json:"openPlayRuleId"`
+	DayOfWeek       int64     `json:"dayOfWeek"`
+	StartTime       string    `json:"startTime"`
+	EndTime         string    `json:"endTime"`
+	CreatedByUserID int64     `json:"createdByUserId"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
 }
 
 type OpenPlaySession struct {
@@ -233,6 +555,12 @@ type OpenPlaySession struct {
 	UpdatedAt          time.Time      `json:"updatedAt"`
 }
 
+type OpenPlaySessionSkillBand struct {
+	SessionID  int64     `json:"sessionId"`
+	SkillBands string    `json:"skillBands"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
 type OperatingHour struct {
 	ID         int64       `json:"id"`
 	FacilityID int64       `json:"facilityId"`
@@ -244,15 +572,29 @@ type OperatingHour struct {
 }
 
 type Organization struct {
-	ID                      int64          `json:"id"`
-	Name                    string         `json:"name"`
-	Slug                    string         `json:"slug"`
-	EmailFromAddress        sql.NullString `json:"emailFromAddress"`
-	ReminderHoursBefore     int64          `json:"reminderHoursBefore"`
-	CrossFacilityVisitPacks bool           `json:"crossFacilityVisitPacks"`
-	Status                  string         `json:"status"`
-	CreatedAt               time.Time      `json:"createdAt"`
-	UpdatedAt               time.Time      `json:"updatedAt"`
+	ID                        int64          `json:"id"`
+	Name                      string         `json:"name"`
+	Slug                      string         `json:"slug"`
+	EmailFromAddress          sql.NullString `json:"emailFromAddress"`
+	ReminderHoursBefore       int64          `json:"reminderHoursBefore"`
+	CrossFacilityVisitPacks   bool           `json:"crossFacilityVisitPacks"`
+	Status                    string         `json:"status"`
+	CreatedAt                 time.Time      `json:"createdAt"`
+	UpdatedAt                 time.Time      `json:"updatedAt"`
+	SharedMemberDirectory     bool           `json:"sharedMemberDirectory"`
+	DefaultCancellationPolicy sql.NullString `json:"defaultCancellationPolicy"`
+}
+
+type PrimeTimeRule struct {
+	ID                 int64     `json:"id"`
+	FacilityID         int64     `json:"facilityId"`
+	DayOfWeek          int64     `json:"dayOfWeek"`
+	StartTime          string    `json:"startTime"`
+	EndTime            string    `json:"endTime"`
+	MinMembershipLevel int64     `json:"minMembershipLevel"`
+	UnlockHoursBefore  int64     `json:"unlockHoursBefore"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
 }
 
 type ProUnavailability struct {
@@ -292,15 +634,48 @@ type Reservation struct {
 	UpdatedAt         time.Time     `json:"updatedAt"`
 }
 
+type ReservationAccessRevocation struct {
+	ReservationID   int64          `json:"reservationId"`
+	RevokedByUserID int64          `json:"revokedByUserId"`
+	Reason          sql.NullString `json:"reason"`
+	RevokedAt       time.Time      `json:"revokedAt"`
+}
+
 type ReservationCancellation struct {
-	ID                      int64     `json:"id"`
-	ReservationID           int64     `json:"reservationId"`
-	CancelledByUserID       int64     `json:"cancelledByUserId"`
-	CancelledAt             time.Time `json:"cancelledAt"`
-	RefundPercentageApplied int64     `json:"refundPercentageApplied"`
-	FeeWaived               bool      `json:"feeWaived"`
-	HoursBeforeStart        int64     `json:"hoursBeforeStart"`
-	CreatedAt               time.Time `json:"createdAt"`
+	ID                      int64         `json:"id"`
+	ReservationID           int64         `json:"reservationId"`
+	CancelledByUserID       int64         `json:"cancelledByUserId"`
+	CancelledAt             time.Time     `json:"cancelledAt"`
+	RefundPercentageApplied int64         `json:"refundPercentageApplied"`
+	FeeWaived               bool          `json:"feeWaived"`
+	HoursBeforeStart        int64         `json:"hoursBeforeStart"`
+	CreatedAt               time.Time     `json:"createdAt"`
+	RefundAmountCents       sql.NullInt64 `json:"refundAmountCents"`
+}
+
+type ReservationCheckin struct {
+	ID                int64     `json:"id"`
+	ReservationID     int64     `json:"reservationId"`
+	UserID            int64     `json:"userId"`
+	CheckedInAt       time.Time `json:"checkedInAt"`
+	CheckedInByUserID int64     `json:"checkedInByUserId"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+type ReservationClosureDetail struct {
+	ReservationID int64          `json:"reservationId"`
+	PublicReason  sql.NullString `json:"publicReason"`
+	InternalNotes sql.NullString `json:"internalNotes"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+type ReservationComment struct {
+	ID            int64     `json:"id"`
+	ReservationID int64     `json:"reservationId"`
+	AuthorUserID  int64     `json:"authorUserId"`
+	Body          string    `json:"body"`
+	CreatedAt     time.Time `json:"createdAt"`
 }
 
 type ReservationCourt struct {
@@ -309,6 +684,54 @@ type ReservationCourt struct {
 	CourtID       int64 `json:"courtId"`
 }
 
+type ReservationDurationOverride struct {
+	ID                 int64         `json:"id"`
+	ReservationID      int64         `json:"reservationId"`
+	DurationMinutes    int64         `json:"durationMinutes"`
+	MinDurationMinutes int64         `json:"minDurationMinutes"`
+	MaxDurationMinutes sql.NullInt64 `json:"maxDurationMinutes"`
+	OverriddenByUserID int64         `json:"overriddenByUserId"`
+	CreatedAt          time.Time     `json:"createdAt"`
+}
+
+type ReservationGuest struct {
+	ID            int64          `json:"id"`
+	ReservationID int64          `json:"reservationId"`
+	HostUserID    sql.NullInt64  `json:"hostUserId"`
+	Name          string         `json:"name"`
+	Email         sql.NullString `json:"email"`
+	IsDropIn      bool           `json:"isDropIn"`
+	CreatedAt     time.Time      `json:"createdAt"`
+}
+
+type ReservationIdempotencyKey struct {
+	ID             int64     `json:"id"`
+	UserID         int64     `json:"userId"`
+	IdempotencyKey string    `json:"idempotencyKey"`
+	RequestHash    string    `json:"requestHash"`
+	ReservationID  int64     `json:"reservationId"`
+	ResponseBody   string    `json:"responseBody"`
+	CreatedAt      time.Time `json:"createdAt"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+}
+
+type ReservationInvitation struct {
+	ID              int64        `json:"id"`
+	ReservationID   int64        `json:"reservationId"`
+	InvitedUserID   int64        `json:"invitedUserId"`
+	InvitedByUserID int64        `json:"invitedByUserId"`
+	Token           string       `json:"token"`
+	Status          string       `json:"status"`
+	RespondedAt     sql.NullTime `json:"respondedAt"`
+	CreatedAt       time.Time    `json:"createdAt"`
+}
+
+type ReservationMemberNote struct {
+	ReservationID int64     `json:"reservationId"`
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
 type ReservationParticipant struct {
 	ID            int64     `json:"id"`
 	ReservationID int64     `json:"reservationId"`
@@ -317,13 +740,84 @@ type ReservationParticipant struct {
 	UpdatedAt     time.Time `json:"updatedAt"`
 }
 
+type ReservationPrice struct {
+	ReservationID int64     `json:"reservationId"`
+	AmountCents   int64     `json:"amountCents"`
+	MemberRate    bool      `json:"memberRate"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type ReservationReminder struct {
+	ReservationID int64     `json:"reservationId"`
+	SentAt        time.Time `json:"sentAt"`
+}
+
+type ReservationTransfer struct {
+	ID            int64        `json:"id"`
+	ReservationID int64        `json:"reservationId"`
+	FromUserID    int64        `json:"fromUserId"`
+	ToUserID      int64        `json:"toUserId"`
+	Token         string       `json:"token"`
+	Status        string       `json:"status"`
+	ExpiresAt     time.Time    `json:"expiresAt"`
+	RespondedAt   sql.NullTime `json:"respondedAt"`
+	CreatedAt     time.Time    `json:"createdAt"`
+}
+
 type ReservationType struct {
-	ID          int64          `json:"id"`
-	Name        string         `json:"name"`
-	Description sql.NullString `json:"description"`
-	Color       sql.NullString `json:"color"`
-	CreatedAt   time.Time      `json:"createdAt"`
-	UpdatedAt   time.Time      `json:"updatedAt"`
+	ID                      int64          `json:"id"`
+	Name                    string         `json:"name"`
+	Description             sql.NullString `json:"description"`
+	Color                   sql.NullString `json:"color"`
+	CreatedAt               time.Time      `json:"createdAt"`
+	UpdatedAt               time.Time      `json:"updatedAt"`
+	OrganizationID          sql.NullInt64  `json:"organizationId"`
+	FacilityID              sql.NullInt64  `json:"facilityId"`
+	DefaultDurationMinutes  int64          `json:"defaultDurationMinutes"`
+	MemberBookable          bool           `json:"memberBookable"`
+	CountsTowardMemberLimit bool           `json:"countsTowardMemberLimit"`
+	BufferMinutes           sql.NullInt64  `json:"bufferMinutes"`
+	MinDurationMinutes      sql.NullInt64  `json:"minDurationMinutes"`
+	MaxDurationMinutes      sql.NullInt64  `json:"maxDurationMinutes"`
+}
+
+type SeasonPass struct {
+	ID              int64         `json:"id"`
+	PassTypeID      int64         `json:"passTypeId"`
+	UserID          int64         `json:"userId"`
+	PurchasedAt     time.Time     `json:"purchasedAt"`
+	PricePaidCents  int64         `json:"pricePaidCents"`
+	GrantedByUserID sql.NullInt64 `json:"grantedByUserId"`
+	Status          string        `json:"status"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+}
+
+type SeasonPassReservation struct {
+	ID            int64     `json:"id"`
+	SeasonPassID  int64     `json:"seasonPassId"`
+	ReservationID int64     `json:"reservationId"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type SeasonPassType struct {
+	ID         int64     `json:"id"`
+	FacilityID int64     `json:"facilityId"`
+	Name       string    `json:"name"`
+	PriceCents int64     `json:"priceCents"`
+	ValidFrom  string    `json:"validFrom"`
+	ValidUntil string    `json:"validUntil"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+type SeasonPassWindow struct {
+	ID         int64  `json:"id"`
+	PassTypeID int64  `json:"passTypeId"`
+	DayOfWeek  int64  `json:"dayOfWeek"`
+	StartsAt   string `json:"startsAt"`
+	EndsAt     string `json:"endsAt"`
 }
 
 type Staff struct {
@@ -351,6 +845,14 @@ type StaffNotification struct {
 	UpdatedAt              time.Time     `json:"updatedAt"`
 }
 
+type SyncChange struct {
+	Seq        int64  `json:"seq"`
+	FacilityID int64  `json:"facilityId"`
+	EntityType string `json:"entityType"`
+	EntityID   int64  `json:"entityId"`
+	ChangedAt  string `json:"changedAt"`
+}
+
 type Theme struct {
 	ID             int64         `json:"id"`
 	FacilityID     sql.NullInt64 `json:"facilityId"`
@@ -365,32 +867,85 @@ type Theme struct {
 	UpdatedAt      time.Time     `json:"updatedAt"`
 }
 
+type Tournament struct {
+	ID              int64         `json:"id"`
+	FacilityID      int64         `json:"facilityId"`
+	LeagueID        int64         `json:"leagueId"`
+	Name            string        `json:"name"`
+	Format          string        `json:"format"`
+	Seeding         string        `json:"seeding"`
+	Status          string        `json:"status"`
+	ChampionTeamID  sql.NullInt64 `json:"championTeamId"`
+	CreatedByUserID int64         `json:"createdByUserId"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+}
+
+type TournamentEntrant struct {
+	TournamentID int64 `json:"tournamentId"`
+	LeagueTeamID int64 `json:"leagueTeamId"`
+	Seed         int64 `json:"seed"`
+}
+
+type TournamentMatch struct {
+	ID              int64          `json:"id"`
+	TournamentID    int64          `json:"tournamentId"`
+	Bracket         string         `json:"bracket"`
+	RoundNumber     int64          `json:"roundNumber"`
+	Position        int64          `json:"position"`
+	TeamAID         sql.NullInt64  `json:"teamAId"`
+	TeamBID         sql.NullInt64  `json:"teamBId"`
+	TeamAScore      sql.NullInt64  `json:"teamAScore"`
+	TeamBScore      sql.NullInt64  `json:"teamBScore"`
+	WinnerTeamID    sql.NullInt64  `json:"winnerTeamId"`
+	WinnerToMatchID sql.NullInt64  `json:"winnerToMatchId"`
+	WinnerToSlot    sql.NullString `json:"winnerToSlot"`
+	LoserToMatchID  sql.NullInt64  `json:"loserToMatchId"`
+	LoserToSlot     sql.NullString `json:"loserToSlot"`
+	Status          string         `json:"status"`
+	CourtID         sql.NullInt64  `json:"courtId"`
+	ScheduledTime   sql.NullTime   `json:"scheduledTime"`
+	ReservationID   sql.NullInt64  `json:"reservationId"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+}
+
 type User struct {
-	ID                  int64          `json:"id"`
-	Email               sql.NullString `json:"email"`
-	Phone               sql.NullString `json:"phone"`
-	CognitoSub          sql.NullString `json:"cognitoSub"`
-	CognitoStatus       sql.NullString `json:"cognitoStatus"`
-	PreferredAuthMethod sql.NullString `json:"preferredAuthMethod"`
-	PasswordHash        sql.NullString `json:"passwordHash"`
-	LocalAuthEnabled    bool           `json:"localAuthEnabled"`
-	FirstName           string         `json:"firstName"`
-	LastName            string         `json:"lastName"`
-	PhotoUrl            sql.NullString `json:"photoUrl"`
-	StreetAddress       sql.NullString `json:"streetAddress"`
-	City                sql.NullString `json:"city"`
-	State               sql.NullString `json:"state"`
-	PostalCode          sql.NullString `json:"postalCode"`
-	HomeFacilityID      sql.NullInt64  `json:"homeFacilityId"`
-	IsMember            bool           `json:"isMember"`
-	IsStaff             bool           `json:"isStaff"`
-	DateOfBirth         string         `json:"dateOfBirth"`
-	WaiverSigned        bool           `json:"waiverSigned"`
-	MembershipLevel     int64          `json:"membershipLevel"`
-	StaffRole           sql.NullString `json:"staffRole"`
-	Status              string         `json:"status"`
-	CreatedAt           time.Time      `json:"createdAt"`
-	UpdatedAt           time.Time      `json:"updatedAt"`
+	ID                      int64          `json:"id"`
+	Email                   sql.NullString `json:"email"`
+	Phone                   sql.NullString `json:"phone"`
+	CognitoSub              sql.NullString `json:"cognitoSub"`
+	CognitoStatus           sql.NullString `json:"cognitoStatus"`
+	PreferredAuthMethod     sql.NullString `json:"preferredAuthMethod"`
+	PasswordHash            sql.NullString `json:"passwordHash"`
+	LocalAuthEnabled        bool           `json:"localAuthEnabled"`
+	FirstName               string         `json:"firstName"`
+	LastName                string         `json:"lastName"`
+	PhotoUrl                sql.NullString `json:"photoUrl"`
+	StreetAddress           sql.NullString `json:"streetAddress"`
+	City                    sql.NullString `json:"city"`
+	State                   sql.NullString `json:"state"`
+	PostalCode              sql.NullString `json:"postalCode"`
+	HomeFacilityID          sql.NullInt64  `json:"homeFacilityId"`
+	IsMember                bool           `json:"isMember"`
+	IsStaff                 bool           `json:"isStaff"`
+	DateOfBirth             string         `json:"dateOfBirth"`
+	WaiverSigned            bool           `json:"waiverSigned"`
+	MembershipLevel         int64          `json:"membershipLevel"`
+	HideFromRosters         bool           `json:"hideFromRosters"`
+	NotifyConfirmations     bool           `json:"notifyConfirmations"`
+	NotifyCancellations     bool           `json:"notifyCancellations"`
+	NotifyWaitlistOffers    bool           `json:"notifyWaitlistOffers"`
+	NotifyOpenPlayReminders bool           `json:"notifyOpenPlayReminders"`
+	SmsOptIn                bool           `json:"smsOptIn"`
+	EmailUndeliverable      bool           `json:"emailUndeliverable"`
+	StaffRole               sql.NullString `json:"staffRole"`
+	DeletedAt               sql.NullTime   `json:"deletedAt"`
+	DeletionRequestedAt     sql.NullTime   `json:"deletionRequestedAt"`
+	AnonymizedAt            sql.NullTime   `json:"anonymizedAt"`
+	Status                  string         `json:"status"`
+	CreatedAt               time.Time      `json:"createdAt"`
+	UpdatedAt               time.Time      `json:"updatedAt"`
 }
 
 type UserBilling struct {
@@ -407,25 +962,49 @@ type UserBilling struct {
 }
 
 type UserPhoto struct {
-	ID          int64     `json:"id"`
-	UserID      int64     `json:"userId"`
-	Data        []byte    `json:"data"`
-	ContentType string    `json:"contentType"`
-	Size        int64     `json:"size"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID            int64     `json:"id"`
+	UserID        int64     `json:"userId"`
+	Data          []byte    `json:"data"`
+	ContentType   string    `json:"contentType"`
+	Size          int64     `json:"size"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	ThumbnailData []byte    `json:"thumbnailData"`
+}
+
+type UserSession struct {
+	ID          int64        `json:"id"`
+	Token       string       `json:"token"`
+	UserID      int64        `json:"userId"`
+	SessionType string       `json:"sessionType"`
+	UserAgent   string       `json:"userAgent"`
+	IpAddress   string       `json:"ipAddress"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	LastSeenAt  time.Time    `json:"lastSeenAt"`
+	ExpiresAt   time.Time    `json:"expiresAt"`
+	RevokedAt   sql.NullTime `json:"revokedAt"`
 }
 
 type VisitPack struct {
-	ID              int64     `json:"id"`
-	PackTypeID      int64     `json:"packTypeId"`
-	UserID          int64     `json:"userId"`
-	PurchaseDate    time.Time `json:"purchaseDate"`
-	ExpiresAt       time.Time `json:"expiresAt"`
-	VisitsRemaining int64     `json:"visitsRemaining"`
-	Status          string    `json:"status"`
-	CreatedAt       time.Time `json:"createdAt"`
-	UpdatedAt       time.Time `json:"updatedAt"`
+	ID              int64         `json:"id"`
+	PackTypeID      int64         `json:"packTypeId"`
+	UserID          int64         `json:"userId"`
+	PurchaseDate    time.Time     `json:"purchaseDate"`
+	ExpiresAt       time.Time     `json:"expiresAt"`
+	VisitsRemaining int64         `json:"visitsRemaining"`
+	Status          string        `json:"status"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+	FacilityID      sql.NullInt64 `json:"facilityId"`
+}
+
+type VisitPackPayment struct {
+	ID          int64     `json:"id"`
+	VisitPackID int64     `json:"visitPackId"`
+	AmountCents int64     `json:"amountCents"`
+	Processor   string    `json:"processor"`
+	Reference   string    `json:"reference"`
+	CreatedAt   time.Time `json:"createdAt"`
 }
 
 type VisitPackRedemption struct {
@@ -461,6 +1040,8 @@ type Waitlist struct {
 	Status          string        `json:"status"`
 	CreatedAt       time.Time     `json:"createdAt"`
 	UpdatedAt       time.Time     `json:"updatedAt"`
+	AutoBook        bool          `json:"autoBook"`
+	UseVisitPack    bool          `json:"useVisitPack"`
 }
 
 type WaitlistConfig struct {
@@ -470,6 +1051,7 @@ type WaitlistConfig struct {
 	NotificationMode          string    `json:"notificationMode"`
 	OfferExpiryMinutes        int64     `json:"offerExpiryMinutes"`
 	NotificationWindowMinutes int64     `json:"notificationWindowMinutes"`
+	ExpiredOfferAction        string    `json:"expiredOfferAction"`
 	CreatedAt                 time.Time `json:"createdAt"`
 	UpdatedAt                 time.Time `json:"updatedAt"`
 }
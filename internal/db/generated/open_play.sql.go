@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createOpenPlayRule = `-- name: CreateOpenPlayRule :one
@@ -18,7 +19,10 @@ INSERT INTO open_play_rules (
     cancellation_cutoff_minutes,
     auto_scale_enabled,
     min_courts,
-    max_courts
+    max_courts,
+    min_membership_level,
+    max_membership_level,
+    skill_bands
 ) VALUES (
     ?1,
     ?2,
@@ -27,22 +31,29 @@ INSERT INTO open_play_rules (
     ?5,
     ?6,
     ?7,
-    ?8
+    ?8,
+    ?9,
+    ?10,
+    ?11
 )
 RETURNING id, facility_id, name, min_participants, max_participants_per_court,
     cancellation_cutoff_minutes, auto_scale_enabled, min_courts, max_courts,
-    created_at, updated_at
+    created_at, updated_at, min_membership_level, max_membership_level,
+    skill_bands
 `
 
 type CreateOpenPlayRuleParams struct {
-	FacilityID                int64  `json:"facilityId"`
-	Name                      string `json:"name"`
-	MinParticipants           int64  `json:"minParticipants"`
-	MaxParticipantsPerCourt   int64  `json:"maxParticipantsPerCourt"`
-	CancellationCutoffMinutes int64  `json:"cancellationCutoffMinutes"`
-	AutoScaleEnabled          bool   `json:"autoScaleEnabled"`
-	MinCourts                 int64  `json:"minCourts"`
-	MaxCourts                 int64  `json:"maxCourts"`
+	FacilityID                int64          `json:"facilityId"`
+	Name                      string         `json:"name"`
+	MinParticipants           int64          `json:"minParticipants"`
+	MaxParticipantsPerCourt   int64          `json:"maxParticipantsPerCourt"`
+	CancellationCutoffMinutes int64          `json:"cancellationCutoffMinutes"`
+	AutoScaleEnabled          bool           `json:"autoScaleEnabled"`
+	MinCourts                 int64          `json:"minCourts"`
+	MaxCourts                 int64          `json:"maxCourts"`
+	MinMembershipLevel        int64          `json:"minMembershipLevel"`
+	MaxMembershipLevel        sql.NullInt64  `json:"maxMembershipLevel"`
+	SkillBands                sql.NullString `json:"skillBands"`
 }
 
 func (q *Queries) CreateOpenPlayRule(ctx context.Context, arg CreateOpenPlayRuleParams) (OpenPlayRule, error) {
@@ -55,6 +66,9 @@ func (q *Queries) CreateOpenPlayRule(ctx context.Context, arg CreateOpenPlayRule
 		arg.AutoScaleEnabled,
 		arg.MinCourts,
 		arg.MaxCourts,
+		arg.MinMembershipLevel,
+		arg.MaxMembershipLevel,
+		arg.SkillBands,
 	)
 	var i OpenPlayRule
 	err := row.Scan(
@@ -69,6 +83,57 @@ func (q *Queries) CreateOpenPlayRule(ctx context.Context, arg CreateOpenPlayRule
 		&i.MaxCourts,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MinMembershipLevel,
+		&i.MaxMembershipLevel,
+		&i.SkillBands,
+	)
+	return i, err
+}
+
+const createOpenPlayRuleSlot = `-- name: CreateOpenPlayRuleSlot :one
+INSERT INTO open_play_rule_slots (
+    open_play_rule_id,
+    day_of_week,
+    start_time,
+    end_time,
+    created_by_user_id
+) VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4,
+    ?5
+)
+RETURNING id, open_play_rule_id, day_of_week, start_time, end_time,
+    created_by_user_id, created_at, updated_at
+`
+
+type CreateOpenPlayRuleSlotParams struct {
+	OpenPlayRuleID  int64  `json:"openPlayRuleId"`
+	DayOfWeek       int64  `json:"dayOfWeek"`
+	StartTime       string `json:"startTime"`
+	EndTime         string `json:"endTime"`
+	CreatedByUserID int64  `json:"createdByUserId"`
+}
+
+func (q *Queries) CreateOpenPlayRuleSlot(ctx context.Context, arg CreateOpenPlayRuleSlotParams) (OpenPlayRuleSlot, error) {
+	row := q.queryRow(ctx, q.createOpenPlayRuleSlotStmt, createOpenPlayRuleSlot,
+		arg.OpenPlayRuleID,
+		arg.DayOfWeek,
+		arg.StartTime,
+		arg.EndTime,
+		arg.CreatedByUserID,
+	)
+	var i OpenPlayRuleSlot
+	err := row.Scan(
+		&i.ID,
+		&i.OpenPlayRuleID,
+		&i.DayOfWeek,
+		&i.StartTime,
+		&i.EndTime,
+		&i.CreatedByUserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -92,10 +157,36 @@ func (q *Queries) DeleteOpenPlayRule(ctx context.Context, arg DeleteOpenPlayRule
 	return result.RowsAffected()
 }
 
+const deleteOpenPlayRuleSlot = `-- name: DeleteOpenPlayRuleSlot :execrows
+DELETE FROM open_play_rule_slots
+WHERE open_play_rule_slots.id = ?1
+  AND open_play_rule_slots.open_play_rule_id = (
+    SELECT r.id
+    FROM open_play_rules r
+    WHERE r.id = ?2
+      AND r.facility_id = ?3
+  )
+`
+
+type DeleteOpenPlayRuleSlotParams struct {
+	ID             int64 `json:"id"`
+	OpenPlayRuleID int64 `json:"openPlayRuleId"`
+	FacilityID     int64 `json:"facilityId"`
+}
+
+func (q *Queries) DeleteOpenPlayRuleSlot(ctx context.Context, arg DeleteOpenPlayRuleSlotParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteOpenPlayRuleSlotStmt, deleteOpenPlayRuleSlot, arg.ID, arg.OpenPlayRuleID, arg.FacilityID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getOpenPlayRule = `-- name: GetOpenPlayRule :one
 SELECT id, facility_id, name, min_participants, max_participants_per_court,
     cancellation_cutoff_minutes, auto_scale_enabled, min_courts, max_courts,
-    created_at, updated_at
+    created_at, updated_at, min_membership_level, max_membership_level,
+    skill_bands
 FROM open_play_rules
 WHERE id = ?1
   AND facility_id = ?2
@@ -121,14 +212,147 @@ func (q *Queries) GetOpenPlayRule(ctx context.Context, arg GetOpenPlayRuleParams
 		&i.MaxCourts,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MinMembershipLevel,
+		&i.MaxMembershipLevel,
+		&i.SkillBands,
 	)
 	return i, err
 }
 
+const listFacilitiesWithOpenPlayRuleSlots = `-- name: ListFacilitiesWithOpenPlayRuleSlots :many
+SELECT DISTINCT r.facility_id
+FROM open_play_rule_slots s
+JOIN open_play_rules r ON r.id = s.open_play_rule_id
+ORDER BY r.facility_id
+`
+
+func (q *Queries) ListFacilitiesWithOpenPlayRuleSlots(ctx context.Context) ([]int64, error) {
+	rows, err := q.query(ctx, q.listFacilitiesWithOpenPlayRuleSlotsStmt, listFacilitiesWithOpenPlayRuleSlots)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var facility_id int64
+		if err := rows.Scan(&facility_id); err != nil {
+			return nil, err
+		}
+		items = append(items, facility_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFacilityOpenPlayRuleSlots = `-- name: ListFacilityOpenPlayRuleSlots :many
+SELECT s.id, s.open_play_rule_id, s.day_of_week, s.start_time, s.end_time,
+    s.created_by_user_id, r.name AS rule_name, r.min_courts
+FROM open_play_rule_slots s
+JOIN open_play_rules r ON r.id = s.open_play_rule_id
+WHERE r.facility_id = ?1
+ORDER BY s.day_of_week, s.start_time, r.name
+`
+
+type ListFacilityOpenPlayRuleSlotsRow struct {
+	ID              int64  `json:"id"`
+	OpenPlayRuleID  int64  `json:"openPlayRuleId"`
+	DayOfWeek       int64  `json:"dayOfWeek"`
+	StartTime       string `json:"startTime"`
+	EndTime         string `json:"endTime"`
+	CreatedByUserID int64  `json:"createdByUserId"`
+	RuleName        string `json:"ruleName"`
+	MinCourts       int64  `json:"minCourts"`
+}
+
+func (q *Queries) ListFacilityOpenPlayRuleSlots(ctx context.Context, facilityID int64) ([]ListFacilityOpenPlayRuleSlotsRow, error) {
+	rows, err := q.query(ctx, q.listFacilityOpenPlayRuleSlotsStmt, listFacilityOpenPlayRuleSlots, facilityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFacilityOpenPlayRuleSlotsRow
+	for rows.Next() {
+		var i ListFacilityOpenPlayRuleSlotsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OpenPlayRuleID,
+			&i.DayOfWeek,
+			&i.StartTime,
+			&i.EndTime,
+			&i.CreatedByUserID,
+			&i.RuleName,
+			&i.MinCourts,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOpenPlayRuleSlots = `-- name: ListOpenPlayRuleSlots :many
+SELECT s.id, s.open_play_rule_id, s.day_of_week, s.start_time, s.end_time,
+    s.created_by_user_id, s.created_at, s.updated_at
+FROM open_play_rule_slots s
+JOIN open_play_rules r ON r.id = s.open_play_rule_id
+WHERE s.open_play_rule_id = ?1
+  AND r.facility_id = ?2
+ORDER BY s.day_of_week, s.start_time
+`
+
+type ListOpenPlayRuleSlotsParams struct {
+	OpenPlayRuleID int64 `json:"openPlayRuleId"`
+	FacilityID     int64 `json:"facilityId"`
+}
+
+func (q *Queries) ListOpenPlayRuleSlots(ctx context.Context, arg ListOpenPlayRuleSlotsParams) ([]OpenPlayRuleSlot, error) {
+	rows, err := q.query(ctx, q.listOpenPlayRuleSlotsStmt, listOpenPlayRuleSlots, arg.OpenPlayRuleID, arg.FacilityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OpenPlayRuleSlot
+	for rows.Next() {
+		var i OpenPlayRuleSlot
+		if err := rows.Scan(
+			&i.ID,
+			&i.OpenPlayRuleID,
+			&i.DayOfWeek,
+			&i.StartTime,
+			&i.EndTime,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listOpenPlayRules = `-- name: ListOpenPlayRules :many
 SELECT id, facility_id, name, min_participants, max_participants_per_court,
     cancellation_cutoff_minutes, auto_scale_enabled, min_courts, max_courts,
-    created_at, updated_at
+    created_at, updated_at, min_membership_level, max_membership_level,
+    skill_bands
 FROM open_play_rules
 WHERE facility_id = ?1
 ORDER BY name
@@ -155,6 +379,9 @@ func (q *Queries) ListOpenPlayRules(ctx context.Context, facilityID int64) ([]Op
 			&i.MaxCourts,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MinMembershipLevel,
+			&i.MaxMembershipLevel,
+			&i.SkillBands,
 		); err != nil {
 			return nil, err
 		}
@@ -178,24 +405,31 @@ SET name = ?1,
     auto_scale_enabled = ?5,
     min_courts = ?6,
     max_courts = ?7,
+    min_membership_level = ?8,
+    max_membership_level = ?9,
+    skill_bands = ?10,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = ?8
-  AND facility_id = ?9
+WHERE id = ?11
+  AND facility_id = ?12
 RETURNING id, facility_id, name, min_participants, max_participants_per_court,
     cancellation_cutoff_minutes, auto_scale_enabled, min_courts, max_courts,
-    created_at, updated_at
+    created_at, updated_at, min_membership_level, max_membership_level,
+    skill_bands
 `
 
 type UpdateOpenPlayRuleParams struct {
-	Name                      string `json:"name"`
-	MinParticipants           int64  `json:"minParticipants"`
-	MaxParticipantsPerCourt   int64  `json:"maxParticipantsPerCourt"`
-	CancellationCutoffMinutes int64  `json:"cancellationCutoffMinutes"`
-	AutoScaleEnabled          bool   `json:"autoScaleEnabled"`
-	MinCourts                 int64  `json:"minCourts"`
-	MaxCourts                 int64  `json:"maxCourts"`
-	ID                        int64  `json:"id"`
-	FacilityID                int64  `json:"facilityId"`
+	Name                      string         `json:"name"`
+	MinParticipants           int64          `json:"minParticipants"`
+	MaxParticipantsPerCourt   int64          `json:"maxParticipantsPerCourt"`
+	CancellationCutoffMinutes int64          `json:"cancellationCutoffMinutes"`
+	AutoScaleEnabled          bool           `json:"autoScaleEnabled"`
+	MinCourts                 int64          `json:"minCourts"`
+	MaxCourts                 int64          `json:"maxCourts"`
+	MinMembershipLevel        int64          `json:"minMembershipLevel"`
+	MaxMembershipLevel        sql.NullInt64  `json:"maxMembershipLevel"`
+	SkillBands                sql.NullString `json:"skillBands"`
+	ID                        int64          `json:"id"`
+	FacilityID                int64          `json:"facilityId"`
 }
 
 func (q *Queries) UpdateOpenPlayRule(ctx context.Context, arg UpdateOpenPlayRuleParams) (OpenPlayRule, error) {
@@ -207,6 +441,9 @@ func (q *Queries) UpdateOpenPlayRule(ctx context.Context, arg UpdateOpenPlayRule
 		arg.AutoScaleEnabled,
 		arg.MinCourts,
 		arg.MaxCourts,
+		arg.MinMembershipLevel,
+		arg.MaxMembershipLevel,
+		arg.SkillBands,
 		arg.ID,
 		arg.FacilityID,
 	)
@@ -223,6 +460,9 @@ func (q *Queries) UpdateOpenPlayRule(ctx context.Context, arg UpdateOpenPlayRule
 		&i.MaxCourts,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MinMembershipLevel,
+		&i.MaxMembershipLevel,
+		&i.SkillBands,
 	)
 	return i, err
 }
@@ -126,6 +126,97 @@ func (q *Queries) ListAvailableCourts(ctx context.Context, arg ListAvailableCour
 	return items, nil
 }
 
+const listCourtBlocksForDay = `-- name: ListCourtBlocksForDay :many
+SELECT
+    c.id AS court_id,
+    c.court_number,
+    c.is_indoor,
+    c.surface,
+    c.has_lights,
+    r.start_time AS block_start_time,
+    r.end_time AS block_end_time,
+    rt.name AS block_reservation_type,
+    d.public_reason AS block_public_reason,
+    COALESCE(rt.buffer_minutes, f.buffer_minutes) AS block_buffer_minutes,
+    f.buffer_minutes AS facility_buffer_minutes
+FROM courts c
+JOIN facilities f ON f.id = c.facility_id
+LEFT JOIN reservation_courts rc
+    ON rc.court_id = c.id
+    AND rc.reservation_id IN (
+        SELECT overlapping.id
+        FROM reservations overlapping
+        WHERE overlapping.facility_id = ?1
+          AND overlapping.start_time < ?2
+          AND overlapping.end_time > ?3
+    )
+LEFT JOIN reservations r ON r.id = rc.reservation_id
+LEFT JOIN reservation_types rt ON rt.id = r.reservation_type_id
+LEFT JOIN reservation_closure_details d ON d.reservation_id = r.id
+WHERE c.facility_id = ?1
+  AND c.status = 'active'
+ORDER BY c.court_number, r.start_time
+`
+
+type ListCourtBlocksForDayParams struct {
+	FacilityID int64     `json:"facilityId"`
+	RangeEnd   time.Time `json:"rangeEnd"`
+	RangeStart time.Time `json:"rangeStart"`
+}
+
+type ListCourtBlocksForDayRow struct {
+	CourtID               int64          `json:"courtId"`
+	CourtNumber           int64          `json:"courtNumber"`
+	IsIndoor              bool           `json:"isIndoor"`
+	Surface               sql.NullString `json:"surface"`
+	HasLights             bool           `json:"hasLights"`
+	BlockStartTime        sql.NullTime   `json:"blockStartTime"`
+	BlockEndTime          sql.NullTime   `json:"blockEndTime"`
+	BlockReservationType  sql.NullString `json:"blockReservationType"`
+	BlockPublicReason     sql.NullString `json:"blockPublicReason"`
+	BlockBufferMinutes    int64          `json:"blockBufferMinutes"`
+	FacilityBufferMinutes int64          `json:"facilityBufferMinutes"`
+}
+
+// Returns every active court once per overlapping block within the range
+// (block columns are NULL for courts with nothing booked). Only the public
+// closure reason is selected; internal notes never leave the staff views.
+// Each block carries its turnover buffer, and every row the facility default.
+func (q *Queries) ListCourtBlocksForDay(ctx context.Context, arg ListCourtBlocksForDayParams) ([]ListCourtBlocksForDayRow, error) {
+	rows, err := q.query(ctx, q.listCourtBlocksForDayStmt, listCourtBlocksForDay, arg.FacilityID, arg.RangeEnd, arg.RangeStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCourtBlocksForDayRow
+	for rows.Next() {
+		var i ListCourtBlocksForDayRow
+		if err := rows.Scan(
+			&i.CourtID,
+			&i.CourtNumber,
+			&i.IsIndoor,
+			&i.Surface,
+			&i.HasLights,
+			&i.BlockStartTime,
+			&i.BlockEndTime,
+			&i.BlockReservationType,
+			&i.BlockPublicReason,
+			&i.BlockBufferMinutes,
+			&i.FacilityBufferMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listReservationCourts = `-- name: ListReservationCourts :many
 SELECT rc.court_id, c.court_number
 FROM reservation_courts rc
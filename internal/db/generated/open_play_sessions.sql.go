@@ -83,6 +83,35 @@ func (q *Queries) CountOpenPlayReservationsForSession(ctx context.Context, arg C
 	return count, err
 }
 
+const countOpenPlaySessionsForSlot = `-- name: CountOpenPlaySessionsForSlot :one
+SELECT COUNT(*)
+FROM open_play_sessions
+WHERE facility_id = ?1
+  AND open_play_rule_id = ?2
+  AND start_time = ?3
+  AND end_time = ?4
+`
+
+type CountOpenPlaySessionsForSlotParams struct {
+	FacilityID     int64     `json:"facilityId"`
+	OpenPlayRuleID int64     `json:"openPlayRuleId"`
+	StartTime      time.Time `json:"startTime"`
+	EndTime        time.Time `json:"endTime"`
+}
+
+// Counts sessions in any status so a cancelled occurrence is not regenerated.
+func (q *Queries) CountOpenPlaySessionsForSlot(ctx context.Context, arg CountOpenPlaySessionsForSlotParams) (int64, error) {
+	row := q.queryRow(ctx, q.countOpenPlaySessionsForSlotStmt, countOpenPlaySessionsForSlot,
+		arg.FacilityID,
+		arg.OpenPlayRuleID,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUnreadStaffNotifications = `-- name: CountUnreadStaffNotifications :one
 SELECT COUNT(*)
 FROM staff_notifications
@@ -315,6 +344,19 @@ func (q *Queries) CreateStaffNotification(ctx context.Context, arg CreateStaffNo
 	return i, err
 }
 
+const deleteOpenPlaySessionSkillBands = `-- name: DeleteOpenPlaySessionSkillBands :execrows
+DELETE FROM open_play_session_skill_bands
+WHERE session_id = ?1
+`
+
+func (q *Queries) DeleteOpenPlaySessionSkillBands(ctx context.Context, sessionID int64) (int64, error) {
+	result, err := q.exec(ctx, q.deleteOpenPlaySessionSkillBandsStmt, deleteOpenPlaySessionSkillBands, sessionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getOpenPlaySession = `-- name: GetOpenPlaySession :one
 SELECT ops.id,
     ops.facility_id,
@@ -386,6 +428,35 @@ func (q *Queries) GetOpenPlaySession(ctx context.Context, arg GetOpenPlaySession
 	return i, err
 }
 
+const getOpenPlaySessionSkillBands = `-- name: GetOpenPlaySessionSkillBands :one
+SELECT opr.skill_bands AS rule_skill_bands,
+    sb.skill_bands AS session_skill_bands
+FROM open_play_sessions ops
+JOIN open_play_rules opr
+  ON opr.id = ops.open_play_rule_id
+LEFT JOIN open_play_session_skill_bands sb
+  ON sb.session_id = ops.id
+WHERE ops.id = ?1
+  AND ops.facility_id = ?2
+`
+
+type GetOpenPlaySessionSkillBandsParams struct {
+	SessionID  int64 `json:"sessionId"`
+	FacilityID int64 `json:"facilityId"`
+}
+
+type GetOpenPlaySessionSkillBandsRow struct {
+	RuleSkillBands    sql.NullString `json:"ruleSkillBands"`
+	SessionSkillBands sql.NullString `json:"sessionSkillBands"`
+}
+
+func (q *Queries) GetOpenPlaySessionSkillBands(ctx context.Context, arg GetOpenPlaySessionSkillBandsParams) (GetOpenPlaySessionSkillBandsRow, error) {
+	row := q.queryRow(ctx, q.getOpenPlaySessionSkillBandsStmt, getOpenPlaySessionSkillBands, arg.SessionID, arg.FacilityID)
+	var i GetOpenPlaySessionSkillBandsRow
+	err := row.Scan(&i.RuleSkillBands, &i.SessionSkillBands)
+	return i, err
+}
+
 const getStaffNotificationByID = `-- name: GetStaffNotificationByID :one
 SELECT id, facility_id, notification_type, message, related_session_id,
     related_reservation_id, related_clinic_session_id, target_staff_id, read,
@@ -492,10 +563,16 @@ SELECT ops.id,
           AND r.end_time = ops.end_time
           AND rt.name = 'OPEN_PLAY'
     ) AS participant_count,
-    opr.min_participants
+    opr.min_participants,
+    opr.min_membership_level,
+    opr.max_membership_level,
+    opr.skill_bands AS rule_skill_bands,
+    sb.skill_bands AS session_skill_bands
 FROM open_play_sessions ops
 JOIN open_play_rules opr
   ON ops.open_play_rule_id = opr.id
+LEFT JOIN open_play_session_skill_bands sb
+  ON sb.session_id = ops.id
 WHERE ops.facility_id IN (/*SLICE:facility_ids*/?)
   AND ops.status = 'scheduled'
   AND ops.start_time > ?2
@@ -508,13 +585,17 @@ type ListMemberUpcomingOpenPlaySessionsParams struct {
 }
 
 type ListMemberUpcomingOpenPlaySessionsRow struct {
-	ID               int64     `json:"id"`
-	StartTime        time.Time `json:"startTime"`
-	EndTime          time.Time `json:"endTime"`
-	Status           string    `json:"status"`
-	RuleName         string    `json:"ruleName"`
-	ParticipantCount int64     `json:"participantCount"`
-	MinParticipants  int64     `json:"minParticipants"`
+	ID                 int64          `json:"id"`
+	StartTime          time.Time      `json:"startTime"`
+	EndTime            time.Time      `json:"endTime"`
+	Status             string         `json:"status"`
+	RuleName           string         `json:"ruleName"`
+	ParticipantCount   int64          `json:"participantCount"`
+	MinParticipants    int64          `json:"minParticipants"`
+	MinMembershipLevel int64          `json:"minMembershipLevel"`
+	MaxMembershipLevel sql.NullInt64  `json:"maxMembershipLevel"`
+	RuleSkillBands     sql.NullString `json:"ruleSkillBands"`
+	SessionSkillBands  sql.NullString `json:"sessionSkillBands"`
 }
 
 // Empty facility_ids intentionally yields zero rows (caller should prefilter).
@@ -546,6 +627,10 @@ func (q *Queries) ListMemberUpcomingOpenPlaySessions(ctx context.Context, arg Li
 			&i.RuleName,
 			&i.ParticipantCount,
 			&i.MinParticipants,
+			&i.MinMembershipLevel,
+			&i.MaxMembershipLevel,
+			&i.RuleSkillBands,
+			&i.SessionSkillBands,
 		); err != nil {
 			return nil, err
 		}
@@ -612,6 +697,58 @@ func (q *Queries) ListOpenPlayAuditLog(ctx context.Context, arg ListOpenPlayAudi
 	return items, nil
 }
 
+const listOpenPlayParticipantSkillRatings = `-- name: ListOpenPlayParticipantSkillRatings :many
+SELECT u.id AS user_id,
+    msr.rating
+FROM open_play_sessions ops
+JOIN reservations r
+  ON r.facility_id = ops.facility_id
+ AND r.open_play_rule_id = ops.open_play_rule_id
+ AND r.start_time = ops.start_time
+ AND r.end_time = ops.end_time
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+JOIN reservation_participants rp ON rp.reservation_id = r.id
+JOIN users u ON u.id = rp.user_id
+LEFT JOIN member_skill_ratings msr ON msr.user_id = u.id
+WHERE ops.id = ?1
+  AND ops.facility_id = ?2
+  AND rt.name = 'OPEN_PLAY'
+ORDER BY rp.created_at, rp.id
+`
+
+type ListOpenPlayParticipantSkillRatingsParams struct {
+	SessionID  int64 `json:"sessionId"`
+	FacilityID int64 `json:"facilityId"`
+}
+
+type ListOpenPlayParticipantSkillRatingsRow struct {
+	UserID int64           `json:"userId"`
+	Rating sql.NullFloat64 `json:"rating"`
+}
+
+func (q *Queries) ListOpenPlayParticipantSkillRatings(ctx context.Context, arg ListOpenPlayParticipantSkillRatingsParams) ([]ListOpenPlayParticipantSkillRatingsRow, error) {
+	rows, err := q.query(ctx, q.listOpenPlayParticipantSkillRatingsStmt, listOpenPlayParticipantSkillRatings, arg.SessionID, arg.FacilityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOpenPlayParticipantSkillRatingsRow
+	for rows.Next() {
+		var i ListOpenPlayParticipantSkillRatingsRow
+		if err := rows.Scan(&i.UserID, &i.Rating); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listOpenPlayParticipants = `-- name: ListOpenPlayParticipants :many
 SELECT u.id,
     u.first_name,
@@ -676,6 +813,133 @@ func (q *Queries) ListOpenPlayParticipants(ctx context.Context, arg ListOpenPlay
 	return items, nil
 }
 
+const listOpenPlayParticipantsForSession = `-- name: ListOpenPlayParticipantsForSession :many
+SELECT u.id,
+    u.first_name,
+    u.hide_from_rosters
+FROM open_play_sessions ops
+JOIN reservations r
+  ON r.facility_id = ops.facility_id
+ AND r.open_play_rule_id = ops.open_play_rule_id
+ AND r.start_time = ops.start_time
+ AND r.end_time = ops.end_time
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+JOIN reservation_participants rp ON rp.reservation_id = r.id
+JOIN users u ON u.id = rp.user_id
+WHERE ops.id = ?1
+  AND ops.facility_id = ?2
+  AND rt.name = 'OPEN_PLAY'
+ORDER BY rp.created_at, rp.id
+`
+
+type ListOpenPlayParticipantsForSessionParams struct {
+	SessionID  int64 `json:"sessionId"`
+	FacilityID int64 `json:"facilityId"`
+}
+
+type ListOpenPlayParticipantsForSessionRow struct {
+	ID              int64  `json:"id"`
+	FirstName       string `json:"firstName"`
+	HideFromRosters bool   `json:"hideFromRosters"`
+}
+
+func (q *Queries) ListOpenPlayParticipantsForSession(ctx context.Context, arg ListOpenPlayParticipantsForSessionParams) ([]ListOpenPlayParticipantsForSessionRow, error) {
+	rows, err := q.query(ctx, q.listOpenPlayParticipantsForSessionStmt, listOpenPlayParticipantsForSession, arg.SessionID, arg.FacilityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOpenPlayParticipantsForSessionRow
+	for rows.Next() {
+		var i ListOpenPlayParticipantsForSessionRow
+		if err := rows.Scan(&i.ID, &i.FirstName, &i.HideFromRosters); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOpenPlaySessionReservationMismatches = `-- name: ListOpenPlaySessionReservationMismatches :many
+SELECT
+    s.id AS session_id,
+    s.open_play_rule_id,
+    s.start_time,
+    s.end_time,
+    s.status,
+    COUNT(r.id) AS reservation_count,
+    CAST(COALESCE(GROUP_CONCAT(r.id), '') AS TEXT) AS reservation_ids
+FROM open_play_sessions s
+LEFT JOIN reservations r
+    ON r.facility_id = s.facility_id
+   AND r.open_play_rule_id = s.open_play_rule_id
+   AND r.start_time = s.start_time
+   AND r.end_time = s.end_time
+   AND r.reservation_type_id IN (SELECT id FROM reservation_types WHERE name = 'OPEN_PLAY')
+WHERE s.facility_id = ?1
+  AND s.status != 'cancelled'
+  AND s.start_time < ?2
+  AND s.end_time > ?3
+GROUP BY s.id
+HAVING COUNT(r.id) != 1
+ORDER BY s.start_time, s.id
+`
+
+type ListOpenPlaySessionReservationMismatchesParams struct {
+	FacilityID int64     `json:"facilityId"`
+	EndTime    time.Time `json:"endTime"`
+	StartTime  time.Time `json:"startTime"`
+}
+
+type ListOpenPlaySessionReservationMismatchesRow struct {
+	SessionID        int64     `json:"sessionId"`
+	OpenPlayRuleID   int64     `json:"openPlayRuleId"`
+	StartTime        time.Time `json:"startTime"`
+	EndTime          time.Time `json:"endTime"`
+	Status           string    `json:"status"`
+	ReservationCount int64     `json:"reservationCount"`
+	ReservationIds   string    `json:"reservationIds"`
+}
+
+// Live sessions in the range without exactly one backing reservation, by the
+// same match CountOpenPlayReservationsForSession uses, with the IDs found.
+func (q *Queries) ListOpenPlaySessionReservationMismatches(ctx context.Context, arg ListOpenPlaySessionReservationMismatchesParams) ([]ListOpenPlaySessionReservationMismatchesRow, error) {
+	rows, err := q.query(ctx, q.listOpenPlaySessionReservationMismatchesStmt, listOpenPlaySessionReservationMismatches, arg.FacilityID, arg.EndTime, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOpenPlaySessionReservationMismatchesRow
+	for rows.Next() {
+		var i ListOpenPlaySessionReservationMismatchesRow
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.OpenPlayRuleID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Status,
+			&i.ReservationCount,
+			&i.ReservationIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listOpenPlaySessions = `-- name: ListOpenPlaySessions :many
 SELECT id, facility_id, open_play_rule_id, start_time, end_time, status,
     current_court_count, auto_scale_override, cancelled_at, cancellation_reason,
@@ -1135,3 +1399,24 @@ func (q *Queries) UpdateSessionAutoScaleOverride(ctx context.Context, arg Update
 	)
 	return i, err
 }
+
+const upsertOpenPlaySessionSkillBands = `-- name: UpsertOpenPlaySessionSkillBands :one
+INSERT INTO open_play_session_skill_bands (session_id, skill_bands)
+VALUES (?1, ?2)
+ON CONFLICT(session_id) DO UPDATE SET
+    skill_bands = excluded.skill_bands,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING session_id, skill_bands, updated_at
+`
+
+type UpsertOpenPlaySessionSkillBandsParams struct {
+	SessionID  int64  `json:"sessionId"`
+	SkillBands string `json:"skillBands"`
+}
+
+func (q *Queries) UpsertOpenPlaySessionSkillBands(ctx context.Context, arg UpsertOpenPlaySessionSkillBandsParams) (OpenPlaySessionSkillBand, error) {
+	row := q.queryRow(ctx, q.upsertOpenPlaySessionSkillBandsStmt, upsertOpenPlaySessionSkillBands, arg.SessionID, arg.SkillBands)
+	var i OpenPlaySessionSkillBand
+	err := row.Scan(&i.SessionID, &i.SkillBands, &i.UpdatedAt)
+	return i, err
+}
@@ -124,6 +124,40 @@ func (q *Queries) GetOrganizationReminderConfig(ctx context.Context, id int64) (
 	return i, err
 }
 
+const getOrganizationSettings = `-- name: GetOrganizationSettings :one
+SELECT id, name, slug, status, cross_facility_visit_packs, shared_member_directory,
+    default_cancellation_policy, updated_at
+FROM organizations
+WHERE id = ?1
+`
+
+type GetOrganizationSettingsRow struct {
+	ID                        int64          `json:"id"`
+	Name                      string         `json:"name"`
+	Slug                      string         `json:"slug"`
+	Status                    string         `json:"status"`
+	CrossFacilityVisitPacks   bool           `json:"crossFacilityVisitPacks"`
+	SharedMemberDirectory     bool           `json:"sharedMemberDirectory"`
+	DefaultCancellationPolicy sql.NullString `json:"defaultCancellationPolicy"`
+	UpdatedAt                 time.Time      `json:"updatedAt"`
+}
+
+func (q *Queries) GetOrganizationSettings(ctx context.Context, id int64) (GetOrganizationSettingsRow, error) {
+	row := q.queryRow(ctx, q.getOrganizationSettingsStmt, getOrganizationSettings, id)
+	var i GetOrganizationSettingsRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.Status,
+		&i.CrossFacilityVisitPacks,
+		&i.SharedMemberDirectory,
+		&i.DefaultCancellationPolicy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const listOrganizations = `-- name: ListOrganizations :many
 SELECT id, name, slug, email_from_address, status, created_at, updated_at
 FROM organizations
@@ -196,3 +230,53 @@ func (q *Queries) UpdateOrganizationEmailConfig(ctx context.Context, arg UpdateO
 	err := row.Scan(&i.ID, &i.EmailFromAddress)
 	return i, err
 }
+
+const updateOrganizationSettings = `-- name: UpdateOrganizationSettings :one
+UPDATE organizations
+SET cross_facility_visit_packs = ?1,
+    shared_member_directory = ?2,
+    default_cancellation_policy = ?3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?4
+RETURNING id, name, slug, status, cross_facility_visit_packs, shared_member_directory,
+    default_cancellation_policy, updated_at
+`
+
+type UpdateOrganizationSettingsParams struct {
+	CrossFacilityVisitPacks   bool           `json:"crossFacilityVisitPacks"`
+	SharedMemberDirectory     bool           `json:"sharedMemberDirectory"`
+	DefaultCancellationPolicy sql.NullString `json:"defaultCancellationPolicy"`
+	ID                        int64          `json:"id"`
+}
+
+type UpdateOrganizationSettingsRow struct {
+	ID                        int64          `json:"id"`
+	Name                      string         `json:"name"`
+	Slug                      string         `json:"slug"`
+	Status                    string         `json:"status"`
+	CrossFacilityVisitPacks   bool           `json:"crossFacilityVisitPacks"`
+	SharedMemberDirectory     bool           `json:"sharedMemberDirectory"`
+	DefaultCancellationPolicy sql.NullString `json:"defaultCancellationPolicy"`
+	UpdatedAt                 time.Time      `json:"updatedAt"`
+}
+
+func (q *Queries) UpdateOrganizationSettings(ctx context.Context, arg UpdateOrganizationSettingsParams) (UpdateOrganizationSettingsRow, error) {
+	row := q.queryRow(ctx, q.updateOrganizationSettingsStmt, updateOrganizationSettings,
+		arg.CrossFacilityVisitPacks,
+		arg.SharedMemberDirectory,
+		arg.DefaultCancellationPolicy,
+		arg.ID,
+	)
+	var i UpdateOrganizationSettingsRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.Status,
+		&i.CrossFacilityVisitPacks,
+		&i.SharedMemberDirectory,
+		&i.DefaultCancellationPolicy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
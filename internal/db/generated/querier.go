@@ -12,86 +12,220 @@ import (
 
 type Querier interface {
 	AcceptOffer(ctx context.Context, arg AcceptOfferParams) (WaitlistOffer, error)
+	AddOpenPlayGamePlayer(ctx context.Context, arg AddOpenPlayGamePlayerParams) error
 	AddOpenPlayParticipant(ctx context.Context, arg AddOpenPlayParticipantParams) (ReservationParticipant, error)
 	AddParticipant(ctx context.Context, arg AddParticipantParams) error
 	AddReservationCourt(ctx context.Context, arg AddReservationCourtParams) error
+	AddReservationGuest(ctx context.Context, arg AddReservationGuestParams) (ReservationGuest, error)
 	AddTeamMember(ctx context.Context, arg AddTeamMemberParams) (LeagueTeamMember, error)
+	AddTournamentEntrant(ctx context.Context, arg AddTournamentEntrantParams) error
 	AdvanceWaitlistOffer(ctx context.Context, arg AdvanceWaitlistOfferParams) (WaitlistOffer, error)
+	// Historical reservations keep pointing at the row, so it stays with a
+	// placeholder name and no way to contact or identify the member.
+	AnonymizeDeletedMembers(ctx context.Context, arg AnonymizeDeletedMembersParams) (int64, error)
+	ApplyStagedWaitlistPositions(ctx context.Context, arg ApplyStagedWaitlistPositionsParams) (int64, error)
 	AssignFreeAgentToTeam(ctx context.Context, arg AssignFreeAgentToTeamParams) (LeagueTeamMember, error)
+	CancelPendingReservationInvitations(ctx context.Context, reservationID int64) error
+	// Clears the way for a new request or a staff transfer, and drops lapsed
+	// requests still marked pending.
+	CancelPendingReservationTransfers(ctx context.Context, reservationID int64) error
+	CancelUpcomingReservationInvitationsForUser(ctx context.Context, arg CancelUpcomingReservationInvitationsForUserParams) (int64, error)
+	// Zero rows means another run already sent this reminder.
+	ClaimReservationReminder(ctx context.Context, arg ClaimReservationReminderParams) (int64, error)
+	ClearUserEmailUndeliverable(ctx context.Context, id int64) (int64, error)
+	ConsumeAccountCredit(ctx context.Context, arg ConsumeAccountCreditParams) (int64, error)
+	// Zero rows means the member has no guest passes left.
+	ConsumeMemberGuestPass(ctx context.Context, arg ConsumeMemberGuestPassParams) (int64, error)
+	CountActiveCourts(ctx context.Context, facilityID int64) (int64, error)
+	CountActiveHouseholdDependents(ctx context.Context, primaryUserID int64) (int64, error)
+	CountActiveHouseholdReservations(ctx context.Context, arg CountActiveHouseholdReservationsParams) (int64, error)
 	CountActiveMemberReservations(ctx context.Context, arg CountActiveMemberReservationsParams) (int64, error)
+	// One row per hour from range_start up to range_end with how many active
+	// courts are reserved for any part of it. Each reservation holds its court
+	// through the turnover buffer that follows it, and maintenance blocks count
+	// like any other reservation.
+	CountBusyCourtsByHour(ctx context.Context, arg CountBusyCourtsByHourParams) ([]CountBusyCourtsByHourRow, error)
 	CountCheckinsByFacilityInRange(ctx context.Context, arg CountCheckinsByFacilityInRangeParams) (int64, error)
 	CountFacilityThemeName(ctx context.Context, arg CountFacilityThemeNameParams) (int64, error)
 	CountFacilityThemeNameExcludingID(ctx context.Context, arg CountFacilityThemeNameExcludingIDParams) (int64, error)
 	CountFacilityThemes(ctx context.Context, facilityID sql.NullInt64) (int64, error)
 	CountLessonPackageTypesByFacility(ctx context.Context, facilityID int64) (int64, error)
 	CountOpenPlayReservationsForSession(ctx context.Context, arg CountOpenPlayReservationsForSessionParams) (int64, error)
+	// Counts sessions in any status so a cancelled occurrence is not regenerated.
+	CountOpenPlaySessionsForSlot(ctx context.Context, arg CountOpenPlaySessionsForSlotParams) (int64, error)
+	CountPendingReservationInvitations(ctx context.Context, reservationID int64) (int64, error)
+	CountProSessionsByProInRange(ctx context.Context, arg CountProSessionsByProInRangeParams) ([]CountProSessionsByProInRangeRow, error)
 	CountReservationParticipants(ctx context.Context, reservationID int64) (int64, error)
+	CountReservationTypeNamesInRange(ctx context.Context, arg CountReservationTypeNamesInRangeParams) ([]CountReservationTypeNamesInRangeRow, error)
+	CountReservationTypeUsage(ctx context.Context, id int64) (int64, error)
 	CountReservationsByTypeInRange(ctx context.Context, arg CountReservationsByTypeInRangeParams) ([]CountReservationsByTypeInRangeRow, error)
 	CountScheduledVsCompletedReservations(ctx context.Context, arg CountScheduledVsCompletedReservationsParams) ([]CountScheduledVsCompletedReservationsRow, error)
 	CountThemeUsage(ctx context.Context, themeID sql.NullInt64) (int64, error)
+	CountTournamentMatches(ctx context.Context, tournamentID int64) (int64, error)
+	CountUnreadStaffInboxNotifications(ctx context.Context, arg CountUnreadStaffInboxNotificationsParams) (int64, error)
 	CountUnreadStaffNotifications(ctx context.Context, facilityID interface{}) (int64, error)
 	CountVisitPackTypesByFacility(ctx context.Context, facilityID int64) (int64, error)
+	// internal/db/queries/account_credits.sql
+	CreateAccountCredit(ctx context.Context, arg CreateAccountCreditParams) (AccountCredit, error)
+	CreateAccountCreditRedemption(ctx context.Context, arg CreateAccountCreditRedemptionParams) (AccountCreditRedemption, error)
 	CreateCancellationPolicyTier(ctx context.Context, arg CreateCancellationPolicyTierParams) (CancellationPolicyTier, error)
 	CreateClinicEnrollment(ctx context.Context, arg CreateClinicEnrollmentParams) (ClinicEnrollment, error)
 	CreateClinicSession(ctx context.Context, arg CreateClinicSessionParams) (ClinicSession, error)
 	// internal/db/queries/clinics.sql
 	CreateClinicType(ctx context.Context, arg CreateClinicTypeParams) (ClinicType, error)
 	CreateCourt(ctx context.Context, arg CreateCourtParams) (Court, error)
+	CreateCourtPricingRule(ctx context.Context, arg CreateCourtPricingRuleParams) (CourtPricingRule, error)
+	// A member may hold several courts for one slot; holding a new slot first
+	// deletes their earlier holds.
+	CreateCourtSlotHold(ctx context.Context, arg CreateCourtSlotHoldParams) (CourtSlotHold, error)
+	CreateDeferredEmail(ctx context.Context, arg CreateDeferredEmailParams) (DeferredEmail, error)
+	// internal/db/queries/email_delivery_events.sql
+	// SNS redelivers notifications, so a repeat of the same message is ignored.
+	CreateEmailDeliveryEvent(ctx context.Context, arg CreateEmailDeliveryEventParams) (int64, error)
+	CreateFacilityAnnouncement(ctx context.Context, arg CreateFacilityAnnouncementParams) (FacilityAnnouncement, error)
 	// internal/db/queries/facility_visits.sql
 	CreateFacilityVisit(ctx context.Context, arg CreateFacilityVisitParams) (FacilityVisit, error)
+	// internal/db/queries/households.sql
+	CreateHouseholdLink(ctx context.Context, arg CreateHouseholdLinkParams) (HouseholdLink, error)
+	CreateImpersonationAuditEntry(ctx context.Context, arg CreateImpersonationAuditEntryParams) error
 	// internal/db/queries/leagues.sql
 	CreateLeague(ctx context.Context, arg CreateLeagueParams) (League, error)
+	CreateLeagueFreeAgentRegistration(ctx context.Context, arg CreateLeagueFreeAgentRegistrationParams) (LeagueFreeAgent, error)
 	CreateLeagueMatch(ctx context.Context, arg CreateLeagueMatchParams) (LeagueMatch, error)
+	CreateLeagueMatchResultSubmission(ctx context.Context, arg CreateLeagueMatchResultSubmissionParams) (LeagueMatchResultSubmission, error)
+	// internal/db/queries/league_payments.sql
+	CreateLeaguePayment(ctx context.Context, arg CreateLeaguePaymentParams) (LeaguePayment, error)
 	CreateLeagueTeam(ctx context.Context, arg CreateLeagueTeamParams) (LeagueTeam, error)
+	CreateLeagueTeamInvitation(ctx context.Context, arg CreateLeagueTeamInvitationParams) (LeagueTeamInvitation, error)
 	CreateLessonCancelledNotification(ctx context.Context, arg CreateLessonCancelledNotificationParams) (StaffNotification, error)
 	CreateLessonPackage(ctx context.Context, arg CreateLessonPackageParams) (LessonPackage, error)
 	CreateLessonPackageRedemption(ctx context.Context, arg CreateLessonPackageRedemptionParams) (LessonPackageRedemption, error)
 	// internal/db/queries/lesson_packages.sql
 	CreateLessonPackageType(ctx context.Context, arg CreateLessonPackageTypeParams) (LessonPackageType, error)
+	CreateLessonReassignedNotification(ctx context.Context, arg CreateLessonReassignedNotificationParams) (StaffNotification, error)
 	CreateMember(ctx context.Context, arg CreateMemberParams) (int64, error)
+	CreateMemberCard(ctx context.Context, arg CreateMemberCardParams) (MemberCard, error)
+	CreateMemberHomeFacilityChange(ctx context.Context, arg CreateMemberHomeFacilityChangeParams) (MemberHomeFacilityChange, error)
+	// internal/db/queries/member_ratings.sql
+	// Verified entries are stamped verified by their recorder.
+	CreateMemberRatingEntry(ctx context.Context, arg CreateMemberRatingEntryParams) (MemberRatingHistory, error)
+	CreateMembershipTier(ctx context.Context, arg CreateMembershipTierParams) (MembershipTier, error)
+	CreateNoShowRestrictionClear(ctx context.Context, arg CreateNoShowRestrictionClearParams) (NoShowRestrictionClear, error)
 	CreateOpenPlayAuditLog(ctx context.Context, arg CreateOpenPlayAuditLogParams) (OpenPlayAuditLog, error)
+	CreateOpenPlayGame(ctx context.Context, arg CreateOpenPlayGameParams) (OpenPlayGame, error)
+	// internal/db/queries/open_play_rotation.sql
+	// Adds a checked-in player to the back of the session's queue.
+	CreateOpenPlayQueueEntry(ctx context.Context, arg CreateOpenPlayQueueEntryParams) (OpenPlayQueueEntry, error)
 	CreateOpenPlayRule(ctx context.Context, arg CreateOpenPlayRuleParams) (OpenPlayRule, error)
+	CreateOpenPlayRuleSlot(ctx context.Context, arg CreateOpenPlayRuleSlotParams) (OpenPlayRuleSlot, error)
 	CreateOpenPlaySession(ctx context.Context, arg CreateOpenPlaySessionParams) (OpenPlaySession, error)
 	CreatePhoto(ctx context.Context, arg CreatePhotoParams) (int64, error)
+	CreatePrimeTimeRule(ctx context.Context, arg CreatePrimeTimeRuleParams) (PrimeTimeRule, error)
 	CreateProUnavailability(ctx context.Context, arg CreateProUnavailabilityParams) (int64, error)
 	CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error)
+	CreateReservationCheckin(ctx context.Context, arg CreateReservationCheckinParams) (ReservationCheckin, error)
+	CreateReservationComment(ctx context.Context, arg CreateReservationCommentParams) (ReservationComment, error)
+	CreateReservationDurationOverride(ctx context.Context, arg CreateReservationDurationOverrideParams) (ReservationDurationOverride, error)
+	CreateReservationIdempotencyKey(ctx context.Context, arg CreateReservationIdempotencyKeyParams) error
+	// internal/db/queries/reservation_invitations.sql
+	// Re-inviting someone who declined or whose invitation was cancelled reopens
+	// the row with a new token. A pending or accepted invitation is left alone
+	// and no row is returned.
+	CreateReservationInvitation(ctx context.Context, arg CreateReservationInvitationParams) (ReservationInvitation, error)
+	// internal/db/queries/reservation_notes.sql
+	CreateReservationMemberNote(ctx context.Context, arg CreateReservationMemberNoteParams) error
+	CreateReservationPrice(ctx context.Context, arg CreateReservationPriceParams) (ReservationPrice, error)
+	// internal/db/queries/reservation_transfers.sql
+	CreateReservationTransfer(ctx context.Context, arg CreateReservationTransferParams) (ReservationTransfer, error)
+	CreateReservationType(ctx context.Context, arg CreateReservationTypeParams) (ReservationType, error)
+	CreateSeasonPass(ctx context.Context, arg CreateSeasonPassParams) (SeasonPass, error)
+	CreateSeasonPassReservation(ctx context.Context, arg CreateSeasonPassReservationParams) (SeasonPassReservation, error)
+	// internal/db/queries/season_passes.sql
+	CreateSeasonPassType(ctx context.Context, arg CreateSeasonPassTypeParams) (SeasonPassType, error)
+	CreateSeasonPassWindow(ctx context.Context, arg CreateSeasonPassWindowParams) (SeasonPassWindow, error)
 	CreateStaff(ctx context.Context, arg CreateStaffParams) (int64, error)
 	CreateStaffNotification(ctx context.Context, arg CreateStaffNotificationParams) (StaffNotification, error)
 	CreateStaffUser(ctx context.Context, arg CreateStaffUserParams) (int64, error)
 	CreateTheme(ctx context.Context, arg CreateThemeParams) (Theme, error)
+	// internal/db/queries/tournaments.sql
+	CreateTournament(ctx context.Context, arg CreateTournamentParams) (Tournament, error)
+	CreateTournamentMatch(ctx context.Context, arg CreateTournamentMatchParams) (TournamentMatch, error)
+	CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error)
 	CreateVisitPack(ctx context.Context, arg CreateVisitPackParams) (VisitPack, error)
+	CreateVisitPackPayment(ctx context.Context, arg CreateVisitPackPaymentParams) (VisitPackPayment, error)
 	CreateVisitPackRedemption(ctx context.Context, arg CreateVisitPackRedemptionParams) (VisitPackRedemption, error)
 	// internal/db/queries/visit_packs.sql
 	CreateVisitPackType(ctx context.Context, arg CreateVisitPackTypeParams) (VisitPackType, error)
 	// internal/db/queries/waitlist.sql
 	CreateWaitlistEntry(ctx context.Context, arg CreateWaitlistEntryParams) (Waitlist, error)
+	CreateWaitlistFulfilledNotification(ctx context.Context, arg CreateWaitlistFulfilledNotificationParams) (StaffNotification, error)
 	CreateWaitlistOffer(ctx context.Context, arg CreateWaitlistOfferParams) (WaitlistOffer, error)
 	DeactivateLessonPackageType(ctx context.Context, arg DeactivateLessonPackageTypeParams) (LessonPackageType, error)
+	DeactivateSeasonPassType(ctx context.Context, arg DeactivateSeasonPassTypeParams) (SeasonPassType, error)
 	DeactivateVisitPackType(ctx context.Context, arg DeactivateVisitPackTypeParams) (VisitPackType, error)
+	DeclineOffer(ctx context.Context, arg DeclineOfferParams) (WaitlistOffer, error)
 	DecrementLessonPackageLesson(ctx context.Context, id int64) (LessonPackage, error)
 	DecrementVisitPackVisit(ctx context.Context, id int64) (VisitPack, error)
+	DeleteActiveWaitlistEntriesForUser(ctx context.Context, userID int64) ([]Waitlist, error)
+	DeleteAnonymizableMemberBilling(ctx context.Context, cutoff sql.NullTime) (int64, error)
+	// Photos and billing go before the scrub below stamps anonymized_at, so the
+	// three statements share one cutoff.
+	DeleteAnonymizableMemberPhotos(ctx context.Context, cutoff sql.NullTime) (int64, error)
+	// Removes every tier of one policy: the facility default when
+	// reservation_type_id is NULL, otherwise that type's override.
+	DeleteCancellationPolicy(ctx context.Context, arg DeleteCancellationPolicyParams) (int64, error)
 	DeleteCancellationPolicyTier(ctx context.Context, arg DeleteCancellationPolicyTierParams) (int64, error)
 	DeleteClinicEnrollment(ctx context.Context, arg DeleteClinicEnrollmentParams) (int64, error)
 	DeleteClinicSession(ctx context.Context, arg DeleteClinicSessionParams) (int64, error)
 	DeleteClinicType(ctx context.Context, arg DeleteClinicTypeParams) (int64, error)
+	DeleteCourtPricingRule(ctx context.Context, arg DeleteCourtPricingRuleParams) (int64, error)
+	DeleteCourtSlotHoldByUser(ctx context.Context, userID int64) (int64, error)
+	DeleteExpiredCourtSlotHolds(ctx context.Context, now time.Time) (int64, error)
+	// Frees one user's key for reuse once it has expired.
+	DeleteExpiredReservationIdempotencyKey(ctx context.Context, arg DeleteExpiredReservationIdempotencyKeyParams) error
+	DeleteExpiredReservationIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+	DeleteExpiredUserSessions(ctx context.Context, now time.Time) (int64, error)
+	DeleteFacilityAnnouncement(ctx context.Context, arg DeleteFacilityAnnouncementParams) (int64, error)
+	DeleteFacilityHoursOverride(ctx context.Context, arg DeleteFacilityHoursOverrideParams) (int64, error)
+	DeleteFacilityNoShowPolicy(ctx context.Context, facilityID int64) (int64, error)
+	DeleteFacilityQuietHours(ctx context.Context, facilityID int64) (int64, error)
 	DeleteLeague(ctx context.Context, id int64) (int64, error)
+	DeleteLeagueFreeAgentRegistration(ctx context.Context, arg DeleteLeagueFreeAgentRegistrationParams) (int64, error)
 	DeleteLeagueMatchesByLeagueID(ctx context.Context, leagueID int64) (int64, error)
 	DeleteLessonPackageRedemptionsByReservationID(ctx context.Context, reservationID sql.NullInt64) error
-	DeleteMember(ctx context.Context, id int64) error
+	DeleteMember(ctx context.Context, id int64) (int64, error)
+	DeleteMemberSkillRating(ctx context.Context, userID int64) error
+	DeleteMembershipTier(ctx context.Context, arg DeleteMembershipTierParams) (int64, error)
+	DeleteOpenPlayQueueEntry(ctx context.Context, arg DeleteOpenPlayQueueEntryParams) error
 	DeleteOpenPlayRule(ctx context.Context, arg DeleteOpenPlayRuleParams) (int64, error)
+	DeleteOpenPlayRuleSlot(ctx context.Context, arg DeleteOpenPlayRuleSlotParams) (int64, error)
+	DeleteOpenPlaySessionSkillBands(ctx context.Context, sessionID int64) (int64, error)
 	DeleteOperatingHours(ctx context.Context, arg DeleteOperatingHoursParams) (int64, error)
 	DeletePastWaitlistEntries(ctx context.Context, arg DeletePastWaitlistEntriesParams) (int64, error)
 	DeletePhoto(ctx context.Context, id int64) error
+	DeletePrimeTimeRule(ctx context.Context, arg DeletePrimeTimeRuleParams) (int64, error)
 	DeleteProUnavailability(ctx context.Context, id int64) error
 	DeleteReservation(ctx context.Context, arg DeleteReservationParams) (int64, error)
+	DeleteReservationClosureDetails(ctx context.Context, reservationID int64) error
 	DeleteReservationCourtsByReservationID(ctx context.Context, reservationID int64) error
 	DeleteReservationParticipantsByReservationID(ctx context.Context, reservationID int64) error
+	DeleteReservationReminder(ctx context.Context, reservationID int64) error
+	DeleteReservationType(ctx context.Context, id int64) (int64, error)
+	DeleteSeasonPassWindows(ctx context.Context, passTypeID int64) error
 	DeleteStaff(ctx context.Context, id int64) error
+	DeleteStaffNotificationsBefore(ctx context.Context, cutoff time.Time) (int64, error)
 	DeleteTheme(ctx context.Context, id int64) (int64, error)
 	DeleteTierBookingWindow(ctx context.Context, arg DeleteTierBookingWindowParams) (int64, error)
 	DeleteWaitlistEntry(ctx context.Context, arg DeleteWaitlistEntryParams) (int64, error)
+	DismissFacilityAnnouncement(ctx context.Context, arg DismissFacilityAnnouncementParams) error
 	ExpireOffer(ctx context.Context, arg ExpireOfferParams) (WaitlistOffer, error)
+	ExpireSiblingWaitlistOffers(ctx context.Context, waitlistID int64) ([]WaitlistOffer, error)
 	FacilityExists(ctx context.Context, facilityID int64) (int64, error)
+	FindMatchingReservation(ctx context.Context, arg FindMatchingReservationParams) (int64, error)
+	GetActiveHouseholdLinkByDependent(ctx context.Context, dependentUserID int64) (HouseholdLink, error)
+	// internal/db/queries/member_cards.sql
+	GetActiveMemberCard(ctx context.Context, userID int64) (MemberCard, error)
+	GetActiveMemberCardByToken(ctx context.Context, cardToken string) (MemberCard, error)
 	// internal/db/queries/facility_themes.sql
 	GetActiveThemeID(ctx context.Context, facilityID int64) (int64, error)
 	// Prefer type-specific tiers over defaults, then pick the highest hours threshold.
@@ -110,17 +244,31 @@ type Querier interface {
 	GetCreatedMember(ctx context.Context) (GetCreatedMemberRow, error)
 	GetEligibleLessonPackageForUser(ctx context.Context, arg GetEligibleLessonPackageForUserParams) (LessonPackage, error)
 	GetEnrollmentCount(ctx context.Context, arg GetEnrollmentCountParams) (int64, error)
+	GetFacilityAnnouncement(ctx context.Context, arg GetFacilityAnnouncementParams) (FacilityAnnouncement, error)
 	GetFacilityByID(ctx context.Context, id int64) (Facility, error)
 	GetFacilityEmailConfig(ctx context.Context, id int64) (GetFacilityEmailConfigRow, error)
 	// internal/db/queries/schedules.sql
 	GetFacilityHours(ctx context.Context, facilityID int64) ([]OperatingHour, error)
+	GetFacilityHoursOverride(ctx context.Context, arg GetFacilityHoursOverrideParams) (FacilityHoursOverride, error)
+	// internal/db/queries/no_shows.sql
+	GetFacilityNoShowPolicy(ctx context.Context, facilityID int64) (FacilityNoShowPolicy, error)
+	// internal/db/queries/notifications_queue.sql
+	GetFacilityQuietHours(ctx context.Context, facilityID int64) (FacilityQuietHour, error)
 	GetFacilityTierBookingEnabled(ctx context.Context, id int64) (bool, error)
+	GetFirstLeagueMatchTime(ctx context.Context, leagueID int64) (time.Time, error)
 	GetFutureProSessionsByStaffID(ctx context.Context, arg GetFutureProSessionsByStaffIDParams) ([]GetFutureProSessionsByStaffIDRow, error)
+	GetHouseholdLink(ctx context.Context, id int64) (HouseholdLink, error)
+	GetHouseholdLinkByToken(ctx context.Context, token string) (HouseholdLink, error)
 	GetLatestCancellationByReservationID(ctx context.Context, reservationID int64) (ReservationCancellation, error)
+	GetLatestNoShowRestrictionClear(ctx context.Context, arg GetLatestNoShowRestrictionClearParams) (NoShowRestrictionClear, error)
+	GetLatestSyncSeq(ctx context.Context) (int64, error)
 	GetLeague(ctx context.Context, id int64) (League, error)
+	GetLeagueFreeAgentRegistration(ctx context.Context, arg GetLeagueFreeAgentRegistrationParams) (LeagueFreeAgent, error)
 	GetLeagueMatch(ctx context.Context, arg GetLeagueMatchParams) (LeagueMatch, error)
+	GetLeagueMatchResultSubmission(ctx context.Context, leagueMatchID int64) (LeagueMatchResultSubmission, error)
 	GetLeagueStandingsData(ctx context.Context, leagueID int64) ([]GetLeagueStandingsDataRow, error)
 	GetLeagueTeam(ctx context.Context, id int64) (LeagueTeam, error)
+	GetLeagueTeamInvitationByToken(ctx context.Context, token string) (GetLeagueTeamInvitationByTokenRow, error)
 	GetLeagueWithFacilityTimezone(ctx context.Context, id int64) (GetLeagueWithFacilityTimezoneRow, error)
 	GetLessonPackage(ctx context.Context, arg GetLessonPackageParams) (LessonPackage, error)
 	GetLessonPackageRedemptionInfo(ctx context.Context, id int64) (GetLessonPackageRedemptionInfoRow, error)
@@ -129,27 +277,57 @@ type Querier interface {
 	GetMemberByEmail(ctx context.Context, email sql.NullString) (User, error)
 	GetMemberByEmailIncludeDeleted(ctx context.Context, email sql.NullString) (User, error)
 	GetMemberByID(ctx context.Context, id int64) (GetMemberByIDRow, error)
+	GetMemberGuestPassBalance(ctx context.Context, arg GetMemberGuestPassBalanceParams) (int64, error)
+	// The earliest active reservation, at any facility, that the user is the
+	// primary user or a participant on and that overlaps [start_time, end_time).
+	GetMemberOverlappingReservation(ctx context.Context, arg GetMemberOverlappingReservationParams) (GetMemberOverlappingReservationRow, error)
 	GetMemberPhoto(ctx context.Context, userID int64) (GetMemberPhotoRow, error)
+	GetMemberRatingEntry(ctx context.Context, arg GetMemberRatingEntryParams) (MemberRatingHistory, error)
+	GetMemberSkillRating(ctx context.Context, userID int64) (MemberSkillRating, error)
 	GetMemberTodayActivities(ctx context.Context, arg GetMemberTodayActivitiesParams) ([]GetMemberTodayActivitiesRow, error)
+	GetMembershipTier(ctx context.Context, arg GetMembershipTierParams) (MembershipTier, error)
+	// The tier with the highest level at or below the member's.
+	GetMembershipTierForLevel(ctx context.Context, arg GetMembershipTierForLevelParams) (MembershipTier, error)
+	GetOpenEvent(ctx context.Context, arg GetOpenEventParams) (GetOpenEventRow, error)
 	GetOpenPlayReservationID(ctx context.Context, arg GetOpenPlayReservationIDParams) (int64, error)
 	GetOpenPlayRule(ctx context.Context, arg GetOpenPlayRuleParams) (OpenPlayRule, error)
 	GetOpenPlaySession(ctx context.Context, arg GetOpenPlaySessionParams) (GetOpenPlaySessionRow, error)
+	GetOpenPlaySessionSkillBands(ctx context.Context, arg GetOpenPlaySessionSkillBandsParams) (GetOpenPlaySessionSkillBandsRow, error)
 	GetOrganizationByID(ctx context.Context, id int64) (GetOrganizationByIDRow, error)
 	// internal/db/queries/organizations.sql
 	GetOrganizationBySlug(ctx context.Context, slug string) (GetOrganizationBySlugRow, error)
 	GetOrganizationCrossFacilitySetting(ctx context.Context, id int64) (bool, error)
 	GetOrganizationEmailConfig(ctx context.Context, id int64) (GetOrganizationEmailConfigRow, error)
 	GetOrganizationReminderConfig(ctx context.Context, id int64) (GetOrganizationReminderConfigRow, error)
+	GetOrganizationSettings(ctx context.Context, id int64) (GetOrganizationSettingsRow, error)
 	GetPendingOffer(ctx context.Context, waitlistID int64) (WaitlistOffer, error)
 	GetPhoto(ctx context.Context, id int64) (GetPhotoRow, error)
+	// The entry eligibility should use: the most recently verified one, else the
+	// latest unverified one.
+	GetPreferredMemberRating(ctx context.Context, userID int64) (MemberRatingHistory, error)
 	GetProLessonSlots(ctx context.Context, arg GetProLessonSlotsParams) ([]GetProLessonSlotsRow, error)
 	GetProUnavailabilityByID(ctx context.Context, id int64) (ProUnavailability, error)
 	GetReservation(ctx context.Context, arg GetReservationParams) (Reservation, error)
+	GetReservationAccessRevocation(ctx context.Context, reservationID int64) (ReservationAccessRevocation, error)
 	GetReservationByID(ctx context.Context, id int64) (Reservation, error)
+	GetReservationClosureDetails(ctx context.Context, reservationID int64) (ReservationClosureDetail, error)
+	GetReservationCreditAppliedCents(ctx context.Context, reservationID int64) (int64, error)
+	// internal/db/queries/reservation_idempotency_keys.sql
+	// Only unexpired keys count; an expired key may be reused.
+	GetReservationIdempotencyKey(ctx context.Context, arg GetReservationIdempotencyKeyParams) (ReservationIdempotencyKey, error)
+	GetReservationInvitationByToken(ctx context.Context, token string) (GetReservationInvitationByTokenRow, error)
+	GetReservationMemberNote(ctx context.Context, reservationID int64) (string, error)
+	GetReservationPrice(ctx context.Context, reservationID int64) (ReservationPrice, error)
+	GetReservationTransferByToken(ctx context.Context, token string) (GetReservationTransferByTokenRow, error)
 	GetReservationType(ctx context.Context, id int64) (ReservationType, error)
+	// The turnover buffer after a reservation of this type at the facility: the
+	// type's override, or the facility default.
+	GetReservationTypeBufferMinutes(ctx context.Context, arg GetReservationTypeBufferMinutesParams) (int64, error)
 	GetReservationTypeByName(ctx context.Context, name string) (ReservationType, error)
+	GetReservationTypeForFacility(ctx context.Context, arg GetReservationTypeForFacilityParams) (ReservationType, error)
 	GetReservationTypeNameByReservationID(ctx context.Context, reservationID int64) (string, error)
 	GetRestoredMember(ctx context.Context, id int64) (GetRestoredMemberRow, error)
+	GetSeasonPassType(ctx context.Context, arg GetSeasonPassTypeParams) (SeasonPassType, error)
 	GetStaffByEmail(ctx context.Context, email sql.NullString) (GetStaffByEmailRow, error)
 	GetStaffByID(ctx context.Context, id int64) (GetStaffByIDRow, error)
 	GetStaffByPhone(ctx context.Context, phone sql.NullString) (GetStaffByPhoneRow, error)
@@ -159,127 +337,358 @@ type Querier interface {
 	GetTheme(ctx context.Context, id int64) (Theme, error)
 	// internal/db/queries/tier_booking_window.sql
 	GetTierBookingWindow(ctx context.Context, arg GetTierBookingWindowParams) (MemberTierBookingWindow, error)
+	GetTournament(ctx context.Context, id int64) (Tournament, error)
+	GetTournamentMatch(ctx context.Context, arg GetTournamentMatchParams) (TournamentMatch, error)
 	GetUpdatedMember(ctx context.Context, id int64) (GetUpdatedMemberRow, error)
 	// internal/db/queries/users.sql
 	GetUserByEmail(ctx context.Context, email sql.NullString) (User, error)
 	GetUserByID(ctx context.Context, id int64) (User, error)
 	GetUserByPhone(ctx context.Context, phone sql.NullString) (User, error)
+	GetUserNotificationPreferences(ctx context.Context, id int64) (GetUserNotificationPreferencesRow, error)
+	GetUserPhoto(ctx context.Context, userID int64) (GetUserPhotoRow, error)
+	GetUserSessionByToken(ctx context.Context, token string) (UserSession, error)
 	GetVisitPack(ctx context.Context, arg GetVisitPackParams) (VisitPack, error)
 	GetVisitPackRedemptionInfo(ctx context.Context, id int64) (GetVisitPackRedemptionInfoRow, error)
 	GetVisitPackType(ctx context.Context, arg GetVisitPackTypeParams) (VisitPackType, error)
 	GetWaitlistConfig(ctx context.Context, facilityID int64) (WaitlistConfig, error)
 	GetWaitlistEntry(ctx context.Context, arg GetWaitlistEntryParams) (Waitlist, error)
+	GetWaitlistOfferForUser(ctx context.Context, arg GetWaitlistOfferForUserParams) (GetWaitlistOfferForUserRow, error)
+	GrantMemberGuestPasses(ctx context.Context, arg GrantMemberGuestPassesParams) (MemberGuestPass, error)
 	IsMemberOpenPlayParticipant(ctx context.Context, arg IsMemberOpenPlayParticipantParams) (int64, error)
+	// internal/db/queries/reservation_checkins.sql
+	IsReservationCancelled(ctx context.Context, reservationID int64) (int64, error)
+	IsReservationUpcoming(ctx context.Context, arg IsReservationUpcomingParams) (int64, error)
+	IsUserOnLeagueTeam(ctx context.Context, arg IsUserOnLeagueTeamParams) (int64, error)
+	ListAccountCreditRedemptions(ctx context.Context, arg ListAccountCreditRedemptionsParams) ([]AccountCreditRedemption, error)
+	ListAccountCredits(ctx context.Context, arg ListAccountCreditsParams) ([]AccountCredit, error)
+	ListActiveCourts(ctx context.Context, facilityID int64) ([]Court, error)
+	// Announcements showing now to audience (members or staff) that user_id has
+	// not dismissed, most severe first.
+	ListActiveFacilityAnnouncements(ctx context.Context, arg ListActiveFacilityAnnouncementsParams) ([]FacilityAnnouncement, error)
+	ListActiveHouseholdDependents(ctx context.Context, primaryUserID int64) ([]ListActiveHouseholdDependentsRow, error)
 	ListActiveLessonPackagesForUser(ctx context.Context, arg ListActiveLessonPackagesForUserParams) ([]LessonPackage, error)
 	ListActiveLessonPackagesForUserByFacility(ctx context.Context, arg ListActiveLessonPackagesForUserByFacilityParams) ([]LessonPackage, error)
 	ListActiveLessonPackagesForUserByOrganization(ctx context.Context, arg ListActiveLessonPackagesForUserByOrganizationParams) ([]LessonPackage, error)
+	ListActiveSeasonPassesForUserByFacility(ctx context.Context, arg ListActiveSeasonPassesForUserByFacilityParams) ([]ListActiveSeasonPassesForUserByFacilityRow, error)
+	ListActiveUserSessions(ctx context.Context, arg ListActiveUserSessionsParams) ([]UserSession, error)
 	ListActiveVisitPacksForUser(ctx context.Context, arg ListActiveVisitPacksForUserParams) ([]VisitPack, error)
 	ListActiveVisitPacksForUserByFacility(ctx context.Context, arg ListActiveVisitPacksForUserByFacilityParams) ([]VisitPack, error)
 	ListActiveVisitPacksForUserByOrganization(ctx context.Context, arg ListActiveVisitPacksForUserByOrganizationParams) ([]VisitPack, error)
 	ListAvailableCourts(ctx context.Context, arg ListAvailableCourtsParams) ([]ListAvailableCourtsRow, error)
+	ListBillingSummaryLessonPackages(ctx context.Context, arg ListBillingSummaryLessonPackagesParams) ([]ListBillingSummaryLessonPackagesRow, error)
+	ListBillingSummaryReservations(ctx context.Context, arg ListBillingSummaryReservationsParams) ([]ListBillingSummaryReservationsRow, error)
+	ListBillingSummaryVisitPacks(ctx context.Context, arg ListBillingSummaryVisitPacksParams) ([]ListBillingSummaryVisitPacksRow, error)
 	ListCancellationPolicyTiers(ctx context.Context, arg ListCancellationPolicyTiersParams) ([]CancellationPolicyTier, error)
+	// Empty facility_ids intentionally yields zero rows (caller should prefilter).
+	ListCancellationPolicyTiersForFacilities(ctx context.Context, facilityIds []int64) ([]CancellationPolicyTier, error)
+	// Empty reservation_ids intentionally yields zero rows (caller should prefilter).
+	ListCheckedInReservationIDs(ctx context.Context, arg ListCheckedInReservationIDsParams) ([]int64, error)
 	ListClinicSessionsByFacility(ctx context.Context, facilityID int64) ([]ClinicSession, error)
 	ListClinicTypesByFacility(ctx context.Context, facilityID int64) ([]ClinicType, error)
+	// Returns every active court once per overlapping block within the range
+	// (block columns are NULL for courts with nothing booked). Only the public
+	// closure reason is selected; internal notes never leave the staff views.
+	// Each block carries its turnover buffer, and every row the facility default.
+	ListCourtBlocksForDay(ctx context.Context, arg ListCourtBlocksForDayParams) ([]ListCourtBlocksForDayRow, error)
+	// One row per reserved court, so multi-court reservations count on each.
+	ListCourtBookingsInRange(ctx context.Context, arg ListCourtBookingsInRangeParams) ([]ListCourtBookingsInRangeRow, error)
+	// Reservations on the facility's courts within the window, each with the
+	// buffer that follows it and what identifies its recurring series.
+	ListCourtBufferNeighbors(ctx context.Context, arg ListCourtBufferNeighborsParams) ([]ListCourtBufferNeighborsRow, error)
+	// Pairs of reservations holding the same court at overlapping times, each
+	// pair once per shared court. Cancelled reservations have released their
+	// courts, so they never appear.
+	ListCourtOverlapConflicts(ctx context.Context, arg ListCourtOverlapConflictsParams) ([]ListCourtOverlapConflictsRow, error)
+	ListCourtPricingRules(ctx context.Context, facilityID int64) ([]CourtPricingRule, error)
 	ListCourts(ctx context.Context, facilityID int64) ([]Court, error)
 	ListDistinctFacilitiesWithScheduledSessions(ctx context.Context, comparisonTime time.Time) ([]int64, error)
+	ListDueDeferredEmails(ctx context.Context, arg ListDueDeferredEmailsParams) ([]DeferredEmail, error)
+	ListEmailDeliveryEvents(ctx context.Context, arg ListEmailDeliveryEventsParams) ([]EmailDeliveryEvent, error)
 	ListEnrollmentsForClinic(ctx context.Context, arg ListEnrollmentsForClinicParams) ([]ClinicEnrollment, error)
 	ListExpiredOffers(ctx context.Context, comparisonTime time.Time) ([]ListExpiredOffersRow, error)
 	// internal/db/queries/facilities.sql
 	ListFacilities(ctx context.Context) ([]Facility, error)
+	ListFacilitiesByOrganization(ctx context.Context, organizationID int64) ([]Facility, error)
+	ListFacilitiesWithOpenPlayRuleSlots(ctx context.Context) ([]int64, error)
+	ListFacilityAnnouncements(ctx context.Context, facilityID int64) ([]FacilityAnnouncement, error)
+	// One row per expected person for each live reservation starting in the range.
+	ListFacilityArrivalsInRange(ctx context.Context, arg ListFacilityArrivalsInRangeParams) ([]ListFacilityArrivalsInRangeRow, error)
+	ListFacilityHoursOverrides(ctx context.Context, arg ListFacilityHoursOverridesParams) ([]FacilityHoursOverride, error)
+	ListFacilityOpenPlayRuleSlots(ctx context.Context, facilityID int64) ([]ListFacilityOpenPlayRuleSlotsRow, error)
 	ListFacilityThemes(ctx context.Context, facilityID sql.NullInt64) ([]Theme, error)
 	ListFreeAgentsByLeague(ctx context.Context, leagueID int64) ([]ListFreeAgentsByLeagueRow, error)
+	// Courts with an unexpired hold overlapping the range by anyone other than
+	// holder_user_id.
+	ListHeldCourtIDs(ctx context.Context, arg ListHeldCourtIDsParams) ([]int64, error)
+	// Pending and active links on either side of the user, with both names.
+	ListHouseholdLinksForUser(ctx context.Context, userID int64) ([]ListHouseholdLinksForUserRow, error)
+	// The user plus everyone in their household: the primary account and each of
+	// its active dependents. A user in no household gets just their own ID.
+	ListHouseholdUserIDs(ctx context.Context, userID int64) ([]int64, error)
+	// internal/db/queries/job_statuses.sql
+	ListJobStatuses(ctx context.Context) ([]JobStatus, error)
+	ListLapsedLeagueMatchResultSubmissions(ctx context.Context, now time.Time) ([]ListLapsedLeagueMatchResultSubmissionsRow, error)
 	ListLeagueMatches(ctx context.Context, leagueID int64) ([]LeagueMatch, error)
 	ListLeagueMatchesWithReservations(ctx context.Context, leagueID int64) ([]ListLeagueMatchesWithReservationsRow, error)
+	ListLeagueTeamPayments(ctx context.Context, leagueTeamID int64) ([]LeaguePayment, error)
 	ListLeagueTeams(ctx context.Context, leagueID int64) ([]LeagueTeam, error)
 	ListLeaguesByFacility(ctx context.Context, facilityID int64) ([]League, error)
 	ListLessonPackageRedemptionsByReservationID(ctx context.Context, reservationID sql.NullInt64) ([]ListLessonPackageRedemptionsByReservationIDRow, error)
 	ListLessonPackageTypes(ctx context.Context, facilityID int64) ([]LessonPackageType, error)
+	// Courts taken out of service, either by a MAINTENANCE block in the range or
+	// by a court status other than active.
+	ListMaintenanceCourtsInRange(ctx context.Context, arg ListMaintenanceCourtsInRangeParams) ([]ListMaintenanceCourtsInRangeRow, error)
 	ListMatchingPendingWaitlistsForCancelledSlot(ctx context.Context, arg ListMatchingPendingWaitlistsForCancelledSlotParams) ([]Waitlist, error)
+	// Active teams the user captains or plays on in leagues at the facility that
+	// are still registering or in season.
+	ListMemberLeagueTeams(ctx context.Context, arg ListMemberLeagueTeamsParams) ([]ListMemberLeagueTeamsRow, error)
+	// Ended reservations the member was on where nobody checked in. Reservations
+	// cancelled before they started are not no-shows; late cancellations are.
+	ListMemberNoShowEndTimes(ctx context.Context, arg ListMemberNoShowEndTimesParams) ([]time.Time, error)
+	// Paid visits for the visit history export: the member's own reservations,
+	// with the visit pack that paid for them (0 when none), and visit pack
+	// redemptions made without a reservation (e.g. at check-in).
+	ListMemberPaidVisitsPage(ctx context.Context, arg ListMemberPaidVisitsPageParams) ([]ListMemberPaidVisitsPageRow, error)
+	ListMemberRatingHistory(ctx context.Context, userID int64) ([]MemberRatingHistory, error)
 	// Empty facility_ids intentionally yields zero rows (caller should prefilter).
 	ListMemberUpcomingOpenPlaySessions(ctx context.Context, arg ListMemberUpcomingOpenPlaySessionsParams) ([]ListMemberUpcomingOpenPlaySessionsRow, error)
 	// internal/db/queries/members.sql
 	// Queries for users who are members (is_member = 1)
 	// Uses consolidated users table
 	ListMembers(ctx context.Context, arg ListMembersParams) ([]ListMembersRow, error)
+	// internal/db/queries/membership_tiers.sql
+	ListMembershipTiers(ctx context.Context, organizationID int64) ([]MembershipTier, error)
+	// Finished reservations that had someone booked but nobody checked in.
+	ListNoShowReservationsInRange(ctx context.Context, arg ListNoShowReservationsInRangeParams) ([]ListNoShowReservationsInRangeRow, error)
 	ListOpenPlayAuditLog(ctx context.Context, arg ListOpenPlayAuditLogParams) ([]OpenPlayAuditLog, error)
+	ListOpenPlayParticipantSkillRatings(ctx context.Context, arg ListOpenPlayParticipantSkillRatingsParams) ([]ListOpenPlayParticipantSkillRatingsRow, error)
 	ListOpenPlayParticipants(ctx context.Context, arg ListOpenPlayParticipantsParams) ([]ListOpenPlayParticipantsRow, error)
+	ListOpenPlayParticipantsForSession(ctx context.Context, arg ListOpenPlayParticipantsForSessionParams) ([]ListOpenPlayParticipantsForSessionRow, error)
+	ListOpenPlayQueue(ctx context.Context, sessionID int64) ([]ListOpenPlayQueueRow, error)
+	ListOpenPlayRuleSlots(ctx context.Context, arg ListOpenPlayRuleSlotsParams) ([]OpenPlayRuleSlot, error)
 	ListOpenPlayRules(ctx context.Context, facilityID int64) ([]OpenPlayRule, error)
+	ListOpenPlaySessionCapacity(ctx context.Context, arg ListOpenPlaySessionCapacityParams) ([]ListOpenPlaySessionCapacityRow, error)
+	ListOpenPlaySessionCapacityByIDs(ctx context.Context, arg ListOpenPlaySessionCapacityByIDsParams) ([]ListOpenPlaySessionCapacityByIDsRow, error)
+	ListOpenPlaySessionFillInRange(ctx context.Context, arg ListOpenPlaySessionFillInRangeParams) ([]ListOpenPlaySessionFillInRangeRow, error)
+	// Live sessions in the range without exactly one backing reservation, by the
+	// same match CountOpenPlayReservationsForSession uses, with the IDs found.
+	ListOpenPlaySessionReservationMismatches(ctx context.Context, arg ListOpenPlaySessionReservationMismatchesParams) ([]ListOpenPlaySessionReservationMismatchesRow, error)
 	ListOpenPlaySessions(ctx context.Context, facilityID int64) ([]OpenPlaySession, error)
 	ListOpenPlaySessionsApproachingCutoff(ctx context.Context, arg ListOpenPlaySessionsApproachingCutoffParams) ([]OpenPlaySession, error)
 	ListOrganizations(ctx context.Context) ([]ListOrganizationsRow, error)
 	ListParticipantsForReservation(ctx context.Context, reservationID int64) ([]ListParticipantsForReservationRow, error)
+	// Empty reservation_ids intentionally yields zero rows (caller should prefilter).
+	ListParticipantsForReservations(ctx context.Context, reservationIds []int64) ([]ListParticipantsForReservationsRow, error)
+	ListPendingWaitlistOffersForFacility(ctx context.Context, arg ListPendingWaitlistOffersForFacilityParams) ([]ListPendingWaitlistOffersForFacilityRow, error)
+	// internal/db/queries/prime_time_rules.sql
+	ListPrimeTimeRules(ctx context.Context, facilityID int64) ([]PrimeTimeRule, error)
+	ListPrimeTimeRulesForDay(ctx context.Context, arg ListPrimeTimeRulesForDayParams) ([]PrimeTimeRule, error)
+	ListProLessonReportReservations(ctx context.Context, arg ListProLessonReportReservationsParams) ([]ListProLessonReportReservationsRow, error)
 	ListProUnavailabilityByFacilityAndDateRange(ctx context.Context, arg ListProUnavailabilityByFacilityAndDateRangeParams) ([]ProUnavailability, error)
 	ListProUnavailabilityByProID(ctx context.Context, proID int64) ([]ProUnavailability, error)
 	ListProsByFacility(ctx context.Context, facilityID sql.NullInt64) ([]ListProsByFacilityRow, error)
 	ListRecentVisitsByUser(ctx context.Context, userID int64) ([]FacilityVisit, error)
+	// One row per reservation court; reservations with no courts yield a single
+	// row with a NULL court_id. With court_id set, a matching reservation still
+	// returns all of its courts.
+	ListReservationCalendarEntries(ctx context.Context, arg ListReservationCalendarEntriesParams) ([]ListReservationCalendarEntriesRow, error)
+	// Participants plus the primary user, each with their check-in if recorded.
+	ListReservationCheckinRoster(ctx context.Context, reservationID int64) ([]ListReservationCheckinRosterRow, error)
+	ListReservationComments(ctx context.Context, reservationID int64) ([]ListReservationCommentsRow, error)
 	ListReservationCourts(ctx context.Context, reservationID int64) ([]ListReservationCourtsRow, error)
 	ListReservationCourtsByDateRange(ctx context.Context, arg ListReservationCourtsByDateRangeParams) ([]ListReservationCourtsByDateRangeRow, error)
+	ListReservationDurationOverrides(ctx context.Context, reservationID int64) ([]ReservationDurationOverride, error)
+	ListReservationFacilitiesByUserID(ctx context.Context, userID sql.NullInt64) ([]ListReservationFacilitiesByUserIDRow, error)
+	ListReservationGuests(ctx context.Context, reservationID int64) ([]ReservationGuest, error)
+	// Empty reservation_ids intentionally yields zero rows (caller should prefilter).
+	ListReservationGuestsForReservations(ctx context.Context, reservationIds []int64) ([]ReservationGuest, error)
+	// Empty reservation_ids intentionally yields zero rows (caller should prefilter).
+	ListReservationInvitationsForReservations(ctx context.Context, reservationIds []int64) ([]ListReservationInvitationsForReservationsRow, error)
+	// Empty reservation_ids intentionally yields zero rows (caller should prefilter).
+	ListReservationMemberNotesForReservations(ctx context.Context, reservationIds []int64) ([]ListReservationMemberNotesForReservationsRow, error)
 	ListReservationTypes(ctx context.Context) ([]ReservationType, error)
+	// Built-in types, the facility organization's shared types, and the
+	// facility's own types.
+	ListReservationTypesForFacility(ctx context.Context, facilityID sql.NullInt64) ([]ReservationType, error)
+	// Built-in types and every custom type in the organization, shared or
+	// facility-only.
+	ListReservationTypesForOrganization(ctx context.Context, organizationID sql.NullInt64) ([]ReservationType, error)
 	ListReservationsByDateRange(ctx context.Context, arg ListReservationsByDateRangeParams) ([]Reservation, error)
-	ListReservationsByUserID(ctx context.Context, userID sql.NullInt64) ([]ListReservationsByUserIDRow, error)
+	// Newest first unless oldest_first is set. A negative limit returns every row.
+	ListReservationsByUserID(ctx context.Context, arg ListReservationsByUserIDParams) ([]ListReservationsByUserIDRow, error)
+	// internal/db/queries/reservation_reminders.sql
+	// Non-cancelled reservations starting after now and within the reminder
+	// window that have not been reminded yet.
+	ListReservationsDueForReminder(ctx context.Context, arg ListReservationsDueForReminderParams) ([]Reservation, error)
+	ListReservationsForSync(ctx context.Context, arg ListReservationsForSyncParams) ([]ListReservationsForSyncRow, error)
 	ListReservationsStartingBetween(ctx context.Context, arg ListReservationsStartingBetweenParams) ([]Reservation, error)
+	ListSeasonPassTypes(ctx context.Context, facilityID int64) ([]SeasonPassType, error)
+	ListSeasonPassUsageForUser(ctx context.Context, userID int64) ([]ListSeasonPassUsageForUserRow, error)
+	ListSeasonPassWindows(ctx context.Context, passTypeID int64) ([]SeasonPassWindow, error)
+	ListSelfRegisteredFreeAgentsByLeague(ctx context.Context, leagueID int64) ([]ListSelfRegisteredFreeAgentsByLeagueRow, error)
+	// Unexpired credit with a balance left, oldest first, the order bookings
+	// use it in.
+	ListSpendableAccountCredits(ctx context.Context, arg ListSpendableAccountCreditsParams) ([]AccountCredit, error)
 	// internal/db/queries/staff.sql
 	// Queries for staff members (join staff table with users for auth/contact info)
 	ListStaff(ctx context.Context) ([]ListStaffRow, error)
 	ListStaffByFacility(ctx context.Context, facilityID sql.NullInt64) ([]ListStaffByFacilityRow, error)
 	ListStaffByRole(ctx context.Context, role string) ([]ListStaffByRoleRow, error)
+	// A staff member's inbox: notifications addressed to them plus those with no
+	// target, which go to every staff member at the facility. A NULL facility_id
+	// covers every facility for corporate staff; a NULL staff_id sees only
+	// untargeted notifications.
+	ListStaffInboxNotifications(ctx context.Context, arg ListStaffInboxNotificationsParams) ([]StaffNotification, error)
 	ListStaffNotifications(ctx context.Context, arg ListStaffNotificationsParams) ([]StaffNotification, error)
 	ListStaffNotificationsForFacilityOrCorporate(ctx context.Context, arg ListStaffNotificationsForFacilityOrCorporateParams) ([]StaffNotification, error)
 	ListStaffNotificationsForStaff(ctx context.Context, arg ListStaffNotificationsForStaffParams) ([]StaffNotification, error)
+	// internal/db/queries/sync.sql
+	// One row per changed entity, ordered by its latest change.
+	ListSyncChanges(ctx context.Context, arg ListSyncChangesParams) ([]ListSyncChangesRow, error)
 	ListSystemThemes(ctx context.Context) ([]Theme, error)
+	// Effective ratings for a league team's roster; rating is NULL for unrated
+	// players.
+	ListTeamMemberRatings(ctx context.Context, leagueTeamID int64) ([]ListTeamMemberRatingsRow, error)
 	ListTeamMembers(ctx context.Context, leagueTeamID int64) ([]LeagueTeamMember, error)
 	ListTierBookingWindowsForFacility(ctx context.Context, facilityID int64) ([]MemberTierBookingWindow, error)
 	ListTodayVisitsByFacility(ctx context.Context, arg ListTodayVisitsByFacilityParams) ([]FacilityVisit, error)
+	ListTournamentEntrants(ctx context.Context, tournamentID int64) ([]ListTournamentEntrantsRow, error)
+	ListTournamentMatches(ctx context.Context, tournamentID int64) ([]TournamentMatch, error)
+	ListTournamentsByFacility(ctx context.Context, facilityID int64) ([]Tournament, error)
+	// Active reservations on court_id that have not ended by now, including one
+	// in progress, which a court taken out of service would strand.
+	ListUpcomingCourtReservations(ctx context.Context, arg ListUpcomingCourtReservationsParams) ([]ListUpcomingCourtReservationsRow, error)
+	ListUpcomingOpenEvents(ctx context.Context, arg ListUpcomingOpenEventsParams) ([]ListUpcomingOpenEventsRow, error)
+	ListUpcomingReservationIDsForPrimaryUser(ctx context.Context, arg ListUpcomingReservationIDsForPrimaryUserParams) ([]int64, error)
 	ListVisitPackTypes(ctx context.Context, facilityID int64) ([]VisitPackType, error)
 	ListWaitlistsByFacility(ctx context.Context, facilityID int64) ([]Waitlist, error)
 	ListWaitlistsByUser(ctx context.Context, userID int64) ([]Waitlist, error)
 	ListWaitlistsByUserAndFacility(ctx context.Context, arg ListWaitlistsByUserAndFacilityParams) ([]Waitlist, error)
 	ListWaitlistsForSlot(ctx context.Context, arg ListWaitlistsForSlotParams) ([]Waitlist, error)
 	LogCancellation(ctx context.Context, arg LogCancellationParams) (ReservationCancellation, error)
+	MarkDeferredEmailProcessed(ctx context.Context, arg MarkDeferredEmailProcessedParams) (int64, error)
+	MarkEmailUndeliverable(ctx context.Context, email string) (int64, error)
+	MarkLeagueTeamInvitationAccepted(ctx context.Context, id int64) (int64, error)
+	MarkReservationInvitationAccepted(ctx context.Context, id int64) (int64, error)
+	// Accepted invitees may still back out before the reservation starts.
+	MarkReservationInvitationDeclined(ctx context.Context, id int64) (int64, error)
+	MarkReservationTransferAccepted(ctx context.Context, arg MarkReservationTransferAcceptedParams) (int64, error)
+	MarkReservationTransferDeclined(ctx context.Context, id int64) (int64, error)
 	MarkStaffNotificationAsRead(ctx context.Context, arg MarkStaffNotificationAsReadParams) (StaffNotification, error)
 	OperatingHoursExists(ctx context.Context, arg OperatingHoursExistsParams) (int64, error)
+	OverrideLeagueMatchResultSubmission(ctx context.Context, arg OverrideLeagueMatchResultSubmissionParams) (int64, error)
+	// Pass consecutive_failures as 1 for a failed run and 0 otherwise; failures
+	// add up across runs until one succeeds.
+	RecordJobRun(ctx context.Context, arg RecordJobRunParams) (JobStatus, error)
+	RemoveHouseholdLink(ctx context.Context, arg RemoveHouseholdLinkParams) (int64, error)
 	RemoveOpenPlayParticipant(ctx context.Context, arg RemoveOpenPlayParticipantParams) (int64, error)
 	RemoveParticipant(ctx context.Context, arg RemoveParticipantParams) error
 	RemoveReservationCourt(ctx context.Context, arg RemoveReservationCourtParams) error
 	RemoveTeamMember(ctx context.Context, arg RemoveTeamMemberParams) (int64, error)
+	// Takes the user off every future participant list, open play signups
+	// included, without touching the reservations themselves.
+	RemoveUserFromUpcomingReservations(ctx context.Context, arg RemoveUserFromUpcomingReservationsParams) (int64, error)
+	// Repeat requests keep the original timestamp.
+	RequestMemberDeletion(ctx context.Context, id int64) (sql.NullTime, error)
+	// Sends a player who just finished a game to the back of the queue.
+	RequeueOpenPlayPlayer(ctx context.Context, arg RequeueOpenPlayPlayerParams) error
+	// Whether two reservations at the facility still hold a shared court at
+	// overlapping times.
+	ReservationsOverlapOnCourt(ctx context.Context, arg ReservationsOverlapOnCourtParams) (int64, error)
+	// Settles a member's open invitation when staff add or remove them directly.
+	ResolveReservationInvitationForUser(ctx context.Context, arg ResolveReservationInvitationForUserParams) error
+	RespondToHouseholdLink(ctx context.Context, arg RespondToHouseholdLinkParams) (int64, error)
+	RespondToLeagueMatchResultSubmission(ctx context.Context, arg RespondToLeagueMatchResultSubmissionParams) (int64, error)
 	RestoreLessonPackageLesson(ctx context.Context, id int64) (LessonPackage, error)
 	RestoreMember(ctx context.Context, id int64) error
+	RevokeMemberCards(ctx context.Context, userID int64) (int64, error)
+	// Revokes every live session of the user except keep_id; pass 0 to revoke
+	// them all.
+	RevokeOtherUserSessions(ctx context.Context, arg RevokeOtherUserSessionsParams) (int64, error)
+	// internal/db/queries/reservation_access.sql
+	// Revoking an already revoked reservation keeps the first revocation.
+	RevokeReservationAccess(ctx context.Context, arg RevokeReservationAccessParams) (int64, error)
+	RevokeUserSession(ctx context.Context, arg RevokeUserSessionParams) (int64, error)
+	RevokeUserSessionByToken(ctx context.Context, token string) error
+	ScheduleTournamentMatch(ctx context.Context, arg ScheduleTournamentMatchParams) (int64, error)
+	// Case-insensitive prefix match on name or email, or on phone digits with or
+	// without the +1 country code, for the booking participant picker. @prefix
+	// must be lowercase; @phone_prefix is digits only or NULL.
+	SearchFacilityMembers(ctx context.Context, arg SearchFacilityMembersParams) ([]SearchFacilityMembersRow, error)
 	SearchMembers(ctx context.Context, arg SearchMembersParams) ([]SearchMembersRow, error)
+	SearchReservations(ctx context.Context, arg SearchReservationsParams) ([]SearchReservationsRow, error)
+	SeasonPassCoverageReport(ctx context.Context, facilityID int64) ([]SeasonPassCoverageReportRow, error)
+	SetStaffInboxNotificationRead(ctx context.Context, arg SetStaffInboxNotificationReadParams) (StaffNotification, error)
+	SetTournamentMatchTeamA(ctx context.Context, arg SetTournamentMatchTeamAParams) (TournamentMatch, error)
+	SetTournamentMatchTeamB(ctx context.Context, arg SetTournamentMatchTeamBParams) (TournamentMatch, error)
+	SetTournamentMatchWinner(ctx context.Context, arg SetTournamentMatchWinnerParams) (int64, error)
+	// Positions are renumbered in two steps because the slot position index is
+	// checked row by row: StageWaitlistPositions writes each active entry's new
+	// place as a negative number and ApplyStagedWaitlistPositions flips them back.
+	// Entries join at the back of the queue, so join order (id) is queue order.
+	StageWaitlistPositions(ctx context.Context, arg StageWaitlistPositionsParams) (int64, error)
+	TouchUserSession(ctx context.Context, arg TouchUserSessionParams) error
+	// Moves a member's usable packs held at from_facility_id to to_facility_id.
+	TransferActiveVisitPacks(ctx context.Context, arg TransferActiveVisitPacksParams) (int64, error)
 	UpdateBillingInfo(ctx context.Context, arg UpdateBillingInfoParams) (UserBilling, error)
 	UpdateCancellationPolicyTier(ctx context.Context, arg UpdateCancellationPolicyTierParams) (CancellationPolicyTier, error)
 	UpdateClinicSession(ctx context.Context, arg UpdateClinicSessionParams) (ClinicSession, error)
 	UpdateClinicType(ctx context.Context, arg UpdateClinicTypeParams) (ClinicType, error)
+	UpdateCourtPricingRule(ctx context.Context, arg UpdateCourtPricingRuleParams) (CourtPricingRule, error)
 	UpdateCourtStatus(ctx context.Context, arg UpdateCourtStatusParams) (Court, error)
 	UpdateEnrollmentStatus(ctx context.Context, arg UpdateEnrollmentStatusParams) (ClinicEnrollment, error)
+	UpdateFacilityAnnouncement(ctx context.Context, arg UpdateFacilityAnnouncementParams) (FacilityAnnouncement, error)
 	UpdateFacilityBookingConfig(ctx context.Context, arg UpdateFacilityBookingConfigParams) (Facility, error)
 	UpdateFacilityEmailConfig(ctx context.Context, arg UpdateFacilityEmailConfigParams) (UpdateFacilityEmailConfigRow, error)
 	UpdateFacilityVisitActivity(ctx context.Context, arg UpdateFacilityVisitActivityParams) (FacilityVisit, error)
 	UpdateLeague(ctx context.Context, arg UpdateLeagueParams) (League, error)
+	UpdateLeagueMatchStatus(ctx context.Context, arg UpdateLeagueMatchStatusParams) (int64, error)
 	UpdateLeagueTeam(ctx context.Context, arg UpdateLeagueTeamParams) (LeagueTeam, error)
+	UpdateLeagueTeamDivision(ctx context.Context, arg UpdateLeagueTeamDivisionParams) (LeagueTeam, error)
 	UpdateLessonPackageType(ctx context.Context, arg UpdateLessonPackageTypeParams) (LessonPackageType, error)
 	UpdateMatchResult(ctx context.Context, arg UpdateMatchResultParams) (LeagueMatch, error)
 	UpdateMember(ctx context.Context, arg UpdateMemberParams) error
 	UpdateMemberEmail(ctx context.Context, arg UpdateMemberEmailParams) (User, error)
+	UpdateMembershipTier(ctx context.Context, arg UpdateMembershipTierParams) (MembershipTier, error)
 	UpdateOpenPlayRule(ctx context.Context, arg UpdateOpenPlayRuleParams) (OpenPlayRule, error)
 	UpdateOpenPlaySessionCourtCount(ctx context.Context, arg UpdateOpenPlaySessionCourtCountParams) (OpenPlaySession, error)
 	UpdateOpenPlaySessionStatus(ctx context.Context, arg UpdateOpenPlaySessionStatusParams) (OpenPlaySession, error)
 	UpdateOrganizationEmailConfig(ctx context.Context, arg UpdateOrganizationEmailConfigParams) (UpdateOrganizationEmailConfigRow, error)
+	UpdateOrganizationSettings(ctx context.Context, arg UpdateOrganizationSettingsParams) (UpdateOrganizationSettingsRow, error)
+	UpdatePrimeTimeRule(ctx context.Context, arg UpdatePrimeTimeRuleParams) (PrimeTimeRule, error)
 	UpdateReservation(ctx context.Context, arg UpdateReservationParams) (Reservation, error)
+	UpdateReservationPrimaryUser(ctx context.Context, arg UpdateReservationPrimaryUserParams) (int64, error)
+	// Built-in types are shared by every organization and are not editable.
+	UpdateReservationType(ctx context.Context, arg UpdateReservationTypeParams) (ReservationType, error)
+	UpdateSeasonPassType(ctx context.Context, arg UpdateSeasonPassTypeParams) (SeasonPassType, error)
 	UpdateSessionAutoScaleOverride(ctx context.Context, arg UpdateSessionAutoScaleOverrideParams) (OpenPlaySession, error)
 	UpdateStaff(ctx context.Context, arg UpdateStaffParams) error
 	UpdateStaffUser(ctx context.Context, arg UpdateStaffUserParams) error
 	UpdateTeamCaptain(ctx context.Context, arg UpdateTeamCaptainParams) (LeagueTeam, error)
 	UpdateTheme(ctx context.Context, arg UpdateThemeParams) (Theme, error)
+	UpdateTournamentMatchStatus(ctx context.Context, arg UpdateTournamentMatchStatusParams) (int64, error)
+	UpdateTournamentStatus(ctx context.Context, arg UpdateTournamentStatusParams) (int64, error)
 	UpdateUserCognitoStatus(ctx context.Context, arg UpdateUserCognitoStatusParams) error
+	UpdateUserHideFromRosters(ctx context.Context, arg UpdateUserHideFromRostersParams) error
+	UpdateUserHomeFacility(ctx context.Context, arg UpdateUserHomeFacilityParams) error
+	UpdateUserNotificationPreferences(ctx context.Context, arg UpdateUserNotificationPreferencesParams) error
 	UpdateUserPasswordHash(ctx context.Context, arg UpdateUserPasswordHashParams) error
 	UpdateUserStatus(ctx context.Context, arg UpdateUserStatusParams) error
 	UpdateVisitPackType(ctx context.Context, arg UpdateVisitPackTypeParams) (VisitPackType, error)
+	UpdateWaitlistAutoBook(ctx context.Context, arg UpdateWaitlistAutoBookParams) (Waitlist, error)
 	UpdateWaitlistStatus(ctx context.Context, arg UpdateWaitlistStatusParams) (Waitlist, error)
 	UpsertActiveThemeID(ctx context.Context, arg UpsertActiveThemeIDParams) (int64, error)
+	UpsertFacilityHoursOverride(ctx context.Context, arg UpsertFacilityHoursOverrideParams) (FacilityHoursOverride, error)
+	UpsertFacilityNoShowPolicy(ctx context.Context, arg UpsertFacilityNoShowPolicyParams) (FacilityNoShowPolicy, error)
+	UpsertFacilityQuietHours(ctx context.Context, arg UpsertFacilityQuietHoursParams) (FacilityQuietHour, error)
+	UpsertMemberSkillRating(ctx context.Context, arg UpsertMemberSkillRatingParams) (MemberSkillRating, error)
+	UpsertOpenPlaySessionSkillBands(ctx context.Context, arg UpsertOpenPlaySessionSkillBandsParams) (OpenPlaySessionSkillBand, error)
 	UpsertOperatingHours(ctx context.Context, arg UpsertOperatingHoursParams) (OperatingHour, error)
 	UpsertPhoto(ctx context.Context, arg UpsertPhotoParams) (UserPhoto, error)
+	UpsertReservationClosureDetails(ctx context.Context, arg UpsertReservationClosureDetailsParams) error
 	UpsertTierBookingWindow(ctx context.Context, arg UpsertTierBookingWindowParams) (MemberTierBookingWindow, error)
 	UpsertWaitlistConfig(ctx context.Context, arg UpsertWaitlistConfigParams) (WaitlistConfig, error)
+	VerifyMemberRatingEntry(ctx context.Context, arg VerifyMemberRatingEntryParams) (MemberRatingHistory, error)
 }
 
 var _ Querier = (*Queries)(nil)
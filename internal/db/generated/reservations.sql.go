@@ -8,6 +8,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 )
 
@@ -26,6 +27,50 @@ func (q *Queries) AddParticipant(ctx context.Context, arg AddParticipantParams)
 	return err
 }
 
+const countActiveHouseholdReservations = `-- name: CountActiveHouseholdReservations :one
+SELECT COUNT(*)
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+WHERE r.facility_id = ?1
+  AND r.primary_user_id IN (/*SLICE:user_ids*/?)
+  AND r.start_time > CURRENT_TIMESTAMP
+  AND rt.counts_toward_member_limit = 1
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM season_pass_reservations spr
+      WHERE spr.reservation_id = r.id
+  )
+`
+
+type CountActiveHouseholdReservationsParams struct {
+	FacilityID int64   `json:"facilityId"`
+	UserIds    []int64 `json:"userIds"`
+}
+
+// CountActiveMemberReservations summed across every user in user_ids.
+func (q *Queries) CountActiveHouseholdReservations(ctx context.Context, arg CountActiveHouseholdReservationsParams) (int64, error) {
+	query := countActiveHouseholdReservations
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.FacilityID)
+	if len(arg.UserIds) > 0 {
+		for _, v := range arg.UserIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:user_ids*/?", strings.Repeat(",?", len(arg.UserIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:user_ids*/?", "NULL", 1)
+	}
+	row := q.queryRow(ctx, nil, query, queryParams...)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countActiveMemberReservations = `-- name: CountActiveMemberReservations :one
 SELECT COUNT(*)
 FROM reservations r
@@ -33,12 +78,17 @@ JOIN reservation_types rt ON rt.id = r.reservation_type_id
 WHERE r.facility_id = ?1
   AND r.primary_user_id = ?2
   AND r.start_time > CURRENT_TIMESTAMP
-  AND rt.name IN ('GAME', 'PRO_SESSION')
+  AND rt.counts_toward_member_limit = 1
   AND NOT EXISTS (
       SELECT 1
       FROM reservation_cancellations rcc
       WHERE rcc.reservation_id = r.id
   )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM season_pass_reservations spr
+      WHERE spr.reservation_id = r.id
+  )
 `
 
 type CountActiveMemberReservationsParams struct {
@@ -156,6 +206,16 @@ func (q *Queries) DeleteReservation(ctx context.Context, arg DeleteReservationPa
 	return result.RowsAffected()
 }
 
+const deleteReservationClosureDetails = `-- name: DeleteReservationClosureDetails :exec
+DELETE FROM reservation_closure_details
+WHERE reservation_id = ?1
+`
+
+func (q *Queries) DeleteReservationClosureDetails(ctx context.Context, reservationID int64) error {
+	_, err := q.exec(ctx, q.deleteReservationClosureDetailsStmt, deleteReservationClosureDetails, reservationID)
+	return err
+}
+
 const deleteReservationCourtsByReservationID = `-- name: DeleteReservationCourtsByReservationID :exec
 DELETE FROM reservation_courts
 WHERE reservation_id = ?1
@@ -176,6 +236,120 @@ func (q *Queries) DeleteReservationParticipantsByReservationID(ctx context.Conte
 	return err
 }
 
+const findMatchingReservation = `-- name: FindMatchingReservation :one
+SELECT r.id
+FROM reservations r
+JOIN reservation_courts rc ON rc.reservation_id = r.id
+WHERE r.facility_id = ?1
+  AND r.reservation_type_id = ?2
+  AND r.primary_user_id = ?3
+  AND r.start_time = ?4
+  AND r.end_time = ?5
+  AND rc.court_id = ?6
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+LIMIT 1
+`
+
+type FindMatchingReservationParams struct {
+	FacilityID        int64         `json:"facilityId"`
+	ReservationTypeID int64         `json:"reservationTypeId"`
+	PrimaryUserID     sql.NullInt64 `json:"primaryUserId"`
+	StartTime         time.Time     `json:"startTime"`
+	EndTime           time.Time     `json:"endTime"`
+	CourtID           int64         `json:"courtId"`
+}
+
+// An active reservation already booking court_id for the same type, primary
+// user, and times, so a repeated import can skip it.
+func (q *Queries) FindMatchingReservation(ctx context.Context, arg FindMatchingReservationParams) (int64, error) {
+	row := q.queryRow(ctx, q.findMatchingReservationStmt, findMatchingReservation,
+		arg.FacilityID,
+		arg.ReservationTypeID,
+		arg.PrimaryUserID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.CourtID,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getMemberOverlappingReservation = `-- name: GetMemberOverlappingReservation :one
+SELECT
+    r.id,
+    r.facility_id,
+    r.start_time,
+    r.end_time,
+    f.name AS facility_name,
+    rt.name AS reservation_type_name,
+    CAST(COALESCE(group_concat(DISTINCT COALESCE(NULLIF(c.name, ''), 'Court ' || c.court_number)), '') AS TEXT) AS court_names
+FROM reservations r
+JOIN facilities f ON f.id = r.facility_id
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+LEFT JOIN reservation_courts rc ON rc.reservation_id = r.id
+LEFT JOIN courts c ON c.id = rc.court_id
+WHERE (
+        r.primary_user_id = ?1
+     OR EXISTS (
+         SELECT 1
+         FROM reservation_participants rp
+         WHERE rp.reservation_id = r.id
+           AND rp.user_id = ?1
+     )
+  )
+  AND r.start_time < ?2
+  AND r.end_time > ?3
+  AND r.id != ?4
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+GROUP BY r.id, r.facility_id, r.start_time, r.end_time, f.name, rt.name
+ORDER BY r.start_time, r.id
+LIMIT 1
+`
+
+type GetMemberOverlappingReservationParams struct {
+	UserID               sql.NullInt64 `json:"userId"`
+	EndTime              time.Time     `json:"endTime"`
+	StartTime            time.Time     `json:"startTime"`
+	ExcludeReservationID int64         `json:"excludeReservationId"`
+}
+
+type GetMemberOverlappingReservationRow struct {
+	ID                  int64     `json:"id"`
+	FacilityID          int64     `json:"facilityId"`
+	StartTime           time.Time `json:"startTime"`
+	EndTime             time.Time `json:"endTime"`
+	FacilityName        string    `json:"facilityName"`
+	ReservationTypeName string    `json:"reservationTypeName"`
+	CourtNames          string    `json:"courtNames"`
+}
+
+// The earliest active reservation, at any facility, that the user is the
+// primary user or a participant on and that overlaps [start_time, end_time),
+// other than exclude_reservation_id (zero excludes nothing).
+func (q *Queries) GetMemberOverlappingReservation(ctx context.Context, arg GetMemberOverlappingReservationParams) (GetMemberOverlappingReservationRow, error) {
+	row := q.queryRow(ctx, q.getMemberOverlappingReservationStmt, getMemberOverlappingReservation, arg.UserID, arg.EndTime, arg.StartTime, arg.ExcludeReservationID)
+	var i GetMemberOverlappingReservationRow
+	err := row.Scan(
+		&i.ID,
+		&i.FacilityID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.FacilityName,
+		&i.ReservationTypeName,
+		&i.CourtNames,
+	)
+	return i, err
+}
+
 const getReservation = `-- name: GetReservation :one
 SELECT id, facility_id, reservation_type_id, recurrence_rule_id,
     primary_user_id, created_by_user_id, pro_id, open_play_rule_id, start_time, end_time,
@@ -244,40 +418,19 @@ func (q *Queries) GetReservationByID(ctx context.Context, id int64) (Reservation
 	return i, err
 }
 
-const getReservationType = `-- name: GetReservationType :one
-SELECT id, name, description, color, created_at, updated_at
-FROM reservation_types
-WHERE id = ?1
-`
-
-func (q *Queries) GetReservationType(ctx context.Context, id int64) (ReservationType, error) {
-	row := q.queryRow(ctx, q.getReservationTypeStmt, getReservationType, id)
-	var i ReservationType
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Description,
-		&i.Color,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
-}
-
-const getReservationTypeByName = `-- name: GetReservationTypeByName :one
-SELECT id, name, description, color, created_at, updated_at
-FROM reservation_types
-WHERE LOWER(name) = LOWER(?1)
+const getReservationClosureDetails = `-- name: GetReservationClosureDetails :one
+SELECT reservation_id, public_reason, internal_notes, created_at, updated_at
+FROM reservation_closure_details
+WHERE reservation_id = ?1
 `
 
-func (q *Queries) GetReservationTypeByName(ctx context.Context, name string) (ReservationType, error) {
-	row := q.queryRow(ctx, q.getReservationTypeByNameStmt, getReservationTypeByName, name)
-	var i ReservationType
+func (q *Queries) GetReservationClosureDetails(ctx context.Context, reservationID int64) (ReservationClosureDetail, error) {
+	row := q.queryRow(ctx, q.getReservationClosureDetailsStmt, getReservationClosureDetails, reservationID)
+	var i ReservationClosureDetail
 	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Description,
-		&i.Color,
+		&i.ReservationID,
+		&i.PublicReason,
+		&i.InternalNotes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -298,6 +451,99 @@ func (q *Queries) GetReservationTypeNameByReservationID(ctx context.Context, res
 	return name, err
 }
 
+const listCourtOverlapConflicts = `-- name: ListCourtOverlapConflicts :many
+SELECT
+    c.id AS court_id,
+    c.court_number,
+    c.name AS court_name,
+    a.id AS first_reservation_id,
+    a.start_time AS first_start_time,
+    a.end_time AS first_end_time,
+    a.primary_user_id AS first_primary_user_id,
+    rta.name AS first_reservation_type,
+    b.id AS second_reservation_id,
+    b.start_time AS second_start_time,
+    b.end_time AS second_end_time,
+    b.primary_user_id AS second_primary_user_id,
+    rtb.name AS second_reservation_type
+FROM reservations a
+JOIN reservation_courts ac ON ac.reservation_id = a.id
+JOIN reservation_courts bc ON bc.court_id = ac.court_id AND bc.reservation_id > a.id
+JOIN reservations b ON b.id = bc.reservation_id
+JOIN courts c ON c.id = ac.court_id
+JOIN reservation_types rta ON rta.id = a.reservation_type_id
+JOIN reservation_types rtb ON rtb.id = b.reservation_type_id
+WHERE a.facility_id = ?1
+  AND a.start_time < ?2
+  AND a.end_time > ?3
+  AND b.facility_id = ?1
+  AND b.start_time < a.end_time
+  AND b.end_time > a.start_time
+ORDER BY a.start_time, c.court_number, a.id, b.id
+`
+
+type ListCourtOverlapConflictsParams struct {
+	FacilityID int64     `json:"facilityId"`
+	EndTime    time.Time `json:"endTime"`
+	StartTime  time.Time `json:"startTime"`
+}
+
+type ListCourtOverlapConflictsRow struct {
+	CourtID               int64         `json:"courtId"`
+	CourtNumber           int64         `json:"courtNumber"`
+	CourtName             string        `json:"courtName"`
+	FirstReservationID    int64         `json:"firstReservationId"`
+	FirstStartTime        time.Time     `json:"firstStartTime"`
+	FirstEndTime          time.Time     `json:"firstEndTime"`
+	FirstPrimaryUserID    sql.NullInt64 `json:"firstPrimaryUserId"`
+	FirstReservationType  string        `json:"firstReservationType"`
+	SecondReservationID   int64         `json:"secondReservationId"`
+	SecondStartTime       time.Time     `json:"secondStartTime"`
+	SecondEndTime         time.Time     `json:"secondEndTime"`
+	SecondPrimaryUserID   sql.NullInt64 `json:"secondPrimaryUserId"`
+	SecondReservationType string        `json:"secondReservationType"`
+}
+
+// Pairs of reservations holding the same court at overlapping times, each
+// pair once per shared court. Cancelled reservations have released their
+// courts, so they never appear.
+func (q *Queries) ListCourtOverlapConflicts(ctx context.Context, arg ListCourtOverlapConflictsParams) ([]ListCourtOverlapConflictsRow, error) {
+	rows, err := q.query(ctx, q.listCourtOverlapConflictsStmt, listCourtOverlapConflicts, arg.FacilityID, arg.EndTime, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCourtOverlapConflictsRow
+	for rows.Next() {
+		var i ListCourtOverlapConflictsRow
+		if err := rows.Scan(
+			&i.CourtID,
+			&i.CourtNumber,
+			&i.CourtName,
+			&i.FirstReservationID,
+			&i.FirstStartTime,
+			&i.FirstEndTime,
+			&i.FirstPrimaryUserID,
+			&i.FirstReservationType,
+			&i.SecondReservationID,
+			&i.SecondStartTime,
+			&i.SecondEndTime,
+			&i.SecondPrimaryUserID,
+			&i.SecondReservationType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listParticipantsForReservation = `-- name: ListParticipantsForReservation :many
 SELECT u.id, u.email, u.phone, u.first_name, u.last_name, u.photo_url,
     u.is_member, u.is_staff, u.membership_level, u.status
@@ -354,6 +600,153 @@ func (q *Queries) ListParticipantsForReservation(ctx context.Context, reservatio
 	return items, nil
 }
 
+const listParticipantsForReservations = `-- name: ListParticipantsForReservations :many
+SELECT rp.reservation_id, u.id, u.email, u.first_name, u.last_name
+FROM reservation_participants rp
+JOIN users u ON u.id = rp.user_id
+WHERE rp.reservation_id IN (/*SLICE:reservation_ids*/?)
+ORDER BY rp.reservation_id, u.last_name, u.first_name
+`
+
+type ListParticipantsForReservationsRow struct {
+	ReservationID int64          `json:"reservationId"`
+	ID            int64          `json:"id"`
+	Email         sql.NullString `json:"email"`
+	FirstName     string         `json:"firstName"`
+	LastName      string         `json:"lastName"`
+}
+
+// Empty reservation_ids intentionally yields zero rows (caller should prefilter).
+func (q *Queries) ListParticipantsForReservations(ctx context.Context, reservationIds []int64) ([]ListParticipantsForReservationsRow, error) {
+	query := listParticipantsForReservations
+	var queryParams []interface{}
+	if len(reservationIds) > 0 {
+		for _, v := range reservationIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:reservation_ids*/?", strings.Repeat(",?", len(reservationIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:reservation_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListParticipantsForReservationsRow
+	for rows.Next() {
+		var i ListParticipantsForReservationsRow
+		if err := rows.Scan(
+			&i.ReservationID,
+			&i.ID,
+			&i.Email,
+			&i.FirstName,
+			&i.LastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReservationCalendarEntries = `-- name: ListReservationCalendarEntries :many
+SELECT r.id, r.start_time, r.end_time, r.is_open_event,
+    rt.name AS reservation_type_name,
+    rt.color AS reservation_type_color,
+    rc.court_id,
+    u.first_name AS primary_first_name,
+    u.last_name AS primary_last_name
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+LEFT JOIN reservation_courts rc ON rc.reservation_id = r.id
+LEFT JOIN users u ON u.id = r.primary_user_id
+WHERE r.facility_id = ?1
+  AND r.start_time < ?2
+  AND r.end_time > ?3
+  AND (
+      ?4 IS NULL
+      OR EXISTS (
+          SELECT 1
+          FROM reservation_courts frc
+          WHERE frc.reservation_id = r.id
+            AND frc.court_id = ?4
+      )
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY r.start_time, r.id, rc.court_id
+`
+
+type ListReservationCalendarEntriesParams struct {
+	FacilityID int64       `json:"facilityId"`
+	EndTime    time.Time   `json:"endTime"`
+	StartTime  time.Time   `json:"startTime"`
+	CourtID    interface{} `json:"courtId"`
+}
+
+type ListReservationCalendarEntriesRow struct {
+	ID                   int64          `json:"id"`
+	StartTime            time.Time      `json:"startTime"`
+	EndTime              time.Time      `json:"endTime"`
+	IsOpenEvent          bool           `json:"isOpenEvent"`
+	ReservationTypeName  string         `json:"reservationTypeName"`
+	ReservationTypeColor sql.NullString `json:"reservationTypeColor"`
+	CourtID              sql.NullInt64  `json:"courtId"`
+	PrimaryFirstName     sql.NullString `json:"primaryFirstName"`
+	PrimaryLastName      sql.NullString `json:"primaryLastName"`
+}
+
+// One row per reservation court; reservations with no courts yield a single
+// row with a NULL court_id. With court_id set, a matching reservation still
+// returns all of its courts.
+func (q *Queries) ListReservationCalendarEntries(ctx context.Context, arg ListReservationCalendarEntriesParams) ([]ListReservationCalendarEntriesRow, error) {
+	rows, err := q.query(ctx, q.listReservationCalendarEntriesStmt, listReservationCalendarEntries,
+		arg.FacilityID,
+		arg.EndTime,
+		arg.StartTime,
+		arg.CourtID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListReservationCalendarEntriesRow
+	for rows.Next() {
+		var i ListReservationCalendarEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.IsOpenEvent,
+			&i.ReservationTypeName,
+			&i.ReservationTypeColor,
+			&i.CourtID,
+			&i.PrimaryFirstName,
+			&i.PrimaryLastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listReservationCourtsByDateRange = `-- name: ListReservationCourtsByDateRange :many
 SELECT rc.reservation_id, c.court_number
 FROM reservation_courts rc
@@ -404,29 +797,48 @@ func (q *Queries) ListReservationCourtsByDateRange(ctx context.Context, arg List
 	return items, nil
 }
 
-const listReservationTypes = `-- name: ListReservationTypes :many
-SELECT id, name, description, color, created_at, updated_at
-FROM reservation_types
-ORDER BY name
+const listReservationFacilitiesByUserID = `-- name: ListReservationFacilitiesByUserID :many
+SELECT DISTINCT f.id, f.name
+FROM reservations r
+JOIN facilities f ON f.id = r.facility_id
+WHERE (
+        r.primary_user_id = ?1
+     OR EXISTS (
+         SELECT 1
+         FROM reservation_participants rp
+         WHERE rp.reservation_id = r.id
+           AND rp.user_id = ?1
+     )
+     OR r.primary_user_id IN (
+         SELECT hl.dependent_user_id
+         FROM household_links hl
+         WHERE hl.primary_user_id = ?1
+           AND hl.status = 'active'
+     )
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY f.name
 `
 
-func (q *Queries) ListReservationTypes(ctx context.Context) ([]ReservationType, error) {
-	rows, err := q.query(ctx, q.listReservationTypesStmt, listReservationTypes)
+type ListReservationFacilitiesByUserIDRow struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (q *Queries) ListReservationFacilitiesByUserID(ctx context.Context, userID sql.NullInt64) ([]ListReservationFacilitiesByUserIDRow, error) {
+	rows, err := q.query(ctx, q.listReservationFacilitiesByUserIDStmt, listReservationFacilitiesByUserID, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ReservationType
+	var items []ListReservationFacilitiesByUserIDRow
 	for rows.Next() {
-		var i ReservationType
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Description,
-			&i.Color,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
+		var i ListReservationFacilitiesByUserIDRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -519,6 +931,7 @@ SELECT
     r.created_at,
     r.updated_at,
     f.name AS facility_name,
+    f.timezone AS facility_timezone,
     rt.name AS reservation_type_name,
     s.first_name AS pro_first_name,
     s.last_name AS pro_last_name,
@@ -529,13 +942,20 @@ LEFT JOIN reservation_types rt ON rt.id = r.reservation_type_id
 LEFT JOIN staff s ON s.id = r.pro_id
 LEFT JOIN reservation_courts rc ON rc.reservation_id = r.id
 LEFT JOIN courts c ON c.id = rc.court_id
+CROSS JOIN (SELECT CAST(?1 AS BOOLEAN) AS oldest_first) sort_order
 WHERE (
-        r.primary_user_id = ?1
+        r.primary_user_id = ?2
      OR EXISTS (
          SELECT 1
          FROM reservation_participants rp
          WHERE rp.reservation_id = r.id
-           AND rp.user_id = ?1
+           AND rp.user_id = ?2
+     )
+     OR r.primary_user_id IN (
+         SELECT hl.dependent_user_id
+         FROM household_links hl
+         WHERE hl.primary_user_id = ?2
+           AND hl.status = 'active'
      )
   )
   AND NOT EXISTS (
@@ -543,6 +963,10 @@ WHERE (
       FROM reservation_cancellations rcc
       WHERE rcc.reservation_id = r.id
   )
+  AND (?3 IS NULL OR r.facility_id = ?3)
+  AND (?4 IS NULL OR r.start_time >= ?4)
+  AND (?5 IS NULL OR r.start_time < ?5)
+  AND (?6 IS NULL OR r.end_time > ?6)
 GROUP BY r.id,
     r.facility_id,
     r.reservation_type_id,
@@ -559,12 +983,26 @@ GROUP BY r.id,
     r.created_at,
     r.updated_at,
     f.name,
+    f.timezone,
     rt.name,
     s.first_name,
     s.last_name
-ORDER BY r.start_time DESC
+ORDER BY CASE WHEN sort_order.oldest_first THEN r.start_time END ASC,
+    r.start_time DESC
+LIMIT ?8 OFFSET ?7
 `
 
+type ListReservationsByUserIDParams struct {
+	OldestFirst bool          `json:"oldestFirst"`
+	UserID      sql.NullInt64 `json:"userId"`
+	FacilityID  interface{}   `json:"facilityId"`
+	StartFrom   interface{}   `json:"startFrom"`
+	StartBefore interface{}   `json:"startBefore"`
+	EndAfter    interface{}   `json:"endAfter"`
+	Offset      int64         `json:"offset"`
+	Limit       int64         `json:"limit"`
+}
+
 type ListReservationsByUserIDRow struct {
 	ID                  int64          `json:"id"`
 	FacilityID          int64          `json:"facilityId"`
@@ -582,14 +1020,25 @@ type ListReservationsByUserIDRow struct {
 	CreatedAt           time.Time      `json:"createdAt"`
 	UpdatedAt           time.Time      `json:"updatedAt"`
 	FacilityName        string         `json:"facilityName"`
+	FacilityTimezone    string         `json:"facilityTimezone"`
 	ReservationTypeName sql.NullString `json:"reservationTypeName"`
 	ProFirstName        sql.NullString `json:"proFirstName"`
 	ProLastName         sql.NullString `json:"proLastName"`
 	CourtName           string         `json:"courtName"`
 }
 
-func (q *Queries) ListReservationsByUserID(ctx context.Context, userID sql.NullInt64) ([]ListReservationsByUserIDRow, error) {
-	rows, err := q.query(ctx, q.listReservationsByUserIDStmt, listReservationsByUserID, userID)
+// Newest first unless oldest_first is set. A negative limit returns every row.
+func (q *Queries) ListReservationsByUserID(ctx context.Context, arg ListReservationsByUserIDParams) ([]ListReservationsByUserIDRow, error) {
+	rows, err := q.query(ctx, q.listReservationsByUserIDStmt, listReservationsByUserID,
+		arg.OldestFirst,
+		arg.UserID,
+		arg.FacilityID,
+		arg.StartFrom,
+		arg.StartBefore,
+		arg.EndAfter,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -614,6 +1063,7 @@ func (q *Queries) ListReservationsByUserID(ctx context.Context, userID sql.NullI
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FacilityName,
+			&i.FacilityTimezone,
 			&i.ReservationTypeName,
 			&i.ProFirstName,
 			&i.ProLastName,
@@ -693,6 +1143,108 @@ func (q *Queries) ListReservationsStartingBetween(ctx context.Context, arg ListR
 	return items, nil
 }
 
+const listUpcomingCourtReservations = `-- name: ListUpcomingCourtReservations :many
+SELECT r.id, r.facility_id, r.start_time, r.end_time, r.primary_user_id, rt.name AS reservation_type
+FROM reservations r
+JOIN reservation_courts rc ON rc.reservation_id = r.id
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+WHERE rc.court_id = ?1
+  AND r.end_time > ?2
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY r.start_time, r.id
+`
+
+type ListUpcomingCourtReservationsParams struct {
+	CourtID int64     `json:"courtId"`
+	Now     time.Time `json:"now"`
+}
+
+type ListUpcomingCourtReservationsRow struct {
+	ID              int64         `json:"id"`
+	FacilityID      int64         `json:"facilityId"`
+	StartTime       time.Time     `json:"startTime"`
+	EndTime         time.Time     `json:"endTime"`
+	PrimaryUserID   sql.NullInt64 `json:"primaryUserId"`
+	ReservationType string        `json:"reservationType"`
+}
+
+// Active reservations on court_id that have not ended by now, including one
+// in progress, which a court taken out of service would strand.
+func (q *Queries) ListUpcomingCourtReservations(ctx context.Context, arg ListUpcomingCourtReservationsParams) ([]ListUpcomingCourtReservationsRow, error) {
+	rows, err := q.query(ctx, q.listUpcomingCourtReservationsStmt, listUpcomingCourtReservations, arg.CourtID, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUpcomingCourtReservationsRow
+	for rows.Next() {
+		var i ListUpcomingCourtReservationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FacilityID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.PrimaryUserID,
+			&i.ReservationType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUpcomingReservationIDsForPrimaryUser = `-- name: ListUpcomingReservationIDsForPrimaryUser :many
+SELECT r.id
+FROM reservations r
+WHERE r.primary_user_id = ?1
+  AND r.start_time > ?2
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY r.start_time, r.id
+`
+
+type ListUpcomingReservationIDsForPrimaryUserParams struct {
+	PrimaryUserID  sql.NullInt64 `json:"primaryUserId"`
+	ComparisonTime time.Time     `json:"comparisonTime"`
+}
+
+func (q *Queries) ListUpcomingReservationIDsForPrimaryUser(ctx context.Context, arg ListUpcomingReservationIDsForPrimaryUserParams) ([]int64, error) {
+	rows, err := q.query(ctx, q.listUpcomingReservationIDsForPrimaryUserStmt, listUpcomingReservationIDsForPrimaryUser, arg.PrimaryUserID, arg.ComparisonTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const removeParticipant = `-- name: RemoveParticipant :exec
 DELETE FROM reservation_participants
 WHERE reservation_id = ?1
@@ -709,6 +1261,68 @@ func (q *Queries) RemoveParticipant(ctx context.Context, arg RemoveParticipantPa
 	return err
 }
 
+const removeUserFromUpcomingReservations = `-- name: RemoveUserFromUpcomingReservations :execrows
+DELETE FROM reservation_participants
+WHERE user_id = ?1
+  AND reservation_id IN (
+      SELECT r.id
+      FROM reservations r
+      WHERE r.start_time > ?2
+        AND NOT EXISTS (
+            SELECT 1
+            FROM reservation_cancellations rcc
+            WHERE rcc.reservation_id = r.id
+        )
+  )
+`
+
+type RemoveUserFromUpcomingReservationsParams struct {
+	UserID         int64     `json:"userId"`
+	ComparisonTime time.Time `json:"comparisonTime"`
+}
+
+// Takes the user off every future participant list, open play signups
+// included, without touching the reservations themselves.
+func (q *Queries) RemoveUserFromUpcomingReservations(ctx context.Context, arg RemoveUserFromUpcomingReservationsParams) (int64, error) {
+	result, err := q.exec(ctx, q.removeUserFromUpcomingReservationsStmt, removeUserFromUpcomingReservations, arg.UserID, arg.ComparisonTime)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const reservationsOverlapOnCourt = `-- name: ReservationsOverlapOnCourt :one
+SELECT EXISTS (
+    SELECT 1
+    FROM reservations a
+    JOIN reservation_courts ac ON ac.reservation_id = a.id
+    JOIN reservation_courts bc ON bc.court_id = ac.court_id
+    JOIN reservations b ON b.id = bc.reservation_id
+    WHERE a.id = ?1
+      AND b.id = ?2
+      AND a.id != b.id
+      AND a.facility_id = ?3
+      AND b.facility_id = ?3
+      AND a.start_time < b.end_time
+      AND b.start_time < a.end_time
+) AS overlapping
+`
+
+type ReservationsOverlapOnCourtParams struct {
+	ReservationID      int64 `json:"reservationId"`
+	OtherReservationID int64 `json:"otherReservationId"`
+	FacilityID         int64 `json:"facilityId"`
+}
+
+// Whether two reservations at the facility still hold a shared court at
+// overlapping times.
+func (q *Queries) ReservationsOverlapOnCourt(ctx context.Context, arg ReservationsOverlapOnCourtParams) (int64, error) {
+	row := q.queryRow(ctx, q.reservationsOverlapOnCourtStmt, reservationsOverlapOnCourt, arg.ReservationID, arg.OtherReservationID, arg.FacilityID)
+	var overlapping int64
+	err := row.Scan(&overlapping)
+	return overlapping, err
+}
+
 const updateReservation = `-- name: UpdateReservation :one
 UPDATE reservations
 SET reservation_type_id = ?1,
@@ -779,3 +1393,43 @@ func (q *Queries) UpdateReservation(ctx context.Context, arg UpdateReservationPa
 	)
 	return i, err
 }
+
+const updateReservationPrimaryUser = `-- name: UpdateReservationPrimaryUser :execrows
+UPDATE reservations
+SET primary_user_id = ?1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?2
+`
+
+type UpdateReservationPrimaryUserParams struct {
+	PrimaryUserID sql.NullInt64 `json:"primaryUserId"`
+	ID            int64         `json:"id"`
+}
+
+func (q *Queries) UpdateReservationPrimaryUser(ctx context.Context, arg UpdateReservationPrimaryUserParams) (int64, error) {
+	result, err := q.exec(ctx, q.updateReservationPrimaryUserStmt, updateReservationPrimaryUser, arg.PrimaryUserID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertReservationClosureDetails = `-- name: UpsertReservationClosureDetails :exec
+INSERT INTO reservation_closure_details (reservation_id, public_reason, internal_notes)
+VALUES (?1, ?2, ?3)
+ON CONFLICT(reservation_id) DO UPDATE SET
+    public_reason = excluded.public_reason,
+    internal_notes = excluded.internal_notes,
+    updated_at = CURRENT_TIMESTAMP
+`
+
+type UpsertReservationClosureDetailsParams struct {
+	ReservationID int64          `json:"reservationId"`
+	PublicReason  sql.NullString `json:"publicReason"`
+	InternalNotes sql.NullString `json:"internalNotes"`
+}
+
+func (q *Queries) UpsertReservationClosureDetails(ctx context.Context, arg UpsertReservationClosureDetailsParams) error {
+	_, err := q.exec(ctx, q.upsertReservationClosureDetailsStmt, upsertReservationClosureDetails, arg.ReservationID, arg.PublicReason, arg.InternalNotes)
+	return err
+}
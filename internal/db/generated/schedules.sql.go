@@ -7,8 +7,28 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
+const deleteFacilityHoursOverride = `-- name: DeleteFacilityHoursOverride :execrows
+DELETE FROM facility_hours_overrides
+WHERE facility_id = ?1
+  AND override_date = ?2
+`
+
+type DeleteFacilityHoursOverrideParams struct {
+	FacilityID   int64  `json:"facilityId"`
+	OverrideDate string `json:"overrideDate"`
+}
+
+func (q *Queries) DeleteFacilityHoursOverride(ctx context.Context, arg DeleteFacilityHoursOverrideParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteFacilityHoursOverrideStmt, deleteFacilityHoursOverride, arg.FacilityID, arg.OverrideDate)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteOperatingHours = `-- name: DeleteOperatingHours :execrows
 DELETE FROM operating_hours
 WHERE facility_id = ? AND day_of_week = ?
@@ -65,6 +85,79 @@ func (q *Queries) GetFacilityHours(ctx context.Context, facilityID int64) ([]Ope
 	return items, nil
 }
 
+const getFacilityHoursOverride = `-- name: GetFacilityHoursOverride :one
+SELECT id, facility_id, override_date, is_closed, opens_at, closes_at, reason, created_at, updated_at FROM facility_hours_overrides
+WHERE facility_id = ?1
+  AND override_date = ?2
+`
+
+type GetFacilityHoursOverrideParams struct {
+	FacilityID   int64  `json:"facilityId"`
+	OverrideDate string `json:"overrideDate"`
+}
+
+func (q *Queries) GetFacilityHoursOverride(ctx context.Context, arg GetFacilityHoursOverrideParams) (FacilityHoursOverride, error) {
+	row := q.queryRow(ctx, q.getFacilityHoursOverrideStmt, getFacilityHoursOverride, arg.FacilityID, arg.OverrideDate)
+	var i FacilityHoursOverride
+	err := row.Scan(
+		&i.ID,
+		&i.FacilityID,
+		&i.OverrideDate,
+		&i.IsClosed,
+		&i.OpensAt,
+		&i.ClosesAt,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listFacilityHoursOverrides = `-- name: ListFacilityHoursOverrides :many
+SELECT id, facility_id, override_date, is_closed, opens_at, closes_at, reason, created_at, updated_at FROM facility_hours_overrides
+WHERE facility_id = ?1
+  AND override_date >= ?2
+ORDER BY override_date
+`
+
+type ListFacilityHoursOverridesParams struct {
+	FacilityID int64  `json:"facilityId"`
+	FromDate   string `json:"fromDate"`
+}
+
+func (q *Queries) ListFacilityHoursOverrides(ctx context.Context, arg ListFacilityHoursOverridesParams) ([]FacilityHoursOverride, error) {
+	rows, err := q.query(ctx, q.listFacilityHoursOverridesStmt, listFacilityHoursOverrides, arg.FacilityID, arg.FromDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FacilityHoursOverride
+	for rows.Next() {
+		var i FacilityHoursOverride
+		if err := rows.Scan(
+			&i.ID,
+			&i.FacilityID,
+			&i.OverrideDate,
+			&i.IsClosed,
+			&i.OpensAt,
+			&i.ClosesAt,
+			&i.Reason,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const operatingHoursExists = `-- name: OperatingHoursExists :one
 SELECT COUNT(1) FROM operating_hours
 WHERE facility_id = ? AND day_of_week = ?
@@ -82,6 +175,64 @@ func (q *Queries) OperatingHoursExists(ctx context.Context, arg OperatingHoursEx
 	return count, err
 }
 
+const upsertFacilityHoursOverride = `-- name: UpsertFacilityHoursOverride :one
+INSERT INTO facility_hours_overrides (
+    facility_id,
+    override_date,
+    is_closed,
+    opens_at,
+    closes_at,
+    reason
+) VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4,
+    ?5,
+    ?6
+)
+ON CONFLICT(facility_id, override_date) DO UPDATE SET
+    is_closed = excluded.is_closed,
+    opens_at = excluded.opens_at,
+    closes_at = excluded.closes_at,
+    reason = excluded.reason,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING id, facility_id, override_date, is_closed, opens_at, closes_at, reason, created_at, updated_at
+`
+
+type UpsertFacilityHoursOverrideParams struct {
+	FacilityID   int64          `json:"facilityId"`
+	OverrideDate string         `json:"overrideDate"`
+	IsClosed     bool           `json:"isClosed"`
+	OpensAt      sql.NullString `json:"opensAt"`
+	ClosesAt     sql.NullString `json:"closesAt"`
+	Reason       sql.NullString `json:"reason"`
+}
+
+func (q *Queries) UpsertFacilityHoursOverride(ctx context.Context, arg UpsertFacilityHoursOverrideParams) (FacilityHoursOverride, error) {
+	row := q.queryRow(ctx, q.upsertFacilityHoursOverrideStmt, upsertFacilityHoursOverride,
+		arg.FacilityID,
+		arg.OverrideDate,
+		arg.IsClosed,
+		arg.OpensAt,
+		arg.ClosesAt,
+		arg.Reason,
+	)
+	var i FacilityHoursOverride
+	err := row.Scan(
+		&i.ID,
+		&i.FacilityID,
+		&i.OverrideDate,
+		&i.IsClosed,
+		&i.OpensAt,
+		&i.ClosesAt,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const upsertOperatingHours = `-- name: UpsertOperatingHours :one
 INSERT INTO operating_hours (
     facility_id,
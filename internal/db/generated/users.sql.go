@@ -52,7 +52,7 @@ func (q *Queries) CreateStaffUser(ctx context.Context, arg CreateStaffUserParams
 
 const getUserByEmail = `-- name: GetUserByEmail :one
 
-SELECT id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, staff_role, status, created_at, updated_at FROM users WHERE email = ?1 LIMIT 1
+SELECT id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, hide_from_rosters, notify_confirmations, notify_cancellations, notify_waitlist_offers, notify_open_play_reminders, sms_opt_in, email_undeliverable, staff_role, deleted_at, deletion_requested_at, anonymized_at, status, created_at, updated_at FROM users WHERE email = ?1 LIMIT 1
 `
 
 // internal/db/queries/users.sql
@@ -81,7 +81,17 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email sql.NullString) (Use
 		&i.DateOfBirth,
 		&i.WaiverSigned,
 		&i.MembershipLevel,
+		&i.HideFromRosters,
+		&i.NotifyConfirmations,
+		&i.NotifyCancellations,
+		&i.NotifyWaitlistOffers,
+		&i.NotifyOpenPlayReminders,
+		&i.SmsOptIn,
+		&i.EmailUndeliverable,
 		&i.StaffRole,
+		&i.DeletedAt,
+		&i.DeletionRequestedAt,
+		&i.AnonymizedAt,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -90,7 +100,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email sql.NullString) (Use
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, staff_role, status, created_at, updated_at FROM users WHERE id = ?1 LIMIT 1
+SELECT id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, hide_from_rosters, notify_confirmations, notify_cancellations, notify_waitlist_offers, notify_open_play_reminders, sms_opt_in, email_undeliverable, staff_role, deleted_at, deletion_requested_at, anonymized_at, status, created_at, updated_at FROM users WHERE id = ?1 LIMIT 1
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
@@ -118,7 +128,17 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 		&i.DateOfBirth,
 		&i.WaiverSigned,
 		&i.MembershipLevel,
+		&i.HideFromRosters,
+		&i.NotifyConfirmations,
+		&i.NotifyCancellations,
+		&i.NotifyWaitlistOffers,
+		&i.NotifyOpenPlayReminders,
+		&i.SmsOptIn,
+		&i.EmailUndeliverable,
 		&i.StaffRole,
+		&i.DeletedAt,
+		&i.DeletionRequestedAt,
+		&i.AnonymizedAt,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -127,7 +147,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 }
 
 const getUserByPhone = `-- name: GetUserByPhone :one
-SELECT id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, staff_role, status, created_at, updated_at FROM users WHERE phone = ?1 LIMIT 1
+SELECT id, email, phone, cognito_sub, cognito_status, preferred_auth_method, password_hash, local_auth_enabled, first_name, last_name, photo_url, street_address, city, state, postal_code, home_facility_id, is_member, is_staff, date_of_birth, waiver_signed, membership_level, hide_from_rosters, notify_confirmations, notify_cancellations, notify_waitlist_offers, notify_open_play_reminders, sms_opt_in, email_undeliverable, staff_role, deleted_at, deletion_requested_at, anonymized_at, status, created_at, updated_at FROM users WHERE phone = ?1 LIMIT 1
 `
 
 func (q *Queries) GetUserByPhone(ctx context.Context, phone sql.NullString) (User, error) {
@@ -155,7 +175,17 @@ func (q *Queries) GetUserByPhone(ctx context.Context, phone sql.NullString) (Use
 		&i.DateOfBirth,
 		&i.WaiverSigned,
 		&i.MembershipLevel,
+		&i.HideFromRosters,
+		&i.NotifyConfirmations,
+		&i.NotifyCancellations,
+		&i.NotifyWaitlistOffers,
+		&i.NotifyOpenPlayReminders,
+		&i.SmsOptIn,
+		&i.EmailUndeliverable,
 		&i.StaffRole,
+		&i.DeletedAt,
+		&i.DeletionRequestedAt,
+		&i.AnonymizedAt,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -163,6 +193,37 @@ func (q *Queries) GetUserByPhone(ctx context.Context, phone sql.NullString) (Use
 	return i, err
 }
 
+const getUserNotificationPreferences = `-- name: GetUserNotificationPreferences :one
+SELECT notify_confirmations,
+    notify_cancellations,
+    notify_waitlist_offers,
+    notify_open_play_reminders,
+    sms_opt_in
+FROM users
+WHERE id = ?1
+`
+
+type GetUserNotificationPreferencesRow struct {
+	NotifyConfirmations     bool `json:"notifyConfirmations"`
+	NotifyCancellations     bool `json:"notifyCancellations"`
+	NotifyWaitlistOffers    bool `json:"notifyWaitlistOffers"`
+	NotifyOpenPlayReminders bool `json:"notifyOpenPlayReminders"`
+	SmsOptIn                bool `json:"smsOptIn"`
+}
+
+func (q *Queries) GetUserNotificationPreferences(ctx context.Context, id int64) (GetUserNotificationPreferencesRow, error) {
+	row := q.queryRow(ctx, q.getUserNotificationPreferencesStmt, getUserNotificationPreferences, id)
+	var i GetUserNotificationPreferencesRow
+	err := row.Scan(
+		&i.NotifyConfirmations,
+		&i.NotifyCancellations,
+		&i.NotifyWaitlistOffers,
+		&i.NotifyOpenPlayReminders,
+		&i.SmsOptIn,
+	)
+	return i, err
+}
+
 const updateStaffUser = `-- name: UpdateStaffUser :exec
 UPDATE users
 SET first_name = ?1,
@@ -218,6 +279,72 @@ func (q *Queries) UpdateUserCognitoStatus(ctx context.Context, arg UpdateUserCog
 	return err
 }
 
+const updateUserHideFromRosters = `-- name: UpdateUserHideFromRosters :exec
+UPDATE users
+SET hide_from_rosters = ?1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?2
+`
+
+type UpdateUserHideFromRostersParams struct {
+	HideFromRosters bool  `json:"hideFromRosters"`
+	ID              int64 `json:"id"`
+}
+
+func (q *Queries) UpdateUserHideFromRosters(ctx context.Context, arg UpdateUserHideFromRostersParams) error {
+	_, err := q.exec(ctx, q.updateUserHideFromRostersStmt, updateUserHideFromRosters, arg.HideFromRosters, arg.ID)
+	return err
+}
+
+const updateUserHomeFacility = `-- name: UpdateUserHomeFacility :exec
+UPDATE users
+SET home_facility_id = ?1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?2
+`
+
+type UpdateUserHomeFacilityParams struct {
+	HomeFacilityID sql.NullInt64 `json:"homeFacilityId"`
+	ID             int64         `json:"id"`
+}
+
+func (q *Queries) UpdateUserHomeFacility(ctx context.Context, arg UpdateUserHomeFacilityParams) error {
+	_, err := q.exec(ctx, q.updateUserHomeFacilityStmt, updateUserHomeFacility, arg.HomeFacilityID, arg.ID)
+	return err
+}
+
+const updateUserNotificationPreferences = `-- name: UpdateUserNotificationPreferences :exec
+UPDATE users
+SET notify_confirmations = ?1,
+    notify_cancellations = ?2,
+    notify_waitlist_offers = ?3,
+    notify_open_play_reminders = ?4,
+    sms_opt_in = ?5,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?6
+`
+
+type UpdateUserNotificationPreferencesParams struct {
+	NotifyConfirmations     bool  `json:"notifyConfirmations"`
+	NotifyCancellations     bool  `json:"notifyCancellations"`
+	NotifyWaitlistOffers    bool  `json:"notifyWaitlistOffers"`
+	NotifyOpenPlayReminders bool  `json:"notifyOpenPlayReminders"`
+	SmsOptIn                bool  `json:"smsOptIn"`
+	ID                      int64 `json:"id"`
+}
+
+func (q *Queries) UpdateUserNotificationPreferences(ctx context.Context, arg UpdateUserNotificationPreferencesParams) error {
+	_, err := q.exec(ctx, q.updateUserNotificationPreferencesStmt, updateUserNotificationPreferences,
+		arg.NotifyConfirmations,
+		arg.NotifyCancellations,
+		arg.NotifyWaitlistOffers,
+		arg.NotifyOpenPlayReminders,
+		arg.SmsOptIn,
+		arg.ID,
+	)
+	return err
+}
+
 const updateUserPasswordHash = `-- name: UpdateUserPasswordHash :exec
 UPDATE users
 SET password_hash = ?1,
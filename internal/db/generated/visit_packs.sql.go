@@ -44,7 +44,7 @@ FROM visit_pack_types vpt
 WHERE vpt.id = ?4
   AND vpt.status = 'active'
 RETURNING id, pack_type_id, user_id, purchase_date, expires_at,
-    visits_remaining, status, created_at, updated_at
+    visits_remaining, status, created_at, updated_at, facility_id
 `
 
 type CreateVisitPackParams struct {
@@ -72,6 +72,48 @@ func (q *Queries) CreateVisitPack(ctx context.Context, arg CreateVisitPackParams
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FacilityID,
+	)
+	return i, err
+}
+
+const createVisitPackPayment = `-- name: CreateVisitPackPayment :one
+INSERT INTO visit_pack_payments (
+    visit_pack_id,
+    amount_cents,
+    processor,
+    reference
+) VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4
+)
+RETURNING id, visit_pack_id, amount_cents, processor, reference, created_at
+`
+
+type CreateVisitPackPaymentParams struct {
+	VisitPackID int64  `json:"visitPackId"`
+	AmountCents int64  `json:"amountCents"`
+	Processor   string `json:"processor"`
+	Reference   string `json:"reference"`
+}
+
+func (q *Queries) CreateVisitPackPayment(ctx context.Context, arg CreateVisitPackPaymentParams) (VisitPackPayment, error) {
+	row := q.queryRow(ctx, q.createVisitPackPaymentStmt, createVisitPackPayment,
+		arg.VisitPackID,
+		arg.AmountCents,
+		arg.Processor,
+		arg.Reference,
+	)
+	var i VisitPackPayment
+	err := row.Scan(
+		&i.ID,
+		&i.VisitPackID,
+		&i.AmountCents,
+		&i.Processor,
+		&i.Reference,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -217,7 +259,7 @@ WHERE id = ?1
   AND visits_remaining > 0
   AND expires_at > CURRENT_TIMESTAMP
 RETURNING id, pack_type_id, user_id, purchase_date, expires_at,
-    visits_remaining, status, created_at, updated_at
+    visits_remaining, status, created_at, updated_at, facility_id
 `
 
 func (q *Queries) DecrementVisitPackVisit(ctx context.Context, id int64) (VisitPack, error) {
@@ -233,13 +275,14 @@ func (q *Queries) DecrementVisitPackVisit(ctx context.Context, id int64) (VisitP
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FacilityID,
 	)
 	return i, err
 }
 
 const getVisitPack = `-- name: GetVisitPack :one
 SELECT id, pack_type_id, user_id, purchase_date, expires_at,
-    visits_remaining, status, created_at, updated_at
+    visits_remaining, status, created_at, updated_at, facility_id
 FROM visit_packs
 WHERE id = ?1
   AND user_id = ?2
@@ -263,6 +306,7 @@ func (q *Queries) GetVisitPack(ctx context.Context, arg GetVisitPackParams) (Vis
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FacilityID,
 	)
 	return i, err
 }
@@ -270,11 +314,11 @@ func (q *Queries) GetVisitPack(ctx context.Context, arg GetVisitPackParams) (Vis
 const getVisitPackRedemptionInfo = `-- name: GetVisitPackRedemptionInfo :one
 SELECT vp.id AS visit_pack_id,
     vp.pack_type_id,
-    vpt.facility_id AS pack_facility_id,
+    CAST(COALESCE(vp.facility_id, vpt.facility_id) AS INTEGER) AS pack_facility_id,
     f.organization_id AS organization_id
 FROM visit_packs vp
 JOIN visit_pack_types vpt ON vpt.id = vp.pack_type_id
-JOIN facilities f ON f.id = vpt.facility_id
+JOIN facilities f ON f.id = COALESCE(vp.facility_id, vpt.facility_id)
 WHERE vp.id = ?1
 `
 
@@ -329,7 +373,7 @@ func (q *Queries) GetVisitPackType(ctx context.Context, arg GetVisitPackTypePara
 
 const listActiveVisitPacksForUser = `-- name: ListActiveVisitPacksForUser :many
 SELECT id, pack_type_id, user_id, purchase_date, expires_at,
-    visits_remaining, status, created_at, updated_at
+    visits_remaining, status, created_at, updated_at, facility_id
 FROM visit_packs
 WHERE user_id = ?1
   AND status = 'active'
@@ -362,6 +406,7 @@ func (q *Queries) ListActiveVisitPacksForUser(ctx context.Context, arg ListActiv
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FacilityID,
 		); err != nil {
 			return nil, err
 		}
@@ -378,11 +423,11 @@ func (q *Queries) ListActiveVisitPacksForUser(ctx context.Context, arg ListActiv
 
 const listActiveVisitPacksForUserByFacility = `-- name: ListActiveVisitPacksForUserByFacility :many
 SELECT vp.id, vp.pack_type_id, vp.user_id, vp.purchase_date, vp.expires_at,
-    vp.visits_remaining, vp.status, vp.created_at, vp.updated_at
+    vp.visits_remaining, vp.status, vp.created_at, vp.updated_at, vp.facility_id
 FROM visit_packs vp
 JOIN visit_pack_types vpt ON vpt.id = vp.pack_type_id
 WHERE vp.user_id = ?1
-  AND vpt.facility_id = ?2
+  AND COALESCE(vp.facility_id, vpt.facility_id) = CAST(?2 AS INTEGER)
   AND vp.status = 'active'
   AND vp.visits_remaining > 0
   AND vp.expires_at > ?3
@@ -414,6 +459,7 @@ func (q *Queries) ListActiveVisitPacksForUserByFacility(ctx context.Context, arg
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FacilityID,
 		); err != nil {
 			return nil, err
 		}
@@ -430,10 +476,10 @@ func (q *Queries) ListActiveVisitPacksForUserByFacility(ctx context.Context, arg
 
 const listActiveVisitPacksForUserByOrganization = `-- name: ListActiveVisitPacksForUserByOrganization :many
 SELECT vp.id, vp.pack_type_id, vp.user_id, vp.purchase_date, vp.expires_at,
-    vp.visits_remaining, vp.status, vp.created_at, vp.updated_at
+    vp.visits_remaining, vp.status, vp.created_at, vp.updated_at, vp.facility_id
 FROM visit_packs vp
 JOIN visit_pack_types vpt ON vpt.id = vp.pack_type_id
-JOIN facilities f ON f.id = vpt.facility_id
+JOIN facilities f ON f.id = COALESCE(vp.facility_id, vpt.facility_id)
 WHERE vp.user_id = ?1
   AND f.organization_id = ?2
   AND vp.status = 'active'
@@ -467,6 +513,120 @@ func (q *Queries) ListActiveVisitPacksForUserByOrganization(ctx context.Context,
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FacilityID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMemberPaidVisitsPage = `-- name: ListMemberPaidVisitsPage :many
+SELECT
+    visit_at,
+    facility_id,
+    reservation_type,
+    CAST(court_names AS TEXT) AS court_names,
+    CAST(COALESCE(visit_pack_id, 0) AS INTEGER) AS visit_pack_id
+FROM (
+    SELECT
+        r.start_time AS visit_at,
+        r.facility_id AS facility_id,
+        rt.name AS reservation_type,
+        COALESCE((
+            SELECT GROUP_CONCAT(c.name, ', ')
+            FROM reservation_courts rc
+            JOIN courts c ON c.id = rc.court_id
+            WHERE rc.reservation_id = r.id
+        ), '') AS court_names,
+        (
+            SELECT vpr.visit_pack_id
+            FROM visit_pack_redemptions vpr
+            WHERE vpr.reservation_id = r.id
+            ORDER BY vpr.id
+            LIMIT 1
+        ) AS visit_pack_id,
+        0 AS sort_kind,
+        r.id AS sort_id
+    FROM reservations r
+    JOIN reservation_types rt ON rt.id = r.reservation_type_id
+    WHERE r.primary_user_id = ?1
+      AND r.start_time >= ?2
+      AND r.start_time < ?3
+      AND (?4 IS NULL OR r.facility_id = ?4)
+      AND NOT EXISTS (
+          SELECT 1 FROM reservation_cancellations rcc WHERE rcc.reservation_id = r.id
+      )
+    UNION ALL
+    SELECT
+        vpr.redeemed_at AS visit_at,
+        vpr.facility_id AS facility_id,
+        '' AS reservation_type,
+        '' AS court_names,
+        vpr.visit_pack_id AS visit_pack_id,
+        1 AS sort_kind,
+        vpr.id AS sort_id
+    FROM visit_pack_redemptions vpr
+    JOIN visit_packs vp ON vp.id = vpr.visit_pack_id
+    WHERE vp.user_id = ?1
+      AND vpr.reservation_id IS NULL
+      AND vpr.redeemed_at >= ?2
+      AND vpr.redeemed_at < ?3
+      AND (?4 IS NULL OR vpr.facility_id = ?4)
+) AS visits
+ORDER BY visit_at, sort_kind, sort_id
+LIMIT ?6 OFFSET ?5
+`
+
+type ListMemberPaidVisitsPageParams struct {
+	UserID      sql.NullInt64 `json:"userId"`
+	StartFrom   time.Time     `json:"startFrom"`
+	StartBefore time.Time     `json:"startBefore"`
+	FacilityID  interface{}   `json:"facilityId"`
+	PageOffset  int64         `json:"pageOffset"`
+	PageSize    int64         `json:"pageSize"`
+}
+
+type ListMemberPaidVisitsPageRow struct {
+	VisitAt         time.Time `json:"visitAt"`
+	FacilityID      int64     `json:"facilityId"`
+	ReservationType string    `json:"reservationType"`
+	CourtNames      string    `json:"courtNames"`
+	VisitPackID     int64     `json:"visitPackId"`
+}
+
+// Paid visits for the visit history export: the member's own reservations,
+// with the visit pack that paid for them (0 when none), and visit pack
+// redemptions made without a reservation (e.g. at check-in).
+func (q *Queries) ListMemberPaidVisitsPage(ctx context.Context, arg ListMemberPaidVisitsPageParams) ([]ListMemberPaidVisitsPageRow, error) {
+	rows, err := q.query(ctx, q.listMemberPaidVisitsPageStmt, listMemberPaidVisitsPage,
+		arg.UserID,
+		arg.StartFrom,
+		arg.StartBefore,
+		arg.FacilityID,
+		arg.PageOffset,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMemberPaidVisitsPageRow
+	for rows.Next() {
+		var i ListMemberPaidVisitsPageRow
+		if err := rows.Scan(
+			&i.VisitAt,
+			&i.FacilityID,
+			&i.ReservationType,
+			&i.CourtNames,
+			&i.VisitPackID,
 		); err != nil {
 			return nil, err
 		}
@@ -522,6 +682,41 @@ func (q *Queries) ListVisitPackTypes(ctx context.Context, facilityID int64) ([]V
 	return items, nil
 }
 
+const transferActiveVisitPacks = `-- name: TransferActiveVisitPacks :execrows
+UPDATE visit_packs
+SET facility_id = CAST(?1 AS INTEGER),
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = ?2
+  AND status = 'active'
+  AND visits_remaining > 0
+  AND expires_at > ?3
+  AND COALESCE(
+      visit_packs.facility_id,
+      (SELECT vpt.facility_id FROM visit_pack_types vpt WHERE vpt.id = visit_packs.pack_type_id)
+  ) = CAST(?4 AS INTEGER)
+`
+
+type TransferActiveVisitPacksParams struct {
+	ToFacilityID   int64     `json:"toFacilityId"`
+	UserID         int64     `json:"userId"`
+	ComparisonTime time.Time `json:"comparisonTime"`
+	FromFacilityID int64     `json:"fromFacilityId"`
+}
+
+// Moves a member's usable packs held at from_facility_id to to_facility_id.
+func (q *Queries) TransferActiveVisitPacks(ctx context.Context, arg TransferActiveVisitPacksParams) (int64, error) {
+	result, err := q.exec(ctx, q.transferActiveVisitPacksStmt, transferActiveVisitPacks,
+		arg.ToFacilityID,
+		arg.UserID,
+		arg.ComparisonTime,
+		arg.FromFacilityID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateVisitPackType = `-- name: UpdateVisitPackType :one
 UPDATE visit_pack_types
 SET name = ?1,
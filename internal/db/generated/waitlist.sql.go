@@ -95,6 +95,43 @@ func (q *Queries) AdvanceWaitlistOffer(ctx context.Context, arg AdvanceWaitlistO
 	return i, err
 }
 
+const applyStagedWaitlistPositions = `-- name: ApplyStagedWaitlistPositions :execrows
+UPDATE waitlists
+SET position = -position,
+    updated_at = CURRENT_TIMESTAMP
+WHERE facility_id = ?1
+  AND target_date = ?2
+  AND target_start_time = ?3
+  AND target_end_time = ?4
+  AND position < 0
+  AND (
+      target_court_id = ?5
+      OR (?5 IS NULL AND target_court_id IS NULL)
+  )
+`
+
+type ApplyStagedWaitlistPositionsParams struct {
+	FacilityID      int64         `json:"facilityId"`
+	TargetDate      time.Time     `json:"targetDate"`
+	TargetStartTime interface{}   `json:"targetStartTime"`
+	TargetEndTime   interface{}   `json:"targetEndTime"`
+	TargetCourtID   sql.NullInt64 `json:"targetCourtId"`
+}
+
+func (q *Queries) ApplyStagedWaitlistPositions(ctx context.Context, arg ApplyStagedWaitlistPositionsParams) (int64, error) {
+	result, err := q.exec(ctx, q.applyStagedWaitlistPositionsStmt, applyStagedWaitlistPositions,
+		arg.FacilityID,
+		arg.TargetDate,
+		arg.TargetStartTime,
+		arg.TargetEndTime,
+		arg.TargetCourtID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createWaitlistEntry = `-- name: CreateWaitlistEntry :one
 INSERT INTO waitlists (
     facility_id,
@@ -104,7 +141,9 @@ INSERT INTO waitlists (
     target_start_time,
     target_end_time,
     position,
-    status
+    status,
+    auto_book,
+    use_visit_pack
 ) SELECT
     ?1,
     ?2,
@@ -113,12 +152,15 @@ INSERT INTO waitlists (
     ?5,
     ?6,
     COALESCE(MAX(position), 0) + 1,
-    ?7
+    ?7,
+    ?8,
+    ?9
 FROM waitlists
 WHERE facility_id = ?1
   AND target_date = ?4
   AND target_start_time = ?5
   AND target_end_time = ?6
+  AND status IN ('pending', 'notified')
   AND (
       target_court_id = ?3
       OR (?3 IS NULL AND target_court_id IS NULL)
@@ -134,7 +176,9 @@ RETURNING
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 `
 
 type CreateWaitlistEntryParams struct {
@@ -145,6 +189,8 @@ type CreateWaitlistEntryParams struct {
 	TargetStartTime interface{}   `json:"targetStartTime"`
 	TargetEndTime   interface{}   `json:"targetEndTime"`
 	Status          string        `json:"status"`
+	AutoBook        bool          `json:"autoBook"`
+	UseVisitPack    bool          `json:"useVisitPack"`
 }
 
 // internal/db/queries/waitlist.sql
@@ -157,6 +203,8 @@ func (q *Queries) CreateWaitlistEntry(ctx context.Context, arg CreateWaitlistEnt
 		arg.TargetStartTime,
 		arg.TargetEndTime,
 		arg.Status,
+		arg.AutoBook,
+		arg.UseVisitPack,
 	)
 	var i Waitlist
 	err := row.Scan(
@@ -171,6 +219,8 @@ func (q *Queries) CreateWaitlistEntry(ctx context.Context, arg CreateWaitlistEnt
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoBook,
+		&i.UseVisitPack,
 	)
 	return i, err
 }
@@ -212,6 +262,94 @@ func (q *Queries) CreateWaitlistOffer(ctx context.Context, arg CreateWaitlistOff
 	return i, err
 }
 
+const declineOffer = `-- name: DeclineOffer :one
+UPDATE waitlist_offers
+SET status = 'declined'
+WHERE id = ?1
+  AND waitlist_id = ?2
+RETURNING
+    id,
+    waitlist_id,
+    offered_at,
+    expires_at,
+    status
+`
+
+type DeclineOfferParams struct {
+	ID         int64 `json:"id"`
+	WaitlistID int64 `json:"waitlistId"`
+}
+
+func (q *Queries) DeclineOffer(ctx context.Context, arg DeclineOfferParams) (WaitlistOffer, error) {
+	row := q.queryRow(ctx, q.declineOfferStmt, declineOffer, arg.ID, arg.WaitlistID)
+	var i WaitlistOffer
+	err := row.Scan(
+		&i.ID,
+		&i.WaitlistID,
+		&i.OfferedAt,
+		&i.ExpiresAt,
+		&i.Status,
+	)
+	return i, err
+}
+
+const deleteActiveWaitlistEntriesForUser = `-- name: DeleteActiveWaitlistEntriesForUser :many
+DELETE FROM waitlists
+WHERE user_id = ?1
+  AND status IN ('pending', 'notified')
+RETURNING
+    id,
+    facility_id,
+    user_id,
+    target_court_id,
+    target_date,
+    target_start_time,
+    target_end_time,
+    position,
+    status,
+    created_at,
+    updated_at,
+    auto_book,
+    use_visit_pack
+`
+
+func (q *Queries) DeleteActiveWaitlistEntriesForUser(ctx context.Context, userID int64) ([]Waitlist, error) {
+	rows, err := q.query(ctx, q.deleteActiveWaitlistEntriesForUserStmt, deleteActiveWaitlistEntriesForUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Waitlist
+	for rows.Next() {
+		var i Waitlist
+		if err := rows.Scan(
+			&i.ID,
+			&i.FacilityID,
+			&i.UserID,
+			&i.TargetCourtID,
+			&i.TargetDate,
+			&i.TargetStartTime,
+			&i.TargetEndTime,
+			&i.Position,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.AutoBook,
+			&i.UseVisitPack,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const deletePastWaitlistEntries = `-- name: DeletePastWaitlistEntries :execrows
 DELETE FROM waitlists
 WHERE facility_id = ?1
@@ -285,6 +423,61 @@ func (q *Queries) ExpireOffer(ctx context.Context, arg ExpireOfferParams) (Waitl
 	return i, err
 }
 
+const expireSiblingWaitlistOffers = `-- name: ExpireSiblingWaitlistOffers :many
+UPDATE waitlist_offers
+SET status = 'expired'
+WHERE status = 'pending'
+  AND waitlist_id IN (
+      SELECT w.id
+      FROM waitlists w
+      JOIN waitlists c ON c.id = ?1
+      WHERE w.id != c.id
+        AND w.facility_id = c.facility_id
+        AND w.target_date = c.target_date
+        AND w.target_start_time = c.target_start_time
+        AND w.target_end_time = c.target_end_time
+        AND (
+            w.target_court_id = c.target_court_id
+            OR (w.target_court_id IS NULL AND c.target_court_id IS NULL)
+        )
+  )
+RETURNING
+    id,
+    waitlist_id,
+    offered_at,
+    expires_at,
+    status
+`
+
+func (q *Queries) ExpireSiblingWaitlistOffers(ctx context.Context, waitlistID int64) ([]WaitlistOffer, error) {
+	rows, err := q.query(ctx, q.expireSiblingWaitlistOffersStmt, expireSiblingWaitlistOffers, waitlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WaitlistOffer
+	for rows.Next() {
+		var i WaitlistOffer
+		if err := rows.Scan(
+			&i.ID,
+			&i.WaitlistID,
+			&i.OfferedAt,
+			&i.ExpiresAt,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPendingOffer = `-- name: GetPendingOffer :one
 SELECT
     id,
@@ -320,6 +513,7 @@ SELECT
     notification_mode,
     offer_expiry_minutes,
     notification_window_minutes,
+    expired_offer_action,
     created_at,
     updated_at
 FROM waitlist_config
@@ -337,6 +531,7 @@ func (q *Queries) GetWaitlistConfig(ctx context.Context, facilityID int64) (Wait
 		&i.NotificationMode,
 		&i.OfferExpiryMinutes,
 		&i.NotificationWindowMinutes,
+		&i.ExpiredOfferAction,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -355,7 +550,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE id = ?1
   AND facility_id = ?2
@@ -382,6 +579,63 @@ func (q *Queries) GetWaitlistEntry(ctx context.Context, arg GetWaitlistEntryPara
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoBook,
+		&i.UseVisitPack,
+	)
+	return i, err
+}
+
+const getWaitlistOfferForUser = `-- name: GetWaitlistOfferForUser :one
+SELECT
+    wo.id AS offer_id,
+    wo.expires_at,
+    wo.status AS offer_status,
+    w.id AS waitlist_id,
+    w.facility_id,
+    w.target_court_id,
+    w.target_date,
+    w.target_start_time,
+    w.target_end_time,
+    w.status AS waitlist_status
+FROM waitlist_offers wo
+JOIN waitlists w ON w.id = wo.waitlist_id
+WHERE wo.id = ?1
+  AND w.user_id = ?2
+LIMIT 1
+`
+
+type GetWaitlistOfferForUserParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"userId"`
+}
+
+type GetWaitlistOfferForUserRow struct {
+	OfferID         int64         `json:"offerId"`
+	ExpiresAt       time.Time     `json:"expiresAt"`
+	OfferStatus     string        `json:"offerStatus"`
+	WaitlistID      int64         `json:"waitlistId"`
+	FacilityID      int64         `json:"facilityId"`
+	TargetCourtID   sql.NullInt64 `json:"targetCourtId"`
+	TargetDate      time.Time     `json:"targetDate"`
+	TargetStartTime interface{}   `json:"targetStartTime"`
+	TargetEndTime   interface{}   `json:"targetEndTime"`
+	WaitlistStatus  string        `json:"waitlistStatus"`
+}
+
+func (q *Queries) GetWaitlistOfferForUser(ctx context.Context, arg GetWaitlistOfferForUserParams) (GetWaitlistOfferForUserRow, error) {
+	row := q.queryRow(ctx, q.getWaitlistOfferForUserStmt, getWaitlistOfferForUser, arg.ID, arg.UserID)
+	var i GetWaitlistOfferForUserRow
+	err := row.Scan(
+		&i.OfferID,
+		&i.ExpiresAt,
+		&i.OfferStatus,
+		&i.WaitlistID,
+		&i.FacilityID,
+		&i.TargetCourtID,
+		&i.TargetDate,
+		&i.TargetStartTime,
+		&i.TargetEndTime,
+		&i.WaitlistStatus,
 	)
 	return i, err
 }
@@ -390,23 +644,19 @@ const listExpiredOffers = `-- name: ListExpiredOffers :many
 SELECT
     wo.id AS offer_id,
     wo.waitlist_id,
-    w.facility_id,
-    wc.offer_expiry_minutes
+    w.facility_id
 FROM waitlist_offers wo
 JOIN waitlists w ON w.id = wo.waitlist_id
-JOIN waitlist_config wc ON wc.facility_id = w.facility_id
 WHERE wo.status = 'pending'
   AND wo.expires_at < ?1
   AND w.status = 'notified'
-  AND wc.notification_mode = 'sequential'
 ORDER BY wo.expires_at
 `
 
 type ListExpiredOffersRow struct {
-	OfferID            int64 `json:"offerId"`
-	WaitlistID         int64 `json:"waitlistId"`
-	FacilityID         int64 `json:"facilityId"`
-	OfferExpiryMinutes int64 `json:"offerExpiryMinutes"`
+	OfferID    int64 `json:"offerId"`
+	WaitlistID int64 `json:"waitlistId"`
+	FacilityID int64 `json:"facilityId"`
 }
 
 func (q *Queries) ListExpiredOffers(ctx context.Context, comparisonTime time.Time) ([]ListExpiredOffersRow, error) {
@@ -422,7 +672,6 @@ func (q *Queries) ListExpiredOffers(ctx context.Context, comparisonTime time.Tim
 			&i.OfferID,
 			&i.WaitlistID,
 			&i.FacilityID,
-			&i.OfferExpiryMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -449,7 +698,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE facility_id = ?1
   AND target_date = ?2
@@ -498,6 +749,8 @@ func (q *Queries) ListMatchingPendingWaitlistsForCancelledSlot(ctx context.Conte
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AutoBook,
+			&i.UseVisitPack,
 		); err != nil {
 			return nil, err
 		}
@@ -524,7 +777,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE facility_id = ?1
 ORDER BY target_date, target_start_time, position
@@ -551,6 +806,8 @@ func (q *Queries) ListWaitlistsByFacility(ctx context.Context, facilityID int64)
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AutoBook,
+			&i.UseVisitPack,
 		); err != nil {
 			return nil, err
 		}
@@ -577,7 +834,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE user_id = ?1
 ORDER BY created_at DESC
@@ -604,6 +863,8 @@ func (q *Queries) ListWaitlistsByUser(ctx context.Context, userID int64) ([]Wait
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AutoBook,
+			&i.UseVisitPack,
 		); err != nil {
 			return nil, err
 		}
@@ -630,7 +891,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE user_id = ?1
   AND facility_id = ?2
@@ -663,6 +926,8 @@ func (q *Queries) ListWaitlistsByUserAndFacility(ctx context.Context, arg ListWa
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AutoBook,
+			&i.UseVisitPack,
 		); err != nil {
 			return nil, err
 		}
@@ -689,7 +954,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE facility_id = ?1
   AND target_date = ?2
@@ -737,6 +1004,8 @@ func (q *Queries) ListWaitlistsForSlot(ctx context.Context, arg ListWaitlistsFor
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AutoBook,
+			&i.UseVisitPack,
 		); err != nil {
 			return nil, err
 		}
@@ -751,6 +1020,113 @@ func (q *Queries) ListWaitlistsForSlot(ctx context.Context, arg ListWaitlistsFor
 	return items, nil
 }
 
+const stageWaitlistPositions = `-- name: StageWaitlistPositions :execrows
+
+UPDATE waitlists
+SET position = -(
+    SELECT COUNT(*)
+    FROM waitlists w2
+    WHERE w2.facility_id = waitlists.facility_id
+      AND w2.target_date = waitlists.target_date
+      AND w2.target_start_time = waitlists.target_start_time
+      AND w2.target_end_time = waitlists.target_end_time
+      AND COALESCE(w2.target_court_id, -1) = COALESCE(waitlists.target_court_id, -1)
+      AND w2.status IN ('pending', 'notified')
+      AND w2.id <= waitlists.id
+)
+WHERE facility_id = ?1
+  AND target_date = ?2
+  AND target_start_time = ?3
+  AND target_end_time = ?4
+  AND status IN ('pending', 'notified')
+  AND (
+      target_court_id = ?5
+      OR (?5 IS NULL AND target_court_id IS NULL)
+  )
+`
+
+type StageWaitlistPositionsParams struct {
+	FacilityID      int64         `json:"facilityId"`
+	TargetDate      time.Time     `json:"targetDate"`
+	TargetStartTime interface{}   `json:"targetStartTime"`
+	TargetEndTime   interface{}   `json:"targetEndTime"`
+	TargetCourtID   sql.NullInt64 `json:"targetCourtId"`
+}
+
+// Positions are renumbered in two steps because the slot position index is
+// checked row by row: StageWaitlistPositions writes each active entry's new
+// place as a negative number and ApplyStagedWaitlistPositions flips them back.
+// Entries join at the back of the queue, so join order (id) is queue order.
+func (q *Queries) StageWaitlistPositions(ctx context.Context, arg StageWaitlistPositionsParams) (int64, error) {
+	result, err := q.exec(ctx, q.stageWaitlistPositionsStmt, stageWaitlistPositions,
+		arg.FacilityID,
+		arg.TargetDate,
+		arg.TargetStartTime,
+		arg.TargetEndTime,
+		arg.TargetCourtID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateWaitlistAutoBook = `-- name: UpdateWaitlistAutoBook :one
+UPDATE waitlists
+SET auto_book = ?1,
+    use_visit_pack = ?2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?3
+  AND facility_id = ?4
+RETURNING
+    id,
+    facility_id,
+    user_id,
+    target_court_id,
+    target_date,
+    target_start_time,
+    target_end_time,
+    position,
+    status,
+    created_at,
+    updated_at,
+    auto_book,
+    use_visit_pack
+`
+
+type UpdateWaitlistAutoBookParams struct {
+	AutoBook     bool  `json:"autoBook"`
+	UseVisitPack bool  `json:"useVisitPack"`
+	ID           int64 `json:"id"`
+	FacilityID   int64 `json:"facilityId"`
+}
+
+func (q *Queries) UpdateWaitlistAutoBook(ctx context.Context, arg UpdateWaitlistAutoBookParams) (Waitlist, error) {
+	row := q.queryRow(ctx, q.updateWaitlistAutoBookStmt, updateWaitlistAutoBook,
+		arg.AutoBook,
+		arg.UseVisitPack,
+		arg.ID,
+		arg.FacilityID,
+	)
+	var i Waitlist
+	err := row.Scan(
+		&i.ID,
+		&i.FacilityID,
+		&i.UserID,
+		&i.TargetCourtID,
+		&i.TargetDate,
+		&i.TargetStartTime,
+		&i.TargetEndTime,
+		&i.Position,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AutoBook,
+		&i.UseVisitPack,
+	)
+	return i, err
+}
+
 const updateWaitlistStatus = `-- name: UpdateWaitlistStatus :one
 UPDATE waitlists
 SET status = ?1,
@@ -768,7 +1144,9 @@ RETURNING
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 `
 
 type UpdateWaitlistStatusParams struct {
@@ -792,6 +1170,8 @@ func (q *Queries) UpdateWaitlistStatus(ctx context.Context, arg UpdateWaitlistSt
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoBook,
+		&i.UseVisitPack,
 	)
 	return i, err
 }
@@ -802,19 +1182,22 @@ INSERT INTO waitlist_config (
     max_waitlist_size,
     notification_mode,
     offer_expiry_minutes,
-    notification_window_minutes
+    notification_window_minutes,
+    expired_offer_action
 ) VALUES (
     ?1,
     ?2,
     ?3,
     ?4,
-    ?5
+    ?5,
+    ?6
 )
 ON CONFLICT(facility_id) DO UPDATE SET
     max_waitlist_size = excluded.max_waitlist_size,
     notification_mode = excluded.notification_mode,
     offer_expiry_minutes = excluded.offer_expiry_minutes,
     notification_window_minutes = excluded.notification_window_minutes,
+    expired_offer_action = excluded.expired_offer_action,
     updated_at = CURRENT_TIMESTAMP
 RETURNING
     id,
@@ -823,6 +1206,7 @@ RETURNING
     notification_mode,
     offer_expiry_minutes,
     notification_window_minutes,
+    expired_offer_action,
     created_at,
     updated_at
 `
@@ -833,6 +1217,7 @@ type UpsertWaitlistConfigParams struct {
 	NotificationMode          string `json:"notificationMode"`
 	OfferExpiryMinutes        int64  `json:"offerExpiryMinutes"`
 	NotificationWindowMinutes int64  `json:"notificationWindowMinutes"`
+	ExpiredOfferAction        string `json:"expiredOfferAction"`
 }
 
 func (q *Queries) UpsertWaitlistConfig(ctx context.Context, arg UpsertWaitlistConfigParams) (WaitlistConfig, error) {
@@ -842,6 +1227,7 @@ func (q *Queries) UpsertWaitlistConfig(ctx context.Context, arg UpsertWaitlistCo
 		arg.NotificationMode,
 		arg.OfferExpiryMinutes,
 		arg.NotificationWindowMinutes,
+		arg.ExpiredOfferAction,
 	)
 	var i WaitlistConfig
 	err := row.Scan(
@@ -851,6 +1237,7 @@ func (q *Queries) UpsertWaitlistConfig(ctx context.Context, arg UpsertWaitlistCo
 		&i.NotificationMode,
 		&i.OfferExpiryMinutes,
 		&i.NotificationWindowMinutes,
+		&i.ExpiredOfferAction,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
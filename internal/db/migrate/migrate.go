@@ -0,0 +1,183 @@
+// Package migrate applies the schema migrations embedded in the binary. The
+// server uses it to migrate on boot or to check the schema is current, and
+// cmd/tools/dbmigrate wraps it for operators.
+package migrate
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/codr1/Pickleicious/internal/db/migrations"
+)
+
+const sqliteScheme = "sqlite3://"
+
+// Runner applies migrations to one database.
+type Runner struct {
+	m *migrate.Migrate
+	// ownsDB is set when the runner opened the database itself; closing a
+	// runner built on a caller's *sql.DB would close that connection too.
+	ownsDB bool
+}
+
+// New returns a Runner over an open SQLite connection. Closing the runner
+// leaves db open.
+func New(db *sql.DB) (*Runner, error) {
+	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("could not create migrate driver: %w", err)
+	}
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("could not create source: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", source, "sqlite3", driver)
+	if err != nil {
+		return nil, fmt.Errorf("could not create migrate instance: %w", err)
+	}
+	return &Runner{m: m}, nil
+}
+
+// Open returns a Runner over the SQLite database at path, creating its
+// directory if needed. Close releases the connection.
+func Open(path string) (*Runner, error) {
+	databaseURL, err := DatabaseURL(path)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(strings.TrimPrefix(databaseURL, sqliteScheme))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create database directory: %w", err)
+	}
+
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("could not create source: %w", err)
+	}
+	m, err := migrate.NewWithSourceInstance("iofs", source, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not create migrate instance: %w", err)
+	}
+	return &Runner{m: m, ownsDB: true}, nil
+}
+
+// DatabaseURL turns a SQLite file path, with or without the sqlite3://
+// scheme, into a sqlite3:// URL with an absolute path so the result does not
+// depend on the working directory. Query parameters are kept.
+func DatabaseURL(path string) (string, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), sqliteScheme)
+	if path == "" {
+		return "", errors.New("database path is required")
+	}
+	file, query, hasQuery := strings.Cut(path, "?")
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", fmt.Errorf("invalid database path: %w", err)
+	}
+	if hasQuery {
+		return sqliteScheme + abs + "?" + query, nil
+	}
+	return sqliteScheme + abs, nil
+}
+
+// Up applies every pending migration. An up-to-date database is not an
+// error.
+func (r *Runner) Up() error {
+	if err := r.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("could not run migrations: %w", err)
+	}
+	return nil
+}
+
+// Down rolls back the last applied migration. Rolling back everything is
+// not offered: the baseline migration's down drops the version table itself.
+func (r *Runner) Down() error {
+	if err := r.m.Steps(-1); err != nil {
+		return fmt.Errorf("could not roll back migration: %w", err)
+	}
+	return nil
+}
+
+// Version reports the last applied migration and whether it failed partway
+// through. A database with no migrations applied reports version 0.
+func (r *Runner) Version() (version int64, dirty bool, err error) {
+	v, dirty, err := r.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("could not read migration version: %w", err)
+	}
+	return int64(v), dirty, nil
+}
+
+// Force records version as applied and clears the dirty flag without
+// running anything. It is for recovering from a failed migration after the
+// schema has been repaired by hand.
+func (r *Runner) Force(version int64) error {
+	if err := r.m.Force(int(version)); err != nil {
+		return fmt.Errorf("could not force migration version %d: %w", version, err)
+	}
+	return nil
+}
+
+// EnsureCurrent fails unless the database is at the latest embedded
+// migration and clean.
+func (r *Runner) EnsureCurrent() error {
+	latest, err := Latest()
+	if err != nil {
+		return err
+	}
+	version, dirty, err := r.Version()
+	if err != nil {
+		return err
+	}
+	switch {
+	case dirty:
+		return fmt.Errorf("migration %d is dirty", version)
+	case version != latest:
+		return fmt.Errorf("database is at migration %d, expected %d", version, latest)
+	}
+	return nil
+}
+
+// Close releases the runner, and the database connection when Open made it.
+func (r *Runner) Close() error {
+	if !r.ownsDB {
+		return nil
+	}
+	sourceErr, dbErr := r.m.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+// Latest returns the newest migration embedded in the binary, which is the
+// version a fully migrated database reports.
+func Latest() (int64, error) {
+	entries, err := fs.ReadDir(migrations.FS, ".")
+	if err != nil {
+		return 0, fmt.Errorf("could not read migrations: %w", err)
+	}
+	var latest int64
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok || !strings.HasSuffix(entry.Name(), ".up.sql") {
+			continue
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("could not parse migration version %q: %w", entry.Name(), err)
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
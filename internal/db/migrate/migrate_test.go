@@ -0,0 +1,122 @@
+package migrate
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestDatabaseURL(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"build/db/app.db", "sqlite3://" + filepath.Join(wd, "build/db/app.db")},
+		{"sqlite3://build/db/app.db", "sqlite3://" + filepath.Join(wd, "build/db/app.db")},
+		{"/var/lib/app.db?_fk=1", "sqlite3:///var/lib/app.db?_fk=1"},
+	}
+	for _, tt := range tests {
+		got, err := DatabaseURL(tt.path)
+		if err != nil {
+			t.Fatalf("DatabaseURL(%q): %v", tt.path, err)
+		}
+		if got != tt.want {
+			t.Errorf("DatabaseURL(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	if _, err := DatabaseURL("  "); err == nil {
+		t.Error("expected an empty path to be rejected")
+	}
+}
+
+func TestRunner_UpDownAndForce(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "nested", "migrate.db")
+	runner, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = runner.Close() })
+
+	if version, dirty, err := runner.Version(); err != nil || version != 0 || dirty {
+		t.Fatalf("expected a new database at version 0, got %d dirty=%v err=%v", version, dirty, err)
+	}
+	if err := runner.EnsureCurrent(); err == nil {
+		t.Fatal("expected an unmigrated database not to be current")
+	}
+
+	if err := runner.Up(); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	latest, err := Latest()
+	if err != nil {
+		t.Fatalf("latest: %v", err)
+	}
+	if version, dirty, err := runner.Version(); err != nil || version != latest || dirty {
+		t.Fatalf("expected version %d after up, got %d dirty=%v err=%v", latest, version, dirty, err)
+	}
+	if err := runner.EnsureCurrent(); err != nil {
+		t.Fatalf("expected a migrated database to be current: %v", err)
+	}
+	// Running up again is a no-op.
+	if err := runner.Up(); err != nil {
+		t.Fatalf("repeat up: %v", err)
+	}
+
+	if err := runner.Force(latest - 1); err != nil {
+		t.Fatalf("force: %v", err)
+	}
+	err = runner.EnsureCurrent()
+	if err == nil || !strings.Contains(err.Error(), "expected") {
+		t.Fatalf("expected a forced older version to be behind, got %v", err)
+	}
+	if err := runner.Force(latest); err != nil {
+		t.Fatalf("force back: %v", err)
+	}
+
+	if err := runner.Down(); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	if version, dirty, err := runner.Version(); err != nil || version != latest-1 || dirty {
+		t.Fatalf("expected down to roll back one migration to %d, got %d dirty=%v err=%v", latest-1, version, dirty, err)
+	}
+	if err := runner.Up(); err != nil {
+		t.Fatalf("up after down: %v", err)
+	}
+}
+
+func TestNew_LeavesCallerConnectionOpen(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "shared.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	runner, err := New(db)
+	if err != nil {
+		t.Fatalf("new runner: %v", err)
+	}
+	if err := runner.Up(); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if err := runner.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM reservation_types").Scan(&count); err != nil {
+		t.Fatalf("expected the connection to stay usable: %v", err)
+	}
+	if count == 0 {
+		t.Fatal("expected seeded reservation types after up")
+	}
+}
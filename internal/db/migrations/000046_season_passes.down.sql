@@ -0,0 +1,16 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_season_pass_reservations_season_pass_id;
+DROP TABLE IF EXISTS season_pass_reservations;
+
+DROP INDEX IF EXISTS idx_season_passes_user_status;
+DROP INDEX IF EXISTS idx_season_passes_pass_type_id;
+DROP TABLE IF EXISTS season_passes;
+
+DROP INDEX IF EXISTS idx_season_pass_windows_pass_type_id;
+DROP TABLE IF EXISTS season_pass_windows;
+
+DROP INDEX IF EXISTS idx_season_pass_types_facility_id;
+DROP TABLE IF EXISTS season_pass_types;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,64 @@
+PRAGMA foreign_keys = ON;
+
+------ SEASON PASSES ------
+CREATE TABLE season_pass_types (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    price_cents INTEGER NOT NULL CHECK (price_cents >= 0),
+    valid_from TEXT NOT NULL,   -- YYYY-MM-DD in facility time, inclusive
+    valid_until TEXT NOT NULL,  -- YYYY-MM-DD in facility time, inclusive
+    status TEXT NOT NULL DEFAULT 'active',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (valid_from <= valid_until),
+    CHECK (status IN ('active', 'inactive')),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id)
+);
+
+CREATE INDEX idx_season_pass_types_facility_id ON season_pass_types(facility_id);
+
+-- Off-peak windows covered by a pass type, in facility local time.
+CREATE TABLE season_pass_windows (
+    id INTEGER PRIMARY KEY,
+    pass_type_id INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week >= 0 AND day_of_week <= 6),  -- 0=Sunday
+    starts_at TEXT NOT NULL,  -- HH:MM
+    ends_at TEXT NOT NULL,    -- HH:MM
+    CHECK (starts_at < ends_at),
+    FOREIGN KEY (pass_type_id) REFERENCES season_pass_types(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_season_pass_windows_pass_type_id ON season_pass_windows(pass_type_id);
+
+CREATE TABLE season_passes (
+    id INTEGER PRIMARY KEY,
+    pass_type_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    purchased_at DATETIME NOT NULL,
+    price_paid_cents INTEGER NOT NULL CHECK (price_paid_cents >= 0),
+    granted_by_user_id INTEGER,  -- staff who granted the pass; null for member purchases
+    status TEXT NOT NULL DEFAULT 'active',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('active', 'revoked')),
+    FOREIGN KEY (pass_type_id) REFERENCES season_pass_types(id),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (granted_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_season_passes_pass_type_id ON season_passes(pass_type_id);
+CREATE INDEX idx_season_passes_user_status ON season_passes(user_id, status);
+
+-- Reservations booked under a season pass. Covered reservations carry no
+-- per-booking charge and are excluded from the member reservation limit.
+CREATE TABLE season_pass_reservations (
+    id INTEGER PRIMARY KEY,
+    season_pass_id INTEGER NOT NULL,
+    reservation_id INTEGER NOT NULL UNIQUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (season_pass_id) REFERENCES season_passes(id),
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_season_pass_reservations_season_pass_id ON season_pass_reservations(season_pass_id);
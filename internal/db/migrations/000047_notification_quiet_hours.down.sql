@@ -0,0 +1,8 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_deferred_emails_status_send_after;
+DROP TABLE IF EXISTS deferred_emails;
+
+DROP TABLE IF EXISTS facility_quiet_hours;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,33 @@
+PRAGMA foreign_keys = ON;
+
+------ NOTIFICATION QUIET HOURS ------
+CREATE TABLE facility_quiet_hours (
+    facility_id INTEGER PRIMARY KEY,
+    starts_at TEXT NOT NULL,  -- HH:MM in facility time
+    ends_at TEXT NOT NULL,    -- HH:MM in facility time; may wrap past midnight
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (starts_at <> ends_at),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE
+);
+
+------ DEFERRED EMAILS ------
+CREATE TABLE deferred_emails (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    reservation_id INTEGER,  -- re-checked at dispatch; cancelled or deleted reservations suppress the send
+    user_id INTEGER NOT NULL,
+    recipient TEXT NOT NULL,
+    sender TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    send_after DATETIME NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    processed_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('pending', 'sent', 'suppressed', 'failed')),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_deferred_emails_status_send_after ON deferred_emails(status, send_after);
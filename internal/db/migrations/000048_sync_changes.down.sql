@@ -0,0 +1,20 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TRIGGER IF EXISTS open_play_rules_sync_update;
+DROP TRIGGER IF EXISTS open_play_sessions_sync_delete;
+DROP TRIGGER IF EXISTS open_play_sessions_sync_update;
+DROP TRIGGER IF EXISTS open_play_sessions_sync_insert;
+DROP TRIGGER IF EXISTS reservation_cancellations_touch_insert;
+DROP TRIGGER IF EXISTS reservation_participants_touch_delete;
+DROP TRIGGER IF EXISTS reservation_participants_touch_insert;
+DROP TRIGGER IF EXISTS reservation_courts_touch_delete;
+DROP TRIGGER IF EXISTS reservation_courts_touch_insert;
+DROP TRIGGER IF EXISTS reservations_sync_delete;
+DROP TRIGGER IF EXISTS reservations_sync_update;
+DROP TRIGGER IF EXISTS reservations_sync_insert;
+
+DROP INDEX IF EXISTS idx_sync_changes_facility_type_changed_at;
+DROP INDEX IF EXISTS idx_sync_changes_facility_type_seq;
+DROP TABLE IF EXISTS sync_changes;
+
+PRAGMA foreign_keys = ON;
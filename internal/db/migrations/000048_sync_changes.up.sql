@@ -0,0 +1,119 @@
+PRAGMA foreign_keys = ON;
+
+------ DELTA SYNC ------
+-- Append-only change log for delta sync clients. seq is assigned inside the
+-- writing transaction and SQLite serializes writers, so seq order matches
+-- commit order and a reader never observes a gap that later fills in.
+CREATE TABLE sync_changes (
+    seq INTEGER PRIMARY KEY AUTOINCREMENT,
+    facility_id INTEGER NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id INTEGER NOT NULL,
+    changed_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),  -- UTC, millisecond precision
+    CHECK (entity_type IN ('reservation', 'open_play_session'))
+);
+
+CREATE INDEX idx_sync_changes_facility_type_seq ON sync_changes(facility_id, entity_type, seq);
+CREATE INDEX idx_sync_changes_facility_type_changed_at ON sync_changes(facility_id, entity_type, changed_at);
+
+CREATE TRIGGER reservations_sync_insert
+AFTER INSERT ON reservations
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (NEW.facility_id, 'reservation', NEW.id);
+END;
+
+CREATE TRIGGER reservations_sync_update
+AFTER UPDATE ON reservations
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (NEW.facility_id, 'reservation', NEW.id);
+END;
+
+CREATE TRIGGER reservations_sync_delete
+AFTER DELETE ON reservations
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (OLD.facility_id, 'reservation', OLD.id);
+END;
+
+-- Court, participant, and cancellation rows change what a reservation means to
+-- clients, so they bump the parent updated_at (which also logs a sync change).
+CREATE TRIGGER reservation_courts_touch_insert
+AFTER INSERT ON reservation_courts
+BEGIN
+    UPDATE reservations SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.reservation_id;
+END;
+
+CREATE TRIGGER reservation_courts_touch_delete
+AFTER DELETE ON reservation_courts
+BEGIN
+    UPDATE reservations SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.reservation_id;
+END;
+
+CREATE TRIGGER reservation_participants_touch_insert
+AFTER INSERT ON reservation_participants
+BEGIN
+    UPDATE reservations SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.reservation_id;
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    SELECT ops.facility_id, 'open_play_session', ops.id
+    FROM reservations r
+    JOIN open_play_sessions ops
+      ON ops.facility_id = r.facility_id
+     AND ops.open_play_rule_id = r.open_play_rule_id
+     AND ops.start_time = r.start_time
+     AND ops.end_time = r.end_time
+    WHERE r.id = NEW.reservation_id;
+END;
+
+CREATE TRIGGER reservation_participants_touch_delete
+AFTER DELETE ON reservation_participants
+BEGIN
+    UPDATE reservations SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.reservation_id;
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    SELECT ops.facility_id, 'open_play_session', ops.id
+    FROM reservations r
+    JOIN open_play_sessions ops
+      ON ops.facility_id = r.facility_id
+     AND ops.open_play_rule_id = r.open_play_rule_id
+     AND ops.start_time = r.start_time
+     AND ops.end_time = r.end_time
+    WHERE r.id = OLD.reservation_id;
+END;
+
+CREATE TRIGGER reservation_cancellations_touch_insert
+AFTER INSERT ON reservation_cancellations
+BEGIN
+    UPDATE reservations SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.reservation_id;
+END;
+
+CREATE TRIGGER open_play_sessions_sync_insert
+AFTER INSERT ON open_play_sessions
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (NEW.facility_id, 'open_play_session', NEW.id);
+END;
+
+CREATE TRIGGER open_play_sessions_sync_update
+AFTER UPDATE ON open_play_sessions
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (NEW.facility_id, 'open_play_session', NEW.id);
+END;
+
+CREATE TRIGGER open_play_sessions_sync_delete
+AFTER DELETE ON open_play_sessions
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (OLD.facility_id, 'open_play_session', OLD.id);
+END;
+
+-- Rule edits change session capacity.
+CREATE TRIGGER open_play_rules_sync_update
+AFTER UPDATE ON open_play_rules
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    SELECT facility_id, 'open_play_session', id
+    FROM open_play_sessions
+    WHERE open_play_rule_id = NEW.id;
+END;
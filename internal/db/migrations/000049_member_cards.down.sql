@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_member_cards_active_user;
+DROP TABLE IF EXISTS member_cards;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,16 @@
+PRAGMA foreign_keys = ON;
+
+------ MEMBER ID CARDS ------
+-- card_token is the random identifier embedded in the signed check-in QR
+-- payload; member IDs never appear in the payload. Resetting a lost card
+-- revokes the current token and issues a new one.
+CREATE TABLE member_cards (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    card_token TEXT NOT NULL UNIQUE,
+    issued_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE UNIQUE INDEX idx_member_cards_active_user ON member_cards(user_id) WHERE revoked_at IS NULL;
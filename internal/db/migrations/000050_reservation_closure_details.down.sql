@@ -0,0 +1,5 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TABLE IF EXISTS reservation_closure_details;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,13 @@
+PRAGMA foreign_keys = ON;
+
+------ RESERVATION CLOSURE DETAILS ------
+-- Maintenance and closure blocks carry two separate notes: public_reason is
+-- shown to members browsing availability, internal_notes stays with staff.
+CREATE TABLE reservation_closure_details (
+    reservation_id INTEGER PRIMARY KEY,
+    public_reason TEXT,
+    internal_notes TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE
+);
@@ -0,0 +1,32 @@
+PRAGMA foreign_keys = OFF;
+
+CREATE TABLE waitlist_offers_old (
+    id INTEGER PRIMARY KEY,
+    waitlist_id INTEGER NOT NULL,
+    offered_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'accepted', 'expired')),
+    FOREIGN KEY (waitlist_id) REFERENCES waitlists(id) ON DELETE CASCADE
+);
+
+INSERT INTO waitlist_offers_old (id, waitlist_id, offered_at, expires_at, status)
+SELECT
+    id,
+    waitlist_id,
+    offered_at,
+    expires_at,
+    CASE WHEN status = 'declined' THEN 'expired' ELSE status END
+FROM waitlist_offers;
+
+DROP INDEX IF EXISTS idx_waitlist_offers_waitlist_id;
+DROP INDEX IF EXISTS idx_waitlist_offers_status;
+DROP INDEX IF EXISTS idx_waitlist_offers_expires_at;
+DROP TABLE waitlist_offers;
+
+ALTER TABLE waitlist_offers_old RENAME TO waitlist_offers;
+
+CREATE INDEX idx_waitlist_offers_waitlist_id ON waitlist_offers(waitlist_id);
+CREATE INDEX idx_waitlist_offers_status ON waitlist_offers(status);
+CREATE INDEX idx_waitlist_offers_expires_at ON waitlist_offers(expires_at);
+
+PRAGMA foreign_keys = ON;
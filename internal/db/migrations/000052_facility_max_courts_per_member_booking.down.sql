@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE facilities
+DROP COLUMN max_courts_per_member_booking;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,5 @@
+PRAGMA foreign_keys = ON;
+
+------ FACILITY MEMBER MULTI-COURT BOOKING ------
+ALTER TABLE facilities
+    ADD COLUMN max_courts_per_member_booking INTEGER NOT NULL DEFAULT 1 CHECK (max_courts_per_member_booking > 0);
@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE users
+DROP COLUMN hide_from_rosters;
+
+PRAGMA foreign_keys = ON;
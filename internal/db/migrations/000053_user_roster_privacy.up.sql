@@ -0,0 +1,5 @@
+PRAGMA foreign_keys = ON;
+
+------ MEMBER ROSTER PRIVACY ------
+ALTER TABLE users
+    ADD COLUMN hide_from_rosters BOOLEAN NOT NULL DEFAULT 0;
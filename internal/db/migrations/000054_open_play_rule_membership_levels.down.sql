@@ -0,0 +1,9 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE open_play_rules
+DROP COLUMN max_membership_level;
+
+ALTER TABLE open_play_rules
+DROP COLUMN min_membership_level;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,8 @@
+PRAGMA foreign_keys = ON;
+
+------ OPEN PLAY MEMBERSHIP ELIGIBILITY ------
+ALTER TABLE open_play_rules
+    ADD COLUMN min_membership_level INTEGER NOT NULL DEFAULT 0 CHECK (min_membership_level >= 0);
+
+ALTER TABLE open_play_rules
+    ADD COLUMN max_membership_level INTEGER CHECK (max_membership_level IS NULL OR max_membership_level >= min_membership_level);
@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE league_matches
+DROP COLUMN round_number;
+
+PRAGMA foreign_keys = ON;
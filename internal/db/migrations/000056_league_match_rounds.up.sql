@@ -0,0 +1,5 @@
+PRAGMA foreign_keys = ON;
+
+------ LEAGUE MATCH ROUNDS ------
+ALTER TABLE league_matches
+    ADD COLUMN round_number INTEGER CHECK (round_number IS NULL OR round_number > 0);
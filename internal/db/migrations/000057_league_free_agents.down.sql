@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_league_free_agents_user_id;
+DROP TABLE IF EXISTS league_free_agents;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,17 @@
+PRAGMA foreign_keys = ON;
+
+------ LEAGUE FREE AGENT REGISTRATIONS ------
+CREATE TABLE league_free_agents (
+    id INTEGER PRIMARY KEY,
+    league_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    skill_level TEXT NOT NULL,
+    availability_notes TEXT,
+    gender TEXT CHECK (gender IS NULL OR gender IN ('male', 'female')),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (league_id, user_id)
+);
+
+CREATE INDEX idx_league_free_agents_user_id ON league_free_agents(user_id);
@@ -0,0 +1,7 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_league_team_invitations_pending;
+DROP INDEX IF EXISTS idx_league_team_invitations_team_id;
+DROP TABLE IF EXISTS league_team_invitations;
+
+PRAGMA foreign_keys = ON;
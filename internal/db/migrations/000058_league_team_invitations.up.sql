@@ -0,0 +1,22 @@
+PRAGMA foreign_keys = ON;
+
+------ LEAGUE TEAM INVITATIONS ------
+CREATE TABLE league_team_invitations (
+    id INTEGER PRIMARY KEY,
+    league_team_id INTEGER NOT NULL,
+    invited_user_id INTEGER NOT NULL,
+    invited_by_user_id INTEGER NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'cancelled')),
+    expires_at DATETIME NOT NULL,
+    responded_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (league_team_id) REFERENCES league_teams(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_league_team_invitations_team_id ON league_team_invitations(league_team_id);
+CREATE UNIQUE INDEX idx_league_team_invitations_pending
+    ON league_team_invitations(league_team_id, invited_user_id)
+    WHERE status = 'pending';
@@ -0,0 +1,5 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TABLE IF EXISTS visit_pack_payments;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,12 @@
+PRAGMA foreign_keys = ON;
+
+------ VISIT PACK PAYMENTS ------
+CREATE TABLE visit_pack_payments (
+    id INTEGER PRIMARY KEY,
+    visit_pack_id INTEGER NOT NULL UNIQUE,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents >= 0),
+    processor TEXT NOT NULL,
+    reference TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (visit_pack_id) REFERENCES visit_packs(id) ON DELETE CASCADE
+);
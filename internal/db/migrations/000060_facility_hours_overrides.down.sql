@@ -0,0 +1,5 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TABLE IF EXISTS facility_hours_overrides;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,22 @@
+PRAGMA foreign_keys = ON;
+
+------ FACILITY HOURS OVERRIDES ------
+-- Exact-date hours that replace the weekly operating_hours row for that day,
+-- e.g. closing early on Dec 24 or closing entirely on a holiday.
+CREATE TABLE facility_hours_overrides (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    override_date TEXT NOT NULL,  -- YYYY-MM-DD in facility time
+    is_closed BOOLEAN NOT NULL DEFAULT 0,
+    opens_at TEXT,                -- HH:MM; NULL when closed
+    closes_at TEXT,               -- HH:MM; NULL when closed
+    reason TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (
+        (is_closed = 1 AND opens_at IS NULL AND closes_at IS NULL)
+        OR (is_closed = 0 AND opens_at IS NOT NULL AND closes_at IS NOT NULL AND opens_at < closes_at)
+    ),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    UNIQUE(facility_id, override_date)
+);
@@ -0,0 +1,8 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TABLE IF EXISTS member_home_facility_changes;
+
+ALTER TABLE visit_packs
+DROP COLUMN facility_id;
+
+PRAGMA foreign_keys = ON;
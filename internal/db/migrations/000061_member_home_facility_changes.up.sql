@@ -0,0 +1,27 @@
+PRAGMA foreign_keys = ON;
+
+------ VISIT PACK FACILITY TRANSFERS ------
+-- Facility a pack was transferred to when its owner changed home facility.
+-- NULL means the pack belongs to its pack type's facility. Kept without a
+-- foreign key so the down migration can drop the column.
+ALTER TABLE visit_packs
+    ADD COLUMN facility_id INTEGER;
+
+------ MEMBER HOME FACILITY CHANGES ------
+-- Audit trail of staff moving a member to a different home facility.
+CREATE TABLE member_home_facility_changes (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    from_facility_id INTEGER,
+    to_facility_id INTEGER NOT NULL,
+    changed_by_user_id INTEGER NOT NULL,
+    transferred_visit_packs INTEGER NOT NULL DEFAULT 0,
+    reason TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (from_facility_id) REFERENCES facilities(id),
+    FOREIGN KEY (to_facility_id) REFERENCES facilities(id),
+    FOREIGN KEY (changed_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_member_home_facility_changes_user_id ON member_home_facility_changes(user_id);
@@ -0,0 +1,18 @@
+PRAGMA foreign_keys = ON;
+
+------ RESERVATION CHECK-INS ------
+-- One row per participant who showed up for a reservation.
+CREATE TABLE reservation_checkins (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    checked_in_at DATETIME NOT NULL,
+    checked_in_by_user_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (checked_in_by_user_id) REFERENCES users(id),
+    UNIQUE (reservation_id, user_id)
+);
+
+CREATE INDEX idx_reservation_checkins_user_id ON reservation_checkins(user_id);
@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TABLE IF EXISTS no_show_restriction_clears;
+DROP TABLE IF EXISTS facility_no_show_policies;
+
+PRAGMA foreign_keys = ON;
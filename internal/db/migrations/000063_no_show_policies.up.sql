@@ -0,0 +1,31 @@
+PRAGMA foreign_keys = ON;
+
+------ NO-SHOW POLICIES ------
+-- Facilities without a row never restrict members for no-shows.
+CREATE TABLE facility_no_show_policies (
+    facility_id INTEGER PRIMARY KEY,
+    max_no_shows_per_30_days INTEGER NOT NULL CHECK (max_no_shows_per_30_days >= 0),
+    restriction_days INTEGER NOT NULL CHECK (restriction_days > 0),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE
+);
+
+------ NO-SHOW RESTRICTION CLEARS ------
+-- Staff overrides. No-shows ending at or before cleared_at stop counting
+-- toward a restriction at that facility.
+CREATE TABLE no_show_restriction_clears (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    facility_id INTEGER NOT NULL,
+    restricted_until DATETIME NOT NULL,
+    cleared_at DATETIME NOT NULL,
+    cleared_by_user_id INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    FOREIGN KEY (cleared_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_no_show_restriction_clears_user_facility ON no_show_restriction_clears(user_id, facility_id);
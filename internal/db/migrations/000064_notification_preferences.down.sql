@@ -0,0 +1,12 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE users
+DROP COLUMN notify_open_play_reminders;
+ALTER TABLE users
+DROP COLUMN notify_waitlist_offers;
+ALTER TABLE users
+DROP COLUMN notify_cancellations;
+ALTER TABLE users
+DROP COLUMN notify_confirmations;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,11 @@
+PRAGMA foreign_keys = ON;
+
+------ MEMBER NOTIFICATION PREFERENCES ------
+ALTER TABLE users
+    ADD COLUMN notify_confirmations BOOLEAN NOT NULL DEFAULT 1;
+ALTER TABLE users
+    ADD COLUMN notify_cancellations BOOLEAN NOT NULL DEFAULT 1;
+ALTER TABLE users
+    ADD COLUMN notify_waitlist_offers BOOLEAN NOT NULL DEFAULT 1;
+ALTER TABLE users
+    ADD COLUMN notify_open_play_reminders BOOLEAN NOT NULL DEFAULT 1;
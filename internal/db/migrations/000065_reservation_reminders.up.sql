@@ -0,0 +1,9 @@
+PRAGMA foreign_keys = ON;
+
+------ RESERVATION REMINDERS ------
+-- Marks reservations whose reminder email went out so restarts don't resend.
+CREATE TABLE reservation_reminders (
+    reservation_id INTEGER PRIMARY KEY,
+    sent_at DATETIME NOT NULL,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE
+);
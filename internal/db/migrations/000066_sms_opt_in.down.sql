@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE users
+DROP COLUMN sms_opt_in;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,5 @@
+PRAGMA foreign_keys = ON;
+
+------ MEMBER SMS OPT-IN ------
+ALTER TABLE users
+    ADD COLUMN sms_opt_in BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_prime_time_rules_facility_day;
+DROP TABLE IF EXISTS prime_time_rules;
+
+PRAGMA foreign_keys = ON;
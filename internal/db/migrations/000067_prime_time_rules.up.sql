@@ -0,0 +1,21 @@
+PRAGMA foreign_keys = ON;
+
+------ PRIME TIME RULES ------
+-- Weekly windows held for higher membership levels. Members below
+-- min_membership_level can book an overlapping slot only once it is within
+-- unlock_hours_before of its start.
+CREATE TABLE prime_time_rules (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),  -- 0 = Sunday
+    start_time TEXT NOT NULL,  -- HH:MM in facility time
+    end_time TEXT NOT NULL,    -- HH:MM in facility time
+    min_membership_level INTEGER NOT NULL CHECK (min_membership_level >= 0),
+    unlock_hours_before INTEGER NOT NULL CHECK (unlock_hours_before >= 0),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (start_time < end_time),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_prime_time_rules_facility_day ON prime_time_rules(facility_id, day_of_week);
@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_reservation_idempotency_keys_expires_at;
+DROP TABLE IF EXISTS reservation_idempotency_keys;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,21 @@
+PRAGMA foreign_keys = ON;
+
+------ RESERVATION IDEMPOTENCY KEYS ------
+-- Keys clients send with reservation creates so a retried request replays
+-- the original response instead of booking twice. Keys are scoped per user
+-- and forgotten once expires_at passes.
+CREATE TABLE reservation_idempotency_keys (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    request_hash TEXT NOT NULL,     -- SHA-256 of the create payload
+    reservation_id INTEGER NOT NULL,
+    response_body TEXT NOT NULL,    -- JSON returned with the original 201
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    UNIQUE(user_id, idempotency_key)
+);
+
+CREATE INDEX idx_reservation_idempotency_keys_expires_at ON reservation_idempotency_keys(expires_at);
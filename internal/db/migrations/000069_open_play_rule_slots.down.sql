@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_open_play_rule_slots_day;
+DROP TABLE IF EXISTS open_play_rule_slots;
+
+PRAGMA foreign_keys = ON;
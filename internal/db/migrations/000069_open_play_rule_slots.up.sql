@@ -0,0 +1,21 @@
+PRAGMA foreign_keys = ON;
+
+------ OPEN PLAY RULE SLOTS ------
+-- Weekly times an open play rule runs. Session generation materializes one
+-- session and its backing reservation per slot occurrence.
+CREATE TABLE open_play_rule_slots (
+    id INTEGER PRIMARY KEY,
+    open_play_rule_id INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),  -- 0 = Sunday
+    start_time TEXT NOT NULL,  -- HH:MM in facility time
+    end_time TEXT NOT NULL,    -- HH:MM in facility time
+    created_by_user_id INTEGER NOT NULL,  -- generated reservations are attributed to this user
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (start_time < end_time),
+    FOREIGN KEY (open_play_rule_id) REFERENCES open_play_rules(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+    UNIQUE(open_play_rule_id, day_of_week, start_time, end_time)
+);
+
+CREATE INDEX idx_open_play_rule_slots_day ON open_play_rule_slots(day_of_week);
@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_reservation_invitations_reservation_status;
+DROP TABLE IF EXISTS reservation_invitations;
+
+PRAGMA foreign_keys = ON;
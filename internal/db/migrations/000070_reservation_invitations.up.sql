@@ -0,0 +1,22 @@
+PRAGMA foreign_keys = ON;
+
+------ RESERVATION INVITATIONS ------
+-- Members a booking member invited to play. The invitee becomes a
+-- reservation participant only after accepting through the emailed token
+-- link; declining needs no login and frees the spot.
+CREATE TABLE reservation_invitations (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    invited_user_id INTEGER NOT NULL,
+    invited_by_user_id INTEGER NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    responded_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_by_user_id) REFERENCES users(id),
+    UNIQUE (reservation_id, invited_user_id)
+);
+
+CREATE INDEX idx_reservation_invitations_reservation_status ON reservation_invitations(reservation_id, status);
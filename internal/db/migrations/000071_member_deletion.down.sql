@@ -0,0 +1,10 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE users
+DROP COLUMN anonymized_at;
+ALTER TABLE users
+DROP COLUMN deletion_requested_at;
+ALTER TABLE users
+DROP COLUMN deleted_at;
+
+PRAGMA foreign_keys = ON;
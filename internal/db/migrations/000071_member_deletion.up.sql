@@ -0,0 +1,16 @@
+PRAGMA foreign_keys = ON;
+
+------ MEMBER DELETION ------
+-- deleted_at starts the retention clock for scrubbing contact details;
+-- anonymized_at records when that scrub ran. deletion_requested_at flags a
+-- member's own request for staff review.
+ALTER TABLE users
+    ADD COLUMN deleted_at DATETIME;
+ALTER TABLE users
+    ADD COLUMN deletion_requested_at DATETIME;
+ALTER TABLE users
+    ADD COLUMN anonymized_at DATETIME;
+
+UPDATE users
+SET deleted_at = updated_at
+WHERE status = 'deleted';
@@ -0,0 +1,8 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE facilities
+DROP COLUMN min_booking_minutes;
+ALTER TABLE facilities
+DROP COLUMN slot_duration_minutes;
+
+PRAGMA foreign_keys = ON;
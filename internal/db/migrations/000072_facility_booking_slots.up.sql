@@ -0,0 +1,8 @@
+PRAGMA foreign_keys = ON;
+
+------ FACILITY BOOKING SLOTS ------
+ALTER TABLE facilities
+    ADD COLUMN slot_duration_minutes INTEGER NOT NULL DEFAULT 60 CHECK (slot_duration_minutes > 0);
+
+ALTER TABLE facilities
+    ADD COLUMN min_booking_minutes INTEGER NOT NULL DEFAULT 60 CHECK (min_booking_minutes > 0);
@@ -0,0 +1,8 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_pro_unavailability_end_time;
+DROP INDEX IF EXISTS idx_pro_unavailability_start_time;
+DROP INDEX IF EXISTS idx_pro_unavailability_pro_id;
+DROP TABLE IF EXISTS pro_unavailability;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,21 @@
+PRAGMA foreign_keys = ON;
+
+------ PRO UNAVAILABILITY ------
+-- schema.sql has always had this table but no migration created it, so
+-- lesson slot lookups failed on migrated databases. IF NOT EXISTS keeps
+-- databases bootstrapped from schema.sql working.
+CREATE TABLE IF NOT EXISTS pro_unavailability (
+    id INTEGER PRIMARY KEY,
+    pro_id INTEGER NOT NULL,
+    start_time DATETIME NOT NULL,
+    end_time DATETIME NOT NULL,
+    reason TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (start_time < end_time),
+    FOREIGN KEY (pro_id) REFERENCES staff(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_pro_unavailability_pro_id ON pro_unavailability(pro_id);
+CREATE INDEX IF NOT EXISTS idx_pro_unavailability_start_time ON pro_unavailability(start_time);
+CREATE INDEX IF NOT EXISTS idx_pro_unavailability_end_time ON pro_unavailability(end_time);
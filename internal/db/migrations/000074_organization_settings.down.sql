@@ -0,0 +1,8 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE organizations
+DROP COLUMN default_cancellation_policy;
+ALTER TABLE organizations
+DROP COLUMN shared_member_directory;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,10 @@
+PRAGMA foreign_keys = ON;
+
+------ ORGANIZATION SETTINGS ------
+ALTER TABLE organizations
+    ADD COLUMN shared_member_directory BOOLEAN NOT NULL DEFAULT 0;
+
+-- JSON array of {"minHoursBefore", "refundPercentage"} tiers org admins keep
+-- as the starting point for club cancellation policies.
+ALTER TABLE organizations
+    ADD COLUMN default_cancellation_policy TEXT;
@@ -0,0 +1,20 @@
+PRAGMA foreign_keys = ON;
+
+DROP INDEX IF EXISTS idx_waitlists_slot_position_unique;
+
+-- Inactive entries may share a position with the live queue once positions
+-- are renumbered, so move them past it before restoring full uniqueness.
+UPDATE waitlists
+SET position = position + (
+    SELECT COALESCE(MAX(w2.position), 0)
+    FROM waitlists w2
+    WHERE w2.facility_id = waitlists.facility_id
+      AND w2.target_date = waitlists.target_date
+      AND w2.target_start_time = waitlists.target_start_time
+      AND w2.target_end_time = waitlists.target_end_time
+      AND COALESCE(w2.target_court_id, -1) = COALESCE(waitlists.target_court_id, -1)
+)
+WHERE status NOT IN ('pending', 'notified');
+
+CREATE UNIQUE INDEX idx_waitlists_slot_position_unique
+    ON waitlists(facility_id, target_date, target_start_time, target_end_time, COALESCE(target_court_id, -1), position);
@@ -0,0 +1,31 @@
+PRAGMA foreign_keys = ON;
+
+------ WAITLIST ACTIVE POSITIONS ------
+-- Positions now describe the live queue only. Expired and fulfilled entries
+-- keep their last position as history, so uniqueness applies to pending and
+-- notified entries.
+DROP INDEX IF EXISTS idx_waitlists_slot_position_unique;
+
+-- Close the gaps left by entries that already left each queue. Positions are
+-- staged as negatives first so no row collides with one not yet renumbered.
+UPDATE waitlists
+SET position = -ranked.new_position
+FROM (
+    SELECT
+        id,
+        ROW_NUMBER() OVER (
+            PARTITION BY facility_id, target_date, target_start_time, target_end_time, COALESCE(target_court_id, -1)
+            ORDER BY position, id
+        ) AS new_position
+    FROM waitlists
+    WHERE status IN ('pending', 'notified')
+) AS ranked
+WHERE waitlists.id = ranked.id;
+
+UPDATE waitlists
+SET position = -position
+WHERE position < 0;
+
+CREATE UNIQUE INDEX idx_waitlists_slot_position_unique
+    ON waitlists(facility_id, target_date, target_start_time, target_end_time, COALESCE(target_court_id, -1), position)
+    WHERE status IN ('pending', 'notified');
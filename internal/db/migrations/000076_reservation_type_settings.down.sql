@@ -0,0 +1,19 @@
+PRAGMA foreign_keys = OFF;
+
+-- Custom types are kept so their reservations stay valid; they become
+-- visible to every facility.
+DROP INDEX IF EXISTS idx_reservation_types_facility_id;
+DROP INDEX IF EXISTS idx_reservation_types_organization_id;
+
+ALTER TABLE reservation_types
+DROP COLUMN counts_toward_member_limit;
+ALTER TABLE reservation_types
+DROP COLUMN member_bookable;
+ALTER TABLE reservation_types
+DROP COLUMN default_duration_minutes;
+ALTER TABLE reservation_types
+DROP COLUMN facility_id;
+ALTER TABLE reservation_types
+DROP COLUMN organization_id;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,26 @@
+PRAGMA foreign_keys = ON;
+
+------ RESERVATION TYPE SETTINGS ------
+-- Built-in types have no organization or facility. Custom types belong to an
+-- organization and, optionally, a single facility within it.
+ALTER TABLE reservation_types
+    ADD COLUMN organization_id INTEGER REFERENCES organizations(id);
+ALTER TABLE reservation_types
+    ADD COLUMN facility_id INTEGER REFERENCES facilities(id);
+ALTER TABLE reservation_types
+    ADD COLUMN default_duration_minutes INTEGER NOT NULL DEFAULT 60 CHECK (default_duration_minutes > 0);
+ALTER TABLE reservation_types
+    ADD COLUMN member_bookable BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE reservation_types
+    ADD COLUMN counts_toward_member_limit BOOLEAN NOT NULL DEFAULT 0;
+
+-- Members book games and lessons themselves, and both count toward the
+-- facility's member reservation limit, as they did when the limit was keyed
+-- on these names.
+UPDATE reservation_types
+SET member_bookable = 1,
+    counts_toward_member_limit = 1
+WHERE name IN ('GAME', 'PRO_SESSION');
+
+CREATE INDEX idx_reservation_types_organization_id ON reservation_types(organization_id);
+CREATE INDEX idx_reservation_types_facility_id ON reservation_types(facility_id);
@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE waitlist_config
+DROP COLUMN expired_offer_action;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,8 @@
+PRAGMA foreign_keys = ON;
+
+------ WAITLIST EXPIRED OFFER ACTION ------
+-- What happens to a waitlist entry whose offer times out: 'remove' takes it
+-- out of the queue, 'requeue' keeps its place for the next opening.
+ALTER TABLE waitlist_config
+    ADD COLUMN expired_offer_action TEXT NOT NULL DEFAULT 'remove'
+    CHECK (expired_offer_action IN ('remove', 'requeue'));
@@ -0,0 +1,9 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TABLE IF EXISTS member_skill_ratings;
+DROP TABLE IF EXISTS open_play_session_skill_bands;
+
+ALTER TABLE open_play_rules
+DROP COLUMN skill_bands;
+
+PRAGMA foreign_keys = ON;
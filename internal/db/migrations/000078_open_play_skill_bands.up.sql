@@ -0,0 +1,23 @@
+PRAGMA foreign_keys = ON;
+
+------ OPEN PLAY SKILL BANDS ------
+-- Optional JSON array of skill bands, each a rating range and the court
+-- numbers it plays on. NULL means the session is not split by skill.
+ALTER TABLE open_play_rules ADD COLUMN skill_bands TEXT;
+
+-- Per-session band overrides set by staff. A row replaces the rule's bands
+-- for that session; '[]' turns bands off for it.
+CREATE TABLE open_play_session_skill_bands (
+    session_id INTEGER PRIMARY KEY,
+    skill_bands TEXT NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (session_id) REFERENCES open_play_sessions(id) ON DELETE CASCADE
+);
+
+------ MEMBER SKILL RATINGS ------
+CREATE TABLE member_skill_ratings (
+    user_id INTEGER PRIMARY KEY,
+    rating REAL NOT NULL CHECK (rating BETWEEN 1.0 AND 8.0),
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
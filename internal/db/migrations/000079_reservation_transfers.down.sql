@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_reservation_transfers_pending;
+DROP TABLE IF EXISTS reservation_transfers;
+
+PRAGMA foreign_keys = ON;
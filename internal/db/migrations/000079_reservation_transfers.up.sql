@@ -0,0 +1,25 @@
+PRAGMA foreign_keys = ON;
+
+------ RESERVATION TRANSFERS ------
+-- A booking member handing a reservation to another member. The reservation
+-- changes hands only once the target accepts through the emailed token link
+-- before expires_at; a pending row past expires_at has lapsed.
+CREATE TABLE reservation_transfers (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    from_user_id INTEGER NOT NULL,
+    to_user_id INTEGER NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    expires_at DATETIME NOT NULL,
+    responded_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (from_user_id) REFERENCES users(id),
+    FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- At most one open transfer per reservation.
+CREATE UNIQUE INDEX idx_reservation_transfers_pending
+    ON reservation_transfers(reservation_id)
+    WHERE status = 'pending';
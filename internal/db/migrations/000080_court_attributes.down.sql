@@ -0,0 +1,12 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE courts
+DROP COLUMN has_lights;
+
+ALTER TABLE courts
+DROP COLUMN surface;
+
+ALTER TABLE courts
+DROP COLUMN is_indoor;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,7 @@
+PRAGMA foreign_keys = ON;
+
+------ COURT ATTRIBUTES ------
+-- Members filter bookable courts by these. surface is NULL when unknown.
+ALTER TABLE courts ADD COLUMN is_indoor BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE courts ADD COLUMN surface TEXT CHECK (surface IS NULL OR surface IN ('hard', 'cushioned', 'sport_tile', 'wood', 'clay', 'turf'));
+ALTER TABLE courts ADD COLUMN has_lights BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE user_photos
+DROP COLUMN thumbnail_data;
+
+PRAGMA foreign_keys = ON;
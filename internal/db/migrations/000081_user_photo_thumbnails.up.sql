@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = ON;
+
+------ USER PHOTO THUMBNAILS ------
+-- Uploads are re-encoded as JPEG at a full size (data) and a square
+-- thumbnail. Photos stored before this migration have no thumbnail.
+ALTER TABLE user_photos ADD COLUMN thumbnail_data BLOB;
@@ -0,0 +1,91 @@
+PRAGMA foreign_keys = OFF;
+
+------ LEAGUE MATCH RESULT SUBMISSIONS ------
+-- Captains report scores from the portal. A home captain's submission puts
+-- the match in pending_result until the away captain confirms it (completed)
+-- or disputes it (disputed, for staff to resolve).
+CREATE TABLE league_matches_new (
+    id INTEGER PRIMARY KEY,
+    league_id INTEGER NOT NULL,
+    home_team_id INTEGER NOT NULL,
+    away_team_id INTEGER NOT NULL,
+    reservation_id INTEGER,
+    scheduled_time DATETIME NOT NULL,
+    home_score INTEGER,
+    away_score INTEGER,
+    status TEXT NOT NULL CHECK (status IN ('scheduled', 'in_progress', 'pending_result', 'disputed', 'completed', 'cancelled', 'forfeit')),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    round_number INTEGER,
+    CHECK (round_number IS NULL OR round_number > 0),
+    CHECK (home_team_id != away_team_id),
+    CHECK (home_score IS NULL OR home_score >= 0),
+    CHECK (away_score IS NULL OR away_score >= 0),
+    FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE,
+    FOREIGN KEY (home_team_id) REFERENCES league_teams(id) ON DELETE RESTRICT,
+    FOREIGN KEY (away_team_id) REFERENCES league_teams(id) ON DELETE RESTRICT,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE SET NULL
+);
+
+INSERT INTO league_matches_new (
+    id,
+    league_id,
+    home_team_id,
+    away_team_id,
+    reservation_id,
+    scheduled_time,
+    home_score,
+    away_score,
+    status,
+    created_at,
+    updated_at,
+    round_number
+)
+SELECT
+    id,
+    league_id,
+    home_team_id,
+    away_team_id,
+    reservation_id,
+    scheduled_time,
+    home_score,
+    away_score,
+    status,
+    created_at,
+    updated_at,
+    round_number
+FROM league_matches;
+
+DROP TABLE league_matches;
+ALTER TABLE league_matches_new RENAME TO league_matches;
+
+CREATE INDEX IF NOT EXISTS idx_league_matches_league_id ON league_matches(league_id);
+CREATE INDEX IF NOT EXISTS idx_league_matches_reservation_id ON league_matches(reservation_id);
+CREATE INDEX IF NOT EXISTS idx_league_matches_scheduled_time ON league_matches(scheduled_time);
+CREATE INDEX IF NOT EXISTS idx_league_matches_home_team_id ON league_matches(home_team_id);
+CREATE INDEX IF NOT EXISTS idx_league_matches_away_team_id ON league_matches(away_team_id);
+
+-- One submission per match. Scores live here until the result is accepted
+-- and copied onto the match.
+CREATE TABLE league_match_result_submissions (
+    id INTEGER PRIMARY KEY,
+    league_match_id INTEGER NOT NULL UNIQUE,
+    submitted_by_user_id INTEGER NOT NULL,
+    home_score INTEGER NOT NULL CHECK (home_score >= 0),
+    away_score INTEGER NOT NULL CHECK (away_score >= 0),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'disputed', 'overridden')),
+    respond_by DATETIME NOT NULL,
+    responded_by_user_id INTEGER,
+    responded_at DATETIME,
+    dispute_reason TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (league_match_id) REFERENCES league_matches(id) ON DELETE CASCADE,
+    FOREIGN KEY (submitted_by_user_id) REFERENCES users(id),
+    FOREIGN KEY (responded_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_league_match_result_submissions_pending
+    ON league_match_result_submissions(respond_by)
+    WHERE status = 'pending';
+
+PRAGMA foreign_keys = ON;
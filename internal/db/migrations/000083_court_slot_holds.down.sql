@@ -0,0 +1,7 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_court_slot_holds_expires_at;
+DROP INDEX IF EXISTS idx_court_slot_holds_court_time;
+DROP TABLE IF EXISTS court_slot_holds;
+
+PRAGMA foreign_keys = ON;
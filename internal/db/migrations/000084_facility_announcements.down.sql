@@ -0,0 +1,7 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TABLE IF EXISTS facility_announcement_dismissals;
+DROP INDEX IF EXISTS idx_facility_announcements_facility_window;
+DROP TABLE IF EXISTS facility_announcements;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,34 @@
+PRAGMA foreign_keys = ON;
+
+------ FACILITY ANNOUNCEMENTS ------
+-- Banners shown at the top of the member portal and/or staff pages between
+-- starts_at and ends_at (stored in UTC, entered in facility time).
+CREATE TABLE facility_announcements (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    severity TEXT NOT NULL DEFAULT 'info',
+    audience TEXT NOT NULL DEFAULT 'members',
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    created_by_user_id INTEGER,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (severity IN ('info', 'warning', 'critical')),
+    CHECK (audience IN ('members', 'staff', 'both')),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_facility_announcements_facility_window ON facility_announcements(facility_id, ends_at, starts_at);
+
+-- A dismissed banner stays hidden for that user.
+CREATE TABLE facility_announcement_dismissals (
+    announcement_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    dismissed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (announcement_id, user_id),
+    FOREIGN KEY (announcement_id) REFERENCES facility_announcements(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
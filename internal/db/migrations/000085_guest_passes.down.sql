@@ -0,0 +1,10 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_reservation_guests_reservation_id;
+DROP TABLE IF EXISTS reservation_guests;
+DROP TABLE IF EXISTS member_guest_passes;
+
+ALTER TABLE facilities
+DROP COLUMN max_guests_per_reservation;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,33 @@
+PRAGMA foreign_keys = ON;
+
+------ GUEST PASSES ------
+ALTER TABLE facilities
+    ADD COLUMN max_guests_per_reservation INTEGER NOT NULL DEFAULT 2 CHECK (max_guests_per_reservation >= 0);
+
+-- Guest passes a member holds at a facility. Each guest they bring uses one.
+CREATE TABLE member_guest_passes (
+    user_id INTEGER NOT NULL,
+    facility_id INTEGER NOT NULL,
+    balance INTEGER NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, facility_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE
+);
+
+-- Non-members brought along on a reservation. A guest either used one of the
+-- host's guest passes or is flagged as a drop-in to bill later.
+CREATE TABLE reservation_guests (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    host_user_id INTEGER,
+    name TEXT NOT NULL,
+    email TEXT,
+    is_drop_in BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (host_user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_reservation_guests_reservation_id ON reservation_guests(reservation_id);
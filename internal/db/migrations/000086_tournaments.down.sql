@@ -0,0 +1,12 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_tournament_matches_reservation_id;
+DROP INDEX IF EXISTS idx_tournament_matches_tournament_id;
+DROP INDEX IF EXISTS idx_tournament_entrants_team_id;
+DROP INDEX IF EXISTS idx_tournaments_league_id;
+DROP INDEX IF EXISTS idx_tournaments_facility_id;
+DROP TABLE IF EXISTS tournament_matches;
+DROP TABLE IF EXISTS tournament_entrants;
+DROP TABLE IF EXISTS tournaments;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,76 @@
+PRAGMA foreign_keys = ON;
+
+------ TOURNAMENTS ------
+-- One-day elimination events played by a league's teams. Entrants are
+-- seeded when the tournament is created, either by hand or from the
+-- league standings.
+CREATE TABLE tournaments (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    league_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    format TEXT NOT NULL CHECK (format IN ('single_elimination', 'double_elimination')),
+    seeding TEXT NOT NULL CHECK (seeding IN ('manual', 'standings')),
+    status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'in_progress', 'completed')),
+    champion_team_id INTEGER,
+    created_by_user_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id),
+    FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE,
+    FOREIGN KEY (champion_team_id) REFERENCES league_teams(id) ON DELETE SET NULL,
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+
+CREATE TABLE tournament_entrants (
+    tournament_id INTEGER NOT NULL,
+    league_team_id INTEGER NOT NULL,
+    seed INTEGER NOT NULL CHECK (seed > 0),
+    PRIMARY KEY (tournament_id, league_team_id),
+    UNIQUE (tournament_id, seed),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (league_team_id) REFERENCES league_teams(id) ON DELETE RESTRICT
+);
+
+-- Every match of the bracket is created up front. Teams fill in as earlier
+-- matches finish: the winner moves to winner_to_match_id and, in double
+-- elimination, the loser drops to loser_to_match_id in the consolation
+-- bracket. A bye match never gets a second team; its one team advances
+-- without playing.
+CREATE TABLE tournament_matches (
+    id INTEGER PRIMARY KEY,
+    tournament_id INTEGER NOT NULL,
+    bracket TEXT NOT NULL CHECK (bracket IN ('winners', 'consolation', 'final')),
+    round_number INTEGER NOT NULL CHECK (round_number > 0),
+    position INTEGER NOT NULL CHECK (position > 0),
+    team_a_id INTEGER,
+    team_b_id INTEGER,
+    team_a_score INTEGER CHECK (team_a_score IS NULL OR team_a_score >= 0),
+    team_b_score INTEGER CHECK (team_b_score IS NULL OR team_b_score >= 0),
+    winner_team_id INTEGER,
+    winner_to_match_id INTEGER,
+    winner_to_slot TEXT CHECK (winner_to_slot IS NULL OR winner_to_slot IN ('a', 'b')),
+    loser_to_match_id INTEGER,
+    loser_to_slot TEXT CHECK (loser_to_slot IS NULL OR loser_to_slot IN ('a', 'b')),
+    status TEXT NOT NULL CHECK (status IN ('pending', 'ready', 'completed', 'bye')),
+    court_id INTEGER,
+    scheduled_time DATETIME,
+    reservation_id INTEGER,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tournament_id, bracket, round_number, position),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (team_a_id) REFERENCES league_teams(id) ON DELETE RESTRICT,
+    FOREIGN KEY (team_b_id) REFERENCES league_teams(id) ON DELETE RESTRICT,
+    FOREIGN KEY (winner_team_id) REFERENCES league_teams(id) ON DELETE RESTRICT,
+    FOREIGN KEY (winner_to_match_id) REFERENCES tournament_matches(id) ON DELETE CASCADE,
+    FOREIGN KEY (loser_to_match_id) REFERENCES tournament_matches(id) ON DELETE CASCADE,
+    FOREIGN KEY (court_id) REFERENCES courts(id) ON DELETE SET NULL,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_tournaments_facility_id ON tournaments(facility_id);
+CREATE INDEX idx_tournaments_league_id ON tournaments(league_id);
+CREATE INDEX idx_tournament_entrants_team_id ON tournament_entrants(league_team_id);
+CREATE INDEX idx_tournament_matches_tournament_id ON tournament_matches(tournament_id);
+CREATE INDEX idx_tournament_matches_reservation_id ON tournament_matches(reservation_id);
@@ -0,0 +1,11 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_household_links_primary_status;
+DROP INDEX IF EXISTS idx_household_links_pending;
+DROP INDEX IF EXISTS idx_household_links_active_dependent;
+DROP TABLE IF EXISTS household_links;
+
+ALTER TABLE facilities
+DROP COLUMN reservation_limit_scope;
+
+PRAGMA foreign_keys = ON;
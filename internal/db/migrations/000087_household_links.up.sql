@@ -0,0 +1,35 @@
+PRAGMA foreign_keys = ON;
+
+------ HOUSEHOLD LINKS ------
+-- Whether max_member_reservations caps each member or a whole household.
+ALTER TABLE facilities
+    ADD COLUMN reservation_limit_scope TEXT NOT NULL DEFAULT 'person' CHECK (reservation_limit_scope IN ('person', 'household'));
+
+-- A primary account and a dependent it books and cancels for. Minors are
+-- linked straight away; an adult dependent must accept the emailed token link
+-- before expires_at, so a pending row past expires_at has lapsed.
+CREATE TABLE household_links (
+    id INTEGER PRIMARY KEY,
+    primary_user_id INTEGER NOT NULL,
+    dependent_user_id INTEGER NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'active', 'declined', 'removed')),
+    expires_at DATETIME NOT NULL,
+    responded_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (primary_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (dependent_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CHECK (primary_user_id <> dependent_user_id)
+);
+
+-- A dependent belongs to at most one household.
+CREATE UNIQUE INDEX idx_household_links_active_dependent
+    ON household_links(dependent_user_id)
+    WHERE status = 'active';
+
+-- At most one open request per primary and dependent.
+CREATE UNIQUE INDEX idx_household_links_pending
+    ON household_links(primary_user_id, dependent_user_id)
+    WHERE status = 'pending';
+
+CREATE INDEX idx_household_links_primary_status ON household_links(primary_user_id, status);
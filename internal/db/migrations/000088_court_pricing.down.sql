@@ -0,0 +1,10 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE reservation_cancellations
+DROP COLUMN refund_amount_cents;
+
+DROP TABLE IF EXISTS reservation_prices;
+DROP INDEX IF EXISTS idx_court_pricing_rules_facility_day;
+DROP TABLE IF EXISTS court_pricing_rules;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,36 @@
+PRAGMA foreign_keys = ON;
+
+------ COURT PRICING ------
+-- Weekly windows with an hourly court price, one rate for members and one
+-- for everyone else. Where windows overlap, the higher rate applies; time no
+-- window covers is free.
+CREATE TABLE court_pricing_rules (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),  -- 0 = Sunday
+    start_time TEXT NOT NULL,  -- HH:MM in facility time
+    end_time TEXT NOT NULL,    -- HH:MM in facility time
+    member_price_per_hour_cents INTEGER NOT NULL CHECK (member_price_per_hour_cents >= 0),
+    non_member_price_per_hour_cents INTEGER NOT NULL CHECK (non_member_price_per_hour_cents >= 0),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (start_time < end_time),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_court_pricing_rules_facility_day ON court_pricing_rules(facility_id, day_of_week);
+
+-- The price of a court booking, fixed when it was made. member_rate records
+-- which of the rule's two rates applied.
+CREATE TABLE reservation_prices (
+    reservation_id INTEGER PRIMARY KEY,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents >= 0),
+    member_rate BOOLEAN NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE
+);
+
+-- What a priced reservation's cancellation refunds: its price times
+-- refund_percentage_applied. NULL for reservations without a price.
+ALTER TABLE reservation_cancellations
+    ADD COLUMN refund_amount_cents INTEGER CHECK (refund_amount_cents >= 0);
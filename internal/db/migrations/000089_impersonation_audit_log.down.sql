@@ -0,0 +1,7 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_impersonation_audit_log_member;
+DROP INDEX IF EXISTS idx_impersonation_audit_log_impersonator;
+DROP TABLE IF EXISTS impersonation_audit_log;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,22 @@
+PRAGMA foreign_keys = ON;
+
+------ IMPERSONATION AUDIT LOG ------
+-- Staff sessions acting as a member. Every row names both identities:
+-- 'start' and 'end' bracket the session and 'request' records each change
+-- made while impersonating, with its response status.
+CREATE TABLE impersonation_audit_log (
+    id INTEGER PRIMARY KEY,
+    impersonator_user_id INTEGER NOT NULL,
+    member_user_id INTEGER NOT NULL,
+    action TEXT NOT NULL,
+    method TEXT,
+    path TEXT,
+    status INTEGER,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (action IN ('start', 'end', 'request')),
+    FOREIGN KEY (impersonator_user_id) REFERENCES users(id),
+    FOREIGN KEY (member_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_impersonation_audit_log_impersonator ON impersonation_audit_log(impersonator_user_id, created_at);
+CREATE INDEX idx_impersonation_audit_log_member ON impersonation_audit_log(member_user_id, created_at);
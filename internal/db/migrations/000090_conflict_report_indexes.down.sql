@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_open_play_sessions_facility_id_start_time;
+DROP INDEX IF EXISTS idx_reservations_open_play_rule_id_start_time;
+DROP INDEX IF EXISTS idx_reservation_courts_court_id;
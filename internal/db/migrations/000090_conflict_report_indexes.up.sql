@@ -0,0 +1,5 @@
+-- Support the conflict report: finding other bookings on a court, and the
+-- reservations backing an open play session.
+CREATE INDEX IF NOT EXISTS idx_reservation_courts_court_id ON reservation_courts(court_id, reservation_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_open_play_rule_id_start_time ON reservations(open_play_rule_id, start_time);
+CREATE INDEX IF NOT EXISTS idx_open_play_sessions_facility_id_start_time ON open_play_sessions(facility_id, start_time);
@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_user_sessions_user;
+DROP TABLE IF EXISTS user_sessions;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,22 @@
+PRAGMA foreign_keys = ON;
+
+------ USER SESSIONS ------
+-- One row per sign-in, so members and staff can see where they are logged in
+-- and revoke a session. The token is carried in the session cookie; a row
+-- with revoked_at set no longer authenticates.
+CREATE TABLE user_sessions (
+    id INTEGER PRIMARY KEY,
+    token TEXT NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL,
+    session_type TEXT NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME,
+    CHECK (session_type IN ('staff', 'member')),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_user_sessions_user ON user_sessions(user_id, expires_at);
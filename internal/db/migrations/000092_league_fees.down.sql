@@ -0,0 +1,11 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_league_payments_team;
+DROP TABLE IF EXISTS league_payments;
+
+ALTER TABLE leagues
+DROP COLUMN fee_basis;
+ALTER TABLE leagues
+DROP COLUMN registration_fee_cents;
+
+PRAGMA foreign_keys = ON;
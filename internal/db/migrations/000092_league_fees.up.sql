@@ -0,0 +1,27 @@
+PRAGMA foreign_keys = ON;
+
+------ LEAGUE FEES ------
+-- registration_fee_cents is owed once per team or by each rostered player,
+-- as fee_basis says.
+ALTER TABLE leagues
+    ADD COLUMN registration_fee_cents INTEGER NOT NULL DEFAULT 0 CHECK (registration_fee_cents >= 0);
+ALTER TABLE leagues
+    ADD COLUMN fee_basis TEXT NOT NULL DEFAULT 'team' CHECK (fee_basis IN ('team', 'player'));
+
+-- Payments staff recorded against a team's fee. user_id names the player a
+-- payment covers in per-player leagues and is NULL for per-team fees.
+CREATE TABLE league_payments (
+    id INTEGER PRIMARY KEY,
+    league_team_id INTEGER NOT NULL,
+    user_id INTEGER,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+    method TEXT NOT NULL CHECK (method IN ('cash', 'card', 'check', 'other')),
+    note TEXT,
+    recorded_by_user_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (league_team_id) REFERENCES league_teams(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (recorded_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_league_payments_team ON league_payments(league_team_id);
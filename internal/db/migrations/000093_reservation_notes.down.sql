@@ -0,0 +1,7 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_reservation_comments_reservation;
+DROP TABLE IF EXISTS reservation_comments;
+DROP TABLE IF EXISTS reservation_member_notes;
+
+PRAGMA foreign_keys = ON;
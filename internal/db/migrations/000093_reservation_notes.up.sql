@@ -0,0 +1,24 @@
+PRAGMA foreign_keys = ON;
+
+------ RESERVATION NOTES ------
+-- The note a member leaves when booking. It is shown back to them and to
+-- staff.
+CREATE TABLE reservation_member_notes (
+    reservation_id INTEGER PRIMARY KEY,
+    note TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE
+);
+
+-- Staff-only comment thread on a reservation.
+CREATE TABLE reservation_comments (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    author_user_id INTEGER NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_reservation_comments_reservation ON reservation_comments(reservation_id, created_at);
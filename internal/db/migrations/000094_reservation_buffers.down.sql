@@ -0,0 +1,9 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE reservation_types
+DROP COLUMN buffer_minutes;
+
+ALTER TABLE facilities
+DROP COLUMN buffer_minutes;
+
+PRAGMA foreign_keys = ON;
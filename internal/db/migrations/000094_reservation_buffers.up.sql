@@ -0,0 +1,10 @@
+PRAGMA foreign_keys = ON;
+
+------ RESERVATION BUFFERS ------
+-- Minutes a court stays unbookable after each reservation, e.g. to squeegee
+-- it. A reservation type may override the facility default.
+ALTER TABLE facilities
+    ADD COLUMN buffer_minutes INTEGER NOT NULL DEFAULT 0 CHECK (buffer_minutes BETWEEN 0 AND 120);
+
+ALTER TABLE reservation_types
+    ADD COLUMN buffer_minutes INTEGER CHECK (buffer_minutes IS NULL OR buffer_minutes BETWEEN 0 AND 120);
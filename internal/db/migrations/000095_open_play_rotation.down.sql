@@ -0,0 +1,9 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TABLE IF EXISTS open_play_game_players;
+DROP INDEX IF EXISTS idx_open_play_games_session_id;
+DROP TABLE IF EXISTS open_play_games;
+DROP INDEX IF EXISTS idx_open_play_queue_entries_position;
+DROP TABLE IF EXISTS open_play_queue_entries;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,39 @@
+PRAGMA foreign_keys = ON;
+
+------ OPEN PLAY ROTATION ------
+-- The "next up" order of checked-in players at an open play session. A
+-- player joins at the back on check-in and returns to the back after each
+-- game, so the lowest queue_position is always up next.
+CREATE TABLE open_play_queue_entries (
+    id INTEGER PRIMARY KEY,
+    session_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    queue_position INTEGER NOT NULL,
+    games_played INTEGER NOT NULL DEFAULT 0,
+    joined_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (session_id) REFERENCES open_play_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (session_id, user_id)
+);
+
+CREATE INDEX idx_open_play_queue_entries_position ON open_play_queue_entries(session_id, queue_position);
+
+-- Games called off the queue and the players sent to the court.
+CREATE TABLE open_play_games (
+    id INTEGER PRIMARY KEY,
+    session_id INTEGER NOT NULL,
+    recorded_by_user_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (session_id) REFERENCES open_play_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (recorded_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_open_play_games_session_id ON open_play_games(session_id);
+
+CREATE TABLE open_play_game_players (
+    game_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    PRIMARY KEY (game_id, user_id),
+    FOREIGN KEY (game_id) REFERENCES open_play_games(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
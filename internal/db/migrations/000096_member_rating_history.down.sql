@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_member_rating_history_user_id;
+DROP TABLE IF EXISTS member_rating_history;
+
+PRAGMA foreign_keys = ON;
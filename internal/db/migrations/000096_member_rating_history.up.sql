@@ -0,0 +1,28 @@
+PRAGMA foreign_keys = ON;
+
+------ MEMBER RATING HISTORY ------
+-- Every rating a member reported or staff recorded. member_skill_ratings
+-- keeps the effective rating derived from it: the most recently verified
+-- entry, else the latest self-report.
+CREATE TABLE member_rating_history (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    rating REAL NOT NULL CHECK (rating BETWEEN 1.0 AND 8.0),
+    source TEXT NOT NULL CHECK (source IN ('self', 'dupr', 'utr', 'staff')),
+    verified BOOLEAN NOT NULL DEFAULT 0,
+    recorded_by_user_id INTEGER,    -- NULL for ratings carried over from before history was kept
+    verified_by_user_id INTEGER,
+    verified_at DATETIME,
+    note TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (recorded_by_user_id) REFERENCES users(id),
+    FOREIGN KEY (verified_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_member_rating_history_user_id ON member_rating_history(user_id, created_at);
+
+-- Ratings staff set before history was kept count as verified.
+INSERT INTO member_rating_history (user_id, rating, source, verified, verified_at, created_at)
+SELECT user_id, rating, 'staff', 1, updated_at, updated_at
+FROM member_skill_ratings;
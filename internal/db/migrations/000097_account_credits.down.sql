@@ -0,0 +1,9 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_account_credit_redemptions_reservation_id;
+DROP INDEX IF EXISTS idx_account_credit_redemptions_credit_id;
+DROP TABLE IF EXISTS account_credit_redemptions;
+DROP INDEX IF EXISTS idx_account_credits_user_facility;
+DROP TABLE IF EXISTS account_credits;
+
+PRAGMA foreign_keys = ON;
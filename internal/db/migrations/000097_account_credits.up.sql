@@ -0,0 +1,39 @@
+PRAGMA foreign_keys = ON;
+
+------ ACCOUNT CREDITS ------
+-- Credit a member holds at a facility, from a cancellation refund or issued
+-- by staff. Bookings that apply credit draw remaining_cents down, oldest
+-- credit first; expired credit no longer counts toward the balance.
+CREATE TABLE account_credits (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    facility_id INTEGER NOT NULL,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+    remaining_cents INTEGER NOT NULL CHECK (remaining_cents >= 0),
+    source_reservation_id INTEGER,  -- the cancelled reservation; NULL for manual credits
+    reason TEXT NOT NULL,
+    issued_by_user_id INTEGER NOT NULL,
+    expires_at DATETIME,  -- NULL never expires
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (remaining_cents <= amount_cents),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    FOREIGN KEY (source_reservation_id) REFERENCES reservations(id) ON DELETE SET NULL,
+    FOREIGN KEY (issued_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_account_credits_user_facility ON account_credits(user_id, facility_id, created_at);
+
+-- Credit applied toward a booking's price.
+CREATE TABLE account_credit_redemptions (
+    id INTEGER PRIMARY KEY,
+    credit_id INTEGER NOT NULL,
+    reservation_id INTEGER NOT NULL,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (credit_id) REFERENCES account_credits(id) ON DELETE CASCADE,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id)
+);
+
+CREATE INDEX idx_account_credit_redemptions_credit_id ON account_credit_redemptions(credit_id);
+CREATE INDEX idx_account_credit_redemptions_reservation_id ON account_credit_redemptions(reservation_id);
@@ -0,0 +1,9 @@
+PRAGMA foreign_keys = OFF;
+
+DROP INDEX IF EXISTS idx_email_delivery_events_email;
+DROP TABLE IF EXISTS email_delivery_events;
+
+ALTER TABLE users
+DROP COLUMN email_undeliverable;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,22 @@
+PRAGMA foreign_keys = ON;
+
+------ EMAIL DELIVERABILITY ------
+-- Set when SES reports a hard bounce or complaint for the member's address.
+-- Transactional email skips flagged users until staff clear it.
+ALTER TABLE users
+    ADD COLUMN email_undeliverable BOOLEAN NOT NULL DEFAULT 0;
+
+-- Bounce and complaint notifications received from SES through SNS.
+CREATE TABLE email_delivery_events (
+    id INTEGER PRIMARY KEY,
+    email TEXT NOT NULL,
+    event_type TEXT NOT NULL CHECK (event_type IN ('bounce', 'complaint')),
+    bounce_type TEXT,      -- 'Permanent', 'Transient' or 'Undetermined'
+    bounce_sub_type TEXT,
+    diagnostic TEXT,
+    sns_message_id TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (sns_message_id, email, event_type)
+);
+
+CREATE INDEX idx_email_delivery_events_email ON email_delivery_events(email, created_at);
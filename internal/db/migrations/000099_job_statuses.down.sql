@@ -0,0 +1,5 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TABLE IF EXISTS job_statuses;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,17 @@
+PRAGMA foreign_keys = ON;
+
+------ BACKGROUND JOBS ------
+-- Outcome of the latest run of each scheduled job, kept across restarts for
+-- the admin jobs dashboard and readiness checks.
+CREATE TABLE job_statuses (
+    name TEXT PRIMARY KEY,
+    last_trigger TEXT NOT NULL CHECK (last_trigger IN ('schedule', 'manual')),
+    last_started_at DATETIME NOT NULL,
+    last_finished_at DATETIME NOT NULL,
+    last_duration_ms INTEGER NOT NULL,
+    last_items INTEGER NOT NULL,
+    last_error TEXT,       -- NULL when the latest run succeeded
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    last_success_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
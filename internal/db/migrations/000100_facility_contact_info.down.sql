@@ -0,0 +1,9 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE facilities
+DROP COLUMN phone;
+
+ALTER TABLE facilities
+DROP COLUMN address;
+
+PRAGMA foreign_keys = ON;
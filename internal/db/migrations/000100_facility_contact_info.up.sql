@@ -0,0 +1,10 @@
+PRAGMA foreign_keys = ON;
+
+------ FACILITY CONTACT INFO ------
+-- Shown to members on the facility info page. Both are free text and
+-- optional.
+ALTER TABLE facilities
+    ADD COLUMN address TEXT;
+
+ALTER TABLE facilities
+    ADD COLUMN phone TEXT;
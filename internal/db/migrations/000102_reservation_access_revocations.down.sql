@@ -0,0 +1,5 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TABLE IF EXISTS reservation_access_revocations;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,13 @@
+PRAGMA foreign_keys = ON;
+
+------ RESERVATION ACCESS REVOCATIONS ------
+-- Reservations whose access QR codes staff have revoked. Gate verification
+-- denies every code for a listed reservation.
+CREATE TABLE reservation_access_revocations (
+    reservation_id INTEGER PRIMARY KEY,
+    revoked_by_user_id INTEGER NOT NULL,
+    reason TEXT,
+    revoked_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (revoked_by_user_id) REFERENCES users(id)
+);
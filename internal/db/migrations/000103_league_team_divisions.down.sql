@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE league_teams
+DROP COLUMN division;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,7 @@
+PRAGMA foreign_keys = ON;
+
+------ LEAGUE TEAM DIVISIONS ------
+-- The division a team plays in, by name from the league's division_config.
+-- NULL until divisions are assigned.
+ALTER TABLE league_teams
+    ADD COLUMN division TEXT;
@@ -0,0 +1,11 @@
+PRAGMA foreign_keys = OFF;
+
+DROP TABLE IF EXISTS reservation_duration_overrides;
+
+ALTER TABLE reservation_types
+DROP COLUMN max_duration_minutes;
+
+ALTER TABLE reservation_types
+DROP COLUMN min_duration_minutes;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,28 @@
+PRAGMA foreign_keys = ON;
+
+------ RESERVATION TYPE DURATIONS ------
+-- Bounds on how long a reservation of the type may run, e.g. two-hour
+-- clinics or one-hour ball machine slots. A NULL minimum falls back to the
+-- facility's minimum booking; a NULL maximum leaves the length unbounded.
+ALTER TABLE reservation_types
+    ADD COLUMN min_duration_minutes INTEGER CHECK (min_duration_minutes IS NULL OR min_duration_minutes > 0);
+
+ALTER TABLE reservation_types
+    ADD COLUMN max_duration_minutes INTEGER CHECK (max_duration_minutes IS NULL OR max_duration_minutes > 0);
+
+------ RESERVATION DURATION OVERRIDES ------
+-- Audit trail of staff saving a reservation whose length breaks its type's
+-- duration bounds, e.g. after the bounds were tightened.
+CREATE TABLE reservation_duration_overrides (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    duration_minutes INTEGER NOT NULL,
+    min_duration_minutes INTEGER NOT NULL,
+    max_duration_minutes INTEGER,   -- NULL: the type had no maximum
+    overridden_by_user_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (overridden_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_reservation_duration_overrides_reservation_id ON reservation_duration_overrides(reservation_id);
@@ -0,0 +1,5 @@
+ALTER TABLE waitlists
+DROP COLUMN use_visit_pack;
+
+ALTER TABLE waitlists
+DROP COLUMN auto_book;
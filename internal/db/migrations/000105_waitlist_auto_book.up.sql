@@ -0,0 +1,7 @@
+-- auto_book entries are booked straight into a freed slot instead of being
+-- offered it; use_visit_pack redeems a visit pack for that booking.
+ALTER TABLE waitlists
+ADD COLUMN auto_book BOOLEAN NOT NULL DEFAULT 0;
+
+ALTER TABLE waitlists
+ADD COLUMN use_visit_pack BOOLEAN NOT NULL DEFAULT 0;
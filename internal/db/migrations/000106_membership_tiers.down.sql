@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS organizations_seed_membership_tiers;
+
+DROP TABLE IF EXISTS membership_tiers;
@@ -0,0 +1,43 @@
+-- What each membership level gets within an organization. A member takes the
+-- tier with the highest level at or below their own. NULL booking_window_days
+-- and max_active_reservations fall back to the facility settings; a
+-- max_active_reservations of 0 means no limit.
+CREATE TABLE membership_tiers (
+    id INTEGER PRIMARY KEY,
+    organization_id INTEGER NOT NULL,
+    level INTEGER NOT NULL CHECK (level >= 0),
+    name TEXT NOT NULL,
+    booking_window_days INTEGER CHECK (booking_window_days >= 1 AND booking_window_days <= 364),
+    max_active_reservations INTEGER CHECK (max_active_reservations >= 0),
+    prime_time_access BOOLEAN NOT NULL DEFAULT 0,
+    guest_passes_per_month INTEGER NOT NULL DEFAULT 0 CHECK (guest_passes_per_month >= 0),
+    visit_packs BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    UNIQUE(organization_id, level)
+);
+
+-- Seed every organization with the tiers the hard-coded levels gave before:
+-- guests buy visit packs, members do not.
+INSERT INTO membership_tiers (organization_id, level, name, visit_packs)
+SELECT organizations.id, defaults.level, defaults.name, defaults.visit_packs
+FROM organizations
+CROSS JOIN (
+    SELECT 0 AS level, 'Unverified Guest' AS name, 1 AS visit_packs
+    UNION ALL SELECT 1, 'Verified Guest', 1
+    UNION ALL SELECT 2, 'Member', 0
+    UNION ALL SELECT 3, 'Member+', 0
+) AS defaults;
+
+-- New organizations start with the same default tiers.
+CREATE TRIGGER organizations_seed_membership_tiers
+AFTER INSERT ON organizations
+BEGIN
+    INSERT INTO membership_tiers (organization_id, level, name, visit_packs)
+    VALUES
+        (NEW.id, 0, 'Unverified Guest', 1),
+        (NEW.id, 1, 'Verified Guest', 1),
+        (NEW.id, 2, 'Member', 0),
+        (NEW.id, 3, 'Member+', 0);
+END;
@@ -0,0 +1,22 @@
+PRAGMA foreign_keys = ON;
+
+DROP INDEX IF EXISTS idx_court_slot_holds_expires_at;
+DROP INDEX IF EXISTS idx_court_slot_holds_court_time;
+DROP TABLE IF EXISTS court_slot_holds;
+
+CREATE TABLE court_slot_holds (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    court_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL UNIQUE,
+    start_time DATETIME NOT NULL,
+    end_time DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    FOREIGN KEY (court_id) REFERENCES courts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_court_slot_holds_court_time ON court_slot_holds(court_id, start_time, end_time);
+CREATE INDEX idx_court_slot_holds_expires_at ON court_slot_holds(expires_at);
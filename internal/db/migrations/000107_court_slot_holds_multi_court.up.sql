@@ -0,0 +1,27 @@
+PRAGMA foreign_keys = ON;
+
+------ COURT SLOT HOLDS ------
+-- A member booking several courts holds each of them, so a hold is now one
+-- row per member and court rather than one row per member. Holds last a few
+-- minutes, so the table is rebuilt without copying them.
+DROP INDEX IF EXISTS idx_court_slot_holds_expires_at;
+DROP INDEX IF EXISTS idx_court_slot_holds_court_time;
+DROP TABLE IF EXISTS court_slot_holds;
+
+CREATE TABLE court_slot_holds (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    court_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    start_time DATETIME NOT NULL,
+    end_time DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    FOREIGN KEY (court_id) REFERENCES courts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (user_id, court_id)
+);
+
+CREATE INDEX idx_court_slot_holds_court_time ON court_slot_holds(court_id, start_time, end_time);
+CREATE INDEX idx_court_slot_holds_expires_at ON court_slot_holds(expires_at);
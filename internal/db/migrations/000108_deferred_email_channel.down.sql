@@ -0,0 +1,5 @@
+DELETE FROM deferred_emails
+WHERE channel = 'sms';
+
+ALTER TABLE deferred_emails
+DROP COLUMN channel;
@@ -0,0 +1,4 @@
+-- SMS reminders share the quiet-hours queue with email; channel says how a
+-- queued row is delivered and recipient holds the phone number for texts.
+ALTER TABLE deferred_emails
+ADD COLUMN channel TEXT NOT NULL DEFAULT 'email' CHECK (channel IN ('email', 'sms'));
@@ -0,0 +1,8 @@
+// Package migrations embeds the schema migrations so the server and the
+// dbmigrate tool apply exactly the files built into the binary.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,77 @@
+-- internal/db/queries/account_credits.sql
+
+-- name: CreateAccountCredit :one
+INSERT INTO account_credits (
+    user_id,
+    facility_id,
+    amount_cents,
+    remaining_cents,
+    source_reservation_id,
+    reason,
+    issued_by_user_id,
+    expires_at
+) VALUES (
+    @user_id,
+    @facility_id,
+    @amount_cents,
+    @amount_cents,
+    @source_reservation_id,
+    @reason,
+    @issued_by_user_id,
+    @expires_at
+)
+RETURNING *;
+
+-- name: ListAccountCredits :many
+SELECT *
+FROM account_credits
+WHERE user_id = @user_id
+  AND facility_id = @facility_id
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListSpendableAccountCredits :many
+-- Unexpired credit with a balance left, oldest first, the order bookings
+-- use it in.
+SELECT *
+FROM account_credits
+WHERE user_id = @user_id
+  AND facility_id = @facility_id
+  AND remaining_cents > 0
+  AND (expires_at IS NULL OR expires_at > @comparison_time)
+ORDER BY created_at, id;
+
+-- name: ConsumeAccountCredit :execrows
+UPDATE account_credits
+SET remaining_cents = remaining_cents - @amount_cents
+WHERE id = @id
+  AND remaining_cents >= @amount_cents;
+
+-- name: CreateAccountCreditRedemption :one
+INSERT INTO account_credit_redemptions (
+    credit_id,
+    reservation_id,
+    amount_cents
+) VALUES (
+    @credit_id,
+    @reservation_id,
+    @amount_cents
+)
+RETURNING *;
+
+-- name: ListAccountCreditRedemptions :many
+SELECT
+    acr.id,
+    acr.credit_id,
+    acr.reservation_id,
+    acr.amount_cents,
+    acr.created_at
+FROM account_credit_redemptions acr
+JOIN account_credits ac ON ac.id = acr.credit_id
+WHERE ac.user_id = @user_id
+  AND ac.facility_id = @facility_id
+ORDER BY acr.created_at DESC, acr.id DESC;
+
+-- name: GetReservationCreditAppliedCents :one
+SELECT CAST(COALESCE(SUM(amount_cents), 0) AS INTEGER) AS applied_cents
+FROM account_credit_redemptions
+WHERE reservation_id = @reservation_id;
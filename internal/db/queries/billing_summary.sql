@@ -0,0 +1,63 @@
+-- name: ListBillingSummaryVisitPacks :many
+-- Active visit packs at the facility, named for the front desk summary.
+SELECT vp.id,
+    vpt.name AS pack_name,
+    vp.visits_remaining,
+    vp.purchase_date,
+    vp.expires_at
+FROM visit_packs vp
+JOIN visit_pack_types vpt ON vpt.id = vp.pack_type_id
+WHERE vp.user_id = @user_id
+  AND COALESCE(vp.facility_id, vpt.facility_id) = CAST(@facility_id AS INTEGER)
+  AND vp.status = 'active'
+  AND vp.visits_remaining > 0
+  AND vp.expires_at > @comparison_time
+ORDER BY vp.expires_at, vp.id;
+
+-- name: ListBillingSummaryLessonPackages :many
+-- Active lesson packages at the facility, named for the front desk summary.
+SELECT lp.id,
+    lpt.name AS package_name,
+    lp.lessons_remaining,
+    lp.purchase_date,
+    lp.expires_at
+FROM lesson_packages lp
+JOIN lesson_package_types lpt ON lpt.id = lp.pack_type_id
+WHERE lp.user_id = @user_id
+  AND lpt.facility_id = @facility_id
+  AND lp.status = 'active'
+  AND lp.lessons_remaining > 0
+  AND lp.expires_at > @comparison_time
+ORDER BY lp.expires_at, lp.id;
+
+-- name: ListBillingSummaryReservations :many
+-- Upcoming uncancelled reservations the user is primary on at the facility,
+-- with the stored price when the booking was priced and any credit applied.
+SELECT r.id,
+    r.start_time,
+    r.end_time,
+    rt.name AS reservation_type_name,
+    CAST((
+        SELECT COUNT(*)
+        FROM reservation_courts rc
+        WHERE rc.reservation_id = r.id
+    ) AS INTEGER) AS court_count,
+    rp.amount_cents AS price_cents,
+    rp.member_rate,
+    CAST((
+        SELECT COALESCE(SUM(acr.amount_cents), 0)
+        FROM account_credit_redemptions acr
+        WHERE acr.reservation_id = r.id
+    ) AS INTEGER) AS credit_applied_cents
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+LEFT JOIN reservation_prices rp ON rp.reservation_id = r.id
+WHERE r.primary_user_id = @primary_user_id
+  AND r.facility_id = @facility_id
+  AND r.start_time > @comparison_time
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY r.start_time, r.id;
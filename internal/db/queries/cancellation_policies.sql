@@ -36,6 +36,20 @@ ORDER BY
     reservation_type_id,
     min_hours_before DESC;
 
+-- name: ListCancellationPolicyTiersForFacilities :many
+SELECT
+    id,
+    facility_id,
+    reservation_type_id,
+    min_hours_before,
+    refund_percentage,
+    created_at,
+    updated_at
+FROM cancellation_policy_tiers
+-- Empty facility_ids intentionally yields zero rows (caller should prefilter).
+WHERE facility_id IN (sqlc.slice('facility_ids'))
+ORDER BY facility_id, min_hours_before DESC;
+
 -- name: GetCancellationPolicyTier :one
 SELECT
     id,
@@ -71,6 +85,13 @@ DELETE FROM cancellation_policy_tiers
 WHERE id = @id
   AND facility_id = @facility_id;
 
+-- name: DeleteCancellationPolicy :execrows
+-- Removes every tier of one policy: the facility default when
+-- reservation_type_id is NULL, otherwise that type's override.
+DELETE FROM cancellation_policy_tiers
+WHERE facility_id = @facility_id
+  AND reservation_type_id IS @reservation_type_id;
+
 -- name: GetApplicableCancellationTier :one
 SELECT
     id,
@@ -100,14 +121,16 @@ INSERT INTO reservation_cancellations (
     cancelled_at,
     refund_percentage_applied,
     fee_waived,
-    hours_before_start
+    hours_before_start,
+    refund_amount_cents
 ) VALUES (
     @reservation_id,
     @cancelled_by_user_id,
     @cancelled_at,
     @refund_percentage_applied,
     @fee_waived,
-    @hours_before_start
+    @hours_before_start,
+    @refund_amount_cents
 )
 RETURNING
     id,
@@ -117,7 +140,8 @@ RETURNING
     refund_percentage_applied,
     fee_waived,
     hours_before_start,
-    created_at;
+    created_at,
+    refund_amount_cents;
 
 -- name: GetLatestCancellationByReservationID :one
 SELECT
@@ -128,7 +152,8 @@ SELECT
     refund_percentage_applied,
     fee_waived,
     hours_before_start,
-    created_at
+    created_at,
+    refund_amount_cents
 FROM reservation_cancellations
 WHERE reservation_id = @reservation_id
 ORDER BY cancelled_at DESC
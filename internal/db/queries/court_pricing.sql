@@ -0,0 +1,57 @@
+-- internal/db/queries/court_pricing.sql
+
+-- name: ListCourtPricingRules :many
+SELECT * FROM court_pricing_rules
+WHERE facility_id = @facility_id
+ORDER BY day_of_week, start_time, id;
+
+-- name: CreateCourtPricingRule :one
+INSERT INTO court_pricing_rules (
+    facility_id,
+    day_of_week,
+    start_time,
+    end_time,
+    member_price_per_hour_cents,
+    non_member_price_per_hour_cents
+) VALUES (
+    @facility_id,
+    @day_of_week,
+    @start_time,
+    @end_time,
+    @member_price_per_hour_cents,
+    @non_member_price_per_hour_cents
+)
+RETURNING *;
+
+-- name: UpdateCourtPricingRule :one
+UPDATE court_pricing_rules
+SET day_of_week = @day_of_week,
+    start_time = @start_time,
+    end_time = @end_time,
+    member_price_per_hour_cents = @member_price_per_hour_cents,
+    non_member_price_per_hour_cents = @non_member_price_per_hour_cents,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND facility_id = @facility_id
+RETURNING *;
+
+-- name: DeleteCourtPricingRule :execrows
+DELETE FROM court_pricing_rules
+WHERE id = @id
+  AND facility_id = @facility_id;
+
+-- name: CreateReservationPrice :one
+INSERT INTO reservation_prices (
+    reservation_id,
+    amount_cents,
+    member_rate
+) VALUES (
+    @reservation_id,
+    @amount_cents,
+    @member_rate
+)
+RETURNING *;
+
+-- name: GetReservationPrice :one
+SELECT * FROM reservation_prices
+WHERE reservation_id = @reservation_id;
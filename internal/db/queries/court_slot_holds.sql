@@ -0,0 +1,30 @@
+-- internal/db/queries/court_slot_holds.sql
+
+-- name: CreateCourtSlotHold :one
+-- Records one court held by a member for a slot. A member may hold several
+-- courts for one slot; callers delete their holds on other slots first.
+INSERT INTO court_slot_holds (
+    facility_id, court_id, user_id, start_time, end_time, expires_at
+) VALUES (
+    @facility_id, @court_id, @user_id, @start_time, @end_time, @expires_at
+)
+RETURNING id, facility_id, court_id, user_id, start_time, end_time, expires_at, created_at;
+
+-- name: ListHeldCourtIDs :many
+-- Courts with an unexpired hold overlapping the range by anyone other than
+-- holder_user_id.
+SELECT DISTINCT court_id
+FROM court_slot_holds
+WHERE facility_id = @facility_id
+  AND user_id != @holder_user_id
+  AND expires_at > @now
+  AND start_time < @end_time
+  AND end_time > @start_time;
+
+-- name: DeleteCourtSlotHoldByUser :execrows
+DELETE FROM court_slot_holds
+WHERE user_id = @user_id;
+
+-- name: DeleteExpiredCourtSlotHolds :execrows
+DELETE FROM court_slot_holds
+WHERE expires_at <= @now;
@@ -8,6 +8,17 @@ SELECT * FROM courts
 WHERE facility_id = ?
 ORDER BY court_number;
 
+-- name: ListActiveCourts :many
+SELECT * FROM courts
+WHERE facility_id = ?
+  AND status = 'active'
+ORDER BY court_number;
+
+-- name: CountActiveCourts :one
+SELECT COUNT(*) FROM courts
+WHERE facility_id = ?
+  AND status = 'active';
+
 -- name: CreateCourt :one
 INSERT INTO courts (
     facility_id, name, court_number, status
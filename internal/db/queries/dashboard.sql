@@ -139,3 +139,209 @@ WHERE (@facility_id = 0 OR r.facility_id = @facility_id)
   )
 GROUP BY reservation_status
 ORDER BY reservation_status;
+
+-- name: ListNoShowReservationsInRange :many
+-- Finished reservations that had someone booked but nobody checked in.
+SELECT r.id,
+    r.facility_id,
+    r.start_time,
+    r.end_time,
+    rt.name AS reservation_type_name,
+    u.first_name AS primary_first_name,
+    u.last_name AS primary_last_name
+FROM reservations r
+LEFT JOIN reservation_types rt ON rt.id = r.reservation_type_id
+LEFT JOIN users u ON u.id = r.primary_user_id
+WHERE (@facility_id = 0 OR r.facility_id = @facility_id)
+  AND r.start_time >= @start_time
+  AND r.start_time < @end_time
+  AND r.end_time < @comparison_time
+  AND (
+        r.primary_user_id IS NOT NULL
+     OR EXISTS (
+         SELECT 1
+         FROM reservation_participants rp
+         WHERE rp.reservation_id = r.id
+     )
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_checkins rci
+      WHERE rci.reservation_id = r.id
+  )
+ORDER BY r.start_time DESC, r.id DESC;
+
+-- name: ListCourtBookingsInRange :many
+-- One row per reserved court, so multi-court reservations count on each.
+SELECT rc.court_id,
+    r.start_time,
+    r.end_time,
+    rt.name AS reservation_type_name
+FROM reservation_courts rc
+JOIN reservations r ON r.id = rc.reservation_id
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+WHERE r.facility_id = @facility_id
+  AND r.start_time < @end_time
+  AND r.end_time > @start_time
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY rc.court_id, r.start_time;
+
+-- name: CountReservationTypeNamesInRange :many
+SELECT rt.name AS reservation_type_name,
+    COUNT(*) AS reservation_count
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+WHERE r.facility_id = @facility_id
+  AND r.start_time < @end_time
+  AND r.end_time > @start_time
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+GROUP BY rt.name
+ORDER BY rt.name;
+
+-- name: ListOpenPlaySessionFillInRange :many
+SELECT ops.id,
+    ops.start_time,
+    ops.end_time,
+    ops.status,
+    ops.current_court_count,
+    opr.name AS rule_name,
+    opr.min_participants,
+    opr.min_courts,
+    opr.max_participants_per_court,
+    (
+        SELECT COUNT(*)
+        FROM reservation_participants rp
+        JOIN reservations r ON r.id = rp.reservation_id
+        JOIN reservation_types rt ON rt.id = r.reservation_type_id
+        WHERE r.facility_id = ops.facility_id
+          AND r.open_play_rule_id = ops.open_play_rule_id
+          AND r.start_time = ops.start_time
+          AND r.end_time = ops.end_time
+          AND rt.name = 'OPEN_PLAY'
+    ) AS participant_count
+FROM open_play_sessions ops
+JOIN open_play_rules opr ON opr.id = ops.open_play_rule_id
+WHERE ops.facility_id = @facility_id
+  AND ops.status != 'cancelled'
+  AND ops.start_time < @end_time
+  AND ops.end_time > @start_time
+ORDER BY ops.start_time, ops.id;
+
+-- name: CountProSessionsByProInRange :many
+SELECT s.id AS pro_id,
+    s.first_name,
+    s.last_name,
+    COUNT(*) AS lesson_count
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+JOIN staff s ON s.id = r.pro_id
+WHERE r.facility_id = @facility_id
+  AND rt.name = 'PRO_SESSION'
+  AND r.start_time < @end_time
+  AND r.end_time > @start_time
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+GROUP BY s.id, s.first_name, s.last_name
+ORDER BY lesson_count DESC, s.last_name, s.first_name;
+
+-- name: ListPendingWaitlistOffersForFacility :many
+SELECT wo.id,
+    wo.expires_at,
+    w.target_date,
+    w.target_start_time,
+    w.target_end_time,
+    u.first_name,
+    u.last_name
+FROM waitlist_offers wo
+JOIN waitlists w ON w.id = wo.waitlist_id
+JOIN users u ON u.id = w.user_id
+WHERE w.facility_id = @facility_id
+  AND wo.status = 'pending'
+  AND wo.expires_at > @comparison_time
+ORDER BY wo.expires_at, wo.id;
+
+-- name: ListMaintenanceCourtsInRange :many
+-- Courts taken out of service, either by a MAINTENANCE block in the range or
+-- by a court status other than active.
+SELECT c.id AS court_id,
+    c.name,
+    c.court_number,
+    c.status,
+    r.start_time,
+    r.end_time
+FROM courts c
+LEFT JOIN reservation_courts rc ON rc.court_id = c.id
+    AND rc.reservation_id IN (
+        SELECT r2.id
+        FROM reservations r2
+        JOIN reservation_types rt ON rt.id = r2.reservation_type_id
+        WHERE r2.facility_id = @facility_id
+          AND rt.name = 'MAINTENANCE'
+          AND r2.start_time < @end_time
+          AND r2.end_time > @start_time
+          AND NOT EXISTS (
+              SELECT 1
+              FROM reservation_cancellations rcc
+              WHERE rcc.reservation_id = r2.id
+          )
+    )
+LEFT JOIN reservations r ON r.id = rc.reservation_id
+WHERE c.facility_id = @facility_id
+  AND (c.status != 'active' OR r.id IS NOT NULL)
+ORDER BY c.court_number, r.start_time;
+
+-- name: CountBusyCourtsByHour :many
+-- One row per hour from range_start up to range_end with how many active
+-- courts are reserved for any part of it. Each reservation holds its court
+-- through the turnover buffer that follows it, and maintenance blocks count
+-- like any other reservation.
+WITH RECURSIVE slots(slot_index) AS (
+    SELECT 0
+    UNION ALL
+    SELECT slot_index + 1
+    FROM slots
+    WHERE julianday(@range_start, '+' || (slot_index + 1) || ' hours') < julianday(@range_end)
+),
+blocks AS (
+    SELECT rc.court_id,
+        julianday(r.start_time) AS block_start,
+        julianday(r.end_time, '+' || COALESCE(rt.buffer_minutes, f.buffer_minutes) || ' minutes') AS block_end
+    FROM reservation_courts rc
+    JOIN reservations r ON r.id = rc.reservation_id
+    JOIN reservation_types rt ON rt.id = r.reservation_type_id
+    JOIN facilities f ON f.id = r.facility_id
+    JOIN courts c ON c.id = rc.court_id
+    WHERE r.facility_id = @facility_id
+      AND c.status = 'active'
+      AND r.start_time < @range_end
+      AND julianday(r.end_time, '+' || COALESCE(rt.buffer_minutes, f.buffer_minutes) || ' minutes') > julianday(@range_start)
+      AND NOT EXISTS (
+          SELECT 1
+          FROM reservation_cancellations rcc
+          WHERE rcc.reservation_id = r.id
+      )
+)
+SELECT slots.slot_index,
+    COUNT(DISTINCT blocks.court_id) AS busy_courts
+FROM slots
+LEFT JOIN blocks
+    ON blocks.block_start < julianday(@range_start, '+' || (slots.slot_index + 1) || ' hours')
+    AND blocks.block_end > julianday(@range_start, '+' || slots.slot_index || ' hours')
+GROUP BY slots.slot_index
+ORDER BY slots.slot_index;
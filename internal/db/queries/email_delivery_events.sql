@@ -0,0 +1,41 @@
+-- internal/db/queries/email_delivery_events.sql
+
+-- name: CreateEmailDeliveryEvent :execrows
+-- SNS redelivers notifications, so a repeat of the same message is ignored.
+INSERT INTO email_delivery_events (
+    email,
+    event_type,
+    bounce_type,
+    bounce_sub_type,
+    diagnostic,
+    sns_message_id
+) VALUES (
+    @email,
+    @event_type,
+    @bounce_type,
+    @bounce_sub_type,
+    @diagnostic,
+    @sns_message_id
+)
+ON CONFLICT (sns_message_id, email, event_type) DO NOTHING;
+
+-- name: ListEmailDeliveryEvents :many
+SELECT *
+FROM email_delivery_events
+WHERE email = LOWER(@email)
+ORDER BY created_at DESC, id DESC
+LIMIT @limit;
+
+-- name: MarkEmailUndeliverable :execrows
+UPDATE users
+SET email_undeliverable = 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE LOWER(email) = LOWER(@email)
+  AND email_undeliverable = 0;
+
+-- name: ClearUserEmailUndeliverable :execrows
+UPDATE users
+SET email_undeliverable = 0,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND email_undeliverable = 1;
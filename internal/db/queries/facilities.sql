@@ -11,14 +11,50 @@ SELECT
     email_from_address,
     max_advance_booking_days,
     max_member_reservations,
+    max_courts_per_member_booking,
     lesson_min_notice_hours,
     reminder_hours_before,
     tier_booking_enabled,
     created_at,
-    updated_at
+    updated_at,
+    slot_duration_minutes,
+    min_booking_minutes,
+    max_guests_per_reservation,
+    reservation_limit_scope,
+    buffer_minutes,
+    address,
+    phone
 FROM facilities
 ORDER BY name;
 
+-- name: ListFacilitiesByOrganization :many
+SELECT
+    id,
+    organization_id,
+    name,
+    slug,
+    timezone,
+    active_theme_id,
+    email_from_address,
+    max_advance_booking_days,
+    max_member_reservations,
+    max_courts_per_member_booking,
+    lesson_min_notice_hours,
+    reminder_hours_before,
+    tier_booking_enabled,
+    created_at,
+    updated_at,
+    slot_duration_minutes,
+    min_booking_minutes,
+    max_guests_per_reservation,
+    reservation_limit_scope,
+    buffer_minutes,
+    address,
+    phone
+FROM facilities
+WHERE organization_id = @organization_id
+ORDER BY name;
+
 -- name: GetFacilityByID :one
 SELECT
     id,
@@ -30,11 +66,19 @@ SELECT
     email_from_address,
     max_advance_booking_days,
     max_member_reservations,
+    max_courts_per_member_booking,
     lesson_min_notice_hours,
     reminder_hours_before,
     tier_booking_enabled,
     created_at,
-    updated_at
+    updated_at,
+    slot_duration_minutes,
+    min_booking_minutes,
+    max_guests_per_reservation,
+    reservation_limit_scope,
+    buffer_minutes,
+    address,
+    phone
 FROM facilities
 WHERE id = ?;
 
@@ -42,7 +86,13 @@ WHERE id = ?;
 UPDATE facilities
 SET max_advance_booking_days = @max_advance_booking_days,
     max_member_reservations = @max_member_reservations,
+    max_courts_per_member_booking = @max_courts_per_member_booking,
     lesson_min_notice_hours = @lesson_min_notice_hours,
+    slot_duration_minutes = @slot_duration_minutes,
+    min_booking_minutes = @min_booking_minutes,
+    max_guests_per_reservation = @max_guests_per_reservation,
+    reservation_limit_scope = @reservation_limit_scope,
+    buffer_minutes = @buffer_minutes,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = @id
 RETURNING
@@ -55,11 +105,19 @@ RETURNING
     email_from_address,
     max_advance_booking_days,
     max_member_reservations,
+    max_courts_per_member_booking,
     lesson_min_notice_hours,
     reminder_hours_before,
     tier_booking_enabled,
     created_at,
-    updated_at;
+    updated_at,
+    slot_duration_minutes,
+    min_booking_minutes,
+    max_guests_per_reservation,
+    reservation_limit_scope,
+    buffer_minutes,
+    address,
+    phone;
 
 -- name: GetFacilityEmailConfig :one
 SELECT id, email_from_address, reminder_hours_before
@@ -0,0 +1,63 @@
+-- internal/db/queries/facility_announcements.sql
+
+-- name: CreateFacilityAnnouncement :one
+INSERT INTO facility_announcements (
+    facility_id, title, body, severity, audience, starts_at, ends_at, created_by_user_id
+) VALUES (
+    @facility_id, @title, @body, @severity, @audience, @starts_at, @ends_at, @created_by_user_id
+)
+RETURNING id, facility_id, title, body, severity, audience, starts_at, ends_at, created_by_user_id, created_at, updated_at;
+
+-- name: GetFacilityAnnouncement :one
+SELECT id, facility_id, title, body, severity, audience, starts_at, ends_at, created_by_user_id, created_at, updated_at
+FROM facility_announcements
+WHERE id = @id
+  AND facility_id = @facility_id;
+
+-- name: ListFacilityAnnouncements :many
+SELECT id, facility_id, title, body, severity, audience, starts_at, ends_at, created_by_user_id, created_at, updated_at
+FROM facility_announcements
+WHERE facility_id = @facility_id
+ORDER BY starts_at DESC, id DESC;
+
+-- name: ListActiveFacilityAnnouncements :many
+-- Announcements showing now to audience (members or staff) that user_id has
+-- not dismissed, most severe first.
+SELECT a.id, a.facility_id, a.title, a.body, a.severity, a.audience, a.starts_at, a.ends_at, a.created_by_user_id, a.created_at, a.updated_at
+FROM facility_announcements a
+WHERE a.facility_id = @facility_id
+  AND a.starts_at <= @now
+  AND a.ends_at > @now
+  AND a.audience IN (@audience, 'both')
+  AND NOT EXISTS (
+      SELECT 1
+      FROM facility_announcement_dismissals d
+      WHERE d.announcement_id = a.id
+        AND d.user_id = @user_id
+  )
+ORDER BY CASE a.severity WHEN 'critical' THEN 0 WHEN 'warning' THEN 1 ELSE 2 END,
+    a.starts_at DESC,
+    a.id DESC;
+
+-- name: UpdateFacilityAnnouncement :one
+UPDATE facility_announcements
+SET title = @title,
+    body = @body,
+    severity = @severity,
+    audience = @audience,
+    starts_at = @starts_at,
+    ends_at = @ends_at,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND facility_id = @facility_id
+RETURNING id, facility_id, title, body, severity, audience, starts_at, ends_at, created_by_user_id, created_at, updated_at;
+
+-- name: DeleteFacilityAnnouncement :execrows
+DELETE FROM facility_announcements
+WHERE id = @id
+  AND facility_id = @facility_id;
+
+-- name: DismissFacilityAnnouncement :exec
+INSERT INTO facility_announcement_dismissals (announcement_id, user_id)
+VALUES (@announcement_id, @user_id)
+ON CONFLICT(announcement_id, user_id) DO NOTHING;
@@ -68,6 +68,11 @@ LEFT JOIN open_play_sessions ops
 WHERE r.facility_id = @facility_id
   AND r.start_time >= @today_start
   AND r.start_time < @today_end
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
   AND (
     (rt.name = 'OPEN_PLAY' AND rp.user_id = @user_id AND ops.id IS NOT NULL)
     OR (rt.name != 'OPEN_PLAY' AND (r.primary_user_id = @user_id OR rp.user_id = @user_id))
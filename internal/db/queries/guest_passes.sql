@@ -0,0 +1,60 @@
+-- internal/db/queries/guest_passes.sql
+
+-- name: GetMemberGuestPassBalance :one
+SELECT balance
+FROM member_guest_passes
+WHERE user_id = @user_id
+  AND facility_id = @facility_id;
+
+-- name: GrantMemberGuestPasses :one
+INSERT INTO member_guest_passes (
+    user_id,
+    facility_id,
+    balance
+) VALUES (
+    @user_id,
+    @facility_id,
+    @passes
+)
+ON CONFLICT(user_id, facility_id) DO UPDATE
+SET balance = member_guest_passes.balance + excluded.balance,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING user_id, facility_id, balance, created_at, updated_at;
+
+-- name: ConsumeMemberGuestPass :execrows
+-- Zero rows means the member has no guest passes left.
+UPDATE member_guest_passes
+SET balance = balance - 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = @user_id
+  AND facility_id = @facility_id
+  AND balance > 0;
+
+-- name: AddReservationGuest :one
+INSERT INTO reservation_guests (
+    reservation_id,
+    host_user_id,
+    name,
+    email,
+    is_drop_in
+) VALUES (
+    @reservation_id,
+    @host_user_id,
+    @name,
+    @email,
+    @is_drop_in
+)
+RETURNING id, reservation_id, host_user_id, name, email, is_drop_in, created_at;
+
+-- name: ListReservationGuests :many
+SELECT id, reservation_id, host_user_id, name, email, is_drop_in, created_at
+FROM reservation_guests
+WHERE reservation_id = @reservation_id
+ORDER BY id;
+
+-- name: ListReservationGuestsForReservations :many
+-- Empty reservation_ids intentionally yields zero rows (caller should prefilter).
+SELECT id, reservation_id, host_user_id, name, email, is_drop_in, created_at
+FROM reservation_guests
+WHERE reservation_id IN (sqlc.slice('reservation_ids'))
+ORDER BY reservation_id, id;
@@ -0,0 +1,111 @@
+-- internal/db/queries/households.sql
+
+-- name: CreateHouseholdLink :one
+INSERT INTO household_links (
+    primary_user_id,
+    dependent_user_id,
+    token,
+    status,
+    expires_at,
+    responded_at
+) VALUES (
+    @primary_user_id,
+    @dependent_user_id,
+    @token,
+    @status,
+    @expires_at,
+    @responded_at
+)
+RETURNING *;
+
+-- name: GetHouseholdLink :one
+SELECT *
+FROM household_links
+WHERE id = ?;
+
+-- name: GetHouseholdLinkByToken :one
+SELECT *
+FROM household_links
+WHERE token = ?;
+
+-- name: GetActiveHouseholdLinkByDependent :one
+SELECT *
+FROM household_links
+WHERE dependent_user_id = ?
+  AND status = 'active';
+
+-- name: CountActiveHouseholdDependents :one
+SELECT COUNT(*)
+FROM household_links
+WHERE primary_user_id = ?
+  AND status = 'active';
+
+-- name: ListHouseholdLinksForUser :many
+-- Pending and active links on either side of the user, with both names.
+SELECT
+    hl.id,
+    hl.primary_user_id,
+    hl.dependent_user_id,
+    hl.status,
+    hl.expires_at,
+    hl.created_at,
+    p.first_name AS primary_first_name,
+    p.last_name AS primary_last_name,
+    d.first_name AS dependent_first_name,
+    d.last_name AS dependent_last_name
+FROM household_links hl
+JOIN users p ON p.id = hl.primary_user_id
+JOIN users d ON d.id = hl.dependent_user_id
+WHERE (hl.primary_user_id = @user_id OR hl.dependent_user_id = @user_id)
+  AND hl.status IN ('pending', 'active')
+ORDER BY hl.status, d.first_name, d.last_name, hl.id;
+
+-- name: ListActiveHouseholdDependents :many
+SELECT u.id, u.first_name, u.last_name
+FROM household_links hl
+JOIN users u ON u.id = hl.dependent_user_id
+WHERE hl.primary_user_id = ?
+  AND hl.status = 'active'
+ORDER BY u.first_name, u.last_name, u.id;
+
+-- name: ListHouseholdUserIDs :many
+-- The user plus everyone in their household: the primary account and each of
+-- its active dependents. A user in no household gets just their own ID.
+SELECT u.id
+FROM users u
+WHERE u.id = @user_id
+   OR u.id IN (
+       SELECT hl.primary_user_id
+       FROM household_links hl
+       WHERE hl.dependent_user_id = @user_id
+         AND hl.status = 'active'
+   )
+   OR u.id IN (
+       SELECT hl.dependent_user_id
+       FROM household_links hl
+       WHERE hl.status = 'active'
+         AND (
+             hl.primary_user_id = @user_id
+             OR hl.primary_user_id IN (
+                 SELECT parent.primary_user_id
+                 FROM household_links parent
+                 WHERE parent.dependent_user_id = @user_id
+                   AND parent.status = 'active'
+             )
+         )
+   )
+ORDER BY u.id;
+
+-- name: RespondToHouseholdLink :execrows
+UPDATE household_links
+SET status = @status,
+    responded_at = @responded_at
+WHERE id = @id
+  AND status = 'pending';
+
+-- name: RemoveHouseholdLink :execrows
+UPDATE household_links
+SET status = 'removed',
+    responded_at = @responded_at
+WHERE id = @id
+  AND status IN ('pending', 'active');
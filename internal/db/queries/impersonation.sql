@@ -0,0 +1,18 @@
+-- internal/db/queries/impersonation.sql
+
+-- name: CreateImpersonationAuditEntry :exec
+INSERT INTO impersonation_audit_log (
+    impersonator_user_id,
+    member_user_id,
+    action,
+    method,
+    path,
+    status
+) VALUES (
+    @impersonator_user_id,
+    @member_user_id,
+    @action,
+    @method,
+    @path,
+    @status
+);
@@ -0,0 +1,45 @@
+-- internal/db/queries/job_statuses.sql
+
+-- name: ListJobStatuses :many
+SELECT *
+FROM job_statuses
+ORDER BY name;
+
+-- name: RecordJobRun :one
+-- Pass consecutive_failures as 1 for a failed run and 0 otherwise; failures
+-- add up across runs until one succeeds.
+INSERT INTO job_statuses (
+    name,
+    last_trigger,
+    last_started_at,
+    last_finished_at,
+    last_duration_ms,
+    last_items,
+    last_error,
+    consecutive_failures,
+    last_success_at
+) VALUES (
+    @name,
+    @last_trigger,
+    @last_started_at,
+    @last_finished_at,
+    @last_duration_ms,
+    @last_items,
+    @last_error,
+    @consecutive_failures,
+    @last_success_at
+)
+ON CONFLICT (name) DO UPDATE SET
+    last_trigger = excluded.last_trigger,
+    last_started_at = excluded.last_started_at,
+    last_finished_at = excluded.last_finished_at,
+    last_duration_ms = excluded.last_duration_ms,
+    last_items = excluded.last_items,
+    last_error = excluded.last_error,
+    consecutive_failures = CASE
+        WHEN excluded.last_error IS NULL THEN 0
+        ELSE job_statuses.consecutive_failures + excluded.consecutive_failures
+    END,
+    last_success_at = COALESCE(excluded.last_success_at, job_statuses.last_success_at),
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
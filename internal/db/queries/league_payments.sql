@@ -0,0 +1,52 @@
+-- internal/db/queries/league_payments.sql
+
+-- name: CreateLeaguePayment :one
+INSERT INTO league_payments (
+    league_team_id,
+    user_id,
+    amount_cents,
+    method,
+    note,
+    recorded_by_user_id
+) VALUES (
+    @league_team_id,
+    @user_id,
+    @amount_cents,
+    @method,
+    @note,
+    @recorded_by_user_id
+)
+RETURNING id, league_team_id, user_id, amount_cents, method, note, recorded_by_user_id, created_at;
+
+-- name: ListLeagueTeamPayments :many
+SELECT id, league_team_id, user_id, amount_cents, method, note, recorded_by_user_id, created_at
+FROM league_payments
+WHERE league_team_id = @league_team_id
+ORDER BY created_at, id;
+
+-- name: ListMemberLeagueTeams :many
+-- Active teams the user captains or plays on in leagues at the facility that
+-- are still registering or in season.
+SELECT lt.id AS team_id,
+    lt.name AS team_name,
+    lt.captain_user_id,
+    l.id AS league_id,
+    l.name AS league_name,
+    l.status AS league_status,
+    l.registration_fee_cents,
+    l.fee_basis
+FROM league_teams lt
+JOIN leagues l ON l.id = lt.league_id
+WHERE l.facility_id = @facility_id
+  AND l.status IN ('registration', 'active')
+  AND lt.status = 'active'
+  AND (
+    lt.captain_user_id = @user_id
+    OR EXISTS (
+        SELECT 1
+        FROM league_team_members ltm
+        WHERE ltm.league_team_id = lt.id
+          AND ltm.user_id = @user_id
+    )
+  )
+ORDER BY l.start_date, l.name, lt.name;
@@ -11,7 +11,9 @@ INSERT INTO leagues (
     min_team_size,
     max_team_size,
     roster_lock_date,
-    status
+    status,
+    registration_fee_cents,
+    fee_basis
 ) VALUES (
     @facility_id,
     @name,
@@ -22,20 +24,25 @@ INSERT INTO leagues (
     @min_team_size,
     @max_team_size,
     @roster_lock_date,
-    @status
+    @status,
+    @registration_fee_cents,
+    @fee_basis
 )
 RETURNING id, facility_id, name, format, start_date, end_date, division_config,
-    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at;
+    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at,
+    registration_fee_cents, fee_basis;
 
 -- name: GetLeague :one
 SELECT id, facility_id, name, format, start_date, end_date, division_config,
-    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at
+    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at,
+    registration_fee_cents, fee_basis
 FROM leagues
 WHERE id = @id;
 
 -- name: GetLeagueWithFacilityTimezone :one
 SELECT l.id, l.facility_id, l.name, l.format, l.start_date, l.end_date, l.division_config,
     l.min_team_size, l.max_team_size, l.roster_lock_date, l.status, l.created_at, l.updated_at,
+    l.registration_fee_cents, l.fee_basis,
     f.timezone AS facility_timezone
 FROM leagues l
 JOIN facilities f ON f.id = l.facility_id
@@ -43,7 +50,8 @@ WHERE l.id = @id;
 
 -- name: ListLeaguesByFacility :many
 SELECT id, facility_id, name, format, start_date, end_date, division_config,
-    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at
+    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at,
+    registration_fee_cents, fee_basis
 FROM leagues
 WHERE facility_id = @facility_id
 ORDER BY start_date DESC, name;
@@ -59,10 +67,13 @@ SET name = @name,
     max_team_size = @max_team_size,
     roster_lock_date = @roster_lock_date,
     status = @status,
+    registration_fee_cents = @registration_fee_cents,
+    fee_basis = @fee_basis,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = @id
 RETURNING id, facility_id, name, format, start_date, end_date, division_config,
-    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at;
+    min_team_size, max_team_size, roster_lock_date, status, created_at, updated_at,
+    registration_fee_cents, fee_basis;
 
 -- name: DeleteLeague :execrows
 DELETE FROM leagues
@@ -80,15 +91,15 @@ INSERT INTO league_teams (
     @captain_user_id,
     @status
 )
-RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at;
+RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at, division;
 
 -- name: GetLeagueTeam :one
-SELECT id, league_id, name, captain_user_id, status, created_at, updated_at
+SELECT id, league_id, name, captain_user_id, status, created_at, updated_at, division
 FROM league_teams
 WHERE id = @id;
 
 -- name: ListLeagueTeams :many
-SELECT id, league_id, name, captain_user_id, status, created_at, updated_at
+SELECT id, league_id, name, captain_user_id, status, created_at, updated_at, division
 FROM league_teams
 WHERE league_id = @league_id
 ORDER BY name;
@@ -120,7 +131,8 @@ INSERT INTO league_matches (
     scheduled_time,
     home_score,
     away_score,
-    status
+    status,
+    round_number
 ) VALUES (
     @league_id,
     @home_team_id,
@@ -129,10 +141,11 @@ INSERT INTO league_matches (
     @scheduled_time,
     @home_score,
     @away_score,
-    @status
+    @status,
+    @round_number
 )
 RETURNING id, league_id, home_team_id, away_team_id, reservation_id,
-    scheduled_time, home_score, away_score, status, created_at, updated_at;
+    scheduled_time, home_score, away_score, status, created_at, updated_at, round_number;
 
 -- name: UpdateMatchResult :one
 UPDATE league_matches
@@ -143,11 +156,11 @@ SET home_score = @home_score,
 WHERE id = @id
   AND league_id = @league_id
 RETURNING id, league_id, home_team_id, away_team_id, reservation_id,
-    scheduled_time, home_score, away_score, status, created_at, updated_at;
+    scheduled_time, home_score, away_score, status, created_at, updated_at, round_number;
 
 -- name: GetLeagueMatch :one
 SELECT id, league_id, home_team_id, away_team_id, reservation_id,
-    scheduled_time, home_score, away_score, status, created_at, updated_at
+    scheduled_time, home_score, away_score, status, created_at, updated_at, round_number
 FROM league_matches
 WHERE id = @id
   AND league_id = @league_id;
@@ -159,7 +172,8 @@ SELECT lt.id AS team_id,
     lm.home_team_id,
     lm.away_team_id,
     lm.home_score,
-    lm.away_score
+    lm.away_score,
+    lt.division
 FROM league_teams lt
 LEFT JOIN league_matches lm
     ON lm.league_id = lt.league_id
@@ -170,7 +184,7 @@ ORDER BY lt.name, lm.scheduled_time;
 
 -- name: ListLeagueMatches :many
 SELECT id, league_id, home_team_id, away_team_id, reservation_id,
-    scheduled_time, home_score, away_score, status, created_at, updated_at
+    scheduled_time, home_score, away_score, status, created_at, updated_at, round_number
 FROM league_matches
 WHERE league_id = @league_id
 ORDER BY scheduled_time;
@@ -187,6 +201,7 @@ SELECT lm.id,
     lm.status,
     lm.created_at,
     lm.updated_at,
+    lm.round_number,
     r.start_time,
     r.end_time,
     CASE
@@ -208,6 +223,7 @@ GROUP BY lm.id,
     lm.status,
     lm.created_at,
     lm.updated_at,
+    lm.round_number,
     r.start_time,
     r.end_time
 ORDER BY lm.scheduled_time;
@@ -223,7 +239,7 @@ SET name = @name,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = @id
   AND league_id = @league_id
-RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at;
+RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at, division;
 
 -- name: UpdateTeamCaptain :one
 UPDATE league_teams
@@ -231,7 +247,16 @@ SET captain_user_id = @captain_user_id,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = @id
   AND league_id = @league_id
-RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at;
+RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at, division;
+
+-- name: UpdateLeagueTeamDivision :one
+-- A NULL division leaves the team unassigned.
+UPDATE league_teams
+SET division = @division,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND league_id = @league_id
+RETURNING id, league_id, name, captain_user_id, status, created_at, updated_at, division;
 
 -- name: RemoveTeamMember :execrows
 DELETE FROM league_team_members
@@ -253,6 +278,64 @@ WHERE lt.league_id = @league_id
   AND ltm.is_free_agent = 1
 ORDER BY u.last_name, u.first_name;
 
+-- name: ListSelfRegisteredFreeAgentsByLeague :many
+SELECT lfa.id,
+    lfa.user_id,
+    u.first_name,
+    u.last_name,
+    u.photo_url,
+    lfa.skill_level,
+    lfa.availability_notes,
+    lfa.gender,
+    lfa.created_at
+FROM league_free_agents lfa
+JOIN users u ON u.id = lfa.user_id
+WHERE lfa.league_id = @league_id
+  AND NOT EXISTS (
+    SELECT 1
+    FROM league_team_members ltm
+    JOIN league_teams lt ON lt.id = ltm.league_team_id
+    WHERE lt.league_id = lfa.league_id
+      AND ltm.user_id = lfa.user_id
+  )
+ORDER BY u.last_name, u.first_name;
+
+-- name: GetLeagueFreeAgentRegistration :one
+SELECT id, league_id, user_id, skill_level, availability_notes, gender, created_at
+FROM league_free_agents
+WHERE league_id = @league_id
+  AND user_id = @user_id;
+
+-- name: CreateLeagueFreeAgentRegistration :one
+INSERT INTO league_free_agents (
+    league_id,
+    user_id,
+    skill_level,
+    availability_notes,
+    gender
+) VALUES (
+    @league_id,
+    @user_id,
+    @skill_level,
+    @availability_notes,
+    @gender
+)
+RETURNING id, league_id, user_id, skill_level, availability_notes, gender, created_at;
+
+-- name: DeleteLeagueFreeAgentRegistration :execrows
+DELETE FROM league_free_agents
+WHERE league_id = @league_id
+  AND user_id = @user_id;
+
+-- name: IsUserOnLeagueTeam :one
+SELECT EXISTS (
+    SELECT 1
+    FROM league_team_members ltm
+    JOIN league_teams lt ON lt.id = ltm.league_team_id
+    WHERE lt.league_id = @league_id
+      AND ltm.user_id = @user_id
+) AS on_team;
+
 -- name: AssignFreeAgentToTeam :one
 UPDATE league_team_members
 SET league_team_id = @league_team_id,
@@ -266,3 +349,104 @@ WHERE user_id = @user_id
     SELECT 1 FROM league_teams lt WHERE lt.id = @league_team_id AND lt.league_id = @league_id
   )
 RETURNING id, league_team_id, user_id, is_free_agent, created_at;
+
+-- name: CreateLeagueTeamInvitation :one
+INSERT INTO league_team_invitations (
+    league_team_id,
+    invited_user_id,
+    invited_by_user_id,
+    token,
+    expires_at
+) VALUES (
+    @league_team_id,
+    @invited_user_id,
+    @invited_by_user_id,
+    @token,
+    @expires_at
+)
+RETURNING id, league_team_id, invited_user_id, invited_by_user_id, token, status, expires_at, responded_at, created_at;
+
+-- name: GetLeagueTeamInvitationByToken :one
+SELECT lti.id,
+    lti.league_team_id,
+    lti.invited_user_id,
+    lti.status,
+    lti.expires_at,
+    lt.league_id
+FROM league_team_invitations lti
+JOIN league_teams lt ON lt.id = lti.league_team_id
+WHERE lti.token = @token;
+
+-- name: MarkLeagueTeamInvitationAccepted :execrows
+UPDATE league_team_invitations
+SET status = 'accepted',
+    responded_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND status = 'pending';
+
+-- name: GetFirstLeagueMatchTime :one
+SELECT scheduled_time
+FROM league_matches
+WHERE league_id = @league_id
+  AND status != 'cancelled'
+ORDER BY scheduled_time
+LIMIT 1;
+
+-- name: UpdateLeagueMatchStatus :execrows
+UPDATE league_matches
+SET status = @status,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND status = @expected_status;
+
+-- name: CreateLeagueMatchResultSubmission :one
+INSERT INTO league_match_result_submissions (
+    league_match_id,
+    submitted_by_user_id,
+    home_score,
+    away_score,
+    respond_by
+) VALUES (
+    @league_match_id,
+    @submitted_by_user_id,
+    @home_score,
+    @away_score,
+    @respond_by
+)
+RETURNING id, league_match_id, submitted_by_user_id, home_score, away_score, status,
+    respond_by, responded_by_user_id, responded_at, dispute_reason, created_at;
+
+-- name: GetLeagueMatchResultSubmission :one
+SELECT id, league_match_id, submitted_by_user_id, home_score, away_score, status,
+    respond_by, responded_by_user_id, responded_at, dispute_reason, created_at
+FROM league_match_result_submissions
+WHERE league_match_id = @league_match_id;
+
+-- name: RespondToLeagueMatchResultSubmission :execrows
+UPDATE league_match_result_submissions
+SET status = @status,
+    responded_by_user_id = @responded_by_user_id,
+    responded_at = CURRENT_TIMESTAMP,
+    dispute_reason = @dispute_reason
+WHERE league_match_id = @league_match_id
+  AND status = 'pending';
+
+-- name: OverrideLeagueMatchResultSubmission :execrows
+UPDATE league_match_result_submissions
+SET status = 'overridden',
+    responded_by_user_id = @responded_by_user_id,
+    responded_at = CURRENT_TIMESTAMP
+WHERE league_match_id = @league_match_id
+  AND status IN ('pending', 'disputed');
+
+-- name: ListLapsedLeagueMatchResultSubmissions :many
+SELECT s.league_match_id,
+    lm.league_id,
+    s.home_score,
+    s.away_score
+FROM league_match_result_submissions s
+JOIN league_matches lm ON lm.id = s.league_match_id
+WHERE s.status = 'pending'
+  AND lm.status = 'pending_result'
+  AND s.respond_by <= @now
+ORDER BY s.respond_by;
@@ -0,0 +1,24 @@
+-- internal/db/queries/member_cards.sql
+
+-- name: GetActiveMemberCard :one
+SELECT id, user_id, card_token, issued_at, revoked_at
+FROM member_cards
+WHERE user_id = @user_id
+  AND revoked_at IS NULL;
+
+-- name: CreateMemberCard :one
+INSERT INTO member_cards (user_id, card_token)
+VALUES (@user_id, @card_token)
+RETURNING id, user_id, card_token, issued_at, revoked_at;
+
+-- name: RevokeMemberCards :execrows
+UPDATE member_cards
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE user_id = @user_id
+  AND revoked_at IS NULL;
+
+-- name: GetActiveMemberCardByToken :one
+SELECT id, user_id, card_token, issued_at, revoked_at
+FROM member_cards
+WHERE card_token = @card_token
+  AND revoked_at IS NULL;
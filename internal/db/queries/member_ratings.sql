@@ -0,0 +1,66 @@
+-- internal/db/queries/member_ratings.sql
+
+-- name: CreateMemberRatingEntry :one
+-- Verified entries are stamped verified by their recorder.
+INSERT INTO member_rating_history (
+    user_id,
+    rating,
+    source,
+    verified,
+    recorded_by_user_id,
+    verified_by_user_id,
+    verified_at,
+    note,
+    created_at
+) VALUES (
+    @user_id,
+    @rating,
+    @source,
+    @verified,
+    @recorded_by_user_id,
+    CASE WHEN @verified THEN @recorded_by_user_id END,
+    CASE WHEN @verified THEN @created_at END,
+    @note,
+    @created_at
+)
+RETURNING *;
+
+-- name: VerifyMemberRatingEntry :one
+UPDATE member_rating_history
+SET verified = 1,
+    verified_by_user_id = @verified_by_user_id,
+    verified_at = @verified_at
+WHERE id = @id
+  AND user_id = @user_id
+  AND verified = 0
+RETURNING *;
+
+-- name: GetMemberRatingEntry :one
+SELECT *
+FROM member_rating_history
+WHERE id = @id
+  AND user_id = @user_id;
+
+-- name: ListMemberRatingHistory :many
+SELECT *
+FROM member_rating_history
+WHERE user_id = @user_id
+ORDER BY created_at DESC, id DESC;
+
+-- name: GetPreferredMemberRating :one
+-- The entry eligibility should use: the most recently verified one, else the
+-- latest unverified one.
+SELECT *
+FROM member_rating_history
+WHERE user_id = @user_id
+ORDER BY verified DESC, COALESCE(verified_at, created_at) DESC, id DESC
+LIMIT 1;
+
+-- name: ListTeamMemberRatings :many
+-- Effective ratings for a league team's roster; rating is NULL for unrated
+-- players.
+SELECT ltm.user_id, msr.rating
+FROM league_team_members ltm
+LEFT JOIN member_skill_ratings msr ON msr.user_id = ltm.user_id
+WHERE ltm.league_team_id = @league_team_id
+ORDER BY ltm.created_at, ltm.id;
@@ -48,6 +48,36 @@ WHERE u.is_member = 1
 ORDER BY u.last_name, u.first_name
 LIMIT @limit;
 
+-- name: SearchFacilityMembers :many
+-- Case-insensitive prefix match on name or email, or on phone digits with or
+-- without the +1 country code, for the booking participant picker. @prefix
+-- must be lowercase; @phone_prefix is digits only or NULL.
+SELECT
+    u.id,
+    u.first_name,
+    u.last_name,
+    u.email,
+    u.phone,
+    msr.rating
+FROM users u
+LEFT JOIN member_skill_ratings msr ON msr.user_id = u.id
+WHERE u.is_member = 1
+    AND u.status = 'active'
+    AND u.home_facility_id = @facility_id
+    AND (
+        lower(substr(u.first_name, 1, length(@prefix))) = @prefix
+        OR lower(substr(u.last_name, 1, length(@prefix))) = @prefix
+        OR lower(substr(u.first_name || ' ' || u.last_name, 1, length(@prefix))) = @prefix
+        OR lower(substr(u.email, 1, length(@prefix))) = @prefix
+        OR substr(u.phone, 2, length(@phone_prefix)) = @phone_prefix
+        OR (
+            substr(u.phone, 1, 2) = '+1'
+            AND substr(u.phone, 3, length(@phone_prefix)) = @phone_prefix
+        )
+    )
+ORDER BY u.last_name, u.first_name, u.id
+LIMIT @limit;
+
 -- name: GetMemberByID :one
 SELECT
     u.id,
@@ -65,6 +95,8 @@ SELECT
     u.membership_level,
     u.created_at,
     u.updated_at,
+    u.deletion_requested_at,
+    u.email_undeliverable,
     up.id as photo_id
 FROM users u
 LEFT JOIN user_photos up ON up.user_id = u.id
@@ -113,11 +145,61 @@ LEFT JOIN user_photos p ON p.user_id = u.id
 LEFT JOIN user_billing ub ON ub.user_id = u.id
 WHERE u.id = last_insert_rowid();
 
--- name: DeleteMember :exec
+-- name: DeleteMember :execrows
 UPDATE users
 SET status = 'deleted',
+    deleted_at = CURRENT_TIMESTAMP,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = @id AND is_member = 1;
+WHERE id = @id AND is_member = 1 AND status != 'deleted';
+
+-- name: RequestMemberDeletion :one
+-- Repeat requests keep the original timestamp.
+UPDATE users
+SET deletion_requested_at = COALESCE(deletion_requested_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND is_member = 1 AND status != 'deleted'
+RETURNING deletion_requested_at;
+
+-- name: DeleteAnonymizableMemberPhotos :execrows
+-- Photos and billing go before the scrub below stamps anonymized_at, so the
+-- three statements share one cutoff.
+DELETE FROM user_photos
+WHERE user_id IN (
+    SELECT id FROM users
+    WHERE status = 'deleted'
+      AND anonymized_at IS NULL
+      AND deleted_at <= @cutoff
+);
+
+-- name: DeleteAnonymizableMemberBilling :execrows
+DELETE FROM user_billing
+WHERE user_id IN (
+    SELECT id FROM users
+    WHERE status = 'deleted'
+      AND anonymized_at IS NULL
+      AND deleted_at <= @cutoff
+);
+
+-- name: AnonymizeDeletedMembers :execrows
+-- Historical reservations keep pointing at the row, so it stays with a
+-- placeholder name and no way to contact or identify the member.
+UPDATE users
+SET first_name = 'Former',
+    last_name = 'Member',
+    email = NULL,
+    phone = NULL,
+    cognito_sub = NULL,
+    photo_url = NULL,
+    street_address = NULL,
+    city = NULL,
+    state = NULL,
+    postal_code = NULL,
+    date_of_birth = '',
+    anonymized_at = @anonymized_at,
+    updated_at = CURRENT_TIMESTAMP
+WHERE status = 'deleted'
+  AND anonymized_at IS NULL
+  AND deleted_at <= @cutoff;
 
 -- name: UpdateMember :exec
 UPDATE users
@@ -179,6 +261,11 @@ INSERT INTO user_photos (user_id, data, content_type, size)
 VALUES (@user_id, @data, @content_type, @size)
 RETURNING id;
 
+-- name: GetUserPhoto :one
+SELECT data, content_type, thumbnail_data, updated_at
+FROM user_photos
+WHERE user_id = @user_id;
+
 -- name: GetPhoto :one
 SELECT data, content_type
 FROM user_photos
@@ -191,8 +278,9 @@ WHERE id = @id;
 -- name: RestoreMember :exec
 UPDATE users
 SET status = 'active',
+    deleted_at = NULL,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = @id AND is_member = 1;
+WHERE id = @id AND is_member = 1 AND anonymized_at IS NULL;
 
 -- name: GetRestoredMember :one
 SELECT
@@ -228,11 +316,47 @@ FROM user_billing
 WHERE user_id = @user_id;
 
 -- name: UpsertPhoto :one
-INSERT INTO user_photos (user_id, data, content_type, size)
-VALUES (@user_id, @data, @content_type, @size)
+INSERT INTO user_photos (user_id, data, content_type, size, thumbnail_data)
+VALUES (@user_id, @data, @content_type, @size, @thumbnail_data)
 ON CONFLICT(user_id) DO UPDATE SET
     data = excluded.data,
     content_type = excluded.content_type,
     size = excluded.size,
+    thumbnail_data = excluded.thumbnail_data,
     updated_at = CURRENT_TIMESTAMP
 RETURNING *;
+
+-- name: CreateMemberHomeFacilityChange :one
+INSERT INTO member_home_facility_changes (
+    user_id,
+    from_facility_id,
+    to_facility_id,
+    changed_by_user_id,
+    transferred_visit_packs,
+    reason
+) VALUES (
+    @user_id,
+    @from_facility_id,
+    @to_facility_id,
+    @changed_by_user_id,
+    @transferred_visit_packs,
+    @reason
+)
+RETURNING *;
+
+-- name: GetMemberSkillRating :one
+SELECT user_id, rating, updated_at
+FROM member_skill_ratings
+WHERE user_id = @user_id;
+
+-- name: UpsertMemberSkillRating :one
+INSERT INTO member_skill_ratings (user_id, rating)
+VALUES (@user_id, @rating)
+ON CONFLICT(user_id) DO UPDATE SET
+    rating = excluded.rating,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING user_id, rating, updated_at;
+
+-- name: DeleteMemberSkillRating :exec
+DELETE FROM member_skill_ratings
+WHERE user_id = @user_id;
@@ -0,0 +1,105 @@
+-- internal/db/queries/membership_tiers.sql
+
+-- name: ListMembershipTiers :many
+SELECT
+    id,
+    organization_id,
+    level,
+    name,
+    booking_window_days,
+    max_active_reservations,
+    prime_time_access,
+    guest_passes_per_month,
+    visit_packs,
+    created_at,
+    updated_at
+FROM membership_tiers
+WHERE organization_id = ?
+ORDER BY level;
+
+-- name: GetMembershipTier :one
+SELECT
+    id,
+    organization_id,
+    level,
+    name,
+    booking_window_days,
+    max_active_reservations,
+    prime_time_access,
+    guest_passes_per_month,
+    visit_packs,
+    created_at,
+    updated_at
+FROM membership_tiers
+WHERE id = ? AND organization_id = ?;
+
+-- name: GetMembershipTierForLevel :one
+-- The tier with the highest level at or below the member's.
+SELECT
+    id,
+    organization_id,
+    level,
+    name,
+    booking_window_days,
+    max_active_reservations,
+    prime_time_access,
+    guest_passes_per_month,
+    visit_packs,
+    created_at,
+    updated_at
+FROM membership_tiers
+WHERE organization_id = @organization_id AND level <= @membership_level
+ORDER BY level DESC
+LIMIT 1;
+
+-- name: CreateMembershipTier :one
+INSERT INTO membership_tiers (
+    organization_id,
+    level,
+    name,
+    booking_window_days,
+    max_active_reservations,
+    prime_time_access,
+    guest_passes_per_month,
+    visit_packs
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING
+    id,
+    organization_id,
+    level,
+    name,
+    booking_window_days,
+    max_active_reservations,
+    prime_time_access,
+    guest_passes_per_month,
+    visit_packs,
+    created_at,
+    updated_at;
+
+-- name: UpdateMembershipTier :one
+UPDATE membership_tiers
+SET level = @level,
+    name = @name,
+    booking_window_days = @booking_window_days,
+    max_active_reservations = @max_active_reservations,
+    prime_time_access = @prime_time_access,
+    guest_passes_per_month = @guest_passes_per_month,
+    visit_packs = @visit_packs,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND organization_id = @organization_id
+RETURNING
+    id,
+    organization_id,
+    level,
+    name,
+    booking_window_days,
+    max_active_reservations,
+    prime_time_access,
+    guest_passes_per_month,
+    visit_packs,
+    created_at,
+    updated_at;
+
+-- name: DeleteMembershipTier :execrows
+DELETE FROM membership_tiers
+WHERE id = ? AND organization_id = ?;
@@ -0,0 +1,82 @@
+-- internal/db/queries/no_shows.sql
+
+-- name: GetFacilityNoShowPolicy :one
+SELECT facility_id, max_no_shows_per_30_days, restriction_days, created_at, updated_at
+FROM facility_no_show_policies
+WHERE facility_id = @facility_id;
+
+-- name: UpsertFacilityNoShowPolicy :one
+INSERT INTO facility_no_show_policies (
+    facility_id,
+    max_no_shows_per_30_days,
+    restriction_days
+) VALUES (
+    @facility_id,
+    @max_no_shows_per_30_days,
+    @restriction_days
+)
+ON CONFLICT(facility_id) DO UPDATE SET
+    max_no_shows_per_30_days = excluded.max_no_shows_per_30_days,
+    restriction_days = excluded.restriction_days,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING facility_id, max_no_shows_per_30_days, restriction_days, created_at, updated_at;
+
+-- name: DeleteFacilityNoShowPolicy :execrows
+DELETE FROM facility_no_show_policies
+WHERE facility_id = @facility_id;
+
+-- name: ListMemberNoShowEndTimes :many
+-- Ended reservations the member was on where nobody checked in. Reservations
+-- cancelled before they started are not no-shows; late cancellations are.
+SELECT r.end_time
+FROM reservations r
+WHERE r.facility_id = @facility_id
+  AND (
+        r.primary_user_id = CAST(@user_id AS INTEGER)
+     OR EXISTS (
+         SELECT 1
+         FROM reservation_participants rp
+         WHERE rp.reservation_id = r.id
+           AND rp.user_id = CAST(@user_id AS INTEGER)
+     )
+  )
+  AND r.end_time > @since
+  AND r.end_time <= @comparison_time
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+        AND rcc.cancelled_at < r.start_time
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_checkins rci
+      WHERE rci.reservation_id = r.id
+  )
+ORDER BY r.end_time;
+
+-- name: GetLatestNoShowRestrictionClear :one
+SELECT id, user_id, facility_id, restricted_until, cleared_at, cleared_by_user_id, reason, created_at
+FROM no_show_restriction_clears
+WHERE user_id = @user_id
+  AND facility_id = @facility_id
+ORDER BY cleared_at DESC, id DESC
+LIMIT 1;
+
+-- name: CreateNoShowRestrictionClear :one
+INSERT INTO no_show_restriction_clears (
+    user_id,
+    facility_id,
+    restricted_until,
+    cleared_at,
+    cleared_by_user_id,
+    reason
+) VALUES (
+    @user_id,
+    @facility_id,
+    @restricted_until,
+    @cleared_at,
+    @cleared_by_user_id,
+    @reason
+)
+RETURNING id, user_id, facility_id, restricted_until, cleared_at, cleared_by_user_id, reason, created_at;
@@ -0,0 +1,80 @@
+-- internal/db/queries/notifications_queue.sql
+
+-- name: GetFacilityQuietHours :one
+SELECT facility_id, starts_at, ends_at, created_at, updated_at
+FROM facility_quiet_hours
+WHERE facility_id = @facility_id;
+
+-- name: UpsertFacilityQuietHours :one
+INSERT INTO facility_quiet_hours (
+    facility_id,
+    starts_at,
+    ends_at
+) VALUES (
+    @facility_id,
+    @starts_at,
+    @ends_at
+)
+ON CONFLICT(facility_id) DO UPDATE SET
+    starts_at = excluded.starts_at,
+    ends_at = excluded.ends_at,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING facility_id, starts_at, ends_at, created_at, updated_at;
+
+-- name: DeleteFacilityQuietHours :execrows
+DELETE FROM facility_quiet_hours
+WHERE facility_id = @facility_id;
+
+-- name: CreateDeferredEmail :one
+INSERT INTO deferred_emails (
+    facility_id,
+    reservation_id,
+    user_id,
+    channel,
+    recipient,
+    sender,
+    subject,
+    body,
+    send_after
+) VALUES (
+    @facility_id,
+    sqlc.narg('reservation_id'),
+    @user_id,
+    @channel,
+    @recipient,
+    @sender,
+    @subject,
+    @body,
+    @send_after
+)
+RETURNING id, facility_id, reservation_id, user_id, channel, recipient, sender,
+    subject, body, send_after, status, processed_at, created_at;
+
+-- name: ListDueDeferredEmails :many
+SELECT id, facility_id, reservation_id, user_id, channel, recipient, sender,
+    subject, body, send_after, status, processed_at, created_at
+FROM deferred_emails
+WHERE status = 'pending'
+  AND send_after <= @now
+ORDER BY send_after, id
+LIMIT @limit;
+
+-- name: MarkDeferredEmailProcessed :execrows
+UPDATE deferred_emails
+SET status = @status,
+    processed_at = @processed_at
+WHERE id = @id
+  AND status = 'pending';
+
+-- name: IsReservationUpcoming :one
+SELECT EXISTS (
+    SELECT 1
+    FROM reservations r
+    WHERE r.id = @reservation_id
+      AND r.start_time > @now
+      AND NOT EXISTS (
+          SELECT 1
+          FROM reservation_cancellations rc
+          WHERE rc.reservation_id = r.id
+      )
+) AS upcoming;
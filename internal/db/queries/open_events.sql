@@ -0,0 +1,65 @@
+-- Open events are staff-booked reservations marked is_open_event that members
+-- sign up for through reservation_participants. Open play reservations are
+-- also marked open but go through the open play session path, so every query
+-- here skips reservations with an open play rule.
+
+-- name: ListUpcomingOpenEvents :many
+SELECT r.id,
+    r.start_time,
+    r.end_time,
+    rt.name AS reservation_type,
+    r.teams_per_court,
+    r.people_per_team,
+    (
+        SELECT COUNT(*)
+        FROM reservation_courts rc
+        WHERE rc.reservation_id = r.id
+    ) AS court_count,
+    (
+        SELECT COUNT(*)
+        FROM reservation_participants rp
+        WHERE rp.reservation_id = r.id
+    ) AS signup_count,
+    EXISTS (
+        SELECT 1
+        FROM reservation_participants rp
+        WHERE rp.reservation_id = r.id
+          AND rp.user_id = @user_id
+    ) AS is_signed_up
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+WHERE r.facility_id = @facility_id
+  AND r.is_open_event = 1
+  AND r.open_play_rule_id IS NULL
+  AND r.start_time > @comparison_time
+ORDER BY r.start_time, r.id;
+
+-- name: GetOpenEvent :one
+SELECT r.id,
+    r.start_time,
+    r.end_time,
+    rt.name AS reservation_type,
+    r.teams_per_court,
+    r.people_per_team,
+    (
+        SELECT COUNT(*)
+        FROM reservation_courts rc
+        WHERE rc.reservation_id = r.id
+    ) AS court_count,
+    (
+        SELECT COUNT(*)
+        FROM reservation_participants rp
+        WHERE rp.reservation_id = r.id
+    ) AS signup_count,
+    EXISTS (
+        SELECT 1
+        FROM reservation_participants rp
+        WHERE rp.reservation_id = r.id
+          AND rp.user_id = @user_id
+    ) AS is_signed_up
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+WHERE r.id = @id
+  AND r.facility_id = @facility_id
+  AND r.is_open_event = 1
+  AND r.open_play_rule_id IS NULL;
@@ -7,7 +7,10 @@ INSERT INTO open_play_rules (
     cancellation_cutoff_minutes,
     auto_scale_enabled,
     min_courts,
-    max_courts
+    max_courts,
+    min_membership_level,
+    max_membership_level,
+    skill_bands
 ) VALUES (
     @facility_id,
     @name,
@@ -16,16 +19,21 @@ INSERT INTO open_play_rules (
     @cancellation_cutoff_minutes,
     @auto_scale_enabled,
     @min_courts,
-    @max_courts
+    @max_courts,
+    @min_membership_level,
+    @max_membership_level,
+    @skill_bands
 )
 RETURNING id, facility_id, name, min_participants, max_participants_per_court,
     cancellation_cutoff_minutes, auto_scale_enabled, min_courts, max_courts,
-    created_at, updated_at;
+    created_at, updated_at, min_membership_level, max_membership_level,
+    skill_bands;
 
 -- name: GetOpenPlayRule :one
 SELECT id, facility_id, name, min_participants, max_participants_per_court,
     cancellation_cutoff_minutes, auto_scale_enabled, min_courts, max_courts,
-    created_at, updated_at
+    created_at, updated_at, min_membership_level, max_membership_level,
+    skill_bands
 FROM open_play_rules
 WHERE id = @id
   AND facility_id = @facility_id;
@@ -33,7 +41,8 @@ WHERE id = @id
 -- name: ListOpenPlayRules :many
 SELECT id, facility_id, name, min_participants, max_participants_per_court,
     cancellation_cutoff_minutes, auto_scale_enabled, min_courts, max_courts,
-    created_at, updated_at
+    created_at, updated_at, min_membership_level, max_membership_level,
+    skill_bands
 FROM open_play_rules
 WHERE facility_id = @facility_id
 ORDER BY name;
@@ -47,14 +56,68 @@ SET name = @name,
     auto_scale_enabled = @auto_scale_enabled,
     min_courts = @min_courts,
     max_courts = @max_courts,
+    min_membership_level = @min_membership_level,
+    max_membership_level = @max_membership_level,
+    skill_bands = @skill_bands,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = @id
   AND facility_id = @facility_id
 RETURNING id, facility_id, name, min_participants, max_participants_per_court,
     cancellation_cutoff_minutes, auto_scale_enabled, min_courts, max_courts,
-    created_at, updated_at;
+    created_at, updated_at, min_membership_level, max_membership_level,
+    skill_bands;
 
 -- name: DeleteOpenPlayRule :execrows
 DELETE FROM open_play_rules
 WHERE id = @id
   AND facility_id = @facility_id;
+
+-- name: ListOpenPlayRuleSlots :many
+SELECT s.id, s.open_play_rule_id, s.day_of_week, s.start_time, s.end_time,
+    s.created_by_user_id, s.created_at, s.updated_at
+FROM open_play_rule_slots s
+JOIN open_play_rules r ON r.id = s.open_play_rule_id
+WHERE s.open_play_rule_id = @open_play_rule_id
+  AND r.facility_id = @facility_id
+ORDER BY s.day_of_week, s.start_time;
+
+-- name: CreateOpenPlayRuleSlot :one
+INSERT INTO open_play_rule_slots (
+    open_play_rule_id,
+    day_of_week,
+    start_time,
+    end_time,
+    created_by_user_id
+) VALUES (
+    @open_play_rule_id,
+    @day_of_week,
+    @start_time,
+    @end_time,
+    @created_by_user_id
+)
+RETURNING id, open_play_rule_id, day_of_week, start_time, end_time,
+    created_by_user_id, created_at, updated_at;
+
+-- name: DeleteOpenPlayRuleSlot :execrows
+DELETE FROM open_play_rule_slots
+WHERE open_play_rule_slots.id = @id
+  AND open_play_rule_slots.open_play_rule_id = (
+    SELECT r.id
+    FROM open_play_rules r
+    WHERE r.id = @open_play_rule_id
+      AND r.facility_id = @facility_id
+  );
+
+-- name: ListFacilityOpenPlayRuleSlots :many
+SELECT s.id, s.open_play_rule_id, s.day_of_week, s.start_time, s.end_time,
+    s.created_by_user_id, r.name AS rule_name, r.min_courts
+FROM open_play_rule_slots s
+JOIN open_play_rules r ON r.id = s.open_play_rule_id
+WHERE r.facility_id = @facility_id
+ORDER BY s.day_of_week, s.start_time, r.name;
+
+-- name: ListFacilitiesWithOpenPlayRuleSlots :many
+SELECT DISTINCT r.facility_id
+FROM open_play_rule_slots s
+JOIN open_play_rules r ON r.id = s.open_play_rule_id
+ORDER BY r.facility_id;
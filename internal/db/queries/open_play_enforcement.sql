@@ -36,6 +36,41 @@ WHERE c.facility_id = @facility_id
   )
 ORDER BY c.court_number;
 
+-- name: ListCourtBlocksForDay :many
+-- Returns every active court once per overlapping block within the range
+-- (block columns are NULL for courts with nothing booked). Only the public
+-- closure reason is selected; internal notes never leave the staff views.
+-- Each block carries its turnover buffer, and every row the facility default.
+SELECT
+    c.id AS court_id,
+    c.court_number,
+    c.is_indoor,
+    c.surface,
+    c.has_lights,
+    r.start_time AS block_start_time,
+    r.end_time AS block_end_time,
+    rt.name AS block_reservation_type,
+    d.public_reason AS block_public_reason,
+    COALESCE(rt.buffer_minutes, f.buffer_minutes) AS block_buffer_minutes,
+    f.buffer_minutes AS facility_buffer_minutes
+FROM courts c
+JOIN facilities f ON f.id = c.facility_id
+LEFT JOIN reservation_courts rc
+    ON rc.court_id = c.id
+    AND rc.reservation_id IN (
+        SELECT overlapping.id
+        FROM reservations overlapping
+        WHERE overlapping.facility_id = @facility_id
+          AND overlapping.start_time < @range_end
+          AND overlapping.end_time > @range_start
+    )
+LEFT JOIN reservations r ON r.id = rc.reservation_id
+LEFT JOIN reservation_types rt ON rt.id = r.reservation_type_id
+LEFT JOIN reservation_closure_details d ON d.reservation_id = r.id
+WHERE c.facility_id = @facility_id
+  AND c.status = 'active'
+ORDER BY c.court_number, r.start_time;
+
 -- name: AddReservationCourt :exec
 INSERT INTO reservation_courts (reservation_id, court_id)
 VALUES (@reservation_id, @court_id);
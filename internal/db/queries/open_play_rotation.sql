@@ -0,0 +1,45 @@
+-- internal/db/queries/open_play_rotation.sql
+
+-- name: CreateOpenPlayQueueEntry :one
+-- Adds a checked-in player to the back of the session's queue.
+INSERT INTO open_play_queue_entries (session_id, user_id, queue_position)
+VALUES (
+    @session_id,
+    @user_id,
+    (SELECT COALESCE(MAX(queue_position), 0) + 1 FROM open_play_queue_entries WHERE session_id = @session_id)
+)
+RETURNING *;
+
+-- name: ListOpenPlayQueue :many
+SELECT q.user_id,
+    u.first_name,
+    u.last_name,
+    q.queue_position,
+    q.games_played,
+    q.joined_at
+FROM open_play_queue_entries q
+JOIN users u ON u.id = q.user_id
+WHERE q.session_id = @session_id
+ORDER BY q.queue_position;
+
+-- name: RequeueOpenPlayPlayer :exec
+-- Sends a player who just finished a game to the back of the queue.
+UPDATE open_play_queue_entries
+SET queue_position = (SELECT MAX(queue_position) + 1 FROM open_play_queue_entries WHERE session_id = @session_id),
+    games_played = games_played + 1
+WHERE session_id = @session_id
+  AND user_id = @user_id;
+
+-- name: DeleteOpenPlayQueueEntry :exec
+DELETE FROM open_play_queue_entries
+WHERE session_id = @session_id
+  AND user_id = @user_id;
+
+-- name: CreateOpenPlayGame :one
+INSERT INTO open_play_games (session_id, recorded_by_user_id)
+VALUES (@session_id, @recorded_by_user_id)
+RETURNING *;
+
+-- name: AddOpenPlayGamePlayer :exec
+INSERT INTO open_play_game_players (game_id, user_id)
+VALUES (@game_id, @user_id);
@@ -22,10 +22,16 @@ SELECT ops.id,
           AND r.end_time = ops.end_time
           AND rt.name = 'OPEN_PLAY'
     ) AS participant_count,
-    opr.min_participants
+    opr.min_participants,
+    opr.min_membership_level,
+    opr.max_membership_level,
+    opr.skill_bands AS rule_skill_bands,
+    sb.skill_bands AS session_skill_bands
 FROM open_play_sessions ops
 JOIN open_play_rules opr
   ON ops.open_play_rule_id = opr.id
+LEFT JOIN open_play_session_skill_bands sb
+  ON sb.session_id = ops.id
 -- Empty facility_ids intentionally yields zero rows (caller should prefilter).
 WHERE ops.facility_id IN (sqlc.slice('facility_ids'))
   AND ops.status = 'scheduled'
@@ -91,6 +97,15 @@ RETURNING id, facility_id, open_play_rule_id, start_time, end_time, status,
     current_court_count, auto_scale_override, cancelled_at, cancellation_reason,
     created_at, updated_at;
 
+-- name: CountOpenPlaySessionsForSlot :one
+-- Counts sessions in any status so a cancelled occurrence is not regenerated.
+SELECT COUNT(*)
+FROM open_play_sessions
+WHERE facility_id = @facility_id
+  AND open_play_rule_id = @open_play_rule_id
+  AND start_time = @start_time
+  AND end_time = @end_time;
+
 -- name: GetOpenPlaySession :one
 SELECT ops.id,
     ops.facility_id,
@@ -253,6 +268,24 @@ WHERE r.facility_id = @facility_id
   AND rt.name = 'OPEN_PLAY'
 ORDER BY u.last_name, u.first_name;
 
+-- name: ListOpenPlayParticipantsForSession :many
+SELECT u.id,
+    u.first_name,
+    u.hide_from_rosters
+FROM open_play_sessions ops
+JOIN reservations r
+  ON r.facility_id = ops.facility_id
+ AND r.open_play_rule_id = ops.open_play_rule_id
+ AND r.start_time = ops.start_time
+ AND r.end_time = ops.end_time
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+JOIN reservation_participants rp ON rp.reservation_id = r.id
+JOIN users u ON u.id = rp.user_id
+WHERE ops.id = @session_id
+  AND ops.facility_id = @facility_id
+  AND rt.name = 'OPEN_PLAY'
+ORDER BY rp.created_at, rp.id;
+
 -- name: CreateStaffNotification :one
 INSERT INTO staff_notifications (
     facility_id,
@@ -340,3 +373,70 @@ WHERE id = @id
 RETURNING id, facility_id, notification_type, message, related_session_id,
     related_reservation_id, related_clinic_session_id, target_staff_id, read,
     created_at, updated_at;
+
+-- name: GetOpenPlaySessionSkillBands :one
+SELECT opr.skill_bands AS rule_skill_bands,
+    sb.skill_bands AS session_skill_bands
+FROM open_play_sessions ops
+JOIN open_play_rules opr
+  ON opr.id = ops.open_play_rule_id
+LEFT JOIN open_play_session_skill_bands sb
+  ON sb.session_id = ops.id
+WHERE ops.id = @session_id
+  AND ops.facility_id = @facility_id;
+
+-- name: UpsertOpenPlaySessionSkillBands :one
+INSERT INTO open_play_session_skill_bands (session_id, skill_bands)
+VALUES (@session_id, @skill_bands)
+ON CONFLICT(session_id) DO UPDATE SET
+    skill_bands = excluded.skill_bands,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING session_id, skill_bands, updated_at;
+
+-- name: DeleteOpenPlaySessionSkillBands :execrows
+DELETE FROM open_play_session_skill_bands
+WHERE session_id = @session_id;
+
+-- name: ListOpenPlayParticipantSkillRatings :many
+SELECT u.id AS user_id,
+    msr.rating
+FROM open_play_sessions ops
+JOIN reservations r
+  ON r.facility_id = ops.facility_id
+ AND r.open_play_rule_id = ops.open_play_rule_id
+ AND r.start_time = ops.start_time
+ AND r.end_time = ops.end_time
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+JOIN reservation_participants rp ON rp.reservation_id = r.id
+JOIN users u ON u.id = rp.user_id
+LEFT JOIN member_skill_ratings msr ON msr.user_id = u.id
+WHERE ops.id = @session_id
+  AND ops.facility_id = @facility_id
+  AND rt.name = 'OPEN_PLAY'
+ORDER BY rp.created_at, rp.id;
+
+-- name: ListOpenPlaySessionReservationMismatches :many
+-- Live sessions in the range without exactly one backing reservation, by the
+-- same match CountOpenPlayReservationsForSession uses, with the IDs found.
+SELECT
+    s.id AS session_id,
+    s.open_play_rule_id,
+    s.start_time,
+    s.end_time,
+    s.status,
+    COUNT(r.id) AS reservation_count,
+    CAST(COALESCE(GROUP_CONCAT(r.id), '') AS TEXT) AS reservation_ids
+FROM open_play_sessions s
+LEFT JOIN reservations r
+    ON r.facility_id = s.facility_id
+   AND r.open_play_rule_id = s.open_play_rule_id
+   AND r.start_time = s.start_time
+   AND r.end_time = s.end_time
+   AND r.reservation_type_id IN (SELECT id FROM reservation_types WHERE name = 'OPEN_PLAY')
+WHERE s.facility_id = @facility_id
+  AND s.status != 'cancelled'
+  AND s.start_time < @end_time
+  AND s.end_time > @start_time
+GROUP BY s.id
+HAVING COUNT(r.id) != 1
+ORDER BY s.start_time, s.id;
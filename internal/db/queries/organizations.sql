@@ -37,3 +37,19 @@ SET email_from_address = @email_from_address,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = @id
 RETURNING id, email_from_address;
+
+-- name: GetOrganizationSettings :one
+SELECT id, name, slug, status, cross_facility_visit_packs, shared_member_directory,
+    default_cancellation_policy, updated_at
+FROM organizations
+WHERE id = @id;
+
+-- name: UpdateOrganizationSettings :one
+UPDATE organizations
+SET cross_facility_visit_packs = @cross_facility_visit_packs,
+    shared_member_directory = @shared_member_directory,
+    default_cancellation_policy = @default_cancellation_policy,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+RETURNING id, name, slug, status, cross_facility_visit_packs, shared_member_directory,
+    default_cancellation_policy, updated_at;
@@ -0,0 +1,47 @@
+-- internal/db/queries/prime_time_rules.sql
+
+-- name: ListPrimeTimeRules :many
+SELECT * FROM prime_time_rules
+WHERE facility_id = @facility_id
+ORDER BY day_of_week, start_time, id;
+
+-- name: ListPrimeTimeRulesForDay :many
+SELECT * FROM prime_time_rules
+WHERE facility_id = @facility_id
+  AND day_of_week = @day_of_week
+ORDER BY start_time, id;
+
+-- name: CreatePrimeTimeRule :one
+INSERT INTO prime_time_rules (
+    facility_id,
+    day_of_week,
+    start_time,
+    end_time,
+    min_membership_level,
+    unlock_hours_before
+) VALUES (
+    @facility_id,
+    @day_of_week,
+    @start_time,
+    @end_time,
+    @min_membership_level,
+    @unlock_hours_before
+)
+RETURNING *;
+
+-- name: UpdatePrimeTimeRule :one
+UPDATE prime_time_rules
+SET day_of_week = @day_of_week,
+    start_time = @start_time,
+    end_time = @end_time,
+    min_membership_level = @min_membership_level,
+    unlock_hours_before = @unlock_hours_before,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND facility_id = @facility_id
+RETURNING *;
+
+-- name: DeletePrimeTimeRule :execrows
+DELETE FROM prime_time_rules
+WHERE id = @id
+  AND facility_id = @facility_id;
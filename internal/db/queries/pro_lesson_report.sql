@@ -0,0 +1,36 @@
+-- name: ListProLessonReportReservations :many
+-- Pro sessions the pro holds that start within the range, cancelled ones
+-- included with their cancellation terms. A lesson reassigned to the pro
+-- is flagged by its lesson_reassigned notice.
+SELECT r.id,
+    r.facility_id,
+    r.start_time,
+    r.end_time,
+    r.primary_user_id,
+    COALESCE(u.first_name, '') AS member_first_name,
+    COALESCE(u.last_name, '') AS member_last_name,
+    rp.amount_cents AS price_cents,
+    rcc.cancelled_at,
+    rcc.refund_percentage_applied,
+    rcc.fee_waived,
+    CAST(EXISTS (
+        SELECT 1
+        FROM staff_notifications sn
+        WHERE sn.related_reservation_id = r.id
+          AND sn.notification_type = 'lesson_reassigned'
+          AND sn.target_staff_id = r.pro_id
+    ) AS BOOLEAN) AS reassigned
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+LEFT JOIN users u ON u.id = r.primary_user_id
+LEFT JOIN reservation_prices rp ON rp.reservation_id = r.id
+LEFT JOIN reservation_cancellations rcc ON rcc.id = (
+    SELECT MAX(c.id)
+    FROM reservation_cancellations c
+    WHERE c.reservation_id = r.id
+)
+WHERE r.pro_id = @pro_id
+  AND rt.name = 'PRO_SESSION'
+  AND r.start_time >= @range_start
+  AND r.start_time < @range_end
+ORDER BY r.start_time, r.id;
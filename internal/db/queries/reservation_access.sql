@@ -0,0 +1,19 @@
+-- internal/db/queries/reservation_access.sql
+
+-- name: RevokeReservationAccess :execrows
+-- Revoking an already revoked reservation keeps the first revocation.
+INSERT INTO reservation_access_revocations (
+    reservation_id,
+    revoked_by_user_id,
+    reason
+) VALUES (
+    @reservation_id,
+    @revoked_by_user_id,
+    @reason
+)
+ON CONFLICT (reservation_id) DO NOTHING;
+
+-- name: GetReservationAccessRevocation :one
+SELECT *
+FROM reservation_access_revocations
+WHERE reservation_id = @reservation_id;
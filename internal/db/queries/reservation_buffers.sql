@@ -0,0 +1,31 @@
+-- internal/db/queries/reservation_buffers.sql
+
+-- name: GetReservationTypeBufferMinutes :one
+-- The turnover buffer after a reservation of this type at the facility: the
+-- type's override, or the facility default.
+SELECT COALESCE(rt.buffer_minutes, f.buffer_minutes) AS buffer_minutes
+FROM facilities f
+JOIN reservation_types rt ON rt.id = @reservation_type_id
+WHERE f.id = @facility_id;
+
+-- name: ListCourtBufferNeighbors :many
+-- Reservations on the facility's courts within the window, each with the
+-- buffer that follows it and what identifies its recurring series.
+SELECT
+    rc.court_id,
+    r.id AS reservation_id,
+    r.start_time,
+    r.end_time,
+    r.reservation_type_id,
+    r.recurrence_rule_id,
+    r.primary_user_id,
+    COALESCE(rt.buffer_minutes, f.buffer_minutes) AS buffer_minutes
+FROM reservation_courts rc
+JOIN reservations r ON r.id = rc.reservation_id
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+JOIN facilities f ON f.id = r.facility_id
+WHERE r.facility_id = @facility_id
+  AND r.id != @reservation_id
+  AND r.start_time < @window_end
+  AND r.end_time > @window_start
+ORDER BY rc.court_id, r.start_time;
@@ -0,0 +1,83 @@
+-- internal/db/queries/reservation_checkins.sql
+
+-- name: IsReservationCancelled :one
+SELECT EXISTS (
+    SELECT 1
+    FROM reservation_cancellations
+    WHERE reservation_id = @reservation_id
+) AS cancelled;
+
+-- name: ListReservationCheckinRoster :many
+-- Participants plus the primary user, each with their check-in if recorded.
+SELECT u.id AS user_id,
+    u.first_name,
+    u.last_name,
+    rc.checked_in_at
+FROM users u
+LEFT JOIN reservation_checkins rc
+    ON rc.reservation_id = @reservation_id
+   AND rc.user_id = u.id
+WHERE u.id IN (
+    SELECT rp.user_id
+    FROM reservation_participants rp
+    WHERE rp.reservation_id = @reservation_id
+    UNION
+    SELECT r.primary_user_id
+    FROM reservations r
+    WHERE r.id = @reservation_id
+      AND r.primary_user_id IS NOT NULL
+)
+ORDER BY u.last_name, u.first_name, u.id;
+
+-- name: CreateReservationCheckin :one
+INSERT INTO reservation_checkins (
+    reservation_id,
+    user_id,
+    checked_in_at,
+    checked_in_by_user_id
+) VALUES (
+    @reservation_id,
+    @user_id,
+    @checked_in_at,
+    @checked_in_by_user_id
+)
+RETURNING *;
+
+-- name: ListFacilityArrivalsInRange :many
+-- One row per expected person for each live reservation starting in the range.
+SELECT r.id AS reservation_id,
+    r.start_time,
+    r.end_time,
+    COALESCE(rt.name, '') AS reservation_type_name,
+    u.id AS user_id,
+    u.first_name,
+    u.last_name,
+    rc.checked_in_at
+FROM reservations r
+LEFT JOIN reservation_types rt ON rt.id = r.reservation_type_id
+JOIN users u ON u.id IN (
+    SELECT rp.user_id
+    FROM reservation_participants rp
+    WHERE rp.reservation_id = r.id
+    UNION
+    SELECT r.primary_user_id
+)
+LEFT JOIN reservation_checkins rc
+    ON rc.reservation_id = r.id
+   AND rc.user_id = u.id
+WHERE r.facility_id = @facility_id
+  AND r.start_time >= @start_time
+  AND r.start_time < @end_time
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY r.start_time, r.id, u.last_name, u.first_name, u.id;
+
+-- name: ListCheckedInReservationIDs :many
+-- Empty reservation_ids intentionally yields zero rows (caller should prefilter).
+SELECT DISTINCT reservation_id
+FROM reservation_checkins
+WHERE user_id = @user_id
+  AND reservation_id IN (sqlc.slice('reservation_ids'));
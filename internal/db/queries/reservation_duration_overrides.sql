@@ -0,0 +1,23 @@
+-- internal/db/queries/reservation_duration_overrides.sql
+
+-- name: CreateReservationDurationOverride :one
+INSERT INTO reservation_duration_overrides (
+    reservation_id,
+    duration_minutes,
+    min_duration_minutes,
+    max_duration_minutes,
+    overridden_by_user_id
+) VALUES (
+    @reservation_id,
+    @duration_minutes,
+    @min_duration_minutes,
+    @max_duration_minutes,
+    @overridden_by_user_id
+)
+RETURNING *;
+
+-- name: ListReservationDurationOverrides :many
+SELECT *
+FROM reservation_duration_overrides
+WHERE reservation_id = @reservation_id
+ORDER BY created_at, id;
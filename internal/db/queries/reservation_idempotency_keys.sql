@@ -0,0 +1,28 @@
+-- internal/db/queries/reservation_idempotency_keys.sql
+
+-- name: GetReservationIdempotencyKey :one
+-- Only unexpired keys count; an expired key may be reused.
+SELECT id, user_id, idempotency_key, request_hash, reservation_id,
+    response_body, created_at, expires_at
+FROM reservation_idempotency_keys
+WHERE user_id = @user_id
+  AND idempotency_key = @idempotency_key
+  AND expires_at > @now;
+
+-- name: CreateReservationIdempotencyKey :exec
+INSERT INTO reservation_idempotency_keys (
+    user_id, idempotency_key, request_hash, reservation_id, response_body, expires_at
+) VALUES (
+    @user_id, @idempotency_key, @request_hash, @reservation_id, @response_body, @expires_at
+);
+
+-- name: DeleteExpiredReservationIdempotencyKey :exec
+-- Frees one user's key for reuse once it has expired.
+DELETE FROM reservation_idempotency_keys
+WHERE user_id = @user_id
+  AND idempotency_key = @idempotency_key
+  AND expires_at <= @now;
+
+-- name: DeleteExpiredReservationIdempotencyKeys :execrows
+DELETE FROM reservation_idempotency_keys
+WHERE expires_at <= @now;
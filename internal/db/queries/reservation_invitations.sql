@@ -0,0 +1,104 @@
+-- internal/db/queries/reservation_invitations.sql
+
+-- name: CreateReservationInvitation :one
+-- Re-inviting someone who declined or whose invitation was cancelled reopens
+-- the row with a new token. A pending or accepted invitation is left alone
+-- and no row is returned.
+INSERT INTO reservation_invitations (
+    reservation_id,
+    invited_user_id,
+    invited_by_user_id,
+    token
+) VALUES (
+    @reservation_id,
+    @invited_user_id,
+    @invited_by_user_id,
+    @token
+)
+ON CONFLICT (reservation_id, invited_user_id) DO UPDATE
+SET invited_by_user_id = excluded.invited_by_user_id,
+    token = excluded.token,
+    status = 'pending',
+    responded_at = NULL,
+    created_at = CURRENT_TIMESTAMP
+WHERE reservation_invitations.status IN ('declined', 'cancelled')
+RETURNING id, reservation_id, invited_user_id, invited_by_user_id, token, status, responded_at, created_at;
+
+-- name: GetReservationInvitationByToken :one
+SELECT ri.id,
+    ri.reservation_id,
+    ri.invited_user_id,
+    ri.invited_by_user_id,
+    ri.status,
+    r.facility_id,
+    r.start_time,
+    r.end_time,
+    EXISTS (
+        SELECT 1 FROM reservation_cancellations rc WHERE rc.reservation_id = r.id
+    ) AS reservation_cancelled
+FROM reservation_invitations ri
+JOIN reservations r ON r.id = ri.reservation_id
+WHERE ri.token = @token;
+
+-- name: CountPendingReservationInvitations :one
+SELECT COUNT(*)
+FROM reservation_invitations
+WHERE reservation_id = @reservation_id
+  AND status = 'pending';
+
+-- name: MarkReservationInvitationAccepted :execrows
+UPDATE reservation_invitations
+SET status = 'accepted',
+    responded_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND status = 'pending';
+
+-- name: MarkReservationInvitationDeclined :execrows
+-- Accepted invitees may still back out before the reservation starts.
+UPDATE reservation_invitations
+SET status = 'declined',
+    responded_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND status IN ('pending', 'accepted');
+
+-- name: ResolveReservationInvitationForUser :exec
+-- Settles a member's open invitation when staff add or remove them directly.
+UPDATE reservation_invitations
+SET status = @status,
+    responded_at = CURRENT_TIMESTAMP
+WHERE reservation_id = @reservation_id
+  AND invited_user_id = @invited_user_id
+  AND status IN ('pending', 'accepted')
+  AND status <> @status;
+
+-- name: CancelPendingReservationInvitations :exec
+UPDATE reservation_invitations
+SET status = 'cancelled',
+    responded_at = CURRENT_TIMESTAMP
+WHERE reservation_id = @reservation_id
+  AND status = 'pending';
+
+-- name: CancelUpcomingReservationInvitationsForUser :execrows
+UPDATE reservation_invitations
+SET status = 'cancelled',
+    responded_at = CURRENT_TIMESTAMP
+WHERE invited_user_id = @invited_user_id
+  AND status IN ('pending', 'accepted')
+  AND reservation_id IN (
+      SELECT r.id
+      FROM reservations r
+      WHERE r.start_time > @comparison_time
+  );
+
+-- name: ListReservationInvitationsForReservations :many
+-- Empty reservation_ids intentionally yields zero rows (caller should prefilter).
+SELECT ri.reservation_id,
+    ri.invited_user_id,
+    ri.status,
+    u.first_name,
+    u.last_name
+FROM reservation_invitations ri
+JOIN users u ON u.id = ri.invited_user_id
+WHERE ri.reservation_id IN (sqlc.slice('reservation_ids'))
+  AND ri.status IN ('pending', 'accepted', 'declined')
+ORDER BY ri.reservation_id, u.last_name, u.first_name;
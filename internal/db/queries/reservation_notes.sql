@@ -0,0 +1,34 @@
+-- internal/db/queries/reservation_notes.sql
+
+-- name: CreateReservationMemberNote :exec
+INSERT INTO reservation_member_notes (reservation_id, note)
+VALUES (@reservation_id, @note);
+
+-- name: GetReservationMemberNote :one
+SELECT note
+FROM reservation_member_notes
+WHERE reservation_id = @reservation_id;
+
+-- name: ListReservationMemberNotesForReservations :many
+-- Empty reservation_ids intentionally yields zero rows (caller should prefilter).
+SELECT reservation_id, note
+FROM reservation_member_notes
+WHERE reservation_id IN (sqlc.slice('reservation_ids'));
+
+-- name: CreateReservationComment :one
+INSERT INTO reservation_comments (reservation_id, author_user_id, body)
+VALUES (@reservation_id, @author_user_id, @body)
+RETURNING id, reservation_id, author_user_id, body, created_at;
+
+-- name: ListReservationComments :many
+SELECT rc.id,
+    rc.reservation_id,
+    rc.author_user_id,
+    rc.body,
+    rc.created_at,
+    u.first_name AS author_first_name,
+    u.last_name AS author_last_name
+FROM reservation_comments rc
+JOIN users u ON u.id = rc.author_user_id
+WHERE rc.reservation_id = @reservation_id
+ORDER BY rc.created_at, rc.id;
@@ -0,0 +1,33 @@
+-- internal/db/queries/reservation_reminders.sql
+
+-- name: ListReservationsDueForReminder :many
+-- Non-cancelled reservations starting after now and within the reminder
+-- window that have not been reminded yet.
+SELECT id, facility_id, reservation_type_id, recurrence_rule_id,
+    primary_user_id, created_by_user_id, pro_id, open_play_rule_id, start_time, end_time,
+    is_open_event, teams_per_court, people_per_team, created_at, updated_at
+FROM reservations
+WHERE facility_id = @facility_id
+  AND start_time > @now
+  AND start_time <= @window_end
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = reservations.id
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_reminders rr
+      WHERE rr.reservation_id = reservations.id
+  )
+ORDER BY start_time;
+
+-- name: ClaimReservationReminder :execrows
+-- Zero rows means another run already sent this reminder.
+INSERT INTO reservation_reminders (reservation_id, sent_at)
+VALUES (@reservation_id, @sent_at)
+ON CONFLICT (reservation_id) DO NOTHING;
+
+-- name: DeleteReservationReminder :exec
+DELETE FROM reservation_reminders
+WHERE reservation_id = @reservation_id;
@@ -0,0 +1,79 @@
+-- name: SearchReservations :many
+-- Reservations at the facility matching @search_term against the primary
+-- member's or a participant's name or email, a court label, or the
+-- reservation ID, soonest first. @search_term must be lowercase; NULL
+-- matches everything. Court labels and participant names come back joined
+-- with ", ".
+SELECT r.id,
+    r.facility_id,
+    r.start_time,
+    r.end_time,
+    rt.name AS reservation_type_name,
+    r.primary_user_id,
+    COALESCE(pu.first_name, '') AS primary_first_name,
+    COALESCE(pu.last_name, '') AS primary_last_name,
+    CAST(COALESCE((
+        SELECT group_concat(label, ', ')
+        FROM (
+            SELECT COALESCE(NULLIF(c.name, ''), 'Court ' || c.court_number) AS label
+            FROM reservation_courts rc
+            JOIN courts c ON c.id = rc.court_id
+            WHERE rc.reservation_id = r.id
+            ORDER BY c.court_number
+        )
+    ), '') AS TEXT) AS court_labels,
+    CAST(COALESCE((
+        SELECT group_concat(participant_name, ', ')
+        FROM (
+            SELECT TRIM(u.first_name || ' ' || u.last_name) AS participant_name
+            FROM reservation_participants rp
+            JOIN users u ON u.id = rp.user_id
+            WHERE rp.reservation_id = r.id
+            ORDER BY u.last_name, u.first_name
+        )
+    ), '') AS TEXT) AS participant_names,
+    CAST(EXISTS (
+        SELECT 1
+        FROM reservation_cancellations rcc
+        WHERE rcc.reservation_id = r.id
+    ) AS BOOLEAN) AS cancelled
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+LEFT JOIN users pu ON pu.id = r.primary_user_id
+WHERE r.facility_id = @facility_id
+  AND (sqlc.narg('start_from') IS NULL OR r.start_time >= sqlc.narg('start_from'))
+  AND (sqlc.narg('start_before') IS NULL OR r.start_time < sqlc.narg('start_before'))
+  AND (sqlc.narg('reservation_type') IS NULL OR rt.name = sqlc.narg('reservation_type'))
+  AND (
+      @include_cancelled
+      OR NOT EXISTS (
+          SELECT 1
+          FROM reservation_cancellations rcc
+          WHERE rcc.reservation_id = r.id
+      )
+  )
+  AND (
+      @search_term IS NULL
+      OR CAST(r.id AS TEXT) = @search_term
+      OR lower(pu.first_name || ' ' || pu.last_name) LIKE '%' || @search_term || '%'
+      OR lower(COALESCE(pu.email, '')) LIKE '%' || @search_term || '%'
+      OR EXISTS (
+          SELECT 1
+          FROM reservation_participants rp
+          JOIN users u ON u.id = rp.user_id
+          WHERE rp.reservation_id = r.id
+            AND (
+                lower(u.first_name || ' ' || u.last_name) LIKE '%' || @search_term || '%'
+                OR lower(COALESCE(u.email, '')) LIKE '%' || @search_term || '%'
+            )
+      )
+      OR EXISTS (
+          SELECT 1
+          FROM reservation_courts rc
+          JOIN courts c ON c.id = rc.court_id
+          WHERE rc.reservation_id = r.id
+            AND lower(COALESCE(NULLIF(c.name, ''), 'Court ' || c.court_number)) LIKE '%' || @search_term || '%'
+      )
+  )
+ORDER BY r.start_time, r.id
+LIMIT @limit OFFSET @offset;
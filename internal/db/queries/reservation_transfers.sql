@@ -0,0 +1,60 @@
+-- internal/db/queries/reservation_transfers.sql
+
+-- name: CreateReservationTransfer :one
+INSERT INTO reservation_transfers (
+    reservation_id,
+    from_user_id,
+    to_user_id,
+    token,
+    expires_at
+) VALUES (
+    @reservation_id,
+    @from_user_id,
+    @to_user_id,
+    @token,
+    @expires_at
+)
+RETURNING id, reservation_id, from_user_id, to_user_id, token, status, expires_at, responded_at, created_at;
+
+-- name: GetReservationTransferByToken :one
+SELECT rt.id,
+    rt.reservation_id,
+    rt.from_user_id,
+    rt.to_user_id,
+    rt.status,
+    rt.expires_at,
+    r.facility_id,
+    r.reservation_type_id,
+    r.primary_user_id,
+    r.start_time,
+    r.end_time,
+    EXISTS (
+        SELECT 1 FROM reservation_cancellations rc WHERE rc.reservation_id = r.id
+    ) AS reservation_cancelled
+FROM reservation_transfers rt
+JOIN reservations r ON r.id = rt.reservation_id
+WHERE rt.token = @token;
+
+-- name: CancelPendingReservationTransfers :exec
+-- Clears the way for a new request or a staff transfer, and drops lapsed
+-- requests still marked pending.
+UPDATE reservation_transfers
+SET status = 'cancelled',
+    responded_at = CURRENT_TIMESTAMP
+WHERE reservation_id = @reservation_id
+  AND status = 'pending';
+
+-- name: MarkReservationTransferAccepted :execrows
+UPDATE reservation_transfers
+SET status = 'accepted',
+    responded_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND status = 'pending'
+  AND expires_at > @now;
+
+-- name: MarkReservationTransferDeclined :execrows
+UPDATE reservation_transfers
+SET status = 'declined',
+    responded_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND status = 'pending';
@@ -0,0 +1,104 @@
+-- name: GetReservationType :one
+SELECT *
+FROM reservation_types
+WHERE id = @id;
+
+-- name: GetReservationTypeByName :one
+SELECT *
+FROM reservation_types
+WHERE LOWER(name) = LOWER(@name);
+
+-- name: ListReservationTypes :many
+SELECT *
+FROM reservation_types
+ORDER BY name;
+
+-- name: ListReservationTypesForFacility :many
+-- Built-in types, the facility organization's shared types, and the
+-- facility's own types.
+SELECT rt.*
+FROM reservation_types rt
+WHERE (rt.organization_id IS NULL AND rt.facility_id IS NULL)
+   OR rt.facility_id = @facility_id
+   OR (
+       rt.facility_id IS NULL
+       AND rt.organization_id = (SELECT f.organization_id FROM facilities f WHERE f.id = @facility_id)
+   )
+ORDER BY rt.name;
+
+-- name: GetReservationTypeForFacility :one
+SELECT rt.*
+FROM reservation_types rt
+WHERE rt.id = @id
+  AND (
+      (rt.organization_id IS NULL AND rt.facility_id IS NULL)
+      OR rt.facility_id = @facility_id
+      OR (
+          rt.facility_id IS NULL
+          AND rt.organization_id = (SELECT f.organization_id FROM facilities f WHERE f.id = @facility_id)
+      )
+  );
+
+-- name: ListReservationTypesForOrganization :many
+-- Built-in types and every custom type in the organization, shared or
+-- facility-only.
+SELECT *
+FROM reservation_types
+WHERE (organization_id IS NULL AND facility_id IS NULL)
+   OR organization_id = @organization_id
+ORDER BY name;
+
+-- name: CreateReservationType :one
+INSERT INTO reservation_types (
+    name,
+    description,
+    color,
+    organization_id,
+    facility_id,
+    default_duration_minutes,
+    member_bookable,
+    counts_toward_member_limit,
+    buffer_minutes,
+    min_duration_minutes,
+    max_duration_minutes
+) VALUES (
+    @name,
+    @description,
+    @color,
+    @organization_id,
+    @facility_id,
+    @default_duration_minutes,
+    @member_bookable,
+    @counts_toward_member_limit,
+    @buffer_minutes,
+    @min_duration_minutes,
+    @max_duration_minutes
+)
+RETURNING *;
+
+-- name: UpdateReservationType :one
+-- Built-in types are shared by every organization and are not editable.
+UPDATE reservation_types
+SET name = @name,
+    description = @description,
+    color = @color,
+    default_duration_minutes = @default_duration_minutes,
+    member_bookable = @member_bookable,
+    counts_toward_member_limit = @counts_toward_member_limit,
+    buffer_minutes = @buffer_minutes,
+    min_duration_minutes = @min_duration_minutes,
+    max_duration_minutes = @max_duration_minutes,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND organization_id IS NOT NULL
+RETURNING *;
+
+-- name: CountReservationTypeUsage :one
+SELECT
+    (SELECT COUNT(*) FROM reservations r WHERE r.reservation_type_id = @id)
+    + (SELECT COUNT(*) FROM cancellation_policy_tiers cpt WHERE cpt.reservation_type_id = @id) AS usage_count;
+
+-- name: DeleteReservationType :execrows
+DELETE FROM reservation_types
+WHERE id = @id
+  AND organization_id IS NOT NULL;
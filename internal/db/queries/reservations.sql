@@ -96,6 +96,39 @@ WHERE r.facility_id = @facility_id
   )
 ORDER BY rc.reservation_id, c.court_number;
 
+-- name: ListReservationCalendarEntries :many
+-- One row per reservation court; reservations with no courts yield a single
+-- row with a NULL court_id. With court_id set, a matching reservation still
+-- returns all of its courts.
+SELECT r.id, r.start_time, r.end_time, r.is_open_event,
+    rt.name AS reservation_type_name,
+    rt.color AS reservation_type_color,
+    rc.court_id,
+    u.first_name AS primary_first_name,
+    u.last_name AS primary_last_name
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+LEFT JOIN reservation_courts rc ON rc.reservation_id = r.id
+LEFT JOIN users u ON u.id = r.primary_user_id
+WHERE r.facility_id = @facility_id
+  AND r.start_time < @end_time
+  AND r.end_time > @start_time
+  AND (
+      sqlc.narg('court_id') IS NULL
+      OR EXISTS (
+          SELECT 1
+          FROM reservation_courts frc
+          WHERE frc.reservation_id = r.id
+            AND frc.court_id = sqlc.narg('court_id')
+      )
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY r.start_time, r.id, rc.court_id;
+
 -- name: UpdateReservation :one
 UPDATE reservations
 SET reservation_type_id = @reservation_type_id,
@@ -115,6 +148,12 @@ RETURNING id, facility_id, reservation_type_id, recurrence_rule_id,
     primary_user_id, created_by_user_id, pro_id, open_play_rule_id, start_time, end_time,
     is_open_event, teams_per_court, people_per_team, created_at, updated_at;
 
+-- name: UpdateReservationPrimaryUser :execrows
+UPDATE reservations
+SET primary_user_id = @primary_user_id,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
 -- name: DeleteReservation :execrows
 DELETE FROM reservations
 WHERE id = @id
@@ -137,20 +176,13 @@ JOIN users u ON u.id = rp.user_id
 WHERE rp.reservation_id = @reservation_id
 ORDER BY u.last_name, u.first_name;
 
--- name: GetReservationType :one
-SELECT id, name, description, color, created_at, updated_at
-FROM reservation_types
-WHERE id = @id;
-
--- name: GetReservationTypeByName :one
-SELECT id, name, description, color, created_at, updated_at
-FROM reservation_types
-WHERE LOWER(name) = LOWER(@name);
-
--- name: ListReservationTypes :many
-SELECT id, name, description, color, created_at, updated_at
-FROM reservation_types
-ORDER BY name;
+-- name: ListParticipantsForReservations :many
+SELECT rp.reservation_id, u.id, u.email, u.first_name, u.last_name
+FROM reservation_participants rp
+JOIN users u ON u.id = rp.user_id
+-- Empty reservation_ids intentionally yields zero rows (caller should prefilter).
+WHERE rp.reservation_id IN (sqlc.slice('reservation_ids'))
+ORDER BY rp.reservation_id, u.last_name, u.first_name;
 
 -- name: DeleteReservationParticipantsByReservationID :exec
 DELETE FROM reservation_participants
@@ -178,6 +210,7 @@ SELECT
     r.created_at,
     r.updated_at,
     f.name AS facility_name,
+    f.timezone AS facility_timezone,
     rt.name AS reservation_type_name,
     s.first_name AS pro_first_name,
     s.last_name AS pro_last_name,
@@ -188,6 +221,7 @@ LEFT JOIN reservation_types rt ON rt.id = r.reservation_type_id
 LEFT JOIN staff s ON s.id = r.pro_id
 LEFT JOIN reservation_courts rc ON rc.reservation_id = r.id
 LEFT JOIN courts c ON c.id = rc.court_id
+CROSS JOIN (SELECT CAST(@oldest_first AS BOOLEAN) AS oldest_first) sort_order
 WHERE (
         r.primary_user_id = @user_id
      OR EXISTS (
@@ -196,12 +230,22 @@ WHERE (
          WHERE rp.reservation_id = r.id
            AND rp.user_id = @user_id
      )
+     OR r.primary_user_id IN (
+         SELECT hl.dependent_user_id
+         FROM household_links hl
+         WHERE hl.primary_user_id = @user_id
+           AND hl.status = 'active'
+     )
   )
   AND NOT EXISTS (
       SELECT 1
       FROM reservation_cancellations rcc
       WHERE rcc.reservation_id = r.id
   )
+  AND (sqlc.narg('facility_id') IS NULL OR r.facility_id = sqlc.narg('facility_id'))
+  AND (sqlc.narg('start_from') IS NULL OR r.start_time >= sqlc.narg('start_from'))
+  AND (sqlc.narg('start_before') IS NULL OR r.start_time < sqlc.narg('start_before'))
+  AND (sqlc.narg('end_after') IS NULL OR r.end_time > sqlc.narg('end_after'))
 GROUP BY r.id,
     r.facility_id,
     r.reservation_type_id,
@@ -218,10 +262,40 @@ GROUP BY r.id,
     r.created_at,
     r.updated_at,
     f.name,
+    f.timezone,
     rt.name,
     s.first_name,
     s.last_name
-ORDER BY r.start_time DESC;
+-- Newest first unless oldest_first is set. A negative limit returns every row.
+ORDER BY CASE WHEN sort_order.oldest_first THEN r.start_time END ASC,
+    r.start_time DESC
+LIMIT @limit OFFSET @offset;
+
+-- name: ListReservationFacilitiesByUserID :many
+SELECT DISTINCT f.id, f.name
+FROM reservations r
+JOIN facilities f ON f.id = r.facility_id
+WHERE (
+        r.primary_user_id = @user_id
+     OR EXISTS (
+         SELECT 1
+         FROM reservation_participants rp
+         WHERE rp.reservation_id = r.id
+           AND rp.user_id = @user_id
+     )
+     OR r.primary_user_id IN (
+         SELECT hl.dependent_user_id
+         FROM household_links hl
+         WHERE hl.primary_user_id = @user_id
+           AND hl.status = 'active'
+     )
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY f.name;
 
 -- name: CountActiveMemberReservations :one
 SELECT COUNT(*)
@@ -230,9 +304,203 @@ JOIN reservation_types rt ON rt.id = r.reservation_type_id
 WHERE r.facility_id = @facility_id
   AND r.primary_user_id = @primary_user_id
   AND r.start_time > CURRENT_TIMESTAMP
-  AND rt.name IN ('GAME', 'PRO_SESSION')
+  AND rt.counts_toward_member_limit = 1
   AND NOT EXISTS (
       SELECT 1
       FROM reservation_cancellations rcc
       WHERE rcc.reservation_id = r.id
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM season_pass_reservations spr
+      WHERE spr.reservation_id = r.id
   );
+
+-- name: CountActiveHouseholdReservations :one
+-- CountActiveMemberReservations summed across every user in user_ids.
+SELECT COUNT(*)
+FROM reservations r
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+WHERE r.facility_id = @facility_id
+  AND r.primary_user_id IN (sqlc.slice('user_ids'))
+  AND r.start_time > CURRENT_TIMESTAMP
+  AND rt.counts_toward_member_limit = 1
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+  AND NOT EXISTS (
+      SELECT 1
+      FROM season_pass_reservations spr
+      WHERE spr.reservation_id = r.id
+  );
+
+-- name: ListUpcomingReservationIDsForPrimaryUser :many
+SELECT r.id
+FROM reservations r
+WHERE r.primary_user_id = @primary_user_id
+  AND r.start_time > @comparison_time
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY r.start_time, r.id;
+
+-- name: RemoveUserFromUpcomingReservations :execrows
+-- Takes the user off every future participant list, open play signups
+-- included, without touching the reservations themselves.
+DELETE FROM reservation_participants
+WHERE user_id = @user_id
+  AND reservation_id IN (
+      SELECT r.id
+      FROM reservations r
+      WHERE r.start_time > @comparison_time
+        AND NOT EXISTS (
+            SELECT 1
+            FROM reservation_cancellations rcc
+            WHERE rcc.reservation_id = r.id
+        )
+  );
+
+-- name: GetReservationClosureDetails :one
+SELECT reservation_id, public_reason, internal_notes, created_at, updated_at
+FROM reservation_closure_details
+WHERE reservation_id = @reservation_id;
+
+-- name: UpsertReservationClosureDetails :exec
+INSERT INTO reservation_closure_details (reservation_id, public_reason, internal_notes)
+VALUES (@reservation_id, @public_reason, @internal_notes)
+ON CONFLICT(reservation_id) DO UPDATE SET
+    public_reason = excluded.public_reason,
+    internal_notes = excluded.internal_notes,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteReservationClosureDetails :exec
+DELETE FROM reservation_closure_details
+WHERE reservation_id = @reservation_id;
+
+-- name: GetMemberOverlappingReservation :one
+-- The earliest active reservation, at any facility, that the user is the
+-- primary user or a participant on and that overlaps [start_time, end_time),
+-- other than exclude_reservation_id (zero excludes nothing).
+SELECT
+    r.id,
+    r.facility_id,
+    r.start_time,
+    r.end_time,
+    f.name AS facility_name,
+    rt.name AS reservation_type_name,
+    CAST(COALESCE(group_concat(DISTINCT COALESCE(NULLIF(c.name, ''), 'Court ' || c.court_number)), '') AS TEXT) AS court_names
+FROM reservations r
+JOIN facilities f ON f.id = r.facility_id
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+LEFT JOIN reservation_courts rc ON rc.reservation_id = r.id
+LEFT JOIN courts c ON c.id = rc.court_id
+WHERE (
+        r.primary_user_id = @user_id
+     OR EXISTS (
+         SELECT 1
+         FROM reservation_participants rp
+         WHERE rp.reservation_id = r.id
+           AND rp.user_id = @user_id
+     )
+  )
+  AND r.start_time < @end_time
+  AND r.end_time > @start_time
+  AND r.id != @exclude_reservation_id
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+GROUP BY r.id, r.facility_id, r.start_time, r.end_time, f.name, rt.name
+ORDER BY r.start_time, r.id
+LIMIT 1;
+
+-- name: FindMatchingReservation :one
+-- An active reservation already booking court_id for the same type, primary
+-- user, and times, so a repeated import can skip it.
+SELECT r.id
+FROM reservations r
+JOIN reservation_courts rc ON rc.reservation_id = r.id
+WHERE r.facility_id = @facility_id
+  AND r.reservation_type_id = @reservation_type_id
+  AND r.primary_user_id = @primary_user_id
+  AND r.start_time = @start_time
+  AND r.end_time = @end_time
+  AND rc.court_id = @court_id
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+LIMIT 1;
+
+-- name: ListCourtOverlapConflicts :many
+-- Pairs of reservations holding the same court at overlapping times, each
+-- pair once per shared court. Cancelled reservations have released their
+-- courts, so they never appear.
+SELECT
+    c.id AS court_id,
+    c.court_number,
+    c.name AS court_name,
+    a.id AS first_reservation_id,
+    a.start_time AS first_start_time,
+    a.end_time AS first_end_time,
+    a.primary_user_id AS first_primary_user_id,
+    rta.name AS first_reservation_type,
+    b.id AS second_reservation_id,
+    b.start_time AS second_start_time,
+    b.end_time AS second_end_time,
+    b.primary_user_id AS second_primary_user_id,
+    rtb.name AS second_reservation_type
+FROM reservations a
+JOIN reservation_courts ac ON ac.reservation_id = a.id
+JOIN reservation_courts bc ON bc.court_id = ac.court_id AND bc.reservation_id > a.id
+JOIN reservations b ON b.id = bc.reservation_id
+JOIN courts c ON c.id = ac.court_id
+JOIN reservation_types rta ON rta.id = a.reservation_type_id
+JOIN reservation_types rtb ON rtb.id = b.reservation_type_id
+WHERE a.facility_id = @facility_id
+  AND a.start_time < @end_time
+  AND a.end_time > @start_time
+  AND b.facility_id = @facility_id
+  AND b.start_time < a.end_time
+  AND b.end_time > a.start_time
+ORDER BY a.start_time, c.court_number, a.id, b.id;
+
+-- name: ReservationsOverlapOnCourt :one
+-- Whether two reservations at the facility still hold a shared court at
+-- overlapping times.
+SELECT EXISTS (
+    SELECT 1
+    FROM reservations a
+    JOIN reservation_courts ac ON ac.reservation_id = a.id
+    JOIN reservation_courts bc ON bc.court_id = ac.court_id
+    JOIN reservations b ON b.id = bc.reservation_id
+    WHERE a.id = @reservation_id
+      AND b.id = @other_reservation_id
+      AND a.id != b.id
+      AND a.facility_id = @facility_id
+      AND b.facility_id = @facility_id
+      AND a.start_time < b.end_time
+      AND b.start_time < a.end_time
+) AS overlapping;
+
+-- name: ListUpcomingCourtReservations :many
+-- Active reservations on court_id that have not ended by now, including one
+-- in progress, which a court taken out of service would strand.
+SELECT r.id, r.facility_id, r.start_time, r.end_time, r.primary_user_id, rt.name AS reservation_type
+FROM reservations r
+JOIN reservation_courts rc ON rc.reservation_id = r.id
+JOIN reservation_types rt ON rt.id = r.reservation_type_id
+WHERE rc.court_id = @court_id
+  AND r.end_time > @now
+  AND NOT EXISTS (
+      SELECT 1
+      FROM reservation_cancellations rcc
+      WHERE rcc.reservation_id = r.id
+  )
+ORDER BY r.start_time, r.id;
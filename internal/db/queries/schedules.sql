@@ -24,3 +24,43 @@ RETURNING *;
 -- name: DeleteOperatingHours :execrows
 DELETE FROM operating_hours
 WHERE facility_id = ? AND day_of_week = ?;
+
+-- name: UpsertFacilityHoursOverride :one
+INSERT INTO facility_hours_overrides (
+    facility_id,
+    override_date,
+    is_closed,
+    opens_at,
+    closes_at,
+    reason
+) VALUES (
+    @facility_id,
+    @override_date,
+    @is_closed,
+    @opens_at,
+    @closes_at,
+    @reason
+)
+ON CONFLICT(facility_id, override_date) DO UPDATE SET
+    is_closed = excluded.is_closed,
+    opens_at = excluded.opens_at,
+    closes_at = excluded.closes_at,
+    reason = excluded.reason,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetFacilityHoursOverride :one
+SELECT * FROM facility_hours_overrides
+WHERE facility_id = @facility_id
+  AND override_date = @override_date;
+
+-- name: ListFacilityHoursOverrides :many
+SELECT * FROM facility_hours_overrides
+WHERE facility_id = @facility_id
+  AND override_date >= @from_date
+ORDER BY override_date;
+
+-- name: DeleteFacilityHoursOverride :execrows
+DELETE FROM facility_hours_overrides
+WHERE facility_id = @facility_id
+  AND override_date = @override_date;
@@ -0,0 +1,179 @@
+-- internal/db/queries/season_passes.sql
+
+-- name: CreateSeasonPassType :one
+INSERT INTO season_pass_types (
+    facility_id,
+    name,
+    price_cents,
+    valid_from,
+    valid_until,
+    status
+) VALUES (
+    @facility_id,
+    @name,
+    @price_cents,
+    @valid_from,
+    @valid_until,
+    @status
+)
+RETURNING id, facility_id, name, price_cents, valid_from, valid_until, status,
+    created_at, updated_at;
+
+-- name: ListSeasonPassTypes :many
+SELECT id, facility_id, name, price_cents, valid_from, valid_until, status,
+    created_at, updated_at
+FROM season_pass_types
+WHERE facility_id = @facility_id
+ORDER BY valid_from DESC, name;
+
+-- name: GetSeasonPassType :one
+SELECT id, facility_id, name, price_cents, valid_from, valid_until, status,
+    created_at, updated_at
+FROM season_pass_types
+WHERE id = @id
+  AND facility_id = @facility_id;
+
+-- name: UpdateSeasonPassType :one
+UPDATE season_pass_types
+SET name = @name,
+    price_cents = @price_cents,
+    valid_from = @valid_from,
+    valid_until = @valid_until,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND facility_id = @facility_id
+RETURNING id, facility_id, name, price_cents, valid_from, valid_until, status,
+    created_at, updated_at;
+
+-- name: DeactivateSeasonPassType :one
+UPDATE season_pass_types
+SET status = 'inactive',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND facility_id = @facility_id
+RETURNING id, facility_id, name, price_cents, valid_from, valid_until, status,
+    created_at, updated_at;
+
+-- name: CreateSeasonPassWindow :one
+INSERT INTO season_pass_windows (
+    pass_type_id,
+    day_of_week,
+    starts_at,
+    ends_at
+) VALUES (
+    @pass_type_id,
+    @day_of_week,
+    @starts_at,
+    @ends_at
+)
+RETURNING id, pass_type_id, day_of_week, starts_at, ends_at;
+
+-- name: DeleteSeasonPassWindows :exec
+DELETE FROM season_pass_windows
+WHERE pass_type_id = @pass_type_id;
+
+-- name: ListSeasonPassWindows :many
+SELECT id, pass_type_id, day_of_week, starts_at, ends_at
+FROM season_pass_windows
+WHERE pass_type_id = @pass_type_id
+ORDER BY day_of_week, starts_at;
+
+-- name: CreateSeasonPass :one
+INSERT INTO season_passes (
+    pass_type_id,
+    user_id,
+    purchased_at,
+    price_paid_cents,
+    granted_by_user_id,
+    status
+)
+SELECT
+    spt.id,
+    @user_id,
+    @purchased_at,
+    @price_paid_cents,
+    @granted_by_user_id,
+    'active'
+FROM season_pass_types spt
+WHERE spt.id = @pass_type_id
+  AND spt.status = 'active'
+RETURNING id, pass_type_id, user_id, purchased_at, price_paid_cents,
+    granted_by_user_id, status, created_at, updated_at;
+
+-- name: ListActiveSeasonPassesForUserByFacility :many
+SELECT sp.id AS season_pass_id,
+    sp.pass_type_id,
+    spt.name AS pass_type_name,
+    spt.valid_from,
+    spt.valid_until
+FROM season_passes sp
+JOIN season_pass_types spt ON spt.id = sp.pass_type_id
+WHERE sp.user_id = @user_id
+  AND spt.facility_id = @facility_id
+  AND sp.status = 'active'
+  AND spt.status = 'active'
+  AND spt.valid_until >= @on_date
+ORDER BY spt.valid_from, sp.id;
+
+-- name: ListSeasonPassUsageForUser :many
+SELECT sp.id AS season_pass_id,
+    sp.pass_type_id,
+    spt.facility_id,
+    spt.name AS pass_type_name,
+    spt.valid_from,
+    spt.valid_until,
+    sp.purchased_at,
+    sp.price_paid_cents,
+    sp.status,
+    (
+        SELECT COUNT(*)
+        FROM season_pass_reservations spr
+        WHERE spr.season_pass_id = sp.id
+          AND NOT EXISTS (
+              SELECT 1
+              FROM reservation_cancellations rc
+              WHERE rc.reservation_id = spr.reservation_id
+          )
+    ) AS covered_bookings
+FROM season_passes sp
+JOIN season_pass_types spt ON spt.id = sp.pass_type_id
+WHERE sp.user_id = @user_id
+ORDER BY spt.valid_until DESC, sp.id DESC;
+
+-- name: CreateSeasonPassReservation :one
+INSERT INTO season_pass_reservations (
+    season_pass_id,
+    reservation_id
+) VALUES (
+    @season_pass_id,
+    @reservation_id
+)
+RETURNING id, season_pass_id, reservation_id, created_at;
+
+-- name: SeasonPassCoverageReport :many
+SELECT sp.id AS season_pass_id,
+    sp.user_id,
+    u.first_name,
+    u.last_name,
+    u.email,
+    spt.id AS pass_type_id,
+    spt.name AS pass_type_name,
+    spt.valid_from,
+    spt.valid_until,
+    sp.price_paid_cents,
+    sp.status,
+    COUNT(r.id) AS covered_bookings,
+    CAST(COALESCE(MAX(r.start_time), '') AS TEXT) AS last_booking_start
+FROM season_passes sp
+JOIN season_pass_types spt ON spt.id = sp.pass_type_id
+JOIN users u ON u.id = sp.user_id
+LEFT JOIN season_pass_reservations spr ON spr.season_pass_id = sp.id
+LEFT JOIN reservations r ON r.id = spr.reservation_id
+    AND NOT EXISTS (
+        SELECT 1
+        FROM reservation_cancellations rc
+        WHERE rc.reservation_id = r.id
+    )
+WHERE spt.facility_id = @facility_id
+GROUP BY sp.id
+ORDER BY covered_bookings DESC, u.last_name, u.first_name;
@@ -182,6 +182,11 @@ WITH RECURSIVE
         FROM reservations r
         JOIN hours ON r.start_time < hours.day_close AND r.end_time > hours.day_open
         WHERE r.pro_id = @pro_id
+          AND NOT EXISTS (
+              SELECT 1
+              FROM reservation_cancellations rcc
+              WHERE rcc.reservation_id = r.id
+          )
         UNION ALL
         SELECT pu.start_time, pu.end_time
         FROM pro_unavailability pu
@@ -0,0 +1,74 @@
+-- internal/db/queries/staff_notifications.sql
+
+-- name: ListStaffInboxNotifications :many
+-- A staff member's inbox: notifications addressed to them plus those with no
+-- target, which go to every staff member at the facility. A NULL facility_id
+-- covers every facility for corporate staff; a NULL staff_id sees only
+-- untargeted notifications.
+SELECT id, facility_id, notification_type, message, related_session_id,
+    related_reservation_id, related_clinic_session_id, target_staff_id, read,
+    created_at, updated_at
+FROM staff_notifications
+WHERE (@facility_id IS NULL OR facility_id = @facility_id)
+  AND (target_staff_id IS NULL OR target_staff_id = @staff_id)
+  AND (NOT CAST(@unread_only AS BOOLEAN) OR read = 0)
+ORDER BY created_at DESC, id DESC
+LIMIT @limit OFFSET @offset;
+
+-- name: CountUnreadStaffInboxNotifications :one
+SELECT COUNT(*)
+FROM staff_notifications
+WHERE (@facility_id IS NULL OR facility_id = @facility_id)
+  AND (target_staff_id IS NULL OR target_staff_id = @staff_id)
+  AND read = 0;
+
+-- name: SetStaffInboxNotificationRead :one
+UPDATE staff_notifications
+SET read = @read,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND (@facility_id IS NULL OR facility_id = @facility_id)
+  AND (target_staff_id IS NULL OR target_staff_id = @staff_id)
+RETURNING id, facility_id, notification_type, message, related_session_id,
+    related_reservation_id, related_clinic_session_id, target_staff_id, read,
+    created_at, updated_at;
+
+-- name: CreateLessonReassignedNotification :one
+INSERT INTO staff_notifications (
+    facility_id,
+    notification_type,
+    message,
+    related_reservation_id,
+    target_staff_id
+)
+VALUES (
+    @facility_id,
+    'lesson_reassigned',
+    @message,
+    @related_reservation_id,
+    @target_staff_id
+)
+RETURNING id, facility_id, notification_type, message, related_session_id,
+    related_reservation_id, related_clinic_session_id, target_staff_id, read,
+    created_at, updated_at;
+
+-- name: CreateWaitlistFulfilledNotification :one
+INSERT INTO staff_notifications (
+    facility_id,
+    notification_type,
+    message,
+    related_reservation_id
+)
+VALUES (
+    @facility_id,
+    'waitlist_fulfilled',
+    @message,
+    @related_reservation_id
+)
+RETURNING id, facility_id, notification_type, message, related_session_id,
+    related_reservation_id, related_clinic_session_id, target_staff_id, read,
+    created_at, updated_at;
+
+-- name: DeleteStaffNotificationsBefore :execrows
+DELETE FROM staff_notifications
+WHERE created_at < @cutoff;
@@ -0,0 +1,89 @@
+-- internal/db/queries/sync.sql
+
+-- name: ListSyncChanges :many
+-- One row per changed entity, ordered by its latest change.
+SELECT entity_id,
+    CAST(MAX(seq) AS INTEGER) AS last_seq,
+    CAST(MAX(changed_at) AS TEXT) AS last_changed_at
+FROM sync_changes
+WHERE facility_id = @facility_id
+  AND entity_type = @entity_type
+  AND seq > @after_seq
+  AND changed_at > @updated_since
+GROUP BY entity_id
+ORDER BY last_seq
+LIMIT @limit;
+
+-- name: GetLatestSyncSeq :one
+SELECT CAST(COALESCE(MAX(seq), 0) AS INTEGER) AS seq
+FROM sync_changes;
+
+-- name: ListReservationsForSync :many
+SELECT r.id, r.facility_id, r.reservation_type_id, r.recurrence_rule_id,
+    r.primary_user_id, r.created_by_user_id, r.pro_id, r.open_play_rule_id, r.start_time, r.end_time,
+    r.is_open_event, r.teams_per_court, r.people_per_team, r.created_at, r.updated_at,
+    EXISTS (
+        SELECT 1
+        FROM reservation_cancellations rcc
+        WHERE rcc.reservation_id = r.id
+    ) AS cancelled
+FROM reservations r
+WHERE r.facility_id = @facility_id
+  AND r.id IN (sqlc.slice('ids'));
+
+-- name: ListOpenPlaySessionCapacity :many
+SELECT ops.id,
+    ops.facility_id,
+    ops.open_play_rule_id,
+    opr.name AS rule_name,
+    ops.start_time,
+    ops.end_time,
+    ops.status,
+    ops.current_court_count,
+    opr.min_participants,
+    opr.max_participants_per_court,
+    (
+        SELECT COUNT(*)
+        FROM reservation_participants rp
+        JOIN reservations r ON r.id = rp.reservation_id
+        JOIN reservation_types rt ON rt.id = r.reservation_type_id
+        WHERE r.facility_id = ops.facility_id
+          AND r.open_play_rule_id = ops.open_play_rule_id
+          AND r.start_time = ops.start_time
+          AND r.end_time = ops.end_time
+          AND rt.name = 'OPEN_PLAY'
+    ) AS participant_count,
+    ops.updated_at
+FROM open_play_sessions ops
+JOIN open_play_rules opr ON opr.id = ops.open_play_rule_id
+WHERE ops.facility_id = @facility_id
+  AND ops.end_time > @comparison_time
+ORDER BY ops.start_time, ops.id;
+
+-- name: ListOpenPlaySessionCapacityByIDs :many
+SELECT ops.id,
+    ops.facility_id,
+    ops.open_play_rule_id,
+    opr.name AS rule_name,
+    ops.start_time,
+    ops.end_time,
+    ops.status,
+    ops.current_court_count,
+    opr.min_participants,
+    opr.max_participants_per_court,
+    (
+        SELECT COUNT(*)
+        FROM reservation_participants rp
+        JOIN reservations r ON r.id = rp.reservation_id
+        JOIN reservation_types rt ON rt.id = r.reservation_type_id
+        WHERE r.facility_id = ops.facility_id
+          AND r.open_play_rule_id = ops.open_play_rule_id
+          AND r.start_time = ops.start_time
+          AND r.end_time = ops.end_time
+          AND rt.name = 'OPEN_PLAY'
+    ) AS participant_count,
+    ops.updated_at
+FROM open_play_sessions ops
+JOIN open_play_rules opr ON opr.id = ops.open_play_rule_id
+WHERE ops.facility_id = @facility_id
+  AND ops.id IN (sqlc.slice('ids'));
@@ -0,0 +1,157 @@
+-- internal/db/queries/tournaments.sql
+
+-- name: CreateTournament :one
+INSERT INTO tournaments (
+    facility_id,
+    league_id,
+    name,
+    format,
+    seeding,
+    created_by_user_id
+) VALUES (
+    @facility_id,
+    @league_id,
+    @name,
+    @format,
+    @seeding,
+    @created_by_user_id
+)
+RETURNING id, facility_id, league_id, name, format, seeding, status,
+    champion_team_id, created_by_user_id, created_at, updated_at;
+
+-- name: GetTournament :one
+SELECT id, facility_id, league_id, name, format, seeding, status,
+    champion_team_id, created_by_user_id, created_at, updated_at
+FROM tournaments
+WHERE id = @id;
+
+-- name: ListTournamentsByFacility :many
+SELECT id, facility_id, league_id, name, format, seeding, status,
+    champion_team_id, created_by_user_id, created_at, updated_at
+FROM tournaments
+WHERE facility_id = @facility_id
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateTournamentStatus :execrows
+UPDATE tournaments
+SET status = @status,
+    champion_team_id = @champion_team_id,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: AddTournamentEntrant :exec
+INSERT INTO tournament_entrants (
+    tournament_id,
+    league_team_id,
+    seed
+) VALUES (
+    @tournament_id,
+    @league_team_id,
+    @seed
+);
+
+-- name: ListTournamentEntrants :many
+SELECT te.tournament_id, te.league_team_id, te.seed, lt.name AS team_name
+FROM tournament_entrants te
+JOIN league_teams lt ON lt.id = te.league_team_id
+WHERE te.tournament_id = @tournament_id
+ORDER BY te.seed;
+
+-- name: CreateTournamentMatch :one
+INSERT INTO tournament_matches (
+    tournament_id,
+    bracket,
+    round_number,
+    position,
+    team_a_id,
+    team_b_id,
+    winner_to_match_id,
+    winner_to_slot,
+    loser_to_match_id,
+    loser_to_slot,
+    status
+) VALUES (
+    @tournament_id,
+    @bracket,
+    @round_number,
+    @position,
+    @team_a_id,
+    @team_b_id,
+    @winner_to_match_id,
+    @winner_to_slot,
+    @loser_to_match_id,
+    @loser_to_slot,
+    @status
+)
+RETURNING id, tournament_id, bracket, round_number, position, team_a_id, team_b_id,
+    team_a_score, team_b_score, winner_team_id, winner_to_match_id, winner_to_slot,
+    loser_to_match_id, loser_to_slot, status, court_id, scheduled_time, reservation_id,
+    created_at, updated_at;
+
+-- name: GetTournamentMatch :one
+SELECT id, tournament_id, bracket, round_number, position, team_a_id, team_b_id,
+    team_a_score, team_b_score, winner_team_id, winner_to_match_id, winner_to_slot,
+    loser_to_match_id, loser_to_slot, status, court_id, scheduled_time, reservation_id,
+    created_at, updated_at
+FROM tournament_matches
+WHERE id = @id
+  AND tournament_id = @tournament_id;
+
+-- name: ListTournamentMatches :many
+SELECT id, tournament_id, bracket, round_number, position, team_a_id, team_b_id,
+    team_a_score, team_b_score, winner_team_id, winner_to_match_id, winner_to_slot,
+    loser_to_match_id, loser_to_slot, status, court_id, scheduled_time, reservation_id,
+    created_at, updated_at
+FROM tournament_matches
+WHERE tournament_id = @tournament_id
+ORDER BY CASE bracket WHEN 'winners' THEN 1 WHEN 'consolation' THEN 2 ELSE 3 END,
+    round_number, position;
+
+-- name: SetTournamentMatchTeamA :one
+UPDATE tournament_matches
+SET team_a_id = @team_id,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+RETURNING id, tournament_id, bracket, round_number, position, team_a_id, team_b_id,
+    team_a_score, team_b_score, winner_team_id, winner_to_match_id, winner_to_slot,
+    loser_to_match_id, loser_to_slot, status, court_id, scheduled_time, reservation_id,
+    created_at, updated_at;
+
+-- name: SetTournamentMatchTeamB :one
+UPDATE tournament_matches
+SET team_b_id = @team_id,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+RETURNING id, tournament_id, bracket, round_number, position, team_a_id, team_b_id,
+    team_a_score, team_b_score, winner_team_id, winner_to_match_id, winner_to_slot,
+    loser_to_match_id, loser_to_slot, status, court_id, scheduled_time, reservation_id,
+    created_at, updated_at;
+
+-- name: UpdateTournamentMatchStatus :execrows
+UPDATE tournament_matches
+SET status = @status,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: SetTournamentMatchWinner :execrows
+UPDATE tournament_matches
+SET team_a_score = @team_a_score,
+    team_b_score = @team_b_score,
+    winner_team_id = @winner_team_id,
+    status = @status,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: ScheduleTournamentMatch :execrows
+UPDATE tournament_matches
+SET court_id = @court_id,
+    scheduled_time = @scheduled_time,
+    reservation_id = @reservation_id,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND tournament_id = @tournament_id;
+
+-- name: CountTournamentMatches :one
+SELECT COUNT(*)
+FROM tournament_matches
+WHERE tournament_id = @tournament_id;
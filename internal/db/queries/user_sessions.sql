@@ -0,0 +1,53 @@
+-- internal/db/queries/user_sessions.sql
+
+-- name: CreateUserSession :one
+INSERT INTO user_sessions (
+    token, user_id, session_type, user_agent, ip_address, created_at, last_seen_at, expires_at
+) VALUES (
+    @token, @user_id, @session_type, @user_agent, @ip_address, @created_at, @created_at, @expires_at
+)
+RETURNING id, token, user_id, session_type, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at;
+
+-- name: GetUserSessionByToken :one
+SELECT id, token, user_id, session_type, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at
+FROM user_sessions
+WHERE token = @token;
+
+-- name: ListActiveUserSessions :many
+SELECT id, token, user_id, session_type, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at
+FROM user_sessions
+WHERE user_id = @user_id
+  AND revoked_at IS NULL
+  AND expires_at > @now
+ORDER BY last_seen_at DESC, id DESC;
+
+-- name: TouchUserSession :exec
+UPDATE user_sessions
+SET last_seen_at = @last_seen_at
+WHERE id = @id;
+
+-- name: RevokeUserSession :execrows
+UPDATE user_sessions
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND user_id = @user_id
+  AND revoked_at IS NULL;
+
+-- name: RevokeUserSessionByToken :exec
+UPDATE user_sessions
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE token = @token
+  AND revoked_at IS NULL;
+
+-- name: RevokeOtherUserSessions :execrows
+-- Revokes every live session of the user except keep_id; pass 0 to revoke
+-- them all.
+UPDATE user_sessions
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE user_id = @user_id
+  AND id != @keep_id
+  AND revoked_at IS NULL;
+
+-- name: DeleteExpiredUserSessions :execrows
+DELETE FROM user_sessions
+WHERE expires_at <= @now;
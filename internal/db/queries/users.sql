@@ -49,3 +49,34 @@ UPDATE users
 SET password_hash = @password_hash,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = @id;
+
+-- name: UpdateUserHideFromRosters :exec
+UPDATE users
+SET hide_from_rosters = @hide_from_rosters,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: UpdateUserHomeFacility :exec
+UPDATE users
+SET home_facility_id = @home_facility_id,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: GetUserNotificationPreferences :one
+SELECT notify_confirmations,
+    notify_cancellations,
+    notify_waitlist_offers,
+    notify_open_play_reminders,
+    sms_opt_in
+FROM users
+WHERE id = @id;
+
+-- name: UpdateUserNotificationPreferences :exec
+UPDATE users
+SET notify_confirmations = @notify_confirmations,
+    notify_cancellations = @notify_cancellations,
+    notify_waitlist_offers = @notify_waitlist_offers,
+    notify_open_play_reminders = @notify_open_play_reminders,
+    sms_opt_in = @sms_opt_in,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
@@ -36,11 +36,11 @@ WHERE id = @id
 -- name: GetVisitPackRedemptionInfo :one
 SELECT vp.id AS visit_pack_id,
     vp.pack_type_id,
-    vpt.facility_id AS pack_facility_id,
+    CAST(COALESCE(vp.facility_id, vpt.facility_id) AS INTEGER) AS pack_facility_id,
     f.organization_id AS organization_id
 FROM visit_packs vp
 JOIN visit_pack_types vpt ON vpt.id = vp.pack_type_id
-JOIN facilities f ON f.id = vpt.facility_id
+JOIN facilities f ON f.id = COALESCE(vp.facility_id, vpt.facility_id)
 WHERE vp.id = @id;
 
 -- name: UpdateVisitPackType :one
@@ -89,18 +89,18 @@ FROM visit_pack_types vpt
 WHERE vpt.id = @pack_type_id
   AND vpt.status = 'active'
 RETURNING id, pack_type_id, user_id, purchase_date, expires_at,
-    visits_remaining, status, created_at, updated_at;
+    visits_remaining, status, created_at, updated_at, facility_id;
 
 -- name: GetVisitPack :one
 SELECT id, pack_type_id, user_id, purchase_date, expires_at,
-    visits_remaining, status, created_at, updated_at
+    visits_remaining, status, created_at, updated_at, facility_id
 FROM visit_packs
 WHERE id = @id
   AND user_id = @user_id;
 
 -- name: ListActiveVisitPacksForUser :many
 SELECT id, pack_type_id, user_id, purchase_date, expires_at,
-    visits_remaining, status, created_at, updated_at
+    visits_remaining, status, created_at, updated_at, facility_id
 FROM visit_packs
 WHERE user_id = @user_id
   AND status = 'active'
@@ -110,11 +110,11 @@ ORDER BY expires_at;
 
 -- name: ListActiveVisitPacksForUserByFacility :many
 SELECT vp.id, vp.pack_type_id, vp.user_id, vp.purchase_date, vp.expires_at,
-    vp.visits_remaining, vp.status, vp.created_at, vp.updated_at
+    vp.visits_remaining, vp.status, vp.created_at, vp.updated_at, vp.facility_id
 FROM visit_packs vp
 JOIN visit_pack_types vpt ON vpt.id = vp.pack_type_id
 WHERE vp.user_id = @user_id
-  AND vpt.facility_id = @facility_id
+  AND COALESCE(vp.facility_id, vpt.facility_id) = CAST(@facility_id AS INTEGER)
   AND vp.status = 'active'
   AND vp.visits_remaining > 0
   AND vp.expires_at > @comparison_time
@@ -122,10 +122,10 @@ ORDER BY vp.expires_at;
 
 -- name: ListActiveVisitPacksForUserByOrganization :many
 SELECT vp.id, vp.pack_type_id, vp.user_id, vp.purchase_date, vp.expires_at,
-    vp.visits_remaining, vp.status, vp.created_at, vp.updated_at
+    vp.visits_remaining, vp.status, vp.created_at, vp.updated_at, vp.facility_id
 FROM visit_packs vp
 JOIN visit_pack_types vpt ON vpt.id = vp.pack_type_id
-JOIN facilities f ON f.id = vpt.facility_id
+JOIN facilities f ON f.id = COALESCE(vp.facility_id, vpt.facility_id)
 WHERE vp.user_id = @user_id
   AND f.organization_id = @organization_id
   AND vp.status = 'active'
@@ -133,6 +133,20 @@ WHERE vp.user_id = @user_id
   AND vp.expires_at > @comparison_time
 ORDER BY vp.expires_at;
 
+-- name: TransferActiveVisitPacks :execrows
+-- Moves a member's usable packs held at from_facility_id to to_facility_id.
+UPDATE visit_packs
+SET facility_id = CAST(@to_facility_id AS INTEGER),
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = @user_id
+  AND status = 'active'
+  AND visits_remaining > 0
+  AND expires_at > @comparison_time
+  AND COALESCE(
+      visit_packs.facility_id,
+      (SELECT vpt.facility_id FROM visit_pack_types vpt WHERE vpt.id = visit_packs.pack_type_id)
+  ) = CAST(@from_facility_id AS INTEGER);
+
 -- name: DecrementVisitPackVisit :one
 UPDATE visit_packs
 SET visits_remaining = visits_remaining - 1,
@@ -146,7 +160,7 @@ WHERE id = @id
   AND visits_remaining > 0
   AND expires_at > CURRENT_TIMESTAMP
 RETURNING id, pack_type_id, user_id, purchase_date, expires_at,
-    visits_remaining, status, created_at, updated_at;
+    visits_remaining, status, created_at, updated_at, facility_id;
 
 -- name: CreateVisitPackRedemption :one
 INSERT INTO visit_pack_redemptions (
@@ -161,3 +175,76 @@ INSERT INTO visit_pack_redemptions (
     @reservation_id
 )
 RETURNING id, visit_pack_id, facility_id, redeemed_at, reservation_id, created_at;
+
+-- name: CreateVisitPackPayment :one
+INSERT INTO visit_pack_payments (
+    visit_pack_id,
+    amount_cents,
+    processor,
+    reference
+) VALUES (
+    @visit_pack_id,
+    @amount_cents,
+    @processor,
+    @reference
+)
+RETURNING id, visit_pack_id, amount_cents, processor, reference, created_at;
+
+-- name: ListMemberPaidVisitsPage :many
+-- Paid visits for the visit history export: the member's own reservations,
+-- with the visit pack that paid for them (0 when none), and visit pack
+-- redemptions made without a reservation (e.g. at check-in).
+SELECT
+    visit_at,
+    facility_id,
+    reservation_type,
+    CAST(court_names AS TEXT) AS court_names,
+    CAST(COALESCE(visit_pack_id, 0) AS INTEGER) AS visit_pack_id
+FROM (
+    SELECT
+        r.start_time AS visit_at,
+        r.facility_id AS facility_id,
+        rt.name AS reservation_type,
+        COALESCE((
+            SELECT GROUP_CONCAT(c.name, ', ')
+            FROM reservation_courts rc
+            JOIN courts c ON c.id = rc.court_id
+            WHERE rc.reservation_id = r.id
+        ), '') AS court_names,
+        (
+            SELECT vpr.visit_pack_id
+            FROM visit_pack_redemptions vpr
+            WHERE vpr.reservation_id = r.id
+            ORDER BY vpr.id
+            LIMIT 1
+        ) AS visit_pack_id,
+        0 AS sort_kind,
+        r.id AS sort_id
+    FROM reservations r
+    JOIN reservation_types rt ON rt.id = r.reservation_type_id
+    WHERE r.primary_user_id = @user_id
+      AND r.start_time >= @start_from
+      AND r.start_time < @start_before
+      AND (sqlc.narg('facility_id') IS NULL OR r.facility_id = sqlc.narg('facility_id'))
+      AND NOT EXISTS (
+          SELECT 1 FROM reservation_cancellations rcc WHERE rcc.reservation_id = r.id
+      )
+    UNION ALL
+    SELECT
+        vpr.redeemed_at AS visit_at,
+        vpr.facility_id AS facility_id,
+        '' AS reservation_type,
+        '' AS court_names,
+        vpr.visit_pack_id AS visit_pack_id,
+        1 AS sort_kind,
+        vpr.id AS sort_id
+    FROM visit_pack_redemptions vpr
+    JOIN visit_packs vp ON vp.id = vpr.visit_pack_id
+    WHERE vp.user_id = @user_id
+      AND vpr.reservation_id IS NULL
+      AND vpr.redeemed_at >= @start_from
+      AND vpr.redeemed_at < @start_before
+      AND (sqlc.narg('facility_id') IS NULL OR vpr.facility_id = sqlc.narg('facility_id'))
+) AS visits
+ORDER BY visit_at, sort_kind, sort_id
+LIMIT @page_size OFFSET @page_offset;
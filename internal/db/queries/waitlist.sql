@@ -8,7 +8,9 @@ INSERT INTO waitlists (
     target_start_time,
     target_end_time,
     position,
-    status
+    status,
+    auto_book,
+    use_visit_pack
 ) SELECT
     @facility_id,
     @user_id,
@@ -17,12 +19,15 @@ INSERT INTO waitlists (
     @target_start_time,
     @target_end_time,
     COALESCE(MAX(position), 0) + 1,
-    @status
+    @status,
+    @auto_book,
+    @use_visit_pack
 FROM waitlists
 WHERE facility_id = @facility_id
   AND target_date = @target_date
   AND target_start_time = @target_start_time
   AND target_end_time = @target_end_time
+  AND status IN ('pending', 'notified')
   AND (
       target_court_id = @target_court_id
       OR (@target_court_id IS NULL AND target_court_id IS NULL)
@@ -38,7 +43,9 @@ RETURNING
     position,
     status,
     created_at,
-    updated_at;
+    updated_at,
+    auto_book,
+    use_visit_pack;
 
 -- name: GetWaitlistEntry :one
 SELECT
@@ -52,7 +59,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE id = @id
   AND facility_id = @facility_id
@@ -70,7 +79,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE facility_id = @facility_id
   AND target_date = @target_date
@@ -94,7 +105,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE facility_id = @facility_id
   AND target_date = @target_date
@@ -119,7 +132,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE user_id = @user_id
 ORDER BY created_at DESC;
@@ -136,7 +151,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE user_id = @user_id
   AND facility_id = @facility_id
@@ -154,7 +171,9 @@ SELECT
     position,
     status,
     created_at,
-    updated_at
+    updated_at,
+    auto_book,
+    use_visit_pack
 FROM waitlists
 WHERE facility_id = @facility_id
 ORDER BY target_date, target_start_time, position;
@@ -176,13 +195,98 @@ RETURNING
     position,
     status,
     created_at,
-    updated_at;
+    updated_at,
+    auto_book,
+    use_visit_pack;
+
+-- name: UpdateWaitlistAutoBook :one
+UPDATE waitlists
+SET auto_book = @auto_book,
+    use_visit_pack = @use_visit_pack,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+  AND facility_id = @facility_id
+RETURNING
+    id,
+    facility_id,
+    user_id,
+    target_court_id,
+    target_date,
+    target_start_time,
+    target_end_time,
+    position,
+    status,
+    created_at,
+    updated_at,
+    auto_book,
+    use_visit_pack;
 
 -- name: DeleteWaitlistEntry :execrows
 DELETE FROM waitlists
 WHERE id = @id
   AND facility_id = @facility_id;
 
+-- name: DeleteActiveWaitlistEntriesForUser :many
+DELETE FROM waitlists
+WHERE user_id = @user_id
+  AND status IN ('pending', 'notified')
+RETURNING
+    id,
+    facility_id,
+    user_id,
+    target_court_id,
+    target_date,
+    target_start_time,
+    target_end_time,
+    position,
+    status,
+    created_at,
+    updated_at,
+    auto_book,
+    use_visit_pack;
+
+-- Positions are renumbered in two steps because the slot position index is
+-- checked row by row: StageWaitlistPositions writes each active entry's new
+-- place as a negative number and ApplyStagedWaitlistPositions flips them back.
+-- Entries join at the back of the queue, so join order (id) is queue order.
+
+-- name: StageWaitlistPositions :execrows
+UPDATE waitlists
+SET position = -(
+    SELECT COUNT(*)
+    FROM waitlists w2
+    WHERE w2.facility_id = waitlists.facility_id
+      AND w2.target_date = waitlists.target_date
+      AND w2.target_start_time = waitlists.target_start_time
+      AND w2.target_end_time = waitlists.target_end_time
+      AND COALESCE(w2.target_court_id, -1) = COALESCE(waitlists.target_court_id, -1)
+      AND w2.status IN ('pending', 'notified')
+      AND w2.id <= waitlists.id
+)
+WHERE facility_id = @facility_id
+  AND target_date = @target_date
+  AND target_start_time = @target_start_time
+  AND target_end_time = @target_end_time
+  AND status IN ('pending', 'notified')
+  AND (
+      target_court_id = @target_court_id
+      OR (@target_court_id IS NULL AND target_court_id IS NULL)
+  );
+
+-- name: ApplyStagedWaitlistPositions :execrows
+UPDATE waitlists
+SET position = -position,
+    updated_at = CURRENT_TIMESTAMP
+WHERE facility_id = @facility_id
+  AND target_date = @target_date
+  AND target_start_time = @target_start_time
+  AND target_end_time = @target_end_time
+  AND position < 0
+  AND (
+      target_court_id = @target_court_id
+      OR (@target_court_id IS NULL AND target_court_id IS NULL)
+  );
+
 -- name: DeletePastWaitlistEntries :execrows
 DELETE FROM waitlists
 WHERE facility_id = @facility_id
@@ -199,6 +303,7 @@ SELECT
     notification_mode,
     offer_expiry_minutes,
     notification_window_minutes,
+    expired_offer_action,
     created_at,
     updated_at
 FROM waitlist_config
@@ -211,19 +316,22 @@ INSERT INTO waitlist_config (
     max_waitlist_size,
     notification_mode,
     offer_expiry_minutes,
-    notification_window_minutes
+    notification_window_minutes,
+    expired_offer_action
 ) VALUES (
     @facility_id,
     @max_waitlist_size,
     @notification_mode,
     @offer_expiry_minutes,
-    @notification_window_minutes
+    @notification_window_minutes,
+    @expired_offer_action
 )
 ON CONFLICT(facility_id) DO UPDATE SET
     max_waitlist_size = excluded.max_waitlist_size,
     notification_mode = excluded.notification_mode,
     offer_expiry_minutes = excluded.offer_expiry_minutes,
     notification_window_minutes = excluded.notification_window_minutes,
+    expired_offer_action = excluded.expired_offer_action,
     updated_at = CURRENT_TIMESTAMP
 RETURNING
     id,
@@ -232,6 +340,7 @@ RETURNING
     notification_mode,
     offer_expiry_minutes,
     notification_window_minutes,
+    expired_offer_action,
     created_at,
     updated_at;
 
@@ -293,15 +402,12 @@ RETURNING
 SELECT
     wo.id AS offer_id,
     wo.waitlist_id,
-    w.facility_id,
-    wc.offer_expiry_minutes
+    w.facility_id
 FROM waitlist_offers wo
 JOIN waitlists w ON w.id = wo.waitlist_id
-JOIN waitlist_config wc ON wc.facility_id = w.facility_id
 WHERE wo.status = 'pending'
   AND wo.expires_at < @comparison_time
   AND w.status = 'notified'
-  AND wc.notification_mode = 'sequential'
 ORDER BY wo.expires_at;
 
 -- name: AdvanceWaitlistOffer :one
@@ -337,3 +443,58 @@ RETURNING
     offered_at,
     expires_at,
     status;
+
+-- name: GetWaitlistOfferForUser :one
+SELECT
+    wo.id AS offer_id,
+    wo.expires_at,
+    wo.status AS offer_status,
+    w.id AS waitlist_id,
+    w.facility_id,
+    w.target_court_id,
+    w.target_date,
+    w.target_start_time,
+    w.target_end_time,
+    w.status AS waitlist_status
+FROM waitlist_offers wo
+JOIN waitlists w ON w.id = wo.waitlist_id
+WHERE wo.id = @id
+  AND w.user_id = @user_id
+LIMIT 1;
+
+-- name: DeclineOffer :one
+UPDATE waitlist_offers
+SET status = 'declined'
+WHERE id = @id
+  AND waitlist_id = @waitlist_id
+RETURNING
+    id,
+    waitlist_id,
+    offered_at,
+    expires_at,
+    status;
+
+-- name: ExpireSiblingWaitlistOffers :many
+UPDATE waitlist_offers
+SET status = 'expired'
+WHERE status = 'pending'
+  AND waitlist_id IN (
+      SELECT w.id
+      FROM waitlists w
+      JOIN waitlists c ON c.id = @waitlist_id
+      WHERE w.id != c.id
+        AND w.facility_id = c.facility_id
+        AND w.target_date = c.target_date
+        AND w.target_start_time = c.target_start_time
+        AND w.target_end_time = c.target_end_time
+        AND (
+            w.target_court_id = c.target_court_id
+            OR (w.target_court_id IS NULL AND c.target_court_id IS NULL)
+        )
+  )
+RETURNING
+    id,
+    waitlist_id,
+    offered_at,
+    expires_at,
+    status;
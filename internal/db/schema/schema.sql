@@ -11,9 +11,44 @@ CREATE TABLE organizations (
     cross_facility_visit_packs BOOLEAN NOT NULL DEFAULT 0,
     status TEXT NOT NULL DEFAULT 'active',
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    shared_member_directory BOOLEAN NOT NULL DEFAULT 0,
+    default_cancellation_policy TEXT  -- JSON tiers: [{"minHoursBefore", "refundPercentage"}]
+);
+
+-- What each membership level gets within an organization. A member takes the
+-- tier with the highest level at or below their own. NULL booking_window_days
+-- and max_active_reservations fall back to the facility settings; a
+-- max_active_reservations of 0 means no limit.
+CREATE TABLE membership_tiers (
+    id INTEGER PRIMARY KEY,
+    organization_id INTEGER NOT NULL,
+    level INTEGER NOT NULL CHECK (level >= 0),
+    name TEXT NOT NULL,
+    booking_window_days INTEGER CHECK (booking_window_days >= 1 AND booking_window_days <= 364),
+    max_active_reservations INTEGER CHECK (max_active_reservations >= 0),
+    prime_time_access BOOLEAN NOT NULL DEFAULT 0,
+    guest_passes_per_month INTEGER NOT NULL DEFAULT 0 CHECK (guest_passes_per_month >= 0),
+    visit_packs BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    UNIQUE(organization_id, level)
 );
 
+-- New organizations start with the default tiers: guests buy visit packs,
+-- members do not.
+CREATE TRIGGER organizations_seed_membership_tiers
+AFTER INSERT ON organizations
+BEGIN
+    INSERT INTO membership_tiers (organization_id, level, name, visit_packs)
+    VALUES
+        (NEW.id, 0, 'Unverified Guest', 1),
+        (NEW.id, 1, 'Verified Guest', 1),
+        (NEW.id, 2, 'Member', 0),
+        (NEW.id, 3, 'Member+', 0);
+END;
+
 ------ FACILITY ------
 CREATE TABLE facilities (
     id INTEGER PRIMARY KEY,
@@ -25,11 +60,19 @@ CREATE TABLE facilities (
     email_from_address TEXT,
     max_advance_booking_days INTEGER NOT NULL DEFAULT 7,
     max_member_reservations INTEGER NOT NULL DEFAULT 30,
+    max_courts_per_member_booking INTEGER NOT NULL DEFAULT 1 CHECK (max_courts_per_member_booking > 0),
     lesson_min_notice_hours INTEGER NOT NULL DEFAULT 24,
     reminder_hours_before INTEGER NOT NULL DEFAULT 24 CHECK (reminder_hours_before > 0),
     tier_booking_enabled BOOLEAN NOT NULL DEFAULT 0,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    slot_duration_minutes INTEGER NOT NULL DEFAULT 60 CHECK (slot_duration_minutes > 0),
+    min_booking_minutes INTEGER NOT NULL DEFAULT 60 CHECK (min_booking_minutes > 0),
+    max_guests_per_reservation INTEGER NOT NULL DEFAULT 2 CHECK (max_guests_per_reservation >= 0),
+    reservation_limit_scope TEXT NOT NULL DEFAULT 'person' CHECK (reservation_limit_scope IN ('person', 'household')),
+    buffer_minutes INTEGER NOT NULL DEFAULT 0 CHECK (buffer_minutes BETWEEN 0 AND 120), -- court turnover time after each reservation
+    address TEXT,
+    phone TEXT,
     FOREIGN KEY (organization_id) REFERENCES organizations(id),
     FOREIGN KEY (active_theme_id) REFERENCES themes(id)
 );
@@ -48,6 +91,64 @@ CREATE TABLE operating_hours (
     UNIQUE(facility_id, day_of_week)
 );
 
+-- Exact-date hours that replace the weekly operating_hours row for that day,
+-- e.g. closing early on Dec 24 or closing entirely on a holiday.
+CREATE TABLE facility_hours_overrides (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    override_date TEXT NOT NULL,  -- YYYY-MM-DD in facility time
+    is_closed BOOLEAN NOT NULL DEFAULT 0,
+    opens_at TEXT,                -- HH:MM; NULL when closed
+    closes_at TEXT,               -- HH:MM; NULL when closed
+    reason TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (
+        (is_closed = 1 AND opens_at IS NULL AND closes_at IS NULL)
+        OR (is_closed = 0 AND opens_at IS NOT NULL AND closes_at IS NOT NULL AND opens_at < closes_at)
+    ),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    UNIQUE(facility_id, override_date)
+);
+
+-- Weekly windows held for higher membership levels. Members below
+-- min_membership_level can book an overlapping slot only once it is within
+-- unlock_hours_before of its start.
+CREATE TABLE prime_time_rules (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),  -- 0 = Sunday
+    start_time TEXT NOT NULL,  -- HH:MM in facility time
+    end_time TEXT NOT NULL,    -- HH:MM in facility time
+    min_membership_level INTEGER NOT NULL CHECK (min_membership_level >= 0),
+    unlock_hours_before INTEGER NOT NULL CHECK (unlock_hours_before >= 0),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (start_time < end_time),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_prime_time_rules_facility_day ON prime_time_rules(facility_id, day_of_week);
+
+-- Weekly windows with an hourly court price, one rate for members and one
+-- for everyone else. Where windows overlap, the higher rate applies; time no
+-- window covers is free.
+CREATE TABLE court_pricing_rules (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),  -- 0 = Sunday
+    start_time TEXT NOT NULL,  -- HH:MM in facility time
+    end_time TEXT NOT NULL,    -- HH:MM in facility time
+    member_price_per_hour_cents INTEGER NOT NULL CHECK (member_price_per_hour_cents >= 0),
+    non_member_price_per_hour_cents INTEGER NOT NULL CHECK (non_member_price_per_hour_cents >= 0),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (start_time < end_time),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_court_pricing_rules_facility_day ON court_pricing_rules(facility_id, day_of_week);
+
 CREATE TABLE member_tier_booking_windows (
     facility_id INTEGER NOT NULL,
     membership_level INTEGER NOT NULL CHECK (membership_level >= 0),
@@ -88,12 +189,26 @@ CREATE TABLE users (
     date_of_birth TEXT NOT NULL DEFAULT '',  -- stored as YYYY-MM-DD
     waiver_signed BOOLEAN NOT NULL DEFAULT 0,
     membership_level INTEGER NOT NULL DEFAULT 0,  -- 0=Unverified Guest, 1=Verified Guest, 2=Member, 3+=Member+
+    hide_from_rosters BOOLEAN NOT NULL DEFAULT 0,  -- Hide first name from other members on session rosters
+    notify_confirmations BOOLEAN NOT NULL DEFAULT 1,        -- Email notification preferences
+    notify_cancellations BOOLEAN NOT NULL DEFAULT 1,
+    notify_waitlist_offers BOOLEAN NOT NULL DEFAULT 1,
+    notify_open_play_reminders BOOLEAN NOT NULL DEFAULT 1,
+    sms_opt_in BOOLEAN NOT NULL DEFAULT 0,              -- Also text waitlist offers and reminders
+    email_undeliverable BOOLEAN NOT NULL DEFAULT 0,     -- SES reported a hard bounce or complaint
 
     -- Staff-specific fields (nullable if not staff)
     staff_role TEXT,                        -- 'admin', 'manager', 'desk', 'pro', etc.
 
+    -- Deletion: deleted_at starts the retention clock for scrubbing contact
+    -- details, anonymized_at records the scrub, deletion_requested_at flags a
+    -- member's own request for staff review.
+    deleted_at DATETIME,
+    deletion_requested_at DATETIME,
+    anonymized_at DATETIME,
+
     -- Common
-    status TEXT NOT NULL DEFAULT 'active',  -- e.g. 'active', 'suspended', 'archived'
+    status TEXT NOT NULL DEFAULT 'active',  -- e.g. 'active', 'suspended', 'archived', 'deleted'
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 
@@ -128,6 +243,7 @@ CREATE TABLE user_photos (
     size INTEGER NOT NULL,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    thumbnail_data BLOB,
     FOREIGN KEY (user_id) REFERENCES users(id)
 );
 
@@ -173,6 +289,10 @@ CREATE TABLE courts (
     status TEXT NOT NULL DEFAULT 'active',
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Members filter bookable courts by these. surface is NULL when unknown.
+    is_indoor BOOLEAN NOT NULL DEFAULT 0,
+    surface TEXT CHECK (surface IS NULL OR surface IN ('hard', 'cushioned', 'sport_tile', 'wood', 'clay', 'turf')),
+    has_lights BOOLEAN NOT NULL DEFAULT 0,
     FOREIGN KEY (facility_id) REFERENCES facilities(id),
     UNIQUE(facility_id, court_number)
 );
@@ -213,6 +333,13 @@ CREATE TABLE open_play_rules (
     max_courts INTEGER NOT NULL DEFAULT 4,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Members below min_membership_level (or above max_membership_level, when
+    -- set) cannot sign themselves up.
+    min_membership_level INTEGER NOT NULL DEFAULT 0 CHECK (min_membership_level >= 0),
+    max_membership_level INTEGER CHECK (max_membership_level IS NULL OR max_membership_level >= min_membership_level),
+    -- Optional JSON array of skill bands, each a rating range and the court
+    -- numbers it plays on. NULL means the session is not split by skill.
+    skill_bands TEXT,
     CHECK (min_participants > 0),
     CHECK (max_participants_per_court > 0),
     CHECK (min_courts > 0),
@@ -248,8 +375,66 @@ CREATE TABLE open_play_sessions (
 CREATE INDEX idx_open_play_sessions_facility_id ON open_play_sessions(facility_id);
 CREATE INDEX idx_open_play_sessions_rule_id ON open_play_sessions(open_play_rule_id);
 CREATE INDEX idx_open_play_sessions_start_time ON open_play_sessions(start_time);
+CREATE INDEX idx_open_play_sessions_facility_id_start_time ON open_play_sessions(facility_id, start_time);
 CREATE INDEX idx_open_play_sessions_status ON open_play_sessions(status);
 
+-- Per-session band overrides set by staff. A row replaces the rule's bands
+-- for that session; '[]' turns bands off for it.
+CREATE TABLE open_play_session_skill_bands (
+    session_id INTEGER PRIMARY KEY,
+    skill_bands TEXT NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (session_id) REFERENCES open_play_sessions(id) ON DELETE CASCADE
+);
+
+------ MEMBER SKILL RATINGS ------
+CREATE TABLE member_skill_ratings (
+    user_id INTEGER PRIMARY KEY,
+    rating REAL NOT NULL CHECK (rating BETWEEN 1.0 AND 8.0),
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Every rating a member reported or staff recorded. member_skill_ratings
+-- keeps the effective rating derived from it: the most recently verified
+-- entry, else the latest self-report.
+CREATE TABLE member_rating_history (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    rating REAL NOT NULL CHECK (rating BETWEEN 1.0 AND 8.0),
+    source TEXT NOT NULL CHECK (source IN ('self', 'dupr', 'utr', 'staff')),
+    verified BOOLEAN NOT NULL DEFAULT 0,
+    recorded_by_user_id INTEGER,    -- NULL for ratings carried over from before history was kept
+    verified_by_user_id INTEGER,
+    verified_at DATETIME,
+    note TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (recorded_by_user_id) REFERENCES users(id),
+    FOREIGN KEY (verified_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_member_rating_history_user_id ON member_rating_history(user_id, created_at);
+
+-- Weekly times an open play rule runs. Session generation materializes one
+-- session and its backing reservation per slot occurrence.
+CREATE TABLE open_play_rule_slots (
+    id INTEGER PRIMARY KEY,
+    open_play_rule_id INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),  -- 0 = Sunday
+    start_time TEXT NOT NULL,  -- HH:MM in facility time
+    end_time TEXT NOT NULL,    -- HH:MM in facility time
+    created_by_user_id INTEGER NOT NULL,  -- generated reservations are attributed to this user
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (start_time < end_time),
+    FOREIGN KEY (open_play_rule_id) REFERENCES open_play_rules(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+    UNIQUE(open_play_rule_id, day_of_week, start_time, end_time)
+);
+
+CREATE INDEX idx_open_play_rule_slots_day ON open_play_rule_slots(day_of_week);
+
 ------ STAFF NOTIFICATIONS ------
 CREATE TABLE staff_notifications (
     id INTEGER PRIMARY KEY,
@@ -263,7 +448,7 @@ CREATE TABLE staff_notifications (
     read BOOLEAN NOT NULL DEFAULT 0,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    CHECK (notification_type IN ('scale_up', 'scale_down', 'cancelled', 'lesson_cancelled', 'clinic_enrollment_below_minimum')),
+    CHECK (notification_type IN ('scale_up', 'scale_down', 'cancelled', 'lesson_cancelled', 'clinic_enrollment_below_minimum', 'lesson_reassigned', 'waitlist_fulfilled')),
     FOREIGN KEY (facility_id) REFERENCES facilities(id),
     FOREIGN KEY (related_session_id) REFERENCES open_play_sessions(id),
     FOREIGN KEY (related_reservation_id) REFERENCES reservations(id),
@@ -277,6 +462,7 @@ CREATE INDEX idx_staff_notifications_related_reservation_id ON staff_notificatio
 CREATE INDEX idx_staff_notifications_related_clinic_session_id ON staff_notifications(related_clinic_session_id);
 CREATE INDEX idx_staff_notifications_read ON staff_notifications(read);
 CREATE INDEX idx_staff_notifications_target_staff_id ON staff_notifications(target_staff_id);
+CREATE INDEX idx_staff_notifications_created_at ON staff_notifications(created_at);
 
 ------ OPEN PLAY AUDIT LOG ------
 CREATE TABLE open_play_audit_log (
@@ -294,20 +480,113 @@ CREATE TABLE open_play_audit_log (
 CREATE INDEX idx_open_play_audit_log_session_id ON open_play_audit_log(session_id);
 CREATE INDEX idx_open_play_audit_log_created_at ON open_play_audit_log(created_at);
 
+------ OPEN PLAY ROTATION ------
+-- The "next up" order of checked-in players at an open play session. A
+-- player joins at the back on check-in and returns to the back after each
+-- game, so the lowest queue_position is always up next.
+CREATE TABLE open_play_queue_entries (
+    id INTEGER PRIMARY KEY,
+    session_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    queue_position INTEGER NOT NULL,
+    games_played INTEGER NOT NULL DEFAULT 0,
+    joined_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (session_id) REFERENCES open_play_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (session_id, user_id)
+);
+
+CREATE INDEX idx_open_play_queue_entries_position ON open_play_queue_entries(session_id, queue_position);
+
+-- Games called off the queue and the players sent to the court.
+CREATE TABLE open_play_games (
+    id INTEGER PRIMARY KEY,
+    session_id INTEGER NOT NULL,
+    recorded_by_user_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (session_id) REFERENCES open_play_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (recorded_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_open_play_games_session_id ON open_play_games(session_id);
+
+CREATE TABLE open_play_game_players (
+    game_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    PRIMARY KEY (game_id, user_id),
+    FOREIGN KEY (game_id) REFERENCES open_play_games(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+------ IMPERSONATION AUDIT LOG ------
+-- Staff sessions acting as a member. Every row names both identities:
+-- 'start' and 'end' bracket the session and 'request' records each change
+-- made while impersonating, with its response status.
+CREATE TABLE impersonation_audit_log (
+    id INTEGER PRIMARY KEY,
+    impersonator_user_id INTEGER NOT NULL,
+    member_user_id INTEGER NOT NULL,
+    action TEXT NOT NULL,
+    method TEXT,
+    path TEXT,
+    status INTEGER,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (action IN ('start', 'end', 'request')),
+    FOREIGN KEY (impersonator_user_id) REFERENCES users(id),
+    FOREIGN KEY (member_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_impersonation_audit_log_impersonator ON impersonation_audit_log(impersonator_user_id, created_at);
+CREATE INDEX idx_impersonation_audit_log_member ON impersonation_audit_log(member_user_id, created_at);
+
+------ USER SESSIONS ------
+-- One row per sign-in, so members and staff can see where they are logged in
+-- and revoke a session. The token is carried in the session cookie; a row
+-- with revoked_at set no longer authenticates.
+CREATE TABLE user_sessions (
+    id INTEGER PRIMARY KEY,
+    token TEXT NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL,
+    session_type TEXT NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME,
+    CHECK (session_type IN ('staff', 'member')),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_user_sessions_user ON user_sessions(user_id, expires_at);
+
 
 --------- Reservations ---------
 
 -- Reservation Types (lookup table)
---    Acts like an enum for reservation categories.
+--    Acts like an enum for reservation categories. Built-in types have no
+--    organization or facility; custom types belong to an organization and,
+--    optionally, one of its facilities.
 CREATE TABLE reservation_types (
     id INTEGER PRIMARY KEY,
     name TEXT NOT NULL UNIQUE,  -- e.g. 'GAME', 'PRO_SESSION', 'EVENT', 'MAINTENANCE', 'LEAGUE', etc.
     description TEXT,           -- optional: describe this type in detail
     color TEXT,                 -- optional: store a default color code like '#FF0000'
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    organization_id INTEGER REFERENCES organizations(id),
+    facility_id INTEGER REFERENCES facilities(id),
+    default_duration_minutes INTEGER NOT NULL DEFAULT 60 CHECK (default_duration_minutes > 0),
+    member_bookable BOOLEAN NOT NULL DEFAULT 0,
+    counts_toward_member_limit BOOLEAN NOT NULL DEFAULT 0,
+    buffer_minutes INTEGER CHECK (buffer_minutes IS NULL OR buffer_minutes BETWEEN 0 AND 120), -- overrides facilities.buffer_minutes when set
+    min_duration_minutes INTEGER CHECK (min_duration_minutes IS NULL OR min_duration_minutes > 0), -- overrides facilities.min_booking_minutes when set
+    max_duration_minutes INTEGER CHECK (max_duration_minutes IS NULL OR max_duration_minutes > 0)  -- NULL: no maximum
 );
 
+CREATE INDEX idx_reservation_types_organization_id ON reservation_types(organization_id);
+CREATE INDEX idx_reservation_types_facility_id ON reservation_types(facility_id);
+
 -- Recurrence Rules (lookup table)
 --    Manages possible recurrence patterns (e.g. weekly, monthly).
 CREATE TABLE recurrence_rules (
@@ -445,6 +724,144 @@ CREATE TABLE reservation_participants (
     UNIQUE (reservation_id, user_id)
 );
 
+------ GUEST PASSES ------
+-- Guest passes a member holds at a facility. Each guest they bring uses one.
+CREATE TABLE member_guest_passes (
+    user_id INTEGER NOT NULL,
+    facility_id INTEGER NOT NULL,
+    balance INTEGER NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, facility_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE
+);
+
+-- Non-members brought along on a reservation. A guest either used one of the
+-- host's guest passes or is flagged as a drop-in to bill later.
+CREATE TABLE reservation_guests (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    host_user_id INTEGER,
+    name TEXT NOT NULL,
+    email TEXT,
+    is_drop_in BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (host_user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_reservation_guests_reservation_id ON reservation_guests(reservation_id);
+
+------ RESERVATION INVITATIONS ------
+-- Members a booking member invited to play. The invitee becomes a
+-- reservation participant only after accepting through the emailed token
+-- link; declining needs no login and frees the spot.
+CREATE TABLE reservation_invitations (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    invited_user_id INTEGER NOT NULL,
+    invited_by_user_id INTEGER NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    responded_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_by_user_id) REFERENCES users(id),
+    UNIQUE (reservation_id, invited_user_id)
+);
+
+CREATE INDEX idx_reservation_invitations_reservation_status ON reservation_invitations(reservation_id, status);
+
+------ RESERVATION TRANSFERS ------
+-- A booking member handing a reservation to another member. The reservation
+-- changes hands only once the target accepts through the emailed token link
+-- before expires_at; a pending row past expires_at has lapsed.
+CREATE TABLE reservation_transfers (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    from_user_id INTEGER NOT NULL,
+    to_user_id INTEGER NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    expires_at DATETIME NOT NULL,
+    responded_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (from_user_id) REFERENCES users(id),
+    FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- At most one open transfer per reservation.
+CREATE UNIQUE INDEX idx_reservation_transfers_pending
+    ON reservation_transfers(reservation_id)
+    WHERE status = 'pending';
+
+------ RESERVATION PRICES ------
+-- The price of a court booking, fixed when it was made. member_rate records
+-- which of the rule's two rates applied.
+CREATE TABLE reservation_prices (
+    reservation_id INTEGER PRIMARY KEY,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents >= 0),
+    member_rate BOOLEAN NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE
+);
+
+------ HOUSEHOLD LINKS ------
+-- A primary account and a dependent it books and cancels for. Minors are
+-- linked straight away; an adult dependent must accept the emailed token link
+-- before expires_at, so a pending row past expires_at has lapsed.
+CREATE TABLE household_links (
+    id INTEGER PRIMARY KEY,
+    primary_user_id INTEGER NOT NULL,
+    dependent_user_id INTEGER NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'active', 'declined', 'removed')),
+    expires_at DATETIME NOT NULL,
+    responded_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (primary_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (dependent_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CHECK (primary_user_id <> dependent_user_id)
+);
+
+-- A dependent belongs to at most one household.
+CREATE UNIQUE INDEX idx_household_links_active_dependent
+    ON household_links(dependent_user_id)
+    WHERE status = 'active';
+
+-- At most one open request per primary and dependent.
+CREATE UNIQUE INDEX idx_household_links_pending
+    ON household_links(primary_user_id, dependent_user_id)
+    WHERE status = 'pending';
+
+CREATE INDEX idx_household_links_primary_status ON household_links(primary_user_id, status);
+
+------ COURT SLOT HOLDS ------
+-- A member reserving a court slot while they finish the booking form. A hold
+-- blocks the court for everyone else until expires_at; the member's booking
+-- consumes it. A member holds one slot at a time, with a row for each court
+-- they selected.
+CREATE TABLE court_slot_holds (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    court_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    start_time DATETIME NOT NULL,
+    end_time DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    FOREIGN KEY (court_id) REFERENCES courts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (user_id, court_id)
+);
+
+CREATE INDEX idx_court_slot_holds_court_time ON court_slot_holds(court_id, start_time, end_time);
+CREATE INDEX idx_court_slot_holds_expires_at ON court_slot_holds(expires_at);
+
 ------ WAITLISTS ------
 CREATE TABLE waitlist_config (
     id INTEGER PRIMARY KEY,
@@ -453,6 +870,7 @@ CREATE TABLE waitlist_config (
     notification_mode TEXT NOT NULL CHECK (notification_mode IN ('broadcast', 'sequential')),
     offer_expiry_minutes INTEGER NOT NULL DEFAULT 0,
     notification_window_minutes INTEGER NOT NULL DEFAULT 0,
+    expired_offer_action TEXT NOT NULL DEFAULT 'remove' CHECK (expired_offer_action IN ('remove', 'requeue')),
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (facility_id) REFERENCES facilities(id),
@@ -471,6 +889,8 @@ CREATE TABLE waitlists (
     status TEXT NOT NULL CHECK (status IN ('pending', 'notified', 'expired', 'fulfilled')),
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    auto_book BOOLEAN NOT NULL DEFAULT 0,       -- book the freed slot directly instead of offering it
+    use_visit_pack BOOLEAN NOT NULL DEFAULT 0,  -- redeem a visit pack for that booking
     CHECK (target_start_time < target_end_time),
     FOREIGN KEY (facility_id) REFERENCES facilities(id),
     FOREIGN KEY (user_id) REFERENCES users(id),
@@ -482,14 +902,15 @@ CREATE TABLE waitlist_offers (
     waitlist_id INTEGER NOT NULL,
     offered_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     expires_at DATETIME NOT NULL,
-    status TEXT NOT NULL CHECK (status IN ('pending', 'accepted', 'expired')),
+    status TEXT NOT NULL CHECK (status IN ('pending', 'accepted', 'declined', 'expired')),
     FOREIGN KEY (waitlist_id) REFERENCES waitlists(id) ON DELETE CASCADE
 );
 
 CREATE INDEX idx_waitlists_facility_id ON waitlists(facility_id);
 CREATE INDEX idx_waitlists_slot ON waitlists(facility_id, target_date, target_start_time, target_end_time);
 CREATE UNIQUE INDEX idx_waitlists_slot_position_unique
-    ON waitlists(facility_id, target_date, target_start_time, target_end_time, COALESCE(target_court_id, -1), position);
+    ON waitlists(facility_id, target_date, target_start_time, target_end_time, COALESCE(target_court_id, -1), position)
+    WHERE status IN ('pending', 'notified');
 CREATE INDEX idx_waitlists_user_id ON waitlists(user_id);
 CREATE INDEX idx_waitlists_target_date ON waitlists(target_date);
 CREATE INDEX idx_waitlists_status ON waitlists(status);
@@ -515,6 +936,8 @@ CREATE TABLE leagues (
     status TEXT NOT NULL CHECK (status IN ('draft', 'registration', 'active', 'completed', 'cancelled')),
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    registration_fee_cents INTEGER NOT NULL DEFAULT 0 CHECK (registration_fee_cents >= 0),
+    fee_basis TEXT NOT NULL DEFAULT 'team' CHECK (fee_basis IN ('team', 'player')),  -- fee owed once per team or by each rostered player
     CHECK (start_date <= end_date),
     CHECK (min_team_size > 0 AND max_team_size > 0 AND min_team_size <= max_team_size),
     FOREIGN KEY (facility_id) REFERENCES facilities(id)
@@ -528,6 +951,7 @@ CREATE TABLE league_teams (
     status TEXT NOT NULL CHECK (status IN ('active', 'inactive')),
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    division TEXT,                     -- division name from leagues.division_config; null until assigned
     FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE,
     FOREIGN KEY (captain_user_id) REFERENCES users(id) ON DELETE RESTRICT,
     UNIQUE (league_id, name)
@@ -544,6 +968,22 @@ CREATE TABLE league_team_members (
     UNIQUE (league_team_id, user_id)
 );
 
+-- Captain invitations; the invitee joins the team by following the emailed token link.
+CREATE TABLE league_team_invitations (
+    id INTEGER PRIMARY KEY,
+    league_team_id INTEGER NOT NULL,
+    invited_user_id INTEGER NOT NULL,
+    invited_by_user_id INTEGER NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'cancelled')),
+    expires_at DATETIME NOT NULL,
+    responded_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (league_team_id) REFERENCES league_teams(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_by_user_id) REFERENCES users(id)
+);
+
 CREATE TABLE league_matches (
     id INTEGER PRIMARY KEY,
     league_id INTEGER NOT NULL,
@@ -553,9 +993,11 @@ CREATE TABLE league_matches (
     scheduled_time DATETIME NOT NULL,
     home_score INTEGER,
     away_score INTEGER,
-    status TEXT NOT NULL CHECK (status IN ('scheduled', 'in_progress', 'completed', 'cancelled', 'forfeit')),
+    status TEXT NOT NULL CHECK (status IN ('scheduled', 'in_progress', 'pending_result', 'disputed', 'completed', 'cancelled', 'forfeit')),
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    round_number INTEGER,              -- round-robin round or bracket round; null for matches created before rounds were tracked
+    CHECK (round_number IS NULL OR round_number > 0),
     CHECK (home_team_id != away_team_id),
     CHECK (home_score IS NULL OR home_score >= 0),
     CHECK (away_score IS NULL OR away_score >= 0),
@@ -565,16 +1007,150 @@ CREATE TABLE league_matches (
     FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE SET NULL
 );
 
+-- Captain-reported scores. The home captain submits; the away captain
+-- confirms or disputes by respond_by, after which a pending submission is
+-- accepted automatically. Scores are copied onto the match once accepted.
+CREATE TABLE league_match_result_submissions (
+    id INTEGER PRIMARY KEY,
+    league_match_id INTEGER NOT NULL UNIQUE,
+    submitted_by_user_id INTEGER NOT NULL,
+    home_score INTEGER NOT NULL CHECK (home_score >= 0),
+    away_score INTEGER NOT NULL CHECK (away_score >= 0),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'disputed', 'overridden')),
+    respond_by DATETIME NOT NULL,
+    responded_by_user_id INTEGER,
+    responded_at DATETIME,
+    dispute_reason TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (league_match_id) REFERENCES league_matches(id) ON DELETE CASCADE,
+    FOREIGN KEY (submitted_by_user_id) REFERENCES users(id),
+    FOREIGN KEY (responded_by_user_id) REFERENCES users(id)
+);
+
+-- Payments staff recorded against a team's fee. user_id names the player a
+-- payment covers in per-player leagues and is NULL for per-team fees.
+CREATE TABLE league_payments (
+    id INTEGER PRIMARY KEY,
+    league_team_id INTEGER NOT NULL,
+    user_id INTEGER,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+    method TEXT NOT NULL CHECK (method IN ('cash', 'card', 'check', 'other')),
+    note TEXT,
+    recorded_by_user_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (league_team_id) REFERENCES league_teams(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (recorded_by_user_id) REFERENCES users(id)
+);
+
+-- Members who signed up for a league without a team; staff assign them to teams.
+CREATE TABLE league_free_agents (
+    id INTEGER PRIMARY KEY,
+    league_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    skill_level TEXT NOT NULL,
+    availability_notes TEXT,
+    gender TEXT CHECK (gender IS NULL OR gender IN ('male', 'female')),  -- required for mixed_doubles leagues
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (league_id, user_id)
+);
+
 CREATE INDEX idx_leagues_facility_id ON leagues(facility_id);
 CREATE INDEX idx_league_teams_league_id ON league_teams(league_id);
 CREATE INDEX idx_league_teams_captain_user_id ON league_teams(captain_user_id);
 CREATE INDEX idx_league_team_members_team_id ON league_team_members(league_team_id);
 CREATE INDEX idx_league_team_members_user_id ON league_team_members(user_id);
+CREATE INDEX idx_league_free_agents_user_id ON league_free_agents(user_id);
+CREATE INDEX idx_league_payments_team ON league_payments(league_team_id);
+CREATE INDEX idx_league_team_invitations_team_id ON league_team_invitations(league_team_id);
+CREATE UNIQUE INDEX idx_league_team_invitations_pending
+    ON league_team_invitations(league_team_id, invited_user_id)
+    WHERE status = 'pending';
 CREATE INDEX idx_league_matches_league_id ON league_matches(league_id);
 CREATE INDEX idx_league_matches_reservation_id ON league_matches(reservation_id);
 CREATE INDEX idx_league_matches_scheduled_time ON league_matches(scheduled_time);
 CREATE INDEX idx_league_matches_home_team_id ON league_matches(home_team_id);
 CREATE INDEX idx_league_matches_away_team_id ON league_matches(away_team_id);
+CREATE INDEX idx_league_match_result_submissions_pending
+    ON league_match_result_submissions(respond_by)
+    WHERE status = 'pending';
+
+------ TOURNAMENTS ------
+-- One-day elimination events played by a league's teams. Entrants are
+-- seeded when the tournament is created, either by hand or from the
+-- league standings.
+CREATE TABLE tournaments (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    league_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    format TEXT NOT NULL CHECK (format IN ('single_elimination', 'double_elimination')),
+    seeding TEXT NOT NULL CHECK (seeding IN ('manual', 'standings')),
+    status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'in_progress', 'completed')),
+    champion_team_id INTEGER,
+    created_by_user_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id),
+    FOREIGN KEY (league_id) REFERENCES leagues(id) ON DELETE CASCADE,
+    FOREIGN KEY (champion_team_id) REFERENCES league_teams(id) ON DELETE SET NULL,
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id)
+);
+
+CREATE TABLE tournament_entrants (
+    tournament_id INTEGER NOT NULL,
+    league_team_id INTEGER NOT NULL,
+    seed INTEGER NOT NULL CHECK (seed > 0),
+    PRIMARY KEY (tournament_id, league_team_id),
+    UNIQUE (tournament_id, seed),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (league_team_id) REFERENCES league_teams(id) ON DELETE RESTRICT
+);
+
+-- Every match of the bracket is created up front. Teams fill in as earlier
+-- matches finish: the winner moves to winner_to_match_id and, in double
+-- elimination, the loser drops to loser_to_match_id in the consolation
+-- bracket. A bye match never gets a second team; its one team advances
+-- without playing.
+CREATE TABLE tournament_matches (
+    id INTEGER PRIMARY KEY,
+    tournament_id INTEGER NOT NULL,
+    bracket TEXT NOT NULL CHECK (bracket IN ('winners', 'consolation', 'final')),
+    round_number INTEGER NOT NULL CHECK (round_number > 0),
+    position INTEGER NOT NULL CHECK (position > 0),
+    team_a_id INTEGER,
+    team_b_id INTEGER,
+    team_a_score INTEGER CHECK (team_a_score IS NULL OR team_a_score >= 0),
+    team_b_score INTEGER CHECK (team_b_score IS NULL OR team_b_score >= 0),
+    winner_team_id INTEGER,
+    winner_to_match_id INTEGER,
+    winner_to_slot TEXT CHECK (winner_to_slot IS NULL OR winner_to_slot IN ('a', 'b')),
+    loser_to_match_id INTEGER,
+    loser_to_slot TEXT CHECK (loser_to_slot IS NULL OR loser_to_slot IN ('a', 'b')),
+    status TEXT NOT NULL CHECK (status IN ('pending', 'ready', 'completed', 'bye')),
+    court_id INTEGER,
+    scheduled_time DATETIME,
+    reservation_id INTEGER,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tournament_id, bracket, round_number, position),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+    FOREIGN KEY (team_a_id) REFERENCES league_teams(id) ON DELETE RESTRICT,
+    FOREIGN KEY (team_b_id) REFERENCES league_teams(id) ON DELETE RESTRICT,
+    FOREIGN KEY (winner_team_id) REFERENCES league_teams(id) ON DELETE RESTRICT,
+    FOREIGN KEY (winner_to_match_id) REFERENCES tournament_matches(id) ON DELETE CASCADE,
+    FOREIGN KEY (loser_to_match_id) REFERENCES tournament_matches(id) ON DELETE CASCADE,
+    FOREIGN KEY (court_id) REFERENCES courts(id) ON DELETE SET NULL,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_tournaments_facility_id ON tournaments(facility_id);
+CREATE INDEX idx_tournaments_league_id ON tournaments(league_id);
+CREATE INDEX idx_tournament_entrants_team_id ON tournament_entrants(league_team_id);
+CREATE INDEX idx_tournament_matches_tournament_id ON tournament_matches(tournament_id);
+CREATE INDEX idx_tournament_matches_reservation_id ON tournament_matches(reservation_id);
 
 ------ RESERVATION CANCELLATIONS ------
 CREATE TABLE reservation_cancellations (
@@ -586,6 +1162,7 @@ CREATE TABLE reservation_cancellations (
     fee_waived BOOLEAN NOT NULL DEFAULT 0,
     hours_before_start INTEGER NOT NULL,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    refund_amount_cents INTEGER CHECK (refund_amount_cents >= 0),  -- price x refund_percentage_applied; NULL when unpriced
     CHECK (refund_percentage_applied >= 0 AND refund_percentage_applied <= 100),
     CHECK (hours_before_start >= 0),
     FOREIGN KEY (reservation_id) REFERENCES reservations(id),
@@ -597,6 +1174,78 @@ CREATE INDEX idx_reservation_cancellations_cancelled_by_user_id ON reservation_c
 CREATE INDEX idx_reservation_cancellations_cancelled_at ON reservation_cancellations(cancelled_at);
 CREATE INDEX idx_reservations_created_by_user_id ON reservations(created_by_user_id);
 CREATE INDEX idx_reservations_facility_id_start_time ON reservations(facility_id, start_time);
+CREATE INDEX idx_reservations_open_play_rule_id_start_time ON reservations(open_play_rule_id, start_time);
+CREATE INDEX idx_reservation_courts_court_id ON reservation_courts(court_id, reservation_id);
+
+------ ACCOUNT CREDITS ------
+-- Credit a member holds at a facility, from a cancellation refund or issued
+-- by staff. Bookings that apply credit draw remaining_cents down, oldest
+-- credit first; expired credit no longer counts toward the balance.
+CREATE TABLE account_credits (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    facility_id INTEGER NOT NULL,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+    remaining_cents INTEGER NOT NULL CHECK (remaining_cents >= 0),
+    source_reservation_id INTEGER,  -- the cancelled reservation; NULL for manual credits
+    reason TEXT NOT NULL,
+    issued_by_user_id INTEGER NOT NULL,
+    expires_at DATETIME,  -- NULL never expires
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (remaining_cents <= amount_cents),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    FOREIGN KEY (source_reservation_id) REFERENCES reservations(id) ON DELETE SET NULL,
+    FOREIGN KEY (issued_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_account_credits_user_facility ON account_credits(user_id, facility_id, created_at);
+
+-- Credit applied toward a booking's price.
+CREATE TABLE account_credit_redemptions (
+    id INTEGER PRIMARY KEY,
+    credit_id INTEGER NOT NULL,
+    reservation_id INTEGER NOT NULL,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (credit_id) REFERENCES account_credits(id) ON DELETE CASCADE,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id)
+);
+
+CREATE INDEX idx_account_credit_redemptions_credit_id ON account_credit_redemptions(credit_id);
+CREATE INDEX idx_account_credit_redemptions_reservation_id ON account_credit_redemptions(reservation_id);
+
+------ EMAIL DELIVERABILITY ------
+-- Bounce and complaint notifications received from SES through SNS.
+CREATE TABLE email_delivery_events (
+    id INTEGER PRIMARY KEY,
+    email TEXT NOT NULL,
+    event_type TEXT NOT NULL CHECK (event_type IN ('bounce', 'complaint')),
+    bounce_type TEXT,      -- 'Permanent', 'Transient' or 'Undetermined'
+    bounce_sub_type TEXT,
+    diagnostic TEXT,
+    sns_message_id TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (sns_message_id, email, event_type)
+);
+
+CREATE INDEX idx_email_delivery_events_email ON email_delivery_events(email, created_at);
+
+------ BACKGROUND JOBS ------
+-- Outcome of the latest run of each scheduled job, kept across restarts for
+-- the admin jobs dashboard and readiness checks.
+CREATE TABLE job_statuses (
+    name TEXT PRIMARY KEY,
+    last_trigger TEXT NOT NULL CHECK (last_trigger IN ('schedule', 'manual')),
+    last_started_at DATETIME NOT NULL,
+    last_finished_at DATETIME NOT NULL,
+    last_duration_ms INTEGER NOT NULL,
+    last_items INTEGER NOT NULL,
+    last_error TEXT,       -- NULL when the latest run succeeded
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    last_success_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
 
 ------ CANCELLATION POLICIES ------
 CREATE TABLE cancellation_policy_tiers (
@@ -671,6 +1320,7 @@ CREATE TABLE visit_packs (
     status TEXT NOT NULL DEFAULT 'active',
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    facility_id INTEGER,  -- set when transferred with a home facility change; NULL means the pack type's facility
     CHECK (status IN ('active', 'expired', 'depleted')),
     FOREIGN KEY (pack_type_id) REFERENCES visit_pack_types(id),
     FOREIGN KEY (user_id) REFERENCES users(id)
@@ -699,6 +1349,102 @@ CREATE INDEX idx_visit_pack_redemptions_facility_id ON visit_pack_redemptions(fa
 CREATE INDEX idx_visit_pack_redemptions_reservation_id ON visit_pack_redemptions(reservation_id);
 CREATE INDEX idx_visit_pack_redemptions_redeemed_at ON visit_pack_redemptions(redeemed_at);
 
+CREATE TABLE visit_pack_payments (
+    id INTEGER PRIMARY KEY,
+    visit_pack_id INTEGER NOT NULL UNIQUE,
+    amount_cents INTEGER NOT NULL CHECK (amount_cents >= 0),
+    processor TEXT NOT NULL,
+    reference TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (visit_pack_id) REFERENCES visit_packs(id) ON DELETE CASCADE
+);
+
+------ MEMBER HOME FACILITY CHANGES ------
+-- Audit trail of staff moving a member to a different home facility.
+CREATE TABLE member_home_facility_changes (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    from_facility_id INTEGER,
+    to_facility_id INTEGER NOT NULL,
+    changed_by_user_id INTEGER NOT NULL,
+    transferred_visit_packs INTEGER NOT NULL DEFAULT 0,
+    reason TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (from_facility_id) REFERENCES facilities(id),
+    FOREIGN KEY (to_facility_id) REFERENCES facilities(id),
+    FOREIGN KEY (changed_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_member_home_facility_changes_user_id ON member_home_facility_changes(user_id);
+
+------ RESERVATION CHECK-INS ------
+-- One row per participant who showed up for a reservation.
+CREATE TABLE reservation_checkins (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    checked_in_at DATETIME NOT NULL,
+    checked_in_by_user_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (checked_in_by_user_id) REFERENCES users(id),
+    UNIQUE (reservation_id, user_id)
+);
+
+CREATE INDEX idx_reservation_checkins_user_id ON reservation_checkins(user_id);
+
+------ RESERVATION ACCESS REVOCATIONS ------
+-- Reservations whose access QR codes staff have revoked. Gate verification
+-- denies every code for a listed reservation.
+CREATE TABLE reservation_access_revocations (
+    reservation_id INTEGER PRIMARY KEY,
+    revoked_by_user_id INTEGER NOT NULL,
+    reason TEXT,
+    revoked_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (revoked_by_user_id) REFERENCES users(id)
+);
+
+------ RESERVATION REMINDERS ------
+-- Marks reservations whose reminder email went out so restarts don't resend.
+CREATE TABLE reservation_reminders (
+    reservation_id INTEGER PRIMARY KEY,
+    sent_at DATETIME NOT NULL,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE
+);
+
+------ NO-SHOW POLICIES ------
+-- Facilities without a row never restrict members for no-shows.
+CREATE TABLE facility_no_show_policies (
+    facility_id INTEGER PRIMARY KEY,
+    max_no_shows_per_30_days INTEGER NOT NULL CHECK (max_no_shows_per_30_days >= 0),
+    restriction_days INTEGER NOT NULL CHECK (restriction_days > 0),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE
+);
+
+------ NO-SHOW RESTRICTION CLEARS ------
+-- Staff overrides. No-shows ending at or before cleared_at stop counting
+-- toward a restriction at that facility.
+CREATE TABLE no_show_restriction_clears (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    facility_id INTEGER NOT NULL,
+    restricted_until DATETIME NOT NULL,
+    cleared_at DATETIME NOT NULL,
+    cleared_by_user_id INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    FOREIGN KEY (cleared_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_no_show_restriction_clears_user_facility ON no_show_restriction_clears(user_id, facility_id);
+
 CREATE TRIGGER visit_pack_types_limit_insert
 BEFORE INSERT ON visit_pack_types
 WHEN (
@@ -801,6 +1547,341 @@ BEGIN
     SELECT RAISE(ABORT, 'lesson package type limit exceeded');
 END;
 
+------ SEASON PASSES ------
+CREATE TABLE season_pass_types (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    price_cents INTEGER NOT NULL CHECK (price_cents >= 0),
+    valid_from TEXT NOT NULL,   -- YYYY-MM-DD in facility time, inclusive
+    valid_until TEXT NOT NULL,  -- YYYY-MM-DD in facility time, inclusive
+    status TEXT NOT NULL DEFAULT 'active',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (valid_from <= valid_until),
+    CHECK (status IN ('active', 'inactive')),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id)
+);
+
+CREATE INDEX idx_season_pass_types_facility_id ON season_pass_types(facility_id);
+
+-- Off-peak windows covered by a pass type, in facility local time.
+CREATE TABLE season_pass_windows (
+    id INTEGER PRIMARY KEY,
+    pass_type_id INTEGER NOT NULL,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week >= 0 AND day_of_week <= 6),  -- 0=Sunday
+    starts_at TEXT NOT NULL,  -- HH:MM
+    ends_at TEXT NOT NULL,    -- HH:MM
+    CHECK (starts_at < ends_at),
+    FOREIGN KEY (pass_type_id) REFERENCES season_pass_types(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_season_pass_windows_pass_type_id ON season_pass_windows(pass_type_id);
+
+CREATE TABLE season_passes (
+    id INTEGER PRIMARY KEY,
+    pass_type_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    purchased_at DATETIME NOT NULL,
+    price_paid_cents INTEGER NOT NULL CHECK (price_paid_cents >= 0),
+    granted_by_user_id INTEGER,  -- staff who granted the pass; null for member purchases
+    status TEXT NOT NULL DEFAULT 'active',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('active', 'revoked')),
+    FOREIGN KEY (pass_type_id) REFERENCES season_pass_types(id),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (granted_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_season_passes_pass_type_id ON season_passes(pass_type_id);
+CREATE INDEX idx_season_passes_user_status ON season_passes(user_id, status);
+
+-- Reservations booked under a season pass. Covered reservations carry no
+-- per-booking charge and are excluded from the member reservation limit.
+CREATE TABLE season_pass_reservations (
+    id INTEGER PRIMARY KEY,
+    season_pass_id INTEGER NOT NULL,
+    reservation_id INTEGER NOT NULL UNIQUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (season_pass_id) REFERENCES season_passes(id),
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_season_pass_reservations_season_pass_id ON season_pass_reservations(season_pass_id);
+
+------ NOTIFICATION QUIET HOURS ------
+CREATE TABLE facility_quiet_hours (
+    facility_id INTEGER PRIMARY KEY,
+    starts_at TEXT NOT NULL,  -- HH:MM in facility time
+    ends_at TEXT NOT NULL,    -- HH:MM in facility time; may wrap past midnight
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (starts_at <> ends_at),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE
+);
+
+------ FACILITY ANNOUNCEMENTS ------
+-- Banners shown at the top of the member portal and/or staff pages between
+-- starts_at and ends_at (stored in UTC, entered in facility time).
+CREATE TABLE facility_announcements (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    severity TEXT NOT NULL DEFAULT 'info',
+    audience TEXT NOT NULL DEFAULT 'members',
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    created_by_user_id INTEGER,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (severity IN ('info', 'warning', 'critical')),
+    CHECK (audience IN ('members', 'staff', 'both')),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by_user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_facility_announcements_facility_window ON facility_announcements(facility_id, ends_at, starts_at);
+
+-- A dismissed banner stays hidden for that user.
+CREATE TABLE facility_announcement_dismissals (
+    announcement_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    dismissed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (announcement_id, user_id),
+    FOREIGN KEY (announcement_id) REFERENCES facility_announcements(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+------ DEFERRED EMAILS ------
+CREATE TABLE deferred_emails (
+    id INTEGER PRIMARY KEY,
+    facility_id INTEGER NOT NULL,
+    reservation_id INTEGER,  -- re-checked at dispatch; cancelled or deleted reservations suppress the send
+    user_id INTEGER NOT NULL,
+    channel TEXT NOT NULL DEFAULT 'email',  -- 'sms' rows hold a phone number in recipient
+    recipient TEXT NOT NULL,
+    sender TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    send_after DATETIME NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    processed_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('pending', 'sent', 'suppressed', 'failed')),
+    CHECK (channel IN ('email', 'sms')),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_deferred_emails_status_send_after ON deferred_emails(status, send_after);
+
+------ DELTA SYNC ------
+-- Append-only change log for delta sync clients. seq is assigned inside the
+-- writing transaction and SQLite serializes writers, so seq order matches
+-- commit order and a reader never observes a gap that later fills in.
+CREATE TABLE sync_changes (
+    seq INTEGER PRIMARY KEY AUTOINCREMENT,
+    facility_id INTEGER NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id INTEGER NOT NULL,
+    changed_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),  -- UTC, millisecond precision
+    CHECK (entity_type IN ('reservation', 'open_play_session'))
+);
+
+CREATE INDEX idx_sync_changes_facility_type_seq ON sync_changes(facility_id, entity_type, seq);
+CREATE INDEX idx_sync_changes_facility_type_changed_at ON sync_changes(facility_id, entity_type, changed_at);
+
+CREATE TRIGGER reservations_sync_insert
+AFTER INSERT ON reservations
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (NEW.facility_id, 'reservation', NEW.id);
+END;
+
+CREATE TRIGGER reservations_sync_update
+AFTER UPDATE ON reservations
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (NEW.facility_id, 'reservation', NEW.id);
+END;
+
+CREATE TRIGGER reservations_sync_delete
+AFTER DELETE ON reservations
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (OLD.facility_id, 'reservation', OLD.id);
+END;
+
+-- Court, participant, and cancellation rows change what a reservation means to
+-- clients, so they bump the parent updated_at (which also logs a sync change).
+CREATE TRIGGER reservation_courts_touch_insert
+AFTER INSERT ON reservation_courts
+BEGIN
+    UPDATE reservations SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.reservation_id;
+END;
+
+CREATE TRIGGER reservation_courts_touch_delete
+AFTER DELETE ON reservation_courts
+BEGIN
+    UPDATE reservations SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.reservation_id;
+END;
+
+CREATE TRIGGER reservation_participants_touch_insert
+AFTER INSERT ON reservation_participants
+BEGIN
+    UPDATE reservations SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.reservation_id;
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    SELECT ops.facility_id, 'open_play_session', ops.id
+    FROM reservations r
+    JOIN open_play_sessions ops
+      ON ops.facility_id = r.facility_id
+     AND ops.open_play_rule_id = r.open_play_rule_id
+     AND ops.start_time = r.start_time
+     AND ops.end_time = r.end_time
+    WHERE r.id = NEW.reservation_id;
+END;
+
+CREATE TRIGGER reservation_participants_touch_delete
+AFTER DELETE ON reservation_participants
+BEGIN
+    UPDATE reservations SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.reservation_id;
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    SELECT ops.facility_id, 'open_play_session', ops.id
+    FROM reservations r
+    JOIN open_play_sessions ops
+      ON ops.facility_id = r.facility_id
+     AND ops.open_play_rule_id = r.open_play_rule_id
+     AND ops.start_time = r.start_time
+     AND ops.end_time = r.end_time
+    WHERE r.id = OLD.reservation_id;
+END;
+
+CREATE TRIGGER reservation_cancellations_touch_insert
+AFTER INSERT ON reservation_cancellations
+BEGIN
+    UPDATE reservations SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.reservation_id;
+END;
+
+CREATE TRIGGER open_play_sessions_sync_insert
+AFTER INSERT ON open_play_sessions
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (NEW.facility_id, 'open_play_session', NEW.id);
+END;
+
+CREATE TRIGGER open_play_sessions_sync_update
+AFTER UPDATE ON open_play_sessions
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (NEW.facility_id, 'open_play_session', NEW.id);
+END;
+
+CREATE TRIGGER open_play_sessions_sync_delete
+AFTER DELETE ON open_play_sessions
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    VALUES (OLD.facility_id, 'open_play_session', OLD.id);
+END;
+
+-- Rule edits change session capacity.
+CREATE TRIGGER open_play_rules_sync_update
+AFTER UPDATE ON open_play_rules
+BEGIN
+    INSERT INTO sync_changes (facility_id, entity_type, entity_id)
+    SELECT facility_id, 'open_play_session', id
+    FROM open_play_sessions
+    WHERE open_play_rule_id = NEW.id;
+END;
+
+------ MEMBER ID CARDS ------
+-- card_token is the random identifier embedded in the signed check-in QR
+-- payload; member IDs never appear in the payload. Resetting a lost card
+-- revokes the current token and issues a new one.
+CREATE TABLE member_cards (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    card_token TEXT NOT NULL UNIQUE,
+    issued_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE UNIQUE INDEX idx_member_cards_active_user ON member_cards(user_id) WHERE revoked_at IS NULL;
+
+------ RESERVATION CLOSURE DETAILS ------
+-- Maintenance and closure blocks carry two separate notes: public_reason is
+-- shown to members browsing availability, internal_notes stays with staff.
+CREATE TABLE reservation_closure_details (
+    reservation_id INTEGER PRIMARY KEY,
+    public_reason TEXT,
+    internal_notes TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE
+);
+
+------ RESERVATION NOTES ------
+-- The note a member leaves when booking. It is shown back to them and to
+-- staff.
+CREATE TABLE reservation_member_notes (
+    reservation_id INTEGER PRIMARY KEY,
+    note TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE
+);
+
+-- Staff-only comment thread on a reservation.
+CREATE TABLE reservation_comments (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    author_user_id INTEGER NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_reservation_comments_reservation ON reservation_comments(reservation_id, created_at);
+
+------ RESERVATION IDEMPOTENCY KEYS ------
+-- Keys clients send with reservation creates so a retried request replays
+-- the original response instead of booking twice. Keys are scoped per user
+-- and forgotten once expires_at passes.
+CREATE TABLE reservation_idempotency_keys (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    request_hash TEXT NOT NULL,     -- SHA-256 of the create payload
+    reservation_id INTEGER NOT NULL,
+    response_body TEXT NOT NULL,    -- JSON returned with the original 201
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    UNIQUE(user_id, idempotency_key)
+);
+
+CREATE INDEX idx_reservation_idempotency_keys_expires_at ON reservation_idempotency_keys(expires_at);
+
+------ RESERVATION DURATION OVERRIDES ------
+-- Audit trail of staff saving a reservation whose length breaks its type's
+-- duration bounds, e.g. after the bounds were tightened.
+CREATE TABLE reservation_duration_overrides (
+    id INTEGER PRIMARY KEY,
+    reservation_id INTEGER NOT NULL,
+    duration_minutes INTEGER NOT NULL,
+    min_duration_minutes INTEGER NOT NULL,
+    max_duration_minutes INTEGER,   -- NULL: the type had no maximum
+    overridden_by_user_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reservation_id) REFERENCES reservations(id) ON DELETE CASCADE,
+    FOREIGN KEY (overridden_by_user_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_reservation_duration_overrides_reservation_id ON reservation_duration_overrides(reservation_id);
+
 ------ COGNITO CONFIG ------
 CREATE TABLE cognito_config (
     id INTEGER PRIMARY KEY,
@@ -0,0 +1,623 @@
+// Package seed populates a database with a realistic development dataset:
+// one organization with two facilities in different timezones, courts,
+// operating hours, staff and pros, 50 members across membership levels, open
+// play rules, a league with teams, and a week of reservations starting today.
+//
+// Every row is looked up by a natural key before it is inserted, so running
+// the seed again only fills in what is missing. cmd/tools/seed wraps it for
+// local development and integration tests can call Run directly.
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	appdb "github.com/codr1/Pickleicious/internal/db"
+)
+
+// Summary counts the rows a run created. A re-run over a seeded database
+// reports zero everywhere except the reservations of days not yet seeded.
+type Summary struct {
+	Organizations  int
+	Facilities     int
+	OperatingHours int
+	Courts         int
+	Staff          int
+	Members        int
+	OpenPlayRules  int
+	Leagues        int
+	LeagueTeams    int
+	Reservations   int
+}
+
+type facilitySpec struct {
+	Name               string
+	Slug               string
+	Timezone           string
+	Address            string
+	Phone              string
+	Courts             int
+	MaxAdvanceDays     int
+	WeekdayOpen        string
+	WeekdayClose       string
+	WeekendOpen        string
+	WeekendClose       string
+	MaxMemberBookings  int
+	ProEmail           string
+	CreatedByUserEmail string
+}
+
+type staffSpec struct {
+	Email     string
+	Password  string
+	FirstName string
+	LastName  string
+	Role      string
+	Facility  string
+}
+
+type openPlayRuleSpec struct {
+	Name               string
+	MinParticipants    int
+	CancellationCutoff int
+	MinCourts          int
+	MaxCourts          int
+	MinMembershipLevel int
+}
+
+const (
+	organizationName = "Pickle Paradise"
+	organizationSlug = "pickle"
+
+	// MemberCount is the number of member accounts the seed creates.
+	MemberCount = 50
+
+	leagueName = "Fall Doubles League"
+)
+
+var facilities = []facilitySpec{
+	{
+		Name:               "Downtown Club",
+		Slug:               "downtown-club",
+		Timezone:           "America/New_York",
+		Address:            "120 Canal St, New York, NY 10013",
+		Phone:              "(212) 555-0140",
+		Courts:             5,
+		MaxAdvanceDays:     14,
+		WeekdayOpen:        "06:00",
+		WeekdayClose:       "22:00",
+		WeekendOpen:        "07:00",
+		WeekendClose:       "21:00",
+		MaxMemberBookings:  30,
+		ProEmail:           "pro@pickle.test",
+		CreatedByUserEmail: "admin@pickle.test",
+	},
+	{
+		Name:               "Westside Center",
+		Slug:               "westside-center",
+		Timezone:           "America/Denver",
+		Address:            "4400 W Colfax Ave, Denver, CO 80204",
+		Phone:              "(303) 555-0175",
+		Courts:             3,
+		MaxAdvanceDays:     7,
+		WeekdayOpen:        "07:00",
+		WeekdayClose:       "21:00",
+		WeekendOpen:        "08:00",
+		WeekendClose:       "20:00",
+		MaxMemberBookings:  20,
+		ProEmail:           "coach@pickle.test",
+		CreatedByUserEmail: "westside@pickle.test",
+	},
+}
+
+// Staff logins use local auth; each password is the email's local part
+// followed by "123".
+var staff = []staffSpec{
+	{"admin@pickle.test", "admin123", "Alice", "Admin", "admin", "downtown-club"},
+	{"manager@pickle.test", "manager123", "Mike", "Manager", "manager", "downtown-club"},
+	{"desk@pickle.test", "desk123", "Dana", "Desk", "desk", "downtown-club"},
+	{"pro@pickle.test", "pro123", "Pete", "Pro", "pro", "downtown-club"},
+	{"westside@pickle.test", "westside123", "Wendy", "West", "manager", "westside-center"},
+	{"coach@pickle.test", "coach123", "Cora", "Coach", "pro", "westside-center"},
+}
+
+var openPlayRules = []openPlayRuleSpec{
+	{"Morning Open Play", 4, 60, 1, 2, 0},
+	{"Evening Open Play", 6, 120, 1, 3, 0},
+	{"Advanced Open Play", 4, 90, 1, 2, 3},
+}
+
+var (
+	memberFirstNames = []string{"Alice", "Bob", "Carol", "David", "Emma", "Frank", "Grace", "Henry", "Ivy", "Jack"}
+	memberLastNames  = []string{"Johnson", "Smith", "Williams", "Brown", "Garcia"}
+	leagueTeamNames  = []string{"Dink Dynasty", "Kitchen Kings", "Net Ninjas", "Third Shot Drop"}
+)
+
+// gameHours are the facility-local start times of the hour-long games booked
+// each day, on courts 1-3 so every facility can host them.
+var gameHours = []int{9, 11, 17, 19}
+
+const (
+	proSessionHour   = 14
+	leagueNightStart = 18
+	leagueNightHours = 3
+
+	leagueNightWeekday = time.Wednesday
+)
+
+type seeder struct {
+	tx      *sql.Tx
+	now     time.Time
+	summary Summary
+
+	organizationID int64
+	facilityIDs    map[string]int64
+	courtIDs       map[string][]int64
+	userIDs        map[string]int64
+	staffIDs       map[string]int64
+	typeIDs        map[string]int64
+	// membersByFacility lists member emails by home facility slug.
+	membersByFacility map[string][]string
+}
+
+// Run seeds database in one transaction, placing reservations in the seven
+// days starting at now's date in each facility's timezone. The database must
+// be fully migrated: the standard reservation types come from migrations.
+func Run(ctx context.Context, database *appdb.DB, now time.Time) (Summary, error) {
+	tx, err := database.BeginTx(ctx)
+	if err != nil {
+		return Summary{}, err
+	}
+	defer tx.Rollback()
+
+	s := &seeder{
+		tx:                tx,
+		now:               now,
+		facilityIDs:       make(map[string]int64),
+		courtIDs:          make(map[string][]int64),
+		userIDs:           make(map[string]int64),
+		staffIDs:          make(map[string]int64),
+		typeIDs:           make(map[string]int64),
+		membersByFacility: make(map[string][]string),
+	}
+	steps := []struct {
+		name string
+		fn   func(context.Context) error
+	}{
+		{"reservation types", s.loadReservationTypes},
+		{"organization", s.seedOrganization},
+		{"facilities", s.seedFacilities},
+		{"staff", s.seedStaff},
+		{"members", s.seedMembers},
+		{"open play rules", s.seedOpenPlayRules},
+		{"league", s.seedLeague},
+		{"reservations", s.seedReservations},
+	}
+	for _, step := range steps {
+		if err := step.fn(ctx); err != nil {
+			return Summary{}, fmt.Errorf("seed %s: %w", step.name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Summary{}, fmt.Errorf("commit seed: %w", err)
+	}
+	return s.summary, nil
+}
+
+func (s *seeder) loadReservationTypes(ctx context.Context) error {
+	for _, name := range []string{"GAME", "PRO_SESSION", "LEAGUE"} {
+		var id int64
+		err := s.tx.QueryRowContext(ctx, "SELECT id FROM reservation_types WHERE name = ?", name).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("reservation type %s is missing; is the database migrated?", name)
+		}
+		if err != nil {
+			return err
+		}
+		s.typeIDs[name] = id
+	}
+	return nil
+}
+
+func (s *seeder) seedOrganization(ctx context.Context) error {
+	id, created, err := s.lookupOrInsert(ctx,
+		"SELECT id FROM organizations WHERE slug = ?", []any{organizationSlug},
+		"INSERT INTO organizations (name, slug, status) VALUES (?, ?, 'active')",
+		[]any{organizationName, organizationSlug},
+	)
+	if err != nil {
+		return err
+	}
+	s.organizationID = id
+	s.summary.Organizations += created
+	return nil
+}
+
+func (s *seeder) seedFacilities(ctx context.Context) error {
+	for _, f := range facilities {
+		id, created, err := s.lookupOrInsert(ctx,
+			"SELECT id FROM facilities WHERE slug = ?", []any{f.Slug},
+			`INSERT INTO facilities (organization_id, name, slug, timezone, address, phone, max_advance_booking_days, max_member_reservations)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			[]any{s.organizationID, f.Name, f.Slug, f.Timezone, f.Address, f.Phone, f.MaxAdvanceDays, f.MaxMemberBookings},
+		)
+		if err != nil {
+			return fmt.Errorf("facility %s: %w", f.Slug, err)
+		}
+		s.facilityIDs[f.Slug] = id
+		s.summary.Facilities += created
+
+		for day := 0; day < 7; day++ {
+			opens, closes := f.WeekdayOpen, f.WeekdayClose
+			if day == int(time.Sunday) || day == int(time.Saturday) {
+				opens, closes = f.WeekendOpen, f.WeekendClose
+			}
+			res, err := s.tx.ExecContext(ctx,
+				`INSERT OR IGNORE INTO operating_hours (facility_id, day_of_week, opens_at, closes_at)
+				 VALUES (?, ?, ?, ?)`,
+				id, day, opens, closes,
+			)
+			if err != nil {
+				return fmt.Errorf("operating hours for %s: %w", f.Slug, err)
+			}
+			s.summary.OperatingHours += rowsAffected(res)
+		}
+
+		for number := 1; number <= f.Courts; number++ {
+			// The lower half of the courts are lit indoor courts; the rest
+			// are outdoor hard courts, every other one lit.
+			indoor := number <= (f.Courts+1)/2
+			surface := "hard"
+			if indoor {
+				surface = "sport_tile"
+			}
+			lights := indoor || number%2 == 0
+			courtID, created, err := s.lookupOrInsert(ctx,
+				"SELECT id FROM courts WHERE facility_id = ? AND court_number = ?", []any{id, number},
+				"INSERT INTO courts (facility_id, name, court_number, status, is_indoor, surface, has_lights) VALUES (?, ?, ?, 'active', ?, ?, ?)",
+				[]any{id, fmt.Sprintf("Court %d", number), number, indoor, surface, lights},
+			)
+			if err != nil {
+				return fmt.Errorf("court %d for %s: %w", number, f.Slug, err)
+			}
+			s.courtIDs[f.Slug] = append(s.courtIDs[f.Slug], courtID)
+			s.summary.Courts += created
+		}
+	}
+	return nil
+}
+
+func (s *seeder) seedStaff(ctx context.Context) error {
+	for _, person := range staff {
+		facilityID := s.facilityIDs[person.Facility]
+		userID, err := s.lookupID(ctx, "SELECT id FROM users WHERE email = ?", person.Email)
+		if err != nil {
+			return err
+		}
+		if userID == 0 {
+			hash, err := bcrypt.GenerateFromPassword([]byte(person.Password), bcrypt.DefaultCost)
+			if err != nil {
+				return fmt.Errorf("hash password for %s: %w", person.Email, err)
+			}
+			userID, err = s.insert(ctx,
+				`INSERT INTO users (email, first_name, last_name, is_staff, local_auth_enabled, password_hash, home_facility_id, staff_role, status)
+				 VALUES (?, ?, ?, 1, 1, ?, ?, ?, 'active')`,
+				person.Email, person.FirstName, person.LastName, string(hash), facilityID, person.Role,
+			)
+			if err != nil {
+				return fmt.Errorf("staff user %s: %w", person.Email, err)
+			}
+		}
+		s.userIDs[person.Email] = userID
+
+		staffID, created, err := s.lookupOrInsert(ctx,
+			"SELECT id FROM staff WHERE user_id = ?", []any{userID},
+			"INSERT INTO staff (user_id, first_name, last_name, home_facility_id, role) VALUES (?, ?, ?, ?, ?)",
+			[]any{userID, person.FirstName, person.LastName, facilityID, person.Role},
+		)
+		if err != nil {
+			return fmt.Errorf("staff record %s: %w", person.Email, err)
+		}
+		s.staffIDs[person.Email] = staffID
+		s.summary.Staff += created
+	}
+	return nil
+}
+
+// seedMembers creates MemberCount members alternating between the facilities
+// and cycling through membership levels 0-3. Members sign in with OTP, so
+// they get a phone number and no password.
+func (s *seeder) seedMembers(ctx context.Context) error {
+	for i := 0; i < MemberCount; i++ {
+		first := memberFirstNames[i%len(memberFirstNames)]
+		last := memberLastNames[i/len(memberFirstNames)%len(memberLastNames)]
+		email := fmt.Sprintf("%s.%s@email.test", strings.ToLower(first), strings.ToLower(last))
+		phone := fmt.Sprintf("+1302555%04d", 1001+i)
+		facility := facilities[i%len(facilities)].Slug
+		level := i % 4
+		waiverSigned := level > 0
+		dateOfBirth := fmt.Sprintf("%d-%02d-%02d", 1960+i%40, i%12+1, i%28+1)
+
+		id, created, err := s.lookupOrInsert(ctx,
+			"SELECT id FROM users WHERE email = ?", []any{email},
+			`INSERT INTO users (email, phone, first_name, last_name, is_member, home_facility_id, membership_level, waiver_signed, date_of_birth, status)
+			 VALUES (?, ?, ?, ?, 1, ?, ?, ?, ?, 'active')`,
+			[]any{email, phone, first, last, s.facilityIDs[facility], level, waiverSigned, dateOfBirth},
+		)
+		if err != nil {
+			return fmt.Errorf("member %s: %w", email, err)
+		}
+		s.userIDs[email] = id
+		s.membersByFacility[facility] = append(s.membersByFacility[facility], email)
+		s.summary.Members += created
+	}
+	return nil
+}
+
+func (s *seeder) seedOpenPlayRules(ctx context.Context) error {
+	for _, f := range facilities {
+		facilityID := s.facilityIDs[f.Slug]
+		for _, rule := range openPlayRules {
+			maxCourts := min(rule.MaxCourts, f.Courts)
+			_, created, err := s.lookupOrInsert(ctx,
+				"SELECT id FROM open_play_rules WHERE facility_id = ? AND name = ?", []any{facilityID, rule.Name},
+				`INSERT INTO open_play_rules (facility_id, name, min_participants, max_participants_per_court, cancellation_cutoff_minutes, auto_scale_enabled, min_courts, max_courts, min_membership_level)
+				 VALUES (?, ?, ?, 8, ?, 1, ?, ?, ?)`,
+				[]any{facilityID, rule.Name, rule.MinParticipants, rule.CancellationCutoff, rule.MinCourts, maxCourts, rule.MinMembershipLevel},
+			)
+			if err != nil {
+				return fmt.Errorf("open play rule %q for %s: %w", rule.Name, f.Slug, err)
+			}
+			s.summary.OpenPlayRules += created
+		}
+	}
+	return nil
+}
+
+// seedLeague creates an active doubles league at the first facility with
+// four teams of two of its members. The first member of each team captains
+// it.
+func (s *seeder) seedLeague(ctx context.Context) error {
+	facility := facilities[0]
+	loc, err := time.LoadLocation(facility.Timezone)
+	if err != nil {
+		return err
+	}
+	startDate := s.now.In(loc)
+	leagueID, created, err := s.lookupOrInsert(ctx,
+		"SELECT id FROM leagues WHERE facility_id = ? AND name = ?", []any{s.facilityIDs[facility.Slug], leagueName},
+		`INSERT INTO leagues (facility_id, name, format, start_date, end_date, division_config, min_team_size, max_team_size, status)
+		 VALUES (?, ?, 'doubles', ?, ?, '{"divisions":["Open"]}', 2, 4, 'active')`,
+		[]any{
+			s.facilityIDs[facility.Slug], leagueName,
+			startDate.Format(time.DateOnly), startDate.AddDate(0, 0, 55).Format(time.DateOnly),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	s.summary.Leagues += created
+
+	members := s.membersByFacility[facility.Slug]
+	for i, name := range leagueTeamNames {
+		roster := members[i*2 : i*2+2]
+		teamID, created, err := s.lookupOrInsert(ctx,
+			"SELECT id FROM league_teams WHERE league_id = ? AND name = ?", []any{leagueID, name},
+			"INSERT INTO league_teams (league_id, name, captain_user_id, status) VALUES (?, ?, ?, 'active')",
+			[]any{leagueID, name, s.userIDs[roster[0]]},
+		)
+		if err != nil {
+			return fmt.Errorf("league team %q: %w", name, err)
+		}
+		s.summary.LeagueTeams += created
+		for _, email := range roster {
+			if _, err := s.tx.ExecContext(ctx,
+				"INSERT OR IGNORE INTO league_team_members (league_team_id, user_id) VALUES (?, ?)",
+				teamID, s.userIDs[email],
+			); err != nil {
+				return fmt.Errorf("league team %q member %s: %w", name, email, err)
+			}
+		}
+	}
+	return nil
+}
+
+// seedReservations books each facility for the seven days starting today:
+// doubles games through the day, a pro session in the afternoon, and a
+// Wednesday league night at the first facility. A slot is skipped when its
+// court already has a reservation starting at that time.
+func (s *seeder) seedReservations(ctx context.Context) error {
+	for fi, f := range facilities {
+		loc, err := time.LoadLocation(f.Timezone)
+		if err != nil {
+			return err
+		}
+		facilityID := s.facilityIDs[f.Slug]
+		courts := s.courtIDs[f.Slug]
+		members := s.membersByFacility[f.Slug]
+		today := s.now.In(loc)
+
+		for day := 0; day < 7; day++ {
+			date := time.Date(today.Year(), today.Month(), today.Day()+day, 0, 0, 0, 0, loc)
+			at := func(hour int) time.Time {
+				return date.Add(time.Duration(hour) * time.Hour)
+			}
+			// Courts and players rotate by calendar day rather than by offset
+			// from today so a re-run on a later day books the same slots.
+			dayNumber := int(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+
+			for slot, hour := range gameHours {
+				court := courts[(slot+dayNumber)%3]
+				players := rotate(members, dayNumber*len(gameHours)+slot, 4)
+				err := s.bookReservation(ctx, reservationSpec{
+					FacilityID: facilityID,
+					TypeID:     s.typeIDs["GAME"],
+					PrimaryID:  s.userIDs[players[0]],
+					CreatedBy:  s.userIDs[players[0]],
+					Start:      at(hour),
+					End:        at(hour + 1),
+					Courts:     []int64{court},
+					Players:    s.ids(players[1:]),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			student := rotate(members, dayNumber*7+fi, 1)[0]
+			err := s.bookReservation(ctx, reservationSpec{
+				FacilityID: facilityID,
+				TypeID:     s.typeIDs["PRO_SESSION"],
+				PrimaryID:  s.userIDs[student],
+				CreatedBy:  s.userIDs[student],
+				ProID:      s.staffIDs[f.ProEmail],
+				Start:      at(proSessionHour),
+				End:        at(proSessionHour + 1),
+				Courts:     courts[:1],
+			})
+			if err != nil {
+				return err
+			}
+
+			if fi == 0 && date.Weekday() == leagueNightWeekday {
+				err := s.bookReservation(ctx, reservationSpec{
+					FacilityID:    facilityID,
+					TypeID:        s.typeIDs["LEAGUE"],
+					CreatedBy:     s.userIDs[f.CreatedByUserEmail],
+					Start:         at(leagueNightStart),
+					End:           at(leagueNightStart + leagueNightHours),
+					Courts:        courts[3:],
+					TeamsPerCourt: 2,
+					PeoplePerTeam: 2,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type reservationSpec struct {
+	FacilityID    int64
+	TypeID        int64
+	PrimaryID     int64
+	CreatedBy     int64
+	ProID         int64
+	Start         time.Time
+	End           time.Time
+	Courts        []int64
+	Players       []int64
+	TeamsPerCourt int64
+	PeoplePerTeam int64
+}
+
+func (s *seeder) bookReservation(ctx context.Context, spec reservationSpec) error {
+	existing, err := s.lookupID(ctx,
+		`SELECT r.id FROM reservations r
+		 JOIN reservation_courts rc ON rc.reservation_id = r.id
+		 WHERE r.facility_id = ? AND rc.court_id = ? AND r.start_time = ?
+		 LIMIT 1`,
+		spec.FacilityID, spec.Courts[0], spec.Start,
+	)
+	if err != nil || existing != 0 {
+		return err
+	}
+
+	id, err := s.insert(ctx,
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, pro_id, start_time, end_time, teams_per_court, people_per_team)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		spec.FacilityID, spec.TypeID, nullID(spec.PrimaryID), spec.CreatedBy, nullID(spec.ProID),
+		spec.Start, spec.End, nullID(spec.TeamsPerCourt), nullID(spec.PeoplePerTeam),
+	)
+	if err != nil {
+		return fmt.Errorf("reservation at %s: %w", spec.Start.Format(time.RFC3339), err)
+	}
+	for _, courtID := range spec.Courts {
+		if _, err := s.tx.ExecContext(ctx,
+			"INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", id, courtID,
+		); err != nil {
+			return fmt.Errorf("reservation %d court %d: %w", id, courtID, err)
+		}
+	}
+	for _, userID := range spec.Players {
+		if _, err := s.tx.ExecContext(ctx,
+			"INSERT INTO reservation_participants (reservation_id, user_id) VALUES (?, ?)", id, userID,
+		); err != nil {
+			return fmt.Errorf("reservation %d participant %d: %w", id, userID, err)
+		}
+	}
+	s.summary.Reservations++
+	return nil
+}
+
+// lookupOrInsert returns the id found by the lookup query, or inserts a row
+// and returns its id. created is 1 when the row was inserted so callers can
+// add it to the summary.
+func (s *seeder) lookupOrInsert(ctx context.Context, lookup string, lookupArgs []any, insert string, insertArgs []any) (id int64, created int, err error) {
+	id, err = s.lookupID(ctx, lookup, lookupArgs...)
+	if err != nil || id != 0 {
+		return id, 0, err
+	}
+	id, err = s.insert(ctx, insert, insertArgs...)
+	if err != nil {
+		return 0, 0, err
+	}
+	return id, 1, nil
+}
+
+// lookupID returns the id selected by query, or 0 when there is no row.
+func (s *seeder) lookupID(ctx context.Context, query string, args ...any) (int64, error) {
+	var id int64
+	err := s.tx.QueryRowContext(ctx, query, args...).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return id, err
+}
+
+func (s *seeder) insert(ctx context.Context, query string, args ...any) (int64, error) {
+	res, err := s.tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (s *seeder) ids(emails []string) []int64 {
+	ids := make([]int64, len(emails))
+	for i, email := range emails {
+		ids[i] = s.userIDs[email]
+	}
+	return ids
+}
+
+// rotate returns n consecutive entries of list starting at offset, wrapping
+// around the end.
+func rotate(list []string, offset, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = list[(offset+i)%len(list)]
+	}
+	return out
+}
+
+func rowsAffected(res sql.Result) int {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+func nullID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
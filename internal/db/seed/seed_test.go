@@ -0,0 +1,71 @@
+package seed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestRun_SeedsDatasetAndIsIdempotent(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	now := time.Date(2026, time.March, 2, 15, 0, 0, 0, time.UTC) // a Monday
+
+	first, err := Run(ctx, database, now)
+	if err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	want := Summary{
+		Organizations:  1,
+		Facilities:     2,
+		OperatingHours: 14,
+		Courts:         8,
+		Staff:          len(staff),
+		Members:        MemberCount,
+		OpenPlayRules:  len(openPlayRules) * 2,
+		Leagues:        1,
+		LeagueTeams:    len(leagueTeamNames),
+		// Per facility and day: the games plus a pro session, and one league night.
+		Reservations: 2*7*(len(gameHours)+1) + 1,
+	}
+	if first != want {
+		t.Fatalf("first run created %+v, want %+v", first, want)
+	}
+
+	second, err := Run(ctx, database, now)
+	if err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if second != (Summary{}) {
+		t.Fatalf("expected a re-run to create nothing, got %+v", second)
+	}
+
+	counts := map[string]int{
+		"SELECT COUNT(*) FROM users WHERE is_member = 1":                          MemberCount,
+		"SELECT COUNT(DISTINCT membership_level) FROM users WHERE is_member = 1":  4,
+		"SELECT COUNT(DISTINCT timezone) FROM facilities":                         2,
+		"SELECT COUNT(*) FROM league_team_members":                                len(leagueTeamNames) * 2,
+		"SELECT COUNT(*) FROM reservations WHERE pro_id IS NOT NULL":              14,
+		"SELECT COUNT(*) FROM users WHERE is_staff = 1 AND password_hash IS NULL": 0,
+	}
+	for query, want := range counts {
+		var got int
+		if err := database.DB.QueryRowContext(ctx, query).Scan(&got); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		if got != want {
+			t.Errorf("%s = %d, want %d", query, got, want)
+		}
+	}
+
+	// A later run only adds the days that were not seeded yet.
+	third, err := Run(ctx, database, now.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("next-day run: %v", err)
+	}
+	if want := 2 * (len(gameHours) + 1); third.Reservations != want {
+		t.Fatalf("expected the next day to add %d reservations, got %d", want, third.Reservations)
+	}
+}
@@ -0,0 +1,115 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/metrics"
+)
+
+// Attachment is a file delivered alongside a plain-text message body.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// SendWithAttachment delivers a plain-text email with one attachment from the
+// default sender.
+func (c *SESClient) SendWithAttachment(ctx context.Context, recipient, subject, body string, attachment Attachment) error {
+	if c == nil || c.client == nil {
+		return fmt.Errorf("ses client is not initialized")
+	}
+	if recipient == "" {
+		return fmt.Errorf("recipient is required")
+	}
+	if Suppressed(ctx) {
+		log.Info().Str("recipient_masked", maskEmail(recipient)).Msg("Email with attachment suppressed")
+		return nil
+	}
+
+	raw, err := buildRawMessage(c.sender, recipient, subject, body, attachment)
+	if err != nil {
+		return fmt.Errorf("build raw email: %w", err)
+	}
+
+	input := &sesv2.SendEmailInput{
+		Destination: &types.Destination{
+			ToAddresses: []string{recipient},
+		},
+		Content: &types.EmailContent{
+			Raw: &types.RawMessage{Data: raw},
+		},
+		FromEmailAddress: aws.String(c.sender),
+	}
+
+	_, err = c.client.SendEmail(ctx, input)
+	metrics.EmailSent(err)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("recipient_masked", maskEmail(recipient)).
+			Int("subject_len", len(subject)).
+			Msg("Failed to send SES email with attachment")
+		return fmt.Errorf("send ses email: %w", err)
+	}
+
+	return nil
+}
+
+// buildRawMessage renders a multipart/mixed MIME message.
+func buildRawMessage(sender, recipient, subject, body string, attachment Attachment) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", sender)
+	fmt.Fprintf(&buf, "To: %s\r\n", recipient)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", writer.Boundary())
+
+	textPart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"8bit"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := textPart.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+
+	filePart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {attachment.ContentType},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+	})
+	if err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+	for len(encoded) > 76 {
+		if _, err := filePart.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return nil, err
+		}
+		encoded = encoded[76:]
+	}
+	if _, err := filePart.Write([]byte(encoded)); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
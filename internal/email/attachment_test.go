@@ -0,0 +1,54 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+)
+
+func TestBuildRawMessage_AttachesFile(t *testing.T) {
+	data := bytes.Repeat([]byte{0x89, 'P', 'N', 'G'}, 40)
+	raw, err := buildRawMessage("club@test.com", "member@test.com", "Your Member Card", "See attached.", Attachment{
+		Filename:    "member-card.png",
+		ContentType: "image/png",
+		Data:        data,
+	})
+	if err != nil {
+		t.Fatalf("buildRawMessage: %v", err)
+	}
+
+	message, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("content type = %q (%v)", mediaType, err)
+	}
+
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	text, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("text part: %v", err)
+	}
+	if body, _ := io.ReadAll(text); string(body) != "See attached." {
+		t.Fatalf("text body = %q", body)
+	}
+
+	file, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("attachment part: %v", err)
+	}
+	if file.FileName() != "member-card.png" {
+		t.Fatalf("filename = %q", file.FileName())
+	}
+	encoded, _ := io.ReadAll(file)
+	decoded, err := base64.StdEncoding.DecodeString(string(bytes.ReplaceAll(encoded, []byte("\r\n"), nil)))
+	if err != nil || !bytes.Equal(decoded, data) {
+		t.Fatalf("attachment data mismatch (%v)", err)
+	}
+}
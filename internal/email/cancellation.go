@@ -43,21 +43,11 @@ func SendCancellationEmail(ctx context.Context, q *dbgen.Queries, client EmailSe
 	if recipient == "" {
 		return
 	}
+	if Undeliverable(user, "cancellation", logger) {
+		return
+	}
 
-	go func() {
-		sendCtx, cancel := newEmailContext(ctx, cancellationEmailTimeout)
-		defer cancel()
-		if sendCtx.Err() != nil {
-			return
-		}
-		if err := client.SendFrom(sendCtx, recipient, message.Subject, message.Body, sender); err != nil {
-			if logger != nil {
-				logger.Error().Err(err).Int64("user_id", userID).Msg("Failed to send cancellation email")
-			}
-		} else if logger != nil {
-			logger.Info().Int64("user_id", userID).Msg("Cancellation email sent")
-		}
-	}()
+	dispatchEmail(ctx, q, client, userID, recipient, sender, message, time.Now(), cancellationEmailTimeout, "cancellation", logger)
 }
 
 // ResolveFromAddress uses the facility email_from_address with an organization fallback.
@@ -0,0 +1,49 @@
+package email
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const changeEmailTimeout = 5 * time.Second
+
+// SendReservationChangedEmail sends a reservation change email asynchronously.
+func SendReservationChangedEmail(ctx context.Context, q *dbgen.Queries, client EmailSender, userID int64, message ConfirmationEmail, sender string, logger *zerolog.Logger) {
+	if client == nil || q == nil {
+		return
+	}
+	if userID <= 0 {
+		if logger != nil {
+			logger.Warn().Int64("user_id", userID).Msg("Skipping change email with invalid user ID")
+		}
+		return
+	}
+	if message.Subject == "" || message.Body == "" {
+		return
+	}
+
+	user, err := q.GetUserByID(ctx, userID)
+	if err != nil {
+		if logger != nil {
+			logger.Error().Err(err).Int64("user_id", userID).Msg("Failed to load user for change email")
+		}
+		return
+	}
+	if !user.Email.Valid {
+		return
+	}
+	recipient := strings.TrimSpace(user.Email.String)
+	if recipient == "" {
+		return
+	}
+	if Undeliverable(user, "change", logger) {
+		return
+	}
+
+	dispatchEmail(ctx, q, client, userID, recipient, sender, message, time.Now(), changeEmailTimeout, "change", logger)
+}
@@ -36,19 +36,9 @@ func SendConfirmationEmail(ctx context.Context, q *dbgen.Queries, client EmailSe
 	if recipient == "" {
 		return
 	}
+	if Undeliverable(user, "confirmation", logger) {
+		return
+	}
 
-	go func() {
-		sendCtx, cancel := newEmailContext(ctx, confirmationEmailTimeout)
-		defer cancel()
-		if sendCtx.Err() != nil {
-			return
-		}
-		if err := client.Send(sendCtx, recipient, confirmation.Subject, confirmation.Body); err != nil {
-			if logger != nil {
-				logger.Error().Err(err).Int64("user_id", userID).Msg("Failed to send confirmation email")
-			}
-		} else if logger != nil {
-			logger.Info().Int64("user_id", userID).Msg("Confirmation email sent")
-		}
-	}()
+	dispatchEmail(ctx, q, client, userID, recipient, "", confirmation, time.Now(), confirmationEmailTimeout, "confirmation", logger)
 }
@@ -5,6 +5,24 @@ import (
 	"time"
 )
 
+type suppressedContextKey struct{}
+
+// WithSuppressed marks ctx so that email and notifications sent under it are
+// dropped instead of delivered. Staff impersonating a member use it so their
+// debugging never reaches the member's inbox.
+func WithSuppressed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, suppressedContextKey{}, true)
+}
+
+// Suppressed reports whether ctx was marked by WithSuppressed.
+func Suppressed(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	suppressed, _ := ctx.Value(suppressedContextKey{}).(bool)
+	return suppressed
+}
+
 func newEmailContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
 	if parent == nil {
 		parent = context.Background()
@@ -0,0 +1,54 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// Undeliverable reports whether email to the user should be skipped because
+// SES reported their address as hard-bounced or complained about. The skip is
+// logged so staff can see why a member stopped receiving email.
+func Undeliverable(user dbgen.User, kind string, logger *zerolog.Logger) bool {
+	if !user.EmailUndeliverable {
+		return false
+	}
+	if logger != nil {
+		logger.Warn().
+			Int64("user_id", user.ID).
+			Str("recipient_masked", maskEmail(user.Email.String)).
+			Str("kind", kind).
+			Msg("Skipping email to undeliverable address")
+	}
+	return true
+}
+
+// RecordDeliveryEvent stores a bounce or complaint reported in the SNS
+// message and, for hard events, flags every user with that address. It
+// returns how many users were newly flagged; a redelivered message flags
+// nobody.
+func RecordDeliveryEvent(ctx context.Context, q *dbgen.Queries, snsMessageID string, event DeliveryEvent) (int64, error) {
+	inserted, err := q.CreateEmailDeliveryEvent(ctx, dbgen.CreateEmailDeliveryEventParams{
+		Email:         event.Email,
+		EventType:     event.Type,
+		BounceType:    sql.NullString{String: event.BounceType, Valid: event.BounceType != ""},
+		BounceSubType: sql.NullString{String: event.BounceSubType, Valid: event.BounceSubType != ""},
+		Diagnostic:    sql.NullString{String: event.Diagnostic, Valid: event.Diagnostic != ""},
+		SnsMessageID:  snsMessageID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("record email delivery event: %w", err)
+	}
+	if inserted == 0 || !event.Hard() {
+		return 0, nil
+	}
+	flagged, err := q.MarkEmailUndeliverable(ctx, event.Email)
+	if err != nil {
+		return 0, fmt.Errorf("flag undeliverable email: %w", err)
+	}
+	return flagged, nil
+}
@@ -0,0 +1,54 @@
+package email
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestRecordDeliveryEvent_HardEventsStopEmail(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	userID := insertTestUser(t, database, "Member@Test.com")
+
+	flagged, err := RecordDeliveryEvent(ctx, database.Queries, "msg-1", DeliveryEvent{
+		Email:      "member@test.com",
+		Type:       DeliveryEventBounce,
+		BounceType: "Transient",
+	})
+	if err != nil || flagged != 0 {
+		t.Fatalf("transient bounce flagged %d users, err %v; want none", flagged, err)
+	}
+
+	hard := DeliveryEvent{Email: "member@test.com", Type: DeliveryEventBounce, BounceType: BounceTypePermanent, BounceSubType: "General"}
+	flagged, err = RecordDeliveryEvent(ctx, database.Queries, "msg-2", hard)
+	if err != nil || flagged != 1 {
+		t.Fatalf("permanent bounce flagged %d users, err %v; want 1", flagged, err)
+	}
+	if flagged, err = RecordDeliveryEvent(ctx, database.Queries, "msg-2", hard); err != nil || flagged != 0 {
+		t.Fatalf("redelivered bounce flagged %d users, err %v; want none", flagged, err)
+	}
+	events, err := database.Queries.ListEmailDeliveryEvents(ctx, dbgen.ListEmailDeliveryEventsParams{Email: "MEMBER@test.com", Limit: 10})
+	if err != nil || len(events) != 2 {
+		t.Fatalf("expected 2 recorded events, got %d (err %v)", len(events), err)
+	}
+
+	sender := newFakeEmailSender()
+	message := ConfirmationEmail{Subject: "Subject", Body: "Body"}
+	SendConfirmationEmail(ctx, database.Queries, sender, userID, message, nil)
+	SendCancellationEmail(ctx, database.Queries, sender, userID, message, "cancellations@test.com", nil)
+	select {
+	case <-sender.sendStarted:
+		t.Fatal("expected confirmation to undeliverable address to be skipped")
+	case <-sender.sendFromStarted:
+		t.Fatal("expected cancellation to undeliverable address to be skipped")
+	case <-time.After(200 * time.Millisecond):
+	}
+	if calls := atomic.LoadInt32(&sender.sendCalls) + atomic.LoadInt32(&sender.sendFromCalls); calls != 0 {
+		t.Fatalf("expected no sends, got %d", calls)
+	}
+}
@@ -0,0 +1,311 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	deferredEmailTimeout   = 5 * time.Second
+	deferredEmailBatchSize = 100
+	quietHoursClockLayout  = "15:04"
+)
+
+// Category classifies outbound email for quiet-hours handling.
+type Category string
+
+const (
+	CategoryNotice        Category = ""
+	CategoryReminder      Category = "reminder"
+	CategoryWaitlistOffer Category = "waitlist_offer"
+	CategoryVerification  Category = "verification"
+)
+
+// Urgent reports whether the category bypasses facility quiet hours.
+func (c Category) Urgent() bool {
+	switch c {
+	case CategoryWaitlistOffer, CategoryVerification:
+		return true
+	default:
+		return false
+	}
+}
+
+// QuietHours is a daily HH:MM window in facility time during which
+// non-urgent email is held. A window whose start is after its end wraps
+// past midnight.
+type QuietHours struct {
+	Start string
+	End   string
+}
+
+// Contains reports whether the wall-clock time of t falls inside the window.
+func (qh QuietHours) Contains(t time.Time) bool {
+	if qh.Start == "" || qh.End == "" || qh.Start == qh.End {
+		return false
+	}
+	clock := t.Format(quietHoursClockLayout)
+	if qh.Start < qh.End {
+		return clock >= qh.Start && clock < qh.End
+	}
+	return clock >= qh.Start || clock < qh.End
+}
+
+// NextSendTime returns now when it falls outside quiet hours, otherwise the
+// moment the current quiet window ends in loc.
+func (qh QuietHours) NextSendTime(now time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	if !qh.Contains(local) {
+		return now
+	}
+	end, err := time.Parse(quietHoursClockLayout, qh.End)
+	if err != nil {
+		return now
+	}
+
+	day := local.Day()
+	if local.Format(quietHoursClockLayout) >= qh.End {
+		day++
+	}
+	// time.Date normalizes both the day overflow and wall times skipped by DST.
+	return time.Date(local.Year(), local.Month(), day, end.Hour(), end.Minute(), 0, 0, loc)
+}
+
+// dispatchEmail sends the message now, or queues it when it is non-urgent
+// facility mail that would otherwise arrive during quiet hours. An empty
+// sender uses the client default address. Suppressed contexts send nothing.
+func dispatchEmail(ctx context.Context, q *dbgen.Queries, client EmailSender, userID int64, recipient, sender string, message ConfirmationEmail, now time.Time, timeout time.Duration, kind string, logger *zerolog.Logger) {
+	if Suppressed(ctx) {
+		if logger != nil {
+			logger.Info().Int64("user_id", userID).Msgf("%s email suppressed", capitalize(kind))
+		}
+		return
+	}
+	if sendAt, ok := quietHoursSendTime(ctx, q, message, now, logger); ok {
+		reservationID := sql.NullInt64{}
+		if message.ReservationID > 0 {
+			reservationID = sql.NullInt64{Int64: message.ReservationID, Valid: true}
+		}
+		_, err := q.CreateDeferredEmail(ctx, dbgen.CreateDeferredEmailParams{
+			FacilityID:    message.FacilityID,
+			ReservationID: reservationID,
+			UserID:        userID,
+			Channel:       string(ChannelEmail),
+			Recipient:     recipient,
+			Sender:        sender,
+			Subject:       message.Subject,
+			Body:          message.Body,
+			SendAfter:     sendAt.UTC(),
+		})
+		if err == nil {
+			if logger != nil {
+				logger.Info().Int64("user_id", userID).Time("send_after", sendAt).Msgf("%s email deferred for quiet hours", kind)
+			}
+			return
+		}
+		// Sending during quiet hours beats silently dropping the message.
+		if logger != nil {
+			logger.Error().Err(err).Int64("user_id", userID).Msgf("Failed to defer %s email; sending immediately", kind)
+		}
+	}
+
+	go func() {
+		sendCtx, cancel := newEmailContext(ctx, timeout)
+		defer cancel()
+		if sendCtx.Err() != nil {
+			return
+		}
+		if err := deliver(sendCtx, client, recipient, sender, message.Subject, message.Body); err != nil {
+			if logger != nil {
+				logger.Error().Err(err).Int64("user_id", userID).Msgf("Failed to send %s email", kind)
+			}
+			return
+		}
+		if logger != nil {
+			logger.Info().Int64("user_id", userID).Msgf("%s email sent", capitalize(kind))
+		}
+	}()
+}
+
+// dispatchText texts the message's short form now, or queues it under the
+// same quiet-hours rules as email. Suppressed contexts send nothing.
+func dispatchText(ctx context.Context, q *dbgen.Queries, notifier Notifier, recipient Recipient, message ConfirmationEmail, now time.Time, kind string, logger *zerolog.Logger) {
+	if Suppressed(ctx) {
+		if logger != nil {
+			logger.Info().Int64("user_id", recipient.UserID).Msgf("%s text suppressed", capitalize(kind))
+		}
+		return
+	}
+	if sendAt, ok := quietHoursSendTime(ctx, q, message, now, logger); ok {
+		reservationID := sql.NullInt64{}
+		if message.ReservationID > 0 {
+			reservationID = sql.NullInt64{Int64: message.ReservationID, Valid: true}
+		}
+		_, err := q.CreateDeferredEmail(ctx, dbgen.CreateDeferredEmailParams{
+			FacilityID:    message.FacilityID,
+			ReservationID: reservationID,
+			UserID:        recipient.UserID,
+			Channel:       string(ChannelSMS),
+			Recipient:     recipient.Phone,
+			Subject:       message.Subject,
+			Body:          message.TextOrSubject(),
+			SendAfter:     sendAt.UTC(),
+		})
+		if err == nil {
+			if logger != nil {
+				logger.Info().Int64("user_id", recipient.UserID).Time("send_after", sendAt).Msgf("%s text deferred for quiet hours", kind)
+			}
+			return
+		}
+		if logger != nil {
+			logger.Error().Err(err).Int64("user_id", recipient.UserID).Msgf("Failed to defer %s text; sending immediately", kind)
+		}
+	}
+
+	sendCtx, cancel := newEmailContext(ctx, notifyChannelTimeout)
+	defer cancel()
+	if err := notifier.Notify(sendCtx, recipient, message, ""); err != nil {
+		if logger != nil {
+			logger.Error().Err(err).Int64("user_id", recipient.UserID).Msgf("Failed to send %s text", kind)
+		}
+		return
+	}
+	if logger != nil {
+		logger.Info().Int64("user_id", recipient.UserID).Msgf("%s text sent", capitalize(kind))
+	}
+}
+
+// quietHoursSendTime returns the deferred send time when the message must
+// wait for the facility's quiet hours to end.
+func quietHoursSendTime(ctx context.Context, q *dbgen.Queries, message ConfirmationEmail, now time.Time, logger *zerolog.Logger) (time.Time, bool) {
+	if q == nil || message.FacilityID <= 0 || message.Category.Urgent() {
+		return time.Time{}, false
+	}
+
+	row, err := q.GetFacilityQuietHours(ctx, message.FacilityID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) && logger != nil {
+			logger.Error().Err(err).Int64("facility_id", message.FacilityID).Msg("Failed to load facility quiet hours")
+		}
+		return time.Time{}, false
+	}
+
+	loc := apiutil.DefaultLocation()
+	facility, err := q.GetFacilityByID(ctx, message.FacilityID)
+	if err != nil {
+		if logger != nil {
+			logger.Error().Err(err).Int64("facility_id", message.FacilityID).Msg("Failed to load facility for quiet hours")
+		}
+	} else {
+		loc = apiutil.FacilityLocation(facility, logger)
+	}
+
+	sendAt := QuietHours{Start: row.StartsAt, End: row.EndsAt}.NextSendTime(now, loc)
+	if !sendAt.After(now) {
+		return time.Time{}, false
+	}
+	return sendAt, true
+}
+
+// DispatchDueEmails sends queued emails and texts whose quiet-hours hold has
+// elapsed. Messages tied to a reservation that has since been cancelled,
+// deleted, or already started are suppressed instead of sent. Messages for a
+// channel with no configured sender are marked failed so they do not clog
+// the queue.
+func DispatchDueEmails(ctx context.Context, q *dbgen.Queries, client EmailSender, smsNotifier Notifier, now time.Time, logger *zerolog.Logger) (int, error) {
+	if q == nil || (client == nil && smsNotifier == nil) {
+		return 0, nil
+	}
+
+	due, err := q.ListDueDeferredEmails(ctx, dbgen.ListDueDeferredEmailsParams{
+		Now:   now.UTC(),
+		Limit: deferredEmailBatchSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list due deferred emails: %w", err)
+	}
+
+	sent := 0
+	for _, queued := range due {
+		isText := queued.Channel == string(ChannelSMS)
+		status := "sent"
+		if (isText && smsNotifier == nil) || (!isText && client == nil) {
+			status = "failed"
+			if logger != nil {
+				logger.Warn().Int64("deferred_email_id", queued.ID).Str("channel", queued.Channel).Msg("Deferred message channel not configured")
+			}
+		} else if queued.ReservationID.Valid {
+			upcoming, err := q.IsReservationUpcoming(ctx, dbgen.IsReservationUpcomingParams{
+				ReservationID: queued.ReservationID.Int64,
+				Now:           now.UTC(),
+			})
+			if err != nil {
+				if logger != nil {
+					logger.Error().Err(err).Int64("deferred_email_id", queued.ID).Msg("Failed to check reservation for deferred email")
+				}
+				continue
+			}
+			if upcoming == 0 {
+				status = "suppressed"
+			}
+		}
+
+		if status == "sent" {
+			sendCtx, cancel := context.WithTimeout(ctx, deferredEmailTimeout)
+			var err error
+			if isText {
+				err = smsNotifier.Notify(sendCtx, Recipient{UserID: queued.UserID, Phone: queued.Recipient}, ConfirmationEmail{
+					Subject: queued.Subject,
+					Text:    queued.Body,
+				}, "")
+			} else {
+				err = deliver(sendCtx, client, queued.Recipient, queued.Sender, queued.Subject, queued.Body)
+			}
+			cancel()
+			if err != nil {
+				status = "failed"
+				if logger != nil {
+					logger.Error().Err(err).Int64("deferred_email_id", queued.ID).Int64("user_id", queued.UserID).Str("channel", queued.Channel).Msg("Failed to send deferred email")
+				}
+			} else {
+				sent++
+			}
+		}
+
+		if _, err := q.MarkDeferredEmailProcessed(ctx, dbgen.MarkDeferredEmailProcessedParams{
+			Status:      status,
+			ProcessedAt: sql.NullTime{Time: now.UTC(), Valid: true},
+			ID:          queued.ID,
+		}); err != nil && logger != nil {
+			logger.Error().Err(err).Int64("deferred_email_id", queued.ID).Str("status", status).Msg("Failed to update deferred email")
+		}
+	}
+
+	return sent, nil
+}
+
+func deliver(ctx context.Context, client EmailSender, recipient, sender, subject, body string) error {
+	if strings.TrimSpace(sender) == "" {
+		return client.Send(ctx, recipient, subject, body)
+	}
+	return client.SendFrom(ctx, recipient, subject, body, sender)
+}
+
+func capitalize(value string) string {
+	if value == "" {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + value[1:]
+}
@@ -0,0 +1,396 @@
+package email
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type recordingEmailSender struct {
+	mu   sync.Mutex
+	sent []string
+}
+
+func (r *recordingEmailSender) Send(ctx context.Context, recipient, subject, body string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, recipient)
+	return nil
+}
+
+func (r *recordingEmailSender) SendFrom(ctx context.Context, recipient, subject, body, sender string) error {
+	return r.Send(ctx, recipient, subject, body)
+}
+
+func (r *recordingEmailSender) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.sent)
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestQuietHoursNextSendTime(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	overnight := QuietHours{Start: "21:00", End: "08:00"}
+
+	tests := []struct {
+		name  string
+		quiet QuietHours
+		now   time.Time
+		want  time.Time
+	}{
+		{
+			name:  "before_quiet_hours",
+			quiet: overnight,
+			now:   time.Date(2026, time.June, 1, 20, 59, 0, 0, newYork),
+			want:  time.Date(2026, time.June, 1, 20, 59, 0, 0, newYork),
+		},
+		{
+			name:  "evening_defers_to_next_morning",
+			quiet: overnight,
+			now:   time.Date(2026, time.June, 1, 22, 0, 0, 0, newYork),
+			want:  time.Date(2026, time.June, 2, 8, 0, 0, 0, newYork),
+		},
+		{
+			name:  "early_morning_defers_to_same_morning",
+			quiet: overnight,
+			now:   time.Date(2026, time.June, 2, 6, 0, 0, 0, newYork),
+			want:  time.Date(2026, time.June, 2, 8, 0, 0, 0, newYork),
+		},
+		{
+			name:  "end_of_window_sends",
+			quiet: overnight,
+			now:   time.Date(2026, time.June, 2, 8, 0, 0, 0, newYork),
+			want:  time.Date(2026, time.June, 2, 8, 0, 0, 0, newYork),
+		},
+		{
+			name:  "month_rollover",
+			quiet: overnight,
+			now:   time.Date(2026, time.June, 30, 23, 30, 0, 0, newYork),
+			want:  time.Date(2026, time.July, 1, 8, 0, 0, 0, newYork),
+		},
+		{
+			name:  "same_day_window",
+			quiet: QuietHours{Start: "12:00", End: "13:30"},
+			now:   time.Date(2026, time.June, 1, 12, 15, 0, 0, newYork),
+			want:  time.Date(2026, time.June, 1, 13, 30, 0, 0, newYork),
+		},
+		{
+			name:  "evaluated_in_facility_time",
+			quiet: overnight,
+			now:   time.Date(2026, time.June, 2, 2, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, time.June, 2, 8, 0, 0, 0, newYork),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.quiet.NextSendTime(test.now, newYork)
+			if !got.Equal(test.want) {
+				t.Fatalf("NextSendTime(%s) = %s, want %s", test.now, got, test.want)
+			}
+		})
+	}
+}
+
+func TestQuietHoursNextSendTime_DSTShortenedNight(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	quiet := QuietHours{Start: "21:00", End: "08:00"}
+
+	// Clocks spring forward at 02:00 on 2026-03-08, so the night is an hour short.
+	now := time.Date(2026, time.March, 7, 22, 0, 0, 0, newYork)
+	got := quiet.NextSendTime(now, newYork)
+
+	want := time.Date(2026, time.March, 8, 8, 0, 0, 0, newYork)
+	if !got.Equal(want) {
+		t.Fatalf("NextSendTime = %s, want %s", got, want)
+	}
+	if elapsed := got.Sub(now); elapsed != 9*time.Hour {
+		t.Fatalf("expected 9h deferral across the DST change, got %s", elapsed)
+	}
+	if _, offset := got.Zone(); offset != -4*60*60 {
+		t.Fatalf("expected EDT offset after the change, got %d", offset)
+	}
+}
+
+func TestCategoryUrgent(t *testing.T) {
+	for _, category := range []Category{CategoryWaitlistOffer, CategoryVerification} {
+		if !category.Urgent() {
+			t.Fatalf("expected %q to be urgent", category)
+		}
+	}
+	for _, category := range []Category{CategoryNotice, CategoryReminder} {
+		if category.Urgent() {
+			t.Fatalf("expected %q to be non-urgent", category)
+		}
+	}
+}
+
+type quietHoursFixture struct {
+	database      *db.DB
+	facilityID    int64
+	userID        int64
+	reservationID int64
+	loc           *time.Location
+}
+
+func setupQuietHoursFixture(t *testing.T) quietHoursFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+	loc := mustLoadLocation(t, "America/New_York")
+
+	orgResult, err := database.Exec(
+		`INSERT INTO organizations (name, slug, status) VALUES (?, ?, ?)`,
+		"Test Org", "test-org", "active",
+	)
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, err := orgResult.LastInsertId()
+	if err != nil {
+		t.Fatalf("organization id: %v", err)
+	}
+
+	facilityResult, err := database.Exec(
+		`INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, ?, ?, ?)`,
+		orgID, "Main Facility", "main-facility", loc.String(),
+	)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, err := facilityResult.LastInsertId()
+	if err != nil {
+		t.Fatalf("facility id: %v", err)
+	}
+
+	if _, err := database.Queries.UpsertFacilityQuietHours(context.Background(), dbgen.UpsertFacilityQuietHoursParams{
+		FacilityID: facilityID,
+		StartsAt:   "21:00",
+		EndsAt:     "08:00",
+	}); err != nil {
+		t.Fatalf("upsert quiet hours: %v", err)
+	}
+
+	userID := insertTestUser(t, database, "member@test.com")
+
+	start := time.Date(2026, time.June, 2, 18, 0, 0, 0, loc)
+	reservationResult, err := database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types ORDER BY id LIMIT 1), ?, ?, ?, ?)`,
+		facilityID, userID, userID, start.UTC(), start.Add(time.Hour).UTC(),
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, err := reservationResult.LastInsertId()
+	if err != nil {
+		t.Fatalf("reservation id: %v", err)
+	}
+
+	return quietHoursFixture{
+		database:      database,
+		facilityID:    facilityID,
+		userID:        userID,
+		reservationID: reservationID,
+		loc:           loc,
+	}
+}
+
+func (f quietHoursFixture) reminder() ConfirmationEmail {
+	return BuildReminderEmail(ReminderDetails{
+		FacilityID:    f.facilityID,
+		ReservationID: f.reservationID,
+		FacilityName:  "Main Facility",
+	})
+}
+
+func TestDispatchEmail_ReminderDuringQuietHoursDeliveredNextMorning(t *testing.T) {
+	fixture := setupQuietHoursFixture(t)
+	ctx := context.Background()
+	sender := &recordingEmailSender{}
+
+	scheduledAt := time.Date(2026, time.June, 1, 22, 0, 0, 0, fixture.loc)
+	dispatchEmail(ctx, fixture.database.Queries, sender, fixture.userID, "member@test.com", "reminders@test.com", fixture.reminder(), scheduledAt, testTimeout, "reminder", nil)
+
+	var sendAfter time.Time
+	if err := fixture.database.QueryRow(`SELECT send_after FROM deferred_emails WHERE user_id = ?`, fixture.userID).Scan(&sendAfter); err != nil {
+		t.Fatalf("load deferred email: %v", err)
+	}
+	want := time.Date(2026, time.June, 2, 8, 0, 0, 0, fixture.loc)
+	if !sendAfter.Equal(want) {
+		t.Fatalf("send_after = %s, want %s", sendAfter.In(fixture.loc), want)
+	}
+
+	sent, err := DispatchDueEmails(ctx, fixture.database.Queries, sender, nil, want.Add(-time.Minute), nil)
+	if err != nil {
+		t.Fatalf("dispatch before window end: %v", err)
+	}
+	if sent != 0 || sender.count() != 0 {
+		t.Fatalf("expected no sends before 08:00, got %d", sender.count())
+	}
+
+	sent, err = DispatchDueEmails(ctx, fixture.database.Queries, sender, nil, want, nil)
+	if err != nil {
+		t.Fatalf("dispatch at window end: %v", err)
+	}
+	if sent != 1 || sender.count() != 1 {
+		t.Fatalf("expected one send at 08:00, got %d", sender.count())
+	}
+
+	sent, err = DispatchDueEmails(ctx, fixture.database.Queries, sender, nil, want.Add(time.Hour), nil)
+	if err != nil {
+		t.Fatalf("dispatch after send: %v", err)
+	}
+	if sent != 0 || sender.count() != 1 {
+		t.Fatalf("expected deferred email to send once, got %d", sender.count())
+	}
+}
+
+func TestDispatchDueEmails_SuppressesCancelledReservation(t *testing.T) {
+	fixture := setupQuietHoursFixture(t)
+	ctx := context.Background()
+	sender := &recordingEmailSender{}
+
+	scheduledAt := time.Date(2026, time.June, 1, 22, 0, 0, 0, fixture.loc)
+	dispatchEmail(ctx, fixture.database.Queries, sender, fixture.userID, "member@test.com", "reminders@test.com", fixture.reminder(), scheduledAt, testTimeout, "reminder", nil)
+
+	// Closure mass-cancellations record a cancellation row per reservation.
+	if _, err := fixture.database.Exec(
+		`INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, fee_waived, hours_before_start)
+		 VALUES (?, ?, ?, 100, 1, 20)`,
+		fixture.reservationID, fixture.userID, scheduledAt.UTC(),
+	); err != nil {
+		t.Fatalf("insert cancellation: %v", err)
+	}
+
+	sent, err := DispatchDueEmails(ctx, fixture.database.Queries, sender, nil, time.Date(2026, time.June, 2, 8, 0, 0, 0, fixture.loc), nil)
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if sent != 0 || sender.count() != 0 {
+		t.Fatalf("expected cancelled reservation reminder to be suppressed, got %d sends", sender.count())
+	}
+
+	var status string
+	if err := fixture.database.QueryRow(`SELECT status FROM deferred_emails WHERE user_id = ?`, fixture.userID).Scan(&status); err != nil {
+		t.Fatalf("load deferred email status: %v", err)
+	}
+	if status != "suppressed" {
+		t.Fatalf("status = %q, want suppressed", status)
+	}
+}
+
+func TestDispatchEmail_UrgentBypassesQuietHours(t *testing.T) {
+	fixture := setupQuietHoursFixture(t)
+	sender := newFakeEmailSender()
+
+	message := ConfirmationEmail{
+		Subject:    "Court available",
+		Body:       "A court opened up.",
+		Category:   CategoryWaitlistOffer,
+		FacilityID: fixture.facilityID,
+	}
+	scheduledAt := time.Date(2026, time.June, 1, 22, 0, 0, 0, fixture.loc)
+	dispatchEmail(context.Background(), fixture.database.Queries, sender, fixture.userID, "member@test.com", "", message, scheduledAt, testTimeout, "waitlist offer", nil)
+
+	waitForSignal(t, sender.sendStarted, "expected urgent email to send immediately")
+
+	var queued int
+	if err := fixture.database.QueryRow(`SELECT COUNT(*) FROM deferred_emails`).Scan(&queued); err != nil {
+		t.Fatalf("count deferred emails: %v", err)
+	}
+	if queued != 0 {
+		t.Fatalf("expected no deferred emails, got %d", queued)
+	}
+}
+
+func TestDispatchEmail_SuppressedContextSendsNothing(t *testing.T) {
+	fixture := setupQuietHoursFixture(t)
+	ctx := WithSuppressed(context.Background())
+	sender := &recordingEmailSender{}
+
+	// During quiet hours an unsuppressed reminder would be queued.
+	scheduledAt := time.Date(2026, time.June, 1, 22, 0, 0, 0, fixture.loc)
+	dispatchEmail(ctx, fixture.database.Queries, sender, fixture.userID, "member@test.com", "reminders@test.com", fixture.reminder(), scheduledAt, testTimeout, "reminder", nil)
+
+	var queued int
+	if err := fixture.database.QueryRow(`SELECT COUNT(*) FROM deferred_emails`).Scan(&queued); err != nil {
+		t.Fatalf("count deferred emails: %v", err)
+	}
+	if queued != 0 || sender.count() != 0 {
+		t.Fatalf("expected a suppressed email to be dropped, got %d queued and %d sent", queued, sender.count())
+	}
+}
+
+func TestDispatchText_ReminderDuringQuietHoursDeliveredNextMorning(t *testing.T) {
+	fixture := setupQuietHoursFixture(t)
+	ctx := context.Background()
+	sms := &recordingNotifier{channel: ChannelSMS}
+
+	scheduledAt := time.Date(2026, time.June, 1, 22, 0, 0, 0, fixture.loc)
+	recipient := Recipient{UserID: fixture.userID, Phone: "+14155550199"}
+	dispatchText(ctx, fixture.database.Queries, sms, recipient, fixture.reminder(), scheduledAt, "reminder", nil)
+	if len(sms.calls()) != 0 {
+		t.Fatalf("expected no text during quiet hours, got %d", len(sms.calls()))
+	}
+
+	var channel, queuedRecipient string
+	if err := fixture.database.QueryRow(`SELECT channel, recipient FROM deferred_emails WHERE user_id = ?`, fixture.userID).Scan(&channel, &queuedRecipient); err != nil {
+		t.Fatalf("load deferred text: %v", err)
+	}
+	if channel != string(ChannelSMS) || queuedRecipient != recipient.Phone {
+		t.Fatalf("queued channel=%q recipient=%q, want sms to %s", channel, queuedRecipient, recipient.Phone)
+	}
+
+	mail := &recordingEmailSender{}
+	sent, err := DispatchDueEmails(ctx, fixture.database.Queries, mail, sms, time.Date(2026, time.June, 2, 8, 0, 0, 0, fixture.loc), nil)
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if sent != 1 || len(sms.calls()) != 1 || mail.count() != 0 {
+		t.Fatalf("expected one text and no email at 08:00, got %d texts and %d emails", len(sms.calls()), mail.count())
+	}
+	if got := sms.calls()[0].Phone; got != recipient.Phone {
+		t.Fatalf("texted %q, want %q", got, recipient.Phone)
+	}
+}
+
+func TestDispatchText_SuppressedForCancelledReservation(t *testing.T) {
+	fixture := setupQuietHoursFixture(t)
+	ctx := context.Background()
+	sms := &recordingNotifier{channel: ChannelSMS}
+
+	scheduledAt := time.Date(2026, time.June, 1, 22, 0, 0, 0, fixture.loc)
+	dispatchText(ctx, fixture.database.Queries, sms, Recipient{UserID: fixture.userID, Phone: "+14155550199"}, fixture.reminder(), scheduledAt, "reminder", nil)
+
+	if _, err := fixture.database.Exec(
+		`INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, fee_waived, hours_before_start)
+		 VALUES (?, ?, ?, 100, 1, 20)`,
+		fixture.reservationID, fixture.userID, scheduledAt.UTC(),
+	); err != nil {
+		t.Fatalf("insert cancellation: %v", err)
+	}
+
+	sent, err := DispatchDueEmails(ctx, fixture.database.Queries, nil, sms, time.Date(2026, time.June, 2, 8, 0, 0, 0, fixture.loc), nil)
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if sent != 0 || len(sms.calls()) != 0 {
+		t.Fatalf("expected cancelled reservation text to be suppressed, got %d texts", len(sms.calls()))
+	}
+}
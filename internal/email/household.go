@@ -0,0 +1,14 @@
+package email
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// SendHouseholdLinkRequestEmail asks an adult member to join a household.
+func SendHouseholdLinkRequestEmail(ctx context.Context, q *dbgen.Queries, client EmailSender, userID int64, message ConfirmationEmail, sender string, logger *zerolog.Logger) {
+	sendReservationInvitationMessage(ctx, q, client, userID, message, sender, "household_link_request", logger)
+}
@@ -0,0 +1,55 @@
+package email
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const leagueInvitationEmailTimeout = 5 * time.Second
+
+// SendLeagueTeamInvitationEmail sends a team invitation email to the invitee.
+func SendLeagueTeamInvitationEmail(ctx context.Context, q *dbgen.Queries, client EmailSender, userID int64, message ConfirmationEmail, sender string, logger *zerolog.Logger) {
+	if client == nil || q == nil {
+		return
+	}
+	if userID <= 0 {
+		if logger != nil {
+			logger.Warn().Int64("user_id", userID).Msg("Skipping league invitation email with invalid user ID")
+		}
+		return
+	}
+	if message.Subject == "" || message.Body == "" {
+		return
+	}
+
+	user, err := q.GetUserByID(ctx, userID)
+	if err != nil {
+		if logger != nil {
+			logger.Error().Err(err).Int64("user_id", userID).Msg("Failed to load user for league invitation email")
+		}
+		return
+	}
+	if !user.Email.Valid {
+		return
+	}
+	recipient := strings.TrimSpace(user.Email.String)
+	if recipient == "" {
+		return
+	}
+	if Undeliverable(user, "league_invitation", logger) {
+		return
+	}
+
+	dispatchEmail(ctx, q, client, userID, recipient, sender, message, time.Now(), leagueInvitationEmailTimeout, "league_invitation", logger)
+}
+
+// SendLeagueMatchResultSubmittedEmail asks the away captain to confirm or
+// dispute a result the home captain submitted.
+func SendLeagueMatchResultSubmittedEmail(ctx context.Context, q *dbgen.Queries, client EmailSender, userID int64, message ConfirmationEmail, sender string, logger *zerolog.Logger) {
+	sendReservationInvitationMessage(ctx, q, client, userID, message, sender, "league_result_submitted", logger)
+}
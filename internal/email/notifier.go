@@ -0,0 +1,145 @@
+package email
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/cognito"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const notifyChannelTimeout = 10 * time.Second
+
+// Channel identifies how a Notifier reaches a member.
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelSMS   Channel = "sms"
+)
+
+// Recipient carries the addresses a Notifier may deliver to. Phone is E.164.
+type Recipient struct {
+	UserID int64
+	Email  string
+	Phone  string
+}
+
+// Notifier delivers a message over one channel. Sender is the email "from"
+// address; channels that have their own sender identity ignore it.
+type Notifier interface {
+	Channel() Channel
+	Notify(ctx context.Context, recipient Recipient, message ConfirmationEmail, sender string) error
+}
+
+// TextEligible reports whether the category is time-sensitive enough to be
+// texted to members who opted in to SMS.
+func (c Category) TextEligible() bool {
+	switch c {
+	case CategoryWaitlistOffer, CategoryReminder:
+		return true
+	default:
+		return false
+	}
+}
+
+// NotifyUser delivers the message to the user on every channel they can
+// receive it on. Email goes to members with an address; SMS only carries
+// text-eligible categories to members who opted in and have a valid phone.
+// Each channel gets its own timeout and a failure is logged without
+// stopping the others. An empty preference skips the opt-out check.
+func NotifyUser(ctx context.Context, q *dbgen.Queries, notifiers []Notifier, userID int64, message ConfirmationEmail, sender string, preference Preference, logger *zerolog.Logger) {
+	if q == nil || len(notifiers) == 0 {
+		return
+	}
+	if userID <= 0 {
+		if logger != nil {
+			logger.Warn().Int64("user_id", userID).Msg("Skipping notification with invalid user ID")
+		}
+		return
+	}
+	if message.Subject == "" || message.Body == "" {
+		return
+	}
+	if Suppressed(ctx) {
+		if logger != nil {
+			logger.Info().Int64("user_id", userID).Str("category", string(message.Category)).Msg("Notification suppressed")
+		}
+		return
+	}
+	if preference != "" && !ShouldSend(ctx, q, userID, preference) {
+		return
+	}
+
+	user, err := q.GetUserByID(ctx, userID)
+	if err != nil {
+		if logger != nil {
+			logger.Error().Err(err).Int64("user_id", userID).Msg("Failed to load user for notification")
+		}
+		return
+	}
+	recipient := Recipient{
+		UserID: userID,
+		Email:  strings.TrimSpace(user.Email.String),
+		Phone:  cognito.NormalizePhone(strings.TrimSpace(user.Phone.String)),
+	}
+
+	for _, notifier := range notifiers {
+		if notifier == nil {
+			continue
+		}
+		channel := notifier.Channel()
+		switch channel {
+		case ChannelEmail:
+			if recipient.Email == "" || Undeliverable(user, "notification", logger) {
+				continue
+			}
+		case ChannelSMS:
+			if !message.Category.TextEligible() || !user.SmsOptIn || recipient.Phone == "" {
+				continue
+			}
+		}
+
+		channelCtx, cancel := context.WithTimeout(ctx, notifyChannelTimeout)
+		err := notifier.Notify(channelCtx, recipient, message, sender)
+		cancel()
+		if err != nil && logger != nil {
+			logger.Error().
+				Err(err).
+				Int64("user_id", userID).
+				Str("channel", string(channel)).
+				Str("category", string(message.Category)).
+				Msg("Failed to deliver notification")
+		}
+	}
+}
+
+// LogNotifier logs messages instead of delivering them. It stands in for
+// unconfigured channels during local development.
+type LogNotifier struct {
+	channel Channel
+}
+
+func NewLogNotifier(channel Channel) *LogNotifier {
+	return &LogNotifier{channel: channel}
+}
+
+func (n *LogNotifier) Channel() Channel {
+	return n.channel
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, recipient Recipient, message ConfirmationEmail, sender string) error {
+	log.Ctx(ctx).Info().
+		Str("channel", string(n.channel)).
+		Int64("user_id", recipient.UserID).
+		Str("email", recipient.Email).
+		Str("phone", recipient.Phone).
+		Str("subject", message.Subject).
+		Str("text", message.TextOrSubject()).
+		Msg("Notification not delivered: logging channel")
+	return nil
+}
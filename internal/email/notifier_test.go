@@ -0,0 +1,79 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type recordingNotifier struct {
+	mu         sync.Mutex
+	channel    Channel
+	err        error
+	recipients []Recipient
+}
+
+func (n *recordingNotifier) Channel() Channel {
+	return n.channel
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, recipient Recipient, message ConfirmationEmail, sender string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.recipients = append(n.recipients, recipient)
+	return n.err
+}
+
+func (n *recordingNotifier) calls() []Recipient {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Recipient(nil), n.recipients...)
+}
+
+func TestNotifyUser_SelectsChannelsPerCategoryAndPreference(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	userID := insertTestUser(t, database, "member@test.com")
+	if _, err := database.Exec("UPDATE users SET phone = '(415) 555-0199' WHERE id = ?", userID); err != nil {
+		t.Fatalf("set phone: %v", err)
+	}
+	ctx := context.Background()
+	offer := BuildWaitlistOfferEmail(WaitlistOfferDetails{FacilityName: "Main", Date: "Monday", TimeRange: "9:00 AM - 10:00 AM UTC", ExpiresAt: "9:30 AM UTC"})
+
+	// Without SMS opt-in only email is used.
+	sms := &recordingNotifier{channel: ChannelSMS, err: errors.New("provider down")}
+	mail := &recordingNotifier{channel: ChannelEmail}
+	NotifyUser(ctx, database.Queries, []Notifier{sms, mail}, userID, offer, "", PreferenceWaitlistOffers, nil)
+	if len(sms.calls()) != 0 || len(mail.calls()) != 1 {
+		t.Fatalf("expected email only, got sms=%d email=%d", len(sms.calls()), len(mail.calls()))
+	}
+
+	// Opted in: both channels, and the failing SMS doesn't stop the email.
+	if _, err := database.Exec("UPDATE users SET sms_opt_in = 1 WHERE id = ?", userID); err != nil {
+		t.Fatalf("opt in: %v", err)
+	}
+	NotifyUser(ctx, database.Queries, []Notifier{sms, mail}, userID, offer, "", PreferenceWaitlistOffers, nil)
+	if calls := sms.calls(); len(calls) != 1 || calls[0].Phone != "+14155550199" {
+		t.Fatalf("expected one SMS to the E.164 number, got %+v", calls)
+	}
+	if len(mail.calls()) != 2 {
+		t.Fatalf("expected the email to go out despite the SMS failure, got %d", len(mail.calls()))
+	}
+
+	// Categories that aren't time-sensitive never text.
+	NotifyUser(ctx, database.Queries, []Notifier{sms}, userID, ConfirmationEmail{Subject: "Confirmed", Body: "Body"}, "", "", nil)
+	if len(sms.calls()) != 1 {
+		t.Fatalf("expected no SMS for a confirmation, got %d", len(sms.calls()))
+	}
+
+	// Opting out of the category silences every channel.
+	if _, err := database.Exec("UPDATE users SET notify_waitlist_offers = 0 WHERE id = ?", userID); err != nil {
+		t.Fatalf("opt out: %v", err)
+	}
+	NotifyUser(ctx, database.Queries, []Notifier{sms, mail}, userID, offer, "", PreferenceWaitlistOffers, nil)
+	if len(sms.calls()) != 1 || len(mail.calls()) != 2 {
+		t.Fatalf("expected no deliveries after opting out, got sms=%d email=%d", len(sms.calls()), len(mail.calls()))
+	}
+}
@@ -0,0 +1,42 @@
+package email
+
+import (
+	"context"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// Preference names a kind of email a member can opt out of.
+type Preference string
+
+const (
+	PreferenceConfirmations     Preference = "confirmations"
+	PreferenceCancellations     Preference = "cancellations"
+	PreferenceWaitlistOffers    Preference = "waitlist_offers"
+	PreferenceOpenPlayReminders Preference = "open_play_reminders"
+)
+
+// ShouldSend reports whether the user wants email of the given kind. Unknown
+// preferences and lookup failures send, so a preference problem never
+// silently drops a message the member would have received before.
+func ShouldSend(ctx context.Context, q *dbgen.Queries, userID int64, preference Preference) bool {
+	if q == nil || userID <= 0 {
+		return true
+	}
+	prefs, err := q.GetUserNotificationPreferences(ctx, userID)
+	if err != nil {
+		return true
+	}
+	switch preference {
+	case PreferenceConfirmations:
+		return prefs.NotifyConfirmations
+	case PreferenceCancellations:
+		return prefs.NotifyCancellations
+	case PreferenceWaitlistOffers:
+		return prefs.NotifyWaitlistOffers
+	case PreferenceOpenPlayReminders:
+		return prefs.NotifyOpenPlayReminders
+	default:
+		return true
+	}
+}
@@ -0,0 +1,33 @@
+package email
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestShouldSend_DefaultsOnAndHonorsOptOut(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	userID := insertTestUser(t, database, "member@test.com")
+	ctx := context.Background()
+
+	for _, preference := range []Preference{PreferenceConfirmations, PreferenceCancellations, PreferenceWaitlistOffers, PreferenceOpenPlayReminders} {
+		if !ShouldSend(ctx, database.Queries, userID, preference) {
+			t.Fatalf("expected %s to default on", preference)
+		}
+	}
+
+	if _, err := database.Exec("UPDATE users SET notify_cancellations = 0 WHERE id = ?", userID); err != nil {
+		t.Fatalf("update preferences: %v", err)
+	}
+	if ShouldSend(ctx, database.Queries, userID, PreferenceCancellations) {
+		t.Fatalf("expected cancellations to be suppressed")
+	}
+	if !ShouldSend(ctx, database.Queries, userID, PreferenceConfirmations) {
+		t.Fatalf("expected confirmations to stay on")
+	}
+	if !ShouldSend(ctx, database.Queries, 999999, PreferenceCancellations) {
+		t.Fatalf("expected unknown users to fall back to sending")
+	}
+}
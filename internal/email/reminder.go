@@ -7,6 +7,7 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"github.com/codr1/Pickleicious/internal/cognito"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 )
 
@@ -41,21 +42,35 @@ func SendReminderEmail(ctx context.Context, q *dbgen.Queries, client EmailSender
 	if recipient == "" {
 		return
 	}
+	if Undeliverable(user, "reminder", logger) {
+		return
+	}
 
-	go func() {
-		sendCtx, cancel := newEmailContext(ctx, reminderEmailTimeout)
-		defer cancel()
-		if sendCtx.Err() != nil {
-			return
-		}
-		if err := client.SendFrom(sendCtx, recipient, message.Subject, message.Body, sender); err != nil {
-			if logger != nil {
-				logger.Error().Err(err).Int64("user_id", userID).Msg("Failed to send reminder email")
-			}
-			return
-		}
+	dispatchEmail(ctx, q, client, userID, recipient, sender, message, time.Now(), reminderEmailTimeout, "reminder", logger)
+}
+
+// SendReminderText texts a reminder to a member who opted in to SMS. Like
+// reminder email it is held through facility quiet hours and dropped for
+// suppressed contexts.
+func SendReminderText(ctx context.Context, q *dbgen.Queries, notifier Notifier, userID int64, message ConfirmationEmail, logger *zerolog.Logger) {
+	if notifier == nil || q == nil || userID <= 0 {
+		return
+	}
+	if message.Subject == "" || !message.Category.TextEligible() {
+		return
+	}
+
+	user, err := q.GetUserByID(ctx, userID)
+	if err != nil {
 		if logger != nil {
-			logger.Info().Int64("user_id", userID).Msg("Reminder email sent")
+			logger.Error().Err(err).Int64("user_id", userID).Msg("Failed to load user for reminder text")
 		}
-	}()
+		return
+	}
+	phone := cognito.NormalizePhone(strings.TrimSpace(user.Phone.String))
+	if !user.SmsOptIn || phone == "" {
+		return
+	}
+
+	dispatchText(ctx, q, notifier, Recipient{UserID: userID, Phone: phone}, message, time.Now(), "reminder", logger)
 }
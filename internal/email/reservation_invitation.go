@@ -0,0 +1,58 @@
+package email
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const reservationInvitationEmailTimeout = 5 * time.Second
+
+// SendReservationInvitationEmail sends a play invitation to the invitee.
+func SendReservationInvitationEmail(ctx context.Context, q *dbgen.Queries, client EmailSender, userID int64, message ConfirmationEmail, sender string, logger *zerolog.Logger) {
+	sendReservationInvitationMessage(ctx, q, client, userID, message, sender, "reservation_invitation", logger)
+}
+
+// SendInvitationDeclinedEmail tells the organizer an invitee declined.
+func SendInvitationDeclinedEmail(ctx context.Context, q *dbgen.Queries, client EmailSender, userID int64, message ConfirmationEmail, sender string, logger *zerolog.Logger) {
+	sendReservationInvitationMessage(ctx, q, client, userID, message, sender, "invitation_declined", logger)
+}
+
+func sendReservationInvitationMessage(ctx context.Context, q *dbgen.Queries, client EmailSender, userID int64, message ConfirmationEmail, sender, kind string, logger *zerolog.Logger) {
+	if client == nil || q == nil {
+		return
+	}
+	if userID <= 0 {
+		if logger != nil {
+			logger.Warn().Int64("user_id", userID).Str("kind", kind).Msg("Skipping invitation email with invalid user ID")
+		}
+		return
+	}
+	if message.Subject == "" || message.Body == "" {
+		return
+	}
+
+	user, err := q.GetUserByID(ctx, userID)
+	if err != nil {
+		if logger != nil {
+			logger.Error().Err(err).Int64("user_id", userID).Str("kind", kind).Msg("Failed to load user for invitation email")
+		}
+		return
+	}
+	if !user.Email.Valid {
+		return
+	}
+	recipient := strings.TrimSpace(user.Email.String)
+	if recipient == "" {
+		return
+	}
+	if Undeliverable(user, kind, logger) {
+		return
+	}
+
+	dispatchEmail(ctx, q, client, userID, recipient, sender, message, time.Now(), reservationInvitationEmailTimeout, kind, logger)
+}
@@ -0,0 +1,25 @@
+package email
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// SendReservationTransferRequestEmail asks the target member to accept a
+// reservation transfer.
+func SendReservationTransferRequestEmail(ctx context.Context, q *dbgen.Queries, client EmailSender, userID int64, message ConfirmationEmail, sender string, logger *zerolog.Logger) {
+	sendReservationInvitationMessage(ctx, q, client, userID, message, sender, "reservation_transfer_request", logger)
+}
+
+// SendReservationTransferredEmail tells one party a transfer went through.
+func SendReservationTransferredEmail(ctx context.Context, q *dbgen.Queries, client EmailSender, userID int64, message ConfirmationEmail, sender string, logger *zerolog.Logger) {
+	sendReservationInvitationMessage(ctx, q, client, userID, message, sender, "reservation_transferred", logger)
+}
+
+// SendTransferDeclinedEmail tells the booker the target declined.
+func SendTransferDeclinedEmail(ctx context.Context, q *dbgen.Queries, client EmailSender, userID int64, message ConfirmationEmail, sender string, logger *zerolog.Logger) {
+	sendReservationInvitationMessage(ctx, q, client, userID, message, sender, "transfer_declined", logger)
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/singleflight"
+
+	"github.com/codr1/Pickleicious/internal/metrics"
 )
 
 // SESClient wraps AWS SESv2 sending.
@@ -101,7 +103,9 @@ func (c *SESClient) Send(ctx context.Context, recipient, subject, body string) e
 		FromEmailAddress: aws.String(c.sender),
 	}
 
-	if _, err := c.client.SendEmail(ctx, input); err != nil {
+	_, err := c.client.SendEmail(ctx, input)
+	metrics.EmailSent(err)
+	if err != nil {
 		log.Error().
 			Err(err).
 			Str("recipient_masked", maskEmail(recipient)).
@@ -113,6 +117,16 @@ func (c *SESClient) Send(ctx context.Context, recipient, subject, body string) e
 	return nil
 }
 
+// Channel reports that SESClient delivers by email.
+func (c *SESClient) Channel() Channel {
+	return ChannelEmail
+}
+
+// Notify emails the message to the recipient, satisfying Notifier.
+func (c *SESClient) Notify(ctx context.Context, recipient Recipient, message ConfirmationEmail, sender string) error {
+	return c.SendFrom(ctx, recipient.Email, message.Subject, message.Body, sender)
+}
+
 // SendFrom delivers a simple email using an optional sender override.
 func (c *SESClient) SendFrom(ctx context.Context, recipient, subject, body, sender string) error {
 	if c == nil || c.client == nil {
@@ -158,7 +172,9 @@ func (c *SESClient) SendFrom(ctx context.Context, recipient, subject, body, send
 		FromEmailAddress: aws.String(from),
 	}
 
-	if _, err := c.client.SendEmail(ctx, input); err != nil {
+	_, err = c.client.SendEmail(ctx, input)
+	metrics.EmailSent(err)
+	if err != nil {
 		log.Error().
 			Err(err).
 			Str("recipient_masked", maskEmail(recipient)).
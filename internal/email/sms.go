@@ -0,0 +1,107 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/cognito"
+)
+
+const (
+	defaultSMSAPIBaseURL = "https://api.twilio.com"
+	smsRequestTimeout    = 10 * time.Second
+	// smsMaxLength is the longest body the Messages API accepts.
+	smsMaxLength = 1600
+)
+
+// SMSSender sends text messages through a Twilio-compatible Messages API.
+type SMSSender struct {
+	accountSID string
+	authToken  string
+	from       string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewSMSSender validates credentials and the E.164 from number. An empty
+// baseURL uses Twilio; other providers exposing the same API can be set
+// instead.
+func NewSMSSender(accountSID, authToken, from, baseURL string) (*SMSSender, error) {
+	accountSID = strings.TrimSpace(accountSID)
+	authToken = strings.TrimSpace(authToken)
+	if accountSID == "" || authToken == "" {
+		return nil, fmt.Errorf("sms account SID and auth token are required")
+	}
+	normalizedFrom := cognito.NormalizePhone(strings.TrimSpace(from))
+	if normalizedFrom == "" {
+		return nil, fmt.Errorf("sms from number must be a valid phone number")
+	}
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		baseURL = defaultSMSAPIBaseURL
+	}
+
+	return &SMSSender{
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       normalizedFrom,
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: smsRequestTimeout},
+	}, nil
+}
+
+// Channel reports that SMSSender delivers by text message.
+func (s *SMSSender) Channel() Channel {
+	return ChannelSMS
+}
+
+// Notify texts the message's short form to the recipient, satisfying Notifier.
+func (s *SMSSender) Notify(ctx context.Context, recipient Recipient, message ConfirmationEmail, sender string) error {
+	return s.Send(ctx, recipient.Phone, message.TextOrSubject())
+}
+
+// Send texts body to phone after normalizing it to E.164.
+func (s *SMSSender) Send(ctx context.Context, phone, body string) error {
+	if s == nil || s.httpClient == nil {
+		return fmt.Errorf("sms sender is not initialized")
+	}
+	to := cognito.NormalizePhone(strings.TrimSpace(phone))
+	if to == "" {
+		return fmt.Errorf("recipient phone %q is not a valid phone number", phone)
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return fmt.Errorf("sms body is required")
+	}
+	if len(body) > smsMaxLength {
+		body = body[:smsMaxLength]
+	}
+
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("From", s.from)
+	form.Set("Body", body)
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", s.baseURL, url.PathEscape(s.accountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("build sms request: %w", err)
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send sms: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("send sms: status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
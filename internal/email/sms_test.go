@@ -0,0 +1,53 @@
+package email
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSMSSender_PostsNormalizedMessage(t *testing.T) {
+	var gotPath, gotUser, gotPass, gotTo, gotFrom, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUser, gotPass, _ = r.BasicAuth()
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parse form: %v", err)
+		}
+		gotTo, gotFrom, gotBody = r.PostForm.Get("To"), r.PostForm.Get("From"), r.PostForm.Get("Body")
+		if gotTo == "+14155550142" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"unreachable"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	sender, err := NewSMSSender("AC123", "secret", "(415) 555-0100", server.URL)
+	if err != nil {
+		t.Fatalf("NewSMSSender: %v", err)
+	}
+
+	if err := sender.Send(context.Background(), "415.555.0199", "Court open"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if gotPath != "/2010-04-01/Accounts/AC123/Messages.json" || gotUser != "AC123" || gotPass != "secret" {
+		t.Fatalf("unexpected request: path=%s user=%s", gotPath, gotUser)
+	}
+	if gotTo != "+14155550199" || gotFrom != "+14155550100" || gotBody != "Court open" {
+		t.Fatalf("unexpected form: to=%s from=%s body=%s", gotTo, gotFrom, gotBody)
+	}
+
+	if err := sender.Send(context.Background(), "not a phone", "Court open"); err == nil {
+		t.Fatal("expected an invalid phone to be rejected")
+	}
+	if err := sender.Send(context.Background(), "+14155550142", "Court open"); err == nil || !strings.Contains(err.Error(), "unreachable") {
+		t.Fatalf("expected provider error, got %v", err)
+	}
+	if _, err := NewSMSSender("AC123", "secret", "nope", ""); err == nil {
+		t.Fatal("expected an invalid from number to be rejected")
+	}
+}
@@ -0,0 +1,374 @@
+package email
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SNS message types delivered to an HTTP(S) subscription.
+const (
+	SNSTypeNotification             = "Notification"
+	SNSTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	SNSTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+const (
+	snsFetchTimeout   = 10 * time.Second
+	snsMaxCertBytes   = 64 << 10
+	snsMaxConfirmBody = 64 << 10
+)
+
+// snsHostPattern matches the regional SNS endpoints AWS signs from and sends
+// subscription confirmations for.
+var snsHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// SNSMessage is the JSON envelope SNS posts to an HTTP(S) endpoint.
+type SNSMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token,omitempty"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject,omitempty"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL,omitempty"`
+	UnsubscribeURL   string `json:"UnsubscribeURL,omitempty"`
+}
+
+// ParseSNSMessage decodes an SNS delivery body.
+func ParseSNSMessage(body []byte) (SNSMessage, error) {
+	var msg SNSMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return SNSMessage{}, fmt.Errorf("decode sns message: %w", err)
+	}
+	if msg.Type == "" || msg.MessageID == "" {
+		return SNSMessage{}, errors.New("sns message is missing Type or MessageId")
+	}
+	return msg, nil
+}
+
+// stringToSign builds the canonical text SNS signs for the message type.
+func (m SNSMessage) stringToSign() (string, error) {
+	var fields [][2]string
+	switch m.Type {
+	case SNSTypeNotification:
+		fields = append(fields, [2]string{"Message", m.Message}, [2]string{"MessageId", m.MessageID})
+		if m.Subject != "" {
+			fields = append(fields, [2]string{"Subject", m.Subject})
+		}
+		fields = append(fields,
+			[2]string{"Timestamp", m.Timestamp},
+			[2]string{"TopicArn", m.TopicArn},
+			[2]string{"Type", m.Type},
+		)
+	case SNSTypeSubscriptionConfirmation, SNSTypeUnsubscribeConfirmation:
+		fields = [][2]string{
+			{"Message", m.Message},
+			{"MessageId", m.MessageID},
+			{"SubscribeURL", m.SubscribeURL},
+			{"Timestamp", m.Timestamp},
+			{"Token", m.Token},
+			{"TopicArn", m.TopicArn},
+			{"Type", m.Type},
+		}
+	default:
+		return "", fmt.Errorf("unsupported sns message type %q", m.Type)
+	}
+
+	var b strings.Builder
+	for _, field := range fields {
+		b.WriteString(field[0])
+		b.WriteByte('\n')
+		b.WriteString(field[1])
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// SNSVerifier checks SNS message signatures against the AWS signing
+// certificate and confirms subscriptions. Certificates are cached by URL.
+type SNSVerifier struct {
+	// Client fetches signing certificates and subscription URLs.
+	Client *http.Client
+	// TopicARN, when set, is the only topic accepted.
+	TopicARN string
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+// NewSNSVerifier returns a verifier that accepts topicARN, or any topic when
+// it is empty.
+func NewSNSVerifier(topicARN string) *SNSVerifier {
+	return &SNSVerifier{
+		Client:   &http.Client{Timeout: snsFetchTimeout},
+		TopicARN: strings.TrimSpace(topicARN),
+		certs:    make(map[string]*x509.Certificate),
+	}
+}
+
+// Verify reports an error unless msg was signed by SNS for an accepted topic.
+func (v *SNSVerifier) Verify(ctx context.Context, msg SNSMessage) error {
+	if v.TopicARN != "" && msg.TopicArn != v.TopicARN {
+		return fmt.Errorf("sns topic %q is not accepted", msg.TopicArn)
+	}
+
+	var hash crypto.Hash
+	switch msg.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return fmt.Errorf("unsupported sns signature version %q", msg.SignatureVersion)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if err != nil {
+		return fmt.Errorf("decode sns signature: %w", err)
+	}
+	text, err := msg.stringToSign()
+	if err != nil {
+		return err
+	}
+	cert, err := v.certificate(ctx, msg.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("sns signing certificate does not hold an RSA key")
+	}
+
+	var digest []byte
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum([]byte(text))
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256([]byte(text))
+		digest = sum[:]
+	}
+	if err := rsa.VerifyPKCS1v15(publicKey, hash, digest, signature); err != nil {
+		return fmt.Errorf("sns signature mismatch: %w", err)
+	}
+	return nil
+}
+
+// ConfirmSubscription completes the SNS handshake by visiting the
+// SubscribeURL of a verified SubscriptionConfirmation message.
+func (v *SNSVerifier) ConfirmSubscription(ctx context.Context, msg SNSMessage) error {
+	if msg.Type != SNSTypeSubscriptionConfirmation {
+		return fmt.Errorf("sns message type %q is not a subscription confirmation", msg.Type)
+	}
+	subscribeURL, err := validateSNSURL(msg.SubscribeURL)
+	if err != nil {
+		return fmt.Errorf("sns subscribe url: %w", err)
+	}
+	if _, err := v.fetch(ctx, subscribeURL, snsMaxConfirmBody); err != nil {
+		return fmt.Errorf("confirm sns subscription: %w", err)
+	}
+	return nil
+}
+
+func (v *SNSVerifier) certificate(ctx context.Context, rawURL string) (*x509.Certificate, error) {
+	certURL, err := validateSNSURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("sns signing cert url: %w", err)
+	}
+	if !strings.HasSuffix(certURL.Path, ".pem") {
+		return nil, errors.New("sns signing cert url must point to a .pem file")
+	}
+
+	key := certURL.String()
+	v.mu.Lock()
+	cert, ok := v.certs[key]
+	v.mu.Unlock()
+	if ok {
+		return cert, nil
+	}
+
+	data, err := v.fetch(ctx, certURL, snsMaxCertBytes)
+	if err != nil {
+		return nil, fmt.Errorf("fetch sns signing cert: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("sns signing cert is not PEM encoded")
+	}
+	cert, err = x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse sns signing cert: %w", err)
+	}
+
+	v.mu.Lock()
+	if v.certs == nil {
+		v.certs = make(map[string]*x509.Certificate)
+	}
+	v.certs[key] = cert
+	v.mu.Unlock()
+	return cert, nil
+}
+
+func (v *SNSVerifier) fetch(ctx context.Context, target *url.URL, limit int64) ([]byte, error) {
+	client := v.Client
+	if client == nil {
+		client = &http.Client{Timeout: snsFetchTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, limit))
+}
+
+// validateSNSURL only allows https URLs on an SNS endpoint, so a forged
+// message cannot point the verifier at a certificate or URL of its choosing.
+func validateSNSURL(raw string) (*url.URL, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "https" {
+		return nil, errors.New("must use https")
+	}
+	if parsed.Port() != "" || !snsHostPattern.MatchString(parsed.Hostname()) {
+		return nil, fmt.Errorf("host %q is not an SNS endpoint", parsed.Host)
+	}
+	return parsed, nil
+}
+
+// Delivery event types recorded for SES notifications.
+const (
+	DeliveryEventBounce    = "bounce"
+	DeliveryEventComplaint = "complaint"
+)
+
+// BounceTypePermanent is the SES bounce type for addresses that will never
+// accept mail.
+const BounceTypePermanent = "Permanent"
+
+// DeliveryEvent is one recipient's bounce or complaint from an SES
+// notification.
+type DeliveryEvent struct {
+	Email         string
+	Type          string
+	BounceType    string
+	BounceSubType string
+	Diagnostic    string
+}
+
+// Hard reports whether the event should stop further email to the address:
+// permanent bounces and complaints do, transient bounces do not.
+func (e DeliveryEvent) Hard() bool {
+	return e.Type == DeliveryEventComplaint || (e.Type == DeliveryEventBounce && e.BounceType == BounceTypePermanent)
+}
+
+// sesNotification is the SES bounce/complaint payload carried in an SNS
+// notification's Message. Identity notifications set notificationType;
+// configuration-set event publishing sets eventType.
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Bounce           *struct {
+		BounceType        string `json:"bounceType"`
+		BounceSubType     string `json:"bounceSubType"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint *struct {
+		ComplaintFeedbackType string `json:"complaintFeedbackType"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+}
+
+// ParseSESDeliveryEvents extracts per-recipient bounce and complaint events
+// from an SES notification. Other notification types, such as deliveries,
+// yield no events.
+func ParseSESDeliveryEvents(message string) ([]DeliveryEvent, error) {
+	var n sesNotification
+	if err := json.Unmarshal([]byte(message), &n); err != nil {
+		return nil, fmt.Errorf("decode ses notification: %w", err)
+	}
+	kind := n.NotificationType
+	if kind == "" {
+		kind = n.EventType
+	}
+
+	var events []DeliveryEvent
+	switch kind {
+	case "Bounce":
+		if n.Bounce == nil {
+			return nil, errors.New("ses bounce notification has no bounce details")
+		}
+		for _, recipient := range n.Bounce.BouncedRecipients {
+			address := normalizeDeliveryAddress(recipient.EmailAddress)
+			if address == "" {
+				continue
+			}
+			events = append(events, DeliveryEvent{
+				Email:         address,
+				Type:          DeliveryEventBounce,
+				BounceType:    n.Bounce.BounceType,
+				BounceSubType: n.Bounce.BounceSubType,
+				Diagnostic:    recipient.DiagnosticCode,
+			})
+		}
+	case "Complaint":
+		if n.Complaint == nil {
+			return nil, errors.New("ses complaint notification has no complaint details")
+		}
+		for _, recipient := range n.Complaint.ComplainedRecipients {
+			address := normalizeDeliveryAddress(recipient.EmailAddress)
+			if address == "" {
+				continue
+			}
+			events = append(events, DeliveryEvent{
+				Email:      address,
+				Type:       DeliveryEventComplaint,
+				Diagnostic: n.Complaint.ComplaintFeedbackType,
+			})
+		}
+	}
+	return events, nil
+}
+
+// normalizeDeliveryAddress strips any display name SES includes and
+// lowercases the address so events match users regardless of case.
+func normalizeDeliveryAddress(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if start := strings.LastIndex(raw, "<"); start >= 0 && strings.HasSuffix(raw, ">") {
+		raw = raw[start+1 : len(raw)-1]
+	}
+	return strings.ToLower(strings.TrimSpace(raw))
+}
@@ -0,0 +1,214 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testSigningCertURL = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-test.pem"
+
+// snsTestSigner signs messages with a self-signed certificate served in place
+// of the AWS one.
+type snsTestSigner struct {
+	key     *rsa.PrivateKey
+	certPEM []byte
+
+	mu      sync.Mutex
+	fetched []string
+}
+
+func newSNSTestSigner(t *testing.T) *snsTestSigner {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	return &snsTestSigner{
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func (s *snsTestSigner) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	s.fetched = append(s.fetched, req.URL.String())
+	s.mu.Unlock()
+	body := []byte("<ConfirmSubscriptionResponse/>")
+	if strings.HasSuffix(req.URL.Path, ".pem") {
+		body = s.certPEM
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body)), Header: make(http.Header), Request: req}, nil
+}
+
+func (s *snsTestSigner) sign(t *testing.T, msg SNSMessage) SNSMessage {
+	t.Helper()
+	text, err := msg.stringToSign()
+	if err != nil {
+		t.Fatalf("string to sign: %v", err)
+	}
+	hash := crypto.SHA256
+	var digest []byte
+	if msg.SignatureVersion == "1" {
+		hash = crypto.SHA1
+		sum := sha1.Sum([]byte(text))
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256([]byte(text))
+		digest = sum[:]
+	}
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, hash, digest)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	msg.Signature = base64.StdEncoding.EncodeToString(signature)
+	return msg
+}
+
+func TestSNSVerifier_Verify(t *testing.T) {
+	signer := newSNSTestSigner(t)
+	verifier := NewSNSVerifier("arn:aws:sns:us-east-1:123456789012:ses-events")
+	verifier.Client = &http.Client{Transport: signer}
+	ctx := context.Background()
+
+	notification := SNSMessage{
+		Type:             SNSTypeNotification,
+		MessageID:        "msg-1",
+		TopicArn:         "arn:aws:sns:us-east-1:123456789012:ses-events",
+		Message:          `{"notificationType":"Bounce"}`,
+		Timestamp:        "2026-10-15T12:00:00.000Z",
+		SignatureVersion: "2",
+		SigningCertURL:   testSigningCertURL,
+	}
+	for _, version := range []string{"1", "2"} {
+		msg := notification
+		msg.SignatureVersion = version
+		if err := verifier.Verify(ctx, signer.sign(t, msg)); err != nil {
+			t.Fatalf("signature version %s: %v", version, err)
+		}
+	}
+	if len(signer.fetched) != 1 {
+		t.Fatalf("expected the signing cert to be fetched once and cached, got %v", signer.fetched)
+	}
+
+	tampered := signer.sign(t, notification)
+	tampered.Message = `{"notificationType":"Complaint"}`
+	if err := verifier.Verify(ctx, tampered); err == nil {
+		t.Fatal("expected a tampered message to fail verification")
+	}
+
+	otherTopic := notification
+	otherTopic.TopicArn = "arn:aws:sns:us-east-1:123456789012:other"
+	if err := verifier.Verify(ctx, signer.sign(t, otherTopic)); err == nil {
+		t.Fatal("expected a message from another topic to be rejected")
+	}
+
+	for _, certURL := range []string{
+		"http://sns.us-east-1.amazonaws.com/cert.pem",
+		"https://sns.us-east-1.amazonaws.com.evil.test/cert.pem",
+		"https://sns.us-east-1.amazonaws.com/cert.txt",
+	} {
+		msg := notification
+		msg.SigningCertURL = certURL
+		if err := verifier.Verify(ctx, signer.sign(t, msg)); err == nil {
+			t.Fatalf("expected cert url %s to be rejected", certURL)
+		}
+	}
+}
+
+func TestSNSVerifier_ConfirmSubscription(t *testing.T) {
+	signer := newSNSTestSigner(t)
+	verifier := NewSNSVerifier("")
+	verifier.Client = &http.Client{Transport: signer}
+	ctx := context.Background()
+
+	subscribeURL := "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=abc"
+	msg := signer.sign(t, SNSMessage{
+		Type:             SNSTypeSubscriptionConfirmation,
+		MessageID:        "msg-1",
+		Token:            "abc",
+		TopicArn:         "arn:aws:sns:us-east-1:123456789012:ses-events",
+		Message:          "You have chosen to subscribe to the topic.",
+		SubscribeURL:     subscribeURL,
+		Timestamp:        "2026-10-15T12:00:00.000Z",
+		SignatureVersion: "1",
+		SigningCertURL:   testSigningCertURL,
+	})
+	if err := verifier.Verify(ctx, msg); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if err := verifier.ConfirmSubscription(ctx, msg); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	if last := signer.fetched[len(signer.fetched)-1]; last != subscribeURL {
+		t.Fatalf("expected the subscribe url to be visited, got %v", signer.fetched)
+	}
+
+	msg.SubscribeURL = "https://example.com/?Action=ConfirmSubscription"
+	if err := verifier.ConfirmSubscription(ctx, msg); err == nil {
+		t.Fatal("expected a non-SNS subscribe url to be refused")
+	}
+}
+
+func TestParseSESDeliveryEvents(t *testing.T) {
+	events, err := ParseSESDeliveryEvents(`{
+		"notificationType": "Bounce",
+		"bounce": {
+			"bounceType": "Permanent",
+			"bounceSubType": "General",
+			"bouncedRecipients": [
+				{"emailAddress": "Jo Player <Jo@Example.com>", "diagnosticCode": "smtp; 550 5.1.1 user unknown"}
+			]
+		}
+	}`)
+	if err != nil {
+		t.Fatalf("parse bounce: %v", err)
+	}
+	if len(events) != 1 || events[0].Email != "jo@example.com" || !events[0].Hard() || events[0].Diagnostic == "" {
+		t.Fatalf("unexpected bounce events %+v", events)
+	}
+
+	events, err = ParseSESDeliveryEvents(`{
+		"eventType": "Complaint",
+		"complaint": {"complaintFeedbackType": "abuse", "complainedRecipients": [{"emailAddress": "pat@example.com"}]}
+	}`)
+	if err != nil {
+		t.Fatalf("parse complaint: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != DeliveryEventComplaint || !events[0].Hard() {
+		t.Fatalf("unexpected complaint events %+v", events)
+	}
+
+	events, err = ParseSESDeliveryEvents(`{"notificationType": "Delivery"}`)
+	if err != nil || len(events) != 0 {
+		t.Fatalf("expected deliveries to yield no events, got %+v (err %v)", events, err)
+	}
+	if (DeliveryEvent{Type: DeliveryEventBounce, BounceType: "Transient"}).Hard() {
+		t.Fatal("expected transient bounces not to stop email")
+	}
+}
@@ -9,6 +9,21 @@ import (
 type ConfirmationEmail struct {
 	Subject string
 	Body    string
+	// Category, FacilityID, and ReservationID drive quiet-hours dispatch.
+	// Messages without a facility are always sent immediately.
+	Category      Category
+	FacilityID    int64
+	ReservationID int64
+	// Text is the short form sent by SMS.
+	Text string
+}
+
+// TextOrSubject returns the SMS text, falling back to the subject.
+func (m ConfirmationEmail) TextOrSubject() string {
+	if text := strings.TrimSpace(m.Text); text != "" {
+		return text
+	}
+	return m.Subject
 }
 
 type ConfirmationDetails struct {
@@ -17,6 +32,15 @@ type ConfirmationDetails struct {
 	TimeRange          string
 	Courts             string
 	CancellationPolicy string
+	// Guests lists the non-members on the booking; empty omits the line.
+	Guests string
+	// Note is the member's note on the booking; empty omits the line.
+	Note string
+	// Price is the formatted booking price; empty omits the line.
+	Price string
+	// AccessURL links to the reservation's door access QR code; empty omits
+	// the line.
+	AccessURL string
 }
 
 type CancellationDetails struct {
@@ -27,10 +51,26 @@ type CancellationDetails struct {
 	Courts           string
 	Reason           string
 	RefundPercentage *int64
-	FeeWaived        bool
+	// RefundAmount is the formatted amount the refund returns; empty leaves
+	// just the percentage.
+	RefundAmount string
+	FeeWaived    bool
+}
+
+type ReservationChangedDetails struct {
+	FacilityName    string
+	ReservationType string
+	OldDate         string
+	OldTimeRange    string
+	OldCourts       string
+	NewDate         string
+	NewTimeRange    string
+	NewCourts       string
 }
 
 type ReminderDetails struct {
+	FacilityID      int64
+	ReservationID   int64
 	FacilityName    string
 	ReservationType string
 	Date            string
@@ -38,6 +78,96 @@ type ReminderDetails struct {
 	Courts          string
 }
 
+type WaitlistOfferDetails struct {
+	FacilityID   int64
+	FacilityName string
+	Date         string
+	TimeRange    string
+	ExpiresAt    string
+}
+
+type MemberCardDetails struct {
+	MemberName   string
+	FacilityName string
+	Membership   string
+	ValidThrough string
+}
+
+type LeagueTeamInvitationDetails struct {
+	FacilityName string
+	LeagueName   string
+	TeamName     string
+	CaptainName  string
+	AcceptURL    string
+	ExpiresAt    string
+}
+
+type LeagueMatchResultSubmittedDetails struct {
+	FacilityName  string
+	LeagueName    string
+	HomeTeamName  string
+	AwayTeamName  string
+	HomeScore     int64
+	AwayScore     int64
+	SubmitterName string
+	RespondBy     string
+}
+
+type ReservationInvitationDetails struct {
+	FacilityName  string
+	OrganizerName string
+	Date          string
+	TimeRange     string
+	Courts        string
+	AcceptURL     string
+	DeclineURL    string
+}
+
+type InvitationDeclinedDetails struct {
+	FacilityName string
+	InviteeName  string
+	Date         string
+	TimeRange    string
+}
+
+type ReservationTransferRequestDetails struct {
+	FacilityName string
+	FromName     string
+	Date         string
+	TimeRange    string
+	Courts       string
+	AcceptURL    string
+	DeclineURL   string
+	ExpiresAt    string
+}
+
+// ReservationTransferredDetails describes a completed transfer to one of its
+// two parties. ToRecipient is set on the copy sent to the new holder.
+type ReservationTransferredDetails struct {
+	FacilityName string
+	FromName     string
+	ToName       string
+	Date         string
+	TimeRange    string
+	Courts       string
+	ToRecipient  bool
+}
+
+type TransferDeclinedDetails struct {
+	FacilityName string
+	ToName       string
+	Date         string
+	TimeRange    string
+}
+
+type HouseholdLinkRequestDetails struct {
+	FacilityName string
+	PrimaryName  string
+	AcceptURL    string
+	DeclineURL   string
+	ExpiresAt    string
+}
+
 func FormatDateTimeRange(start, end time.Time) (string, string) {
 	date := start.Format("Monday, Jan 2, 2006")
 	timeRange := fmt.Sprintf("%s - %s %s", start.Format("3:04 PM"), end.Format("3:04 PM"), start.Format("MST"))
@@ -56,6 +186,12 @@ func BuildOpenPlayConfirmation(details ConfirmationDetails) ConfirmationEmail {
 	return buildConfirmationEmail("Open Play", "Open Play Signup Confirmed", details)
 }
 
+// BuildReservationConfirmation confirms a booking of any reservation type.
+func BuildReservationConfirmation(reservationType string, details ConfirmationDetails) ConfirmationEmail {
+	label := ReservationTypeLabel(reservationType)
+	return buildConfirmationEmail(label, fmt.Sprintf("%s Confirmed", label), details)
+}
+
 func ReservationTypeLabel(reservationType string) string {
 	normalized := strings.ToUpper(strings.TrimSpace(reservationType))
 	normalized = strings.NewReplacer(" ", "_", "-", "_").Replace(normalized)
@@ -125,7 +261,54 @@ func BuildCancellationEmail(details CancellationDetails) ConfirmationEmail {
 	if details.FeeWaived {
 		lines = append(lines, "Fee waived: Yes")
 	} else if details.RefundPercentage != nil {
-		lines = append(lines, fmt.Sprintf("Refund: %d%%", *details.RefundPercentage))
+		refund := fmt.Sprintf("Refund: %d%%", *details.RefundPercentage)
+		if amount := strings.TrimSpace(details.RefundAmount); amount != "" {
+			refund = fmt.Sprintf("%s (%s)", refund, amount)
+		}
+		lines = append(lines, refund)
+	}
+
+	return ConfirmationEmail{
+		Subject: subject,
+		Body:    strings.Join(lines, "\n"),
+	}
+}
+
+func BuildReservationChanged(details ReservationChangedDetails) ConfirmationEmail {
+	rawFacilityName := strings.TrimSpace(details.FacilityName)
+	facilityName := rawFacilityName
+	if facilityName == "" {
+		facilityName = "your facility"
+	}
+	reservationType := ReservationTypeLabel(details.ReservationType)
+	orTBD := func(value string) string {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return "TBD"
+		}
+		return value
+	}
+
+	subject := fmt.Sprintf("%s Changed", reservationType)
+	if rawFacilityName != "" {
+		subject = fmt.Sprintf("%s - %s", subject, rawFacilityName)
+	}
+
+	lines := []string{
+		fmt.Sprintf("Your %s booking has been changed.", reservationType),
+		"",
+		fmt.Sprintf("Facility: %s", facilityName),
+		fmt.Sprintf("Reservation type: %s", reservationType),
+		"",
+		"Was:",
+		fmt.Sprintf("Date: %s", orTBD(details.OldDate)),
+		fmt.Sprintf("Time: %s", orTBD(details.OldTimeRange)),
+		fmt.Sprintf("Courts: %s", orTBD(details.OldCourts)),
+		"",
+		"Now:",
+		fmt.Sprintf("Date: %s", orTBD(details.NewDate)),
+		fmt.Sprintf("Time: %s", orTBD(details.NewTimeRange)),
+		fmt.Sprintf("Courts: %s", orTBD(details.NewCourts)),
 	}
 
 	return ConfirmationEmail{
@@ -169,6 +352,376 @@ func BuildReminderEmail(details ReminderDetails) ConfirmationEmail {
 		fmt.Sprintf("Courts: %s", courts),
 	}
 
+	return ConfirmationEmail{
+		Subject:       subject,
+		Body:          strings.Join(lines, "\n"),
+		Category:      CategoryReminder,
+		FacilityID:    details.FacilityID,
+		ReservationID: details.ReservationID,
+		Text:          fmt.Sprintf("%s reminder: your %s is %s, %s (%s).", facilityName, reservationType, date, timeRange, courts),
+	}
+}
+
+func BuildWaitlistOfferEmail(details WaitlistOfferDetails) ConfirmationEmail {
+	rawFacilityName := strings.TrimSpace(details.FacilityName)
+	facilityName := rawFacilityName
+	if facilityName == "" {
+		facilityName = "your facility"
+	}
+	date := strings.TrimSpace(details.Date)
+	if date == "" {
+		date = "TBD"
+	}
+	timeRange := strings.TrimSpace(details.TimeRange)
+	if timeRange == "" {
+		timeRange = "TBD"
+	}
+	expiresAt := strings.TrimSpace(details.ExpiresAt)
+	if expiresAt == "" {
+		expiresAt = "the offer deadline"
+	}
+
+	subject := "Court Available"
+	if rawFacilityName != "" {
+		subject = fmt.Sprintf("%s - %s", subject, rawFacilityName)
+	}
+
+	lines := []string{
+		"A court opened up for a slot you are waitlisted for.",
+		"",
+		fmt.Sprintf("Facility: %s", facilityName),
+		fmt.Sprintf("Date: %s", date),
+		fmt.Sprintf("Time: %s", timeRange),
+		"",
+		fmt.Sprintf("Accept the offer in the member portal by %s or it goes to the next person.", expiresAt),
+	}
+
+	return ConfirmationEmail{
+		Subject:    subject,
+		Body:       strings.Join(lines, "\n"),
+		Category:   CategoryWaitlistOffer,
+		FacilityID: details.FacilityID,
+		Text:       fmt.Sprintf("%s: a court opened %s, %s. Accept in the member portal by %s.", facilityName, date, timeRange, expiresAt),
+	}
+}
+
+func BuildMemberCardEmail(details MemberCardDetails) ConfirmationEmail {
+	rawFacilityName := strings.TrimSpace(details.FacilityName)
+	facilityName := rawFacilityName
+	if facilityName == "" {
+		facilityName = "your facility"
+	}
+
+	subject := "Your Member Card"
+	if rawFacilityName != "" {
+		subject = fmt.Sprintf("%s - %s", subject, rawFacilityName)
+	}
+
+	lines := []string{
+		"Your member card QR code is attached.",
+		"Show it at the front desk to check in, or print it and keep it with you.",
+		"",
+		fmt.Sprintf("Member: %s", strings.TrimSpace(details.MemberName)),
+		fmt.Sprintf("Facility: %s", facilityName),
+		fmt.Sprintf("Membership: %s", strings.TrimSpace(details.Membership)),
+		fmt.Sprintf("Valid through: %s", strings.TrimSpace(details.ValidThrough)),
+		"",
+		"If you lose your card, reset it from the member portal. The old code stops working immediately.",
+	}
+
+	return ConfirmationEmail{
+		Subject: subject,
+		Body:    strings.Join(lines, "\n"),
+	}
+}
+
+func BuildLeagueTeamInvitationEmail(details LeagueTeamInvitationDetails) ConfirmationEmail {
+	rawFacilityName := strings.TrimSpace(details.FacilityName)
+	facilityName := rawFacilityName
+	if facilityName == "" {
+		facilityName = "your facility"
+	}
+	captain := strings.TrimSpace(details.CaptainName)
+	if captain == "" {
+		captain = "Your team captain"
+	}
+	teamName := strings.TrimSpace(details.TeamName)
+
+	subject := fmt.Sprintf("Join %s", teamName)
+	if rawFacilityName != "" {
+		subject = fmt.Sprintf("%s - %s", subject, rawFacilityName)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s invited you to join %s.", captain, teamName),
+		"",
+		fmt.Sprintf("League: %s", strings.TrimSpace(details.LeagueName)),
+		fmt.Sprintf("Facility: %s", facilityName),
+		"",
+		fmt.Sprintf("Accept the invitation: %s", strings.TrimSpace(details.AcceptURL)),
+	}
+	if expiresAt := strings.TrimSpace(details.ExpiresAt); expiresAt != "" {
+		lines = append(lines, fmt.Sprintf("This link expires %s.", expiresAt))
+	}
+
+	return ConfirmationEmail{
+		Subject: subject,
+		Body:    strings.Join(lines, "\n"),
+	}
+}
+
+func BuildLeagueMatchResultSubmittedEmail(details LeagueMatchResultSubmittedDetails) ConfirmationEmail {
+	rawFacilityName := strings.TrimSpace(details.FacilityName)
+	facilityName := rawFacilityName
+	if facilityName == "" {
+		facilityName = "your facility"
+	}
+	submitter := strings.TrimSpace(details.SubmitterName)
+	if submitter == "" {
+		submitter = "The home captain"
+	}
+	homeTeam := strings.TrimSpace(details.HomeTeamName)
+	awayTeam := strings.TrimSpace(details.AwayTeamName)
+
+	subject := fmt.Sprintf("Confirm result: %s vs %s", homeTeam, awayTeam)
+	if rawFacilityName != "" {
+		subject = fmt.Sprintf("%s - %s", subject, rawFacilityName)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s submitted a result for your match.", submitter),
+		"",
+		fmt.Sprintf("League: %s", strings.TrimSpace(details.LeagueName)),
+		fmt.Sprintf("Facility: %s", facilityName),
+		fmt.Sprintf("Result: %s %d, %s %d", homeTeam, details.HomeScore, awayTeam, details.AwayScore),
+		"",
+		"Sign in to the member portal to confirm or dispute it.",
+	}
+	if respondBy := strings.TrimSpace(details.RespondBy); respondBy != "" {
+		lines = append(lines, fmt.Sprintf("If you don't respond by %s, the result will be confirmed automatically.", respondBy))
+	}
+
+	return ConfirmationEmail{
+		Subject: subject,
+		Body:    strings.Join(lines, "\n"),
+	}
+}
+
+func BuildReservationInvitationEmail(details ReservationInvitationDetails) ConfirmationEmail {
+	rawFacilityName := strings.TrimSpace(details.FacilityName)
+	facilityName := rawFacilityName
+	if facilityName == "" {
+		facilityName = "your facility"
+	}
+	organizer := strings.TrimSpace(details.OrganizerName)
+	if organizer == "" {
+		organizer = "A member"
+	}
+	courts := strings.TrimSpace(details.Courts)
+	if courts == "" {
+		courts = "TBD"
+	}
+
+	subject := "You're invited to play"
+	if rawFacilityName != "" {
+		subject = fmt.Sprintf("%s - %s", subject, rawFacilityName)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s invited you to play pickleball.", organizer),
+		"",
+		fmt.Sprintf("Facility: %s", facilityName),
+		fmt.Sprintf("Date: %s", details.Date),
+		fmt.Sprintf("Time: %s", details.TimeRange),
+		fmt.Sprintf("Courts: %s", courts),
+		"",
+		fmt.Sprintf("Accept: %s", strings.TrimSpace(details.AcceptURL)),
+		fmt.Sprintf("Decline: %s", strings.TrimSpace(details.DeclineURL)),
+	}
+
+	return ConfirmationEmail{
+		Subject: subject,
+		Body:    strings.Join(lines, "\n"),
+	}
+}
+
+func BuildInvitationDeclinedEmail(details InvitationDeclinedDetails) ConfirmationEmail {
+	rawFacilityName := strings.TrimSpace(details.FacilityName)
+	facilityName := rawFacilityName
+	if facilityName == "" {
+		facilityName = "your facility"
+	}
+	invitee := strings.TrimSpace(details.InviteeName)
+	if invitee == "" {
+		invitee = "A member you invited"
+	}
+
+	subject := fmt.Sprintf("%s can't make it", invitee)
+	if rawFacilityName != "" {
+		subject = fmt.Sprintf("%s - %s", subject, rawFacilityName)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s declined your invitation, so their spot is open again.", invitee),
+		"",
+		fmt.Sprintf("Facility: %s", facilityName),
+		fmt.Sprintf("Date: %s", details.Date),
+		fmt.Sprintf("Time: %s", details.TimeRange),
+		"",
+		"You can invite someone else from your reservations in the member portal.",
+	}
+
+	return ConfirmationEmail{
+		Subject: subject,
+		Body:    strings.Join(lines, "\n"),
+	}
+}
+
+func BuildReservationTransferRequestEmail(details ReservationTransferRequestDetails) ConfirmationEmail {
+	rawFacilityName := strings.TrimSpace(details.FacilityName)
+	facilityName := rawFacilityName
+	if facilityName == "" {
+		facilityName = "your facility"
+	}
+	from := strings.TrimSpace(details.FromName)
+	if from == "" {
+		from = "A member"
+	}
+	courts := strings.TrimSpace(details.Courts)
+	if courts == "" {
+		courts = "TBD"
+	}
+
+	subject := "A reservation is being transferred to you"
+	if rawFacilityName != "" {
+		subject = fmt.Sprintf("%s - %s", subject, rawFacilityName)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s would like to hand their reservation over to you.", from),
+		"",
+		fmt.Sprintf("Facility: %s", facilityName),
+		fmt.Sprintf("Date: %s", details.Date),
+		fmt.Sprintf("Time: %s", details.TimeRange),
+		fmt.Sprintf("Courts: %s", courts),
+		"",
+		"Accepting makes the reservation yours and counts it toward your booking limit.",
+		fmt.Sprintf("Accept: %s", strings.TrimSpace(details.AcceptURL)),
+		fmt.Sprintf("Decline: %s", strings.TrimSpace(details.DeclineURL)),
+	}
+	if expiresAt := strings.TrimSpace(details.ExpiresAt); expiresAt != "" {
+		lines = append(lines, fmt.Sprintf("This offer expires %s.", expiresAt))
+	}
+
+	return ConfirmationEmail{
+		Subject: subject,
+		Body:    strings.Join(lines, "\n"),
+	}
+}
+
+func BuildReservationTransferredEmail(details ReservationTransferredDetails) ConfirmationEmail {
+	rawFacilityName := strings.TrimSpace(details.FacilityName)
+	facilityName := rawFacilityName
+	if facilityName == "" {
+		facilityName = "your facility"
+	}
+	from := strings.TrimSpace(details.FromName)
+	if from == "" {
+		from = "Another member"
+	}
+	to := strings.TrimSpace(details.ToName)
+	if to == "" {
+		to = "another member"
+	}
+	courts := strings.TrimSpace(details.Courts)
+	if courts == "" {
+		courts = "TBD"
+	}
+
+	subject := "Reservation transferred"
+	intro := fmt.Sprintf("Your reservation now belongs to %s and no longer counts toward your booking limit.", to)
+	if details.ToRecipient {
+		subject = "A reservation is now yours"
+		intro = fmt.Sprintf("%s transferred their reservation to you.", from)
+	}
+	if rawFacilityName != "" {
+		subject = fmt.Sprintf("%s - %s", subject, rawFacilityName)
+	}
+
+	lines := []string{
+		intro,
+		"",
+		fmt.Sprintf("Facility: %s", facilityName),
+		fmt.Sprintf("Date: %s", details.Date),
+		fmt.Sprintf("Time: %s", details.TimeRange),
+		fmt.Sprintf("Courts: %s", courts),
+	}
+
+	return ConfirmationEmail{
+		Subject: subject,
+		Body:    strings.Join(lines, "\n"),
+	}
+}
+
+func BuildTransferDeclinedEmail(details TransferDeclinedDetails) ConfirmationEmail {
+	rawFacilityName := strings.TrimSpace(details.FacilityName)
+	facilityName := rawFacilityName
+	if facilityName == "" {
+		facilityName = "your facility"
+	}
+	to := strings.TrimSpace(details.ToName)
+	if to == "" {
+		to = "The member you chose"
+	}
+
+	subject := fmt.Sprintf("%s declined your reservation", to)
+	if rawFacilityName != "" {
+		subject = fmt.Sprintf("%s - %s", subject, rawFacilityName)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s declined the transfer, so the reservation is still yours.", to),
+		"",
+		fmt.Sprintf("Facility: %s", facilityName),
+		fmt.Sprintf("Date: %s", details.Date),
+		fmt.Sprintf("Time: %s", details.TimeRange),
+	}
+
+	return ConfirmationEmail{
+		Subject: subject,
+		Body:    strings.Join(lines, "\n"),
+	}
+}
+
+func BuildHouseholdLinkRequestEmail(details HouseholdLinkRequestDetails) ConfirmationEmail {
+	rawFacilityName := strings.TrimSpace(details.FacilityName)
+	facilityName := rawFacilityName
+	if facilityName == "" {
+		facilityName = "your facility"
+	}
+	primary := strings.TrimSpace(details.PrimaryName)
+	if primary == "" {
+		primary = "A member"
+	}
+
+	subject := fmt.Sprintf("%s wants to link your account", primary)
+	if rawFacilityName != "" {
+		subject = fmt.Sprintf("%s - %s", subject, rawFacilityName)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s would like to add you to their household at %s.", primary, facilityName),
+		"",
+		"Once linked, they can book courts for you, see your reservations, and cancel them.",
+		"You can leave the household from the member portal at any time.",
+		"",
+		fmt.Sprintf("Accept: %s", strings.TrimSpace(details.AcceptURL)),
+		fmt.Sprintf("Decline: %s", strings.TrimSpace(details.DeclineURL)),
+	}
+	if expiresAt := strings.TrimSpace(details.ExpiresAt); expiresAt != "" {
+		lines = append(lines, fmt.Sprintf("This request expires %s.", expiresAt))
+	}
+
 	return ConfirmationEmail{
 		Subject: subject,
 		Body:    strings.Join(lines, "\n"),
@@ -211,7 +764,19 @@ func buildConfirmationEmail(reservationType, subjectPrefix string, details Confi
 		fmt.Sprintf("Date: %s", date),
 		fmt.Sprintf("Time: %s", timeRange),
 		fmt.Sprintf("Courts: %s", courts),
-		fmt.Sprintf("Cancellation policy: %s", cancellationPolicy),
+	}
+	if guests := strings.TrimSpace(details.Guests); guests != "" {
+		lines = append(lines, fmt.Sprintf("Guests: %s", guests))
+	}
+	if note := strings.TrimSpace(details.Note); note != "" {
+		lines = append(lines, fmt.Sprintf("Your note: %s", note))
+	}
+	if price := strings.TrimSpace(details.Price); price != "" {
+		lines = append(lines, fmt.Sprintf("Price: %s", price))
+	}
+	lines = append(lines, fmt.Sprintf("Cancellation policy: %s", cancellationPolicy))
+	if accessURL := strings.TrimSpace(details.AccessURL); accessURL != "" {
+		lines = append(lines, "", fmt.Sprintf("Door access QR code: %s", accessURL))
 	}
 
 	return ConfirmationEmail{
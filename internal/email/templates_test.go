@@ -0,0 +1,211 @@
+package email
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildReservationChanged_ShowsOldAndNew(t *testing.T) {
+	message := BuildReservationChanged(ReservationChangedDetails{
+		FacilityName:    "Main Facility",
+		ReservationType: "GAME",
+		OldDate:         "Monday, Mar 2, 2026",
+		OldTimeRange:    "9:00 AM - 10:00 AM EST",
+		OldCourts:       "Court 1",
+		NewDate:         "Tuesday, Mar 3, 2026",
+		NewTimeRange:    "6:00 PM - 7:00 PM EST",
+		NewCourts:       "Courts 2-3",
+	})
+
+	if message.Subject != "Court Reservation Changed - Main Facility" {
+		t.Fatalf("unexpected subject %q", message.Subject)
+	}
+	was := strings.Index(message.Body, "Was:")
+	now := strings.Index(message.Body, "Now:")
+	if was < 0 || now < was {
+		t.Fatalf("expected Was: before Now: in body:\n%s", message.Body)
+	}
+	if !strings.Contains(message.Body[was:now], "Court 1") || !strings.Contains(message.Body[was:now], "9:00 AM") {
+		t.Fatalf("old details missing from body:\n%s", message.Body)
+	}
+	if !strings.Contains(message.Body[now:], "Courts 2-3") || !strings.Contains(message.Body[now:], "Tuesday, Mar 3, 2026") {
+		t.Fatalf("new details missing from body:\n%s", message.Body)
+	}
+}
+
+func TestBuildLeagueTeamInvitationEmail(t *testing.T) {
+	message := BuildLeagueTeamInvitationEmail(LeagueTeamInvitationDetails{
+		FacilityName: "Main Facility",
+		LeagueName:   "Summer Doubles",
+		TeamName:     "Kitchen Kings",
+		CaptainName:  "Pat Lee",
+		AcceptURL:    "https://club.example.com/member/league-invitations/abc123/accept",
+		ExpiresAt:    "Monday, Jun 8, 2026",
+	})
+
+	if message.Subject != "Join Kitchen Kings - Main Facility" {
+		t.Fatalf("unexpected subject %q", message.Subject)
+	}
+	for _, want := range []string{
+		"Pat Lee invited you to join Kitchen Kings.",
+		"League: Summer Doubles",
+		"https://club.example.com/member/league-invitations/abc123/accept",
+		"expires Monday, Jun 8, 2026",
+	} {
+		if !strings.Contains(message.Body, want) {
+			t.Fatalf("expected %q in body:\n%s", want, message.Body)
+		}
+	}
+}
+
+func TestBuildReservationTransferredEmail(t *testing.T) {
+	details := ReservationTransferredDetails{
+		FacilityName: "Main Facility",
+		FromName:     "Pat Lee",
+		ToName:       "Sam Reed",
+		Date:         "Monday, Jun 8, 2026",
+		TimeRange:    "9:00 AM - 10:00 AM UTC",
+		Courts:       "Court 1",
+	}
+
+	message := BuildReservationTransferredEmail(details)
+	if message.Subject != "Reservation transferred - Main Facility" {
+		t.Fatalf("unexpected subject %q", message.Subject)
+	}
+	if !strings.Contains(message.Body, "now belongs to Sam Reed") {
+		t.Fatalf("expected new holder in body:\n%s", message.Body)
+	}
+
+	details.ToRecipient = true
+	message = BuildReservationTransferredEmail(details)
+	if message.Subject != "A reservation is now yours - Main Facility" {
+		t.Fatalf("unexpected subject %q", message.Subject)
+	}
+	if !strings.Contains(message.Body, "Pat Lee transferred their reservation to you.") {
+		t.Fatalf("expected previous holder in body:\n%s", message.Body)
+	}
+}
+
+func TestBuildLeagueMatchResultSubmittedEmail(t *testing.T) {
+	message := BuildLeagueMatchResultSubmittedEmail(LeagueMatchResultSubmittedDetails{
+		FacilityName:  "Main Facility",
+		LeagueName:    "Spring Ladder",
+		HomeTeamName:  "Dinkers",
+		AwayTeamName:  "Lobbers",
+		HomeScore:     11,
+		AwayScore:     7,
+		SubmitterName: "Pat Lee",
+		RespondBy:     "Wednesday, Jun 10, 2026 6:00 PM UTC",
+	})
+	if message.Subject != "Confirm result: Dinkers vs Lobbers - Main Facility" {
+		t.Fatalf("unexpected subject %q", message.Subject)
+	}
+	for _, want := range []string{
+		"Pat Lee submitted a result for your match.",
+		"Result: Dinkers 11, Lobbers 7",
+		"by Wednesday, Jun 10, 2026 6:00 PM UTC, the result will be confirmed automatically",
+	} {
+		if !strings.Contains(message.Body, want) {
+			t.Fatalf("expected %q in body:\n%s", want, message.Body)
+		}
+	}
+}
+
+func TestBuildGameConfirmation_ListsGuests(t *testing.T) {
+	details := ConfirmationDetails{
+		FacilityName: "Main Facility",
+		Date:         "Monday, Mar 2, 2026",
+		TimeRange:    "9:00 AM - 10:00 AM EST",
+		Courts:       "Court 1",
+	}
+	if message := BuildGameConfirmation(details); strings.Contains(message.Body, "Guests:") {
+		t.Fatalf("expected no guests line without guests:\n%s", message.Body)
+	}
+
+	details.Guests = "Alex Guest, Jo Visitor"
+	message := BuildGameConfirmation(details)
+	if !strings.Contains(message.Body, "Guests: Alex Guest, Jo Visitor") {
+		t.Fatalf("expected guest names in body:\n%s", message.Body)
+	}
+}
+
+func TestBuildGameConfirmation_IncludesMemberNote(t *testing.T) {
+	details := ConfirmationDetails{
+		FacilityName: "Main Facility",
+		Date:         "Monday, Mar 2, 2026",
+		TimeRange:    "9:00 AM - 10:00 AM EST",
+		Courts:       "Court 1",
+	}
+	if message := BuildGameConfirmation(details); strings.Contains(message.Body, "Your note:") {
+		t.Fatalf("expected no note line without a note:\n%s", message.Body)
+	}
+
+	details.Note = "Bringing a ball machine"
+	if message := BuildGameConfirmation(details); !strings.Contains(message.Body, "Your note: Bringing a ball machine") {
+		t.Fatalf("expected member note in body:\n%s", message.Body)
+	}
+}
+
+func TestBuildGameConfirmation_LinksAccessCode(t *testing.T) {
+	details := ConfirmationDetails{
+		FacilityName: "Main Facility",
+		Date:         "Monday, Mar 2, 2026",
+		TimeRange:    "9:00 AM - 10:00 AM EST",
+		Courts:       "Court 1",
+	}
+	if message := BuildGameConfirmation(details); strings.Contains(message.Body, "Door access") {
+		t.Fatalf("expected no access line without a link:\n%s", message.Body)
+	}
+
+	details.AccessURL = "https://club.example.com/member/reservations/7/qr"
+	if message := BuildGameConfirmation(details); !strings.HasSuffix(message.Body, "\n\nDoor access QR code: https://club.example.com/member/reservations/7/qr") {
+		t.Fatalf("expected access link at the end of the body:\n%s", message.Body)
+	}
+}
+
+func TestBuildHouseholdLinkRequestEmail(t *testing.T) {
+	message := BuildHouseholdLinkRequestEmail(HouseholdLinkRequestDetails{
+		FacilityName: "Main Facility",
+		PrimaryName:  "Pat Lee",
+		AcceptURL:    "https://club.example.com/member/household-links/abc123/accept",
+		DeclineURL:   "https://club.example.com/member/household-links/abc123/decline",
+		ExpiresAt:    "Monday, Jun 8, 2026",
+	})
+
+	if message.Subject != "Pat Lee wants to link your account - Main Facility" {
+		t.Fatalf("unexpected subject %q", message.Subject)
+	}
+	for _, want := range []string{
+		"Pat Lee would like to add you to their household at Main Facility.",
+		"Accept: https://club.example.com/member/household-links/abc123/accept",
+		"Decline: https://club.example.com/member/household-links/abc123/decline",
+		"expires Monday, Jun 8, 2026",
+	} {
+		if !strings.Contains(message.Body, want) {
+			t.Fatalf("expected %q in body:\n%s", want, message.Body)
+		}
+	}
+}
+
+func TestBuildPricedConfirmationAndRefund(t *testing.T) {
+	confirmation := BuildGameConfirmation(ConfirmationDetails{
+		FacilityName: "Main Facility",
+		Date:         "Monday, Mar 2, 2026",
+		TimeRange:    "6:00 PM - 7:30 PM EST",
+		Courts:       "Court 1",
+		Price:        "$52.50",
+	})
+	if !strings.Contains(confirmation.Body, "Price: $52.50\nCancellation policy:") {
+		t.Fatalf("expected price before the cancellation policy:\n%s", confirmation.Body)
+	}
+
+	refundPercentage := int64(50)
+	cancellation := BuildCancellationEmail(CancellationDetails{
+		FacilityName:     "Main Facility",
+		RefundPercentage: &refundPercentage,
+		RefundAmount:     "$26.25",
+	})
+	if !strings.Contains(cancellation.Body, "Refund: 50% ($26.25)") {
+		t.Fatalf("expected refund amount in body:\n%s", cancellation.Body)
+	}
+}
@@ -0,0 +1,230 @@
+// Package ical writes RFC 5545 iCalendar documents for calendar export.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	utcLayout   = "20060102T150405Z"
+	localLayout = "20060102T150405"
+	maxLineLen  = 75
+)
+
+// Calendar is a VCALENDAR containing one or more events.
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// Event is a VEVENT. UID must be stable so re-importing a calendar updates
+// existing events instead of duplicating them. TimeZone controls how Start
+// and End are written; nil or UTC writes UTC timestamps.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	TimeZone    *time.Location
+	Summary     string
+	Location    string
+	Description string
+	Updated     time.Time
+}
+
+// Bytes renders the calendar with CRLF line endings and folded lines.
+func (c Calendar) Bytes() []byte {
+	var w writer
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + c.ProdID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME:" + escapeText(c.Name))
+	}
+
+	for _, zone := range c.timeZones() {
+		writeTimeZone(&w, zone.loc, zone.from, zone.to)
+	}
+
+	for _, event := range c.Events {
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + escapeText(event.UID))
+		stamp := event.Updated
+		if stamp.IsZero() {
+			stamp = event.Start
+		}
+		w.line("DTSTAMP:" + stamp.UTC().Format(utcLayout))
+		if !event.Updated.IsZero() {
+			w.line("LAST-MODIFIED:" + event.Updated.UTC().Format(utcLayout))
+		}
+		w.line(formatDateTime("DTSTART", event.Start, event.TimeZone))
+		w.line(formatDateTime("DTEND", event.End, event.TimeZone))
+		w.line("SUMMARY:" + escapeText(event.Summary))
+		if event.Location != "" {
+			w.line("LOCATION:" + escapeText(event.Location))
+		}
+		if event.Description != "" {
+			w.line("DESCRIPTION:" + escapeText(event.Description))
+		}
+		w.line("END:VEVENT")
+	}
+
+	w.line("END:VCALENDAR")
+	return w.buf.Bytes()
+}
+
+type zoneRange struct {
+	loc      *time.Location
+	from, to time.Time
+}
+
+// timeZones returns each non-UTC zone used by the events along with the span
+// of time its VTIMEZONE needs to cover.
+func (c Calendar) timeZones() []zoneRange {
+	byName := make(map[string]*zoneRange)
+	for _, event := range c.Events {
+		if !usesTimeZone(event.TimeZone) {
+			continue
+		}
+		name := event.TimeZone.String()
+		zone, ok := byName[name]
+		if !ok {
+			byName[name] = &zoneRange{loc: event.TimeZone, from: event.Start, to: event.End}
+			continue
+		}
+		if event.Start.Before(zone.from) {
+			zone.from = event.Start
+		}
+		if event.End.After(zone.to) {
+			zone.to = event.End
+		}
+	}
+
+	zones := make([]zoneRange, 0, len(byName))
+	for _, zone := range byName {
+		zones = append(zones, *zone)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].loc.String() < zones[j].loc.String()
+	})
+	return zones
+}
+
+func usesTimeZone(loc *time.Location) bool {
+	return loc != nil && loc != time.UTC && loc.String() != "UTC"
+}
+
+func formatDateTime(property string, t time.Time, loc *time.Location) string {
+	if !usesTimeZone(loc) {
+		return property + ":" + t.UTC().Format(utcLayout)
+	}
+	return fmt.Sprintf("%s;TZID=%s:%s", property, loc.String(), t.In(loc).Format(localLayout))
+}
+
+// writeTimeZone emits a VTIMEZONE describing the offsets in effect between
+// from and to, derived from the Go time zone database.
+func writeTimeZone(w *writer, loc *time.Location, from, to time.Time) {
+	from = from.Add(-24 * time.Hour)
+	to = to.Add(24 * time.Hour)
+
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + loc.String())
+
+	_, initialOffset := from.In(loc).Zone()
+	writeObservance(w, from.In(loc), initialOffset, initialOffset)
+
+	previous := from
+	_, previousOffset := previous.In(loc).Zone()
+	for day := from.Add(24 * time.Hour); !day.After(to); day = day.Add(24 * time.Hour) {
+		_, offset := day.In(loc).Zone()
+		if offset != previousOffset {
+			transition := findTransition(loc, previous, day)
+			writeObservance(w, transition.In(loc), previousOffset, offset)
+			previousOffset = offset
+		}
+		previous = day
+	}
+
+	w.line("END:VTIMEZONE")
+}
+
+// findTransition narrows an offset change between lo and hi to the second.
+func findTransition(loc *time.Location, lo, hi time.Time) time.Time {
+	_, loOffset := lo.In(loc).Zone()
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+		if _, offset := mid.In(loc).Zone(); offset == loOffset {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+func writeObservance(w *writer, onset time.Time, offsetFrom, offsetTo int) {
+	kind := "STANDARD"
+	if onset.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, _ := onset.Zone()
+	// DTSTART is the onset expressed in the wall clock time before it.
+	local := onset.UTC().Add(time.Duration(offsetFrom) * time.Second)
+
+	w.line("BEGIN:" + kind)
+	w.line("DTSTART:" + local.Format(localLayout))
+	w.line("TZOFFSETFROM:" + formatOffset(offsetFrom))
+	w.line("TZOFFSETTO:" + formatOffset(offsetTo))
+	if name != "" {
+		w.line("TZNAME:" + escapeText(name))
+	}
+	w.line("END:" + kind)
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+type writer struct {
+	buf bytes.Buffer
+}
+
+// line writes a content line, folding it at 75 octets without splitting
+// UTF-8 sequences.
+func (w *writer) line(value string) {
+	limit := maxLineLen
+	for len(value) > limit {
+		cut := limit
+		for cut > 0 && value[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.buf.WriteString(value[:cut])
+		w.buf.WriteString("\r\n ")
+		value = value[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		limit = maxLineLen - 1
+	}
+	w.buf.WriteString(value)
+	w.buf.WriteString("\r\n")
+}
@@ -0,0 +1,82 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendarBytes_FacilityTimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	start := time.Date(2026, time.November, 2, 14, 0, 0, 0, time.UTC)
+	calendar := Calendar{
+		ProdID: "-//Test//EN",
+		Events: []Event{{
+			UID:         "reservation-7@test",
+			Start:       start,
+			End:         start.Add(time.Hour),
+			TimeZone:    loc,
+			Summary:     "Court Reservation at Main, North",
+			Location:    "Main; North",
+			Description: "Court: Court 1\nPro: Sam",
+		}, {
+			UID:      "reservation-6@test",
+			Start:    start.AddDate(0, 0, -4),
+			End:      start.AddDate(0, 0, -4).Add(time.Hour),
+			TimeZone: loc,
+			Summary:  "Court Reservation",
+		}},
+	}
+	out := string(calendar.Bytes())
+
+	for _, want := range []string{
+		"DTSTART;TZID=America/New_York:20261102T090000\r\n",
+		"DTEND;TZID=America/New_York:20261102T100000\r\n",
+		"DTSTART;TZID=America/New_York:20261029T100000\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:America/New_York\r\n",
+		// DST ends Nov 1, 2026 at 2:00 EDT.
+		"BEGIN:STANDARD\r\nDTSTART:20261101T020000\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0500\r\n",
+		"UID:reservation-7@test\r\n",
+		"SUMMARY:Court Reservation at Main\\, North\r\n",
+		"LOCATION:Main\\; North\r\n",
+		"DESCRIPTION:Court: Court 1\\nPro: Sam\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in calendar:\n%s", want, out)
+		}
+	}
+}
+
+func TestCalendarBytes_UTCAndFolding(t *testing.T) {
+	start := time.Date(2026, time.March, 1, 9, 30, 0, 0, time.UTC)
+	calendar := Calendar{
+		ProdID: "-//Test//EN",
+		Events: []Event{{
+			UID:         "reservation-1@test",
+			Start:       start,
+			End:         start.Add(time.Hour),
+			Summary:     "Game",
+			Description: strings.Repeat("é", 60),
+		}},
+	}
+	out := string(calendar.Bytes())
+
+	if strings.Contains(out, "VTIMEZONE") {
+		t.Fatalf("expected no VTIMEZONE for UTC events:\n%s", out)
+	}
+	if !strings.Contains(out, "DTSTART:20260301T093000Z\r\n") {
+		t.Fatalf("expected UTC start:\n%s", out)
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > maxLineLen {
+			t.Fatalf("line exceeds %d octets: %q", maxLineLen, line)
+		}
+	}
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, "DESCRIPTION:"+strings.Repeat("é", 60)+"\r\n") {
+		t.Fatalf("folded description did not round-trip:\n%s", out)
+	}
+}
@@ -0,0 +1,234 @@
+// Package images validates uploaded photos and normalizes them to JPEG.
+//
+// Uploads are identified by their magic bytes rather than any declared
+// content type, decoded with the standard image packages, and re-encoded at
+// a full size and a square thumbnail. WebP is recognized but only decodes
+// when a WebP decoder is registered with the image package; otherwise it is
+// rejected as unsupported.
+package images
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeWebP = "image/webp"
+
+	// DefaultMaxUploadBytes caps an upload's decoded size until
+	// SetMaxUploadBytes runs.
+	DefaultMaxUploadBytes = 5 << 20
+
+	// FullSize bounds the longer edge of the stored photo.
+	FullSize = 1024
+	// ThumbnailSize is the edge of the square thumbnail.
+	ThumbnailSize = 160
+
+	// maxPixels rejects images whose dimensions would need far more memory
+	// to decode than any phone photo, before decoding them.
+	maxPixels   = 50_000_000
+	jpegQuality = 85
+)
+
+var (
+	ErrEmpty             = errors.New("images: empty upload")
+	ErrTooLarge          = errors.New("images: upload too large")
+	ErrUnsupportedFormat = errors.New("images: unsupported format")
+	ErrInvalid           = errors.New("images: invalid image data")
+)
+
+var maxUploadBytes atomic.Int64
+
+// SetMaxUploadBytes sets the largest upload Process accepts. Zero or less
+// restores DefaultMaxUploadBytes.
+func SetMaxUploadBytes(n int64) {
+	if n <= 0 {
+		n = DefaultMaxUploadBytes
+	}
+	maxUploadBytes.Store(n)
+}
+
+// MaxUploadBytes returns the configured upload limit.
+func MaxUploadBytes() int64 {
+	if n := maxUploadBytes.Load(); n > 0 {
+		return n
+	}
+	return DefaultMaxUploadBytes
+}
+
+// Photo is a normalized upload. Both sizes are JPEG.
+type Photo struct {
+	Full        []byte
+	Thumbnail   []byte
+	ContentType string
+	// SourceType is the detected content type of the original upload.
+	SourceType string
+}
+
+// DetectContentType identifies JPEG, PNG and WebP data by magic bytes.
+func DetectContentType(data []byte) (string, error) {
+	switch {
+	case len(data) == 0:
+		return "", ErrEmpty
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return ContentTypeJPEG, nil
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return ContentTypePNG, nil
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return ContentTypeWebP, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// DecodeDataURL decodes the base64 payload of a data URL such as
+// "data:image/png;base64,...". The declared media type is ignored. Payloads
+// over the upload limit are rejected before decoding.
+func DecodeDataURL(dataURL string) ([]byte, error) {
+	_, payload, found := strings.Cut(dataURL, ",")
+	if !found {
+		return nil, fmt.Errorf("%w: missing data URL payload", ErrInvalid)
+	}
+	payload = strings.TrimSpace(payload)
+	if payload == "" {
+		return nil, ErrEmpty
+	}
+	decodedLen := base64.StdEncoding.DecodedLen(len(payload)) - (len(payload) - len(strings.TrimRight(payload, "=")))
+	if int64(decodedLen) > MaxUploadBytes() {
+		return nil, ErrTooLarge
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return data, nil
+}
+
+// Process validates an upload and re-encodes it as a JPEG no larger than
+// FullSize on either edge, plus a center-cropped square thumbnail.
+// Transparent areas are flattened onto white.
+func Process(data []byte) (Photo, error) {
+	if int64(len(data)) > MaxUploadBytes() {
+		return Photo{}, ErrTooLarge
+	}
+	sourceType, err := DetectContentType(data)
+	if err != nil {
+		return Photo{}, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Photo{}, decodeError(err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return Photo{}, fmt.Errorf("%w: %dx%d pixels", ErrTooLarge, config.Width, config.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Photo{}, decodeError(err)
+	}
+
+	flat := flatten(src)
+	bounds := flat.Bounds()
+	fullWidth, fullHeight := fitWithin(bounds.Dx(), bounds.Dy(), FullSize)
+	full, err := encodeJPEG(resize(flat, fullWidth, fullHeight))
+	if err != nil {
+		return Photo{}, err
+	}
+
+	side := min(bounds.Dx(), bounds.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt((bounds.Dx()-side)/2, (bounds.Dy()-side)/2))
+	thumbSide := min(side, ThumbnailSize)
+	thumbnail, err := encodeJPEG(resize(flat.SubImage(crop).(*image.RGBA), thumbSide, thumbSide))
+	if err != nil {
+		return Photo{}, err
+	}
+
+	return Photo{
+		Full:        full,
+		Thumbnail:   thumbnail,
+		ContentType: ContentTypeJPEG,
+		SourceType:  sourceType,
+	}, nil
+}
+
+func decodeError(err error) error {
+	if errors.Is(err, image.ErrFormat) {
+		return fmt.Errorf("%w: no decoder available", ErrUnsupportedFormat)
+	}
+	return fmt.Errorf("%w: %v", ErrInvalid, err)
+}
+
+// flatten copies src onto an opaque white RGBA image anchored at the origin.
+func flatten(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Over)
+	return dst
+}
+
+// fitWithin scales width and height down so neither exceeds limit, keeping
+// the aspect ratio. Smaller images keep their size.
+func fitWithin(width, height, limit int) (int, int) {
+	if width <= limit && height <= limit {
+		return width, height
+	}
+	if width >= height {
+		return limit, max(1, height*limit/width)
+	}
+	return max(1, width*limit/height), limit
+}
+
+// resize box-filters src down to width x height by averaging the source
+// pixels each destination pixel covers.
+func resize(src *image.RGBA, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	if bounds.Dx() == width && bounds.Dy() == height {
+		return src
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+			var r, g, b, count uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[src.PixOffset(x0, sy):src.PixOffset(x1, sy)]
+				for i := 0; i < len(row); i += 4 {
+					r += uint32(row[i])
+					g += uint32(row[i+1])
+					b += uint32(row[i+2])
+					count++
+				}
+			}
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = 0xFF
+		}
+	}
+	return dst
+}
+
+func encodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("encode jpeg: %w", err)
+	}
+	return buf.Bytes(), nil
+}
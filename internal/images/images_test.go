@@ -0,0 +1,127 @@
+package images
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodeTestPNG(t *testing.T, width, height int, fill color.Color) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDetectContentType(t *testing.T) {
+	jpegData := func() []byte {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2)), nil); err != nil {
+			t.Fatalf("encode jpeg: %v", err)
+		}
+		return buf.Bytes()
+	}()
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+		want string
+		err  error
+	}{
+		{"jpeg", jpegData, ContentTypeJPEG, nil},
+		{"png", encodeTestPNG(t, 2, 2, color.Black), ContentTypePNG, nil},
+		{"webp", []byte("RIFF\x24\x00\x00\x00WEBPVP8 "), ContentTypeWebP, nil},
+		{"gif", []byte("GIF89a\x01\x00\x01\x00"), "", ErrUnsupportedFormat},
+		{"text labeled as jpeg", []byte("<svg xmlns='http://www.w3.org/2000/svg'/>"), "", ErrUnsupportedFormat},
+		{"empty", nil, "", ErrEmpty},
+	} {
+		got, err := DetectContentType(tc.data)
+		if got != tc.want || !errors.Is(err, tc.err) {
+			t.Fatalf("%s: got (%q, %v), want (%q, %v)", tc.name, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+func TestProcess_NormalizesToJPEGSizes(t *testing.T) {
+	// Fully transparent pixels are flattened onto white.
+	photo, err := Process(encodeTestPNG(t, 2000, 1000, color.NRGBA{}))
+	if err != nil {
+		t.Fatalf("process: %v", err)
+	}
+	if photo.ContentType != ContentTypeJPEG || photo.SourceType != ContentTypePNG {
+		t.Fatalf("unexpected types: %q from %q", photo.ContentType, photo.SourceType)
+	}
+
+	full, format, err := image.Decode(bytes.NewReader(photo.Full))
+	if err != nil || format != "jpeg" {
+		t.Fatalf("decode full: %v (%s)", err, format)
+	}
+	if got := full.Bounds().Size(); got != image.Pt(FullSize, FullSize/2) {
+		t.Fatalf("full size = %v", got)
+	}
+	if r, g, b, _ := full.At(10, 10).RGBA(); r>>8 < 250 || g>>8 < 250 || b>>8 < 250 {
+		t.Fatalf("expected transparency flattened to white, got %d,%d,%d", r>>8, g>>8, b>>8)
+	}
+
+	thumb, err := jpeg.Decode(bytes.NewReader(photo.Thumbnail))
+	if err != nil {
+		t.Fatalf("decode thumbnail: %v", err)
+	}
+	if got := thumb.Bounds().Size(); got != image.Pt(ThumbnailSize, ThumbnailSize) {
+		t.Fatalf("thumbnail size = %v", got)
+	}
+
+	// Small images are not scaled up.
+	small, err := Process(encodeTestPNG(t, 40, 60, color.Black))
+	if err != nil {
+		t.Fatalf("process small: %v", err)
+	}
+	smallFull, _ := jpeg.Decode(bytes.NewReader(small.Full))
+	smallThumb, _ := jpeg.Decode(bytes.NewReader(small.Thumbnail))
+	if smallFull.Bounds().Size() != image.Pt(40, 60) || smallThumb.Bounds().Size() != image.Pt(40, 40) {
+		t.Fatalf("small image resized to %v / %v", smallFull.Bounds().Size(), smallThumb.Bounds().Size())
+	}
+}
+
+func TestProcess_RejectsBadUploads(t *testing.T) {
+	t.Cleanup(func() { SetMaxUploadBytes(0) })
+
+	if _, err := Process([]byte("RIFF\x24\x00\x00\x00WEBPVP8 ")); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Fatalf("expected webp without a decoder to be unsupported, got %v", err)
+	}
+	if _, err := Process([]byte("\x89PNG\r\n\x1a\ntruncated")); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected a truncated png to be invalid, got %v", err)
+	}
+
+	data := encodeTestPNG(t, 20, 20, color.Black)
+	SetMaxUploadBytes(int64(len(data) - 1))
+	if _, err := Process(data); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("expected the size limit to apply, got %v", err)
+	}
+	dataURL := "data:image/png;base64," + base64.StdEncoding.EncodeToString(data)
+	if _, err := DecodeDataURL(dataURL); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("expected the size limit to apply before decoding, got %v", err)
+	}
+
+	SetMaxUploadBytes(0)
+	decoded, err := DecodeDataURL(dataURL)
+	if err != nil || !bytes.Equal(decoded, data) {
+		t.Fatalf("decode data URL: %v", err)
+	}
+	if _, err := DecodeDataURL("not a data url"); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected a missing payload to be invalid, got %v", err)
+	}
+}
@@ -0,0 +1,289 @@
+// Package jobs tracks the app's background jobs: each run's timing, items
+// processed and error, persisted so operators can see job health across
+// restarts and trigger a run by hand.
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// Run triggers recorded with each run.
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+// FailingThreshold is how many consecutive failed runs mark a job as
+// failing repeatedly.
+const FailingThreshold = 3
+
+var (
+	ErrNotInitialized = errors.New("job registry not initialized")
+	ErrUnknownJob     = errors.New("unknown job")
+	ErrAlreadyRunning = errors.New("job is already running")
+	ErrDuplicateJob   = errors.New("job already registered")
+)
+
+// Func runs a job once and reports how many items it processed.
+type Func func(ctx context.Context) (int64, error)
+
+// Store persists job outcomes.
+type Store interface {
+	ListJobStatuses(ctx context.Context) ([]dbgen.JobStatus, error)
+	RecordJobRun(ctx context.Context, arg dbgen.RecordJobRunParams) (dbgen.JobStatus, error)
+}
+
+// Status is a registered job with the outcome of its latest run. The Last
+// fields are zero until the job has run once.
+type Status struct {
+	Name                string     `json:"name"`
+	Schedule            string     `json:"schedule"`
+	Running             bool       `json:"running"`
+	LastTrigger         string     `json:"lastTrigger,omitempty"`
+	LastStartedAt       *time.Time `json:"lastStartedAt,omitempty"`
+	LastFinishedAt      *time.Time `json:"lastFinishedAt,omitempty"`
+	LastDurationMs      int64      `json:"lastDurationMs"`
+	LastItems           int64      `json:"lastItems"`
+	LastError           string     `json:"lastError,omitempty"`
+	ConsecutiveFailures int64      `json:"consecutiveFailures"`
+	LastSuccessAt       *time.Time `json:"lastSuccessAt,omitempty"`
+}
+
+// Failing reports whether the job has failed FailingThreshold runs in a row.
+func (s Status) Failing() bool {
+	return s.ConsecutiveFailures >= FailingThreshold
+}
+
+type job struct {
+	name     string
+	schedule string
+	timeout  time.Duration
+	run      Func
+	// lock keeps a manual run and a scheduled run of the same job from
+	// overlapping.
+	lock sync.Mutex
+
+	mu      sync.Mutex
+	running bool
+}
+
+// Registry holds the registered jobs.
+type Registry struct {
+	store Store
+
+	mu   sync.RWMutex
+	jobs map[string]*job
+}
+
+// NewRegistry returns an empty registry recording runs in store.
+func NewRegistry(store Store) *Registry {
+	return &Registry{store: store, jobs: make(map[string]*job)}
+}
+
+var (
+	registry     *Registry
+	registryOnce sync.Once
+)
+
+// Init sets up the app-wide registry.
+func Init(store Store) {
+	if store == nil {
+		return
+	}
+	registryOnce.Do(func() {
+		registry = NewRegistry(store)
+	})
+}
+
+// Default returns the app-wide registry, or nil before Init.
+func Default() *Registry {
+	return registry
+}
+
+// Register adds a job. schedule is informational; timeout bounds each run.
+func (r *Registry) Register(name, schedule string, timeout time.Duration, run Func) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("job name is required")
+	}
+	if run == nil {
+		return fmt.Errorf("job %s has no run func", name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.jobs[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateJob, name)
+	}
+	r.jobs[name] = &job{name: name, schedule: schedule, timeout: timeout, run: run}
+	return nil
+}
+
+func (r *Registry) lookup(name string) (*job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	j, ok := r.jobs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJob, name)
+	}
+	return j, nil
+}
+
+// Run runs the named job now and waits for it. It returns ErrAlreadyRunning
+// without running when another run of the job is in progress. The run's own
+// error is recorded and returned.
+func (r *Registry) Run(ctx context.Context, name, trigger string) error {
+	j, err := r.lookup(name)
+	if err != nil {
+		return err
+	}
+	if !j.lock.TryLock() {
+		return ErrAlreadyRunning
+	}
+	defer j.lock.Unlock()
+	return r.execute(ctx, j, trigger)
+}
+
+// Start runs the named job in the background, detached from ctx's
+// cancellation. It returns once the run holds the job's lock, or
+// ErrAlreadyRunning when another run is in progress.
+func (r *Registry) Start(ctx context.Context, name, trigger string) error {
+	j, err := r.lookup(name)
+	if err != nil {
+		return err
+	}
+	if !j.lock.TryLock() {
+		return ErrAlreadyRunning
+	}
+	go func() {
+		defer j.lock.Unlock()
+		_ = r.execute(context.WithoutCancel(ctx), j, trigger)
+	}()
+	return nil
+}
+
+func (r *Registry) execute(ctx context.Context, j *job, trigger string) error {
+	j.mu.Lock()
+	j.running = true
+	j.mu.Unlock()
+	defer func() {
+		j.mu.Lock()
+		j.running = false
+		j.mu.Unlock()
+	}()
+
+	logger := log.Ctx(ctx).With().Str("job_name", j.name).Str("trigger", trigger).Logger()
+	runCtx := ctx
+	if j.timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, j.timeout)
+		defer cancel()
+	}
+
+	startedAt := time.Now()
+	items, runErr := j.run(runCtx)
+	finishedAt := time.Now()
+
+	params := dbgen.RecordJobRunParams{
+		Name:           j.name,
+		LastTrigger:    trigger,
+		LastStartedAt:  startedAt.UTC(),
+		LastFinishedAt: finishedAt.UTC(),
+		LastDurationMs: finishedAt.Sub(startedAt).Milliseconds(),
+		LastItems:      items,
+	}
+	if runErr != nil {
+		params.LastError = sql.NullString{String: runErr.Error(), Valid: true}
+		params.ConsecutiveFailures = 1
+	} else {
+		params.LastSuccessAt = sql.NullTime{Time: finishedAt.UTC(), Valid: true}
+	}
+	// Record even when the run timed out, so the dashboard shows why.
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	status, err := r.store.RecordJobRun(recordCtx, params)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to record job run")
+	}
+	if runErr != nil {
+		logger.Error().Err(runErr).Int64("consecutive_failures", status.ConsecutiveFailures).Msg("Job run failed")
+	}
+	return runErr
+}
+
+// List returns every registered job with its latest persisted outcome,
+// sorted by name.
+func (r *Registry) List(ctx context.Context) ([]Status, error) {
+	rows, err := r.store.ListJobStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	persisted := make(map[string]dbgen.JobStatus, len(rows))
+	for _, row := range rows {
+		persisted[row.Name] = row
+	}
+
+	r.mu.RLock()
+	statuses := make([]Status, 0, len(r.jobs))
+	for name, j := range r.jobs {
+		status := Status{Name: name}
+		if row, ok := persisted[name]; ok {
+			status = newStatus(row)
+		}
+		status.Schedule = j.schedule
+		j.mu.Lock()
+		status.Running = j.running
+		j.mu.Unlock()
+		statuses = append(statuses, status)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(statuses, func(i, k int) bool { return statuses[i].Name < statuses[k].Name })
+	return statuses, nil
+}
+
+// Failing returns the registered jobs that have failed FailingThreshold
+// runs in a row.
+func (r *Registry) Failing(ctx context.Context) ([]Status, error) {
+	statuses, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var failing []Status
+	for _, status := range statuses {
+		if status.Failing() {
+			failing = append(failing, status)
+		}
+	}
+	return failing, nil
+}
+
+func newStatus(row dbgen.JobStatus) Status {
+	status := Status{
+		Name:                row.Name,
+		LastTrigger:         row.LastTrigger,
+		LastDurationMs:      row.LastDurationMs,
+		LastItems:           row.LastItems,
+		LastError:           row.LastError.String,
+		ConsecutiveFailures: row.ConsecutiveFailures,
+	}
+	if !row.LastStartedAt.IsZero() {
+		startedAt, finishedAt := row.LastStartedAt, row.LastFinishedAt
+		status.LastStartedAt = &startedAt
+		status.LastFinishedAt = &finishedAt
+	}
+	if row.LastSuccessAt.Valid {
+		successAt := row.LastSuccessAt.Time
+		status.LastSuccessAt = &successAt
+	}
+	return status
+}
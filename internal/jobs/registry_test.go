@@ -0,0 +1,100 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestRegistry_RecordsRuns(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	registry := NewRegistry(database.Queries)
+
+	fail := true
+	if err := registry.Register("sweep", "*/5 * * * *", 0, func(context.Context) (int64, error) {
+		if fail {
+			return 2, errors.New("partial sweep")
+		}
+		return 7, nil
+	}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := registry.Register("sweep", "* * * * *", 0, func(context.Context) (int64, error) { return 0, nil }); !errors.Is(err, ErrDuplicateJob) {
+		t.Fatalf("expected a duplicate name to be refused, got %v", err)
+	}
+	if err := registry.Run(ctx, "missing", TriggerManual); !errors.Is(err, ErrUnknownJob) {
+		t.Fatalf("expected ErrUnknownJob, got %v", err)
+	}
+
+	statuses, err := registry.List(ctx)
+	if err != nil || len(statuses) != 1 || statuses[0].LastStartedAt != nil || statuses[0].Schedule != "*/5 * * * *" {
+		t.Fatalf("expected one job that has not run, got %+v (err %v)", statuses, err)
+	}
+
+	for range 2 {
+		if err := registry.Run(ctx, "sweep", TriggerSchedule); err == nil {
+			t.Fatal("expected the run to fail")
+		}
+	}
+	statuses, err = registry.List(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	status := statuses[0]
+	if status.ConsecutiveFailures != 2 || status.LastError != "partial sweep" || status.LastItems != 2 || status.LastSuccessAt != nil || status.LastFinishedAt == nil {
+		t.Fatalf("unexpected status after failures %+v", status)
+	}
+
+	fail = false
+	if err := registry.Run(ctx, "sweep", TriggerManual); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	// A fresh registry reads the persisted outcome, as after a restart.
+	restarted := NewRegistry(database.Queries)
+	if err := restarted.Register("sweep", "*/5 * * * *", 0, func(context.Context) (int64, error) { return 0, nil }); err != nil {
+		t.Fatalf("register after restart: %v", err)
+	}
+	statuses, err = restarted.List(ctx)
+	if err != nil {
+		t.Fatalf("list after restart: %v", err)
+	}
+	status = statuses[0]
+	if status.ConsecutiveFailures != 0 || status.LastError != "" || status.LastItems != 7 || status.LastTrigger != TriggerManual || status.LastSuccessAt == nil {
+		t.Fatalf("expected a success to reset the failure count, got %+v", status)
+	}
+}
+
+func TestRegistry_RunsAreMutuallyExclusive(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	registry := NewRegistry(database.Queries)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	if err := registry.Register("slow", "0 * * * *", 0, func(context.Context) (int64, error) {
+		close(started)
+		<-release
+		return 1, nil
+	}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	if err := registry.Start(ctx, "slow", TriggerManual); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	<-started
+	if err := registry.Run(ctx, "slow", TriggerSchedule); !errors.Is(err, ErrAlreadyRunning) {
+		t.Fatalf("expected a scheduled run to be refused while a manual run is going, got %v", err)
+	}
+	if err := registry.Start(ctx, "slow", TriggerManual); !errors.Is(err, ErrAlreadyRunning) {
+		t.Fatalf("expected a second manual run to be refused, got %v", err)
+	}
+	statuses, err := registry.List(ctx)
+	if err != nil || !statuses[0].Running {
+		t.Fatalf("expected the job to show as running, got %+v (err %v)", statuses, err)
+	}
+	close(release)
+}
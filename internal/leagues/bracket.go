@@ -0,0 +1,227 @@
+package leagues
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Tournament formats.
+const (
+	FormatSingleElimination = "single_elimination"
+	FormatDoubleElimination = "double_elimination"
+)
+
+// Brackets a tournament match can belong to. Double elimination sends first
+// losses to the consolation bracket, and its winner meets the winners
+// bracket champion in the final.
+const (
+	BracketWinners     = "winners"
+	BracketConsolation = "consolation"
+	BracketFinal       = "final"
+)
+
+// Match slots, "a" for the upper team in the bracket and "b" for the lower.
+const (
+	SlotA = "a"
+	SlotB = "b"
+)
+
+// BracketLink sends a team on to a slot of a later match, given by its index
+// in the plan.
+type BracketLink struct {
+	Match int
+	Slot  string
+}
+
+// BracketMatch is one match of a planned bracket. First-round winners bracket
+// matches carry the seeds that play in them, zero for a seed the field does
+// not have; every other slot is filled through the links of earlier matches.
+// Bye is set when a slot can never be filled, so the match is decided
+// without being played.
+type BracketMatch struct {
+	Bracket  string
+	Round    int
+	Position int
+	SeedA    int
+	SeedB    int
+	WinnerTo *BracketLink
+	LoserTo  *BracketLink
+	Bye      bool
+}
+
+type bracketSource struct {
+	match int
+	loser bool
+}
+
+type bracketPlan struct {
+	matches []BracketMatch
+	sources map[BracketLink]bracketSource
+}
+
+// PlanBracket lays out every match of an elimination bracket for the given
+// number of seeded entrants. Seeds are placed so the top seeds meet as late
+// as possible, and the seeds missing from a field that is not a power of two
+// become first-round byes for the top seeds. Every match comes after the
+// matches that feed it.
+func PlanBracket(format string, entrants int) ([]BracketMatch, error) {
+	if format != FormatSingleElimination && format != FormatDoubleElimination {
+		return nil, fmt.Errorf("unsupported tournament format %q", format)
+	}
+	if entrants < 2 {
+		return nil, errors.New("at least two teams are required")
+	}
+
+	size := 1
+	rounds := 0
+	for size < entrants {
+		size *= 2
+		rounds++
+	}
+
+	plan := &bracketPlan{sources: make(map[BracketLink]bracketSource)}
+	winners := make([][]int, rounds+1)
+	order := seedOrder(size)
+	for i := 0; i < size/2; i++ {
+		seedA, seedB := order[2*i], order[2*i+1]
+		if seedB > entrants {
+			seedB = 0
+		}
+		winners[1] = append(winners[1], plan.add(BracketMatch{
+			Bracket:  BracketWinners,
+			Round:    1,
+			Position: i + 1,
+			SeedA:    seedA,
+			SeedB:    seedB,
+		}))
+	}
+	for round := 2; round <= rounds; round++ {
+		previous := winners[round-1]
+		for i := 0; i < len(previous)/2; i++ {
+			idx := plan.add(BracketMatch{Bracket: BracketWinners, Round: round, Position: i + 1})
+			plan.link(previous[2*i], false, idx, SlotA)
+			plan.link(previous[2*i+1], false, idx, SlotB)
+			winners[round] = append(winners[round], idx)
+		}
+	}
+
+	if format == FormatDoubleElimination {
+		plan.addConsolation(winners, rounds)
+	}
+
+	plan.markByes()
+	return plan.matches, nil
+}
+
+// addConsolation adds the consolation bracket and the final. First-round
+// losers play each other, then each later round alternates between taking
+// in the losers of the next winners round and halving the field. Dropped-in
+// losers are paired in reverse order to put off rematches.
+func (p *bracketPlan) addConsolation(winners [][]int, rounds int) {
+	final := BracketMatch{Bracket: BracketFinal, Round: 1, Position: 1}
+	if rounds == 1 {
+		// With two teams the first loss sends the loser straight to the final.
+		idx := p.add(final)
+		p.link(winners[1][0], false, idx, SlotA)
+		p.link(winners[1][0], true, idx, SlotB)
+		return
+	}
+
+	round := 1
+	var current []int
+	first := winners[1]
+	for i := 0; i < len(first)/2; i++ {
+		idx := p.add(BracketMatch{Bracket: BracketConsolation, Round: round, Position: i + 1})
+		p.link(first[2*i], true, idx, SlotA)
+		p.link(first[2*i+1], true, idx, SlotB)
+		current = append(current, idx)
+	}
+
+	for winnersRound := 2; winnersRound <= rounds; winnersRound++ {
+		round++
+		dropping := winners[winnersRound]
+		next := make([]int, 0, len(current))
+		for i, source := range current {
+			idx := p.add(BracketMatch{Bracket: BracketConsolation, Round: round, Position: i + 1})
+			p.link(source, false, idx, SlotA)
+			p.link(dropping[len(dropping)-1-i], true, idx, SlotB)
+			next = append(next, idx)
+		}
+		current = next
+
+		if len(current) > 1 {
+			round++
+			next = make([]int, 0, len(current)/2)
+			for i := 0; i < len(current)/2; i++ {
+				idx := p.add(BracketMatch{Bracket: BracketConsolation, Round: round, Position: i + 1})
+				p.link(current[2*i], false, idx, SlotA)
+				p.link(current[2*i+1], false, idx, SlotB)
+				next = append(next, idx)
+			}
+			current = next
+		}
+	}
+
+	idx := p.add(final)
+	p.link(winners[rounds][0], false, idx, SlotA)
+	p.link(current[0], false, idx, SlotB)
+}
+
+func (p *bracketPlan) add(match BracketMatch) int {
+	p.matches = append(p.matches, match)
+	return len(p.matches) - 1
+}
+
+func (p *bracketPlan) link(from int, loser bool, to int, slot string) {
+	target := &BracketLink{Match: to, Slot: slot}
+	if loser {
+		p.matches[from].LoserTo = target
+	} else {
+		p.matches[from].WinnerTo = target
+	}
+	p.sources[*target] = bracketSource{match: from, loser: loser}
+}
+
+// markByes works forward through the plan: a slot stays empty when its seed
+// is missing or the match feeding it produces no team, and a match with an
+// empty slot is a bye. A bye still has a winner when its other slot fills,
+// but never a loser.
+func (p *bracketPlan) markByes() {
+	hasWinner := make([]bool, len(p.matches))
+	hasLoser := make([]bool, len(p.matches))
+	for idx := range p.matches {
+		match := &p.matches[idx]
+		emptyA := p.slotEmpty(idx, SlotA, match.SeedA, hasWinner, hasLoser)
+		emptyB := p.slotEmpty(idx, SlotB, match.SeedB, hasWinner, hasLoser)
+		match.Bye = emptyA || emptyB
+		hasWinner[idx] = !emptyA || !emptyB
+		hasLoser[idx] = !emptyA && !emptyB
+	}
+}
+
+func (p *bracketPlan) slotEmpty(idx int, slot string, seed int, hasWinner, hasLoser []bool) bool {
+	source, ok := p.sources[BracketLink{Match: idx, Slot: slot}]
+	if !ok {
+		return seed == 0
+	}
+	if source.loser {
+		return !hasLoser[source.match]
+	}
+	return !hasWinner[source.match]
+}
+
+// seedOrder lists seeds 1..size in first-round bracket order, so adjacent
+// pairs are first-round matches and seeds 1 and 2 can only meet in the last
+// round.
+func seedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		n := len(order) * 2
+		next := make([]int, 0, n)
+		for _, seed := range order {
+			next = append(next, seed, n+1-seed)
+		}
+		order = next
+	}
+	return order
+}
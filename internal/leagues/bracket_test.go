@@ -0,0 +1,95 @@
+package leagues
+
+import (
+	"fmt"
+	"testing"
+)
+
+func describeBracket(plan []BracketMatch) []string {
+	described := make([]string, 0, len(plan))
+	for _, match := range plan {
+		entry := fmt.Sprintf("%s%d.%d", match.Bracket[:1], match.Round, match.Position)
+		if match.Round == 1 && match.Bracket == BracketWinners {
+			entry += fmt.Sprintf(" %dv%d", match.SeedA, match.SeedB)
+		}
+		if match.Bye {
+			entry += " bye"
+		}
+		described = append(described, entry)
+	}
+	return described
+}
+
+func TestPlanBracket_SingleEliminationByesForTopSeeds(t *testing.T) {
+	plan, err := PlanBracket(FormatSingleElimination, 6)
+	if err != nil {
+		t.Fatalf("PlanBracket: %v", err)
+	}
+
+	got := fmt.Sprint(describeBracket(plan))
+	want := "[w1.1 1v0 bye w1.2 4v5 w1.3 2v0 bye w1.4 3v6 w2.1 w2.2 w3.1]"
+	if got != want {
+		t.Fatalf("plan = %s, want %s", got, want)
+	}
+	if link := plan[0].WinnerTo; link == nil || link.Match != 4 || link.Slot != SlotA {
+		t.Fatalf("seed 1 should advance to slot a of w2.1, got %+v", link)
+	}
+	if link := plan[3].WinnerTo; link == nil || link.Match != 5 || link.Slot != SlotB {
+		t.Fatalf("3v6 winner should advance to slot b of w2.2, got %+v", link)
+	}
+	if plan[6].WinnerTo != nil || plan[0].LoserTo != nil {
+		t.Fatalf("single elimination has no loser links and the final goes nowhere")
+	}
+
+	if _, err := PlanBracket(FormatSingleElimination, 1); err == nil {
+		t.Fatalf("expected an error for one entrant")
+	}
+	if _, err := PlanBracket("round_robin", 4); err == nil {
+		t.Fatalf("expected an error for an unsupported format")
+	}
+}
+
+func TestPlanBracket_DoubleEliminationConsolation(t *testing.T) {
+	plan, err := PlanBracket(FormatDoubleElimination, 4)
+	if err != nil {
+		t.Fatalf("PlanBracket: %v", err)
+	}
+	got := fmt.Sprint(describeBracket(plan))
+	want := "[w1.1 1v4 w1.2 2v3 w2.1 c1.1 c2.1 f1.1]"
+	if got != want {
+		t.Fatalf("plan = %s, want %s", got, want)
+	}
+	if plan[0].LoserTo == nil || plan[0].LoserTo.Match != 3 || plan[1].LoserTo.Match != 3 {
+		t.Fatalf("first-round losers should meet in c1.1")
+	}
+	if link := plan[2].LoserTo; link == nil || link.Match != 4 || link.Slot != SlotB {
+		t.Fatalf("winners final loser should drop to slot b of c2.1, got %+v", link)
+	}
+	if plan[2].WinnerTo.Match != 5 || plan[4].WinnerTo.Match != 5 || plan[4].WinnerTo.Slot != SlotB {
+		t.Fatalf("both bracket winners should meet in the final")
+	}
+
+	// With three teams the top seed's bye leaves the first consolation match
+	// without a second team.
+	plan, err = PlanBracket(FormatDoubleElimination, 3)
+	if err != nil {
+		t.Fatalf("PlanBracket: %v", err)
+	}
+	got = fmt.Sprint(describeBracket(plan))
+	want = "[w1.1 1v0 bye w1.2 2v3 w2.1 c1.1 bye c2.1 f1.1]"
+	if got != want {
+		t.Fatalf("plan = %s, want %s", got, want)
+	}
+
+	plan, err = PlanBracket(FormatDoubleElimination, 8)
+	if err != nil {
+		t.Fatalf("PlanBracket: %v", err)
+	}
+	if len(plan) != 14 {
+		t.Fatalf("eight teams should need 14 matches, got %d", len(plan))
+	}
+	// Winners round 2 losers drop into consolation round 2 in reverse order.
+	if plan[4].LoserTo.Match != 10 || plan[5].LoserTo.Match != 9 {
+		t.Fatalf("dropped losers should be crossed, got %+v and %+v", plan[4].LoserTo, plan[5].LoserTo)
+	}
+}
@@ -0,0 +1,247 @@
+package leagues
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// DefaultDivisionName names the only division of a league whose
+// division_config lists none, or is free text from before divisions were
+// structured.
+const DefaultDivisionName = "Open"
+
+// Division is one entry of a league's division_config. MaxTeams of zero
+// leaves the division uncapped.
+type Division struct {
+	DivisionRatingRange
+	MaxTeams int `json:"maxTeams,omitempty"`
+}
+
+// DivisionConfig is the parsed form of leagues.division_config:
+//
+//	{"divisions":[{"name":"3.0","minRating":2.5,"maxRating":3.25,"maxTeams":8}]}
+//
+// A division may also be given by name alone, as in {"divisions":["Open"]}.
+type DivisionConfig struct {
+	Divisions []Division `json:"divisions"`
+}
+
+var errDivisionConfigShape = errors.New(`division_config must be a JSON object such as {"divisions":[{"name":"Open"}]}`)
+
+// ParseDivisionConfig decodes and validates a division_config. A config that
+// lists no divisions is a single open division named DefaultDivisionName.
+func ParseDivisionConfig(raw string) (DivisionConfig, error) {
+	var envelope struct {
+		Divisions []json.RawMessage `json:"divisions"`
+	}
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&envelope); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field == "divisions" {
+			return DivisionConfig{}, errors.New("division_config divisions must be a list")
+		}
+		if strings.HasPrefix(err.Error(), "json: unknown field") {
+			return DivisionConfig{}, fmt.Errorf("division_config has an %s", strings.TrimPrefix(err.Error(), "json: "))
+		}
+		return DivisionConfig{}, errDivisionConfigShape
+	}
+	if decoder.More() {
+		return DivisionConfig{}, errDivisionConfigShape
+	}
+
+	config := DivisionConfig{Divisions: make([]Division, 0, len(envelope.Divisions))}
+	seen := make(map[string]struct{}, len(envelope.Divisions))
+	for i, entry := range envelope.Divisions {
+		division, err := decodeDivision(entry)
+		if err != nil {
+			return DivisionConfig{}, fmt.Errorf("division %d: %w", i+1, err)
+		}
+		key := strings.ToLower(division.Name)
+		if _, ok := seen[key]; ok {
+			return DivisionConfig{}, fmt.Errorf("division %q is listed more than once", division.Name)
+		}
+		seen[key] = struct{}{}
+		config.Divisions = append(config.Divisions, division)
+	}
+	if len(config.Divisions) == 0 {
+		config.Divisions = []Division{{DivisionRatingRange: DivisionRatingRange{Name: DefaultDivisionName}}}
+	}
+	return config, nil
+}
+
+func decodeDivision(entry json.RawMessage) (Division, error) {
+	var division Division
+	var name string
+	if err := json.Unmarshal(entry, &name); err == nil {
+		division.Name = name
+	} else {
+		decoder := json.NewDecoder(bytes.NewReader(entry))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&division); err != nil {
+			if strings.HasPrefix(err.Error(), "json: unknown field") {
+				return Division{}, errors.New(strings.TrimPrefix(err.Error(), "json: "))
+			}
+			return Division{}, errors.New("must be a name or an object with name, minRating, maxRating, and maxTeams")
+		}
+	}
+
+	division.Name = strings.TrimSpace(division.Name)
+	if division.Name == "" {
+		return Division{}, errors.New("name is required")
+	}
+	if division.MinRating != nil && (*division.MinRating < apiutil.MinSkillRating || *division.MinRating > apiutil.MaxSkillRating) {
+		return Division{}, fmt.Errorf("%s minRating must be between %.1f and %.1f", division.Name, apiutil.MinSkillRating, apiutil.MaxSkillRating)
+	}
+	if division.MaxRating != nil && (*division.MaxRating < apiutil.MinSkillRating || *division.MaxRating > apiutil.MaxSkillRating) {
+		return Division{}, fmt.Errorf("%s maxRating must be between %.1f and %.1f", division.Name, apiutil.MinSkillRating, apiutil.MaxSkillRating)
+	}
+	if division.MinRating != nil && division.MaxRating != nil && *division.MaxRating < *division.MinRating {
+		return Division{}, fmt.Errorf("%s maxRating must be at least its minRating", division.Name)
+	}
+	if division.MaxTeams < 0 {
+		return Division{}, fmt.Errorf("%s maxTeams must be zero or greater", division.Name)
+	}
+	return division, nil
+}
+
+// LeagueDivisions returns the divisions of a stored division_config. Configs
+// that do not parse are legacy free text and count as one default division.
+func LeagueDivisions(raw string) DivisionConfig {
+	config, err := ParseDivisionConfig(raw)
+	if err != nil {
+		return DivisionConfig{Divisions: []Division{{DivisionRatingRange: DivisionRatingRange{Name: DefaultDivisionName}}}}
+	}
+	return config
+}
+
+// Lookup finds a division by name, ignoring case.
+func (c DivisionConfig) Lookup(name string) (Division, bool) {
+	name = strings.TrimSpace(name)
+	for _, division := range c.Divisions {
+		if strings.EqualFold(division.Name, name) {
+			return division, true
+		}
+	}
+	return Division{}, false
+}
+
+// TeamDivision resolves the division a team plays in from its stored
+// division. In a single-division league every team plays in that division;
+// otherwise a team whose stored division is missing or no longer configured
+// is unassigned and ok is false.
+func (c DivisionConfig) TeamDivision(stored sql.NullString) (string, bool) {
+	if len(c.Divisions) == 1 {
+		return c.Divisions[0].Name, true
+	}
+	if !stored.Valid {
+		return "", false
+	}
+	division, ok := c.Lookup(stored.String)
+	return division.Name, ok
+}
+
+// DivisionEntrants is one division's teams for a schedule. Round is the
+// bracket round to pair and is ignored by round robins.
+type DivisionEntrants struct {
+	Division string
+	Round    int
+	Teams    []dbgen.LeagueTeam
+}
+
+// GroupTeams splits teams by division in config order, leaving out divisions
+// without teams. Teams that have no division are returned separately.
+func (c DivisionConfig) GroupTeams(teams []dbgen.LeagueTeam) ([]DivisionEntrants, []dbgen.LeagueTeam) {
+	byDivision := make(map[string][]dbgen.LeagueTeam, len(c.Divisions))
+	var unassigned []dbgen.LeagueTeam
+	for _, team := range teams {
+		name, ok := c.TeamDivision(team.Division)
+		if !ok {
+			unassigned = append(unassigned, team)
+			continue
+		}
+		byDivision[name] = append(byDivision[name], team)
+	}
+
+	groups := make([]DivisionEntrants, 0, len(byDivision))
+	for _, division := range c.Divisions {
+		if members := byDivision[division.Name]; len(members) > 0 {
+			groups = append(groups, DivisionEntrants{Division: division.Name, Teams: members})
+		}
+	}
+	return groups, unassigned
+}
+
+// TeamRating is a team's roster rating as division assignment sees it.
+type TeamRating struct {
+	TeamID   int64
+	TeamName string
+	Rating   RosterRating
+}
+
+// DivisionPlacement is where AssignDivisions put a team. Division is empty
+// when the team fits nowhere, and Reason says why.
+type DivisionPlacement struct {
+	TeamID   int64
+	Division string
+	Reason   string
+}
+
+// AssignDivisions places teams, highest average first, into the first
+// division in config order whose rating range fits the roster and which still
+// has room. Ranking by average means uncapped, unrated divisions fill from
+// the strongest teams down.
+func AssignDivisions(config DivisionConfig, teams []TeamRating) []DivisionPlacement {
+	ordered := append([]TeamRating(nil), teams...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		left, right := ordered[i].Rating.Average, ordered[j].Rating.Average
+		if (left == nil) != (right == nil) {
+			return left != nil
+		}
+		if left != nil && *left != *right {
+			return *left > *right
+		}
+		return ordered[i].TeamName < ordered[j].TeamName
+	})
+
+	counts := make(map[string]int, len(config.Divisions))
+	placements := make([]DivisionPlacement, 0, len(ordered))
+	for _, team := range ordered {
+		placement := DivisionPlacement{TeamID: team.TeamID}
+		full := false
+		for _, division := range config.Divisions {
+			if err := CheckRosterRating(division.DivisionRatingRange, team.Rating); err != nil {
+				if placement.Reason == "" {
+					placement.Reason = err.Error()
+				}
+				continue
+			}
+			if division.MaxTeams > 0 && counts[division.Name] >= division.MaxTeams {
+				full = true
+				continue
+			}
+			placement.Division = division.Name
+			placement.Reason = ""
+			counts[division.Name]++
+			break
+		}
+		if placement.Division == "" {
+			switch {
+			case full:
+				placement.Reason = "every division the team fits is full"
+			case len(config.Divisions) > 1 && team.Rating.Unrated == 0 && team.Rating.Average != nil:
+				placement.Reason = fmt.Sprintf("team average %.2f fits no division", *team.Rating.Average)
+			}
+		}
+		placements = append(placements, placement)
+	}
+	return placements
+}
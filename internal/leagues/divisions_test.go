@@ -0,0 +1,110 @@
+package leagues
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestParseDivisionConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		wantNames []string
+		wantErr   string
+	}{
+		{name: "structured", raw: `{"divisions":[{"name":"3.0","minRating":2.5,"maxRating":3.25,"maxTeams":6},{"name":"Open"}]}`, wantNames: []string{"3.0", "Open"}},
+		{name: "names only", raw: `{"divisions":["A"," B "]}`, wantNames: []string{"A", "B"}},
+		{name: "no divisions", raw: `{}`, wantNames: []string{DefaultDivisionName}},
+		{name: "free text", raw: `A and B flights`, wantErr: "JSON object"},
+		{name: "unknown config field", raw: `{"divsions":[]}`, wantErr: `unknown field "divsions"`},
+		{name: "divisions not a list", raw: `{"divisions":"A"}`, wantErr: "must be a list"},
+		{name: "unknown division field", raw: `{"divisions":[{"name":"A","maxTeam":4}]}`, wantErr: `division 1: unknown field "maxTeam"`},
+		{name: "missing name", raw: `{"divisions":[{"minRating":3}]}`, wantErr: "division 1: name is required"},
+		{name: "duplicate name", raw: `{"divisions":["A","a"]}`, wantErr: `"a" is listed more than once`},
+		{name: "rating out of range", raw: `{"divisions":[{"name":"A","minRating":0.5}]}`, wantErr: "A minRating must be between 1.0 and 8.0"},
+		{name: "inverted range", raw: `{"divisions":[{"name":"A","minRating":4,"maxRating":3}]}`, wantErr: "A maxRating must be at least its minRating"},
+		{name: "negative cap", raw: `{"divisions":[{"name":"A","maxTeams":-1}]}`, wantErr: "A maxTeams must be zero or greater"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseDivisionConfig(tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, want it to mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(config.Divisions) != len(tt.wantNames) {
+				t.Fatalf("divisions %+v, want %v", config.Divisions, tt.wantNames)
+			}
+			for i, name := range tt.wantNames {
+				if config.Divisions[i].Name != name {
+					t.Fatalf("division %d named %q, want %q", i, config.Divisions[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestLeagueDivisions_LegacyFreeTextIsOneDivision(t *testing.T) {
+	config := LeagueDivisions("Beginners and intermediates")
+	if len(config.Divisions) != 1 || config.Divisions[0].Name != DefaultDivisionName {
+		t.Fatalf("unexpected divisions %+v", config.Divisions)
+	}
+	if name, ok := config.TeamDivision(sql.NullString{}); !ok || name != DefaultDivisionName {
+		t.Fatalf("unassigned team resolved to %q, %v", name, ok)
+	}
+
+	groups, unassigned := config.GroupTeams([]dbgen.LeagueTeam{{ID: 1}, {ID: 2, Division: sql.NullString{String: "Old", Valid: true}}})
+	if len(unassigned) != 0 || len(groups) != 1 || len(groups[0].Teams) != 2 {
+		t.Fatalf("groups %+v, unassigned %+v", groups, unassigned)
+	}
+}
+
+func TestAssignDivisions(t *testing.T) {
+	config, err := ParseDivisionConfig(`{"divisions":[
+		{"name":"Advanced","minRating":4.0,"maxTeams":1},
+		{"name":"Intermediate","minRating":3.0,"maxRating":4.5},
+		{"name":"Beginner","maxRating":3.0}
+	]}`)
+	if err != nil {
+		t.Fatalf("ParseDivisionConfig: %v", err)
+	}
+	rated := func(teamID int64, name string, average float64) TeamRating {
+		return TeamRating{TeamID: teamID, TeamName: name, Rating: RosterRating{Average: &average, Rated: 2}}
+	}
+	teams := []TeamRating{
+		rated(1, "Aces", 4.4),
+		rated(2, "Bandits", 4.2),
+		rated(3, "Comets", 3.5),
+		rated(4, "Dinks", 2.5),
+		rated(5, "Eagles", 5.0),
+		{TeamID: 6, TeamName: "Falcons", Rating: RosterRating{Unrated: 2, UnratedUserIDs: []int64{7, 8}}},
+		rated(7, "Giants", 4.8),
+	}
+
+	got := make(map[int64]DivisionPlacement)
+	for _, placement := range AssignDivisions(config, teams) {
+		got[placement.TeamID] = placement
+	}
+
+	// Eagles claim the one Advanced spot, so Aces drop to Intermediate.
+	want := map[int64]string{1: "Intermediate", 2: "Intermediate", 3: "Intermediate", 4: "Beginner", 5: "Advanced"}
+	for teamID, division := range want {
+		if got[teamID].Division != division {
+			t.Errorf("team %d placed in %q, want %q", teamID, got[teamID].Division, division)
+		}
+	}
+	if got[7].Division != "" || got[7].Reason != "every division the team fits is full" {
+		t.Errorf("team 7 placement %+v, want unassigned because Advanced is full", got[7])
+	}
+	if got[6].Division != "" || !strings.Contains(got[6].Reason, "no rating") {
+		t.Errorf("team 6 placement %+v, want unassigned for unrated players", got[6])
+	}
+}
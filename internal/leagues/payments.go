@@ -0,0 +1,173 @@
+package leagues
+
+import (
+	"context"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// Fee bases: a league's registration fee is owed once per team or by each
+// rostered player.
+const (
+	FeeBasisTeam   = "team"
+	FeeBasisPlayer = "player"
+)
+
+// Payment statuses for a team or player. NotRequired means nothing is owed,
+// either because the league is free or the team has no players to bill.
+const (
+	PaymentStatusPaid        = "paid"
+	PaymentStatusPartial     = "partial"
+	PaymentStatusUnpaid      = "unpaid"
+	PaymentStatusNotRequired = "not_required"
+)
+
+// PaymentMethods are the ways staff can record a payment being received.
+var PaymentMethods = []string{"cash", "card", "check", "other"}
+
+// PlayerPayment is one rostered player's share of a per-player fee.
+type PlayerPayment struct {
+	UserID           int64  `json:"userId"`
+	DueCents         int64  `json:"dueCents"`
+	PaidCents        int64  `json:"paidCents"`
+	OutstandingCents int64  `json:"outstandingCents"`
+	Status           string `json:"status"`
+}
+
+// TeamPayment is where a team stands against its league's registration fee.
+// Players is set for per-player fees only.
+type TeamPayment struct {
+	FeeBasis         string          `json:"feeBasis"`
+	FeeCents         int64           `json:"feeCents"`
+	DueCents         int64           `json:"dueCents"`
+	PaidCents        int64           `json:"paidCents"`
+	OutstandingCents int64           `json:"outstandingCents"`
+	Status           string          `json:"status"`
+	Players          []PlayerPayment `json:"players,omitempty"`
+}
+
+// Unpaid reports whether the team still owes part of its fee.
+func (p TeamPayment) Unpaid() bool {
+	return p.OutstandingCents > 0
+}
+
+// SummarizeTeamPayment totals payments against the fee. For per-player fees
+// each of memberIDs owes feeCents and only payments naming them count;
+// overpaying for one player does not cover another. Per-team fees count
+// every payment.
+func SummarizeTeamPayment(feeCents int64, feeBasis string, memberIDs []int64, payments []dbgen.LeaguePayment) TeamPayment {
+	summary := TeamPayment{FeeBasis: feeBasis, FeeCents: feeCents}
+
+	if feeBasis != FeeBasisPlayer {
+		summary.FeeBasis = FeeBasisTeam
+		summary.DueCents = feeCents
+		for _, payment := range payments {
+			summary.PaidCents += payment.AmountCents
+		}
+		summary.OutstandingCents = outstanding(summary.DueCents, summary.PaidCents)
+		summary.Status = paymentStatus(summary.DueCents, summary.PaidCents)
+		return summary
+	}
+
+	paidByPlayer := make(map[int64]int64, len(memberIDs))
+	for _, payment := range payments {
+		if payment.UserID.Valid {
+			paidByPlayer[payment.UserID.Int64] += payment.AmountCents
+		}
+	}
+	summary.Players = make([]PlayerPayment, 0, len(memberIDs))
+	for _, userID := range memberIDs {
+		paid := paidByPlayer[userID]
+		player := PlayerPayment{
+			UserID:           userID,
+			DueCents:         feeCents,
+			PaidCents:        paid,
+			OutstandingCents: outstanding(feeCents, paid),
+			Status:           paymentStatus(feeCents, paid),
+		}
+		summary.DueCents += player.DueCents
+		summary.PaidCents += player.PaidCents
+		summary.OutstandingCents += player.OutstandingCents
+		summary.Players = append(summary.Players, player)
+	}
+	switch {
+	case summary.DueCents == 0:
+		summary.Status = PaymentStatusNotRequired
+	case summary.OutstandingCents == 0:
+		summary.Status = PaymentStatusPaid
+	case summary.PaidCents > 0:
+		summary.Status = PaymentStatusPartial
+	default:
+		summary.Status = PaymentStatusUnpaid
+	}
+	return summary
+}
+
+// LoadTeamPayment summarizes a team's payments against the league fee.
+func LoadTeamPayment(ctx context.Context, q *dbgen.Queries, feeCents int64, feeBasis string, teamID int64) (TeamPayment, []dbgen.LeaguePayment, error) {
+	payments, err := q.ListLeagueTeamPayments(ctx, teamID)
+	if err != nil {
+		return TeamPayment{}, nil, err
+	}
+
+	var memberIDs []int64
+	if feeBasis == FeeBasisPlayer {
+		members, err := q.ListTeamMembers(ctx, teamID)
+		if err != nil {
+			return TeamPayment{}, nil, err
+		}
+		for _, member := range members {
+			memberIDs = append(memberIDs, member.UserID)
+		}
+	}
+
+	return SummarizeTeamPayment(feeCents, feeBasis, memberIDs, payments), payments, nil
+}
+
+// UnpaidTeams returns the active teams of a league that still owe part of
+// the fee.
+func UnpaidTeams(ctx context.Context, q *dbgen.Queries, league dbgen.League) ([]dbgen.LeagueTeam, error) {
+	if league.RegistrationFeeCents == 0 {
+		return nil, nil
+	}
+
+	teams, err := q.ListLeagueTeams(ctx, league.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var unpaid []dbgen.LeagueTeam
+	for _, team := range teams {
+		if team.Status != "active" {
+			continue
+		}
+		summary, _, err := LoadTeamPayment(ctx, q, league.RegistrationFeeCents, league.FeeBasis, team.ID)
+		if err != nil {
+			return nil, err
+		}
+		if summary.Unpaid() {
+			unpaid = append(unpaid, team)
+		}
+	}
+	return unpaid, nil
+}
+
+func outstanding(due, paid int64) int64 {
+	if paid >= due {
+		return 0
+	}
+	return due - paid
+}
+
+func paymentStatus(due, paid int64) string {
+	switch {
+	case due == 0:
+		return PaymentStatusNotRequired
+	case paid >= due:
+		return PaymentStatusPaid
+	case paid > 0:
+		return PaymentStatusPartial
+	default:
+		return PaymentStatusUnpaid
+	}
+}
@@ -0,0 +1,72 @@
+package leagues
+
+import (
+	"database/sql"
+	"testing"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestSummarizeTeamPayment(t *testing.T) {
+	player := func(userID, amount int64) dbgen.LeaguePayment {
+		return dbgen.LeaguePayment{UserID: sql.NullInt64{Int64: userID, Valid: true}, AmountCents: amount}
+	}
+
+	tests := []struct {
+		name            string
+		feeCents        int64
+		feeBasis        string
+		memberIDs       []int64
+		payments        []dbgen.LeaguePayment
+		wantStatus      string
+		wantOutstanding int64
+	}{
+		{name: "free league", feeCents: 0, feeBasis: FeeBasisTeam, wantStatus: PaymentStatusNotRequired},
+		{name: "team unpaid", feeCents: 5000, feeBasis: FeeBasisTeam, wantStatus: PaymentStatusUnpaid, wantOutstanding: 5000},
+		{
+			name:            "team partial",
+			feeCents:        5000,
+			feeBasis:        FeeBasisTeam,
+			payments:        []dbgen.LeaguePayment{{AmountCents: 2000}},
+			wantStatus:      PaymentStatusPartial,
+			wantOutstanding: 3000,
+		},
+		{
+			name:       "team overpaid",
+			feeCents:   5000,
+			feeBasis:   FeeBasisTeam,
+			payments:   []dbgen.LeaguePayment{{AmountCents: 3000}, {AmountCents: 3000}},
+			wantStatus: PaymentStatusPaid,
+		},
+		{
+			name:            "player overpayment does not cover a teammate",
+			feeCents:        2500,
+			feeBasis:        FeeBasisPlayer,
+			memberIDs:       []int64{1, 2},
+			payments:        []dbgen.LeaguePayment{player(1, 5000)},
+			wantStatus:      PaymentStatusPartial,
+			wantOutstanding: 2500,
+		},
+		{
+			name:       "every player paid",
+			feeCents:   2500,
+			feeBasis:   FeeBasisPlayer,
+			memberIDs:  []int64{1, 2},
+			payments:   []dbgen.LeaguePayment{player(1, 2500), player(2, 2500)},
+			wantStatus: PaymentStatusPaid,
+		},
+		{name: "player fee with empty roster", feeCents: 2500, feeBasis: FeeBasisPlayer, wantStatus: PaymentStatusNotRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SummarizeTeamPayment(tt.feeCents, tt.feeBasis, tt.memberIDs, tt.payments)
+			if got.Status != tt.wantStatus || got.OutstandingCents != tt.wantOutstanding {
+				t.Fatalf("got status %q outstanding %d, want %q outstanding %d", got.Status, got.OutstandingCents, tt.wantStatus, tt.wantOutstanding)
+			}
+			if got.Unpaid() != (tt.wantOutstanding > 0) {
+				t.Fatalf("Unpaid() = %t with %d outstanding", got.Unpaid(), got.OutstandingCents)
+			}
+		})
+	}
+}
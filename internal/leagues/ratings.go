@@ -0,0 +1,69 @@
+package leagues
+
+import (
+	"fmt"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// DivisionRatingRange is the rating band a league division accepts. A nil
+// bound leaves that side open.
+type DivisionRatingRange struct {
+	Name      string   `json:"name"`
+	MinRating *float64 `json:"minRating,omitempty"`
+	MaxRating *float64 `json:"maxRating,omitempty"`
+}
+
+// RosterRating summarizes a team's effective member ratings. Average covers
+// rated players only and is nil when nobody on the roster has a rating.
+type RosterRating struct {
+	Average        *float64 `json:"average"`
+	Rated          int      `json:"rated"`
+	Unrated        int      `json:"unrated"`
+	UnratedUserIDs []int64  `json:"unratedUserIds,omitempty"`
+}
+
+// SummarizeRosterRating averages the roster's ratings.
+func SummarizeRosterRating(rows []dbgen.ListTeamMemberRatingsRow) RosterRating {
+	var (
+		summary RosterRating
+		total   float64
+	)
+	for _, row := range rows {
+		if !row.Rating.Valid {
+			summary.Unrated++
+			summary.UnratedUserIDs = append(summary.UnratedUserIDs, row.UserID)
+			continue
+		}
+		summary.Rated++
+		total += row.Rating.Float64
+	}
+	if summary.Rated > 0 {
+		average := total / float64(summary.Rated)
+		summary.Average = &average
+	}
+	return summary
+}
+
+// CheckRosterRating reports why a roster cannot play in the division, or nil
+// when its average rating fits. Every player must be rated so the average
+// cannot be skewed by leaving someone out.
+func CheckRosterRating(division DivisionRatingRange, roster RosterRating) error {
+	if division.MinRating == nil && division.MaxRating == nil {
+		return nil
+	}
+	if roster.Unrated > 0 {
+		return fmt.Errorf("%d rostered player(s) have no rating", roster.Unrated)
+	}
+	if roster.Average == nil {
+		return fmt.Errorf("team has no rated players")
+	}
+	average := *roster.Average
+	if division.MinRating != nil && average < *division.MinRating {
+		return fmt.Errorf("team average %.2f is below division %s minimum %.1f", average, division.Name, *division.MinRating)
+	}
+	if division.MaxRating != nil && average > *division.MaxRating {
+		return fmt.Errorf("team average %.2f is above division %s maximum %.1f", average, division.Name, *division.MaxRating)
+	}
+	return nil
+}
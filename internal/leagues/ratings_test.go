@@ -0,0 +1,58 @@
+package leagues
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestCheckRosterRating(t *testing.T) {
+	rated := func(userID int64, rating float64) dbgen.ListTeamMemberRatingsRow {
+		return dbgen.ListTeamMemberRatingsRow{UserID: userID, Rating: sql.NullFloat64{Float64: rating, Valid: true}}
+	}
+	bound := func(v float64) *float64 { return &v }
+	division := DivisionRatingRange{Name: "3.5", MinRating: bound(3.0), MaxRating: bound(3.75)}
+
+	tests := []struct {
+		name     string
+		division DivisionRatingRange
+		roster   []dbgen.ListTeamMemberRatingsRow
+		wantErr  string
+	}{
+		{name: "fits", division: division, roster: []dbgen.ListTeamMemberRatingsRow{rated(1, 3.0), rated(2, 4.0)}},
+		{name: "below minimum", division: division, roster: []dbgen.ListTeamMemberRatingsRow{rated(1, 2.5), rated(2, 3.0)}, wantErr: "below"},
+		{name: "above maximum", division: division, roster: []dbgen.ListTeamMemberRatingsRow{rated(1, 4.0), rated(2, 4.0)}, wantErr: "above"},
+		{name: "unrated player", division: division, roster: []dbgen.ListTeamMemberRatingsRow{rated(1, 3.5), {UserID: 2}}, wantErr: "no rating"},
+		{name: "open division", division: DivisionRatingRange{Name: "Open"}, roster: []dbgen.ListTeamMemberRatingsRow{{UserID: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRosterRating(tt.division, SummarizeRosterRating(tt.roster))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSummarizeRosterRating(t *testing.T) {
+	summary := SummarizeRosterRating([]dbgen.ListTeamMemberRatingsRow{
+		{UserID: 1, Rating: sql.NullFloat64{Float64: 3.0, Valid: true}},
+		{UserID: 2, Rating: sql.NullFloat64{Float64: 4.0, Valid: true}},
+		{UserID: 3},
+	})
+	if summary.Average == nil || *summary.Average != 3.5 {
+		t.Fatalf("average %v, want 3.5", summary.Average)
+	}
+	if summary.Rated != 2 || summary.Unrated != 1 || len(summary.UnratedUserIDs) != 1 || summary.UnratedUserIDs[0] != 3 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+}
@@ -0,0 +1,71 @@
+package leagues
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// ResultResponseWindow is how long the away captain has to confirm or
+// dispute a submitted result before it is confirmed automatically.
+const ResultResponseWindow = 48 * time.Hour
+
+// ErrResultNotPending reports that a result submission was already
+// confirmed, disputed or overridden.
+var ErrResultNotPending = errors.New("match result is no longer pending")
+
+// ValidateMatchResult checks a final score: no ties, a winning score of at
+// least 11 and a margin of at least two.
+func ValidateMatchResult(homeScore, awayScore int64) error {
+	if homeScore == awayScore {
+		return fmt.Errorf("matches cannot end in a tie")
+	}
+	diff := homeScore - awayScore
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff < 2 {
+		return fmt.Errorf("winner must lead by at least two points")
+	}
+	winnerScore := homeScore
+	if awayScore > homeScore {
+		winnerScore = awayScore
+	}
+	if winnerScore < 11 {
+		return fmt.Errorf("winning score must be at least 11 points")
+	}
+	return nil
+}
+
+// ConfirmSubmittedResult accepts a match's pending result submission and
+// completes the match with the submitted scores, which puts it in the
+// standings. respondedBy is left invalid for automatic confirmations. Run it
+// inside a transaction so the submission and match change together.
+func ConfirmSubmittedResult(ctx context.Context, q *dbgen.Queries, leagueID, matchID int64, respondedBy sql.NullInt64) (dbgen.LeagueMatch, error) {
+	submission, err := q.GetLeagueMatchResultSubmission(ctx, matchID)
+	if err != nil {
+		return dbgen.LeagueMatch{}, err
+	}
+	confirmed, err := q.RespondToLeagueMatchResultSubmission(ctx, dbgen.RespondToLeagueMatchResultSubmissionParams{
+		Status:            "confirmed",
+		RespondedByUserID: respondedBy,
+		LeagueMatchID:     matchID,
+	})
+	if err != nil {
+		return dbgen.LeagueMatch{}, err
+	}
+	if confirmed == 0 {
+		return dbgen.LeagueMatch{}, ErrResultNotPending
+	}
+	return q.UpdateMatchResult(ctx, dbgen.UpdateMatchResultParams{
+		HomeScore: sql.NullInt64{Int64: submission.HomeScore, Valid: true},
+		AwayScore: sql.NullInt64{Int64: submission.AwayScore, Valid: true},
+		Status:    "completed",
+		ID:        matchID,
+		LeagueID:  leagueID,
+	})
+}
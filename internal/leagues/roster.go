@@ -0,0 +1,32 @@
+package leagues
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// RosterLockedAt reports whether a roster lock date has passed at now. The
+// lock takes effect at local midnight on the lock date in loc, which should
+// be the facility's timezone; a nil loc falls back to UTC.
+func RosterLockedAt(lockDate sql.NullTime, loc *time.Location, now time.Time) bool {
+	if !lockDate.Valid {
+		return false
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	date := lockDate.Time
+	lockTime := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	return !now.In(loc).Before(lockTime)
+}
+
+// PeoplePerTeam is the number of players a side fields in a league format.
+func PeoplePerTeam(format string) int64 {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "singles":
+		return 1
+	default:
+		return 2
+	}
+}
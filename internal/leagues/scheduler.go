@@ -3,6 +3,7 @@ package leagues
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,41 +32,157 @@ type dayHours struct {
 	Closes time.Time
 }
 
-func GenerateRoundRobinSchedule(leagueID int64, teams []dbgen.LeagueTeam, startDate, endDate time.Time, courts []dbgen.Court, operatingHours []dbgen.OperatingHour, matchDuration time.Duration) ([]ScheduledMatch, error) {
+// SlotFilter reports whether a court is free for a candidate match slot.
+// Returning an error aborts scheduling. A nil filter accepts every slot.
+type SlotFilter func(start, end time.Time, court dbgen.Court) (bool, error)
+
+// SlotWindow bounds the match slots a schedule may use. Slots starting before
+// NotBefore are skipped, which lets later bracket rounds follow earlier ones.
+type SlotWindow struct {
+	StartDate      time.Time
+	EndDate        time.Time
+	NotBefore      time.Time
+	Courts         []dbgen.Court
+	OperatingHours []dbgen.OperatingHour
+	MatchDuration  time.Duration
+	Available      SlotFilter
+}
+
+func GenerateRoundRobinSchedule(leagueID int64, teams []dbgen.LeagueTeam, window SlotWindow) ([]ScheduledMatch, error) {
 	if leagueID <= 0 {
 		return nil, errors.New("league ID is required")
 	}
 	if len(teams) < 2 {
 		return nil, errors.New("at least two teams are required")
 	}
-	if len(courts) == 0 {
+	return GenerateDivisionRoundRobinSchedule(leagueID, []DivisionEntrants{{Teams: teams}}, window)
+}
+
+// GenerateDivisionRoundRobinSchedule gives each division its own round robin;
+// teams only meet teams in their division. Rounds are interleaved, so every
+// division plays round one before any plays round two, and all divisions
+// share the window's slots.
+func GenerateDivisionRoundRobinSchedule(leagueID int64, divisions []DivisionEntrants, window SlotWindow) ([]ScheduledMatch, error) {
+	if leagueID <= 0 {
+		return nil, errors.New("league ID is required")
+	}
+	if len(divisions) == 0 {
+		return nil, errors.New("at least two teams are required")
+	}
+
+	var pairs []roundPair
+	for _, division := range divisions {
+		if len(division.Teams) < 2 {
+			return nil, fmt.Errorf("division %s needs at least two teams", division.Division)
+		}
+		divisionPairs, err := buildRoundRobinPairs(division.Teams)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, divisionPairs...)
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Round < pairs[j].Round })
+	return assignSlots(leagueID, pairs, window)
+}
+
+// GenerateSingleEliminationRound pairs one bracket round, highest seed against
+// lowest. entrants must be in seed order. When the field is not a power of
+// two the top seeds get byes; they are returned so the caller can carry them
+// into the next round.
+func GenerateSingleEliminationRound(leagueID int64, round int, entrants []dbgen.LeagueTeam, window SlotWindow) ([]ScheduledMatch, []dbgen.LeagueTeam, error) {
+	if leagueID <= 0 {
+		return nil, nil, errors.New("league ID is required")
+	}
+	if round <= 0 {
+		return nil, nil, errors.New("round must be positive")
+	}
+	if len(entrants) < 2 {
+		return nil, nil, errors.New("at least two teams are required")
+	}
+	return GenerateDivisionEliminationRound(leagueID, []DivisionEntrants{{Round: round, Teams: entrants}}, window)
+}
+
+// GenerateDivisionEliminationRound pairs the next round of each division's
+// bracket. Divisions are separate brackets and may be on different rounds;
+// their byes are returned together.
+func GenerateDivisionEliminationRound(leagueID int64, divisions []DivisionEntrants, window SlotWindow) ([]ScheduledMatch, []dbgen.LeagueTeam, error) {
+	if leagueID <= 0 {
+		return nil, nil, errors.New("league ID is required")
+	}
+	if len(divisions) == 0 {
+		return nil, nil, errors.New("at least two teams are required")
+	}
+
+	var (
+		pairs []roundPair
+		byes  []dbgen.LeagueTeam
+	)
+	for _, division := range divisions {
+		if division.Round <= 0 {
+			return nil, nil, errors.New("round must be positive")
+		}
+		if len(division.Teams) < 2 {
+			return nil, nil, fmt.Errorf("division %s needs at least two teams", division.Division)
+		}
+		divisionPairs, divisionByes := buildEliminationPairs(division.Round, division.Teams)
+		pairs = append(pairs, divisionPairs...)
+		byes = append(byes, divisionByes...)
+	}
+	schedule, err := assignSlots(leagueID, pairs, window)
+	if err != nil {
+		return nil, nil, err
+	}
+	return schedule, byes, nil
+}
+
+func buildEliminationPairs(round int, entrants []dbgen.LeagueTeam) ([]roundPair, []dbgen.LeagueTeam) {
+	size := 1
+	for size < len(entrants) {
+		size *= 2
+	}
+	byeCount := size - len(entrants)
+	byes := append([]dbgen.LeagueTeam(nil), entrants[:byeCount]...)
+	playing := entrants[byeCount:]
+
+	pairs := make([]roundPair, 0, len(playing)/2)
+	for i := 0; i < len(playing)/2; i++ {
+		pairs = append(pairs, roundPair{
+			Round:    round,
+			HomeTeam: playing[i],
+			AwayTeam: playing[len(playing)-1-i],
+		})
+	}
+	return pairs, byes
+}
+
+func assignSlots(leagueID int64, pairs []roundPair, window SlotWindow) ([]ScheduledMatch, error) {
+	if len(window.Courts) == 0 {
 		return nil, errors.New("at least one court is required")
 	}
-	if matchDuration <= 0 {
+	if window.MatchDuration <= 0 {
 		return nil, errors.New("match duration must be positive")
 	}
-	startDate = truncateDate(startDate)
-	endDate = truncateDate(endDate)
+	startDate := truncateDate(window.StartDate)
+	endDate := truncateDate(window.EndDate)
 	if endDate.Before(startDate) {
 		return nil, errors.New("start date must be on or before end date")
 	}
 
-	pairs, err := buildRoundRobinPairs(teams)
+	slots, err := buildMatchSlots(startDate, endDate, window.Courts, window.OperatingHours, window.MatchDuration)
 	if err != nil {
 		return nil, err
 	}
 
-	slots, err := buildMatchSlots(startDate, endDate, courts, operatingHours, matchDuration)
-	if err != nil {
-		return nil, err
-	}
-	if len(slots) < len(pairs) {
-		return nil, fmt.Errorf("insufficient slots: need %d matches but only %d available", len(pairs), len(slots))
-	}
-
 	schedule := make([]ScheduledMatch, 0, len(pairs))
-	for idx, pairing := range pairs {
-		slot := slots[idx]
+	next := 0
+	for _, pairing := range pairs {
+		slot, ok, err := nextOpenSlot(slots, &next, window)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("insufficient slots: need %d matches but only %d available", len(pairs), len(schedule))
+		}
 		schedule = append(schedule, ScheduledMatch{
 			LeagueID:  leagueID,
 			Round:     pairing.Round,
@@ -79,6 +196,27 @@ func GenerateRoundRobinSchedule(leagueID int64, teams []dbgen.LeagueTeam, startD
 	return schedule, nil
 }
 
+func nextOpenSlot(slots []matchSlot, next *int, window SlotWindow) (matchSlot, bool, error) {
+	for ; *next < len(slots); *next++ {
+		slot := slots[*next]
+		if slot.Start.Before(window.NotBefore) {
+			continue
+		}
+		if window.Available != nil {
+			open, err := window.Available(slot.Start, slot.End, slot.Court)
+			if err != nil {
+				return matchSlot{}, false, err
+			}
+			if !open {
+				continue
+			}
+		}
+		*next++
+		return slot, true, nil
+	}
+	return matchSlot{}, false, nil
+}
+
 type roundPair struct {
 	Round    int
 	HomeTeam dbgen.LeagueTeam
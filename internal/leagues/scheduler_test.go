@@ -0,0 +1,93 @@
+package leagues
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func testTeams(count int) []dbgen.LeagueTeam {
+	teams := make([]dbgen.LeagueTeam, 0, count)
+	for i := 1; i <= count; i++ {
+		teams = append(teams, dbgen.LeagueTeam{ID: int64(i), Name: fmt.Sprintf("Seed %d", i), Status: "active"})
+	}
+	return teams
+}
+
+func testWindow() SlotWindow {
+	// 2026-06-01 is a Monday; the facility is open 18:00-20:00 Mondays only.
+	day := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	return SlotWindow{
+		StartDate: day,
+		EndDate:   day.AddDate(0, 0, 7),
+		Courts: []dbgen.Court{
+			{ID: 1, Name: "Court 1", CourtNumber: 1, Status: "active"},
+			{ID: 2, Name: "Court 2", CourtNumber: 2, Status: "active"},
+		},
+		OperatingHours: []dbgen.OperatingHour{{DayOfWeek: int64(time.Monday), OpensAt: "18:00", ClosesAt: "20:00"}},
+		MatchDuration:  time.Hour,
+	}
+}
+
+func TestGenerateSingleEliminationRound_ByesForTopSeeds(t *testing.T) {
+	schedule, byes, err := GenerateSingleEliminationRound(1, 1, testTeams(6), testWindow())
+	if err != nil {
+		t.Fatalf("GenerateSingleEliminationRound: %v", err)
+	}
+
+	if len(byes) != 2 || byes[0].ID != 1 || byes[1].ID != 2 {
+		t.Fatalf("byes = %+v, want seeds 1 and 2", byes)
+	}
+	got := make([]string, 0, len(schedule))
+	for _, match := range schedule {
+		if match.Round != 1 {
+			t.Fatalf("match round = %d, want 1", match.Round)
+		}
+		got = append(got, fmt.Sprintf("%dv%d", match.HomeTeam.ID, match.AwayTeam.ID))
+	}
+	if fmt.Sprint(got) != "[3v6 4v5]" {
+		t.Fatalf("pairs = %v, want [3v6 4v5]", got)
+	}
+
+	// A power-of-two field has no byes.
+	_, byes, err = GenerateSingleEliminationRound(1, 2, testTeams(4), testWindow())
+	if err != nil || len(byes) != 0 {
+		t.Fatalf("expected no byes for four teams, got %+v, %v", byes, err)
+	}
+}
+
+func TestGenerateRoundRobinSchedule_SkipsUnavailableSlots(t *testing.T) {
+	window := testWindow()
+	busyStart := time.Date(2026, time.June, 1, 18, 0, 0, 0, time.UTC)
+	window.NotBefore = busyStart
+	window.Available = func(start, end time.Time, court dbgen.Court) (bool, error) {
+		return !(start.Equal(busyStart) && court.ID == 1), nil
+	}
+
+	schedule, err := GenerateRoundRobinSchedule(1, testTeams(3), window)
+	if err != nil {
+		t.Fatalf("GenerateRoundRobinSchedule: %v", err)
+	}
+	if len(schedule) != 3 {
+		t.Fatalf("expected 3 matches, got %d", len(schedule))
+	}
+	for _, match := range schedule {
+		if match.StartTime.Equal(busyStart) && match.Court.ID == 1 {
+			t.Fatalf("match scheduled into a busy slot: %+v", match)
+		}
+	}
+	if first := schedule[0]; !first.StartTime.Equal(busyStart) || first.Court.ID != 2 {
+		t.Fatalf("first match = court %d at %s, want court 2 at %s", first.Court.ID, first.StartTime, busyStart)
+	}
+
+	window.NotBefore = busyStart.Add(time.Hour)
+	schedule, err = GenerateRoundRobinSchedule(1, testTeams(2), window)
+	if err != nil {
+		t.Fatalf("GenerateRoundRobinSchedule: %v", err)
+	}
+	if !schedule[0].StartTime.Equal(window.NotBefore) {
+		t.Fatalf("first match starts %s, want not before %s", schedule[0].StartTime, window.NotBefore)
+	}
+}
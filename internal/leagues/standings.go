@@ -2,6 +2,7 @@ package leagues
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"sort"
@@ -22,11 +23,67 @@ type TeamStanding struct {
 
 type teamStats struct {
 	TeamStanding
+	division            sql.NullString
 	headToHeadWins      map[int64]int
 	headToHeadPointDiff map[int64]int
 }
 
+// DivisionStandings is one division's standings table. Division is empty
+// for the table of teams that have no division.
+type DivisionStandings struct {
+	Division  string         `json:"division"`
+	Standings []TeamStanding `json:"standings"`
+}
+
 func CalculateStandings(ctx context.Context, q *dbgen.Queries, leagueID int64) ([]TeamStanding, error) {
+	teams, err := loadTeamStats(ctx, q, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := make([]*teamStats, 0, len(teams))
+	for _, team := range teams {
+		ordered = append(ordered, team)
+	}
+	return rankTeams(ordered), nil
+}
+
+// CalculateDivisionStandings ranks each division separately, so tiebreakers
+// only compare teams within a division. Tables follow the league's
+// division_config order; teams without a division get a final table of their
+// own.
+func CalculateDivisionStandings(ctx context.Context, q *dbgen.Queries, league dbgen.League) ([]DivisionStandings, error) {
+	teams, err := loadTeamStats(ctx, q, league.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	config := LeagueDivisions(league.DivisionConfig)
+	byDivision := make(map[string][]*teamStats, len(config.Divisions))
+	var unassigned []*teamStats
+	for _, team := range teams {
+		name, ok := config.TeamDivision(team.division)
+		if !ok {
+			unassigned = append(unassigned, team)
+			continue
+		}
+		byDivision[name] = append(byDivision[name], team)
+	}
+
+	tables := make([]DivisionStandings, 0, len(config.Divisions)+1)
+	for _, division := range config.Divisions {
+		tables = append(tables, DivisionStandings{
+			Division:  division.Name,
+			Standings: rankTeams(byDivision[division.Name]),
+		})
+	}
+	if len(unassigned) > 0 {
+		tables = append(tables, DivisionStandings{Standings: rankTeams(unassigned)})
+	}
+	return tables, nil
+}
+
+func loadTeamStats(ctx context.Context, q *dbgen.Queries, leagueID int64) (map[int64]*teamStats, error) {
 	if q == nil {
 		return nil, errors.New("queries are required")
 	}
@@ -48,6 +105,7 @@ func CalculateStandings(ctx context.Context, q *dbgen.Queries, leagueID int64) (
 					TeamID:   row.TeamID,
 					TeamName: row.TeamName,
 				},
+				division:            row.Division,
 				headToHeadWins:      make(map[int64]int),
 				headToHeadPointDiff: make(map[int64]int),
 			}
@@ -81,12 +139,10 @@ func CalculateStandings(ctx context.Context, q *dbgen.Queries, leagueID int64) (
 		}
 		entry.headToHeadPointDiff[opponentID] += teamScore - opponentScore
 	}
+	return teams, nil
+}
 
-	ordered := make([]*teamStats, 0, len(teams))
-	for _, team := range teams {
-		ordered = append(ordered, team)
-	}
-
+func rankTeams(ordered []*teamStats) []TeamStanding {
 	sort.SliceStable(ordered, func(i, j int) bool {
 		if ordered[i].Wins != ordered[j].Wins {
 			return ordered[i].Wins > ordered[j].Wins
@@ -100,7 +156,7 @@ func CalculateStandings(ctx context.Context, q *dbgen.Queries, leagueID int64) (
 	for _, team := range ordered {
 		standings = append(standings, team.TeamStanding)
 	}
-	return standings, nil
+	return standings
 }
 
 func resolveMatchScore(row dbgen.GetLeagueStandingsDataRow, teamID int64) (int, int, int64, error) {
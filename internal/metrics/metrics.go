@@ -0,0 +1,168 @@
+// Package metrics exposes Prometheus metrics for bookings, notifications, and
+// request latency. Collectors are created and registered once, at package
+// initialization, on a registry of their own; callers only record values.
+//
+// Labels stay low-cardinality: facility IDs, reservation type names, route
+// patterns, and fixed outcomes. Never label by user.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pickleicious"
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+var registry = prometheus.NewRegistry()
+
+var (
+	reservationsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservations_created_total",
+		Help:      "Reservations booked, by facility and reservation type.",
+	}, []string{"facility_id", "type"})
+
+	reservationsCancelled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservations_cancelled_total",
+		Help:      "Reservations cancelled, by facility and reservation type.",
+	}, []string{"facility_id", "type"})
+
+	openPlaySignups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "open_play_signups_total",
+		Help:      "Open play sign-ups, by facility.",
+	}, []string{"facility_id"})
+
+	waitlistOffersCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "waitlist_offers_created_total",
+		Help:      "Waitlist offers made, by facility.",
+	}, []string{"facility_id"})
+
+	waitlistOffersExpired = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "waitlist_offers_expired_total",
+		Help:      "Waitlist offers that expired unanswered, by facility.",
+	}, []string{"facility_id"})
+
+	emailsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "emails_sent_total",
+		Help:      "Emails handed to the provider, by result.",
+	}, []string{"result"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP handler latency, by method, route pattern, and status class.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	bookingSlotsDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "member_booking_slots_duration_seconds",
+		Help:      "Time spent building a day of member booking slots.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		reservationsCreated,
+		reservationsCancelled,
+		openPlaySignups,
+		waitlistOffersCreated,
+		waitlistOffersExpired,
+		emailsSent,
+		httpRequestDuration,
+		bookingSlotsDuration,
+	)
+}
+
+// Handler serves the registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// ReservationCreated counts a booked reservation.
+func ReservationCreated(facilityID int64, reservationType string) {
+	reservationsCreated.WithLabelValues(facilityLabel(facilityID), reservationType).Inc()
+}
+
+// ReservationCancelled counts a cancelled reservation.
+func ReservationCancelled(facilityID int64, reservationType string) {
+	reservationsCancelled.WithLabelValues(facilityLabel(facilityID), reservationType).Inc()
+}
+
+// OpenPlaySignup counts a player added to an open play session.
+func OpenPlaySignup(facilityID int64) {
+	openPlaySignups.WithLabelValues(facilityLabel(facilityID)).Inc()
+}
+
+// WaitlistOffersCreated counts offers made to waitlisted members.
+func WaitlistOffersCreated(facilityID int64, count int) {
+	if count > 0 {
+		waitlistOffersCreated.WithLabelValues(facilityLabel(facilityID)).Add(float64(count))
+	}
+}
+
+// WaitlistOfferExpired counts an offer that lapsed.
+func WaitlistOfferExpired(facilityID int64) {
+	waitlistOffersExpired.WithLabelValues(facilityLabel(facilityID)).Inc()
+}
+
+// EmailSent counts one attempt to send an email through the provider.
+func EmailSent(err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultFailure
+	}
+	emailsSent.WithLabelValues(result).Inc()
+}
+
+// ObserveBookingSlots records how long building booking slots took.
+func ObserveBookingSlots(d time.Duration) {
+	bookingSlotsDuration.Observe(d.Seconds())
+}
+
+// ObserveRequest records a handled request. route is the matched mux
+// pattern, never the raw path, so IDs in URLs do not become labels.
+func ObserveRequest(method, route string, status int, d time.Duration) {
+	if route == "" {
+		route = "unmatched"
+	}
+	httpRequestDuration.WithLabelValues(methodLabel(method), route, statusClass(status)).Observe(d.Seconds())
+}
+
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		return method
+	}
+	return "other"
+}
+
+func facilityLabel(facilityID int64) string {
+	return strconv.FormatInt(facilityID, 10)
+}
+
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
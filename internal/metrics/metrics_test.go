@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlerExposesRecordedValues(t *testing.T) {
+	ReservationCreated(7, "GAME")
+	ReservationCreated(7, "GAME")
+	ReservationCancelled(7, "PRO_SESSION")
+	WaitlistOffersCreated(7, 0)
+	WaitlistOffersCreated(7, 3)
+	EmailSent(nil)
+	EmailSent(errors.New("throttled"))
+	ObserveBookingSlots(20 * time.Millisecond)
+	ObserveRequest("BREW", "", 418, time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := recorder.Body.String()
+	for _, want := range []string{
+		`pickleicious_reservations_created_total{facility_id="7",type="GAME"} 2`,
+		`pickleicious_reservations_cancelled_total{facility_id="7",type="PRO_SESSION"} 1`,
+		`pickleicious_waitlist_offers_created_total{facility_id="7"} 3`,
+		`pickleicious_emails_sent_total{result="success"} 1`,
+		`pickleicious_emails_sent_total{result="failure"} 1`,
+		`pickleicious_member_booking_slots_duration_seconds_count 1`,
+		`pickleicious_http_request_duration_seconds_count{method="other",route="unmatched",status="4xx"} 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in metrics output", want)
+		}
+	}
+}
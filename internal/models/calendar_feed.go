@@ -0,0 +1,38 @@
+// internal/models/calendar_feed.go
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+const calendarFeedTokenLen = 32 // 192 bits of HMAC-SHA256, base64url
+
+// CalendarFeedSigner issues the token embedded in a member's calendar
+// subscription URL. Calendar apps fetch the feed without a session, so the
+// token alone grants read access to that member's reservations.
+type CalendarFeedSigner struct {
+	secret []byte
+}
+
+func NewCalendarFeedSigner(secret string) (*CalendarFeedSigner, error) {
+	if strings.TrimSpace(secret) == "" {
+		return nil, fmt.Errorf("calendar feed secret is required")
+	}
+	return &CalendarFeedSigner{secret: []byte(secret)}, nil
+}
+
+// Token returns the feed token for a member.
+func (s *CalendarFeedSigner) Token(userID int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "calendar-feed:%d", userID)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))[:calendarFeedTokenLen]
+}
+
+// Verify reports whether token was issued for userID.
+func (s *CalendarFeedSigner) Verify(userID int64, token string) bool {
+	return hmac.Equal([]byte(s.Token(userID)), []byte(token))
+}
@@ -0,0 +1,158 @@
+// internal/models/member_cards.go
+package models
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	memberCardPayloadPrefix = "PKC1"
+	memberCardTokenBytes    = 16
+	memberCardSignatureLen  = 22 // 128 bits of HMAC-SHA256, base64url
+
+	// MemberCardKeyPeriod is how long one signing key stays current. Keys from
+	// the previous MemberCardKeyGracePeriods periods are still accepted so
+	// printed cards keep working across a rotation.
+	MemberCardKeyPeriod       = 90 * 24 * time.Hour
+	MemberCardKeyGracePeriods = 2
+)
+
+var (
+	ErrMemberCardInvalid = errors.New("member card is invalid")
+	ErrMemberCardExpired = errors.New("member card has expired")
+)
+
+type MemberCardQueries interface {
+	GetActiveMemberCard(ctx context.Context, userID int64) (dbgen.MemberCard, error)
+	CreateMemberCard(ctx context.Context, arg dbgen.CreateMemberCardParams) (dbgen.MemberCard, error)
+	RevokeMemberCards(ctx context.Context, userID int64) (int64, error)
+}
+
+// MemberCardSigner signs check-in QR payloads. Signing keys are derived from
+// the application secret per key period, so they rotate without storage.
+type MemberCardSigner struct {
+	secret []byte
+}
+
+// MemberCardPayload is the signed string encoded in a member card QR code.
+type MemberCardPayload struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+func NewMemberCardSigner(secret string) (*MemberCardSigner, error) {
+	if strings.TrimSpace(secret) == "" {
+		return nil, fmt.Errorf("member card secret is required")
+	}
+	return &MemberCardSigner{secret: []byte(secret)}, nil
+}
+
+// Sign returns the QR payload for a card token using the key current at now.
+func (s *MemberCardSigner) Sign(cardToken string, now time.Time) MemberCardPayload {
+	period := memberCardKeyPeriod(now)
+	unsigned := fmt.Sprintf("%s.%d.%s", memberCardPayloadPrefix, period, cardToken)
+	return MemberCardPayload{
+		Value:     unsigned + "." + s.signature(period, unsigned),
+		ExpiresAt: memberCardPeriodStart(period + MemberCardKeyGracePeriods + 1),
+	}
+}
+
+// Verify checks a scanned payload and returns the card token it carries.
+func (s *MemberCardSigner) Verify(payload string, now time.Time) (string, error) {
+	parts := strings.Split(strings.TrimSpace(payload), ".")
+	if len(parts) != 4 || parts[0] != memberCardPayloadPrefix {
+		return "", ErrMemberCardInvalid
+	}
+	period, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", ErrMemberCardInvalid
+	}
+	unsigned := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(parts[3]), []byte(s.signature(period, unsigned))) {
+		return "", ErrMemberCardInvalid
+	}
+
+	current := memberCardKeyPeriod(now)
+	if period > current {
+		return "", ErrMemberCardInvalid
+	}
+	if period < current-MemberCardKeyGracePeriods {
+		return "", ErrMemberCardExpired
+	}
+	return parts[2], nil
+}
+
+func (s *MemberCardSigner) signature(period int64, unsigned string) string {
+	keyMAC := hmac.New(sha256.New, s.secret)
+	_, _ = keyMAC.Write([]byte(fmt.Sprintf("member-card-key:%d", period)))
+
+	mac := hmac.New(sha256.New, keyMAC.Sum(nil))
+	_, _ = mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))[:memberCardSignatureLen]
+}
+
+func memberCardKeyPeriod(t time.Time) int64 {
+	return t.Unix() / int64(MemberCardKeyPeriod/time.Second)
+}
+
+func memberCardPeriodStart(period int64) time.Time {
+	return time.Unix(period*int64(MemberCardKeyPeriod/time.Second), 0).UTC()
+}
+
+// EnsureMemberCard returns the member's active card, issuing one if needed.
+func EnsureMemberCard(ctx context.Context, q MemberCardQueries, userID int64) (dbgen.MemberCard, error) {
+	card, err := q.GetActiveMemberCard(ctx, userID)
+	if err == nil {
+		return card, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return dbgen.MemberCard{}, err
+	}
+
+	token, err := newMemberCardToken()
+	if err != nil {
+		return dbgen.MemberCard{}, err
+	}
+	card, err = q.CreateMemberCard(ctx, dbgen.CreateMemberCardParams{UserID: userID, CardToken: token})
+	if err != nil {
+		// A concurrent request may have issued the card first.
+		if existing, getErr := q.GetActiveMemberCard(ctx, userID); getErr == nil {
+			return existing, nil
+		}
+		return dbgen.MemberCard{}, err
+	}
+	return card, nil
+}
+
+// ResetMemberCard revokes the member's card and issues a new token. Call it
+// inside a transaction.
+func ResetMemberCard(ctx context.Context, q MemberCardQueries, userID int64) (dbgen.MemberCard, error) {
+	if _, err := q.RevokeMemberCards(ctx, userID); err != nil {
+		return dbgen.MemberCard{}, err
+	}
+	token, err := newMemberCardToken()
+	if err != nil {
+		return dbgen.MemberCard{}, err
+	}
+	return q.CreateMemberCard(ctx, dbgen.CreateMemberCardParams{UserID: userID, CardToken: token})
+}
+
+func newMemberCardToken() (string, error) {
+	token := make([]byte, memberCardTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
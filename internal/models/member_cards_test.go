@@ -0,0 +1,58 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemberCardSigner_SignVerify(t *testing.T) {
+	signer, err := NewMemberCardSigner("test-secret")
+	if err != nil {
+		t.Fatalf("NewMemberCardSigner: %v", err)
+	}
+	now := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	payload := signer.Sign("card-token", now)
+	token, err := signer.Verify(payload.Value, now)
+	if err != nil || token != "card-token" {
+		t.Fatalf("Verify = %q, %v; want card-token", token, err)
+	}
+
+	parts := strings.Split(payload.Value, ".")
+	tampered := strings.Join([]string{parts[0], parts[1], "other-token", parts[3]}, ".")
+	if _, err := signer.Verify(tampered, now); !errors.Is(err, ErrMemberCardInvalid) {
+		t.Fatalf("expected tampered token to be invalid, got %v", err)
+	}
+
+	other, _ := NewMemberCardSigner("other-secret")
+	if _, err := other.Verify(payload.Value, now); !errors.Is(err, ErrMemberCardInvalid) {
+		t.Fatalf("expected payload signed with another secret to be invalid, got %v", err)
+	}
+}
+
+func TestMemberCardSigner_KeyRotation(t *testing.T) {
+	signer, _ := NewMemberCardSigner("test-secret")
+	issued := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+	payload := signer.Sign("card-token", issued)
+
+	rotated := issued.Add(MemberCardKeyPeriod)
+	if next := signer.Sign("card-token", rotated); next.Value == payload.Value {
+		t.Fatal("expected a new signature after the key period rolls over")
+	}
+	if _, err := signer.Verify(payload.Value, rotated); err != nil {
+		t.Fatalf("expected previous key to be accepted during grace, got %v", err)
+	}
+
+	if _, err := signer.Verify(payload.Value, payload.ExpiresAt.Add(-time.Second)); err != nil {
+		t.Fatalf("expected card to validate until it expires, got %v", err)
+	}
+	if _, err := signer.Verify(payload.Value, payload.ExpiresAt); !errors.Is(err, ErrMemberCardExpired) {
+		t.Fatalf("expected card to expire at %s, got %v", payload.ExpiresAt, err)
+	}
+
+	if _, err := signer.Verify(payload.Value, issued.Add(-MemberCardKeyPeriod)); !errors.Is(err, ErrMemberCardInvalid) {
+		t.Fatalf("expected future key period to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReservationAccessSigner_SignVerify(t *testing.T) {
+	signer, err := NewReservationAccessSigner("test-secret")
+	if err != nil {
+		t.Fatalf("NewReservationAccessSigner: %v", err)
+	}
+	start := time.Date(2026, time.June, 1, 18, 0, 0, 0, time.UTC)
+	access := NewReservationAccess(42, 7, start, start.Add(time.Hour))
+	if want := start.Add(-ReservationAccessOpensBeforeStart); !access.ValidFrom.Equal(want) {
+		t.Fatalf("ValidFrom = %s, want %s", access.ValidFrom, want)
+	}
+
+	payload := signer.Sign(access)
+	got, err := signer.Verify(payload)
+	if err != nil || got != access {
+		t.Fatalf("Verify = %+v, %v; want %+v", got, err, access)
+	}
+
+	parts := strings.Split(payload, ".")
+	parts[2] = "8"
+	if _, err := signer.Verify(strings.Join(parts, ".")); !errors.Is(err, ErrReservationAccessInvalid) {
+		t.Fatalf("expected a code moved to another member to be invalid, got %v", err)
+	}
+
+	other, _ := NewReservationAccessSigner("other-secret")
+	if _, err := other.Verify(payload); !errors.Is(err, ErrReservationAccessInvalid) {
+		t.Fatalf("expected a code signed with another secret to be invalid, got %v", err)
+	}
+	if _, err := signer.Verify("PKC1.1.token.sig"); !errors.Is(err, ErrReservationAccessInvalid) {
+		t.Fatalf("expected a member card payload to be invalid, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+// internal/models/reservation_calendar.go
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	CalendarGranularityDay  = "day"
+	CalendarGranularityWeek = "week"
+)
+
+type CalendarCourt struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	CourtNumber int64  `json:"courtNumber"`
+	Status      string `json:"status"`
+}
+
+// CalendarReservation is one reservation with all of its courts. Start and
+// End are the reservation's own times, not clipped to the calendar window,
+// so a booking that crosses midnight reads the same on both days.
+type CalendarReservation struct {
+	ID                int64     `json:"id"`
+	Type              string    `json:"type"`
+	TypeColor         string    `json:"typeColor,omitempty"`
+	Start             time.Time `json:"start"`
+	End               time.Time `json:"end"`
+	CourtIDs          []int64   `json:"courtIds"`
+	PrimaryMemberName string    `json:"primaryMemberName,omitempty"`
+	IsOpenEvent       bool      `json:"isOpenEvent"`
+}
+
+type ReservationCalendar struct {
+	Granularity  string                `json:"granularity"`
+	Start        time.Time             `json:"start"`
+	End          time.Time             `json:"end"`
+	Courts       []CalendarCourt       `json:"courts"`
+	Reservations []CalendarReservation `json:"reservations"`
+}
+
+// ParseCalendarGranularity reads the granularity query value, defaulting to day.
+func ParseCalendarGranularity(raw string) (string, error) {
+	switch value := strings.ToLower(strings.TrimSpace(raw)); value {
+	case "", CalendarGranularityDay:
+		return CalendarGranularityDay, nil
+	case CalendarGranularityWeek:
+		return CalendarGranularityWeek, nil
+	default:
+		return "", fmt.Errorf("granularity must be day or week")
+	}
+}
+
+// CalendarWindow returns the [start, end) range covering one day, or seven
+// days, beginning at midnight of date in date's location.
+func CalendarWindow(date time.Time, granularity string) (time.Time, time.Time) {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	if granularity == CalendarGranularityWeek {
+		return start, start.AddDate(0, 0, 7)
+	}
+	return start, start.AddDate(0, 0, 1)
+}
+
+// BuildReservationCalendar folds per-court calendar rows into one entry per
+// reservation. Rows must be grouped by reservation, as the query orders them.
+// Times are reported in the window's location.
+func BuildReservationCalendar(
+	granularity string,
+	start, end time.Time,
+	courts []dbgen.Court,
+	rows []dbgen.ListReservationCalendarEntriesRow,
+) ReservationCalendar {
+	calendar := ReservationCalendar{
+		Granularity:  granularity,
+		Start:        start,
+		End:          end,
+		Courts:       make([]CalendarCourt, 0, len(courts)),
+		Reservations: []CalendarReservation{},
+	}
+	for _, court := range courts {
+		calendar.Courts = append(calendar.Courts, CalendarCourt{
+			ID:          court.ID,
+			Name:        court.Name,
+			CourtNumber: court.CourtNumber,
+			Status:      court.Status,
+		})
+	}
+
+	loc := start.Location()
+	for _, row := range rows {
+		last := len(calendar.Reservations) - 1
+		if last < 0 || calendar.Reservations[last].ID != row.ID {
+			calendar.Reservations = append(calendar.Reservations, CalendarReservation{
+				ID:                row.ID,
+				Type:              row.ReservationTypeName,
+				TypeColor:         strings.TrimSpace(row.ReservationTypeColor.String),
+				Start:             row.StartTime.In(loc),
+				End:               row.EndTime.In(loc),
+				CourtIDs:          []int64{},
+				PrimaryMemberName: calendarMemberName(row.PrimaryFirstName.String, row.PrimaryLastName.String),
+				IsOpenEvent:       row.IsOpenEvent,
+			})
+			last++
+		}
+		if row.CourtID.Valid {
+			calendar.Reservations[last].CourtIDs = append(calendar.Reservations[last].CourtIDs, row.CourtID.Int64)
+		}
+	}
+	return calendar
+}
+
+func calendarMemberName(firstName, lastName string) string {
+	return strings.TrimSpace(strings.TrimSpace(firstName) + " " + strings.TrimSpace(lastName))
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestParseCalendarGranularity(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "", want: CalendarGranularityDay},
+		{raw: "day", want: CalendarGranularityDay},
+		{raw: " Week ", want: CalendarGranularityWeek},
+		{raw: "month", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseCalendarGranularity(test.raw)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Fatalf("ParseCalendarGranularity(%q) = %q, %v; want %q, error %t", test.raw, got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestCalendarWindow(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	// 2026-03-08 is the US spring-forward day, which is only 23 hours long.
+	date := time.Date(2026, time.March, 8, 15, 30, 0, 0, loc)
+
+	start, end := CalendarWindow(date, CalendarGranularityDay)
+	if want := time.Date(2026, time.March, 8, 0, 0, 0, 0, loc); !start.Equal(want) {
+		t.Fatalf("day start = %s, want %s", start, want)
+	}
+	if want := time.Date(2026, time.March, 9, 0, 0, 0, 0, loc); !end.Equal(want) {
+		t.Fatalf("day end = %s, want %s", end, want)
+	}
+
+	_, end = CalendarWindow(date, CalendarGranularityWeek)
+	if want := time.Date(2026, time.March, 15, 0, 0, 0, 0, loc); !end.Equal(want) {
+		t.Fatalf("week end = %s, want %s", end, want)
+	}
+}
+
+func TestBuildReservationCalendar_SpansMidnight(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	dayStart := time.Date(2026, time.June, 2, 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	// A late league block runs from 22:00 the previous evening to 01:00, and
+	// an open play session overlaps its last hour on a second court.
+	lateStart := time.Date(2026, time.June, 2, 3, 0, 0, 0, time.UTC)
+	rows := []dbgen.ListReservationCalendarEntriesRow{
+		{ID: 7, StartTime: lateStart, EndTime: lateStart.Add(3 * time.Hour), ReservationTypeName: "LEAGUE", CourtID: sql.NullInt64{Int64: 1, Valid: true}},
+		{ID: 7, StartTime: lateStart, EndTime: lateStart.Add(3 * time.Hour), ReservationTypeName: "LEAGUE", CourtID: sql.NullInt64{Int64: 2, Valid: true}},
+		{
+			ID:                   9,
+			StartTime:            lateStart.Add(2 * time.Hour),
+			EndTime:              lateStart.Add(4 * time.Hour),
+			ReservationTypeName:  "OPEN_PLAY",
+			ReservationTypeColor: sql.NullString{String: "#2E7D32", Valid: true},
+			IsOpenEvent:          true,
+			CourtID:              sql.NullInt64{Int64: 3, Valid: true},
+			PrimaryFirstName:     sql.NullString{String: "Pat", Valid: true},
+			PrimaryLastName:      sql.NullString{String: "Lee", Valid: true},
+		},
+		{ID: 11, StartTime: lateStart.Add(10 * time.Hour), EndTime: lateStart.Add(11 * time.Hour), ReservationTypeName: "GAME"},
+	}
+	courts := []dbgen.Court{
+		{ID: 1, Name: "Court 1", CourtNumber: 1, Status: "active"},
+		{ID: 2, Name: "Court 2", CourtNumber: 2, Status: "active"},
+		{ID: 3, Name: "Court 3", CourtNumber: 3, Status: "maintenance"},
+	}
+
+	calendar := BuildReservationCalendar(CalendarGranularityDay, dayStart, dayEnd, courts, rows)
+
+	if len(calendar.Courts) != 3 || calendar.Courts[2].Status != "maintenance" {
+		t.Fatalf("unexpected courts: %+v", calendar.Courts)
+	}
+	if len(calendar.Reservations) != 3 {
+		t.Fatalf("expected 3 reservations, got %+v", calendar.Reservations)
+	}
+
+	league := calendar.Reservations[0]
+	if league.ID != 7 || len(league.CourtIDs) != 2 || league.CourtIDs[0] != 1 || league.CourtIDs[1] != 2 {
+		t.Fatalf("expected league block on courts 1 and 2, got %+v", league)
+	}
+	if want := time.Date(2026, time.June, 1, 22, 0, 0, 0, loc); !league.Start.Equal(want) || league.Start.Location() != loc {
+		t.Fatalf("league start = %s, want unclipped %s in window location", league.Start, want)
+	}
+	if !league.Start.Before(calendar.Start) || !league.End.After(calendar.Start) {
+		t.Fatalf("expected league block to straddle the window start: %s-%s", league.Start, league.End)
+	}
+
+	openPlay := calendar.Reservations[1]
+	if !openPlay.IsOpenEvent || openPlay.PrimaryMemberName != "Pat Lee" || openPlay.Type != "OPEN_PLAY" || openPlay.TypeColor != "#2E7D32" {
+		t.Fatalf("unexpected open play entry: %+v", openPlay)
+	}
+	if !openPlay.Start.Before(league.End) {
+		t.Fatalf("expected open play to overlap the league block")
+	}
+
+	game := calendar.Reservations[2]
+	if game.CourtIDs == nil || len(game.CourtIDs) != 0 || game.PrimaryMemberName != "" || game.TypeColor != "" {
+		t.Fatalf("expected a courtless game with an empty court list, got %+v", game)
+	}
+}
@@ -0,0 +1,158 @@
+// internal/models/season_passes.go
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const SeasonPassDateLayout = "2006-01-02"
+const SeasonPassTimeLayout = "15:04"
+
+var ErrSeasonPassUnavailable = errors.New("season pass unavailable")
+
+type SeasonPassQueries interface {
+	ListActiveSeasonPassesForUserByFacility(ctx context.Context, arg dbgen.ListActiveSeasonPassesForUserByFacilityParams) ([]dbgen.ListActiveSeasonPassesForUserByFacilityRow, error)
+	ListSeasonPassWindows(ctx context.Context, passTypeID int64) ([]dbgen.SeasonPassWindow, error)
+}
+
+// SeasonPassCoversBooking reports whether the booking falls entirely inside the
+// pass validity range and a single off-peak window. Bookings that straddle a
+// window boundary are treated as peak and are not covered.
+func SeasonPassCoversBooking(validFrom, validUntil string, windows []dbgen.SeasonPassWindow, start, end time.Time, loc *time.Location) bool {
+	if loc == nil {
+		loc = time.UTC
+	}
+	start = start.In(loc)
+	end = end.In(loc)
+	if !end.After(start) {
+		return false
+	}
+
+	startDate := start.Format(SeasonPassDateLayout)
+	if startDate < validFrom || startDate > validUntil {
+		return false
+	}
+	// Bookings ending exactly at midnight still belong to the start day.
+	lastMoment := end.Add(-time.Nanosecond)
+	if lastMoment.Format(SeasonPassDateLayout) != startDate {
+		return false
+	}
+
+	startClock := start.Format(SeasonPassTimeLayout)
+	endClock := end.Format(SeasonPassTimeLayout)
+	if endClock == "00:00" {
+		endClock = "24:00"
+	}
+	for _, window := range windows {
+		if window.DayOfWeek != int64(start.Weekday()) {
+			continue
+		}
+		if window.StartsAt <= startClock && endClock <= window.EndsAt {
+			return true
+		}
+	}
+	return false
+}
+
+// FindCoveringSeasonPass returns the first active season pass held by the user
+// at the facility that covers the booking, or nil when the booking is peak.
+func FindCoveringSeasonPass(ctx context.Context, q SeasonPassQueries, userID, facilityID int64, start, end time.Time, loc *time.Location) (*dbgen.ListActiveSeasonPassesForUserByFacilityRow, error) {
+	if q == nil {
+		return nil, fmt.Errorf("queries are required")
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	passes, err := q.ListActiveSeasonPassesForUserByFacility(ctx, dbgen.ListActiveSeasonPassesForUserByFacilityParams{
+		UserID:     userID,
+		FacilityID: facilityID,
+		OnDate:     start.In(loc).Format(SeasonPassDateLayout),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	windowsByType := make(map[int64][]dbgen.SeasonPassWindow, len(passes))
+	for i := range passes {
+		pass := passes[i]
+		windows, ok := windowsByType[pass.PassTypeID]
+		if !ok {
+			windows, err = q.ListSeasonPassWindows(ctx, pass.PassTypeID)
+			if err != nil {
+				return nil, err
+			}
+			windowsByType[pass.PassTypeID] = windows
+		}
+		if SeasonPassCoversBooking(pass.ValidFrom, pass.ValidUntil, windows, start, end, loc) {
+			return &pass, nil
+		}
+	}
+	return nil, nil
+}
+
+type IssueSeasonPassParams struct {
+	UserID          int64
+	PricePaidCents  int64
+	GrantedByUserID *int64
+	PurchasedAt     time.Time
+}
+
+// IssueSeasonPass records a purchased or staff-granted pass. Passes can only be
+// issued for active pass types whose validity has not already ended.
+func IssueSeasonPass(ctx context.Context, q dbgen.Querier, passType dbgen.SeasonPassType, params IssueSeasonPassParams) (dbgen.SeasonPass, error) {
+	if q == nil {
+		return dbgen.SeasonPass{}, fmt.Errorf("queries are required")
+	}
+	if params.UserID <= 0 {
+		return dbgen.SeasonPass{}, fmt.Errorf("user_id must be a positive integer")
+	}
+	if params.PricePaidCents < 0 {
+		return dbgen.SeasonPass{}, fmt.Errorf("price_paid_cents must be 0 or greater")
+	}
+	if !strings.EqualFold(passType.Status, "active") {
+		return dbgen.SeasonPass{}, ErrSeasonPassUnavailable
+	}
+
+	purchasedAt := params.PurchasedAt
+	if purchasedAt.IsZero() {
+		purchasedAt = time.Now()
+	}
+
+	facility, err := q.GetFacilityByID(ctx, passType.FacilityID)
+	if err != nil {
+		return dbgen.SeasonPass{}, err
+	}
+	loc := apiutil.FacilityLocation(facility, nil)
+	if purchasedAt.In(loc).Format(SeasonPassDateLayout) > passType.ValidUntil {
+		return dbgen.SeasonPass{}, ErrSeasonPassUnavailable
+	}
+
+	grantedBy := sql.NullInt64{}
+	if params.GrantedByUserID != nil {
+		grantedBy = sql.NullInt64{Int64: *params.GrantedByUserID, Valid: true}
+	}
+
+	created, err := q.CreateSeasonPass(ctx, dbgen.CreateSeasonPassParams{
+		UserID:          params.UserID,
+		PurchasedAt:     purchasedAt,
+		PricePaidCents:  params.PricePaidCents,
+		GrantedByUserID: grantedBy,
+		PassTypeID:      passType.ID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbgen.SeasonPass{}, ErrSeasonPassUnavailable
+		}
+		return dbgen.SeasonPass{}, err
+	}
+	return created, nil
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestSeasonPassCoversBooking(t *testing.T) {
+	loc := time.UTC
+	// 2026-06-01 is a Monday.
+	windows := []dbgen.SeasonPassWindow{
+		{DayOfWeek: int64(time.Monday), StartsAt: "06:00", EndsAt: "16:00"},
+		{DayOfWeek: int64(time.Monday), StartsAt: "21:00", EndsAt: "24:00"},
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.June, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+		want  bool
+	}{
+		{name: "inside_window", start: at(1, 9, 0), end: at(1, 10, 30), want: true},
+		{name: "matches_window_edges", start: at(1, 6, 0), end: at(1, 16, 0), want: true},
+		{name: "straddles_peak", start: at(1, 15, 30), end: at(1, 16, 30), want: false},
+		{name: "peak_only", start: at(1, 17, 0), end: at(1, 18, 0), want: false},
+		{name: "ends_at_midnight", start: at(1, 22, 0), end: at(2, 0, 0), want: true},
+		{name: "crosses_midnight", start: at(1, 23, 0), end: at(2, 1, 0), want: false},
+		{name: "wrong_weekday", start: at(2, 9, 0), end: at(2, 10, 0), want: false},
+		{name: "before_validity", start: time.Date(2026, time.May, 25, 9, 0, 0, 0, loc), end: time.Date(2026, time.May, 25, 10, 0, 0, 0, loc), want: false},
+		{name: "after_validity", start: time.Date(2026, time.September, 7, 9, 0, 0, 0, loc), end: time.Date(2026, time.September, 7, 10, 0, 0, 0, loc), want: false},
+		{name: "empty_range", start: at(1, 9, 0), end: at(1, 9, 0), want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := SeasonPassCoversBooking("2026-06-01", "2026-08-31", windows, test.start, test.end, loc)
+			if got != test.want {
+				t.Fatalf("SeasonPassCoversBooking(%s, %s) = %t, want %t", test.start, test.end, got, test.want)
+			}
+		})
+	}
+}
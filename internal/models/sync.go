@@ -0,0 +1,140 @@
+// internal/models/sync.go
+package models
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	SyncEntityReservation     = "reservation"
+	SyncEntityOpenPlaySession = "open_play_session"
+
+	// SyncTimestampLayout matches sync_changes.changed_at (UTC).
+	SyncTimestampLayout = "2006-01-02 15:04:05.000"
+	SyncPageLimit       = 500
+)
+
+type SyncQueries interface {
+	ListSyncChanges(ctx context.Context, arg dbgen.ListSyncChangesParams) ([]dbgen.ListSyncChangesRow, error)
+	GetLatestSyncSeq(ctx context.Context) (int64, error)
+}
+
+// SyncParams selects the changes a delta sync client has not yet seen. A
+// sync token takes precedence over updated_since.
+type SyncParams struct {
+	AfterSeq     int64
+	UpdatedSince time.Time
+	HasToken     bool
+}
+
+// Delta reports whether the request asked for changes rather than a full list.
+func (p SyncParams) Delta() bool {
+	return p.HasToken || !p.UpdatedSince.IsZero()
+}
+
+type SyncPage struct {
+	Changes []dbgen.ListSyncChangesRow
+	Token   string
+	HasMore bool
+}
+
+// SyncTombstone tells a client to drop a record it holds locally.
+type SyncTombstone struct {
+	ID        int64  `json:"id"`
+	Reason    string `json:"reason"`
+	DeletedAt string `json:"deletedAt"`
+}
+
+// ParseSyncParams reads sync_token and updated_since (RFC 3339) values.
+func ParseSyncParams(syncToken, updatedSince string) (SyncParams, error) {
+	syncToken = strings.TrimSpace(syncToken)
+	if syncToken != "" {
+		seq, err := strconv.ParseInt(syncToken, 10, 64)
+		if err != nil || seq < 0 {
+			return SyncParams{}, fmt.Errorf("sync_token is invalid")
+		}
+		return SyncParams{AfterSeq: seq, HasToken: true}, nil
+	}
+
+	updatedSince = strings.TrimSpace(updatedSince)
+	if updatedSince == "" {
+		return SyncParams{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, updatedSince)
+	if err != nil {
+		return SyncParams{}, fmt.Errorf("updated_since must be an RFC 3339 timestamp")
+	}
+	return SyncParams{UpdatedSince: parsed}, nil
+}
+
+// FormatSyncToken renders the opaque token a client echoes back on its next poll.
+func FormatSyncToken(seq int64) string {
+	return strconv.FormatInt(seq, 10)
+}
+
+// ListSyncPage returns the entities changed after params, one row per entity
+// ordered by its latest change. Call it inside a transaction together with the
+// record lookups so the token and the records come from one snapshot.
+func ListSyncPage(ctx context.Context, q SyncQueries, facilityID int64, entityType string, params SyncParams) (SyncPage, error) {
+	if q == nil {
+		return SyncPage{}, fmt.Errorf("queries are required")
+	}
+
+	updatedSince := ""
+	if !params.HasToken && !params.UpdatedSince.IsZero() {
+		updatedSince = params.UpdatedSince.UTC().Format(SyncTimestampLayout)
+	}
+
+	changes, err := q.ListSyncChanges(ctx, dbgen.ListSyncChangesParams{
+		FacilityID:   facilityID,
+		EntityType:   entityType,
+		AfterSeq:     params.AfterSeq,
+		UpdatedSince: updatedSince,
+		Limit:        SyncPageLimit + 1,
+	})
+	if err != nil {
+		return SyncPage{}, err
+	}
+
+	page := SyncPage{Changes: changes}
+	if len(changes) > SyncPageLimit {
+		page.Changes = changes[:SyncPageLimit]
+		page.HasMore = true
+		page.Token = FormatSyncToken(page.Changes[len(page.Changes)-1].LastSeq)
+		return page, nil
+	}
+
+	latest, err := q.GetLatestSyncSeq(ctx)
+	if err != nil {
+		return SyncPage{}, err
+	}
+	if latest < params.AfterSeq {
+		latest = params.AfterSeq
+	}
+	page.Token = FormatSyncToken(latest)
+	return page, nil
+}
+
+// SyncTimestamp converts a changed_at value to RFC 3339 for API responses.
+func SyncTimestamp(raw string) string {
+	parsed, err := time.Parse(SyncTimestampLayout, raw)
+	if err != nil {
+		return raw
+	}
+	return parsed.UTC().Format(time.RFC3339Nano)
+}
+
+// EntityIDs returns the changed entity IDs in page order.
+func (p SyncPage) EntityIDs() []int64 {
+	ids := make([]int64, len(p.Changes))
+	for i, change := range p.Changes {
+		ids[i] = change.EntityID
+	}
+	return ids
+}
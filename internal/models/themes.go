@@ -67,6 +67,12 @@ func DefaultTheme() Theme {
 	}
 }
 
+// AvailableTo reports whether a facility may activate or preview the theme:
+// system themes are shared, custom themes belong to a single facility.
+func (t Theme) AvailableTo(facilityID int64) bool {
+	return t.IsSystem || t.FacilityID == nil || *t.FacilityID == facilityID
+}
+
 func (t Theme) Validate() error {
 	trimmedName := strings.TrimSpace(t.Name)
 	if trimmedName == "" {
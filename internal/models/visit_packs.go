@@ -6,12 +6,17 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/payments"
 )
 
-var ErrVisitPackUnavailable = errors.New("visit pack unavailable")
+var (
+	ErrVisitPackUnavailable = errors.New("visit pack unavailable")
+	ErrPaymentsUnavailable  = errors.New("payments unavailable")
+)
 
 type RedeemVisitPackVisitParams struct {
 	VisitPackID   int64
@@ -103,3 +108,101 @@ func RedeemVisitPackVisit(ctx context.Context, q dbgen.Querier, params RedeemVis
 		Redemption: redemption,
 	}, nil
 }
+
+type PurchaseVisitPackParams struct {
+	UserID      int64
+	PurchasedAt time.Time
+}
+
+type VisitPackPurchaseResult struct {
+	VisitPack dbgen.VisitPack `json:"visitPack"`
+	// Payment is nil for free pack types, which are never sent to the processor.
+	Payment *dbgen.VisitPackPayment `json:"payment"`
+}
+
+// voidChargeTimeout bounds voiding a charge after the purchase failed to
+// record; it runs even when the request context is gone.
+const voidChargeTimeout = 10 * time.Second
+
+// PurchaseVisitPack creates a pack from an active pack type and charges its
+// price through the processor. The charge is taken before inTx opens a
+// transaction, so the network call never holds the database write lock; the
+// pack and its payment row are then written through the transactional
+// querier inTx passes to its callback. If those writes or the commit fail,
+// the charge is voided.
+func PurchaseVisitPack(ctx context.Context, inTx func(func(dbgen.Querier) error) error, processor payments.PaymentProcessor, packType dbgen.VisitPackType, params PurchaseVisitPackParams) (VisitPackPurchaseResult, error) {
+	if inTx == nil {
+		return VisitPackPurchaseResult{}, fmt.Errorf("transaction runner is required")
+	}
+	if params.UserID <= 0 {
+		return VisitPackPurchaseResult{}, fmt.Errorf("user_id must be a positive integer")
+	}
+	if !strings.EqualFold(packType.Status, "active") {
+		return VisitPackPurchaseResult{}, ErrVisitPackUnavailable
+	}
+	if packType.PriceCents > 0 && processor == nil {
+		return VisitPackPurchaseResult{}, ErrPaymentsUnavailable
+	}
+
+	purchasedAt := params.PurchasedAt
+	if purchasedAt.IsZero() {
+		purchasedAt = time.Now()
+	}
+
+	var charge *payments.Charge
+	if packType.PriceCents > 0 {
+		charged, err := processor.Charge(ctx, payments.ChargeRequest{
+			FacilityID:  packType.FacilityID,
+			UserID:      params.UserID,
+			AmountCents: packType.PriceCents,
+			Description: packType.Name,
+		})
+		if err != nil {
+			return VisitPackPurchaseResult{}, fmt.Errorf("charge visit pack: %w", err)
+		}
+		charge = &charged
+	}
+
+	var result VisitPackPurchaseResult
+	err := inTx(func(q dbgen.Querier) error {
+		created, err := q.CreateVisitPack(ctx, dbgen.CreateVisitPackParams{
+			UserID:       params.UserID,
+			PurchaseDate: purchasedAt,
+			Status:       "active",
+			PackTypeID:   packType.ID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrVisitPackUnavailable
+			}
+			return err
+		}
+		result = VisitPackPurchaseResult{VisitPack: created}
+		if charge == nil {
+			return nil
+		}
+
+		payment, err := q.CreateVisitPackPayment(ctx, dbgen.CreateVisitPackPaymentParams{
+			VisitPackID: created.ID,
+			AmountCents: charge.AmountCents,
+			Processor:   charge.Processor,
+			Reference:   charge.Reference,
+		})
+		if err != nil {
+			return err
+		}
+		result.Payment = &payment
+		return nil
+	})
+	if err != nil {
+		if charge != nil {
+			voidCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), voidChargeTimeout)
+			defer cancel()
+			if voidErr := processor.Void(voidCtx, *charge); voidErr != nil {
+				return VisitPackPurchaseResult{}, fmt.Errorf("%w (void charge %s: %v)", err, charge.Reference, voidErr)
+			}
+		}
+		return VisitPackPurchaseResult{}, err
+	}
+	return result, nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	db "github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 	"github.com/codr1/Pickleicious/internal/email"
@@ -509,14 +510,7 @@ func (e *Engine) notifyOpenPlayCancellation(ctx context.Context, queries *dbgen.
 		return err
 	}
 
-	facilityLoc := time.Local
-	if facility.Timezone != "" {
-		if loadedLoc, loadErr := time.LoadLocation(facility.Timezone); loadErr == nil {
-			facilityLoc = loadedLoc
-		} else {
-			log.Ctx(ctx).Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone for open play cancellation email")
-		}
-	}
+	facilityLoc := apiutil.FacilityLocation(facility, log.Ctx(ctx))
 
 	date, timeRange := email.FormatDateTimeRange(session.StartTime.In(facilityLoc), session.EndTime.In(facilityLoc))
 	courtLabel := "TBD"
@@ -552,7 +546,9 @@ func (e *Engine) notifyOpenPlayCancellation(ctx context.Context, queries *dbgen.
 		return nil
 	}
 	for _, participant := range participants {
-		email.SendCancellationEmail(ctx, queries, e.emailClient, participant.ID, message, sender, log.Ctx(ctx))
+		if email.ShouldSend(ctx, queries, participant.ID, email.PreferenceCancellations) {
+			email.SendCancellationEmail(ctx, queries, e.emailClient, participant.ID, message, sender, log.Ctx(ctx))
+		}
 	}
 	return nil
 }
@@ -0,0 +1,373 @@
+package openplay
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	db "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// MaxGenerationDays caps how many days one generation run may cover.
+const MaxGenerationDays = 92
+
+const openPlayReservationTypeName = "OPEN_PLAY"
+
+// Reasons a slot occurrence was not materialized.
+const (
+	SkipReasonSessionExists       = "session_exists"
+	SkipReasonStarted             = "started"
+	SkipReasonFacilityClosed      = "facility_closed"
+	SkipReasonOutsideHours        = "outside_operating_hours"
+	SkipReasonCourtsUnavailable   = "courts_unavailable"
+	SkipReasonReservationConflict = "reservation_conflict"
+)
+
+// GenerationRequest selects the facility-local dates, inclusive, to
+// materialize rule slots for.
+type GenerationRequest struct {
+	FacilityID int64
+	From       time.Time
+	To         time.Time
+	// CreatedByUserID is recorded on new reservations. Zero attributes each
+	// reservation to the staff member who added its slot.
+	CreatedByUserID int64
+	// Now defaults to time.Now; occurrences starting before it are skipped.
+	Now time.Time
+}
+
+// GeneratedSession is a slot occurrence that now has a session.
+type GeneratedSession struct {
+	SessionID      int64     `json:"sessionId"`
+	ReservationID  int64     `json:"reservationId"`
+	OpenPlayRuleID int64     `json:"openPlayRuleId"`
+	RuleName       string    `json:"ruleName"`
+	StartTime      time.Time `json:"startTime"`
+	EndTime        time.Time `json:"endTime"`
+	CourtCount     int64     `json:"courtCount"`
+}
+
+// SkippedSession is a slot occurrence left without a new session.
+type SkippedSession struct {
+	OpenPlayRuleID int64     `json:"openPlayRuleId"`
+	RuleName       string    `json:"ruleName"`
+	StartTime      time.Time `json:"startTime"`
+	EndTime        time.Time `json:"endTime"`
+	Reason         string    `json:"reason"`
+	Detail         string    `json:"detail,omitempty"`
+}
+
+// GenerationSummary reports every slot occurrence in the requested range.
+type GenerationSummary struct {
+	Created []GeneratedSession `json:"created"`
+	Skipped []SkippedSession   `json:"skipped"`
+}
+
+type slotOccurrence struct {
+	slot  dbgen.ListFacilityOpenPlayRuleSlotsRow
+	start time.Time
+	end   time.Time
+}
+
+type dayWindow struct {
+	opens  time.Time
+	closes time.Time
+	open   bool
+}
+
+// GenerateSessions materializes a scheduled session and its backing OPEN_PLAY
+// reservation for each rule slot occurrence in the range. Occurrences that
+// already have a session, fall outside facility hours, or cannot get the
+// rule's minimum courts are reported as skipped. Each occurrence commits on
+// its own, so a rerun picks up only what is still missing.
+func (e *Engine) GenerateSessions(ctx context.Context, req GenerationRequest) (GenerationSummary, error) {
+	summary := GenerationSummary{Created: []GeneratedSession{}, Skipped: []SkippedSession{}}
+	if e == nil || e.db == nil || e.db.Queries == nil {
+		return summary, errors.New("open play engine not initialized")
+	}
+	q := e.db.Queries
+
+	now := req.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	facility, err := q.GetFacilityByID(ctx, req.FacilityID)
+	if err != nil {
+		return summary, fmt.Errorf("load facility %d: %w", req.FacilityID, err)
+	}
+	loc := apiutil.FacilityLocation(facility, log.Ctx(ctx))
+
+	fromDate := time.Date(req.From.Year(), req.From.Month(), req.From.Day(), 0, 0, 0, 0, loc)
+	toDate := time.Date(req.To.Year(), req.To.Month(), req.To.Day(), 0, 0, 0, 0, loc)
+	if toDate.Before(fromDate) {
+		return summary, errors.New("generation range ends before it starts")
+	}
+	if toDate.After(fromDate.AddDate(0, 0, MaxGenerationDays-1)) {
+		return summary, fmt.Errorf("generation range exceeds %d days", MaxGenerationDays)
+	}
+
+	slots, err := q.ListFacilityOpenPlayRuleSlots(ctx, req.FacilityID)
+	if err != nil {
+		return summary, fmt.Errorf("list open play rule slots: %w", err)
+	}
+	if len(slots) == 0 {
+		return summary, nil
+	}
+
+	hours, err := q.GetFacilityHours(ctx, req.FacilityID)
+	if err != nil {
+		return summary, fmt.Errorf("load operating hours: %w", err)
+	}
+	reservationType, err := q.GetReservationTypeByName(ctx, openPlayReservationTypeName)
+	if err != nil {
+		return summary, fmt.Errorf("load open play reservation type: %w", err)
+	}
+
+	for date := fromDate; !date.After(toDate); date = date.AddDate(0, 0, 1) {
+		window, err := facilityDayWindow(ctx, q, req.FacilityID, hours, date)
+		if err != nil {
+			return summary, err
+		}
+
+		for _, slot := range slots {
+			if slot.DayOfWeek != int64(date.Weekday()) {
+				continue
+			}
+			occurrence, err := newSlotOccurrence(slot, date)
+			if err != nil {
+				return summary, err
+			}
+
+			skip := func(reason, detail string) {
+				summary.Skipped = append(summary.Skipped, SkippedSession{
+					OpenPlayRuleID: slot.OpenPlayRuleID,
+					RuleName:       slot.RuleName,
+					StartTime:      occurrence.start,
+					EndTime:        occurrence.end,
+					Reason:         reason,
+					Detail:         detail,
+				})
+			}
+
+			switch {
+			case occurrence.start.Before(now):
+				skip(SkipReasonStarted, "")
+				continue
+			case !window.open:
+				skip(SkipReasonFacilityClosed, "")
+				continue
+			case occurrence.start.Before(window.opens) || occurrence.end.After(window.closes):
+				skip(SkipReasonOutsideHours, fmt.Sprintf("open %s-%s", window.opens.Format("15:04"), window.closes.Format("15:04")))
+				continue
+			}
+
+			createdBy := req.CreatedByUserID
+			if createdBy <= 0 {
+				createdBy = slot.CreatedByUserID
+			}
+			created, reason, detail, err := e.materializeOccurrence(ctx, occurrence, req.FacilityID, reservationType.ID, createdBy)
+			if err != nil {
+				return summary, err
+			}
+			if reason != "" {
+				skip(reason, detail)
+				continue
+			}
+			summary.Created = append(summary.Created, created)
+		}
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("facility_id", req.FacilityID).
+		Int("created", len(summary.Created)).
+		Int("skipped", len(summary.Skipped)).
+		Msg("Generated open play sessions")
+	return summary, nil
+}
+
+// GenerateUpcomingSessions runs GenerateSessions for the days facility dates
+// starting with the facility's today, attributing reservations to each slot's
+// creator.
+func (e *Engine) GenerateUpcomingSessions(ctx context.Context, facilityID int64, days int, now time.Time) (GenerationSummary, error) {
+	if e == nil || e.db == nil || e.db.Queries == nil {
+		return GenerationSummary{}, errors.New("open play engine not initialized")
+	}
+	if days > MaxGenerationDays {
+		days = MaxGenerationDays
+	}
+	facility, err := e.db.Queries.GetFacilityByID(ctx, facilityID)
+	if err != nil {
+		return GenerationSummary{}, fmt.Errorf("load facility %d: %w", facilityID, err)
+	}
+	today := now.In(apiutil.FacilityLocation(facility, log.Ctx(ctx)))
+	return e.GenerateSessions(ctx, GenerationRequest{
+		FacilityID: facilityID,
+		From:       today,
+		To:         today.AddDate(0, 0, days-1),
+		Now:        now,
+	})
+}
+
+// materializeOccurrence creates the session for one occurrence, backed by a
+// new reservation on the rule's minimum courts or, when staff already booked
+// one, by that reservation. A non-empty reason means nothing was created.
+func (e *Engine) materializeOccurrence(ctx context.Context, occurrence slotOccurrence, facilityID, reservationTypeID, createdByUserID int64) (GeneratedSession, string, string, error) {
+	var (
+		created GeneratedSession
+		reason  string
+		detail  string
+	)
+	slot := occurrence.slot
+	start := occurrence.start.UTC()
+	end := occurrence.end.UTC()
+
+	err := e.db.RunInTx(ctx, func(txdb *db.DB) error {
+		qtx := txdb.Queries
+
+		sessionCount, err := qtx.CountOpenPlaySessionsForSlot(ctx, dbgen.CountOpenPlaySessionsForSlotParams{
+			FacilityID:     facilityID,
+			OpenPlayRuleID: slot.OpenPlayRuleID,
+			StartTime:      start,
+			EndTime:        end,
+		})
+		if err != nil {
+			return fmt.Errorf("count open play sessions for rule %d: %w", slot.OpenPlayRuleID, err)
+		}
+		if sessionCount > 0 {
+			reason = SkipReasonSessionExists
+			return nil
+		}
+
+		ruleID := sql.NullInt64{Int64: slot.OpenPlayRuleID, Valid: true}
+		reservationCount, err := qtx.CountOpenPlayReservationsForSession(ctx, dbgen.CountOpenPlayReservationsForSessionParams{
+			FacilityID:     facilityID,
+			OpenPlayRuleID: ruleID,
+			StartTime:      start,
+			EndTime:        end,
+		})
+		if err != nil {
+			return fmt.Errorf("count open play reservations for rule %d: %w", slot.OpenPlayRuleID, err)
+		}
+
+		var (
+			reservationID int64
+			courtCount    int64
+		)
+		switch {
+		case reservationCount > 1:
+			// Sessions resolve their reservation by rule and time, so a
+			// second one would make signups ambiguous.
+			reason = SkipReasonReservationConflict
+			detail = fmt.Sprintf("%d open play reservations already cover this slot", reservationCount)
+			return nil
+		case reservationCount == 1:
+			reservationID, err = qtx.GetOpenPlayReservationID(ctx, dbgen.GetOpenPlayReservationIDParams{
+				FacilityID:     facilityID,
+				OpenPlayRuleID: ruleID,
+				StartTime:      start,
+				EndTime:        end,
+			})
+			if err != nil {
+				return fmt.Errorf("lookup open play reservation for rule %d: %w", slot.OpenPlayRuleID, err)
+			}
+			courts, err := listReservationCourts(ctx, qtx, reservationID)
+			if err != nil {
+				return err
+			}
+			courtCount = int64(len(courts))
+		default:
+			available, err := qtx.ListAvailableCourts(ctx, dbgen.ListAvailableCourtsParams{
+				FacilityID: facilityID,
+				StartTime:  start,
+				EndTime:    end,
+			})
+			if err != nil {
+				return fmt.Errorf("list available courts for rule %d: %w", slot.OpenPlayRuleID, err)
+			}
+			if int64(len(available)) < slot.MinCourts {
+				reason = SkipReasonCourtsUnavailable
+				detail = fmt.Sprintf("%d of %d required courts free", len(available), slot.MinCourts)
+				return nil
+			}
+
+			reservation, err := qtx.CreateReservation(ctx, dbgen.CreateReservationParams{
+				FacilityID:        facilityID,
+				ReservationTypeID: reservationTypeID,
+				CreatedByUserID:   createdByUserID,
+				OpenPlayRuleID:    ruleID,
+				StartTime:         start,
+				EndTime:           end,
+				IsOpenEvent:       true,
+			})
+			if err != nil {
+				return fmt.Errorf("create open play reservation for rule %d: %w", slot.OpenPlayRuleID, err)
+			}
+			reservationID = reservation.ID
+			if err := addReservationCourts(ctx, qtx, reservationID, mapAvailableCourts(available[:slot.MinCourts])); err != nil {
+				return err
+			}
+			courtCount = slot.MinCourts
+		}
+
+		session, err := qtx.CreateOpenPlaySession(ctx, dbgen.CreateOpenPlaySessionParams{
+			FacilityID:        facilityID,
+			OpenPlayRuleID:    slot.OpenPlayRuleID,
+			StartTime:         start,
+			EndTime:           end,
+			Status:            "scheduled",
+			CurrentCourtCount: courtCount,
+		})
+		if err != nil {
+			return fmt.Errorf("create open play session for rule %d: %w", slot.OpenPlayRuleID, err)
+		}
+
+		created = GeneratedSession{
+			SessionID:      session.ID,
+			ReservationID:  reservationID,
+			OpenPlayRuleID: slot.OpenPlayRuleID,
+			RuleName:       slot.RuleName,
+			StartTime:      occurrence.start,
+			EndTime:        occurrence.end,
+			CourtCount:     courtCount,
+		}
+		return nil
+	})
+	if err != nil {
+		return GeneratedSession{}, "", "", err
+	}
+	return created, reason, detail, nil
+}
+
+// facilityDayWindow returns date's hours: its override when one exists,
+// otherwise the weekly schedule. Days with neither are closed.
+func facilityDayWindow(ctx context.Context, q *dbgen.Queries, facilityID int64, hours []dbgen.OperatingHour, date time.Time) (dayWindow, error) {
+	override, err := apiutil.LoadFacilityHoursOverride(ctx, q, facilityID, date)
+	if err != nil {
+		return dayWindow{}, fmt.Errorf("load hours override for %s: %w", date.Format(apiutil.HoursOverrideDateLayout), err)
+	}
+	opens, closes, ok := apiutil.OperatingHoursWindow(date, hours, override)
+	return dayWindow{opens: opens, closes: closes, open: ok}, nil
+}
+
+func newSlotOccurrence(slot dbgen.ListFacilityOpenPlayRuleSlotsRow, date time.Time) (slotOccurrence, error) {
+	startClock, err := time.Parse("15:04", slot.StartTime)
+	if err != nil {
+		return slotOccurrence{}, fmt.Errorf("invalid start_time for open play rule slot %d: %w", slot.ID, err)
+	}
+	endClock, err := time.Parse("15:04", slot.EndTime)
+	if err != nil {
+		return slotOccurrence{}, fmt.Errorf("invalid end_time for open play rule slot %d: %w", slot.ID, err)
+	}
+	return slotOccurrence{
+		slot:  slot,
+		start: time.Date(date.Year(), date.Month(), date.Day(), startClock.Hour(), startClock.Minute(), 0, 0, date.Location()),
+		end:   time.Date(date.Year(), date.Month(), date.Day(), endClock.Hour(), endClock.Minute(), 0, 0, date.Location()),
+	}, nil
+}
@@ -0,0 +1,194 @@
+package openplay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const (
+	// PlayersPerGame is how many players each game takes off the queue.
+	PlayersPerGame = 4
+	// CheckinOpensBeforeStart is how early players may check in.
+	CheckinOpensBeforeStart = 30 * time.Minute
+	// NoShowGracePeriod is how long after the start staff must wait before
+	// bumping players who never checked in.
+	NoShowGracePeriod = 15 * time.Minute
+)
+
+// SessionCheckin identifies a player arriving at a session and its backing
+// reservation.
+type SessionCheckin struct {
+	SessionID         int64
+	ReservationID     int64
+	StartTime         time.Time
+	EndTime           time.Time
+	UserID            int64
+	CheckedInByUserID int64
+}
+
+// QueuedPlayer is one checked-in player in rotation order. Position is 1 for
+// the player up next.
+type QueuedPlayer struct {
+	Position    int       `json:"position"`
+	UserID      int64     `json:"userId"`
+	FirstName   string    `json:"firstName"`
+	LastName    string    `json:"lastName"`
+	GamesPlayed int64     `json:"gamesPlayed"`
+	JoinedAt    time.Time `json:"joinedAt"`
+}
+
+// Game is a game called off the queue with the players sent to the court.
+type Game struct {
+	ID        int64          `json:"id"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Players   []QueuedPlayer `json:"players"`
+}
+
+// CheckIn marks a signed-up player present on the session's reservation and
+// adds them to the back of the rotation queue. Run it inside a transaction.
+func CheckIn(ctx context.Context, q *dbgen.Queries, checkin SessionCheckin, now time.Time) (QueuedPlayer, error) {
+	if now.Before(checkin.StartTime.Add(-CheckinOpensBeforeStart)) {
+		return QueuedPlayer{}, apiutil.HandlerError{
+			Status:  http.StatusConflict,
+			Message: fmt.Sprintf("Check-in opens %d minutes before the session starts", int(CheckinOpensBeforeStart.Minutes())),
+		}
+	}
+	if !now.Before(checkin.EndTime) {
+		return QueuedPlayer{}, apiutil.HandlerError{Status: http.StatusConflict, Message: "Check-in closed when the session ended"}
+	}
+
+	roster, err := q.ListReservationCheckinRoster(ctx, checkin.ReservationID)
+	if err != nil {
+		return QueuedPlayer{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load participants", Err: err}
+	}
+	var (
+		signedUp  bool
+		checkedIn bool
+	)
+	for _, row := range roster {
+		if row.UserID == checkin.UserID {
+			signedUp = true
+			checkedIn = row.CheckedInAt.Valid
+			break
+		}
+	}
+	if !signedUp {
+		return QueuedPlayer{}, apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Player is not signed up for this session"}
+	}
+
+	queue, err := Queue(ctx, q, checkin.SessionID)
+	if err != nil {
+		return QueuedPlayer{}, err
+	}
+	for _, player := range queue {
+		if player.UserID == checkin.UserID {
+			return QueuedPlayer{}, apiutil.HandlerError{Status: http.StatusConflict, Message: "Player is already checked in"}
+		}
+	}
+
+	// A player the front desk already checked in against the reservation
+	// only needs a place in the queue.
+	if !checkedIn {
+		if _, err := q.CreateReservationCheckin(ctx, dbgen.CreateReservationCheckinParams{
+			ReservationID:     checkin.ReservationID,
+			UserID:            checkin.UserID,
+			CheckedInAt:       now,
+			CheckedInByUserID: checkin.CheckedInByUserID,
+		}); err != nil {
+			return QueuedPlayer{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to record check-in", Err: err}
+		}
+	}
+	if _, err := q.CreateOpenPlayQueueEntry(ctx, dbgen.CreateOpenPlayQueueEntryParams{
+		SessionID: checkin.SessionID,
+		UserID:    checkin.UserID,
+	}); err != nil {
+		return QueuedPlayer{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to join the queue", Err: err}
+	}
+
+	queue, err = Queue(ctx, q, checkin.SessionID)
+	if err != nil {
+		return QueuedPlayer{}, err
+	}
+	return queue[len(queue)-1], nil
+}
+
+// Queue lists the session's checked-in players, up next first.
+func Queue(ctx context.Context, q *dbgen.Queries, sessionID int64) ([]QueuedPlayer, error) {
+	rows, err := q.ListOpenPlayQueue(ctx, sessionID)
+	if err != nil {
+		return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load the queue", Err: err}
+	}
+	queue := make([]QueuedPlayer, 0, len(rows))
+	for i, row := range rows {
+		queue = append(queue, QueuedPlayer{
+			Position:    i + 1,
+			UserID:      row.UserID,
+			FirstName:   row.FirstName,
+			LastName:    row.LastName,
+			GamesPlayed: row.GamesPlayed,
+			JoinedAt:    row.JoinedAt,
+		})
+	}
+	return queue, nil
+}
+
+// AdvanceQueue calls the next game: it records the first PlayersPerGame
+// players as a game and sends them to the back of the queue in the order
+// they were called. Run it inside a transaction.
+func AdvanceQueue(ctx context.Context, q *dbgen.Queries, sessionID, recordedByUserID int64) (Game, error) {
+	queue, err := Queue(ctx, q, sessionID)
+	if err != nil {
+		return Game{}, err
+	}
+	if len(queue) < PlayersPerGame {
+		return Game{}, apiutil.HandlerError{
+			Status:  http.StatusConflict,
+			Message: fmt.Sprintf("A game needs %d checked-in players; %d are waiting", PlayersPerGame, len(queue)),
+		}
+	}
+
+	row, err := q.CreateOpenPlayGame(ctx, dbgen.CreateOpenPlayGameParams{
+		SessionID:        sessionID,
+		RecordedByUserID: recordedByUserID,
+	})
+	if err != nil {
+		return Game{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to record game", Err: err}
+	}
+	game := Game{ID: row.ID, CreatedAt: row.CreatedAt, Players: queue[:PlayersPerGame]}
+	for _, player := range game.Players {
+		if err := q.AddOpenPlayGamePlayer(ctx, dbgen.AddOpenPlayGamePlayerParams{
+			GameID: row.ID,
+			UserID: player.UserID,
+		}); err != nil {
+			return Game{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to record game", Err: err}
+		}
+		if err := q.RequeueOpenPlayPlayer(ctx, dbgen.RequeueOpenPlayPlayerParams{
+			SessionID: sessionID,
+			UserID:    player.UserID,
+		}); err != nil {
+			return Game{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update the queue", Err: err}
+		}
+	}
+	return game, nil
+}
+
+// NoShows lists signed-up players on the session's reservation who have not
+// checked in.
+func NoShows(ctx context.Context, q *dbgen.Queries, reservationID int64) ([]dbgen.ListReservationCheckinRosterRow, error) {
+	roster, err := q.ListReservationCheckinRoster(ctx, reservationID)
+	if err != nil {
+		return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load participants", Err: err}
+	}
+	var noShows []dbgen.ListReservationCheckinRosterRow
+	for _, row := range roster {
+		if !row.CheckedInAt.Valid {
+			noShows = append(noShows, row)
+		}
+	}
+	return noShows, nil
+}
@@ -0,0 +1,69 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const fakeProcessorName = "fake"
+
+// FakeProcessor approves every charge unless Err is set. It is meant for
+// tests and local development; no money moves.
+type FakeProcessor struct {
+	mu      sync.Mutex
+	Err     error
+	charges []ChargeRequest
+	voids   []Charge
+}
+
+func NewFakeProcessor() *FakeProcessor {
+	return &FakeProcessor{}
+}
+
+func (p *FakeProcessor) Charge(ctx context.Context, req ChargeRequest) (Charge, error) {
+	if err := ctx.Err(); err != nil {
+		return Charge{}, err
+	}
+	if req.AmountCents <= 0 {
+		return Charge{}, fmt.Errorf("charge amount must be positive")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.Err != nil {
+		return Charge{}, p.Err
+	}
+	p.charges = append(p.charges, req)
+	return Charge{
+		Processor:   fakeProcessorName,
+		Reference:   fmt.Sprintf("fake_ch_%d", len(p.charges)),
+		AmountCents: req.AmountCents,
+	}, nil
+}
+
+// Charges returns the approved charge requests in order.
+func (p *FakeProcessor) Charges() []ChargeRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]ChargeRequest(nil), p.charges...)
+}
+
+// Void records the charge as voided.
+func (p *FakeProcessor) Void(ctx context.Context, charge Charge) error {
+	if charge.Processor != fakeProcessorName || charge.Reference == "" {
+		return fmt.Errorf("unknown charge %q", charge.Reference)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.voids = append(p.voids, charge)
+	return nil
+}
+
+// Voids returns the voided charges in order.
+func (p *FakeProcessor) Voids() []Charge {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Charge(nil), p.voids...)
+}
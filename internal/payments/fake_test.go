@@ -0,0 +1,41 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFakeProcessor(t *testing.T) {
+	processor := NewFakeProcessor()
+
+	charge, err := processor.Charge(context.Background(), ChargeRequest{UserID: 7, AmountCents: 4500, Description: "10-visit pack"})
+	if err != nil {
+		t.Fatalf("Charge: %v", err)
+	}
+	if charge.Processor != "fake" || charge.Reference != "fake_ch_1" || charge.AmountCents != 4500 {
+		t.Fatalf("unexpected charge: %+v", charge)
+	}
+
+	if _, err := processor.Charge(context.Background(), ChargeRequest{UserID: 7}); err == nil {
+		t.Fatal("expected a zero-amount charge to fail")
+	}
+
+	processor.Err = ErrDeclined
+	if _, err := processor.Charge(context.Background(), ChargeRequest{UserID: 7, AmountCents: 100}); !errors.Is(err, ErrDeclined) {
+		t.Fatalf("expected decline, got %v", err)
+	}
+	if got := processor.Charges(); len(got) != 1 || got[0].AmountCents != 4500 {
+		t.Fatalf("expected only the approved charge to be recorded, got %+v", got)
+	}
+
+	if err := processor.Void(context.Background(), charge); err != nil {
+		t.Fatalf("Void: %v", err)
+	}
+	if err := processor.Void(context.Background(), Charge{Processor: "other", Reference: "ch_1"}); err == nil {
+		t.Fatal("expected another processor's charge to be rejected")
+	}
+	if got := processor.Voids(); len(got) != 1 || got[0].Reference != "fake_ch_1" {
+		t.Fatalf("expected the approved charge to be voided, got %+v", got)
+	}
+}
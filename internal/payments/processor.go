@@ -0,0 +1,35 @@
+// Package payments defines how purchase handlers take payment. Handlers
+// depend only on PaymentProcessor so a card provider can be wired in at
+// startup without changing them.
+package payments
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrDeclined reports that the processor refused the charge.
+var ErrDeclined = errors.New("payment declined")
+
+type ChargeRequest struct {
+	FacilityID  int64
+	UserID      int64
+	AmountCents int64
+	Description string
+}
+
+// Charge is the processor's record of a successful payment.
+type Charge struct {
+	Processor   string
+	Reference   string
+	AmountCents int64
+}
+
+// PaymentProcessor takes payment for purchases. Callers charge before
+// opening a database transaction and void the charge if recording the
+// purchase fails, so a member is never billed for something not created.
+type PaymentProcessor interface {
+	Charge(ctx context.Context, req ChargeRequest) (Charge, error)
+	// Void cancels or refunds a charge this processor made.
+	Void(ctx context.Context, charge Charge) error
+}
@@ -0,0 +1,150 @@
+// Package pricing works out what court time costs under a facility's court
+// pricing rules. It only quotes prices: a Quote's AmountCents is what a
+// payments.PaymentProcessor would be asked to charge once bookings take
+// payment, and reservations store it so refunds can be worked out from it.
+package pricing
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// MemberLevel is the lowest membership level charged the member rate. Lower
+// levels are guests and pay the non-member rate.
+const MemberLevel = 2
+
+type RulesQuerier interface {
+	ListCourtPricingRules(ctx context.Context, facilityID int64) ([]dbgen.CourtPricingRule, error)
+}
+
+// Request is a proposed court booking. Start and End must already be in
+// facility time.
+type Request struct {
+	Start  time.Time
+	End    time.Time
+	Courts int
+	// Member selects the rules' member rate over the non-member one.
+	Member bool
+}
+
+// Quote is the price of a Request.
+type Quote struct {
+	// PerCourtCents is one court for the whole booking.
+	PerCourtCents int64
+	// AmountCents is PerCourtCents for every court booked.
+	AmountCents int64
+	Member      bool
+	// Priced is false when no rule covers any of the booking, so the
+	// facility does not charge for it.
+	Priced bool
+}
+
+// QuoteBooking prices req under facilityID's rules.
+func QuoteBooking(ctx context.Context, q RulesQuerier, facilityID int64, req Request) (Quote, error) {
+	rules, err := q.ListCourtPricingRules(ctx, facilityID)
+	if err != nil {
+		return Quote{}, err
+	}
+	return Price(rules, req), nil
+}
+
+// window is one rule's stretch of a booking, at the rate that applies to it.
+type window struct {
+	start        time.Time
+	end          time.Time
+	centsPerHour int64
+}
+
+// Price prices req under rules. Each stretch of the booking costs the highest
+// hourly rate among the rules covering it; stretches no rule covers are free.
+// The total is rounded to the nearest cent once, after summing.
+func Price(rules []dbgen.CourtPricingRule, req Request) Quote {
+	quote := Quote{Member: req.Member}
+	windows := ruleWindows(rules, req)
+	if len(windows) == 0 {
+		return quote
+	}
+
+	cuts := []time.Time{req.Start, req.End}
+	for _, w := range windows {
+		cuts = append(cuts, w.start, w.end)
+	}
+	sort.Slice(cuts, func(i, j int) bool { return cuts[i].Before(cuts[j]) })
+
+	// centSeconds is cents per hour times seconds, so rates that don't divide
+	// evenly by the minute still add up exactly.
+	var centSeconds int64
+	for i := 0; i+1 < len(cuts); i++ {
+		from, to := cuts[i], cuts[i+1]
+		if !to.After(from) {
+			continue
+		}
+		var rate int64
+		for _, w := range windows {
+			if !w.start.After(from) && w.end.After(from) && w.centsPerHour > rate {
+				rate = w.centsPerHour
+			}
+		}
+		centSeconds += rate * int64(to.Sub(from)/time.Second)
+	}
+
+	courts := int64(req.Courts)
+	if courts < 1 {
+		courts = 1
+	}
+	quote.Priced = true
+	quote.PerCourtCents = (centSeconds + 1800) / 3600
+	quote.AmountCents = quote.PerCourtCents * courts
+	return quote
+}
+
+// ruleWindows clips every rule occurrence between req.Start and req.End to
+// the booking. A booking past midnight picks up the next day's rules.
+func ruleWindows(rules []dbgen.CourtPricingRule, req Request) []window {
+	if !req.End.After(req.Start) {
+		return nil
+	}
+	loc := req.Start.Location()
+	var windows []window
+	for day := time.Date(req.Start.Year(), req.Start.Month(), req.Start.Day(), 0, 0, 0, 0, loc); day.Before(req.End); day = day.AddDate(0, 0, 1) {
+		for _, rule := range rules {
+			if rule.DayOfWeek != int64(day.Weekday()) {
+				continue
+			}
+			ruleStart, err := time.Parse("15:04", rule.StartTime)
+			if err != nil {
+				continue
+			}
+			ruleEnd, err := time.Parse("15:04", rule.EndTime)
+			if err != nil {
+				continue
+			}
+			start := time.Date(day.Year(), day.Month(), day.Day(), ruleStart.Hour(), ruleStart.Minute(), 0, 0, loc)
+			end := time.Date(day.Year(), day.Month(), day.Day(), ruleEnd.Hour(), ruleEnd.Minute(), 0, 0, loc)
+			if start.Before(req.Start) {
+				start = req.Start
+			}
+			if end.After(req.End) {
+				end = req.End
+			}
+			if !end.After(start) {
+				continue
+			}
+			rate := rule.NonMemberPricePerHourCents
+			if req.Member {
+				rate = rule.MemberPricePerHourCents
+			}
+			windows = append(windows, window{start: start, end: end, centsPerHour: rate})
+		}
+	}
+	return windows
+}
+
+// RefundCents is the part of amountCents a cancellation refunding
+// refundPercentage gives back, rounded to the nearest cent.
+func RefundCents(amountCents, refundPercentage int64) int64 {
+	return (amountCents*refundPercentage + 50) / 100
+}
@@ -0,0 +1,105 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestPrice(t *testing.T) {
+	// Friday, October 16, 2026.
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2026, time.October, 16, hour, minute, 0, 0, time.UTC)
+	}
+	rules := []dbgen.CourtPricingRule{
+		{DayOfWeek: int64(time.Friday), StartTime: "08:00", EndTime: "22:00", MemberPricePerHourCents: 2000, NonMemberPricePerHourCents: 3000},
+		{DayOfWeek: int64(time.Friday), StartTime: "17:00", EndTime: "21:00", MemberPricePerHourCents: 3500, NonMemberPricePerHourCents: 5000},
+		{DayOfWeek: int64(time.Saturday), StartTime: "00:00", EndTime: "02:00", MemberPricePerHourCents: 1000, NonMemberPricePerHourCents: 1000},
+	}
+
+	tests := []struct {
+		name     string
+		req      Request
+		perCourt int64
+		amount   int64
+		priced   bool
+	}{
+		{
+			name:     "off-peak member hour",
+			req:      Request{Start: friday(10, 0), End: friday(11, 0), Courts: 1, Member: true},
+			perCourt: 2000, amount: 2000, priced: true,
+		},
+		{
+			name:     "peak rate wins where windows overlap",
+			req:      Request{Start: friday(18, 0), End: friday(19, 30), Courts: 1, Member: true},
+			perCourt: 5250, amount: 5250, priced: true,
+		},
+		{
+			name:     "booking straddling the peak start",
+			req:      Request{Start: friday(16, 30), End: friday(17, 30), Courts: 2},
+			perCourt: 4000, amount: 8000, priced: true,
+		},
+		{
+			name:     "uncovered time is free",
+			req:      Request{Start: friday(7, 0), End: friday(9, 0), Courts: 1, Member: true},
+			perCourt: 2000, amount: 2000, priced: true,
+		},
+		{
+			name:     "past midnight picks up the next day's rules",
+			req:      Request{Start: friday(23, 0), End: friday(25, 0), Courts: 1},
+			perCourt: 1000, amount: 1000, priced: true,
+		},
+		{
+			name:   "no rule covers the booking",
+			req:    Request{Start: friday(6, 0), End: friday(7, 0), Courts: 1},
+			priced: false,
+		},
+		{
+			name:     "rounds to the nearest cent",
+			req:      Request{Start: friday(10, 0), End: friday(10, 20), Courts: 1, Member: true},
+			perCourt: 667, amount: 667, priced: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := Price(rules, tt.req)
+			if quote.Priced != tt.priced || quote.PerCourtCents != tt.perCourt || quote.AmountCents != tt.amount {
+				t.Fatalf("expected priced=%v per court %d total %d, got %+v", tt.priced, tt.perCourt, tt.amount, quote)
+			}
+			if quote.Member != tt.req.Member {
+				t.Fatalf("expected member=%v, got %+v", tt.req.Member, quote)
+			}
+		})
+	}
+}
+
+func TestPriceFollowsFacilityWallClock(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	rules := []dbgen.CourtPricingRule{
+		{DayOfWeek: int64(time.Friday), StartTime: "17:00", EndTime: "21:00", MemberPricePerHourCents: 4000, NonMemberPricePerHourCents: 6000},
+	}
+	start := time.Date(2026, time.October, 16, 17, 0, 0, 0, loc)
+	quote := Price(rules, Request{Start: start, End: start.Add(time.Hour), Courts: 1, Member: true})
+	if quote.AmountCents != 4000 {
+		t.Fatalf("expected the 5pm facility-time rule to apply, got %+v", quote)
+	}
+}
+
+func TestRefundCents(t *testing.T) {
+	for _, tt := range []struct {
+		amount, percent, want int64
+	}{
+		{5000, 100, 5000},
+		{5000, 50, 2500},
+		{5000, 0, 0},
+		{1999, 50, 1000},
+	} {
+		if got := RefundCents(tt.amount, tt.percent); got != tt.want {
+			t.Fatalf("RefundCents(%d, %d) = %d, want %d", tt.amount, tt.percent, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+// Package qrcode encodes short byte payloads as QR Code symbols.
+//
+// It supports byte mode at error correction level M for versions 1-10
+// (up to 213 bytes), which covers signed check-in tokens and URLs.
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// quietZone is the light border, in modules, required around a symbol.
+const quietZone = 4
+
+// ErrTooLong is returned when a payload does not fit the largest supported version.
+var ErrTooLong = errors.New("qrcode: payload too long")
+
+// versionSpec describes the level M block structure of one version.
+type versionSpec struct {
+	ecPerBlock int
+	groups     [2]blockGroup
+	alignment  []int
+}
+
+type blockGroup struct {
+	blocks       int
+	dataPerBlock int
+}
+
+func (v versionSpec) dataCodewords() int {
+	return v.groups[0].blocks*v.groups[0].dataPerBlock + v.groups[1].blocks*v.groups[1].dataPerBlock
+}
+
+// versions is indexed by version number; entry 0 is unused.
+var versions = []versionSpec{
+	{},
+	{ecPerBlock: 10, groups: [2]blockGroup{{1, 16}}},
+	{ecPerBlock: 16, groups: [2]blockGroup{{1, 28}}, alignment: []int{6, 18}},
+	{ecPerBlock: 26, groups: [2]blockGroup{{1, 44}}, alignment: []int{6, 22}},
+	{ecPerBlock: 18, groups: [2]blockGroup{{2, 32}}, alignment: []int{6, 26}},
+	{ecPerBlock: 24, groups: [2]blockGroup{{2, 43}}, alignment: []int{6, 30}},
+	{ecPerBlock: 16, groups: [2]blockGroup{{4, 27}}, alignment: []int{6, 34}},
+	{ecPerBlock: 18, groups: [2]blockGroup{{4, 31}}, alignment: []int{6, 22, 38}},
+	{ecPerBlock: 22, groups: [2]blockGroup{{2, 38}, {2, 39}}, alignment: []int{6, 24, 42}},
+	{ecPerBlock: 22, groups: [2]blockGroup{{3, 36}, {2, 37}}, alignment: []int{6, 26, 46}},
+	{ecPerBlock: 26, groups: [2]blockGroup{{4, 43}, {1, 44}}, alignment: []int{6, 28, 50}},
+}
+
+// Code is an encoded symbol. Modules are indexed [y][x]; true is dark.
+type Code struct {
+	Version int
+	Size    int
+	modules [][]bool
+	reserve [][]bool
+}
+
+// Dark reports whether the module at (x, y) is dark.
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// Encode builds the smallest symbol that holds data.
+func Encode(data []byte) (*Code, error) {
+	version := 0
+	for v := 1; v < len(versions); v++ {
+		if 4+countBits(v)+8*len(data) <= 8*versions[v].dataCodewords() {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	code := newCode(version)
+	code.drawFunctionPatterns()
+	code.drawCodewords(interleave(versions[version], encodeData(version, data)))
+
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		code.applyMask(mask)
+		code.drawFormatBits(mask)
+		if penalty := code.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		code.applyMask(mask)
+	}
+	code.applyMask(best)
+	code.drawFormatBits(best)
+	return code, nil
+}
+
+// PNG renders the symbol with its quiet zone, scale pixels per module.
+func (c *Code) PNG(scale int) ([]byte, error) {
+	if scale < 1 {
+		scale = 1
+	}
+	width := (c.Size + 2*quietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, width, width), color.Palette{color.White, color.Black})
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.modules[y][x] {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				row := (y+quietZone)*scale + dy
+				offset := img.PixOffset((x+quietZone)*scale, row)
+				for dx := 0; dx < scale; dx++ {
+					img.Pix[offset+dx] = 1
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+func newCode(version int) *Code {
+	size := 17 + 4*version
+	code := &Code{Version: version, Size: size}
+	code.modules = make([][]bool, size)
+	code.reserve = make([][]bool, size)
+	for i := range code.modules {
+		code.modules[i] = make([]bool, size)
+		code.reserve[i] = make([]bool, size)
+	}
+	return code
+}
+
+// encodeData returns the padded data codewords for a byte mode segment.
+func encodeData(version int, data []byte) []byte {
+	capacity := versions[version].dataCodewords()
+	var bits bitBuffer
+	bits.append(0b0100, 4)
+	bits.append(len(data), countBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	terminator := 8*capacity - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-len(bits)%8)%8)
+
+	codewords := bits.bytes()
+	for pad := byte(0xEC); len(codewords) < capacity; pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+	return codewords
+}
+
+// interleave splits data into blocks, appends error correction to each and
+// interleaves the result in symbol order.
+func interleave(spec versionSpec, data []byte) []byte {
+	generator := rsGenerator(spec.ecPerBlock)
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for _, group := range spec.groups {
+		for i := 0; i < group.blocks; i++ {
+			block := data[offset : offset+group.dataPerBlock]
+			offset += group.dataPerBlock
+			dataBlocks = append(dataBlocks, block)
+			ecBlocks = append(ecBlocks, rsRemainder(block, generator))
+		}
+	}
+
+	result := make([]byte, 0, len(data)+len(dataBlocks)*spec.ecPerBlock)
+	longest := len(dataBlocks[len(dataBlocks)-1])
+	for i := 0; i < longest; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.reserve[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.Size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	positions := versions[c.Version].alignment
+	last := len(positions) - 1
+	for i, y := range positions {
+		for j, x := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(x, y)
+		}
+	}
+
+	// Reserve the format areas; the real bits are drawn once a mask is chosen.
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+func (c *Code) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits writes both copies of the format information for level M.
+func (c *Code) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(bits, i))
+	}
+	c.setFunction(8, 7, bit(bits, 6))
+	c.setFunction(8, 8, bit(bits, 7))
+	c.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(bits, i))
+	}
+	c.setFunction(8, c.Size-8, true)
+}
+
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+	bits := versionBits(c.Version)
+	for i := 0; i < 18; i++ {
+		a, b := c.Size-11+i%3, i/3
+		c.setFunction(a, b, bit(bits, i))
+		c.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// formatBits returns the 15-bit BCH protected format word for level M.
+func formatBits(mask int) int {
+	data := mask // level M is encoded as 00
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionBits returns the 18-bit BCH protected version word.
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+// drawCodewords places data in the two-column zigzag, skipping function modules.
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.reserve[y][x] && i < len(data)*8 {
+					c.modules[y][x] = bit(int(data[i>>3]), 7-(i&7))
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask XORs a mask pattern over the data modules; applying it twice undoes it.
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.reserve[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol with the four mask evaluation rules.
+func (c *Code) penalty() int {
+	score := 0
+	line := make([]bool, c.Size)
+	for _, vertical := range []bool{false, true} {
+		for a := 0; a < c.Size; a++ {
+			for b := 0; b < c.Size; b++ {
+				if vertical {
+					line[b] = c.modules[b][a]
+				} else {
+					line[b] = c.modules[a][b]
+				}
+			}
+			score += linePenalty(line)
+		}
+	}
+
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x+1 < c.Size && y+1 < c.Size {
+				v := c.modules[y][x]
+				if v == c.modules[y][x+1] && v == c.modules[y+1][x] && v == c.modules[y+1][x+1] {
+					score += 3
+				}
+			}
+		}
+	}
+
+	total := c.Size * c.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return score + k*10
+}
+
+var finderLike = [][]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+func linePenalty(line []bool) int {
+	score := 0
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			score += 3 + run - 5
+		}
+		run = 1
+	}
+
+	for i := 0; i+11 <= len(line); i++ {
+		for _, pattern := range finderLike {
+			match := true
+			for j, v := range pattern {
+				if line[i+j] != v {
+					match = false
+					break
+				}
+			}
+			if match {
+				score += 40
+			}
+		}
+	}
+	return score
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, bit(value, i))
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	out := make([]byte, len(b)/8)
+	for i, v := range b {
+		if v {
+			out[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return out
+}
+
+func bit(value, i int) bool {
+	return (value>>i)&1 != 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,176 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestRSRemainder_SpecExample(t *testing.T) {
+	// ISO/IEC 18004 Annex I: "01234567" at version 1-M.
+	data := []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}
+	want := []byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55}
+
+	got := rsRemainder(data, rsGenerator(10))
+	if !bytes.Equal(got, want) {
+		t.Fatalf("rsRemainder = % X, want % X", got, want)
+	}
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	formats := map[int]int{0: 0x5412, 1: 0x5125, 2: 0x5E7C, 3: 0x5B4B}
+	for mask, want := range formats {
+		if got := formatBits(mask); got != want {
+			t.Fatalf("formatBits(%d) = %#x, want %#x", mask, got, want)
+		}
+	}
+	if got := versionBits(7); got != 0x07C94 {
+		t.Fatalf("versionBits(7) = %#x, want 0x07c94", got)
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	payloads := []string{
+		"hi",
+		"PKC1.227.AAAAAAAAAAAAAAAAAAAAAA.BBBBBBBBBBBBBBBBBBBBBB",
+		strings.Repeat("x", 150),
+	}
+	for _, payload := range payloads {
+		code, err := Encode([]byte(payload))
+		if err != nil {
+			t.Fatalf("Encode(%d bytes): %v", len(payload), err)
+		}
+		if got := decode(t, code); got != payload {
+			t.Fatalf("decoded %q, want %q", got, payload)
+		}
+	}
+}
+
+func TestEncode_TooLong(t *testing.T) {
+	if _, err := Encode(bytes.Repeat([]byte("x"), 214)); err != ErrTooLong {
+		t.Fatalf("expected ErrTooLong, got %v", err)
+	}
+}
+
+func TestPNG_IncludesQuietZone(t *testing.T) {
+	code, err := Encode([]byte("hi"))
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	data, err := code.PNG(3)
+	if err != nil {
+		t.Fatalf("PNG: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode png: %v", err)
+	}
+	if width := img.Bounds().Dx(); width != (code.Size+8)*3 {
+		t.Fatalf("width = %d, want %d", width, (code.Size+8)*3)
+	}
+	if r, _, _, _ := img.At(quietZone*3, quietZone*3).RGBA(); r != 0 {
+		t.Fatal("expected the finder corner to be dark")
+	}
+}
+
+// decode reads a symbol back using its format information, so it checks that
+// the chosen mask, format bits and block interleaving agree with each other.
+func decode(t *testing.T, code *Code) string {
+	t.Helper()
+
+	var format int
+	for i := 0; i <= 5; i++ {
+		format |= boolBit(code.Dark(8, i)) << i
+	}
+	format |= boolBit(code.Dark(8, 7)) << 6
+	format |= boolBit(code.Dark(8, 8)) << 7
+	format |= boolBit(code.Dark(7, 8)) << 8
+	for i := 9; i < 15; i++ {
+		format |= boolBit(code.Dark(14-i, 8)) << i
+	}
+	mask := -1
+	for m := 0; m < 8; m++ {
+		if formatBits(m) == format {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("format bits %#x do not match any level M mask", format)
+	}
+
+	code.applyMask(mask)
+	defer code.applyMask(mask)
+
+	spec := versions[code.Version]
+	total := spec.dataCodewords() + spec.ecPerBlock*(spec.groups[0].blocks+spec.groups[1].blocks)
+	var bits bitBuffer
+	for right := code.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < code.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = code.Size - 1 - vert
+				}
+				if !code.reserve[y][x] && len(bits) < total*8 {
+					bits = append(bits, code.modules[y][x])
+				}
+			}
+		}
+	}
+	codewords := bits.bytes()
+
+	var blocks [][]byte
+	for _, group := range spec.groups {
+		for i := 0; i < group.blocks; i++ {
+			blocks = append(blocks, make([]byte, 0, group.dataPerBlock))
+		}
+	}
+	sizes := make([]int, 0, len(blocks))
+	for _, group := range spec.groups {
+		for i := 0; i < group.blocks; i++ {
+			sizes = append(sizes, group.dataPerBlock)
+		}
+	}
+	next := 0
+	for i := 0; i < sizes[len(sizes)-1]; i++ {
+		for b := range blocks {
+			if i < sizes[b] {
+				blocks[b] = append(blocks[b], codewords[next])
+				next++
+			}
+		}
+	}
+	data := bytes.Join(blocks, nil)
+
+	if mode := data[0] >> 4; mode != 0b0100 {
+		t.Fatalf("mode = %b, want byte mode", mode)
+	}
+	var stream bitBuffer
+	for _, b := range data {
+		stream.append(int(b), 8)
+	}
+	read := func(offset, length int) int {
+		value := 0
+		for i := 0; i < length; i++ {
+			value = value<<1 | boolBit(stream[offset+i])
+		}
+		return value
+	}
+	length := read(4, countBits(code.Version))
+	out := make([]byte, length)
+	for i := range out {
+		out[i] = byte(read(4+countBits(code.Version)+8*i, 8))
+	}
+	return string(out)
+}
+
+func boolBit(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,44 @@
+package qrcode
+
+// Reed-Solomon arithmetic over GF(256) with the QR primitive polynomial 0x11D.
+
+func gfMultiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= ((int(y) >> i) & 1) * int(x)
+	}
+	return byte(z)
+}
+
+// rsGenerator returns the coefficients, highest power first and excluding the
+// leading 1, of the generator polynomial for degree error correction codewords.
+func rsGenerator(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// rsRemainder returns the error correction codewords for data.
+func rsRemainder(data, generator []byte) []byte {
+	result := make([]byte, len(generator))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range generator {
+			result[i] ^= gfMultiply(coef, factor)
+		}
+	}
+	return result
+}
@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// bucketSweepInterval is how often idle buckets are dropped.
+const bucketSweepInterval = time.Minute
+
+// BucketConfig sizes a token bucket.
+type BucketConfig struct {
+	RequestsPerMinute int // Sustained refill rate
+	Burst             int // Bucket capacity
+
+	// Clock for testing (nil uses real time)
+	Clock Clock
+}
+
+// bucket holds the tokens left for one key as of last.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Buckets is a keyed token-bucket limiter, one bucket per key (client IP or
+// user). It is safe for concurrent use. Buckets that have refilled to
+// capacity carry no state, so a periodic sweep drops them and one-off
+// clients do not accumulate.
+type Buckets struct {
+	ratePerSec float64
+	burst      float64
+	clock      Clock
+	mu         sync.Mutex
+	buckets    map[string]*bucket
+
+	// Cleanup goroutine management
+	cleanupCtx    context.Context
+	cleanupCancel context.CancelFunc
+	cleanupOnce   sync.Once
+	cleanupWg     sync.WaitGroup
+}
+
+// NewBuckets creates a token-bucket limiter. Non-positive values fall back to
+// one request per second with a burst of one.
+func NewBuckets(cfg BucketConfig) *Buckets {
+	perMinute := cfg.RequestsPerMinute
+	if perMinute <= 0 {
+		perMinute = 60
+	}
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+	clock := cfg.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Buckets{
+		ratePerSec:    float64(perMinute) / 60,
+		burst:         float64(burst),
+		clock:         clock,
+		buckets:       make(map[string]*bucket),
+		cleanupCtx:    ctx,
+		cleanupCancel: cancel,
+	}
+}
+
+// Close stops the sweep goroutine.
+func (b *Buckets) Close() {
+	b.cleanupCancel()
+	b.cleanupWg.Wait()
+}
+
+// Allow takes a token from key's bucket. When the bucket is empty it returns
+// false and how long until the next token is available.
+func (b *Buckets) Allow(key string) (bool, time.Duration) {
+	b.startCleanup()
+	now := b.clock.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bk := b.buckets[key]
+	if bk == nil {
+		bk = &bucket{tokens: b.burst, last: now}
+		b.buckets[key] = bk
+	} else {
+		bk.tokens = b.refill(bk, now)
+		bk.last = now
+	}
+
+	if bk.tokens < 1 {
+		wait := (1 - bk.tokens) / b.ratePerSec
+		return false, time.Duration(math.Ceil(wait * float64(time.Second)))
+	}
+	bk.tokens--
+	return true, 0
+}
+
+// Len reports how many keys currently hold a bucket.
+func (b *Buckets) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.buckets)
+}
+
+func (b *Buckets) refill(bk *bucket, now time.Time) float64 {
+	elapsed := now.Sub(bk.last).Seconds()
+	if elapsed <= 0 {
+		return bk.tokens
+	}
+	return math.Min(b.burst, bk.tokens+elapsed*b.ratePerSec)
+}
+
+func (b *Buckets) startCleanup() {
+	b.cleanupOnce.Do(func() {
+		b.cleanupWg.Add(1)
+		go func() {
+			defer b.cleanupWg.Done()
+			ticker := time.NewTicker(bucketSweepInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-b.cleanupCtx.Done():
+					return
+				case <-ticker.C:
+					b.sweep()
+				}
+			}
+		}()
+	})
+}
+
+// sweep drops buckets that have refilled to capacity; recreating one later
+// is indistinguishable from keeping it.
+func (b *Buckets) sweep() {
+	now := b.clock.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for k, bk := range b.buckets {
+		if b.refill(bk, now) >= b.burst {
+			delete(b.buckets, k)
+		}
+	}
+}
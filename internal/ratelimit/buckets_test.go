@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBuckets_BurstThenRefill(t *testing.T) {
+	clock := newMockClock()
+	buckets := NewBuckets(BucketConfig{RequestsPerMinute: 30, Burst: 3, Clock: clock})
+	defer buckets.Close()
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := buckets.Allow("user:1"); !ok {
+			t.Fatalf("request %d within burst should be allowed", i+1)
+		}
+	}
+
+	ok, retryAfter := buckets.Allow("user:1")
+	if ok {
+		t.Fatal("request beyond burst should be blocked")
+	}
+	if retryAfter != 2*time.Second {
+		t.Errorf("expected retry after 2s at 30/min, got %v", retryAfter)
+	}
+
+	// Other keys have their own bucket.
+	if ok, _ := buckets.Allow("user:2"); !ok {
+		t.Error("a different key should not share the exhausted bucket")
+	}
+
+	clock.Advance(2 * time.Second)
+	if ok, _ := buckets.Allow("user:1"); !ok {
+		t.Error("request should be allowed once a token has refilled")
+	}
+	if ok, _ := buckets.Allow("user:1"); ok {
+		t.Error("only one token should have refilled")
+	}
+}
+
+func TestBuckets_RefillCapsAtBurst(t *testing.T) {
+	clock := newMockClock()
+	buckets := NewBuckets(BucketConfig{RequestsPerMinute: 60, Burst: 2, Clock: clock})
+	defer buckets.Close()
+
+	buckets.Allow("ip")
+	clock.Advance(time.Hour)
+
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if ok, _ := buckets.Allow("ip"); ok {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("expected an idle bucket to refill only to burst (2), got %d", allowed)
+	}
+}
+
+func TestBuckets_SweepDropsIdleBuckets(t *testing.T) {
+	clock := newMockClock()
+	buckets := NewBuckets(BucketConfig{RequestsPerMinute: 60, Burst: 5, Clock: clock})
+	defer buckets.Close()
+
+	for i := 0; i < 100; i++ {
+		buckets.Allow(fmt.Sprintf("ip-%d", i))
+	}
+	for i := 0; i < 5; i++ {
+		buckets.Allow("busy")
+	}
+
+	clock.Advance(2 * time.Second)
+	buckets.sweep()
+	if got := buckets.Len(); got != 1 {
+		t.Errorf("expected only the drained bucket to survive the sweep, got %d", got)
+	}
+
+	clock.Advance(5 * time.Second)
+	buckets.sweep()
+	if got := buckets.Len(); got != 0 {
+		t.Errorf("expected every bucket swept once refilled, got %d", got)
+	}
+}
+
+func TestBuckets_ConcurrentAllow(t *testing.T) {
+	clock := newMockClock()
+	buckets := NewBuckets(BucketConfig{RequestsPerMinute: 1, Burst: 10, Clock: clock})
+	defer buckets.Close()
+
+	var allowed atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := buckets.Allow("shared"); ok {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := allowed.Load(); got != 10 {
+		t.Errorf("expected exactly burst (10) concurrent requests allowed, got %d", got)
+	}
+}
@@ -1,4 +1,4 @@
-// Package ratelimit provides rate limiting for OTP operations.
+// Package ratelimit provides rate limiting for OTP operations and HTTP endpoints.
 package ratelimit
 
 import (
@@ -0,0 +1,196 @@
+package ratings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// Rating sources. Members may self-report any of SelfReportSources; staff
+// entries are always verified.
+const (
+	SourceSelf  = "self"
+	SourceDUPR  = "dupr"
+	SourceUTR   = "utr"
+	SourceStaff = "staff"
+)
+
+// SelfReportSources are the sources a member may give for their own rating.
+var SelfReportSources = []string{SourceSelf, SourceDUPR, SourceUTR}
+
+// MaxNoteLength caps the note attached to a rating entry.
+const MaxNoteLength = 500
+
+// Entry is one reported or adjusted rating in a member's history.
+type Entry struct {
+	ID               int64      `json:"id"`
+	Rating           float64    `json:"rating"`
+	Source           string     `json:"source"`
+	Verified         bool       `json:"verified"`
+	RecordedByUserID *int64     `json:"recordedByUserId,omitempty"`
+	VerifiedByUserID *int64     `json:"verifiedByUserId,omitempty"`
+	VerifiedAt       *time.Time `json:"verifiedAt,omitempty"`
+	Note             string     `json:"note,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// Summary is a member's effective rating with the history behind it. Rating
+// is what eligibility checks use and is nil when the member has none.
+type Summary struct {
+	Rating  *float64 `json:"rating"`
+	Current *Entry   `json:"current"`
+	History []Entry  `json:"history"`
+}
+
+// NewEntry converts a history row for API responses.
+func NewEntry(row dbgen.MemberRatingHistory) Entry {
+	entry := Entry{
+		ID:        row.ID,
+		Rating:    row.Rating,
+		Source:    row.Source,
+		Verified:  row.Verified,
+		Note:      row.Note.String,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.RecordedByUserID.Valid {
+		entry.RecordedByUserID = &row.RecordedByUserID.Int64
+	}
+	if row.VerifiedByUserID.Valid {
+		entry.VerifiedByUserID = &row.VerifiedByUserID.Int64
+	}
+	if row.VerifiedAt.Valid {
+		entry.VerifiedAt = &row.VerifiedAt.Time
+	}
+	return entry
+}
+
+// RecordInput describes a new rating entry. RecordedByUserID is zero for
+// entries with no known recorder.
+type RecordInput struct {
+	UserID           int64
+	Rating           float64
+	Source           string
+	Verified         bool
+	RecordedByUserID int64
+	Note             string
+}
+
+// Record adds an entry to the member's history and refreshes the effective
+// rating. Run it inside a transaction.
+func Record(ctx context.Context, q *dbgen.Queries, input RecordInput) (Entry, error) {
+	if input.Rating < apiutil.MinSkillRating || input.Rating > apiutil.MaxSkillRating {
+		return Entry{}, apiutil.HandlerError{
+			Status:  http.StatusBadRequest,
+			Message: fmt.Sprintf("rating must be between %.1f and %.1f", apiutil.MinSkillRating, apiutil.MaxSkillRating),
+		}
+	}
+	if input.Source != SourceStaff && !slices.Contains(SelfReportSources, input.Source) {
+		return Entry{}, apiutil.HandlerError{Status: http.StatusBadRequest, Message: "source must be one of self, dupr, utr or staff"}
+	}
+	if len(input.Note) > MaxNoteLength {
+		return Entry{}, apiutil.HandlerError{Status: http.StatusBadRequest, Message: "note is too long"}
+	}
+
+	row, err := q.CreateMemberRatingEntry(ctx, dbgen.CreateMemberRatingEntryParams{
+		UserID:           input.UserID,
+		Rating:           input.Rating,
+		Source:           input.Source,
+		Verified:         input.Verified,
+		RecordedByUserID: sql.NullInt64{Int64: input.RecordedByUserID, Valid: input.RecordedByUserID > 0},
+		CreatedAt:        time.Now().UTC(),
+		Note:             sql.NullString{String: input.Note, Valid: input.Note != ""},
+	})
+	if err != nil {
+		return Entry{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to record rating", Err: err}
+	}
+	if err := refreshEffectiveRating(ctx, q, input.UserID); err != nil {
+		return Entry{}, err
+	}
+	return NewEntry(row), nil
+}
+
+// Verify marks an unverified entry verified by a staff member and refreshes
+// the effective rating. Run it inside a transaction.
+func Verify(ctx context.Context, q *dbgen.Queries, userID, entryID, verifiedByUserID int64) (Entry, error) {
+	row, err := q.VerifyMemberRatingEntry(ctx, dbgen.VerifyMemberRatingEntryParams{
+		VerifiedByUserID: sql.NullInt64{Int64: verifiedByUserID, Valid: true},
+		VerifiedAt:       sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		ID:               entryID,
+		UserID:           userID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := q.GetMemberRatingEntry(ctx, dbgen.GetMemberRatingEntryParams{ID: entryID, UserID: userID}); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return Entry{}, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Rating entry not found"}
+			}
+			return Entry{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load rating entry", Err: err}
+		}
+		return Entry{}, apiutil.HandlerError{Status: http.StatusConflict, Message: "Rating entry is already verified"}
+	}
+	if err != nil {
+		return Entry{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to verify rating", Err: err}
+	}
+	if err := refreshEffectiveRating(ctx, q, userID); err != nil {
+		return Entry{}, err
+	}
+	return NewEntry(row), nil
+}
+
+// Load returns the member's effective rating and rating history, newest
+// first.
+func Load(ctx context.Context, q *dbgen.Queries, userID int64) (Summary, error) {
+	summary := Summary{History: []Entry{}}
+
+	effective, err := q.GetMemberSkillRating(ctx, userID)
+	switch {
+	case err == nil:
+		summary.Rating = &effective.Rating
+	case !errors.Is(err, sql.ErrNoRows):
+		return Summary{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load rating", Err: err}
+	}
+
+	preferred, err := q.GetPreferredMemberRating(ctx, userID)
+	switch {
+	case err == nil:
+		// Clearing the rating from the member form leaves history behind, so
+		// only report the entry that still backs the effective rating.
+		if summary.Rating != nil && *summary.Rating == preferred.Rating {
+			current := NewEntry(preferred)
+			summary.Current = &current
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return Summary{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load rating", Err: err}
+	}
+
+	rows, err := q.ListMemberRatingHistory(ctx, userID)
+	if err != nil {
+		return Summary{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load rating history", Err: err}
+	}
+	for _, row := range rows {
+		summary.History = append(summary.History, NewEntry(row))
+	}
+	return summary, nil
+}
+
+// refreshEffectiveRating copies the preferred history entry into
+// member_skill_ratings, which skill bands and eligibility checks read.
+func refreshEffectiveRating(ctx context.Context, q *dbgen.Queries, userID int64) error {
+	preferred, err := q.GetPreferredMemberRating(ctx, userID)
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update effective rating", Err: err}
+	}
+	if _, err := q.UpsertMemberSkillRating(ctx, dbgen.UpsertMemberSkillRatingParams{
+		UserID: userID,
+		Rating: preferred.Rating,
+	}); err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update effective rating", Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/metrics"
+)
+
+const waitlistNotificationTimeout = 5 * time.Second
+
+// CancellationPenalty is the refund the facility's policy allows for
+// cancelling a reservation at CalculatedAt.
+type CancellationPenalty struct {
+	Reservation      dbgen.Reservation
+	RefundPercentage int64
+	// Price is what the reservation was priced at, nil when it was not.
+	Price            *dbgen.ReservationPrice
+	HoursBeforeStart int64
+	CalculatedAt     time.Time
+}
+
+// RefundAmountCents is what the refund returns of the reservation's price,
+// nil when it has none.
+func (p CancellationPenalty) RefundAmountCents() *int64 {
+	return refundAmountCents(p.Price, p.RefundPercentage)
+}
+
+// CancellationPenaltyError reports a cancellation held back because it
+// forfeits part of the refund and the caller has not agreed to that yet.
+type CancellationPenaltyError struct {
+	Penalty CancellationPenalty
+}
+
+func (e CancellationPenaltyError) Error() string {
+	return "cancellation requires confirmation"
+}
+
+type CancelInput struct {
+	ReservationID     int64
+	CancelledByUserID int64
+	// OwnerID, when set, only lets that primary user cancel, and only before
+	// the reservation starts.
+	OwnerID int64
+	// WaiveFee refunds in full whatever the policy says.
+	WaiveFee bool
+	// ConfirmPenalty is asked whether a cancellation with less than a full
+	// refund may go ahead. Returning false fails with CancellationPenaltyError.
+	// Nil goes ahead.
+	ConfirmPenalty func(ctx context.Context, q *dbgen.Queries, penalty CancellationPenalty) (bool, error)
+	// RestoreLessonPackages returns lessons redeemed for a cancelled pro
+	// session to their packages.
+	RestoreLessonPackages bool
+	// NotifyPro tells the pro about a cancelled pro session.
+	NotifyPro bool
+	// OfferToWaitlist offers the freed slot to matching waitlist entries.
+	OfferToWaitlist bool
+	// IssueCredit credits the refund amount, capped at what the member paid,
+	// to the primary user's account at the facility.
+	IssueCredit bool
+}
+
+type CancelResult struct {
+	Reservation      dbgen.Reservation
+	RefundPercentage int64
+	// RefundAmountCents is the reservation's price times RefundPercentage,
+	// nil when it was not priced.
+	RefundAmountCents *int64
+	FeeWaived         bool
+	// CreditCents is the account credit issued for the refund, zero when
+	// none was or nothing had been paid.
+	CreditCents int64
+}
+
+// CancelReservation logs the cancellation with the refund the policy allows,
+// frees the courts, and emails everyone who was on the reservation.
+func (s *Service) CancelReservation(ctx context.Context, in CancelInput) (CancelResult, error) {
+	logger := log.Ctx(ctx)
+
+	var result CancelResult
+	var courts []dbgen.ListReservationCourtsRow
+	var participants []dbgen.ListParticipantsForReservationRow
+	var reservationTypeName string
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		reservation, err := qtx.GetReservationByID(ctx, in.ReservationID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Reservation not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch reservation", Err: err}
+		}
+		now := time.Now()
+		if in.OwnerID != 0 {
+			if !reservation.PrimaryUserID.Valid || reservation.PrimaryUserID.Int64 != in.OwnerID {
+				return apiutil.HandlerError{Status: http.StatusForbidden, Message: "Forbidden"}
+			}
+			if !reservation.StartTime.After(now) {
+				return apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Reservation must be in the future"}
+			}
+		}
+
+		hoursBeforeStart := HoursUntilStart(reservation.StartTime, now)
+		refundPercentage, err := apiutil.ApplicableRefundPercentage(ctx, qtx, reservation.FacilityID, hoursBeforeStart, &reservation.ReservationTypeID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load cancellation policy", Err: err}
+		}
+		price, err := LoadReservationPrice(ctx, qtx, reservation.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation price", Err: err}
+		}
+		if refundPercentage < 100 && !in.WaiveFee && in.ConfirmPenalty != nil {
+			penalty := CancellationPenalty{
+				Reservation:      reservation,
+				RefundPercentage: refundPercentage,
+				Price:            price,
+				HoursBeforeStart: hoursBeforeStart,
+				CalculatedAt:     now,
+			}
+			confirmed, err := in.ConfirmPenalty(ctx, qtx, penalty)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return CancellationPenaltyError{Penalty: penalty}
+			}
+		}
+		if in.WaiveFee {
+			refundPercentage = 100
+		}
+		refundAmount := refundAmountCents(price, refundPercentage)
+
+		if _, err := qtx.LogCancellation(ctx, dbgen.LogCancellationParams{
+			ReservationID:           reservation.ID,
+			CancelledByUserID:       in.CancelledByUserID,
+			CancelledAt:             now,
+			RefundPercentageApplied: refundPercentage,
+			FeeWaived:               in.WaiveFee,
+			HoursBeforeStart:        hoursBeforeStart,
+			RefundAmountCents:       apiutil.ToNullInt64(refundAmount),
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to log cancellation", Err: err}
+		}
+		var creditCents int64
+		if in.IssueCredit && refundAmount != nil && *refundAmount > 0 && reservation.PrimaryUserID.Valid {
+			// Never credit more than the member paid: an unpaid booking
+			// refunds nothing.
+			paid, err := reservationPaidCents(ctx, qtx, reservation.ID)
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation payments", Err: err}
+			}
+			if amount := min(*refundAmount, paid); amount > 0 {
+				credit, err := IssueCredit(ctx, qtx, reservation.PrimaryUserID.Int64, reservation.FacilityID, amount, &reservation.ID, CancellationCreditReason, in.CancelledByUserID, nil)
+				if err != nil {
+					return err
+				}
+				creditCents = credit.AmountCents
+			}
+		}
+
+		reservationTypeName, err = qtx.GetReservationTypeNameByReservationID(ctx, reservation.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation type", Err: err}
+		}
+		if reservationTypeName == "PRO_SESSION" {
+			if in.RestoreLessonPackages {
+				if err := restoreLessonPackages(ctx, qtx, reservation.ID); err != nil {
+					return err
+				}
+			}
+			if in.NotifyPro {
+				if err := notifyProOfCancellation(ctx, qtx, reservation); err != nil {
+					return err
+				}
+			}
+		}
+
+		courts, err = qtx.ListReservationCourts(ctx, reservation.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation courts", Err: err}
+		}
+		for _, court := range courts {
+			if err := qtx.RemoveReservationCourt(ctx, dbgen.RemoveReservationCourtParams{
+				ReservationID: reservation.ID,
+				CourtID:       court.CourtID,
+			}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation court", Err: err}
+			}
+		}
+
+		participants, err = qtx.ListParticipantsForReservation(ctx, reservation.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation participants", Err: err}
+		}
+		for _, participant := range participants {
+			if err := qtx.RemoveParticipant(ctx, dbgen.RemoveParticipantParams{
+				ReservationID: reservation.ID,
+				UserID:        participant.ID,
+			}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation participant", Err: err}
+			}
+		}
+		if err := qtx.CancelPendingReservationInvitations(ctx, reservation.ID); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to cancel reservation invitations", Err: err}
+		}
+
+		result = CancelResult{
+			Reservation:       reservation,
+			RefundPercentage:  refundPercentage,
+			RefundAmountCents: refundAmount,
+			FeeWaived:         in.WaiveFee,
+			CreditCents:       creditCents,
+		}
+		return nil
+	})
+	if err != nil {
+		return CancelResult{}, err
+	}
+	metrics.ReservationCancelled(result.Reservation.FacilityID, reservationTypeName)
+
+	s.sendCancellationEmails(ctx, result, reservationTypeName, courts, participants)
+
+	if in.OfferToWaitlist {
+		notifyCtx, notifyCancel := context.WithTimeout(context.WithoutCancel(ctx), waitlistNotificationTimeout)
+		defer notifyCancel()
+		if err := s.notifyWaitlistedMembers(notifyCtx, result.Reservation, courts); err != nil {
+			logger.Error().Err(err).Int64("reservation_id", in.ReservationID).Msg("Failed to notify waitlisted members")
+		}
+	}
+	return result, nil
+}
+
+// restoreLessonPackages gives back the lessons a pro session redeemed.
+// Packages that expired or are already full are skipped.
+func restoreLessonPackages(ctx context.Context, q *dbgen.Queries, reservationID int64) error {
+	reservationRef := sql.NullInt64{Int64: reservationID, Valid: true}
+	redemptions, err := q.ListLessonPackageRedemptionsByReservationID(ctx, reservationRef)
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load lesson package redemption", Err: err}
+	}
+	if len(redemptions) == 0 {
+		return nil
+	}
+	for _, redemption := range redemptions {
+		if _, err := q.RestoreLessonPackageLesson(ctx, redemption.LessonPackageID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				log.Ctx(ctx).Info().Int64("lesson_package_id", redemption.LessonPackageID).Msg("Skipped lesson package restore (expired or already at max)")
+				continue
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to restore lesson package", Err: err}
+		}
+	}
+	if err := q.DeleteLessonPackageRedemptionsByReservationID(ctx, reservationRef); err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to clear lesson package redemption", Err: err}
+	}
+	return nil
+}
+
+// notifyProOfCancellation leaves the session's pro a staff notification.
+// Failing to create it does not stop the cancellation.
+func notifyProOfCancellation(ctx context.Context, q *dbgen.Queries, reservation dbgen.Reservation) error {
+	logger := log.Ctx(ctx)
+	if !reservation.ProID.Valid {
+		logger.Error().Int64("reservation_id", reservation.ID).Msg("Missing pro for lesson cancellation notification")
+		return nil
+	}
+
+	memberName := "Member"
+	if reservation.PrimaryUserID.Valid {
+		member, err := q.GetMemberByID(ctx, reservation.PrimaryUserID.Int64)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load member details", Err: err}
+		}
+		if err == nil {
+			memberName = strings.TrimSpace(fmt.Sprintf("%s %s", member.FirstName, member.LastName))
+		}
+		if memberName == "" {
+			memberName = "Member"
+		}
+	}
+	message := fmt.Sprintf(
+		"Lesson cancelled: %s (%s - %s)",
+		memberName,
+		reservation.StartTime.Format(timeLayoutDatetimeMinute),
+		reservation.EndTime.Format(timeLayoutDatetimeMinute),
+	)
+	if _, err := q.CreateLessonCancelledNotification(ctx, dbgen.CreateLessonCancelledNotificationParams{
+		FacilityID:           reservation.FacilityID,
+		Message:              message,
+		RelatedReservationID: sql.NullInt64{Int64: reservation.ID, Valid: true},
+		TargetStaffID:        reservation.ProID,
+	}); err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to notify pro about lesson cancellation")
+	}
+	return nil
+}
+
+// sendCancellationEmails tells the primary member and participants about a
+// cancellation, with the refund that applied.
+func (s *Service) sendCancellationEmails(
+	ctx context.Context,
+	result CancelResult,
+	reservationTypeName string,
+	courts []dbgen.ListReservationCourtsRow,
+	participants []dbgen.ListParticipantsForReservationRow,
+) {
+	if s.emailClient == nil {
+		return
+	}
+	logger := log.Ctx(ctx)
+	q := s.db.Queries
+	reservation := result.Reservation
+
+	emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(ctx), queryTimeout)
+	defer emailCancel()
+	facility, err := s.facilities().GetFacilityByID(emailCtx, reservation.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", reservation.FacilityID).Msg("Failed to load facility for cancellation email")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+
+	date, timeRange := email.FormatDateTimeRange(reservation.StartTime.In(facilityLoc), reservation.EndTime.In(facilityLoc))
+	refund := result.RefundPercentage
+	var refundAmount string
+	if result.RefundAmountCents != nil {
+		refundAmount = apiutil.FormatPriceCents(*result.RefundAmountCents)
+	}
+	message := email.BuildCancellationEmail(email.CancellationDetails{
+		FacilityName:     facility.Name,
+		ReservationType:  reservationTypeName,
+		Date:             date,
+		TimeRange:        timeRange,
+		Courts:           apiutil.ReservationCourtLabel(courts),
+		RefundPercentage: &refund,
+		RefundAmount:     refundAmount,
+		FeeWaived:        result.FeeWaived,
+	})
+	sender := email.ResolveFromAddress(emailCtx, q, facility, logger)
+	_, recipients := splitReservationRecipients(nil, reservationRecipients(reservation, participants))
+	for _, userID := range recipients {
+		if email.ShouldSend(emailCtx, q, userID, email.PreferenceCancellations) {
+			email.SendCancellationEmail(emailCtx, q, s.emailClient, userID, message, sender, logger)
+		}
+	}
+}
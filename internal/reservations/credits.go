@@ -0,0 +1,125 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// CancellationCreditReason is the reason recorded on credit issued for a
+// cancellation refund.
+const CancellationCreditReason = "Cancellation refund"
+
+// CreditAccount is a member's account credit at a facility: the balance they
+// can spend, what was issued, and what bookings used.
+type CreditAccount struct {
+	BalanceCents int64                           `json:"balanceCents"`
+	Credits      []dbgen.AccountCredit           `json:"credits"`
+	Redemptions  []dbgen.AccountCreditRedemption `json:"redemptions"`
+}
+
+// IssueCredit adds credit to a member's account at a facility. A nil
+// expiresAt never expires.
+func IssueCredit(ctx context.Context, q *dbgen.Queries, userID, facilityID, amountCents int64, sourceReservationID *int64, reason string, issuedByUserID int64, expiresAt *time.Time) (dbgen.AccountCredit, error) {
+	params := dbgen.CreateAccountCreditParams{
+		UserID:              userID,
+		FacilityID:          facilityID,
+		AmountCents:         amountCents,
+		SourceReservationID: apiutil.ToNullInt64(sourceReservationID),
+		Reason:              reason,
+		IssuedByUserID:      issuedByUserID,
+	}
+	if expiresAt != nil {
+		params.ExpiresAt = sql.NullTime{Time: *expiresAt, Valid: true}
+	}
+	credit, err := q.CreateAccountCredit(ctx, params)
+	if err != nil {
+		return dbgen.AccountCredit{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to issue account credit", Err: err}
+	}
+	return credit, nil
+}
+
+// ApplyCredits spends up to amountCents of the member's unexpired credit at
+// the facility on a reservation, oldest credit first, and returns how much it
+// used. Run it inside a transaction.
+func ApplyCredits(ctx context.Context, q *dbgen.Queries, userID, facilityID, reservationID, amountCents int64, now time.Time) (int64, error) {
+	if amountCents <= 0 {
+		return 0, nil
+	}
+	credits, err := q.ListSpendableAccountCredits(ctx, dbgen.ListSpendableAccountCreditsParams{
+		UserID:         userID,
+		FacilityID:     facilityID,
+		ComparisonTime: now,
+	})
+	if err != nil {
+		return 0, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load account credit", Err: err}
+	}
+
+	var applied int64
+	for _, credit := range credits {
+		if applied == amountCents {
+			break
+		}
+		amount := min(credit.RemainingCents, amountCents-applied)
+		consumed, err := q.ConsumeAccountCredit(ctx, dbgen.ConsumeAccountCreditParams{
+			AmountCents: amount,
+			ID:          credit.ID,
+		})
+		if err != nil {
+			return 0, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to apply account credit", Err: err}
+		}
+		if consumed == 0 {
+			return 0, apiutil.HandlerError{Status: http.StatusConflict, Message: "Account credit changed while booking; please try again"}
+		}
+		if _, err := q.CreateAccountCreditRedemption(ctx, dbgen.CreateAccountCreditRedemptionParams{
+			CreditID:      credit.ID,
+			ReservationID: reservationID,
+			AmountCents:   amount,
+		}); err != nil {
+			return 0, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to apply account credit", Err: err}
+		}
+		applied += amount
+	}
+	return applied, nil
+}
+
+// reservationPaidCents is what the member paid for a reservation. Bookings
+// do not capture card payments, so only account credit applied to it counts.
+func reservationPaidCents(ctx context.Context, q *dbgen.Queries, reservationID int64) (int64, error) {
+	return q.GetReservationCreditAppliedCents(ctx, reservationID)
+}
+
+// LoadCreditAccount returns the member's credit at a facility, newest first.
+// Expired credit is listed but left out of the balance.
+func LoadCreditAccount(ctx context.Context, q *dbgen.Queries, userID, facilityID int64, now time.Time) (CreditAccount, error) {
+	credits, err := q.ListAccountCredits(ctx, dbgen.ListAccountCreditsParams{UserID: userID, FacilityID: facilityID})
+	if err != nil {
+		return CreditAccount{}, err
+	}
+	redemptions, err := q.ListAccountCreditRedemptions(ctx, dbgen.ListAccountCreditRedemptionsParams{UserID: userID, FacilityID: facilityID})
+	if err != nil {
+		return CreditAccount{}, err
+	}
+
+	account := CreditAccount{
+		Credits:     credits,
+		Redemptions: redemptions,
+	}
+	if account.Credits == nil {
+		account.Credits = []dbgen.AccountCredit{}
+	}
+	if account.Redemptions == nil {
+		account.Redemptions = []dbgen.AccountCreditRedemption{}
+	}
+	for _, credit := range credits {
+		if credit.ExpiresAt.Valid && !credit.ExpiresAt.Time.After(now) {
+			continue
+		}
+		account.BalanceCents += credit.RemainingCents
+	}
+	return account, nil
+}
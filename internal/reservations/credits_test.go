@@ -0,0 +1,157 @@
+package reservations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestAccountCredits_IssuedOnCancelAndAppliedOldestFirst(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+2, 10, 0, 0, 0, time.UTC)
+
+	if _, err := fixture.database.Exec(
+		`INSERT INTO court_pricing_rules (facility_id, day_of_week, start_time, end_time, member_price_per_hour_cents, non_member_price_per_hour_cents)
+		 VALUES (?, ?, '08:00', '20:00', 4000, 4000)`,
+		fixture.facilityID, int64(start.Weekday()),
+	); err != nil {
+		t.Fatalf("insert pricing rule: %v", err)
+	}
+	if _, err := fixture.database.Exec(
+		"INSERT INTO cancellation_policy_tiers (facility_id, min_hours_before, refund_percentage) VALUES (?, 0, 50)",
+		fixture.facilityID,
+	); err != nil {
+		t.Fatalf("insert cancellation tier: %v", err)
+	}
+
+	// An expired credit is never spent, however old it is.
+	expired := now.Add(-time.Hour)
+	if _, err := IssueCredit(ctx, fixture.database.Queries, fixture.userID, fixture.facilityID, 9000, nil, "Goodwill", fixture.userID, &expired); err != nil {
+		t.Fatalf("issue expired credit: %v", err)
+	}
+
+	// The first booking is paid in full from credit, so its cancellation has
+	// something to refund.
+	if _, err := IssueCredit(ctx, fixture.database.Queries, fixture.userID, fixture.facilityID, 4000, nil, "Prepaid", fixture.userID, nil); err != nil {
+		t.Fatalf("issue prepaid credit: %v", err)
+	}
+	input := fixture.createInput(start, fixture.courtIDs[0])
+	input.PriceCourtTime = true
+	input.ApplyCredits = true
+	created, err := fixture.service.CreateReservation(ctx, input)
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+	result, err := fixture.service.CancelReservation(ctx, CancelInput{
+		ReservationID:     created.ID,
+		CancelledByUserID: fixture.userID,
+		OwnerID:           fixture.userID,
+		IssueCredit:       true,
+		ConfirmPenalty: func(context.Context, *dbgen.Queries, CancellationPenalty) (bool, error) {
+			return true, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("cancel reservation: %v", err)
+	}
+	if result.CreditCents != 2000 {
+		t.Fatalf("expected half the $40.00 price credited, got %d", result.CreditCents)
+	}
+
+	if _, err := IssueCredit(ctx, fixture.database.Queries, fixture.userID, fixture.facilityID, 3000, nil, "Rained out", fixture.userID, nil); err != nil {
+		t.Fatalf("issue manual credit: %v", err)
+	}
+	account, err := LoadCreditAccount(ctx, fixture.database.Queries, fixture.userID, fixture.facilityID, time.Now())
+	if err != nil {
+		t.Fatalf("load credit account: %v", err)
+	}
+	if account.BalanceCents != 5000 || len(account.Credits) != 4 {
+		t.Fatalf("expected a $50.00 balance over four credits, got %+v", account)
+	}
+
+	input = fixture.createInput(start, fixture.courtIDs[1])
+	input.PriceCourtTime = true
+	input.ApplyCredits = true
+	rebooked, err := fixture.service.CreateReservation(ctx, input)
+	if err != nil {
+		t.Fatalf("rebook with credit: %v", err)
+	}
+	priced, err := WithPrice(ctx, fixture.database.Queries, rebooked)
+	if err != nil {
+		t.Fatalf("load price: %v", err)
+	}
+	if priced.PriceCents == nil || *priced.PriceCents != 4000 ||
+		priced.CreditAppliedCents == nil || *priced.CreditAppliedCents != 4000 ||
+		priced.AmountDueCents == nil || *priced.AmountDueCents != 0 {
+		t.Fatalf("expected the full price paid from credit, got %+v", priced)
+	}
+
+	account, err = LoadCreditAccount(ctx, fixture.database.Queries, fixture.userID, fixture.facilityID, time.Now())
+	if err != nil {
+		t.Fatalf("load credit account: %v", err)
+	}
+	if account.BalanceCents != 1000 || len(account.Redemptions) != 3 {
+		t.Fatalf("expected $10.00 left after three redemptions, got %+v", account)
+	}
+	for _, credit := range account.Credits {
+		want := map[string]int64{"Prepaid": 0, CancellationCreditReason: 0, "Rained out": 1000, "Goodwill": 9000}[credit.Reason]
+		if credit.RemainingCents != want {
+			t.Fatalf("credit %q has %d remaining, want %d", credit.Reason, credit.RemainingCents, want)
+		}
+		if credit.Reason == CancellationCreditReason && (!credit.SourceReservationID.Valid || credit.SourceReservationID.Int64 != created.ID) {
+			t.Fatalf("expected the cancellation credit to point at reservation %d, got %+v", created.ID, credit.SourceReservationID)
+		}
+	}
+}
+
+func TestCancelReservation_UnpaidBookingIssuesNoCredit(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day()+2, 10, 0, 0, 0, time.UTC)
+
+	if _, err := fixture.database.Exec(
+		`INSERT INTO court_pricing_rules (facility_id, day_of_week, start_time, end_time, member_price_per_hour_cents, non_member_price_per_hour_cents)
+		 VALUES (?, ?, '08:00', '20:00', 4000, 4000)`,
+		fixture.facilityID, int64(start.Weekday()),
+	); err != nil {
+		t.Fatalf("insert pricing rule: %v", err)
+	}
+	if _, err := fixture.database.Exec(
+		"INSERT INTO cancellation_policy_tiers (facility_id, min_hours_before, refund_percentage) VALUES (?, 0, 100)",
+		fixture.facilityID,
+	); err != nil {
+		t.Fatalf("insert cancellation tier: %v", err)
+	}
+
+	input := fixture.createInput(start, fixture.courtIDs[0])
+	input.PriceCourtTime = true
+	created, err := fixture.service.CreateReservation(ctx, input)
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+	result, err := fixture.service.CancelReservation(ctx, CancelInput{
+		ReservationID:     created.ID,
+		CancelledByUserID: fixture.userID,
+		OwnerID:           fixture.userID,
+		IssueCredit:       true,
+	})
+	if err != nil {
+		t.Fatalf("cancel reservation: %v", err)
+	}
+	if result.RefundPercentage != 100 || result.CreditCents != 0 {
+		t.Fatalf("expected a full refund of nothing paid, got %d%% and %d credited", result.RefundPercentage, result.CreditCents)
+	}
+
+	account, err := LoadCreditAccount(ctx, fixture.database.Queries, fixture.userID, fixture.facilityID, time.Now())
+	if err != nil {
+		t.Fatalf("load credit account: %v", err)
+	}
+	if account.BalanceCents != 0 || len(account.Credits) != 0 {
+		t.Fatalf("expected no credit for an unpaid booking, got %+v", account)
+	}
+}
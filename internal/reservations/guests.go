@@ -0,0 +1,115 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+const maxGuestNameLength = 100
+
+// Guest is a non-member brought along on a reservation.
+type Guest struct {
+	Name  string
+	Email string
+}
+
+// NormalizeGuests trims each guest and checks that it has a name and, when
+// given, a valid email address.
+func NormalizeGuests(guests []Guest) ([]Guest, error) {
+	if len(guests) == 0 {
+		return nil, nil
+	}
+	normalized := make([]Guest, 0, len(guests))
+	for _, guest := range guests {
+		name := strings.TrimSpace(guest.Name)
+		if name == "" {
+			return nil, apiutil.FieldError{Field: "guests", Reason: "need a name"}
+		}
+		if len(name) > maxGuestNameLength {
+			return nil, apiutil.FieldError{Field: "guests", Reason: fmt.Sprintf("names must be at most %d characters", maxGuestNameLength)}
+		}
+		address := strings.TrimSpace(guest.Email)
+		if address != "" {
+			parsed, err := mail.ParseAddress(address)
+			if err != nil || parsed.Address != address {
+				return nil, apiutil.FieldError{Field: "guests", Reason: "need valid email addresses"}
+			}
+		}
+		normalized = append(normalized, Guest{Name: name, Email: address})
+	}
+	return normalized, nil
+}
+
+// GuestPassBalance is the member's remaining guest passes at the facility.
+func GuestPassBalance(ctx context.Context, q *dbgen.Queries, userID, facilityID int64) (int64, error) {
+	balance, err := q.GetMemberGuestPassBalance(ctx, dbgen.GetMemberGuestPassBalanceParams{
+		UserID:     userID,
+		FacilityID: facilityID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return balance, err
+}
+
+// addGuests enforces the facility's guest cap and records each guest on the
+// reservation, using one of the host's guest passes per guest and flagging
+// the rest as drop-ins. A reservation without a primary user has no host,
+// so all of its guests are drop-ins.
+func addGuests(ctx context.Context, q *dbgen.Queries, reservation dbgen.Reservation, guests []Guest) ([]dbgen.ReservationGuest, error) {
+	if len(guests) == 0 {
+		return nil, nil
+	}
+	facility, err := q.GetFacilityByID(ctx, reservation.FacilityID)
+	if err != nil {
+		return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load facility", Err: err}
+	}
+	if int64(len(guests)) > facility.MaxGuestsPerReservation {
+		err := apiutil.FieldError{Field: "guests", Reason: fmt.Sprintf("are limited to %d per reservation", facility.MaxGuestsPerReservation)}
+		return nil, apiutil.HandlerError{Status: http.StatusBadRequest, Message: err.Error(), Err: err}
+	}
+
+	added := make([]dbgen.ReservationGuest, 0, len(guests))
+	for _, guest := range guests {
+		dropIn := true
+		if reservation.PrimaryUserID.Valid {
+			consumed, err := q.ConsumeMemberGuestPass(ctx, dbgen.ConsumeMemberGuestPassParams{
+				UserID:     reservation.PrimaryUserID.Int64,
+				FacilityID: reservation.FacilityID,
+			})
+			if err != nil {
+				return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to use guest pass", Err: err}
+			}
+			dropIn = consumed == 0
+		}
+		row, err := q.AddReservationGuest(ctx, dbgen.AddReservationGuestParams{
+			ReservationID: reservation.ID,
+			HostUserID:    reservation.PrimaryUserID,
+			Name:          guest.Name,
+			Email:         sql.NullString{String: guest.Email, Valid: guest.Email != ""},
+			IsDropIn:      dropIn,
+		})
+		if err != nil {
+			return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation guest", Err: err}
+		}
+		added = append(added, row)
+	}
+	return added, nil
+}
+
+// guestNames lists the guests' names for display.
+func guestNames(guests []dbgen.ReservationGuest) string {
+	names := make([]string, 0, len(guests))
+	for _, guest := range guests {
+		names = append(names, guest.Name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,90 @@
+package reservations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestCreateReservation_GuestsUsePassesThenDropIn(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	if _, err := fixture.database.Queries.GrantMemberGuestPasses(ctx, dbgen.GrantMemberGuestPassesParams{
+		UserID:     fixture.userID,
+		FacilityID: fixture.facilityID,
+		Passes:     1,
+	}); err != nil {
+		t.Fatalf("grant guest passes: %v", err)
+	}
+
+	in := fixture.createInput(start, fixture.courtIDs[0])
+	in.Guests = []Guest{{Name: " Alex Guest ", Email: "alex@example.com"}, {Name: "Jo Visitor"}}
+	created, err := fixture.service.CreateReservation(ctx, in)
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+
+	guests, err := fixture.database.Queries.ListReservationGuests(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("list guests: %v", err)
+	}
+	if len(guests) != 2 {
+		t.Fatalf("expected 2 guests, got %d", len(guests))
+	}
+	if guests[0].Name != "Alex Guest" || guests[0].IsDropIn || guests[0].HostUserID.Int64 != fixture.userID {
+		t.Fatalf("first guest should use the host's pass, got %+v", guests[0])
+	}
+	if guests[1].Name != "Jo Visitor" || !guests[1].IsDropIn || guests[1].Email.Valid {
+		t.Fatalf("second guest should be a drop-in without email, got %+v", guests[1])
+	}
+
+	balance, err := GuestPassBalance(ctx, fixture.database.Queries, fixture.userID, fixture.facilityID)
+	if err != nil {
+		t.Fatalf("guest pass balance: %v", err)
+	}
+	if balance != 0 {
+		t.Fatalf("expected the pass to be used, balance %d", balance)
+	}
+}
+
+func TestCreateReservation_EnforcesGuestCap(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	if _, err := fixture.database.Exec("UPDATE facilities SET max_guests_per_reservation = 1 WHERE id = ?", fixture.facilityID); err != nil {
+		t.Fatalf("set guest cap: %v", err)
+	}
+
+	in := fixture.createInput(start, fixture.courtIDs[0])
+	in.Guests = []Guest{{Name: "Alex Guest"}, {Name: "Jo Visitor"}}
+	_, err := fixture.service.CreateReservation(ctx, in)
+	var herr apiutil.HandlerError
+	if !errors.As(err, &herr) || herr.Status != http.StatusBadRequest {
+		t.Fatalf("expected 400 over the guest cap, got %v", err)
+	}
+	var fieldErr apiutil.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "guests" {
+		t.Fatalf("expected guests field error, got %v", err)
+	}
+
+	var count int
+	if err := fixture.database.QueryRow("SELECT COUNT(*) FROM reservations").Scan(&count); err != nil {
+		t.Fatalf("count reservations: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("rejected booking should not be saved, found %d reservations", count)
+	}
+
+	in.Guests = []Guest{{Name: "Alex Guest", Email: "not-an-email"}}
+	if _, err := fixture.service.CreateReservation(ctx, in); !errors.As(err, &fieldErr) {
+		t.Fatalf("expected invalid guest email to fail, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// Values of facilities.reservation_limit_scope.
+const (
+	LimitScopePerson    = "person"
+	LimitScopeHousehold = "household"
+)
+
+// CountActiveReservations counts the active reservations at the facility that
+// count against userID's member limit: their own under the person scope, or
+// those of everyone in their household under the household scope.
+func CountActiveReservations(ctx context.Context, q *dbgen.Queries, facilityID, userID int64, scope string) (int64, error) {
+	if scope != LimitScopeHousehold {
+		return q.CountActiveMemberReservations(ctx, dbgen.CountActiveMemberReservationsParams{
+			FacilityID:    facilityID,
+			PrimaryUserID: sql.NullInt64{Int64: userID, Valid: true},
+		})
+	}
+	userIDs, err := q.ListHouseholdUserIDs(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return q.CountActiveHouseholdReservations(ctx, dbgen.CountActiveHouseholdReservationsParams{
+		FacilityID: facilityID,
+		UserIds:    userIDs,
+	})
+}
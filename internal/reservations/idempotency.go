@@ -0,0 +1,129 @@
+package reservations
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// IdempotencyKeyTTL is how long a create's idempotency key replays its
+// result. Expired keys are purged by the scheduler and may be reused.
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// errIdempotencyKeyClaimed means a concurrent create stored the same key
+// first; the caller replays that create instead.
+var errIdempotencyKeyClaimed = errors.New("idempotency key claimed concurrently")
+
+// createRequestHash fingerprints what a create would book, so a key replayed
+// with a different payload can be told apart from a retry.
+func createRequestHash(in CreateInput) (string, error) {
+	payload, err := json.Marshal(struct {
+		FacilityID     int64
+		Details        Details
+		ParticipantIDs []int64
+		InviteeIDs     []int64
+		Guests         []Guest
+		MemberNote     string
+		VisitPackID    *int64
+		ApplyCredits   bool
+	}{
+		FacilityID:     in.FacilityID,
+		Details:        in.Details,
+		ParticipantIDs: normalizeIDs(in.ParticipantIDs),
+		InviteeIDs:     normalizeIDs(in.InviteeIDs),
+		Guests:         in.Guests,
+		MemberNote:     in.MemberNote,
+		VisitPackID:    in.VisitPackID,
+		ApplyCredits:   in.ApplyCredits,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HasIdempotentCreate reports whether userID already created a reservation
+// under key. Handlers use it to skip checks the original create changed the
+// answer to, such as a visit pack it used up, so the retry replays instead.
+func (s *Service) HasIdempotentCreate(ctx context.Context, userID int64, key string) (bool, error) {
+	if key == "" {
+		return false, nil
+	}
+	_, err := s.db.Queries.GetReservationIdempotencyKey(ctx, dbgen.GetReservationIdempotencyKeyParams{
+		UserID:         userID,
+		IdempotencyKey: key,
+		Now:            time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// replayCreate returns the reservation first created under the user's key.
+// ok is false when the key is unused or expired.
+func replayCreate(ctx context.Context, q *dbgen.Queries, userID int64, key, requestHash string, now time.Time) (reservation dbgen.Reservation, ok bool, err error) {
+	stored, err := q.GetReservationIdempotencyKey(ctx, dbgen.GetReservationIdempotencyKeyParams{
+		UserID:         userID,
+		IdempotencyKey: key,
+		Now:            now,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbgen.Reservation{}, false, nil
+		}
+		return dbgen.Reservation{}, false, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check idempotency key", Err: err}
+	}
+	if stored.RequestHash != requestHash {
+		return dbgen.Reservation{}, false, apiutil.HandlerError{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "Idempotency-Key was already used for a different reservation request",
+		}
+	}
+	if err := json.Unmarshal([]byte(stored.ResponseBody), &reservation); err != nil {
+		return dbgen.Reservation{}, false, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to replay reservation", Err: err}
+	}
+	return reservation, true, nil
+}
+
+// storeIdempotencyKey records the created reservation under the user's key.
+// A unique violation means a concurrent create got there first.
+func storeIdempotencyKey(ctx context.Context, q *dbgen.Queries, userID int64, key, requestHash string, created dbgen.Reservation, now time.Time) error {
+	body, err := json.Marshal(created)
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to store idempotency key", Err: err}
+	}
+	if err := q.DeleteExpiredReservationIdempotencyKey(ctx, dbgen.DeleteExpiredReservationIdempotencyKeyParams{
+		UserID:         userID,
+		IdempotencyKey: key,
+		Now:            now,
+	}); err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to store idempotency key", Err: err}
+	}
+	if err := q.CreateReservationIdempotencyKey(ctx, dbgen.CreateReservationIdempotencyKeyParams{
+		UserID:         userID,
+		IdempotencyKey: key,
+		RequestHash:    requestHash,
+		ReservationID:  created.ID,
+		ResponseBody:   string(body),
+		ExpiresAt:      now.Add(IdempotencyKeyTTL),
+	}); err != nil {
+		if apiutil.IsSQLiteUniqueViolation(err) {
+			return errIdempotencyKeyClaimed
+		}
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to store idempotency key", Err: err}
+	}
+	return nil
+}
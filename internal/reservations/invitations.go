@@ -0,0 +1,383 @@
+package reservations
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+)
+
+const (
+	// PlayersPerCourt caps a reservation's participants plus its pending
+	// invitations at this many per booked court.
+	PlayersPerCourt = 4
+
+	invitationTokenBytes = 32
+
+	InvitationStatusPending   = "pending"
+	InvitationStatusAccepted  = "accepted"
+	InvitationStatusDeclined  = "declined"
+	InvitationStatusCancelled = "cancelled"
+)
+
+// InvitationLinks returns the accept and decline URLs emailed for an
+// invitation token.
+type InvitationLinks func(token string) (acceptURL, declineURL string)
+
+// AccessURL returns the link to a reservation's access QR code emailed with
+// its confirmation.
+type AccessURL func(reservationID int64) string
+
+type InviteInput struct {
+	ReservationID int64
+	// OrganizerID must be the reservation's primary user.
+	OrganizerID int64
+	UserIDs     []int64
+	Links       InvitationLinks
+}
+
+// InviteParticipants invites members at the reservation's facility to join
+// it. Invitees are not participants until they accept.
+func (s *Service) InviteParticipants(ctx context.Context, in InviteInput) ([]dbgen.ReservationInvitation, error) {
+	userIDs := normalizeIDs(in.UserIDs)
+	if len(userIDs) == 0 {
+		return nil, apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Choose at least one member to invite"}
+	}
+
+	var reservation dbgen.Reservation
+	var invitations []dbgen.ReservationInvitation
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		reservation, err = qtx.GetReservationByID(ctx, in.ReservationID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Reservation not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch reservation", Err: err}
+		}
+		if !reservation.PrimaryUserID.Valid || reservation.PrimaryUserID.Int64 != in.OrganizerID {
+			return apiutil.HandlerError{Status: http.StatusForbidden, Message: "Only the member who booked can invite players"}
+		}
+		if !reservation.StartTime.After(time.Now()) {
+			return apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Reservation must be in the future"}
+		}
+		cancelled, err := qtx.IsReservationCancelled(ctx, reservation.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch reservation", Err: err}
+		}
+		if cancelled != 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Reservation was cancelled"}
+		}
+
+		invitations, err = createInvitations(ctx, qtx, reservation, userIDs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.sendInvitationEmails(ctx, reservation, invitations, in.Links)
+	return invitations, nil
+}
+
+// createInvitations invites userIDs to the reservation on behalf of its
+// primary user, keeping participants plus pending invitations within
+// PlayersPerCourt for each booked court.
+func createInvitations(ctx context.Context, q *dbgen.Queries, reservation dbgen.Reservation, userIDs []int64) ([]dbgen.ReservationInvitation, error) {
+	if !reservation.PrimaryUserID.Valid {
+		return nil, apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Only member bookings can invite players"}
+	}
+	organizerID := reservation.PrimaryUserID.Int64
+
+	participants, err := q.ListParticipantsForReservation(ctx, reservation.ID)
+	if err != nil {
+		return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation participants", Err: err}
+	}
+	onReservation := map[int64]struct{}{organizerID: {}}
+	for _, participant := range participants {
+		onReservation[participant.ID] = struct{}{}
+	}
+	courts, err := q.ListReservationCourts(ctx, reservation.ID)
+	if err != nil {
+		return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation courts", Err: err}
+	}
+	pending, err := q.CountPendingReservationInvitations(ctx, reservation.ID)
+	if err != nil {
+		return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation invitations", Err: err}
+	}
+	spotsLeft := int64(len(courts))*PlayersPerCourt - int64(len(onReservation)) - pending
+	if int64(len(userIDs)) > spotsLeft {
+		if spotsLeft <= 0 {
+			return nil, apiutil.HandlerError{Status: http.StatusConflict, Message: "This reservation has no open spots"}
+		}
+		return nil, apiutil.HandlerError{Status: http.StatusConflict, Message: fmt.Sprintf("Only %d spots are open on this reservation", spotsLeft)}
+	}
+
+	invitations := make([]dbgen.ReservationInvitation, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if _, ok := onReservation[userID]; ok {
+			return nil, apiutil.HandlerError{Status: http.StatusConflict, Message: "Member is already on this reservation"}
+		}
+		invitee, err := q.GetUserByID(ctx, userID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to look up invitee", Err: err}
+		}
+		if err != nil || !invitee.IsMember || invitee.Status != "active" ||
+			!invitee.HomeFacilityID.Valid || invitee.HomeFacilityID.Int64 != reservation.FacilityID {
+			return nil, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Member not found"}
+		}
+
+		token, err := newInvitationToken()
+		if err != nil {
+			return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to create invitation", Err: err}
+		}
+		invitation, err := q.CreateReservationInvitation(ctx, dbgen.CreateReservationInvitationParams{
+			ReservationID:   reservation.ID,
+			InvitedUserID:   userID,
+			InvitedByUserID: organizerID,
+			Token:           token,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, apiutil.HandlerError{Status: http.StatusConflict, Message: "Member already has an invitation"}
+			}
+			return nil, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to create invitation", Err: err}
+		}
+		invitations = append(invitations, invitation)
+	}
+	return invitations, nil
+}
+
+// AcceptInvitation adds the invitee to the reservation. userID must be the
+// invitee's.
+func (s *Service) AcceptInvitation(ctx context.Context, token string, userID int64) (dbgen.GetReservationInvitationByTokenRow, error) {
+	var invitation dbgen.GetReservationInvitationByTokenRow
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		invitation, err = loadOpenInvitation(ctx, qtx, token)
+		if err != nil {
+			return err
+		}
+		if invitation.InvitedUserID != userID {
+			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Invitation not found"}
+		}
+		if invitation.Status != InvitationStatusPending {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Invitation is no longer pending"}
+		}
+
+		accepted, err := qtx.MarkReservationInvitationAccepted(ctx, invitation.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to accept invitation", Err: err}
+		}
+		if accepted == 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Invitation is no longer pending"}
+		}
+		if err := qtx.AddParticipant(ctx, dbgen.AddParticipantParams{
+			ReservationID: invitation.ReservationID,
+			UserID:        userID,
+		}); err != nil && !apiutil.IsSQLiteUniqueViolation(err) {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation participant", Err: err}
+		}
+		invitation.Status = InvitationStatusAccepted
+		return nil
+	})
+	return invitation, err
+}
+
+// DeclineInvitation frees the invitee's spot, taking them off the
+// reservation if they had accepted, and tells the organizer. The token
+// alone authorizes it so invitees can decline without logging in.
+func (s *Service) DeclineInvitation(ctx context.Context, token string) (dbgen.GetReservationInvitationByTokenRow, error) {
+	var invitation dbgen.GetReservationInvitationByTokenRow
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		invitation, err = loadOpenInvitation(ctx, qtx, token)
+		if err != nil {
+			return err
+		}
+		if invitation.Status != InvitationStatusPending && invitation.Status != InvitationStatusAccepted {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Invitation is no longer open"}
+		}
+
+		declined, err := qtx.MarkReservationInvitationDeclined(ctx, invitation.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to decline invitation", Err: err}
+		}
+		if declined == 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Invitation is no longer open"}
+		}
+		if invitation.Status == InvitationStatusAccepted {
+			if err := qtx.RemoveParticipant(ctx, dbgen.RemoveParticipantParams{
+				ReservationID: invitation.ReservationID,
+				UserID:        invitation.InvitedUserID,
+			}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation participant", Err: err}
+			}
+		}
+		invitation.Status = InvitationStatusDeclined
+		return nil
+	})
+	if err != nil {
+		return invitation, err
+	}
+
+	s.sendInvitationDeclinedEmail(ctx, invitation)
+	return invitation, nil
+}
+
+// loadOpenInvitation loads the invitation for token and rejects ones whose
+// reservation was cancelled or has started.
+func loadOpenInvitation(ctx context.Context, q *dbgen.Queries, token string) (dbgen.GetReservationInvitationByTokenRow, error) {
+	invitation, err := q.GetReservationInvitationByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return invitation, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Invitation not found", Err: err}
+		}
+		return invitation, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load invitation", Err: err}
+	}
+	if invitation.ReservationCancelled != 0 || invitation.Status == InvitationStatusCancelled {
+		return invitation, apiutil.HandlerError{Status: http.StatusConflict, Message: "Reservation was cancelled"}
+	}
+	if !invitation.StartTime.After(time.Now()) {
+		return invitation, apiutil.HandlerError{Status: http.StatusConflict, Message: "Reservation has already started"}
+	}
+	return invitation, nil
+}
+
+// addParticipant puts userID on the reservation directly, settling any
+// invitation they had as accepted.
+func addParticipant(ctx context.Context, q *dbgen.Queries, reservationID, userID int64) error {
+	if err := q.AddParticipant(ctx, dbgen.AddParticipantParams{
+		ReservationID: reservationID,
+		UserID:        userID,
+	}); err != nil {
+		return err
+	}
+	return q.ResolveReservationInvitationForUser(ctx, dbgen.ResolveReservationInvitationForUserParams{
+		Status:        InvitationStatusAccepted,
+		ReservationID: reservationID,
+		InvitedUserID: userID,
+	})
+}
+
+// removeParticipant takes userID off the reservation directly, cancelling
+// any invitation they had so it no longer holds a spot.
+func removeParticipant(ctx context.Context, q *dbgen.Queries, reservationID, userID int64) error {
+	if err := q.RemoveParticipant(ctx, dbgen.RemoveParticipantParams{
+		ReservationID: reservationID,
+		UserID:        userID,
+	}); err != nil {
+		return err
+	}
+	return q.ResolveReservationInvitationForUser(ctx, dbgen.ResolveReservationInvitationForUserParams{
+		Status:        InvitationStatusCancelled,
+		ReservationID: reservationID,
+		InvitedUserID: userID,
+	})
+}
+
+func (s *Service) sendInvitationEmails(ctx context.Context, reservation dbgen.Reservation, invitations []dbgen.ReservationInvitation, links InvitationLinks) {
+	if s.emailClient == nil || links == nil || len(invitations) == 0 {
+		return
+	}
+	logger := log.Ctx(ctx)
+	q := s.db.Queries
+
+	emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(ctx), queryTimeout)
+	defer emailCancel()
+	facility, err := s.facilities().GetFacilityByID(emailCtx, reservation.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", reservation.FacilityID).Msg("Failed to load facility for invitation email")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+	courts, err := q.ListReservationCourts(emailCtx, reservation.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to load courts for invitation email")
+		return
+	}
+	organizerName := ""
+	if organizer, err := q.GetUserByID(emailCtx, reservation.PrimaryUserID.Int64); err == nil {
+		organizerName = strings.TrimSpace(organizer.FirstName + " " + organizer.LastName)
+	}
+
+	date, timeRange := email.FormatDateTimeRange(reservation.StartTime.In(facilityLoc), reservation.EndTime.In(facilityLoc))
+	sender := email.ResolveFromAddress(emailCtx, q, facility, logger)
+	for _, invitation := range invitations {
+		acceptURL, declineURL := links(invitation.Token)
+		message := email.BuildReservationInvitationEmail(email.ReservationInvitationDetails{
+			FacilityName:  facility.Name,
+			OrganizerName: organizerName,
+			Date:          date,
+			TimeRange:     timeRange,
+			Courts:        apiutil.ReservationCourtLabel(courts),
+			AcceptURL:     acceptURL,
+			DeclineURL:    declineURL,
+		})
+		message.FacilityID = facility.ID
+		message.ReservationID = reservation.ID
+		email.SendReservationInvitationEmail(emailCtx, q, s.emailClient, invitation.InvitedUserID, message, sender, logger)
+	}
+}
+
+func (s *Service) sendInvitationDeclinedEmail(ctx context.Context, invitation dbgen.GetReservationInvitationByTokenRow) {
+	if s.emailClient == nil {
+		return
+	}
+	logger := log.Ctx(ctx)
+	q := s.db.Queries
+
+	emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(ctx), queryTimeout)
+	defer emailCancel()
+	if !email.ShouldSend(emailCtx, q, invitation.InvitedByUserID, email.PreferenceCancellations) {
+		return
+	}
+	facility, err := s.facilities().GetFacilityByID(emailCtx, invitation.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", invitation.FacilityID).Msg("Failed to load facility for invitation declined email")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+	inviteeName := ""
+	if invitee, err := q.GetUserByID(emailCtx, invitation.InvitedUserID); err == nil {
+		inviteeName = strings.TrimSpace(invitee.FirstName + " " + invitee.LastName)
+	}
+
+	date, timeRange := email.FormatDateTimeRange(invitation.StartTime.In(facilityLoc), invitation.EndTime.In(facilityLoc))
+	message := email.BuildInvitationDeclinedEmail(email.InvitationDeclinedDetails{
+		FacilityName: facility.Name,
+		InviteeName:  inviteeName,
+		Date:         date,
+		TimeRange:    timeRange,
+	})
+	message.FacilityID = facility.ID
+	message.ReservationID = invitation.ReservationID
+	sender := email.ResolveFromAddress(emailCtx, q, facility, logger)
+	email.SendInvitationDeclinedEmail(emailCtx, q, s.emailClient, invitation.InvitedByUserID, message, sender, logger)
+}
+
+func newInvitationToken() (string, error) {
+	token := make([]byte, invitationTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
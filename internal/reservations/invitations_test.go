@@ -0,0 +1,230 @@
+package reservations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+)
+
+func (f serviceFixture) insertMember(t *testing.T, name string) int64 {
+	t.Helper()
+
+	result, err := f.database.Exec(
+		`INSERT INTO users (first_name, last_name, email, status, is_member, home_facility_id)
+		 VALUES (?, 'Player', ?, 'active', 1, ?)`,
+		name, name+"@test.com", f.facilityID,
+	)
+	if err != nil {
+		t.Fatalf("insert member: %v", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatalf("member id: %v", err)
+	}
+	return id
+}
+
+func (f serviceFixture) invitationStatuses(t *testing.T, reservationID int64) map[int64]string {
+	t.Helper()
+
+	rows, err := f.database.Query("SELECT invited_user_id, status FROM reservation_invitations WHERE reservation_id = ?", reservationID)
+	if err != nil {
+		t.Fatalf("list invitations: %v", err)
+	}
+	defer rows.Close()
+	statuses := make(map[int64]string)
+	for rows.Next() {
+		var userID int64
+		var status string
+		if err := rows.Scan(&userID, &status); err != nil {
+			t.Fatalf("scan invitation: %v", err)
+		}
+		statuses[userID] = status
+	}
+	return statuses
+}
+
+func (f serviceFixture) participantIDs(t *testing.T, reservationID int64) []int64 {
+	t.Helper()
+
+	rows, err := f.database.Queries.ListParticipantsForReservation(context.Background(), reservationID)
+	if err != nil {
+		t.Fatalf("list participants: %v", err)
+	}
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return ids
+}
+
+func expectHandlerStatus(t *testing.T, err error, status int) {
+	t.Helper()
+
+	var herr apiutil.HandlerError
+	if !errors.As(err, &herr) || herr.Status != status {
+		t.Fatalf("expected %d handler error, got %v", status, err)
+	}
+}
+
+func TestInvitations_AcceptDeclineAndCapacity(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	invitees := make([]int64, 0, 4)
+	for i := 0; i < 4; i++ {
+		invitees = append(invitees, fixture.insertMember(t, fmt.Sprintf("Player%d", i)))
+	}
+
+	input := fixture.createInput(start, fixture.courtIDs[0])
+	input.InviteeIDs = invitees[:2]
+	reservation, err := fixture.service.CreateReservation(ctx, input)
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+	if got := fixture.participantIDs(t, reservation.ID); fmt.Sprint(got) != fmt.Sprint([]int64{fixture.userID}) {
+		t.Fatalf("expected invitees to stay off the participant list, got %v", got)
+	}
+
+	invite := func(userIDs ...int64) error {
+		_, err := fixture.service.InviteParticipants(ctx, InviteInput{
+			ReservationID: reservation.ID,
+			OrganizerID:   fixture.userID,
+			UserIDs:       userIDs,
+		})
+		return err
+	}
+
+	// The organizer and two pending invitees leave one of four spots.
+	expectHandlerStatus(t, invite(invitees[2], invitees[3]), http.StatusConflict)
+	expectHandlerStatus(t, invite(invitees[0]), http.StatusConflict)
+	_, err = fixture.service.InviteParticipants(ctx, InviteInput{
+		ReservationID: reservation.ID,
+		OrganizerID:   invitees[0],
+		UserIDs:       []int64{invitees[2]},
+	})
+	expectHandlerStatus(t, err, http.StatusForbidden)
+	if err := invite(invitees[2]); err != nil {
+		t.Fatalf("invite into last spot: %v", err)
+	}
+
+	token := func(userID int64) string {
+		t.Helper()
+		var token string
+		if err := fixture.database.QueryRow(
+			"SELECT token FROM reservation_invitations WHERE reservation_id = ? AND invited_user_id = ?",
+			reservation.ID, userID,
+		).Scan(&token); err != nil {
+			t.Fatalf("load invitation token: %v", err)
+		}
+		return token
+	}
+
+	if _, err := fixture.service.AcceptInvitation(ctx, token(invitees[0]), invitees[1]); err == nil {
+		t.Fatalf("expected another member's token to be rejected")
+	}
+	if _, err := fixture.service.AcceptInvitation(ctx, token(invitees[0]), invitees[0]); err != nil {
+		t.Fatalf("accept invitation: %v", err)
+	}
+	if got := fixture.participantIDs(t, reservation.ID); len(got) != 2 {
+		t.Fatalf("expected the invitee to join, got participants %v", got)
+	}
+
+	// Declining an accepted invitation takes the player back off and frees
+	// the spot for someone else.
+	if _, err := fixture.service.DeclineInvitation(ctx, token(invitees[0])); err != nil {
+		t.Fatalf("decline invitation: %v", err)
+	}
+	if got := fixture.participantIDs(t, reservation.ID); len(got) != 1 {
+		t.Fatalf("expected the decliner to leave, got participants %v", got)
+	}
+	if _, err := fixture.service.DeclineInvitation(ctx, token(invitees[0])); err == nil {
+		t.Fatalf("expected a second decline to fail")
+	}
+	if err := invite(invitees[3]); err != nil {
+		t.Fatalf("invite into freed spot: %v", err)
+	}
+
+	if _, err := fixture.service.CancelReservation(ctx, CancelInput{
+		ReservationID:     reservation.ID,
+		CancelledByUserID: fixture.userID,
+		OwnerID:           fixture.userID,
+	}); err != nil {
+		t.Fatalf("cancel reservation: %v", err)
+	}
+	statuses := fixture.invitationStatuses(t, reservation.ID)
+	want := map[int64]string{
+		invitees[0]: InvitationStatusDeclined,
+		invitees[1]: InvitationStatusCancelled,
+		invitees[2]: InvitationStatusCancelled,
+		invitees[3]: InvitationStatusCancelled,
+	}
+	if fmt.Sprint(statuses) != fmt.Sprint(want) {
+		t.Fatalf("expected pending invitations cancelled, got %v", statuses)
+	}
+	if _, err := fixture.service.AcceptInvitation(ctx, token(invitees[1]), invitees[1]); err == nil {
+		t.Fatalf("expected accepting on a cancelled reservation to fail")
+	}
+}
+
+func TestUpdateReservation_SettlesInvitations(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	invitee := fixture.insertMember(t, "Invitee")
+
+	input := fixture.createInput(start, fixture.courtIDs[0])
+	input.InviteeIDs = []int64{invitee}
+	reservation, err := fixture.service.CreateReservation(ctx, input)
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+
+	update := func(participantIDs ...int64) {
+		t.Helper()
+		if _, err := fixture.service.UpdateReservation(ctx, UpdateInput{
+			Details: Details{
+				ReservationTypeID: fixture.gameTypeID,
+				PrimaryUserID:     &fixture.userID,
+				StartTime:         start,
+				EndTime:           start.Add(time.Hour),
+				CourtIDs:          []int64{fixture.courtIDs[0]},
+			},
+			ReservationID:       reservation.ID,
+			FacilityID:          fixture.facilityID,
+			ReplaceParticipants: true,
+			ParticipantIDs:      participantIDs,
+		}); err != nil {
+			t.Fatalf("update reservation: %v", err)
+		}
+	}
+
+	// Staff adding the invitee directly settles the invitation as accepted.
+	update(fixture.userID, invitee)
+	if status := fixture.invitationStatuses(t, reservation.ID)[invitee]; status != InvitationStatusAccepted {
+		t.Fatalf("expected accepted invitation, got %q", status)
+	}
+	update(fixture.userID)
+	if status := fixture.invitationStatuses(t, reservation.ID)[invitee]; status != InvitationStatusCancelled {
+		t.Fatalf("expected cancelled invitation, got %q", status)
+	}
+
+	// A cancelled invitation can be sent again.
+	invitations, err := fixture.service.InviteParticipants(ctx, InviteInput{
+		ReservationID: reservation.ID,
+		OrganizerID:   fixture.userID,
+		UserIDs:       []int64{invitee},
+	})
+	if err != nil {
+		t.Fatalf("re-invite: %v", err)
+	}
+	if len(invitations) != 1 || invitations[0].Status != InvitationStatusPending {
+		t.Fatalf("expected a pending invitation, got %+v", invitations)
+	}
+}
@@ -0,0 +1,94 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+type ReleaseMemberInput struct {
+	UserID           int64
+	ReleasedByUserID int64
+}
+
+type ReleaseMemberResult struct {
+	CancelledReservations  []CancelResult
+	RemovedSignups         int64
+	CancelledInvitations   int64
+	RemovedWaitlistEntries int64
+}
+
+// ReleaseMember frees everything a departing member still holds: their
+// upcoming reservations are cancelled with a full refund and the usual
+// emails, and they are taken off other reservations, open play signups,
+// invitations, and waitlists. Past reservations are left alone.
+func (s *Service) ReleaseMember(ctx context.Context, in ReleaseMemberInput) (ReleaseMemberResult, error) {
+	var result ReleaseMemberResult
+	now := time.Now()
+
+	reservationIDs, err := s.db.Queries.ListUpcomingReservationIDsForPrimaryUser(ctx, dbgen.ListUpcomingReservationIDsForPrimaryUserParams{
+		PrimaryUserID:  sql.NullInt64{Int64: in.UserID, Valid: true},
+		ComparisonTime: now,
+	})
+	if err != nil {
+		return result, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to list member reservations", Err: err}
+	}
+	for _, reservationID := range reservationIDs {
+		cancelled, err := s.CancelReservation(ctx, CancelInput{
+			ReservationID:         reservationID,
+			CancelledByUserID:     in.ReleasedByUserID,
+			WaiveFee:              true,
+			RestoreLessonPackages: true,
+			NotifyPro:             true,
+			OfferToWaitlist:       true,
+		})
+		if err != nil {
+			return result, fmt.Errorf("cancel reservation %d: %w", reservationID, err)
+		}
+		result.CancelledReservations = append(result.CancelledReservations, cancelled)
+	}
+
+	err = s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		removed, err := qtx.RemoveUserFromUpcomingReservations(ctx, dbgen.RemoveUserFromUpcomingReservationsParams{
+			UserID:         in.UserID,
+			ComparisonTime: now,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove member from reservations", Err: err}
+		}
+		result.RemovedSignups = removed
+
+		invitations, err := qtx.CancelUpcomingReservationInvitationsForUser(ctx, dbgen.CancelUpcomingReservationInvitationsForUserParams{
+			InvitedUserID:  in.UserID,
+			ComparisonTime: now,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to cancel member invitations", Err: err}
+		}
+		result.CancelledInvitations = invitations
+
+		waitlists, err := qtx.DeleteActiveWaitlistEntriesForUser(ctx, in.UserID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove member from waitlists", Err: err}
+		}
+		for _, entry := range waitlists {
+			if err := ReorderWaitlistPositions(ctx, qtx, entry); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update waitlist positions", Err: err}
+			}
+		}
+		result.RemovedWaitlistEntries = int64(len(waitlists))
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package reservations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestReleaseMember_FreesUpcomingBookings(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	departing := fixture.insertMember(t, "Dana")
+
+	own := fixture.createInput(start, fixture.courtIDs[0])
+	own.PrimaryUserID = &departing
+	own.CreatedByUserID = departing
+	own.ParticipantIDs = []int64{departing}
+	ownReservation, err := fixture.service.CreateReservation(ctx, own)
+	if err != nil {
+		t.Fatalf("create own reservation: %v", err)
+	}
+
+	joined := fixture.createInput(start, fixture.courtIDs[1])
+	joined.ParticipantIDs = []int64{fixture.userID, departing}
+	joinedReservation, err := fixture.service.CreateReservation(ctx, joined)
+	if err != nil {
+		t.Fatalf("create joined reservation: %v", err)
+	}
+
+	invited := fixture.createInput(start.Add(2*time.Hour), fixture.courtIDs[0])
+	invited.InviteeIDs = []int64{departing}
+	invitedReservation, err := fixture.service.CreateReservation(ctx, invited)
+	if err != nil {
+		t.Fatalf("create invited reservation: %v", err)
+	}
+
+	past := start.Add(-96 * time.Hour)
+	pastResult, err := fixture.database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		fixture.facilityID, fixture.gameTypeID, departing, departing, past, past.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert past reservation: %v", err)
+	}
+	pastID, _ := pastResult.LastInsertId()
+
+	if _, err := fixture.database.Exec(
+		`INSERT INTO waitlists (facility_id, user_id, target_date, target_start_time, target_end_time, position, status)
+		 VALUES (?, ?, ?, '10:00', '11:00', 1, 'pending')`,
+		fixture.facilityID, departing, start.Format("2006-01-02"),
+	); err != nil {
+		t.Fatalf("insert waitlist entry: %v", err)
+	}
+
+	result, err := fixture.service.ReleaseMember(ctx, ReleaseMemberInput{
+		UserID:           departing,
+		ReleasedByUserID: fixture.userID,
+	})
+	if err != nil {
+		t.Fatalf("release member: %v", err)
+	}
+	if len(result.CancelledReservations) != 1 || result.CancelledReservations[0].Reservation.ID != ownReservation.ID ||
+		result.CancelledReservations[0].RefundPercentage != 100 {
+		t.Fatalf("expected the member's own booking cancelled with a full refund, got %+v", result.CancelledReservations)
+	}
+	if result.RemovedSignups != 1 || result.CancelledInvitations != 1 || result.RemovedWaitlistEntries != 1 {
+		t.Fatalf("unexpected release counts: %+v", result)
+	}
+
+	cancelledCount := func(reservationID int64) int {
+		t.Helper()
+		var count int
+		if err := fixture.database.QueryRow("SELECT COUNT(*) FROM reservation_cancellations WHERE reservation_id = ?", reservationID).Scan(&count); err != nil {
+			t.Fatalf("count cancellations: %v", err)
+		}
+		return count
+	}
+	if cancelledCount(pastID) != 0 || cancelledCount(joinedReservation.ID) != 0 {
+		t.Fatalf("expected past and other members' reservations to stay")
+	}
+	if got := fixture.participantIDs(t, joinedReservation.ID); fmt.Sprint(got) != fmt.Sprint([]int64{fixture.userID}) {
+		t.Fatalf("expected the member off the participant list, got %v", got)
+	}
+	if status := fixture.invitationStatuses(t, invitedReservation.ID)[departing]; status != InvitationStatusCancelled {
+		t.Fatalf("expected cancelled invitation, got %q", status)
+	}
+}
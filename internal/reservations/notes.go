@@ -0,0 +1,22 @@
+package reservations
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+)
+
+// MaxMemberNoteLength caps the note a member leaves with a booking.
+const MaxMemberNoteLength = 500
+
+// NormalizeMemberNote trims a booking note and checks its length. Notes are
+// stored as written; pages escape them when they render.
+func NormalizeMemberNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > MaxMemberNoteLength {
+		return "", apiutil.FieldError{Field: "member_note", Reason: fmt.Sprintf("must be at most %d characters", MaxMemberNoteLength)}
+	}
+	return note, nil
+}
@@ -0,0 +1,242 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+)
+
+// notifyReservationUpdate emails the people on a reservation after it was
+// edited. People who were already on it get a before/after notice, but only
+// when the time or courts changed; people added by the edit get a plain
+// confirmation instead.
+func (s *Service) notifyReservationUpdate(
+	ctx context.Context,
+	before dbgen.Reservation,
+	after dbgen.Reservation,
+	beforeCourts []dbgen.ListReservationCourtsRow,
+	beforeParticipants []dbgen.ListParticipantsForReservationRow,
+) {
+	if s.emailClient == nil {
+		return
+	}
+	logger := log.Ctx(ctx)
+	q := s.db.Queries
+
+	queryCtx, queryCancel := context.WithTimeout(context.WithoutCancel(ctx), queryTimeout)
+	defer queryCancel()
+
+	afterCourts, err := q.ListReservationCourts(queryCtx, after.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", after.ID).Msg("Failed to load reservation courts for change email")
+		return
+	}
+	afterParticipants, err := q.ListParticipantsForReservation(queryCtx, after.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", after.ID).Msg("Failed to load reservation participants for change email")
+		return
+	}
+
+	changed := reservationScheduleChanged(before, after, beforeCourts, afterCourts)
+	existing, added := splitReservationRecipients(
+		reservationRecipients(before, beforeParticipants),
+		reservationRecipients(after, afterParticipants),
+	)
+	if !changed {
+		existing = nil
+	}
+	if len(existing) == 0 && len(added) == 0 {
+		return
+	}
+
+	facility, err := s.facilities().GetFacilityByID(queryCtx, after.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", after.FacilityID).Msg("Failed to load facility for change email")
+		return
+	}
+	reservationTypeName, err := q.GetReservationTypeNameByReservationID(queryCtx, after.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", after.ID).Msg("Failed to load reservation type for change email")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+
+	oldDate, oldTimeRange := email.FormatDateTimeRange(before.StartTime.In(facilityLoc), before.EndTime.In(facilityLoc))
+	newDate, newTimeRange := email.FormatDateTimeRange(after.StartTime.In(facilityLoc), after.EndTime.In(facilityLoc))
+	newCourts := apiutil.ReservationCourtLabel(afterCourts)
+
+	emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(ctx), queryTimeout)
+	defer emailCancel()
+
+	if len(existing) > 0 {
+		message := email.BuildReservationChanged(email.ReservationChangedDetails{
+			FacilityName:    facility.Name,
+			ReservationType: reservationTypeName,
+			OldDate:         oldDate,
+			OldTimeRange:    oldTimeRange,
+			OldCourts:       apiutil.ReservationCourtLabel(beforeCourts),
+			NewDate:         newDate,
+			NewTimeRange:    newTimeRange,
+			NewCourts:       newCourts,
+		})
+		sender := email.ResolveFromAddress(queryCtx, q, facility, logger)
+		for _, userID := range existing {
+			if email.ShouldSend(emailCtx, q, userID, email.PreferenceConfirmations) {
+				email.SendReservationChangedEmail(emailCtx, q, s.emailClient, userID, message, sender, logger)
+			}
+		}
+	}
+
+	if len(added) > 0 {
+		confirmation := email.BuildReservationConfirmation(reservationTypeName, email.ConfirmationDetails{
+			FacilityName: facility.Name,
+			Date:         newDate,
+			TimeRange:    newTimeRange,
+			Courts:       newCourts,
+		})
+		for _, userID := range added {
+			if email.ShouldSend(emailCtx, q, userID, email.PreferenceConfirmations) {
+				email.SendConfirmationEmail(emailCtx, q, s.emailClient, userID, confirmation, logger)
+			}
+		}
+	}
+}
+
+// sendBookingConfirmation emails the primary member a game confirmation with
+// their guests, their note, the price when the booking has one, the
+// cancellation policy that applies from now, and the access QR code link
+// when accessURL is set.
+func (s *Service) sendBookingConfirmation(ctx context.Context, reservation dbgen.Reservation, courtIDs []int64, guests []dbgen.ReservationGuest, price *dbgen.ReservationPrice, memberNote string, accessURL AccessURL) {
+	if s.emailClient == nil || !reservation.PrimaryUserID.Valid {
+		return
+	}
+	logger := log.Ctx(ctx)
+	q := s.db.Queries
+	userID := reservation.PrimaryUserID.Int64
+
+	emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(ctx), queryTimeout)
+	defer emailCancel()
+
+	facility, err := s.facilities().GetFacilityByID(emailCtx, reservation.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", reservation.FacilityID).Msg("Failed to load facility for confirmation email")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+	courtNames := make([]string, 0, len(courtIDs))
+	for _, courtID := range courtIDs {
+		court, err := q.GetCourt(emailCtx, courtID)
+		if err != nil {
+			logger.Error().Err(err).Int64("court_id", courtID).Msg("Failed to load court for confirmation email")
+			return
+		}
+		courtNames = append(courtNames, court.Name)
+	}
+
+	cancellationPolicy, err := CancellationPolicySummary(emailCtx, q, facility.ID, &reservation.ReservationTypeID, reservation.StartTime, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", facility.ID).Msg("Failed to load cancellation policy for confirmation email")
+		cancellationPolicy = "Contact the facility for cancellation policy details."
+	}
+	date, timeRange := email.FormatDateTimeRange(reservation.StartTime.In(facilityLoc), reservation.EndTime.In(facilityLoc))
+	confirmation := email.BuildGameConfirmation(email.ConfirmationDetails{
+		FacilityName:       facility.Name,
+		Date:               date,
+		TimeRange:          timeRange,
+		Courts:             strings.Join(courtNames, ", "),
+		CancellationPolicy: cancellationPolicy,
+		Guests:             guestNames(guests),
+		Note:               memberNote,
+		Price:              priceLabel(price),
+		AccessURL:          accessLink(accessURL, reservation.ID),
+	})
+	if email.ShouldSend(emailCtx, q, userID, email.PreferenceConfirmations) {
+		email.SendConfirmationEmail(emailCtx, q, s.emailClient, userID, confirmation, logger)
+	}
+}
+
+func accessLink(accessURL AccessURL, reservationID int64) string {
+	if accessURL == nil {
+		return ""
+	}
+	return accessURL(reservationID)
+}
+
+// CancellationPolicySummary describes, for a confirmation email, the refund
+// tier that applies to a reservation starting at startTime as of now.
+func CancellationPolicySummary(ctx context.Context, q *dbgen.Queries, facilityID int64, reservationTypeID *int64, startTime time.Time, now time.Time) (string, error) {
+	tier, err := q.GetApplicableCancellationTier(ctx, dbgen.GetApplicableCancellationTierParams{
+		FacilityID:            facilityID,
+		HoursUntilReservation: HoursUntilStart(startTime, now),
+		ReservationTypeID:     apiutil.ToNullInt64(reservationTypeID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "Cancellations are fully refundable until start time.", nil
+		}
+		return "", err
+	}
+
+	if tier.MinHoursBefore <= 0 {
+		return fmt.Sprintf("Cancel any time before start for %d%% refund.", tier.RefundPercentage), nil
+	}
+	return fmt.Sprintf("Cancel at least %d hours before start for %d%% refund.", tier.MinHoursBefore, tier.RefundPercentage), nil
+}
+
+// reservationScheduleChanged reports whether the time or the court set moved.
+func reservationScheduleChanged(before, after dbgen.Reservation, beforeCourts, afterCourts []dbgen.ListReservationCourtsRow) bool {
+	if !before.StartTime.Equal(after.StartTime) || !before.EndTime.Equal(after.EndTime) {
+		return true
+	}
+	if len(beforeCourts) != len(afterCourts) {
+		return true
+	}
+	courts := make(map[int64]struct{}, len(beforeCourts))
+	for _, court := range beforeCourts {
+		courts[court.CourtID] = struct{}{}
+	}
+	for _, court := range afterCourts {
+		if _, ok := courts[court.CourtID]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// reservationRecipients returns the primary member and participants.
+func reservationRecipients(reservation dbgen.Reservation, participants []dbgen.ListParticipantsForReservationRow) map[int64]struct{} {
+	recipients := make(map[int64]struct{}, len(participants)+1)
+	for _, participant := range participants {
+		recipients[participant.ID] = struct{}{}
+	}
+	if reservation.PrimaryUserID.Valid {
+		recipients[reservation.PrimaryUserID.Int64] = struct{}{}
+	}
+	return recipients
+}
+
+// splitReservationRecipients splits the current recipients into those who
+// were already on the reservation and those the edit added, sorted by ID.
+func splitReservationRecipients(previous, current map[int64]struct{}) ([]int64, []int64) {
+	var existing, added []int64
+	for userID := range current {
+		if _, ok := previous[userID]; ok {
+			existing = append(existing, userID)
+		} else {
+			added = append(added, userID)
+		}
+	}
+	sort.Slice(existing, func(i, j int) bool { return existing[i] < existing[j] })
+	sort.Slice(added, func(i, j int) bool { return added[i] < added[j] })
+	return existing, added
+}
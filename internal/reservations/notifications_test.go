@@ -0,0 +1,60 @@
+package reservations
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+func TestReservationScheduleChanged(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	before := dbgen.Reservation{StartTime: start, EndTime: start.Add(time.Hour)}
+	courts := func(ids ...int64) []dbgen.ListReservationCourtsRow {
+		rows := make([]dbgen.ListReservationCourtsRow, len(ids))
+		for i, id := range ids {
+			rows[i] = dbgen.ListReservationCourtsRow{CourtID: id}
+		}
+		return rows
+	}
+
+	moved := before
+	moved.StartTime = start.Add(time.Hour)
+	moved.EndTime = start.Add(2 * time.Hour)
+
+	cases := []struct {
+		name         string
+		after        dbgen.Reservation
+		beforeCourts []dbgen.ListReservationCourtsRow
+		afterCourts  []dbgen.ListReservationCourtsRow
+		want         bool
+	}{
+		{"unchanged", before, courts(1, 2), courts(2, 1), false},
+		{"time moved", moved, courts(1), courts(1), true},
+		{"court swapped", before, courts(1), courts(2), true},
+		{"court added", before, courts(1), courts(1, 2), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := reservationScheduleChanged(before, tc.after, tc.beforeCourts, tc.afterCourts); got != tc.want {
+				t.Fatalf("reservationScheduleChanged = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitReservationRecipients(t *testing.T) {
+	before := dbgen.Reservation{PrimaryUserID: sql.NullInt64{Int64: 1, Valid: true}}
+	previous := reservationRecipients(before, []dbgen.ListParticipantsForReservationRow{{ID: 2}, {ID: 3}})
+	current := reservationRecipients(before, []dbgen.ListParticipantsForReservationRow{{ID: 3}, {ID: 4}})
+
+	existing, added := splitReservationRecipients(previous, current)
+	if !reflect.DeepEqual(existing, []int64{1, 3}) {
+		t.Fatalf("existing = %v, want [1 3]", existing)
+	}
+	if !reflect.DeepEqual(added, []int64{4}) {
+		t.Fatalf("added = %v, want [4]", added)
+	}
+}
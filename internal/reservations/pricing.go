@@ -0,0 +1,116 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/pricing"
+)
+
+// PriceCourtBooking prices a new court booking under its facility's court
+// pricing rules and stores the price on it. The primary user's membership
+// level picks the rate; covered bookings, paid for by a season pass or visit pack,
+// are stored at no charge. It returns nil without storing anything for a
+// booking with no primary user or that no rule covers. loc is the facility's
+// time zone.
+func PriceCourtBooking(ctx context.Context, q *dbgen.Queries, loc *time.Location, reservation dbgen.Reservation, courts int, covered bool) (*dbgen.ReservationPrice, error) {
+	if !reservation.PrimaryUserID.Valid {
+		return nil, nil
+	}
+	primary, err := q.GetUserByID(ctx, reservation.PrimaryUserID.Int64)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := pricing.QuoteBooking(ctx, q, reservation.FacilityID, pricing.Request{
+		Start:  reservation.StartTime.In(loc),
+		End:    reservation.EndTime.In(loc),
+		Courts: courts,
+		Member: primary.MembershipLevel >= pricing.MemberLevel,
+	})
+	if err != nil || !quote.Priced {
+		return nil, err
+	}
+	amount := quote.AmountCents
+	if covered {
+		amount = 0
+	}
+	price, err := q.CreateReservationPrice(ctx, dbgen.CreateReservationPriceParams{
+		ReservationID: reservation.ID,
+		AmountCents:   amount,
+		MemberRate:    quote.Member,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
+
+// LoadReservationPrice returns the price stored for a reservation, or nil
+// when it was not priced.
+func LoadReservationPrice(ctx context.Context, q *dbgen.Queries, reservationID int64) (*dbgen.ReservationPrice, error) {
+	price, err := q.GetReservationPrice(ctx, reservationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &price, nil
+}
+
+// priceLabel shows a stored price in emails; empty leaves the line out.
+func priceLabel(price *dbgen.ReservationPrice) string {
+	if price == nil {
+		return ""
+	}
+	if price.AmountCents == 0 {
+		return "No charge"
+	}
+	return apiutil.FormatPriceCents(price.AmountCents)
+}
+
+// refundAmountCents applies refundPercentage to a stored price.
+func refundAmountCents(price *dbgen.ReservationPrice, refundPercentage int64) *int64 {
+	if price == nil {
+		return nil
+	}
+	amount := pricing.RefundCents(price.AmountCents, refundPercentage)
+	return &amount
+}
+
+// PricedReservation is a reservation with the price it was booked at, as
+// returned by create endpoints. PriceCents is left out when it has none;
+// the credit fields only when account credit paid part of it.
+type PricedReservation struct {
+	dbgen.Reservation
+	PriceCents         *int64 `json:"priceCents,omitempty"`
+	CreditAppliedCents *int64 `json:"creditAppliedCents,omitempty"`
+	AmountDueCents     *int64 `json:"amountDueCents,omitempty"`
+}
+
+// WithPrice pairs reservation with its stored price.
+func WithPrice(ctx context.Context, q *dbgen.Queries, reservation dbgen.Reservation) (PricedReservation, error) {
+	price, err := LoadReservationPrice(ctx, q, reservation.ID)
+	if err != nil {
+		return PricedReservation{}, err
+	}
+	priced := PricedReservation{Reservation: reservation}
+	if price == nil {
+		return priced, nil
+	}
+	priced.PriceCents = &price.AmountCents
+	applied, err := q.GetReservationCreditAppliedCents(ctx, reservation.ID)
+	if err != nil {
+		return PricedReservation{}, err
+	}
+	if applied > 0 {
+		due := price.AmountCents - applied
+		priced.CreditAppliedCents = &applied
+		priced.AmountDueCents = &due
+	}
+	return priced, nil
+}
@@ -0,0 +1,159 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+type RescheduleInput struct {
+	ReservationID int64
+	// OwnerID is the primary user allowed to move the reservation.
+	OwnerID   int64
+	StartTime time.Time
+	EndTime   time.Time
+	// CourtIDs moves the reservation onto these courts; empty keeps the
+	// courts it has.
+	CourtIDs []int64
+}
+
+// RescheduleReservation moves a member's reservation to a new slot in one
+// transaction. It is only a free change while cancelling would still refund
+// in full; past that point it fails with CancellationPenaltyError, as an
+// unconfirmed cancellation does, so the member sees the fee they would pay.
+// Nothing is logged as a cancellation and the original price is kept.
+func (s *Service) RescheduleReservation(ctx context.Context, in RescheduleInput) (dbgen.Reservation, error) {
+	var before, updated dbgen.Reservation
+	var previousCourts []dbgen.ListReservationCourtsRow
+	var participants []dbgen.ListParticipantsForReservationRow
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		before, err = qtx.GetReservationByID(ctx, in.ReservationID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Reservation not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch reservation", Err: err}
+		}
+		if !before.PrimaryUserID.Valid || before.PrimaryUserID.Int64 != in.OwnerID {
+			return apiutil.HandlerError{Status: http.StatusForbidden, Message: "Forbidden"}
+		}
+		if _, err := qtx.GetLatestCancellationByReservationID(ctx, before.ID); err == nil {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Reservation has been cancelled"}
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check reservation status", Err: err}
+		}
+		now := time.Now()
+		if !before.StartTime.After(now) {
+			return apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Reservation must be in the future"}
+		}
+		if before.IsOpenEvent || before.ProID.Valid {
+			return apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Only court bookings can be rescheduled"}
+		}
+
+		hoursBeforeStart := HoursUntilStart(before.StartTime, now)
+		refundPercentage, err := apiutil.ApplicableRefundPercentage(ctx, qtx, before.FacilityID, hoursBeforeStart, &before.ReservationTypeID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load cancellation policy", Err: err}
+		}
+		if refundPercentage < 100 {
+			price, err := LoadReservationPrice(ctx, qtx, before.ID)
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation price", Err: err}
+			}
+			return CancellationPenaltyError{Penalty: CancellationPenalty{
+				Reservation:      before,
+				RefundPercentage: refundPercentage,
+				Price:            price,
+				HoursBeforeStart: hoursBeforeStart,
+				CalculatedAt:     now,
+			}}
+		}
+
+		previousCourts, err = qtx.ListReservationCourts(ctx, before.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation courts", Err: err}
+		}
+		existingCourts := make([]int64, 0, len(previousCourts))
+		for _, court := range previousCourts {
+			existingCourts = append(existingCourts, court.CourtID)
+		}
+		courtIDs := normalizeIDs(in.CourtIDs)
+		if len(courtIDs) == 0 {
+			courtIDs = existingCourts
+		}
+
+		if err := ensureNoMemberOverlapExcept(ctx, qtx, in.OwnerID, before.ID, in.StartTime, in.EndTime); err != nil {
+			return err
+		}
+		details := Details{
+			ReservationTypeID: before.ReservationTypeID,
+			RecurrenceRuleID:  apiutil.NullInt64Ptr(before.RecurrenceRuleID),
+			PrimaryUserID:     apiutil.NullInt64Ptr(before.PrimaryUserID),
+			StartTime:         in.StartTime,
+			EndTime:           in.EndTime,
+		}
+		if err := ensureCourtsAvailable(ctx, qtx, bufferedBooking(before.FacilityID, before.ID, details, courtIDs), in.OwnerID); err != nil {
+			return err
+		}
+
+		updated, err = qtx.UpdateReservation(ctx, dbgen.UpdateReservationParams{
+			ID:                before.ID,
+			FacilityID:        before.FacilityID,
+			ReservationTypeID: before.ReservationTypeID,
+			RecurrenceRuleID:  before.RecurrenceRuleID,
+			PrimaryUserID:     before.PrimaryUserID,
+			ProID:             before.ProID,
+			OpenPlayRuleID:    before.OpenPlayRuleID,
+			StartTime:         in.StartTime,
+			EndTime:           in.EndTime,
+			IsOpenEvent:       before.IsOpenEvent,
+			TeamsPerCourt:     before.TeamsPerCourt,
+			PeoplePerTeam:     before.PeoplePerTeam,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to reschedule reservation", Err: err}
+		}
+		if err := qtx.DeleteReservationReminder(ctx, before.ID); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to reset reservation reminder", Err: err}
+		}
+
+		removedCourts, addedCourts := diffIDs(existingCourts, courtIDs)
+		for _, courtID := range removedCourts {
+			if err := qtx.RemoveReservationCourt(ctx, dbgen.RemoveReservationCourtParams{
+				ReservationID: before.ID,
+				CourtID:       courtID,
+			}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation court", Err: err}
+			}
+		}
+		for _, courtID := range addedCourts {
+			if err := qtx.AddReservationCourt(ctx, dbgen.AddReservationCourtParams{
+				ReservationID: before.ID,
+				CourtID:       courtID,
+			}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation court", Err: err}
+			}
+		}
+
+		participants, err = qtx.ListParticipantsForReservation(ctx, before.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation participants", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return dbgen.Reservation{}, err
+	}
+
+	s.notifyReservationUpdate(ctx, before, updated, previousCourts, participants)
+	return updated, nil
+}
@@ -0,0 +1,680 @@
+// Package reservations creates, updates, and cancels reservations for both
+// the staff API and the member portal. Handlers decode requests, decide who
+// may act, and map the errors returned here onto responses; the transactions
+// and the emails that follow them live here.
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/metrics"
+	"github.com/codr1/Pickleicious/internal/models"
+)
+
+const (
+	queryTimeout             = 5 * time.Second
+	timeLayoutDatetimeMinute = "2006-01-02 15:04"
+)
+
+// Service runs reservation changes against one database. Errors the caller
+// should show as-is are apiutil.HandlerError, ReservationLimitError,
+// MemberOverlapError, or CancellationPenaltyError.
+type Service struct {
+	db          *appdb.DB
+	emailClient *email.SESClient
+	notifiers   []email.Notifier
+}
+
+// NewService returns a Service over database. A nil emailClient skips
+// reservation emails; notifiers deliver waitlist offers and may be empty.
+func NewService(database *appdb.DB, emailClient *email.SESClient, notifiers ...email.Notifier) *Service {
+	return &Service{db: database, emailClient: emailClient, notifiers: notifiers}
+}
+
+// facilities returns the store's facility cache for post-commit lookups,
+// falling back to the uncached queries when the store has none.
+func (s *Service) facilities() apiutil.FacilityQuerier {
+	if s.db.Facilities == nil {
+		return s.db.Queries
+	}
+	return s.db.Facilities
+}
+
+// ReservationLimitError reports a primary user who already holds the
+// facility's maximum number of active reservations.
+type ReservationLimitError struct {
+	CurrentCount int64
+	Limit        int64
+}
+
+func (e ReservationLimitError) Error() string {
+	return "member reservation limit reached"
+}
+
+func (e ReservationLimitError) ErrorCode() string {
+	return apiutil.ErrorCodeReservationLimitReached
+}
+
+// MemberOverlapError reports a member who is already the primary user or a
+// participant on a reservation overlapping the requested time.
+type MemberOverlapError struct {
+	Conflict OverlapConflict
+}
+
+func (e MemberOverlapError) Error() string {
+	return "member has an overlapping reservation"
+}
+
+func (e MemberOverlapError) ErrorCode() string {
+	return apiutil.ErrorCodeReservationOverlap
+}
+
+// OverlapConflict describes the reservation a booking would overlap.
+type OverlapConflict struct {
+	ReservationID   int64     `json:"reservation_id"`
+	FacilityID      int64     `json:"facility_id"`
+	FacilityName    string    `json:"facility_name"`
+	ReservationType string    `json:"reservation_type"`
+	Courts          string    `json:"courts,omitempty"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+}
+
+// EnsureNoMemberOverlap returns a MemberOverlapError when userID is already
+// on an active reservation, at any facility, overlapping [startTime, endTime).
+func EnsureNoMemberOverlap(ctx context.Context, q *dbgen.Queries, userID int64, startTime, endTime time.Time) error {
+	return ensureNoMemberOverlapExcept(ctx, q, userID, 0, startTime, endTime)
+}
+
+// ensureNoMemberOverlapExcept is EnsureNoMemberOverlap ignoring reservationID,
+// for a reservation being moved.
+func ensureNoMemberOverlapExcept(ctx context.Context, q *dbgen.Queries, userID, reservationID int64, startTime, endTime time.Time) error {
+	conflict, err := q.GetMemberOverlappingReservation(ctx, dbgen.GetMemberOverlappingReservationParams{
+		UserID:               sql.NullInt64{Int64: userID, Valid: true},
+		StartTime:            startTime,
+		EndTime:              endTime,
+		ExcludeReservationID: reservationID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check member reservations", Err: err}
+	}
+	return MemberOverlapError{Conflict: OverlapConflict{
+		ReservationID:   conflict.ID,
+		FacilityID:      conflict.FacilityID,
+		FacilityName:    conflict.FacilityName,
+		ReservationType: conflict.ReservationTypeName,
+		Courts:          conflict.CourtNames,
+		StartTime:       conflict.StartTime,
+		EndTime:         conflict.EndTime,
+	}}
+}
+
+// ClosureDetails is the member-facing reason and staff-only notes on a
+// maintenance or closure block. Both empty removes them.
+type ClosureDetails struct {
+	PublicReason  string
+	InternalNotes string
+}
+
+// Details are the reservation fields create and update share.
+type Details struct {
+	ReservationTypeID int64
+	RecurrenceRuleID  *int64
+	PrimaryUserID     *int64
+	ProID             *int64
+	OpenPlayRuleID    *int64
+	StartTime         time.Time
+	EndTime           time.Time
+	IsOpenEvent       bool
+	TeamsPerCourt     *int64
+	PeoplePerTeam     *int64
+	CourtIDs          []int64
+	// ClosureDetails, when set, replaces the reservation's closure text.
+	ClosureDetails *ClosureDetails
+}
+
+type CreateInput struct {
+	Details
+	FacilityID      int64
+	CreatedByUserID int64
+	ParticipantIDs  []int64
+	// InviteeIDs are invited to join on behalf of the primary user rather
+	// than added as participants; InvitationLinks builds their email links.
+	InviteeIDs      []int64
+	InvitationLinks InvitationLinks
+	// Guests are non-members on the booking, capped by the facility's
+	// MaxGuestsPerReservation. Each uses one of the primary user's guest
+	// passes, or is flagged as a drop-in once they have none left.
+	Guests []Guest
+	// MemberNote is the primary user's note for staff, e.g. equipment they
+	// need. It is included in their confirmation email.
+	MemberNote string
+	// MaxActiveReservations caps the primary user's active reservations at
+	// the facility. Zero means no cap.
+	MaxActiveReservations int64
+	// LimitScope is the facility's ReservationLimitScope; LimitScopeHousehold
+	// counts the whole household's reservations against MaxActiveReservations.
+	LimitScope string
+	// PreventMemberOverlap rejects the booking with a MemberOverlapError when
+	// the primary user is already on an overlapping reservation.
+	PreventMemberOverlap bool
+	// SeasonPassID links the reservation to the season pass covering it.
+	SeasonPassID *int64
+	// VisitPackID redeems one visit from the pack for the reservation.
+	VisitPackID *int64
+	// PriceCourtTime prices a booking of a member-bookable type that has a
+	// primary user under the facility's court pricing rules and stores the
+	// price on it. A season pass or visit pack covers the price in full.
+	PriceCourtTime bool
+	// ApplyCredits pays what it can of that price from the primary user's
+	// account credit at the facility, oldest credit first.
+	ApplyCredits bool
+	// SendConfirmation emails the primary user a booking confirmation.
+	SendConfirmation bool
+	// AccessURL, when set, links the confirmation to the reservation's
+	// access QR code.
+	AccessURL AccessURL
+	// HolderUserID books through that member's court slot hold: their hold
+	// is not a conflict, and it is released in the same transaction.
+	HolderUserID int64
+	// IdempotencyKey, when set, makes a repeat of this create by
+	// CreatedByUserID return the first reservation instead of booking again.
+	// Reusing the key for a different request fails with a 422.
+	IdempotencyKey string
+}
+
+type UpdateInput struct {
+	Details
+	ReservationID int64
+	FacilityID    int64
+	// ReplaceParticipants swaps the participant list for ParticipantIDs;
+	// otherwise participants are left alone.
+	ReplaceParticipants bool
+	ParticipantIDs      []int64
+	// DurationOverride records staff saving the reservation outside its
+	// type's duration bounds. Nil when the length fits.
+	DurationOverride *DurationOverride
+}
+
+// DurationOverride is a staff member's sign-off on a reservation length
+// that breaks the type's bounds.
+type DurationOverride struct {
+	UserID   int64
+	Duration apiutil.ReservationDuration
+}
+
+// CreateReservation books the courts in one transaction after checking the
+// primary user's reservation limit, their other bookings when asked, and
+// court availability.
+func (s *Service) CreateReservation(ctx context.Context, in CreateInput) (dbgen.Reservation, error) {
+	courtIDs := normalizeIDs(in.CourtIDs)
+	participantIDs := normalizeIDs(in.ParticipantIDs)
+	guests, err := NormalizeGuests(in.Guests)
+	if err != nil {
+		return dbgen.Reservation{}, apiutil.HandlerError{Status: http.StatusBadRequest, Message: err.Error(), Err: err}
+	}
+	in.Guests = guests
+	memberNote, err := NormalizeMemberNote(in.MemberNote)
+	if err != nil {
+		return dbgen.Reservation{}, apiutil.HandlerError{Status: http.StatusBadRequest, Message: err.Error(), Err: err}
+	}
+	in.MemberNote = memberNote
+
+	now := time.Now()
+	var requestHash string
+	if in.IdempotencyKey != "" {
+		var err error
+		requestHash, err = createRequestHash(in)
+		if err != nil {
+			return dbgen.Reservation{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check idempotency key", Err: err}
+		}
+	}
+
+	var booked booking
+	var replayed bool
+	err = s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		if in.IdempotencyKey != "" {
+			var err error
+			booked.Reservation, replayed, err = replayCreate(ctx, qtx, in.CreatedByUserID, in.IdempotencyKey, requestHash, now)
+			if err != nil || replayed {
+				return err
+			}
+		}
+
+		var err error
+		booked, err = s.book(ctx, qtx, in, courtIDs, participantIDs, now)
+		if err != nil {
+			return err
+		}
+
+		if in.IdempotencyKey != "" {
+			return storeIdempotencyKey(ctx, qtx, in.CreatedByUserID, in.IdempotencyKey, requestHash, booked.Reservation, now)
+		}
+		return nil
+	})
+	if errors.Is(err, errIdempotencyKeyClaimed) {
+		// The concurrent create committed first; answer as its retry.
+		booked.Reservation, replayed, err = replayCreate(ctx, s.db.Queries, in.CreatedByUserID, in.IdempotencyKey, requestHash, now)
+		if err == nil && !replayed {
+			err = apiutil.HandlerError{Status: http.StatusConflict, Message: "Idempotency-Key is already in use", Err: errIdempotencyKeyClaimed}
+		}
+	}
+	if err != nil {
+		return dbgen.Reservation{}, err
+	}
+	if replayed {
+		return booked.Reservation, nil
+	}
+	s.announceBooking(ctx, in, courtIDs, booked)
+	return booked.Reservation, nil
+}
+
+// announceBooking records the new reservation's metric and sends its emails
+// once the transaction that booked it has committed.
+func (s *Service) announceBooking(ctx context.Context, in CreateInput, courtIDs []int64, booked booking) {
+	metrics.ReservationCreated(booked.Reservation.FacilityID, booked.ReservationTypeName)
+
+	if in.SendConfirmation {
+		s.sendBookingConfirmation(ctx, booked.Reservation, courtIDs, booked.Guests, booked.Price, in.MemberNote, in.AccessURL)
+	}
+	s.sendInvitationEmails(ctx, booked.Reservation, booked.Invitations, in.InvitationLinks)
+}
+
+// booking is what book wrote for a new reservation.
+type booking struct {
+	Reservation         dbgen.Reservation
+	ReservationTypeName string
+	Invitations         []dbgen.ReservationInvitation
+	Guests              []dbgen.ReservationGuest
+	Price               *dbgen.ReservationPrice
+}
+
+// book runs CreateReservation's checks and writes inside the caller's
+// transaction. The checks that can turn the booking away all run before the
+// first write, apart from redeeming VisitPackID.
+func (s *Service) book(ctx context.Context, qtx *dbgen.Queries, in CreateInput, courtIDs, participantIDs []int64, now time.Time) (booking, error) {
+	var booked booking
+	if in.MaxActiveReservations > 0 && in.PrimaryUserID != nil {
+		activeCount, err := CountActiveReservations(ctx, qtx, in.FacilityID, *in.PrimaryUserID, in.LimitScope)
+		if err != nil {
+			return booking{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check reservation limits", Err: err}
+		}
+		if activeCount >= in.MaxActiveReservations {
+			return booking{}, ReservationLimitError{CurrentCount: activeCount, Limit: in.MaxActiveReservations}
+		}
+	}
+
+	if in.PreventMemberOverlap && in.PrimaryUserID != nil {
+		if err := EnsureNoMemberOverlap(ctx, qtx, *in.PrimaryUserID, in.StartTime, in.EndTime); err != nil {
+			return booking{}, err
+		}
+	}
+
+	reservationType, err := ensureReservationTypeAvailable(ctx, qtx, in.FacilityID, in.ReservationTypeID)
+	if err != nil {
+		return booking{}, err
+	}
+	booked.ReservationTypeName = reservationType.Name
+	if err := ensureCourtsAvailable(ctx, qtx, bufferedBooking(in.FacilityID, 0, in.Details, courtIDs), in.HolderUserID); err != nil {
+		return booking{}, err
+	}
+	if in.HolderUserID != 0 {
+		if _, err := qtx.DeleteCourtSlotHoldByUser(ctx, in.HolderUserID); err != nil {
+			return booking{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to release slot hold", Err: err}
+		}
+	}
+
+	created, err := qtx.CreateReservation(ctx, dbgen.CreateReservationParams{
+		FacilityID:        in.FacilityID,
+		ReservationTypeID: in.ReservationTypeID,
+		RecurrenceRuleID:  apiutil.ToNullInt64(in.RecurrenceRuleID),
+		PrimaryUserID:     apiutil.ToNullInt64(in.PrimaryUserID),
+		CreatedByUserID:   in.CreatedByUserID,
+		ProID:             apiutil.ToNullInt64(in.ProID),
+		OpenPlayRuleID:    apiutil.ToNullInt64(in.OpenPlayRuleID),
+		StartTime:         in.StartTime,
+		EndTime:           in.EndTime,
+		IsOpenEvent:       in.IsOpenEvent,
+		TeamsPerCourt:     apiutil.ToNullInt64(in.TeamsPerCourt),
+		PeoplePerTeam:     apiutil.ToNullInt64(in.PeoplePerTeam),
+	})
+	if err != nil {
+		return booking{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to create reservation", Err: err}
+	}
+
+	for _, courtID := range courtIDs {
+		if err := qtx.AddReservationCourt(ctx, dbgen.AddReservationCourtParams{
+			ReservationID: created.ID,
+			CourtID:       courtID,
+		}); err != nil {
+			return booking{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to assign courts", Err: err}
+		}
+	}
+	for _, participantID := range participantIDs {
+		if err := qtx.AddParticipant(ctx, dbgen.AddParticipantParams{
+			ReservationID: created.ID,
+			UserID:        participantID,
+		}); err != nil {
+			return booking{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation participant", Err: err}
+		}
+	}
+	booked.Guests, err = addGuests(ctx, qtx, created, in.Guests)
+	if err != nil {
+		return booking{}, err
+	}
+	if in.MemberNote != "" {
+		if err := qtx.CreateReservationMemberNote(ctx, dbgen.CreateReservationMemberNoteParams{
+			ReservationID: created.ID,
+			Note:          in.MemberNote,
+		}); err != nil {
+			return booking{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to save member note", Err: err}
+		}
+	}
+	if inviteeIDs := normalizeIDs(in.InviteeIDs); len(inviteeIDs) > 0 {
+		booked.Invitations, err = createInvitations(ctx, qtx, created, inviteeIDs)
+		if err != nil {
+			return booking{}, err
+		}
+	}
+	if in.ClosureDetails != nil {
+		if err := saveClosureDetails(ctx, qtx, created.ID, *in.ClosureDetails); err != nil {
+			return booking{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to save closure details", Err: err}
+		}
+	}
+
+	if in.SeasonPassID != nil {
+		if _, err := qtx.CreateSeasonPassReservation(ctx, dbgen.CreateSeasonPassReservationParams{
+			SeasonPassID:  *in.SeasonPassID,
+			ReservationID: created.ID,
+		}); err != nil {
+			return booking{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to apply season pass", Err: err}
+		}
+	} else if in.VisitPackID != nil {
+		if _, err := models.RedeemVisitPackVisit(ctx, qtx, models.RedeemVisitPackVisitParams{
+			VisitPackID:   *in.VisitPackID,
+			FacilityID:    in.FacilityID,
+			ReservationID: &created.ID,
+		}); err != nil {
+			if errors.Is(err, models.ErrVisitPackUnavailable) {
+				return booking{}, apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Selected visit pack is not available", Err: err}
+			}
+			return booking{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to redeem visit pack", Err: err}
+		}
+	}
+	if in.PriceCourtTime && reservationType.MemberBookable {
+		facility, err := s.facilities().GetFacilityByID(ctx, in.FacilityID)
+		if err != nil {
+			return booking{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load facility", Err: err}
+		}
+		covered := in.SeasonPassID != nil || in.VisitPackID != nil
+		booked.Price, err = PriceCourtBooking(ctx, qtx, apiutil.FacilityLocation(facility, log.Ctx(ctx)), created, len(courtIDs), covered)
+		if err != nil {
+			return booking{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to price reservation", Err: err}
+		}
+		if in.ApplyCredits && booked.Price != nil {
+			if _, err := ApplyCredits(ctx, qtx, created.PrimaryUserID.Int64, in.FacilityID, created.ID, booked.Price.AmountCents, now); err != nil {
+				return booking{}, err
+			}
+		}
+	}
+
+	booked.Reservation = created
+	return booked, nil
+}
+
+// UpdateReservation rewrites the reservation and its courts, and its
+// participants when asked, then emails the people on it about the change.
+func (s *Service) UpdateReservation(ctx context.Context, in UpdateInput) (dbgen.Reservation, error) {
+	courtIDs := normalizeIDs(in.CourtIDs)
+	participantIDs := normalizeIDs(in.ParticipantIDs)
+
+	var before, updated dbgen.Reservation
+	var previousCourts []dbgen.ListReservationCourtsRow
+	var previousParticipants []dbgen.ListParticipantsForReservationRow
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		before, err = qtx.GetReservation(ctx, dbgen.GetReservationParams{
+			ID:         in.ReservationID,
+			FacilityID: in.FacilityID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Reservation not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch reservation", Err: err}
+		}
+
+		if in.ReservationTypeID != before.ReservationTypeID {
+			if _, err := ensureReservationTypeAvailable(ctx, qtx, in.FacilityID, in.ReservationTypeID); err != nil {
+				return err
+			}
+		}
+		if err := ensureCourtsAvailable(ctx, qtx, bufferedBooking(in.FacilityID, in.ReservationID, in.Details, courtIDs), 0); err != nil {
+			return err
+		}
+
+		updated, err = qtx.UpdateReservation(ctx, dbgen.UpdateReservationParams{
+			ID:                in.ReservationID,
+			FacilityID:        in.FacilityID,
+			ReservationTypeID: in.ReservationTypeID,
+			RecurrenceRuleID:  apiutil.ToNullInt64(in.RecurrenceRuleID),
+			PrimaryUserID:     apiutil.ToNullInt64(in.PrimaryUserID),
+			ProID:             apiutil.ToNullInt64(in.ProID),
+			OpenPlayRuleID:    apiutil.ToNullInt64(in.OpenPlayRuleID),
+			StartTime:         in.StartTime,
+			EndTime:           in.EndTime,
+			IsOpenEvent:       in.IsOpenEvent,
+			TeamsPerCourt:     apiutil.ToNullInt64(in.TeamsPerCourt),
+			PeoplePerTeam:     apiutil.ToNullInt64(in.PeoplePerTeam),
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update reservation", Err: err}
+		}
+		if override := in.DurationOverride; override != nil {
+			if _, err := qtx.CreateReservationDurationOverride(ctx, dbgen.CreateReservationDurationOverrideParams{
+				ReservationID:      in.ReservationID,
+				DurationMinutes:    int64(in.EndTime.Sub(in.StartTime) / time.Minute),
+				MinDurationMinutes: int64(override.Duration.Min / time.Minute),
+				MaxDurationMinutes: sql.NullInt64{Int64: int64(override.Duration.Max / time.Minute), Valid: override.Duration.Max > 0},
+				OverriddenByUserID: override.UserID,
+			}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to record duration override", Err: err}
+			}
+		}
+		// A moved reservation gets a fresh reminder for its new start time.
+		if !updated.StartTime.Equal(before.StartTime) {
+			if err := qtx.DeleteReservationReminder(ctx, in.ReservationID); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to reset reservation reminder", Err: err}
+			}
+		}
+
+		previousCourts, err = qtx.ListReservationCourts(ctx, in.ReservationID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation courts", Err: err}
+		}
+		existingCourts := make([]int64, 0, len(previousCourts))
+		for _, court := range previousCourts {
+			existingCourts = append(existingCourts, court.CourtID)
+		}
+		removedCourts, addedCourts := diffIDs(existingCourts, courtIDs)
+		for _, courtID := range removedCourts {
+			if err := qtx.RemoveReservationCourt(ctx, dbgen.RemoveReservationCourtParams{
+				ReservationID: in.ReservationID,
+				CourtID:       courtID,
+			}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation court", Err: err}
+			}
+		}
+		for _, courtID := range addedCourts {
+			if err := qtx.AddReservationCourt(ctx, dbgen.AddReservationCourtParams{
+				ReservationID: in.ReservationID,
+				CourtID:       courtID,
+			}); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation court", Err: err}
+			}
+		}
+
+		previousParticipants, err = qtx.ListParticipantsForReservation(ctx, in.ReservationID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation participants", Err: err}
+		}
+		if in.ReplaceParticipants {
+			existingParticipants := make([]int64, 0, len(previousParticipants))
+			for _, participant := range previousParticipants {
+				existingParticipants = append(existingParticipants, participant.ID)
+			}
+			removedParticipants, addedParticipants := diffIDs(existingParticipants, participantIDs)
+			for _, participantID := range removedParticipants {
+				if err := removeParticipant(ctx, qtx, in.ReservationID, participantID); err != nil {
+					return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation participant", Err: err}
+				}
+			}
+			for _, participantID := range addedParticipants {
+				if err := addParticipant(ctx, qtx, in.ReservationID, participantID); err != nil {
+					return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation participant", Err: err}
+				}
+			}
+		}
+
+		if in.ClosureDetails != nil {
+			if err := saveClosureDetails(ctx, qtx, in.ReservationID, *in.ClosureDetails); err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to save closure details", Err: err}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return dbgen.Reservation{}, err
+	}
+
+	s.notifyReservationUpdate(ctx, before, updated, previousCourts, previousParticipants)
+	return updated, nil
+}
+
+// HoursUntilStart is the whole hours left before start, or zero once it has
+// passed. Cancellation tiers are matched against it.
+func HoursUntilStart(start time.Time, now time.Time) int64 {
+	hours := int64(start.Sub(now).Hours())
+	if hours < 0 {
+		return 0
+	}
+	return hours
+}
+
+// ensureCourtsAvailable rejects a booking whose courts are taken, held by
+// someone other than holderUserID, or inside another booking's turnover
+// buffer.
+func ensureCourtsAvailable(ctx context.Context, q *dbgen.Queries, booking apiutil.BufferedBooking, holderUserID int64) error {
+	err := apiutil.EnsureCourtsAvailableForHolder(ctx, q, booking.FacilityID, booking.ReservationID, holderUserID, booking.StartTime, booking.EndTime, booking.CourtIDs)
+	if err == nil {
+		err = apiutil.EnsureCourtBuffers(ctx, q, booking)
+	}
+	if err != nil {
+		var availErr apiutil.AvailabilityError
+		if errors.As(err, &availErr) {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: err.Error(), Err: err}
+		}
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check court availability", Err: err}
+	}
+	return nil
+}
+
+func bufferedBooking(facilityID, reservationID int64, details Details, courtIDs []int64) apiutil.BufferedBooking {
+	return apiutil.BufferedBooking{
+		FacilityID:        facilityID,
+		ReservationID:     reservationID,
+		ReservationTypeID: details.ReservationTypeID,
+		RecurrenceRuleID:  details.RecurrenceRuleID,
+		PrimaryUserID:     details.PrimaryUserID,
+		StartTime:         details.StartTime,
+		EndTime:           details.EndTime,
+		CourtIDs:          courtIDs,
+	}
+}
+
+// ensureReservationTypeAvailable rejects custom reservation types that belong
+// to another organization or facility, and returns the type otherwise.
+func ensureReservationTypeAvailable(ctx context.Context, q *dbgen.Queries, facilityID, reservationTypeID int64) (dbgen.ReservationType, error) {
+	reservationType, err := q.GetReservationTypeForFacility(ctx, dbgen.GetReservationTypeForFacilityParams{
+		ID:         reservationTypeID,
+		FacilityID: sql.NullInt64{Int64: facilityID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbgen.ReservationType{}, apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Reservation type not available at this facility", Err: err}
+		}
+		return dbgen.ReservationType{}, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation type", Err: err}
+	}
+	return reservationType, nil
+}
+
+// saveClosureDetails stores the member-facing reason and staff-only notes for
+// a block, or removes them when both are empty.
+func saveClosureDetails(ctx context.Context, q *dbgen.Queries, reservationID int64, details ClosureDetails) error {
+	if details.PublicReason == "" && details.InternalNotes == "" {
+		return q.DeleteReservationClosureDetails(ctx, reservationID)
+	}
+	return q.UpsertReservationClosureDetails(ctx, dbgen.UpsertReservationClosureDetailsParams{
+		ReservationID: reservationID,
+		PublicReason:  sql.NullString{String: details.PublicReason, Valid: details.PublicReason != ""},
+		InternalNotes: sql.NullString{String: details.InternalNotes, Valid: details.InternalNotes != ""},
+	})
+}
+
+// normalizeIDs drops repeats, keeping first-seen order.
+func normalizeIDs(ids []int64) []int64 {
+	if ids == nil {
+		return nil
+	}
+	seen := make(map[int64]struct{}, len(ids))
+	normalized := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		normalized = append(normalized, id)
+	}
+	return normalized
+}
+
+// diffIDs returns the IDs in existing but not next, and in next but not
+// existing, each in input order.
+func diffIDs(existing, next []int64) (removed, added []int64) {
+	existingSet := make(map[int64]struct{}, len(existing))
+	for _, id := range existing {
+		existingSet[id] = struct{}{}
+	}
+	nextSet := make(map[int64]struct{}, len(next))
+	for _, id := range next {
+		nextSet[id] = struct{}{}
+	}
+	for _, id := range existing {
+		if _, ok := nextSet[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	for _, id := range next {
+		if _, ok := existingSet[id]; !ok {
+			added = append(added, id)
+		}
+	}
+	return removed, added
+}
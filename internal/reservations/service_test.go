@@ -0,0 +1,534 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+type serviceFixture struct {
+	database   *db.DB
+	service    *Service
+	facilityID int64
+	userID     int64
+	gameTypeID int64
+	courtIDs   []int64
+}
+
+func setupServiceTest(t *testing.T) serviceFixture {
+	t.Helper()
+
+	database := testutil.NewTestDB(t)
+	exec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.Exec(query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			t.Fatalf("last insert id: %v", err)
+		}
+		return id
+	}
+
+	orgID := exec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := exec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main Facility', 'main-facility', 'UTC')", orgID)
+	userID := exec("INSERT INTO users (first_name, last_name, email, status) VALUES ('Mia', 'Member', 'mia@test.com', 'active')")
+	courtIDs := []int64{
+		exec("INSERT INTO courts (facility_id, name, court_number) VALUES (?, 'Court 1', 1)", facilityID),
+		exec("INSERT INTO courts (facility_id, name, court_number) VALUES (?, 'Court 2', 2)", facilityID),
+	}
+
+	var gameTypeID int64
+	if err := database.QueryRow("SELECT id FROM reservation_types WHERE name = 'GAME'").Scan(&gameTypeID); err != nil {
+		t.Fatalf("load GAME reservation type: %v", err)
+	}
+
+	return serviceFixture{
+		database:   database,
+		service:    NewService(database, nil),
+		facilityID: facilityID,
+		userID:     userID,
+		gameTypeID: gameTypeID,
+		courtIDs:   courtIDs,
+	}
+}
+
+func (f serviceFixture) createInput(start time.Time, courtIDs ...int64) CreateInput {
+	return CreateInput{
+		Details: Details{
+			ReservationTypeID: f.gameTypeID,
+			PrimaryUserID:     &f.userID,
+			StartTime:         start,
+			EndTime:           start.Add(time.Hour),
+			CourtIDs:          courtIDs,
+		},
+		FacilityID:      f.facilityID,
+		CreatedByUserID: f.userID,
+		ParticipantIDs:  []int64{f.userID},
+	}
+}
+
+func (f serviceFixture) insertReservation(t *testing.T, start time.Time) int64 {
+	t.Helper()
+
+	result, err := f.database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		f.facilityID, f.gameTypeID, f.userID, f.userID, start, start.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatalf("reservation id: %v", err)
+	}
+	return id
+}
+
+func (f serviceFixture) courtsOf(t *testing.T, reservationID int64) []int64 {
+	t.Helper()
+
+	rows, err := f.database.Queries.ListReservationCourts(context.Background(), reservationID)
+	if err != nil {
+		t.Fatalf("list reservation courts: %v", err)
+	}
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.CourtID)
+	}
+	return ids
+}
+
+func TestCreateReservation_EnforcesActiveReservationLimit(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	first := fixture.createInput(start, fixture.courtIDs[0])
+	first.MaxActiveReservations = 1
+	created, err := fixture.service.CreateReservation(ctx, first)
+	if err != nil {
+		t.Fatalf("create first reservation: %v", err)
+	}
+	if courts := fixture.courtsOf(t, created.ID); len(courts) != 1 || courts[0] != fixture.courtIDs[0] {
+		t.Fatalf("expected court %d, got %v", fixture.courtIDs[0], courts)
+	}
+
+	second := fixture.createInput(start.Add(2*time.Hour), fixture.courtIDs[1])
+	second.MaxActiveReservations = 1
+	_, err = fixture.service.CreateReservation(ctx, second)
+	var limitErr ReservationLimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("expected reservation limit error, got %v", err)
+	}
+	if limitErr.CurrentCount != 1 || limitErr.Limit != 1 {
+		t.Fatalf("unexpected limit error: %+v", limitErr)
+	}
+}
+
+func TestCreateReservation_RejectsBookedCourt(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	if _, err := fixture.service.CreateReservation(ctx, fixture.createInput(start, fixture.courtIDs[0])); err != nil {
+		t.Fatalf("create first reservation: %v", err)
+	}
+
+	_, err := fixture.service.CreateReservation(ctx, fixture.createInput(start.Add(30*time.Minute), fixture.courtIDs[0], fixture.courtIDs[0]))
+	var herr apiutil.HandlerError
+	if !errors.As(err, &herr) || herr.Status != http.StatusConflict {
+		t.Fatalf("expected 409 handler error, got %v", err)
+	}
+}
+
+func TestCreateReservation_PreventsMemberOverlap(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	existingID := fixture.insertReservation(t, start)
+	if _, err := fixture.database.Exec("INSERT INTO reservation_courts (reservation_id, court_id) VALUES (?, ?)", existingID, fixture.courtIDs[0]); err != nil {
+		t.Fatalf("assign court: %v", err)
+	}
+
+	overlapping := fixture.createInput(start.Add(30*time.Minute), fixture.courtIDs[1])
+	overlapping.PreventMemberOverlap = true
+	_, err := fixture.service.CreateReservation(ctx, overlapping)
+	var overlapErr MemberOverlapError
+	if !errors.As(err, &overlapErr) {
+		t.Fatalf("expected member overlap error, got %v", err)
+	}
+	if overlapErr.Conflict.ReservationID != existingID || overlapErr.Conflict.ReservationType != "GAME" || overlapErr.Conflict.Courts != "Court 1" {
+		t.Fatalf("unexpected conflict: %+v", overlapErr.Conflict)
+	}
+
+	// Back-to-back bookings do not overlap.
+	adjacent := fixture.createInput(start.Add(time.Hour), fixture.courtIDs[0])
+	adjacent.PreventMemberOverlap = true
+	if _, err := fixture.service.CreateReservation(ctx, adjacent); err != nil {
+		t.Fatalf("create adjacent reservation: %v", err)
+	}
+
+	// Staff overrides skip the check.
+	if _, err := fixture.service.CreateReservation(ctx, fixture.createInput(start.Add(30*time.Minute), fixture.courtIDs[1])); err != nil {
+		t.Fatalf("create overlapping reservation without the check: %v", err)
+	}
+
+	// Cancelled reservations no longer block the member.
+	later := start.Add(24 * time.Hour)
+	cancelledID := fixture.insertReservation(t, later)
+	if _, err := fixture.database.Exec("INSERT INTO reservation_cancellations (reservation_id, cancelled_by_user_id, cancelled_at, refund_percentage_applied, fee_waived, hours_before_start) VALUES (?, ?, CURRENT_TIMESTAMP, 100, 0, 24)", cancelledID, fixture.userID); err != nil {
+		t.Fatalf("cancel reservation: %v", err)
+	}
+	afterCancel := fixture.createInput(later, fixture.courtIDs[0])
+	afterCancel.PreventMemberOverlap = true
+	if _, err := fixture.service.CreateReservation(ctx, afterCancel); err != nil {
+		t.Fatalf("expected a cancelled reservation not to block, got %v", err)
+	}
+}
+
+func TestUpdateReservation_ReplacesCourtsAndKeepsParticipantsUnlessAsked(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	created, err := fixture.service.CreateReservation(ctx, fixture.createInput(start, fixture.courtIDs[0]))
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+
+	input := UpdateInput{
+		Details:       fixture.createInput(start.Add(time.Hour), fixture.courtIDs[1]).Details,
+		ReservationID: created.ID,
+		FacilityID:    fixture.facilityID,
+	}
+	updated, err := fixture.service.UpdateReservation(ctx, input)
+	if err != nil {
+		t.Fatalf("update reservation: %v", err)
+	}
+	if !updated.StartTime.Equal(start.Add(time.Hour)) {
+		t.Fatalf("expected start %s, got %s", start.Add(time.Hour), updated.StartTime)
+	}
+	if courts := fixture.courtsOf(t, created.ID); len(courts) != 1 || courts[0] != fixture.courtIDs[1] {
+		t.Fatalf("expected only court %d, got %v", fixture.courtIDs[1], courts)
+	}
+	participants, err := fixture.database.Queries.ListParticipantsForReservation(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("list participants: %v", err)
+	}
+	if len(participants) != 1 {
+		t.Fatalf("expected participants untouched, got %d", len(participants))
+	}
+
+	input.ReplaceParticipants = true
+	input.ParticipantIDs = nil
+	if _, err := fixture.service.UpdateReservation(ctx, input); err != nil {
+		t.Fatalf("clear participants: %v", err)
+	}
+	participants, err = fixture.database.Queries.ListParticipantsForReservation(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("list participants: %v", err)
+	}
+	if len(participants) != 0 {
+		t.Fatalf("expected participants cleared, got %d", len(participants))
+	}
+
+	input.ReservationID = created.ID + 100
+	var herr apiutil.HandlerError
+	if _, err := fixture.service.UpdateReservation(ctx, input); !errors.As(err, &herr) || herr.Status != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown reservation, got %v", err)
+	}
+}
+
+func TestCancelReservation_HoldsPenaltyUntilConfirmed(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	if _, err := fixture.database.Exec(
+		"INSERT INTO cancellation_policy_tiers (facility_id, min_hours_before, refund_percentage) VALUES (?, 0, 50)",
+		fixture.facilityID,
+	); err != nil {
+		t.Fatalf("insert cancellation tier: %v", err)
+	}
+	created, err := fixture.service.CreateReservation(ctx, fixture.createInput(start, fixture.courtIDs[0]))
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+
+	confirm := false
+	input := CancelInput{
+		ReservationID:     created.ID,
+		CancelledByUserID: fixture.userID,
+		OwnerID:           fixture.userID,
+		ConfirmPenalty: func(context.Context, *dbgen.Queries, CancellationPenalty) (bool, error) {
+			return confirm, nil
+		},
+	}
+
+	_, err = fixture.service.CancelReservation(ctx, input)
+	var penaltyErr CancellationPenaltyError
+	if !errors.As(err, &penaltyErr) {
+		t.Fatalf("expected cancellation penalty error, got %v", err)
+	}
+	if penaltyErr.Penalty.RefundPercentage != 50 || penaltyErr.Penalty.Reservation.ID != created.ID {
+		t.Fatalf("unexpected penalty: %+v", penaltyErr.Penalty)
+	}
+	if courts := fixture.courtsOf(t, created.ID); len(courts) != 1 {
+		t.Fatalf("expected courts kept while penalty is pending, got %v", courts)
+	}
+
+	confirm = true
+	result, err := fixture.service.CancelReservation(ctx, input)
+	if err != nil {
+		t.Fatalf("cancel reservation: %v", err)
+	}
+	if result.RefundPercentage != 50 || result.FeeWaived {
+		t.Fatalf("unexpected cancel result: %+v", result)
+	}
+	if courts := fixture.courtsOf(t, created.ID); len(courts) != 0 {
+		t.Fatalf("expected courts freed, got %v", courts)
+	}
+	var refund int64
+	if err := fixture.database.QueryRow(
+		"SELECT refund_percentage_applied FROM reservation_cancellations WHERE reservation_id = ?", created.ID,
+	).Scan(&refund); err != nil {
+		t.Fatalf("load cancellation log: %v", err)
+	}
+	if refund != 50 {
+		t.Fatalf("expected logged refund 50, got %d", refund)
+	}
+}
+
+func TestCancelReservation_WaiveFeeSkipsPenaltyAndRefundsInFull(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	if _, err := fixture.database.Exec(
+		"INSERT INTO cancellation_policy_tiers (facility_id, min_hours_before, refund_percentage) VALUES (?, 0, 50)",
+		fixture.facilityID,
+	); err != nil {
+		t.Fatalf("insert cancellation tier: %v", err)
+	}
+	reservationID := fixture.insertReservation(t, start)
+
+	result, err := fixture.service.CancelReservation(ctx, CancelInput{
+		ReservationID:     reservationID,
+		CancelledByUserID: fixture.userID,
+		WaiveFee:          true,
+		ConfirmPenalty: func(context.Context, *dbgen.Queries, CancellationPenalty) (bool, error) {
+			t.Fatal("penalty should not be confirmed when the fee is waived")
+			return false, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("cancel reservation: %v", err)
+	}
+	if result.RefundPercentage != 100 || !result.FeeWaived {
+		t.Fatalf("unexpected cancel result: %+v", result)
+	}
+}
+
+func TestCancelReservation_OwnerMustMatchAndStartInFuture(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+
+	upcoming := fixture.insertReservation(t, time.Now().Add(48*time.Hour).Truncate(time.Hour))
+	var herr apiutil.HandlerError
+	_, err := fixture.service.CancelReservation(ctx, CancelInput{
+		ReservationID:     upcoming,
+		CancelledByUserID: fixture.userID + 1,
+		OwnerID:           fixture.userID + 1,
+	})
+	if !errors.As(err, &herr) || herr.Status != http.StatusForbidden {
+		t.Fatalf("expected 403 for another member, got %v", err)
+	}
+
+	past := fixture.insertReservation(t, time.Now().Add(-2*time.Hour))
+	_, err = fixture.service.CancelReservation(ctx, CancelInput{
+		ReservationID:     past,
+		CancelledByUserID: fixture.userID,
+		OwnerID:           fixture.userID,
+	})
+	if !errors.As(err, &herr) || herr.Status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a past reservation, got %v", err)
+	}
+}
+
+func TestHoursUntilStart(t *testing.T) {
+	now := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	if got := HoursUntilStart(now.Add(90*time.Minute), now); got != 1 {
+		t.Fatalf("expected 1 hour, got %d", got)
+	}
+	if got := HoursUntilStart(now.Add(-time.Hour), now); got != 0 {
+		t.Fatalf("expected 0 for a past start, got %d", got)
+	}
+}
+
+func TestCreateReservation_IdempotencyKeyReplaysPerUser(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	input := fixture.createInput(start, fixture.courtIDs[0])
+	input.IdempotencyKey = "retry-1"
+	input.MaxActiveReservations = 1
+	created, err := fixture.service.CreateReservation(ctx, input)
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+
+	// The replay wins over the limit the first booking used up.
+	replayed, err := fixture.service.CreateReservation(ctx, input)
+	if err != nil {
+		t.Fatalf("replay reservation: %v", err)
+	}
+	if replayed.ID != created.ID || !replayed.StartTime.Equal(created.StartTime) {
+		t.Fatalf("expected replay of reservation %d, got %+v", created.ID, replayed)
+	}
+
+	changed := input
+	changed.CourtIDs = []int64{fixture.courtIDs[1]}
+	var herr apiutil.HandlerError
+	if _, err := fixture.service.CreateReservation(ctx, changed); !errors.As(err, &herr) || herr.Status != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for a reused key, got %v", err)
+	}
+
+	otherUser := fixture.createInput(start, fixture.courtIDs[1])
+	otherUser.CreatedByUserID = fixture.userID + 1
+	if _, err := fixture.database.Exec("INSERT INTO users (id, first_name, last_name, email, status) VALUES (?, 'Sam', 'Staff', 'sam@test.com', 'active')", otherUser.CreatedByUserID); err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	otherUser.IdempotencyKey = "retry-1"
+	other, err := fixture.service.CreateReservation(ctx, otherUser)
+	if err != nil {
+		t.Fatalf("create with another user's key: %v", err)
+	}
+	if other.ID == created.ID {
+		t.Fatal("expected keys to be scoped per user")
+	}
+
+	if _, err := fixture.database.Exec("UPDATE reservation_idempotency_keys SET expires_at = ? WHERE reservation_id = ?", time.Now().Add(-time.Minute), created.ID); err != nil {
+		t.Fatalf("expire key: %v", err)
+	}
+	reused := fixture.createInput(start.Add(3*time.Hour), fixture.courtIDs[0])
+	reused.IdempotencyKey = "retry-1"
+	fresh, err := fixture.service.CreateReservation(ctx, reused)
+	if err != nil {
+		t.Fatalf("reuse expired key: %v", err)
+	}
+	if fresh.ID == created.ID {
+		t.Fatal("expected an expired key to book again")
+	}
+}
+
+func TestCreateReservation_StoresMemberNote(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	tooLong := fixture.createInput(start, fixture.courtIDs[0])
+	tooLong.MemberNote = strings.Repeat("x", MaxMemberNoteLength+1)
+	_, err := fixture.service.CreateReservation(ctx, tooLong)
+	var herr apiutil.HandlerError
+	if !errors.As(err, &herr) || herr.Status != http.StatusBadRequest {
+		t.Fatalf("expected 400 handler error for an oversized note, got %v", err)
+	}
+
+	in := fixture.createInput(start, fixture.courtIDs[0])
+	in.MemberNote = "  <b>Bringing a ball machine</b>  "
+	created, err := fixture.service.CreateReservation(ctx, in)
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+	note, err := fixture.database.Queries.GetReservationMemberNote(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("load member note: %v", err)
+	}
+	if note != "<b>Bringing a ball machine</b>" {
+		t.Fatalf("expected trimmed note stored verbatim, got %q", note)
+	}
+
+	withoutNote, err := fixture.service.CreateReservation(ctx, fixture.createInput(start, fixture.courtIDs[1]))
+	if err != nil {
+		t.Fatalf("create reservation without note: %v", err)
+	}
+	if _, err := fixture.database.Queries.GetReservationMemberNote(ctx, withoutNote.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected no note row, got %v", err)
+	}
+}
+
+func TestCreateReservation_EnforcesTurnoverBuffer(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	if _, err := fixture.database.Exec("UPDATE facilities SET buffer_minutes = 10 WHERE id = ?", fixture.facilityID); err != nil {
+		t.Fatalf("set buffer: %v", err)
+	}
+
+	first, err := fixture.service.CreateReservation(ctx, fixture.createInput(start, fixture.courtIDs[0]))
+	if err != nil {
+		t.Fatalf("create first reservation: %v", err)
+	}
+
+	for _, next := range []time.Time{start.Add(time.Hour), start.Add(-time.Hour)} {
+		_, err = fixture.service.CreateReservation(ctx, fixture.createInput(next, fixture.courtIDs[0]))
+		var herr apiutil.HandlerError
+		if !errors.As(err, &herr) || herr.Status != http.StatusConflict || !strings.Contains(herr.Message, "10-minute buffer") {
+			t.Fatalf("expected a 409 naming the buffer for %v, got %v", next, err)
+		}
+	}
+	if _, err := fixture.service.CreateReservation(ctx, fixture.createInput(start.Add(70*time.Minute), fixture.courtIDs[0])); err != nil {
+		t.Fatalf("expected a booking after the buffer to succeed: %v", err)
+	}
+	if _, err := fixture.service.CreateReservation(ctx, fixture.createInput(start.Add(time.Hour), fixture.courtIDs[1])); err != nil {
+		t.Fatalf("expected the buffer to apply per court: %v", err)
+	}
+
+	// Moving the reservation does not collide with its own buffer.
+	if _, err := fixture.service.UpdateReservation(ctx, UpdateInput{
+		Details:       fixture.createInput(start.Add(-10*time.Minute), fixture.courtIDs[0]).Details,
+		ReservationID: first.ID,
+		FacilityID:    fixture.facilityID,
+	}); err != nil {
+		t.Fatalf("update reservation: %v", err)
+	}
+}
+
+func TestCreateReservation_NoBufferWithinRecurringSeries(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	if _, err := fixture.database.Exec("UPDATE facilities SET buffer_minutes = 10 WHERE id = ?", fixture.facilityID); err != nil {
+		t.Fatalf("set buffer: %v", err)
+	}
+	var ruleID int64
+	if err := fixture.database.QueryRow("INSERT INTO recurrence_rules (name) VALUES ('WEEKLY') RETURNING id").Scan(&ruleID); err != nil {
+		t.Fatalf("insert recurrence rule: %v", err)
+	}
+
+	first := fixture.createInput(start, fixture.courtIDs[0])
+	first.RecurrenceRuleID = &ruleID
+	if _, err := fixture.service.CreateReservation(ctx, first); err != nil {
+		t.Fatalf("create first segment: %v", err)
+	}
+	second := fixture.createInput(start.Add(time.Hour), fixture.courtIDs[0])
+	second.RecurrenceRuleID = &ruleID
+	if _, err := fixture.service.CreateReservation(ctx, second); err != nil {
+		t.Fatalf("expected the next segment of the series to skip the buffer: %v", err)
+	}
+}
@@ -0,0 +1,475 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+)
+
+const (
+	// DefaultTransferWindow is how long a transfer target has to accept
+	// when the caller does not say.
+	DefaultTransferWindow = 48 * time.Hour
+
+	TransferStatusPending   = "pending"
+	TransferStatusAccepted  = "accepted"
+	TransferStatusDeclined  = "declined"
+	TransferStatusCancelled = "cancelled"
+)
+
+type TransferInput struct {
+	ReservationID int64
+	// FromUserID must be the reservation's primary user.
+	FromUserID int64
+	ToUserID   int64
+	// Window bounds how long the target has to accept; zero uses
+	// DefaultTransferWindow. Offers never outlive the reservation's start.
+	Window time.Duration
+	Links  InvitationLinks
+}
+
+type StaffTransferInput struct {
+	ReservationID int64
+	FacilityID    int64
+	ToUserID      int64
+}
+
+// RequestTransfer offers the reservation to another member at its facility.
+// Nothing changes hands until the target accepts; a new request replaces any
+// earlier one still pending.
+func (s *Service) RequestTransfer(ctx context.Context, in TransferInput) (dbgen.ReservationTransfer, error) {
+	window := in.Window
+	if window <= 0 {
+		window = DefaultTransferWindow
+	}
+
+	var reservation dbgen.Reservation
+	var transfer dbgen.ReservationTransfer
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		reservation, err = qtx.GetReservationByID(ctx, in.ReservationID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Reservation not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch reservation", Err: err}
+		}
+		if !reservation.PrimaryUserID.Valid || reservation.PrimaryUserID.Int64 != in.FromUserID {
+			return apiutil.HandlerError{Status: http.StatusForbidden, Message: "Only the member who booked can transfer this reservation"}
+		}
+		if err := ensureTransferable(ctx, qtx, reservation, in.ToUserID); err != nil {
+			return err
+		}
+
+		if err := qtx.CancelPendingReservationTransfers(ctx, reservation.ID); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to replace pending transfer", Err: err}
+		}
+		token, err := newInvitationToken()
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to create transfer", Err: err}
+		}
+		expiresAt := time.Now().Add(window)
+		if reservation.StartTime.Before(expiresAt) {
+			expiresAt = reservation.StartTime
+		}
+		transfer, err = qtx.CreateReservationTransfer(ctx, dbgen.CreateReservationTransferParams{
+			ReservationID: reservation.ID,
+			FromUserID:    in.FromUserID,
+			ToUserID:      in.ToUserID,
+			Token:         token,
+			ExpiresAt:     expiresAt,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to create transfer", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return dbgen.ReservationTransfer{}, err
+	}
+
+	s.sendTransferRequestEmail(ctx, reservation, transfer, in.Links)
+	return transfer, nil
+}
+
+// AcceptTransfer makes the target the reservation's primary user. userID
+// must be the target's. The reservation then counts toward the target's
+// member limit instead of the booker's.
+func (s *Service) AcceptTransfer(ctx context.Context, token string, userID int64) (dbgen.GetReservationTransferByTokenRow, error) {
+	now := time.Now()
+	var transfer dbgen.GetReservationTransferByTokenRow
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		transfer, err = loadPendingTransfer(ctx, qtx, token, now)
+		if err != nil {
+			return err
+		}
+		if transfer.ToUserID != userID {
+			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Transfer not found"}
+		}
+		if !transfer.PrimaryUserID.Valid || transfer.PrimaryUserID.Int64 != transfer.FromUserID {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Reservation has already changed hands"}
+		}
+		if err := ensureTransferTargetUnderLimit(ctx, qtx, transfer); err != nil {
+			return err
+		}
+
+		accepted, err := qtx.MarkReservationTransferAccepted(ctx, dbgen.MarkReservationTransferAcceptedParams{
+			ID:  transfer.ID,
+			Now: now,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to accept transfer", Err: err}
+		}
+		if accepted == 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Transfer is no longer pending"}
+		}
+		if err := handOverReservation(ctx, qtx, transfer.ReservationID, transfer.FromUserID, transfer.ToUserID); err != nil {
+			return err
+		}
+		transfer.Status = TransferStatusAccepted
+		return nil
+	})
+	if err != nil {
+		return transfer, err
+	}
+
+	s.sendTransferredEmails(ctx, transfer.ReservationID, transfer.FromUserID, transfer.ToUserID)
+	return transfer, nil
+}
+
+// DeclineTransfer leaves the reservation with the booker and tells them. The
+// token alone authorizes it so targets can decline without logging in.
+func (s *Service) DeclineTransfer(ctx context.Context, token string) (dbgen.GetReservationTransferByTokenRow, error) {
+	var transfer dbgen.GetReservationTransferByTokenRow
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		transfer, err = loadPendingTransfer(ctx, qtx, token, time.Now())
+		if err != nil {
+			return err
+		}
+		declined, err := qtx.MarkReservationTransferDeclined(ctx, transfer.ID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to decline transfer", Err: err}
+		}
+		if declined == 0 {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "Transfer is no longer pending"}
+		}
+		transfer.Status = TransferStatusDeclined
+		return nil
+	})
+	if err != nil {
+		return transfer, err
+	}
+
+	s.sendTransferDeclinedEmail(ctx, transfer)
+	return transfer, nil
+}
+
+// StaffTransfer hands a member booking to another member immediately,
+// without asking the target, and cancels any pending member request.
+func (s *Service) StaffTransfer(ctx context.Context, in StaffTransferInput) (dbgen.Reservation, error) {
+	var reservation dbgen.Reservation
+	var fromUserID int64
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		var err error
+		reservation, err = qtx.GetReservation(ctx, dbgen.GetReservationParams{
+			ID:         in.ReservationID,
+			FacilityID: in.FacilityID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Reservation not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch reservation", Err: err}
+		}
+		if !reservation.PrimaryUserID.Valid {
+			return apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Only member bookings can be transferred"}
+		}
+		fromUserID = reservation.PrimaryUserID.Int64
+		if err := ensureTransferable(ctx, qtx, reservation, in.ToUserID); err != nil {
+			return err
+		}
+
+		if err := qtx.CancelPendingReservationTransfers(ctx, reservation.ID); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to cancel pending transfer", Err: err}
+		}
+		if err := handOverReservation(ctx, qtx, reservation.ID, fromUserID, in.ToUserID); err != nil {
+			return err
+		}
+		reservation.PrimaryUserID = sql.NullInt64{Int64: in.ToUserID, Valid: true}
+		return nil
+	})
+	if err != nil {
+		return dbgen.Reservation{}, err
+	}
+
+	s.sendTransferredEmails(ctx, reservation.ID, fromUserID, in.ToUserID)
+	return reservation, nil
+}
+
+// ensureTransferable rejects transfers of past or cancelled reservations and
+// targets who are not active members at the reservation's facility.
+func ensureTransferable(ctx context.Context, q *dbgen.Queries, reservation dbgen.Reservation, toUserID int64) error {
+	if !reservation.StartTime.After(time.Now()) {
+		return apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Reservation must be in the future"}
+	}
+	cancelled, err := q.IsReservationCancelled(ctx, reservation.ID)
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to fetch reservation", Err: err}
+	}
+	if cancelled != 0 {
+		return apiutil.HandlerError{Status: http.StatusConflict, Message: "Reservation was cancelled"}
+	}
+	if reservation.PrimaryUserID.Valid && reservation.PrimaryUserID.Int64 == toUserID {
+		return apiutil.HandlerError{Status: http.StatusBadRequest, Message: "Choose a different member to transfer to"}
+	}
+
+	target, err := q.GetUserByID(ctx, toUserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to look up member", Err: err}
+	}
+	if err != nil || !target.IsMember || target.Status != "active" ||
+		!target.HomeFacilityID.Valid || target.HomeFacilityID.Int64 != reservation.FacilityID {
+		return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Member not found"}
+	}
+	return nil
+}
+
+// ensureTransferTargetUnderLimit returns a ReservationLimitError when taking
+// the reservation would put the target over their member limit.
+func ensureTransferTargetUnderLimit(ctx context.Context, q *dbgen.Queries, transfer dbgen.GetReservationTransferByTokenRow) error {
+	facility, err := q.GetFacilityByID(ctx, transfer.FacilityID)
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load facility", Err: err}
+	}
+	target, err := q.GetUserByID(ctx, transfer.ToUserID)
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load member", Err: err}
+	}
+	tier, err := apiutil.LoadMembershipTier(ctx, q, facility.OrganizationID, target.MembershipLevel)
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load membership tier", Err: err}
+	}
+	limit := apiutil.MemberReservationLimit(tier, facility.MaxMemberReservations)
+	if limit <= 0 {
+		return nil
+	}
+	reservationType, err := q.GetReservationType(ctx, transfer.ReservationTypeID)
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation type", Err: err}
+	}
+	if !reservationType.CountsTowardMemberLimit {
+		return nil
+	}
+	activeCount, err := CountActiveReservations(ctx, q, transfer.FacilityID, transfer.ToUserID, facility.ReservationLimitScope)
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to check reservation limits", Err: err}
+	}
+	if activeCount >= limit {
+		return ReservationLimitError{CurrentCount: activeCount, Limit: limit}
+	}
+	return nil
+}
+
+// loadPendingTransfer loads the transfer for token and rejects ones that were
+// settled, lapsed, or whose reservation was cancelled or has started.
+func loadPendingTransfer(ctx context.Context, q *dbgen.Queries, token string, now time.Time) (dbgen.GetReservationTransferByTokenRow, error) {
+	transfer, err := q.GetReservationTransferByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return transfer, apiutil.HandlerError{Status: http.StatusNotFound, Message: "Transfer not found", Err: err}
+		}
+		return transfer, apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load transfer", Err: err}
+	}
+	if transfer.ReservationCancelled != 0 {
+		return transfer, apiutil.HandlerError{Status: http.StatusConflict, Message: "Reservation was cancelled"}
+	}
+	if transfer.Status != TransferStatusPending {
+		return transfer, apiutil.HandlerError{Status: http.StatusConflict, Message: "Transfer is no longer pending"}
+	}
+	if !transfer.ExpiresAt.After(now) || !transfer.StartTime.After(now) {
+		return transfer, apiutil.HandlerError{Status: http.StatusGone, Message: "This transfer offer has expired"}
+	}
+	return transfer, nil
+}
+
+// handOverReservation makes toUserID the primary user in fromUserID's place.
+// When the previous holder was also playing, the new one takes their
+// participant spot too.
+func handOverReservation(ctx context.Context, q *dbgen.Queries, reservationID, fromUserID, toUserID int64) error {
+	updated, err := q.UpdateReservationPrimaryUser(ctx, dbgen.UpdateReservationPrimaryUserParams{
+		PrimaryUserID: sql.NullInt64{Int64: toUserID, Valid: true},
+		ID:            reservationID,
+	})
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to transfer reservation", Err: err}
+	}
+	if updated == 0 {
+		return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Reservation not found"}
+	}
+
+	participants, err := q.ListParticipantsForReservation(ctx, reservationID)
+	if err != nil {
+		return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load reservation participants", Err: err}
+	}
+	for _, participant := range participants {
+		if participant.ID != fromUserID {
+			continue
+		}
+		if err := removeParticipant(ctx, q, reservationID, fromUserID); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to remove reservation participant", Err: err}
+		}
+		if err := addParticipant(ctx, q, reservationID, toUserID); err != nil && !apiutil.IsSQLiteUniqueViolation(err) {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to add reservation participant", Err: err}
+		}
+	}
+	return nil
+}
+
+func (s *Service) sendTransferRequestEmail(ctx context.Context, reservation dbgen.Reservation, transfer dbgen.ReservationTransfer, links InvitationLinks) {
+	if s.emailClient == nil || links == nil {
+		return
+	}
+	logger := log.Ctx(ctx)
+	q := s.db.Queries
+
+	emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(ctx), queryTimeout)
+	defer emailCancel()
+	facility, err := s.facilities().GetFacilityByID(emailCtx, reservation.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", reservation.FacilityID).Msg("Failed to load facility for transfer email")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+	courts, err := q.ListReservationCourts(emailCtx, reservation.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to load courts for transfer email")
+		return
+	}
+
+	date, timeRange := email.FormatDateTimeRange(reservation.StartTime.In(facilityLoc), reservation.EndTime.In(facilityLoc))
+	acceptURL, declineURL := links(transfer.Token)
+	message := email.BuildReservationTransferRequestEmail(email.ReservationTransferRequestDetails{
+		FacilityName: facility.Name,
+		FromName:     userDisplayName(emailCtx, q, transfer.FromUserID),
+		Date:         date,
+		TimeRange:    timeRange,
+		Courts:       apiutil.ReservationCourtLabel(courts),
+		AcceptURL:    acceptURL,
+		DeclineURL:   declineURL,
+		ExpiresAt:    transfer.ExpiresAt.In(facilityLoc).Format("Monday, Jan 2, 2006 3:04 PM MST"),
+	})
+	message.FacilityID = facility.ID
+	message.ReservationID = reservation.ID
+	sender := email.ResolveFromAddress(emailCtx, q, facility, logger)
+	email.SendReservationTransferRequestEmail(emailCtx, q, s.emailClient, transfer.ToUserID, message, sender, logger)
+}
+
+func (s *Service) sendTransferredEmails(ctx context.Context, reservationID, fromUserID, toUserID int64) {
+	if s.emailClient == nil {
+		return
+	}
+	logger := log.Ctx(ctx)
+	q := s.db.Queries
+
+	emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(ctx), queryTimeout)
+	defer emailCancel()
+	reservation, err := q.GetReservationByID(emailCtx, reservationID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservationID).Msg("Failed to load reservation for transfer email")
+		return
+	}
+	facility, err := s.facilities().GetFacilityByID(emailCtx, reservation.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", reservation.FacilityID).Msg("Failed to load facility for transfer email")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+	courts, err := q.ListReservationCourts(emailCtx, reservation.ID)
+	if err != nil {
+		logger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to load courts for transfer email")
+		return
+	}
+
+	date, timeRange := email.FormatDateTimeRange(reservation.StartTime.In(facilityLoc), reservation.EndTime.In(facilityLoc))
+	details := email.ReservationTransferredDetails{
+		FacilityName: facility.Name,
+		FromName:     userDisplayName(emailCtx, q, fromUserID),
+		ToName:       userDisplayName(emailCtx, q, toUserID),
+		Date:         date,
+		TimeRange:    timeRange,
+		Courts:       apiutil.ReservationCourtLabel(courts),
+	}
+	sender := email.ResolveFromAddress(emailCtx, q, facility, logger)
+	for _, recipient := range []struct {
+		userID      int64
+		toRecipient bool
+	}{{fromUserID, false}, {toUserID, true}} {
+		if !email.ShouldSend(emailCtx, q, recipient.userID, email.PreferenceConfirmations) {
+			continue
+		}
+		details.ToRecipient = recipient.toRecipient
+		message := email.BuildReservationTransferredEmail(details)
+		message.FacilityID = facility.ID
+		message.ReservationID = reservation.ID
+		email.SendReservationTransferredEmail(emailCtx, q, s.emailClient, recipient.userID, message, sender, logger)
+	}
+}
+
+func (s *Service) sendTransferDeclinedEmail(ctx context.Context, transfer dbgen.GetReservationTransferByTokenRow) {
+	if s.emailClient == nil {
+		return
+	}
+	logger := log.Ctx(ctx)
+	q := s.db.Queries
+
+	emailCtx, emailCancel := context.WithTimeout(context.WithoutCancel(ctx), queryTimeout)
+	defer emailCancel()
+	facility, err := s.facilities().GetFacilityByID(emailCtx, transfer.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", transfer.FacilityID).Msg("Failed to load facility for transfer declined email")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+
+	date, timeRange := email.FormatDateTimeRange(transfer.StartTime.In(facilityLoc), transfer.EndTime.In(facilityLoc))
+	message := email.BuildTransferDeclinedEmail(email.TransferDeclinedDetails{
+		FacilityName: facility.Name,
+		ToName:       userDisplayName(emailCtx, q, transfer.ToUserID),
+		Date:         date,
+		TimeRange:    timeRange,
+	})
+	message.FacilityID = facility.ID
+	message.ReservationID = transfer.ReservationID
+	sender := email.ResolveFromAddress(emailCtx, q, facility, logger)
+	email.SendTransferDeclinedEmail(emailCtx, q, s.emailClient, transfer.FromUserID, message, sender, logger)
+}
+
+// userDisplayName returns the user's full name, or "" when they cannot be
+// loaded so email builders fall back to a generic label.
+func userDisplayName(ctx context.Context, q *dbgen.Queries, userID int64) string {
+	user, err := q.GetUserByID(ctx, userID)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
+}
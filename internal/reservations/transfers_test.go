@@ -0,0 +1,150 @@
+package reservations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func (f serviceFixture) primaryUserOf(t *testing.T, reservationID int64) int64 {
+	t.Helper()
+
+	reservation, err := f.database.Queries.GetReservationByID(context.Background(), reservationID)
+	if err != nil {
+		t.Fatalf("load reservation: %v", err)
+	}
+	return reservation.PrimaryUserID.Int64
+}
+
+func TestTransfers_AcceptHandsOverAndFreesLimit(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	if _, err := fixture.database.Exec("UPDATE facilities SET max_member_reservations = 1 WHERE id = ?", fixture.facilityID); err != nil {
+		t.Fatalf("set member limit: %v", err)
+	}
+	target := fixture.insertMember(t, "Taylor")
+	bystander := fixture.insertMember(t, "Blake")
+
+	reservation, err := fixture.service.CreateReservation(ctx, fixture.createInput(start, fixture.courtIDs[0]))
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+
+	_, err = fixture.service.RequestTransfer(ctx, TransferInput{ReservationID: reservation.ID, FromUserID: target, ToUserID: bystander})
+	expectHandlerStatus(t, err, http.StatusForbidden)
+	_, err = fixture.service.RequestTransfer(ctx, TransferInput{ReservationID: reservation.ID, FromUserID: fixture.userID, ToUserID: fixture.userID})
+	expectHandlerStatus(t, err, http.StatusBadRequest)
+
+	transfer, err := fixture.service.RequestTransfer(ctx, TransferInput{
+		ReservationID: reservation.ID,
+		FromUserID:    fixture.userID,
+		ToUserID:      target,
+		Window:        time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("request transfer: %v", err)
+	}
+	if got := transfer.ExpiresAt.Sub(transfer.CreatedAt); got > 2*time.Hour {
+		t.Fatalf("expected the window to bound the offer, got %v", got)
+	}
+	if holder := fixture.primaryUserOf(t, reservation.ID); holder != fixture.userID {
+		t.Fatalf("expected reservation to stay with booker until accepted, got %d", holder)
+	}
+
+	_, err = fixture.service.AcceptTransfer(ctx, transfer.Token, bystander)
+	expectHandlerStatus(t, err, http.StatusNotFound)
+	if _, err := fixture.service.AcceptTransfer(ctx, transfer.Token, target); err != nil {
+		t.Fatalf("accept transfer: %v", err)
+	}
+	if holder := fixture.primaryUserOf(t, reservation.ID); holder != target {
+		t.Fatalf("expected target to hold reservation, got %d", holder)
+	}
+	if got := fixture.participantIDs(t, reservation.ID); fmt.Sprint(got) != fmt.Sprint([]int64{target}) {
+		t.Fatalf("expected target to take the booker's spot, got %v", got)
+	}
+	_, err = fixture.service.AcceptTransfer(ctx, transfer.Token, target)
+	expectHandlerStatus(t, err, http.StatusConflict)
+
+	// The booker's slot is free again under a limit of one.
+	next := fixture.createInput(start.Add(4*time.Hour), fixture.courtIDs[1])
+	next.MaxActiveReservations = 1
+	if _, err := fixture.service.CreateReservation(ctx, next); err != nil {
+		t.Fatalf("expected booker's limit to free up: %v", err)
+	}
+
+	// Handing the new booking to the target would put them over the limit.
+	another, err := fixture.service.RequestTransfer(ctx, TransferInput{
+		ReservationID: fixture.insertReservation(t, start.Add(24*time.Hour)),
+		FromUserID:    fixture.userID,
+		ToUserID:      target,
+	})
+	if err != nil {
+		t.Fatalf("request second transfer: %v", err)
+	}
+	var limitErr ReservationLimitError
+	if _, err := fixture.service.AcceptTransfer(ctx, another.Token, target); !errors.As(err, &limitErr) {
+		t.Fatalf("expected reservation limit error, got %v", err)
+	}
+}
+
+func TestTransfers_DeclineAndLapseLeaveReservation(t *testing.T) {
+	fixture := setupServiceTest(t)
+	ctx := context.Background()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	target := fixture.insertMember(t, "Taylor")
+	reservationID := fixture.insertReservation(t, start)
+
+	request := func() string {
+		t.Helper()
+		transfer, err := fixture.service.RequestTransfer(ctx, TransferInput{
+			ReservationID: reservationID,
+			FromUserID:    fixture.userID,
+			ToUserID:      target,
+		})
+		if err != nil {
+			t.Fatalf("request transfer: %v", err)
+		}
+		return transfer.Token
+	}
+
+	declined := request()
+	if _, err := fixture.service.DeclineTransfer(ctx, declined); err != nil {
+		t.Fatalf("decline transfer: %v", err)
+	}
+	_, err := fixture.service.AcceptTransfer(ctx, declined, target)
+	expectHandlerStatus(t, err, http.StatusConflict)
+
+	lapsed := request()
+	if _, err := fixture.database.Exec("UPDATE reservation_transfers SET expires_at = ? WHERE token = ?", time.Now().Add(-time.Minute), lapsed); err != nil {
+		t.Fatalf("lapse transfer: %v", err)
+	}
+	_, err = fixture.service.AcceptTransfer(ctx, lapsed, target)
+	expectHandlerStatus(t, err, http.StatusGone)
+
+	// A new request replaces the lapsed one.
+	replaced := request()
+	_, err = fixture.service.AcceptTransfer(ctx, lapsed, target)
+	expectHandlerStatus(t, err, http.StatusConflict)
+
+	if holder := fixture.primaryUserOf(t, reservationID); holder != fixture.userID {
+		t.Fatalf("expected reservation to stay with booker, got %d", holder)
+	}
+
+	// Staff transfers skip the target's confirmation and settle the request.
+	if _, err := fixture.service.StaffTransfer(ctx, StaffTransferInput{
+		ReservationID: reservationID,
+		FacilityID:    fixture.facilityID,
+		ToUserID:      target,
+	}); err != nil {
+		t.Fatalf("staff transfer: %v", err)
+	}
+	if holder := fixture.primaryUserOf(t, reservationID); holder != target {
+		t.Fatalf("expected staff transfer to hand over, got %d", holder)
+	}
+	_, err = fixture.service.AcceptTransfer(ctx, replaced, target)
+	expectHandlerStatus(t, err, http.StatusConflict)
+}
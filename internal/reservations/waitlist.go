@@ -0,0 +1,307 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/metrics"
+)
+
+const (
+	waitlistOfferSendTimeout          = 30 * time.Second
+	waitlistTimeLayout                = "15:04:05"
+	defaultWaitlistOfferExpiryMinutes = int64(30)
+)
+
+const (
+	waitlistNotificationBroadcast  = "broadcast"
+	waitlistNotificationSequential = "sequential"
+	waitlistOfferStatusPending     = "pending"
+	waitlistStatusNotified         = "notified"
+)
+
+// notifyWaitlistedMembers records offers for the waitlist entries matching a
+// cancelled reservation's slot and, when notifiers are set, sends them in the
+// background. When the first entry in line asked to be auto-booked, it gets
+// the slot outright instead, and offers go out only if that booking is
+// declined.
+func (s *Service) notifyWaitlistedMembers(ctx context.Context, reservation dbgen.Reservation, courts []dbgen.ListReservationCourtsRow) error {
+	targetDate, targetStartTime, targetEndTime := reservationWaitlistSlot(reservation)
+	now := time.Now()
+	var offered []dbgen.Waitlist
+	var expiresAt time.Time
+	var autoBooked *autoBooking
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		waitlists, err := listMatchingWaitlistsForCancelledSlot(ctx, qtx, reservation.FacilityID, targetDate, targetStartTime, targetEndTime, courts)
+		if err != nil {
+			return err
+		}
+		if len(waitlists) == 0 {
+			return nil
+		}
+
+		config, err := loadWaitlistNotificationConfig(ctx, qtx, reservation.FacilityID)
+		if err != nil {
+			return err
+		}
+
+		waitlists = filterWaitlistsByNotificationWindow(waitlists, reservation.StartTime, now, config.NotificationWindowMinutes)
+		if len(waitlists) == 0 {
+			return nil
+		}
+
+		if waitlists[0].AutoBook {
+			booked, err := s.autoBookWaitlistEntry(ctx, qtx, reservation, courts, waitlists[0], now)
+			if err == nil {
+				autoBooked = &booked
+				return nil
+			}
+			if !errors.Is(err, errAutoBookDeclined) {
+				return err
+			}
+		}
+
+		offered, expiresAt, err = createWaitlistNotifications(ctx, qtx, waitlists, config, now)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if autoBooked != nil {
+		log.Ctx(ctx).Info().
+			Int64("reservation_id", autoBooked.Booked.Reservation.ID).
+			Int64("user_id", *autoBooked.Input.PrimaryUserID).
+			Msg("Waitlist slot auto-booked")
+		s.announceBooking(ctx, autoBooked.Input, autoBooked.CourtIDs, autoBooked.Booked)
+		return nil
+	}
+	metrics.WaitlistOffersCreated(reservation.FacilityID, len(offered))
+
+	if len(offered) > 0 && len(s.notifiers) > 0 {
+		go s.sendWaitlistOffers(context.WithoutCancel(ctx), reservation, offered, expiresAt)
+	}
+	return nil
+}
+
+// sendWaitlistOffers tells each offered member about the open slot on every
+// configured channel. It runs after the offers commit, detached from the
+// request.
+func (s *Service) sendWaitlistOffers(ctx context.Context, reservation dbgen.Reservation, offered []dbgen.Waitlist, expiresAt time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, waitlistOfferSendTimeout)
+	defer cancel()
+	logger := log.Ctx(ctx)
+
+	facility, err := s.facilities().GetFacilityByID(ctx, reservation.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", reservation.FacilityID).Msg("Failed to load facility for waitlist offers")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+	s.deliverWaitlistOffers(ctx, logger, facility, reservation.StartTime.In(facilityLoc), reservation.EndTime.In(facilityLoc), offered, expiresAt)
+}
+
+// deliverWaitlistOffers sends the offer message for a slot, given in the
+// facility's time zone, to each offered member.
+func (s *Service) deliverWaitlistOffers(ctx context.Context, logger *zerolog.Logger, facility dbgen.Facility, start, end time.Time, offered []dbgen.Waitlist, expiresAt time.Time) {
+	q := s.db.Queries
+	facilityLoc := start.Location()
+
+	date, timeRange := email.FormatDateTimeRange(start, end)
+	message := email.BuildWaitlistOfferEmail(email.WaitlistOfferDetails{
+		FacilityID:   facility.ID,
+		FacilityName: facility.Name,
+		Date:         date,
+		TimeRange:    timeRange,
+		ExpiresAt:    expiresAt.In(facilityLoc).Format("3:04 PM MST"),
+	})
+	sender := email.ResolveFromAddress(ctx, q, facility, logger)
+	for _, entry := range offered {
+		email.NotifyUser(ctx, q, s.notifiers, entry.UserID, message, sender, email.PreferenceWaitlistOffers, logger)
+	}
+}
+
+func reservationWaitlistSlot(reservation dbgen.Reservation) (time.Time, string, string) {
+	startTime := reservation.StartTime
+	endTime := reservation.EndTime
+	targetDate := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, startTime.Location())
+	return targetDate, startTime.Format(waitlistTimeLayout), endTime.Format(waitlistTimeLayout)
+}
+
+// listMatchingWaitlistsForCancelledSlot returns the pending entries waiting on
+// the cancelled slot, court-specific and any-court alike, in queue order.
+// Each queue is renumbered first so offers follow current positions.
+func listMatchingWaitlistsForCancelledSlot(
+	ctx context.Context,
+	q *dbgen.Queries,
+	facilityID int64,
+	targetDate time.Time,
+	targetStartTime string,
+	targetEndTime string,
+	courts []dbgen.ListReservationCourtsRow,
+) ([]dbgen.Waitlist, error) {
+	waitlistsByID := make(map[int64]dbgen.Waitlist)
+
+	addEntries := func(targetCourtParam sql.NullInt64) error {
+		if err := ReorderWaitlistPositions(ctx, q, dbgen.Waitlist{
+			FacilityID:      facilityID,
+			TargetCourtID:   targetCourtParam,
+			TargetDate:      targetDate,
+			TargetStartTime: targetStartTime,
+			TargetEndTime:   targetEndTime,
+		}); err != nil {
+			return err
+		}
+		entries, err := q.ListMatchingPendingWaitlistsForCancelledSlot(ctx, dbgen.ListMatchingPendingWaitlistsForCancelledSlotParams{
+			FacilityID:      facilityID,
+			TargetDate:      targetDate,
+			TargetStartTime: targetStartTime,
+			TargetEndTime:   targetEndTime,
+			TargetCourtID:   targetCourtParam,
+		})
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			waitlistsByID[entry.ID] = entry
+		}
+		return nil
+	}
+
+	if len(courts) == 0 {
+		if err := addEntries(sql.NullInt64{}); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, court := range courts {
+			if err := addEntries(sql.NullInt64{Int64: court.CourtID, Valid: true}); err != nil {
+				return nil, err
+			}
+		}
+		if err := addEntries(sql.NullInt64{}); err != nil {
+			return nil, err
+		}
+	}
+
+	waitlists := make([]dbgen.Waitlist, 0, len(waitlistsByID))
+	for _, entry := range waitlistsByID {
+		waitlists = append(waitlists, entry)
+	}
+	sort.Slice(waitlists, func(i, j int) bool {
+		if waitlists[i].Position != waitlists[j].Position {
+			return waitlists[i].Position < waitlists[j].Position
+		}
+		if !waitlists[i].CreatedAt.Equal(waitlists[j].CreatedAt) {
+			return waitlists[i].CreatedAt.Before(waitlists[j].CreatedAt)
+		}
+		return waitlists[i].ID < waitlists[j].ID
+	})
+
+	return waitlists, nil
+}
+
+// ReorderWaitlistPositions renumbers the pending and notified entries queued
+// for entry's slot so their positions run 1..n in join order. Call it inside
+// the transaction that removed entry or moved it out of the queue.
+func ReorderWaitlistPositions(ctx context.Context, q *dbgen.Queries, entry dbgen.Waitlist) error {
+	if _, err := q.StageWaitlistPositions(ctx, dbgen.StageWaitlistPositionsParams{
+		FacilityID:      entry.FacilityID,
+		TargetDate:      entry.TargetDate,
+		TargetStartTime: entry.TargetStartTime,
+		TargetEndTime:   entry.TargetEndTime,
+		TargetCourtID:   entry.TargetCourtID,
+	}); err != nil {
+		return fmt.Errorf("stage waitlist positions: %w", err)
+	}
+	if _, err := q.ApplyStagedWaitlistPositions(ctx, dbgen.ApplyStagedWaitlistPositionsParams{
+		FacilityID:      entry.FacilityID,
+		TargetDate:      entry.TargetDate,
+		TargetStartTime: entry.TargetStartTime,
+		TargetEndTime:   entry.TargetEndTime,
+		TargetCourtID:   entry.TargetCourtID,
+	}); err != nil {
+		return fmt.Errorf("apply waitlist positions: %w", err)
+	}
+	return nil
+}
+
+func loadWaitlistNotificationConfig(ctx context.Context, q *dbgen.Queries, facilityID int64) (dbgen.WaitlistConfig, error) {
+	config, err := q.GetWaitlistConfig(ctx, facilityID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbgen.WaitlistConfig{
+				FacilityID:       facilityID,
+				NotificationMode: waitlistNotificationBroadcast,
+			}, nil
+		}
+		return dbgen.WaitlistConfig{}, err
+	}
+	if strings.TrimSpace(config.NotificationMode) == "" {
+		config.NotificationMode = waitlistNotificationBroadcast
+	}
+	return config, nil
+}
+
+func filterWaitlistsByNotificationWindow(waitlists []dbgen.Waitlist, slotStart time.Time, now time.Time, windowMinutes int64) []dbgen.Waitlist {
+	if windowMinutes <= 0 {
+		return waitlists
+	}
+	windowStart := now
+	windowEnd := now.Add(time.Duration(windowMinutes) * time.Minute)
+	if slotStart.Before(windowStart) || slotStart.After(windowEnd) {
+		return nil
+	}
+	return waitlists
+}
+
+// createWaitlistNotifications records offers for the waitlist entries the
+// facility's notification mode selects and returns those entries with the
+// shared offer expiry.
+func createWaitlistNotifications(ctx context.Context, q *dbgen.Queries, waitlists []dbgen.Waitlist, config dbgen.WaitlistConfig, now time.Time) ([]dbgen.Waitlist, time.Time, error) {
+	mode := strings.ToLower(strings.TrimSpace(config.NotificationMode))
+	if mode == "" {
+		mode = waitlistNotificationBroadcast
+	}
+
+	expiryMinutes := config.OfferExpiryMinutes
+	if expiryMinutes <= 0 {
+		expiryMinutes = defaultWaitlistOfferExpiryMinutes
+	}
+	expiresAt := now.Add(time.Duration(expiryMinutes) * time.Minute)
+
+	selected := waitlists
+	if mode == waitlistNotificationSequential && len(waitlists) > 0 {
+		selected = waitlists[:1]
+	}
+
+	for _, entry := range selected {
+		if _, err := q.UpdateWaitlistStatus(ctx, dbgen.UpdateWaitlistStatusParams{
+			ID:         entry.ID,
+			FacilityID: entry.FacilityID,
+			Status:     waitlistStatusNotified,
+		}); err != nil {
+			return nil, time.Time{}, err
+		}
+		if _, err := q.CreateWaitlistOffer(ctx, dbgen.CreateWaitlistOfferParams{
+			WaitlistID: entry.ID,
+			ExpiresAt:  expiresAt,
+			Status:     waitlistOfferStatusPending,
+		}); err != nil {
+			return nil, time.Time{}, err
+		}
+	}
+	return selected, expiresAt, nil
+}
@@ -0,0 +1,283 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/models"
+)
+
+// waitlistReservationTypeName is the type waitlist bookings are made as,
+// the same one an accepted offer books.
+const waitlistReservationTypeName = "GAME"
+
+const waitlistStatusFulfilled = "fulfilled"
+
+// errAutoBookDeclined marks an auto_book entry that cannot be booked
+// straight into the slot; the slot is offered as usual instead.
+var errAutoBookDeclined = errors.New("waitlist entry cannot be auto-booked")
+
+// autoBooking is a waitlist entry booked straight into a freed slot, to be
+// announced once the transaction commits.
+type autoBooking struct {
+	Input    CreateInput
+	CourtIDs []int64
+	Booked   booking
+}
+
+// autoBookWaitlistEntry books the freed slot for an auto_book entry inside
+// the caller's transaction and marks the entry fulfilled. It holds the entry
+// to the rules of a member booking: the facility's reservation limit, the
+// member's booking window and prime time, no-show restrictions, and, when
+// the entry asks for one, an unexpired visit pack. An entry that fails any of
+// them returns errAutoBookDeclined before anything is written.
+func (s *Service) autoBookWaitlistEntry(ctx context.Context, qtx *dbgen.Queries, reservation dbgen.Reservation, courts []dbgen.ListReservationCourtsRow, entry dbgen.Waitlist, now time.Time) (autoBooking, error) {
+	decline := func(reason string) (autoBooking, error) {
+		log.Ctx(ctx).Info().
+			Int64("waitlist_id", entry.ID).
+			Int64("user_id", entry.UserID).
+			Str("reason", reason).
+			Msg("Waitlist auto-booking declined; offering the slot instead")
+		return autoBooking{}, errAutoBookDeclined
+	}
+
+	member, err := qtx.GetUserByID(ctx, entry.UserID)
+	if err != nil {
+		return autoBooking{}, fmt.Errorf("load waitlisted member: %w", err)
+	}
+	if !member.IsMember || member.Status != "active" {
+		return decline("member is not active")
+	}
+
+	maxAdvanceDays, facility, err := apiutil.GetMemberMaxAdvanceDays(ctx, qtx, s.facilities(), entry.FacilityID, member.MembershipLevel, apiutil.DefaultMaxAdvanceDays)
+	if err != nil {
+		return autoBooking{}, fmt.Errorf("load booking window: %w", err)
+	}
+	loc := apiutil.FacilityLocation(*facility, log.Ctx(ctx))
+	start := reservation.StartTime.In(loc)
+	end := reservation.EndTime.In(loc)
+	localNow := now.In(loc)
+	today := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, loc)
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	if !start.After(now) {
+		return decline("slot has started")
+	}
+	if startDay.After(today.AddDate(0, 0, int(maxAdvanceDays))) {
+		return decline("slot is outside the member's booking window")
+	}
+	tier, err := apiutil.LoadMembershipTier(ctx, qtx, facility.OrganizationID, member.MembershipLevel)
+	if err != nil {
+		return autoBooking{}, fmt.Errorf("load membership tier: %w", err)
+	}
+	if err := apiutil.EnsurePrimeTimeUnlocked(ctx, qtx, entry.FacilityID, apiutil.PrimeTimeLevel(tier, member.MembershipLevel), start, end, now); err != nil {
+		var primeTimeErr apiutil.PrimeTimeLockedError
+		if errors.As(err, &primeTimeErr) {
+			return decline("prime time is locked for the member")
+		}
+		return autoBooking{}, fmt.Errorf("load prime time rules: %w", err)
+	}
+	restriction, err := apiutil.LoadNoShowRestriction(ctx, qtx, entry.FacilityID, member.ID, now)
+	if err != nil {
+		return autoBooking{}, fmt.Errorf("load no-show restriction: %w", err)
+	}
+	if restriction != nil {
+		return decline("member has a no-show restriction")
+	}
+
+	reservationType, err := qtx.GetReservationTypeByName(ctx, waitlistReservationTypeName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return decline("reservation type is not configured")
+		}
+		return autoBooking{}, fmt.Errorf("load reservation type: %w", err)
+	}
+	if !reservationType.MemberBookable {
+		return decline("reservation type is not member bookable")
+	}
+	if _, err := ensureReservationTypeAvailable(ctx, qtx, entry.FacilityID, reservationType.ID); err != nil {
+		var herr apiutil.HandlerError
+		if errors.As(err, &herr) && herr.Status < http.StatusInternalServerError {
+			return decline("reservation type is not available at the facility")
+		}
+		return autoBooking{}, err
+	}
+	if violation := apiutil.ReservationTypeDuration(facility, &reservationType).Violation(end.Sub(start)); violation != "" {
+		return decline("slot length is not allowed for the reservation type")
+	}
+
+	courtID, err := waitlistAutoBookCourt(ctx, qtx, entry, reservation.ID, courts, start, end)
+	if err != nil {
+		return autoBooking{}, err
+	}
+	if courtID == 0 {
+		return decline("no court is free for the slot")
+	}
+
+	memberID := member.ID
+	in := CreateInput{
+		Details: Details{
+			ReservationTypeID: reservationType.ID,
+			PrimaryUserID:     &memberID,
+			StartTime:         reservation.StartTime,
+			EndTime:           reservation.EndTime,
+			CourtIDs:          []int64{courtID},
+		},
+		FacilityID:            entry.FacilityID,
+		CreatedByUserID:       member.ID,
+		ParticipantIDs:        []int64{member.ID},
+		LimitScope:            facility.ReservationLimitScope,
+		PreventMemberOverlap:  true,
+		PriceCourtTime:        true,
+		SendConfirmation:      true,
+		MaxActiveReservations: 0,
+	}
+	if reservationType.CountsTowardMemberLimit {
+		in.MaxActiveReservations = apiutil.MemberReservationLimit(tier, facility.MaxMemberReservations)
+	}
+
+	seasonPass, err := models.FindCoveringSeasonPass(ctx, qtx, member.ID, entry.FacilityID, start, end, loc)
+	if err != nil {
+		return autoBooking{}, fmt.Errorf("load season passes: %w", err)
+	}
+	if seasonPass != nil {
+		in.MaxActiveReservations = 0
+		in.SeasonPassID = &seasonPass.SeasonPassID
+	} else if entry.UseVisitPack {
+		visitPackID, err := waitlistAutoBookVisitPack(ctx, qtx, member, tier, *facility, now)
+		if err != nil {
+			return autoBooking{}, err
+		}
+		if visitPackID == 0 {
+			return decline("no visit pack is available")
+		}
+		in.VisitPackID = &visitPackID
+	}
+
+	// book rejects a booking over the limit, overlapping the member's own, or
+	// on a court that is no longer free before it writes anything, so those
+	// can still fall back to offers. Any later failure rolls back the whole
+	// cancellation follow-up.
+	booked, err := s.book(ctx, qtx, in, in.CourtIDs, in.ParticipantIDs, now)
+	if err != nil {
+		var limitErr ReservationLimitError
+		var overlapErr MemberOverlapError
+		var herr apiutil.HandlerError
+		switch {
+		case errors.As(err, &limitErr):
+			return decline("member is at the reservation limit")
+		case errors.As(err, &overlapErr):
+			return decline("member has an overlapping reservation")
+		case errors.As(err, &herr) && herr.Status == http.StatusConflict:
+			return decline("court is not available")
+		}
+		return autoBooking{}, err
+	}
+
+	fulfilled, err := qtx.UpdateWaitlistStatus(ctx, dbgen.UpdateWaitlistStatusParams{
+		ID:         entry.ID,
+		FacilityID: entry.FacilityID,
+		Status:     waitlistStatusFulfilled,
+	})
+	if err != nil {
+		return autoBooking{}, fmt.Errorf("fulfill waitlist entry: %w", err)
+	}
+	if err := ReorderWaitlistPositions(ctx, qtx, fulfilled); err != nil {
+		return autoBooking{}, err
+	}
+
+	memberName := strings.TrimSpace(member.FirstName + " " + member.LastName)
+	if memberName == "" {
+		memberName = "Member"
+	}
+	if _, err := qtx.CreateWaitlistFulfilledNotification(ctx, dbgen.CreateWaitlistFulfilledNotificationParams{
+		FacilityID: entry.FacilityID,
+		Message: fmt.Sprintf(
+			"Waitlist slot auto-booked: %s (%s - %s)",
+			memberName,
+			booked.Reservation.StartTime.Format("2006-01-02 15:04"),
+			booked.Reservation.EndTime.Format("2006-01-02 15:04"),
+		),
+		RelatedReservationID: sql.NullInt64{Int64: booked.Reservation.ID, Valid: true},
+	}); err != nil {
+		return autoBooking{}, fmt.Errorf("notify staff of waitlist booking: %w", err)
+	}
+
+	return autoBooking{Input: in, CourtIDs: in.CourtIDs, Booked: booked}, nil
+}
+
+// waitlistAutoBookCourt picks the court to book: the waitlisted court if the
+// entry names one, otherwise the first freed court that is still open, or
+// any open court. Zero means none is free.
+func waitlistAutoBookCourt(ctx context.Context, qtx *dbgen.Queries, entry dbgen.Waitlist, reservationID int64, courts []dbgen.ListReservationCourtsRow, start, end time.Time) (int64, error) {
+	available, err := qtx.ListAvailableCourts(ctx, dbgen.ListAvailableCourtsParams{
+		FacilityID:    entry.FacilityID,
+		ReservationID: reservationID,
+		StartTime:     start,
+		EndTime:       end,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list available courts: %w", err)
+	}
+	open := make(map[int64]bool, len(available))
+	for _, court := range available {
+		open[court.ID] = true
+	}
+	if entry.TargetCourtID.Valid {
+		if open[entry.TargetCourtID.Int64] {
+			return entry.TargetCourtID.Int64, nil
+		}
+		return 0, nil
+	}
+	for _, court := range courts {
+		if open[court.CourtID] {
+			return court.CourtID, nil
+		}
+	}
+	if len(available) > 0 {
+		return available[0].ID, nil
+	}
+	return 0, nil
+}
+
+// waitlistAutoBookVisitPack returns the member's visit pack expiring soonest,
+// or zero when they have none to use. Visit packs are only for tiers that
+// allow them, as on the booking form.
+func waitlistAutoBookVisitPack(ctx context.Context, qtx *dbgen.Queries, member dbgen.User, tier dbgen.MembershipTier, facility dbgen.Facility, now time.Time) (int64, error) {
+	if !tier.VisitPacks {
+		return 0, nil
+	}
+	crossFacility, err := qtx.GetOrganizationCrossFacilitySetting(ctx, facility.OrganizationID)
+	if err != nil {
+		return 0, fmt.Errorf("load visit pack settings: %w", err)
+	}
+	var packs []dbgen.VisitPack
+	if crossFacility {
+		packs, err = qtx.ListActiveVisitPacksForUserByOrganization(ctx, dbgen.ListActiveVisitPacksForUserByOrganizationParams{
+			UserID:         member.ID,
+			OrganizationID: facility.OrganizationID,
+			ComparisonTime: now,
+		})
+	} else {
+		packs, err = qtx.ListActiveVisitPacksForUserByFacility(ctx, dbgen.ListActiveVisitPacksForUserByFacilityParams{
+			UserID:         member.ID,
+			FacilityID:     facility.ID,
+			ComparisonTime: now,
+		})
+	}
+	if err != nil {
+		return 0, fmt.Errorf("load visit packs: %w", err)
+	}
+	if len(packs) == 0 {
+		return 0, nil
+	}
+	return packs[0].ID, nil
+}
@@ -0,0 +1,108 @@
+package reservations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// autoBookSlot stands in for a cancelled booking tomorrow at 10:00 and queues
+// an auto_book entry for its slot, returning the reservation and the entry.
+func (f serviceFixture) autoBookSlot(t *testing.T, memberID int64) (dbgen.Reservation, int64) {
+	t.Helper()
+
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	start := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, time.UTC)
+	reservation := dbgen.Reservation{
+		ID:         f.insertReservation(t, start),
+		FacilityID: f.facilityID,
+		StartTime:  start,
+		EndTime:    start.Add(time.Hour),
+	}
+	targetDate, targetStart, targetEnd := reservationWaitlistSlot(reservation)
+	result, err := f.database.Exec(
+		`INSERT INTO waitlists (facility_id, user_id, target_date, target_start_time, target_end_time, position, status, auto_book)
+		 VALUES (?, ?, ?, ?, ?, 1, 'pending', 1)`,
+		f.facilityID, memberID, targetDate, targetStart, targetEnd,
+	)
+	if err != nil {
+		t.Fatalf("insert waitlist: %v", err)
+	}
+	id, _ := result.LastInsertId()
+	return reservation, id
+}
+
+func TestNotifyWaitlistedMembers_AutoBooksTopEntry(t *testing.T) {
+	fixture := setupServiceTest(t)
+	memberID := fixture.insertMember(t, "Auto")
+	reservation, entryID := fixture.autoBookSlot(t, memberID)
+
+	if err := fixture.service.notifyWaitlistedMembers(context.Background(), reservation, nil); err != nil {
+		t.Fatalf("notify waitlisted members: %v", err)
+	}
+
+	if status, _ := fixture.waitlistState(t, entryID); status != waitlistStatusFulfilled {
+		t.Fatalf("expected the entry fulfilled, got %q", status)
+	}
+	if statuses := fixture.offerStatuses(t, entryID); len(statuses) != 0 {
+		t.Fatalf("expected no offer for an auto-booked entry, got %v", statuses)
+	}
+	var bookingID int64
+	if err := fixture.database.QueryRow(
+		"SELECT id FROM reservations WHERE primary_user_id = ? AND start_time = ?", memberID, reservation.StartTime,
+	).Scan(&bookingID); err != nil {
+		t.Fatalf("expected the slot booked for the member: %v", err)
+	}
+	if courts := fixture.courtsOf(t, bookingID); len(courts) != 1 {
+		t.Fatalf("expected one court booked, got %v", courts)
+	}
+	var notifications int
+	if err := fixture.database.QueryRow(
+		"SELECT COUNT(*) FROM staff_notifications WHERE related_reservation_id = ?", bookingID,
+	).Scan(&notifications); err != nil {
+		t.Fatalf("count staff notifications: %v", err)
+	}
+	if notifications != 1 {
+		t.Fatalf("expected the desk told about the booking, got %d notifications", notifications)
+	}
+}
+
+func TestNotifyWaitlistedMembers_AutoBookFallsBackToOffer(t *testing.T) {
+	fixture := setupServiceTest(t)
+	memberID := fixture.insertMember(t, "Auto")
+	reservation, entryID := fixture.autoBookSlot(t, memberID)
+
+	// The member already holds as many bookings as the facility allows.
+	if _, err := fixture.database.Exec("UPDATE facilities SET max_member_reservations = 1 WHERE id = ?", fixture.facilityID); err != nil {
+		t.Fatalf("set reservation limit: %v", err)
+	}
+	if _, err := fixture.database.Exec(
+		`INSERT INTO reservations (facility_id, reservation_type_id, primary_user_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		fixture.facilityID, fixture.gameTypeID, memberID, memberID, reservation.StartTime.Add(3*time.Hour), reservation.EndTime.Add(3*time.Hour),
+	); err != nil {
+		t.Fatalf("insert existing booking: %v", err)
+	}
+
+	if err := fixture.service.notifyWaitlistedMembers(context.Background(), reservation, nil); err != nil {
+		t.Fatalf("notify waitlisted members: %v", err)
+	}
+
+	if status, _ := fixture.waitlistState(t, entryID); status != waitlistStatusNotified {
+		t.Fatalf("expected the entry offered the slot instead, got %q", status)
+	}
+	if statuses := fixture.offerStatuses(t, entryID); len(statuses) != 1 || statuses[0] != waitlistOfferStatusPending {
+		t.Fatalf("expected a pending offer, got %v", statuses)
+	}
+	var bookings int
+	if err := fixture.database.QueryRow(
+		"SELECT COUNT(*) FROM reservations WHERE primary_user_id = ? AND start_time = ?", memberID, reservation.StartTime,
+	).Scan(&bookings); err != nil {
+		t.Fatalf("count bookings: %v", err)
+	}
+	if bookings != 0 {
+		t.Fatalf("expected no booking after a declined auto-book, got %d", bookings)
+	}
+}
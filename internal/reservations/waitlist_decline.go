@@ -0,0 +1,99 @@
+package reservations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/metrics"
+)
+
+// DeclineWaitlistOfferInput identifies the offer a member is turning down.
+type DeclineWaitlistOfferInput struct {
+	OfferID    int64
+	UserID     int64
+	FacilityID int64
+}
+
+// DeclineWaitlistOffer records a member turning down their pending offer and
+// takes their entry out of the queue. In sequential mode the next pending
+// entry behind it is offered the slot and notified, the same way an expired
+// offer advances. An offer past its expiry can still be declined; it just
+// means the expiry sweep has not reached it yet.
+func (s *Service) DeclineWaitlistOffer(ctx context.Context, input DeclineWaitlistOfferInput, now time.Time) error {
+	var entry dbgen.Waitlist
+	var offered []dbgen.Waitlist
+	var expiresAt time.Time
+	err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+		qtx := txdb.Queries
+
+		offer, err := qtx.GetWaitlistOfferForUser(ctx, dbgen.GetWaitlistOfferForUserParams{
+			ID:     input.OfferID,
+			UserID: input.UserID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Offer not found", Err: err}
+			}
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load offer", Err: err}
+		}
+		if offer.FacilityID != input.FacilityID {
+			return apiutil.HandlerError{Status: http.StatusNotFound, Message: "Offer not found"}
+		}
+		if offer.OfferStatus != waitlistOfferStatusPending {
+			return apiutil.HandlerError{Status: http.StatusConflict, Message: "This offer is no longer available"}
+		}
+
+		if _, err := qtx.DeclineOffer(ctx, dbgen.DeclineOfferParams{
+			ID:         offer.OfferID,
+			WaitlistID: offer.WaitlistID,
+		}); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to decline offer", Err: err}
+		}
+		entry, err = qtx.UpdateWaitlistStatus(ctx, dbgen.UpdateWaitlistStatusParams{
+			ID:         offer.WaitlistID,
+			FacilityID: offer.FacilityID,
+			Status:     waitlistStatusExpired,
+		})
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update waitlist entry", Err: err}
+		}
+
+		config, err := loadWaitlistNotificationConfig(ctx, qtx, offer.FacilityID)
+		if err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to load waitlist config", Err: err}
+		}
+		if strings.EqualFold(strings.TrimSpace(config.NotificationMode), waitlistNotificationSequential) {
+			next, err := listWaitlistsBehind(ctx, qtx, entry)
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to advance waitlist", Err: err}
+			}
+			offered, expiresAt, err = createWaitlistNotifications(ctx, qtx, next, config, now)
+			if err != nil {
+				return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to advance waitlist", Err: err}
+			}
+		}
+
+		// The declined entry leaves the queue only after the next offer is
+		// chosen by its old position.
+		if err := ReorderWaitlistPositions(ctx, qtx, entry); err != nil {
+			return apiutil.HandlerError{Status: http.StatusInternalServerError, Message: "Failed to update waitlist positions", Err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.WaitlistOffersCreated(entry.FacilityID, len(offered))
+	if len(offered) > 0 && len(s.notifiers) > 0 {
+		s.sendAdvancedWaitlistOffers(ctx, entry, offered, expiresAt)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package reservations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	"github.com/codr1/Pickleicious/internal/email"
+)
+
+func TestDeclineWaitlistOffer_SequentialOffersAndNotifiesNext(t *testing.T) {
+	fixture := setupServiceTest(t)
+	fixture.setWaitlistConfig(t, waitlistNotificationSequential, ExpiredOfferRemove)
+
+	nextUserID := fixture.insertMember(t, "Wait")
+	if _, err := fixture.database.Exec(
+		"UPDATE users SET email = 'wait@test.com', phone = '415-555-0199', sms_opt_in = 1 WHERE id = ?", nextUserID,
+	); err != nil {
+		t.Fatalf("update user: %v", err)
+	}
+
+	now := time.Date(2030, time.May, 1, 12, 0, 0, 0, time.UTC)
+	first := fixture.insertWaitlistEntry(t, fixture.userID, 1, waitlistStatusNotified)
+	second := fixture.insertWaitlistEntry(t, nextUserID, 2, waitlistStatusPending)
+	offerID := fixture.insertOffer(t, first, now.Add(10*time.Minute))
+
+	sent := make(chan email.Channel, 1)
+	service := NewService(fixture.database, nil, offerNotifier{channel: email.ChannelEmail, sent: sent})
+
+	input := DeclineWaitlistOfferInput{OfferID: offerID, UserID: fixture.userID, FacilityID: fixture.facilityID}
+	if err := service.DeclineWaitlistOffer(context.Background(), input, now); err != nil {
+		t.Fatalf("decline offer: %v", err)
+	}
+
+	if status, _ := fixture.waitlistState(t, first); status != waitlistStatusExpired {
+		t.Fatalf("expected declined entry expired, got %q", status)
+	}
+	if statuses := fixture.offerStatuses(t, first); len(statuses) != 1 || statuses[0] != "declined" {
+		t.Fatalf("expected declined offer, got %v", statuses)
+	}
+	status, position := fixture.waitlistState(t, second)
+	if status != waitlistStatusNotified || position != 1 {
+		t.Fatalf("expected next entry notified at position 1, got %q at %d", status, position)
+	}
+
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the next member's offer")
+	}
+
+	err := service.DeclineWaitlistOffer(context.Background(), input, now)
+	var herr apiutil.HandlerError
+	if !errors.As(err, &herr) || herr.Status != http.StatusConflict {
+		t.Fatalf("expected a second decline to conflict, got %v", err)
+	}
+}
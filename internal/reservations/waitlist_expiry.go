@@ -0,0 +1,213 @@
+package reservations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
+	appdb "github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/metrics"
+)
+
+const (
+	waitlistStatusPending = "pending"
+	waitlistStatusExpired = "expired"
+
+	// ExpiredOfferRemove takes an entry whose offer timed out out of the
+	// queue; ExpiredOfferRequeue keeps its place for the next opening.
+	ExpiredOfferRemove  = "remove"
+	ExpiredOfferRequeue = "requeue"
+)
+
+// WaitlistExpirySummary counts what one ExpireWaitlistOffers sweep did.
+type WaitlistExpirySummary struct {
+	Expired  int
+	Removed  int
+	Requeued int
+	Advanced int
+	Failed   int
+}
+
+// ExpireWaitlistOffers expires pending offers past their expiry as of now.
+// Each entry is removed from its queue or returned to pending per the
+// facility's expired_offer_action, and in sequential mode the next pending
+// entry in the same queue is offered the slot and notified. Offers are
+// handled one transaction each, so one failure does not hold up the rest;
+// failures are logged and counted.
+func (s *Service) ExpireWaitlistOffers(ctx context.Context, now time.Time) (WaitlistExpirySummary, error) {
+	var summary WaitlistExpirySummary
+
+	rows, err := s.db.Queries.ListExpiredOffers(ctx, now)
+	if err != nil {
+		return summary, fmt.Errorf("list expired offers: %w", err)
+	}
+
+	logger := log.Ctx(ctx)
+	for _, row := range rows {
+		var entry dbgen.Waitlist
+		var offered []dbgen.Waitlist
+		var expiresAt time.Time
+		err := s.db.RunInTx(ctx, func(txdb *appdb.DB) error {
+			qtx := txdb.Queries
+
+			if _, err := qtx.ExpireOffer(ctx, dbgen.ExpireOfferParams{
+				ID:         row.OfferID,
+				WaitlistID: row.WaitlistID,
+			}); err != nil {
+				return fmt.Errorf("expire offer: %w", err)
+			}
+
+			config, err := loadWaitlistNotificationConfig(ctx, qtx, row.FacilityID)
+			if err != nil {
+				return fmt.Errorf("load waitlist config: %w", err)
+			}
+
+			status := waitlistStatusExpired
+			if expiredOfferAction(config) == ExpiredOfferRequeue {
+				status = waitlistStatusPending
+			}
+			entry, err = qtx.UpdateWaitlistStatus(ctx, dbgen.UpdateWaitlistStatusParams{
+				ID:         row.WaitlistID,
+				FacilityID: row.FacilityID,
+				Status:     status,
+			})
+			if err != nil {
+				return fmt.Errorf("update waitlist status: %w", err)
+			}
+
+			if strings.EqualFold(strings.TrimSpace(config.NotificationMode), waitlistNotificationSequential) {
+				next, err := listWaitlistsBehind(ctx, qtx, entry)
+				if err != nil {
+					return fmt.Errorf("list next waitlist entries: %w", err)
+				}
+				offered, expiresAt, err = createWaitlistNotifications(ctx, qtx, next, config, now)
+				if err != nil {
+					return fmt.Errorf("create next waitlist offer: %w", err)
+				}
+			}
+
+			if status != waitlistStatusExpired {
+				return nil
+			}
+			// The expired entry leaves the queue only after the next offer
+			// is chosen by its old position.
+			return ReorderWaitlistPositions(ctx, qtx, entry)
+		})
+		if err != nil {
+			summary.Failed++
+			logger.Error().Err(err).
+				Int64("waitlist_id", row.WaitlistID).
+				Int64("offer_id", row.OfferID).
+				Msg("Failed to expire waitlist offer")
+			continue
+		}
+
+		summary.Expired++
+		metrics.WaitlistOfferExpired(row.FacilityID)
+		metrics.WaitlistOffersCreated(row.FacilityID, len(offered))
+		if entry.Status == waitlistStatusPending {
+			summary.Requeued++
+		} else {
+			summary.Removed++
+		}
+		event := logger.Debug().
+			Int64("waitlist_id", row.WaitlistID).
+			Int64("offer_id", row.OfferID).
+			Str("waitlist_status", entry.Status)
+		if len(offered) > 0 {
+			summary.Advanced++
+			event.Int64("next_waitlist_id", offered[0].ID)
+		}
+		event.Msg("Expired waitlist offer")
+
+		if len(offered) > 0 && len(s.notifiers) > 0 {
+			s.sendAdvancedWaitlistOffers(ctx, entry, offered, expiresAt)
+		}
+	}
+
+	return summary, nil
+}
+
+// expiredOfferAction returns the facility's expired_offer_action, treating
+// anything unrecognized as a removal.
+func expiredOfferAction(config dbgen.WaitlistConfig) string {
+	if strings.EqualFold(strings.TrimSpace(config.ExpiredOfferAction), ExpiredOfferRequeue) {
+		return ExpiredOfferRequeue
+	}
+	return ExpiredOfferRemove
+}
+
+// listWaitlistsBehind returns the pending entries queued behind entry for
+// its slot and court, in queue order.
+func listWaitlistsBehind(ctx context.Context, q *dbgen.Queries, entry dbgen.Waitlist) ([]dbgen.Waitlist, error) {
+	pending, err := q.ListMatchingPendingWaitlistsForCancelledSlot(ctx, dbgen.ListMatchingPendingWaitlistsForCancelledSlotParams{
+		FacilityID:      entry.FacilityID,
+		TargetDate:      entry.TargetDate,
+		TargetStartTime: entry.TargetStartTime,
+		TargetEndTime:   entry.TargetEndTime,
+		TargetCourtID:   entry.TargetCourtID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	behind := make([]dbgen.Waitlist, 0, len(pending))
+	for _, candidate := range pending {
+		if candidate.ID != entry.ID && candidate.Position > entry.Position {
+			behind = append(behind, candidate)
+		}
+	}
+	return behind, nil
+}
+
+// sendAdvancedWaitlistOffers notifies members offered a slot because the
+// offer ahead of them expired.
+func (s *Service) sendAdvancedWaitlistOffers(ctx context.Context, entry dbgen.Waitlist, offered []dbgen.Waitlist, expiresAt time.Time) {
+	logger := log.Ctx(ctx)
+	sendCtx, cancel := context.WithTimeout(ctx, waitlistOfferSendTimeout)
+	defer cancel()
+
+	facility, err := s.facilities().GetFacilityByID(sendCtx, entry.FacilityID)
+	if err != nil {
+		logger.Error().Err(err).Int64("facility_id", entry.FacilityID).Msg("Failed to load facility for waitlist offers")
+		return
+	}
+	facilityLoc := apiutil.FacilityLocation(facility, logger)
+
+	start, err := waitlistSlotTime(entry.TargetDate, entry.TargetStartTime, facilityLoc)
+	if err != nil {
+		logger.Error().Err(err).Int64("waitlist_id", entry.ID).Msg("Failed to parse waitlist start time")
+		return
+	}
+	end, err := waitlistSlotTime(entry.TargetDate, entry.TargetEndTime, facilityLoc)
+	if err != nil {
+		logger.Error().Err(err).Int64("waitlist_id", entry.ID).Msg("Failed to parse waitlist end time")
+		return
+	}
+	s.deliverWaitlistOffers(sendCtx, logger, facility, start, end, offered, expiresAt)
+}
+
+// waitlistSlotTime combines a waitlist target date and HH:MM:SS time into a
+// wall-clock time in loc.
+func waitlistSlotTime(targetDate time.Time, value interface{}, loc *time.Location) (time.Time, error) {
+	var raw string
+	switch typed := value.(type) {
+	case string:
+		raw = typed
+	case []byte:
+		raw = string(typed)
+	case time.Time:
+		raw = typed.Format(waitlistTimeLayout)
+	default:
+		return time.Time{}, fmt.Errorf("unexpected waitlist time %T", value)
+	}
+	parsed, err := time.Parse(waitlistTimeLayout, strings.TrimSpace(raw))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, loc), nil
+}
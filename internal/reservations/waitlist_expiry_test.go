@@ -0,0 +1,155 @@
+package reservations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/email"
+)
+
+func (f serviceFixture) insertWaitlistEntry(t *testing.T, userID int64, position int64, status string) int64 {
+	t.Helper()
+
+	result, err := f.database.Exec(
+		`INSERT INTO waitlists (facility_id, user_id, target_date, target_start_time, target_end_time, position, status)
+		 VALUES (?, ?, ?, '10:00:00', '11:00:00', ?, ?)`,
+		f.facilityID, userID, time.Date(2030, time.June, 1, 0, 0, 0, 0, time.UTC), position, status,
+	)
+	if err != nil {
+		t.Fatalf("insert waitlist: %v", err)
+	}
+	id, _ := result.LastInsertId()
+	return id
+}
+
+func (f serviceFixture) insertOffer(t *testing.T, waitlistID int64, expiresAt time.Time) int64 {
+	t.Helper()
+
+	result, err := f.database.Exec(
+		"INSERT INTO waitlist_offers (waitlist_id, expires_at, status) VALUES (?, ?, 'pending')",
+		waitlistID, expiresAt,
+	)
+	if err != nil {
+		t.Fatalf("insert offer: %v", err)
+	}
+	id, _ := result.LastInsertId()
+	return id
+}
+
+func (f serviceFixture) setWaitlistConfig(t *testing.T, mode, expiredOfferAction string) {
+	t.Helper()
+
+	if _, err := f.database.Exec(
+		`INSERT INTO waitlist_config (facility_id, notification_mode, offer_expiry_minutes, expired_offer_action)
+		 VALUES (?, ?, 15, ?)`,
+		f.facilityID, mode, expiredOfferAction,
+	); err != nil {
+		t.Fatalf("insert waitlist config: %v", err)
+	}
+}
+
+func (f serviceFixture) waitlistState(t *testing.T, waitlistID int64) (string, int64) {
+	t.Helper()
+
+	var status string
+	var position int64
+	if err := f.database.QueryRow("SELECT status, position FROM waitlists WHERE id = ?", waitlistID).Scan(&status, &position); err != nil {
+		t.Fatalf("load waitlist %d: %v", waitlistID, err)
+	}
+	return status, position
+}
+
+func (f serviceFixture) offerStatuses(t *testing.T, waitlistID int64) []string {
+	t.Helper()
+
+	rows, err := f.database.Query("SELECT status FROM waitlist_offers WHERE waitlist_id = ? ORDER BY id", waitlistID)
+	if err != nil {
+		t.Fatalf("load offers: %v", err)
+	}
+	defer rows.Close()
+	var statuses []string
+	for rows.Next() {
+		var status string
+		if err := rows.Scan(&status); err != nil {
+			t.Fatalf("scan offer: %v", err)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func TestExpireWaitlistOffers_SequentialRemovesAndOffersNext(t *testing.T) {
+	fixture := setupServiceTest(t)
+	fixture.setWaitlistConfig(t, waitlistNotificationSequential, ExpiredOfferRemove)
+
+	nextUserID := fixture.insertMember(t, "Wait")
+	if _, err := fixture.database.Exec(
+		"UPDATE users SET email = 'wait@test.com', phone = '415-555-0199', sms_opt_in = 1 WHERE id = ?", nextUserID,
+	); err != nil {
+		t.Fatalf("update user: %v", err)
+	}
+
+	now := time.Date(2030, time.May, 1, 12, 0, 0, 0, time.UTC)
+	first := fixture.insertWaitlistEntry(t, fixture.userID, 1, waitlistStatusNotified)
+	second := fixture.insertWaitlistEntry(t, nextUserID, 2, waitlistStatusPending)
+	fixture.insertOffer(t, first, now.Add(-time.Minute))
+
+	sent := make(chan email.Channel, 1)
+	service := NewService(fixture.database, nil, offerNotifier{channel: email.ChannelEmail, sent: sent})
+
+	summary, err := service.ExpireWaitlistOffers(context.Background(), now)
+	if err != nil {
+		t.Fatalf("expire offers: %v", err)
+	}
+	if summary != (WaitlistExpirySummary{Expired: 1, Removed: 1, Advanced: 1}) {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+
+	if status, _ := fixture.waitlistState(t, first); status != waitlistStatusExpired {
+		t.Fatalf("expected first entry expired, got %q", status)
+	}
+	if statuses := fixture.offerStatuses(t, first); len(statuses) != 1 || statuses[0] != "expired" {
+		t.Fatalf("expected first offer expired, got %v", statuses)
+	}
+	status, position := fixture.waitlistState(t, second)
+	if status != waitlistStatusNotified || position != 1 {
+		t.Fatalf("expected second entry notified at position 1, got %q at %d", status, position)
+	}
+	if statuses := fixture.offerStatuses(t, second); len(statuses) != 1 || statuses[0] != waitlistOfferStatusPending {
+		t.Fatalf("expected a pending offer for the second entry, got %v", statuses)
+	}
+
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the next member's offer")
+	}
+}
+
+func TestExpireWaitlistOffers_RequeueKeepsPlace(t *testing.T) {
+	fixture := setupServiceTest(t)
+	fixture.setWaitlistConfig(t, waitlistNotificationBroadcast, ExpiredOfferRequeue)
+
+	now := time.Date(2030, time.May, 1, 12, 0, 0, 0, time.UTC)
+	entry := fixture.insertWaitlistEntry(t, fixture.userID, 1, waitlistStatusNotified)
+	fixture.insertOffer(t, entry, now.Add(-time.Minute))
+	fresh := fixture.insertWaitlistEntry(t, fixture.insertMember(t, "Fresh"), 2, waitlistStatusNotified)
+	fixture.insertOffer(t, fresh, now.Add(time.Minute))
+
+	summary, err := fixture.service.ExpireWaitlistOffers(context.Background(), now)
+	if err != nil {
+		t.Fatalf("expire offers: %v", err)
+	}
+	if summary != (WaitlistExpirySummary{Expired: 1, Requeued: 1}) {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+
+	status, position := fixture.waitlistState(t, entry)
+	if status != waitlistStatusPending || position != 1 {
+		t.Fatalf("expected requeued entry pending at position 1, got %q at %d", status, position)
+	}
+	if status, _ := fixture.waitlistState(t, fresh); status != waitlistStatusNotified {
+		t.Fatalf("expected unexpired offer untouched, got %q", status)
+	}
+}
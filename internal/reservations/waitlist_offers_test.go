@@ -0,0 +1,78 @@
+package reservations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+)
+
+type offerNotifier struct {
+	channel email.Channel
+	sent    chan email.Channel
+}
+
+func (n offerNotifier) Channel() email.Channel {
+	return n.channel
+}
+
+func (n offerNotifier) Notify(ctx context.Context, recipient email.Recipient, message email.ConfirmationEmail, sender string) error {
+	if message.Category != email.CategoryWaitlistOffer {
+		return nil
+	}
+	if recipient.Email != "wait@test.com" || recipient.Phone != "+14155550199" {
+		return nil
+	}
+	n.sent <- n.channel
+	return nil
+}
+
+func TestNotifyWaitlistedMembers_SendsOffersOnEveryChannel(t *testing.T) {
+	fixture := setupServiceTest(t)
+
+	result, err := fixture.database.Exec(
+		"INSERT INTO users (first_name, last_name, email, phone, status, sms_opt_in) VALUES ('Wait', 'Lister', 'wait@test.com', '415-555-0199', 'active', 1)",
+	)
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	waitingUserID, _ := result.LastInsertId()
+
+	start := time.Date(2030, time.June, 1, 10, 0, 0, 0, time.UTC)
+	reservation := dbgen.Reservation{
+		ID:         fixture.insertReservation(t, start),
+		FacilityID: fixture.facilityID,
+		StartTime:  start,
+		EndTime:    start.Add(time.Hour),
+	}
+	targetDate, targetStart, targetEnd := reservationWaitlistSlot(reservation)
+	if _, err := fixture.database.Exec(
+		`INSERT INTO waitlists (facility_id, user_id, target_date, target_start_time, target_end_time, position, status)
+		 VALUES (?, ?, ?, ?, ?, 1, 'pending')`,
+		fixture.facilityID, waitingUserID, targetDate, targetStart, targetEnd,
+	); err != nil {
+		t.Fatalf("insert waitlist: %v", err)
+	}
+
+	sent := make(chan email.Channel, 2)
+	service := NewService(fixture.database, nil, offerNotifier{channel: email.ChannelEmail, sent: sent}, offerNotifier{channel: email.ChannelSMS, sent: sent})
+
+	if err := service.notifyWaitlistedMembers(context.Background(), reservation, nil); err != nil {
+		t.Fatalf("notify waitlisted members: %v", err)
+	}
+
+	delivered := map[email.Channel]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case channel := <-sent:
+			delivered[channel] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for offer %d, got %+v", i+1, delivered)
+		}
+	}
+	if !delivered[email.ChannelEmail] || !delivered[email.ChannelSMS] {
+		t.Fatalf("expected an email and a text to the E.164 number, got %+v", delivered)
+	}
+}
@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/db"
+)
+
+// PurgeExpiredIdempotencyKeys deletes reservation idempotency keys whose
+// replay window has closed.
+func PurgeExpiredIdempotencyKeys(ctx context.Context, database *db.DB, now time.Time) (int64, error) {
+	if database == nil {
+		return 0, fmt.Errorf("idempotency key purge requires database")
+	}
+
+	deleted, err := database.Queries.DeleteExpiredReservationIdempotencyKeys(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	return deleted, nil
+}
+
+// RegisterIdempotencyKeyJobs registers the hourly purge of expired
+// reservation idempotency keys.
+func RegisterIdempotencyKeyJobs(database *db.DB) error {
+	if database == nil {
+		return fmt.Errorf("idempotency key jobs require database")
+	}
+
+	jobName := "idempotency_key_purge"
+	cronExpr := "15 * * * *"
+	jobLogger := log.With().
+		Str("component", "idempotency_key_purge_job").
+		Str("job_name", jobName).
+		Str("cron", cronExpr).
+		Logger()
+
+	_, err := AddTrackedJob(jobName, cronExpr, time.Minute, func(ctx context.Context) (int64, error) {
+		deleted, err := PurgeExpiredIdempotencyKeys(jobLogger.WithContext(ctx), database, time.Now())
+		if err != nil {
+			return 0, err
+		}
+		if deleted > 0 {
+			jobLogger.Info().Int64("deleted", deleted).Msg("Expired idempotency keys purged")
+		}
+		return deleted, nil
+	}, gocron.WithSingletonMode(gocron.LimitModeWait))
+	if err != nil {
+		return fmt.Errorf("add idempotency key purge job: %w", err)
+	}
+
+	jobLogger.Info().Msg("Idempotency key purge job registered")
+	return nil
+}
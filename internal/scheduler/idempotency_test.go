@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestPurgeExpiredIdempotencyKeys(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+
+	orgResult, err := database.ExecContext(ctx, "INSERT INTO organizations (name, slug, status) VALUES ('Org', 'org', 'active')")
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+	facilityResult, err := database.ExecContext(ctx,
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main', 'main', 'UTC')", orgID)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+	userResult, err := database.ExecContext(ctx,
+		"INSERT INTO users (first_name, last_name, email, status) VALUES ('Mia', 'Member', 'mia@test.com', 'active')")
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	userID, _ := userResult.LastInsertId()
+
+	now := time.Now()
+	reservationResult, err := database.ExecContext(ctx,
+		`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, start_time, end_time)
+		 VALUES (?, (SELECT id FROM reservation_types ORDER BY id LIMIT 1), ?, ?, ?)`,
+		facilityID, userID, now.Add(24*time.Hour), now.Add(25*time.Hour))
+	if err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	reservationID, _ := reservationResult.LastInsertId()
+
+	for key, expiresAt := range map[string]time.Time{"old": now.Add(-time.Hour), "live": now.Add(time.Hour)} {
+		if _, err := database.ExecContext(ctx,
+			`INSERT INTO reservation_idempotency_keys (user_id, idempotency_key, request_hash, reservation_id, response_body, expires_at)
+			 VALUES (?, ?, 'hash', ?, '{}', ?)`,
+			userID, key, reservationID, expiresAt); err != nil {
+			t.Fatalf("insert key %s: %v", key, err)
+		}
+	}
+
+	deleted, err := PurgeExpiredIdempotencyKeys(ctx, database, now)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("expected 1 expired key purged, got %d", deleted)
+	}
+	var remaining string
+	if err := database.QueryRowContext(ctx, "SELECT idempotency_key FROM reservation_idempotency_keys").Scan(&remaining); err != nil {
+		t.Fatalf("load remaining key: %v", err)
+	}
+	if remaining != "live" {
+		t.Fatalf("expected live key to remain, got %q", remaining)
+	}
+}
@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/leagues"
+)
+
+// ConfirmLapsedLeagueResults confirms captain-submitted match results the
+// away captain left unanswered past their response window. Each match is
+// confirmed in its own transaction, so one failure does not hold back the
+// rest.
+func ConfirmLapsedLeagueResults(ctx context.Context, database *db.DB, now time.Time) (int, error) {
+	if database == nil {
+		return 0, fmt.Errorf("league result confirmation requires database")
+	}
+
+	// respond_by is written in UTC, so the cutoff must be too.
+	lapsed, err := database.Queries.ListLapsedLeagueMatchResultSubmissions(ctx, now.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("list lapsed result submissions: %w", err)
+	}
+
+	logger := log.Ctx(ctx)
+	confirmed := 0
+	for _, submission := range lapsed {
+		err := database.RunInTx(ctx, func(txdb *db.DB) error {
+			_, err := leagues.ConfirmSubmittedResult(ctx, txdb.Queries, submission.LeagueID, submission.LeagueMatchID, sql.NullInt64{})
+			return err
+		})
+		switch {
+		case err == nil:
+			confirmed++
+		case errors.Is(err, leagues.ErrResultNotPending):
+			// Answered or overridden since it was listed.
+		default:
+			logger.Error().Err(err).Int64("match_id", submission.LeagueMatchID).Msg("Failed to auto-confirm league result")
+		}
+	}
+	return confirmed, nil
+}
+
+// RegisterLeagueResultJobs registers the sweep that auto-confirms lapsed
+// league result submissions.
+func RegisterLeagueResultJobs(database *db.DB) error {
+	if database == nil {
+		return fmt.Errorf("league result jobs require database")
+	}
+
+	jobName := "league_result_auto_confirm"
+	cronExpr := "*/15 * * * *"
+	jobLogger := log.With().
+		Str("component", "league_result_job").
+		Str("job_name", jobName).
+		Str("cron", cronExpr).
+		Logger()
+
+	_, err := AddTrackedJob(jobName, cronExpr, time.Minute, func(ctx context.Context) (int64, error) {
+		confirmed, err := ConfirmLapsedLeagueResults(jobLogger.WithContext(ctx), database, time.Now())
+		if err != nil {
+			return 0, err
+		}
+		if confirmed > 0 {
+			jobLogger.Info().Int("confirmed", confirmed).Msg("Lapsed league results confirmed")
+		}
+		return int64(confirmed), nil
+	}, gocron.WithSingletonMode(gocron.LimitModeWait))
+	if err != nil {
+		return fmt.Errorf("add league result job: %w", err)
+	}
+
+	jobLogger.Info().Msg("League result job registered")
+	return nil
+}
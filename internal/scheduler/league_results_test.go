@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestConfirmLapsedLeagueResults(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	mustExec := func(query string, args ...any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	orgID := mustExec("INSERT INTO organizations (name, slug, status) VALUES ('Test Org', 'test-org', 'active')")
+	facilityID := mustExec("INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main', 'main', 'UTC')", orgID)
+	captainID := mustExec("INSERT INTO users (first_name, last_name, email, status, is_member) VALUES ('Cap', 'Tain', 'cap@test.com', 'active', 1)")
+	leagueID := mustExec(
+		`INSERT INTO leagues (facility_id, name, format, start_date, end_date, division_config, min_team_size, max_team_size, status)
+		 VALUES (?, 'Ladder', 'doubles', '2026-06-01', '2026-08-01', '{}', 1, 4, 'active')`, facilityID)
+	homeID := mustExec("INSERT INTO league_teams (league_id, name, captain_user_id, status) VALUES (?, 'Home', ?, 'active')", leagueID, captainID)
+	awayID := mustExec("INSERT INTO league_teams (league_id, name, captain_user_id, status) VALUES (?, 'Away', ?, 'active')", leagueID, captainID)
+
+	insertSubmission := func(respondBy time.Time) int64 {
+		t.Helper()
+		matchID := mustExec(
+			"INSERT INTO league_matches (league_id, home_team_id, away_team_id, scheduled_time, status) VALUES (?, ?, ?, ?, 'pending_result')",
+			leagueID, homeID, awayID, now.Add(-72*time.Hour).UTC())
+		mustExec(
+			`INSERT INTO league_match_result_submissions (league_match_id, submitted_by_user_id, home_score, away_score, respond_by)
+			 VALUES (?, ?, 11, 6, ?)`, matchID, captainID, respondBy.UTC())
+		return matchID
+	}
+	lapsed := insertSubmission(now.Add(-time.Hour))
+	open := insertSubmission(now.Add(time.Hour))
+
+	confirmed, err := ConfirmLapsedLeagueResults(ctx, database, now)
+	if err != nil {
+		t.Fatalf("confirm lapsed results: %v", err)
+	}
+	if confirmed != 1 {
+		t.Fatalf("expected 1 result confirmed, got %d", confirmed)
+	}
+
+	for matchID, want := range map[int64]string{lapsed: "completed", open: "pending_result"} {
+		var status, submissionStatus string
+		if err := database.QueryRowContext(ctx,
+			`SELECT lm.status, s.status FROM league_matches lm
+			 JOIN league_match_result_submissions s ON s.league_match_id = lm.id WHERE lm.id = ?`, matchID,
+		).Scan(&status, &submissionStatus); err != nil {
+			t.Fatalf("load match %d: %v", matchID, err)
+		}
+		if status != want {
+			t.Fatalf("match %d status %q, want %q (submission %q)", matchID, status, want, submissionStatus)
+		}
+	}
+
+	if confirmed, err := ConfirmLapsedLeagueResults(ctx, database, now); err != nil || confirmed != 0 {
+		t.Fatalf("expected a second run to confirm nothing, got %d (%v)", confirmed, err)
+	}
+}
@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/db"
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// AnonymizeDeletedMembers scrubs contact details, photos, and billing from
+// members deleted more than retention before now. The user rows stay,
+// renamed, so past reservations still resolve.
+func AnonymizeDeletedMembers(ctx context.Context, database *db.DB, now time.Time, retention time.Duration) (int64, error) {
+	if database == nil {
+		return 0, fmt.Errorf("member anonymization requires database")
+	}
+
+	// deleted_at is written by SQLite in UTC, so the cutoff must be too.
+	cutoff := sql.NullTime{Time: now.Add(-retention).UTC(), Valid: true}
+
+	var anonymized int64
+	err := database.RunInTx(ctx, func(txdb *db.DB) error {
+		if _, err := txdb.Queries.DeleteAnonymizableMemberPhotos(ctx, cutoff); err != nil {
+			return fmt.Errorf("delete member photos: %w", err)
+		}
+		if _, err := txdb.Queries.DeleteAnonymizableMemberBilling(ctx, cutoff); err != nil {
+			return fmt.Errorf("delete member billing: %w", err)
+		}
+		var err error
+		anonymized, err = txdb.Queries.AnonymizeDeletedMembers(ctx, dbgen.AnonymizeDeletedMembersParams{
+			AnonymizedAt: sql.NullTime{Time: now, Valid: true},
+			Cutoff:       cutoff,
+		})
+		if err != nil {
+			return fmt.Errorf("anonymize members: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return anonymized, nil
+}
+
+// RegisterMemberAnonymizationJobs registers the nightly scrub of members
+// deleted more than retention ago.
+func RegisterMemberAnonymizationJobs(database *db.DB, retention time.Duration) error {
+	if database == nil {
+		return fmt.Errorf("member anonymization jobs require database")
+	}
+
+	jobName := "member_anonymization"
+	cronExpr := "45 2 * * *"
+	jobLogger := log.With().
+		Str("component", "member_anonymization_job").
+		Str("job_name", jobName).
+		Str("cron", cronExpr).
+		Dur("retention", retention).
+		Logger()
+
+	_, err := AddTrackedJob(jobName, cronExpr, time.Minute, func(ctx context.Context) (int64, error) {
+		anonymized, err := AnonymizeDeletedMembers(jobLogger.WithContext(ctx), database, time.Now(), retention)
+		if err != nil {
+			return 0, err
+		}
+		if anonymized > 0 {
+			jobLogger.Info().Int64("anonymized", anonymized).Msg("Deleted members anonymized")
+		}
+		return anonymized, nil
+	}, gocron.WithSingletonMode(gocron.LimitModeWait))
+	if err != nil {
+		return fmt.Errorf("add member anonymization job: %w", err)
+	}
+
+	jobLogger.Info().Msg("Member anonymization job registered")
+	return nil
+}
@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestAnonymizeDeletedMembers(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	insertMember := func(email string, status string, deletedAt any) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx,
+			`INSERT INTO users (first_name, last_name, email, phone, street_address, status, is_member, deleted_at)
+			 VALUES ('Dana', 'Departed', ?, '+15555550100', '1 Main St', ?, 1, ?)`,
+			email, status, deletedAt)
+		if err != nil {
+			t.Fatalf("insert member: %v", err)
+		}
+		id, _ := result.LastInsertId()
+		if _, err := database.ExecContext(ctx,
+			"INSERT INTO user_photos (user_id, data, content_type, size) VALUES (?, x'00', 'image/png', 1)", id); err != nil {
+			t.Fatalf("insert photo: %v", err)
+		}
+		return id
+	}
+	// Written the way DeleteMember's CURRENT_TIMESTAMP stores it.
+	expired := insertMember("old@test.com", "deleted", now.Add(-40*24*time.Hour).UTC().Format("2006-01-02 15:04:05"))
+	recent := insertMember("recent@test.com", "deleted", now.Add(-29*24*time.Hour).UTC().Format("2006-01-02 15:04:05"))
+	active := insertMember("active@test.com", "active", nil)
+
+	anonymized, err := AnonymizeDeletedMembers(ctx, database, now, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("anonymize: %v", err)
+	}
+	if anonymized != 1 {
+		t.Fatalf("expected 1 member anonymized, got %d", anonymized)
+	}
+
+	type snapshot struct {
+		firstName    string
+		email        sql.NullString
+		phone        sql.NullString
+		anonymizedAt sql.NullTime
+		photos       int
+	}
+	load := func(id int64) snapshot {
+		t.Helper()
+		var s snapshot
+		if err := database.QueryRowContext(ctx,
+			`SELECT first_name, email, phone, anonymized_at, (SELECT COUNT(*) FROM user_photos WHERE user_id = users.id)
+			 FROM users WHERE id = ?`, id).Scan(&s.firstName, &s.email, &s.phone, &s.anonymizedAt, &s.photos); err != nil {
+			t.Fatalf("load member %d: %v", id, err)
+		}
+		return s
+	}
+
+	if got := load(expired); got.firstName != "Former" || got.email.Valid || got.phone.Valid || !got.anonymizedAt.Valid || got.photos != 0 {
+		t.Fatalf("expected expired member scrubbed, got %+v", got)
+	}
+	for _, id := range []int64{recent, active} {
+		if got := load(id); got.firstName != "Dana" || !got.email.Valid || got.anonymizedAt.Valid || got.photos != 1 {
+			t.Fatalf("expected member %d untouched, got %+v", id, got)
+		}
+	}
+
+	if again, err := AnonymizeDeletedMembers(ctx, database, now, 30*24*time.Hour); err != nil || again != 0 {
+		t.Fatalf("expected a second run to do nothing, got %d, %v", again, err)
+	}
+}
@@ -17,55 +17,51 @@ import (
 	"github.com/codr1/Pickleicious/internal/email"
 )
 
-const (
-	defaultReminderHoursBefore int64 = 24
-	reminderJobWindow                = 15 * time.Minute
-)
-
-// RegisterReminderJobs registers scheduled reservation reminder tasks.
-func RegisterReminderJobs(database *db.DB, emailClient *email.SESClient) error {
+const defaultReminderHoursBefore int64 = 24
+
+// RegisterReminderJobs registers scheduled reservation reminder tasks. Each
+// run reminds every reservation starting within its facility's reminder
+// window that has not been reminded yet, so a missed run or restart catches
+// up instead of skipping or resending. Members who opted in to SMS are also
+// texted when smsNotifier is set.
+func RegisterReminderJobs(database *db.DB, emailClient *email.SESClient, smsNotifier email.Notifier) error {
 	if database == nil {
 		return fmt.Errorf("reminder jobs require database")
 	}
 
 	jobName := "reservation_reminders"
-	cronExpr := "*/15 * * * *"
+	cronExpr := "*/10 * * * *"
 	jobLogger := log.With().
 		Str("component", "reservation_reminders_job").
 		Str("job_name", jobName).
 		Str("cron", cronExpr).
 		Logger()
 
-	_, err := AddJob(jobName, cronExpr, func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-		defer cancel()
+	_, err := AddTrackedJob(jobName, cronExpr, 2*time.Minute, func(ctx context.Context) (int64, error) {
 		ctx = jobLogger.WithContext(ctx)
 
-		if emailClient == nil {
-			jobLogger.Debug().Msg("Reminder job skipped: email client not configured")
-			return
+		if emailClient == nil && smsNotifier == nil {
+			jobLogger.Debug().Msg("Reminder job skipped: no notification channel configured")
+			return 0, nil
 		}
 
-		now := time.Now().UTC().Truncate(reminderJobWindow)
+		now := time.Now()
 		facilities, err := database.Queries.ListFacilities(ctx)
 		if err != nil {
-			jobLogger.Error().Err(err).Msg("Failed to load facilities for reminder job")
-			return
+			return 0, fmt.Errorf("load facilities: %w", err)
 		}
 
+		var reminded int64
 		for _, facility := range facilities {
 			facilityLogger := jobLogger.With().Int64("facility_id", facility.ID).Logger()
 			facilityCtx := facilityLogger.WithContext(ctx)
 
 			reminderHours := resolveReminderHours(facilityCtx, database.Queries, facility, &facilityLogger)
 
-			windowStart := now.Add(time.Duration(reminderHours) * time.Hour)
-			windowEnd := windowStart.Add(reminderJobWindow)
-
-			reservations, err := database.Queries.ListReservationsStartingBetween(facilityCtx, dbgen.ListReservationsStartingBetweenParams{
+			reservations, err := database.Queries.ListReservationsDueForReminder(facilityCtx, dbgen.ListReservationsDueForReminderParams{
 				FacilityID: facility.ID,
-				StartTime:  windowStart,
-				EndTime:    windowEnd,
+				Now:        now,
+				WindowEnd:  now.Add(time.Duration(reminderHours) * time.Hour),
 			})
 			if err != nil {
 				facilityLogger.Error().Err(err).Msg("Failed to load reservations for reminder job")
@@ -75,23 +71,30 @@ func RegisterReminderJobs(database *db.DB, emailClient *email.SESClient) error {
 				continue
 			}
 
-			facilityLoc := time.Local
-			if facility.Timezone != "" {
-				loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-				if loadErr != nil {
-					facilityLogger.Error().Err(loadErr).Str("timezone", facility.Timezone).Msg("Failed to load facility timezone for reminders")
-				} else {
-					facilityLoc = loadedLoc
-				}
-			}
+			facilityLoc := apiutil.FacilityLocation(facility, &facilityLogger)
 
 			for _, reservation := range reservations {
-				if err := sendReservationReminder(facilityCtx, database, emailClient, facility, reservation, facilityLoc, &facilityLogger); err != nil {
+				// Claim before sending: a crash mid-send may drop a reminder,
+				// but overlapping runs or restarts never send it twice.
+				claimed, err := database.Queries.ClaimReservationReminder(facilityCtx, dbgen.ClaimReservationReminderParams{
+					ReservationID: reservation.ID,
+					SentAt:        now,
+				})
+				if err != nil {
+					facilityLogger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to mark reservation reminded")
+					continue
+				}
+				if claimed == 0 {
+					continue
+				}
+				if err := sendReservationReminder(facilityCtx, database, emailClient, smsNotifier, facility, reservation, facilityLoc, &facilityLogger); err != nil {
 					facilityLogger.Error().Err(err).Int64("reservation_id", reservation.ID).Msg("Failed to send reminder emails")
 					continue
 				}
+				reminded++
 			}
 		}
+		return reminded, nil
 	}, gocron.WithSingletonMode(gocron.LimitModeWait))
 	if err != nil {
 		return fmt.Errorf("add reservation reminder job: %w", err)
@@ -101,8 +104,52 @@ func RegisterReminderJobs(database *db.DB, emailClient *email.SESClient) error {
 	return nil
 }
 
-func sendReservationReminder(ctx context.Context, database *db.DB, emailClient *email.SESClient, facility dbgen.Facility, reservation dbgen.Reservation, facilityLoc *time.Location, logger *zerolog.Logger) error {
-	if database == nil || emailClient == nil {
+// RegisterDeferredEmailJobs registers delivery of emails and texts held for
+// facility quiet hours.
+func RegisterDeferredEmailJobs(database *db.DB, emailClient *email.SESClient, smsNotifier email.Notifier) error {
+	if database == nil {
+		return fmt.Errorf("deferred email jobs require database")
+	}
+
+	jobName := "deferred_email_dispatch"
+	cronExpr := "*/5 * * * *"
+	jobLogger := log.With().
+		Str("component", "deferred_email_dispatch_job").
+		Str("job_name", jobName).
+		Str("cron", cronExpr).
+		Logger()
+
+	// A nil *SESClient must not become a non-nil EmailSender.
+	var sender email.EmailSender
+	if emailClient != nil {
+		sender = emailClient
+	}
+
+	_, err := AddTrackedJob(jobName, cronExpr, 2*time.Minute, func(ctx context.Context) (int64, error) {
+		if sender == nil && smsNotifier == nil {
+			jobLogger.Debug().Msg("Deferred email job skipped: no notification channel configured")
+			return 0, nil
+		}
+
+		sent, err := email.DispatchDueEmails(jobLogger.WithContext(ctx), database.Queries, sender, smsNotifier, time.Now(), &jobLogger)
+		if err != nil {
+			return 0, err
+		}
+		if sent > 0 {
+			jobLogger.Info().Int("sent", sent).Msg("Deferred emails dispatched")
+		}
+		return int64(sent), nil
+	}, gocron.WithSingletonMode(gocron.LimitModeWait))
+	if err != nil {
+		return fmt.Errorf("add deferred email job: %w", err)
+	}
+
+	jobLogger.Info().Msg("Deferred email job registered")
+	return nil
+}
+
+func sendReservationReminder(ctx context.Context, database *db.DB, emailClient *email.SESClient, smsNotifier email.Notifier, facility dbgen.Facility, reservation dbgen.Reservation, facilityLoc *time.Location, logger *zerolog.Logger) error {
+	if database == nil || (emailClient == nil && smsNotifier == nil) {
 		return nil
 	}
 
@@ -135,6 +182,8 @@ func sendReservationReminder(ctx context.Context, database *db.DB, emailClient *
 
 	date, timeRange := email.FormatDateTimeRange(reservation.StartTime.In(facilityLoc), reservation.EndTime.In(facilityLoc))
 	reminder := email.BuildReminderEmail(email.ReminderDetails{
+		FacilityID:      facility.ID,
+		ReservationID:   reservation.ID,
 		FacilityName:    facility.Name,
 		ReservationType: reservationTypeName,
 		Date:            date,
@@ -142,15 +191,21 @@ func sendReservationReminder(ctx context.Context, database *db.DB, emailClient *
 		Courts:          apiutil.ReservationCourtLabel(courtRows),
 	})
 	sender := email.ResolveFromAddress(ctx, database.Queries, facility, logger)
-	if sender == "" {
-		if logger != nil {
-			logger.Warn().Int64("facility_id", facility.ID).Msg("Skipping reminder emails: missing from address")
-		}
-		return nil
+	if sender == "" && emailClient != nil && logger != nil {
+		logger.Warn().Int64("facility_id", facility.ID).Msg("Skipping reminder emails: missing from address")
 	}
 
+	openPlay := reservation.OpenPlayRuleID.Valid
 	for userID := range recipientIDs {
-		email.SendReminderEmail(ctx, database.Queries, emailClient, userID, reminder, sender, logger)
+		if openPlay && !email.ShouldSend(ctx, database.Queries, userID, email.PreferenceOpenPlayReminders) {
+			continue
+		}
+		// Both channels are held through quiet hours; one failing never holds
+		// back the other.
+		if emailClient != nil && sender != "" {
+			email.SendReminderEmail(ctx, database.Queries, emailClient, userID, reminder, sender, logger)
+		}
+		email.SendReminderText(ctx, database.Queries, smsNotifier, userID, reminder, logger)
 	}
 
 	return nil
@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestReservationReminderClaims(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+
+	orgResult, err := database.ExecContext(ctx, "INSERT INTO organizations (name, slug, status) VALUES ('Org', 'org', 'active')")
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+	facilityResult, err := database.ExecContext(ctx,
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main', 'main', 'UTC')", orgID)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+	userResult, err := database.ExecContext(ctx,
+		"INSERT INTO users (first_name, last_name, email, status) VALUES ('Staff', 'User', 'staff@test.com', 'active')")
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	userID, _ := userResult.LastInsertId()
+
+	now := time.Now()
+	insert := func(start time.Time) int64 {
+		t.Helper()
+		result, err := database.ExecContext(ctx,
+			`INSERT INTO reservations (facility_id, reservation_type_id, created_by_user_id, start_time, end_time)
+			 VALUES (?, (SELECT id FROM reservation_types ORDER BY id LIMIT 1), ?, ?, ?)`,
+			facilityID, userID, start, start.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("insert reservation: %v", err)
+		}
+		id, _ := result.LastInsertId()
+		return id
+	}
+	due := insert(now.Add(3 * time.Hour))
+	insert(now.Add(30 * time.Hour))
+	insert(now.Add(-time.Hour))
+
+	params := dbgen.ListReservationsDueForReminderParams{
+		FacilityID: facilityID,
+		Now:        now,
+		WindowEnd:  now.Add(time.Duration(defaultReminderHoursBefore) * time.Hour),
+	}
+	reservations, err := database.Queries.ListReservationsDueForReminder(ctx, params)
+	if err != nil {
+		t.Fatalf("list due reminders: %v", err)
+	}
+	if len(reservations) != 1 || reservations[0].ID != due {
+		t.Fatalf("expected only reservation %d to be due, got %+v", due, reservations)
+	}
+
+	claim := func() int64 {
+		t.Helper()
+		claimed, err := database.Queries.ClaimReservationReminder(ctx, dbgen.ClaimReservationReminderParams{
+			ReservationID: due,
+			SentAt:        now,
+		})
+		if err != nil {
+			t.Fatalf("claim reminder: %v", err)
+		}
+		return claimed
+	}
+	if claim() != 1 || claim() != 0 {
+		t.Fatal("expected the reminder to be claimed exactly once")
+	}
+	reservations, err = database.Queries.ListReservationsDueForReminder(ctx, params)
+	if err != nil {
+		t.Fatalf("list due reminders: %v", err)
+	}
+	if len(reservations) != 0 {
+		t.Fatalf("expected no reminders after the claim, got %+v", reservations)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/codr1/Pickleicious/internal/db"
+	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/jobs"
 )
 
 var (
@@ -96,6 +98,25 @@ func AddJob(name, cronExpr string, task func(), options ...gocron.JobOption) (go
 	return svc.AddJob(name, cronExpr, task, options...)
 }
 
+// AddTrackedJob registers a cron-based job whose runs are recorded in the job
+// registry, which also lets admins trigger it by hand. timeout bounds each
+// run. A scheduled run is skipped while a manual run is still going.
+func AddTrackedJob(name, cronExpr string, timeout time.Duration, run jobs.Func, options ...gocron.JobOption) (gocron.Job, error) {
+	registry := jobs.Default()
+	if registry == nil {
+		return nil, jobs.ErrNotInitialized
+	}
+	if err := registry.Register(name, cronExpr, timeout, run); err != nil {
+		return nil, err
+	}
+	return AddJob(name, cronExpr, func() {
+		err := registry.Run(context.Background(), name, jobs.TriggerSchedule)
+		if errors.Is(err, jobs.ErrAlreadyRunning) {
+			log.Info().Str("job_name", name).Msg("Scheduled run skipped: job already running")
+		}
+	}, options...)
+}
+
 // Start begins running scheduled jobs.
 func (s *Service) Start() {
 	if s == nil {
@@ -152,28 +173,31 @@ func (s *Service) AddJob(name, cronExpr string, task func(), options ...gocron.J
 	return job, nil
 }
 
-// RegisterWaitlistJobs registers scheduled waitlist maintenance tasks.
-func RegisterWaitlistJobs(database *db.DB) error {
+// DefaultWaitlistOfferExpiryInterval is how often expired waitlist offers are
+// swept when no interval is configured.
+const DefaultWaitlistOfferExpiryInterval = "* * * * *"
+
+// RegisterWaitlistJobs registers scheduled waitlist maintenance tasks. Offers
+// are swept on expiryCronExpr, or every minute when it is empty, and members
+// offered a slot by the sweep are notified on notifiers.
+func RegisterWaitlistJobs(database *db.DB, expiryCronExpr string, notifiers ...email.Notifier) error {
 	if database == nil {
 		return fmt.Errorf("waitlist jobs require database")
 	}
 
 	expireJobName := "waitlist_offer_expiry"
-	expireCronExpr := "* * * * *"
+	expireCronExpr := expiryCronExpr
+	if expireCronExpr == "" {
+		expireCronExpr = DefaultWaitlistOfferExpiryInterval
+	}
 	expireLogger := log.With().
 		Str("component", "waitlist_offer_expiry_job").
 		Str("job_name", expireJobName).
 		Str("cron", expireCronExpr).
 		Logger()
 
-	_, err := AddJob(expireJobName, expireCronExpr, func() {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
-		defer cancel()
-		ctx = expireLogger.WithContext(ctx)
-
-		if err := ExpireWaitlistOffers(ctx, database, time.Now()); err != nil {
-			expireLogger.Error().Err(err).Msg("Waitlist offer expiry run failed")
-		}
+	_, err := AddTrackedJob(expireJobName, expireCronExpr, time.Minute, func(ctx context.Context) (int64, error) {
+		return ExpireWaitlistOffers(expireLogger.WithContext(ctx), database, time.Now(), notifiers...)
 	}, gocron.WithSingletonMode(gocron.LimitModeWait))
 	if err != nil {
 		return fmt.Errorf("add waitlist offer expiry job: %w", err)
@@ -188,14 +212,8 @@ func RegisterWaitlistJobs(database *db.DB) error {
 		Str("cron", cleanupCronExpr).
 		Logger()
 
-	_, err = AddJob(cleanupJobName, cleanupCronExpr, func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-		defer cancel()
-		ctx = cleanupLogger.WithContext(ctx)
-
-		if err := CleanupPastWaitlists(ctx, database, time.Now()); err != nil {
-			cleanupLogger.Error().Err(err).Msg("Waitlist cleanup run failed")
-		}
+	_, err = AddTrackedJob(cleanupJobName, cleanupCronExpr, 2*time.Minute, func(ctx context.Context) (int64, error) {
+		return CleanupPastWaitlists(cleanupLogger.WithContext(ctx), database, time.Now())
 	}, gocron.WithSingletonMode(gocron.LimitModeWait))
 	if err != nil {
 		return fmt.Errorf("add waitlist cleanup job: %w", err)
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/codr1/Pickleicious/internal/db"
+)
+
+// PruneStaffNotifications deletes staff notifications created more than
+// retention before now, read or not.
+func PruneStaffNotifications(ctx context.Context, database *db.DB, now time.Time, retention time.Duration) (int64, error) {
+	if database == nil {
+		return 0, fmt.Errorf("staff notification prune requires database")
+	}
+
+	// created_at is written by SQLite in UTC, so the cutoff must be too.
+	deleted, err := database.Queries.DeleteStaffNotificationsBefore(ctx, now.Add(-retention).UTC())
+	if err != nil {
+		return 0, fmt.Errorf("delete staff notifications: %w", err)
+	}
+	return deleted, nil
+}
+
+// RegisterStaffNotificationJobs registers the nightly prune of staff
+// notifications older than retention.
+func RegisterStaffNotificationJobs(database *db.DB, retention time.Duration) error {
+	if database == nil {
+		return fmt.Errorf("staff notification jobs require database")
+	}
+
+	jobName := "staff_notification_prune"
+	cronExpr := "30 3 * * *"
+	jobLogger := log.With().
+		Str("component", "staff_notification_prune_job").
+		Str("job_name", jobName).
+		Str("cron", cronExpr).
+		Dur("retention", retention).
+		Logger()
+
+	_, err := AddTrackedJob(jobName, cronExpr, time.Minute, func(ctx context.Context) (int64, error) {
+		deleted, err := PruneStaffNotifications(jobLogger.WithContext(ctx), database, time.Now(), retention)
+		if err != nil {
+			return 0, err
+		}
+		if deleted > 0 {
+			jobLogger.Info().Int64("deleted", deleted).Msg("Old staff notifications pruned")
+		}
+		return deleted, nil
+	}, gocron.WithSingletonMode(gocron.LimitModeWait))
+	if err != nil {
+		return fmt.Errorf("add staff notification prune job: %w", err)
+	}
+
+	jobLogger.Info().Msg("Staff notification prune job registered")
+	return nil
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codr1/Pickleicious/internal/testutil"
+)
+
+func TestPruneStaffNotifications(t *testing.T) {
+	database := testutil.NewTestDB(t)
+	ctx := context.Background()
+
+	orgResult, err := database.ExecContext(ctx, "INSERT INTO organizations (name, slug, status) VALUES ('Org', 'org', 'active')")
+	if err != nil {
+		t.Fatalf("insert organization: %v", err)
+	}
+	orgID, _ := orgResult.LastInsertId()
+	facilityResult, err := database.ExecContext(ctx,
+		"INSERT INTO facilities (organization_id, name, slug, timezone) VALUES (?, 'Main', 'main', 'UTC')", orgID)
+	if err != nil {
+		t.Fatalf("insert facility: %v", err)
+	}
+	facilityID, _ := facilityResult.LastInsertId()
+
+	for message, age := range map[string]string{"old": "-100 days", "recent": "-10 days"} {
+		if _, err := database.ExecContext(ctx,
+			`INSERT INTO staff_notifications (facility_id, notification_type, message, read, created_at)
+			 VALUES (?, 'waitlist_fulfilled', ?, 0, datetime('now', ?))`,
+			facilityID, message, age); err != nil {
+			t.Fatalf("insert notification %s: %v", message, err)
+		}
+	}
+
+	deleted, err := PruneStaffNotifications(ctx, database, time.Now(), 90*24*time.Hour)
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("expected 1 old notification pruned, got %d", deleted)
+	}
+	var remaining string
+	if err := database.QueryRowContext(ctx, "SELECT message FROM staff_notifications").Scan(&remaining); err != nil {
+		t.Fatalf("load remaining notification: %v", err)
+	}
+	if remaining != "recent" {
+		t.Fatalf("expected recent notification to remain, got %q", remaining)
+	}
+}
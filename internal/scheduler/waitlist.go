@@ -2,130 +2,60 @@ package scheduler
 
 import (
 	"context"
-	"database/sql"
-	"errors"
 	"fmt"
 	"time"
 
 	"github.com/rs/zerolog/log"
 
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	"github.com/codr1/Pickleicious/internal/db"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+	"github.com/codr1/Pickleicious/internal/email"
+	"github.com/codr1/Pickleicious/internal/reservations"
 )
 
-const (
-	defaultWaitlistOfferExpiryMinutes int64 = 30
-	waitlistStatusExpired                   = "expired"
-	waitlistStatusNotified                  = "notified"
-)
-
-func ExpireWaitlistOffers(ctx context.Context, database *db.DB, now time.Time) error {
+// ExpireWaitlistOffers runs one waitlist offer expiry sweep, logs what it
+// did and returns how many offers expired. Members offered a slot in place of
+// an expired offer are notified on notifiers.
+func ExpireWaitlistOffers(ctx context.Context, database *db.DB, now time.Time, notifiers ...email.Notifier) (int64, error) {
 	if database == nil {
-		return fmt.Errorf("waitlist offer expiry requires database")
+		return 0, fmt.Errorf("waitlist offer expiry requires database")
 	}
 
-	rows, err := database.Queries.ListExpiredOffers(ctx, now)
+	summary, err := reservations.NewService(database, nil, notifiers...).ExpireWaitlistOffers(ctx, now)
 	if err != nil {
-		return fmt.Errorf("list expired offers: %w", err)
-	}
-	if len(rows) == 0 {
-		return nil
+		return 0, err
 	}
-
-	logger := log.Ctx(ctx)
-	for _, row := range rows {
-		// Captured for post-transaction logging (tx closure sets these for the success log below).
-		var advancedOfferID int64
-		var advanced bool
-
-		err := database.RunInTx(ctx, func(txdb *db.DB) error {
-			if _, err := txdb.Queries.ExpireOffer(ctx, dbgen.ExpireOfferParams{
-				ID:         row.OfferID,
-				WaitlistID: row.WaitlistID,
-			}); err != nil {
-				return fmt.Errorf("expire offer: %w", err)
-			}
-
-			if _, err := txdb.Queries.UpdateWaitlistStatus(ctx, dbgen.UpdateWaitlistStatusParams{
-				ID:         row.WaitlistID,
-				FacilityID: row.FacilityID,
-				Status:     waitlistStatusExpired,
-			}); err != nil {
-				return fmt.Errorf("update waitlist status: %w", err)
-			}
-
-			expiryMinutes := row.OfferExpiryMinutes
-			if expiryMinutes <= 0 {
-				expiryMinutes = defaultWaitlistOfferExpiryMinutes
-			}
-			expiresAt := now.Add(time.Duration(expiryMinutes) * time.Minute)
-
-			nextOffer, err := txdb.Queries.AdvanceWaitlistOffer(ctx, dbgen.AdvanceWaitlistOfferParams{
-				WaitlistID: row.WaitlistID,
-				ExpiresAt:  expiresAt,
-			})
-			if err != nil {
-				if errors.Is(err, sql.ErrNoRows) {
-					return nil
-				}
-				return fmt.Errorf("advance waitlist offer: %w", err)
-			}
-
-			advanced = true
-			advancedOfferID = nextOffer.ID
-
-			if _, err := txdb.Queries.UpdateWaitlistStatus(ctx, dbgen.UpdateWaitlistStatusParams{
-				ID:         nextOffer.WaitlistID,
-				FacilityID: row.FacilityID,
-				Status:     waitlistStatusNotified,
-			}); err != nil {
-				return fmt.Errorf("update next waitlist status: %w", err)
-			}
-
-			return nil
-		})
-		if err != nil {
-			logger.Error().Err(err).
-				Int64("waitlist_id", row.WaitlistID).
-				Int64("offer_id", row.OfferID).
-				Msg("Failed to expire waitlist offer")
-			continue
-		}
-
-		event := logger.Info().
-			Int64("waitlist_id", row.WaitlistID).
-			Int64("offer_id", row.OfferID)
-		if advanced {
-			event.Int64("next_offer_id", advancedOfferID)
-		}
-		event.Msg("Expired waitlist offer")
+	if summary.Expired == 0 && summary.Failed == 0 {
+		return 0, nil
 	}
 
-	return nil
+	log.Ctx(ctx).Info().
+		Int("expired", summary.Expired).
+		Int("removed", summary.Removed).
+		Int("requeued", summary.Requeued).
+		Int("advanced", summary.Advanced).
+		Int("failed", summary.Failed).
+		Msg("Waitlist offer expiry sweep completed")
+	return int64(summary.Expired), nil
 }
 
-func CleanupPastWaitlists(ctx context.Context, database *db.DB, now time.Time) error {
+// CleanupPastWaitlists deletes waitlist entries whose slot has passed and
+// returns how many it deleted.
+func CleanupPastWaitlists(ctx context.Context, database *db.DB, now time.Time) (int64, error) {
 	if database == nil {
-		return fmt.Errorf("waitlist cleanup requires database")
+		return 0, fmt.Errorf("waitlist cleanup requires database")
 	}
 
 	facilities, err := database.Queries.ListFacilities(ctx)
 	if err != nil {
-		return fmt.Errorf("list facilities for waitlist cleanup: %w", err)
+		return 0, fmt.Errorf("list facilities for waitlist cleanup: %w", err)
 	}
 
 	logger := log.Ctx(ctx)
 	var deletedTotal int64
 	for _, facility := range facilities {
-		facilityLoc := time.Local
-		if facility.Timezone != "" {
-			loadedLoc, loadErr := time.LoadLocation(facility.Timezone)
-			if loadErr != nil {
-				logger.Error().Err(loadErr).Str("timezone", facility.Timezone).Int64("facility_id", facility.ID).Msg("Failed to load facility timezone for waitlist cleanup")
-			} else {
-				facilityLoc = loadedLoc
-			}
-		}
+		facilityLoc := apiutil.FacilityLocation(facility, logger)
 
 		localNow := now.In(facilityLoc)
 		deleted, err := database.Queries.DeletePastWaitlistEntries(ctx, dbgen.DeletePastWaitlistEntriesParams{
@@ -134,11 +64,11 @@ func CleanupPastWaitlists(ctx context.Context, database *db.DB, now time.Time) e
 			ComparisonTime: localNow.Format("15:04:05"),
 		})
 		if err != nil {
-			return fmt.Errorf("delete past waitlist entries for facility %d: %w", facility.ID, err)
+			return deletedTotal, fmt.Errorf("delete past waitlist entries for facility %d: %w", facility.ID, err)
 		}
 		deletedTotal += deleted
 	}
 
 	logger.Debug().Int64("deleted_waitlists", deletedTotal).Msg("Cleaned up past waitlists")
-	return nil
+	return deletedTotal, nil
 }
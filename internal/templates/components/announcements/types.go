@@ -0,0 +1,49 @@
+package announcements
+
+import (
+	"fmt"
+
+	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
+)
+
+// Banner is an active facility announcement shown at the top of a page.
+type Banner struct {
+	dbgen.FacilityAnnouncement
+	DismissURL string
+}
+
+// NewBanners wraps announcements for display; each banner dismisses itself by
+// POSTing to dismissBase/{id}/dismiss.
+func NewBanners(rows []dbgen.FacilityAnnouncement, dismissBase string) []Banner {
+	banners := make([]Banner, len(rows))
+	for i, row := range rows {
+		banners[i] = Banner{
+			FacilityAnnouncement: row,
+			DismissURL:           fmt.Sprintf("%s/%d/dismiss", dismissBase, row.ID),
+		}
+	}
+	return banners
+}
+
+func (b Banner) ElementID() string {
+	return fmt.Sprintf("announcement-%d", b.ID)
+}
+
+func (b Banner) SeverityClass() string {
+	switch b.Severity {
+	case "critical":
+		return "border-red-200 bg-red-50 text-red-900"
+	case "warning":
+		return "border-yellow-200 bg-yellow-50 text-yellow-900"
+	default:
+		return "border-blue-200 bg-blue-50 text-blue-900"
+	}
+}
+
+// Role lets screen readers announce critical banners immediately.
+func (b Banner) Role() string {
+	if b.Severity == "critical" {
+		return "alert"
+	}
+	return "status"
+}
@@ -0,0 +1,22 @@
+package auth
+
+import "time"
+
+// SessionsData lists where a user is signed in. BasePath is the sessions
+// route for the user's portal, which revoke actions are posted under.
+type SessionsData struct {
+	BasePath string
+	Sessions []SessionItem
+}
+
+// SessionItem is one active sign-in.
+type SessionItem struct {
+	ID         int64
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	// IsCurrent marks the session viewing the list, which cannot be revoked
+	// from it.
+	IsCurrent bool
+}
@@ -1,5 +1,7 @@
 package dashboard
 
+import "time"
+
 type CancellationMetrics struct {
 	Count                 int64
 	TotalReservations     int64
@@ -13,6 +15,15 @@ type BookingTypeCount struct {
 	Count    int64
 }
 
+// NoShowReservation is a finished reservation where nobody checked in.
+type NoShowReservation struct {
+	ReservationID int64
+	TypeName      string
+	MemberName    string
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
 type FacilityOption struct {
 	ID   int64
 	Name string
@@ -30,7 +41,96 @@ type DashboardData struct {
 	BookingsByType       []BookingTypeCount
 	CancellationMetrics  CancellationMetrics
 	CheckinCount         int64
+	NoShowCount          int64
+	NoShows              []NoShowReservation
 	Granularity          string
 	Facilities           []FacilityOption
 	ShowFacilitySelector bool
 }
+
+// TodaySnapshot is the front desk's opening view of one facility's day.
+type TodaySnapshot struct {
+	FacilityID             int64                   `json:"facilityId"`
+	FacilityName           string                  `json:"facilityName"`
+	Date                   string                  `json:"date"`
+	Timezone               string                  `json:"timezone"`
+	ReservationTotal       int64                   `json:"reservationTotal"`
+	ReservationsByType     []TodayTypeCount        `json:"reservationsByType"`
+	OpenPlaySessions       []TodayOpenPlaySession  `json:"openPlaySessions"`
+	LessonsByPro           []TodayProLessons       `json:"lessonsByPro"`
+	PendingWaitlistOffers  []TodayWaitlistOffer    `json:"pendingWaitlistOffers"`
+	CourtsUnderMaintenance []TodayMaintenanceCourt `json:"courtsUnderMaintenance"`
+}
+
+type TodayTypeCount struct {
+	TypeName string `json:"typeName"`
+	Count    int64  `json:"count"`
+}
+
+// TodayOpenPlaySession reports sign-ups against the courts the session holds,
+// or its rule's minimum courts before any have been assigned.
+type TodayOpenPlaySession struct {
+	ID              int64     `json:"id"`
+	RuleName        string    `json:"ruleName"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	Status          string    `json:"status"`
+	Participants    int64     `json:"participants"`
+	MinParticipants int64     `json:"minParticipants"`
+	Capacity        int64     `json:"capacity"`
+	FillRate        float64   `json:"fillRate"`
+}
+
+type TodayProLessons struct {
+	ProID   int64  `json:"proId"`
+	ProName string `json:"proName"`
+	Lessons int64  `json:"lessons"`
+}
+
+type TodayWaitlistOffer struct {
+	ID              int64     `json:"id"`
+	MemberName      string    `json:"memberName"`
+	TargetDate      string    `json:"targetDate"`
+	TargetStartTime string    `json:"targetStartTime"`
+	TargetEndTime   string    `json:"targetEndTime"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+}
+
+// TodayMaintenanceCourt is a court out of service today: Blocks lists its
+// MAINTENANCE reservations, and Status is anything other than active.
+type TodayMaintenanceCourt struct {
+	CourtID     int64            `json:"courtId"`
+	CourtName   string           `json:"courtName"`
+	CourtNumber int64            `json:"courtNumber"`
+	Status      string           `json:"status"`
+	Blocks      []TodayTimeBlock `json:"blocks"`
+}
+
+type TodayTimeBlock struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// AvailabilitySummary is a facility's week of free-court counts per open
+// hour, for the calendar heatmap.
+type AvailabilitySummary struct {
+	FacilityID  int64             `json:"facilityId"`
+	WeekOf      string            `json:"weekOf"`
+	Timezone    string            `json:"timezone"`
+	TotalCourts int64             `json:"totalCourts"`
+	Days        []AvailabilityDay `json:"days"`
+}
+
+// AvailabilityDay lists the hours the facility is open for at least part
+// of; Hours is empty on a closed day.
+type AvailabilityDay struct {
+	Date  string             `json:"date"`
+	Open  bool               `json:"open"`
+	Hours []AvailabilityHour `json:"hours"`
+}
+
+type AvailabilityHour struct {
+	Hour       int       `json:"hour"`
+	StartTime  time.Time `json:"startTime"`
+	FreeCourts int64     `json:"freeCourts"`
+}
@@ -16,6 +16,10 @@ type PortalProfile struct {
 	Email           string
 	MembershipLevel int64
 	HasPhoto        bool
+	// MembershipTierName is the organization's name for the member's tier.
+	MembershipTierName string
+	// MembershipBenefits lists what the tier gets at the home facility.
+	MembershipBenefits []string
 }
 
 func (p PortalProfile) FullName() string {
@@ -46,6 +50,9 @@ func (p PortalProfile) Initials() string {
 }
 
 func (p PortalProfile) MembershipLabel() string {
+	if p.MembershipTierName != "" {
+		return p.MembershipTierName
+	}
 	switch p.MembershipLevel {
 	case 0:
 		return "Unverified Guest"
@@ -58,6 +65,14 @@ func (p PortalProfile) MembershipLabel() string {
 	}
 }
 
+type MemberIDCardData struct {
+	Profile      PortalProfile
+	FacilityName string
+	QRCodeURL    string
+	ValidThrough time.Time
+	Notice       string
+}
+
 type ReservationSummary struct {
 	ID                  int64
 	FacilityID          int64
@@ -71,7 +86,59 @@ type ReservationSummary struct {
 	EndTime             time.Time
 	IsOpenEvent         bool
 	OtherParticipants   []string
+	PrimaryUserID       int64
 	RefundPercentage    int64
+	// RefundDropsAt is the last moment RefundPercentage applies before it
+	// falls to RefundDropsTo; zero when it holds until the start.
+	RefundDropsAt time.Time
+	RefundDropsTo int64
+	CheckedIn     bool
+	// Invitations lists the members invited to join and where each stands;
+	// CanInvite is set when the viewer booked the reservation.
+	Invitations []ReservationInvitationSummary
+	CanInvite   bool
+	// CanReschedule is set on the viewer's own court bookings, which they
+	// can move to another slot.
+	CanReschedule bool
+	// Guests names the non-members on the reservation.
+	Guests []string
+	// BookedFor names the household dependent the reservation is for when
+	// the viewer is their primary account rather than on it themselves.
+	BookedFor string
+	// MemberNote is the note left when booking, shown only to the member it
+	// was booked for or their primary account.
+	MemberNote string
+}
+
+type ReservationInvitationSummary struct {
+	Name   string
+	Status string
+}
+
+// MemberInviteeOption is a member the booking member can invite. Only the
+// name is shown so the picker does not expose contact details.
+type MemberInviteeOption struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// InvitationDeclineData drives the page behind an emailed decline link.
+type InvitationDeclineData struct {
+	Token        string
+	FacilityName string
+	When         string
+	Declined     bool
+	Message      string
+}
+
+// TransferDeclineData drives the page behind an emailed transfer decline
+// link.
+type TransferDeclineData struct {
+	Token        string
+	FacilityName string
+	When         string
+	Declined     bool
+	Message      string
 }
 
 type ReservationFacility struct {
@@ -85,6 +152,18 @@ type ReservationListData struct {
 	Facilities         []ReservationFacility
 	SelectedFacilityID int64
 	ShowFacilityFilter bool
+	CalendarFeedURL    string
+	FromDate           string
+	ToDate             string
+	UpcomingMoreURL    string
+	PastMoreURL        string
+}
+
+// ReservationPage is one "load more" page of the upcoming or past list.
+type ReservationPage struct {
+	Section      string
+	Reservations []ReservationSummary
+	MoreURL      string
 }
 
 type ReservationWidgetData struct {
@@ -101,29 +180,277 @@ type OpenPlaySessionSummary struct {
 	ParticipantCount int64
 	MinParticipants  int64
 	IsSignedUp       bool
+	// EligibilityLabel describes the rule's membership restriction, if any;
+	// Ineligible is set when the viewing member falls outside it.
+	EligibilityLabel string
+	Ineligible       bool
+	// SkillBand names the member's skill band and its courts when the
+	// session is split by skill; IneligibleReason overrides the default
+	// message when the member cannot join.
+	SkillBand        string
+	IneligibleReason string
+}
+
+func (s OpenPlaySessionSummary) IneligibleMessage() string {
+	if s.IneligibleReason != "" {
+		return s.IneligibleReason
+	}
+	return "Not available for your membership level"
 }
 
 type OpenPlayListData struct {
 	Upcoming []OpenPlaySessionSummary
 }
 
+type OpenPlaySessionDetailData struct {
+	Session            OpenPlaySessionSummary
+	Courts             string
+	CancellationCutoff time.Time
+	Capacity           int64
+	// Participants holds first names of members who share them; HiddenCount
+	// counts the rest.
+	Participants    []string
+	HiddenCount     int
+	HideFromRosters bool
+	// QueuePosition is the member's place in the rotation queue once they
+	// have checked in, 1 being up next; 0 when they are not in the queue.
+	QueuePosition int
+}
+
+func (d OpenPlaySessionDetailData) CapacityLabel() string {
+	if d.Capacity <= 0 {
+		return fmt.Sprintf("%d signed up", d.Session.ParticipantCount)
+	}
+	return fmt.Sprintf("%d of %d spots filled", d.Session.ParticipantCount, d.Capacity)
+}
+
+func (d OpenPlaySessionDetailData) QueueLabel() string {
+	switch d.QueuePosition {
+	case 0:
+		return ""
+	case 1:
+		return "You're up next"
+	default:
+		return fmt.Sprintf("#%d in line", d.QueuePosition)
+	}
+}
+
+// RefreshTrigger polls while the member is in the queue so their position
+// stays current between games.
+func (d OpenPlaySessionDetailData) RefreshTrigger() string {
+	if d.QueuePosition > 0 {
+		return "refreshMemberOpenPlay from:body, every 30s"
+	}
+	return "refreshMemberOpenPlay from:body"
+}
+
+func (d OpenPlaySessionDetailData) HiddenLabel() string {
+	switch d.HiddenCount {
+	case 0:
+		return ""
+	case 1:
+		return "1 player prefers not to be listed"
+	default:
+		return fmt.Sprintf("%d players prefer not to be listed", d.HiddenCount)
+	}
+}
+
 type CancellationPenaltyData struct {
-	ReservationID    int64     `json:"reservation_id"`
-	FeePercentage    int64     `json:"fee_percentage"`
+	ReservationID     int64     `json:"reservation_id"`
+	FeePercentage     int64     `json:"fee_percentage"`
+	RefundPercentage  int64     `json:"refund_percentage"`
+	RefundAmountCents *int64    `json:"refund_amount_cents,omitempty"`
+	HoursBeforeStart  int64     `json:"hours_before_start"`
+	StartTime         time.Time `json:"start_time"`
+	EndTime           time.Time `json:"end_time"`
+	CourtName         string    `json:"court_name"`
+	FacilityName      string    `json:"facility_name"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	CalculatedAt      time.Time `json:"penalty_calculated_at"`
+}
+
+// RefundAmountLabel reads like "$12.50", or is empty when the refund amount
+// is unknown.
+func (d CancellationPenaltyData) RefundAmountLabel() string {
+	if d.RefundAmountCents == nil {
+		return ""
+	}
+	return fmt.Sprintf("$%.2f", float64(*d.RefundAmountCents)/100)
+}
+
+// CancellationPolicyTier is one band of the refund schedule. MaxHoursBefore
+// is nil for the open-ended earliest band.
+type CancellationPolicyTier struct {
+	MinHoursBefore   int64     `json:"min_hours_before"`
+	MaxHoursBefore   *int64    `json:"max_hours_before,omitempty"`
 	RefundPercentage int64     `json:"refund_percentage"`
-	HoursBeforeStart int64     `json:"hours_before_start"`
-	StartTime        time.Time `json:"start_time"`
-	EndTime          time.Time `json:"end_time"`
-	CourtName        string    `json:"court_name"`
-	FacilityName     string    `json:"facility_name"`
-	ExpiresAt        time.Time `json:"expires_at"`
-	CalculatedAt     time.Time `json:"penalty_calculated_at"`
+	CancelBy         time.Time `json:"cancel_by"`
+	Current          bool      `json:"current"`
+}
+
+type CancellationPolicyPreviewData struct {
+	FacilityID       int64                    `json:"facility_id"`
+	ReservationType  string                   `json:"reservation_type"`
+	StartTime        time.Time                `json:"start_time"`
+	HasPolicy        bool                     `json:"has_policy"`
+	Summary          string                   `json:"summary"`
+	RefundPercentage int64                    `json:"refund_percentage"`
+	Tiers            []CancellationPolicyTier `json:"tiers"`
+}
+
+// RestrictionData explains whether a member is blocked from booking at their
+// home facility because of no-shows.
+type RestrictionData struct {
+	FacilityID      int64      `json:"facility_id"`
+	Restricted      bool       `json:"restricted"`
+	Until           *time.Time `json:"until,omitempty"`
+	NoShowCount     int64      `json:"no_show_count"`
+	MaxNoShows      int64      `json:"max_no_shows"`
+	RestrictionDays int64      `json:"restriction_days"`
+	Message         string     `json:"message"`
+}
+
+// GuestPassesData is the member's guest pass balance at their home facility.
+type GuestPassesData struct {
+	FacilityID              int64 `json:"facility_id"`
+	Balance                 int64 `json:"balance"`
+	MaxGuestsPerReservation int64 `json:"max_guests_per_reservation"`
+}
+
+// NotificationPreferencesData lists which email kinds the member receives.
+type NotificationPreferencesData struct {
+	Confirmations     bool `json:"confirmations"`
+	Cancellations     bool `json:"cancellations"`
+	WaitlistOffers    bool `json:"waitlist_offers"`
+	OpenPlayReminders bool `json:"open_play_reminders"`
+	// SMS also texts waitlist offers and reminders to the member's phone.
+	SMS bool `json:"sms"`
+}
+
+// AccountDeletionData is the member's own request to delete their account.
+// A zero RequestedAt means nothing has been requested yet.
+type AccountDeletionData struct {
+	RequestedAt time.Time `json:"requestedAt"`
+}
+
+func (t CancellationPolicyTier) RangeLabel() string {
+	switch {
+	case t.MaxHoursBefore == nil && t.MinHoursBefore == 0:
+		return "Any time before start"
+	case t.MaxHoursBefore == nil:
+		return fmt.Sprintf("More than %s before", hoursLabel(t.MinHoursBefore))
+	case t.MinHoursBefore == 0:
+		return fmt.Sprintf("Less than %s before", hoursLabel(*t.MaxHoursBefore))
+	default:
+		return fmt.Sprintf("%d–%d hours before", t.MinHoursBefore, *t.MaxHoursBefore)
+	}
+}
+
+func hoursLabel(hours int64) string {
+	if hours == 1 {
+		return "1 hour"
+	}
+	return fmt.Sprintf("%d hours", hours)
 }
 
 type MemberBookingSlot struct {
-	StartTime time.Time
-	EndTime   time.Time
-	Label     string
+	StartTime       time.Time
+	EndTime         time.Time
+	Label           string
+	AvailableCourts int
+	TotalCourts     int
+	Closures        []MemberCourtClosure
+	// Locked marks a prime-time slot held for higher membership levels
+	// until UnlocksAt.
+	Locked    bool
+	UnlocksAt time.Time
+	// PriceCents is one court for the slot, when the facility prices it.
+	PriceCents *int64
+	// FreeCourtIDs lists the courts free for the whole slot; the court
+	// select narrows to them once the slot is chosen.
+	FreeCourtIDs []int64
+}
+
+// MemberCourtClosure groups courts blocked for maintenance with the same
+// member-facing reason. Reason is the block's public reason only.
+type MemberCourtClosure struct {
+	CourtNumbers []int64
+	Reason       string
+	Until        time.Time
+}
+
+func (s MemberBookingSlot) AvailabilitySummary() string {
+	if s.TotalCourts == 0 {
+		return ""
+	}
+	summary := fmt.Sprintf("%d of %d courts available", s.AvailableCourts, s.TotalCourts)
+	if len(s.Closures) == 0 {
+		return summary
+	}
+	closures := make([]string, 0, len(s.Closures))
+	for _, closure := range s.Closures {
+		closures = append(closures, closure.Summary(s.StartTime))
+	}
+	return fmt.Sprintf("%s (%s)", summary, strings.Join(closures, "; "))
+}
+
+// PriceSummary reads like "$35.00 per court", or is empty when unpriced.
+func (s MemberBookingSlot) PriceSummary() string {
+	if s.PriceCents == nil {
+		return ""
+	}
+	return fmt.Sprintf("$%.2f per court", float64(*s.PriceCents)/100)
+}
+
+// LockSummary reads like "Prime time · opens to you Sun 6:00 PM".
+func (s MemberBookingSlot) LockSummary() string {
+	if !s.Locked {
+		return ""
+	}
+	return "Prime time · opens to you " + s.UnlocksAt.Format("Mon 3:04 PM")
+}
+
+// Summary reads like "Courts 1–2 closed for resurfacing until noon".
+func (c MemberCourtClosure) Summary(slotStart time.Time) string {
+	noun := "Court"
+	if len(c.CourtNumbers) > 1 {
+		noun = "Courts"
+	}
+	reason := strings.TrimSpace(c.Reason)
+	if reason == "" {
+		reason = "maintenance"
+	}
+	return fmt.Sprintf("%s %s closed for %s until %s", noun, formatCourtNumberRanges(c.CourtNumbers), reason, formatClosureUntil(c.Until, slotStart))
+}
+
+func formatCourtNumberRanges(numbers []int64) string {
+	parts := make([]string, 0, len(numbers))
+	for i := 0; i < len(numbers); {
+		j := i
+		for j+1 < len(numbers) && numbers[j+1] == numbers[j]+1 {
+			j++
+		}
+		if j == i {
+			parts = append(parts, fmt.Sprintf("%d", numbers[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d–%d", numbers[i], numbers[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatClosureUntil(until, slotStart time.Time) string {
+	until = until.In(slotStart.Location())
+	y1, m1, d1 := until.Date()
+	y2, m2, d2 := slotStart.Date()
+	if y1 != y2 || m1 != m2 || d1 != d2 {
+		return until.Format("Jan 2, 3:04 PM")
+	}
+	if until.Hour() == 12 && until.Minute() == 0 {
+		return "noon"
+	}
+	return until.Format("3:04 PM")
 }
 
 type LessonProCard struct {
@@ -176,12 +503,144 @@ func (d DatePickerData) DayOptions() []int {
 type MemberBookingFormData struct {
 	FacilityID            int64
 	Courts                []reservations.CourtOption
+	MaxCourts             int64
 	AvailableSlots        []MemberBookingSlot
 	DatePicker            DatePickerData
 	MaxAdvanceBookingDays int64
 	WaitlistStartTime     time.Time
 	WaitlistEndTime       time.Time
 	VisitPacks            []MemberVisitPackOption
+	// ReservationTypes lists the types members may book courts as, the
+	// default first. The form offers a choice only when there is more than
+	// one.
+	ReservationTypes []MemberReservationTypeOption
+	// IdempotencyKey is submitted with the booking so a double-submit books
+	// once. The form replaces it after each successful booking.
+	IdempotencyKey string
+	CourtFilter    MemberCourtFilterData
+	// MaxGuests is how many guests the member may bring; zero hides the
+	// guest fields.
+	MaxGuests int64
+	// BookingFor lists the member and then their household dependents. The
+	// form offers a choice only when there is more than one.
+	BookingFor []MemberBookingForOption
+	// SelectedStart is the slot the member last chose. A zero or stale value
+	// falls back to the first bookable slot.
+	SelectedStart time.Time
+}
+
+type MemberBookingForOption struct {
+	UserID int64
+	Name   string
+}
+
+// MemberCourtFilterData holds the court attribute filters the booking form
+// offers and the member's current choices. An attribute is only offered when
+// the facility's courts differ on it.
+type MemberCourtFilterData struct {
+	Indoor       string
+	Surface      string
+	Lights       string
+	OffersIndoor bool
+	OffersLights bool
+	Surfaces     []MemberCourtSurfaceOption
+}
+
+type MemberCourtSurfaceOption struct {
+	Value string
+	Label string
+}
+
+// Visible reports whether any filter is worth offering.
+func (d MemberCourtFilterData) Visible() bool {
+	return d.OffersIndoor || d.OffersLights || len(d.Surfaces) > 1
+}
+
+// AllowsMultipleCourts reports whether the member may select more than one
+// court for a single booking.
+func (d MemberBookingFormData) AllowsMultipleCourts() bool {
+	return d.MaxCourts > 1 && len(d.SlotCourts()) > 1
+}
+
+// SelectedSlot returns the slot starting at SelectedStart when it is
+// bookable, otherwise the first bookable slot.
+func (d MemberBookingFormData) SelectedSlot() (MemberBookingSlot, bool) {
+	var first *MemberBookingSlot
+	for i, slot := range d.AvailableSlots {
+		if slot.Locked {
+			continue
+		}
+		if slot.StartTime.Equal(d.SelectedStart) {
+			return slot, true
+		}
+		if first == nil {
+			first = &d.AvailableSlots[i]
+		}
+	}
+	if first == nil {
+		return MemberBookingSlot{}, false
+	}
+	return *first, true
+}
+
+// SlotCourts narrows Courts to those free in the selected slot, keeping
+// their order. Without a bookable slot it returns Courts unchanged.
+func (d MemberBookingFormData) SlotCourts() []reservations.CourtOption {
+	slot, ok := d.SelectedSlot()
+	if !ok {
+		return d.Courts
+	}
+	free := make(map[int64]bool, len(slot.FreeCourtIDs))
+	for _, id := range slot.FreeCourtIDs {
+		free[id] = true
+	}
+	courts := make([]reservations.CourtOption, 0, len(slot.FreeCourtIDs))
+	for _, court := range d.Courts {
+		if free[court.ID] {
+			courts = append(courts, court)
+		}
+	}
+	return courts
+}
+
+// HouseholdData is the member's household: the dependents they book for,
+// or the primary account that books for them, and link requests still
+// waiting on an adult's consent.
+type HouseholdData struct {
+	Primary    *HouseholdMember  `json:"primary"`
+	Dependents []HouseholdMember `json:"dependents"`
+	Pending    []HouseholdMember `json:"pending"`
+	// CanAddDependents is false for members who are themselves dependents.
+	CanAddDependents bool `json:"canAddDependents"`
+}
+
+// HouseholdMember is the other side of one household link.
+type HouseholdMember struct {
+	LinkID int64  `json:"linkId"`
+	UserID int64  `json:"userId"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Incoming marks a pending request sent to the viewer rather than by them.
+	Incoming  bool      `json:"incoming"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// HouseholdDeclineData drives the page behind an emailed household link
+// decline link.
+type HouseholdDeclineData struct {
+	Token       string
+	PrimaryName string
+	Declined    bool
+	Message     string
+}
+
+type MemberReservationTypeOption struct {
+	Name  string
+	Label string
+	// MinDurationMinutes and MaxDurationMinutes are the type's own length
+	// bounds, 0 when it sets none.
+	MinDurationMinutes int64
+	MaxDurationMinutes int64
 }
 
 type MemberVisitPackOption struct {
@@ -226,6 +685,7 @@ func NewReservationSummaries(rows []dbgen.ListReservationsByUserIDRow) []Reserva
 			StartTime:           row.StartTime,
 			EndTime:             row.EndTime,
 			IsOpenEvent:         row.IsOpenEvent,
+			PrimaryUserID:       row.PrimaryUserID.Int64,
 		}
 	}
 	return summaries
@@ -289,6 +749,32 @@ func (r ReservationSummary) OtherParticipantsLabel() string {
 	return strings.Join(r.OtherParticipants, ", ")
 }
 
+func (r ReservationSummary) GuestsLabel() string {
+	return strings.Join(r.Guests, ", ")
+}
+
+func (r ReservationSummary) InvitationsLabel() string {
+	labels := make([]string, 0, len(r.Invitations))
+	for _, invitation := range r.Invitations {
+		labels = append(labels, fmt.Sprintf("%s (%s)", invitation.Name, invitation.Status))
+	}
+	return strings.Join(labels, ", ")
+}
+
+// RefundDeadlineLabel says how long the current refund lasts, e.g. "Free
+// cancellation until Thu Oct 22 6pm". It is empty when the refund holds until
+// the start.
+func (r ReservationSummary) RefundDeadlineLabel() string {
+	if r.RefundDropsAt.IsZero() {
+		return ""
+	}
+	until := strings.Replace(r.RefundDropsAt.Format("Mon Jan 2 3:04pm"), ":00", "", 1)
+	if r.RefundPercentage == 100 {
+		return "Free cancellation until " + until
+	}
+	return fmt.Sprintf("%d%% refund until %s", r.RefundPercentage, until)
+}
+
 func (r ReservationSummary) IsProSession() bool {
 	return strings.EqualFold(r.ReservationTypeName, "PRO_SESSION")
 }
@@ -307,3 +793,143 @@ func defaultLessonEndTimeValue(slots []LessonSlotOption) string {
 	}
 	return slots[0].EndTime
 }
+
+type SeasonPassUsageSummary struct {
+	ID              int64
+	Name            string
+	ValidFrom       string
+	ValidUntil      string
+	Status          string
+	CoveredBookings int64
+}
+
+type SeasonPassOffer struct {
+	ID         int64
+	Name       string
+	PriceCents int64
+	ValidFrom  string
+	ValidUntil string
+	Windows    []string
+}
+
+type SeasonPassListData struct {
+	Passes []SeasonPassUsageSummary
+	Offers []SeasonPassOffer
+}
+
+type LeagueTeamSummary struct {
+	LeagueID     int64
+	LeagueName   string
+	LeagueStatus string
+	TeamID       int64
+	TeamName     string
+	IsCaptain    bool
+	// OutstandingCents is the registration fee the team still owes. It is
+	// only filled in for captains.
+	OutstandingCents int64
+	FeeBasis         string
+}
+
+type LeagueTeamListData struct {
+	Teams []LeagueTeamSummary
+}
+
+// FacilityInfoData is a facility's contact details, the coming week's hours,
+// its courts and current announcements as a member sees them. Times are in
+// the facility's timezone.
+type FacilityInfoData struct {
+	FacilityID int64  `json:"facility_id"`
+	Name       string `json:"name"`
+	Address    string `json:"address,omitempty"`
+	Phone      string `json:"phone,omitempty"`
+	Timezone   string `json:"timezone"`
+	IsHome     bool   `json:"is_home"`
+	// OpenNow and Status describe the facility at the time of the request,
+	// e.g. "Open now · closes at 9:00 PM".
+	OpenNow       bool                       `json:"open_now"`
+	Status        string                     `json:"status"`
+	Hours         []FacilityInfoDay          `json:"hours"`
+	CourtCount    int                        `json:"court_count"`
+	Courts        []FacilityInfoCourt        `json:"courts"`
+	Announcements []FacilityInfoAnnouncement `json:"announcements"`
+	// Facilities lists the organization's facilities the member can switch
+	// to. It is only used by the page.
+	Facilities []FacilityInfoOption `json:"-"`
+}
+
+// FacilityInfoDay is one day's hours. Note carries the reason for a holiday
+// or other override of the weekly schedule.
+type FacilityInfoDay struct {
+	Date     string     `json:"date"`
+	Weekday  string     `json:"weekday"`
+	Today    bool       `json:"today"`
+	Open     bool       `json:"open"`
+	OpensAt  *time.Time `json:"opens_at,omitempty"`
+	ClosesAt *time.Time `json:"closes_at,omitempty"`
+	Override bool       `json:"override"`
+	Note     string     `json:"note,omitempty"`
+}
+
+// HoursLabel formats the day's hours for display.
+func (d FacilityInfoDay) HoursLabel() string {
+	if !d.Open || d.OpensAt == nil || d.ClosesAt == nil {
+		return "Closed"
+	}
+	return fmt.Sprintf("%s - %s", d.OpensAt.Format("3:04 PM"), d.ClosesAt.Format("3:04 PM"))
+}
+
+type FacilityInfoCourt struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	CourtNumber int64  `json:"court_number"`
+	IsIndoor    bool   `json:"is_indoor"`
+	Surface     string `json:"surface,omitempty"`
+	HasLights   bool   `json:"has_lights"`
+	// Attributes is the display summary, e.g. "Indoor, Sport tile, Lights".
+	Attributes string `json:"attributes"`
+}
+
+type FacilityInfoAnnouncement struct {
+	ID       int64     `json:"id"`
+	Title    string    `json:"title"`
+	Body     string    `json:"body"`
+	Severity string    `json:"severity"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+type FacilityInfoOption struct {
+	ID   int64
+	Name string
+}
+
+// OpenEventListData lists upcoming open events at the member's facility.
+type OpenEventListData struct {
+	Upcoming []OpenEventSummary `json:"events"`
+}
+
+// OpenEventSummary is an open event with its signups. Capacity is
+// teams per court × people per team × courts; it is zero when staff left
+// the team sizes unset, and such events take any number of signups.
+type OpenEventSummary struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	TeamsPerCourt *int64    `json:"teams_per_court,omitempty"`
+	PeoplePerTeam *int64    `json:"people_per_team,omitempty"`
+	CourtCount    int64     `json:"court_count"`
+	Capacity      int64     `json:"capacity"`
+	SignupCount   int64     `json:"signup_count"`
+	IsSignedUp    bool      `json:"is_signed_up"`
+}
+
+func (e OpenEventSummary) IsFull() bool {
+	return e.Capacity > 0 && e.SignupCount >= e.Capacity
+}
+
+func (e OpenEventSummary) CapacityLabel() string {
+	if e.Capacity <= 0 {
+		return fmt.Sprintf("%d signed up", e.SignupCount)
+	}
+	return fmt.Sprintf("%d of %d spots filled", e.SignupCount, e.Capacity)
+}
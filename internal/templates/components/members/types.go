@@ -1,14 +1,19 @@
 package members
 
 import (
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 )
 
 type Member struct {
 	dbgen.ListMembersRow
+	// SkillRating is the member's open play skill rating, when staff have
+	// set one.
+	SkillRating sql.NullFloat64
 }
 
 // NewMember creates a Member from ListMembersRow
@@ -37,6 +42,19 @@ func (m Member) PhotoUrl() string {
 	return ""
 }
 
+// DeletionRequested reports a member who asked for their account to be
+// deleted and is waiting on staff review.
+func (m Member) DeletionRequested() bool {
+	return m.DeletionRequestedAt.Valid
+}
+
+func (m Member) SkillRatingStr() string {
+	if !m.SkillRating.Valid {
+		return ""
+	}
+	return fmt.Sprintf("%.1f", m.SkillRating.Float64)
+}
+
 func (m Member) EmailStr() string {
 	return m.Email.String
 }
@@ -106,3 +124,133 @@ func (v VisitHistory) ActivityLabel() string {
 		return activity
 	}
 }
+
+// BillingSummary is a member's financial standing at their home facility for
+// the front desk. Times are in the facility's timezone.
+type BillingSummary struct {
+	MemberID             int64                  `json:"memberId"`
+	MemberName           string                 `json:"memberName"`
+	FacilityID           int64                  `json:"facilityId"`
+	FacilityName         string                 `json:"facilityName"`
+	GeneratedAt          time.Time              `json:"generatedAt"`
+	VisitPacks           []BillingVisitPack     `json:"visitPacks"`
+	LessonPackages       []BillingLessonPackage `json:"lessonPackages"`
+	AccountCredit        BillingAccountCredit   `json:"accountCredit"`
+	UnpaidLeagueFees     []BillingLeagueFee     `json:"unpaidLeagueFees"`
+	UpcomingReservations []BillingReservation   `json:"upcomingReservations"`
+	Totals               BillingTotals          `json:"totals"`
+}
+
+type BillingVisitPack struct {
+	ID              int64     `json:"id"`
+	Name            string    `json:"name"`
+	VisitsRemaining int64     `json:"visitsRemaining"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+}
+
+type BillingLessonPackage struct {
+	ID               int64     `json:"id"`
+	Name             string    `json:"name"`
+	LessonsRemaining int64     `json:"lessonsRemaining"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+}
+
+// BillingAccountCredit lists the credit a booking could still spend.
+type BillingAccountCredit struct {
+	BalanceCents int64           `json:"balanceCents"`
+	Credits      []BillingCredit `json:"credits"`
+}
+
+type BillingCredit struct {
+	ID             int64      `json:"id"`
+	RemainingCents int64      `json:"remainingCents"`
+	Reason         string     `json:"reason"`
+	ExpiresAt      *time.Time `json:"expiresAt"`
+}
+
+// BillingLeagueFee is what the member still owes a league: the whole team
+// fee for a captain under per-team fees, their own share under per-player.
+type BillingLeagueFee struct {
+	LeagueID         int64  `json:"leagueId"`
+	LeagueName       string `json:"leagueName"`
+	TeamID           int64  `json:"teamId"`
+	TeamName         string `json:"teamName"`
+	FeeBasis         string `json:"feeBasis"`
+	OutstandingCents int64  `json:"outstandingCents"`
+}
+
+// Price sources for a BillingReservation.
+const (
+	BillingPriceStored   = "stored"
+	BillingPriceQuoted   = "quoted"
+	BillingPriceUnpriced = "unpriced"
+)
+
+// BillingReservation is an upcoming booking with its price: the one stored
+// when it was booked, or else a quote under the current pricing rules.
+// PriceCents and AmountDueCents are nil when neither applies.
+type BillingReservation struct {
+	ID                 int64     `json:"id"`
+	StartTime          time.Time `json:"startTime"`
+	EndTime            time.Time `json:"endTime"`
+	ReservationType    string    `json:"reservationType"`
+	CourtCount         int64     `json:"courtCount"`
+	PriceSource        string    `json:"priceSource"`
+	PriceCents         *int64    `json:"priceCents"`
+	MemberRate         bool      `json:"memberRate"`
+	CreditAppliedCents int64     `json:"creditAppliedCents"`
+	AmountDueCents     *int64    `json:"amountDueCents"`
+}
+
+type BillingTotals struct {
+	VisitsRemaining            int64 `json:"visitsRemaining"`
+	LessonsRemaining           int64 `json:"lessonsRemaining"`
+	CreditBalanceCents         int64 `json:"creditBalanceCents"`
+	LeagueFeesOutstandingCents int64 `json:"leagueFeesOutstandingCents"`
+	ReservationsDueCents       int64 `json:"reservationsDueCents"`
+}
+
+func (s BillingSummary) GeneratedAtLabel() string {
+	return s.GeneratedAt.Format("Jan 2, 2006 3:04 PM MST")
+}
+
+func (c BillingCredit) ExpiresLabel() string {
+	if c.ExpiresAt == nil {
+		return "No expiry"
+	}
+	return "Expires " + c.ExpiresAt.Format("Jan 2, 2006")
+}
+
+func (r BillingReservation) TimeLabel() string {
+	return r.StartTime.Format("Mon Jan 2, 2006 3:04 PM") + " - " + r.EndTime.Format("3:04 PM")
+}
+
+func (r BillingReservation) CourtsLabel() string {
+	if r.CourtCount == 1 {
+		return "1 court"
+	}
+	return fmt.Sprintf("%d courts", r.CourtCount)
+}
+
+func (r BillingReservation) PriceLabel() string {
+	if r.PriceCents == nil {
+		return "Not priced"
+	}
+	label := BillingCentsLabel(*r.PriceCents)
+	if r.PriceSource == BillingPriceQuoted {
+		label += " (quoted)"
+	}
+	return label
+}
+
+func (r BillingReservation) AmountDueLabel() string {
+	if r.AmountDueCents == nil {
+		return "-"
+	}
+	return BillingCentsLabel(*r.AmountDueCents)
+}
+
+// BillingCentsLabel formats cents as dollars.
+func BillingCentsLabel(cents int64) string {
+	return fmt.Sprintf("$%.2f", float64(cents)/100)
+}
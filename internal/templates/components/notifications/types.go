@@ -32,6 +32,10 @@ func (n Notification) TypeLabel() string {
 		return "Cancelled"
 	case "lesson_cancelled":
 		return "Lesson Cancelled"
+	case "lesson_reassigned":
+		return "Lesson Reassigned"
+	case "waitlist_fulfilled":
+		return "Waitlist Booked"
 	default:
 		return n.NotificationType
 	}
@@ -47,11 +51,21 @@ func (n Notification) BadgeClass() string {
 		return "bg-red-100 text-red-800"
 	case "lesson_cancelled":
 		return "bg-orange-100 text-orange-800"
+	case "lesson_reassigned":
+		return "bg-purple-100 text-purple-800"
+	case "waitlist_fulfilled":
+		return "bg-green-100 text-green-800"
 	default:
 		return "bg-muted text-muted-foreground"
 	}
 }
 
+// HasDetail reports whether the notification opens the lesson detail page
+// rather than being marked read in place.
+func (n Notification) HasDetail() bool {
+	return n.NotificationType == "lesson_cancelled" || n.NotificationType == "lesson_reassigned"
+}
+
 func (n Notification) Timestamp() string {
 	return n.CreatedAt.Format("2006-01-02 15:04")
 }
@@ -3,6 +3,7 @@ package openplay
 import (
 	"strings"
 
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 )
 
@@ -108,3 +109,27 @@ func (r OpenPlayRule) MaxCourtsValue() int64 {
 	}
 	return r.MaxCourts
 }
+
+type MembershipLevelOption struct {
+	Value int64
+	Label string
+}
+
+func MembershipLevelOptions() []MembershipLevelOption {
+	options := make([]MembershipLevelOption, 0, apiutil.MaxMembershipLevel+1)
+	for level := int64(0); level <= apiutil.MaxMembershipLevel; level++ {
+		options = append(options, MembershipLevelOption{Value: level, Label: apiutil.MembershipLevelName(level)})
+	}
+	return options
+}
+
+func (r OpenPlayRule) HasMaxMembershipLevel(level int64) bool {
+	return r.MaxMembershipLevel.Valid && r.MaxMembershipLevel.Int64 == level
+}
+
+func (r OpenPlayRule) EligibilityLabel() string {
+	if label := apiutil.OpenPlayEligibilityLabel(r.MinMembershipLevel, r.MaxMembershipLevel); label != "" {
+		return label
+	}
+	return "All levels"
+}
@@ -8,7 +8,13 @@ type DayHours struct {
 }
 
 type BookingConfigData struct {
-	FacilityID            int64
-	MaxAdvanceBookingDays int64
-	MaxMemberReservations int64
+	FacilityID                int64
+	MaxAdvanceBookingDays     int64
+	MaxMemberReservations     int64
+	MaxCourtsPerMemberBooking int64
+	SlotDurationMinutes       int64
+	MinBookingMinutes         int64
+	MaxGuestsPerReservation   int64
+	ReservationLimitScope     string
+	BufferMinutes             int64
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/codr1/Pickleicious/internal/api/apiutil"
 	dbgen "github.com/codr1/Pickleicious/internal/db/generated"
 )
 
@@ -21,8 +22,28 @@ type BookingFormData struct {
 	IsOpenEvent               bool
 	TeamsPerCourt             *int64
 	PeoplePerTeam             *int64
-	IsEdit                    bool
-	ReservationID             int64
+	PublicReason              string
+	InternalNotes             string
+	// MemberNote is the note the member left when booking. It is entered on
+	// new bookings and shown read-only when editing.
+	MemberNote    string
+	IsEdit        bool
+	ReservationID int64
+	// IdempotencyKey is submitted with a new booking so a double-submit
+	// books once.
+	IdempotencyKey string
+}
+
+// ReservationCommentsData is the staff-only comment thread on a reservation.
+type ReservationCommentsData struct {
+	ReservationID int64
+	Comments      []ReservationCommentItem
+}
+
+type ReservationCommentItem struct {
+	AuthorName string
+	Body       string
+	CreatedAt  string
 }
 
 type EventBookingFormData struct {
@@ -41,21 +62,45 @@ type EventBookingFormData struct {
 	PeoplePerTeam             *int64
 	IsEdit                    bool
 	ReservationID             int64
+	// IdempotencyKey is submitted with a new booking so a double-submit
+	// books once.
+	IdempotencyKey string
 }
 
 type CourtOption struct {
 	ID    int64
 	Label string
+	// Attributes summarizes the court's indoor, surface and lighting
+	// attributes, e.g. "Indoor, Sport tile, Lights".
+	Attributes string
+	IsIndoor   bool
+	Surface    string
+	HasLights  bool
+}
+
+// LabelWithAttributes appends the court's attributes to its label.
+func (o CourtOption) LabelWithAttributes() string {
+	if o.Attributes == "" {
+		return o.Label
+	}
+	return fmt.Sprintf("%s · %s", o.Label, o.Attributes)
 }
 
 type ReservationTypeOption struct {
-	ID   int64
-	Name string
+	ID                     int64
+	Name                   string
+	DefaultDurationMinutes int64
+	// MinDurationMinutes and MaxDurationMinutes are the type's own length
+	// bounds, 0 when it sets none.
+	MinDurationMinutes int64
+	MaxDurationMinutes int64
 }
 
 type MemberOption struct {
 	ID    int64
 	Label string
+	// Rating is the member's effective skill rating, when they have one.
+	Rating *float64
 }
 
 type FacilityOption struct {
@@ -102,7 +147,14 @@ func NewCourtOptions(rows []dbgen.Court) []CourtOption {
 		} else {
 			label = fmt.Sprintf("%s (Court %d)", label, court.CourtNumber)
 		}
-		options = append(options, CourtOption{ID: court.ID, Label: label})
+		options = append(options, CourtOption{
+			ID:         court.ID,
+			Label:      label,
+			Attributes: apiutil.CourtAttributesLabel(court.IsIndoor, court.Surface, court.HasLights),
+			IsIndoor:   court.IsIndoor,
+			Surface:    court.Surface.String,
+			HasLights:  court.HasLights,
+		})
 	}
 	return options
 }
@@ -110,7 +162,13 @@ func NewCourtOptions(rows []dbgen.Court) []CourtOption {
 func NewReservationTypeOptions(rows []dbgen.ReservationType) []ReservationTypeOption {
 	options := make([]ReservationTypeOption, 0, len(rows))
 	for _, resType := range rows {
-		options = append(options, ReservationTypeOption{ID: resType.ID, Name: resType.Name})
+		options = append(options, ReservationTypeOption{
+			ID:                     resType.ID,
+			Name:                   resType.Name,
+			DefaultDurationMinutes: resType.DefaultDurationMinutes,
+			MinDurationMinutes:     resType.MinDurationMinutes.Int64,
+			MaxDurationMinutes:     resType.MaxDurationMinutes.Int64,
+		})
 	}
 	return options
 }
@@ -127,6 +185,27 @@ func NewMemberOptions(rows []dbgen.ListMembersRow) []MemberOption {
 	return options
 }
 
+func NewMemberSearchOptions(rows []dbgen.SearchFacilityMembersRow) []MemberOption {
+	options := make([]MemberOption, 0, len(rows))
+	for _, member := range rows {
+		label := strings.TrimSpace(strings.Join([]string{member.FirstName, member.LastName}, " "))
+		switch {
+		case member.Email.Valid:
+			label = fmt.Sprintf("%s - %s", label, member.Email.String)
+		case member.Phone.Valid:
+			label = fmt.Sprintf("%s - %s", label, member.Phone.String)
+		}
+		option := MemberOption{ID: member.ID, Label: label}
+		if member.Rating.Valid {
+			rating := member.Rating.Float64
+			option.Rating = &rating
+			option.Label = fmt.Sprintf("%s (%.1f)", label, rating)
+		}
+		options = append(options, option)
+	}
+	return options
+}
+
 func NewFacilityOptions(rows []dbgen.Facility) []FacilityOption {
 	options := make([]FacilityOption, 0, len(rows))
 	for _, facility := range rows {
@@ -25,12 +25,22 @@ type NotificationDetailData struct {
 	CourtLabel   string
 }
 
+// Title heads the detail page for the lesson notification types.
+func (d NotificationDetailData) Title() string {
+	if d.Notification.NotificationType == "lesson_reassigned" {
+		return "Lesson reassigned to you"
+	}
+	return "Lesson cancelled"
+}
+
 type WaitlistConfigFormData struct {
 	FacilityID                int64
 	MaxWaitlistSize           int64
 	NotificationMode          string
 	OfferExpiryMinutes        int64
 	NotificationWindowMinutes int64
+	// ExpiredOfferAction is "remove" or "requeue".
+	ExpiredOfferAction string
 }
 
 const defaultWaitlistOfferExpiryMinutes int64 = 30
@@ -0,0 +1,139 @@
+package tournaments
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// BracketData is a tournament and its bracket, served as JSON by
+// GET /api/v1/tournaments/{id}/bracket and rendered by TournamentBracket.
+type BracketData struct {
+	Tournament Tournament `json:"tournament"`
+	Entrants   []Team     `json:"entrants"`
+	Brackets   []Bracket  `json:"brackets"`
+}
+
+type Tournament struct {
+	ID           int64  `json:"id"`
+	FacilityID   int64  `json:"facilityId"`
+	LeagueID     int64  `json:"leagueId"`
+	Name         string `json:"name"`
+	Format       string `json:"format"`
+	Seeding      string `json:"seeding"`
+	Status       string `json:"status"`
+	ChampionTeam *Team  `json:"championTeam"`
+}
+
+// Team is a seeded league team.
+type Team struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Seed int64  `json:"seed"`
+}
+
+// Bracket is the winners or consolation bracket, or the double elimination
+// final, with its rounds in order.
+type Bracket struct {
+	Name   string  `json:"name"`
+	Rounds []Round `json:"rounds"`
+}
+
+type Round struct {
+	Number  int64   `json:"number"`
+	Matches []Match `json:"matches"`
+}
+
+// Match is one bracket match. Teams are nil until the matches feeding them
+// finish, and for the empty side of a bye.
+type Match struct {
+	ID              int64      `json:"id"`
+	Position        int64      `json:"position"`
+	Status          string     `json:"status"`
+	TeamA           *Team      `json:"teamA"`
+	TeamB           *Team      `json:"teamB"`
+	TeamAScore      *int64     `json:"teamAScore"`
+	TeamBScore      *int64     `json:"teamBScore"`
+	WinnerTeamID    *int64     `json:"winnerTeamId"`
+	WinnerToMatchID *int64     `json:"winnerToMatchId"`
+	LoserToMatchID  *int64     `json:"loserToMatchId"`
+	CourtID         *int64     `json:"courtId"`
+	CourtName       string     `json:"courtName,omitempty"`
+	ScheduledTime   *time.Time `json:"scheduledTime"`
+	ReservationID   *int64     `json:"reservationId"`
+}
+
+func (d BracketData) ElementID() string {
+	return fmt.Sprintf("tournament-bracket-%d", d.Tournament.ID)
+}
+
+func (d BracketData) StatusLabel() string {
+	switch d.Tournament.Status {
+	case "in_progress":
+		return "In progress"
+	case "completed":
+		return "Completed"
+	default:
+		return "Draft"
+	}
+}
+
+func (b Bracket) Label() string {
+	switch b.Name {
+	case "consolation":
+		return "Consolation bracket"
+	case "final":
+		return "Final"
+	default:
+		return "Winners bracket"
+	}
+}
+
+func (r Round) Label() string {
+	return "Round " + strconv.FormatInt(r.Number, 10)
+}
+
+func (m Match) ElementID() string {
+	return fmt.Sprintf("tournament-match-%d", m.ID)
+}
+
+// TeamLabel names a side of the match, with its seed.
+func (m Match) TeamLabel(team *Team) string {
+	if team == nil {
+		if m.Status == "bye" {
+			return "Bye"
+		}
+		return "TBD"
+	}
+	return fmt.Sprintf("(%d) %s", team.Seed, team.Name)
+}
+
+func (m Match) TeamClass(team *Team) string {
+	if team == nil {
+		return "text-gray-400 italic"
+	}
+	if m.WinnerTeamID != nil && *m.WinnerTeamID == team.ID {
+		return "font-semibold text-gray-900"
+	}
+	return "text-gray-700"
+}
+
+func ScoreLabel(score *int64) string {
+	if score == nil {
+		return ""
+	}
+	return strconv.FormatInt(*score, 10)
+}
+
+// ScheduleLabel is the court and start time, when the match has them.
+func (m Match) ScheduleLabel() string {
+	label := m.CourtName
+	if m.ScheduledTime != nil {
+		when := m.ScheduledTime.Format("Mon Jan 2, 3:04 PM")
+		if label == "" {
+			return when
+		}
+		label += " · " + when
+	}
+	return label
+}
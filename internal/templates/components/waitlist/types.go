@@ -15,6 +15,60 @@ type WaitlistEntry struct {
 	EndTime      time.Time
 	Position     int64
 	Status       string
+	// OfferID is set while the member holds a pending offer for the slot.
+	OfferID        int64
+	OfferExpiresAt time.Time
+	// AutoBook entries are booked outright when the slot opens, paid with a
+	// visit pack when UseVisitPack is set, instead of receiving an offer.
+	AutoBook     bool
+	UseVisitPack bool
+}
+
+func (e WaitlistEntry) HasOffer() bool {
+	return e.OfferID > 0
+}
+
+// AutoBookLabel tells the member the slot will be booked for them.
+func (e WaitlistEntry) AutoBookLabel() string {
+	if e.UseVisitPack {
+		return "Auto-book on • uses a visit pack"
+	}
+	return "Auto-book on"
+}
+
+// AutoBookToggleVals flips the entry's auto-book setting, keeping the visit
+// pack preference when it is turned back on.
+func (e WaitlistEntry) AutoBookToggleVals() string {
+	return `{"auto_book":` + strconv.FormatBool(!e.AutoBook) + `,"use_visit_pack":` + strconv.FormatBool(e.UseVisitPack) + `}`
+}
+
+// OfferExpiryLabel describes how long the member has left to respond. It is
+// relative so it reads correctly regardless of the viewer's time zone.
+func (e WaitlistEntry) OfferExpiryLabel() string {
+	remaining := time.Until(e.OfferExpiresAt).Round(time.Minute)
+	if remaining <= 0 {
+		return "The offer is about to expire."
+	}
+	if remaining < time.Hour {
+		minutes := int(remaining / time.Minute)
+		if minutes == 1 {
+			return "Respond within 1 minute."
+		}
+		return "Respond within " + strconv.Itoa(minutes) + " minutes."
+	}
+	hours := int(remaining / time.Hour)
+	if hours == 1 {
+		return "Respond within 1 hour."
+	}
+	return "Respond within " + strconv.Itoa(hours) + " hours."
+}
+
+// InQueue reports whether the entry is still waiting for the slot. Only
+// queued entries have a current position; expired and fulfilled entries keep
+// the last one they held.
+func (e WaitlistEntry) InQueue() bool {
+	status := strings.ToLower(strings.TrimSpace(e.Status))
+	return status == "" || status == "pending" || status == "notified"
 }
 
 func (e WaitlistEntry) CourtLabel() string {
@@ -1,12 +1,17 @@
 package testutil
 
 import (
+	"fmt"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/codr1/Pickleicious/internal/db"
 )
 
+var inMemoryDBCount atomic.Int64
+
 // NewTestDB creates a temporary SQLite database with migrations applied.
 func NewTestDB(t *testing.T) *db.DB {
 	t.Helper()
@@ -22,3 +27,25 @@ func NewTestDB(t *testing.T) *db.DB {
 
 	return database
 }
+
+// NewInMemoryDB creates an in-memory SQLite database with migrations applied
+// that lives until the test ends. The pool is limited to one connection, so
+// transactions from concurrent requests run one after another the way SQLite
+// serializes writers, and a handler that queries outside its own open
+// transaction blocks until its context times out rather than passing.
+func NewInMemoryDB(t *testing.T) *db.DB {
+	t.Helper()
+
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	dsn := fmt.Sprintf("file:%s-%d?mode=memory&cache=shared", name, inMemoryDBCount.Add(1))
+	database, err := db.New(dsn)
+	if err != nil {
+		t.Fatalf("create in-memory db: %v", err)
+	}
+	database.DB.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = database.Close()
+	})
+
+	return database
+}
@@ -0,0 +1,32 @@
+package testutil
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// ResetHandlers clears the state a handler package's InitHandlers guards
+// with a sync.Once, so the next InitHandlers call takes effect, and clears it
+// again when the test ends. once is the package's sync.Once and state holds
+// pointers to the globals InitHandlers sets:
+//
+//	testutil.ResetHandlers(t, &queriesOnce, &queries, &store)
+//	InitHandlers(database, nil)
+func ResetHandlers(t testing.TB, once *sync.Once, state ...any) {
+	t.Helper()
+
+	for _, ptr := range state {
+		if v := reflect.ValueOf(ptr); v.Kind() != reflect.Pointer || v.IsNil() {
+			t.Fatalf("ResetHandlers: state must be non-nil pointers, got %T", ptr)
+		}
+	}
+	reset := func() {
+		*once = sync.Once{}
+		for _, ptr := range state {
+			reflect.ValueOf(ptr).Elem().SetZero()
+		}
+	}
+	reset()
+	t.Cleanup(reset)
+}
@@ -41,6 +41,7 @@ func TestServerStartup(t *testing.T) {
 database:
   driver: "sqlite"
   filename: "%s"
+  auto_migrate: true
 
 open_play:
   enforcement_interval: "*/5 * * * *"